@@ -3,6 +3,8 @@ package accounts
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -11,6 +13,8 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/record"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"google.golang.org/protobuf/proto"
 )
@@ -65,6 +69,22 @@ var (
 	]()
 )
 
+const (
+	// defaultMaxInvoices is the number of invoices lnd returns if the
+	// client doesn't set a maximum.
+	defaultMaxInvoices = 100
+
+	// listBatchSize is the number of invoices or payments the account
+	// checkers ask lnd for at once when they fill a page with the entries
+	// of an account.
+	listBatchSize = 1000
+
+	// listQueryTimeout is the time after which a remembered list query is
+	// dropped if lnd never answered the call, for example because another
+	// middleware rejected it.
+	listQueryTimeout = 10 * time.Minute
+)
+
 // CheckerMap is a type alias that maps gRPC request URIs to their
 // rpcmiddleware.RoundTripChecker types.
 type CheckerMap map[string]mid.RoundTripChecker
@@ -73,12 +93,72 @@ type CheckerMap map[string]mid.RoundTripChecker
 // including invoices, payments and account balances.
 type AccountChecker struct {
 	checkers CheckerMap
+
+	// listClient is used to fetch further batches of invoices or payments
+	// if lnd's response doesn't fill the page of a list call. If it is
+	// nil, the page is only filled from lnd's response.
+	listClient ListClient
+
+	// listQueries are the pages the clients of the list calls that are
+	// currently served by lnd asked for, keyed by the ID of the call.
+	listQueries    map[uint64]listQuery
+	listQueriesMtx sync.Mutex
+}
+
+// listQuery describes the page of invoices or payments a client asked for.
+type listQuery struct {
+	// maxEntries is the maximum number of entries of the page. Zero means
+	// there is no maximum.
+	maxEntries uint64
+
+	// reversed is true if the client pages backwards from the index
+	// offset.
+	reversed bool
+
+	// invoices is the request for the first batch of invoices. It is nil
+	// for payments.
+	invoices *lnrpc.ListInvoiceRequest
+
+	// payments is the request for the first batch of payments. It is nil
+	// for invoices.
+	payments *lnrpc.ListPaymentsRequest
+
+	// created is the time the query was remembered.
+	created time.Time
+}
+
+// pageFull returns true if the page already holds the given number of
+// entries.
+func (q listQuery) pageFull(numEntries int) bool {
+	return q.maxEntries != 0 && uint64(numEntries) >= q.maxEntries
+}
+
+// nextOffset returns the index offset of the batch that follows the batch
+// with the given index offsets in the direction of the query.
+func (q listQuery) nextOffset(firstIndex, lastIndex uint64) uint64 {
+	if q.reversed {
+		return firstIndex
+	}
+
+	return lastIndex
 }
 
 // NewAccountChecker creates a new account checker that can keep track of all
 // account related requests, including invoices, payments and account balances.
-func NewAccountChecker(service Service,
-	chainParams *chaincfg.Params) *AccountChecker {
+// If no config is given, the default config is used. The list client is used
+// to page through the invoices and payments of the node, it can be nil to only
+// filter lnd's responses.
+func NewAccountChecker(service Service, listClient ListClient,
+	chainParams *chaincfg.Params, cfg *Config) *AccountChecker {
+
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	a := &AccountChecker{
+		listClient:  listClient,
+		listQueries: make(map[uint64]listQuery),
+	}
 
 	// sendResponseHandler is a response handler function that is used by
 	// multiple RPC checkers for checking an RPC response sent for a payment
@@ -110,9 +190,12 @@ func NewAccountChecker(service Service,
 
 	checkers := CheckerMap{
 		// Invoices:
-		"/lnrpc.Lightning/AddInvoice": mid.NewResponseRewriter(
+		"/lnrpc.Lightning/AddInvoice": mid.NewFullChecker(
 			&lnrpc.Invoice{},
 			&lnrpc.AddInvoiceResponse{},
+			func(ctx context.Context, r *lnrpc.Invoice) error {
+				return checkAddInvoice(ctx, r.Value, r.ValueMsat)
+			},
 			func(ctx context.Context,
 				t *lnrpc.AddInvoiceResponse) (proto.Message,
 				error) {
@@ -133,21 +216,44 @@ func NewAccountChecker(service Service,
 				)
 			}, mid.PassThroughErrorHandler,
 		),
-		"/lnrpc.Lightning/ListInvoices": mid.NewResponseRewriter(
+		"/lnrpc.Lightning/ListInvoices": mid.NewFullRewriter(
 			&lnrpc.ListInvoiceRequest{},
 			&lnrpc.ListInvoiceResponse{},
+			func(ctx context.Context,
+				r *lnrpc.ListInvoiceRequest) (proto.Message,
+				error) {
+
+				maxInvoices := r.NumMaxInvoices
+				if maxInvoices == 0 {
+					maxInvoices = defaultMaxInvoices
+				}
+				// lnd pages over all invoices of the node, so
+				// we ask for them in batches and fill the page
+				// with the account's invoices when filtering
+				// the response.
+				q := proto.Clone(r).(*lnrpc.ListInvoiceRequest)
+				q.NumMaxInvoices = listBatchSize
+				a.rememberQuery(ctx, listQuery{
+					maxEntries: maxInvoices,
+					reversed:   r.Reversed,
+					invoices:   q,
+				})
+
+				return q, nil
+			},
 			func(ctx context.Context,
 				t *lnrpc.ListInvoiceResponse) (proto.Message,
 				error) {
 
-				filteredInvoices, err := filterInvoices(ctx, t)
+				resp, err := a.listInvoices(
+					ctx, t, a.popQuery(ctx),
+				)
 				if err != nil {
 					return nil, fmt.Errorf("error "+
 						"filtering invoices: %v", err)
 				}
 
-				t.Invoices = filteredInvoices
-				return t, nil
+				return resp, nil
 			}, mid.PassThroughErrorHandler,
 		),
 		"/lnrpc.Lightning/LookupInvoice": mid.NewRequestChecker(
@@ -179,9 +285,18 @@ func NewAccountChecker(service Service,
 			&lnrpc.SendRequest{},
 			&lnrpc.SendResponse{},
 			func(ctx context.Context, r *lnrpc.SendRequest) error {
+				err := checkKeysend(
+					cfg, r.DestCustomRecords, false,
+					r.PaymentRequest,
+				)
+				if err != nil {
+					return err
+				}
+
 				return checkSend(
 					ctx, chainParams, service, r.Amt,
-					r.AmtMsat, r.PaymentRequest, r.FeeLimit,
+					r.AmtMsat, r.PaymentRequest, r.Dest,
+					r.FeeLimit,
 				)
 			}, sendResponseHandler, mid.PassThroughErrorHandler,
 		),
@@ -189,9 +304,18 @@ func NewAccountChecker(service Service,
 			&lnrpc.SendRequest{},
 			&lnrpc.SendResponse{},
 			func(ctx context.Context, r *lnrpc.SendRequest) error {
+				err := checkKeysend(
+					cfg, r.DestCustomRecords, false,
+					r.PaymentRequest,
+				)
+				if err != nil {
+					return err
+				}
+
 				return checkSend(
 					ctx, chainParams, service, r.Amt,
-					r.AmtMsat, r.PaymentRequest, r.FeeLimit,
+					r.AmtMsat, r.PaymentRequest, r.Dest,
+					r.FeeLimit,
 				)
 			}, sendResponseHandler, mid.PassThroughErrorHandler,
 		),
@@ -202,6 +326,14 @@ func NewAccountChecker(service Service,
 			func(ctx context.Context,
 				r *routerrpc.SendPaymentRequest) error {
 
+				err := checkKeysend(
+					cfg, r.DestCustomRecords, r.Amp,
+					r.PaymentRequest,
+				)
+				if err != nil {
+					return err
+				}
+
 				feeLimitMsat := r.FeeLimitMsat
 				if r.FeeLimitSat > 0 {
 					feeLimitMsat = r.FeeLimitSat * 1000
@@ -209,7 +341,7 @@ func NewAccountChecker(service Service,
 
 				return checkSend(
 					ctx, chainParams, service, r.Amt,
-					r.AmtMsat, r.PaymentRequest,
+					r.AmtMsat, r.PaymentRequest, r.Dest,
 					&lnrpc.FeeLimit{
 						Limit: &lnrpc.FeeLimit_FixedMsat{
 							FixedMsat: feeLimitMsat,
@@ -240,7 +372,10 @@ func NewAccountChecker(service Service,
 			func(ctx context.Context,
 				r *lnrpc.SendToRouteRequest) error {
 
-				return checkSendToRoute(ctx, service, r.Route)
+				return checkSendToRoute(
+					ctx, cfg, service, r.PaymentHash,
+					r.PaymentHashString, r.Route,
+				)
 			}, sendResponseHandler, mid.PassThroughErrorHandler,
 		),
 		"/lnrpc.Lightning/SendToRouteSync": mid.NewFullChecker(
@@ -249,39 +384,63 @@ func NewAccountChecker(service Service,
 			func(ctx context.Context,
 				r *lnrpc.SendToRouteRequest) error {
 
-				return checkSendToRoute(ctx, service, r.Route)
+				return checkSendToRoute(
+					ctx, cfg, service, r.PaymentHash,
+					r.PaymentHashString, r.Route,
+				)
 			}, sendResponseHandler, mid.PassThroughErrorHandler,
 		),
 		// routerrpc.Router/SendToRoute is deprecated.
-		"/routerrpc.Router/SendToRouteV2": mid.NewRequestChecker(
+		"/routerrpc.Router/SendToRouteV2": mid.NewFullChecker(
 			&routerrpc.SendToRouteRequest{},
 			&lnrpc.HTLCAttempt{},
 			func(ctx context.Context,
 				r *routerrpc.SendToRouteRequest) error {
 
-				return checkSendToRoute(ctx, service, r.Route)
+				return checkSendToRoute(
+					ctx, cfg, service, r.PaymentHash, "",
+					r.Route,
+				)
 			},
-			// We don't get the payment hash in the response to this
-			// call. So we can't optimize things and need to rely on
-			// the payment being tracked by the hash sent in the
-			// request.
+			func(ctx context.Context,
+				r *lnrpc.HTLCAttempt) (proto.Message, error) {
+
+				return checkHTLCAttempt(ctx, service, r)
+			}, mid.PassThroughErrorHandler,
 		),
 		"/lnrpc.Lightning/DecodePayReq": DecodePayReqPassThrough,
-		"/lnrpc.Lightning/ListPayments": mid.NewResponseRewriter(
+		"/lnrpc.Lightning/ListPayments": mid.NewFullRewriter(
 			&lnrpc.ListPaymentsRequest{},
 			&lnrpc.ListPaymentsResponse{},
+			func(ctx context.Context,
+				r *lnrpc.ListPaymentsRequest) (proto.Message,
+				error) {
+
+				// Just like for invoices, we ask for the
+				// payments in batches.
+				q := proto.Clone(r).(*lnrpc.ListPaymentsRequest)
+				q.MaxPayments = listBatchSize
+				a.rememberQuery(ctx, listQuery{
+					maxEntries: r.MaxPayments,
+					reversed:   r.Reversed,
+					payments:   q,
+				})
+
+				return q, nil
+			},
 			func(ctx context.Context,
 				t *lnrpc.ListPaymentsResponse) (proto.Message,
 				error) {
 
-				filteredPayments, err := filterPayments(ctx, t)
+				resp, err := a.listPayments(
+					ctx, t, a.popQuery(ctx),
+				)
 				if err != nil {
 					return nil, fmt.Errorf("error "+
 						"filtering payments: %v", err)
 				}
 
-				t.Payments = filteredPayments
-				return t, nil
+				return resp, nil
 			}, mid.PassThroughErrorHandler,
 		),
 		// routerrpc.Router/TrackPayment is deprecated.
@@ -384,42 +543,75 @@ func NewAccountChecker(service Service,
 		"/lnrpc.Lightning/GetNodeInfo": GetNodeInfoPassThrough,
 	}
 
-	return &AccountChecker{
-		checkers: checkers,
+	a.checkers = checkers
+
+	return a
+}
+
+// rememberQuery stores the page the client of the list call in the context
+// asked for until lnd's response is filtered. Queries of calls lnd never
+// answered are dropped after a while.
+func (a *AccountChecker) rememberQuery(ctx context.Context, query listQuery) {
+	a.listQueriesMtx.Lock()
+	defer a.listQueriesMtx.Unlock()
+
+	now := time.Now()
+	for id, q := range a.listQueries {
+		if now.Sub(q.created) > listQueryTimeout {
+			delete(a.listQueries, id)
+		}
 	}
+
+	query.created = now
+	a.listQueries[requestIDFromContext(ctx)] = query
+}
+
+// popQuery returns and forgets the page the client of the list call in the
+// context asked for. If it isn't known, the page has no maximum.
+func (a *AccountChecker) popQuery(ctx context.Context) listQuery {
+	a.listQueriesMtx.Lock()
+	defer a.listQueriesMtx.Unlock()
+
+	id := requestIDFromContext(ctx)
+	query := a.listQueries[id]
+	delete(a.listQueries, id)
+
+	return query
+}
+
+// forgetQuery drops the page the client of the call with the given ID asked
+// for, if it is a list call. It is used if lnd answered the call with an
+// error, so the response isn't filtered.
+func (a *AccountChecker) forgetQuery(requestID uint64) {
+	a.listQueriesMtx.Lock()
+	defer a.listQueriesMtx.Unlock()
+
+	delete(a.listQueries, requestID)
 }
 
 // checkIncomingRequest makes sure the type of incoming call is supported and
-// if it is, that it is allowed with the current account balance.
+// if it is, that it is allowed with the current account balance. If the
+// request should be replaced before it reaches lnd, the replacement is
+// returned.
 func (a *AccountChecker) checkIncomingRequest(ctx context.Context,
-	fullUri string, req proto.Message) error {
+	fullUri string, req proto.Message) (proto.Message, error) {
 
 	// If we don't have a handler for the URI, it means we don't support
 	// that RPC.
 	checker, ok := a.checkers[fullUri]
 	if !ok {
-		return ErrNotSupportedWithAccounts
+		return nil, ErrNotSupportedWithAccounts
 	}
 
 	// This is just a sanity check to make sure the implementation for the
 	// checker actually matches the correct request type.
 	if !checker.HandlesRequest(req.ProtoReflect().Type()) {
-		return fmt.Errorf("invalid implementation, checker for URI "+
-			"%s does not accept request of type %v", fullUri,
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept request of type %v", fullUri,
 			req.ProtoReflect().Type())
 	}
 
-	req, err := checker.HandleRequest(ctx, req)
-	if err != nil {
-		return err
-	}
-
-	if req != nil {
-		return fmt.Errorf("request editing checkers not supported " +
-			"for accounts")
-	}
-
-	return nil
+	return checker.HandleRequest(ctx, req)
 }
 
 // replaceOutgoingResponse inspects the responses before sending them out to the
@@ -447,20 +639,152 @@ func (a *AccountChecker) replaceOutgoingResponse(ctx context.Context,
 	return checker.HandleResponse(ctx, resp)
 }
 
-// filterInvoices filters the total response of all invoices returned by lnd and
-// only includes those that are related to the account in the context.
-func filterInvoices(ctx context.Context,
-	t *lnrpc.ListInvoiceResponse) ([]*lnrpc.Invoice, error) {
+// listInvoices fills the page the client of the list call asked for with the
+// invoices of the account in the context. lnd's response is the first batch of
+// invoices, further batches are fetched until the page is full or lnd has no
+// more invoices.
+//
+// The index offsets are those of the first and last invoice of the page. If the
+// account has no invoices in the fetched batches, the index offsets span all
+// fetched invoices, so the client continues behind them.
+func (a *AccountChecker) listInvoices(ctx context.Context,
+	batch *lnrpc.ListInvoiceResponse,
+	query listQuery) (*lnrpc.ListInvoiceResponse, error) {
+
+	acct, err := AccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ListInvoiceResponse{
+		FirstIndexOffset: batch.FirstIndexOffset,
+		LastIndexOffset:  batch.LastIndexOffset,
+	}
+	for {
+		invoices, err := filterInvoices(acct, batch.Invoices)
+		if err != nil {
+			return nil, err
+		}
+
+		// lnd returns each batch in ascending order, so the batches
+		// of a reversed query get older one by one.
+		switch {
+		case len(batch.Invoices) == 0:
+
+		case query.reversed:
+			resp.Invoices = append(invoices, resp.Invoices...)
+			resp.FirstIndexOffset = batch.FirstIndexOffset
+
+		default:
+			resp.Invoices = append(resp.Invoices, invoices...)
+			resp.LastIndexOffset = batch.LastIndexOffset
+		}
+
+		if query.pageFull(len(resp.Invoices)) ||
+			len(batch.Invoices) < listBatchSize ||
+			a.listClient == nil || query.invoices == nil {
+
+			break
+		}
+
+		req := proto.Clone(query.invoices).(*lnrpc.ListInvoiceRequest)
+		req.IndexOffset = query.nextOffset(
+			batch.FirstIndexOffset, batch.LastIndexOffset,
+		)
+		batch, err = a.listClient.ListInvoices(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching invoices: %v",
+				err)
+		}
+	}
+
+	resp.Invoices = cutPage(resp.Invoices, query)
+	if len(resp.Invoices) > 0 {
+		resp.FirstIndexOffset = resp.Invoices[0].AddIndex
+		resp.LastIndexOffset =
+			resp.Invoices[len(resp.Invoices)-1].AddIndex
+	}
+
+	return resp, nil
+}
+
+// listPayments fills the page the client of the list call asked for with the
+// payments of the account in the context, just like listInvoices does for
+// invoices. The total number of payments is only set by lnd if the client asked
+// for it. It then counts all payments of the node, so it is replaced with the
+// number of payments of the account.
+func (a *AccountChecker) listPayments(ctx context.Context,
+	batch *lnrpc.ListPaymentsResponse,
+	query listQuery) (*lnrpc.ListPaymentsResponse, error) {
 
 	acct, err := AccountFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	resp := &lnrpc.ListPaymentsResponse{
+		FirstIndexOffset: batch.FirstIndexOffset,
+		LastIndexOffset:  batch.LastIndexOffset,
+	}
+	if batch.TotalNumPayments > 0 {
+		resp.TotalNumPayments = uint64(len(acct.Payments))
+	}
+	for {
+		payments, err := filterPayments(acct, batch.Payments)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(batch.Payments) == 0:
+
+		case query.reversed:
+			resp.Payments = append(payments, resp.Payments...)
+			resp.FirstIndexOffset = batch.FirstIndexOffset
+
+		default:
+			resp.Payments = append(resp.Payments, payments...)
+			resp.LastIndexOffset = batch.LastIndexOffset
+		}
+
+		if query.pageFull(len(resp.Payments)) ||
+			len(batch.Payments) < listBatchSize ||
+			a.listClient == nil || query.payments == nil {
+
+			break
+		}
+
+		// The total was already counted for the first batch.
+		req := proto.Clone(query.payments).(*lnrpc.ListPaymentsRequest)
+		req.IndexOffset = query.nextOffset(
+			batch.FirstIndexOffset, batch.LastIndexOffset,
+		)
+		req.CountTotalPayments = false
+		batch, err = a.listClient.ListPayments(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching payments: %v",
+				err)
+		}
+	}
+
+	resp.Payments = cutPage(resp.Payments, query)
+	if len(resp.Payments) > 0 {
+		resp.FirstIndexOffset = resp.Payments[0].PaymentIndex
+		resp.LastIndexOffset =
+			resp.Payments[len(resp.Payments)-1].PaymentIndex
+	}
+
+	return resp, nil
+}
+
+// filterInvoices returns the given invoices that belong to the account.
+func filterInvoices(acct *OffChainBalanceAccount,
+	invoices []*lnrpc.Invoice) ([]*lnrpc.Invoice, error) {
+
 	// We don't pre-allocate, since we don't know how many invoices we have
 	// after filtering.
 	var filteredInvoices []*lnrpc.Invoice
-	for _, invoice := range t.Invoices {
+	for _, invoice := range invoices {
 		invoice := invoice
 
 		hash, err := lntypes.MakeHash(invoice.RHash)
@@ -476,20 +800,14 @@ func filterInvoices(ctx context.Context,
 	return filteredInvoices, nil
 }
 
-// filterPayments filters the total response of all payments returned by lnd and
-// only includes those that are related to the account in the context.
-func filterPayments(ctx context.Context,
-	t *lnrpc.ListPaymentsResponse) ([]*lnrpc.Payment, error) {
-
-	acct, err := AccountFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
+// filterPayments returns the given payments that belong to the account.
+func filterPayments(acct *OffChainBalanceAccount,
+	payments []*lnrpc.Payment) ([]*lnrpc.Payment, error) {
 
 	// We don't pre-allocate, since we don't know how many payments we have
 	// after filtering.
 	var filteredPayments []*lnrpc.Payment
-	for _, payment := range t.Payments {
+	for _, payment := range payments {
 		payment := payment
 
 		hash, err := lntypes.MakeHashFromStr(payment.PaymentHash)
@@ -505,10 +823,42 @@ func filterPayments(ctx context.Context,
 	return filteredPayments, nil
 }
 
+// cutPage returns the entries of the page the client asked for. lnd returns
+// the entries in ascending order even if the client pages backwards, in which
+// case the page ends with the last entry.
+func cutPage[T any](entries []T, query listQuery) []T {
+	numEntries := uint64(len(entries))
+	if query.maxEntries == 0 || numEntries <= query.maxEntries {
+		return entries
+	}
+
+	if query.reversed {
+		return entries[numEntries-query.maxEntries:]
+	}
+
+	return entries[:query.maxEntries]
+}
+
+// checkAddInvoice makes sure the amount of a new invoice is within the limits
+// of the account in the context.
+func checkAddInvoice(ctx context.Context, value, valueMsat int64) error {
+	acct, err := AccountFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	amt := lnwire.NewMSatFromSatoshis(btcutil.Amount(value))
+	if lnwire.MilliSatoshi(valueMsat) > amt {
+		amt = lnwire.MilliSatoshi(valueMsat)
+	}
+
+	return acct.CheckInvoiceAmount(amt)
+}
+
 // checkSend checks if a payment can be initiated by making sure the account in
 // the context has enough balance to pay for it.
 func checkSend(ctx context.Context, chainParams *chaincfg.Params,
-	service Service, amt, amtMsat int64, invoice string,
+	service Service, amt, amtMsat int64, invoice string, destBytes []byte,
 	feeLimit *lnrpc.FeeLimit) error {
 
 	acct, err := AccountFromContext(ctx)
@@ -521,6 +871,16 @@ func checkSend(ctx context.Context, chainParams *chaincfg.Params,
 		sendAmt = lnwire.MilliSatoshi(amtMsat)
 	}
 
+	// The destination is only known for keysend payments or if an invoice
+	// is given.
+	var dest *route.Vertex
+	if len(destBytes) == route.VertexSize {
+		vertex, err := route.NewVertexFromBytes(destBytes)
+		if err == nil {
+			dest = &vertex
+		}
+	}
+
 	// The invoice is optional.
 	if len(invoice) > 0 {
 		payReq, err := zpay32.Decode(invoice, chainParams)
@@ -531,6 +891,9 @@ func checkSend(ctx context.Context, chainParams *chaincfg.Params,
 		if payReq.MilliSat != nil && *payReq.MilliSat > sendAmt {
 			sendAmt = *payReq.MilliSat
 		}
+
+		vertex := route.NewVertex(payReq.Destination)
+		dest = &vertex
 	}
 
 	// We also add the max fee to the amount to check. This might mean that
@@ -542,8 +905,12 @@ func checkSend(ctx context.Context, chainParams *chaincfg.Params,
 		limit = &lnrpc.FeeLimit{}
 	}
 	fee := lnrpc.CalculateFeeLimit(limit, sendAmt)
+	if err := acct.CheckFeeLimit(sendAmt, fee); err != nil {
+		return err
+	}
 	sendAmt += fee
 
+	service.RecordPaymentAttempt(acct.ID, sendAmt, dest)
 	err = service.CheckBalance(acct.ID, sendAmt)
 	if err != nil {
 		return fmt.Errorf("error validating account balance: %v", err)
@@ -552,6 +919,23 @@ func checkSend(ctx context.Context, chainParams *chaincfg.Params,
 	return nil
 }
 
+// checkKeysend makes sure that no keysend payment and no AMP payment without
+// an invoice is sent if those are blocked for accounts.
+func checkKeysend(cfg *Config, customRecords map[uint64][]byte, amp bool,
+	invoice string) error {
+
+	if !cfg.BlockKeysend {
+		return nil
+	}
+
+	_, keysend := customRecords[record.KeySendType]
+	if keysend || (amp && invoice == "") {
+		return ErrKeysendBlocked
+	}
+
+	return nil
+}
+
 // checkSendResponse makes sure that a payment that is in flight is tracked
 // by the payment service in order for it to eventually be debited from the
 // account.
@@ -568,6 +952,8 @@ func checkSendResponse(ctx context.Context, service Service,
 	// we stop tracking the payment and then exit
 	// early.
 	if status == lnrpc.Payment_FAILED {
+		service.RecordPaymentFailure(acct.ID, hash)
+
 		return nil, service.RemovePayment(hash)
 	}
 
@@ -579,9 +965,13 @@ func checkSendResponse(ctx context.Context, service Service,
 }
 
 // checkSendToRoute checks if a payment can be sent to the route by making sure
-// the account in the context has enough balance to pay for it.
-func checkSendToRoute(ctx context.Context, service Service,
-	route *lnrpc.Route) error {
+// the account in the context has enough balance to pay for it. The payment is
+// tracked right away to reserve its amount while it is in flight, since the
+// response to a payment that is sent to a route only arrives once the payment
+// succeeded or failed. The payment hash is either given as bytes or, for the
+// deprecated field of lnd's request, as a hex string.
+func checkSendToRoute(ctx context.Context, cfg *Config, service Service,
+	hashBytes []byte, hashString string, route *lnrpc.Route) error {
 
 	acct, err := AccountFromContext(ctx)
 	if err != nil {
@@ -592,10 +982,41 @@ func checkSendToRoute(ctx context.Context, service Service,
 		return fmt.Errorf("invalid route")
 	}
 
+	var hash lntypes.Hash
+	if len(hashBytes) == 0 && hashString != "" {
+		hash, err = lntypes.MakeHashFromStr(hashString)
+	} else {
+		hash, err = lntypes.MakeHash(hashBytes)
+	}
+	if err != nil {
+		return fmt.Errorf("error parsing payment hash: %v", err)
+	}
+
+	// The payload of the last hop tells us whether this is a keysend or
+	// AMP payment. Since we can't know whether an AMP payment pays an
+	// invoice, we block all AMP routes together with keysend.
+	if len(route.Hops) > 0 {
+		lastHop := route.Hops[len(route.Hops)-1]
+		err := checkKeysend(
+			cfg, lastHop.CustomRecords, lastHop.AmpRecord != nil,
+			"",
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The totals of the route are set by the caller and don't need to
+	// match its hops. So we use whichever is larger.
+	hopsAmt, hopsFee := routeHopAmounts(route)
+
 	sendAmt := lnwire.NewMSatFromSatoshis(btcutil.Amount(route.TotalAmt)) // nolint
 	if lnwire.MilliSatoshi(route.TotalAmtMsat) > sendAmt {
 		sendAmt = lnwire.MilliSatoshi(route.TotalAmtMsat)
 	}
+	if hopsAmt > sendAmt {
+		sendAmt = hopsAmt
+	}
 
 	// We also add the max fee to the amount to check. This might mean that
 	// not every single satoshi of an account can be used up. But it
@@ -605,12 +1026,96 @@ func checkSendToRoute(ctx context.Context, service Service,
 	if lnwire.MilliSatoshi(route.TotalFeesMsat) > fee {
 		fee = lnwire.MilliSatoshi(route.TotalFeesMsat)
 	}
+	if hopsFee > fee {
+		fee = hopsFee
+	}
+
+	// The total amount of the route already includes the fees.
+	if fee <= sendAmt {
+		err = acct.CheckFeeLimit(sendAmt-fee, fee)
+		if err != nil {
+			return err
+		}
+	}
 	sendAmt += fee
 
+	service.RecordPaymentAttempt(acct.ID, sendAmt, routeDestination(route))
 	err = service.CheckBalance(acct.ID, sendAmt)
 	if err != nil {
 		return fmt.Errorf("error validating account balance: %v", err)
 	}
 
-	return nil
+	return service.TrackPayment(acct.ID, hash, sendAmt)
+}
+
+// routeHopAmounts returns the amount that the hops of the given route require
+// to be sent to the first hop, which is the amount the last hop forwards to
+// the destination plus the fees of all hops, and the fees alone.
+func routeHopAmounts(r *lnrpc.Route) (lnwire.MilliSatoshi,
+	lnwire.MilliSatoshi) {
+
+	if len(r.Hops) == 0 {
+		return 0, 0
+	}
+
+	var fees lnwire.MilliSatoshi
+	for _, hop := range r.Hops {
+		fee := lnwire.NewMSatFromSatoshis(btcutil.Amount(hop.Fee)) // nolint
+		if lnwire.MilliSatoshi(hop.FeeMsat) > fee {
+			fee = lnwire.MilliSatoshi(hop.FeeMsat)
+		}
+		fees += fee
+	}
+
+	lastHop := r.Hops[len(r.Hops)-1]
+	amt := lnwire.NewMSatFromSatoshis(btcutil.Amount(lastHop.AmtToForward)) // nolint
+	if lnwire.MilliSatoshi(lastHop.AmtToForwardMsat) > amt {
+		amt = lnwire.MilliSatoshi(lastHop.AmtToForwardMsat)
+	}
+
+	return amt + fees, fees
+}
+
+// checkHTLCAttempt makes sure that a payment that was sent to a route is
+// tracked by the payment service in order for it to be debited from the
+// account. The payment is already tracked since its request was checked, this
+// only covers payments whose tracking was lost in the meantime. The attempt
+// doesn't contain the payment hash, but a successful one contains the preimage
+// it is derived from. Failed attempts are released by the tracking, as lnd
+// fails the payment as well.
+func checkHTLCAttempt(ctx context.Context, service Service,
+	r *lnrpc.HTLCAttempt) (proto.Message, error) {
+
+	if r.Status != lnrpc.HTLCAttempt_SUCCEEDED {
+		return nil, nil
+	}
+
+	preimage, err := lntypes.MakePreimage(r.Preimage)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing preimage: %v", err)
+	}
+
+	var fullAmt int64
+	if r.Route != nil {
+		fullAmt = r.Route.TotalAmtMsat
+	}
+
+	return checkSendResponse(
+		ctx, service, lnrpc.Payment_SUCCEEDED, preimage.Hash(), fullAmt,
+	)
+}
+
+// routeDestination returns the destination of the given route, which is the
+// last hop. It returns nil if the destination can't be determined.
+func routeDestination(r *lnrpc.Route) *route.Vertex {
+	if len(r.Hops) == 0 {
+		return nil
+	}
+
+	dest, err := route.NewVertexFromStr(r.Hops[len(r.Hops)-1].PubKey)
+	if err != nil {
+		return nil
+	}
+
+	return &dest
 }
@@ -11,7 +11,10 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/record"
+	"github.com/lightningnetwork/lnd/routing/route"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -27,6 +30,12 @@ var (
 	testID   = AccountID{77, 88, 99}
 	testHash = lntypes.Hash{1, 2, 3, 4, 5}
 
+	testHash3 = lntypes.Hash{6, 7, 8}
+	testHash4 = lntypes.Hash{9, 10, 11}
+
+	testPreimage     = lntypes.Preimage{5, 4, 3, 2, 1}
+	testPreimageHash = testPreimage.Hash()
+
 	testAmount = &lnrpc.Amount{
 		Sat:  456,
 		Msat: 456789,
@@ -71,6 +80,12 @@ func (m *mockService) AssociateInvoice(id AccountID, hash lntypes.Hash) error {
 func (m *mockService) TrackPayment(id AccountID, hash lntypes.Hash,
 	amt lnwire.MilliSatoshi) error {
 
+	// Just like the service, a payment that is already tracked isn't
+	// tracked again.
+	if _, ok := m.trackedPayments[hash]; ok {
+		return nil
+	}
+
 	m.trackedPayments[hash] = &PaymentEntry{
 		Status:     lnrpc.Payment_UNKNOWN,
 		FullAmount: amt,
@@ -85,6 +100,13 @@ func (m *mockService) RemovePayment(hash lntypes.Hash) error {
 	return nil
 }
 
+func (m *mockService) RecordPaymentAttempt(AccountID, lnwire.MilliSatoshi,
+	*route.Vertex) {
+}
+
+func (m *mockService) RecordPaymentFailure(AccountID, lntypes.Hash) {
+}
+
 var _ Service = (*mockService)(nil)
 
 // TestAccountChecker makes sure all round trip checkers can be instantiated
@@ -92,7 +114,7 @@ var _ Service = (*mockService)(nil)
 func TestAccountChecker(t *testing.T) {
 	t.Parallel()
 
-	checker := NewAccountChecker(nil, nil)
+	checker := NewAccountChecker(nil, nil, nil, nil)
 	for checkerName := range checker.checkers {
 		t.Logf("Checker registered: %v", checkerName)
 	}
@@ -105,9 +127,11 @@ func TestAccountCheckers(t *testing.T) {
 	testCases := []struct {
 		name    string
 		fullURI string
+		cfg     *Config
 		setup   func(s *mockService,
 			acct *OffChainBalanceAccount)
 		originalRequest  proto.Message
+		replacedRequest  proto.Message
 		requestErr       string
 		originalResponse proto.Message
 		replacedResponse proto.Message
@@ -141,6 +165,52 @@ func TestAccountCheckers(t *testing.T) {
 			require.Contains(t, s.trackedInvoices, testHash)
 		},
 	}, {
+		name:    "add invoice, below minimum amount",
+		fullURI: "/lnrpc.Lightning/AddInvoice",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Limits.MinInvoiceAmount = 10_000
+		},
+		originalRequest: &lnrpc.Invoice{
+			ValueMsat: 9_999,
+		},
+		requestErr: "invoice amount 9999 mSAT is below the minimum",
+	}, {
+		name:    "add invoice, above maximum amount",
+		fullURI: "/lnrpc.Lightning/AddInvoice",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Limits.MaxInvoiceAmount = 10_000
+		},
+		originalRequest: &lnrpc.Invoice{
+			Value: 11,
+		},
+		requestErr: "invoice amount 11000 mSAT is above the maximum",
+	}, {
+		name:    "add invoice, amountless with limits",
+		fullURI: "/lnrpc.Lightning/AddInvoice",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Limits.MaxInvoiceAmount = 10_000
+		},
+		originalRequest: &lnrpc.Invoice{},
+		requestErr:      "can't create invoices without an amount",
+	}, {
+		name:    "add invoice, within limits",
+		fullURI: "/lnrpc.Lightning/AddInvoice",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Limits.MinInvoiceAmount = 1_000
+			acct.Limits.MaxInvoiceAmount = 10_000
+		},
+		originalRequest: &lnrpc.Invoice{
+			Value: 10,
+		},
+		originalResponse: &lnrpc.AddInvoiceResponse{
+			RHash: testHash[:],
+		},
+		validate: func(t *testing.T, s *mockService,
+			acct *OffChainBalanceAccount) {
+
+			require.Contains(t, s.trackedInvoices, testHash)
+		},
+	}, {
 		name:            "list invoices, not mapped to account",
 		fullURI:         "/lnrpc.Lightning/ListInvoices",
 		originalRequest: &lnrpc.ListInvoiceRequest{},
@@ -169,6 +239,96 @@ func TestAccountCheckers(t *testing.T) {
 				RHash: testHash[:],
 			}},
 		},
+	}, {
+		name:    "list invoices, page filled with account's invoices",
+		fullURI: "/lnrpc.Lightning/ListInvoices",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Invoices[testHash] = struct{}{}
+			acct.Invoices[testHash3] = struct{}{}
+			acct.Invoices[testHash4] = struct{}{}
+		},
+		originalRequest: &lnrpc.ListInvoiceRequest{
+			IndexOffset:    3,
+			NumMaxInvoices: 2,
+		},
+		replacedRequest: &lnrpc.ListInvoiceRequest{
+			IndexOffset:    3,
+			NumMaxInvoices: listBatchSize,
+		},
+		originalResponse: &lnrpc.ListInvoiceResponse{
+			Invoices: []*lnrpc.Invoice{{
+				RHash:    testHash[:],
+				AddIndex: 4,
+			}, {
+				RHash:    testHash2[:],
+				AddIndex: 5,
+			}, {
+				RHash:    testHash3[:],
+				AddIndex: 6,
+			}, {
+				RHash:    testHash4[:],
+				AddIndex: 7,
+			}},
+			FirstIndexOffset: 4,
+			LastIndexOffset:  7,
+		},
+		replacedResponse: &lnrpc.ListInvoiceResponse{
+			Invoices: []*lnrpc.Invoice{{
+				RHash:    testHash[:],
+				AddIndex: 4,
+			}, {
+				RHash:    testHash3[:],
+				AddIndex: 6,
+			}},
+			FirstIndexOffset: 4,
+			LastIndexOffset:  6,
+		},
+	}, {
+		name:    "list invoices, reversed page filled",
+		fullURI: "/lnrpc.Lightning/ListInvoices",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Invoices[testHash] = struct{}{}
+			acct.Invoices[testHash3] = struct{}{}
+			acct.Invoices[testHash4] = struct{}{}
+		},
+		originalRequest: &lnrpc.ListInvoiceRequest{
+			IndexOffset:    8,
+			NumMaxInvoices: 2,
+			Reversed:       true,
+		},
+		replacedRequest: &lnrpc.ListInvoiceRequest{
+			IndexOffset:    8,
+			NumMaxInvoices: listBatchSize,
+			Reversed:       true,
+		},
+		originalResponse: &lnrpc.ListInvoiceResponse{
+			Invoices: []*lnrpc.Invoice{{
+				RHash:    testHash[:],
+				AddIndex: 4,
+			}, {
+				RHash:    testHash3[:],
+				AddIndex: 5,
+			}, {
+				RHash:    testHash2[:],
+				AddIndex: 6,
+			}, {
+				RHash:    testHash4[:],
+				AddIndex: 7,
+			}},
+			FirstIndexOffset: 4,
+			LastIndexOffset:  7,
+		},
+		replacedResponse: &lnrpc.ListInvoiceResponse{
+			Invoices: []*lnrpc.Invoice{{
+				RHash:    testHash3[:],
+				AddIndex: 5,
+			}, {
+				RHash:    testHash4[:],
+				AddIndex: 7,
+			}},
+			FirstIndexOffset: 5,
+			LastIndexOffset:  7,
+		},
 	}, {
 		name:    "lookup invoice, not mapped to account",
 		fullURI: "/lnrpc.Lightning/LookupInvoice",
@@ -244,6 +404,248 @@ func TestAccountCheckers(t *testing.T) {
 				t, lnrpc.Payment_UNKNOWN, payment.Status,
 			)
 		},
+	}, {
+		name:    "send payment v2, fee limit above account maximum",
+		fullURI: "/routerrpc.Router/SendPaymentV2",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 1_000_000
+			acct.Limits.MaxFee = 500
+		},
+		originalRequest: &routerrpc.SendPaymentRequest{
+			AmtMsat:      5000,
+			FeeLimitMsat: 501,
+		},
+		requestErr: "fee limit 501 mSAT of payment of 5000 mSAT is " +
+			"above the maximum fee of 500 mSAT",
+	}, {
+		name:    "send payment, fee limit above account percentage",
+		fullURI: "/lnrpc.Lightning/SendPaymentSync",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 1_000_000
+			acct.Limits.MaxFee = 500
+			acct.Limits.MaxFeePercent = 5
+		},
+		originalRequest: &lnrpc.SendRequest{
+			// Without a fee limit, the full amount is used as the
+			// limit by lnd.
+			AmtMsat: 5000,
+		},
+		requestErr: "above the maximum fee of 250 mSAT",
+	}, {
+		name:    "send payment v2, fee limit within account maximum",
+		fullURI: "/routerrpc.Router/SendPaymentV2",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 1_000_000
+			acct.Limits.MaxFee = 500
+			acct.Limits.MaxFeePercent = 5
+		},
+		originalRequest: &routerrpc.SendPaymentRequest{
+			AmtMsat:      5000,
+			FeeLimitMsat: 250,
+		},
+		originalResponse: &lnrpc.Payment{
+			PaymentHash: hex.EncodeToString(testHash[:]),
+			ValueMsat:   5000,
+			FeeMsat:     100,
+		},
+		validate: func(t *testing.T, s *mockService,
+			acct *OffChainBalanceAccount) {
+
+			require.Contains(t, s.trackedPayments, testHash)
+		},
+	}, {
+		name:    "send to route, fee above account maximum",
+		fullURI: "/lnrpc.Lightning/SendToRouteSync",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 1_000_000
+			acct.Limits.MaxFee = 500
+		},
+		originalRequest: &lnrpc.SendToRouteRequest{
+			PaymentHash: testHash[:],
+			Route: &lnrpc.Route{
+				TotalAmtMsat:  5600,
+				TotalFeesMsat: 600,
+			},
+		},
+		requestErr: "fee limit exceeds account maximum",
+	}, {
+		name:    "send to route, amount reserved while in flight",
+		fullURI: "/lnrpc.Lightning/SendToRoute",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &lnrpc.SendToRouteRequest{
+			PaymentHashString: testHash.String(),
+			Route: &lnrpc.Route{
+				TotalAmtMsat:  4100,
+				TotalFeesMsat: 100,
+			},
+		},
+		originalResponse: &lnrpc.SendResponse{
+			PaymentHash: testHash[:],
+		},
+		validate: func(t *testing.T, s *mockService,
+			acct *OffChainBalanceAccount) {
+
+			require.Contains(t, s.trackedPayments, testHash)
+			require.EqualValues(
+				t, 4200, s.trackedPayments[testHash].FullAmount,
+			)
+		},
+	}, {
+		name:    "send to route, invalid payment hash",
+		fullURI: "/lnrpc.Lightning/SendToRouteSync",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &lnrpc.SendToRouteRequest{
+			PaymentHash: testHash[:8],
+			Route: &lnrpc.Route{
+				TotalAmtMsat: 1000,
+			},
+		},
+		requestErr: "error parsing payment hash",
+	}, {
+		name:    "send to route v2, hops above route total",
+		fullURI: "/routerrpc.Router/SendToRouteV2",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &routerrpc.SendToRouteRequest{
+			PaymentHash: testHash[:],
+			Route: &lnrpc.Route{
+				TotalAmtMsat: 1000,
+				Hops: []*lnrpc.Hop{{
+					FeeMsat: 100,
+				}, {
+					AmtToForwardMsat: 6000,
+				}},
+			},
+		},
+		requestErr: "error validating account balance: invalid balance",
+	}, {
+		name:    "send to route v2, successful attempt",
+		fullURI: "/routerrpc.Router/SendToRouteV2",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &routerrpc.SendToRouteRequest{
+			PaymentHash: testPreimageHash[:],
+			Route: &lnrpc.Route{
+				TotalAmtMsat:  4000,
+				TotalFeesMsat: 100,
+				Hops: []*lnrpc.Hop{{
+					FeeMsat:          100,
+					AmtToForwardMsat: 3900,
+				}, {
+					AmtToForwardMsat: 3900,
+				}},
+			},
+		},
+		originalResponse: &lnrpc.HTLCAttempt{
+			Status:   lnrpc.HTLCAttempt_SUCCEEDED,
+			Preimage: testPreimage[:],
+			Route: &lnrpc.Route{
+				TotalAmtMsat: 4000,
+			},
+		},
+		validate: func(t *testing.T, s *mockService,
+			acct *OffChainBalanceAccount) {
+
+			// The amount including the fee was reserved when
+			// the request was checked.
+			hash := testPreimageHash
+			require.Contains(t, s.trackedPayments, hash)
+			require.EqualValues(
+				t, 4100, s.trackedPayments[hash].FullAmount,
+			)
+		},
+	}, {
+		name:    "send to route v2, failed attempt",
+		fullURI: "/routerrpc.Router/SendToRouteV2",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &routerrpc.SendToRouteRequest{
+			PaymentHash: testHash[:],
+			Route: &lnrpc.Route{
+				TotalAmtMsat: 4000,
+			},
+		},
+		originalResponse: &lnrpc.HTLCAttempt{
+			Status: lnrpc.HTLCAttempt_FAILED,
+		},
+		validate: func(t *testing.T, s *mockService,
+			acct *OffChainBalanceAccount) {
+
+			// The failed attempt doesn't tell us the payment hash,
+			// the amount is released once the tracking sees that
+			// lnd failed the payment.
+			require.Contains(t, s.trackedPayments, testHash)
+		},
+	}, {
+		name:    "send payment, keysend blocked",
+		fullURI: "/lnrpc.Lightning/SendPaymentSync",
+		cfg:     &Config{BlockKeysend: true},
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &lnrpc.SendRequest{
+			AmtMsat: 1000,
+			DestCustomRecords: map[uint64][]byte{
+				record.KeySendType: testPreimage[:],
+				65537:              []byte("hello"),
+			},
+		},
+		requestErr: ErrKeysendBlocked.Error(),
+	}, {
+		name:    "send payment, keysend allowed",
+		fullURI: "/lnrpc.Lightning/SendPaymentSync",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &lnrpc.SendRequest{
+			AmtMsat: 1000,
+			DestCustomRecords: map[uint64][]byte{
+				record.KeySendType: testPreimage[:],
+				65537:              []byte("hello"),
+			},
+		},
+		originalResponse: &lnrpc.SendResponse{
+			PaymentHash: testHash[:],
+		},
+	}, {
+		name:    "send payment v2, amp without invoice blocked",
+		fullURI: "/routerrpc.Router/SendPaymentV2",
+		cfg:     &Config{BlockKeysend: true},
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &routerrpc.SendPaymentRequest{
+			AmtMsat: 1000,
+			Amp:     true,
+		},
+		requestErr: ErrKeysendBlocked.Error(),
+	}, {
+		name:    "send to route v2, keysend blocked",
+		fullURI: "/routerrpc.Router/SendToRouteV2",
+		cfg:     &Config{BlockKeysend: true},
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			s.acctBalanceMsat = 5000
+		},
+		originalRequest: &routerrpc.SendToRouteRequest{
+			PaymentHash: testHash[:],
+			Route: &lnrpc.Route{
+				TotalAmtMsat: 1000,
+				Hops: []*lnrpc.Hop{{
+					AmtToForwardMsat: 1000,
+					CustomRecords: map[uint64][]byte{
+						record.KeySendType: testPreimage[:],
+					},
+				}},
+			},
+		},
+		requestErr: ErrKeysendBlocked.Error(),
 	}, {
 		name:            "list payments, not mapped to account",
 		fullURI:         "/lnrpc.Lightning/ListPayments",
@@ -273,6 +675,74 @@ func TestAccountCheckers(t *testing.T) {
 				PaymentHash: hex.EncodeToString(testHash[:]),
 			}},
 		},
+	}, {
+		name:    "list payments, page filled with account's payments",
+		fullURI: "/lnrpc.Lightning/ListPayments",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Payments[testHash] = &PaymentEntry{}
+			acct.Payments[testHash3] = &PaymentEntry{}
+			acct.Payments[testHash4] = &PaymentEntry{}
+		},
+		originalRequest: &lnrpc.ListPaymentsRequest{
+			IndexOffset: 3,
+			MaxPayments: 2,
+		},
+		replacedRequest: &lnrpc.ListPaymentsRequest{
+			IndexOffset: 3,
+			MaxPayments: listBatchSize,
+		},
+		originalResponse: &lnrpc.ListPaymentsResponse{
+			Payments: []*lnrpc.Payment{{
+				PaymentHash:  hex.EncodeToString(testHash[:]),
+				PaymentIndex: 4,
+			}, {
+				PaymentHash:  hex.EncodeToString(testHash2[:]),
+				PaymentIndex: 5,
+			}, {
+				PaymentHash:  hex.EncodeToString(testHash3[:]),
+				PaymentIndex: 6,
+			}, {
+				PaymentHash:  hex.EncodeToString(testHash4[:]),
+				PaymentIndex: 7,
+			}},
+			FirstIndexOffset: 4,
+			LastIndexOffset:  7,
+		},
+		replacedResponse: &lnrpc.ListPaymentsResponse{
+			Payments: []*lnrpc.Payment{{
+				PaymentHash:  hex.EncodeToString(testHash[:]),
+				PaymentIndex: 4,
+			}, {
+				PaymentHash:  hex.EncodeToString(testHash3[:]),
+				PaymentIndex: 6,
+			}},
+			FirstIndexOffset: 4,
+			LastIndexOffset:  6,
+		},
+	}, {
+		name:    "list payments, total replaced by account's total",
+		fullURI: "/lnrpc.Lightning/ListPayments",
+		setup: func(s *mockService, acct *OffChainBalanceAccount) {
+			acct.Payments[testHash] = &PaymentEntry{}
+		},
+		originalRequest: &lnrpc.ListPaymentsRequest{
+			IndexOffset:        3,
+			MaxPayments:        2,
+			CountTotalPayments: true,
+		},
+		originalResponse: &lnrpc.ListPaymentsResponse{
+			Payments: []*lnrpc.Payment{{
+				PaymentHash: hex.EncodeToString(testHash2[:]),
+			}},
+			FirstIndexOffset: 4,
+			LastIndexOffset:  4,
+			TotalNumPayments: 42,
+		},
+		replacedResponse: &lnrpc.ListPaymentsResponse{
+			FirstIndexOffset: 4,
+			LastIndexOffset:  4,
+			TotalNumPayments: 1,
+		},
 	}, {
 		name:    "track payment, not mapped to account",
 		fullURI: "/routerrpc.Router/TrackPaymentV2",
@@ -399,7 +869,9 @@ func TestAccountCheckers(t *testing.T) {
 			tt.Parallel()
 
 			service := newMockService()
-			checkers := NewAccountChecker(service, chainParams)
+			checkers := NewAccountChecker(
+				service, nil, chainParams, tc.cfg,
+			)
 			acct := &OffChainBalanceAccount{
 				ID:       testID,
 				Type:     TypeInitialBalance,
@@ -416,7 +888,7 @@ func TestAccountCheckers(t *testing.T) {
 				tc.setup(service, acct)
 			}
 
-			err := checkers.checkIncomingRequest(
+			replacedReq, err := checkers.checkIncomingRequest(
 				ctx, tc.fullURI, tc.originalRequest,
 			)
 
@@ -427,6 +899,12 @@ func TestAccountCheckers(t *testing.T) {
 			}
 			require.NoError(tt, err)
 
+			if tc.replacedRequest != nil {
+				assertMessagesEqual(
+					tt, tc.replacedRequest, replacedReq,
+				)
+			}
+
 			replaced, err := checkers.replaceOutgoingResponse(
 				ctx, tc.fullURI, tc.originalResponse,
 			)
@@ -448,6 +926,96 @@ func TestAccountCheckers(t *testing.T) {
 	}
 }
 
+// TestCheckerExtension tests that the checkers of registered extensions
+// enforce the account balance and track the payments of their calls.
+func TestCheckerExtension(t *testing.T) {
+	t.Parallel()
+
+	const uri = "/testrpc.Test/Send"
+	ext := &CheckerExtension{
+		RequestSample:  &lnrpc.SendRequest{},
+		ResponseSample: &lnrpc.SendResponse{},
+		Spend: func(_ context.Context,
+			req proto.Message) (*ExtensionSpend, error) {
+
+			r := req.(*lnrpc.SendRequest)
+			return &ExtensionSpend{
+				Amount: lnwire.MilliSatoshi(r.AmtMsat),
+				MaxFee: lnwire.MilliSatoshi(
+					r.FeeLimit.GetFixedMsat(),
+				),
+			}, nil
+		},
+		Payment: func(_ context.Context,
+			resp proto.Message) (*ExtensionPayment, error) {
+
+			r := resp.(*lnrpc.SendResponse)
+			hash, err := lntypes.MakeHash(r.PaymentHash)
+			if err != nil {
+				return nil, err
+			}
+
+			return &ExtensionPayment{
+				Hash:       hash,
+				FullAmount: 6000,
+				Status:     lnrpc.Payment_IN_FLIGHT,
+			}, nil
+		},
+	}
+
+	// An extension can't replace a built-in checker, nor can it be
+	// registered twice.
+	interceptor := NewServiceWithStore(nil, nil)
+	err := interceptor.RegisterCheckerExtension(
+		"/lnrpc.Lightning/SendPaymentSync", ext,
+	)
+	require.ErrorIs(t, err, ErrCheckerExists)
+	require.NoError(t, interceptor.RegisterCheckerExtension(uri, ext))
+	err = interceptor.RegisterCheckerExtension(uri, ext)
+	require.ErrorIs(t, err, ErrCheckerExists)
+	require.Error(t, interceptor.RegisterCheckerExtension(
+		"/testrpc.Test/Other", &CheckerExtension{},
+	))
+
+	service := newMockService()
+	service.acctBalanceMsat = 5000
+	checkers := NewAccountChecker(service, nil, chainParams, nil)
+	checker, err := newExtensionChecker(service, ext)
+	require.NoError(t, err)
+	require.NoError(t, checkers.addChecker(uri, checker))
+
+	acct := &OffChainBalanceAccount{
+		ID:       testID,
+		Type:     TypeInitialBalance,
+		Invoices: make(map[lntypes.Hash]struct{}),
+		Payments: make(map[lntypes.Hash]*PaymentEntry),
+	}
+	ctx := AddToContext(context.Background(), KeyAccount, acct)
+
+	// The fee counts towards the balance as well.
+	req := &lnrpc.SendRequest{
+		AmtMsat: 5000,
+		FeeLimit: &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_FixedMsat{FixedMsat: 1000},
+		},
+	}
+	_, err = checkers.checkIncomingRequest(ctx, uri, req)
+	require.ErrorContains(t, err, "invalid balance")
+
+	service.acctBalanceMsat = 6000
+	_, err = checkers.checkIncomingRequest(ctx, uri, req)
+	require.NoError(t, err)
+
+	_, err = checkers.replaceOutgoingResponse(
+		ctx, uri, &lnrpc.SendResponse{PaymentHash: testHash[:]},
+	)
+	require.NoError(t, err)
+	require.Contains(t, service.trackedPayments, testHash)
+	require.EqualValues(
+		t, 6000, service.trackedPayments[testHash].FullAmount,
+	)
+}
+
 // assertMessagesEqual makes sure two proto messages are equal by JSON
 // serializing them.
 func assertMessagesEqual(t *testing.T, expected, actual proto.Message) {
@@ -459,3 +1027,248 @@ func assertMessagesEqual(t *testing.T, expected, actual proto.Message) {
 
 	require.Equal(t, string(expectedJSON), string(actualJSON))
 }
+
+// mockListClient is a list client that pages through the invoices and payments
+// of a node just like lnd does.
+type mockListClient struct {
+	// numEntries is the number of invoices and payments of the node. Their
+	// indexes start at one.
+	numEntries uint64
+
+	// batchSizes are the maximum numbers of entries that were asked for.
+	batchSizes []uint64
+}
+
+// page returns the indexes of the entries of the page with the given offset.
+func (m *mockListClient) page(offset, maxEntries uint64,
+	reversed bool) []uint64 {
+
+	m.batchSizes = append(m.batchSizes, maxEntries)
+
+	var indexes []uint64
+	if reversed {
+		if offset == 0 {
+			offset = m.numEntries + 1
+		}
+		for i := offset - 1; i > 0 &&
+			uint64(len(indexes)) < maxEntries; i-- {
+
+			indexes = append([]uint64{i}, indexes...)
+		}
+
+		return indexes
+	}
+
+	for i := offset + 1; i <= m.numEntries &&
+		uint64(len(indexes)) < maxEntries; i++ {
+
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+func (m *mockListClient) ListInvoices(_ context.Context,
+	in *lnrpc.ListInvoiceRequest,
+	_ ...grpc.CallOption) (*lnrpc.ListInvoiceResponse, error) {
+
+	resp := &lnrpc.ListInvoiceResponse{}
+	indexes := m.page(in.IndexOffset, in.NumMaxInvoices, in.Reversed)
+	for _, index := range indexes {
+		hash := indexHash(index)
+		resp.Invoices = append(resp.Invoices, &lnrpc.Invoice{
+			RHash:    hash[:],
+			AddIndex: index,
+		})
+	}
+	if len(indexes) > 0 {
+		resp.FirstIndexOffset = indexes[0]
+		resp.LastIndexOffset = indexes[len(indexes)-1]
+	}
+
+	return resp, nil
+}
+
+func (m *mockListClient) ListPayments(_ context.Context,
+	in *lnrpc.ListPaymentsRequest,
+	_ ...grpc.CallOption) (*lnrpc.ListPaymentsResponse, error) {
+
+	resp := &lnrpc.ListPaymentsResponse{}
+	indexes := m.page(in.IndexOffset, in.MaxPayments, in.Reversed)
+	for _, index := range indexes {
+		hash := indexHash(index)
+		resp.Payments = append(resp.Payments, &lnrpc.Payment{
+			PaymentHash:  hash.String(),
+			PaymentIndex: index,
+		})
+	}
+	if len(indexes) > 0 {
+		resp.FirstIndexOffset = indexes[0]
+		resp.LastIndexOffset = indexes[len(indexes)-1]
+	}
+
+	return resp, nil
+}
+
+var _ ListClient = (*mockListClient)(nil)
+
+// indexHash returns the hash of the invoice or payment with the given index.
+func indexHash(index uint64) lntypes.Hash {
+	return lntypes.Hash{byte(index), byte(index >> 8), 0xff}
+}
+
+// TestAccountCheckerListBatches makes sure the pages of list calls are filled
+// with the entries of the account by paging through lnd in bounded batches.
+func TestAccountCheckerListBatches(t *testing.T) {
+	t.Parallel()
+
+	acctIndexes := []uint64{5, 1200, 1300, 2400}
+	testCases := []struct {
+		name       string
+		offset     uint64
+		maxEntries uint64
+		reversed   bool
+		indexes    []uint64
+		firstIndex uint64
+		lastIndex  uint64
+		numBatches int
+	}{{
+		name:       "forward",
+		maxEntries: 3,
+		indexes:    []uint64{5, 1200, 1300},
+		firstIndex: 5,
+		lastIndex:  1300,
+		numBatches: 2,
+	}, {
+		name:       "reversed",
+		maxEntries: 2,
+		reversed:   true,
+		indexes:    []uint64{1300, 2400},
+		firstIndex: 1300,
+		lastIndex:  2400,
+		numBatches: 2,
+	}, {
+		name:       "no account entries behind offset",
+		offset:     2400,
+		maxEntries: 10,
+		firstIndex: 2401,
+		lastIndex:  2500,
+		numBatches: 1,
+	}, {
+		name:       "no maximum",
+		indexes:    acctIndexes,
+		firstIndex: 5,
+		lastIndex:  2400,
+		numBatches: 3,
+	}}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			acct := &OffChainBalanceAccount{
+				ID:       testID,
+				Type:     TypeInitialBalance,
+				Invoices: make(map[lntypes.Hash]struct{}),
+				Payments: make(map[lntypes.Hash]*PaymentEntry),
+			}
+			for _, index := range acctIndexes {
+				acct.Invoices[indexHash(index)] = struct{}{}
+				acct.Payments[indexHash(index)] = &PaymentEntry{}
+			}
+			ctx := AddToContext(
+				context.Background(), KeyAccount, acct,
+			)
+
+			// The checker asks lnd for the first batch, which the
+			// middleware passes on as lnd's response.
+			lnd := &mockListClient{numEntries: 2500}
+			checkers := NewAccountChecker(
+				newMockService(), lnd, chainParams, nil,
+			)
+			invoicesURI := "/lnrpc.Lightning/ListInvoices"
+			req, err := checkers.checkIncomingRequest(
+				ctx, invoicesURI, &lnrpc.ListInvoiceRequest{
+					IndexOffset:    tc.offset,
+					NumMaxInvoices: tc.maxEntries,
+					Reversed:       tc.reversed,
+				},
+			)
+			require.NoError(tt, err)
+
+			batch, err := lnd.ListInvoices(
+				ctx, req.(*lnrpc.ListInvoiceRequest),
+			)
+			require.NoError(tt, err)
+			resp, err := checkers.replaceOutgoingResponse(
+				ctx, invoicesURI, batch,
+			)
+			require.NoError(tt, err)
+
+			invoices := resp.(*lnrpc.ListInvoiceResponse)
+			var indexes []uint64
+			for _, invoice := range invoices.Invoices {
+				indexes = append(indexes, invoice.AddIndex)
+			}
+			require.Equal(tt, tc.indexes, indexes)
+			require.Equal(
+				tt, tc.firstIndex, invoices.FirstIndexOffset,
+			)
+			require.Equal(
+				tt, tc.lastIndex, invoices.LastIndexOffset,
+			)
+			require.Len(tt, lnd.batchSizes, tc.numBatches)
+			for _, size := range lnd.batchSizes {
+				require.EqualValues(tt, listBatchSize, size)
+			}
+
+			// Payments are paged through the same way.
+			lnd.batchSizes = nil
+			paymentsURI := "/lnrpc.Lightning/ListPayments"
+			req, err = checkers.checkIncomingRequest(
+				ctx, paymentsURI, &lnrpc.ListPaymentsRequest{
+					IndexOffset: tc.offset,
+					MaxPayments: tc.maxEntries,
+					Reversed:    tc.reversed,
+				},
+			)
+			require.NoError(tt, err)
+			paymentBatch, err := lnd.ListPayments(
+				ctx, req.(*lnrpc.ListPaymentsRequest),
+			)
+			require.NoError(tt, err)
+			resp, err = checkers.replaceOutgoingResponse(
+				ctx, paymentsURI, paymentBatch,
+			)
+			require.NoError(tt, err)
+
+			payments := resp.(*lnrpc.ListPaymentsResponse)
+			indexes = nil
+			for _, payment := range payments.Payments {
+				indexes = append(indexes, payment.PaymentIndex)
+			}
+			require.Equal(tt, tc.indexes, indexes)
+			require.Len(tt, lnd.batchSizes, tc.numBatches)
+
+			// Every query was forgotten once its response was
+			// filtered.
+			require.Empty(tt, checkers.listQueries)
+		})
+	}
+}
+
+// TestAccountCheckerForgetQuery makes sure the query of a list call is dropped
+// if lnd answers it with an error.
+func TestAccountCheckerForgetQuery(t *testing.T) {
+	t.Parallel()
+
+	checkers := NewAccountChecker(newMockService(), nil, chainParams, nil)
+	ctx := context.WithValue(context.Background(), KeyRequestID, uint64(7))
+	checkers.rememberQuery(ctx, listQuery{maxEntries: 1})
+	require.Len(t, checkers.listQueries, 1)
+
+	checkers.forgetQuery(7)
+	require.Empty(t, checkers.listQueries)
+}
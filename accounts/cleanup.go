@@ -0,0 +1,180 @@
+package accounts
+
+import (
+	"errors"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+const (
+	// accountCleanupInterval is the interval in which accounts are checked
+	// against the cleanup policy.
+	accountCleanupInterval = time.Hour
+
+	// cleanupReason is the reason that is recorded for the removal of
+	// accounts that are archived by the cleanup.
+	cleanupReason = "automatic cleanup"
+)
+
+// ErrCleanupDisabled is returned if accounts are cleaned up while no cleanup
+// policy is configured.
+var ErrCleanupDisabled = errors.New("account cleanup is disabled")
+
+// CleanupDue returns true if the account can be cleaned up at the given time.
+// That is the case if it expired and wasn't updated for at least the given
+// period, its balance is below one satoshi, it has no in-flight payments and
+// none of its invoices can credit it anymore. The given function reports if an
+// invoice of the account can still be paid.
+func (a *OffChainBalanceAccount) CleanupDue(now time.Time, after,
+	gracePeriod time.Duration, invoicePending func(lntypes.Hash) bool) bool {
+
+	if a.ExpirationDate.IsZero() || now.Sub(a.ExpirationDate) < after {
+		return false
+	}
+
+	if now.Sub(a.LastUpdate) < after || a.CurrentBalanceSats() > 0 {
+		return false
+	}
+
+	for _, payment := range a.Payments {
+		if payment.Status == lnrpc.Payment_IN_FLIGHT ||
+			payment.Status == lnrpc.Payment_UNKNOWN {
+
+			return false
+		}
+	}
+
+	deadline, ok := a.InvoiceDeadline(gracePeriod)
+	if ok {
+		return !now.Before(deadline)
+	}
+
+	// The invoices of the account can credit it forever, so we only clean
+	// it up once none of them can be paid anymore.
+	for hash := range a.Invoices {
+		if invoicePending(hash) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CleanupAccounts cleans up all accounts that are due according to the
+// configured cleanup policy and returns them. If dryRun is set, the accounts
+// that would be cleaned up are only returned. ErrCleanupDisabled is returned
+// if no cleanup policy is configured.
+func (s *InterceptorService) CleanupAccounts(
+	dryRun bool) ([]*OffChainBalanceAccount, error) {
+
+	if s.cleanupAfter == 0 {
+		return nil, ErrCleanupDisabled
+	}
+
+	accounts, err := s.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var due []*OffChainBalanceAccount
+	for _, account := range accounts {
+		account, err := s.cleanupAccount(account.ID, now, dryRun)
+		if err != nil {
+			return due, err
+		}
+		if account == nil {
+			continue
+		}
+
+		due = append(due, account)
+	}
+
+	return due, nil
+}
+
+// cleanupAccount cleans up the account with the given ID if it is due and
+// returns it. The account is checked again while the mutex is held, so it
+// can't be updated, credited or used for a payment in between. Nil is returned
+// if the account isn't due or doesn't exist anymore.
+func (s *InterceptorService) cleanupAccount(id AccountID, now time.Time,
+	dryRun bool) (*OffChainBalanceAccount, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	account, err := s.store.Account(id)
+	switch {
+	case errors.Is(err, ErrAccNotFound):
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	if !account.CleanupDue(
+		now, s.cleanupAfter, s.invoiceGracePeriod, s.invoicePending,
+	) {
+
+		return nil, nil
+	}
+
+	if dryRun {
+		return account, nil
+	}
+
+	if s.cleanupAction == CleanupActionRemove {
+		err = s.store.DeleteAccount(id)
+		if err == nil {
+			s.forgetAccount(id)
+		}
+	} else {
+		err = s.removeAccount(id, ActorLitd, cleanupReason)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Cleaned up account %x (%s) that expired at %v (action %s)",
+		id[:], account.Label, account.ExpirationDate, s.cleanupAction)
+
+	return account, nil
+}
+
+// invoicePending returns true if the invoice with the given hash is still
+// tracked, which means it is neither settled nor canceled.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *InterceptorService) invoicePending(hash lntypes.Hash) bool {
+	_, ok := s.invoiceToAccount[hash]
+	return ok
+}
+
+// cleanupLoop periodically cleans up the accounts that are due according to
+// the cleanup policy.
+//
+// NOTE: This method must be run in a goroutine.
+func (s *InterceptorService) cleanupLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(accountCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CleanupAccounts(false); err != nil {
+			log.Errorf("Unable to clean up accounts: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+
+		case <-s.mainCtx.Done():
+			return
+
+		case <-s.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package accounts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCleanupDue tests when an account is due to be cleaned up.
+func TestCleanupDue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now         = time.Unix(10_000_000, 0)
+		after       = 48 * time.Hour
+		gracePeriod = 24 * time.Hour
+		longAgo     = now.Add(-72 * time.Hour)
+	)
+
+	newAccount := func() *OffChainBalanceAccount {
+		return &OffChainBalanceAccount{
+			CurrentBalance: 999,
+			ExpirationDate: longAgo,
+			LastUpdate:     longAgo,
+			Invoices: map[lntypes.Hash]struct{}{
+				testHash: {},
+			},
+			InvoiceCredits: make(
+				map[lntypes.Hash]lnwire.MilliSatoshi,
+			),
+			Payments: map[lntypes.Hash]*PaymentEntry{
+				testHash: {Status: lnrpc.Payment_SUCCEEDED},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(a *OffChainBalanceAccount)
+		pending bool
+		due     bool
+	}{{
+		name:   "due",
+		modify: func(a *OffChainBalanceAccount) {},
+		due:    true,
+	}, {
+		name: "never expires",
+		modify: func(a *OffChainBalanceAccount) {
+			a.ExpirationDate = time.Time{}
+		},
+	}, {
+		name: "expired recently",
+		modify: func(a *OffChainBalanceAccount) {
+			a.ExpirationDate = now.Add(-time.Hour)
+		},
+	}, {
+		name: "updated recently",
+		modify: func(a *OffChainBalanceAccount) {
+			a.LastUpdate = now.Add(-time.Hour)
+		},
+	}, {
+		name: "balance left",
+		modify: func(a *OffChainBalanceAccount) {
+			a.CurrentBalance = 1000
+		},
+	}, {
+		name: "in-flight payment",
+		modify: func(a *OffChainBalanceAccount) {
+			a.Payments[testHash].Status = lnrpc.Payment_IN_FLIGHT
+		},
+	}, {
+		name:    "pending invoice after deadline",
+		modify:  func(a *OffChainBalanceAccount) {},
+		pending: true,
+		due:     true,
+	}, {
+		name: "invoice can still credit",
+		modify: func(a *OffChainBalanceAccount) {
+			a.ExpiredInvoicePolicy = ExpiredInvoicePolicyCredit
+		},
+		pending: true,
+	}, {
+		name: "invoice settled before credits were recorded",
+		modify: func(a *OffChainBalanceAccount) {
+			a.ExpiredInvoicePolicy = ExpiredInvoicePolicyCredit
+		},
+		due: true,
+	}, {
+		name: "all invoices credited",
+		modify: func(a *OffChainBalanceAccount) {
+			a.ExpiredInvoicePolicy = ExpiredInvoicePolicyCredit
+			a.InvoiceCredits[testHash] = 1000
+		},
+		due: true,
+	}}
+
+	for _, tc := range testCases {
+		account := newAccount()
+		tc.modify(account)
+
+		pending := func(lntypes.Hash) bool {
+			return tc.pending
+		}
+		require.Equal(
+			t, tc.due,
+			account.CleanupDue(now, after, gracePeriod, pending),
+			tc.name,
+		)
+	}
+}
+
+// TestCleanupAccounts tests that the accounts that are due are archived or
+// removed according to the cleanup action, and only reported in a dry run.
+func TestCleanupAccounts(t *testing.T) {
+	t.Parallel()
+
+	lndMock := newMockLnd()
+	service, err := NewService(t.TempDir(), lndMock.mainErrChan)
+	require.NoError(t, err)
+
+	expired := time.Now().Add(-48 * time.Hour)
+	newAccount := func(balance lnwire.MilliSatoshi,
+		expiration time.Time) *OffChainBalanceAccount {
+
+		account, err := service.store.NewAccount(
+			balance, expiration, "", AccountLimits{},
+			ExpiredInvoicePolicyGrace,
+		)
+		require.NoError(t, err)
+
+		return account
+	}
+	first := newAccount(500, expired)
+	second := newAccount(500, expired)
+	newAccount(5000, expired)
+	newAccount(500, time.Now().Add(time.Hour))
+
+	// An account whose open invoice can still credit it is kept.
+	open := newAccount(500, expired)
+	open.ExpiredInvoicePolicy = ExpiredInvoicePolicyCredit
+	open.Invoices[testHash] = struct{}{}
+	require.NoError(t, service.store.UpdateAccount(open))
+
+	var removed []AccountID
+	service.SetOnRemove(func(id AccountID) {
+		removed = append(removed, id)
+	})
+
+	err = service.Start(lndMock, lndMock, lndMock, chainParams, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+		lndMock.assertNoMainErr(t)
+	})
+
+	// Without a cleanup policy, nothing is cleaned up.
+	_, err = service.CleanupAccounts(true)
+	require.ErrorIs(t, err, ErrCleanupDisabled)
+
+	// A dry run only reports the accounts that are due.
+	service.cleanupAfter = time.Nanosecond
+	service.cleanupAction = CleanupActionArchive
+	due, err := service.CleanupAccounts(true)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+
+	accounts, err := service.Accounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 5)
+
+	// Archived accounts leave a removal record.
+	err = service.RemoveAccount(second.ID, ActorUnknown, "")
+	require.NoError(t, err)
+
+	cleaned, err := service.CleanupAccounts(false)
+	require.NoError(t, err)
+	require.Len(t, cleaned, 1)
+	require.Equal(t, first.ID, cleaned[0].ID)
+
+	removals, err := service.RemovedAccounts()
+	require.NoError(t, err)
+	require.Len(t, removals, 2)
+	for _, removal := range removals {
+		if removal.ID == first.ID {
+			require.Equal(t, ActorLitd, removal.RemovedBy)
+			require.Equal(t, cleanupReason, removal.Reason)
+		}
+	}
+
+	// Removed accounts leave no record.
+	third := newAccount(500, expired)
+	service.cleanupAction = CleanupActionRemove
+	cleaned, err = service.CleanupAccounts(false)
+	require.NoError(t, err)
+	require.Len(t, cleaned, 1)
+	require.Equal(t, third.ID, cleaned[0].ID)
+
+	removals, err = service.RemovedAccounts()
+	require.NoError(t, err)
+	require.Len(t, removals, 2)
+
+	// The removal callback is called for archived and removed accounts.
+	require.Equal(
+		t, []AccountID{second.ID, first.ID, third.ID}, removed,
+	)
+
+	accounts, err = service.Accounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+}
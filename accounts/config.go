@@ -0,0 +1,60 @@
+package accounts
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultInvoiceGracePeriod is the default time after the expiration of an
+// account during which its settled invoices still credit the account.
+const DefaultInvoiceGracePeriod = 24 * time.Hour
+
+const (
+	// CleanupActionArchive removes accounts that are cleaned up but keeps
+	// a record of their removal.
+	CleanupActionArchive = "archive"
+
+	// CleanupActionRemove removes accounts that are cleaned up without
+	// keeping a record of their removal.
+	CleanupActionRemove = "remove"
+)
+
+// Config holds the config options of the account system.
+type Config struct {
+	BlockKeysend bool `long:"blockkeysend" description:"Reject keysend payments and AMP payments without an invoice that are sent with an account macaroon."`
+
+	InvoiceGracePeriod time.Duration `long:"invoicegraceperiod" description:"The time after the expiration of an account during which invoices that were created before the expiration still credit the account when they are settled. Open invoices of the account are canceled once this period is over. Only applies to accounts with the default grace policy for expired invoices."`
+
+	CleanupAfter  time.Duration `long:"cleanupafter" description:"Automatically clean up accounts that expired and weren't updated for at least this long and have a balance below one satoshi. Accounts with in-flight payments or invoices that can still credit them are kept. Set to 0 to disable the cleanup."`
+	CleanupAction string        `long:"cleanupaction" description:"What to do with accounts that are cleaned up. 'archive' removes them but keeps a record of the removal, 'remove' removes them without a trace." choice:"archive" choice:"remove"`
+}
+
+// DefaultConfig returns the default account config.
+func DefaultConfig() *Config {
+	return &Config{
+		InvoiceGracePeriod: DefaultInvoiceGracePeriod,
+		CleanupAction:      CleanupActionArchive,
+	}
+}
+
+// Validate makes sure the account config is valid.
+func (c *Config) Validate() error {
+	if c.InvoiceGracePeriod < 0 {
+		return fmt.Errorf("invoice grace period must not be negative")
+	}
+
+	if c.CleanupAfter < 0 {
+		return fmt.Errorf("account cleanup period must not be negative")
+	}
+
+	switch c.CleanupAction {
+	case CleanupActionArchive, CleanupActionRemove:
+
+	default:
+		return fmt.Errorf("unknown account cleanup action %q, "+
+			"expected %q or %q", c.CleanupAction,
+			CleanupActionArchive, CleanupActionRemove)
+	}
+
+	return nil
+}
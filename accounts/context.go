@@ -18,6 +18,11 @@ var (
 	// KeyAccount is the key under which we store the account in the request
 	// context.
 	KeyAccount = ContextKey{"account"}
+
+	// KeyRequestID is the key under which we store the ID of the
+	// intercepted call in the request context. The request and the
+	// response of a call share the same ID.
+	KeyRequestID = ContextKey{"request_id"}
 )
 
 // FromContext tries to extract a value from the given context.
@@ -46,3 +51,10 @@ func AccountFromContext(ctx context.Context) (*OffChainBalanceAccount, error) {
 
 	return acct, nil
 }
+
+// requestIDFromContext extracts the ID of the intercepted call from the given
+// context. Zero is returned if there is none.
+func requestIDFromContext(ctx context.Context) uint64 {
+	id, _ := FromContext(ctx, KeyRequestID).(uint64)
+	return id
+}
@@ -0,0 +1,258 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrCheckerExists is returned if a checker extension is registered for an
+// RPC method that already has a checker.
+var ErrCheckerExists = errors.New("account checker already registered")
+
+// ExtensionSpend is the amount a request of an external service spends from
+// an account.
+type ExtensionSpend struct {
+	// Amount is the amount that is sent, without fees.
+	Amount lnwire.MilliSatoshi
+
+	// MaxFee is the maximum routing fee the request may pay. It is
+	// checked against the fee limits of the account and reserved together
+	// with the amount.
+	MaxFee lnwire.MilliSatoshi
+
+	// Destination is the node the payment is sent to, nil if it isn't
+	// known.
+	Destination *route.Vertex
+}
+
+// ExtensionPayment is a payment that a call of an external service started
+// and that needs to be debited from the account once it succeeds.
+type ExtensionPayment struct {
+	// Hash is the payment hash of the payment.
+	Hash lntypes.Hash
+
+	// FullAmount is the amount of the payment including the maximum
+	// routing fee. It is reserved until the payment reaches a final
+	// state.
+	FullAmount lnwire.MilliSatoshi
+
+	// Status is the status of the payment as reported in the response. A
+	// failed payment is released right away, any other payment is tracked
+	// until lnd reports a final state.
+	Status lnrpc.Payment_PaymentStatus
+}
+
+// SpendFunc returns what the given request of an external service spends
+// from the account in the context. It returns nil if the request doesn't
+// spend anything.
+type SpendFunc func(ctx context.Context,
+	req proto.Message) (*ExtensionSpend, error)
+
+// PaymentFunc returns the payment that the call of an external service
+// started, extracted from its response. It returns nil if the call didn't
+// start a payment.
+type PaymentFunc func(ctx context.Context,
+	resp proto.Message) (*ExtensionPayment, error)
+
+// CheckerExtension describes how the account checker handles an RPC method
+// that isn't built into lnd, for example one of an external subserver. Calls
+// of methods without a checker are rejected for accounts, so a service must
+// register an extension for every method that account macaroons may call.
+type CheckerExtension struct {
+	// RequestSample is an empty message of the request type of the
+	// method.
+	RequestSample proto.Message
+
+	// ResponseSample is an empty message of the response type of the
+	// method.
+	ResponseSample proto.Message
+
+	// Spend extracts what a request spends from the account. The balance
+	// and the limits of the account are checked against it before the
+	// request is allowed. If nil, the method doesn't spend anything.
+	Spend SpendFunc
+
+	// Payment extracts the payment a call started from its response. The
+	// payment is then tracked and debited from the account once it
+	// succeeds. If nil, the method doesn't start payments.
+	Payment PaymentFunc
+}
+
+// extensionChecker is the round trip checker of a checker extension.
+type extensionChecker struct {
+	ext          *CheckerExtension
+	service      Service
+	requestType  protoreflect.MessageType
+	responseType protoreflect.MessageType
+}
+
+// A compile-time check to ensure that extensionChecker implements
+// mid.RoundTripChecker.
+var _ mid.RoundTripChecker = (*extensionChecker)(nil)
+
+// newExtensionChecker creates a round trip checker for the given extension.
+func newExtensionChecker(service Service,
+	ext *CheckerExtension) (*extensionChecker, error) {
+
+	if ext == nil || ext.RequestSample == nil || ext.ResponseSample == nil {
+		return nil, errors.New("checker extension needs a request " +
+			"and a response sample")
+	}
+
+	return &extensionChecker{
+		ext:          ext,
+		service:      service,
+		requestType:  ext.RequestSample.ProtoReflect().Type(),
+		responseType: ext.ResponseSample.ProtoReflect().Type(),
+	}, nil
+}
+
+// HandlesRequest returns true if the checker accepts protobuf request messages
+// of the given type.
+//
+// NOTE: This is part of the mid.RoundTripChecker interface.
+func (c *extensionChecker) HandlesRequest(t protoreflect.MessageType) bool {
+	return t == c.requestType
+}
+
+// HandlesResponse returns true if the checker can handle protobuf response
+// messages of the given type.
+//
+// NOTE: This is part of the mid.RoundTripChecker interface.
+func (c *extensionChecker) HandlesResponse(t protoreflect.MessageType) bool {
+	return t == c.responseType
+}
+
+// HandleRequest checks that the account in the context can afford what the
+// request spends.
+//
+// NOTE: This is part of the mid.RoundTripChecker interface.
+func (c *extensionChecker) HandleRequest(ctx context.Context,
+	req proto.Message) (proto.Message, error) {
+
+	if c.ext.Spend == nil {
+		return nil, nil
+	}
+
+	spend, err := c.ext.Spend(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if spend == nil {
+		return nil, nil
+	}
+
+	acct, err := AccountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := acct.CheckFeeLimit(spend.Amount, spend.MaxFee); err != nil {
+		return nil, err
+	}
+
+	fullAmt := spend.Amount + spend.MaxFee
+	c.service.RecordPaymentAttempt(acct.ID, fullAmt, spend.Destination)
+	err = c.service.CheckBalance(acct.ID, fullAmt)
+	if err != nil {
+		return nil, fmt.Errorf("error validating account balance: %w",
+			err)
+	}
+
+	return nil, nil
+}
+
+// HandleResponse tracks the payment the call started, if any.
+//
+// NOTE: This is part of the mid.RoundTripChecker interface.
+func (c *extensionChecker) HandleResponse(ctx context.Context,
+	resp proto.Message) (proto.Message, error) {
+
+	if c.ext.Payment == nil {
+		return nil, nil
+	}
+
+	payment, err := c.ext.Payment(ctx, resp)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, nil
+	}
+
+	return checkSendResponse(
+		ctx, c.service, payment.Status, payment.Hash,
+		int64(payment.FullAmount),
+	)
+}
+
+// HandleErrorResponse passes through all errors.
+//
+// NOTE: This is part of the mid.RoundTripChecker interface.
+func (c *extensionChecker) HandleErrorResponse(respErr error) (error, error) {
+	return mid.PassThroughErrorHandler(respErr)
+}
+
+// addChecker adds the checker of an extension for the given URI. It returns
+// ErrCheckerExists if the URI already has a checker.
+func (a *AccountChecker) addChecker(uri string,
+	checker mid.RoundTripChecker) error {
+
+	if _, ok := a.checkers[uri]; ok {
+		return fmt.Errorf("%w: %s", ErrCheckerExists, uri)
+	}
+
+	a.checkers[uri] = checker
+
+	return nil
+}
+
+// RegisterCheckerExtension registers the given checker extension for the RPC
+// method with the given full URI, for example
+// /myservice.MyService/SendSomething. Account macaroons can call the method
+// from then on, as long as the method only requires the permissions of
+// MacaroonPermissions. Extensions can be registered before or after the
+// service is started, but not for a method that already has a checker.
+func (s *InterceptorService) RegisterCheckerExtension(uri string,
+	ext *CheckerExtension) error {
+
+	checker, err := newExtensionChecker(s, ext)
+	if err != nil {
+		return err
+	}
+
+	// The checkers are used while the request mutex is held, so we can
+	// safely change them while holding it ourselves.
+	s.requestMtx.Lock()
+	defer s.requestMtx.Unlock()
+
+	if _, ok := s.extensions[uri]; ok {
+		return fmt.Errorf("%w: %s", ErrCheckerExists, uri)
+	}
+
+	// Before the service is started, we only check that the method isn't
+	// one of the built-in ones. The extension is added to the checkers
+	// once they are created on start.
+	checkers := s.checkers
+	if checkers == nil {
+		checkers = NewAccountChecker(s, nil, nil, nil)
+	}
+	if err := checkers.addChecker(uri, checker); err != nil {
+		return err
+	}
+
+	s.extensions[uri] = checker
+
+	log.Infof("Registered account checker extension for %s", uri)
+
+	return nil
+}
@@ -58,7 +58,7 @@ func (s *InterceptorService) Intercept(ctx context.Context,
 		return mid.RPCErrString(req, "error parsing macaroon: %v", err)
 	}
 
-	acctID, err := accountFromMacaroon(mac)
+	acctID, err := AccountFromMacaroon(mac)
 	if err != nil {
 		return mid.RPCErrString(
 			req, "error parsing account from macaroon: %v", err,
@@ -93,6 +93,7 @@ func (s *InterceptorService) Intercept(ctx context.Context,
 	// We now add the account to the incoming context to give each checker
 	// access to it if required.
 	ctxAccount := AddToContext(ctx, KeyAccount, acct)
+	ctxAccount = context.WithValue(ctxAccount, KeyRequestID, req.RequestId)
 
 	switch r := req.InterceptType.(type) {
 	// In the authentication phase we just check that the account hasn't
@@ -108,12 +109,27 @@ func (s *InterceptorService) Intercept(ctx context.Context,
 			return mid.RPCErr(req, err)
 		}
 
-		return mid.RPCErr(req, s.checkers.checkIncomingRequest(
+		replacement, err := s.checkers.checkIncomingRequest(
 			ctxAccount, r.Request.MethodFullUri, msg,
-		))
+		)
+		if err != nil {
+			return mid.RPCErr(req, err)
+		}
+
+		// Some requests are changed before they reach lnd, for example
+		// to fetch more entries of a list than the client asked for.
+		if replacement != nil {
+			return mid.RPCReplacement(req, replacement)
+		}
+
+		return mid.RPCOk(req)
 
 	// Parse and possibly manipulate outgoing responses.
 	case *lnrpc.RPCMiddlewareRequest_Response:
+		// The page a list call asked for is forgotten when the
+		// response is filtered, but lnd might answer with an error.
+		defer s.checkers.forgetQuery(req.RequestId)
+
 		msg, err := parseRPCMessage(r.Response)
 		if err != nil {
 			return mid.RPCErr(req, err)
@@ -160,9 +176,31 @@ func parseRPCMessage(msg *lnrpc.RPCMessage) (proto.Message, error) {
 	return parsedMsg, nil
 }
 
-// accountFromMacaroon attempts to extract an account ID from the custom account
+// SupportsURI returns true if macaroons that are locked to an account may call
+// the RPC method with the given URI. Calls of all other methods are rejected
+// for accounts.
+func (s *InterceptorService) SupportsURI(uri string) bool {
+	s.requestMtx.Lock()
+	defer s.requestMtx.Unlock()
+
+	// Before the service is started, only the built-in checkers and the
+	// registered extensions are known.
+	if s.checkers == nil {
+		if _, ok := s.extensions[uri]; ok {
+			return true
+		}
+
+		_, ok := NewAccountChecker(s, nil, nil, nil).checkers[uri]
+		return ok
+	}
+
+	_, ok := s.checkers.checkers[uri]
+	return ok
+}
+
+// AccountFromMacaroon attempts to extract an account ID from the custom account
 // caveat in the macaroon.
-func accountFromMacaroon(mac *macaroon.Macaroon) (*AccountID, error) {
+func AccountFromMacaroon(mac *macaroon.Macaroon) (*AccountID, error) {
 	// Extract the account caveat from the macaroon.
 	macaroonAccount := macaroons.GetCustomCaveatCondition(mac, CondAccount)
 	if macaroonAccount == "" {
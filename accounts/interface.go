@@ -1,14 +1,18 @@
 package accounts
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
@@ -33,6 +37,43 @@ const (
 	// allowance) or spend-only (no invoice creation) accounts.
 )
 
+// ExpiredInvoicePolicy determines whether invoices of an account that are
+// settled after the account expired still credit its balance.
+type ExpiredInvoicePolicy uint8
+
+const (
+	// ExpiredInvoicePolicyGrace credits invoices that are settled within
+	// the configured grace period after the account expired. Open invoices
+	// are canceled once the grace period is over.
+	ExpiredInvoicePolicyGrace ExpiredInvoicePolicy = 0
+
+	// ExpiredInvoicePolicyCredit always credits settled invoices, no matter
+	// how long after the expiration of the account they are settled.
+	ExpiredInvoicePolicyCredit ExpiredInvoicePolicy = 1
+
+	// ExpiredInvoicePolicyReject never credits invoices that are settled
+	// after the account expired. Open invoices are canceled as soon as the
+	// account expires.
+	ExpiredInvoicePolicyReject ExpiredInvoicePolicy = 2
+)
+
+// String returns the string representation of the policy.
+func (p ExpiredInvoicePolicy) String() string {
+	switch p {
+	case ExpiredInvoicePolicyGrace:
+		return "grace"
+
+	case ExpiredInvoicePolicyCredit:
+		return "credit"
+
+	case ExpiredInvoicePolicyReject:
+		return "reject"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(p))
+	}
+}
+
 // AccountID represents an account's unique ID.
 type AccountID [AccountIDLen]byte
 
@@ -65,6 +106,17 @@ type PaymentEntry struct {
 	// set to the fee limit set when sending the payment and updated to the
 	// actual routing fee when the payment settles.
 	FullAmount lnwire.MilliSatoshi
+
+	// TrackedSince is the time at which the service started tracking the
+	// payment. It is only stored while the payment is in flight and is
+	// zero for payments that were stored by older versions.
+	TrackedSince time.Time
+}
+
+// inFlight returns true if the payment hasn't reached a final state yet.
+func (p *PaymentEntry) inFlight() bool {
+	return p.Status == lnrpc.Payment_IN_FLIGHT ||
+		p.Status == lnrpc.Payment_UNKNOWN
 }
 
 // OffChainBalanceAccount holds all information that is needed to keep track of
@@ -101,9 +153,216 @@ type OffChainBalanceAccount struct {
 	// account.
 	Invoices map[lntypes.Hash]struct{}
 
+	// InvoiceCredits is the amount in millisatoshis each invoice of the
+	// account has credited to it so far. It is used to adjust the balance
+	// if an invoice is paid more than once or is canceled after it
+	// credited the account.
+	InvoiceCredits map[lntypes.Hash]lnwire.MilliSatoshi
+
 	// Payments is a list of all payments that are associated with the
 	// account and the last status we were aware of.
 	Payments map[lntypes.Hash]*PaymentEntry
+
+	// Label is an optional human-readable label of the account.
+	Label string
+
+	// FrozenAt is the time at which the account was frozen. It is zero if
+	// the account isn't frozen. A frozen account can't send payments
+	// until it is unfrozen.
+	FrozenAt time.Time
+
+	// FrozenReason is the reason that was given when the account was
+	// frozen.
+	FrozenReason string
+
+	// Limits restricts what the account can be used for.
+	Limits AccountLimits
+
+	// ExpiredInvoicePolicy determines whether invoices that are settled
+	// after the account expired still credit the account.
+	ExpiredInvoicePolicy ExpiredInvoicePolicy
+}
+
+// AccountLimits restricts what an account can be used for. A zero value means
+// the respective limit is not set.
+type AccountLimits struct {
+	// MinInvoiceAmount is the minimum amount of the invoices the account
+	// can create.
+	MinInvoiceAmount lnwire.MilliSatoshi
+
+	// MaxInvoiceAmount is the maximum amount of the invoices the account
+	// can create.
+	MaxInvoiceAmount lnwire.MilliSatoshi
+
+	// MaxFee is the maximum routing fee the account can allow for a single
+	// payment.
+	MaxFee lnwire.MilliSatoshi
+
+	// MaxFeePercent is the maximum routing fee the account can allow for a
+	// single payment in percent of the payment amount.
+	MaxFeePercent uint32
+}
+
+// Validate makes sure the limits are consistent.
+func (l *AccountLimits) Validate() error {
+	if l.MaxInvoiceAmount != 0 && l.MinInvoiceAmount > l.MaxInvoiceAmount {
+		return fmt.Errorf("minimum invoice amount %v is larger than "+
+			"the maximum invoice amount %v", l.MinInvoiceAmount,
+			l.MaxInvoiceAmount)
+	}
+
+	if l.MaxFeePercent > 100 {
+		return fmt.Errorf("maximum fee percentage %d is larger than "+
+			"100", l.MaxFeePercent)
+	}
+
+	return nil
+}
+
+// maxFee returns the maximum routing fee the account can allow for a payment
+// of the given amount. The second return value is false if the fee isn't
+// limited.
+func (l *AccountLimits) maxFee(amt lnwire.MilliSatoshi) (lnwire.MilliSatoshi,
+	bool) {
+
+	if l.MaxFee == 0 && l.MaxFeePercent == 0 {
+		return 0, false
+	}
+
+	maxFee := l.MaxFee
+	if l.MaxFeePercent != 0 {
+		maxPercentFee := amt * lnwire.MilliSatoshi(l.MaxFeePercent) / 100
+		if maxFee == 0 || maxPercentFee < maxFee {
+			maxFee = maxPercentFee
+		}
+	}
+
+	return maxFee, true
+}
+
+// hasInvoiceLimits returns true if a minimum or maximum invoice amount is set.
+func (l *AccountLimits) hasInvoiceLimits() bool {
+	return l.MinInvoiceAmount != 0 || l.MaxInvoiceAmount != 0
+}
+
+// CheckInvoiceAmount makes sure an invoice of the given amount can be created
+// by the account. An amount of zero denotes an invoice without an amount,
+// which isn't allowed if any invoice limit is set because the payer could
+// choose any amount.
+func (a *OffChainBalanceAccount) CheckInvoiceAmount(
+	amt lnwire.MilliSatoshi) error {
+
+	l := a.Limits
+	switch {
+	case amt == 0 && l.hasInvoiceLimits():
+		return fmt.Errorf("%w: account %x can't create invoices "+
+			"without an amount", ErrInvoiceAmountOutOfRange, a.ID[:])
+
+	case amt < l.MinInvoiceAmount:
+		return fmt.Errorf("%w: invoice amount %v is below the minimum "+
+			"of %v of account %x", ErrInvoiceAmountOutOfRange, amt,
+			l.MinInvoiceAmount, a.ID[:])
+
+	case l.MaxInvoiceAmount != 0 && amt > l.MaxInvoiceAmount:
+		return fmt.Errorf("%w: invoice amount %v is above the maximum "+
+			"of %v of account %x", ErrInvoiceAmountOutOfRange, amt,
+			l.MaxInvoiceAmount, a.ID[:])
+	}
+
+	return nil
+}
+
+// CheckFeeLimit makes sure the fee limit of a payment of the given amount
+// doesn't exceed the maximum fee of the account.
+func (a *OffChainBalanceAccount) CheckFeeLimit(amt,
+	feeLimit lnwire.MilliSatoshi) error {
+
+	maxFee, ok := a.Limits.maxFee(amt)
+	if !ok || feeLimit <= maxFee {
+		return nil
+	}
+
+	return fmt.Errorf("%w: fee limit %v of payment of %v is above the "+
+		"maximum fee of %v of account %x", ErrFeeLimitExceeded,
+		feeLimit, amt, maxFee, a.ID[:])
+}
+
+const (
+	// ActorLitd is the actor that changes made by litd itself, rather
+	// than by one of its callers, are attributed to.
+	ActorLitd = "litd"
+
+	// ActorUnknown is the actor that changes are attributed to if the
+	// caller can't be identified.
+	ActorUnknown = "unknown"
+)
+
+// LedgerReason is the reason for an entry in the ledger of an account.
+type LedgerReason uint8
+
+const (
+	// LedgerReasonInvoiceSettled is the reason for a credit to an account
+	// because one of its invoices was settled or paid again.
+	LedgerReasonInvoiceSettled LedgerReason = 0
+
+	// LedgerReasonInvoiceCanceled is the reason for a debit from an
+	// account because one of its invoices that already credited the
+	// account was canceled.
+	LedgerReasonInvoiceCanceled LedgerReason = 1
+)
+
+// String returns the string representation of the reason.
+func (r LedgerReason) String() string {
+	switch r {
+	case LedgerReasonInvoiceSettled:
+		return "invoice_settled"
+
+	case LedgerReasonInvoiceCanceled:
+		return "invoice_canceled"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(r))
+	}
+}
+
+// LedgerEntry records an adjustment of the balance of an account that was
+// caused by one of its invoices.
+type LedgerEntry struct {
+	// Timestamp is the time at which the balance was adjusted.
+	Timestamp time.Time
+
+	// InvoiceHash is the payment hash of the invoice that caused the
+	// adjustment.
+	InvoiceHash lntypes.Hash
+
+	// Amount is the amount in millisatoshis the balance was adjusted by.
+	// It is positive for credits and negative for debits.
+	Amount int64
+
+	// Balance is the balance of the account in millisatoshis after the
+	// adjustment.
+	Balance int64
+
+	// Reason is the reason for the adjustment.
+	Reason LedgerReason
+}
+
+// AccountRemoval records who removed an account, when and why.
+type AccountRemoval struct {
+	// ID is the ID of the removed account.
+	ID AccountID
+
+	// Label is the label the account had when it was removed.
+	Label string
+
+	// RemovedAt is the time at which the account was removed.
+	RemovedAt time.Time
+
+	// RemovedBy is the identity of the caller that removed the account.
+	RemovedBy string
+
+	// Reason is the optional reason that was given for the removal.
+	Reason string
 }
 
 // HasExpired returns true if the account has an expiration date set and that
@@ -116,6 +375,44 @@ func (a *OffChainBalanceAccount) HasExpired() bool {
 	return a.ExpirationDate.Before(time.Now())
 }
 
+// InvoiceDeadline returns the time after which settled invoices no longer
+// credit the account, given the grace period that applies to accounts with the
+// default policy. The second return value is false if there is no such time,
+// either because the account never expires or because its policy always
+// credits invoices.
+func (a *OffChainBalanceAccount) InvoiceDeadline(
+	gracePeriod time.Duration) (time.Time, bool) {
+
+	if a.ExpirationDate.IsZero() {
+		return time.Time{}, false
+	}
+
+	switch a.ExpiredInvoicePolicy {
+	case ExpiredInvoicePolicyCredit:
+		return time.Time{}, false
+
+	case ExpiredInvoicePolicyReject:
+		return a.ExpirationDate, true
+
+	default:
+		return a.ExpirationDate.Add(gracePeriod), true
+	}
+}
+
+// CreditsInvoice returns true if an invoice of the account that was settled at
+// the given time credits the account balance.
+func (a *OffChainBalanceAccount) CreditsInvoice(settleDate time.Time,
+	gracePeriod time.Duration) bool {
+
+	deadline, ok := a.InvoiceDeadline(gracePeriod)
+	return !ok || settleDate.Before(deadline)
+}
+
+// IsFrozen returns true if the account is frozen.
+func (a *OffChainBalanceAccount) IsFrozen() bool {
+	return !a.FrozenAt.IsZero()
+}
+
 // CurrentBalanceSats returns the current account balance in satoshis.
 func (a *OffChainBalanceAccount) CurrentBalanceSats() int64 {
 	return a.CurrentBalance / 1000
@@ -139,17 +436,39 @@ var (
 	// and that date is in the past.
 	ErrAccExpired = errors.New("account has expired")
 
+	// ErrAccFrozen is returned if an account that was frozen tries to
+	// send a payment.
+	ErrAccFrozen = errors.New("account is frozen pending review")
+
+	// ErrInvoiceAmountOutOfRange is returned if an account tries to create
+	// an invoice with an amount outside the limits of the account.
+	ErrInvoiceAmountOutOfRange = errors.New("invoice amount out of range")
+
+	// ErrFeeLimitExceeded is returned if an account tries to send a
+	// payment with a fee limit above the maximum fee of the account.
+	ErrFeeLimitExceeded = errors.New("fee limit exceeds account maximum")
+
 	// ErrAccBalanceInsufficient is returned if the amount required to
 	// perform a certain action is larger than the current balance of the
 	// account
 	ErrAccBalanceInsufficient = errors.New("account balance insufficient")
 
+	// ErrKeysendBlocked is returned if an account tries to send a keysend
+	// or AMP payment without an invoice while those are blocked.
+	ErrKeysendBlocked = errors.New("keysend payments are not allowed " +
+		"for accounts")
+
 	// ErrNotSupportedWithAccounts is the error that is returned when an RPC
 	// is called that isn't supported to be handled by the account
 	// interceptor.
 	ErrNotSupportedWithAccounts = errors.New("this RPC call is not " +
 		"supported with restricted account macaroons")
 
+	// ErrServiceFlushed is returned if an account tries to send a payment
+	// while litd is shutting down.
+	ErrServiceFlushed = errors.New("litd is shutting down, no new " +
+		"payments are accepted")
+
 	// MacaroonPermissions are the permissions required for an account
 	// macaroon.
 	MacaroonPermissions = []bakery.Op{{
@@ -176,12 +495,41 @@ var (
 	}}
 )
 
+// InsufficientBalanceError is returned if the amount required to perform a
+// certain action is larger than the available balance of an account. It
+// matches ErrAccBalanceInsufficient with errors.Is.
+type InsufficientBalanceError struct {
+	// AccountID is the ID of the account.
+	AccountID AccountID
+
+	// Available is the balance of the account minus the amount of the
+	// payments that are still in flight in millisatoshis.
+	Available int64
+
+	// Required is the amount that was required.
+	Required lnwire.MilliSatoshi
+}
+
+// Error returns the error message of ErrAccBalanceInsufficient.
+//
+// NOTE: This is part of the error interface.
+func (e *InsufficientBalanceError) Error() string {
+	return ErrAccBalanceInsufficient.Error()
+}
+
+// Unwrap returns ErrAccBalanceInsufficient.
+func (e *InsufficientBalanceError) Unwrap() error {
+	return ErrAccBalanceInsufficient
+}
+
 // Store is the main account store interface.
 type Store interface {
 	// NewAccount creates a new OffChainBalanceAccount with the given
-	// balance and a randomly chosen ID.
-	NewAccount(balance lnwire.MilliSatoshi,
-		expirationDate time.Time) (*OffChainBalanceAccount, error)
+	// balance, optional label, limits, expired invoice policy and a
+	// randomly chosen ID.
+	NewAccount(balance lnwire.MilliSatoshi, expirationDate time.Time,
+		label string, limits AccountLimits,
+		policy ExpiredInvoicePolicy) (*OffChainBalanceAccount, error)
 
 	// UpdateAccount writes an account to the database, overwriting the
 	// existing one if it exists.
@@ -194,9 +542,34 @@ type Store interface {
 	// Accounts retrieves all accounts from the store and un-marshals them.
 	Accounts() ([]*OffChainBalanceAccount, error)
 
-	// RemoveAccount finds an account by its ID and removes it from the¨
-	// store.
-	RemoveAccount(id AccountID) error
+	// RemoveAccount finds an account by its ID and removes it from the
+	// store. The removal is recorded with the given actor and reason.
+	RemoveAccount(id AccountID, removedBy, reason string) error
+
+	// RemovedAccounts returns the records of all removed accounts.
+	RemovedAccounts() ([]*AccountRemoval, error)
+
+	// DeleteAccount removes an account from the store without recording
+	// the removal.
+	DeleteAccount(id AccountID) error
+
+	// UpdateAccountWithLedger writes an account to the database like
+	// UpdateAccount and appends the given entry to its ledger in the same
+	// transaction.
+	UpdateAccountWithLedger(account *OffChainBalanceAccount,
+		entry *LedgerEntry) error
+
+	// Ledger returns the ledger entries of the account with the given ID,
+	// oldest first.
+	Ledger(id AccountID) ([]*LedgerEntry, error)
+
+	// Snapshot writes a consistent copy of the whole store to the given
+	// writer.
+	Snapshot(w io.Writer) error
+
+	// Probe runs an empty read or, if write is set, an empty write
+	// transaction against the store.
+	Probe(write bool) error
 
 	// LastIndexes returns the last invoice add and settle index or
 	// ErrNoInvoiceIndexKnown if no indexes are known yet.
@@ -209,6 +582,18 @@ type Store interface {
 	Close() error
 }
 
+// ListClient is the part of lnd's Lightning client that is used to page
+// through the invoices and payments of the node.
+type ListClient interface {
+	// ListInvoices returns a page of the invoices of the node.
+	ListInvoices(ctx context.Context, in *lnrpc.ListInvoiceRequest,
+		opts ...grpc.CallOption) (*lnrpc.ListInvoiceResponse, error)
+
+	// ListPayments returns a page of the payments of the node.
+	ListPayments(ctx context.Context, in *lnrpc.ListPaymentsRequest,
+		opts ...grpc.CallOption) (*lnrpc.ListPaymentsResponse, error)
+}
+
 // Service is the main account service interface.
 type Service interface {
 	// CheckBalance ensures an account is valid and has a balance equal to
@@ -230,4 +615,33 @@ type Service interface {
 	// longer needs to be tracked. The payment is certain to never succeed,
 	// so we never need to debit the amount from the account.
 	RemovePayment(hash lntypes.Hash) error
+
+	// RecordPaymentAttempt informs the service that the given account is
+	// about to send a payment of the given amount to the given
+	// destination. The destination is nil if it isn't known. It must be
+	// called before the balance is checked for the payment.
+	RecordPaymentAttempt(id AccountID, amount lnwire.MilliSatoshi,
+		dest *route.Vertex)
+
+	// RecordPaymentFailure informs the service that the given payment of
+	// the given account failed right away, before it was tracked.
+	RecordPaymentFailure(id AccountID, hash lntypes.Hash)
+}
+
+// PaymentObserver is notified about the payment activity of accounts, for
+// example to detect unusual behavior.
+type PaymentObserver interface {
+	// PaymentAttempted is called when the given account is about to send
+	// a payment of the given amount to the given destination, before its
+	// balance is checked. The destination is nil if it isn't known and
+	// balance is the current balance of the account in millisatoshis. The
+	// service lock isn't held during the call, so the observer may freeze
+	// the account to block the payment.
+	PaymentAttempted(id AccountID, amount lnwire.MilliSatoshi,
+		dest *route.Vertex, balance int64)
+
+	// PaymentFailed is called once a payment of the given account failed.
+	// The service lock is held during the call, so the observer must not
+	// call back into the service.
+	PaymentFailed(id AccountID, hash lntypes.Hash)
 }
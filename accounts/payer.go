@@ -0,0 +1,219 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+const (
+	// payerPaymentTimeout is the maximum time lnd tries to find a route
+	// for a payment made by the Payer.
+	payerPaymentTimeout = time.Minute
+)
+
+var (
+	// ErrInvalidInvoice is returned by the Payer if the invoice to pay is
+	// invalid or can't be paid with the given amount.
+	ErrInvalidInvoice = errors.New("invalid invoice")
+
+	// ErrPaymentFailed is returned by the Payer if lnd failed to make the
+	// payment.
+	ErrPaymentFailed = errors.New("payment failed")
+
+	// ErrLockdown is returned by the Payer while the lockdown mode of litd
+	// is enabled.
+	ErrLockdown = errors.New("litd is in lockdown mode, account " +
+		"services are unavailable")
+)
+
+// LockdownChecker reports if the lockdown mode of litd is enabled.
+type LockdownChecker interface {
+	// Active returns true if the lockdown mode is enabled.
+	Active() bool
+}
+
+// PaymentResult is the final outcome of a payment made by the Payer.
+type PaymentResult struct {
+	// Preimage is the preimage of the payment if it succeeded.
+	Preimage lntypes.Preimage
+
+	// Err is the reason the payment failed, nil if it succeeded.
+	Err error
+}
+
+// Payer pays invoices on behalf of accounts for litd internal services that
+// don't go through the RPC interception, for example Nostr Wallet Connect or
+// LNURL-withdraw. The payments are checked and debited the same way as the
+// payments made with an account macaroon.
+//
+// Just like the account RPCs, no payments are made while the lockdown mode is
+// enabled.
+type Payer struct {
+	service     Service
+	router      lndclient.RouterClient
+	chainParams *chaincfg.Params
+	lockdown    LockdownChecker
+
+	// mu serializes the payments so that the balance check and the
+	// tracking of a payment by the account service can't interleave with
+	// another payment from the same account.
+	mu sync.Mutex
+}
+
+// NewPayer creates a new Payer. The lockdown checker can be nil if there is
+// no lockdown mode.
+func NewPayer(service Service, router lndclient.RouterClient,
+	chainParams *chaincfg.Params, lockdown LockdownChecker) *Payer {
+
+	return &Payer{
+		service:     service,
+		router:      router,
+		chainParams: chainParams,
+		lockdown:    lockdown,
+	}
+}
+
+// CheckLockdown returns ErrLockdown if the lockdown mode of litd is enabled.
+// The services that use the Payer reject all of their requests while that is
+// the case, not only the payments.
+func (p *Payer) CheckLockdown() error {
+	if p.lockdown != nil && p.lockdown.Active() {
+		return ErrLockdown
+	}
+
+	return nil
+}
+
+// PayInvoice pays the given invoice from the given account. If an amount is
+// given, it must match the amount of the invoice. PayInvoice returns as soon
+// as the payment is in flight and the account is guaranteed to be debited if
+// it succeeds. The final outcome is sent on the returned channel.
+func (p *Payer) PayInvoice(id AccountID, invoice string,
+	amt lnwire.MilliSatoshi) (<-chan PaymentResult, error) {
+
+	payReq, err := zpay32.Decode(invoice, p.chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInvoice, err)
+	}
+
+	sendReq := lndclient.SendPaymentRequest{
+		Invoice: invoice,
+		Timeout: payerPaymentTimeout,
+	}
+
+	// Invoices without an amount can't be paid through lndclient, so we
+	// only accept invoices that specify one.
+	if payReq.MilliSat == nil || *payReq.MilliSat == 0 {
+		return nil, fmt.Errorf("%w: invoice must specify an amount",
+			ErrInvalidInvoice)
+	}
+
+	if amt != 0 && amt != *payReq.MilliSat {
+		return nil, fmt.Errorf("%w: amount %v doesn't match invoice "+
+			"amount %v", ErrInvalidInvoice, amt, *payReq.MilliSat)
+	}
+	amt = *payReq.MilliSat
+
+	// We reserve the maximum routing fee in addition to the amount, the
+	// same way payments through the account interceptor are checked.
+	feeLimit := lnrpc.CalculateFeeLimit(nil, amt)
+	sendReq.MaxFeeMsat = feeLimit
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The lockdown mode is checked while holding the mutex, so no payment
+	// is started after the lockdown mode was enabled and the payments that
+	// are already being started finished.
+	if err := p.CheckLockdown(); err != nil {
+		return nil, err
+	}
+
+	dest := route.NewVertex(payReq.Destination)
+	p.service.RecordPaymentAttempt(id, amt+feeLimit, &dest)
+	if err := p.service.CheckBalance(id, amt+feeLimit); err != nil {
+		return nil, err
+	}
+
+	// The payment continues in lnd regardless of the caller's context,
+	// so we only stop waiting for updates once it reached a final state.
+	sendCtx, cancel := context.WithCancel(context.Background())
+	statusChan, errChan, err := p.router.SendPayment(sendCtx, sendReq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Wait for the first update. As soon as the payment is known to lnd,
+	// the account service tracks it and debits the account once it
+	// succeeds. We can't give up waiting early since the payment might
+	// already be on its way.
+	var status lndclient.PaymentStatus
+	select {
+	case status = <-statusChan:
+	case err := <-errChan:
+		cancel()
+		return nil, err
+	}
+
+	if status.State == lnrpc.Payment_FAILED {
+		cancel()
+		p.service.RecordPaymentFailure(id, *payReq.PaymentHash)
+
+		return nil, fmt.Errorf("%w: %v", ErrPaymentFailed,
+			status.FailureReason)
+	}
+
+	err = p.service.TrackPayment(id, *payReq.PaymentHash, amt+feeLimit)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resultChan := make(chan PaymentResult, 1)
+	go func() {
+		defer cancel()
+
+		for {
+			switch status.State {
+			case lnrpc.Payment_SUCCEEDED:
+				resultChan <- PaymentResult{
+					Preimage: status.Preimage,
+				}
+				return
+
+			case lnrpc.Payment_FAILED:
+				resultChan <- PaymentResult{
+					Err: fmt.Errorf("%w: %v",
+						ErrPaymentFailed,
+						status.FailureReason),
+				}
+				return
+			}
+
+			select {
+			case status = <-statusChan:
+			case err := <-errChan:
+				if err == nil {
+					err = fmt.Errorf("payment stream " +
+						"closed unexpectedly")
+				}
+				resultChan <- PaymentResult{Err: err}
+				return
+			}
+		}
+	}()
+
+	return resultChan, nil
+}
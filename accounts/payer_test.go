@@ -0,0 +1,185 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPayerService is a mock account service that records the tracked
+// payments.
+type mockPayerService struct {
+	Service
+
+	balance lnwire.MilliSatoshi
+	tracked map[lntypes.Hash]lnwire.MilliSatoshi
+}
+
+func (m *mockPayerService) CheckBalance(_ AccountID,
+	requiredBalance lnwire.MilliSatoshi) error {
+
+	if requiredBalance > m.balance {
+		return ErrAccBalanceInsufficient
+	}
+
+	return nil
+}
+
+func (m *mockPayerService) RecordPaymentAttempt(AccountID,
+	lnwire.MilliSatoshi, *route.Vertex) {
+}
+
+func (m *mockPayerService) RecordPaymentFailure(AccountID, lntypes.Hash) {
+}
+
+func (m *mockPayerService) TrackPayment(_ AccountID, hash lntypes.Hash,
+	fullAmt lnwire.MilliSatoshi) error {
+
+	m.tracked[hash] = fullAmt
+	return nil
+}
+
+// mockPayerRouter is a mock router that replays the given payment updates.
+type mockPayerRouter struct {
+	lndclient.RouterClient
+
+	updates []lndclient.PaymentStatus
+}
+
+func (m *mockPayerRouter) SendPayment(_ context.Context,
+	_ lndclient.SendPaymentRequest) (chan lndclient.PaymentStatus,
+	chan error, error) {
+
+	statusChan := make(chan lndclient.PaymentStatus, len(m.updates))
+	for _, update := range m.updates {
+		statusChan <- update
+	}
+
+	return statusChan, make(chan error), nil
+}
+
+// newPayerTestInvoice creates a new signed invoice for the given amount.
+func newPayerTestInvoice(t *testing.T,
+	amt lnwire.MilliSatoshi) (string, lntypes.Hash) {
+
+	nodeKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	preimage := lntypes.Preimage{1, 2, 3}
+	hash := preimage.Hash()
+
+	options := []func(*zpay32.Invoice){zpay32.Description("test")}
+	if amt > 0 {
+		options = append(options, zpay32.Amount(amt))
+	}
+
+	invoice, err := zpay32.NewInvoice(
+		&chaincfg.RegressionNetParams, hash, time.Now(), options...,
+	)
+	require.NoError(t, err)
+
+	payReq, err := invoice.Encode(zpay32.MessageSigner{
+		SignCompact: func(msg []byte) ([]byte, error) {
+			return ecdsa.SignCompact(
+				nodeKey, chainhash.HashB(msg), true,
+			)
+		},
+	})
+	require.NoError(t, err)
+
+	return payReq, hash
+}
+
+// mockLockdown is a lockdown checker whose lockdown mode can be toggled.
+type mockLockdown struct {
+	active bool
+}
+
+// Active returns true if the lockdown mode is enabled.
+func (m *mockLockdown) Active() bool {
+	return m.active
+}
+
+// TestPayer tests that the payer checks the balance of the account and
+// tracks in-flight payments with the account service.
+func TestPayer(t *testing.T) {
+	t.Parallel()
+
+	service := &mockPayerService{
+		balance: 200_000,
+		tracked: make(map[lntypes.Hash]lnwire.MilliSatoshi),
+	}
+	router := &mockPayerRouter{}
+	lockdown := &mockLockdown{}
+	payer := NewPayer(
+		service, router, &chaincfg.RegressionNetParams, lockdown,
+	)
+
+	id := AccountID{1}
+	invoice, hash := newPayerTestInvoice(t, 100_000)
+
+	// An amount that doesn't match the invoice is rejected.
+	_, err := payer.PayInvoice(id, invoice, 1)
+	require.ErrorIs(t, err, ErrInvalidInvoice)
+
+	// Invoices without an amount are not supported.
+	noAmtInvoice, _ := newPayerTestInvoice(t, 0)
+	_, err = payer.PayInvoice(id, noAmtInvoice, 0)
+	require.ErrorIs(t, err, ErrInvalidInvoice)
+
+	// The balance must cover the amount and the maximum routing fee.
+	bigInvoice, _ := newPayerTestInvoice(t, 200_000)
+	_, err = payer.PayInvoice(id, bigInvoice, 0)
+	require.ErrorIs(t, err, ErrAccBalanceInsufficient)
+
+	// No payments are made while the lockdown mode is enabled.
+	lockdown.active = true
+	_, err = payer.PayInvoice(id, invoice, 0)
+	require.ErrorIs(t, err, ErrLockdown)
+	require.Empty(t, service.tracked)
+	lockdown.active = false
+
+	// A payment that fails right away isn't tracked.
+	router.updates = []lndclient.PaymentStatus{{
+		State: lnrpc.Payment_FAILED,
+	}}
+	_, err = payer.PayInvoice(id, invoice, 0)
+	require.ErrorIs(t, err, ErrPaymentFailed)
+	require.Empty(t, service.tracked)
+
+	// A successful payment is tracked with the amount and the maximum
+	// fee and the preimage is returned.
+	preimage := lntypes.Preimage{1, 2, 3}
+	router.updates = []lndclient.PaymentStatus{{
+		State: lnrpc.Payment_IN_FLIGHT,
+	}, {
+		State:    lnrpc.Payment_SUCCEEDED,
+		Preimage: preimage,
+	}}
+	resultChan, err := payer.PayInvoice(id, invoice, 100_000)
+	require.NoError(t, err)
+
+	feeLimit := lnrpc.CalculateFeeLimit(nil, 100_000)
+	require.Equal(t, 100_000+feeLimit, service.tracked[hash])
+
+	select {
+	case result := <-resultChan:
+		require.NoError(t, result.Err)
+		require.Equal(t, preimage, result.Preimage)
+
+	case <-time.After(testTimeout):
+		t.Fatalf("no payment result received")
+	}
+}
@@ -3,6 +3,7 @@ package accounts
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,10 +13,34 @@ import (
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 	"gopkg.in/macaroon.v2"
 )
 
+const (
+	// defaultMailboxServerAddr is the mailbox server that account
+	// sessions use if the request doesn't specify one.
+	defaultMailboxServerAddr = "mailbox.terminal.lightning.today:443"
+)
+
+// SessionAdder is a function that adds a new LNC session, like the AddSession
+// RPC of the Sessions service does.
+type SessionAdder func(ctx context.Context,
+	req *litrpc.AddSessionRequest) (*litrpc.AddSessionResponse, error)
+
+// Auditor identifies the callers of the RPC server and records the changes
+// they make in the audit log.
+type Auditor interface {
+	// Actor returns the identity of the caller of the request with the
+	// given context.
+	Actor(ctx context.Context) string
+
+	// Record records in the audit log that the actor called the given RPC
+	// method with the given request.
+	Record(actor, method string, req proto.Message) error
+}
+
 // RPCServer is the main server that implements the Accounts gRPC service.
 type RPCServer struct {
 	litrpc.UnimplementedAccountsServer
@@ -23,15 +48,31 @@ type RPCServer struct {
 	service *InterceptorService
 
 	superMacBaker session.MacaroonBaker
+
+	// addSession is used to create the sessions that can be requested
+	// together with a new account.
+	addSession SessionAdder
+
+	// auditor attributes the changes of accounts to their callers.
+	auditor Auditor
+
+	// simulate indicates whether the SimulateInvoice and SimulatePayment
+	// RPCs are enabled.
+	simulate bool
 }
 
-// NewRPCServer returns a new RPC server for the given service.
+// NewRPCServer returns a new RPC server for the given service. If simulate is
+// true, the RPCs that simulate invoices and payments are enabled.
 func NewRPCServer(service *InterceptorService,
-	superMacBaker session.MacaroonBaker) *RPCServer {
+	superMacBaker session.MacaroonBaker, addSession SessionAdder,
+	auditor Auditor, simulate bool) *RPCServer {
 
 	return &RPCServer{
 		service:       service,
 		superMacBaker: superMacBaker,
+		addSession:    addSession,
+		auditor:       auditor,
+		simulate:      simulate,
 	}
 }
 
@@ -46,12 +87,22 @@ func NewRPCServer(service *InterceptorService,
 // Accounts only assert a maximum amount spendable. Having a certain account
 // balance does not guarantee that the node has the channel liquidity to
 // actually spend that amount.
+//
+// If requested, an LNC session that is restricted to the new account is
+// created as well. If that fails, the account is removed again.
 func (s *RPCServer) CreateAccount(ctx context.Context,
 	req *litrpc.CreateAccountRequest) (*litrpc.CreateAccountResponse,
 	error) {
 
-	log.Infof("[createaccount] balance=%d, expiration=%d",
-		req.AccountBalance, req.ExpirationDate)
+	log.Infof("[createaccount] balance=%d, balance_msat=%d, "+
+		"expiration=%d, label=%s, session=%v", req.AccountBalance,
+		req.AccountBalanceMsat, req.ExpirationDate, req.Label,
+		req.Session != nil)
+
+	if req.Session != nil && s.addSession == nil {
+		return nil, errors.New("creating sessions together with " +
+			"accounts is not supported")
+	}
 
 	var (
 		balanceMsat    lnwire.MilliSatoshi
@@ -65,12 +116,37 @@ func (s *RPCServer) CreateAccount(ctx context.Context,
 		expirationDate = time.Unix(req.ExpirationDate, 0)
 	}
 
-	// Convert from satoshis to millisatoshis for storage.
-	balance := btcutil.Amount(req.AccountBalance)
-	balanceMsat = lnwire.NewMSatFromSatoshis(balance)
+	// Convert from satoshis to millisatoshis for storage, unless the
+	// balance was already given in millisatoshis.
+	switch {
+	case req.AccountBalanceMsat != 0 && req.AccountBalance != 0:
+		return nil, errors.New("account_balance and " +
+			"account_balance_msat can't both be set")
+
+	case req.AccountBalanceMsat != 0:
+		balanceMsat = lnwire.MilliSatoshi(req.AccountBalanceMsat)
+
+	default:
+		balance := btcutil.Amount(req.AccountBalance)
+		balanceMsat = lnwire.NewMSatFromSatoshis(balance)
+	}
+
+	var limits AccountLimits
+	if req.Limits != nil {
+		limits = unmarshalAccountLimits(req.Limits)
+	}
+
+	policy, _, err := unmarshalExpiredInvoicePolicy(
+		req.ExpiredInvoicePolicy,
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the actual account in the macaroon account store.
-	account, err := s.service.NewAccount(balanceMsat, expirationDate)
+	account, err := s.service.NewAccount(
+		balanceMsat, expirationDate, req.Label, limits, policy,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create account: %v", err)
 	}
@@ -100,18 +176,83 @@ func (s *RPCServer) CreateAccount(ctx context.Context,
 			err)
 	}
 
-	return &litrpc.CreateAccountResponse{
-		Account:  marshalAccount(account),
+	resp := &litrpc.CreateAccountResponse{
+		Account:  MarshalAccount(account),
 		Macaroon: macBytes,
+	}
+	if req.Session != nil {
+		resp.Session, err = s.addAccountSession(
+			ctx, account, req.Session,
+		)
+		if err != nil {
+			// Don't leave an account behind that the caller
+			// doesn't know about.
+			rmErr := s.service.RemoveAccount(
+				account.ID, ActorLitd, "account session "+
+					"could not be created",
+			)
+			if rmErr != nil {
+				log.Errorf("Error removing account %x after "+
+					"failed session creation: %v",
+					account.ID[:], rmErr)
+			}
+
+			return nil, fmt.Errorf("error creating account "+
+				"session: %w", err)
+		}
+	}
+
+	s.record(ctx, "/litrpc.Accounts/CreateAccount", req)
+
+	return resp, nil
+}
+
+// addAccountSession creates an LNC session that is restricted to the given
+// account.
+func (s *RPCServer) addAccountSession(ctx context.Context,
+	account *OffChainBalanceAccount,
+	req *litrpc.AccountSessionRequest) (*litrpc.AccountSession, error) {
+
+	label := req.Label
+	if label == "" {
+		label = account.Label
+	}
+
+	mailboxAddr := req.MailboxServerAddr
+	if mailboxAddr == "" {
+		mailboxAddr = defaultMailboxServerAddr
+	}
+
+	resp, err := s.addSession(ctx, &litrpc.AddSessionRequest{
+		Label:                  label,
+		SessionType:            litrpc.SessionType_TYPE_MACAROON_ACCOUNT,
+		ExpiryTimestampSeconds: req.ExpiryTimestampSeconds,
+		MailboxServerAddr:      mailboxAddr,
+		DevServer:              req.DevServer,
+		AccountId:              hex.EncodeToString(account.ID[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sess := resp.Session
+	return &litrpc.AccountSession{
+		Id:                     sess.Id,
+		Label:                  sess.Label,
+		ExpiryTimestampSeconds: sess.ExpiryTimestampSeconds,
+		MailboxServerAddr:      sess.MailboxServerAddr,
+		PairingSecretMnemonic:  sess.PairingSecretMnemonic,
+		LocalPublicKey:         sess.LocalPublicKey,
 	}, nil
 }
 
 // UpdateAccount updates an existing account in the account database.
-func (s *RPCServer) UpdateAccount(_ context.Context,
+func (s *RPCServer) UpdateAccount(ctx context.Context,
 	req *litrpc.UpdateAccountRequest) (*litrpc.Account, error) {
 
-	log.Infof("[updateaccount] id=%s, balance=%d, expiration=%d", req.Id,
-		req.AccountBalance, req.ExpirationDate)
+	log.Infof("[updateaccount] id=%s, balance=%d, balance_msat=%d, "+
+		"expiration=%d", req.Id, req.AccountBalance,
+		req.AccountBalanceMsat, req.ExpirationDate)
 
 	// Account ID is always a hex string, convert it to our account ID type.
 	var accountID AccountID
@@ -121,23 +262,55 @@ func (s *RPCServer) UpdateAccount(_ context.Context,
 	}
 	copy(accountID[:], decoded)
 
+	// The limits are only updated if new ones are given.
+	var limits *AccountLimits
+	if req.Limits != nil {
+		newLimits := unmarshalAccountLimits(req.Limits)
+		limits = &newLimits
+	}
+
+	// The policy is only updated if a new one is given.
+	var policy *ExpiredInvoicePolicy
+	newPolicy, ok, err := unmarshalExpiredInvoicePolicy(
+		req.ExpiredInvoicePolicy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		policy = &newPolicy
+	}
+
+	// The balance is stored in millisatoshis. A negative balance in
+	// satoshis signals "don't update the balance".
+	balanceMsat := req.AccountBalance
+	switch {
+	case req.AccountBalanceMsat > 0:
+		balanceMsat = req.AccountBalanceMsat
+
+	case req.AccountBalance >= 0:
+		balanceMsat = req.AccountBalance * 1000
+	}
+
 	// Ask the service to update the account.
 	account, err := s.service.UpdateAccount(
-		accountID, req.AccountBalance, req.ExpirationDate,
+		accountID, balanceMsat, req.ExpirationDate, limits, policy,
 	)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 
-	return marshalAccount(account), nil
+	s.record(ctx, "/litrpc.Accounts/UpdateAccount", req)
+
+	return MarshalAccount(account), nil
 }
 
 // ListAccounts returns all accounts that are currently stored in the account
-// database.
-func (s *RPCServer) ListAccounts(context.Context,
-	*litrpc.ListAccountsRequest) (*litrpc.ListAccountsResponse, error) {
+// database and, if requested, the records of the removed accounts.
+func (s *RPCServer) ListAccounts(_ context.Context,
+	req *litrpc.ListAccountsRequest) (*litrpc.ListAccountsResponse, error) {
 
-	log.Info("[listaccounts]")
+	log.Infof("[listaccounts] include_removed=%v", req.IncludeRemoved)
 
 	// Retrieve all accounts from the macaroon account store.
 	accts, err := s.service.Accounts()
@@ -150,20 +323,125 @@ func (s *RPCServer) ListAccounts(context.Context,
 	for i, acct := range accts {
 		acct := acct
 
-		rpcAccounts[i] = marshalAccount(acct)
+		rpcAccounts[i] = MarshalAccount(acct)
 	}
 
-	return &litrpc.ListAccountsResponse{
+	resp := &litrpc.ListAccountsResponse{
 		Accounts: rpcAccounts,
-	}, nil
+	}
+	if !req.IncludeRemoved {
+		return resp, nil
+	}
+
+	removals, err := s.service.RemovedAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list removed accounts: %v",
+			err)
+	}
+
+	resp.RemovedAccounts = make([]*litrpc.RemovedAccount, len(removals))
+	for i, removal := range removals {
+		resp.RemovedAccounts[i] = &litrpc.RemovedAccount{
+			Id:        hex.EncodeToString(removal.ID[:]),
+			Label:     removal.Label,
+			RemovedAt: removal.RemovedAt.Unix(),
+			RemovedBy: removal.RemovedBy,
+			Reason:    removal.Reason,
+		}
+	}
+
+	return resp, nil
+}
+
+// AccountInfo returns the account with the given ID or label.
+func (s *RPCServer) AccountInfo(_ context.Context,
+	req *litrpc.AccountInfoRequest) (*litrpc.Account, error) {
+
+	log.Infof("[accountinfo] id=%v, label=%v, include_ledger=%v", req.Id,
+		req.Label, req.IncludeLedger)
+
+	var account *OffChainBalanceAccount
+	switch {
+	case req.Id != "" && req.Label != "":
+		return nil, fmt.Errorf("either the ID or the label must be " +
+			"set, not both")
+
+	case req.Id != "":
+		accountID, err := ParseAccountID(req.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err = s.service.Account(*accountID)
+		if err != nil {
+			return nil, rpcError(err)
+		}
+
+	case req.Label != "":
+		accts, err := s.service.Accounts()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list accounts: %v",
+				err)
+		}
+
+		for _, acct := range accts {
+			if acct.Label != req.Label {
+				continue
+			}
+
+			if account != nil {
+				return nil, fmt.Errorf("more than one account "+
+					"with the label %s exists", req.Label)
+			}
+			account = acct
+		}
+
+		if account == nil {
+			return nil, rpcError(ErrAccNotFound)
+		}
+
+	default:
+		return nil, fmt.Errorf("either the ID or the label must be " +
+			"set")
+	}
+
+	rpcAccount := MarshalAccount(account)
+	if !req.IncludeLedger {
+		return rpcAccount, nil
+	}
+
+	ledger, err := s.service.Ledger(account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch ledger: %v", err)
+	}
+
+	rpcAccount.Ledger = make([]*litrpc.AccountLedgerEntry, len(ledger))
+	for i, entry := range ledger {
+		rpcAccount.Ledger[i] = &litrpc.AccountLedgerEntry{
+			Timestamp:   entry.Timestamp.Unix(),
+			InvoiceHash: entry.InvoiceHash[:],
+			AmountMsat:  entry.Amount,
+			BalanceMsat: entry.Balance,
+			Reason:      entry.Reason.String(),
+		}
+	}
+
+	return rpcAccount, nil
 }
 
-// RemoveAccount removes the given account from the account database.
-func (s *RPCServer) RemoveAccount(_ context.Context,
+// RemoveAccount removes the given account from the account database. The
+// caller and the optional reason are recorded with the removal.
+func (s *RPCServer) RemoveAccount(ctx context.Context,
 	req *litrpc.RemoveAccountRequest) (*litrpc.RemoveAccountResponse,
 	error) {
 
-	log.Infof("[removeaccount] id=%v", req.Id)
+	actor := ActorUnknown
+	if s.auditor != nil {
+		actor = s.auditor.Actor(ctx)
+	}
+
+	log.Infof("[removeaccount] id=%v, actor=%v, reason=%v", req.Id, actor,
+		req.Reason)
 
 	// Account ID is always a hex string, convert it to our account ID type.
 	var accountID AccountID
@@ -174,28 +452,286 @@ func (s *RPCServer) RemoveAccount(_ context.Context,
 	copy(accountID[:], decoded)
 
 	// Now remove the account.
-	err = s.service.RemoveAccount(accountID)
+	err = s.service.RemoveAccount(accountID, actor, req.Reason)
 	if err != nil {
-		return nil, fmt.Errorf("error removing account: %v", err)
+		return nil, rpcError(
+			fmt.Errorf("error removing account: %w", err),
+		)
+	}
+
+	if s.auditor != nil {
+		err := s.auditor.Record(
+			actor, "/litrpc.Accounts/RemoveAccount", req,
+		)
+		if err != nil {
+			log.Errorf("Error recording removal of account %v: %v",
+				req.Id, err)
+		}
 	}
 
 	return &litrpc.RemoveAccountResponse{}, nil
 }
 
-// marshalAccount converts an account into its RPC counterpart.
-func marshalAccount(acct *OffChainBalanceAccount) *litrpc.Account {
+// CreateInvoices creates multiple invoices for an account in one call.
+func (s *RPCServer) CreateInvoices(ctx context.Context,
+	req *litrpc.CreateInvoicesRequest) (*litrpc.CreateInvoicesResponse,
+	error) {
+
+	log.Infof("[createinvoices] id=%v, num_amounts=%d, num_invoices=%d, "+
+		"amount=%d", req.Id, len(req.Amounts), req.NumInvoices,
+		req.Amount)
+
+	accountID, err := ParseAccountID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var amounts []lnwire.MilliSatoshi
+	switch {
+	case len(req.Amounts) > 0 && req.NumInvoices > 0:
+		return nil, fmt.Errorf("cannot set both amounts and " +
+			"num_invoices")
+
+	case len(req.Amounts) > 0:
+		amounts = make([]lnwire.MilliSatoshi, len(req.Amounts))
+		for i, amt := range req.Amounts {
+			amounts[i] = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(amt),
+			)
+		}
+
+	case req.NumInvoices > MaxInvoiceBatchSize:
+		return nil, fmt.Errorf("cannot create more than %d invoices "+
+			"at once", MaxInvoiceBatchSize)
+
+	case req.NumInvoices > 0:
+		amounts = make([]lnwire.MilliSatoshi, req.NumInvoices)
+		for i := range amounts {
+			amounts[i] = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(req.Amount),
+			)
+		}
+
+	default:
+		return nil, fmt.Errorf("either amounts or num_invoices must " +
+			"be set")
+	}
+
+	invoices, err := s.service.CreateInvoices(
+		ctx, *accountID, amounts, req.Memo, req.Expiry,
+	)
+	if err != nil {
+		return nil, rpcError(
+			fmt.Errorf("error creating invoices: %w", err),
+		)
+	}
+
+	resp := &litrpc.CreateInvoicesResponse{
+		Invoices: make([]*litrpc.CreatedInvoice, len(invoices)),
+	}
+	for i, invoice := range invoices {
+		resp.Invoices[i] = &litrpc.CreatedInvoice{
+			Hash:           invoice.Hash[:],
+			PaymentRequest: invoice.PaymentRequest,
+			Amount:         uint64(invoice.Amount.ToSatoshis()),
+			AmountMsat:     uint64(invoice.Amount),
+		}
+	}
+
+	return resp, nil
+}
+
+// ErrSimulationDisabled is returned by the simulation RPCs if litd wasn't
+// started with account simulation enabled.
+var ErrSimulationDisabled = errors.New("account simulation is disabled, " +
+	"start litd with --dev.simulateaccounts to enable it")
+
+// SimulateInvoice credits an account as if an invoice of the account was
+// settled.
+func (s *RPCServer) SimulateInvoice(_ context.Context,
+	req *litrpc.SimulateInvoiceRequest) (*litrpc.SimulateInvoiceResponse,
+	error) {
+
+	log.Infof("[simulateinvoice] id=%v, amount=%d", req.Id, req.Amount)
+
+	if !s.simulate {
+		return nil, ErrSimulationDisabled
+	}
+
+	accountID, err := ParseAccountID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount == 0 {
+		return nil, fmt.Errorf("amount must be set")
+	}
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.Amount(req.Amount))
+	hash, account, err := s.service.SimulateInvoice(*accountID, amount)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &litrpc.SimulateInvoiceResponse{
+		Hash:    hash[:],
+		Account: MarshalAccount(account),
+	}, nil
+}
+
+// SimulatePayment records a payment of an account as if it was sent through
+// lnd.
+func (s *RPCServer) SimulatePayment(_ context.Context,
+	req *litrpc.SimulatePaymentRequest) (*litrpc.SimulatePaymentResponse,
+	error) {
+
+	log.Infof("[simulatepayment] id=%v, amount=%d, fee=%d, fail=%v",
+		req.Id, req.Amount, req.Fee, req.Fail)
+
+	if !s.simulate {
+		return nil, ErrSimulationDisabled
+	}
+
+	accountID, err := ParseAccountID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount == 0 {
+		return nil, fmt.Errorf("amount must be set")
+	}
+
+	hash, account, err := s.service.SimulatePayment(
+		*accountID,
+		lnwire.NewMSatFromSatoshis(btcutil.Amount(req.Amount)),
+		lnwire.NewMSatFromSatoshis(btcutil.Amount(req.Fee)), req.Fail,
+	)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	return &litrpc.SimulatePaymentResponse{
+		Hash:    hash[:],
+		Account: MarshalAccount(account),
+	}, nil
+}
+
+// FreezeAccount freezes an account so it can't send payments anymore, or
+// unfreezes it again.
+func (s *RPCServer) FreezeAccount(ctx context.Context,
+	req *litrpc.FreezeAccountRequest) (*litrpc.Account, error) {
+
+	log.Infof("[freezeaccount] id=%v, unfreeze=%v, reason=%v", req.Id,
+		req.Unfreeze, req.Reason)
+
+	accountID, err := ParseAccountID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var account *OffChainBalanceAccount
+	if req.Unfreeze {
+		account, err = s.service.UnfreezeAccount(*accountID)
+	} else {
+		account, err = s.service.FreezeAccount(*accountID, req.Reason)
+	}
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	s.record(ctx, "/litrpc.Accounts/FreezeAccount", req)
+
+	return MarshalAccount(account), nil
+}
+
+// CleanupAccounts cleans up all accounts that are due according to the
+// configured cleanup policy, or only reports them in a dry run.
+func (s *RPCServer) CleanupAccounts(ctx context.Context,
+	req *litrpc.CleanupAccountsRequest) (*litrpc.CleanupAccountsResponse,
+	error) {
+
+	log.Infof("[cleanupaccounts] dry_run=%v", req.DryRun)
+
+	accounts, err := s.service.CleanupAccounts(req.DryRun)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+
+	if !req.DryRun {
+		s.record(ctx, "/litrpc.Accounts/CleanupAccounts", req)
+	}
+
+	resp := &litrpc.CleanupAccountsResponse{
+		Accounts: make([]*litrpc.Account, len(accounts)),
+		Action:   s.service.cleanupAction,
+	}
+	for i, account := range accounts {
+		resp.Accounts[i] = MarshalAccount(account)
+	}
+
+	return resp, nil
+}
+
+// record records in the audit log that the caller of the request with the
+// given context changed an account by calling the given RPC method. Errors are
+// only logged, as the change was already made.
+func (s *RPCServer) record(ctx context.Context, method string,
+	req proto.Message) {
+
+	if s.auditor == nil {
+		return
+	}
+
+	err := s.auditor.Record(s.auditor.Actor(ctx), method, req)
+	if err != nil {
+		log.Errorf("Error recording call of %v: %v", method, err)
+	}
+}
+
+// rpcError converts the known account errors into gRPC status errors that
+// carry an ErrorDetail with a machine-readable error code. Other errors are
+// returned unchanged.
+func rpcError(err error) error {
+	var balanceErr *InsufficientBalanceError
+	switch {
+	case errors.As(err, &balanceErr):
+		return litrpc.AccountInsufficientBalanceError(
+			err.Error(), hex.EncodeToString(balanceErr.AccountID[:]),
+			balanceErr.Available, int64(balanceErr.Required),
+		)
+
+	case errors.Is(err, ErrAccNotFound):
+		return litrpc.AccountNotFoundError(err.Error())
+
+	case errors.Is(err, ErrAccExpired):
+		return litrpc.AccountExpiredError(err.Error())
+
+	default:
+		return err
+	}
+}
+
+// MarshalAccount converts an account into its RPC counterpart.
+func MarshalAccount(acct *OffChainBalanceAccount) *litrpc.Account {
 	rpcAccount := &litrpc.Account{
-		Id:             hex.EncodeToString(acct.ID[:]),
-		InitialBalance: uint64(acct.InitialBalance.ToSatoshis()),
-		CurrentBalance: acct.CurrentBalanceSats(),
-		LastUpdate:     acct.LastUpdate.Unix(),
-		ExpirationDate: int64(0),
+		Id:                 hex.EncodeToString(acct.ID[:]),
+		InitialBalance:     uint64(acct.InitialBalance.ToSatoshis()),
+		CurrentBalance:     acct.CurrentBalanceSats(),
+		InitialBalanceMsat: uint64(acct.InitialBalance),
+		CurrentBalanceMsat: acct.CurrentBalance,
+		LastUpdate:         acct.LastUpdate.Unix(),
+		ExpirationDate:     int64(0),
+		Label:              acct.Label,
 		Invoices: make(
 			[]*litrpc.AccountInvoice, 0, len(acct.Invoices),
 		),
 		Payments: make(
 			[]*litrpc.AccountPayment, 0, len(acct.Payments),
 		),
+		Limits: marshalAccountLimits(acct.Limits),
+		ExpiredInvoicePolicy: marshalExpiredInvoicePolicy(
+			acct.ExpiredInvoicePolicy,
+		),
 	}
 
 	for hash := range acct.Invoices {
@@ -206,10 +742,12 @@ func marshalAccount(acct *OffChainBalanceAccount) *litrpc.Account {
 		rpcAccount.Invoices = append(rpcAccount.Invoices, i)
 	}
 	for hash, paymentEntry := range acct.Payments {
+		fullAmount := paymentEntry.FullAmount
 		p := &litrpc.AccountPayment{
-			Hash:       make([]byte, lntypes.HashSize),
-			State:      paymentEntry.Status.String(),
-			FullAmount: int64(paymentEntry.FullAmount.ToSatoshis()),
+			Hash:           make([]byte, lntypes.HashSize),
+			State:          paymentEntry.Status.String(),
+			FullAmount:     int64(fullAmount.ToSatoshis()),
+			FullAmountMsat: int64(fullAmount),
 		}
 		copy(p.Hash, hash[:])
 		rpcAccount.Payments = append(rpcAccount.Payments, p)
@@ -219,5 +757,81 @@ func marshalAccount(acct *OffChainBalanceAccount) *litrpc.Account {
 		rpcAccount.ExpirationDate = acct.ExpirationDate.Unix()
 	}
 
+	if acct.IsFrozen() {
+		rpcAccount.Frozen = true
+		rpcAccount.FrozenAt = acct.FrozenAt.Unix()
+		rpcAccount.FrozenReason = acct.FrozenReason
+	}
+
 	return rpcAccount
 }
+
+// marshalAccountLimits converts account limits into their RPC counterpart.
+func marshalAccountLimits(limits AccountLimits) *litrpc.AccountLimits {
+	return &litrpc.AccountLimits{
+		MinInvoiceAmt: uint64(limits.MinInvoiceAmount.ToSatoshis()),
+		MaxInvoiceAmt: uint64(limits.MaxInvoiceAmount.ToSatoshis()),
+		MaxFee:        uint64(limits.MaxFee.ToSatoshis()),
+		MaxFeePercent: limits.MaxFeePercent,
+	}
+}
+
+// unmarshalAccountLimits converts RPC account limits into their internal
+// counterpart.
+func unmarshalAccountLimits(limits *litrpc.AccountLimits) AccountLimits {
+	return AccountLimits{
+		MinInvoiceAmount: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(limits.MinInvoiceAmt),
+		),
+		MaxInvoiceAmount: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(limits.MaxInvoiceAmt),
+		),
+		MaxFee: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(limits.MaxFee),
+		),
+		MaxFeePercent: limits.MaxFeePercent,
+	}
+}
+
+// marshalExpiredInvoicePolicy converts an expired invoice policy into its RPC
+// counterpart.
+func marshalExpiredInvoicePolicy(
+	policy ExpiredInvoicePolicy) litrpc.ExpiredInvoicePolicy {
+
+	switch policy {
+	case ExpiredInvoicePolicyCredit:
+		return litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_CREDIT
+
+	case ExpiredInvoicePolicyReject:
+		return litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_REJECT
+
+	default:
+		return litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_GRACE
+	}
+}
+
+// unmarshalExpiredInvoicePolicy converts an RPC expired invoice policy into
+// its internal counterpart. The second return value is false if no policy was
+// specified.
+func unmarshalExpiredInvoicePolicy(
+	policy litrpc.ExpiredInvoicePolicy) (ExpiredInvoicePolicy, bool,
+	error) {
+
+	switch policy {
+	case litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_UNSPECIFIED:
+		return ExpiredInvoicePolicyGrace, false, nil
+
+	case litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_GRACE:
+		return ExpiredInvoicePolicyGrace, true, nil
+
+	case litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_CREDIT:
+		return ExpiredInvoicePolicyCredit, true, nil
+
+	case litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_REJECT:
+		return ExpiredInvoicePolicyReject, true, nil
+
+	default:
+		return 0, false, fmt.Errorf("unknown expired invoice policy %v",
+			policy)
+	}
+}
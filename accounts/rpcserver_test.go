@@ -0,0 +1,393 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestCreateAccountWithSession tests that a session restricted to the new
+// account can be created together with the account and that the account is
+// removed again if the session can't be created.
+func TestCreateAccountWithSession(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	macBaker := func(context.Context, uint64,
+		*session.MacaroonRecipe) (string, error) {
+
+		return "0201", nil
+	}
+
+	var (
+		sessionReq *litrpc.AddSessionRequest
+		sessionErr error
+	)
+	addSession := func(_ context.Context,
+		req *litrpc.AddSessionRequest) (*litrpc.AddSessionResponse,
+		error) {
+
+		sessionReq = req
+		if sessionErr != nil {
+			return nil, sessionErr
+		}
+
+		return &litrpc.AddSessionResponse{
+			Session: &litrpc.Session{
+				Id:                     []byte{1, 2, 3, 4},
+				Label:                  req.Label,
+				ExpiryTimestampSeconds: 1234,
+				MailboxServerAddr:      req.MailboxServerAddr,
+				PairingSecretMnemonic:  "pairing phrase",
+				LocalPublicKey:         []byte{2, 3},
+			},
+		}, nil
+	}
+
+	ctx := context.Background()
+	server := NewRPCServer(service, macBaker, addSession, nil, false)
+
+	// Without a session request, no session is created.
+	resp, err := server.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalance: 5000,
+		Label:          "plain",
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Session)
+	require.Nil(t, sessionReq)
+
+	// The session defaults to the label of the account and is restricted
+	// to the account.
+	resp, err = server.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalance: 5000,
+		Label:          "onboarding",
+		Session:        &litrpc.AccountSessionRequest{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{2, 1}, resp.Macaroon)
+	require.Equal(
+		t, litrpc.SessionType_TYPE_MACAROON_ACCOUNT,
+		sessionReq.SessionType,
+	)
+	require.Equal(t, resp.Account.Id, sessionReq.AccountId)
+	require.Equal(t, &litrpc.AccountSession{
+		Id:                     []byte{1, 2, 3, 4},
+		Label:                  "onboarding",
+		ExpiryTimestampSeconds: 1234,
+		MailboxServerAddr:      defaultMailboxServerAddr,
+		PairingSecretMnemonic:  "pairing phrase",
+		LocalPublicKey:         []byte{2, 3},
+	}, resp.Session)
+
+	accounts, err := service.Accounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	// If the session can't be created, the account is removed again.
+	sessionErr = errors.New("no session for you")
+	_, err = server.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalance: 5000,
+		Session: &litrpc.AccountSessionRequest{
+			Label:             "failing",
+			MailboxServerAddr: "localhost:11110",
+		},
+	})
+	require.ErrorContains(t, err, "no session for you")
+	require.Equal(t, "failing", sessionReq.Label)
+	require.Equal(t, "localhost:11110", sessionReq.MailboxServerAddr)
+
+	accounts, err = service.Accounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	for _, acct := range accounts {
+		require.NotEqual(
+			t, sessionReq.AccountId, hex.EncodeToString(acct.ID[:]),
+		)
+	}
+
+	// Without a session adder, session requests are rejected.
+	server = NewRPCServer(service, macBaker, nil, nil, false)
+	_, err = server.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalance: 5000,
+		Session:        &litrpc.AccountSessionRequest{},
+	})
+	require.ErrorContains(t, err, "not supported")
+}
+
+// testAuditor is an Auditor that attributes all requests to the same actor
+// and keeps the recorded requests in memory.
+type testAuditor struct {
+	actor   string
+	records []string
+}
+
+func (a *testAuditor) Actor(context.Context) string {
+	return a.actor
+}
+
+func (a *testAuditor) Record(actor, method string, _ proto.Message) error {
+	a.records = append(a.records, actor+" "+method)
+	return nil
+}
+
+// TestRemoveAccountAudit tests that the caller and reason of an account
+// removal are recorded and returned when listing the removed accounts.
+func TestRemoveAccountAudit(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	acct, err := service.NewAccount(
+		5000, testExpiration, "shop", AccountLimits{},
+		ExpiredInvoicePolicyGrace,
+	)
+	require.NoError(t, err)
+	id := hex.EncodeToString(acct.ID[:])
+
+	ctx := context.Background()
+	auditor := &testAuditor{actor: "oidc:alice"}
+	server := NewRPCServer(service, nil, nil, auditor, false)
+
+	_, err = server.RemoveAccount(ctx, &litrpc.RemoveAccountRequest{
+		Id:     id,
+		Reason: "shop closed",
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t, []string{"oidc:alice /litrpc.Accounts/RemoveAccount"},
+		auditor.records,
+	)
+
+	// The removed account is only listed if requested.
+	resp, err := server.ListAccounts(ctx, &litrpc.ListAccountsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Accounts)
+	require.Empty(t, resp.RemovedAccounts)
+
+	resp, err = server.ListAccounts(ctx, &litrpc.ListAccountsRequest{
+		IncludeRemoved: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.RemovedAccounts, 1)
+
+	removed := resp.RemovedAccounts[0]
+	require.Equal(t, id, removed.Id)
+	require.Equal(t, "shop", removed.Label)
+	require.Equal(t, "oidc:alice", removed.RemovedBy)
+	require.Equal(t, "shop closed", removed.Reason)
+	require.NotZero(t, removed.RemovedAt)
+}
+
+// TestFreezeAccount tests that a frozen account can't send payments until it
+// is unfrozen again.
+func TestFreezeAccount(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	acct, err := service.NewAccount(
+		5000, testExpiration, "", AccountLimits{},
+		ExpiredInvoicePolicyGrace,
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	server := NewRPCServer(service, nil, nil, nil, true)
+	resp, err := server.FreezeAccount(ctx, &litrpc.FreezeAccountRequest{
+		Id:     hex.EncodeToString(acct.ID[:]),
+		Reason: "suspicious",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Frozen)
+	require.NotZero(t, resp.FrozenAt)
+	require.Equal(t, "suspicious", resp.FrozenReason)
+
+	_, _, err = service.SimulatePayment(acct.ID, 1000, 0, false)
+	require.ErrorIs(t, err, ErrAccFrozen)
+
+	resp, err = server.FreezeAccount(ctx, &litrpc.FreezeAccountRequest{
+		Id:       hex.EncodeToString(acct.ID[:]),
+		Unfreeze: true,
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Frozen)
+	require.Empty(t, resp.FrozenReason)
+
+	_, stored, err := service.SimulatePayment(acct.ID, 1000, 0, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 4000, stored.CurrentBalance)
+}
+
+// TestAccountChangesAudit tests that the changes of accounts are attributed
+// to their callers.
+func TestAccountChangesAudit(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	acct, err := service.NewAccount(
+		5000, testExpiration, "", AccountLimits{},
+		ExpiredInvoicePolicyGrace,
+	)
+	require.NoError(t, err)
+	id := hex.EncodeToString(acct.ID[:])
+
+	ctx := context.Background()
+	auditor := &testAuditor{actor: "ui"}
+	server := NewRPCServer(service, nil, nil, auditor, false)
+
+	_, err = server.UpdateAccount(ctx, &litrpc.UpdateAccountRequest{
+		Id:             id,
+		AccountBalance: 6000,
+		ExpirationDate: -1,
+	})
+	require.NoError(t, err)
+
+	_, err = server.FreezeAccount(ctx, &litrpc.FreezeAccountRequest{
+		Id: id,
+	})
+	require.NoError(t, err)
+
+	// Failed changes aren't recorded.
+	_, err = server.FreezeAccount(ctx, &litrpc.FreezeAccountRequest{
+		Id: "00",
+	})
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		"ui /litrpc.Accounts/UpdateAccount",
+		"ui /litrpc.Accounts/FreezeAccount",
+	}, auditor.records)
+}
+
+// TestUpdateExpiredInvoicePolicy tests that the expired invoice policy of an
+// account is only updated if a new one is given.
+func TestUpdateExpiredInvoicePolicy(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	acct, err := service.NewAccount(
+		5000, testExpiration, "", AccountLimits{},
+		ExpiredInvoicePolicyReject,
+	)
+	require.NoError(t, err)
+	id := hex.EncodeToString(acct.ID[:])
+
+	ctx := context.Background()
+	server := NewRPCServer(service, nil, nil, nil, false)
+
+	resp, err := server.UpdateAccount(ctx, &litrpc.UpdateAccountRequest{
+		Id:             id,
+		AccountBalance: -1,
+		ExpirationDate: -1,
+	})
+	require.NoError(t, err)
+	require.Equal(
+		t, litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_REJECT,
+		resp.ExpiredInvoicePolicy,
+	)
+
+	credit := litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_CREDIT
+	resp, err = server.UpdateAccount(ctx, &litrpc.UpdateAccountRequest{
+		Id:                   id,
+		AccountBalance:       -1,
+		ExpirationDate:       -1,
+		ExpiredInvoicePolicy: credit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, credit, resp.ExpiredInvoicePolicy)
+
+	stored, err := service.Account(acct.ID)
+	require.NoError(t, err)
+	require.Equal(t, ExpiredInvoicePolicyCredit, stored.ExpiredInvoicePolicy)
+}
+
+// TestAccountBalanceMsat tests that account balances can be set and are
+// returned in millisatoshis without rounding them to satoshis.
+func TestAccountBalanceMsat(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	macBaker := func(context.Context, uint64,
+		*session.MacaroonRecipe) (string, error) {
+
+		return "0201", nil
+	}
+
+	ctx := context.Background()
+	server := NewRPCServer(service, macBaker, nil, nil, false)
+
+	// The balance can't be given in both units.
+	_, err = server.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalance:     5,
+		AccountBalanceMsat: 5_500,
+	})
+	require.ErrorContains(t, err, "can't both be set")
+
+	resp, err := server.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalanceMsat: 5_500,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 5, resp.Account.InitialBalance)
+	require.EqualValues(t, 5_500, resp.Account.InitialBalanceMsat)
+	require.EqualValues(t, 5_500, resp.Account.CurrentBalanceMsat)
+
+	// A balance in millisatoshis takes precedence over one in satoshis.
+	acct, err := server.UpdateAccount(ctx, &litrpc.UpdateAccountRequest{
+		Id:                 resp.Account.Id,
+		AccountBalance:     -1,
+		AccountBalanceMsat: 7_250,
+		ExpirationDate:     -1,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, acct.CurrentBalance)
+	require.EqualValues(t, 7_250, acct.CurrentBalanceMsat)
+
+	// Balances in satoshis are still converted.
+	acct, err = server.UpdateAccount(ctx, &litrpc.UpdateAccountRequest{
+		Id:             resp.Account.Id,
+		AccountBalance: 3,
+		ExpirationDate: -1,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 3_000, acct.CurrentBalanceMsat)
+}
@@ -2,16 +2,23 @@ package accounts
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
 	"github.com/lightninglabs/lndclient"
 	invpkg "github.com/lightningnetwork/lnd/invoices"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // trackedPayment is a struct that holds all information that identifies a
@@ -29,11 +36,76 @@ type trackedPayment struct {
 	// payment and might be higher than the actual routing fee.
 	fullAmount lnwire.MilliSatoshi
 
+	// trackedSince is the time at which the service started tracking the
+	// payment.
+	trackedSince time.Time
+
 	// cancel is the context cancel function that can be called to abort the
 	// TrackPayment RPC stream.
 	cancel context.CancelFunc
 }
 
+// InFlightPayment is a payment of an account that hasn't reached a final state
+// yet.
+type InFlightPayment struct {
+	// AccountID is the ID of the account the payment was made from.
+	AccountID AccountID
+
+	// Hash is the payment hash of the payment.
+	Hash lntypes.Hash
+
+	// FullAmount is the amount of the payment including the estimated
+	// routing fee.
+	FullAmount lnwire.MilliSatoshi
+
+	// TrackedSince is the time at which the service started tracking the
+	// payment. It survives restarts, except for payments that were stored
+	// by older versions, for which it is the time of the restart.
+	TrackedSince time.Time
+}
+
+// invoiceCancelInterval is the interval in which the open invoices of expired
+// accounts are checked and canceled once they can no longer credit the
+// account.
+const invoiceCancelInterval = 10 * time.Minute
+
+const (
+	// defaultTrackRetryDelay is the delay after which the tracking of a
+	// payment is retried once its stream of updates failed. The delay
+	// doubles with every further failure.
+	defaultTrackRetryDelay = time.Second
+
+	// maxTrackRetryDelay is the maximum delay after which the tracking of
+	// a payment is retried.
+	maxTrackRetryDelay = time.Minute
+
+	// unknownPaymentGracePeriod is the time during which a newly tracked
+	// payment is not released if lnd doesn't know it. Payments that are
+	// sent to a route are tracked before they reach lnd.
+	unknownPaymentGracePeriod = time.Minute
+)
+
+// errTrackingStreamEnded is returned if the stream of updates of a payment
+// ended before the payment reached a final state.
+var errTrackingStreamEnded = errors.New("payment update stream ended")
+
+// MaxInvoiceBatchSize is the maximum number of invoices that can be created
+// for an account in one call.
+const MaxInvoiceBatchSize = 1000
+
+// BatchInvoice is an invoice that was created for an account as part of a
+// batch.
+type BatchInvoice struct {
+	// Hash is the payment hash of the invoice.
+	Hash lntypes.Hash
+
+	// PaymentRequest is the bolt11 payment request of the invoice.
+	PaymentRequest string
+
+	// Amount is the amount of the invoice.
+	Amount lnwire.MilliSatoshi
+}
+
 // InterceptorService is an account storage and interceptor for accounting based
 // macaroon balances and utility methods to manage accounts.
 type InterceptorService struct {
@@ -44,7 +116,9 @@ type InterceptorService struct {
 
 	store Store
 
-	routerClient lndclient.RouterClient
+	lightningClient lndclient.LightningClient
+	routerClient    lndclient.RouterClient
+	invoicesClient  lndclient.InvoicesClient
 
 	mainCtx       context.Context
 	contextCancel context.CancelFunc
@@ -52,12 +126,49 @@ type InterceptorService struct {
 	requestMtx sync.Mutex
 	checkers   *AccountChecker
 
+	// extensions are the checkers registered for RPC methods that aren't
+	// built in, keyed by their URI. They are guarded by requestMtx.
+	extensions map[string]mid.RoundTripChecker
+
 	currentAddIndex    uint64
 	currentSettleIndex uint64
 
 	invoiceToAccount map[lntypes.Hash]AccountID
 	pendingPayments  map[lntypes.Hash]*trackedPayment
 
+	// invoiceGracePeriod is the time after the expiration of an account
+	// with the default policy during which its settled invoices still
+	// credit the account.
+	invoiceGracePeriod time.Duration
+
+	// cleanupAfter is the time after which expired accounts without a
+	// balance are cleaned up, zero if they are never cleaned up.
+	cleanupAfter time.Duration
+
+	// cleanupAction determines whether cleaned up accounts are archived
+	// or removed without a trace.
+	cleanupAction string
+
+	// observer is notified about payment attempts and failures of
+	// accounts. It is nil if no observer was set.
+	observer PaymentObserver
+
+	// listClient is used by the account checkers to page through the
+	// invoices and payments of the node. It is nil if no client was set.
+	listClient ListClient
+
+	// onRemove is called with the ID of every account that was removed.
+	// It is nil if no callback was set.
+	onRemove func(AccountID)
+
+	// trackRetryDelay is the initial delay after which the tracking of a
+	// payment is retried once its stream of updates failed.
+	trackRetryDelay time.Duration
+
+	// flushed is set once the service was flushed on shutdown, after
+	// which no new payments are accepted.
+	flushed bool
+
 	mainErrChan chan<- error
 	wg          sync.WaitGroup
 	quit        chan struct{}
@@ -71,6 +182,13 @@ func NewService(dir string, errChan chan<- error) (*InterceptorService, error) {
 		return nil, err
 	}
 
+	return NewServiceWithStore(accountStore, errChan), nil
+}
+
+// NewServiceWithStore returns a service backed by the given account store.
+func NewServiceWithStore(accountStore *BoltStore,
+	errChan chan<- error) *InterceptorService {
+
 	mainCtx, contextCancel := context.WithCancel(context.Background())
 
 	return &InterceptorService{
@@ -79,53 +197,46 @@ func NewService(dir string, errChan chan<- error) (*InterceptorService, error) {
 		contextCancel:    contextCancel,
 		invoiceToAccount: make(map[lntypes.Hash]AccountID),
 		pendingPayments:  make(map[lntypes.Hash]*trackedPayment),
+		extensions:       make(map[string]mid.RoundTripChecker),
+		trackRetryDelay:  defaultTrackRetryDelay,
 		mainErrChan:      errChan,
 		quit:             make(chan struct{}),
-	}, nil
+	}
 }
 
-// Start starts the account service and its interceptor capability.
+// Start starts the account service and its interceptor capability. The given
+// config determines what the account checkers allow and how long invoices of
+// expired accounts still credit them. The invoices client is used to cancel
+// the open invoices of expired accounts, it can be nil to not cancel them.
 func (s *InterceptorService) Start(lightningClient lndclient.LightningClient,
-	routerClient lndclient.RouterClient, params *chaincfg.Params) error {
-
-	s.routerClient = routerClient
-	s.checkers = NewAccountChecker(s, params)
+	routerClient lndclient.RouterClient,
+	invoicesClient lndclient.InvoicesClient, params *chaincfg.Params,
+	cfg *Config) error {
 
-	// Let's first fill our cache that maps invoices to accounts, which
-	// allows us to credit an account easily once an invoice is settled. We
-	// also track payments that aren't in a final state yet.
-	existingAccounts, err := s.store.Accounts()
-	if err != nil {
-		return fmt.Errorf("error querying existing accounts: %v", err)
+	if cfg == nil {
+		cfg = DefaultConfig()
 	}
-	for _, acct := range existingAccounts {
-		acct := acct
-		for invoice := range acct.Invoices {
-			invoice := invoice
-			s.invoiceToAccount[invoice] = acct.ID
-		}
 
-		// Let's also resume tracking payments that have a last recorded
-		// state of being in-flight.
-		for hash, entry := range acct.Payments {
-			entry := entry
-			if entry.Status == lnrpc.Payment_IN_FLIGHT ||
-				entry.Status == lnrpc.Payment_UNKNOWN {
-
-				err := s.TrackPayment(
-					acct.ID, hash, entry.FullAmount,
-				)
-				if err != nil {
-					return fmt.Errorf("error tracking "+
-						"payment: %v", err)
-				}
-			}
-		}
+	s.lightningClient = lightningClient
+	s.routerClient = routerClient
+	s.invoicesClient = invoicesClient
+	s.invoiceGracePeriod = cfg.InvoiceGracePeriod
+	s.cleanupAfter = cfg.CleanupAfter
+	s.cleanupAction = cfg.CleanupAction
+
+	// The registered extensions were checked against the built-in
+	// checkers on registration already, so adding them can't fail.
+	s.requestMtx.Lock()
+	s.checkers = NewAccountChecker(s, s.listClient, params, cfg)
+	for uri, checker := range s.extensions {
+		s.checkers.checkers[uri] = checker
 	}
+	s.requestMtx.Unlock()
 
 	// First ask our DB about the highest indexes we know. If this is the
 	// first startup then the ErrNoInvoiceIndexKnown error is returned, and
 	// we know we need to do a lookup.
+	var err error
 	s.currentAddIndex, s.currentSettleIndex, err = s.store.LastIndexes()
 	switch err {
 	case nil:
@@ -150,6 +261,48 @@ func (s *InterceptorService) Start(lightningClient lndclient.LightningClient,
 			err)
 	}
 
+	// Then we fill our cache that maps invoices to accounts, which allows
+	// us to credit an account easily once an invoice is settled. We also
+	// track payments that aren't in a final state yet.
+	existingAccounts, err := s.store.Accounts()
+	if err != nil {
+		return fmt.Errorf("error querying existing accounts: %v", err)
+	}
+	for _, acct := range existingAccounts {
+		acct := acct
+		err := s.trackInvoices(s.mainCtx, lightningClient, acct)
+		if err != nil {
+			return fmt.Errorf("error tracking invoices of account "+
+				"%x: %w", acct.ID[:], err)
+		}
+
+		// Let's also resume tracking payments that have a last recorded
+		// state of being in-flight. Their amount is reserved right
+		// away, the tracking itself is retried until lnd reports a
+		// final state.
+		for hash, entry := range acct.Payments {
+			if !entry.inFlight() {
+				continue
+			}
+
+			trackedSince := entry.TrackedSince
+			if trackedSince.IsZero() {
+				trackedSince = time.Now()
+			}
+
+			log.Infof("Resuming tracking of payment %v of account "+
+				"%x, in flight since %v", hash, acct.ID[:],
+				trackedSince)
+
+			s.Lock()
+			s.startTracking(
+				acct.ID, hash, entry.FullAmount, trackedSince,
+				time.Time{},
+			)
+			s.Unlock()
+		}
+	}
+
 	invoiceChan, invoiceErrChan, err := lightningClient.SubscribeInvoices(
 		s.mainCtx, lndclient.InvoiceSubscriptionRequest{
 			AddIndex:    s.currentAddIndex,
@@ -206,31 +359,210 @@ func (s *InterceptorService) Start(lightningClient lndclient.LightningClient,
 		}
 	}()
 
+	if s.invoicesClient != nil {
+		s.wg.Add(1)
+		go s.cancelInvoicesLoop()
+	}
+
+	if s.cleanupAfter > 0 {
+		s.wg.Add(1)
+		go s.cleanupLoop()
+	}
+
 	return nil
 }
 
-// NewAccount creates a new OffChainBalanceAccount with the given balance and a
-// randomly chosen ID.
+// trackInvoices looks up the invoices of the given account and tracks those
+// that can still change its balance. Settled invoices that have no credited
+// amount recorded were settled before the amounts were recorded. The amount
+// they credited back then is recorded now, so paying them again doesn't credit
+// the account a second time.
+func (s *InterceptorService) trackInvoices(ctx context.Context,
+	lightningClient lndclient.LightningClient,
+	account *OffChainBalanceAccount) error {
+
+	backfilled := false
+	for hash := range account.Invoices {
+		invoice, err := lightningClient.LookupInvoice(ctx, hash)
+		switch {
+		// An invoice that lnd doesn't know anymore can't be paid.
+		case status.Code(err) == codes.NotFound:
+			continue
+
+		case err != nil:
+			return fmt.Errorf("error looking up invoice %v: %w",
+				hash, err)
+		}
+
+		// Settlements after the last one we processed are still
+		// delivered by the invoice subscription, which credits them.
+		processed := invoice.SettleIndex != 0 &&
+			invoice.SettleIndex <= s.currentSettleIndex
+
+		_, recorded := account.InvoiceCredits[hash]
+		paid := settledAmount(invoice)
+		if processed && !recorded && paid > 0 {
+			log.Infof("Recording %v credited by invoice %v of "+
+				"account %x", paid, hash, account.ID[:])
+
+			account.InvoiceCredits[hash] = paid
+			backfilled = true
+		}
+
+		// Canceled and settled invoices can't be paid anymore. AMP
+		// invoices stay open, no matter how often they are paid.
+		switch {
+		case invoice.State == invpkg.ContractCanceled:
+			continue
+
+		case invoice.State == invpkg.ContractSettled && processed:
+			continue
+		}
+
+		s.invoiceToAccount[hash] = account.ID
+	}
+
+	if !backfilled {
+		return nil
+	}
+
+	return s.store.UpdateAccount(account)
+}
+
+// cancelInvoicesLoop periodically cancels the open invoices of expired
+// accounts that can no longer credit the account, so they can't be paid
+// anymore.
+//
+// NOTE: This method must be run in a goroutine.
+func (s *InterceptorService) cancelInvoicesLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(invoiceCancelInterval)
+	defer ticker.Stop()
+
+	for {
+		s.cancelExpiredInvoices()
+
+		select {
+		case <-ticker.C:
+
+		case <-s.mainCtx.Done():
+			return
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// cancelExpiredInvoices cancels all open invoices of accounts whose deadline
+// for crediting invoices has passed. Invoices that can't be canceled are tried
+// again the next time, unless they are settled in the meantime.
+func (s *InterceptorService) cancelExpiredInvoices() {
+	now := time.Now()
+
+	s.RLock()
+	var hashes []lntypes.Hash
+	for hash, id := range s.invoiceToAccount {
+		account, err := s.store.Account(id)
+		if err != nil {
+			continue
+		}
+
+		deadline, ok := account.InvoiceDeadline(s.invoiceGracePeriod)
+		if ok && !now.Before(deadline) {
+			hashes = append(hashes, hash)
+		}
+	}
+	s.RUnlock()
+
+	for _, hash := range hashes {
+		err := s.invoicesClient.CancelInvoice(s.mainCtx, hash)
+		if err != nil {
+			log.Debugf("Unable to cancel invoice %v of expired "+
+				"account: %v", hash, err)
+
+			continue
+		}
+
+		s.Lock()
+		acctID := s.invoiceToAccount[hash]
+		delete(s.invoiceToAccount, hash)
+		s.Unlock()
+
+		log.Infof("Canceled open invoice %v of expired account %x",
+			hash, acctID[:])
+	}
+}
+
+// SetPaymentObserver sets the observer that is notified about the payment
+// attempts and failures of all accounts. It must be called before the service
+// is started.
+func (s *InterceptorService) SetPaymentObserver(observer PaymentObserver) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.observer = observer
+}
+
+// SetListClient sets the client the account checkers use to page through the
+// invoices and payments of the node when they fill the page of a list call. It
+// must be called before the service is started.
+func (s *InterceptorService) SetListClient(client ListClient) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.listClient = client
+}
+
+// SetOnRemove sets the function that is called with the ID of every account
+// that was removed, no matter if it was archived or removed without a trace.
+// It must be called before the service is started.
+func (s *InterceptorService) SetOnRemove(onRemove func(AccountID)) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.onRemove = onRemove
+}
+
+// NewAccount creates a new OffChainBalanceAccount with the given balance,
+// optional label, limits, expired invoice policy and a randomly chosen ID.
 func (s *InterceptorService) NewAccount(balance lnwire.MilliSatoshi,
-	expirationDate time.Time) (*OffChainBalanceAccount, error) {
+	expirationDate time.Time, label string, limits AccountLimits,
+	policy ExpiredInvoicePolicy) (*OffChainBalanceAccount, error) {
+
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
 
 	s.Lock()
 	defer s.Unlock()
 
-	return s.store.NewAccount(balance, expirationDate)
+	return s.store.NewAccount(
+		balance, expirationDate, label, limits, policy,
+	)
 }
 
 // UpdateAccount writes an account to the database, overwriting the existing one
-// if it exists.
-func (s *InterceptorService) UpdateAccount(accountID AccountID, accountBalance,
-	expirationDate int64) (*OffChainBalanceAccount, error) {
+// if it exists. The balance is given in millisatoshis. The limits and the
+// expired invoice policy of the account are only updated if new ones are
+// given.
+func (s *InterceptorService) UpdateAccount(accountID AccountID,
+	accountBalanceMsat, expirationDate int64, limits *AccountLimits,
+	policy *ExpiredInvoicePolicy) (*OffChainBalanceAccount, error) {
+
+	if limits != nil {
+		if err := limits.Validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	s.Lock()
 	defer s.Unlock()
 
 	account, err := s.store.Account(accountID)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching account: %v", err)
+		return nil, fmt.Errorf("error fetching account: %w", err)
 	}
 
 	// If the expiration date was set, parse it as a unix time stamp. A
@@ -244,11 +576,18 @@ func (s *InterceptorService) UpdateAccount(accountID AccountID, accountBalance,
 		account.ExpirationDate = time.Time{}
 	}
 
-	// If the new account balance was set, parse it as millisatoshis. A
-	// value of -1 signals "don't update the balance".
-	if accountBalance >= 0 {
-		// Convert from satoshis to millisatoshis for storage.
-		account.CurrentBalance = int64(accountBalance) * 1000
+	// If the new account balance was set, store it. A negative value
+	// signals "don't update the balance".
+	if accountBalanceMsat >= 0 {
+		account.CurrentBalance = accountBalanceMsat
+	}
+
+	if limits != nil {
+		account.Limits = *limits
+	}
+
+	if policy != nil {
+		account.ExpiredInvoicePolicy = *policy
 	}
 
 	// Create the actual account in the macaroon account store.
@@ -260,6 +599,62 @@ func (s *InterceptorService) UpdateAccount(accountID AccountID, accountBalance,
 	return account, nil
 }
 
+// FreezeAccount freezes the given account with the given reason, so it can't
+// send payments until it is unfrozen. Freezing an account that is already
+// frozen keeps the original time and reason.
+func (s *InterceptorService) FreezeAccount(id AccountID,
+	reason string) (*OffChainBalanceAccount, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	account, err := s.store.Account(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.IsFrozen() {
+		return account, nil
+	}
+
+	account.FrozenAt = time.Now()
+	account.FrozenReason = reason
+	if err := s.store.UpdateAccount(account); err != nil {
+		return nil, fmt.Errorf("unable to update account: %v", err)
+	}
+
+	log.Warnf("Account %x frozen: %s", id[:], reason)
+
+	return account, nil
+}
+
+// UnfreezeAccount unfreezes the given account, so it can send payments again.
+func (s *InterceptorService) UnfreezeAccount(
+	id AccountID) (*OffChainBalanceAccount, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	account, err := s.store.Account(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.IsFrozen() {
+		return account, nil
+	}
+
+	account.FrozenAt = time.Time{}
+	account.FrozenReason = ""
+	if err := s.store.UpdateAccount(account); err != nil {
+		return nil, fmt.Errorf("unable to update account: %v", err)
+	}
+
+	log.Infof("Account %x unfrozen", id[:])
+
+	return account, nil
+}
+
 // Account retrieves an account from the bolt DB and un-marshals it. If the
 // account cannot be found, then ErrAccNotFound is returned.
 func (s *InterceptorService) Account(id AccountID) (*OffChainBalanceAccount,
@@ -271,6 +666,33 @@ func (s *InterceptorService) Account(id AccountID) (*OffChainBalanceAccount,
 	return s.store.Account(id)
 }
 
+// Ledger returns the ledger entries of the account with the given ID, oldest
+// first.
+func (s *InterceptorService) Ledger(id AccountID) ([]*LedgerEntry, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.store.Ledger(id)
+}
+
+// Snapshot writes a consistent copy of the whole account store to the given
+// writer.
+func (s *InterceptorService) Snapshot(w io.Writer) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.store.Snapshot(w)
+}
+
+// Probe runs an empty read or, if write is set, an empty write transaction
+// against the account store.
+func (s *InterceptorService) Probe(write bool) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.store.Probe(write)
+}
+
 // Accounts retrieves all accounts from the bolt DB and un-marshals them.
 func (s *InterceptorService) Accounts() ([]*OffChainBalanceAccount, error) {
 	s.RLock()
@@ -279,11 +701,24 @@ func (s *InterceptorService) Accounts() ([]*OffChainBalanceAccount, error) {
 	return s.store.Accounts()
 }
 
-// RemoveAccount finds an account by its ID and removes it from the DB.
-func (s *InterceptorService) RemoveAccount(id AccountID) error {
+// RemoveAccount finds an account by its ID and removes it from the DB. The
+// removal is recorded with the given actor and reason.
+func (s *InterceptorService) RemoveAccount(id AccountID, removedBy,
+	reason string) error {
+
 	s.Lock()
 	defer s.Unlock()
 
+	return s.removeAccount(id, removedBy, reason)
+}
+
+// removeAccount removes the account with the given ID from the DB and records
+// the removal with the given actor and reason.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *InterceptorService) removeAccount(id AccountID, removedBy,
+	reason string) error {
+
 	// Are we currently tracking any payments?
 	for hash, payment := range s.pendingPayments {
 		if payment.accountID != id {
@@ -297,7 +732,38 @@ func (s *InterceptorService) RemoveAccount(id AccountID) error {
 		}
 	}
 
-	return s.store.RemoveAccount(id)
+	err := s.store.RemoveAccount(id, removedBy, reason)
+	if err != nil {
+		return err
+	}
+
+	s.forgetAccount(id)
+
+	return nil
+}
+
+// forgetAccount stops tracking the invoices of the account with the given ID
+// after it was removed and notifies the removal callback.
+//
+// NOTE: The mutex must be held when calling this method.
+func (s *InterceptorService) forgetAccount(id AccountID) {
+	for hash, acctID := range s.invoiceToAccount {
+		if acctID == id {
+			delete(s.invoiceToAccount, hash)
+		}
+	}
+
+	if s.onRemove != nil {
+		s.onRemove(id)
+	}
+}
+
+// RemovedAccounts returns the records of all removed accounts.
+func (s *InterceptorService) RemovedAccounts() ([]*AccountRemoval, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.store.RemovedAccounts()
 }
 
 // CheckBalance ensures an account is valid and has a balance equal to or larger
@@ -308,6 +774,10 @@ func (s *InterceptorService) CheckBalance(id AccountID,
 	s.RLock()
 	defer s.RUnlock()
 
+	if s.flushed {
+		return ErrServiceFlushed
+	}
+
 	// Check that the account exists, it hasn't expired and has sufficient
 	// balance.
 	account, err := s.store.Account(id)
@@ -319,6 +789,10 @@ func (s *InterceptorService) CheckBalance(id AccountID,
 		return ErrAccExpired
 	}
 
+	if account.IsFrozen() {
+		return ErrAccFrozen
+	}
+
 	var inFlightAmt int64
 	for _, pendingPayment := range s.pendingPayments {
 		inFlightAmt += int64(pendingPayment.fullAmount)
@@ -326,12 +800,55 @@ func (s *InterceptorService) CheckBalance(id AccountID,
 
 	availableAmount := account.CurrentBalance - inFlightAmt
 	if availableAmount < int64(requiredBalance) {
-		return ErrAccBalanceInsufficient
+		return &InsufficientBalanceError{
+			AccountID: id,
+			Available: availableAmount,
+			Required:  requiredBalance,
+		}
 	}
 
 	return nil
 }
 
+// RecordPaymentAttempt informs the service that the given account is about to
+// send a payment of the given amount to the given destination. The payment
+// observer, if any, is notified without holding the service lock. Attempts of
+// frozen accounts are rejected anyway, so the observer isn't notified about
+// them.
+func (s *InterceptorService) RecordPaymentAttempt(id AccountID,
+	amount lnwire.MilliSatoshi, dest *route.Vertex) {
+
+	s.RLock()
+	observer := s.observer
+	account, err := s.store.Account(id)
+	s.RUnlock()
+
+	if observer == nil || err != nil || account.IsFrozen() {
+		return
+	}
+
+	observer.PaymentAttempted(id, amount, dest, account.CurrentBalance)
+}
+
+// InFlightPayments returns all account payments that are currently tracked
+// because they haven't reached a final state yet.
+func (s *InterceptorService) InFlightPayments() []InFlightPayment {
+	s.RLock()
+	defer s.RUnlock()
+
+	payments := make([]InFlightPayment, 0, len(s.pendingPayments))
+	for _, p := range s.pendingPayments {
+		payments = append(payments, InFlightPayment{
+			AccountID:    p.accountID,
+			Hash:         p.hash,
+			FullAmount:   p.fullAmount,
+			TrackedSince: p.trackedSince,
+		})
+	}
+
+	return payments
+}
+
 // AssociateInvoice associates a generated invoice with the given account,
 // making it possible for the account to be credited in case the invoice is
 // paid.
@@ -351,8 +868,103 @@ func (s *InterceptorService) AssociateInvoice(id AccountID,
 	return s.store.UpdateAccount(account)
 }
 
-// invoiceUpdate credits the account an invoice was registered with, in case the
-// invoice was settled.
+// CreateInvoices creates an invoice for each of the given amounts and
+// associates all of them with the given account. The invoices are only
+// associated once all of them were created, so either all or none of them
+// credit the account when they are settled. If the batch fails, the invoices
+// that were already created are canceled, so they can't be paid without
+// crediting the account.
+func (s *InterceptorService) CreateInvoices(ctx context.Context, id AccountID,
+	amounts []lnwire.MilliSatoshi, memo string,
+	expiry int64) ([]*BatchInvoice, error) {
+
+	if len(amounts) == 0 {
+		return nil, fmt.Errorf("no invoice amounts specified")
+	}
+	if len(amounts) > MaxInvoiceBatchSize {
+		return nil, fmt.Errorf("cannot create more than %d invoices "+
+			"at once", MaxInvoiceBatchSize)
+	}
+
+	account, err := s.Account(id)
+	if err != nil {
+		return nil, err
+	}
+	if account.HasExpired() {
+		return nil, ErrAccExpired
+	}
+
+	invoices := make([]*BatchInvoice, len(amounts))
+	for i, amt := range amounts {
+		hash, payReq, err := s.lightningClient.AddInvoice(
+			ctx, &invoicesrpc.AddInvoiceData{
+				Memo:   memo,
+				Value:  amt,
+				Expiry: expiry,
+			},
+		)
+		if err != nil {
+			s.cancelBatchInvoices(invoices[:i])
+
+			return nil, fmt.Errorf("error creating invoice %d: %v",
+				i, err)
+		}
+
+		invoices[i] = &BatchInvoice{
+			Hash:           hash,
+			PaymentRequest: payReq,
+			Amount:         amt,
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// The account might have been removed while we were creating the
+	// invoices, so we fetch it again before storing all invoices in a
+	// single update.
+	account, err = s.store.Account(id)
+	if err != nil {
+		s.cancelBatchInvoices(invoices)
+
+		return nil, err
+	}
+
+	for _, invoice := range invoices {
+		account.Invoices[invoice.Hash] = struct{}{}
+	}
+	if err := s.store.UpdateAccount(account); err != nil {
+		s.cancelBatchInvoices(invoices)
+
+		return nil, err
+	}
+
+	for _, invoice := range invoices {
+		s.invoiceToAccount[invoice.Hash] = id
+	}
+
+	return invoices, nil
+}
+
+// cancelBatchInvoices cancels the given invoices of a batch that failed. The
+// invoices were never handed out, so they can't have been paid yet.
+func (s *InterceptorService) cancelBatchInvoices(invoices []*BatchInvoice) {
+	if s.invoicesClient == nil {
+		return
+	}
+
+	for _, invoice := range invoices {
+		err := s.invoicesClient.CancelInvoice(s.mainCtx, invoice.Hash)
+		if err != nil {
+			log.Errorf("Unable to cancel invoice %v of failed "+
+				"batch: %v", invoice.Hash, err)
+		}
+	}
+}
+
+// invoiceUpdate adjusts the balance of the account an invoice was registered
+// with, in case the amount paid to the invoice changed. Settled payments credit
+// the account, canceling an invoice debits what it credited before.
 func (s *InterceptorService) invoiceUpdate(invoice *lndclient.Invoice) error {
 	s.Lock()
 	defer s.Unlock()
@@ -379,14 +991,8 @@ func (s *InterceptorService) invoiceUpdate(invoice *lndclient.Invoice) error {
 		}
 	}
 
-	// The invoice hasn't been settled yet, there is nothing for us to do.
-	// If it eventually settles, we'll be called again.
-	if invoice.State != invpkg.ContractSettled {
-		return nil
-	}
-
-	// The invoice was settled, let's now credit the account. But only if
-	// the invoice actually belongs to an account that we track.
+	// Only invoices that belong to an account that we track are of
+	// interest to us.
 	acctID, ok := s.invoiceToAccount[invoice.Hash]
 	if !ok {
 		return nil
@@ -397,21 +1003,111 @@ func (s *InterceptorService) invoiceUpdate(invoice *lndclient.Invoice) error {
 		return fmt.Errorf("error fetching account: %v", err)
 	}
 
-	// If we get here, the current account has the invoice associated with
-	// it that was just paid. Credit the amount to the account and update it
-	// in the DB.
-	account.CurrentBalance += int64(invoice.AmountPaid)
-	if err := s.store.UpdateAccount(account); err != nil {
-		return fmt.Errorf("error updating account: %v", err)
+	// The account is credited with everything that was paid to the
+	// invoice so far. This is more than the first payment for AMP invoices
+	// that are paid more than once. An invoice that already credited the
+	// account but was canceled afterwards is debited again.
+	credited := account.InvoiceCredits[invoice.Hash]
+	paid := settledAmount(invoice)
+
+	// We don't need to track the invoice anymore once it was canceled or
+	// settled, as it can't be paid again. AMP invoices stay open, no
+	// matter how often they are paid.
+	if invoice.State == invpkg.ContractCanceled ||
+		invoice.State == invpkg.ContractSettled {
+
+		delete(s.invoiceToAccount, invoice.Hash)
+	}
+
+	var (
+		amount = int64(paid) - int64(credited)
+		reason = LedgerReasonInvoiceSettled
+	)
+	switch {
+	// Nothing changed since we last processed the invoice.
+	case amount == 0:
+		return nil
+
+	case amount < 0:
+		reason = LedgerReasonInvoiceCanceled
+
+	// Invoices can only be created before the account expires. If one of
+	// them is settled too long after that, depending on the policy of the
+	// account, it doesn't credit the account anymore.
+	default:
+		settleDate := invoice.SettleDate
+		if settleDate.IsZero() {
+			settleDate = time.Now()
+		}
+		if !account.CreditsInvoice(settleDate, s.invoiceGracePeriod) {
+			log.Warnf("Invoice %v of account %x was settled for "+
+				"%v after the account expired at %v and its "+
+				"grace period ended (policy %v), not "+
+				"crediting the account", invoice.Hash,
+				acctID[:], invoice.AmountPaid,
+				account.ExpirationDate,
+				account.ExpiredInvoicePolicy)
+
+			delete(s.invoiceToAccount, invoice.Hash)
+
+			return nil
+		}
+	}
+
+	// If we get here, the amount paid to the invoice changed. Adjust the
+	// account balance by the difference and record the adjustment in the
+	// ledger of the account.
+	account.CurrentBalance += amount
+	account.InvoiceCredits[invoice.Hash] = paid
+	if paid == 0 {
+		delete(account.InvoiceCredits, invoice.Hash)
 	}
 
-	// We've now fully processed the invoice and don't need to keep it
-	// mapped in memory anymore.
-	delete(s.invoiceToAccount, invoice.Hash)
+	log.Debugf("Adjusting balance of account %x by %d msat for invoice "+
+		"%v (%v)", acctID[:], amount, invoice.Hash, reason)
+
+	err = s.store.UpdateAccountWithLedger(account, &LedgerEntry{
+		Timestamp:   time.Now(),
+		InvoiceHash: invoice.Hash,
+		Amount:      amount,
+		Balance:     account.CurrentBalance,
+		Reason:      reason,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating account: %v", err)
+	}
 
 	return nil
 }
 
+// settledAmount returns the amount that was paid to the given invoice with
+// settled HTLCs. Canceled invoices were never paid, even if some of their
+// HTLCs were settled before, for example by a partially settled MPP payment.
+func settledAmount(invoice *lndclient.Invoice) lnwire.MilliSatoshi {
+	switch {
+	case invoice.State == invpkg.ContractCanceled:
+		return 0
+
+	// Without any HTLC information we can only rely on the amount paid to
+	// a settled invoice.
+	case len(invoice.Htlcs) == 0:
+		if invoice.State == invpkg.ContractSettled {
+			return invoice.AmountPaid
+		}
+
+		return 0
+	}
+
+	var paid lnwire.MilliSatoshi
+	for _, htlc := range invoice.Htlcs {
+		if htlc.State == lnrpc.InvoiceHTLCState_SETTLED {
+			paid += htlc.Amount
+		}
+	}
+
+	return paid
+}
+
 // TrackPayment adds a new payment to be tracked to the service. If the payment
 // is eventually settled, its amount needs to be debited from the given account.
 func (s *InterceptorService) TrackPayment(id AccountID, hash lntypes.Hash,
@@ -443,76 +1139,162 @@ func (s *InterceptorService) TrackPayment(id AccountID, hash lntypes.Hash,
 	}
 
 	// Okay, we haven't tracked this payment before. So let's now associate
-	// the account with it.
+	// the account with it. The time at which we started tracking is
+	// stored as well, so that it survives a restart.
+	now := time.Now()
 	account.Payments[hash] = &PaymentEntry{
-		Status:     lnrpc.Payment_UNKNOWN,
-		FullAmount: fullAmt,
+		Status:       lnrpc.Payment_UNKNOWN,
+		FullAmount:   fullAmt,
+		TrackedSince: now,
 	}
 	if err := s.store.UpdateAccount(account); err != nil {
 		return fmt.Errorf("error updating account: %v", err)
 	}
 
-	// And start the long-running TrackPayment RPC.
-	ctxc, cancel := context.WithCancel(s.mainCtx)
-	statusChan, errChan, err := s.routerClient.TrackPayment(ctxc, hash)
-	if err != nil {
-		cancel()
-		return err
-	}
+	s.startTracking(
+		id, hash, fullAmt, now, now.Add(unknownPaymentGracePeriod),
+	)
+
+	return nil
+}
+
+// startTracking reserves the amount of the given in-flight payment and starts
+// the long-running TrackPayment RPC for it. If lnd doesn't know the payment,
+// it is only released after the given time.
+//
+// NOTE: The store lock MUST be held when calling this method.
+func (s *InterceptorService) startTracking(id AccountID, hash lntypes.Hash,
+	fullAmt lnwire.MilliSatoshi, trackedSince,
+	releaseUnknownAfter time.Time) {
 
-	// We're now tracking the call, store everything we need to be able to
-	// cancel the streaming RPC.
+	// We store everything we need to be able to cancel the streaming RPC.
+	ctxc, cancel := context.WithCancel(s.mainCtx)
 	s.pendingPayments[hash] = &trackedPayment{
-		accountID:  id,
-		hash:       hash,
-		fullAmount: fullAmt,
-		cancel:     cancel,
+		accountID:    id,
+		hash:         hash,
+		fullAmount:   fullAmt,
+		trackedSince: trackedSince,
+		cancel:       cancel,
 	}
 
 	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		defer cancel()
+	go s.trackPayment(ctxc, cancel, hash, releaseUnknownAfter)
+}
 
-		for {
-			select {
-			case paymentUpdate := <-statusChan:
-				terminalState, err := s.paymentUpdate(
-					hash, paymentUpdate,
-				)
-				if err != nil {
-					select {
-					case s.mainErrChan <- err:
-					case <-s.mainCtx.Done():
-					case <-s.quit:
-					}
-					return
-				}
+// trackPayment follows the updates of the given payment until it reaches a
+// final state. If the stream of updates fails, for example because lnd
+// restarted, it is subscribed again after a delay that doubles with every
+// failure. That way, every payment is eventually debited from its account or
+// released, no matter how often the connection to lnd is lost.
+//
+// NOTE: This method must be run in a goroutine.
+func (s *InterceptorService) trackPayment(ctx context.Context,
+	cancel context.CancelFunc, hash lntypes.Hash,
+	releaseUnknownAfter time.Time) {
+
+	defer s.wg.Done()
+	defer cancel()
+
+	retryDelay := s.trackRetryDelay
+	for {
+		done, err := s.followPayment(ctx, hash)
+		if done {
+			return
+		}
 
-				if terminalState {
-					return
-				}
+		// If lnd doesn't know the payment at all, it was never sent,
+		// so it can't succeed anymore either. We release its amount
+		// instead of retrying forever. A payment that was just tracked
+		// might not have reached lnd yet though.
+		if status.Code(err) == codes.NotFound &&
+			time.Now().After(releaseUnknownAfter) {
+
+			log.Warnf("Payment %v is unknown to lnd, releasing "+
+				"its amount: %v", hash, err)
+
+			s.Lock()
+			err := s.removePayment(hash, lnrpc.Payment_FAILED)
+			s.Unlock()
+			if err != nil {
+				s.sendMainErr(err)
+			}
 
-			case err := <-errChan:
-				if err != nil {
-					select {
-					case s.mainErrChan <- err:
-					case <-s.mainCtx.Done():
-					case <-s.quit:
-					}
-				}
-				return
+			return
+		}
 
-			case <-ctxc.Done():
-				return
+		log.Warnf("Error tracking payment %v, retrying in %v: %v",
+			hash, retryDelay, err)
 
-			case <-s.quit:
-				return
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+			return
+		case <-s.quit:
+			return
+		}
+
+		retryDelay *= 2
+		if retryDelay > maxTrackRetryDelay {
+			retryDelay = maxTrackRetryDelay
+		}
+	}
+}
+
+// followPayment subscribes to the updates of the given payment and processes
+// them. It returns true if no further updates need to be followed, either
+// because the payment reached a final state or because the service is
+// shutting down. Otherwise, it returns the error that ended the subscription.
+func (s *InterceptorService) followPayment(ctx context.Context,
+	hash lntypes.Hash) (bool, error) {
+
+	statusChan, errChan, err := s.routerClient.TrackPayment(ctx, hash)
+	if err != nil {
+		return ctx.Err() != nil, err
+	}
+
+	for {
+		select {
+		case paymentUpdate, ok := <-statusChan:
+			if !ok {
+				return false, errTrackingStreamEnded
+			}
+
+			terminalState, err := s.paymentUpdate(
+				hash, paymentUpdate,
+			)
+			if err != nil {
+				s.sendMainErr(err)
+				return true, nil
+			}
+
+			if terminalState {
+				return true, nil
 			}
+
+		case err, ok := <-errChan:
+			if !ok || err == nil {
+				return false, errTrackingStreamEnded
+			}
+
+			return ctx.Err() != nil, err
+
+		case <-ctx.Done():
+			return true, nil
+
+		case <-s.quit:
+			return true, nil
 		}
-	}()
+	}
+}
 
-	return nil
+// sendMainErr sends the given error to the main error channel, unless the
+// service is shutting down.
+func (s *InterceptorService) sendMainErr(err error) {
+	select {
+	case s.mainErrChan <- err:
+	case <-s.mainCtx.Done():
+	case <-s.quit:
+	}
 }
 
 // paymentUpdate debits the full amount of a payment from the account it was
@@ -546,6 +1328,8 @@ func (s *InterceptorService) paymentUpdate(hash lntypes.Hash,
 
 	// A failed payment can just be removed, no further action needed.
 	if status.State == lnrpc.Payment_FAILED {
+		s.notifyPaymentFailed(pendingPayment.accountID, hash)
+
 		return terminalState, s.removePayment(hash, status.State)
 	}
 
@@ -584,6 +1368,30 @@ func (s *InterceptorService) RemovePayment(hash lntypes.Hash) error {
 	return s.removePayment(hash, lnrpc.Payment_FAILED)
 }
 
+// RecordPaymentFailure informs the service that the given payment of the given
+// account failed right away, before the service started tracking it.
+func (s *InterceptorService) RecordPaymentFailure(id AccountID,
+	hash lntypes.Hash) {
+
+	s.RLock()
+	defer s.RUnlock()
+
+	s.notifyPaymentFailed(id, hash)
+}
+
+// notifyPaymentFailed notifies the payment observer, if any, that the given
+// payment of the given account failed.
+//
+// NOTE: The store lock MUST be held, at least for reading, when calling this
+// method.
+func (s *InterceptorService) notifyPaymentFailed(id AccountID,
+	hash lntypes.Hash) {
+
+	if s.observer != nil {
+		s.observer.PaymentFailed(id, hash)
+	}
+}
+
 // removePayment stops tracking a payment and updates the status in the account
 // to the given status.
 //
@@ -617,6 +1425,24 @@ func (s *InterceptorService) removePayment(hash lntypes.Hash,
 	return s.store.UpdateAccount(account)
 }
 
+// Flush prepares the service for the shutdown of litd. From now on, new
+// payments of accounts are rejected. The account updates that are in progress
+// complete before Flush returns. It returns the number of payments that are
+// still in flight, which are tracked again after the next start.
+func (s *InterceptorService) Flush() int {
+	s.Lock()
+	defer s.Unlock()
+
+	s.flushed = true
+	for hash, payment := range s.pendingPayments {
+		log.Infof("Payment %v of account %x is still in flight and "+
+			"will be tracked again after the restart", hash,
+			payment.accountID[:])
+	}
+
+	return len(s.pendingPayments)
+}
+
 // Stop shuts down the account service.
 func (s *InterceptorService) Stop() error {
 	s.contextCancel()
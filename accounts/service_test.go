@@ -3,14 +3,21 @@ package accounts
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/lightninglabs/lightning-terminal/litrpc"
 	"github.com/lightninglabs/lndclient"
 	invpkg "github.com/lightningnetwork/lnd/invoices"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
 	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -21,11 +28,14 @@ var (
 	testInterval   = time.Millisecond * 20
 
 	testHash2 = lntypes.Hash{99, 88, 77}
+
+	testTrackedSince = time.Unix(1_700_000_000, 0)
 )
 
 type mockLnd struct {
 	lndclient.LightningClient
 	lndclient.RouterClient
+	lndclient.InvoicesClient
 
 	mainErrChan chan error
 
@@ -33,9 +43,20 @@ type mockLnd struct {
 	paymentReq chan lntypes.Hash
 
 	callErr      error
+	cancelErr    error
+	invoices     map[lntypes.Hash]*lndclient.Invoice
+	lookupErr    error
+	canceled     chan lntypes.Hash
+	numInvoices  int
+	maxInvoices  int
 	errChan      chan error
 	invoiceChan  chan *lndclient.Invoice
 	paymentChans map[lntypes.Hash]chan lndclient.PaymentStatus
+
+	// paymentMtx guards the payment channels, which are created by the
+	// tracking goroutines of the service.
+	paymentMtx      sync.Mutex
+	paymentErrChans map[lntypes.Hash]chan error
 }
 
 func newMockLnd() *mockLnd {
@@ -47,9 +68,12 @@ func newMockLnd() *mockLnd {
 		paymentReq:  make(chan lntypes.Hash, 10),
 		errChan:     make(chan error, 10),
 		invoiceChan: make(chan *lndclient.Invoice),
+		canceled:    make(chan lntypes.Hash, 10),
+		invoices:    make(map[lntypes.Hash]*lndclient.Invoice),
 		paymentChans: make(
 			map[lntypes.Hash]chan lndclient.PaymentStatus,
 		),
+		paymentErrChans: make(map[lntypes.Hash]chan error),
 	}
 }
 
@@ -141,6 +165,51 @@ func (m *mockLnd) SubscribeInvoices(_ context.Context,
 	return m.invoiceChan, m.errChan, nil
 }
 
+// AddInvoice adds an invoice with a unique payment hash. If maxInvoices is
+// set, adding more invoices than that fails.
+func (m *mockLnd) AddInvoice(_ context.Context,
+	in *invoicesrpc.AddInvoiceData) (lntypes.Hash, string, error) {
+
+	if m.maxInvoices > 0 && m.numInvoices >= m.maxInvoices {
+		return lntypes.Hash{}, "", testErr
+	}
+
+	m.numInvoices++
+	hash := lntypes.Hash{byte(m.numInvoices), 1, 2, 3}
+
+	return hash, fmt.Sprintf("lnbcrt%d", in.Value), nil
+}
+
+// LookupInvoice looks up an invoice by its hash. Invoices that weren't added to
+// the mock are open.
+func (m *mockLnd) LookupInvoice(_ context.Context,
+	hash lntypes.Hash) (*lndclient.Invoice, error) {
+
+	if m.lookupErr != nil {
+		return nil, m.lookupErr
+	}
+
+	if invoice, ok := m.invoices[hash]; ok {
+		return invoice, nil
+	}
+
+	return &lndclient.Invoice{
+		Hash:  hash,
+		State: invpkg.ContractOpen,
+	}, nil
+}
+
+// CancelInvoice cancels an open invoice.
+func (m *mockLnd) CancelInvoice(_ context.Context, hash lntypes.Hash) error {
+	if m.cancelErr != nil {
+		return m.cancelErr
+	}
+
+	m.canceled <- hash
+
+	return nil
+}
+
 // TrackPayment picks up a previously started payment and returns a payment
 // update stream and an error stream.
 func (m *mockLnd) TrackPayment(_ context.Context,
@@ -150,10 +219,38 @@ func (m *mockLnd) TrackPayment(_ context.Context,
 		return nil, nil, m.callErr
 	}
 
+	statusChan, errChan := m.paymentChan(hash), m.paymentErrChan(hash)
 	m.paymentReq <- hash
-	m.paymentChans[hash] = make(chan lndclient.PaymentStatus, 1)
 
-	return m.paymentChans[hash], m.errChan, nil
+	return statusChan, errChan, nil
+}
+
+// paymentChan returns the channel the updates of the given payment are sent
+// on.
+func (m *mockLnd) paymentChan(
+	hash lntypes.Hash) chan lndclient.PaymentStatus {
+
+	m.paymentMtx.Lock()
+	defer m.paymentMtx.Unlock()
+
+	if _, ok := m.paymentChans[hash]; !ok {
+		m.paymentChans[hash] = make(chan lndclient.PaymentStatus, 1)
+	}
+
+	return m.paymentChans[hash]
+}
+
+// paymentErrChan returns the channel the errors of the update stream of the
+// given payment are sent on.
+func (m *mockLnd) paymentErrChan(hash lntypes.Hash) chan error {
+	m.paymentMtx.Lock()
+	defer m.paymentMtx.Unlock()
+
+	if _, ok := m.paymentErrChans[hash]; !ok {
+		m.paymentErrChans[hash] = make(chan error, 1)
+	}
+
+	return m.paymentErrChans[hash]
 }
 
 // TestAccountService tests that the account service can track payments and
@@ -169,6 +266,90 @@ func TestAccountService(t *testing.T) {
 		validate   func(t *testing.T, lnd *mockLnd,
 			s *InterceptorService)
 	}{{
+		name: "create invoices in batch",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			_, err := s.store.NewAccount(
+				1234, testExpiration, "", AccountLimits{},
+				ExpiredInvoicePolicyGrace,
+			)
+			require.NoError(t, err)
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 0, 0)
+
+			accts, err := s.Accounts()
+			require.NoError(t, err)
+			require.Len(t, accts, 1)
+
+			invoices, err := s.CreateInvoices(
+				context.Background(), accts[0].ID,
+				[]lnwire.MilliSatoshi{1000, 2000, 0}, "", 0,
+			)
+			require.NoError(t, err)
+			require.Len(t, invoices, 3)
+			require.Equal(
+				t, lnwire.MilliSatoshi(2000), invoices[1].Amount,
+			)
+
+			acct, err := s.Account(accts[0].ID)
+			require.NoError(t, err)
+			require.Len(t, acct.Invoices, 3)
+			for _, invoice := range invoices {
+				require.Contains(t, acct.Invoices, invoice.Hash)
+				require.Equal(
+					t, acct.ID,
+					s.invoiceToAccount[invoice.Hash],
+				)
+			}
+		},
+	}, {
+		name: "create invoices in batch fails atomically",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			_, err := s.store.NewAccount(
+				1234, testExpiration, "", AccountLimits{},
+				ExpiredInvoicePolicyGrace,
+			)
+			require.NoError(t, err)
+
+			lnd.maxInvoices = 2
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 0, 0)
+
+			accts, err := s.Accounts()
+			require.NoError(t, err)
+			require.Len(t, accts, 1)
+
+			_, err = s.CreateInvoices(
+				context.Background(), accts[0].ID,
+				[]lnwire.MilliSatoshi{1000, 2000, 3000}, "", 0,
+			)
+			require.ErrorContains(t, err, testErr.Error())
+
+			acct, err := s.Account(accts[0].ID)
+			require.NoError(t, err)
+			require.Empty(t, acct.Invoices)
+			require.Empty(t, s.invoiceToAccount)
+
+			// The invoices that were created before the failure
+			// are canceled, so they can't be paid.
+			for i := 1; i <= 2; i++ {
+				want := lntypes.Hash{byte(i), 1, 2, 3}
+
+				select {
+				case hash := <-lnd.canceled:
+					require.Equal(t, want, hash)
+
+				case <-time.After(testTimeout):
+					t.Fatalf("invoice %d not canceled", i)
+				}
+			}
+		},
+	}, {
 		name: "startup err on tracking payment",
 		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
 			lnd.callErr = testErr
@@ -206,7 +387,10 @@ func TestAccountService(t *testing.T) {
 	}, {
 		name: "startup do not track completed payments",
 		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
-			acct, err := s.store.NewAccount(1234, testExpiration)
+			acct, err := s.store.NewAccount(
+				1234, testExpiration, "", AccountLimits{},
+				ExpiredInvoicePolicyGrace,
+			)
 			require.NoError(t, err)
 
 			acct.Invoices[testHash] = struct{}{}
@@ -264,7 +448,7 @@ func TestAccountService(t *testing.T) {
 
 			// Send an actual payment update and make sure the
 			// amount is debited from the account.
-			lnd.paymentChans[testHash] <- lndclient.PaymentStatus{
+			lnd.paymentChan(testHash) <- lndclient.PaymentStatus{
 				State: lnrpc.Payment_SUCCEEDED,
 				Fee:   234,
 				Value: 1000,
@@ -280,7 +464,7 @@ func TestAccountService(t *testing.T) {
 			// Remove the other payment and make sure it disappears
 			// from the tracked payments and is also updated
 			// correctly in the account store.
-			lnd.paymentChans[testHash2] <- lndclient.PaymentStatus{
+			lnd.paymentChan(testHash2) <- lndclient.PaymentStatus{
 				State: lnrpc.Payment_FAILED,
 				Fee:   234,
 				Value: 1000,
@@ -304,6 +488,161 @@ func TestAccountService(t *testing.T) {
 
 			require.NotContains(t, s.pendingPayments, testHash2)
 		},
+	}, {
+		name: "retry tracking resumed payment after stream error",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				Invoices:       make(map[lntypes.Hash]struct{}),
+				Payments: map[lntypes.Hash]*PaymentEntry{
+					testHash: {
+						Status:       lnrpc.Payment_IN_FLIGHT,
+						FullAmount:   1234,
+						TrackedSince: testTrackedSince,
+					},
+				},
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+
+			s.trackRetryDelay = time.Millisecond
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertPaymentRequests(t, map[lntypes.Hash]struct{}{
+				testHash: {},
+			})
+
+			// The time at which the tracking started survives the
+			// restart.
+			payments := s.InFlightPayments()
+			require.Len(t, payments, 1)
+			trackedSince := payments[0].TrackedSince
+			require.True(t, testTrackedSince.Equal(trackedSince))
+
+			// A failing stream doesn't shut down litd, the payment
+			// is tracked again instead.
+			lnd.paymentErrChan(testHash) <- testErr
+			lnd.assertPaymentRequests(t, map[lntypes.Hash]struct{}{
+				testHash: {},
+			})
+			lnd.assertNoMainErr(t)
+
+			lnd.paymentChan(testHash) <- lndclient.PaymentStatus{
+				State: lnrpc.Payment_SUCCEEDED,
+				Value: 1000,
+				Fee:   234,
+			}
+
+			assertEventually(t, func() bool {
+				acct, err := s.store.Account(testID)
+				require.NoError(t, err)
+
+				return acct.CurrentBalance == 0
+			})
+		},
+	}, {
+		name: "release resumed payment unknown to lnd",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				Invoices:       make(map[lntypes.Hash]struct{}),
+				Payments: map[lntypes.Hash]*PaymentEntry{
+					testHash: {
+						Status:     lnrpc.Payment_UNKNOWN,
+						FullAmount: 1234,
+					},
+				},
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertPaymentRequests(t, map[lntypes.Hash]struct{}{
+				testHash: {},
+			})
+			require.ErrorIs(
+				t, s.CheckBalance(testID, 1),
+				ErrAccBalanceInsufficient,
+			)
+
+			lnd.paymentErrChan(testHash) <- status.Error(
+				codes.NotFound, "payment isn't initiated",
+			)
+
+			assertEventually(t, func() bool {
+				return s.CheckBalance(testID, 1234) == nil
+			})
+
+			acct, err := s.store.Account(testID)
+			require.NoError(t, err)
+			require.EqualValues(t, 1234, acct.CurrentBalance)
+			require.Equal(
+				t, lnrpc.Payment_FAILED,
+				acct.Payments[testHash].Status,
+			)
+			lnd.assertNoPaymentRequest(t)
+		},
+	}, {
+		name: "keep new payment unknown to lnd",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				Invoices:       make(map[lntypes.Hash]struct{}),
+			}
+			acct.Payments = make(map[lntypes.Hash]*PaymentEntry)
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+
+			s.trackRetryDelay = time.Millisecond
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			err := s.TrackPayment(testID, testHash, 1234)
+			require.NoError(t, err)
+			lnd.assertPaymentRequests(t, map[lntypes.Hash]struct{}{
+				testHash: {},
+			})
+
+			// A payment that was just tracked might not have
+			// reached lnd yet, so its amount stays reserved.
+			lnd.paymentErrChan(testHash) <- status.Error(
+				codes.NotFound, "payment isn't initiated",
+			)
+			lnd.assertPaymentRequests(t, map[lntypes.Hash]struct{}{
+				testHash: {},
+			})
+			require.ErrorIs(
+				t, s.CheckBalance(testID, 1),
+				ErrAccBalanceInsufficient,
+			)
+
+			lnd.paymentChan(testHash) <- lndclient.PaymentStatus{
+				State: lnrpc.Payment_SUCCEEDED,
+				Value: 1000,
+				Fee:   234,
+			}
+
+			assertEventually(t, func() bool {
+				acct, err := s.store.Account(testID)
+				require.NoError(t, err)
+
+				return acct.CurrentBalance == 0
+			})
+		},
 	}, {
 		name: "keep track of invoice indexes",
 		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
@@ -389,6 +728,418 @@ func TestAccountService(t *testing.T) {
 				return acct.CurrentBalance == (1234 + 777)
 			})
 		},
+	}, {
+		name: "adjust balance for repeated payments and cancellation",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash: {},
+				},
+				Payments: make(map[lntypes.Hash]*PaymentEntry),
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			settled := func(
+				amt lnwire.MilliSatoshi) lndclient.InvoiceHtlc {
+
+				return lndclient.InvoiceHtlc{
+					Amount: amt,
+					State:  lnrpc.InvoiceHTLCState_SETTLED,
+				}
+			}
+			assertBalance := func(balance int64) {
+				assertEventually(t, func() bool {
+					acct, err := s.store.Account(testID)
+					require.NoError(t, err)
+
+					return acct.CurrentBalance == balance
+				})
+			}
+
+			// An AMP invoice stays open while it is paid, only
+			// the settled HTLCs credit the account.
+			lnd.assertInvoiceRequest(t, 0, 0)
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    12,
+				SettleIndex: 12,
+				Hash:        testHash,
+				State:       invpkg.ContractOpen,
+				Htlcs: []lndclient.InvoiceHtlc{
+					settled(500), {
+						Amount: 100,
+						State: lnrpc.
+							InvoiceHTLCState_ACCEPTED,
+					},
+				},
+			}
+			assertBalance(1234 + 500)
+
+			// A second payment only credits the difference.
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    12,
+				SettleIndex: 13,
+				Hash:        testHash,
+				State:       invpkg.ContractOpen,
+				Htlcs: []lndclient.InvoiceHtlc{
+					settled(500), settled(300),
+				},
+			}
+			assertBalance(1234 + 800)
+
+			// Canceling the invoice debits everything it credited.
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    12,
+				SettleIndex: 13,
+				Hash:        testHash,
+				State:       invpkg.ContractCanceled,
+			}
+			assertBalance(1234)
+
+			ledger, err := s.store.Ledger(testID)
+			require.NoError(t, err)
+			require.Len(t, ledger, 3)
+
+			amounts := make([]int64, len(ledger))
+			for i, entry := range ledger {
+				amounts[i] = entry.Amount
+				require.Equal(t, testHash, entry.InvoiceHash)
+			}
+			require.Equal(t, []int64{500, 300, -800}, amounts)
+			require.EqualValues(t, 1234, ledger[2].Balance)
+			require.Equal(
+				t, LedgerReasonInvoiceCanceled, ledger[2].Reason,
+			)
+
+			acct, err := s.store.Account(testID)
+			require.NoError(t, err)
+			require.Empty(t, acct.InvoiceCredits)
+
+			s.RLock()
+			_, ok := s.invoiceToAccount[testHash]
+			s.RUnlock()
+			require.False(t, ok)
+		},
+	}, {
+		name: "settled invoices are no longer tracked",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash: {},
+				},
+				Payments: make(map[lntypes.Hash]*PaymentEntry),
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 0, 0)
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    12,
+				SettleIndex: 12,
+				Hash:        testHash,
+				AmountPaid:  777,
+				State:       invpkg.ContractSettled,
+			}
+
+			assertEventually(t, func() bool {
+				s.RLock()
+				defer s.RUnlock()
+
+				_, ok := s.invoiceToAccount[testHash]
+				return !ok
+			})
+
+			acct, err := s.store.Account(testID)
+			require.NoError(t, err)
+			require.EqualValues(t, 1234+777, acct.CurrentBalance)
+		},
+	}, {
+		name: "startup record credits of settled invoices",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash:  {},
+					testHash2: {},
+					{1}:       {},
+					{2}:       {},
+				},
+				Payments: make(map[lntypes.Hash]*PaymentEntry),
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+
+			err = s.store.StoreLastIndexes(20, 10)
+			require.NoError(t, err)
+
+			settled := lndclient.InvoiceHtlc{
+				Amount: 500,
+				State:  lnrpc.InvoiceHTLCState_SETTLED,
+			}
+
+			// An AMP invoice that was paid before the credited
+			// amounts were recorded.
+			lnd.invoices[testHash] = &lndclient.Invoice{
+				Hash:        testHash,
+				SettleIndex: 9,
+				State:       invpkg.ContractOpen,
+				Htlcs:       []lndclient.InvoiceHtlc{settled},
+			}
+
+			// A settled invoice that was paid before the credited
+			// amounts were recorded.
+			lnd.invoices[testHash2] = &lndclient.Invoice{
+				Hash:        testHash2,
+				SettleIndex: 10,
+				AmountPaid:  700,
+				State:       invpkg.ContractSettled,
+			}
+
+			// An invoice that was settled while we were offline,
+			// which is still credited by the subscription.
+			lnd.invoices[lntypes.Hash{1}] = &lndclient.Invoice{
+				Hash:        lntypes.Hash{1},
+				SettleIndex: 11,
+				AmountPaid:  300,
+				State:       invpkg.ContractSettled,
+			}
+
+			// A canceled invoice.
+			lnd.invoices[lntypes.Hash{2}] = &lndclient.Invoice{
+				Hash:  lntypes.Hash{2},
+				State: invpkg.ContractCanceled,
+			}
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 20, 10)
+
+			acct, err := s.store.Account(testID)
+			require.NoError(t, err)
+			require.EqualValues(t, 1234, acct.CurrentBalance)
+			require.Equal(
+				t, map[lntypes.Hash]lnwire.MilliSatoshi{
+					testHash:  500,
+					testHash2: 700,
+				}, acct.InvoiceCredits,
+			)
+
+			s.RLock()
+			require.Equal(
+				t, map[lntypes.Hash]AccountID{
+					testHash:        testID,
+					lntypes.Hash{1}: testID,
+				}, s.invoiceToAccount,
+			)
+			s.RUnlock()
+
+			// Paying the AMP invoice again only credits the new
+			// payment.
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    21,
+				SettleIndex: 12,
+				Hash:        testHash,
+				State:       invpkg.ContractOpen,
+				Htlcs: []lndclient.InvoiceHtlc{{
+					Amount: 500,
+					State:  lnrpc.InvoiceHTLCState_SETTLED,
+				}, {
+					Amount: 200,
+					State:  lnrpc.InvoiceHTLCState_SETTLED,
+				}},
+			}
+
+			assertEventually(t, func() bool {
+				acct, err := s.store.Account(testID)
+				require.NoError(t, err)
+
+				return acct.CurrentBalance == 1234+200
+			})
+		},
+	}, {
+		name: "startup invoice lookup error",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct, err := s.store.NewAccount(
+				1234, testExpiration, "", AccountLimits{},
+				ExpiredInvoicePolicyGrace,
+			)
+			require.NoError(t, err)
+
+			acct.Invoices[testHash] = struct{}{}
+			err = s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+
+			lnd.lookupErr = testErr
+		},
+		startupErr: testErr.Error(),
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertNoInvoiceRequest(t)
+		},
+	}, {
+		name: "credit expired account within grace period",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				ExpirationDate: time.Now().Add(-time.Hour),
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash: {},
+				},
+				Payments: make(map[lntypes.Hash]*PaymentEntry),
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 0, 0)
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    12,
+				SettleIndex: 12,
+				Hash:        testHash,
+				AmountPaid:  777,
+				State:       invpkg.ContractSettled,
+				SettleDate:  time.Now(),
+			}
+
+			assertEventually(t, func() bool {
+				acct, err := s.store.Account(testID)
+				require.NoError(t, err)
+
+				return acct.CurrentBalance == (1234 + 777)
+			})
+		},
+	}, {
+		name: "don't credit expired account with reject policy",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				ExpirationDate: time.Now().Add(-time.Hour),
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash: {},
+				},
+				Payments:             make(map[lntypes.Hash]*PaymentEntry),
+				ExpiredInvoicePolicy: ExpiredInvoicePolicyReject,
+			}
+
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+
+			// Make sure the invoice isn't canceled before it is
+			// settled.
+			lnd.cancelErr = testErr
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 0, 0)
+			lnd.invoiceChan <- &lndclient.Invoice{
+				AddIndex:    12,
+				SettleIndex: 12,
+				Hash:        testHash,
+				AmountPaid:  777,
+				State:       invpkg.ContractSettled,
+				SettleDate:  time.Now(),
+			}
+
+			// The invoice is no longer tracked, but the account
+			// isn't credited.
+			assertEventually(t, func() bool {
+				s.RLock()
+				defer s.RUnlock()
+
+				_, ok := s.invoiceToAccount[testHash]
+				return !ok
+			})
+
+			acct, err := s.store.Account(testID)
+			require.NoError(t, err)
+			require.EqualValues(t, 1234, acct.CurrentBalance)
+		},
+	}, {
+		name: "cancel open invoices after grace period",
+		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
+			expired := time.Now().Add(-2 * DefaultInvoiceGracePeriod)
+			acct := &OffChainBalanceAccount{
+				ID:             testID,
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				ExpirationDate: expired,
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash: {},
+				},
+				Payments: make(map[lntypes.Hash]*PaymentEntry),
+			}
+			err := s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+
+			// An account that always credits its invoices keeps
+			// them open.
+			acct = &OffChainBalanceAccount{
+				ID:             AccountID{9, 8, 7},
+				Type:           TypeInitialBalance,
+				CurrentBalance: 1234,
+				ExpirationDate: expired,
+				Invoices: map[lntypes.Hash]struct{}{
+					testHash2: {},
+				},
+				Payments:             make(map[lntypes.Hash]*PaymentEntry),
+				ExpiredInvoicePolicy: ExpiredInvoicePolicyCredit,
+			}
+			err = s.store.UpdateAccount(acct)
+			require.NoError(t, err)
+		},
+		validate: func(t *testing.T, lnd *mockLnd,
+			s *InterceptorService) {
+
+			lnd.assertInvoiceRequest(t, 0, 0)
+
+			select {
+			case hash := <-lnd.canceled:
+				require.Equal(t, testHash, hash)
+
+			case <-time.After(testTimeout):
+				t.Fatalf("invoice not canceled")
+			}
+
+			assertEventually(t, func() bool {
+				s.RLock()
+				defer s.RUnlock()
+
+				_, ok := s.invoiceToAccount[testHash]
+				return !ok
+			})
+
+			s.RLock()
+			require.Contains(t, s.invoiceToAccount, testHash2)
+			s.RUnlock()
+			require.Empty(t, lnd.canceled)
+		},
 	}, {
 		name: "in-flight payments",
 		setup: func(t *testing.T, lnd *mockLnd, s *InterceptorService) {
@@ -428,9 +1179,25 @@ func TestAccountService(t *testing.T) {
 			err = s.CheckBalance(testID, 2001)
 			require.ErrorIs(t, err, ErrAccBalanceInsufficient)
 
+			var balanceErr *InsufficientBalanceError
+			require.ErrorAs(t, err, &balanceErr)
+			require.EqualValues(t, 2000, balanceErr.Available)
+			require.EqualValues(t, 2001, balanceErr.Required)
+
+			// The RPC error carries the same details.
+			detail := litrpc.ErrorDetailFromError(rpcError(err))
+			require.Equal(
+				t, litrpc.ErrorCode_ERROR_ACCOUNT_INSUFFICIENT_BALANCE,
+				detail.Code,
+			)
+			require.EqualValues(
+				t, 2000,
+				detail.GetAccountInsufficientBalance().AvailableMsat,
+			)
+
 			// Remove one of the payments (to simulate it failed)
 			// and try again.
-			lnd.paymentChans[testHash] <- lndclient.PaymentStatus{
+			lnd.paymentChan(testHash) <- lndclient.PaymentStatus{
 				State: lnrpc.Payment_FAILED,
 			}
 
@@ -461,7 +1228,9 @@ func TestAccountService(t *testing.T) {
 			}
 
 			// Any errors during startup expected?
-			err = service.Start(lndMock, lndMock, chainParams)
+			err = service.Start(
+				lndMock, lndMock, lndMock, chainParams, nil,
+			)
 			if tc.startupErr != "" {
 				require.ErrorContains(tt, err, tc.startupErr)
 
@@ -490,3 +1259,27 @@ func TestAccountService(t *testing.T) {
 func assertEventually(t *testing.T, predicate func() bool) {
 	require.Eventually(t, predicate, testTimeout, testInterval)
 }
+
+// TestFlush tests that a flushed service rejects new payments of accounts.
+func TestFlush(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	acct, err := service.NewAccount(
+		5000, testExpiration, "", AccountLimits{},
+		ExpiredInvoicePolicyGrace,
+	)
+	require.NoError(t, err)
+	require.NoError(t, service.CheckBalance(acct.ID, 1000))
+
+	require.Zero(t, service.Flush())
+	require.ErrorIs(
+		t, service.CheckBalance(acct.ID, 1000), ErrServiceFlushed,
+	)
+}
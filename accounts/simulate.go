@@ -0,0 +1,106 @@
+package accounts
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// randomHash returns a random hash that is used as the payment hash of
+// simulated invoices and payments.
+func randomHash() (lntypes.Hash, error) {
+	var hash lntypes.Hash
+	if _, err := rand.Read(hash[:]); err != nil {
+		return hash, fmt.Errorf("error creating random hash: %v", err)
+	}
+
+	return hash, nil
+}
+
+// SimulateInvoice credits the given account as if an invoice of the account
+// was settled with the given amount. No invoice is created in lnd, the
+// returned hash is random.
+//
+// NOTE: This is only meant for testing account based applications on regtest.
+func (s *InterceptorService) SimulateInvoice(id AccountID,
+	amount lnwire.MilliSatoshi) (lntypes.Hash, *OffChainBalanceAccount,
+	error) {
+
+	hash, err := randomHash()
+	if err != nil {
+		return hash, nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	account, err := s.store.Account(id)
+	if err != nil {
+		return hash, nil, err
+	}
+
+	account.Invoices[hash] = struct{}{}
+	account.CurrentBalance += int64(amount)
+	if err := s.store.UpdateAccount(account); err != nil {
+		return hash, nil, fmt.Errorf("error updating account: %v", err)
+	}
+
+	log.Debugf("Simulated settlement of invoice %v with %v for account %v",
+		hash, amount, id)
+
+	return hash, account, nil
+}
+
+// SimulatePayment records a payment of the given account as if it was sent
+// through lnd. The balance is checked the same way as for real payments. A
+// successful payment debits the full amount from the account, a failed one is
+// only recorded. No HTLCs are sent, the returned hash is random.
+//
+// NOTE: This is only meant for testing account based applications on regtest.
+func (s *InterceptorService) SimulatePayment(id AccountID, amount,
+	fee lnwire.MilliSatoshi, fail bool) (lntypes.Hash,
+	*OffChainBalanceAccount, error) {
+
+	hash, err := randomHash()
+	if err != nil {
+		return hash, nil, err
+	}
+
+	fullAmount := amount + fee
+	s.RecordPaymentAttempt(id, fullAmount, nil)
+	if err := s.CheckBalance(id, fullAmount); err != nil {
+		return hash, nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	account, err := s.store.Account(id)
+	if err != nil {
+		return hash, nil, err
+	}
+
+	status := lnrpc.Payment_SUCCEEDED
+	if fail {
+		status = lnrpc.Payment_FAILED
+		s.notifyPaymentFailed(id, hash)
+	} else {
+		account.CurrentBalance -= int64(fullAmount)
+	}
+
+	account.Payments[hash] = &PaymentEntry{
+		Status:     status,
+		FullAmount: fullAmount,
+	}
+	if err := s.store.UpdateAccount(account); err != nil {
+		return hash, nil, fmt.Errorf("error updating account: %v", err)
+	}
+
+	log.Debugf("Simulated payment %v of %v with status %v for account %v",
+		hash, fullAmount, status, id)
+
+	return hash, account, nil
+}
@@ -0,0 +1,80 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSimulate tests that simulated invoices and payments update the balance
+// of an account the same way real ones do.
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	errChan := make(chan error, 1)
+	service, err := NewService(t.TempDir(), errChan)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, service.Stop())
+	})
+
+	acct, err := service.NewAccount(
+		5000, testExpiration, "", AccountLimits{},
+		ExpiredInvoicePolicyGrace,
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	id := hex.EncodeToString(acct.ID[:])
+
+	// The RPCs must be rejected unless simulation is enabled.
+	disabled := NewRPCServer(service, nil, nil, nil, false)
+	_, err = disabled.SimulateInvoice(ctx, &litrpc.SimulateInvoiceRequest{
+		Id:     id,
+		Amount: 1,
+	})
+	require.ErrorIs(t, err, ErrSimulationDisabled)
+
+	server := NewRPCServer(service, nil, nil, nil, true)
+	invoiceResp, err := server.SimulateInvoice(
+		ctx, &litrpc.SimulateInvoiceRequest{
+			Id:     id,
+			Amount: 2,
+		},
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, invoiceResp.Account.CurrentBalance)
+
+	invoiceHash, err := lntypes.MakeHash(invoiceResp.Hash)
+	require.NoError(t, err)
+	acct, err = service.Account(acct.ID)
+	require.NoError(t, err)
+	require.Contains(t, acct.Invoices, invoiceHash)
+
+	// A successful payment debits the amount and the fee.
+	_, stored, err := service.SimulatePayment(acct.ID, 3000, 1000, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 3000, stored.CurrentBalance)
+
+	// A failed payment is recorded but doesn't change the balance.
+	hash, stored, err := service.SimulatePayment(acct.ID, 1000, 0, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 3000, stored.CurrentBalance)
+	require.Equal(t, lnrpc.Payment_FAILED, stored.Payments[hash].Status)
+
+	// A payment that exceeds the balance is rejected.
+	_, err = server.SimulatePayment(ctx, &litrpc.SimulatePaymentRequest{
+		Id:     id,
+		Amount: 4,
+	})
+	require.ErrorContains(t, err, ErrAccBalanceInsufficient.Error())
+	require.Equal(
+		t, litrpc.ErrorCode_ERROR_ACCOUNT_INSUFFICIENT_BALANCE,
+		litrpc.ErrorCodeFromError(err),
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -37,6 +38,14 @@ var (
 	// based balances are stored.
 	accountBucketName = []byte("accounts")
 
+	// removedAccountsBucketName is the name of the bucket where the
+	// records of removed accounts are stored.
+	removedAccountsBucketName = []byte("removed-accounts")
+
+	// accountLedgerBucketName is the name of the bucket that holds a
+	// sub-bucket with the ledger entries of each account.
+	accountLedgerBucketName = []byte("account-ledger")
+
 	// lastAddIndexKey is the name of the key under which we store the last
 	// known invoice add index.
 	lastAddIndexKey = []byte("last-add-index")
@@ -83,9 +92,25 @@ func NewBoltStore(dir, fileName string) (*BoltStore, error) {
 		return nil, err
 	}
 
-	// If the store's bucket doesn't exist, create it.
-	err = db.Update(func(tx kvdb.RwTx) error {
+	return NewStore(db)
+}
+
+// NewStore creates a BoltStore instance on top of the given, already opened
+// database backend and creates the store's buckets if they don't exist yet.
+func NewStore(db kvdb.Backend) (*BoltStore, error) {
+	// If the store's buckets don't exist, create them.
+	err := db.Update(func(tx kvdb.RwTx) error {
 		_, err := tx.CreateTopLevelBucket(accountBucketName)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateTopLevelBucket(removedAccountsBucketName)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateTopLevelBucket(accountLedgerBucketName)
 		return err
 	}, func() {})
 	if err != nil {
@@ -101,10 +126,11 @@ func (s *BoltStore) Close() error {
 	return s.db.Close()
 }
 
-// NewAccount creates a new OffChainBalanceAccount with the given balance and a
-// randomly chosen ID.
+// NewAccount creates a new OffChainBalanceAccount with the given balance,
+// optional label, limits, expired invoice policy and a randomly chosen ID.
 func (s *BoltStore) NewAccount(balance lnwire.MilliSatoshi,
-	expirationDate time.Time) (*OffChainBalanceAccount, error) {
+	expirationDate time.Time, label string, limits AccountLimits,
+	policy ExpiredInvoicePolicy) (*OffChainBalanceAccount, error) {
 
 	if balance == 0 {
 		return nil, fmt.Errorf("a new account cannot have balance of 0")
@@ -119,7 +145,13 @@ func (s *BoltStore) NewAccount(balance lnwire.MilliSatoshi,
 		ExpirationDate: expirationDate,
 		LastUpdate:     time.Now(),
 		Invoices:       make(map[lntypes.Hash]struct{}),
-		Payments:       make(map[lntypes.Hash]*PaymentEntry),
+		InvoiceCredits: make(
+			map[lntypes.Hash]lnwire.MilliSatoshi,
+		),
+		Payments:             make(map[lntypes.Hash]*PaymentEntry),
+		Label:                label,
+		Limits:               limits,
+		ExpiredInvoicePolicy: policy,
 	}
 
 	// Try storing the account in the account database, so we can keep track
@@ -148,6 +180,25 @@ func (s *BoltStore) NewAccount(balance lnwire.MilliSatoshi,
 	return account, nil
 }
 
+// Snapshot writes a consistent copy of the whole store to the given writer.
+func (s *BoltStore) Snapshot(w io.Writer) error {
+	return s.db.Copy(w)
+}
+
+// Probe runs an empty read or, if write is set, an empty write transaction
+// against the store.
+func (s *BoltStore) Probe(write bool) error {
+	if write {
+		return s.db.Update(func(kvdb.RwTx) error {
+			return nil
+		}, func() {})
+	}
+
+	return s.db.View(func(kvdb.RTx) error {
+		return nil
+	}, func() {})
+}
+
 // UpdateAccount writes an account to the database, overwriting the existing one
 // if it exists.
 func (s *BoltStore) UpdateAccount(account *OffChainBalanceAccount) error {
@@ -277,23 +328,200 @@ func (s *BoltStore) Accounts() ([]*OffChainBalanceAccount, error) {
 	return accounts, nil
 }
 
-// RemoveAccount finds an account by its ID and removes it from the DB.
-func (s *BoltStore) RemoveAccount(id AccountID) error {
+// RemoveAccount finds an account by its ID and removes it from the DB. The
+// removal is recorded with the given actor and reason.
+func (s *BoltStore) RemoveAccount(id AccountID, removedBy,
+	reason string) error {
+
+	return s.db.Update(func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(accountBucketName)
+		if bucket == nil {
+			return ErrAccountBucketNotFound
+		}
+
+		removedBucket := tx.ReadWriteBucket(removedAccountsBucketName)
+		if removedBucket == nil {
+			return ErrAccountBucketNotFound
+		}
+
+		accountBytes := bucket.Get(id[:])
+		if len(accountBytes) == 0 {
+			return ErrAccNotFound
+		}
+
+		account, err := deserializeAccount(accountBytes)
+		if err != nil {
+			return err
+		}
+
+		removal, err := serializeAccountRemoval(&AccountRemoval{
+			ID:        id,
+			Label:     account.Label,
+			RemovedAt: time.Now(),
+			RemovedBy: removedBy,
+			Reason:    reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := removedBucket.Put(id[:], removal); err != nil {
+			return err
+		}
+
+		if err := deleteLedger(tx, id); err != nil {
+			return err
+		}
+
+		return bucket.Delete(id[:])
+	}, func() {})
+}
+
+// DeleteAccount finds an account by its ID and removes it from the DB together
+// with its ledger. Unlike RemoveAccount, no record of the removal is kept.
+func (s *BoltStore) DeleteAccount(id AccountID) error {
 	return s.db.Update(func(tx kvdb.RwTx) error {
 		bucket := tx.ReadWriteBucket(accountBucketName)
 		if bucket == nil {
 			return ErrAccountBucketNotFound
 		}
 
-		account := bucket.Get(id[:])
-		if len(account) == 0 {
+		if len(bucket.Get(id[:])) == 0 {
 			return ErrAccNotFound
 		}
 
+		if err := deleteLedger(tx, id); err != nil {
+			return err
+		}
+
 		return bucket.Delete(id[:])
 	}, func() {})
 }
 
+// deleteLedger removes the ledger of the account with the given ID, if it has
+// one.
+func deleteLedger(tx kvdb.RwTx, id AccountID) error {
+	ledgerBucket := tx.ReadWriteBucket(accountLedgerBucketName)
+	if ledgerBucket == nil {
+		return ErrAccountBucketNotFound
+	}
+
+	err := ledgerBucket.DeleteNestedBucket(id[:])
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// RemovedAccounts returns the records of all removed accounts.
+func (s *BoltStore) RemovedAccounts() ([]*AccountRemoval, error) {
+	var removals []*AccountRemoval
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(removedAccountsBucketName)
+		if bucket == nil {
+			return ErrAccountBucketNotFound
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			removal, err := deserializeAccountRemoval(v)
+			if err != nil {
+				return err
+			}
+
+			removals = append(removals, removal)
+			return nil
+		})
+	}, func() {
+		removals = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removals, nil
+}
+
+// UpdateAccountWithLedger writes an account to the database, overwriting the
+// existing one, and appends the given entry to the ledger of the account in
+// the same transaction.
+func (s *BoltStore) UpdateAccountWithLedger(account *OffChainBalanceAccount,
+	entry *LedgerEntry) error {
+
+	return s.db.Update(func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(accountBucketName)
+		if bucket == nil {
+			return ErrAccountBucketNotFound
+		}
+
+		ledgerBucket := tx.ReadWriteBucket(accountLedgerBucketName)
+		if ledgerBucket == nil {
+			return ErrAccountBucketNotFound
+		}
+
+		account.LastUpdate = time.Now()
+		if err := storeAccount(bucket, account); err != nil {
+			return err
+		}
+
+		entries, err := ledgerBucket.CreateBucketIfNotExists(
+			account.ID[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		seq, err := entries.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		entryBytes, err := serializeLedgerEntry(entry)
+		if err != nil {
+			return err
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], seq)
+
+		return entries.Put(key[:], entryBytes)
+	}, func() {})
+}
+
+// Ledger returns the ledger entries of the account with the given ID, oldest
+// first.
+func (s *BoltStore) Ledger(id AccountID) ([]*LedgerEntry, error) {
+	var ledger []*LedgerEntry
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(accountLedgerBucketName)
+		if bucket == nil {
+			return ErrAccountBucketNotFound
+		}
+
+		entries := bucket.NestedReadBucket(id[:])
+		if entries == nil {
+			return nil
+		}
+
+		return entries.ForEach(func(_, v []byte) error {
+			entry, err := deserializeLedgerEntry(v)
+			if err != nil {
+				return err
+			}
+
+			ledger = append(ledger, entry)
+			return nil
+		})
+	}, func() {
+		ledger = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
 // LastIndexes returns the last invoice add and settle index or
 // ErrNoInvoiceIndexKnown if no indexes are known yet.
 func (s *BoltStore) LastIndexes() (uint64, uint64, error) {
@@ -18,11 +18,16 @@ func TestAccountStore(t *testing.T) {
 
 	// An initial balance of 0 is not allowed, but later we can reach a
 	// zero balance.
-	_, err = store.NewAccount(0, time.Time{})
+	_, err = store.NewAccount(
+		0, time.Time{}, "", AccountLimits{}, ExpiredInvoicePolicyGrace,
+	)
 	require.ErrorContains(t, err, "cannot have balance of 0")
 
 	// Create an account that does not expire.
-	acct1, err := store.NewAccount(123, time.Time{})
+	acct1, err := store.NewAccount(
+		123, time.Time{}, "foo", AccountLimits{},
+		ExpiredInvoicePolicyGrace,
+	)
 	require.NoError(t, err)
 	require.False(t, acct1.HasExpired())
 
@@ -44,12 +49,23 @@ func TestAccountStore(t *testing.T) {
 	}
 	acct1.Invoices[lntypes.Hash{12, 34, 56, 78}] = struct{}{}
 	acct1.Invoices[lntypes.Hash{34, 56, 78, 90}] = struct{}{}
+	acct1.InvoiceCredits[lntypes.Hash{34, 56, 78, 90}] = 4000
+	acct1.FrozenAt = time.Now()
+	acct1.FrozenReason = "unusual activity"
+	acct1.Limits = AccountLimits{
+		MinInvoiceAmount: 1000,
+		MaxInvoiceAmount: 5000000,
+		MaxFee:           20000,
+		MaxFeePercent:    3,
+	}
+	acct1.ExpiredInvoicePolicy = ExpiredInvoicePolicyReject
 	err = store.UpdateAccount(acct1)
 	require.NoError(t, err)
 
 	dbAccount, err = store.Account(acct1.ID)
 	require.NoError(t, err)
 	assertEqualAccounts(t, acct1, dbAccount)
+	require.True(t, dbAccount.IsFrozen())
 
 	// Sleep just a tiny bit to make sure we are never too quick to measure
 	// the expiry, even though the time is nanosecond scale and writing to
@@ -58,12 +74,40 @@ func TestAccountStore(t *testing.T) {
 	time.Sleep(5 * time.Millisecond)
 	require.True(t, acct1.HasExpired())
 
+	// Balance adjustments are recorded in the ledger of the account.
+	acct1.CurrentBalance += 1000
+	err = store.UpdateAccountWithLedger(acct1, &LedgerEntry{
+		Timestamp:   time.Now(),
+		InvoiceHash: lntypes.Hash{34, 56, 78, 90},
+		Amount:      1000,
+		Balance:     acct1.CurrentBalance,
+		Reason:      LedgerReasonInvoiceSettled,
+	})
+	require.NoError(t, err)
+	err = store.UpdateAccountWithLedger(acct1, &LedgerEntry{
+		Timestamp:   time.Now(),
+		InvoiceHash: lntypes.Hash{34, 56, 78, 90},
+		Amount:      -1000,
+		Balance:     acct1.CurrentBalance - 1000,
+		Reason:      LedgerReasonInvoiceCanceled,
+	})
+	require.NoError(t, err)
+
+	ledger, err := store.Ledger(acct1.ID)
+	require.NoError(t, err)
+	require.Len(t, ledger, 2)
+	require.EqualValues(t, 1000, ledger[0].Amount)
+	require.EqualValues(t, 500, ledger[0].Balance)
+	require.EqualValues(t, -1000, ledger[1].Amount)
+	require.EqualValues(t, -500, ledger[1].Balance)
+	require.Equal(t, LedgerReasonInvoiceCanceled, ledger[1].Reason)
+
 	// Test listing and deleting accounts.
 	accounts, err := store.Accounts()
 	require.NoError(t, err)
 	require.Len(t, accounts, 1)
 
-	err = store.RemoveAccount(acct1.ID)
+	err = store.RemoveAccount(acct1.ID, "alice", "no longer needed")
 	require.NoError(t, err)
 
 	accounts, err = store.Accounts()
@@ -72,11 +116,30 @@ func TestAccountStore(t *testing.T) {
 
 	_, err = store.Account(acct1.ID)
 	require.ErrorIs(t, err, ErrAccNotFound)
+
+	// The ledger is removed together with the account.
+	ledger, err = store.Ledger(acct1.ID)
+	require.NoError(t, err)
+	require.Empty(t, ledger)
+
+	// The removal is recorded with the actor and reason.
+	removals, err := store.RemovedAccounts()
+	require.NoError(t, err)
+	require.Len(t, removals, 1)
+	require.Equal(t, acct1.ID, removals[0].ID)
+	require.Equal(t, acct1.Label, removals[0].Label)
+	require.Equal(t, "alice", removals[0].RemovedBy)
+	require.Equal(t, "no longer needed", removals[0].Reason)
+	require.False(t, removals[0].RemovedAt.IsZero())
+
+	// An account that doesn't exist can't be removed.
+	err = store.RemoveAccount(acct1.ID, "alice", "")
+	require.ErrorIs(t, err, ErrAccNotFound)
 }
 
 // assertEqualAccounts asserts that two accounts are equal. This helper function
-// is needed because an account contains two time.Time values that cannot be
-// compared using reflect.DeepEqual().
+// is needed because an account contains several time.Time values that cannot
+// be compared using reflect.DeepEqual().
 func assertEqualAccounts(t *testing.T, expected,
 	actual *OffChainBalanceAccount) {
 
@@ -84,21 +147,28 @@ func assertEqualAccounts(t *testing.T, expected,
 	actualExpiry := actual.ExpirationDate
 	expectedUpdate := expected.LastUpdate
 	actualUpdate := actual.LastUpdate
+	expectedFrozen := expected.FrozenAt
+	actualFrozen := actual.FrozenAt
 
 	expected.ExpirationDate = time.Time{}
 	expected.LastUpdate = time.Time{}
+	expected.FrozenAt = time.Time{}
 	actual.ExpirationDate = time.Time{}
 	actual.LastUpdate = time.Time{}
+	actual.FrozenAt = time.Time{}
 
 	require.Equal(t, expected, actual)
 	require.Equal(t, expectedExpiry.UnixNano(), actualExpiry.UnixNano())
 	require.Equal(t, expectedUpdate.UnixNano(), actualUpdate.UnixNano())
+	require.Equal(t, expectedFrozen.UnixNano(), actualFrozen.UnixNano())
 
 	// Restore the old values to not influence the tests.
 	expected.ExpirationDate = expectedExpiry
 	expected.LastUpdate = expectedUpdate
+	expected.FrozenAt = expectedFrozen
 	actual.ExpirationDate = actualExpiry
 	actual.LastUpdate = actualUpdate
+	actual.FrozenAt = actualFrozen
 }
 
 // TestLastInvoiceIndexes makes sure the last known invoice indexes can be
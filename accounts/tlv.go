@@ -21,6 +21,32 @@ const (
 	typeExpirationDate tlv.Type = 6
 	typeInvoices       tlv.Type = 7
 	typePayments       tlv.Type = 8
+	typeLabel          tlv.Type = 9
+	typeFrozenAt       tlv.Type = 10
+	typeFrozenReason   tlv.Type = 11
+	typeMinInvoiceAmt  tlv.Type = 12
+	typeMaxInvoiceAmt  tlv.Type = 13
+	typeMaxFee         tlv.Type = 14
+	typeMaxFeePercent  tlv.Type = 15
+	typeInvoicePolicy  tlv.Type = 16
+	typeInvoiceCredits tlv.Type = 17
+	typeTrackedSince   tlv.Type = 18
+)
+
+const (
+	typeRemovalID        tlv.Type = 1
+	typeRemovalLabel     tlv.Type = 2
+	typeRemovalTime      tlv.Type = 3
+	typeRemovalRemovedBy tlv.Type = 4
+	typeRemovalReason    tlv.Type = 5
+)
+
+const (
+	typeLedgerTimestamp tlv.Type = 1
+	typeLedgerHash      tlv.Type = 2
+	typeLedgerAmount    tlv.Type = 3
+	typeLedgerBalance   tlv.Type = 4
+	typeLedgerReason    tlv.Type = 5
 )
 
 func serializeAccount(account *OffChainBalanceAccount) ([]byte, error) {
@@ -57,6 +83,82 @@ func serializeAccount(account *OffChainBalanceAccount) ([]byte, error) {
 		newPaymentEntryMapRecord(typePayments, &account.Payments),
 	)
 
+	if account.Label != "" {
+		label := []byte(account.Label)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeLabel, &label,
+		))
+	}
+
+	if account.IsFrozen() {
+		frozenAt := uint64(account.FrozenAt.UnixNano())
+		frozenReason := []byte(account.FrozenReason)
+		tlvRecords = append(
+			tlvRecords,
+			tlv.MakePrimitiveRecord(typeFrozenAt, &frozenAt),
+			tlv.MakePrimitiveRecord(
+				typeFrozenReason, &frozenReason,
+			),
+		)
+	}
+
+	if account.Limits.MinInvoiceAmount != 0 {
+		minInvoiceAmt := uint64(account.Limits.MinInvoiceAmount)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeMinInvoiceAmt, &minInvoiceAmt,
+		))
+	}
+
+	if account.Limits.MaxInvoiceAmount != 0 {
+		maxInvoiceAmt := uint64(account.Limits.MaxInvoiceAmount)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeMaxInvoiceAmt, &maxInvoiceAmt,
+		))
+	}
+
+	if account.Limits.MaxFee != 0 {
+		maxFee := uint64(account.Limits.MaxFee)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeMaxFee, &maxFee,
+		))
+	}
+
+	if account.Limits.MaxFeePercent != 0 {
+		maxFeePercent := account.Limits.MaxFeePercent
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeMaxFeePercent, &maxFeePercent,
+		))
+	}
+
+	if account.ExpiredInvoicePolicy != ExpiredInvoicePolicyGrace {
+		invoicePolicy := uint8(account.ExpiredInvoicePolicy)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeInvoicePolicy, &invoicePolicy,
+		))
+	}
+
+	if len(account.InvoiceCredits) > 0 {
+		tlvRecords = append(tlvRecords, newAmountMapRecord(
+			typeInvoiceCredits, &account.InvoiceCredits,
+		))
+	}
+
+	// We only store the time at which the tracking of a payment started
+	// while the payment is in flight, so it can be resumed after a restart.
+	trackedSince := make(map[lntypes.Hash]uint64)
+	for hash, entry := range account.Payments {
+		if !entry.inFlight() || entry.TrackedSince.IsZero() {
+			continue
+		}
+
+		trackedSince[hash] = uint64(entry.TrackedSince.UnixNano())
+	}
+	if len(trackedSince) > 0 {
+		tlvRecords = append(tlvRecords, newTimestampMapRecord(
+			typeTrackedSince, &trackedSince,
+		))
+	}
+
 	tlvStream, err := tlv.NewStream(tlvRecords...)
 	if err != nil {
 		return nil, err
@@ -80,6 +182,16 @@ func deserializeAccount(content []byte) (*OffChainBalanceAccount, error) {
 		expirationDate uint64
 		invoices       map[lntypes.Hash]struct{}
 		payments       map[lntypes.Hash]*PaymentEntry
+		label          []byte
+		frozenAt       uint64
+		frozenReason   []byte
+		minInvoiceAmt  uint64
+		maxInvoiceAmt  uint64
+		maxFee         uint64
+		maxFeePercent  uint32
+		invoicePolicy  uint8
+		invoiceCredits map[lntypes.Hash]lnwire.MilliSatoshi
+		trackedSince   map[lntypes.Hash]uint64
 	)
 
 	tlvStream, err := tlv.NewStream(
@@ -91,6 +203,16 @@ func deserializeAccount(content []byte) (*OffChainBalanceAccount, error) {
 		tlv.MakePrimitiveRecord(typeExpirationDate, &expirationDate),
 		newHashMapRecord(typeInvoices, &invoices),
 		newPaymentEntryMapRecord(typePayments, &payments),
+		tlv.MakePrimitiveRecord(typeLabel, &label),
+		tlv.MakePrimitiveRecord(typeFrozenAt, &frozenAt),
+		tlv.MakePrimitiveRecord(typeFrozenReason, &frozenReason),
+		tlv.MakePrimitiveRecord(typeMinInvoiceAmt, &minInvoiceAmt),
+		tlv.MakePrimitiveRecord(typeMaxInvoiceAmt, &maxInvoiceAmt),
+		tlv.MakePrimitiveRecord(typeMaxFee, &maxFee),
+		tlv.MakePrimitiveRecord(typeMaxFeePercent, &maxFeePercent),
+		tlv.MakePrimitiveRecord(typeInvoicePolicy, &invoicePolicy),
+		newAmountMapRecord(typeInvoiceCredits, &invoiceCredits),
+		newTimestampMapRecord(typeTrackedSince, &trackedSince),
 	)
 	if err != nil {
 		return nil, err
@@ -108,13 +230,41 @@ func deserializeAccount(content []byte) (*OffChainBalanceAccount, error) {
 		LastUpdate:     time.Unix(0, int64(lastUpdate)),
 		Invoices:       invoices,
 		Payments:       payments,
+		Label:          string(label),
+		Limits: AccountLimits{
+			MinInvoiceAmount: lnwire.MilliSatoshi(minInvoiceAmt),
+			MaxInvoiceAmount: lnwire.MilliSatoshi(maxInvoiceAmt),
+			MaxFee:           lnwire.MilliSatoshi(maxFee),
+			MaxFeePercent:    maxFeePercent,
+		},
+		ExpiredInvoicePolicy: ExpiredInvoicePolicy(invoicePolicy),
+		InvoiceCredits:       invoiceCredits,
 	}
 	copy(account.ID[:], id)
 
+	// Accounts that were stored before any of their invoices credited
+	// them don't have the record at all.
+	if account.InvoiceCredits == nil {
+		account.InvoiceCredits = make(
+			map[lntypes.Hash]lnwire.MilliSatoshi,
+		)
+	}
+
+	for hash, timestamp := range trackedSince {
+		if entry, ok := account.Payments[hash]; ok {
+			entry.TrackedSince = time.Unix(0, int64(timestamp))
+		}
+	}
+
 	if t, ok := parsedTypes[typeExpirationDate]; ok && t == nil {
 		account.ExpirationDate = time.Unix(0, int64(expirationDate))
 	}
 
+	if t, ok := parsedTypes[typeFrozenAt]; ok && t == nil {
+		account.FrozenAt = time.Unix(0, int64(frozenAt))
+		account.FrozenReason = string(frozenReason)
+	}
+
 	return account, nil
 }
 
@@ -258,3 +408,266 @@ func PaymentEntryMapDecoder(r io.Reader, val any, buf *[8]byte, _ uint64) error
 		val, "*map[lntypes.Hash]*PaymentEntry",
 	)
 }
+
+// newAmountMapRecord returns a new TLV record for encoding the given map of
+// hashes to amounts.
+func newAmountMapRecord(tlvType tlv.Type,
+	amountMap *map[lntypes.Hash]lnwire.MilliSatoshi) tlv.Record {
+
+	recordSize := func() uint64 {
+		// We have a 32-byte hash and 8 bytes for the amount for each
+		// entry.
+		return uint64(len(*amountMap) * (lntypes.HashSize + 8))
+	}
+	return tlv.MakeDynamicRecord(
+		tlvType, amountMap, recordSize, AmountMapEncoder,
+		AmountMapDecoder,
+	)
+}
+
+// AmountMapEncoder encodes a map of hashes to amounts.
+func AmountMapEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*map[lntypes.Hash]lnwire.MilliSatoshi); ok {
+		if err := tlv.WriteVarInt(w, uint64(len(*t)), buf); err != nil {
+			return err
+		}
+		for hash, amt := range *t {
+			hash := [32]byte(hash)
+
+			if err := tlv.EBytes32(w, &hash, buf); err != nil {
+				return err
+			}
+
+			err := tlv.EUint64T(w, uint64(amt), buf)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return tlv.NewTypeForEncodingErr(
+		val, "*map[lntypes.Hash]lnwire.MilliSatoshi",
+	)
+}
+
+// AmountMapDecoder decodes a map of hashes to amounts.
+func AmountMapDecoder(r io.Reader, val any, buf *[8]byte, _ uint64) error {
+	if typ, ok := val.(*map[lntypes.Hash]lnwire.MilliSatoshi); ok {
+		numItems, err := tlv.ReadVarInt(r, buf)
+		if err != nil {
+			return err
+		}
+
+		amounts := make(
+			map[lntypes.Hash]lnwire.MilliSatoshi, numItems,
+		)
+		for i := uint64(0); i < numItems; i++ {
+			var item [32]byte
+			if err := tlv.DBytes32(r, &item, buf, 32); err != nil {
+				return err
+			}
+
+			var amt uint64
+			if err := tlv.DUint64(r, &amt, buf, 8); err != nil {
+				return err
+			}
+
+			amounts[item] = lnwire.MilliSatoshi(amt)
+		}
+		*typ = amounts
+		return nil
+	}
+	return tlv.NewTypeForEncodingErr(
+		val, "*map[lntypes.Hash]lnwire.MilliSatoshi",
+	)
+}
+
+// newTimestampMapRecord returns a new TLV record for encoding the given map of
+// hashes to unix timestamps in nanoseconds.
+func newTimestampMapRecord(tlvType tlv.Type,
+	timestampMap *map[lntypes.Hash]uint64) tlv.Record {
+
+	recordSize := func() uint64 {
+		// We have a 32-byte hash and 8 bytes for the timestamp for
+		// each entry.
+		return uint64(len(*timestampMap) * (lntypes.HashSize + 8))
+	}
+	return tlv.MakeDynamicRecord(
+		tlvType, timestampMap, recordSize, TimestampMapEncoder,
+		TimestampMapDecoder,
+	)
+}
+
+// TimestampMapEncoder encodes a map of hashes to timestamps.
+func TimestampMapEncoder(w io.Writer, val any, buf *[8]byte) error {
+	if t, ok := val.(*map[lntypes.Hash]uint64); ok {
+		if err := tlv.WriteVarInt(w, uint64(len(*t)), buf); err != nil {
+			return err
+		}
+		for hash, timestamp := range *t {
+			hash := [32]byte(hash)
+
+			if err := tlv.EBytes32(w, &hash, buf); err != nil {
+				return err
+			}
+
+			if err := tlv.EUint64T(w, timestamp, buf); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return tlv.NewTypeForEncodingErr(val, "*map[lntypes.Hash]uint64")
+}
+
+// TimestampMapDecoder decodes a map of hashes to timestamps.
+func TimestampMapDecoder(r io.Reader, val any, buf *[8]byte, _ uint64) error {
+	if typ, ok := val.(*map[lntypes.Hash]uint64); ok {
+		numItems, err := tlv.ReadVarInt(r, buf)
+		if err != nil {
+			return err
+		}
+
+		timestamps := make(map[lntypes.Hash]uint64, numItems)
+		for i := uint64(0); i < numItems; i++ {
+			var item [32]byte
+			if err := tlv.DBytes32(r, &item, buf, 32); err != nil {
+				return err
+			}
+
+			var timestamp uint64
+			err := tlv.DUint64(r, &timestamp, buf, 8)
+			if err != nil {
+				return err
+			}
+
+			timestamps[item] = timestamp
+		}
+		*typ = timestamps
+		return nil
+	}
+	return tlv.NewTypeForEncodingErr(val, "*map[lntypes.Hash]uint64")
+}
+
+func serializeLedgerEntry(entry *LedgerEntry) ([]byte, error) {
+	var (
+		buf       bytes.Buffer
+		timestamp = uint64(entry.Timestamp.UnixNano())
+		hash      = [32]byte(entry.InvoiceHash)
+		amount    = uint64(entry.Amount)
+		balance   = uint64(entry.Balance)
+		reason    = uint8(entry.Reason)
+	)
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeLedgerTimestamp, &timestamp),
+		tlv.MakePrimitiveRecord(typeLedgerHash, &hash),
+		tlv.MakePrimitiveRecord(typeLedgerAmount, &amount),
+		tlv.MakePrimitiveRecord(typeLedgerBalance, &balance),
+		tlv.MakePrimitiveRecord(typeLedgerReason, &reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tlvStream.Encode(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func deserializeLedgerEntry(content []byte) (*LedgerEntry, error) {
+	var (
+		r               = bytes.NewReader(content)
+		timestamp       uint64
+		hash            [32]byte
+		amount, balance uint64
+		reason          uint8
+	)
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeLedgerTimestamp, &timestamp),
+		tlv.MakePrimitiveRecord(typeLedgerHash, &hash),
+		tlv.MakePrimitiveRecord(typeLedgerAmount, &amount),
+		tlv.MakePrimitiveRecord(typeLedgerBalance, &balance),
+		tlv.MakePrimitiveRecord(typeLedgerReason, &reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tlvStream.Decode(r); err != nil {
+		return nil, err
+	}
+
+	return &LedgerEntry{
+		Timestamp:   time.Unix(0, int64(timestamp)),
+		InvoiceHash: hash,
+		Amount:      int64(amount),
+		Balance:     int64(balance),
+		Reason:      LedgerReason(reason),
+	}, nil
+}
+
+func serializeAccountRemoval(removal *AccountRemoval) ([]byte, error) {
+	var (
+		buf       bytes.Buffer
+		id        = removal.ID[:]
+		label     = []byte(removal.Label)
+		removedAt = uint64(removal.RemovedAt.UnixNano())
+		removedBy = []byte(removal.RemovedBy)
+		reason    = []byte(removal.Reason)
+	)
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeRemovalID, &id),
+		tlv.MakePrimitiveRecord(typeRemovalLabel, &label),
+		tlv.MakePrimitiveRecord(typeRemovalTime, &removedAt),
+		tlv.MakePrimitiveRecord(typeRemovalRemovedBy, &removedBy),
+		tlv.MakePrimitiveRecord(typeRemovalReason, &reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tlvStream.Encode(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func deserializeAccountRemoval(content []byte) (*AccountRemoval, error) {
+	var (
+		r                 = bytes.NewReader(content)
+		id, label         []byte
+		removedBy, reason []byte
+		removedAt         uint64
+	)
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeRemovalID, &id),
+		tlv.MakePrimitiveRecord(typeRemovalLabel, &label),
+		tlv.MakePrimitiveRecord(typeRemovalTime, &removedAt),
+		tlv.MakePrimitiveRecord(typeRemovalRemovedBy, &removedBy),
+		tlv.MakePrimitiveRecord(typeRemovalReason, &reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tlvStream.Decode(r); err != nil {
+		return nil, err
+	}
+
+	removal := &AccountRemoval{
+		Label:     string(label),
+		RemovedAt: time.Unix(0, int64(removedAt)),
+		RemovedBy: string(removedBy),
+		Reason:    string(reason),
+	}
+	copy(removal.ID[:], id)
+
+	return removal, nil
+}
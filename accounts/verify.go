@@ -0,0 +1,63 @@
+package accounts
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"go.etcd.io/bbolt"
+)
+
+// VerifyRecords decodes all accounts, account removals and invoice indexes of
+// the accounts database.
+func VerifyRecords(tx *bbolt.Tx, c *dbverify.Checker) error {
+	if bucket := tx.Bucket(accountBucketName); bucket != nil {
+		path := [][]byte{accountBucketName}
+		err := bucket.ForEach(func(k, v []byte) error {
+			c.Check(path, k, func() error {
+				switch {
+				case v == nil:
+					return fmt.Errorf("unexpected bucket")
+
+				case bytes.Equal(k, lastAddIndexKey) ||
+					bytes.Equal(k, lastSettleIndexKey):
+
+					if len(v) != 8 {
+						return fmt.Errorf("invalid "+
+							"index length %d",
+							len(v))
+					}
+
+					return nil
+				}
+
+				_, err := deserializeAccount(v)
+				return err
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	bucket := tx.Bucket(removedAccountsBucketName)
+	if bucket == nil {
+		return nil
+	}
+
+	path := [][]byte{removedAccountsBucketName}
+	return bucket.ForEach(func(k, v []byte) error {
+		c.Check(path, k, func() error {
+			if v == nil {
+				return fmt.Errorf("unexpected bucket")
+			}
+
+			_, err := deserializeAccountRemoval(v)
+			return err
+		})
+
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package adminauth
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ModeNone disables signed admin actions.
+	ModeNone = "none"
+
+	// ModeNode requires the challenges to be signed with lnd's identity
+	// key.
+	ModeNode = "node"
+
+	// ModeKey requires the challenges to be signed with a dedicated admin
+	// key.
+	ModeKey = "key"
+
+	// defaultChallengeExpiry is the default time a challenge can be used
+	// after it was created.
+	defaultChallengeExpiry = 5 * time.Minute
+)
+
+// Config holds the options of the signed admin actions.
+type Config struct {
+	Mode            string        `long:"mode" description:"The key that must sign a challenge before a destructive admin RPC is executed. 'none' disables signed admin actions, 'node' requires a signature by lnd's identity key and 'key' one by the key set with adminkey." choice:"none" choice:"node" choice:"key"`
+	AdminKey        string        `long:"adminkey" description:"The hex encoded public key that must sign the challenges in the 'key' mode."`
+	ChallengeExpiry time.Duration `long:"challengeexpiry" description:"How long a challenge can be used after it was created."`
+}
+
+// DefaultConfig returns the default config of the signed admin actions.
+func DefaultConfig() *Config {
+	return &Config{
+		Mode:            ModeNone,
+		ChallengeExpiry: defaultChallengeExpiry,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	switch c.Mode {
+	case ModeNone, ModeNode:
+		if c.AdminKey != "" {
+			return fmt.Errorf("adminauth.adminkey can only be " +
+				"set in the 'key' mode")
+		}
+
+	case ModeKey:
+		if _, err := parsePubKey(c.AdminKey); err != nil {
+			return fmt.Errorf("invalid adminauth.adminkey: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown adminauth.mode '%s'", c.Mode)
+	}
+
+	if c.ChallengeExpiry <= 0 {
+		return fmt.Errorf("adminauth.challengeexpiry must be positive")
+	}
+
+	return nil
+}
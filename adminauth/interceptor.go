@@ -0,0 +1,42 @@
+package adminauth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor is a gRPC unary interceptor that rejects calls of the
+// destructive RPCs that don't carry a valid signed challenge. It must run
+// after the authentication, so that unauthenticated callers can't use up the
+// challenges.
+func (v *Verifier) UnaryServerInterceptor(ctx context.Context,
+	req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	if err := v.Verify(ctx, info.FullMethod); err != nil {
+		log.Debugf("Rejecting call to %s: %v", info.FullMethod, err)
+
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor is a gRPC stream interceptor that rejects calls of
+// the destructive RPCs that don't carry a valid signed challenge. The RPCs
+// that are proxied to lnd are handled as streams, so this covers them.
+func (v *Verifier) StreamServerInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if err := v.Verify(ss.Context(), info.FullMethod); err != nil {
+		log.Debugf("Rejecting call to %s: %v", info.FullMethod, err)
+
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return handler(srv, ss)
+}
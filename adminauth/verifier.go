@@ -0,0 +1,270 @@
+package adminauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/tv42/zbase32"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// HeaderChallenge is the gRPC metadata field the challenge of a signed
+	// admin action is sent in.
+	HeaderChallenge = "lit-admin-challenge"
+
+	// HeaderSignature is the gRPC metadata field the zbase32 encoded
+	// signature of a signed admin action is sent in.
+	HeaderSignature = "lit-admin-signature"
+
+	// challengeLen is the length of a challenge in bytes.
+	challengeLen = 32
+
+	// maxChallenges is the maximum number of challenges that can be
+	// outstanding at the same time.
+	maxChallenges = 1000
+
+	// signedMsgPrefix is the prefix lnd's SignMessage RPC prepends to all
+	// messages it signs. We use the same format so that the challenges
+	// can be signed with lncli signmessage.
+	signedMsgPrefix = "Lightning Signed Message:"
+)
+
+var (
+	// guardedMethods are the full URIs of the destructive RPCs that need a
+	// signed challenge if signed admin actions are enabled. Besides the
+	// RPCs that remove accounts or revoke sessions directly, this includes
+	// those that do so in bulk and those that bake admin macaroons.
+	guardedMethods = map[string]struct{}{
+		"/litrpc.Accounts/RemoveAccount":           {},
+		"/litrpc.Accounts/CleanupAccounts":         {},
+		"/litrpc.Sessions/RevokeSession":           {},
+		"/litrpc.Autopilot/RevokeAutopilotSession": {},
+		"/litrpc.Provisioning/ApplySpec":           {},
+		"/litrpc.ApiKeys/CreateApiKey":             {},
+		"/litrpc.Proxy/GetConnectURI":              {},
+		"/lnrpc.Lightning/BakeMacaroon":            {},
+	}
+
+	// ErrSignatureRequired is returned if a guarded RPC is called without
+	// a signed challenge.
+	ErrSignatureRequired = errors.New("this RPC requires a signed admin " +
+		"challenge")
+
+	// ErrInvalidChallenge is returned if the challenge of a call is
+	// unknown, was already used, expired or was created for a different
+	// RPC.
+	ErrInvalidChallenge = errors.New("unknown, used or expired admin " +
+		"challenge")
+
+	// ErrInvalidSignature is returned if the signature of a call wasn't
+	// created by the admin key.
+	ErrInvalidSignature = errors.New("invalid admin signature")
+)
+
+// Guarded returns true if the RPC with the given full URI is a destructive
+// RPC that needs a signed challenge.
+func Guarded(method string) bool {
+	_, ok := guardedMethods[method]
+	return ok
+}
+
+// Message returns the message that must be signed to authorize the call of
+// the given RPC with the given hex encoded challenge.
+func Message(method, challenge string) string {
+	return fmt.Sprintf("lit-admin-action:%s:%s", method, challenge)
+}
+
+// Challenge is a single use challenge for a destructive RPC.
+type Challenge struct {
+	// Challenge is the hex encoded challenge.
+	Challenge string
+
+	// Method is the full URI of the RPC the challenge is for.
+	Method string
+
+	// PubKey is the key the challenge must be signed with.
+	PubKey *btcec.PublicKey
+
+	// ExpiresAt is the time after which the challenge can't be used
+	// anymore.
+	ExpiresAt time.Time
+
+	// Required is false if signed admin actions are disabled. The
+	// challenge is empty in that case.
+	Required bool
+}
+
+// Verifier hands out challenges for the destructive admin RPCs and makes sure
+// they are only executed if the call carries a challenge that was signed by
+// the admin key. This protects against stolen admin macaroons, as the key
+// doesn't need to be present on the machine that calls the RPCs.
+type Verifier struct {
+	cfg *Config
+
+	// adminKey is the key the challenges must be signed with in the 'key'
+	// mode.
+	adminKey *btcec.PublicKey
+
+	// nodeKey returns lnd's identity key, which the challenges must be
+	// signed with in the 'node' mode.
+	nodeKey func() (*btcec.PublicKey, error)
+
+	// now returns the current time, it can be overwritten in tests.
+	now func() time.Time
+
+	// mu guards the challenges.
+	mu         sync.Mutex
+	challenges map[string]*Challenge
+}
+
+// NewVerifier creates a new verifier for the signed admin actions. The
+// function that returns lnd's identity key is only called once a challenge
+// is created or verified in the 'node' mode.
+func NewVerifier(cfg *Config,
+	nodeKey func() (*btcec.PublicKey, error)) (*Verifier, error) {
+
+	v := &Verifier{
+		cfg:        cfg,
+		nodeKey:    nodeKey,
+		now:        time.Now,
+		challenges: make(map[string]*Challenge),
+	}
+
+	if cfg.Mode == ModeKey {
+		adminKey, err := parsePubKey(cfg.AdminKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin key: %v", err)
+		}
+		v.adminKey = adminKey
+	}
+
+	return v, nil
+}
+
+// Enabled returns true if the destructive RPCs require a signed challenge.
+func (v *Verifier) Enabled() bool {
+	return v.cfg.Mode != ModeNone
+}
+
+// pubKey returns the key the challenges must be signed with.
+func (v *Verifier) pubKey() (*btcec.PublicKey, error) {
+	if v.cfg.Mode == ModeKey {
+		return v.adminKey, nil
+	}
+
+	pubKey, err := v.nodeKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get node identity key: %v",
+			err)
+	}
+
+	return pubKey, nil
+}
+
+// NewChallenge creates a single use challenge for the RPC with the given full
+// URI.
+func (v *Verifier) NewChallenge(method string) (*Challenge, error) {
+	if !Guarded(method) {
+		return nil, fmt.Errorf("%s doesn't need a signed admin "+
+			"challenge", method)
+	}
+
+	if !v.Enabled() {
+		return &Challenge{
+			Method: method,
+		}, nil
+	}
+
+	pubKey, err := v.pubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge [challengeLen]byte
+	if _, err := rand.Read(challenge[:]); err != nil {
+		return nil, fmt.Errorf("unable to create challenge: %v", err)
+	}
+
+	now := v.now()
+	c := &Challenge{
+		Challenge: hex.EncodeToString(challenge[:]),
+		Method:    method,
+		PubKey:    pubKey,
+		ExpiresAt: now.Add(v.cfg.ChallengeExpiry),
+		Required:  true,
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for id, outstanding := range v.challenges {
+		if !now.Before(outstanding.ExpiresAt) {
+			delete(v.challenges, id)
+		}
+	}
+
+	if len(v.challenges) >= maxChallenges {
+		return nil, fmt.Errorf("too many outstanding admin challenges")
+	}
+	v.challenges[c.Challenge] = c
+
+	return c, nil
+}
+
+// Verify makes sure that a call of the RPC with the given full URI carries a
+// signed challenge if the RPC needs one. A challenge can only be used once,
+// even if the signature turns out to be invalid.
+func (v *Verifier) Verify(ctx context.Context, method string) error {
+	if !v.Enabled() || !Guarded(method) {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	challenges, sigs := md.Get(HeaderChallenge), md.Get(HeaderSignature)
+	if len(challenges) != 1 || len(sigs) != 1 {
+		return ErrSignatureRequired
+	}
+
+	v.mu.Lock()
+	c, ok := v.challenges[challenges[0]]
+	delete(v.challenges, challenges[0])
+	v.mu.Unlock()
+
+	if !ok || c.Method != method || !v.now().Before(c.ExpiresAt) {
+		return ErrInvalidChallenge
+	}
+
+	sig, err := zbase32.DecodeString(sigs[0])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	msg := []byte(signedMsgPrefix + Message(method, c.Challenge))
+	pubKey, _, err := ecdsa.RecoverCompact(sig, chainhash.DoubleHashB(msg))
+	if err != nil || !pubKey.IsEqual(c.PubKey) {
+		return ErrInvalidSignature
+	}
+
+	log.Infof("Verified admin signature for call to %s", method)
+
+	return nil
+}
+
+// parsePubKey parses a hex encoded public key.
+func parsePubKey(pubKeyHex string) (*btcec.PublicKey, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return btcec.ParsePubKey(pubKeyBytes)
+}
@@ -0,0 +1,136 @@
+package adminauth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tv42/zbase32"
+	"google.golang.org/grpc/metadata"
+)
+
+const revokeSession = "/litrpc.Sessions/RevokeSession"
+
+// sign signs the message of a challenge the same way lnd's SignMessage RPC
+// does.
+func sign(t *testing.T, key *btcec.PrivateKey, c *Challenge) string {
+	msg := []byte(signedMsgPrefix + Message(c.Method, c.Challenge))
+	sig, err := ecdsa.SignCompact(key, chainhash.DoubleHashB(msg), true)
+	require.NoError(t, err)
+
+	return zbase32.EncodeToString(sig)
+}
+
+// signedCtx returns a context that carries the given challenge and signature.
+func signedCtx(challenge, sig string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		HeaderChallenge, challenge, HeaderSignature, sig,
+	))
+}
+
+// TestVerifier tests that the guarded RPCs are only allowed with a valid
+// signature of a fresh challenge.
+func TestVerifier(t *testing.T) {
+	t.Parallel()
+
+	adminKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	cfg := DefaultConfig()
+	cfg.Mode = ModeKey
+	cfg.AdminKey = hex.EncodeToString(
+		adminKey.PubKey().SerializeCompressed(),
+	)
+	require.NoError(t, cfg.Validate())
+
+	v, err := NewVerifier(cfg, func() (*btcec.PublicKey, error) {
+		return nil, errors.New("no node key in key mode")
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	v.now = func() time.Time {
+		return now
+	}
+
+	// Only the destructive RPCs need a challenge.
+	_, err = v.NewChallenge("/litrpc.Sessions/ListSessions")
+	require.Error(t, err)
+	require.NoError(t, v.Verify(
+		context.Background(), "/litrpc.Sessions/ListSessions",
+	))
+	require.ErrorIs(
+		t, v.Verify(context.Background(), revokeSession),
+		ErrSignatureRequired,
+	)
+
+	// The RPCs that remove accounts or revoke sessions in bulk and those
+	// that bake admin macaroons are guarded as well.
+	for _, method := range []string{
+		"/litrpc.Accounts/CleanupAccounts",
+		"/litrpc.Provisioning/ApplySpec",
+		"/litrpc.ApiKeys/CreateApiKey",
+		"/litrpc.Proxy/GetConnectURI",
+	} {
+		require.ErrorIs(
+			t, v.Verify(context.Background(), method),
+			ErrSignatureRequired,
+		)
+	}
+
+	// A valid signature can only be used once.
+	c, err := v.NewChallenge(revokeSession)
+	require.NoError(t, err)
+	require.True(t, c.Required)
+	require.True(t, c.PubKey.IsEqual(adminKey.PubKey()))
+
+	ctx := signedCtx(c.Challenge, sign(t, adminKey, c))
+	require.NoError(t, v.Verify(ctx, revokeSession))
+	require.ErrorIs(t, v.Verify(ctx, revokeSession), ErrInvalidChallenge)
+
+	// A challenge is bound to its RPC.
+	c, err = v.NewChallenge(revokeSession)
+	require.NoError(t, err)
+	ctx = signedCtx(c.Challenge, sign(t, adminKey, c))
+	require.ErrorIs(
+		t, v.Verify(ctx, "/litrpc.Accounts/RemoveAccount"),
+		ErrInvalidChallenge,
+	)
+
+	// Signatures of other keys are rejected.
+	c, err = v.NewChallenge(revokeSession)
+	require.NoError(t, err)
+	ctx = signedCtx(c.Challenge, sign(t, otherKey, c))
+	require.ErrorIs(t, v.Verify(ctx, revokeSession), ErrInvalidSignature)
+
+	// Expired challenges are rejected.
+	c, err = v.NewChallenge(revokeSession)
+	require.NoError(t, err)
+	now = now.Add(cfg.ChallengeExpiry)
+	ctx = signedCtx(c.Challenge, sign(t, adminKey, c))
+	require.ErrorIs(t, v.Verify(ctx, revokeSession), ErrInvalidChallenge)
+}
+
+// TestVerifierDisabled tests that no signature is needed if signed admin
+// actions are disabled.
+func TestVerifierDisabled(t *testing.T) {
+	t.Parallel()
+
+	v, err := NewVerifier(DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	c, err := v.NewChallenge(revokeSession)
+	require.NoError(t, err)
+	require.False(t, c.Required)
+	require.Empty(t, c.Challenge)
+
+	require.NoError(t, v.Verify(context.Background(), revokeSession))
+}
@@ -0,0 +1,458 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// KeyPrefix is the prefix of all API keys. It distinguishes API keys
+	// from other bearer tokens.
+	KeyPrefix = "litkey_"
+
+	// idLen is the length of a key ID in bytes.
+	idLen = 8
+
+	// secretLen is the length of a key secret in bytes.
+	secretLen = 32
+
+	// idMarker is the first byte of the ID of every new key. The root key
+	// ID of a key's macaroon is derived from the first four bytes of its
+	// ID, just like the one of a session's macaroon is derived from the
+	// first four bytes of the session's compressed public key. Those
+	// always start with 0x02 or 0x03, so the root keys of keys and
+	// sessions never collide.
+	idMarker = 0xaa
+
+	// maxIDAttempts is the number of random IDs that are tried before
+	// giving up on finding one with an unused root key ID.
+	maxIDAttempts = 10
+)
+
+var (
+	// ErrInvalidKey is returned if an API key is malformed, unknown,
+	// expired or its secret doesn't match.
+	ErrInvalidKey = errors.New("invalid api key")
+
+	// encryptionKeyDomain separates the key the macaroon is encrypted
+	// with from the hash of the secret that is stored in the db.
+	encryptionKeyDomain = []byte("lit-apikey-macaroon")
+)
+
+// Preset is a set of permissions an API key grants.
+type Preset string
+
+const (
+	// PresetAdmin grants all permissions of all active daemons.
+	PresetAdmin Preset = "admin"
+
+	// PresetReadOnly grants all read permissions of all active daemons.
+	PresetReadOnly Preset = "readonly"
+
+	// PresetAccount grants the permissions needed to use an account. A
+	// key with this preset must be locked to an account.
+	PresetAccount Preset = "account"
+)
+
+// AccountService is the subset of the account service the manager looks up
+// the accounts with.
+type AccountService interface {
+	// Account retrieves the account with the given ID.
+	Account(id accounts.AccountID) (*accounts.OffChainBalanceAccount,
+		error)
+}
+
+// RootKeyDeleter deletes the macaroon root key with the given ID, so that the
+// macaroons that were baked with it are no longer accepted.
+type RootKeyDeleter func(ctx context.Context, rootKeyID uint64) error
+
+// Manager manages API keys. An API key is an alternative to a macaroon for
+// integrators that can't easily handle macaroons: it wraps a macaroon that is
+// baked when the key is created and is unlocked with the key's secret for each
+// request. The macaroon is then validated like any other, so all restrictions,
+// for example of an account, apply to the API key as well.
+type Manager struct {
+	dir         string
+	baker       session.MacaroonBaker
+	deleter     RootKeyDeleter
+	activePerms func(readOnly bool) []bakery.Op
+	accounts    AccountService
+
+	store *Store
+
+	// started is set once the store is opened and the keys can be used.
+	started atomic.Bool
+}
+
+// NewManager creates a new API key manager that stores its data in the given
+// directory. The macaroons of the keys are baked with the given baker and
+// their root keys are deleted with the given deleter once a key is revoked.
+func NewManager(dir string, baker session.MacaroonBaker,
+	deleter RootKeyDeleter, activePerms func(readOnly bool) []bakery.Op,
+	accts AccountService) *Manager {
+
+	return &Manager{
+		dir:         dir,
+		baker:       baker,
+		deleter:     deleter,
+		activePerms: activePerms,
+		accounts:    accts,
+	}
+}
+
+// Start opens the API key store.
+func (m *Manager) Start() error {
+	store, err := NewStore(m.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open api key store: %v", err)
+	}
+
+	m.store = store
+	m.started.Store(true)
+
+	return nil
+}
+
+// Stop closes the API key store.
+func (m *Manager) Stop() error {
+	if !m.started.Load() {
+		return nil
+	}
+	m.started.Store(false)
+
+	return m.store.Close()
+}
+
+// CreateKey creates a new API key with the given preset that is optionally
+// locked to the given account. The returned API key contains the secret and
+// can only be retrieved once.
+func (m *Manager) CreateKey(ctx context.Context, label string, preset Preset,
+	accountID *accounts.AccountID, expiresAt time.Time) (*Key, string,
+	error) {
+
+	if !m.started.Load() {
+		return nil, "", fmt.Errorf("api key manager not started")
+	}
+
+	var perms []bakery.Op
+	switch preset {
+	case PresetAdmin:
+		perms = m.activePerms(false)
+
+	case PresetReadOnly:
+		perms = m.activePerms(true)
+
+	case PresetAccount:
+		if accountID == nil {
+			return nil, "", fmt.Errorf("keys with the %s preset "+
+				"must be locked to an account", preset)
+		}
+		perms = accounts.MacaroonPermissions
+
+	default:
+		return nil, "", fmt.Errorf("unknown preset %s", preset)
+	}
+
+	if !expiresAt.IsZero() && expiresAt.Before(time.Now()) {
+		return nil, "", fmt.Errorf("expiry must be in the future")
+	}
+
+	id, err := m.newID()
+	if err != nil {
+		return nil, "", err
+	}
+	secret := make([]byte, secretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+
+	key := &Key{
+		ID:        hex.EncodeToString(id[:]),
+		Label:     label,
+		Preset:    preset,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	var caveats []macaroon.Caveat
+	if accountID != nil {
+		if _, err := m.accounts.Account(*accountID); err != nil {
+			return nil, "", err
+		}
+
+		key.AccountID = hex.EncodeToString(accountID[:])
+
+		cav := checkers.Condition(macaroons.CondLndCustom, fmt.Sprintf(
+			"%s %x", accounts.CondAccount, accountID[:],
+		))
+		caveats = append(caveats, macaroon.Caveat{Id: []byte(cav)})
+	}
+
+	macHex, err := m.baker(
+		ctx, rootKeyID(id), &session.MacaroonRecipe{
+			Permissions: perms,
+			Caveats:     caveats,
+		},
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("error baking api key macaroon: %v",
+			err)
+	}
+
+	macBytes, err := hex.DecodeString(macHex)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secretHash := sha256.Sum256(secret)
+	key.SecretHash = secretHash[:]
+	key.EncryptedMacaroon, err = encryptMacaroon(secret, macBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := m.store.AddKey(key); err != nil {
+		return nil, "", err
+	}
+
+	return key, KeyPrefix + key.ID + "_" + hex.EncodeToString(secret), nil
+}
+
+// newID returns a random key ID whose macaroon root key ID isn't used by any
+// other key yet.
+func (m *Manager) newID() ([idLen]byte, error) {
+	var id [idLen]byte
+
+	keys, err := m.store.Keys()
+	if err != nil {
+		return id, err
+	}
+
+	for i := 0; i < maxIDAttempts; i++ {
+		if _, err := rand.Read(id[:]); err != nil {
+			return id, err
+		}
+		id[0] = idMarker
+
+		if !rootKeyIDUsed(keys, rootKeyID(id), "") {
+			return id, nil
+		}
+	}
+
+	return id, fmt.Errorf("unable to find an unused key ID")
+}
+
+// rootKeyID returns the ID of the root key the macaroon of the key with the
+// given ID is baked with.
+func rootKeyID(id [idLen]byte) uint64 {
+	var rootKeyIDSuffix [4]byte
+	copy(rootKeyIDSuffix[:], id[:])
+
+	return session.NewSuperMacaroonRootKeyID(rootKeyIDSuffix)
+}
+
+// parseID decodes the given hex encoded key ID.
+func parseID(hexID string) ([idLen]byte, error) {
+	var id [idLen]byte
+
+	idBytes, err := hex.DecodeString(hexID)
+	if err != nil || len(idBytes) != idLen {
+		return id, fmt.Errorf("invalid key ID %s", hexID)
+	}
+	copy(id[:], idBytes)
+
+	return id, nil
+}
+
+// rootKeyIDUsed returns true if any of the given keys, except for the one with
+// the given ID, has a macaroon that is baked with the given root key ID.
+func rootKeyIDUsed(keys []*Key, rootKey uint64, exceptID string) bool {
+	for _, key := range keys {
+		if key.ID == exceptID {
+			continue
+		}
+
+		id, err := parseID(key.ID)
+		if err == nil && rootKeyID(id) == rootKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Keys returns all API keys.
+func (m *Manager) Keys() ([]*Key, error) {
+	if !m.started.Load() {
+		return nil, fmt.Errorf("api key manager not started")
+	}
+
+	return m.store.Keys()
+}
+
+// RevokeKey removes the API key with the given ID so that it is no longer
+// accepted. The root key of its macaroon is deleted as well, so the macaroon
+// itself is no longer accepted either. Keys created before their IDs were
+// marked might share their root key with another key, in which case the root
+// key is kept.
+func (m *Manager) RevokeKey(ctx context.Context, id string) error {
+	if !m.started.Load() {
+		return fmt.Errorf("api key manager not started")
+	}
+
+	if _, err := m.store.Key(id); err != nil {
+		return err
+	}
+
+	keyID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+
+	keys, err := m.store.Keys()
+	if err != nil {
+		return err
+	}
+
+	rootKey := rootKeyID(keyID)
+	if !rootKeyIDUsed(keys, rootKey, id) {
+		if err := m.deleter(ctx, rootKey); err != nil {
+			return fmt.Errorf("error deleting macaroon root key: %v",
+				err)
+		}
+	}
+
+	return m.store.RemoveKey(id)
+}
+
+// IsAPIKey returns true if the given authorization header carries an API key.
+func IsAPIKey(authHeader string) bool {
+	parts := strings.Split(authHeader, " ")
+	return len(parts) == 2 && strings.EqualFold(parts[0], "bearer") &&
+		strings.HasPrefix(parts[1], KeyPrefix)
+}
+
+// parseAPIKey splits the API key in the given authorization header into its
+// ID and secret.
+func parseAPIKey(authHeader string) (string, []byte, error) {
+	if !IsAPIKey(authHeader) {
+		return "", nil, ErrInvalidKey
+	}
+
+	apiKey := strings.TrimPrefix(strings.Split(authHeader, " ")[1], KeyPrefix)
+	parts := strings.Split(apiKey, "_")
+	if len(parts) != 2 {
+		return "", nil, ErrInvalidKey
+	}
+
+	secret, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, ErrInvalidKey
+	}
+
+	return parts[0], secret, nil
+}
+
+// KeyID returns the ID of the API key in the given authorization header. It
+// doesn't check whether the key is valid.
+func KeyID(authHeader string) (string, error) {
+	id, _, err := parseAPIKey(authHeader)
+	return id, err
+}
+
+// Macaroon returns the macaroon the API key in the given authorization header
+// grants access with.
+func (m *Manager) Macaroon(authHeader string) ([]byte, error) {
+	if !m.started.Load() {
+		return nil, ErrInvalidKey
+	}
+
+	id, secret, err := parseAPIKey(authHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := m.store.Key(id)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	secretHash := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare(secretHash[:], key.SecretHash) != 1 {
+		return nil, ErrInvalidKey
+	}
+
+	if key.HasExpired() {
+		return nil, fmt.Errorf("%w: key expired", ErrInvalidKey)
+	}
+
+	return decryptMacaroon(secret, key.EncryptedMacaroon)
+}
+
+// newCipher creates the AEAD the macaroon of a key is encrypted with.
+func newCipher(secret []byte) (cipher.AEAD, error) {
+	h := sha256.New()
+	_, _ = h.Write(encryptionKeyDomain)
+	_, _ = h.Write(secret)
+
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptMacaroon encrypts the given macaroon with a key derived from the
+// given secret.
+func encryptMacaroon(secret, mac []byte) ([]byte, error) {
+	aead, err := newCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, mac, nil), nil
+}
+
+// decryptMacaroon decrypts the given encrypted macaroon with a key derived
+// from the given secret.
+func decryptMacaroon(secret, encrypted []byte) ([]byte, error) {
+	aead, err := newCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encrypted) < aead.NonceSize() {
+		return nil, ErrInvalidKey
+	}
+
+	nonce := encrypted[:aead.NonceSize()]
+	mac, err := aead.Open(nil, nonce, encrypted[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return mac, nil
+}
@@ -0,0 +1,232 @@
+package apikeys
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+var testOps = []bakery.Op{{Entity: "info", Action: "read"}}
+
+type mockAccounts struct {
+	accounts map[accounts.AccountID]*accounts.OffChainBalanceAccount
+}
+
+func (m *mockAccounts) Account(
+	id accounts.AccountID) (*accounts.OffChainBalanceAccount, error) {
+
+	acct, ok := m.accounts[id]
+	if !ok {
+		return nil, accounts.ErrAccNotFound
+	}
+
+	return acct, nil
+}
+
+// mockBaker bakes a macaroon that records the root key ID and caveats of the
+// recipe it was baked with.
+func mockBaker(_ context.Context, rootKeyID uint64,
+	recipe *session.MacaroonRecipe) (string, error) {
+
+	idProto, err := proto.Marshal(&lnrpc.MacaroonId{
+		StorageId: []byte(strconv.FormatUint(rootKeyID, 10)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	rawID := make([]byte, len(idProto)+1)
+	rawID[0] = byte(bakery.LatestVersion)
+	copy(rawID[1:], idProto)
+
+	mac, err := macaroon.New(
+		[]byte("root-key"), rawID, "lnd", macaroon.LatestVersion,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, cav := range recipe.Caveats {
+		if err := mac.AddFirstPartyCaveat(cav.Id); err != nil {
+			return "", err
+		}
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(macBytes), nil
+}
+
+// mockDeleter records the root key IDs it was asked to delete.
+type mockDeleter struct {
+	deleted []uint64
+}
+
+func (m *mockDeleter) deleteRootKey(_ context.Context, rootKeyID uint64) error {
+	m.deleted = append(m.deleted, rootKeyID)
+
+	return nil
+}
+
+func newTestManager(t *testing.T, accts *mockAccounts) (*Manager,
+	*mockDeleter) {
+
+	activePerms := func(bool) []bakery.Op {
+		return testOps
+	}
+
+	deleter := &mockDeleter{}
+	m := NewManager(
+		t.TempDir(), mockBaker, deleter.deleteRootKey, activePerms,
+		accts,
+	)
+	require.NoError(t, m.Start())
+	t.Cleanup(func() {
+		require.NoError(t, m.Stop())
+	})
+
+	return m, deleter
+}
+
+// TestCreateKey tests that an API key unlocks the macaroon it was created
+// with and that it can be revoked.
+func TestCreateKey(t *testing.T) {
+	m, deleter := newTestManager(t, &mockAccounts{})
+	ctx := context.Background()
+
+	key, apiKey, err := m.CreateKey(
+		ctx, "shop", PresetReadOnly, nil, time.Time{},
+	)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(apiKey, KeyPrefix))
+	require.True(t, IsAPIKey("Bearer "+apiKey))
+	require.False(t, IsAPIKey("Bearer some-oidc-token"))
+
+	macBytes, err := m.Macaroon("Bearer " + apiKey)
+	require.NoError(t, err)
+
+	var mac macaroon.Macaroon
+	require.NoError(t, mac.UnmarshalBinary(macBytes))
+	require.True(t, session.IsSuperMacaroon(hex.EncodeToString(macBytes)))
+
+	// A key with a wrong secret is rejected.
+	wrongSecret := KeyPrefix + key.ID + "_" + strings.Repeat("00", secretLen)
+	_, err = m.Macaroon("Bearer " + wrongSecret)
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	// A malformed key is rejected.
+	_, err = m.Macaroon("Bearer " + KeyPrefix + key.ID)
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	keys, err := m.Keys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, "shop", keys[0].Label)
+
+	// The root key of the macaroon can't be the one of a session, as the
+	// IDs of sessions are taken from compressed public keys.
+	rootKeyID, err := session.RootKeyIDFromMacaroon(&mac)
+	require.NoError(t, err)
+	sessionID := session.IDFromMacRootKeyID(rootKeyID)
+	require.EqualValues(t, idMarker, sessionID[0])
+
+	// A revoked key is rejected and the root key of its macaroon is
+	// deleted.
+	require.NoError(t, m.RevokeKey(ctx, key.ID))
+	_, err = m.Macaroon("Bearer " + apiKey)
+	require.ErrorIs(t, err, ErrInvalidKey)
+	require.Equal(t, []uint64{rootKeyID}, deleter.deleted)
+
+	require.ErrorIs(t, m.RevokeKey(ctx, key.ID), ErrKeyNotFound)
+
+	// The root key of a key that shares it with another key is kept when
+	// only one of them is revoked.
+	for _, id := range []string{"0102030405060708", "0102030408070605"} {
+		require.NoError(t, m.store.AddKey(&Key{
+			ID:        id,
+			CreatedAt: time.Now(),
+		}))
+	}
+	require.NoError(t, m.RevokeKey(ctx, "0102030405060708"))
+	require.Len(t, deleter.deleted, 1)
+	require.NoError(t, m.RevokeKey(ctx, "0102030408070605"))
+	require.Len(t, deleter.deleted, 2)
+}
+
+// TestExpiredKey tests that an expired API key is rejected.
+func TestExpiredKey(t *testing.T) {
+	m, _ := newTestManager(t, &mockAccounts{})
+	ctx := context.Background()
+
+	_, _, err := m.CreateKey(
+		ctx, "", PresetAdmin, nil, time.Now().Add(-time.Minute),
+	)
+	require.Error(t, err)
+
+	key, apiKey, err := m.CreateKey(
+		ctx, "", PresetAdmin, nil, time.Now().Add(time.Hour),
+	)
+	require.NoError(t, err)
+
+	_, err = m.Macaroon("Bearer " + apiKey)
+	require.NoError(t, err)
+
+	key.ExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, m.store.AddKey(key))
+
+	_, err = m.Macaroon("Bearer " + apiKey)
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+// TestAccountKey tests that a key with the account preset is locked to an
+// existing account.
+func TestAccountKey(t *testing.T) {
+	acctID := accounts.AccountID{1, 2, 3, 4, 5, 6, 7, 8}
+	m, _ := newTestManager(t, &mockAccounts{
+		accounts: map[accounts.AccountID]*accounts.OffChainBalanceAccount{
+			acctID: {ID: acctID},
+		},
+	})
+	ctx := context.Background()
+
+	// The account preset requires an account.
+	_, _, err := m.CreateKey(ctx, "", PresetAccount, nil, time.Time{})
+	require.Error(t, err)
+
+	// The account must exist.
+	unknownID := accounts.AccountID{8, 7, 6, 5, 4, 3, 2, 1}
+	_, _, err = m.CreateKey(
+		ctx, "", PresetAccount, &unknownID, time.Time{},
+	)
+	require.ErrorIs(t, err, accounts.ErrAccNotFound)
+
+	key, apiKey, err := m.CreateKey(
+		ctx, "", PresetAccount, &acctID, time.Time{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(acctID[:]), key.AccountID)
+
+	macBytes, err := m.Macaroon("Bearer " + apiKey)
+	require.NoError(t, err)
+
+	var mac macaroon.Macaroon
+	require.NoError(t, mac.UnmarshalBinary(macBytes))
+	require.Len(t, mac.Caveats(), 1)
+	require.Contains(
+		t, string(mac.Caveats()[0].Id), hex.EncodeToString(acctID[:]),
+	)
+}
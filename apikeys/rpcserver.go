@@ -0,0 +1,146 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the ApiKeys gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedApiKeysServer
+
+	manager *Manager
+}
+
+// NewRPCServer returns a new RPC server for the given API key manager.
+func NewRPCServer(manager *Manager) *RPCServer {
+	return &RPCServer{
+		manager: manager,
+	}
+}
+
+// CreateApiKey creates a new API key.
+func (s *RPCServer) CreateApiKey(ctx context.Context,
+	req *litrpc.CreateApiKeyRequest) (*litrpc.CreateApiKeyResponse, error) {
+
+	log.Infof("[createapikey] label=%s, preset=%v, account_id=%s, "+
+		"expiration_date=%d", req.Label, req.Preset, req.AccountId,
+		req.ExpirationDate)
+
+	preset, err := unmarshalPreset(req.Preset)
+	if err != nil {
+		return nil, err
+	}
+
+	var accountID *accounts.AccountID
+	if req.AccountId != "" {
+		accountID, err = accounts.ParseAccountID(req.AccountId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var expiresAt time.Time
+	if req.ExpirationDate > 0 {
+		expiresAt = time.Unix(req.ExpirationDate, 0)
+	}
+
+	key, apiKey, err := s.manager.CreateKey(
+		ctx, req.Label, preset, accountID, expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.CreateApiKeyResponse{
+		ApiKey: marshalKey(key),
+		Key:    apiKey,
+	}, nil
+}
+
+// ListApiKeys returns all API keys.
+func (s *RPCServer) ListApiKeys(_ context.Context,
+	_ *litrpc.ListApiKeysRequest) (*litrpc.ListApiKeysResponse, error) {
+
+	log.Info("[listapikeys]")
+
+	keys, err := s.manager.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListApiKeysResponse{
+		ApiKeys: make([]*litrpc.ApiKey, len(keys)),
+	}
+	for i, key := range keys {
+		resp.ApiKeys[i] = marshalKey(key)
+	}
+
+	return resp, nil
+}
+
+// RevokeApiKey revokes the given API key.
+func (s *RPCServer) RevokeApiKey(ctx context.Context,
+	req *litrpc.RevokeApiKeyRequest) (*litrpc.RevokeApiKeyResponse, error) {
+
+	log.Infof("[revokeapikey] id=%s", req.Id)
+
+	if err := s.manager.RevokeKey(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RevokeApiKeyResponse{}, nil
+}
+
+// marshalKey converts a key into its RPC counterpart.
+func marshalKey(key *Key) *litrpc.ApiKey {
+	rpcKey := &litrpc.ApiKey{
+		Id:        key.ID,
+		Label:     key.Label,
+		Preset:    marshalPreset(key.Preset),
+		AccountId: key.AccountID,
+		CreatedAt: key.CreatedAt.Unix(),
+	}
+
+	if !key.ExpiresAt.IsZero() {
+		rpcKey.ExpirationDate = key.ExpiresAt.Unix()
+	}
+
+	return rpcKey
+}
+
+// marshalPreset converts a preset into its RPC counterpart.
+func marshalPreset(preset Preset) litrpc.ApiKeyPreset {
+	switch preset {
+	case PresetAdmin:
+		return litrpc.ApiKeyPreset_API_KEY_PRESET_ADMIN
+
+	case PresetAccount:
+		return litrpc.ApiKeyPreset_API_KEY_PRESET_ACCOUNT
+
+	default:
+		return litrpc.ApiKeyPreset_API_KEY_PRESET_READONLY
+	}
+}
+
+// unmarshalPreset converts an RPC preset into a preset.
+func unmarshalPreset(preset litrpc.ApiKeyPreset) (Preset, error) {
+	switch preset {
+	case litrpc.ApiKeyPreset_API_KEY_PRESET_READONLY:
+		return PresetReadOnly, nil
+
+	case litrpc.ApiKeyPreset_API_KEY_PRESET_ADMIN:
+		return PresetAdmin, nil
+
+	case litrpc.ApiKeyPreset_API_KEY_PRESET_ACCOUNT:
+		return PresetAccount, nil
+
+	default:
+		return "", fmt.Errorf("unknown preset %v", preset)
+	}
+}
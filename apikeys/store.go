@@ -0,0 +1,193 @@
+package apikeys
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the API key database.
+	DBFilename = "apikeys.db"
+
+	// dbFilePermission is the default permission the API key database
+	// file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The API keys are stored in the following structure in the db:
+
+	keys -> key ID -> json encoded Key
+*/
+
+var (
+	// keysBucketKey is the key of the top level bucket holding all API
+	// keys.
+	keysBucketKey = []byte("keys")
+
+	// ErrKeyNotFound is returned when an API key with the given ID does
+	// not exist in the db.
+	ErrKeyNotFound = errors.New("api key not found")
+)
+
+// Key is a stored API key. Only a hash of the key's secret is stored, the
+// macaroon the key grants access with is encrypted with the secret.
+type Key struct {
+	// ID is the hex encoded ID of the key.
+	ID string `json:"id"`
+
+	// Label is an optional human-readable label of the key.
+	Label string `json:"label"`
+
+	// Preset is the permission preset the key was created with.
+	Preset Preset `json:"preset"`
+
+	// AccountID is the hex encoded ID of the account the key is locked
+	// to. It is empty if the key isn't locked to an account.
+	AccountID string `json:"account_id,omitempty"`
+
+	// CreatedAt is the time the key was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is the time after which the key is no longer accepted. A
+	// zero time means it never expires.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// SecretHash is the SHA256 hash of the key's secret.
+	SecretHash []byte `json:"secret_hash"`
+
+	// EncryptedMacaroon is the macaroon the key grants access with,
+	// encrypted with a key derived from the key's secret.
+	EncryptedMacaroon []byte `json:"encrypted_macaroon"`
+}
+
+// HasExpired returns true if the key has an expiry time that lies in the past.
+func (k *Key) HasExpired() bool {
+	return !k.ExpiresAt.IsZero() && k.ExpiresAt.Before(time.Now())
+}
+
+// Store is a bolt-backed persistent store of the API keys.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the API key store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(keysBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// AddKey stores the given key.
+func (s *Store) AddKey(k *Key) error {
+	b, err := json.Marshal(k)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(keysBucketKey).Put([]byte(k.ID), b)
+	})
+}
+
+// Key fetches the key with the given ID. If no such key exists,
+// ErrKeyNotFound is returned.
+func (s *Store) Key(id string) (*Key, error) {
+	var k *Key
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(keysBucketKey).Get([]byte(id))
+		if b == nil {
+			return ErrKeyNotFound
+		}
+
+		k = &Key{}
+		return json.Unmarshal(b, k)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Keys returns all keys sorted by their creation time.
+func (s *Store) Keys() ([]*Key, error) {
+	var keys []*Key
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(keysBucketKey)
+
+		return bucket.ForEach(func(_, b []byte) error {
+			var k Key
+			if err := json.Unmarshal(b, &k); err != nil {
+				return err
+			}
+
+			keys = append(keys, &k)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+// RemoveKey removes the key with the given ID. If no such key exists,
+// ErrKeyNotFound is returned.
+func (s *Store) RemoveKey(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(keysBucketKey)
+
+		if bucket.Get([]byte(id)) == nil {
+			return ErrKeyNotFound
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
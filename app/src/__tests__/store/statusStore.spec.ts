@@ -0,0 +1,65 @@
+import { values } from 'mobx';
+import { waitFor } from '@testing-library/react';
+import { createStore, StatusStore, Store } from 'store';
+
+const fetchMock = global.fetch as jest.Mock;
+
+describe('StatusStore', () => {
+  let rootStore: Store;
+  let store: StatusStore;
+
+  beforeEach(() => {
+    rootStore = createStore();
+    store = rootStore.statusStore;
+  });
+
+  it('should fetch the lockdown mode', async () => {
+    fetchMock.mockResolvedValueOnce({
+      ok: true,
+      json: () =>
+        Promise.resolve({
+          lockdown: { active: true, reason: 'incident', since: '1700000000' },
+        }),
+    });
+    expect(store.lockdownActive).toBe(false);
+    await store.fetchStatus();
+    expect(store.lockdownActive).toBe(true);
+    expect(store.lockdownReason).toBe('incident');
+    expect(store.lockdownSince.getTime()).toBe(1700000000000);
+    expect(store.maintenanceActive).toBe(false);
+  });
+
+  it('should fetch the active maintenance window', async () => {
+    fetchMock.mockResolvedValueOnce({
+      ok: true,
+      json: () =>
+        Promise.resolve({
+          maintenance: {
+            id: 'abcd',
+            start: '1700000000',
+            end: '1700001800',
+            reason: 'lnd upgrade',
+            active: true,
+          },
+        }),
+    });
+    expect(store.maintenanceActive).toBe(false);
+    await store.fetchStatus();
+    expect(store.maintenanceActive).toBe(true);
+    expect(store.maintenanceReason).toBe('lnd upgrade');
+    expect(store.maintenanceEnd.getTime()).toBe(1700001800000);
+    expect(store.lockdownActive).toBe(false);
+  });
+
+  it('should handle errors fetching the status', async () => {
+    fetchMock.mockResolvedValueOnce({ ok: false, statusText: 'test-err' });
+    expect(rootStore.appView.alerts.size).toBe(0);
+    await store.fetchStatus();
+    await waitFor(() => {
+      expect(rootStore.appView.alerts.size).toBe(1);
+      expect(values(rootStore.appView.alerts)[0].message).toBe(
+        'Unable to fetch the status: test-err',
+      );
+    });
+  });
+});
@@ -1,6 +1,9 @@
 import { EventMap } from 'types/emitter';
 import BaseEmitter from 'util/BaseEmitter';
 
+/** the prefix of credentials that are sent as a bearer token */
+export const BEARER_PREFIX = 'Bearer ';
+
 /**
  * A shared base class containing logic for storing the API credentials
  */
@@ -12,14 +15,16 @@ class BaseApi<T extends EventMap> extends BaseEmitter<T> {
    * previous set if any
    */
   protected get _meta() {
-    return this._credentials
-      ? { authorization: `Basic ${this._credentials}` }
-      : undefined;
+    if (!this._credentials) return undefined;
+    // session tokens issued after an OIDC login are sent as bearer tokens
+    return this._credentials.startsWith(BEARER_PREFIX)
+      ? { authorization: this._credentials }
+      : { authorization: `Basic ${this._credentials}` };
   }
 
   /**
    * Sets the credentials to use for all API requests
-   * @param credentials the base64 encoded password
+   * @param credentials the base64 encoded password or a bearer token
    */
   setCredentials(credentials: string) {
     this._credentials = credentials;
@@ -4,9 +4,33 @@ import { Accounts } from 'types/generated/lit-accounts_pb_service';
 import { Sessions } from 'types/generated/lit-sessions_pb_service';
 import { b64 } from 'util/strings';
 import { MAX_DATE } from 'util/constants';
+import { DEV_HOST } from 'config';
 import BaseApi from './base';
 import GrpcClient from './grpc';
 
+/** the lockdown mode of litd as returned by the `GetStatus` RPC */
+export interface LockdownStatus {
+  active: boolean;
+  reason: string;
+  since: string;
+}
+
+/** a maintenance window of litd as returned by the `GetStatus` RPC */
+export interface MaintenanceWindow {
+  id: string;
+  start: string;
+  end: string;
+  reason: string;
+  active: boolean;
+}
+
+/** the parts of the `GetStatus` RPC response used by the UI */
+export interface LitStatus {
+  lockdown: LockdownStatus;
+  /** the active maintenance window, not set if the node isn't in maintenance */
+  maintenance?: MaintenanceWindow;
+}
+
 /** the names and argument types for the subscription events */
 // eslint-disable-next-line @typescript-eslint/no-empty-interface
 interface LitEvents {}
@@ -84,6 +108,22 @@ class LitApi extends BaseApi<LitEvents> {
     const res = await this._grpc.request(Sessions.RevokeSession, req, this._meta);
     return res.toObject();
   }
+
+  /**
+   * call the REST endpoint of the Lit `GetStatus` RPC and return the lockdown
+   * mode and the active maintenance window. The Status service has no
+   * grpc-web bindings, so the REST endpoint is used instead
+   */
+  async getStatus(): Promise<LitStatus> {
+    const res = await fetch(`${DEV_HOST}/v1/status`, { headers: this._meta });
+    if (!res.ok) throw new Error(`Unable to fetch the status: ${res.statusText}`);
+
+    const status = await res.json();
+    return {
+      lockdown: status.lockdown || { active: false, reason: '', since: '0' },
+      maintenance: status.maintenance,
+    };
+  }
 }
 
 export default LitApi;
@@ -4,6 +4,8 @@ import { Global, Theme } from '@emotion/react';
 import styled from '@emotion/styled';
 import { useStore } from 'store';
 import { Background, Menu } from 'components/base';
+import LockdownBanner from './LockdownBanner';
+import MaintenanceBanner from './MaintenanceBanner';
 import Sidebar from './Sidebar';
 
 interface CollapsedProps {
@@ -129,6 +131,8 @@ export const Layout: React.FC = ({ children }) => {
           <Sidebar />
         </Aside>
         <Content collapsed={!settingsStore.sidebarVisible} fullWidth={appView.fullWidth}>
+          <LockdownBanner />
+          <MaintenanceBanner />
           <Fluid className="container-fluid">{children}</Fluid>
         </Content>
       </Container>
@@ -0,0 +1,38 @@
+import React from 'react';
+import { observer } from 'mobx-react-lite';
+import styled from '@emotion/styled';
+import { usePrefixedTranslation } from 'hooks';
+import { useStore } from 'store';
+
+const Styled = {
+  Banner: styled.div`
+    margin: 20px 0 0;
+    padding: 12px 20px;
+    color: ${props => props.theme.colors.white};
+    background-color: ${props => props.theme.colors.lightningRed};
+    border-radius: 8px;
+  `,
+  Title: styled.strong`
+    margin-right: 10px;
+  `,
+};
+
+const LockdownBanner: React.FC = () => {
+  const { l } = usePrefixedTranslation('cmps.layout.LockdownBanner');
+  const { statusStore } = useStore();
+
+  if (!statusStore.lockdownActive) return null;
+
+  const { Banner, Title } = Styled;
+  return (
+    <Banner role="alert">
+      <Title>{l('title')}</Title>
+      {l('message', { since: statusStore.lockdownSince.toLocaleString() })}
+      {statusStore.lockdownReason && (
+        <div>{l('reason', { reason: statusStore.lockdownReason })}</div>
+      )}
+    </Banner>
+  );
+};
+
+export default observer(LockdownBanner);
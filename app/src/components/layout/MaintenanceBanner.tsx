@@ -0,0 +1,38 @@
+import React from 'react';
+import { observer } from 'mobx-react-lite';
+import styled from '@emotion/styled';
+import { usePrefixedTranslation } from 'hooks';
+import { useStore } from 'store';
+
+const Styled = {
+  Banner: styled.div`
+    margin: 20px 0 0;
+    padding: 12px 20px;
+    color: ${props => props.theme.colors.blue};
+    background-color: ${props => props.theme.colors.gold};
+    border-radius: 8px;
+  `,
+  Title: styled.strong`
+    margin-right: 10px;
+  `,
+};
+
+const MaintenanceBanner: React.FC = () => {
+  const { l } = usePrefixedTranslation('cmps.layout.MaintenanceBanner');
+  const { statusStore } = useStore();
+
+  if (!statusStore.maintenanceActive) return null;
+
+  const { Banner, Title } = Styled;
+  return (
+    <Banner role="alert">
+      <Title>{l('title')}</Title>
+      {l('message', { end: statusStore.maintenanceEnd.toLocaleString() })}
+      {statusStore.maintenanceReason && (
+        <div>{l('reason', { reason: statusStore.maintenanceReason })}</div>
+      )}
+    </Banner>
+  );
+};
+
+export default observer(MaintenanceBanner);
@@ -11,6 +11,7 @@ module.exports = function (app) {
         '/frdrpc.FaradayServer',
         '/litrpc.Session',
         '/litrpc.Accounts',
+        '/v1/status',
       ],
       {
         target: 'https://localhost:8443',
@@ -14,6 +14,13 @@ jest.spyOn(window.localStorage.__proto__, 'getItem');
 jest.spyOn(window.sessionStorage.__proto__, 'setItem');
 jest.spyOn(window.sessionStorage.__proto__, 'getItem');
 
+// mock the REST endpoints of litd in unit tests, the lockdown mode is lifted by
+// default
+global.fetch = jest.fn().mockResolvedValue({
+  ok: true,
+  json: () => Promise.resolve({ lockdown: { active: false, reason: '', since: '0' } }),
+});
+
 beforeEach(() => {
   jest.clearAllMocks();
 });
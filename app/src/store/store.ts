@@ -16,6 +16,7 @@ import {
   RouterStore,
   SessionStore,
   SettingsStore,
+  StatusStore,
   SwapStore,
 } from './stores';
 import {
@@ -49,6 +50,7 @@ export class Store {
   orderStore = new OrderStore(this);
   settingsStore = new SettingsStore(this);
   sessionStore = new SessionStore(this);
+  statusStore = new StatusStore(this);
 
   /** the store which synchronizes with the browser history */
   router: RouterStore;
@@ -162,6 +164,7 @@ export class Store {
     await this.swapStore.fetchSwaps();
     await this.nodeStore.fetchBalances();
     await this.sessionStore.fetchSessions();
+    await this.statusStore.fetchStatus();
   }
 
   /** connects to the LND and Loop websocket streams if not already connected */
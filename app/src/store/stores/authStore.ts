@@ -2,6 +2,10 @@ import { makeAutoObservable, runInAction } from 'mobx';
 import { Buffer } from 'buffer';
 import { prefixTranslation } from 'util/translate';
 import { Store } from 'store';
+import { BEARER_PREFIX } from 'api/base';
+
+/** the URL fragment parameter litd hands the token to after an OIDC login */
+const OIDC_TOKEN_PARAM = 'oidc_token';
 
 const { l } = prefixTranslation('stores.authStore');
 
@@ -65,10 +69,29 @@ export default class AuthStore {
     });
   }
 
+  /**
+   * stores the session token litd passes in the URL fragment after a
+   * successful OIDC login and removes it from the URL
+   */
+  loadOidcToken() {
+    const params = new URLSearchParams(window.location.hash.substring(1));
+    const token = params.get(OIDC_TOKEN_PARAM);
+    if (!token) return;
+
+    this._store.log.info('found OIDC session token');
+    this._store.storage.setSession('credentials', `${BEARER_PREFIX}${token}`);
+    window.history.replaceState(
+      null,
+      '',
+      window.location.pathname + window.location.search,
+    );
+  }
+
   /**
    * load and validate credentials from the browser's session storage
    */
   async init() {
+    this.loadOidcToken();
     this._store.log.info('loading credentials from sessionStorage');
     const creds = this._store.storage.getSession('credentials');
     if (creds) {
@@ -8,3 +8,4 @@ export { default as SettingsStore } from './settingsStore';
 export { default as SwapStore } from './swapStore';
 export { default as RouterStore } from './routerStore';
 export { default as SessionStore } from './sessionStore';
+export { default as StatusStore } from './statusStore';
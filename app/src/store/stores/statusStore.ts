@@ -0,0 +1,52 @@
+import { makeAutoObservable, runInAction } from 'mobx';
+import { Store } from 'store';
+
+export default class StatusStore {
+  private _store: Store;
+
+  /** whether litd is in lockdown mode and rejects all account and session RPCs */
+  lockdownActive = false;
+  /** why the lockdown mode was enabled */
+  lockdownReason = '';
+  /** the time the lockdown mode was enabled */
+  lockdownSince = new Date(0);
+  /** whether the node is in a maintenance window and rejects all LNC session calls */
+  maintenanceActive = false;
+  /** why the node is in maintenance */
+  maintenanceReason = '';
+  /** the time the active maintenance window ends */
+  maintenanceEnd = new Date(0);
+
+  constructor(store: Store) {
+    makeAutoObservable(this, {}, { deep: false, autoBind: true });
+
+    this._store = store;
+  }
+
+  /**
+   * fetch the lockdown mode and the active maintenance window of litd from the
+   * `GetStatus` RPC
+   */
+  async fetchStatus() {
+    this._store.log.info('fetching status');
+
+    try {
+      const { lockdown, maintenance } = await this._store.api.lit.getStatus();
+      runInAction(() => {
+        this.lockdownActive = lockdown.active;
+        this.lockdownReason = lockdown.reason;
+        this.lockdownSince = new Date(parseInt(lockdown.since) * 1000);
+        this.maintenanceActive = !!maintenance;
+        this.maintenanceReason = maintenance?.reason || '';
+        this.maintenanceEnd = new Date(parseInt(maintenance?.end || '0') * 1000);
+      });
+      this._store.log.info('updated statusStore.lockdownActive', this.lockdownActive);
+      this._store.log.info(
+        'updated statusStore.maintenanceActive',
+        this.maintenanceActive,
+      );
+    } catch (error: any) {
+      this._store.appView.handleError(error, 'Unable to fetch the status');
+    }
+  }
+}
@@ -0,0 +1,258 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/apikeys"
+	"github.com/lightninglabs/lightning-terminal/authlimit"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/guardrails"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// actorUI is the actor of requests that were authenticated with the
+	// UI password.
+	actorUI = "ui"
+
+	// actorClientPrefix is the prefix of the actor of the lockouts, which
+	// are attributed to the IP address of the locked out client since it
+	// couldn't authenticate.
+	actorClientPrefix = "client:"
+
+	// auditPendingGrace is how long the audit trail waits for a pending
+	// action of a session to complete before its cursor moves past it.
+	auditPendingGrace = time.Minute
+)
+
+var (
+	// A compile-time assertion that auditLog is an accounts.Auditor.
+	_ accounts.Auditor = (*auditLog)(nil)
+
+	// A compile-time assertion that auditLog is a guardrails.Auditor.
+	_ guardrails.Auditor = (*auditLog)(nil)
+
+	// denialReasons are contained in the error reasons of the actions that
+	// were rejected by a firewall rule or a guardrail.
+	denialReasons = []string{
+		"rule violation", guardrails.ErrGuardrailViolation.Error(),
+	}
+)
+
+// auditLog identifies the callers of LiT's RPCs and records the changes they
+// make in the firewall's action log.
+type auditLog struct {
+	// actor returns the identity of the caller of the request with the
+	// given context.
+	actor func(ctx context.Context) string
+
+	actionsDB firewalldb.ActionsWriteDB
+}
+
+// Actor returns the identity of the caller of the request with the given
+// context.
+//
+// NOTE: this is part of the accounts.Auditor interface.
+func (a *auditLog) Actor(ctx context.Context) string {
+	return a.actor(ctx)
+}
+
+// Record adds a completed action for the given RPC method to the action log.
+// The action is attributed to the given actor and carries the request as its
+// parameters.
+//
+// NOTE: this is part of the accounts.Auditor interface.
+func (a *auditLog) Record(actor, method string, req proto.Message) error {
+	return a.addAction(actor, method, req, firewalldb.ActionStateDone, "")
+}
+
+// RecordViolation adds a failed action for the given RPC method to the action
+// log, with the reason the request was rejected.
+//
+// NOTE: this is part of the guardrails.Auditor interface.
+func (a *auditLog) RecordViolation(actor, method string, req proto.Message,
+	reason string) error {
+
+	return a.addAction(
+		actor, method, req, firewalldb.ActionStateError, reason,
+	)
+}
+
+// RecordLockout adds a failed action to the action log for a client that was
+// locked out after too many failed UI password attempts. The method is the
+// one the client tried to call with its last attempt.
+func (a *auditLog) RecordLockout(method string,
+	state authlimit.ClientState) error {
+
+	return a.addAction(
+		actorClientPrefix+state.Client, method,
+		marshalAuthLockout(&state), firewalldb.ActionStateError,
+		fmt.Sprintf("%v, locked out until %v", authlimit.ErrLockedOut,
+			state.NextAttempt.UTC().Format(time.RFC3339)),
+	)
+}
+
+// addAction adds an action in the given state to the action log.
+func (a *auditLog) addAction(actor, method string, req proto.Message,
+	state firewalldb.ActionState, errorReason string) error {
+
+	params, err := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.actionsDB.AddAction(session.ID{}, &firewalldb.Action{
+		ActorName:     actor,
+		RPCMethod:     method,
+		RPCParamsJson: params,
+		AttemptedAt:   time.Now(),
+		State:         state,
+		ErrorReason:   errorReason,
+	})
+
+	return err
+}
+
+// auditCategory returns the category of the given action in the audit trail.
+// Unknown is returned for the actions that aren't part of the trail, like the
+// calls that sessions and accounts make to lnd.
+func auditCategory(a *firewalldb.Action) litrpc.AuditCategory {
+	if a.State == firewalldb.ActionStateError {
+		for _, reason := range denialReasons {
+			if strings.Contains(a.ErrorReason, reason) {
+				return litrpc.AuditCategory_AUDIT_CATEGORY_FIREWALL_DENIAL
+			}
+		}
+
+		if strings.HasPrefix(a.ActorName, actorClientPrefix) &&
+			strings.Contains(
+				a.ErrorReason, authlimit.ErrLockedOut.Error(),
+			) {
+
+			return litrpc.AuditCategory_AUDIT_CATEGORY_AUTH
+		}
+	}
+
+	// All other events of the trail are recorded by the audit log or the
+	// action guards, which always name the actor and don't act on behalf
+	// of a session.
+	if a.SessionID != (session.ID{}) || a.ActorName == "" {
+		return litrpc.AuditCategory_AUDIT_CATEGORY_UNKNOWN
+	}
+
+	switch {
+	case strings.HasPrefix(a.RPCMethod, "/litrpc.Accounts/"):
+		return litrpc.AuditCategory_AUDIT_CATEGORY_ACCOUNT
+
+	case strings.HasPrefix(a.RPCMethod, "/litrpc.Sessions/"):
+		return litrpc.AuditCategory_AUDIT_CATEGORY_SESSION
+
+	case a.RPCMethod == "/litrpc.Proxy/ClearAuthLockout":
+		return litrpc.AuditCategory_AUDIT_CATEGORY_AUTH
+
+	default:
+		return litrpc.AuditCategory_AUDIT_CATEGORY_ADMIN
+	}
+}
+
+// actorFromContext returns the identity of the caller of the request with the
+// given context. Credentials are only used to identify the caller if they are
+// valid, so that a request can't claim to be made by someone else.
+func (p *rpcProxy) actorFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return accounts.ActorUnknown
+	}
+
+	if authHeaders := md.Get("authorization"); len(authHeaders) == 1 {
+		if actor := p.actorFromAuthHeader(authHeaders[0]); actor != "" {
+			return actor
+		}
+	}
+
+	macHeaders := md.Get(HeaderMacaroon)
+	if len(macHeaders) != 1 {
+		return accounts.ActorUnknown
+	}
+
+	macBytes, err := hex.DecodeString(macHeaders[0])
+	if err != nil {
+		return accounts.ActorUnknown
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		return accounts.ActorUnknown
+	}
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return accounts.ActorUnknown
+	}
+
+	if session.IsSuperMacaroon(macHeaders[0]) {
+		id := session.IDFromMacRootKeyID(rootKeyID)
+		return fmt.Sprintf("supermacaroon:%x", id[:])
+	}
+
+	return fmt.Sprintf("macaroon:%d", rootKeyID)
+}
+
+// actorFromAuthHeader returns the identity of the caller that authenticated
+// with the given authorization header, or an empty string if the header isn't
+// valid.
+func (p *rpcProxy) actorFromAuthHeader(authHeader string) string {
+	if apikeys.IsAPIKey(authHeader) {
+		if p.apiKeys == nil {
+			return ""
+		}
+
+		if _, err := p.apiKeys.Macaroon(authHeader); err != nil {
+			return ""
+		}
+
+		id, err := apikeys.KeyID(authHeader)
+		if err != nil {
+			return ""
+		}
+
+		return "apikey:" + id
+	}
+
+	authHeaderParts := strings.Split(authHeader, " ")
+	if len(authHeaderParts) != 2 {
+		return ""
+	}
+
+	switch {
+	case p.oidcAuth != nil && strings.EqualFold(
+		authHeaderParts[0], "bearer",
+	):
+		oidcSession, err := p.oidcAuth.ValidateToken(
+			authHeaderParts[1],
+		)
+		if err != nil {
+			return ""
+		}
+
+		return "oidc:" + oidcSession.Subject
+
+	case !p.cfg.DisableUI && authHeaderParts[1] == p.basicAuth:
+		return actorUI
+
+	default:
+		return ""
+	}
+}
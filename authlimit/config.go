@@ -0,0 +1,65 @@
+package authlimit
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultMaxAttempts is the default number of consecutive failed
+	// attempts after which a client is locked out.
+	defaultMaxAttempts = 5
+
+	// defaultBaseDelay is the default time a client must wait after its
+	// first failed attempt.
+	defaultBaseDelay = time.Second
+
+	// defaultLockout is the default duration of the first lockout of a
+	// client.
+	defaultLockout = 15 * time.Minute
+
+	// defaultMaxLockout is the default maximum duration of a lockout.
+	defaultMaxLockout = 24 * time.Hour
+)
+
+// Config holds all config options for the rate limiting of the UI password
+// attempts.
+type Config struct {
+	MaxAttempts uint32        `long:"maxattempts" description:"The number of consecutive failed UI password attempts after which a client is locked out. Set to 0 to disable the rate limiting."`
+	BaseDelay   time.Duration `long:"basedelay" description:"The time a client must wait after a failed UI password attempt before it may try again. The delay doubles with every further failed attempt."`
+	Lockout     time.Duration `long:"lockout" description:"How long a client is locked out once it reached the maximum number of failed attempts. The duration doubles with every further lockout of the same client."`
+	MaxLockout  time.Duration `long:"maxlockout" description:"The maximum duration of a lockout."`
+}
+
+// DefaultConfig constructs the default auth limit Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxAttempts: defaultMaxAttempts,
+		BaseDelay:   defaultBaseDelay,
+		Lockout:     defaultLockout,
+		MaxLockout:  defaultMaxLockout,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.MaxAttempts == 0 {
+		return nil
+	}
+
+	if c.BaseDelay < 0 {
+		return fmt.Errorf("the auth limit base delay must not be " +
+			"negative")
+	}
+
+	if c.Lockout <= 0 {
+		return fmt.Errorf("the auth limit lockout must be positive")
+	}
+
+	if c.MaxLockout < c.Lockout {
+		return fmt.Errorf("the auth limit max lockout must not be " +
+			"shorter than the lockout")
+	}
+
+	return nil
+}
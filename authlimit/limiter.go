@@ -0,0 +1,256 @@
+package authlimit
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrLockedOut is returned if a client makes an attempt before its backoff
+// delay or lockout has passed.
+var ErrLockedOut = errors.New("too many failed password attempts")
+
+// ClientState is the state of a client that recently failed to authenticate.
+type ClientState struct {
+	// Client identifies the client, usually by its IP address.
+	Client string
+
+	// FailedAttempts is the number of consecutive failed attempts since
+	// the last lockout of the client.
+	FailedAttempts uint32
+
+	// LastFailure is the time of the last failed attempt.
+	LastFailure time.Time
+
+	// NextAttempt is the time before which all attempts of the client
+	// are rejected.
+	NextAttempt time.Time
+
+	// LockedOut is true if the client is waiting for the end of a lockout
+	// rather than a backoff delay.
+	LockedOut bool
+
+	// Lockouts is the number of times the client was locked out since its
+	// last successful attempt.
+	Lockouts uint32
+}
+
+// LockoutFunc is called whenever a client is locked out. The method is the
+// one the client tried to call with its last failed attempt.
+type LockoutFunc func(method string, state ClientState)
+
+// Limiter limits the rate of the failed password attempts per client. After
+// every failed attempt, the client must wait for a delay that doubles with
+// every further failure. After too many failures, the client is locked out.
+type Limiter struct {
+	cfg       *Config
+	onLockout LockoutFunc
+
+	// now returns the current time and can be overwritten in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*ClientState
+}
+
+// NewLimiter creates a new limiter for the given config. The given function is
+// called for every lockout and may be nil.
+func NewLimiter(cfg *Config, onLockout LockoutFunc) *Limiter {
+	return &Limiter{
+		cfg:       cfg,
+		onLockout: onLockout,
+		now:       time.Now,
+		clients:   make(map[string]*ClientState),
+	}
+}
+
+// enabled returns true if the attempts are limited at all.
+func (l *Limiter) enabled() bool {
+	return l != nil && l.cfg.MaxAttempts > 0
+}
+
+// Check returns an error that wraps ErrLockedOut if the given client must not
+// make an attempt yet.
+func (l *Limiter) Check(client string) error {
+	if !l.enabled() {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state, ok := l.clients[client]
+	if !ok {
+		return nil
+	}
+
+	now := l.now()
+	if !now.Before(state.NextAttempt) {
+		return nil
+	}
+
+	wait := state.NextAttempt.Sub(now).Round(time.Second)
+	if state.LockedOut {
+		return fmt.Errorf("%w, locked out for %v", ErrLockedOut, wait)
+	}
+
+	return fmt.Errorf("%w, retry in %v", ErrLockedOut, wait)
+}
+
+// Failure records a failed attempt of the given client to call the given
+// method and returns the new state of the client.
+func (l *Limiter) Failure(client, method string) ClientState {
+	if !l.enabled() {
+		return ClientState{Client: client}
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	l.prune(now)
+
+	state, ok := l.clients[client]
+	if !ok {
+		state = &ClientState{Client: client}
+		l.clients[client] = state
+	}
+
+	state.FailedAttempts++
+	state.LastFailure = now
+
+	lockedOut := state.FailedAttempts >= l.cfg.MaxAttempts
+	if lockedOut {
+		state.Lockouts++
+		state.FailedAttempts = 0
+		state.LockedOut = true
+		state.NextAttempt = now.Add(backoff(
+			l.cfg.Lockout, state.Lockouts, l.cfg.MaxLockout,
+		))
+	} else {
+		state.LockedOut = false
+		state.NextAttempt = now.Add(backoff(
+			l.cfg.BaseDelay, state.FailedAttempts, l.cfg.Lockout,
+		))
+	}
+	result := *state
+	l.mu.Unlock()
+
+	if lockedOut {
+		log.Warnf("Client %s locked out until %v after %d failed "+
+			"password attempts", client, result.NextAttempt,
+			l.cfg.MaxAttempts)
+
+		if l.onLockout != nil {
+			l.onLockout(method, result)
+		}
+	}
+
+	return result
+}
+
+// Success records a successful attempt of the given client, which resets its
+// state.
+func (l *Limiter) Success(client string) {
+	if !l.enabled() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.clients, client)
+}
+
+// Clients returns the state of all clients that recently failed to
+// authenticate, ordered by client.
+func (l *Limiter) Clients() []ClientState {
+	if !l.enabled() {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prune(l.now())
+
+	clients := make([]ClientState, 0, len(l.clients))
+	for _, state := range l.clients {
+		clients = append(clients, *state)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Client < clients[j].Client
+	})
+
+	return clients
+}
+
+// Clear resets the state of the given client, which lifts its backoff delay
+// or lockout. It returns false if the client wasn't known.
+func (l *Limiter) Clear(client string) bool {
+	if !l.enabled() {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.clients[client]; !ok {
+		return false
+	}
+	delete(l.clients, client)
+
+	log.Infof("Cleared the failed password attempts of client %s", client)
+
+	return true
+}
+
+// ClearAll resets the state of all clients and returns how many were cleared.
+func (l *Limiter) ClearAll() int {
+	if !l.enabled() {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cleared := len(l.clients)
+	l.clients = make(map[string]*ClientState)
+
+	log.Infof("Cleared the failed password attempts of %d clients",
+		cleared)
+
+	return cleared
+}
+
+// prune forgets the clients whose last failure is longer ago than the maximum
+// lockout, so that their next lockout starts with the shortest duration
+// again. The caller must hold the mutex.
+func (l *Limiter) prune(now time.Time) {
+	for client, state := range l.clients {
+		if now.Before(state.NextAttempt) {
+			continue
+		}
+
+		if now.Sub(state.LastFailure) > l.cfg.MaxLockout {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// backoff returns the base duration doubled for every step after the first
+// one, capped at the given maximum.
+func backoff(base time.Duration, step uint32,
+	maxDuration time.Duration) time.Duration {
+
+	d := base
+	for i := uint32(1); i < step && d < maxDuration; i++ {
+		d *= 2
+	}
+
+	if d > maxDuration {
+		return maxDuration
+	}
+
+	return d
+}
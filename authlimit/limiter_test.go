@@ -0,0 +1,113 @@
+package authlimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimiter tests that failed attempts are delayed exponentially and lead to
+// lockouts that grow with every further lockout.
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now      = time.Unix(1_700_000_000, 0)
+		lockouts []ClientState
+	)
+	l := NewLimiter(&Config{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		Lockout:     time.Minute,
+		MaxLockout:  90 * time.Second,
+	}, func(method string, state ClientState) {
+		require.Equal(t, "/lnrpc.Lightning/GetInfo", method)
+		lockouts = append(lockouts, state)
+	})
+	l.now = func() time.Time {
+		return now
+	}
+
+	const client = "10.0.0.1"
+	fail := func() ClientState {
+		require.NoError(t, l.Check(client))
+		return l.Failure(client, "/lnrpc.Lightning/GetInfo")
+	}
+
+	// The delay doubles with every failed attempt.
+	state := fail()
+	require.Equal(t, now.Add(time.Second), state.NextAttempt)
+	require.ErrorIs(t, l.Check(client), ErrLockedOut)
+
+	// Other clients are not affected.
+	require.NoError(t, l.Check("10.0.0.2"))
+
+	now = now.Add(time.Second)
+	state = fail()
+	require.Equal(t, now.Add(2*time.Second), state.NextAttempt)
+	require.False(t, state.LockedOut)
+
+	// The third failure locks the client out.
+	now = now.Add(2 * time.Second)
+	state = fail()
+	require.True(t, state.LockedOut)
+	require.Equal(t, now.Add(time.Minute), state.NextAttempt)
+	require.Len(t, lockouts, 1)
+	require.ErrorContains(t, l.Check(client), "locked out for 1m0s")
+
+	// The next lockout is longer, but capped at the maximum.
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		state = fail()
+		now = state.NextAttempt
+	}
+	require.Len(t, lockouts, 2)
+	require.Equal(t, uint32(2), lockouts[1].Lockouts)
+	require.Equal(t, 90*time.Second, state.NextAttempt.Sub(
+		lockouts[1].LastFailure,
+	))
+
+	clients := l.Clients()
+	require.Len(t, clients, 1)
+	require.Equal(t, client, clients[0].Client)
+
+	// A successful attempt resets the client.
+	l.Success(client)
+	require.Empty(t, l.Clients())
+
+	// A lockout can be cleared by an admin.
+	for i := 0; i < 3; i++ {
+		state = fail()
+		now = now.Add(2 * time.Second)
+	}
+	require.True(t, state.LockedOut)
+	require.False(t, l.Clear("10.0.0.2"))
+	require.True(t, l.Clear(client))
+	require.NoError(t, l.Check(client))
+
+	_ = fail()
+	require.Equal(t, 1, l.ClearAll())
+
+	// Clients are forgotten once their last failure is longer ago than
+	// the maximum lockout.
+	_ = fail()
+	now = now.Add(91 * time.Second)
+	require.Empty(t, l.Clients())
+}
+
+// TestLimiterDisabled tests that a limiter without a maximum number of
+// attempts never rejects an attempt.
+func TestLimiterDisabled(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(&Config{}, nil)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.Check("10.0.0.1"))
+		l.Failure("10.0.0.1", "")
+	}
+	require.Empty(t, l.Clients())
+
+	var nilLimiter *Limiter
+	require.NoError(t, nilLimiter.Check("10.0.0.1"))
+}
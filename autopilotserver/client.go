@@ -32,6 +32,10 @@ type Config struct {
 	// Address is the domain:port of the autopilot server.
 	Address string `long:"address" description:"autopilot server address host:port"`
 
+	// Mock signals that an in-process mock autopilot server should be
+	// started and used instead of a remote one.
+	Mock bool `long:"mock" description:"Start an in-process mock autopilot server that serves a set of example features and connect to it instead of a remote autopilot server. Only allowed on regtest and simnet."`
+
 	// Proxy is the SOCKS proxy that should be used to establish the
 	// connection.
 	Proxy string `long:"proxy" description:"The host:port of a SOCKS proxy through which all connections to the autopilot server will be established over"`
@@ -2,7 +2,6 @@ package autopilotserver
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
@@ -25,9 +24,8 @@ func TestAutopilotClient(t *testing.T) {
 	// Create a new client and connect it to the mock server. We set a very
 	// short ping cadence so that we can test that the client correctly
 	// ensures re-activation of a session.
-	addr := fmt.Sprintf("localhost:%d", server.GetPort())
 	client, err := NewClient(&Config{
-		Address:     addr,
+		Address:     server.Addr(),
 		Insecure:    true,
 		PingCadence: time.Second,
 	})
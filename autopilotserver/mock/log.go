@@ -5,7 +5,7 @@ import (
 	"github.com/lightningnetwork/lnd/build"
 )
 
-const Subsystem = "AUTO"
+const Subsystem = "APMK"
 
 // log is a logger that is initialized with no output filters.  This
 // means the package will not perform any logging by default until the caller
@@ -10,7 +10,6 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightninglabs/lightning-terminal/autopilotserverrpc"
 	"github.com/lightninglabs/lightning-terminal/rules"
-	"github.com/lightningnetwork/lnd/lntest/node"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"gopkg.in/macaroon-bakery.v2/bakery"
@@ -31,7 +30,8 @@ type Server struct {
 	sessMu   sync.Mutex
 
 	// port is the port number on which the mock autopilot server will
-	// host its grpc service.
+	// host its grpc service. It is chosen by the OS when the server is
+	// started.
 	port int
 
 	grpcServer *grpc.Server
@@ -54,7 +54,6 @@ type clientSession struct {
 // NewServer constructs a new MockAutoPilotServer.
 func NewServer() *Server {
 	return &Server{
-		port:     node.NextAvailablePort(),
 		sessions: make(map[string]*clientSession),
 		grpcServer: grpc.NewServer(
 			grpc.Creds(insecure.NewCredentials()),
@@ -65,10 +64,11 @@ func NewServer() *Server {
 
 // Start kicks off the mock autopilot grpc server.
 func (m *Server) Start() error {
-	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", m.port))
+	lis, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
 		return err
 	}
+	m.port = lis.Addr().(*net.TCPAddr).Port
 
 	autopilotserverrpc.RegisterAutopilotServer(m.grpcServer, m)
 
@@ -92,11 +92,17 @@ func (m *Server) Stop() {
 }
 
 // GetPort returns the port number that the mock server is serving its grpc
-// server on.
+// server on. The port is only known after the server was started.
 func (m *Server) GetPort() int {
 	return m.port
 }
 
+// Addr returns the host:port address the mock server is serving its grpc
+// server on. The address is only known after the server was started.
+func (m *Server) Addr() string {
+	return fmt.Sprintf("localhost:%d", m.port)
+}
+
 // SetFeatures can be used to override the feature set served by the mock
 // autopilot server.
 func (m *Server) SetFeatures(f map[string]*Feature) {
@@ -373,7 +379,7 @@ func rulesToRPC(rulesMap map[string]*RuleRanges) (
 }
 
 func permissionsToRPC(ps map[string][]bakery.Op) []*autopilotserverrpc.Permissions {
-	res := make([]*autopilotserverrpc.Permissions, len(ps))
+	res := make([]*autopilotserverrpc.Permissions, 0, len(ps))
 
 	for method, ops := range ps {
 		operations := make([]*autopilotserverrpc.Operation, len(ops))
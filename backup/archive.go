@@ -0,0 +1,96 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Source is a lit store that can be included in a backup.
+type Source struct {
+	// Name is the file name of the store within the backup archive.
+	Name string
+
+	// Snapshot writes a consistent copy of the store to the given writer.
+	Snapshot func(w io.Writer) error
+}
+
+// writeArchive writes a gzip compressed tar archive containing a snapshot of
+// each of the given sources and the given extra files to the given writer.
+func writeArchive(w io.Writer, sources []Source, extraFiles map[string][]byte,
+	modTime time.Time) error {
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	addFile := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: modTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tw.Write(content)
+		return err
+	}
+
+	for _, source := range sources {
+		var buf bytes.Buffer
+		if err := source.Snapshot(&buf); err != nil {
+			return fmt.Errorf("unable to snapshot %s: %v",
+				source.Name, err)
+		}
+
+		if err := addFile(source.Name, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	for name, content := range extraFiles {
+		if err := addFile(name, content); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gzw.Close()
+}
+
+// readArchive reads all files of a gzip compressed tar archive created by
+// writeArchive.
+func readArchive(r io.Reader) (map[string][]byte, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		files[hdr.Name] = content
+	}
+}
@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultInterval is the default time between two backups.
+	defaultInterval = 24 * time.Hour
+
+	// defaultRetention is the default number of backups that are kept on
+	// the remote.
+	defaultRetention = 7
+
+	// minInterval is the minimum time between two backups.
+	minInterval = time.Minute
+)
+
+// Config holds all config options for the scheduled backups of the lit
+// databases.
+type Config struct {
+	Enable            bool          `long:"enable" description:"Periodically snapshot the lit databases and upload them, encrypted, to an S3-compatible endpoint."`
+	Interval          time.Duration `long:"interval" description:"The time between two backups."`
+	Retention         int           `long:"retention" description:"The number of backups to keep on the remote. Older backups are deleted after each successful upload. Set to 0 to keep all backups."`
+	IncludeChanBackup bool          `long:"include-chanbackup" description:"Also include the latest static channel backup of lnd in each backup."`
+	PassphraseFile    string        `long:"passphrase-file" description:"The full path to a file containing the passphrase that is used to encrypt the backups."`
+
+	S3 *S3Config `group:"s3" namespace:"s3"`
+}
+
+// S3Config holds the options for the S3-compatible endpoint the backups are
+// uploaded to.
+type S3Config struct {
+	Endpoint        string `long:"endpoint" description:"The URL of the S3-compatible endpoint, for example https://s3.us-east-1.amazonaws.com."`
+	Region          string `long:"region" description:"The region of the bucket."`
+	Bucket          string `long:"bucket" description:"The name of the bucket to upload the backups to."`
+	Prefix          string `long:"prefix" description:"An optional key prefix for the uploaded backups, for example litd/."`
+	AccessKeyID     string `long:"accesskeyid" description:"The access key ID used to authenticate with the endpoint."`
+	SecretAccessKey string `long:"secretaccesskey" description:"The secret access key used to authenticate with the endpoint."`
+	PathStyle       bool   `long:"pathstyle" description:"Address the bucket as part of the URL path instead of the host name. Required by most self-hosted S3-compatible services."`
+}
+
+// DefaultConfig constructs the default backup Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		Interval:  defaultInterval,
+		Retention: defaultRetention,
+		S3: &S3Config{
+			Region: "us-east-1",
+		},
+	}
+}
+
+// Validate makes sure the config is sane if backups are enabled.
+func (c *Config) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.Interval < minInterval {
+		return fmt.Errorf("backup interval must be at least %v",
+			minInterval)
+	}
+
+	if c.Retention < 0 {
+		return fmt.Errorf("backup retention cannot be negative")
+	}
+
+	if c.PassphraseFile == "" {
+		return fmt.Errorf("a backup passphrase file must be set")
+	}
+
+	switch {
+	case c.S3.Endpoint == "":
+		return fmt.Errorf("the backup S3 endpoint must be set")
+
+	case c.S3.Bucket == "":
+		return fmt.Errorf("the backup S3 bucket must be set")
+
+	case c.S3.Region == "":
+		return fmt.Errorf("the backup S3 region must be set")
+
+	case c.S3.AccessKeyID == "" || c.S3.SecretAccessKey == "":
+		return fmt.Errorf("the backup S3 credentials must be set")
+	}
+
+	return nil
+}
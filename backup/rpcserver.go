@@ -0,0 +1,94 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/scb"
+)
+
+// RPCServer is the main server that implements the Backups gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedBackupsServer
+
+	chanBackupMgr *scb.Manager
+	scheduler     *Scheduler
+}
+
+// NewRPCServer returns a new RPC server for the given channel backup manager
+// and backup scheduler.
+func NewRPCServer(chanBackupMgr *scb.Manager,
+	scheduler *Scheduler) *RPCServer {
+
+	return &RPCServer{
+		chanBackupMgr: chanBackupMgr,
+		scheduler:     scheduler,
+	}
+}
+
+// ExportChannelBackup fetches the latest static channel backup of all channels
+// from lnd and returns it encrypted.
+func (s *RPCServer) ExportChannelBackup(ctx context.Context,
+	req *litrpc.ExportChannelBackupRequest) (
+	*litrpc.ExportChannelBackupResponse, error) {
+
+	log.Infof("[exportchannelbackup]")
+
+	backup, err := s.chanBackupMgr.Export(ctx, req.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("unable to export channel backup: %v",
+			err)
+	}
+
+	resp := &litrpc.ExportChannelBackupResponse{
+		EncryptedBackup: backup.EncryptedBlob,
+		CreatedAt:       backup.CreatedAt.Unix(),
+		KeySource:       string(backup.KeySource),
+	}
+
+	lastPush, lastPushErr := s.chanBackupMgr.LastPush()
+	if !lastPush.IsZero() {
+		resp.LastPush = lastPush.Unix()
+	}
+	if lastPushErr != nil {
+		resp.LastPushError = lastPushErr.Error()
+	}
+
+	return resp, nil
+}
+
+// GetBackupStatus returns the status of the scheduled backups of the lit
+// databases.
+func (s *RPCServer) GetBackupStatus(_ context.Context,
+	_ *litrpc.GetBackupStatusRequest) (*litrpc.GetBackupStatusResponse,
+	error) {
+
+	log.Infof("[getbackupstatus]")
+
+	status := s.scheduler.Status()
+	resp := &litrpc.GetBackupStatusResponse{
+		Enabled:       status.Enabled,
+		LastAttempt:   unixOrZero(status.LastAttempt),
+		LastSuccess:   unixOrZero(status.LastSuccess),
+		LastObjectKey: status.LastObjectKey,
+		NextRun:       unixOrZero(status.NextRun),
+	}
+	if status.LastErr != nil {
+		resp.LastError = status.LastErr.Error()
+	}
+
+	return resp, nil
+}
+
+// unixOrZero returns the unix timestamp of the given time or zero if the time
+// is not set.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
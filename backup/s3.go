@@ -0,0 +1,322 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// s3Timeout is the maximum time a single request to the S3 endpoint
+	// may take.
+	s3Timeout = 5 * time.Minute
+
+	// amzDateFormat is the time format used in the x-amz-date header.
+	amzDateFormat = "20060102T150405Z"
+)
+
+// s3Client is a minimal client for S3-compatible object stores that supports
+// uploading, listing and deleting objects using AWS signature version 4.
+type s3Client struct {
+	cfg      *S3Config
+	endpoint *url.URL
+	client   *http.Client
+
+	// now returns the current time. It can be overwritten in tests.
+	now func() time.Time
+}
+
+// newS3Client creates a new client for the S3-compatible endpoint of the
+// given config.
+func newS3Client(cfg *S3Config) (*s3Client, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %v", err)
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("S3 endpoint must be a http(s) URL")
+	}
+
+	return &s3Client{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   http.DefaultClient,
+		now:      time.Now,
+	}, nil
+}
+
+// putObject uploads the given data as an object with the given key.
+func (c *s3Client) putObject(ctx context.Context, key string,
+	data []byte) error {
+
+	resp, err := c.do(ctx, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkStatus(resp)
+}
+
+// deleteObject deletes the object with the given key.
+func (c *s3Client) deleteObject(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkStatus(resp)
+}
+
+// listBucketResult is the XML response of a ListObjectsV2 request.
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// listObjects returns the keys of all objects that start with the given
+// prefix.
+func (c *s3Client) listObjects(ctx context.Context,
+	prefix string) ([]string, error) {
+
+	var (
+		keys  []string
+		token string
+	)
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		resp, err := c.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkStatus(resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode object list: "+
+				"%v", err)
+		}
+
+		for _, obj := range result.Contents {
+			keys = append(keys, obj.Key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do creates, signs and sends a request for the given object key.
+func (c *s3Client) do(ctx context.Context, method, key string,
+	query url.Values, body []byte) (*http.Response, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+
+	reqURL := *c.endpoint
+	if c.cfg.PathStyle {
+		reqURL.Path = "/" + c.cfg.Bucket + "/" + key
+	} else {
+		reqURL.Host = c.cfg.Bucket + "." + reqURL.Host
+		reqURL.Path = "/" + key
+	}
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(
+		ctx, method, reqURL.String(), bytes.NewReader(body),
+	)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	signV4(
+		req, body, c.cfg.AccessKeyID, c.cfg.SecretAccessKey,
+		c.cfg.Region, c.now(),
+	)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Cancel the context once the body was read and closed.
+	resp.Body = &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelReadCloser is an io.ReadCloser that cancels a context when closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel func()
+}
+
+// Close closes the underlying reader and cancels the context.
+func (c *cancelReadCloser) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// checkStatus returns an error if the response has a non-2xx status code.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("unexpected S3 response status %d: %s",
+		resp.StatusCode, body)
+}
+
+// signV4 signs the given request with AWS signature version 4. All headers
+// already set on the request are included in the signature.
+func signV4(req *http.Request, body []byte, accessKeyID, secretKey,
+	region string, t time.Time) {
+
+	t = t.UTC()
+	amzDate := t.Format(amzDateFormat)
+	date := t.Format("20060102")
+
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("x-amz-content-sha256", hex.EncodeToString(
+		payloadHash[:],
+	))
+	req.Header.Set("x-amz-date", amzDate)
+
+	// Collect the headers to sign, which always includes the host.
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(
+			strings.Join(values, ","),
+		)
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, "+
+			"Signature=%s", accessKeyID, scope, signedHeaders,
+		signature,
+	))
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the given data with the given key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery returns the canonical query string of the given values as
+// defined by AWS signature version 4.
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vals := append([]string(nil), values[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(
+				parts, uriEncode(k, true)+"="+uriEncode(v, true),
+			)
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// uriEncode encodes the given string as defined by AWS signature version 4.
+// All characters except the unreserved ones are percent-encoded. The slash is
+// only encoded if encodeSlash is true.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z',
+			c >= '0' && c <= '9', c == '-', c == '_', c == '.',
+			c == '~':
+
+			b.WriteByte(c)
+
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,274 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/scb"
+)
+
+const (
+	// chanBackupFileName is the name of the static channel backup within
+	// the backup archive.
+	chanBackupFileName = "channel.backup"
+
+	// keyTimeFormat is the time format used in the object keys of the
+	// backups. It sorts lexicographically in chronological order.
+	keyTimeFormat = "20060102T150405Z"
+)
+
+// objectStore is the interface of the remote the backups are uploaded to.
+type objectStore interface {
+	// putObject uploads the given data as an object with the given key.
+	putObject(ctx context.Context, key string, data []byte) error
+
+	// listObjects returns the keys of all objects with the given prefix.
+	listObjects(ctx context.Context, prefix string) ([]string, error)
+
+	// deleteObject deletes the object with the given key.
+	deleteObject(ctx context.Context, key string) error
+}
+
+// Status is the status of the backup scheduler.
+type Status struct {
+	// Enabled is true if scheduled backups are enabled.
+	Enabled bool
+
+	// LastAttempt is the time of the last backup attempt.
+	LastAttempt time.Time
+
+	// LastSuccess is the time of the last successful backup.
+	LastSuccess time.Time
+
+	// LastErr is the error of the last backup attempt, if it failed.
+	LastErr error
+
+	// LastObjectKey is the object key of the last successful backup.
+	LastObjectKey string
+
+	// NextRun is the time the next backup is scheduled for.
+	NextRun time.Time
+}
+
+// Scheduler periodically snapshots the lit databases and uploads them,
+// encrypted, to an S3-compatible endpoint.
+type Scheduler struct {
+	cfg     *Config
+	network string
+	sources []Source
+
+	// chanBackup fetches the latest static channel backup. It is only
+	// used if the channel backup should be included.
+	chanBackup func(ctx context.Context) ([]byte, error)
+
+	store objectStore
+
+	mu     sync.Mutex
+	status Status
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new backup Scheduler.
+func NewScheduler(cfg *Config, network string, sources []Source,
+	chanBackup func(ctx context.Context) ([]byte, error)) (*Scheduler,
+	error) {
+
+	s := &Scheduler{
+		cfg:        cfg,
+		network:    network,
+		sources:    sources,
+		chanBackup: chanBackup,
+		quit:       make(chan struct{}),
+		status: Status{
+			Enabled: cfg.Enable,
+		},
+	}
+
+	if !cfg.Enable {
+		return s, nil
+	}
+
+	store, err := newS3Client(cfg.S3)
+	if err != nil {
+		return nil, err
+	}
+	s.store = store
+
+	return s, nil
+}
+
+// Start starts the periodic backups if they are enabled.
+func (s *Scheduler) Start() error {
+	if !s.cfg.Enable {
+		return nil
+	}
+
+	log.Infof("Starting backup scheduler with interval %v", s.cfg.Interval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-s.quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		// Run the first backup right away so that a fresh start always
+		// results in an up-to-date backup.
+		for {
+			s.setNextRun(time.Now().Add(s.cfg.Interval))
+			s.runBackup(ctx)
+
+			select {
+			case <-ticker.C:
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the scheduler and waits for any running backup to finish.
+func (s *Scheduler) Stop() error {
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// Status returns the current status of the scheduler.
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// setNextRun sets the time of the next scheduled backup.
+func (s *Scheduler) setNextRun(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.NextRun = t
+}
+
+// runBackup creates a new backup, uploads it and records the outcome in the
+// status.
+func (s *Scheduler) runBackup(ctx context.Context) {
+	now := time.Now()
+	key, err := s.backup(ctx, now)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.LastAttempt = now
+	s.status.LastErr = err
+	if err != nil {
+		log.Errorf("Backup failed: %v", err)
+		return
+	}
+
+	log.Infof("Uploaded backup %s", key)
+	s.status.LastSuccess = now
+	s.status.LastObjectKey = key
+}
+
+// backup creates a new encrypted backup archive, uploads it and then applies
+// the retention policy. The key of the uploaded object is returned.
+func (s *Scheduler) backup(ctx context.Context, now time.Time) (string,
+	error) {
+
+	passphrase, err := scb.ReadPassphraseFile(s.cfg.PassphraseFile)
+	if err != nil {
+		return "", err
+	}
+
+	extraFiles := make(map[string][]byte)
+	if s.cfg.IncludeChanBackup {
+		multi, err := s.chanBackup(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		extraFiles[chanBackupFileName] = multi
+	}
+
+	var archive bytes.Buffer
+	err = writeArchive(&archive, s.sources, extraFiles, now)
+	if err != nil {
+		return "", fmt.Errorf("unable to create backup archive: %v",
+			err)
+	}
+
+	blob, err := scb.Encrypt(archive.Bytes(), passphrase)
+	if err != nil {
+		return "", fmt.Errorf("unable to encrypt backup: %v", err)
+	}
+
+	key := s.keyPrefix() + now.UTC().Format(keyTimeFormat) + ".tar.gz.enc"
+	if err := s.store.putObject(ctx, key, blob); err != nil {
+		return "", fmt.Errorf("unable to upload backup: %v", err)
+	}
+
+	// A failure to clean up old backups shouldn't mark the backup itself
+	// as failed.
+	if err := s.applyRetention(ctx); err != nil {
+		log.Errorf("Unable to apply backup retention policy: %v", err)
+	}
+
+	return key, nil
+}
+
+// applyRetention deletes all but the newest backups as configured by the
+// retention policy.
+func (s *Scheduler) applyRetention(ctx context.Context) error {
+	if s.cfg.Retention == 0 {
+		return nil
+	}
+
+	keys, err := s.store.listObjects(ctx, s.keyPrefix())
+	if err != nil {
+		return err
+	}
+
+	if len(keys) <= s.cfg.Retention {
+		return nil
+	}
+
+	// The keys contain the backup time in a lexicographically sortable
+	// format, so the oldest backups come first.
+	sort.Strings(keys)
+	for _, key := range keys[:len(keys)-s.cfg.Retention] {
+		if err := s.store.deleteObject(ctx, key); err != nil {
+			return err
+		}
+
+		log.Debugf("Deleted old backup %s", key)
+	}
+
+	return nil
+}
+
+// keyPrefix returns the common prefix of the object keys of all backups of
+// this node's network.
+func (s *Scheduler) keyPrefix() string {
+	return fmt.Sprintf("%slit-backup-%s-", s.cfg.S3.Prefix, s.network)
+}
@@ -0,0 +1,142 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/scb"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStore is an in-memory objectStore.
+type mockStore struct {
+	objects map[string][]byte
+}
+
+func (m *mockStore) putObject(_ context.Context, key string,
+	data []byte) error {
+
+	m.objects[key] = data
+	return nil
+}
+
+func (m *mockStore) listObjects(_ context.Context,
+	prefix string) ([]string, error) {
+
+	var keys []string
+	for k := range m.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (m *mockStore) deleteObject(_ context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+// TestSchedulerBackup tests that a backup contains all sources, is encrypted
+// and that the retention policy is applied.
+func TestSchedulerBackup(t *testing.T) {
+	passFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passFile, []byte("pass"), 0600))
+
+	cfg := &Config{
+		Enable:            true,
+		Interval:          time.Hour,
+		Retention:         2,
+		IncludeChanBackup: true,
+		PassphraseFile:    passFile,
+		S3:                &S3Config{Prefix: "litd/"},
+	}
+	sources := []Source{{
+		Name: "a.db",
+		Snapshot: func(w io.Writer) error {
+			_, err := w.Write([]byte("db a"))
+			return err
+		},
+	}, {
+		Name: "b.db",
+		Snapshot: func(w io.Writer) error {
+			_, err := w.Write([]byte("db b"))
+			return err
+		},
+	}}
+	chanBackup := func(context.Context) ([]byte, error) {
+		return []byte("scb"), nil
+	}
+
+	store := &mockStore{objects: make(map[string][]byte)}
+	s := &Scheduler{
+		cfg:        cfg,
+		network:    "regtest",
+		sources:    sources,
+		chanBackup: chanBackup,
+		store:      store,
+	}
+
+	// Also add a backup of another network that must not be touched by
+	// the retention policy.
+	otherKey := "litd/lit-backup-mainnet-20200101T000000Z.tar.gz.enc"
+	store.objects[otherKey] = nil
+
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var keys []string
+	for i := 0; i < 3; i++ {
+		key, err := s.backup(ctx, start.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+
+	require.Equal(
+		t, "litd/lit-backup-regtest-20230101T000000Z.tar.gz.enc",
+		keys[0],
+	)
+
+	// Only the two newest backups should be kept.
+	require.Len(t, store.objects, 3)
+	require.NotContains(t, store.objects, keys[0])
+	require.Contains(t, store.objects, keys[1])
+	require.Contains(t, store.objects, keys[2])
+	require.Contains(t, store.objects, otherKey)
+
+	// The backup should decrypt to an archive with all sources and the
+	// channel backup.
+	archive, err := scb.Decrypt(store.objects[keys[2]], []byte("pass"))
+	require.NoError(t, err)
+
+	files, err := readArchive(bytes.NewReader(archive))
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{
+		"a.db":             []byte("db a"),
+		"b.db":             []byte("db b"),
+		chanBackupFileName: []byte("scb"),
+	}, files)
+
+	// A failing source should fail the backup and be reflected in the
+	// status.
+	s.sources = append(s.sources, Source{
+		Name: "c.db",
+		Snapshot: func(io.Writer) error {
+			return fmt.Errorf("db closed")
+		},
+	})
+	s.runBackup(ctx)
+
+	status := s.Status()
+	require.ErrorContains(t, status.LastErr, "db closed")
+	require.True(t, status.LastSuccess.IsZero())
+}
@@ -0,0 +1,89 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/cluster"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	// clusterAccountsDB is the name of the accounts store in the shared
+	// database of a cluster.
+	clusterAccountsDB = "accounts"
+
+	// clusterSessionDB is the name of the session store in the shared
+	// database of a cluster.
+	clusterSessionDB = "session"
+)
+
+// joinCluster blocks until this instance is elected as the leader of its
+// cluster and then opens the account service and the session store backend
+// in the shared database. Only the leader may touch the shared stores, so a
+// standby instance waits here until the active one is gone or the given
+// shutdown channel is closed.
+func (g *LightningTerminal) joinCluster(shutdown <-chan struct{}) (
+	kvdb.Backend, error) {
+
+	var err error
+	g.clusterMgr, err = cluster.NewManager(g.cfg.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cluster manager: %v",
+			err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := g.clusterMgr.WaitForLeadership(ctx); err != nil {
+		return nil, err
+	}
+
+	// The backends run all their transactions with the context they are
+	// opened with, so it must outlive the election.
+	accountsBackend, err := g.clusterMgr.OpenBackend(
+		context.Background(), clusterAccountsDB,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not open shared accounts "+
+			"database: %v", err)
+	}
+
+	accountStore, err := accounts.NewStore(accountsBackend)
+	if err != nil {
+		return nil, fmt.Errorf("could not create account store: %v",
+			err)
+	}
+	g.accountService = accounts.NewServiceWithStore(
+		accountStore, g.errQueue.ChanIn(),
+	)
+
+	sessionBackend, err := g.clusterMgr.OpenBackend(
+		context.Background(), clusterSessionDB,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not open shared session "+
+			"database: %v", err)
+	}
+
+	// Once the leadership is lost, another instance may already be
+	// active. The shared stores are fenced by the cluster manager, and no
+	// new account payments are accepted before litd shuts down.
+	accountService := g.accountService
+	g.clusterMgr.Start(g.errQueue.ChanIn(), func() {
+		accountService.Flush()
+	})
+
+	return sessionBackend, nil
+}
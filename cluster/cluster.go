@@ -0,0 +1,164 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// elector campaigns for the leadership of the cluster.
+type elector interface {
+	// campaign blocks until this instance is the leader of the cluster or
+	// the context is canceled.
+	campaign(ctx context.Context) error
+
+	// heartbeat returns an error if this instance lost the leadership.
+	heartbeat(ctx context.Context) error
+
+	// leader returns the ID of the current leader of the cluster.
+	leader(ctx context.Context) (string, error)
+
+	// resign gives up the leadership so that a standby instance can take
+	// over.
+	resign() error
+}
+
+// Manager makes sure that only one litd instance of a cluster is active at a
+// time and opens the stores that are shared by all instances.
+type Manager struct {
+	cfg     *Config
+	elector elector
+
+	// backends are the shared stores this instance opened. They are
+	// fenced as soon as the leadership is lost.
+	backendsMtx sync.Mutex
+	backends    []*fencedBackend
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// NewManager creates a new cluster manager.
+func NewManager(cfg *Config) (*Manager, error) {
+	e, err := newElector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		cfg:     cfg,
+		elector: e,
+		quit:    make(chan struct{}),
+	}, nil
+}
+
+// WaitForLeadership blocks until this instance is the leader of the cluster or
+// the context is canceled. A standby instance waits here until the active one
+// is gone.
+func (m *Manager) WaitForLeadership(ctx context.Context) error {
+	leader, err := m.elector.leader(ctx)
+	if err == nil && leader != "" && leader != m.cfg.ID {
+		log.Infof("Waiting in standby, %s is the cluster leader",
+			leader)
+	}
+
+	if err := m.elector.campaign(ctx); err != nil {
+		return fmt.Errorf("unable to become the cluster leader: %v",
+			err)
+	}
+
+	log.Infof("Elected as the cluster leader with ID %s", m.cfg.ID)
+
+	return nil
+}
+
+// Start starts checking that this instance is still the leader of the
+// cluster. Once the leadership is lost, this instance must not touch the shared
+// stores anymore. Before an error is sent to the given channel, the shared
+// stores are fenced and the given function is called, so that nothing is
+// written to them while litd shuts down.
+func (m *Manager) Start(errChan chan<- error, onLost func()) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.cfg.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(
+					context.Background(),
+					m.cfg.HeartbeatInterval,
+				)
+				err := m.elector.heartbeat(ctx)
+				cancel()
+				if err == nil {
+					continue
+				}
+
+				log.Errorf("Lost the cluster leadership: %v",
+					err)
+
+				m.fence()
+				if onLost != nil {
+					onLost()
+				}
+
+				errChan <- fmt.Errorf("lost the cluster "+
+					"leadership: %v", err)
+
+				return
+
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the leadership checks and resigns from the leadership. It must
+// only be called once the shared stores are closed.
+func (m *Manager) Stop() error {
+	close(m.quit)
+	m.wg.Wait()
+
+	return m.elector.resign()
+}
+
+// fence fails all further transactions on the shared stores. It waits for the
+// transactions that are still running.
+func (m *Manager) fence() {
+	m.backendsMtx.Lock()
+	defer m.backendsMtx.Unlock()
+
+	for _, backend := range m.backends {
+		backend.fence()
+	}
+
+	log.Infof("Fenced %d shared stores", len(m.backends))
+}
+
+// OpenBackend opens the database backend with the given name in the shared
+// Postgres database of the cluster. All its transactions fail with ErrFenced
+// once this instance lost the leadership.
+func (m *Manager) OpenBackend(ctx context.Context, name string) (
+	kvdb.Backend, error) {
+
+	backend, err := openBackend(ctx, m.cfg, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fenced := newFencedBackend(backend)
+
+	m.backendsMtx.Lock()
+	m.backends = append(m.backends, fenced)
+	m.backendsMtx.Unlock()
+
+	return fenced, nil
+}
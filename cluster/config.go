@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb/postgres"
+)
+
+const (
+	// defaultHeartbeatInterval is the default interval in which the leader
+	// checks that it still holds the leadership and in which a standby
+	// instance retries to become the leader.
+	defaultHeartbeatInterval = 5 * time.Second
+
+	// defaultPostgresTimeout is the default timeout of the queries run
+	// against the shared Postgres database.
+	defaultPostgresTimeout = 30 * time.Second
+)
+
+// Config holds all config options for running litd as part of an
+// active/standby cluster.
+type Config struct {
+	Enable            bool          `long:"enable" description:"Store the accounts and sessions in a shared Postgres database and only become active once this instance is elected as the leader of the cluster. Requires litd to be built with the kvdb_postgres build tag."`
+	ID                string        `long:"id" description:"The unique ID of this instance in the cluster. Defaults to the hostname."`
+	HeartbeatInterval time.Duration `long:"heartbeatinterval" description:"The interval in which the leader checks that it still holds the leadership and in which a standby instance tries to become the leader."`
+
+	Postgres *postgres.Config `group:"postgres" namespace:"postgres"`
+}
+
+// DefaultConfig constructs the default cluster Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		HeartbeatInterval: defaultHeartbeatInterval,
+		Postgres: &postgres.Config{
+			Timeout: defaultPostgresTimeout,
+		},
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if !Available {
+		return fmt.Errorf("litd must be built with the kvdb_postgres " +
+			"build tag to run in a cluster")
+	}
+
+	if c.Postgres.Dsn == "" {
+		return fmt.Errorf("the cluster postgres DSN must be set")
+	}
+
+	if c.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("unable to determine the cluster ID: "+
+				"%v", err)
+		}
+		c.ID = hostname
+	}
+
+	if c.HeartbeatInterval < time.Second {
+		return fmt.Errorf("the cluster heartbeat interval must be at " +
+			"least one second")
+	}
+
+	return nil
+}
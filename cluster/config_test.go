@@ -0,0 +1,31 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate tests that the cluster config is only accepted if litd can
+// actually run in a cluster.
+func TestValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enable = true
+	if !Available {
+		require.ErrorContains(t, cfg.Validate(), "kvdb_postgres")
+		return
+	}
+
+	require.ErrorContains(t, cfg.Validate(), "DSN")
+
+	cfg.Postgres.Dsn = "postgres://lit@localhost/lit"
+	cfg.HeartbeatInterval = time.Millisecond
+	require.ErrorContains(t, cfg.Validate(), "heartbeat")
+
+	cfg.HeartbeatInterval = time.Second
+	require.NoError(t, cfg.Validate())
+	require.NotEmpty(t, cfg.ID)
+}
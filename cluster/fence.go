@@ -0,0 +1,202 @@
+package cluster
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// ErrFenced is returned for every transaction on a shared store once this
+// instance lost the leadership of the cluster.
+var ErrFenced = errors.New("lost the cluster leadership, the shared stores " +
+	"can't be used anymore")
+
+// fencedBackend is a database backend that can be fenced off once this
+// instance lost the leadership of the cluster. Every transaction holds a read
+// lock while it runs, so fencing the backend waits for the running
+// transactions and no new ones are started afterwards.
+type fencedBackend struct {
+	kvdb.Backend
+
+	mu     sync.RWMutex
+	fenced bool
+	closed bool
+}
+
+// newFencedBackend wraps the given backend so that it can be fenced.
+func newFencedBackend(backend kvdb.Backend) *fencedBackend {
+	return &fencedBackend{
+		Backend: backend,
+	}
+}
+
+// fence waits for the running transactions and fails all new ones with
+// ErrFenced.
+func (b *fencedBackend) fence() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.fenced = true
+}
+
+// acquire takes the read lock for a transaction. It fails if the backend was
+// fenced, in which case the lock isn't held.
+func (b *fencedBackend) acquire() error {
+	b.mu.RLock()
+	if b.fenced {
+		b.mu.RUnlock()
+		return ErrFenced
+	}
+
+	return nil
+}
+
+// BeginReadTx opens a database read transaction.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *fencedBackend) BeginReadTx() (walletdb.ReadTx, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+
+	tx, err := b.Backend.BeginReadTx()
+	if err != nil {
+		b.mu.RUnlock()
+		return nil, err
+	}
+
+	return &fencedReadTx{ReadTx: tx, release: b.releaseOnce()}, nil
+}
+
+// BeginReadWriteTx opens a database read+write transaction.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *fencedBackend) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+
+	tx, err := b.Backend.BeginReadWriteTx()
+	if err != nil {
+		b.mu.RUnlock()
+		return nil, err
+	}
+
+	return &fencedReadWriteTx{
+		ReadWriteTx: tx,
+		release:     b.releaseOnce(),
+	}, nil
+}
+
+// releaseOnce returns a function that releases the read lock of a
+// transaction, no matter how often it is called.
+func (b *fencedBackend) releaseOnce() func() {
+	var once sync.Once
+	return func() {
+		once.Do(b.mu.RUnlock)
+	}
+}
+
+// View opens a database read transaction and executes the given function.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *fencedBackend) View(f func(tx walletdb.ReadTx) error,
+	reset func()) error {
+
+	if err := b.acquire(); err != nil {
+		return err
+	}
+	defer b.mu.RUnlock()
+
+	return b.Backend.View(f, reset)
+}
+
+// Update opens a database read/write transaction and executes the given
+// function.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *fencedBackend) Update(f func(tx walletdb.ReadWriteTx) error,
+	reset func()) error {
+
+	if err := b.acquire(); err != nil {
+		return err
+	}
+	defer b.mu.RUnlock()
+
+	return b.Backend.Update(f, reset)
+}
+
+// Copy writes a copy of the database to the provided writer.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *fencedBackend) Copy(w io.Writer) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+	defer b.mu.RUnlock()
+
+	return b.Backend.Copy(w)
+}
+
+// Close closes the underlying backend. It can be called more than once, as
+// the stores are still closed on shutdown after they were fenced.
+//
+// NOTE: This is part of the walletdb.DB interface.
+func (b *fencedBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.fenced = true
+
+	return b.Backend.Close()
+}
+
+// fencedReadTx is a read transaction that releases the read lock of its
+// fenced backend once it is done.
+type fencedReadTx struct {
+	walletdb.ReadTx
+
+	release func()
+}
+
+// Rollback closes the transaction.
+//
+// NOTE: This is part of the walletdb.ReadTx interface.
+func (t *fencedReadTx) Rollback() error {
+	defer t.release()
+
+	return t.ReadTx.Rollback()
+}
+
+// fencedReadWriteTx is a read/write transaction that releases the read lock of
+// its fenced backend once it is committed or rolled back.
+type fencedReadWriteTx struct {
+	walletdb.ReadWriteTx
+
+	release func()
+}
+
+// Rollback closes the transaction, discarding changes.
+//
+// NOTE: This is part of the walletdb.ReadTx interface.
+func (t *fencedReadWriteTx) Rollback() error {
+	defer t.release()
+
+	return t.ReadWriteTx.Rollback()
+}
+
+// Commit commits all changes that have been made through the transaction.
+//
+// NOTE: This is part of the walletdb.ReadWriteTx interface.
+func (t *fencedReadWriteTx) Commit() error {
+	defer t.release()
+
+	return t.ReadWriteTx.Commit()
+}
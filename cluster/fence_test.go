@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFencedBackend tests that fencing a backend waits for the running
+// transactions and fails all new ones.
+func TestFencedBackend(t *testing.T) {
+	t.Parallel()
+
+	bolt, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
+		DBPath:     t.TempDir(),
+		DBFileName: "test.db",
+		DBTimeout:  time.Second,
+	})
+	require.NoError(t, err)
+
+	backend := newFencedBackend(bolt)
+
+	bucket := []byte("bucket")
+	err = kvdb.Update(backend, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(bucket)
+		return err
+	}, func() {})
+	require.NoError(t, err)
+
+	// A transaction that is still running when the backend is fenced is
+	// allowed to finish first.
+	tx, err := backend.BeginReadWriteTx()
+	require.NoError(t, err)
+
+	fenced := make(chan struct{})
+	go func() {
+		backend.fence()
+		close(fenced)
+	}()
+
+	select {
+	case <-fenced:
+		t.Fatal("backend fenced while a transaction is running")
+
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	err = tx.ReadWriteBucket(bucket).Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	select {
+	case <-fenced:
+
+	case <-time.After(time.Second):
+		t.Fatal("backend not fenced after the transaction finished")
+	}
+
+	// No new transactions are started once the backend is fenced.
+	err = kvdb.View(backend, func(kvdb.RTx) error {
+		return nil
+	}, func() {})
+	require.ErrorIs(t, err, ErrFenced)
+
+	_, err = backend.BeginReadWriteTx()
+	require.ErrorIs(t, err, ErrFenced)
+
+	// The stores are still closed on shutdown.
+	require.NoError(t, backend.Close())
+	require.NoError(t, backend.Close())
+}
@@ -0,0 +1,28 @@
+//go:build !kvdb_postgres
+// +build !kvdb_postgres
+
+package cluster
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// Available is true if litd was built with Postgres support and can run in a
+// cluster.
+const Available = false
+
+// errNotAvailable is returned if a cluster is used without Postgres support.
+var errNotAvailable = errors.New("postgres support not available")
+
+// openBackend always fails without Postgres support.
+func openBackend(context.Context, *Config, string) (kvdb.Backend, error) {
+	return nil, errNotAvailable
+}
+
+// newElector always fails without Postgres support.
+func newElector(*Config) (elector, error) {
+	return nil, errNotAvailable
+}
@@ -0,0 +1,188 @@
+//go:build kvdb_postgres
+// +build kvdb_postgres
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	// Register the pgx driver with database/sql.
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	// Available is true if litd was built with Postgres support and can
+	// run in a cluster.
+	Available = true
+
+	// tablePrefix is the prefix of the tables of all lit stores in the
+	// shared Postgres database.
+	tablePrefix = "lit_"
+
+	// advisoryLockKey is the key of the Postgres advisory lock that is
+	// held by the leader of the cluster. It is "lit" in ASCII.
+	advisoryLockKey = 0x6c6974
+)
+
+// openBackend opens the database backend with the given name in the shared
+// Postgres database.
+func openBackend(ctx context.Context, cfg *Config, name string) (
+	kvdb.Backend, error) {
+
+	return kvdb.Open(
+		kvdb.PostgresBackendName, ctx, cfg.Postgres, tablePrefix+name,
+	)
+}
+
+// postgresElector elects the leader of the cluster through a session level
+// advisory lock. The lock is released by Postgres as soon as the connection
+// of the leader breaks, so a standby instance can take over even if the
+// leader crashed.
+type postgresElector struct {
+	cfg *Config
+	db  *sql.DB
+
+	// conn is the connection that holds the advisory lock. It is nil
+	// while this instance isn't the leader.
+	conn *sql.Conn
+}
+
+// newElector creates a new elector that uses the shared Postgres database.
+func newElector(cfg *Config) (elector, error) {
+	db, err := sql.Open("pgx", cfg.Postgres.Dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), cfg.Postgres.Timeout,
+	)
+	defer cancel()
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS
+		lit_cluster_leader (
+			id SMALLINT PRIMARY KEY,
+			leader TEXT NOT NULL,
+			elected_at TIMESTAMP NOT NULL
+		)`,
+	)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to create leader table: %v",
+			err)
+	}
+
+	return &postgresElector{
+		cfg: cfg,
+		db:  db,
+	}, nil
+}
+
+// campaign blocks until this instance is the leader of the cluster or the
+// context is canceled.
+func (e *postgresElector) campaign(ctx context.Context) error {
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var locked bool
+		err := conn.QueryRowContext(
+			ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey,
+		).Scan(&locked)
+		if err != nil {
+			_ = conn.Close()
+			return err
+		}
+
+		if locked {
+			break
+		}
+
+		select {
+		case <-time.After(e.cfg.HeartbeatInterval):
+
+		case <-ctx.Done():
+			_ = conn.Close()
+			return ctx.Err()
+		}
+	}
+
+	e.conn = conn
+
+	_, err = conn.ExecContext(ctx, `INSERT INTO lit_cluster_leader
+		(id, leader, elected_at) VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET leader = $1, elected_at = $2`,
+		e.cfg.ID, time.Now().UTC(),
+	)
+
+	return err
+}
+
+// heartbeat returns an error if the advisory lock isn't held by the connection
+// of this instance anymore, for example because the connection broke or was
+// terminated by an administrator, which means the leadership is lost.
+func (e *postgresElector) heartbeat(ctx context.Context) error {
+	if e.conn == nil {
+		return errors.New("not the leader")
+	}
+
+	// A ping alone isn't enough, a connection that was reestablished by
+	// the driver doesn't hold the lock anymore.
+	var held bool
+	err := e.conn.QueryRowContext(ctx, `SELECT EXISTS (
+		SELECT 1 FROM pg_locks WHERE locktype = 'advisory' AND
+			objid = $1 AND pid = pg_backend_pid() AND granted
+		)`, advisoryLockKey,
+	).Scan(&held)
+	if err != nil {
+		return err
+	}
+
+	if !held {
+		return errors.New("advisory lock not held anymore")
+	}
+
+	return nil
+}
+
+// leader returns the ID of the most recently elected leader of the cluster.
+func (e *postgresElector) leader(ctx context.Context) (string, error) {
+	var leader string
+	err := e.db.QueryRowContext(
+		ctx, "SELECT leader FROM lit_cluster_leader WHERE id = 1",
+	).Scan(&leader)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return leader, err
+}
+
+// resign releases the advisory lock and closes the database connections.
+func (e *postgresElector) resign() error {
+	if e.conn != nil {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), e.cfg.Postgres.Timeout,
+		)
+		defer cancel()
+
+		_, err := e.conn.ExecContext(
+			ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey,
+		)
+		if err != nil {
+			log.Errorf("Unable to release the leader lock: %v", err)
+		}
+
+		_ = e.conn.Close()
+		e.conn = nil
+	}
+
+	return e.db.Close()
+}
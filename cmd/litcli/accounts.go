@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/lightninglabs/lightning-terminal/litrpc"
 	"github.com/lightningnetwork/lnd/lncfg"
@@ -22,7 +23,12 @@ var accountsCommands = []cli.Command{
 			createAccountCommand,
 			updateAccountCommand,
 			listAccountsCommand,
+			accountInfoCommand,
 			removeAccountCommand,
+			freezeAccountCommand,
+			cleanupAccountsCommand,
+			createInvoicesCommand,
+			simulateCommand,
 		},
 	},
 }
@@ -50,6 +56,11 @@ var createAccountCommand = cli.Command{
 			Name:  "balance",
 			Usage: "the initial balance of the account",
 		},
+		cli.Uint64Flag{
+			Name: "balance_msat",
+			Usage: "the initial balance of the account in " +
+				"millisatoshis; can't be combined with balance",
+		},
 		cli.Int64Flag{
 			Name: "expiration_date",
 			Usage: "the expiration date of the account expressed " +
@@ -61,10 +72,141 @@ var createAccountCommand = cli.Command{
 			Usage: "store the account macaroon created for the " +
 				"account to the given file",
 		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "an optional label to identify the account",
+		},
+		cli.BoolFlag{
+			Name: "session",
+			Usage: "also create an LNC session that is restricted " +
+				"to the new account",
+		},
+		cli.StringFlag{
+			Name: "session_label",
+			Usage: "the label of the session, defaults to the " +
+				"label of the account",
+		},
+		cli.Uint64Flag{
+			Name: "session_expiry",
+			Usage: "number of seconds that the session should " +
+				"remain active, defaults to the session " +
+				"expiry configured in litd",
+		},
+		cli.StringFlag{
+			Name: "mailboxserveraddr",
+			Usage: "the host:port of the mailbox server to be " +
+				"used by the session",
+		},
+		cli.BoolFlag{
+			Name: "devserver",
+			Usage: "set to true to skip verification of the " +
+				"mailbox server's tls cert",
+		},
+		minInvoiceAmtFlag,
+		maxInvoiceAmtFlag,
+		maxFeeFlag,
+		maxFeePercentFlag,
+		expiredInvoicePolicyFlag,
 	},
 	Action: createAccount,
 }
 
+var (
+	minInvoiceAmtFlag = cli.Uint64Flag{
+		Name: "min_invoice_amt",
+		Usage: "the minimum amount in satoshis of the invoices " +
+			"the account can create; 0 means no minimum",
+	}
+	maxInvoiceAmtFlag = cli.Uint64Flag{
+		Name: "max_invoice_amt",
+		Usage: "the maximum amount in satoshis of the invoices " +
+			"the account can create; 0 means no maximum",
+	}
+	maxFeeFlag = cli.Uint64Flag{
+		Name: "max_fee",
+		Usage: "the maximum routing fee in satoshis the account " +
+			"can allow for a single payment; 0 means no maximum",
+	}
+	maxFeePercentFlag = cli.UintFlag{
+		Name: "max_fee_percent",
+		Usage: "the maximum routing fee the account can allow " +
+			"for a single payment in percent of the payment " +
+			"amount; 0 means no maximum",
+	}
+	expiredInvoicePolicyFlag = cli.StringFlag{
+		Name: "expired_invoice_policy",
+		Usage: "whether invoices that are settled after the " +
+			"account expired still credit it: 'grace' credits " +
+			"them during the grace period configured in litd, " +
+			"'credit' always credits them and 'reject' never " +
+			"credits them",
+	}
+)
+
+// expiredInvoicePolicies maps the values of the expired invoice policy flag to
+// their RPC counterparts.
+var expiredInvoicePolicies = map[string]litrpc.ExpiredInvoicePolicy{
+	"grace":  litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_GRACE,
+	"credit": litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_CREDIT,
+	"reject": litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_REJECT,
+}
+
+// parseExpiredInvoicePolicy parses the expired invoice policy flag. An empty
+// string means no policy was specified.
+func parseExpiredInvoicePolicy(
+	policyStr string) (litrpc.ExpiredInvoicePolicy, error) {
+
+	if policyStr == "" {
+		return litrpc.ExpiredInvoicePolicy_EXPIRED_INVOICE_POLICY_UNSPECIFIED,
+			nil
+	}
+
+	policy, ok := expiredInvoicePolicies[policyStr]
+	if !ok {
+		return 0, fmt.Errorf("unknown expired invoice policy %s. "+
+			"Valid options include 'grace', 'credit' and 'reject'",
+			policyStr)
+	}
+
+	return policy, nil
+}
+
+// accountLimitsSet returns true if any of the account limits was set on the
+// command line.
+func accountLimitsSet(ctx *cli.Context) bool {
+	return ctx.IsSet(minInvoiceAmtFlag.Name) ||
+		ctx.IsSet(maxInvoiceAmtFlag.Name) ||
+		ctx.IsSet(maxFeeFlag.Name) ||
+		ctx.IsSet(maxFeePercentFlag.Name)
+}
+
+// accountLimitsFromFlags returns the given account limits with the values
+// that were set on the command line applied.
+func accountLimitsFromFlags(ctx *cli.Context,
+	current *litrpc.AccountLimits) *litrpc.AccountLimits {
+
+	limits := &litrpc.AccountLimits{
+		MinInvoiceAmt: current.GetMinInvoiceAmt(),
+		MaxInvoiceAmt: current.GetMaxInvoiceAmt(),
+		MaxFee:        current.GetMaxFee(),
+		MaxFeePercent: current.GetMaxFeePercent(),
+	}
+	if ctx.IsSet(minInvoiceAmtFlag.Name) {
+		limits.MinInvoiceAmt = ctx.Uint64(minInvoiceAmtFlag.Name)
+	}
+	if ctx.IsSet(maxInvoiceAmtFlag.Name) {
+		limits.MaxInvoiceAmt = ctx.Uint64(maxInvoiceAmtFlag.Name)
+	}
+	if ctx.IsSet(maxFeeFlag.Name) {
+		limits.MaxFee = ctx.Uint64(maxFeeFlag.Name)
+	}
+	if ctx.IsSet(maxFeePercentFlag.Name) {
+		limits.MaxFeePercent = uint32(ctx.Uint(maxFeePercentFlag.Name))
+	}
+
+	return limits
+}
+
 func createAccount(ctx *cli.Context) error {
 	ctxb := context.Background()
 	clientConn, cleanup, err := connectClient(ctx)
@@ -83,6 +225,8 @@ func createAccount(ctx *cli.Context) error {
 	switch {
 	case ctx.IsSet("balance"):
 		initialBalance = ctx.Uint64("balance")
+	case ctx.IsSet("balance_msat"):
+		// The balance is given in millisatoshis instead.
 	case args.Present():
 		initialBalance, err = strconv.ParseUint(args.First(), 10, 64)
 		if err != nil {
@@ -104,13 +248,42 @@ func createAccount(ctx *cli.Context) error {
 		args = args.Tail()
 	}
 
-	if initialBalance <= 0 {
+	balanceMsat := ctx.Uint64("balance_msat")
+	if initialBalance <= 0 && balanceMsat <= 0 {
 		return fmt.Errorf("initial balance cannot be smaller than 1")
 	}
 
+	policy, err := parseExpiredInvoicePolicy(
+		ctx.String(expiredInvoicePolicyFlag.Name),
+	)
+	if err != nil {
+		return err
+	}
+
 	req := &litrpc.CreateAccountRequest{
-		AccountBalance: initialBalance,
-		ExpirationDate: expirationDate,
+		AccountBalance:       initialBalance,
+		AccountBalanceMsat:   balanceMsat,
+		ExpirationDate:       expirationDate,
+		Label:                ctx.String("label"),
+		ExpiredInvoicePolicy: policy,
+	}
+	if accountLimitsSet(ctx) {
+		req.Limits = accountLimitsFromFlags(ctx, nil)
+	}
+	if ctx.Bool("session") {
+		var sessionExpiry int64
+		if ctx.IsSet("session_expiry") {
+			sessionLength := time.Second *
+				time.Duration(ctx.Uint64("session_expiry"))
+			sessionExpiry = time.Now().Add(sessionLength).Unix()
+		}
+
+		req.Session = &litrpc.AccountSessionRequest{
+			Label:                  ctx.String("session_label"),
+			ExpiryTimestampSeconds: uint64(sessionExpiry),
+			MailboxServerAddr:      ctx.String("mailboxserveraddr"),
+			DevServer:              ctx.Bool("devserver"),
+		}
 	}
 	resp, err := client.CreateAccount(ctxb, req)
 	if err != nil {
@@ -141,8 +314,10 @@ var updateAccountCommand = cli.Command{
 	Usage:     "Update an existing off-chain account.",
 	ArgsUsage: "id new_balance [new_expiration_date] [--save_to=]",
 	Description: `
-	Updates an existing off-chain account and sets either a new balance or
-	new expiration date or both.
+	Updates an existing off-chain account and sets a new balance, a new
+	expiration date, new invoice amount and fee limits or a new policy for
+	invoices that are settled after the account expired. Limits that aren't
+	specified keep their current value.
 	`,
 	Flags: []cli.Flag{
 		cli.StringFlag{
@@ -155,6 +330,12 @@ var updateAccountCommand = cli.Command{
 				"not update the balance",
 			Value: -1,
 		},
+		cli.Int64Flag{
+			Name: "new_balance_msat",
+			Usage: "the new balance of the account in " +
+				"millisatoshis; takes precedence over " +
+				"new_balance if set",
+		},
 		cli.Int64Flag{
 			Name: "new_expiration_date",
 			Usage: "the new expiration date of the account " +
@@ -163,6 +344,11 @@ var updateAccountCommand = cli.Command{
 				"0 means it does not expire",
 			Value: -1,
 		},
+		minInvoiceAmtFlag,
+		maxInvoiceAmtFlag,
+		maxFeeFlag,
+		maxFeePercentFlag,
+		expiredInvoicePolicyFlag,
 	},
 	Action: updateAccount,
 }
@@ -223,11 +409,34 @@ func updateAccount(ctx *cli.Context) error {
 		args = args.Tail()
 	}
 
+	policy, err := parseExpiredInvoicePolicy(
+		ctx.String(expiredInvoicePolicyFlag.Name),
+	)
+	if err != nil {
+		return err
+	}
+
 	req := &litrpc.UpdateAccountRequest{
-		Id:             hex.EncodeToString(id),
-		AccountBalance: newBalance,
-		ExpirationDate: expirationDate,
+		Id:                   hex.EncodeToString(id),
+		AccountBalance:       newBalance,
+		AccountBalanceMsat:   ctx.Int64("new_balance_msat"),
+		ExpirationDate:       expirationDate,
+		ExpiredInvoicePolicy: policy,
 	}
+
+	// The limits are always updated together, so we need to fetch the
+	// current ones to only change the limits that were specified.
+	if accountLimitsSet(ctx) {
+		acct, err := client.AccountInfo(ctxb, &litrpc.AccountInfoRequest{
+			Id: req.Id,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching account: %v", err)
+		}
+
+		req.Limits = accountLimitsFromFlags(ctx, acct.Limits)
+	}
+
 	resp, err := client.UpdateAccount(ctxb, req)
 	if err != nil {
 		return err
@@ -245,6 +454,13 @@ var listAccountsCommand = cli.Command{
 	Returns all accounts that are currently stored in the account
 	database.
 	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "include_removed",
+			Usage: "also list who removed which accounts, when " +
+				"and why",
+		},
+	},
 	Action: listAccounts,
 }
 
@@ -257,7 +473,9 @@ func listAccounts(ctx *cli.Context) error {
 	defer cleanup()
 	client := litrpc.NewAccountsClient(clientConn)
 
-	req := &litrpc.ListAccountsRequest{}
+	req := &litrpc.ListAccountsRequest{
+		IncludeRemoved: ctx.Bool("include_removed"),
+	}
 	resp, err := client.ListAccounts(ctxb, req)
 	if err != nil {
 		return err
@@ -267,6 +485,59 @@ func listAccounts(ctx *cli.Context) error {
 	return nil
 }
 
+var accountInfoCommand = cli.Command{
+	Name:  "info",
+	Usage: "Show information about a single off-chain account.",
+	Description: `
+	Returns a single account, identified either by its ID or by its label.
+	With --include_ledger, the adjustments of the balance caused by the
+	invoices of the account are listed as well.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the account",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "the label of the account",
+		},
+		cli.BoolFlag{
+			Name: "include_ledger",
+			Usage: "include the balance adjustments caused by " +
+				"the invoices of the account",
+		},
+	},
+	Action: accountInfo,
+}
+
+func accountInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountsClient(clientConn)
+
+	req := &litrpc.AccountInfoRequest{
+		Id:            ctx.String("id"),
+		Label:         ctx.String("label"),
+		IncludeLedger: ctx.Bool("include_ledger"),
+	}
+	if (req.Id == "") == (req.Label == "") {
+		return fmt.Errorf("exactly one of --id or --label must be set")
+	}
+
+	resp, err := client.AccountInfo(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var removeAccountCommand = cli.Command{
 	Name:      "remove",
 	ShortName: "r",
@@ -280,6 +551,11 @@ var removeAccountCommand = cli.Command{
 			Name:  "id",
 			Usage: "the ID of the account",
 		},
+		cli.StringFlag{
+			Name: "reason",
+			Usage: "an optional reason for the removal that is " +
+				"recorded with it",
+		},
 	},
 	Action: removeAccount,
 }
@@ -314,8 +590,362 @@ func removeAccount(ctx *cli.Context) error {
 	}
 
 	req := &litrpc.RemoveAccountRequest{
-		Id: accountID,
+		Id:     accountID,
+		Reason: ctx.String("reason"),
 	}
 	_, err = client.RemoveAccount(ctxb, req)
 	return err
 }
+
+var freezeAccountCommand = cli.Command{
+	Name:      "freeze",
+	Usage:     "Freeze or unfreeze an off-chain account.",
+	ArgsUsage: "id",
+	Description: `
+	Freezes an account so it can't send payments anymore until it is
+	unfrozen. Invoices of a frozen account still credit it. Accounts can
+	also be frozen automatically by the anomaly detection of the watchdog.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the account",
+		},
+		cli.StringFlag{
+			Name: "reason",
+			Usage: "an optional reason that is stored with the " +
+				"account",
+		},
+		cli.BoolFlag{
+			Name:  "unfreeze",
+			Usage: "unfreeze the account instead of freezing it",
+		},
+	},
+	Action: freezeAccount,
+}
+
+func freezeAccount(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountsClient(clientConn)
+
+	var accountID string
+	switch {
+	case ctx.IsSet("id"):
+		accountID = ctx.String("id")
+	case ctx.Args().Present():
+		accountID = ctx.Args().First()
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	if _, err := hex.DecodeString(accountID); err != nil {
+		return err
+	}
+
+	resp, err := client.FreezeAccount(ctxb, &litrpc.FreezeAccountRequest{
+		Id:       accountID,
+		Unfreeze: ctx.Bool("unfreeze"),
+		Reason:   ctx.String("reason"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var cleanupAccountsCommand = cli.Command{
+	Name:  "cleanup",
+	Usage: "Clean up expired off-chain accounts without balance.",
+	Description: `
+	Cleans up all accounts that are due according to the cleanup policy
+	that litd was started with (--accounts.cleanupafter and
+	--accounts.cleanupaction) right away instead of waiting for the next
+	periodic cleanup. Use --dry_run to only list the accounts that would be
+	cleaned up.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "dry_run",
+			Usage: "only list the accounts that would be cleaned " +
+				"up",
+		},
+	},
+	Action: cleanupAccounts,
+}
+
+func cleanupAccounts(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountsClient(clientConn)
+
+	req := &litrpc.CleanupAccountsRequest{
+		DryRun: ctx.Bool("dry_run"),
+	}
+	resp, err := client.CleanupAccounts(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var createInvoicesCommand = cli.Command{
+	Name:      "createinvoices",
+	ShortName: "i",
+	Usage:     "Creates multiple invoices for an off-chain account.",
+	ArgsUsage: "id",
+	Description: `
+	Creates multiple invoices for an account in one call. Settling any of
+	the invoices credits the account.
+
+	Either specify the amount of each invoice with --amounts or create
+	--num_invoices invoices of the same --amount.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the account",
+		},
+		cli.Int64SliceFlag{
+			Name: "amounts",
+			Usage: "the amount in satoshis of an invoice to create, " +
+				"can be specified multiple times",
+		},
+		cli.UintFlag{
+			Name:  "num_invoices",
+			Usage: "the number of invoices to create for --amount",
+		},
+		cli.Uint64Flag{
+			Name: "amount",
+			Usage: "the amount in satoshis of each invoice if " +
+				"--num_invoices is set",
+		},
+		cli.StringFlag{
+			Name:  "memo",
+			Usage: "an optional memo to attach to all invoices",
+		},
+		cli.Int64Flag{
+			Name:  "expiry",
+			Usage: "the expiry of the invoices in seconds",
+		},
+	},
+	Action: createInvoices,
+}
+
+func createInvoices(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountsClient(clientConn)
+
+	var accountID string
+	switch {
+	case ctx.IsSet("id"):
+		accountID = ctx.String("id")
+	case ctx.Args().Present():
+		accountID = ctx.Args().First()
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	var amounts []uint64
+	for _, amt := range ctx.Int64Slice("amounts") {
+		if amt < 0 {
+			return fmt.Errorf("invalid amount %d", amt)
+		}
+		amounts = append(amounts, uint64(amt))
+	}
+
+	resp, err := client.CreateInvoices(
+		ctxb, &litrpc.CreateInvoicesRequest{
+			Id:          accountID,
+			Amounts:     amounts,
+			NumInvoices: uint32(ctx.Uint("num_invoices")),
+			Amount:      ctx.Uint64("amount"),
+			Memo:        ctx.String("memo"),
+			Expiry:      ctx.Int64("expiry"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var simulateCommand = cli.Command{
+	Name:  "simulate",
+	Usage: "Simulate invoices and payments of an off-chain account.",
+	Description: `
+	Simulates settled invoices and completed payments of an account
+	without creating invoices in lnd or sending any HTLCs. This makes it
+	possible to test account based applications quickly on regtest or in
+	CI. Only available if litd was started with --dev.simulateaccounts.
+	`,
+	Subcommands: []cli.Command{
+		simulateInvoiceCommand,
+		simulatePaymentCommand,
+	},
+}
+
+var simulateInvoiceCommand = cli.Command{
+	Name:      "invoice",
+	Usage:     "Credit an account as if one of its invoices was settled.",
+	ArgsUsage: "id amount",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the account",
+		},
+		cli.Uint64Flag{
+			Name:  "amount",
+			Usage: "the amount in satoshis the invoice was settled with",
+		},
+	},
+	Action: simulateInvoice,
+}
+
+func simulateInvoice(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountsClient(clientConn)
+
+	accountID, amount, err := parseSimulateArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.SimulateInvoice(
+		ctxb, &litrpc.SimulateInvoiceRequest{
+			Id:     accountID,
+			Amount: amount,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var simulatePaymentCommand = cli.Command{
+	Name:      "payment",
+	Usage:     "Record a payment of an account without sending it.",
+	ArgsUsage: "id amount",
+	Description: `
+	Records a payment of an account as if it was sent through lnd. The
+	balance of the account is checked the same way as for real payments.
+	A successful payment debits the amount and fee from the account, a
+	payment simulated with --fail is only recorded.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the account",
+		},
+		cli.Uint64Flag{
+			Name:  "amount",
+			Usage: "the amount in satoshis of the payment",
+		},
+		cli.Uint64Flag{
+			Name:  "fee",
+			Usage: "the routing fee in satoshis of the payment",
+		},
+		cli.BoolFlag{
+			Name:  "fail",
+			Usage: "simulate a failed payment",
+		},
+	},
+	Action: simulatePayment,
+}
+
+func simulatePayment(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountsClient(clientConn)
+
+	accountID, amount, err := parseSimulateArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.SimulatePayment(
+		ctxb, &litrpc.SimulatePaymentRequest{
+			Id:     accountID,
+			Amount: amount,
+			Fee:    ctx.Uint64("fee"),
+			Fail:   ctx.Bool("fail"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// parseSimulateArgs parses the account ID and amount of the simulate
+// commands from either the flags or the positional arguments.
+func parseSimulateArgs(ctx *cli.Context) (string, uint64, error) {
+	var (
+		accountID string
+		amount    uint64
+		err       error
+	)
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("id"):
+		accountID = ctx.String("id")
+	case args.Present():
+		accountID = args.First()
+		args = args.Tail()
+	default:
+		return "", 0, fmt.Errorf("id argument missing")
+	}
+
+	if _, err := hex.DecodeString(accountID); err != nil {
+		return "", 0, err
+	}
+
+	switch {
+	case ctx.IsSet("amount"):
+		amount = ctx.Uint64("amount")
+	case args.Present():
+		amount, err = strconv.ParseUint(args.First(), 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("unable to decode amount %v",
+				err)
+		}
+	default:
+		return "", 0, fmt.Errorf("amount argument missing")
+	}
+
+	return accountID, amount, nil
+}
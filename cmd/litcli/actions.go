@@ -4,16 +4,29 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"io"
 
 	"github.com/lightninglabs/lightning-terminal/litrpc"
 	"github.com/urfave/cli"
 )
 
 var listActionsCommand = cli.Command{
-	Name:   "actions",
-	Usage:  "List actions performed on the Litd server",
+	Name:  "actions",
+	Usage: "List actions performed on the Litd server",
+	Description: "Lists the actions that match the given filters. With " +
+		"--follow, the most recent matching actions are shown " +
+		"first and then every new matching action as soon as it " +
+		"is recorded, until the command is interrupted. Pending " +
+		"actions are shown again once their state changes.",
 	Action: listActions,
 	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "follow",
+			Usage: "Keep showing new actions as soon as they are " +
+				"recorded. Only the session_id, feature, " +
+				"actor, method, state and max_num_actions " +
+				"filters can be used with this option.",
+		},
 		cli.StringFlag{
 			Name: "feature",
 			Usage: "The name of the feature to " +
@@ -53,7 +66,8 @@ var listActionsCommand = cli.Command{
 			Name: "state",
 			Usage: "The action state to filter on. If not set, " +
 				"then actions of any state will be returned. " +
-				"Options include: 'pending', 'done' and 'error",
+				"Options include: 'pending', 'done', 'error' " +
+				"and 'dryrun'",
 		},
 		cli.Uint64Flag{
 			Name: "index_offset",
@@ -109,6 +123,10 @@ func listActions(ctx *cli.Context) error {
 		}
 	}
 
+	if ctx.Bool("follow") {
+		return followActions(ctx, client, sessionID, state)
+	}
+
 	resp, err := client.ListActions(
 		ctxb, &litrpc.ListActionsRequest{
 			SessionId:      sessionID,
@@ -133,6 +151,365 @@ func listActions(ctx *cli.Context) error {
 	return nil
 }
 
+// followActions shows the most recent matching actions and then every new
+// matching action as soon as it is recorded.
+func followActions(ctx *cli.Context, client litrpc.FirewallClient,
+	sessionID []byte, state litrpc.ActionState) error {
+
+	for _, flag := range []string{
+		"index_offset", "oldest_first", "count_total",
+		"start_timestamp", "end_timestamp",
+	} {
+		if ctx.IsSet(flag) {
+			return fmt.Errorf("--%s can't be used with --follow",
+				flag)
+		}
+	}
+
+	// Like tail, we show the last 10 actions by default.
+	numRecent := uint32(10)
+	if ctx.IsSet("max_num_actions") {
+		numRecent = uint32(ctx.Uint64("max_num_actions"))
+	}
+
+	ctxb := context.Background()
+	stream, err := client.SubscribeActions(
+		ctxb, &litrpc.SubscribeActionsRequest{
+			SessionId:   sessionID,
+			FeatureName: ctx.String("feature"),
+			ActorName:   ctx.String("actor"),
+			MethodName:  ctx.String("method"),
+			State:       state,
+			NumRecent:   numRecent,
+			Follow:      true,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(event)
+	}
+}
+
+var verifyActionsCommand = cli.Command{
+	Name:  "verifyactions",
+	Usage: "Verify the integrity of the signed action log",
+	Description: "Walks the chain of signed actions, checks all " +
+		"signatures and checks that no signed action was modified " +
+		"or removed since it was recorded. Actions are only signed " +
+		"if the --firewall.action-signing option is set.",
+	Action: verifyActions,
+}
+
+func verifyActions(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	resp, err := client.VerifyActionLog(
+		ctxb, &litrpc.VerifyActionLogRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var auditTrailCommand = cli.Command{
+	Name:  "audit",
+	Usage: "Export the audit trail of the Litd server",
+	Description: "Returns the account changes, session lifecycle " +
+		"events, firewall denials, admin actions and UI password " +
+		"lockouts in the order in which they were recorded. Pass the " +
+		"returned next_cursor as --cursor to fetch the next page.",
+	Action: auditTrail,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "category",
+			Usage: "A category of events to return. Can be " +
+				"specified multiple times. Options include: " +
+				"'account', 'session', 'denial', 'admin' and " +
+				"'auth'. If not set, then events of all " +
+				"categories will be returned.",
+		},
+		cli.StringFlag{
+			Name: "actor",
+			Usage: "The actor name to filter the events by. If " +
+				"left empty, then all events will be " +
+				"returned.",
+		},
+		cli.StringFlag{
+			Name: "session_id",
+			Usage: "The session ID to filter the events by. If " +
+				"left empty, then all events will be " +
+				"returned.",
+		},
+		cli.Uint64Flag{
+			Name: "start_timestamp",
+			Usage: "Only events recorded after this unix " +
+				"timestamp will be returned.",
+		},
+		cli.Uint64Flag{
+			Name: "end_timestamp",
+			Usage: "Only events recorded before this unix " +
+				"timestamp will be returned.",
+		},
+		cli.Uint64Flag{
+			Name: "cursor",
+			Usage: "The cursor returned by a previous call. Only " +
+				"events after the cursor will be returned.",
+		},
+		cli.Uint64Flag{
+			Name:  "max_events",
+			Usage: "The max number of events to return",
+		},
+	},
+}
+
+func auditTrail(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	categories := make(
+		[]litrpc.AuditCategory, 0, len(ctx.StringSlice("category")),
+	)
+	for _, categoryStr := range ctx.StringSlice("category") {
+		category, err := parseAuditCategory(categoryStr)
+		if err != nil {
+			return err
+		}
+		categories = append(categories, category)
+	}
+
+	var sessionID []byte
+	if ctx.String("session_id") != "" {
+		sessionID, err = hex.DecodeString(ctx.String("session_id"))
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.AuditTrail(
+		ctxb, &litrpc.AuditTrailRequest{
+			Categories:     categories,
+			ActorName:      ctx.String("actor"),
+			SessionId:      sessionID,
+			StartTimestamp: ctx.Uint64("start_timestamp"),
+			EndTimestamp:   ctx.Uint64("end_timestamp"),
+			Cursor:         ctx.Uint64("cursor"),
+			MaxEvents:      uint32(ctx.Uint64("max_events")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var billingExportCommand = cli.Command{
+	Name:  "billing",
+	Usage: "Export the usage and cost of the billed RPC calls",
+	Description: "Returns the number of RPC calls made through " +
+		"sessions and accounts and their cost, grouped by period, " +
+		"consumer and method. The costs are only recorded if " +
+		"firewall.billing.enable is set.",
+	Action: billingExport,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "start_timestamp",
+			Usage: "The unix timestamp from which on the usage " +
+				"is exported.",
+		},
+		cli.Uint64Flag{
+			Name: "end_timestamp",
+			Usage: "The unix timestamp until which the usage is " +
+				"exported. If not set, the export ends now.",
+		},
+		cli.StringFlag{
+			Name:  "period",
+			Value: "day",
+			Usage: "The period the usage is grouped by. Options " +
+				"include 'day', 'week' and 'month'.",
+		},
+	},
+}
+
+func billingExport(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	period, err := parseBillingPeriod(ctx.String("period"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.BillingExport(
+		ctxb, &litrpc.BillingExportRequest{
+			StartTimestamp: ctx.Uint64("start_timestamp"),
+			EndTimestamp:   ctx.Uint64("end_timestamp"),
+			Period:         period,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var sessionActivityCommand = cli.Command{
+	Name:  "activity",
+	Usage: "Count the actions of sessions per hour or day",
+	Description: "Returns the number of actions per session, feature " +
+		"and interval, counted from the action log. If no start " +
+		"timestamp is set, the last 24 hours or 30 days are " +
+		"returned.",
+	Action: sessionActivity,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "start_timestamp",
+			Usage: "The unix timestamp from which on the actions " +
+				"are counted.",
+		},
+		cli.Uint64Flag{
+			Name: "end_timestamp",
+			Usage: "The unix timestamp until which the actions " +
+				"are counted. If not set, they are counted " +
+				"until now.",
+		},
+		cli.StringFlag{
+			Name:  "interval",
+			Value: "hour",
+			Usage: "The interval the actions are counted in. " +
+				"Options include 'hour' and 'day'.",
+		},
+		cli.StringFlag{
+			Name: "session_id",
+			Usage: "The session ID to filter the actions by. If " +
+				"left empty, the actions of all sessions are " +
+				"counted.",
+		},
+		cli.StringFlag{
+			Name: "feature",
+			Usage: "The feature name to filter the actions by. " +
+				"If left empty, the actions of all features " +
+				"are counted.",
+		},
+	},
+}
+
+func sessionActivity(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	var interval litrpc.ActivityInterval
+	switch ctx.String("interval") {
+	case "hour":
+		interval = litrpc.ActivityInterval_ACTIVITY_INTERVAL_HOUR
+	case "day":
+		interval = litrpc.ActivityInterval_ACTIVITY_INTERVAL_DAY
+	default:
+		return fmt.Errorf("unknown activity interval %s. Valid "+
+			"options include 'hour' and 'day'",
+			ctx.String("interval"))
+	}
+
+	var sessionID []byte
+	if ctx.String("session_id") != "" {
+		sessionID, err = hex.DecodeString(ctx.String("session_id"))
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.SessionActivity(
+		ctxb, &litrpc.SessionActivityRequest{
+			StartTimestamp: ctx.Uint64("start_timestamp"),
+			EndTimestamp:   ctx.Uint64("end_timestamp"),
+			Interval:       interval,
+			SessionId:      sessionID,
+			FeatureName:    ctx.String("feature"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func parseBillingPeriod(periodStr string) (litrpc.BillingPeriod, error) {
+	switch periodStr {
+	case "day":
+		return litrpc.BillingPeriod_BILLING_PERIOD_DAY, nil
+	case "week":
+		return litrpc.BillingPeriod_BILLING_PERIOD_WEEK, nil
+	case "month":
+		return litrpc.BillingPeriod_BILLING_PERIOD_MONTH, nil
+	default:
+		return 0, fmt.Errorf("unknown billing period %s. Valid "+
+			"options include 'day', 'week' and 'month'", periodStr)
+	}
+}
+
+func parseAuditCategory(categoryStr string) (litrpc.AuditCategory, error) {
+	switch categoryStr {
+	case "account":
+		return litrpc.AuditCategory_AUDIT_CATEGORY_ACCOUNT, nil
+	case "session":
+		return litrpc.AuditCategory_AUDIT_CATEGORY_SESSION, nil
+	case "denial":
+		return litrpc.AuditCategory_AUDIT_CATEGORY_FIREWALL_DENIAL, nil
+	case "admin":
+		return litrpc.AuditCategory_AUDIT_CATEGORY_ADMIN, nil
+	case "auth":
+		return litrpc.AuditCategory_AUDIT_CATEGORY_AUTH, nil
+	default:
+		return 0, fmt.Errorf("unknown audit category %s. Valid "+
+			"options include 'account', 'session', 'denial', "+
+			"'admin' and 'auth'", categoryStr)
+	}
+}
+
 func parseActionState(actionStr string) (litrpc.ActionState, error) {
 	switch actionStr {
 	case "":
@@ -143,8 +520,11 @@ func parseActionState(actionStr string) (litrpc.ActionState, error) {
 		return litrpc.ActionState_STATE_DONE, nil
 	case "error":
 		return litrpc.ActionState_STATE_ERROR, nil
+	case "dryrun":
+		return litrpc.ActionState_STATE_DRY_RUN, nil
 	default:
 		return 0, fmt.Errorf("unknown action state %s. Valid options "+
-			"include 'pending', 'done' and 'error'", actionStr)
+			"include 'pending', 'done', 'error' and 'dryrun'",
+			actionStr)
 	}
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var alertsCommands = cli.Command{
+	Name:     "alerts",
+	Usage:    "Show the alerts of the watchdog.",
+	Category: "Watchdog",
+	Description: `
+	Shows the alerts the watchdog raised for HTLCs that are pending for a
+	long time or are close to their expiry and for account payments that
+	stay in flight.
+
+	The alerts of an account can be routed to a webhook of its own and
+	filtered by type and amount with its notification preferences.
+	`,
+	Subcommands: []cli.Command{
+		listAlertsCommand,
+		setAccountNotificationsCommand,
+		listAccountNotificationsCommand,
+		removeAccountNotificationsCommand,
+	},
+}
+
+var listAlertsCommand = cli.Command{
+	Name:  "list",
+	Usage: "List the active alerts.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "include_resolved",
+			Usage: "also list the recently resolved alerts",
+		},
+	},
+	Action: listAlerts,
+}
+
+func listAlerts(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewWatchdogClient(clientConn)
+
+	resp, err := client.ListAlerts(ctxb, &litrpc.ListAlertsRequest{
+		IncludeResolved: ctx.Bool("include_resolved"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var setAccountNotificationsCommand = cli.Command{
+	Name:      "setaccount",
+	Usage:     "Set the notification preferences of an account.",
+	ArgsUsage: "account_id",
+	Description: `
+	Sets the notification preferences of an account, replacing its previous
+	preferences. The alerts of the account are POSTed to the given webhook
+	instead of the watchdog's webhook. Without any alert type, alerts of all
+	types are delivered.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the ID of the account",
+		},
+		cli.StringFlag{
+			Name: "webhook_url",
+			Usage: "the URL the alerts of the account are POSTed " +
+				"to; if empty, the watchdog's webhook is used",
+		},
+		cli.StringSliceFlag{
+			Name: "alert_type",
+			Usage: "an alert type to deliver, for example " +
+				"ALERT_ACCOUNT_DRAIN; can be specified multiple " +
+				"times",
+		},
+		cli.Uint64Flag{
+			Name: "min_amount_msat",
+			Usage: "the minimum amount of an alert to be " +
+				"delivered",
+		},
+	},
+	Action: setAccountNotifications,
+}
+
+func setAccountNotifications(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewWatchdogClient(clientConn)
+
+	var accountID string
+	switch {
+	case ctx.IsSet("account_id"):
+		accountID = ctx.String("account_id")
+	case ctx.Args().Present():
+		accountID = ctx.Args().First()
+	default:
+		return fmt.Errorf("account_id argument missing")
+	}
+
+	var alertTypes []litrpc.AlertType
+	for _, t := range ctx.StringSlice("alert_type") {
+		alertType, ok := litrpc.AlertType_value[strings.ToUpper(t)]
+		if !ok {
+			return fmt.Errorf("unknown alert type %s", t)
+		}
+
+		alertTypes = append(alertTypes, litrpc.AlertType(alertType))
+	}
+
+	resp, err := client.SetAccountNotifications(
+		ctxb, &litrpc.SetAccountNotificationsRequest{
+			Notifications: &litrpc.AccountNotifications{
+				AccountId:     accountID,
+				WebhookUrl:    ctx.String("webhook_url"),
+				AlertTypes:    alertTypes,
+				MinAmountMsat: ctx.Uint64("min_amount_msat"),
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listAccountNotificationsCommand = cli.Command{
+	Name:   "listaccounts",
+	Usage:  "List the notification preferences of all accounts.",
+	Action: listAccountNotifications,
+}
+
+func listAccountNotifications(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewWatchdogClient(clientConn)
+
+	resp, err := client.ListAccountNotifications(
+		ctxb, &litrpc.ListAccountNotificationsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var removeAccountNotificationsCommand = cli.Command{
+	Name:      "removeaccount",
+	Usage:     "Remove the notification preferences of an account.",
+	ArgsUsage: "account_id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the ID of the account",
+		},
+	},
+	Action: removeAccountNotifications,
+}
+
+func removeAccountNotifications(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewWatchdogClient(clientConn)
+
+	var accountID string
+	switch {
+	case ctx.IsSet("account_id"):
+		accountID = ctx.String("account_id")
+	case ctx.Args().Present():
+		accountID = ctx.Args().First()
+	default:
+		return fmt.Errorf("account_id argument missing")
+	}
+
+	_, err = client.RemoveAccountNotifications(
+		ctxb, &litrpc.RemoveAccountNotificationsRequest{
+			AccountId: accountID,
+		},
+	)
+	return err
+}
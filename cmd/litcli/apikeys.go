@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var apiKeysCommands = cli.Command{
+	Name:     "apikeys",
+	Usage:    "Manage API keys for REST and gRPC web clients.",
+	Category: "API keys",
+	Subcommands: []cli.Command{
+		createApiKeyCommand,
+		listApiKeysCommand,
+		revokeApiKeyCommand,
+	},
+}
+
+var createApiKeyCommand = cli.Command{
+	Name:      "create",
+	ShortName: "c",
+	Usage:     "Create a new API key.",
+	Description: `
+	Creates a new API key that can be sent as a bearer token in the
+	Authorization header instead of a macaroon. The key is only shown once,
+	make sure to store it in a safe place.
+
+	The preset determines the permissions of the key:
+	  - readonly: all read permissions of the active daemons
+	  - admin: all permissions of the active daemons
+	  - account: the permissions needed to use an account, the key must be
+	    locked to an account with --account_id
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "a human-readable label for the key",
+		},
+		cli.StringFlag{
+			Name: "preset",
+			Usage: "the permission preset of the key, one of " +
+				"'readonly', 'admin' or 'account'",
+			Value: "readonly",
+		},
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the ID of the account to lock the key to",
+		},
+		cli.Int64Flag{
+			Name: "expiration_date",
+			Usage: "the expiration date of the key expressed in " +
+				"seconds since the unix epoch. 0 means it " +
+				"does not expire",
+		},
+	},
+	Action: createApiKey,
+}
+
+func createApiKey(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewApiKeysClient(clientConn)
+
+	var preset litrpc.ApiKeyPreset
+	switch ctx.String("preset") {
+	case "readonly":
+		preset = litrpc.ApiKeyPreset_API_KEY_PRESET_READONLY
+
+	case "admin":
+		preset = litrpc.ApiKeyPreset_API_KEY_PRESET_ADMIN
+
+	case "account":
+		preset = litrpc.ApiKeyPreset_API_KEY_PRESET_ACCOUNT
+
+	default:
+		return fmt.Errorf("unknown preset %s", ctx.String("preset"))
+	}
+
+	resp, err := client.CreateApiKey(
+		ctxb, &litrpc.CreateApiKeyRequest{
+			Label:          ctx.String("label"),
+			Preset:         preset,
+			AccountId:      ctx.String("account_id"),
+			ExpirationDate: ctx.Int64("expiration_date"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listApiKeysCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "List all API keys.",
+	Action:    listApiKeys,
+}
+
+func listApiKeys(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewApiKeysClient(clientConn)
+
+	resp, err := client.ListApiKeys(ctxb, &litrpc.ListApiKeysRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var revokeApiKeyCommand = cli.Command{
+	Name:      "revoke",
+	ShortName: "r",
+	Usage:     "Revoke an API key.",
+	ArgsUsage: "id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the API key to revoke",
+		},
+	},
+	Action: revokeApiKey,
+}
+
+func revokeApiKey(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewApiKeysClient(clientConn)
+
+	var id string
+	switch {
+	case ctx.IsSet("id"):
+		id = ctx.String("id")
+	case ctx.Args().Present():
+		id = ctx.Args().First()
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	_, err = client.RevokeApiKey(
+		ctxb, &litrpc.RevokeApiKeyRequest{
+			Id: id,
+		},
+	)
+	return err
+}
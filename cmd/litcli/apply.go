@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/urfave/cli"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+var applyCommand = cli.Command{
+	Name:     "apply",
+	Usage:    "Reconcile accounts, sessions and rule bundles with a spec.",
+	Category: "Provisioning",
+	Description: `
+	Reads a YAML (or JSON) spec of accounts, sessions and custom rule
+	bundles and reconciles the node with it: missing entries are created,
+	drifted entries are updated and, with --prune, entries that are not
+	part of the spec are removed. The planned changes are printed first
+	and need to be confirmed unless --force is set.
+
+	Example spec:
+
+	accounts:
+	  - label: alice
+	    balance: 100000
+	sessions:
+	  - label: alice-wallet
+	    session_type: TYPE_MACAROON_ACCOUNT
+	    expiry_timestamp_seconds: 1893456000
+	    account_label: alice
+	rule_bundles:
+	  - name: cautious
+	    rules:
+	      rules:
+	        rate-limit:
+	          rate_limit:
+	            read_limit: {iterations: 100, num_hours: 1}
+	            write_limit: {iterations: 10, num_hours: 1}
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "the path to the spec file",
+		},
+		cli.BoolFlag{
+			Name: "prune",
+			Usage: "remove accounts, macaroon sessions and custom " +
+				"rule bundles that are not part of the spec",
+		},
+		cli.BoolFlag{
+			Name:  "dry_run",
+			Usage: "only print the planned changes",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "apply the changes without asking for confirmation",
+		},
+	},
+	Action: applySpec,
+}
+
+func applySpec(ctx *cli.Context) error {
+	if !ctx.IsSet("file") {
+		return cli.ShowCommandHelp(ctx, "apply")
+	}
+
+	spec, err := readSpec(lncfg.CleanAndExpandPath(ctx.String("file")))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProvisioningClient(clientConn)
+
+	req := &litrpc.ApplySpecRequest{
+		Spec:   spec,
+		DryRun: true,
+		Prune:  ctx.Bool("prune"),
+	}
+	plan, err := client.ApplySpec(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if len(plan.Changes) == 0 {
+		fmt.Println("The node already matches the spec.")
+		return nil
+	}
+
+	printPlan(plan.Changes)
+
+	if ctx.Bool("dry_run") {
+		return nil
+	}
+
+	if !ctx.Bool("force") {
+		fmt.Print("\nApply these changes? (yes/no): ")
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(strings.ToLower(answer)) != "yes" {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	req.DryRun = false
+	resp, err := client.ApplySpec(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var exportCommand = cli.Command{
+	Name:     "export",
+	Usage:    "Export accounts, sessions and rule bundles as a spec.",
+	Category: "Provisioning",
+	Description: `
+	Exports the current accounts, active macaroon sessions and custom rule
+	bundles as a YAML spec that can be passed to the apply command.
+	Applying an exported spec doesn't change anything. Entries that can't
+	be expressed in a spec, for example because their label is empty or
+	not unique, are skipped and listed on stderr.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "file, f",
+			Usage: "write the spec to this file instead of " +
+				"printing it",
+		},
+	},
+	Action: exportSpec,
+}
+
+func exportSpec(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProvisioningClient(clientConn)
+
+	resp, err := client.ExportSpec(ctxb, &litrpc.ExportSpecRequest{})
+	if err != nil {
+		return err
+	}
+
+	for _, skipped := range resp.Skipped {
+		fmt.Fprintf(os.Stderr, "Skipped %s\n", skipped)
+	}
+
+	if !ctx.IsSet("file") {
+		fmt.Print(resp.Yaml)
+		return nil
+	}
+
+	fileName := lncfg.CleanAndExpandPath(ctx.String("file"))
+	return os.WriteFile(fileName, []byte(resp.Yaml), 0600)
+}
+
+// readSpec reads the provisioning spec from the given YAML or JSON file.
+func readSpec(fileName string) (*litrpc.ProvisioningSpec, error) {
+	specBytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spec: %v", err)
+	}
+
+	// The spec is converted to JSON first so that it can be parsed with
+	// the proto JSON rules, for example for enums and oneofs.
+	var raw interface{}
+	if err := yaml.Unmarshal(specBytes, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse spec: %v", err)
+	}
+
+	jsonBytes, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert spec: %v", err)
+	}
+
+	spec := &litrpc.ProvisioningSpec{}
+	if err := protojson.Unmarshal(jsonBytes, spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %v", err)
+	}
+
+	return spec, nil
+}
+
+// printPlan prints a human-readable summary of the planned changes.
+func printPlan(changes []*litrpc.ProvisioningChange) {
+	fmt.Println("Planned changes:")
+	for _, c := range changes {
+		var symbol string
+		switch c.Action {
+		case litrpc.ProvisioningAction_PROVISIONING_ACTION_CREATE:
+			symbol = "+"
+
+		case litrpc.ProvisioningAction_PROVISIONING_ACTION_UPDATE:
+			symbol = "~"
+
+		case litrpc.ProvisioningAction_PROVISIONING_ACTION_REPLACE:
+			symbol = "-/+"
+
+		case litrpc.ProvisioningAction_PROVISIONING_ACTION_DELETE:
+			symbol = "-"
+		}
+
+		resource := strings.ToLower(strings.TrimPrefix(
+			c.Resource.String(), "PROVISIONING_RESOURCE_",
+		))
+		fmt.Printf("  %-3s %s %s: %s\n", symbol, resource, c.Name,
+			c.Description)
+	}
+}
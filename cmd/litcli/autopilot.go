@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/lightninglabs/lightning-terminal/litrpc"
 	"github.com/lightninglabs/lightning-terminal/rules"
 	"github.com/urfave/cli"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var autopilotCommands = cli.Command{
@@ -21,6 +23,136 @@ var autopilotCommands = cli.Command{
 		addAutopilotSessionCmd,
 		revokeAutopilotSessionCmd,
 		listAutopilotSessionsCmd,
+		ruleBundleCommands,
+		updateSessionRulesCmd,
+	},
+}
+
+var ruleBundleCommands = cli.Command{
+	Name:      "bundles",
+	ShortName: "b",
+	Usage:     "manage rule bundles",
+	Subcommands: []cli.Command{
+		listRuleBundlesCmd,
+		getRuleBundleCmd,
+		setRuleBundleCmd,
+		removeRuleBundleCmd,
+	},
+}
+
+var listRuleBundlesCmd = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "List all rule bundles.",
+	Description: `
+	List all the built-in and custom rule bundles that can be selected
+	when creating an Autopilot session.
+	`,
+	Action: listRuleBundles,
+}
+
+var getRuleBundleCmd = cli.Command{
+	Name:      "get",
+	ShortName: "g",
+	Usage:     "Get a rule bundle by its name.",
+	Action:    getRuleBundle,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "name",
+			Usage:    "the name of the rule bundle",
+			Required: true,
+		},
+	},
+}
+
+var setRuleBundleCmd = cli.Command{
+	Name:      "set",
+	ShortName: "s",
+	Usage:     "Add or update a custom rule bundle.",
+	Description: `
+	Add a new custom rule bundle or update an existing one. Sessions that
+	follow the bundle will have the new rule values applied.
+	`,
+	Action: setRuleBundle,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "name",
+			Usage:    "the name of the rule bundle",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "a description of the rule bundle",
+		},
+		cli.StringFlag{
+			Name: "rules",
+			Usage: "the JSON encoded rules map of the bundle. In " +
+				"the form of: " +
+				"'{\"rules\": {\"rule-name\": {...}}}'",
+			Required: true,
+		},
+	},
+}
+
+var removeRuleBundleCmd = cli.Command{
+	Name:      "remove",
+	ShortName: "r",
+	Usage:     "Remove a custom rule bundle.",
+	Description: `
+	Remove a custom rule bundle. Sessions that followed the bundle fall
+	back to the rule values they were created with.
+	`,
+	Action: removeRuleBundle,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "name",
+			Usage:    "the name of the rule bundle to remove",
+			Required: true,
+		},
+	},
+}
+
+var updateSessionRulesCmd = cli.Command{
+	Name:      "updaterules",
+	ShortName: "u",
+	Usage:     "Update a rule across existing sessions.",
+	Description: `
+	Set the value of a rule across all active Autopilot sessions that have
+	the rule, or only across the given sessions or feature, in a single
+	transaction. The new value takes precedence over the value the session
+	was created with and over the value of a followed rule bundle.
+
+	Use --preview first to list the sessions that would be changed.
+	`,
+	Action: updateSessionRules,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "rule",
+			Usage:    "the name of the rule to update",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name: "value",
+			Usage: "the JSON encoded new value of the rule. In " +
+				"the form of: '{\"rate_limit\": {...}}'",
+			Required: true,
+		},
+		cli.StringSliceFlag{
+			Name: "session_id",
+			Usage: "the hex encoded ID of a session to update; " +
+				"can be specified multiple times. If not " +
+				"set, all active sessions are updated",
+		},
+		cli.StringFlag{
+			Name: "feature",
+			Usage: "only update the rule of this feature. If not " +
+				"set, the rule of all features is updated",
+		},
+		cli.BoolFlag{
+			Name: "preview",
+			Usage: "only list the affected sessions without " +
+				"changing them",
+		},
 	},
 }
 
@@ -29,9 +161,25 @@ var listAutopilotFeaturesCmd = cli.Command{
 	ShortName: "f",
 	Usage:     "List available Autopilot features.",
 	Description: `
-	List available Autopilot features.	
+	List available Autopilot features along with the permissions they
+	need and the default, minimum and maximum values of their rules.
+
+	The features are shown as a table by default. Use --json to print the
+	raw response or --json-schema to print a JSON schema of the rule
+	values each feature accepts, for use by other tools.
 	`,
 	Action: listFeatures,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the raw response as JSON",
+		},
+		cli.BoolFlag{
+			Name: "json-schema",
+			Usage: "print a JSON schema of the rule values " +
+				"accepted by each feature",
+		},
+	},
 }
 
 var addAutopilotSessionCmd = cli.Command{
@@ -47,10 +195,58 @@ var addAutopilotSessionCmd = cli.Command{
 		expiryFlag,
 		mailboxServerAddrFlag,
 		devserver,
+		notesFlag,
+		tagFlag,
 		cli.StringSliceFlag{
 			Name:     "feature",
 			Required: true,
 		},
+		cli.StringFlag{
+			Name: "rule-bundle",
+			Usage: "the name of the rule bundle to take the " +
+				"rule values from",
+		},
+		cli.BoolFlag{
+			Name: "follow-bundle-updates",
+			Usage: "if set, later updates to the rule bundle " +
+				"are applied to the session too",
+		},
+		cli.BoolFlag{
+			Name: "deterministic-privacy",
+			Usage: "if set, the pseudo values of the privacy " +
+				"mapper are derived from the real values " +
+				"with a session key instead of being random",
+		},
+		cli.StringFlag{
+			Name: "privacy-key-from",
+			Usage: "the hex local public key of an earlier " +
+				"session with deterministic privacy whose " +
+				"key should be reused",
+		},
+		cli.Float64Flag{
+			Name: "amount-variation-percent",
+			Usage: "the relative variation in percent that " +
+				"the privacy mapper randomizes amounts " +
+				"with, defaults to 5",
+		},
+		cli.Uint64Flag{
+			Name: "amount-granularity-sat",
+			Usage: "the granularity in satoshis that the " +
+				"privacy mapper rounds amounts to",
+		},
+		cli.StringFlag{
+			Name: "route-hints",
+			Usage: "the policy for the route hints of the " +
+				"invoices created with the session, one of " +
+				"'allow', 'explicit-only' or 'strip'",
+			Value: "allow",
+		},
+		cli.BoolFlag{
+			Name: "block-onchain-spend",
+			Usage: "if set, the session can't spend any on-chain " +
+				"funds of the node, whatever its features " +
+				"are allowed to do",
+		},
 		cli.StringFlag{
 			Name: "channel-restrict-list",
 			Usage: "list of channel IDs that the " +
@@ -65,6 +261,74 @@ var addAutopilotSessionCmd = cli.Command{
 				"perform actions on. In the " +
 				"form of: peerID1,peerID2,...",
 		},
+		cli.StringFlag{
+			Name: "onchain-addr-allow-list",
+			Usage: "list of on-chain addresses that the " +
+				"Autopilot server may send coins to. In " +
+				"the form of: addr1,addr2,...",
+		},
+		cli.BoolFlag{
+			Name: "onchain-internal-wallet",
+			Usage: "if set, the Autopilot server may also " +
+				"send coins to addresses of the internal " +
+				"wallet",
+		},
+		cli.StringFlag{
+			Name: "chan-open-peers",
+			Usage: "list of peer IDs that the Autopilot " +
+				"server may open channels to. In the " +
+				"form of: peerID1,peerID2,...",
+		},
+		cli.Uint64Flag{
+			Name: "chan-open-min-size",
+			Usage: "the minimum size in satoshis of channels " +
+				"opened by the Autopilot server",
+		},
+		cli.Uint64Flag{
+			Name: "chan-open-max-size",
+			Usage: "the maximum size in satoshis of channels " +
+				"opened by the Autopilot server",
+		},
+		cli.Uint64Flag{
+			Name: "chan-open-max-sat-per-vbyte",
+			Usage: "the maximum funding fee rate in sat/vbyte " +
+				"of channels opened by the Autopilot server",
+		},
+		cli.Uint64Flag{
+			Name: "invoice-quota-max-invoices",
+			Usage: "the maximum number of invoices the " +
+				"Autopilot server may create per time window",
+		},
+		cli.Uint64Flag{
+			Name: "invoice-quota-max-amt-msat",
+			Usage: "the maximum aggregate amount in msat of " +
+				"the invoices the Autopilot server may " +
+				"create per time window",
+		},
+		cli.Uint64Flag{
+			Name: "invoice-quota-hours",
+			Usage: "the number of hours the invoice quota " +
+				"time window spans",
+			Value: 24,
+		},
+		cli.Uint64Flag{
+			Name: "fee-limit-default-ppm",
+			Usage: "the fee limit in parts per million of the " +
+				"amount that is set on payments of the " +
+				"Autopilot server without a fee limit",
+		},
+		cli.Uint64Flag{
+			Name: "fee-limit-min-msat",
+			Usage: "the lowest fee limit in msat that is set on " +
+				"payments of the Autopilot server without a " +
+				"fee limit",
+		},
+		cli.Uint64Flag{
+			Name: "fee-limit-max-msat",
+			Usage: "the highest fee limit in msat of any payment " +
+				"of the Autopilot server, larger fee limits " +
+				"are lowered to it",
+		},
 	},
 }
 
@@ -161,7 +425,20 @@ func listFeatures(ctx *cli.Context) error {
 		return err
 	}
 
-	printRespJSON(resp)
+	switch {
+	case ctx.Bool("json") && ctx.Bool("json-schema"):
+		return fmt.Errorf("only one of --json and --json-schema can " +
+			"be set")
+
+	case ctx.Bool("json"):
+		printRespJSON(resp)
+
+	case ctx.Bool("json-schema"):
+		return printFeatureSchema(resp.Features)
+
+	default:
+		printFeatureTable(resp.Features)
+	}
 
 	return nil
 }
@@ -216,6 +493,119 @@ func initAutopilotSession(ctx *cli.Context) error {
 		}
 	}
 
+	addrAllowList := ctx.String("onchain-addr-allow-list")
+	internalWallet := ctx.Bool("onchain-internal-wallet")
+	if addrAllowList != "" || internalWallet {
+		var addrs []string
+		if addrAllowList != "" {
+			addrs = strings.Split(addrAllowList, ",")
+		}
+
+		ruleMap.Rules[rules.OnChainAddrRestrictName] = &litrpc.RuleValue{
+			Value: &litrpc.RuleValue_OnchainAddrRestrict{
+				OnchainAddrRestrict: &litrpc.OnChainAddrRestrict{
+					AllowedAddrs:   addrs,
+					InternalWallet: internalWallet,
+				},
+			},
+		}
+	}
+
+	var (
+		chanOpenPeers   = ctx.String("chan-open-peers")
+		chanOpenMinSize = ctx.Uint64("chan-open-min-size")
+		chanOpenMaxSize = ctx.Uint64("chan-open-max-size")
+		chanOpenMaxFee  = ctx.Uint64("chan-open-max-sat-per-vbyte")
+	)
+	if chanOpenPeers != "" || chanOpenMinSize != 0 ||
+		chanOpenMaxSize != 0 || chanOpenMaxFee != 0 {
+
+		var peerIDs []string
+		if chanOpenPeers != "" {
+			peerIDs = strings.Split(chanOpenPeers, ",")
+		}
+
+		ruleMap.Rules[rules.ChanOpenConstraintsName] = &litrpc.RuleValue{
+			Value: &litrpc.RuleValue_ChannelOpenConstraints{
+				ChannelOpenConstraints: &litrpc.ChannelOpenConstraints{
+					PeerIds:        peerIDs,
+					MinChanSizeSat: chanOpenMinSize,
+					MaxChanSizeSat: chanOpenMaxSize,
+					MaxSatPerVbyte: chanOpenMaxFee,
+				},
+			},
+		}
+	}
+
+	var (
+		invoiceQuotaMax   = ctx.Uint64("invoice-quota-max-invoices")
+		invoiceQuotaAmt   = ctx.Uint64("invoice-quota-max-amt-msat")
+		invoiceQuotaHours = ctx.Uint64("invoice-quota-hours")
+	)
+	if invoiceQuotaMax != 0 || invoiceQuotaAmt != 0 {
+		ruleMap.Rules[rules.InvoiceQuotaName] = &litrpc.RuleValue{
+			Value: &litrpc.RuleValue_InvoiceQuota{
+				InvoiceQuota: &litrpc.InvoiceQuota{
+					MaxInvoices: uint32(invoiceQuotaMax),
+					MaxAmtMsat:  invoiceQuotaAmt,
+					NumHours:    uint32(invoiceQuotaHours),
+				},
+			},
+		}
+	}
+
+	var (
+		feeLimitPpm = ctx.Uint64("fee-limit-default-ppm")
+		feeLimitMin = ctx.Uint64("fee-limit-min-msat")
+		feeLimitMax = ctx.Uint64("fee-limit-max-msat")
+	)
+	if feeLimitPpm != 0 || feeLimitMin != 0 || feeLimitMax != 0 {
+		ruleMap.Rules[rules.PaymentFeeLimitName] = &litrpc.RuleValue{
+			Value: &litrpc.RuleValue_PaymentFeeLimit{
+				PaymentFeeLimit: &litrpc.PaymentFeeLimit{
+					DefaultFeePpm: uint32(feeLimitPpm),
+					MinFeeMsat:    feeLimitMin,
+					MaxFeeMsat:    feeLimitMax,
+				},
+			},
+		}
+	}
+
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
+	var privacyKeyFrom []byte
+	if ctx.IsSet("privacy-key-from") {
+		privacyKeyFrom, err = hex.DecodeString(
+			ctx.String("privacy-key-from"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var obfuscation *litrpc.AmountObfuscation
+	if ctx.IsSet("amount-variation-percent") ||
+		ctx.IsSet("amount-granularity-sat") {
+
+		obfuscation = &litrpc.AmountObfuscation{
+			VariationPercent: 5,
+			GranularitySat:   ctx.Uint64("amount-granularity-sat"),
+		}
+		if ctx.IsSet("amount-variation-percent") {
+			obfuscation.VariationPercent = ctx.Float64(
+				"amount-variation-percent",
+			)
+		}
+	}
+
+	routeHints, err := parseRouteHintPolicy(ctx.String("route-hints"))
+	if err != nil {
+		return err
+	}
+
 	featureMap := make(map[string]*litrpc.FeatureConfig)
 	for _, feature := range ctx.StringSlice("feature") {
 		featureMap[feature] = &litrpc.FeatureConfig{
@@ -231,6 +621,159 @@ func initAutopilotSession(ctx *cli.Context) error {
 			MailboxServerAddr:      ctx.String("mailboxserveraddr"),
 			DevServer:              ctx.Bool("devserver"),
 			Features:               featureMap,
+			RuleBundle:             ctx.String("rule-bundle"),
+			FollowBundleUpdates:    ctx.Bool("follow-bundle-updates"),
+			DeterministicPrivacy:   ctx.Bool("deterministic-privacy"),
+			PrivacyKeyFrom:         privacyKeyFrom,
+			AmountObfuscation:      obfuscation,
+			RouteHintPolicy:        routeHints,
+			BlockOnchainSpend:      ctx.Bool("block-onchain-spend"),
+			Notes:                  ctx.String("notes"),
+			Tags:                   tags,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func listRuleBundles(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAutopilotClient(clientConn)
+
+	resp, err := client.ListRuleBundles(
+		ctxb, &litrpc.ListRuleBundlesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func getRuleBundle(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAutopilotClient(clientConn)
+
+	resp, err := client.GetRuleBundle(
+		ctxb, &litrpc.GetRuleBundleRequest{
+			Name: ctx.String("name"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func setRuleBundle(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAutopilotClient(clientConn)
+
+	ruleMap := &litrpc.RulesMap{}
+	err = protojson.Unmarshal([]byte(ctx.String("rules")), ruleMap)
+	if err != nil {
+		return fmt.Errorf("unable to parse rules: %v", err)
+	}
+
+	resp, err := client.SetRuleBundle(
+		ctxb, &litrpc.SetRuleBundleRequest{
+			Bundle: &litrpc.RuleBundle{
+				Name:        ctx.String("name"),
+				Description: ctx.String("description"),
+				Rules:       ruleMap,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func removeRuleBundle(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAutopilotClient(clientConn)
+
+	resp, err := client.RemoveRuleBundle(
+		ctxb, &litrpc.RemoveRuleBundleRequest{
+			Name: ctx.String("name"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func updateSessionRules(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAutopilotClient(clientConn)
+
+	value := &litrpc.RuleValue{}
+	err = protojson.Unmarshal([]byte(ctx.String("value")), value)
+	if err != nil {
+		return fmt.Errorf("unable to parse rule value: %v", err)
+	}
+
+	var sessionIDs [][]byte
+	for _, idStr := range ctx.StringSlice("session_id") {
+		id, err := hex.DecodeString(idStr)
+		if err != nil {
+			return fmt.Errorf("unable to decode session ID %s: %v",
+				idStr, err)
+		}
+
+		sessionIDs = append(sessionIDs, id)
+	}
+
+	resp, err := client.UpdateSessionRules(
+		ctxb, &litrpc.UpdateSessionRulesRequest{
+			RuleName:    ctx.String("rule"),
+			Value:       value,
+			SessionIds:  sessionIDs,
+			FeatureName: ctx.String("feature"),
+			Preview:     ctx.Bool("preview"),
 		},
 	)
 	if err != nil {
@@ -0,0 +1,435 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonSchemaDialect is the JSON schema version of the feature schema.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// printFeatureTable prints the given features along with their permissions and
+// rule values in a human-readable form.
+func printFeatureTable(features map[string]*litrpc.Feature) {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for i, name := range names {
+		f := features[name]
+
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, f.Name)
+		fmt.Fprintf(w, "  %s\n", f.Description)
+		if f.RequiresUpgrade {
+			fmt.Fprintln(w, "  Requires a newer version of LiT "+
+				"to use this feature.")
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "  PERMISSION\tOPERATIONS")
+		for _, perm := range f.PermissionsList {
+			ops := make([]string, len(perm.Operations))
+			for j, op := range perm.Operations {
+				ops[j] = op.Entity + ":" + op.Action
+			}
+
+			fmt.Fprintf(w, "  %s\t%s\n", perm.Method,
+				strings.Join(ops, ","))
+		}
+
+		if len(f.Rules) == 0 {
+			continue
+		}
+
+		ruleNames := make([]string, 0, len(f.Rules))
+		for ruleName := range f.Rules {
+			ruleNames = append(ruleNames, ruleName)
+		}
+		sort.Strings(ruleNames)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "  RULE\tDEFAULT\tMIN\tMAX")
+		for _, ruleName := range ruleNames {
+			values := f.Rules[ruleName]
+			if !values.Known {
+				fmt.Fprintf(w, "  %s\tunknown rule\t-\t-\n",
+					ruleName)
+
+				continue
+			}
+
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", ruleName,
+				formatRuleValue(values.Defaults),
+				formatRuleValue(values.MinValue),
+				formatRuleValue(values.MaxValue))
+		}
+	}
+
+	_ = w.Flush()
+}
+
+// formatRuleValue formats the given rule value as a compact list of its
+// fields.
+func formatRuleValue(v *litrpc.RuleValue) string {
+	inner := ruleValueMessage(v)
+	if inner == nil {
+		return "-"
+	}
+
+	s := formatMessage(inner)
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+// ruleValueMessage returns the message that is set in the value oneof of the
+// given rule value or nil if none is set.
+func ruleValueMessage(v *litrpc.RuleValue) protoreflect.Message {
+	if v == nil {
+		return nil
+	}
+
+	m := v.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("value"))
+	if fd == nil {
+		return nil
+	}
+
+	return m.Get(fd).Message()
+}
+
+// formatMessage formats all fields of the given message as name=value pairs.
+func formatMessage(m protoreflect.Message) string {
+	fields := m.Descriptor().Fields()
+	parts := make([]string, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		v := m.Get(fd)
+
+		var s string
+		if fd.IsList() {
+			list := v.List()
+			items := make([]string, list.Len())
+			for j := range items {
+				items[j] = formatScalar(fd, list.Get(j))
+			}
+			s = "[" + strings.Join(items, ",") + "]"
+		} else {
+			s = formatScalar(fd, v)
+		}
+
+		parts = append(parts, fmt.Sprintf("%s=%s", fd.Name(), s))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// formatScalar formats a single value of the given field.
+func formatScalar(fd protoreflect.FieldDescriptor,
+	v protoreflect.Value) string {
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return "{" + formatMessage(v.Message()) + "}"
+
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes())
+
+	case protoreflect.EnumKind:
+		ev := fd.Enum().Values().ByNumber(v.Enum())
+		if ev == nil {
+			return v.String()
+		}
+
+		return string(ev.Name())
+
+	default:
+		return v.String()
+	}
+}
+
+// printFeatureSchema prints a JSON schema that describes the feature
+// configurations, as used in the features map of an AddAutopilotSession
+// request, that are accepted for the given features.
+func printFeatureSchema(features map[string]*litrpc.Feature) error {
+	obfuscationSchema := messageSchema(
+		(&litrpc.AmountObfuscation{}).ProtoReflect().Descriptor(), nil,
+		nil, nil,
+	)
+
+	props := make(map[string]interface{}, len(features))
+	for name, f := range features {
+		ruleProps := make(map[string]interface{}, len(f.Rules))
+		for ruleName, values := range f.Rules {
+			// Without knowing the rule, we can't say anything
+			// about its values.
+			if !values.Known {
+				ruleProps[ruleName] = map[string]interface{}{
+					"description": "Unknown rule, a " +
+						"newer version of LiT is " +
+						"required.",
+				}
+
+				continue
+			}
+
+			schema, err := ruleValuesSchema(values)
+			if err != nil {
+				return fmt.Errorf("rule %s of feature %s: %w",
+					ruleName, name, err)
+			}
+			ruleProps[ruleName] = schema
+		}
+
+		rulesMap := objectSchema(map[string]interface{}{
+			"rules": objectSchema(ruleProps),
+		})
+		props[name] = map[string]interface{}{
+			"description": f.Description,
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"rules": rulesMap,
+				"config": map[string]interface{}{
+					"type":            "string",
+					"contentEncoding": "base64",
+				},
+				"amount_obfuscation": obfuscationSchema,
+			},
+			"additionalProperties": false,
+		}
+	}
+
+	schema := objectSchema(props)
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = "Autopilot feature configuration"
+
+	schemaJSON, err := json.MarshalIndent(schema, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(schemaJSON))
+
+	return nil
+}
+
+// objectSchema returns the schema of an object with the given properties that
+// doesn't allow any other properties.
+func objectSchema(props map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+// ruleValuesSchema returns the schema of the values of a rule. The defaults,
+// minimum and maximum values of the rule are added to the fields of the
+// schema.
+func ruleValuesSchema(values *litrpc.RuleValues) (map[string]interface{},
+	error) {
+
+	if values.Defaults == nil {
+		return nil, fmt.Errorf("no default value")
+	}
+
+	m := values.Defaults.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("value"))
+	if fd == nil {
+		return nil, fmt.Errorf("no default value")
+	}
+
+	// The minimum and maximum values must be of the same rule type as
+	// the default value to be of any use.
+	bound := func(v *litrpc.RuleValue) protoreflect.Message {
+		inner := ruleValueMessage(v)
+		if inner == nil || inner.Descriptor() != fd.Message() {
+			return nil
+		}
+
+		return inner
+	}
+
+	inner := messageSchema(
+		fd.Message(), m.Get(fd).Message(), bound(values.MinValue),
+		bound(values.MaxValue),
+	)
+
+	schema := objectSchema(map[string]interface{}{
+		string(fd.Name()): inner,
+	})
+	schema["required"] = []string{string(fd.Name())}
+
+	return schema, nil
+}
+
+// messageSchema returns the schema of the given message. Any of the default,
+// minimum and maximum messages may be nil.
+func messageSchema(md protoreflect.MessageDescriptor, def, minVal,
+	maxVal protoreflect.Message) map[string]interface{} {
+
+	get := func(m protoreflect.Message,
+		fd protoreflect.FieldDescriptor) protoreflect.Value {
+
+		if m == nil {
+			return protoreflect.Value{}
+		}
+
+		return m.Get(fd)
+	}
+
+	fields := md.Fields()
+	props := make(map[string]interface{}, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		props[string(fd.Name())] = fieldSchema(
+			fd, get(def, fd), get(minVal, fd), get(maxVal, fd),
+		)
+	}
+
+	return objectSchema(props)
+}
+
+// fieldSchema returns the schema of the given field. Any of the default,
+// minimum and maximum values may be invalid if they are unknown.
+func fieldSchema(fd protoreflect.FieldDescriptor, def, minVal,
+	maxVal protoreflect.Value) map[string]interface{} {
+
+	if !fd.IsList() {
+		return scalarSchema(fd, def, minVal, maxVal)
+	}
+
+	none := protoreflect.Value{}
+	schema := map[string]interface{}{
+		"type":  "array",
+		"items": scalarSchema(fd, none, none, none),
+	}
+	if def.IsValid() && fd.Kind() != protoreflect.MessageKind {
+		list := def.List()
+		items := make([]interface{}, list.Len())
+		for i := range items {
+			items[i] = jsonValue(fd, list.Get(i))
+		}
+		schema["default"] = items
+	}
+
+	return schema
+}
+
+// scalarSchema returns the schema of a single value of the given field.
+func scalarSchema(fd protoreflect.FieldDescriptor, def, minVal,
+	maxVal protoreflect.Value) map[string]interface{} {
+
+	msgOf := func(v protoreflect.Value) protoreflect.Message {
+		if !v.IsValid() {
+			return nil
+		}
+
+		return v.Message()
+	}
+
+	var schema map[string]interface{}
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return messageSchema(
+			fd.Message(), msgOf(def), msgOf(minVal), msgOf(maxVal),
+		)
+
+	case protoreflect.BoolKind:
+		schema = map[string]interface{}{"type": "boolean"}
+
+	case protoreflect.StringKind:
+		schema = map[string]interface{}{"type": "string"}
+
+	case protoreflect.BytesKind:
+		schema = map[string]interface{}{
+			"type":            "string",
+			"contentEncoding": "base64",
+		}
+
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		schema = map[string]interface{}{
+			"type": "string",
+			"enum": names,
+		}
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		schema = map[string]interface{}{"type": "number"}
+		addBounds(schema, fd, minVal, maxVal)
+
+	default:
+		schema = map[string]interface{}{"type": "integer"}
+		addBounds(schema, fd, minVal, maxVal)
+	}
+
+	if def.IsValid() {
+		schema["default"] = jsonValue(fd, def)
+	}
+
+	return schema
+}
+
+// addBounds adds the minimum and maximum of a numeric field to its schema. A
+// zero maximum means that the field isn't bounded by the rule.
+func addBounds(schema map[string]interface{}, fd protoreflect.FieldDescriptor,
+	minVal, maxVal protoreflect.Value) {
+
+	switch fd.Kind() {
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+
+		schema["minimum"] = uint64(0)
+	}
+
+	if minVal.IsValid() {
+		schema["minimum"] = jsonValue(fd, minVal)
+	}
+
+	if maxVal.IsValid() && maxVal.Interface() != fd.Default().Interface() {
+		schema["maximum"] = jsonValue(fd, maxVal)
+	}
+}
+
+// jsonValue converts a single value of the given field into its JSON
+// representation.
+func jsonValue(fd protoreflect.FieldDescriptor,
+	v protoreflect.Value) interface{} {
+
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+
+	case protoreflect.EnumKind:
+		ev := fd.Enum().Values().ByNumber(v.Enum())
+		if ev == nil {
+			return int32(v.Enum())
+		}
+
+		return string(ev.Name())
+
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/urfave/cli"
+)
+
+var backupCommands = cli.Command{
+	Name:     "backup",
+	Usage:    "Export backups of the node's data.",
+	Category: "Backups",
+	Subcommands: []cli.Command{
+		exportChannelBackupCommand,
+		backupStatusCommand,
+	},
+}
+
+var exportChannelBackupCommand = cli.Command{
+	Name:      "channels",
+	ShortName: "c",
+	Usage:     "Export an encrypted static channel backup.",
+	Description: `
+	Fetches the latest static channel backup (SCB) of all channels from lnd
+	and returns it encrypted. If no passphrase is given, the passphrase file
+	configured in litd or lnd's wallet unlock password file is used.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "passphrase_file",
+			Usage: "the file containing the passphrase to " +
+				"encrypt the backup with",
+		},
+		cli.StringFlag{
+			Name: "save_to",
+			Usage: "write the encrypted backup to the given " +
+				"file instead of printing the response",
+		},
+	},
+	Action: exportChannelBackup,
+}
+
+func exportChannelBackup(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewBackupsClient(clientConn)
+
+	req := &litrpc.ExportChannelBackupRequest{}
+	if ctx.IsSet("passphrase_file") {
+		fileName := lncfg.CleanAndExpandPath(
+			ctx.String("passphrase_file"),
+		)
+		req.Passphrase, err = os.ReadFile(fileName)
+		if err != nil {
+			return fmt.Errorf("error reading passphrase file: %v",
+				err)
+		}
+	}
+
+	resp, err := client.ExportChannelBackup(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet("save_to") {
+		printRespJSON(resp)
+		return nil
+	}
+
+	fileName := lncfg.CleanAndExpandPath(ctx.String("save_to"))
+	err = os.WriteFile(fileName, resp.EncryptedBackup, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing channel backup to %s: %v",
+			fileName, err)
+	}
+
+	fmt.Printf("Encrypted channel backup written to %s\n", fileName)
+
+	return nil
+}
+
+var backupStatusCommand = cli.Command{
+	Name:      "status",
+	ShortName: "s",
+	Usage:     "Show the status of the scheduled database backups.",
+	Action:    backupStatus,
+}
+
+func backupStatus(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewBackupsClient(clientConn)
+
+	resp, err := client.GetBackupStatus(
+		ctxb, &litrpc.GetBackupStatusRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	terminal "github.com/lightninglabs/lightning-terminal"
+	"github.com/lightninglabs/lightning-terminal/dbcompact"
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/urfave/cli"
+)
+
+var dbCommands = cli.Command{
+	Name:     "db",
+	Usage:    "Maintain the lit databases.",
+	Category: "LiT",
+	Subcommands: []cli.Command{
+		compactDBCommand,
+		verifyDBCommand,
+	},
+}
+
+var compactDBCommand = cli.Command{
+	Name:  "compact",
+	Usage: "Compact the accounts, session and firewall databases.",
+	Description: `
+	Compacts the bbolt databases owned by lit by copying them into new
+	files, which returns the space of deleted entries to the file system.
+
+	The databases are accessed directly, so litd must be stopped first. The
+	databases are found through the --basedir, --network and --macaroonpath
+	flags. Use the db.compact option of litd to compact the databases on
+	every startup instead.
+	`,
+	Action: compactDB,
+}
+
+func compactDB(ctx *cli.Context) error {
+	_, macaroonPath, err := extractPathArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseDir := lncfg.CleanAndExpandPath(ctx.GlobalString(baseDirFlag.Name))
+	networkDir := filepath.Join(
+		baseDir, strings.ToLower(ctx.GlobalString("network")),
+	)
+
+	paths := terminal.DatabasePaths(networkDir, macaroonPath)
+	results, err := dbcompact.CompactAll(
+		paths, 0, func(p dbcompact.Progress) {
+			fmt.Printf("\rCompacting %s: %3.0f%%", p.Name,
+				p.Percent())
+
+			if p.CopiedKeys == p.TotalKeys {
+				fmt.Println()
+			}
+		},
+	)
+
+	for _, result := range results {
+		if result.Skipped {
+			fmt.Printf("%s: not found, skipped\n", result.Path)
+			continue
+		}
+
+		fmt.Printf("%s: %s -> %s (%s freed) in %v\n", result.Path,
+			formatBytes(result.SizeBefore),
+			formatBytes(result.SizeAfter),
+			formatBytes(result.SizeBefore-result.SizeAfter),
+			result.Duration)
+	}
+
+	return err
+}
+
+var verifyDBCommand = cli.Command{
+	Name:  "verify",
+	Usage: "Check that all records of the lit databases can be decoded.",
+	Description: `
+	Decodes every account, session and firewall record and reports the
+	ones that are corrupt. With --quarantine, the corrupt records are moved
+	to the quarantine bucket of their database, so litd doesn't fail on
+	them later on.
+
+	The databases are accessed directly, so litd must be stopped first. The
+	databases are found through the --basedir, --network and --macaroonpath
+	flags. Use the db.verify option of litd to verify the databases and
+	quarantine corrupt records on every startup instead.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "quarantine",
+			Usage: "move the corrupt records to the quarantine " +
+				"bucket of their database",
+		},
+	},
+	Action: verifyDB,
+}
+
+func verifyDB(ctx *cli.Context) error {
+	_, macaroonPath, err := extractPathArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseDir := lncfg.CleanAndExpandPath(ctx.GlobalString(baseDirFlag.Name))
+	networkDir := filepath.Join(
+		baseDir, strings.ToLower(ctx.GlobalString("network")),
+	)
+
+	dbs := terminal.DatabaseChecks(networkDir, macaroonPath)
+	results, err := dbverify.VerifyAll(dbs, ctx.Bool("quarantine"))
+
+	var corrupt int
+	for _, result := range results {
+		if result.Skipped {
+			fmt.Printf("%s: not found, skipped\n", result.Path)
+			continue
+		}
+
+		fmt.Printf("%s: %d records checked, %d corrupt\n", result.Path,
+			result.Checked, len(result.Corrupt))
+		for _, record := range result.Corrupt {
+			fmt.Printf("  %s: %v\n", record.Location(), record.Err)
+		}
+		if result.Quarantined {
+			fmt.Printf("  moved %d records to the quarantine "+
+				"bucket\n", len(result.Corrupt))
+		}
+
+		corrupt += len(result.Corrupt)
+	}
+	if err != nil {
+		return err
+	}
+
+	if corrupt > 0 && !ctx.Bool("quarantine") {
+		return fmt.Errorf("found %d corrupt records, run with "+
+			"--quarantine to move them out of the way", corrupt)
+	}
+
+	return nil
+}
+
+// formatBytes formats the given number of bytes in a human-readable way.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div),
+		"KMGTPE"[exp])
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var feePolicyCommands = cli.Command{
+	Name:     "feepolicies",
+	Usage:    "Manage time-based fee policies.",
+	Category: "Fee scheduler",
+	Description: `
+	Manages the policies of the fee scheduler. While a policy is active,
+	the routing fees of all channels it targets are set to the fees of the
+	policy. All times are in UTC.
+	`,
+	Subcommands: []cli.Command{
+		setFeePolicyCommand,
+		listFeePoliciesCommand,
+		removeFeePolicyCommand,
+		applyFeePoliciesCommand,
+	},
+}
+
+var setFeePolicyCommand = cli.Command{
+	Name:      "set",
+	Usage:     "Create or replace a fee policy.",
+	ArgsUsage: "name",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the unique name of the policy",
+		},
+		cli.StringSliceFlag{
+			Name: "chan_point",
+			Usage: "a channel the policy applies to in the form " +
+				"txid:output_index. Can be specified multiple " +
+				"times",
+		},
+		cli.StringSliceFlag{
+			Name: "peer",
+			Usage: "the hex encoded public key of a peer to whose " +
+				"channels the policy applies. Can be " +
+				"specified multiple times. If neither " +
+				"chan_point nor peer is set, the policy " +
+				"applies to all channels",
+		},
+		cli.StringFlag{
+			Name: "start",
+			Usage: "the time of day in the form HH:MM at which " +
+				"the policy becomes active",
+			Value: "00:00",
+		},
+		cli.StringFlag{
+			Name: "end",
+			Usage: "the time of day in the form HH:MM at which " +
+				"the policy becomes inactive. If it equals " +
+				"start, the policy is active all day",
+			Value: "00:00",
+		},
+		cli.IntSliceFlag{
+			Name: "weekday",
+			Usage: "a day of the week on which the policy is " +
+				"active, with 0 being Sunday. Can be " +
+				"specified multiple times. If not set, the " +
+				"policy is active every day",
+		},
+		cli.Int64Flag{
+			Name:  "base_fee_msat",
+			Usage: "the base fee in millisatoshis",
+		},
+		cli.Uint64Flag{
+			Name:  "fee_rate_ppm",
+			Usage: "the fee rate in parts per million",
+		},
+		cli.Uint64Flag{
+			Name: "time_lock_delta",
+			Usage: "the CLTV delta of forwarded HTLCs. If not " +
+				"set, the CLTV delta is left unchanged",
+		},
+		cli.IntFlag{
+			Name: "priority",
+			Usage: "the priority of the policy if several active " +
+				"policies target the same channel",
+		},
+	},
+	Action: setFeePolicy,
+}
+
+func setFeePolicy(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFeeSchedulerClient(clientConn)
+
+	var name string
+	switch {
+	case ctx.IsSet("name"):
+		name = ctx.String("name")
+	case ctx.Args().Present():
+		name = ctx.Args().First()
+	default:
+		return fmt.Errorf("name argument missing")
+	}
+
+	var weekdays []uint32
+	for _, day := range ctx.IntSlice("weekday") {
+		weekdays = append(weekdays, uint32(day))
+	}
+
+	_, err = client.SetFeePolicy(ctxb, &litrpc.SetFeePolicyRequest{
+		Policy: &litrpc.FeePolicy{
+			Name:          name,
+			ChannelPoints: ctx.StringSlice("chan_point"),
+			Peers:         ctx.StringSlice("peer"),
+			StartTime:     ctx.String("start"),
+			EndTime:       ctx.String("end"),
+			Weekdays:      weekdays,
+			BaseFeeMsat:   ctx.Int64("base_fee_msat"),
+			FeeRatePpm:    uint32(ctx.Uint64("fee_rate_ppm")),
+			TimeLockDelta: uint32(ctx.Uint64("time_lock_delta")),
+			Priority:      int32(ctx.Int("priority")),
+		},
+	})
+	return err
+}
+
+var listFeePoliciesCommand = cli.Command{
+	Name:   "list",
+	Usage:  "List all fee policies.",
+	Action: listFeePolicies,
+}
+
+func listFeePolicies(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFeeSchedulerClient(clientConn)
+
+	resp, err := client.ListFeePolicies(
+		ctxb, &litrpc.ListFeePoliciesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var removeFeePolicyCommand = cli.Command{
+	Name:      "remove",
+	Usage:     "Remove a fee policy.",
+	ArgsUsage: "name",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the name of the policy to remove",
+		},
+	},
+	Action: removeFeePolicy,
+}
+
+func removeFeePolicy(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFeeSchedulerClient(clientConn)
+
+	var name string
+	switch {
+	case ctx.IsSet("name"):
+		name = ctx.String("name")
+	case ctx.Args().Present():
+		name = ctx.Args().First()
+	default:
+		return fmt.Errorf("name argument missing")
+	}
+
+	_, err = client.RemoveFeePolicy(ctxb, &litrpc.RemoveFeePolicyRequest{
+		Name: name,
+	})
+	return err
+}
+
+var applyFeePoliciesCommand = cli.Command{
+	Name:  "apply",
+	Usage: "Apply the currently active fee policies right away.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "dry_run",
+			Usage: "only log the resulting channel policy " +
+				"updates without applying them",
+		},
+	},
+	Action: applyFeePolicies,
+}
+
+func applyFeePolicies(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFeeSchedulerClient(clientConn)
+
+	resp, err := client.ApplyFeePolicies(
+		ctxb, &litrpc.ApplyFeePoliciesRequest{
+			DryRun: ctx.Bool("dry_run"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
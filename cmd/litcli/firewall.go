@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var firewallCommands = cli.Command{
+	Name:     "firewall",
+	Usage:    "Show and change the global firewall settings.",
+	Category: "Firewall",
+	Description: `
+	Shows and changes the global firewall settings at runtime, without a
+	restart. The changes are persisted and take precedence over the config
+	file until they are reset. Also shows how long the evaluations of the
+	rules take.
+	`,
+	Subcommands: []cli.Command{
+		getFirewallSettingsCommand,
+		updateFirewallSettingsCommand,
+		listRuleMetricsCommand,
+	},
+}
+
+var getFirewallSettingsCommand = cli.Command{
+	Name:   "settings",
+	Usage:  "Show the firewall settings that are in effect.",
+	Action: getFirewallSettings,
+}
+
+func getFirewallSettings(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	resp, err := client.GetFirewallSettings(
+		ctxb, &litrpc.GetFirewallSettingsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var updateFirewallSettingsCommand = cli.Command{
+	Name:  "update",
+	Usage: "Change the firewall settings.",
+	Description: `
+	Changes the given firewall settings. The settings that aren't given
+	stay as they are.
+
+	Disabling the privacy mapper reveals the real values of the node, like
+	channel points and public keys, to all sessions that use it, until it
+	is enabled again.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "request_logger_level",
+			Usage: "the new level of the request logger; one of " +
+				"interceptor, all or full",
+		},
+		cli.StringFlag{
+			Name:  "privacy_mapper",
+			Usage: "enable or disable the privacy mapper",
+		},
+		cli.StringFlag{
+			Name: "rule_enforcement",
+			Usage: "enable or disable the enforcement of the " +
+				"rules of all sessions",
+		},
+		cli.BoolFlag{
+			Name: "reset",
+			Usage: "drop all settings changed at runtime before " +
+				"applying the update, so that the config " +
+				"file applies again",
+		},
+	},
+	Action: updateFirewallSettings,
+}
+
+func updateFirewallSettings(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	privacyMapper, err := parseFirewallToggle(ctx.String("privacy_mapper"))
+	if err != nil {
+		return err
+	}
+
+	ruleEnforcement, err := parseFirewallToggle(
+		ctx.String("rule_enforcement"),
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.UpdateFirewallSettings(
+		ctxb, &litrpc.UpdateFirewallSettingsRequest{
+			RequestLoggerLevel: ctx.String("request_logger_level"),
+			PrivacyMapper:      privacyMapper,
+			RuleEnforcement:    ruleEnforcement,
+			ResetOverrides:     ctx.Bool("reset"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+// parseFirewallToggle parses the value of a toggle flag. An empty value
+// leaves the setting unchanged.
+func parseFirewallToggle(value string) (litrpc.FirewallToggle, error) {
+	switch value {
+	case "":
+		return litrpc.FirewallToggle_FIREWALL_TOGGLE_UNCHANGED, nil
+
+	case "enable":
+		return litrpc.FirewallToggle_FIREWALL_TOGGLE_ENABLE, nil
+
+	case "disable":
+		return litrpc.FirewallToggle_FIREWALL_TOGGLE_DISABLE, nil
+
+	default:
+		return 0, fmt.Errorf("unknown value %s. Valid options "+
+			"include 'enable' and 'disable'", value)
+	}
+}
+
+var listRuleMetricsCommand = cli.Command{
+	Name:  "rulemetrics",
+	Usage: "Show how long the evaluations of the rules take.",
+	Description: `
+	Shows how often each rule was evaluated, the average, maximum and total
+	time its evaluations took and how many requests it denied. The metrics
+	are accumulated since the rule was first evaluated.
+	`,
+	Action: listRuleMetrics,
+}
+
+func listRuleMetrics(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewFirewallClient(clientConn)
+
+	resp, err := client.ListRuleMetrics(
+		ctxb, &litrpc.ListRuleMetricsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
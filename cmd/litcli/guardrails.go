@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var guardrailsCommands = cli.Command{
+	Name:     "guardrails",
+	Usage:    "Manage the limits of the swaps initiated through litd.",
+	Category: "Guardrails",
+	Description: `
+	Shows the configured limits of the loop swaps and how much of the daily
+	budgets is used, and allows an admin to lift the limits temporarily.
+	`,
+	Subcommands: []cli.Command{
+		getGuardrailsCommand,
+		overrideGuardrailsCommand,
+	},
+}
+
+var getGuardrailsCommand = cli.Command{
+	Name:   "get",
+	Usage:  "Show the limits and the used daily budgets.",
+	Action: getGuardrails,
+}
+
+func getGuardrails(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewGuardrailsClient(clientConn)
+
+	resp, err := client.GetGuardrails(
+		ctxb, &litrpc.GetGuardrailsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var overrideGuardrailsCommand = cli.Command{
+	Name:  "override",
+	Usage: "Lift all limits temporarily.",
+	Description: `
+	Lifts all limits for the given duration, at most 24 hours. A duration
+	of 0 ends an active override. This requires an admin macaroon.
+	`,
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name:  "duration",
+			Usage: "how long the limits are lifted for, e.g. 30m",
+			Value: time.Hour,
+		},
+	},
+	Action: overrideGuardrails,
+}
+
+func overrideGuardrails(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewGuardrailsClient(clientConn)
+
+	duration := ctx.Duration("duration")
+	if duration < 0 || duration%time.Second != 0 {
+		return fmt.Errorf("the duration must be a positive number of " +
+			"seconds")
+	}
+
+	resp, err := client.OverrideGuardrails(
+		ctxb, &litrpc.OverrideGuardrailsRequest{
+			DurationSec: uint32(duration / time.Second),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
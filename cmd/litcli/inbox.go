@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var inboxCommands = cli.Command{
+	Name:     "inbox",
+	Usage:    "Show the notifications of the web UI.",
+	Category: "Watchdog",
+	Description: `
+	Shows the notifications of the inbox of the web UI, for example the
+	alerts of the watchdog, and marks them as read. Whether a notification
+	was read is shared by all browsers.
+	`,
+	Subcommands: []cli.Command{
+		listNotificationsCommand,
+		markNotificationsReadCommand,
+	},
+}
+
+var listNotificationsCommand = cli.Command{
+	Name:  "list",
+	Usage: "List the notifications, newest first.",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "unread_only",
+			Usage: "only list the notifications that weren't " +
+				"read yet",
+		},
+		cli.StringFlag{
+			Name: "source",
+			Usage: "only list the notifications of this source, " +
+				"for example watchdog",
+		},
+		cli.StringFlag{
+			Name: "min_severity",
+			Usage: "only list the notifications with at least " +
+				"this severity; one of info, warning or " +
+				"critical",
+			Value: "info",
+		},
+		cli.Uint64Flag{
+			Name: "before_id",
+			Usage: "only list the notifications with a lower ID, " +
+				"used to page through the inbox",
+		},
+		cli.Uint64Flag{
+			Name: "max_notifications",
+			Usage: "the maximum number of notifications to list; " +
+				"if zero, at most 100 are listed",
+		},
+	},
+	Action: listNotifications,
+}
+
+func listNotifications(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewInboxClient(clientConn)
+
+	minSeverity := ctx.String("min_severity")
+	severity, ok := litrpc.NotificationSeverity_value["SEVERITY_"+
+		strings.ToUpper(minSeverity)]
+	if !ok {
+		return fmt.Errorf("unknown severity %s", minSeverity)
+	}
+
+	resp, err := client.ListNotifications(
+		ctxb, &litrpc.ListNotificationsRequest{
+			UnreadOnly:  ctx.Bool("unread_only"),
+			Source:      ctx.String("source"),
+			MinSeverity: litrpc.NotificationSeverity(severity),
+			BeforeId:    ctx.Uint64("before_id"),
+			MaxNotifications: uint32(
+				ctx.Uint64("max_notifications"),
+			),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var markNotificationsReadCommand = cli.Command{
+	Name:      "markread",
+	Usage:     "Mark notifications as read.",
+	ArgsUsage: "[id...]",
+	Description: `
+	Marks the notifications with the given IDs or, with --all, all
+	notifications as read.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "mark all notifications as read",
+		},
+	},
+	Action: markNotificationsRead,
+}
+
+func markNotificationsRead(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewInboxClient(clientConn)
+
+	ids := make([]uint64, 0, ctx.NArg())
+	for _, arg := range ctx.Args() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid notification ID %s: %v",
+				arg, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 && !ctx.Bool("all") {
+		return fmt.Errorf("notification IDs or --all must be given")
+	}
+
+	resp, err := client.MarkNotificationsRead(
+		ctxb, &litrpc.MarkNotificationsReadRequest{
+			Ids: ids,
+			All: ctx.Bool("all"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var lnurlCommands = cli.Command{
+	Name:     "lnurl",
+	Usage:    "Manage LNURL-withdraw vouchers backed by accounts.",
+	Category: "Accounts",
+	Subcommands: []cli.Command{
+		createVoucherCommand,
+		listVouchersCommand,
+		revokeVoucherCommand,
+	},
+}
+
+var createVoucherCommand = cli.Command{
+	Name:      "create",
+	ShortName: "c",
+	Usage:     "Create a new LNURL-withdraw voucher.",
+	ArgsUsage: "account_id max_withdrawable",
+	Description: `
+	Creates a new LNURL-withdraw voucher that lets its bearer withdraw from
+	the given account. Each withdrawal is limited to max_withdrawable
+	satoshis and to the balance of the account.
+
+	By default the voucher can only be redeemed once. Use --max_uses to
+	create a voucher that can be redeemed multiple times.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the ID of the account to withdraw from",
+		},
+		cli.Uint64Flag{
+			Name: "max_withdrawable",
+			Usage: "the maximum amount in satoshis of a single " +
+				"withdrawal",
+		},
+		cli.Uint64Flag{
+			Name: "min_withdrawable",
+			Usage: "the minimum amount in satoshis of a single " +
+				"withdrawal",
+			Value: 1,
+		},
+		cli.UintFlag{
+			Name:  "max_uses",
+			Usage: "the number of times the voucher can be redeemed",
+			Value: 1,
+		},
+		cli.Int64Flag{
+			Name: "expiration_date",
+			Usage: "the expiration date of the voucher expressed " +
+				"in seconds since the unix epoch. 0 means " +
+				"it does not expire",
+		},
+		cli.StringFlag{
+			Name:  "description",
+			Usage: "the description of the withdrawal invoices",
+		},
+	},
+	Action: createVoucher,
+}
+
+func createVoucher(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewLnurlWithdrawClient(clientConn)
+
+	var (
+		args            = ctx.Args()
+		accountID       string
+		maxWithdrawable uint64
+	)
+	switch {
+	case ctx.IsSet("account_id"):
+		accountID = ctx.String("account_id")
+	case args.Present():
+		accountID = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("account_id argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("max_withdrawable"):
+		maxWithdrawable = ctx.Uint64("max_withdrawable")
+	case args.Present():
+		maxWithdrawable, err = strconv.ParseUint(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode max_withdrawable: "+
+				"%v", err)
+		}
+	default:
+		return fmt.Errorf("max_withdrawable argument missing")
+	}
+
+	resp, err := client.CreateVoucher(
+		ctxb, &litrpc.CreateVoucherRequest{
+			AccountId:       accountID,
+			MinWithdrawable: ctx.Uint64("min_withdrawable"),
+			MaxWithdrawable: maxWithdrawable,
+			MaxUses:         uint32(ctx.Uint("max_uses")),
+			ExpirationDate:  ctx.Int64("expiration_date"),
+			Description:     ctx.String("description"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listVouchersCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "List all LNURL-withdraw vouchers.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "only list the vouchers of the given account",
+		},
+	},
+	Action: listVouchers,
+}
+
+func listVouchers(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewLnurlWithdrawClient(clientConn)
+
+	resp, err := client.ListVouchers(
+		ctxb, &litrpc.ListVouchersRequest{
+			AccountId: ctx.String("account_id"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var revokeVoucherCommand = cli.Command{
+	Name:      "revoke",
+	ShortName: "r",
+	Usage:     "Revoke an LNURL-withdraw voucher.",
+	ArgsUsage: "id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the voucher to revoke",
+		},
+	},
+	Action: revokeVoucher,
+}
+
+func revokeVoucher(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewLnurlWithdrawClient(clientConn)
+
+	var id string
+	switch {
+	case ctx.IsSet("id"):
+		id = ctx.String("id")
+	case ctx.Args().Present():
+		id = ctx.Args().First()
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	_, err = client.RevokeVoucher(
+		ctxb, &litrpc.RevokeVoucherRequest{
+			Id: id,
+		},
+	)
+	return err
+}
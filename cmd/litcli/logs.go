@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var logsCommand = cli.Command{
+	Name:     "logs",
+	Usage:    "Show the log output of litd and its subservers.",
+	Category: "LiT",
+	Description: `
+	Shows the most recent lines of the log output of litd and its
+	integrated subservers. With --follow, new lines are shown as soon as
+	they are written until the command is interrupted.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "follow",
+			Usage: "keep showing new lines as soon as they are " +
+				"written",
+		},
+		cli.StringSliceFlag{
+			Name: "subsystem",
+			Usage: "only show the lines of this subsystem, for " +
+				"example LITD or LOOP; can be specified " +
+				"multiple times",
+		},
+		cli.UintFlag{
+			Name:  "lines",
+			Usage: "the number of most recent lines to show first",
+			Value: 50,
+		},
+	},
+	Action: tailLogs,
+}
+
+func tailLogs(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	stream, err := client.TailLogs(ctxb, &litrpc.TailLogsRequest{
+		Subsystems: ctx.StringSlice("subsystem"),
+		Lines:      uint32(ctx.Uint("lines")),
+		Follow:     ctx.Bool("follow"),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(line.Text)
+	}
+}
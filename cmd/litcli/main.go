@@ -51,6 +51,16 @@ var (
 		Usage: "path to lit's macaroon file",
 		Value: terminal.DefaultMacaroonPath,
 	}
+	adminChallengeFlag = cli.StringFlag{
+		Name: "admin_challenge",
+		Usage: "the challenge of a signed admin action as returned " +
+			"by the adminchallenge command",
+	}
+	adminSignatureFlag = cli.StringFlag{
+		Name: "admin_signature",
+		Usage: "the zbase32 encoded signature of the message of the " +
+			"admin challenge",
+	}
 )
 
 func main() {
@@ -69,12 +79,40 @@ func main() {
 		baseDirFlag,
 		tlsCertFlag,
 		macaroonPathFlag,
+		adminChallengeFlag,
+		adminSignatureFlag,
 	}
 	app.Commands = append(app.Commands, sessionCommands...)
 	app.Commands = append(app.Commands, accountsCommands...)
 	app.Commands = append(app.Commands, listActionsCommand)
+	app.Commands = append(app.Commands, verifyActionsCommand)
+	app.Commands = append(app.Commands, auditTrailCommand)
+	app.Commands = append(app.Commands, billingExportCommand)
+	app.Commands = append(app.Commands, sessionActivityCommand)
+	app.Commands = append(app.Commands, firewallCommands)
 	app.Commands = append(app.Commands, privacyMapCommands)
 	app.Commands = append(app.Commands, autopilotCommands)
+	app.Commands = append(app.Commands, backupCommands)
+	app.Commands = append(app.Commands, reportsCommands)
+	app.Commands = append(app.Commands, nwcCommands)
+	app.Commands = append(app.Commands, lnurlCommands)
+	app.Commands = append(app.Commands, portalCommands)
+	app.Commands = append(app.Commands, apiKeysCommands)
+	app.Commands = append(app.Commands, nodeCommands)
+	app.Commands = append(app.Commands, feePolicyCommands)
+	app.Commands = append(app.Commands, alertsCommands)
+	app.Commands = append(app.Commands, guardrailsCommands)
+	app.Commands = append(app.Commands, uiFlagsCommands)
+	app.Commands = append(app.Commands, inboxCommands)
+	app.Commands = append(app.Commands, applyCommand)
+	app.Commands = append(app.Commands, exportCommand)
+	app.Commands = append(app.Commands, dbCommands)
+	app.Commands = append(app.Commands, statusCommand)
+	app.Commands = append(app.Commands, logsCommand)
+	app.Commands = append(app.Commands, errorsCommand)
+	app.Commands = append(app.Commands, lockdownCommand)
+	app.Commands = append(app.Commands, maintenanceCommands)
+	app.Commands = append(app.Commands, configCommands)
 	app.Commands = append(app.Commands, litCommands...)
 
 	err := app.Run(os.Args)
@@ -94,7 +132,17 @@ func connectClient(ctx *cli.Context) (grpc.ClientConnInterface, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	conn, err := getClientConn(rpcServer, tlsCertPath, macPath)
+	var opts []grpc.DialOption
+	if ctx.GlobalIsSet(adminChallengeFlag.Name) ||
+		ctx.GlobalIsSet(adminSignatureFlag.Name) {
+
+		opts = append(opts, grpc.WithPerRPCCredentials(&adminSignature{
+			challenge: ctx.GlobalString(adminChallengeFlag.Name),
+			signature: ctx.GlobalString(adminSignatureFlag.Name),
+		}))
+	}
+
+	conn, err := getClientConn(rpcServer, tlsCertPath, macPath, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -103,8 +151,8 @@ func connectClient(ctx *cli.Context) (grpc.ClientConnInterface, func(), error) {
 	return conn, cleanup, nil
 }
 
-func getClientConn(address, tlsCertPath, macaroonPath string) (*grpc.ClientConn,
-	error) {
+func getClientConn(address, tlsCertPath, macaroonPath string,
+	extraOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
 
 	// We always need to send a macaroon.
 	macOption, err := readMacaroon(macaroonPath)
@@ -116,6 +164,7 @@ func getClientConn(address, tlsCertPath, macaroonPath string) (*grpc.ClientConn,
 		grpc.WithDefaultCallOptions(maxMsgRecvSize),
 		macOption,
 	}
+	opts = append(opts, extraOpts...)
 
 	// TLS cannot be disabled, we'll always have a cert file to read.
 	creds, err := credentials.NewClientTLSFromFile(tlsCertPath, "")
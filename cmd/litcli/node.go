@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var nodeCommands = cli.Command{
+	Name:     "node",
+	Usage:    "Manage the node with firewall rules enforced.",
+	Category: "Node management",
+	Description: `
+	Performs common node management actions. In contrast to calling lnd
+	directly, all actions are checked against the firewall rules of the
+	rule bundle configured with nodemanagement.rulebundle, regardless of
+	the macaroon that is used.
+	`,
+	Subcommands: []cli.Command{
+		nodeOpenChannelCommand,
+		nodeCloseChannelCommand,
+		nodeUpdateChanPolicyCommand,
+		nodeSetAliasCommand,
+	},
+}
+
+var nodeOpenChannelCommand = cli.Command{
+	Name:      "openchannel",
+	Usage:     "Open a channel to a peer.",
+	ArgsUsage: "node_key local_amt",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node_key",
+			Usage: "the hex encoded public key of the peer",
+		},
+		cli.Int64Flag{
+			Name:  "local_amt",
+			Usage: "the amount in satoshis to commit to the channel",
+		},
+		cli.Int64Flag{
+			Name:  "push_amt",
+			Usage: "the amount in satoshis to push to the peer",
+		},
+		cli.BoolFlag{
+			Name:  "private",
+			Usage: "don't announce the channel to the network",
+		},
+		cli.Uint64Flag{
+			Name:  "sat_per_vbyte",
+			Usage: "the fee rate of the funding transaction",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks the funding transaction " +
+				"should confirm in",
+		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "the address to send the funds to on a " +
+				"cooperative close",
+		},
+	},
+	Action: nodeOpenChannel,
+}
+
+func nodeOpenChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNodeManagementClient(clientConn)
+
+	var (
+		args     = ctx.Args()
+		nodeKey  string
+		localAmt = ctx.Int64("local_amt")
+	)
+	switch {
+	case ctx.IsSet("node_key"):
+		nodeKey = ctx.String("node_key")
+	case args.Present():
+		nodeKey = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("node_key argument missing")
+	}
+
+	if !ctx.IsSet("local_amt") {
+		if !args.Present() {
+			return fmt.Errorf("local_amt argument missing")
+		}
+
+		localAmt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode local_amt: %v", err)
+		}
+	}
+
+	resp, err := client.OpenChannel(ctxb, &litrpc.OpenChannelRequest{
+		NodePubkey:         nodeKey,
+		LocalFundingAmount: localAmt,
+		PushSat:            ctx.Int64("push_amt"),
+		Private:            ctx.Bool("private"),
+		SatPerVbyte:        ctx.Uint64("sat_per_vbyte"),
+		TargetConf:         int32(ctx.Int64("conf_target")),
+		CloseAddress:       ctx.String("close_address"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var nodeCloseChannelCommand = cli.Command{
+	Name:      "closechannel",
+	Usage:     "Close a channel.",
+	ArgsUsage: "chan_point",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel point of the channel in the form " +
+				"txid:output_index",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "force close the channel",
+		},
+		cli.Uint64Flag{
+			Name:  "sat_per_vbyte",
+			Usage: "the fee rate of the closing transaction",
+		},
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "the number of blocks the closing transaction " +
+				"should confirm in",
+		},
+		cli.StringFlag{
+			Name:  "delivery_addr",
+			Usage: "the address to send the funds to",
+		},
+	},
+	Action: nodeCloseChannel,
+}
+
+func nodeCloseChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNodeManagementClient(clientConn)
+
+	var chanPoint string
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPoint = ctx.String("chan_point")
+	case ctx.Args().Present():
+		chanPoint = ctx.Args().First()
+	default:
+		return fmt.Errorf("chan_point argument missing")
+	}
+
+	resp, err := client.CloseChannel(ctxb, &litrpc.CloseChannelRequest{
+		ChannelPoint:    chanPoint,
+		Force:           ctx.Bool("force"),
+		SatPerVbyte:     ctx.Uint64("sat_per_vbyte"),
+		TargetConf:      int32(ctx.Int64("conf_target")),
+		DeliveryAddress: ctx.String("delivery_addr"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var nodeUpdateChanPolicyCommand = cli.Command{
+	Name:  "updatechanpolicy",
+	Usage: "Update the routing policy of one or all channels.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel point of the channel to update in " +
+				"the form txid:output_index. If not set, " +
+				"all channels are updated",
+		},
+		cli.Int64Flag{
+			Name:  "base_fee_msat",
+			Usage: "the base fee in millisatoshis",
+		},
+		cli.Uint64Flag{
+			Name:  "fee_rate_ppm",
+			Usage: "the fee rate in parts per million",
+		},
+		cli.Uint64Flag{
+			Name:  "time_lock_delta",
+			Usage: "the CLTV delta of forwarded HTLCs",
+		},
+		cli.Uint64Flag{
+			Name: "max_htlc_msat",
+			Usage: "the maximum size in millisatoshis of " +
+				"forwarded HTLCs",
+		},
+		cli.Uint64Flag{
+			Name: "min_htlc_msat",
+			Usage: "if set, the minimum size in millisatoshis of " +
+				"forwarded HTLCs",
+		},
+	},
+	Action: nodeUpdateChanPolicy,
+}
+
+func nodeUpdateChanPolicy(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNodeManagementClient(clientConn)
+
+	resp, err := client.UpdateChannelPolicy(
+		ctxb, &litrpc.UpdateChannelPolicyRequest{
+			ChannelPoint:         ctx.String("chan_point"),
+			BaseFeeMsat:          ctx.Int64("base_fee_msat"),
+			FeeRatePpm:           uint32(ctx.Uint64("fee_rate_ppm")),
+			TimeLockDelta:        uint32(ctx.Uint64("time_lock_delta")),
+			MaxHtlcMsat:          ctx.Uint64("max_htlc_msat"),
+			MinHtlcMsat:          ctx.Uint64("min_htlc_msat"),
+			MinHtlcMsatSpecified: ctx.IsSet("min_htlc_msat"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var nodeSetAliasCommand = cli.Command{
+	Name:      "setalias",
+	Usage:     "Set the alias of the node.",
+	ArgsUsage: "alias",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "alias",
+			Usage: "the new alias of the node",
+		},
+	},
+	Action: nodeSetAlias,
+}
+
+func nodeSetAlias(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNodeManagementClient(clientConn)
+
+	var alias string
+	switch {
+	case ctx.IsSet("alias"):
+		alias = ctx.String("alias")
+	case ctx.Args().Present():
+		alias = ctx.Args().First()
+	default:
+		return fmt.Errorf("alias argument missing")
+	}
+
+	_, err = client.SetAlias(ctxb, &litrpc.SetAliasRequest{
+		Alias: alias,
+	})
+	return err
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var nwcCommands = cli.Command{
+	Name:     "nwc",
+	Usage:    "Manage Nostr Wallet Connect connections to accounts.",
+	Category: "Accounts",
+	Subcommands: []cli.Command{
+		addNWCConnectionCommand,
+		listNWCConnectionsCommand,
+		removeNWCConnectionCommand,
+	},
+}
+
+var addNWCConnectionCommand = cli.Command{
+	Name:      "add",
+	ShortName: "a",
+	Usage:     "Connect an NWC app to an account.",
+	ArgsUsage: "account_id",
+	Description: `
+	Creates a new Nostr Wallet Connect connection that lets an app pay
+	invoices from, create invoices for and read the balance of the given
+	account. The account's balance is the spending limit of the app.
+
+	The returned connection URI contains the secret of the app and can only
+	be retrieved once.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the ID of the account to connect the app to",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "an optional label of the connection",
+		},
+	},
+	Action: addNWCConnection,
+}
+
+func addNWCConnection(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNostrWalletConnectClient(clientConn)
+
+	var accountID string
+	switch {
+	case ctx.IsSet("account_id"):
+		accountID = ctx.String("account_id")
+	case ctx.Args().Present():
+		accountID = ctx.Args().First()
+	default:
+		return fmt.Errorf("account_id argument missing")
+	}
+
+	resp, err := client.AddConnection(
+		ctxb, &litrpc.AddNWCConnectionRequest{
+			AccountId: accountID,
+			Label:     ctx.String("label"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listNWCConnectionsCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "List all NWC connections.",
+	Action:    listNWCConnections,
+}
+
+func listNWCConnections(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNostrWalletConnectClient(clientConn)
+
+	resp, err := client.ListConnections(
+		ctxb, &litrpc.ListNWCConnectionsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var removeNWCConnectionCommand = cli.Command{
+	Name:      "remove",
+	ShortName: "r",
+	Usage:     "Disconnect an NWC app.",
+	ArgsUsage: "client_pubkey",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "client_pubkey",
+			Usage: "the nostr public key of the connected app",
+		},
+	},
+	Action: removeNWCConnection,
+}
+
+func removeNWCConnection(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewNostrWalletConnectClient(clientConn)
+
+	var clientPubKey string
+	switch {
+	case ctx.IsSet("client_pubkey"):
+		clientPubKey = ctx.String("client_pubkey")
+	case ctx.Args().Present():
+		clientPubKey = ctx.Args().First()
+	default:
+		return fmt.Errorf("client_pubkey argument missing")
+	}
+
+	_, err = client.RemoveConnection(
+		ctxb, &litrpc.RemoveNWCConnectionRequest{
+			ClientPubkey: clientPubKey,
+		},
+	)
+	return err
+}
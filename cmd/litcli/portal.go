@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var portalCommands = cli.Command{
+	Name:     "portal",
+	Usage:    "Manage the tokens of the self-serve account portal.",
+	Category: "Accounts",
+	Subcommands: []cli.Command{
+		createPortalTokenCommand,
+		listPortalTokensCommand,
+		revokePortalTokenCommand,
+	},
+}
+
+var createPortalTokenCommand = cli.Command{
+	Name:      "create",
+	ShortName: "c",
+	Usage:     "Create a new portal token for an account.",
+	ArgsUsage: "account_id",
+	Description: `
+	Creates a new token that lets its holder see the balance and history
+	of the given account and create invoices for it on the account portal.
+
+	The secret of the token is only shown once. Hand it to the account
+	holder, who sends it as a bearer token with each portal request.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "the ID of the account the token gives access to",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "an optional label of the token",
+		},
+	},
+	Action: createPortalToken,
+}
+
+func createPortalToken(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountPortalClient(clientConn)
+
+	var accountID string
+	switch {
+	case ctx.IsSet("account_id"):
+		accountID = ctx.String("account_id")
+	case ctx.Args().Present():
+		accountID = ctx.Args().First()
+	default:
+		return fmt.Errorf("account_id argument missing")
+	}
+
+	resp, err := client.CreatePortalToken(
+		ctxb, &litrpc.CreatePortalTokenRequest{
+			AccountId: accountID,
+			Label:     ctx.String("label"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listPortalTokensCommand = cli.Command{
+	Name:      "list",
+	ShortName: "l",
+	Usage:     "List all portal tokens.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "account_id",
+			Usage: "only list the tokens of the given account",
+		},
+	},
+	Action: listPortalTokens,
+}
+
+func listPortalTokens(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountPortalClient(clientConn)
+
+	resp, err := client.ListPortalTokens(
+		ctxb, &litrpc.ListPortalTokensRequest{
+			AccountId: ctx.String("account_id"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var revokePortalTokenCommand = cli.Command{
+	Name:      "revoke",
+	ShortName: "r",
+	Usage:     "Revoke a portal token.",
+	ArgsUsage: "id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the token to revoke",
+		},
+	},
+	Action: revokePortalToken,
+}
+
+func revokePortalToken(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewAccountPortalClient(clientConn)
+
+	var id string
+	switch {
+	case ctx.IsSet("id"):
+		id = ctx.String("id")
+	case ctx.Args().Present():
+		id = ctx.Args().First()
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	_, err = client.RevokePortalToken(
+		ctxb, &litrpc.RevokePortalTokenRequest{
+			Id: id,
+		},
+	)
+	return err
+}
@@ -2,10 +2,18 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"github.com/lightninglabs/lightning-terminal/adminauth"
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/urfave/cli"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var litCommands = []cli.Command{
@@ -13,7 +21,21 @@ var litCommands = []cli.Command{
 		Name:     "stop",
 		Usage:    "shutdown the LiT daemon",
 		Category: "LiT",
-		Action:   shutdownLit,
+		Description: `
+	Shuts down litd. Active LNC sessions are drained, pending account
+	updates are flushed and the subservers are stopped in dependency
+	order. With --wait, the command shows every step of the shutdown as
+	soon as it completes and only returns once litd stopped its RPC
+	servers.
+	`,
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name: "wait",
+				Usage: "wait for the shutdown to complete and " +
+					"show its progress",
+			},
+		},
+		Action: shutdownLit,
 	},
 	{
 		Name: "getinfo",
@@ -22,6 +44,351 @@ var litCommands = []cli.Command{
 		Category: "LiT",
 		Action:   getInfo,
 	},
+	{
+		Name:     "adminchallenge",
+		Usage:    "Create a challenge for a destructive admin RPC.",
+		Category: "LiT",
+		Description: `
+	Create a single use challenge for a destructive RPC like
+	/litrpc.Sessions/RevokeSession. If signed admin actions are enabled,
+	sign the returned message with the admin key, for example with
+	lncli signmessage, and pass the challenge and the signature to the
+	next call with the global --admin_challenge and --admin_signature
+	flags.
+	`,
+		ArgsUsage: "method",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name: "method",
+				Usage: "the full URI of the RPC the challenge " +
+					"is for",
+			},
+		},
+		Action: getAdminChallenge,
+	},
+	{
+		Name:     "connecturi",
+		Usage:    "Create an lndconnect URI for LiT's RPC endpoint.",
+		Category: "LiT",
+		Description: `
+	Bake a new macaroon and return it along with LiT's host and TLS
+	certificate as an lndconnect URI that wallets can import to connect
+	to LiT.
+
+	The preset determines the permissions of the macaroon:
+	  - readonly: all read permissions of the active daemons
+	  - invoice: the permissions to create invoices and on-chain addresses
+	  - admin: all permissions of the active daemons
+
+	The macaroon can be revoked with lncli deletemacaroonid and the
+	returned root_key_id.
+	`,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name: "preset",
+				Usage: "the permission preset of the " +
+					"macaroon, one of 'readonly', " +
+					"'invoice' or 'admin'",
+				Value: "readonly",
+			},
+			cli.StringFlag{
+				Name: "host",
+				Usage: "the host:port the wallet connects " +
+					"to, defaults to LiT's --httpslisten " +
+					"address",
+			},
+			cli.DurationFlag{
+				Name: "timeout",
+				Usage: "the duration after which the " +
+					"macaroon expires, 0 means it " +
+					"doesn't expire",
+			},
+		},
+		Action: getConnectURI,
+	},
+	{
+		Name:     "authlockouts",
+		Usage:    "Manage the lockouts of wrong UI passwords.",
+		Category: "LiT",
+		Description: `
+	After a wrong UI password, a client must wait for a delay that doubles
+	with every further wrong password. After too many wrong passwords, the
+	client is locked out. The lockouts can be listed and cleared, for
+	example if the password was mistyped by its owner.
+	`,
+		Subcommands: []cli.Command{
+			{
+				Name: "list",
+				Usage: "List the clients that recently " +
+					"entered a wrong UI password.",
+				Action: listAuthLockouts,
+			},
+			{
+				Name: "clear",
+				Usage: "Clear the failed attempts of a " +
+					"client.",
+				ArgsUsage: "client",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name: "client",
+						Usage: "the IP address of the " +
+							"client to clear",
+					},
+					cli.BoolFlag{
+						Name:  "all",
+						Usage: "clear all clients",
+					},
+				},
+				Action: clearAuthLockout,
+			},
+		},
+	},
+	{
+		Name:     "inspectmacaroon",
+		Usage:    "List the methods a macaroon can call.",
+		Category: "LiT",
+		Description: `
+	List the methods the given macaroon can call through litd. Besides the
+	permissions of the macaroon, its caveats, the account it is locked to
+	and the privacy mapper and rules of its session are taken into
+	account. With --method, only the given method is checked and the
+	reason it can't be called is shown.
+	`,
+		ArgsUsage: "file",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "file",
+				Usage: "the path of the macaroon file to inspect",
+			},
+			cli.StringFlag{
+				Name:  "hex",
+				Usage: "the hex encoded macaroon to inspect",
+			},
+			cli.StringFlag{
+				Name: "method",
+				Usage: "only check the method with this full " +
+					"URI, for example " +
+					"/lnrpc.Lightning/SendPaymentSync",
+			},
+			cli.BoolFlag{
+				Name: "include_denied",
+				Usage: "also list the methods the permissions " +
+					"grant but that the macaroon can't " +
+					"call",
+			},
+		},
+		Action: inspectMacaroon,
+	},
+}
+
+// adminSignature is a gRPC credential that sends the challenge and signature
+// of a signed admin action with every call.
+type adminSignature struct {
+	challenge string
+	signature string
+}
+
+// GetRequestMetadata returns the metadata fields of the signed admin action.
+//
+// NOTE: This is part of the credentials.PerRPCCredentials interface.
+func (a *adminSignature) GetRequestMetadata(_ context.Context,
+	_ ...string) (map[string]string, error) {
+
+	return map[string]string{
+		adminauth.HeaderChallenge: a.challenge,
+		adminauth.HeaderSignature: a.signature,
+	}, nil
+}
+
+// RequireTransportSecurity returns true as the signature must only be sent
+// over TLS.
+//
+// NOTE: This is part of the credentials.PerRPCCredentials interface.
+func (a *adminSignature) RequireTransportSecurity() bool {
+	return true
+}
+
+func getAdminChallenge(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	var method string
+	switch {
+	case ctx.IsSet("method"):
+		method = ctx.String("method")
+	case ctx.Args().Present():
+		method = ctx.Args().First()
+	default:
+		return fmt.Errorf("method argument missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.GetAdminChallenge(
+		ctxb, &litrpc.GetAdminChallengeRequest{
+			Method: method,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func getConnectURI(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	var preset litrpc.ConnectPreset
+	switch ctx.String("preset") {
+	case "readonly":
+		preset = litrpc.ConnectPreset_CONNECT_PRESET_READONLY
+
+	case "invoice":
+		preset = litrpc.ConnectPreset_CONNECT_PRESET_INVOICE
+
+	case "admin":
+		preset = litrpc.ConnectPreset_CONNECT_PRESET_ADMIN
+
+	default:
+		return fmt.Errorf("unknown preset %s", ctx.String("preset"))
+	}
+
+	timeout := ctx.Duration("timeout")
+
+	ctxb := context.Background()
+	resp, err := client.GetConnectURI(
+		ctxb, &litrpc.GetConnectURIRequest{
+			Preset:         preset,
+			Host:           ctx.String("host"),
+			TimeoutSeconds: uint64(timeout.Seconds()),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func listAuthLockouts(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.ListAuthLockouts(
+		ctxb, &litrpc.ListAuthLockoutsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func clearAuthLockout(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	var lockedClient string
+	switch {
+	case ctx.IsSet("client"):
+		lockedClient = ctx.String("client")
+	case ctx.Args().Present():
+		lockedClient = ctx.Args().First()
+	case !ctx.Bool("all"):
+		return fmt.Errorf("client argument missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ClearAuthLockout(
+		ctxb, &litrpc.ClearAuthLockoutRequest{
+			Client: lockedClient,
+			All:    ctx.Bool("all"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+func inspectMacaroon(ctx *cli.Context) error {
+	var (
+		macBytes []byte
+		err      error
+	)
+	switch {
+	case ctx.IsSet("hex"):
+		macBytes, err = hex.DecodeString(ctx.String("hex"))
+		if err != nil {
+			return fmt.Errorf("invalid hex macaroon: %v", err)
+		}
+
+	case ctx.IsSet("file"):
+		macBytes, err = os.ReadFile(lncfg.CleanAndExpandPath(
+			ctx.String("file"),
+		))
+
+	case ctx.Args().Present():
+		macBytes, err = os.ReadFile(lncfg.CleanAndExpandPath(
+			ctx.Args().First(),
+		))
+
+	default:
+		return fmt.Errorf("macaroon file argument missing")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read macaroon: %v", err)
+	}
+
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewProxyClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.InspectMacaroon(
+		ctxb, &litrpc.InspectMacaroonRequest{
+			Macaroon:      macBytes,
+			Method:        ctx.String("method"),
+			IncludeDenied: ctx.Bool("include_denied"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
 }
 
 func getInfo(ctx *cli.Context) error {
@@ -51,13 +418,61 @@ func shutdownLit(ctx *cli.Context) error {
 	defer cleanup()
 	client := litrpc.NewProxyClient(clientConn)
 
+	// We subscribe to the progress before requesting the shutdown, so we
+	// don't miss any of its steps.
 	ctxb := context.Background()
+	var stream litrpc.Status_SubscribeShutdownClient
+	if ctx.Bool("wait") {
+		statusClient := litrpc.NewStatusClient(clientConn)
+		stream, err = statusClient.SubscribeShutdown(
+			ctxb, &litrpc.SubscribeShutdownRequest{},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = client.StopDaemon(ctxb, &litrpc.StopDaemonRequest{})
 	if err != nil {
 		return err
 	}
 
+	if stream != nil {
+		if err := printShutdownProgress(stream); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println("Successfully shutdown LiTd")
 
 	return nil
 }
+
+// printShutdownProgress prints every shutdown step received from the given
+// stream until the stream ends.
+func printShutdownProgress(
+	stream litrpc.Status_SubscribeShutdownClient) error {
+
+	for {
+		step, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+			return nil
+
+		// The stream may also end because litd closed the connection
+		// while stopping its RPC servers.
+		case status.Code(err) == codes.Unavailable:
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		duration := time.Duration(step.DurationMs) * time.Millisecond
+		fmt.Printf("[%d/%d] stopped %s in %v\n", step.Step,
+			step.TotalSteps, step.Name, duration)
+		if step.Error != "" {
+			fmt.Printf("      error: %s\n", step.Error)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var reportsCommands = cli.Command{
+	Name:     "reports",
+	Usage:    "Compute and deliver reports of the node's activity.",
+	Category: "Reports",
+	Subcommands: []cli.Command{
+		forwardingReportCommand,
+		rebalanceReportCommand,
+		testDeliveryCommand,
+		dashboardCommand,
+	},
+}
+
+var (
+	reportStartFlag = cli.Uint64Flag{
+		Name: "start_time",
+		Usage: "the unix timestamp of the start of the report's " +
+			"time window, defaults to one week ago",
+	}
+	reportEndFlag = cli.Uint64Flag{
+		Name: "end_time",
+		Usage: "the unix timestamp of the end of the report's time " +
+			"window, defaults to now",
+	}
+)
+
+var forwardingReportCommand = cli.Command{
+	Name:      "forwarding",
+	ShortName: "f",
+	Usage: "Report the routing revenue, volume and fee efficiency " +
+		"in total and per channel.",
+	Flags: []cli.Flag{
+		reportStartFlag,
+		reportEndFlag,
+		cli.Uint64Flag{
+			Name: "bucket_seconds",
+			Usage: "if set, the totals are also reported per " +
+				"time bucket of the given size",
+		},
+	},
+	Action: forwardingReport,
+}
+
+func forwardingReport(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewReportsClient(clientConn)
+
+	resp, err := client.ForwardingReport(
+		ctxb, &litrpc.ForwardingReportRequest{
+			StartTime:     reportStartTime(ctx),
+			EndTime:       ctx.Uint64("end_time"),
+			BucketSeconds: ctx.Uint64("bucket_seconds"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var rebalanceReportCommand = cli.Command{
+	Name:      "rebalancing",
+	ShortName: "r",
+	Usage: "Report the amounts moved and fees paid by circular " +
+		"rebalances in total and per channel.",
+	Flags: []cli.Flag{
+		reportStartFlag,
+		reportEndFlag,
+	},
+	Action: rebalanceReport,
+}
+
+func rebalanceReport(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewReportsClient(clientConn)
+
+	resp, err := client.RebalanceReport(
+		ctxb, &litrpc.RebalanceReportRequest{
+			StartTime: reportStartTime(ctx),
+			EndTime:   ctx.Uint64("end_time"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var testDeliveryCommand = cli.Command{
+	Name:      "testdelivery",
+	ShortName: "t",
+	Usage: "Send a summary report of the last scheduled period to " +
+		"all configured delivery targets now.",
+	Description: "Generate a summary report of the last scheduled " +
+		"period and deliver it via the configured webhook and " +
+		"SMTP server right away. This can be used to verify the " +
+		"delivery configuration of the scheduled reports.",
+	Action: testDelivery,
+}
+
+func testDelivery(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewReportsClient(clientConn)
+
+	resp, err := client.SendTestReport(
+		ctxb, &litrpc.SendTestReportRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var dashboardCommand = cli.Command{
+	Name:      "dashboard",
+	ShortName: "d",
+	Usage: "Show a summary of the node's current state across all " +
+		"subservers.",
+	Description: "Show the node's balances and channels, pending " +
+		"swaps, pool accounts, account liabilities and active " +
+		"sessions in one summary. Sections that can't be gathered, " +
+		"for example because a daemon isn't running, are listed as " +
+		"unavailable.",
+	Action: dashboardSummary,
+}
+
+func dashboardSummary(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewReportsClient(clientConn)
+
+	resp, err := client.DashboardSummary(
+		ctxb, &litrpc.DashboardSummaryRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+// reportStartTime returns the start time set by the user or one week ago if
+// it wasn't set.
+func reportStartTime(ctx *cli.Context) uint64 {
+	if ctx.IsSet("start_time") {
+		return ctx.Uint64("start_time")
+	}
+
+	return uint64(time.Now().Add(-7 * 24 * time.Hour).Unix())
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lncfg"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/urfave/cli"
 )
@@ -37,6 +42,16 @@ var (
 		Usage: "set to true to skip verification of the " +
 			"server's tls cert.",
 	}
+	notesFlag = cli.StringFlag{
+		Name:  "notes",
+		Usage: "free-form notes to attach to the session",
+	}
+	tagFlag = cli.StringSliceFlag{
+		Name: "tag",
+		Usage: "a key=value tag to attach to the session. This " +
+			"flag can be specified multiple times if " +
+			"multiple tags should be set",
+	}
 )
 
 var sessionCommands = []cli.Command{
@@ -48,7 +63,15 @@ var sessionCommands = []cli.Command{
 		Subcommands: []cli.Command{
 			addSessionCommand,
 			listSessionCommand,
+			getSessionCommand,
+			updateSessionCommand,
 			revokeSessionCommand,
+			searchCommand,
+			exportSessionCommand,
+			importSessionCommand,
+			connectionsCommand,
+			importMacaroonCommand,
+			derivePhraseCommand,
 		},
 	},
 }
@@ -64,6 +87,8 @@ var addSessionCommand = cli.Command{
 		expiryFlag,
 		mailboxServerAddrFlag,
 		devserver,
+		notesFlag,
+		tagFlag,
 		cli.StringFlag{
 			Name: "type",
 			Usage: "session type to be created which will " +
@@ -92,6 +117,61 @@ var addSessionCommand = cli.Command{
 				"will only be used if the 'type' flag is " +
 				"set to 'account'.",
 		},
+		cli.BoolFlag{
+			Name: "idempotent",
+			Usage: "If set, the label is used as an idempotency " +
+				"key and an existing active session with the " +
+				"same label is returned instead of creating " +
+				"a new one.",
+		},
+		cli.BoolFlag{
+			Name: "pin_client",
+			Usage: "If set, the session can only be used by the " +
+				"client that connects to it first. Clients " +
+				"that send a different user agent or client " +
+				"info are rejected.",
+		},
+		cli.Uint64Flag{
+			Name: "macaroon_timeout",
+			Usage: "The number of seconds after which the " +
+				"session's macaroon times out. Must not be " +
+				"longer than the session's expiry.",
+		},
+		cli.StringFlag{
+			Name: "macaroon_ip",
+			Usage: "The IP address to lock the session's " +
+				"macaroon to.",
+		},
+		cli.BoolFlag{
+			Name: "offline_phrase",
+			Usage: "If set, the pairing phrase is never " +
+				"returned by litd. Use 'sessions " +
+				"derive-phrase' to derive it from the " +
+				"pairing secret offline.",
+		},
+		cli.StringFlag{
+			Name: "route_hints",
+			Usage: "The policy for the route hints of the " +
+				"invoices created with the session. Options " +
+				"include 'allow', 'explicit-only' to keep " +
+				"lnd from adding hints for its private " +
+				"channels and 'strip' to remove all hints.",
+			Value: "allow",
+		},
+		cli.StringFlag{
+			Name: "pairing_passphrase_file",
+			Usage: "The file containing a passphrase that the " +
+				"client must enter in addition to the " +
+				"pairing phrase when connecting. It is read " +
+				"from a file so that it doesn't end up in " +
+				"the shell history.",
+		},
+		cli.BoolFlag{
+			Name: "block_onchain_spend",
+			Usage: "If set, the session can't spend any on-chain " +
+				"funds of the node, whatever its permissions " +
+				"allow.",
+		},
 	},
 }
 
@@ -117,8 +197,33 @@ func addSession(ctx *cli.Context) error {
 		})
 	}
 
-	sessionLength := time.Second * time.Duration(ctx.Uint64("expiry"))
-	sessionExpiry := time.Now().Add(sessionLength).Unix()
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
+	routeHints, err := parseRouteHintPolicy(ctx.String("route_hints"))
+	if err != nil {
+		return err
+	}
+
+	var pairingPassphrase []byte
+	if ctx.IsSet("pairing_passphrase_file") {
+		pairingPassphrase, err = readPassphraseFile(
+			ctx, "pairing_passphrase_file",
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// If no expiry is given, we let litd apply its configured default.
+	var sessionExpiry int64
+	if ctx.IsSet("expiry") {
+		sessionLength := time.Second *
+			time.Duration(ctx.Uint64("expiry"))
+		sessionExpiry = time.Now().Add(sessionLength).Unix()
+	}
 
 	ctxb := context.Background()
 	resp, err := client.AddSession(
@@ -130,6 +235,16 @@ func addSession(ctx *cli.Context) error {
 			DevServer:                 ctx.Bool("devserver"),
 			MacaroonCustomPermissions: macPerms,
 			AccountId:                 ctx.String("account_id"),
+			Notes:                     ctx.String("notes"),
+			Tags:                      tags,
+			IdempotentLabel:           ctx.Bool("idempotent"),
+			PinClient:                 ctx.Bool("pin_client"),
+			MacaroonTimeoutSeconds:    ctx.Uint64("macaroon_timeout"),
+			MacaroonIpAddress:         ctx.String("macaroon_ip"),
+			OfflinePairingPhrase:      ctx.Bool("offline_phrase"),
+			RouteHintPolicy:           routeHints,
+			PairingPassphrase:         string(pairingPassphrase),
+			BlockOnchainSpend:         ctx.Bool("block_onchain_spend"),
 		},
 	)
 	if err != nil {
@@ -141,6 +256,26 @@ func addSession(ctx *cli.Context) error {
 	return nil
 }
 
+// parseTags parses a list of key=value strings into a tag map.
+func parseTags(tagStrs []string) (map[string]string, error) {
+	if len(tagStrs) == 0 {
+		return nil, nil
+	}
+
+	tags := make(map[string]string, len(tagStrs))
+	for _, tagStr := range tagStrs {
+		parts := strings.SplitN(tagStr, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid tag %s, expected "+
+				"key=value", tagStr)
+		}
+
+		tags[parts[0]] = parts[1]
+	}
+
+	return tags, nil
+}
+
 func parseSessionType(sessionType string) (litrpc.SessionType, error) {
 	switch sessionType {
 	case "admin":
@@ -156,6 +291,19 @@ func parseSessionType(sessionType string) (litrpc.SessionType, error) {
 	}
 }
 
+func parseRouteHintPolicy(policy string) (litrpc.RouteHintPolicy, error) {
+	switch policy {
+	case "allow":
+		return litrpc.RouteHintPolicy_ROUTE_HINTS_ALLOW, nil
+	case "explicit-only":
+		return litrpc.RouteHintPolicy_ROUTE_HINTS_EXPLICIT_ONLY, nil
+	case "strip":
+		return litrpc.RouteHintPolicy_ROUTE_HINTS_STRIP, nil
+	default:
+		return 0, fmt.Errorf("unsupported route hint policy %s", policy)
+	}
+}
+
 var listSessionCommand = cli.Command{
 	Name:        "list",
 	ShortName:   "l",
@@ -176,6 +324,7 @@ var listAllSessionsCommand = cli.Command{
 	Usage:       "list all Terminal Web sessions",
 	Description: "List all sessions.",
 	Action:      listSessions(sessionFilterAll),
+	Flags:       []cli.Flag{tagFlag},
 }
 
 var listRevokedSessions = cli.Command{
@@ -184,6 +333,7 @@ var listRevokedSessions = cli.Command{
 	Usage:       "list revoked Terminal Web sessions",
 	Description: "List revoked sessions.",
 	Action:      listSessions(sessionFilterRevoked),
+	Flags:       []cli.Flag{tagFlag},
 }
 
 var listInUseSessions = cli.Command{
@@ -192,6 +342,7 @@ var listInUseSessions = cli.Command{
 	Usage:       "list in-use Terminal Web sessions",
 	Description: "List in-use sessions.",
 	Action:      listSessions(sessionFilterInUse),
+	Flags:       []cli.Flag{tagFlag},
 }
 
 var listExpiredSessions = cli.Command{
@@ -200,6 +351,7 @@ var listExpiredSessions = cli.Command{
 	Usage:       "list expired Terminal Web sessions",
 	Description: "List expired sessions.",
 	Action:      listSessions(sessionFilterExpired),
+	Flags:       []cli.Flag{tagFlag},
 }
 
 var listCreatedSessions = cli.Command{
@@ -208,6 +360,7 @@ var listCreatedSessions = cli.Command{
 	Usage:       "list created Terminal Web sessions",
 	Description: "List created sessions.",
 	Action:      listSessions(sessionFilterCreated),
+	Flags:       []cli.Flag{tagFlag},
 }
 
 type sessionFilter uint32
@@ -236,9 +389,16 @@ func listSessions(filter sessionFilter) func(ctx *cli.Context) error {
 		defer cleanup()
 		client := litrpc.NewSessionsClient(clientConn)
 
+		tags, err := parseTags(ctx.StringSlice("tag"))
+		if err != nil {
+			return err
+		}
+
 		ctxb := context.Background()
 		resp, err := client.ListSessions(
-			ctxb, &litrpc.ListSessionsRequest{},
+			ctxb, &litrpc.ListSessionsRequest{
+				Tags: tags,
+			},
 		)
 		if err != nil {
 			return err
@@ -263,6 +423,102 @@ func listSessions(filter sessionFilter) func(ctx *cli.Context) error {
 	}
 }
 
+var updateSessionCommand = cli.Command{
+	Name:      "update",
+	ShortName: "u",
+	Usage:     "update the notes and tags of a session",
+	Description: "Replace the notes and tags of a session. Any " +
+		"notes or tags not specified are removed from the session.",
+	Action: updateSession,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "localpubkey",
+			Usage:    "local pubkey of the session to update",
+			Required: true,
+		},
+		notesFlag,
+		tagFlag,
+	},
+}
+
+func updateSession(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	tags, err := parseTags(ctx.StringSlice("tag"))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.UpdateSession(
+		ctxb, &litrpc.UpdateSessionRequest{
+			LocalPublicKey: pubkey,
+			Notes:          ctx.String("notes"),
+			Tags:           tags,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var getSessionCommand = cli.Command{
+	Name:        "get",
+	ShortName:   "g",
+	Usage:       "get a Terminal Web session",
+	Description: "Get a session by its local pubkey",
+	Action:      getSession,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "localpubkey",
+			Usage:    "local pubkey of the session",
+			Required: true,
+		},
+	},
+}
+
+func getSession(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.GetSession(
+		ctxb, &litrpc.GetSessionRequest{
+			LocalPublicKey: pubkey,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var revokeSessionCommand = cli.Command{
 	Name:        "revoke",
 	ShortName:   "r",
@@ -275,6 +531,11 @@ var revokeSessionCommand = cli.Command{
 			Usage:    "local pubkey of the session to revoke",
 			Required: true,
 		},
+		cli.StringFlag{
+			Name: "reason",
+			Usage: "an optional reason for the revocation that is " +
+				"stored with the session",
+		},
 	},
 }
 
@@ -295,6 +556,7 @@ func revokeSession(ctx *cli.Context) error {
 	resp, err := client.RevokeSession(
 		ctxb, &litrpc.RevokeSessionRequest{
 			LocalPublicKey: pubkey,
+			Reason:         ctx.String("reason"),
 		},
 	)
 	if err != nil {
@@ -305,3 +567,403 @@ func revokeSession(ctx *cli.Context) error {
 
 	return nil
 }
+
+var searchCommand = cli.Command{
+	Name:      "search",
+	Usage:     "search accounts and sessions",
+	ArgsUsage: "query",
+	Description: "Search the labels of accounts and the labels, notes " +
+		"and tags of sessions for the given query.",
+	Action: search,
+}
+
+func search(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("exactly one search query must be given")
+	}
+
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.Search(
+		ctxb, &litrpc.SearchRequest{
+			Query: ctx.Args().First(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var passphraseFileFlag = cli.StringFlag{
+	Name:     "passphrase_file",
+	Usage:    "the file containing the passphrase of the pairing bundle",
+	Required: true,
+}
+
+var exportSessionCommand = cli.Command{
+	Name:      "export",
+	ShortName: "e",
+	Usage:     "export the pairing data of a session as an encrypted file",
+	Description: `
+	Writes the pairing phrase and mailbox server of a session to a file that
+	is encrypted with the given passphrase. The file can be passed on instead
+	of the plain text pairing phrase and be opened with 'sessions import' on
+	another machine.
+	`,
+	Action: exportSession,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "localpubkey",
+			Usage:    "local pubkey of the session to export",
+			Required: true,
+		},
+		passphraseFileFlag,
+		cli.StringFlag{
+			Name:     "save_to",
+			Usage:    "the file to write the encrypted bundle to",
+			Required: true,
+		},
+	},
+}
+
+func exportSession(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := readPassphraseFile(ctx, passphraseFileFlag.Name)
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ExportSessionPairing(
+		ctxb, &litrpc.ExportSessionPairingRequest{
+			LocalPublicKey: pubkey,
+			Passphrase:     passphrase,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fileName := lncfg.CleanAndExpandPath(ctx.String("save_to"))
+	err = os.WriteFile(fileName, resp.EncryptedBundle, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing pairing bundle to %s: %v",
+			fileName, err)
+	}
+
+	fmt.Printf("Encrypted pairing bundle written to %s\n", fileName)
+
+	return nil
+}
+
+var importSessionCommand = cli.Command{
+	Name:      "import",
+	ShortName: "i",
+	Usage:     "show the pairing data of an encrypted pairing bundle",
+	ArgsUsage: "bundle_file",
+	Description: `
+	Decrypts a pairing bundle that was created with 'sessions export' and
+	shows the pairing phrase and mailbox server it contains.
+	`,
+	Action: importSession,
+	Flags: []cli.Flag{
+		passphraseFileFlag,
+	},
+}
+
+func importSession(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "import")
+	}
+
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	fileName := lncfg.CleanAndExpandPath(ctx.Args().First())
+	bundle, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("error reading pairing bundle: %v", err)
+	}
+
+	passphrase, err := readPassphraseFile(ctx, passphraseFileFlag.Name)
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ImportSessionPairing(
+		ctxb, &litrpc.ImportSessionPairingRequest{
+			EncryptedBundle: bundle,
+			Passphrase:      passphrase,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var connectionsCommand = cli.Command{
+	Name:      "connections",
+	ShortName: "c",
+	Usage:     "list the recent connection attempts of a session",
+	Description: `
+	Lists the most recent LNC connection attempts of a session, newest
+	first. Attempts that were rejected, for example because the handshake
+	was a replay of an earlier one, are listed together with the reason.
+	`,
+	Action: listConnectionAttempts,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:     "localpubkey",
+			Usage:    "local pubkey of the session",
+			Required: true,
+		},
+	},
+}
+
+func listConnectionAttempts(ctx *cli.Context) error {
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	pubkey, err := hex.DecodeString(ctx.String("localpubkey"))
+	if err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ListConnectionAttempts(
+		ctxb, &litrpc.ListConnectionAttemptsRequest{
+			LocalPublicKey: pubkey,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var importMacaroonCommand = cli.Command{
+	Name:      "importmacaroon",
+	ShortName: "im",
+	Usage:     "create a session from an existing lnd macaroon",
+	ArgsUsage: "macaroon_file",
+	Description: `
+	Creates a custom macaroon session from an existing macaroon that was
+	baked by lnd, so that an application that accesses lnd directly can be
+	moved to LNC without provisioning it from scratch. The session gets the
+	permissions of the macaroon and keeps all of its caveats. The
+	protections of the session, like its route hint policy, are applied on
+	top.
+
+	If no expiry is given, the session expires after litd's default expiry
+	or when the macaroon expires, whichever is earlier.
+	`,
+	Action: importMacaroon,
+	Flags: []cli.Flag{
+		labelFlag,
+		cli.Uint64Flag{
+			Name: "expiry",
+			Usage: "number of seconds that the session should " +
+				"remain active",
+		},
+		mailboxServerAddrFlag,
+		devserver,
+		notesFlag,
+		cli.BoolFlag{
+			Name: "pin_client",
+			Usage: "If set, the session can only be used by the " +
+				"client that connects to it first.",
+		},
+		cli.StringFlag{
+			Name: "route_hints",
+			Usage: "The policy for the route hints of the " +
+				"invoices created with the session. Options " +
+				"include 'allow', 'explicit-only' and 'strip'.",
+			Value: "allow",
+		},
+		cli.StringFlag{
+			Name: "pairing_passphrase_file",
+			Usage: "The file containing a passphrase that the " +
+				"client must enter in addition to the " +
+				"pairing phrase when connecting.",
+		},
+		cli.BoolFlag{
+			Name: "block_onchain_spend",
+			Usage: "If set, the session can't spend any on-chain " +
+				"funds of the node, whatever the permissions " +
+				"of the macaroon allow.",
+		},
+	},
+}
+
+func importMacaroon(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "importmacaroon")
+	}
+
+	macBytes, err := os.ReadFile(lncfg.CleanAndExpandPath(
+		ctx.Args().First(),
+	))
+	if err != nil {
+		return fmt.Errorf("unable to read macaroon: %v", err)
+	}
+
+	routeHints, err := parseRouteHintPolicy(ctx.String("route_hints"))
+	if err != nil {
+		return err
+	}
+
+	var pairingPassphrase []byte
+	if ctx.IsSet("pairing_passphrase_file") {
+		pairingPassphrase, err = readPassphraseFile(
+			ctx, "pairing_passphrase_file",
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// If no expiry is given, we let litd derive it from its configured
+	// default and the expiry of the macaroon.
+	var sessionExpiry int64
+	if ctx.IsSet("expiry") {
+		sessionLength := time.Second *
+			time.Duration(ctx.Uint64("expiry"))
+		sessionExpiry = time.Now().Add(sessionLength).Unix()
+	}
+
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewSessionsClient(clientConn)
+
+	ctxb := context.Background()
+	resp, err := client.ImportMacaroon(
+		ctxb, &litrpc.ImportMacaroonRequest{
+			Macaroon:               macBytes,
+			Label:                  ctx.String("label"),
+			ExpiryTimestampSeconds: uint64(sessionExpiry),
+			MailboxServerAddr:      ctx.String("mailboxserveraddr"),
+			DevServer:              ctx.Bool("devserver"),
+			Notes:                  ctx.String("notes"),
+			PinClient:              ctx.Bool("pin_client"),
+			RouteHintPolicy:        routeHints,
+			PairingPassphrase:      string(pairingPassphrase),
+			BlockOnchainSpend:      ctx.Bool("block_onchain_spend"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+// readPassphraseFile reads the passphrase from the file that is set with the
+// given flag. A trailing newline is not part of the passphrase.
+func readPassphraseFile(ctx *cli.Context, flag string) ([]byte, error) {
+	fileName := lncfg.CleanAndExpandPath(ctx.String(flag))
+	passphrase, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading passphrase file: %v", err)
+	}
+
+	return []byte(strings.TrimRight(string(passphrase), "\r\n")), nil
+}
+
+var derivePhraseCommand = cli.Command{
+	Name:      "derive-phrase",
+	Usage:     "derive the pairing phrase from a pairing secret",
+	ArgsUsage: "[pairing_secret]",
+	Description: `
+	Derives the pairing phrase of a session from its hex encoded pairing
+	secret. This is meant for sessions that were created with
+	--offline_phrase, for which litd never returns the phrase.
+
+	The command doesn't connect to litd, so it can be run on an
+	air-gapped machine. If the pairing secret isn't given as an
+	argument, it is read from stdin so that it doesn't end up in the
+	shell history.
+	`,
+	Action: derivePhrase,
+}
+
+func derivePhrase(ctx *cli.Context) error {
+	var secretHex string
+	switch ctx.NArg() {
+	case 0:
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("error reading pairing secret: %v",
+				err)
+		}
+		secretHex = string(input)
+
+	case 1:
+		secretHex = ctx.Args().First()
+
+	default:
+		return cli.ShowCommandHelp(ctx, "derive-phrase")
+	}
+
+	secret, err := hex.DecodeString(strings.TrimSpace(secretHex))
+	if err != nil {
+		return fmt.Errorf("invalid pairing secret: %v", err)
+	}
+
+	var entropy [mailbox.NumPassphraseEntropyBytes]byte
+	if len(secret) != len(entropy) {
+		return fmt.Errorf("pairing secret must be %d bytes, got %d",
+			len(entropy), len(secret))
+	}
+	copy(entropy[:], secret)
+
+	mnemonic, err := mailbox.PassphraseEntropyToMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(strings.Join(mnemonic[:], " "))
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var statusCommand = cli.Command{
+	Name:     "status",
+	Usage:    "Show the health of litd's components.",
+	Category: "LiT",
+	Description: `
+	Shows the size, the time of the last compaction and the recent read and
+	write latency percentiles of each database owned by litd.
+	`,
+	Action: getStatus,
+}
+
+func getStatus(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.GetStatus(ctxb, &litrpc.GetStatusRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var errorsCommand = cli.Command{
+	Name:     "errors",
+	Usage:    "Show the recent warnings and errors of each subsystem.",
+	Category: "LiT",
+	Description: `
+	Shows the most recent warnings and errors that litd and its integrated
+	subservers logged since litd was started, grouped by subsystem.
+	`,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "subsystem",
+			Usage: "only show the errors of this subsystem, for " +
+				"example LITD or LOOP; can be specified " +
+				"multiple times",
+		},
+	},
+	Action: recentErrors,
+}
+
+func recentErrors(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.RecentErrors(ctxb, &litrpc.RecentErrorsRequest{
+		Subsystems: ctx.StringSlice("subsystem"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var lockdownCommand = cli.Command{
+	Name:     "lockdown",
+	Usage:    "Enable or lift the lockdown mode.",
+	Category: "LiT",
+	Description: `
+	Enables the lockdown mode of litd, so that all RPCs that are
+	authenticated with the macaroon of an account or through an LNC session
+	are rejected right away. Calls with other macaroons, for example the
+	admin macaroon, are not affected. The lockdown mode stays enabled
+	across restarts until it is lifted with --lift.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "reason",
+			Usage: "why the lockdown mode is enabled, for " +
+				"example a reference to the incident",
+		},
+		cli.BoolFlag{
+			Name:  "lift",
+			Usage: "lift the lockdown mode instead of enabling it",
+		},
+	},
+	Action: lockdownMode,
+}
+
+func lockdownMode(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.LockdownMode(ctxb, &litrpc.LockdownModeRequest{
+		Enable: !ctx.Bool("lift"),
+		Reason: ctx.String("reason"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var maintenanceCommands = cli.Command{
+	Name:     "maintenance",
+	Usage:    "Manage the maintenance windows.",
+	Category: "LiT",
+	Description: `
+	Manages the maintenance windows of litd. While a maintenance window is
+	active, all calls of LNC sessions fail with an ERROR_NODE_MAINTENANCE
+	error that tells the clients until when the node is in maintenance.
+	litd enters and leaves the windows automatically.
+	`,
+	Subcommands: []cli.Command{
+		scheduleMaintenanceCommand,
+		listMaintenanceWindowsCommand,
+		cancelMaintenanceCommand,
+	},
+}
+
+var scheduleMaintenanceCommand = cli.Command{
+	Name:  "schedule",
+	Usage: "Schedule a maintenance window.",
+	Description: `
+	Schedules a maintenance window. The window starts right away unless
+	--start is set and ends either at --end or after --duration.
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start",
+			Usage: "the unix timestamp at which the window " +
+				"starts; if not set, the window starts right " +
+				"away",
+		},
+		cli.Int64Flag{
+			Name:  "end",
+			Usage: "the unix timestamp at which the window ends",
+		},
+		cli.DurationFlag{
+			Name: "duration",
+			Usage: "how long the window lasts, for example 30m; " +
+				"can be used instead of --end",
+		},
+		cli.StringFlag{
+			Name: "reason",
+			Usage: "why the node is in maintenance, it is " +
+				"passed on to the LNC clients",
+		},
+	},
+	Action: scheduleMaintenance,
+}
+
+func scheduleMaintenance(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	start := ctx.Int64("start")
+	end := ctx.Int64("end")
+	switch {
+	case ctx.IsSet("end") && ctx.IsSet("duration"):
+		return errors.New("only one of --end and --duration can be set")
+
+	case ctx.IsSet("duration"):
+		startTime := time.Now()
+		if start != 0 {
+			startTime = time.Unix(start, 0)
+		}
+		end = startTime.Add(ctx.Duration("duration")).Unix()
+
+	case !ctx.IsSet("end"):
+		return errors.New("either --end or --duration must be set")
+	}
+
+	resp, err := client.ScheduleMaintenance(
+		ctxb, &litrpc.ScheduleMaintenanceRequest{
+			Start:  start,
+			End:    end,
+			Reason: ctx.String("reason"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listMaintenanceWindowsCommand = cli.Command{
+	Name:   "list",
+	Usage:  "List the active and upcoming maintenance windows.",
+	Action: listMaintenanceWindows,
+}
+
+func listMaintenanceWindows(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.ListMaintenanceWindows(
+		ctxb, &litrpc.ListMaintenanceWindowsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var cancelMaintenanceCommand = cli.Command{
+	Name:      "cancel",
+	Usage:     "Cancel a maintenance window.",
+	ArgsUsage: "id",
+	Description: `
+	Cancels a maintenance window. Cancelling an active window ends it right
+	away.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "the ID of the maintenance window",
+		},
+	},
+	Action: cancelMaintenance,
+}
+
+func cancelMaintenance(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	var id string
+	switch {
+	case ctx.IsSet("id"):
+		id = ctx.String("id")
+	case ctx.Args().Present():
+		id = ctx.Args().First()
+	default:
+		return fmt.Errorf("id argument missing")
+	}
+
+	_, err = client.CancelMaintenance(
+		ctxb, &litrpc.CancelMaintenanceRequest{
+			Id: id,
+		},
+	)
+	return err
+}
+
+var configCommands = cli.Command{
+	Name:     "config",
+	Usage:    "Reload the config and show the reload history.",
+	Category: "LiT",
+	Description: `
+	Reloads the config of litd and shows the history of the past reloads.
+	Only some options, like the log levels and the guardrails, are applied
+	while litd is running, all other changes take effect after a restart.
+	`,
+	Subcommands: []cli.Command{
+		reloadConfigCommand,
+		listConfigReloadsCommand,
+	},
+}
+
+var reloadConfigCommand = cli.Command{
+	Name:  "reload",
+	Usage: "Reload the config file of litd.",
+	Description: `
+	Loads the config file of litd again and applies the changed options
+	that can be changed while litd is running. Shows which options changed
+	and which of them only take effect after a restart. Sending SIGHUP to
+	litd has the same effect.
+	`,
+	Action: reloadConfig,
+}
+
+func reloadConfig(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.ReloadConfig(ctxb, &litrpc.ReloadConfigRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listConfigReloadsCommand = cli.Command{
+	Name:  "history",
+	Usage: "List the past config reloads.",
+	Description: `
+	Lists the past config reloads, the most recent one first, including the
+	ones that failed.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "max_reloads",
+			Usage: "the maximum number of reloads to list; if " +
+				"not set, the 100 most recent reloads are " +
+				"listed",
+		},
+	},
+	Action: listConfigReloads,
+}
+
+func listConfigReloads(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewStatusClient(clientConn)
+
+	resp, err := client.ListConfigReloads(
+		ctxb, &litrpc.ListConfigReloadsRequest{
+			MaxReloads: uint32(ctx.Uint64("max_reloads")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
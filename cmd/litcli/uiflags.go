@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/urfave/cli"
+)
+
+var uiFlagsCommands = cli.Command{
+	Name:     "uiflags",
+	Usage:    "Manage the feature flags of the web UI.",
+	Category: "UI",
+	Description: `
+	Manages the feature flags of the web UI. A flag hides or shows a module
+	of the UI, for example pool, loop or accounts, for all users or for the
+	users of a role. The roles are admin and readonly.
+	`,
+	Subcommands: []cli.Command{
+		getUIFlagsCommand,
+		listUIFlagSettingsCommand,
+		setUIFlagCommand,
+		removeUIFlagCommand,
+	},
+}
+
+var getUIFlagsCommand = cli.Command{
+	Name:  "get",
+	Usage: "Show which modules are shown to the users of a role.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "role",
+			Usage: "the role to show the flags for, either admin " +
+				"or readonly. If not set, the role of the " +
+				"caller is used",
+		},
+	},
+	Action: getUIFlags,
+}
+
+func getUIFlags(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewUIFlagsClient(clientConn)
+
+	resp, err := client.GetUIFlags(ctxb, &litrpc.GetUIFlagsRequest{
+		Role: ctx.String("role"),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var listUIFlagSettingsCommand = cli.Command{
+	Name:   "list",
+	Usage:  "List the flag values set through the config or the RPC.",
+	Action: listUIFlagSettings,
+}
+
+func listUIFlagSettings(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewUIFlagsClient(clientConn)
+
+	resp, err := client.ListUIFlagSettings(
+		ctxb, &litrpc.ListUIFlagSettingsRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var setUIFlagCommand = cli.Command{
+	Name:      "set",
+	Usage:     "Show or hide a module of the web UI.",
+	ArgsUsage: "name",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the name of the flag, for example pool",
+		},
+		cli.StringFlag{
+			Name: "role",
+			Usage: "the role the value applies to, either admin or " +
+				"readonly. If not set, it applies to all roles",
+		},
+		cli.BoolFlag{
+			Name:  "disable",
+			Usage: "hide the module instead of showing it",
+		},
+	},
+	Action: setUIFlag,
+}
+
+func setUIFlag(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewUIFlagsClient(clientConn)
+
+	name, err := uiFlagName(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.SetUIFlag(ctxb, &litrpc.SetUIFlagRequest{
+		Name:    name,
+		Role:    ctx.String("role"),
+		Enabled: !ctx.Bool("disable"),
+	})
+	return err
+}
+
+var removeUIFlagCommand = cli.Command{
+	Name:      "remove",
+	Usage:     "Remove a flag value set through the RPC.",
+	ArgsUsage: "name",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "name",
+			Usage: "the name of the flag",
+		},
+		cli.StringFlag{
+			Name: "role",
+			Usage: "the role of the value to remove. If not set, " +
+				"the value for all roles is removed",
+		},
+	},
+	Action: removeUIFlag,
+}
+
+func removeUIFlag(ctx *cli.Context) error {
+	ctxb := context.Background()
+	clientConn, cleanup, err := connectClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	client := litrpc.NewUIFlagsClient(clientConn)
+
+	name, err := uiFlagName(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.RemoveUIFlag(ctxb, &litrpc.RemoveUIFlagRequest{
+		Name: name,
+		Role: ctx.String("role"),
+	})
+	return err
+}
+
+// uiFlagName returns the flag name given as flag or as first argument.
+func uiFlagName(ctx *cli.Context) (string, error) {
+	switch {
+	case ctx.IsSet("name"):
+		return ctx.String("name"), nil
+
+	case ctx.Args().Present():
+		return ctx.Args().First(), nil
+
+	default:
+		return "", fmt.Errorf("name argument missing")
+	}
+}
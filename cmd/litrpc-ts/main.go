@@ -0,0 +1,301 @@
+// litrpc-ts generates a typed TypeScript client for all litrpc services. The
+// client sends its calls through the JSON stubs of the litrpc services, for
+// example over an LNC connection of lnc-web, so the types follow the JSON
+// encoding of those stubs.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// pkg is the proto package the client is generated for.
+	pkg = "litrpc"
+
+	// header is written to the top of the generated file.
+	header = `// Code generated by litrpc-ts. DO NOT EDIT.
+// source: litrpc/*.proto
+
+/**
+ * A transport that sends a call to the JSON stub registered for the method,
+ * for example the LNC class of lnc-web.
+ */
+export interface LitRpcTransport {
+    request<T>(method: string, request?: object): Promise<T>;
+    subscribe<T>(
+        method: string,
+        request?: object,
+        onMessage?: (message: T) => void,
+        onError?: (error: Error) => void,
+    ): void;
+}
+
+/** Makes all fields of a request optional, also those of nested messages. */
+export type DeepPartial<T> = T extends object
+    ? { [K in keyof T]?: DeepPartial<T[K]> }
+    : T;
+`
+)
+
+func main() {
+	// Make sure the litrpc descriptors are registered.
+	_ = litrpc.File_lit_accounts_proto
+
+	out, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[litrpc-ts] %v\n", err)
+		os.Exit(1)
+	}
+
+	if _, err := os.Stdout.Write(out); err != nil {
+		fmt.Fprintf(os.Stderr, "[litrpc-ts] %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the TypeScript source of the client.
+func generate() ([]byte, error) {
+	var files []protoreflect.FileDescriptor
+	protoregistry.GlobalFiles.RangeFilesByPackage(
+		pkg, func(fd protoreflect.FileDescriptor) bool {
+			files = append(files, fd)
+			return true
+		},
+	)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files of package %s registered", pkg)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path() < files[j].Path()
+	})
+
+	var (
+		buf      bytes.Buffer
+		services []protoreflect.ServiceDescriptor
+	)
+	buf.WriteString(header)
+
+	for _, fd := range files {
+		for i := 0; i < fd.Enums().Len(); i++ {
+			writeEnum(&buf, fd.Enums().Get(i))
+		}
+		for i := 0; i < fd.Messages().Len(); i++ {
+			if err := writeMessage(&buf, fd.Messages().Get(i)); err != nil {
+				return nil, err
+			}
+		}
+		for i := 0; i < fd.Services().Len(); i++ {
+			services = append(services, fd.Services().Get(i))
+		}
+	}
+
+	for _, sd := range services {
+		writeService(&buf, sd)
+	}
+	writeClient(&buf, services)
+
+	return buf.Bytes(), nil
+}
+
+// typeName returns the TypeScript name of a message or enum. Nested types are
+// joined with an underscore.
+func typeName(d protoreflect.Descriptor) string {
+	name := strings.TrimPrefix(string(d.FullName()), pkg+".")
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+// lowerFirst returns the given name in lower camel case. A leading acronym is
+// lowered as a whole, so UIFlags becomes uiFlags.
+func lowerFirst(name string) string {
+	i := 0
+	for i < len(name) && unicode.IsUpper(rune(name[i])) {
+		i++
+	}
+
+	// Keep the first letter of the next word upper case.
+	if i > 1 && i < len(name) {
+		i--
+	}
+
+	return strings.ToLower(name[:i]) + name[i:]
+}
+
+// writeEnum writes an enum as a union of its value names, which is how the
+// JSON stubs encode them.
+func writeEnum(buf *bytes.Buffer, ed protoreflect.EnumDescriptor) {
+	fmt.Fprintf(buf, "\nexport type %s =", typeName(ed))
+	for i := 0; i < ed.Values().Len(); i++ {
+		fmt.Fprintf(buf, "\n    | '%s'", ed.Values().Get(i).Name())
+	}
+	buf.WriteString(";\n")
+}
+
+// writeMessage writes a message and all its nested enums and messages as
+// interfaces.
+func writeMessage(buf *bytes.Buffer, md protoreflect.MessageDescriptor) error {
+	if md.IsMapEntry() {
+		return nil
+	}
+
+	for i := 0; i < md.Enums().Len(); i++ {
+		writeEnum(buf, md.Enums().Get(i))
+	}
+	for i := 0; i < md.Messages().Len(); i++ {
+		if err := writeMessage(buf, md.Messages().Get(i)); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(buf, "\nexport interface %s {\n", typeName(md))
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+
+		tsType, err := fieldType(fd)
+		if err != nil {
+			return fmt.Errorf("%s: %v", fd.FullName(), err)
+		}
+
+		// Only the field that is set of a oneof is encoded.
+		optional := ""
+		if fd.ContainingOneof() != nil {
+			optional = "?"
+		}
+
+		fmt.Fprintf(buf, "    %s%s: %s;\n", fd.Name(), optional, tsType)
+	}
+	buf.WriteString("}\n")
+
+	return nil
+}
+
+// fieldType returns the TypeScript type of a field.
+func fieldType(fd protoreflect.FieldDescriptor) (string, error) {
+	if fd.IsMap() {
+		valueType, err := singularType(fd.MapValue())
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("{ [key: string]: %s }", valueType), nil
+	}
+
+	tsType, err := singularType(fd)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case fd.IsList():
+		return tsType + "[]", nil
+
+	// Unset messages are encoded as null.
+	case fd.Kind() == protoreflect.MessageKind &&
+		fd.ContainingOneof() == nil:
+
+		return tsType + " | null", nil
+	}
+
+	return tsType, nil
+}
+
+// singularType returns the TypeScript type of a single value of a field.
+func singularType(fd protoreflect.FieldDescriptor) (string, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return "boolean", nil
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind, protoreflect.FloatKind,
+		protoreflect.DoubleKind:
+
+		return "number", nil
+
+	// 64-bit integers are encoded as strings so they don't lose
+	// precision, bytes are encoded as base64 strings.
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind, protoreflect.StringKind,
+		protoreflect.BytesKind:
+
+		return "string", nil
+
+	case protoreflect.EnumKind:
+		if fd.Enum().ParentFile().Package() != pkg {
+			return "", fmt.Errorf("enum %s of other package",
+				fd.Enum().FullName())
+		}
+
+		return typeName(fd.Enum()), nil
+
+	case protoreflect.MessageKind:
+		if fd.Message().ParentFile().Package() != pkg {
+			return "", fmt.Errorf("message %s of other package",
+				fd.Message().FullName())
+		}
+
+		return typeName(fd.Message()), nil
+	}
+
+	return "", fmt.Errorf("unsupported kind %v", fd.Kind())
+}
+
+// writeService writes a class with one method per RPC of the service.
+func writeService(buf *bytes.Buffer, sd protoreflect.ServiceDescriptor) {
+	fmt.Fprintf(buf, "\nexport class %s {\n", sd.Name())
+	buf.WriteString("    constructor(private transport: LitRpcTransport) {}\n")
+
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		method := fmt.Sprintf("%s.%s", sd.FullName(), md.Name())
+		in, out := typeName(md.Input()), typeName(md.Output())
+
+		if md.IsStreamingServer() {
+			fmt.Fprintf(buf, `
+    %s(
+        request?: DeepPartial<%s>,
+        onMessage?: (message: %s) => void,
+        onError?: (error: Error) => void,
+    ): void {
+        this.transport.subscribe('%s', request, onMessage, onError);
+    }
+`, lowerFirst(string(md.Name())), in, out, method)
+
+			continue
+		}
+
+		fmt.Fprintf(buf, `
+    %s(request?: DeepPartial<%s>): Promise<%s> {
+        return this.transport.request('%s', request);
+    }
+`, lowerFirst(string(md.Name())), in, out, method)
+	}
+
+	buf.WriteString("}\n")
+}
+
+// writeClient writes a class that bundles the clients of all services.
+func writeClient(buf *bytes.Buffer, services []protoreflect.ServiceDescriptor) {
+	buf.WriteString("\n/** The clients of all litrpc services. */\n")
+	buf.WriteString("export class LitRpc {\n")
+	for _, sd := range services {
+		fmt.Fprintf(buf, "    %s: %s;\n", lowerFirst(string(sd.Name())),
+			sd.Name())
+	}
+
+	buf.WriteString("\n    constructor(transport: LitRpcTransport) {\n")
+	for _, sd := range services {
+		fmt.Fprintf(buf, "        this.%s = new %s(transport);\n",
+			lowerFirst(string(sd.Name())), sd.Name())
+	}
+	buf.WriteString("    }\n}\n")
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedClientUpToDate makes sure the checked in TypeScript client
+// matches the current proto definitions.
+func TestGeneratedClientUpToDate(t *testing.T) {
+	generated, err := generate()
+	require.NoError(t, err)
+
+	existing, err := os.ReadFile("../../litrpc/ts/litrpc.ts")
+	require.NoError(t, err)
+
+	require.Equal(
+		t, string(existing), string(generated), "litrpc/ts/litrpc.ts "+
+			"is outdated, run make rpc to regenerate it",
+	)
+}
+
+// TestLowerFirst tests the conversion of service and method names to lower
+// camel case.
+func TestLowerFirst(t *testing.T) {
+	require.Equal(t, "accounts", lowerFirst("Accounts"))
+	require.Equal(t, "apiKeys", lowerFirst("ApiKeys"))
+	require.Equal(t, "uiFlags", lowerFirst("UIFlags"))
+	require.Equal(t, "getUIFlags", lowerFirst("GetUIFlags"))
+	require.Equal(t, "ui", lowerFirst("UI"))
+}
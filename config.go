@@ -17,9 +17,35 @@ import (
 	"github.com/lightninglabs/faraday"
 	"github.com/lightninglabs/faraday/chain"
 	"github.com/lightninglabs/faraday/frdrpcserver"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/adminauth"
+	"github.com/lightninglabs/lightning-terminal/authlimit"
 	"github.com/lightninglabs/lightning-terminal/autopilotserver"
+	"github.com/lightninglabs/lightning-terminal/backup"
+	"github.com/lightninglabs/lightning-terminal/cluster"
+	"github.com/lightninglabs/lightning-terminal/dbcompact"
+	"github.com/lightninglabs/lightning-terminal/faults"
+	"github.com/lightninglabs/lightning-terminal/feesched"
 	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/guardrails"
+	"github.com/lightninglabs/lightning-terminal/inbox"
+	"github.com/lightninglabs/lightning-terminal/keepalive"
+	"github.com/lightninglabs/lightning-terminal/lnurl"
+	"github.com/lightninglabs/lightning-terminal/logsink"
+	"github.com/lightninglabs/lightning-terminal/maccache"
+	"github.com/lightninglabs/lightning-terminal/nodemgmt"
+	"github.com/lightninglabs/lightning-terminal/nwc"
+	"github.com/lightninglabs/lightning-terminal/oidc"
+	"github.com/lightninglabs/lightning-terminal/portal"
+	"github.com/lightninglabs/lightning-terminal/reports"
 	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightninglabs/lightning-terminal/scb"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lightning-terminal/status"
+	"github.com/lightninglabs/lightning-terminal/uiflags"
+	"github.com/lightninglabs/lightning-terminal/watchdog"
+	"github.com/lightninglabs/lightning-terminal/webproxy"
+	"github.com/lightninglabs/lightning-terminal/webui"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop/loopd"
 	"github.com/lightninglabs/pool"
@@ -56,8 +82,9 @@ const (
 	defaultLetsEncryptListen          = ":80"
 	defaultSelfSignedCertOrganization = "litd autogenerated cert"
 
-	defaultLogDirname  = "logs"
-	defaultLogFilename = "litd.log"
+	defaultLogDirname     = "logs"
+	defaultLogFilename    = "litd.log"
+	defaultLndLogFilename = "lnd.log"
 
 	DefaultTLSCertFilename = "tls.cert"
 	DefaultTLSKeyFilename  = "tls.key"
@@ -81,6 +108,14 @@ const (
 	DefaultMacaroonFilename = "lit.macaroon"
 
 	defaultFirstLNCConnTimeout = 10 * time.Minute
+
+	// defaultSubserverRetryInterval is the default interval in which the
+	// start of degraded subservers is retried.
+	defaultSubserverRetryInterval = time.Minute
+
+	// defaultSessionDrainTimeout is the default time active LNC sessions
+	// are given to finish their calls when litd shuts down.
+	defaultSessionDrainTimeout = 10 * time.Second
 )
 
 var (
@@ -189,12 +224,69 @@ type Config struct {
 	PoolMode string       `long:"pool-mode" description:"The mode to run pool in, either 'integrated' (default) or 'remote'. 'integrated' means poold is started alongside the UI and everything is stored in pool's main data directory, configure everything by using the --pool.* flags. 'remote' means the UI connects to an existing poold node and acts as a proxy for gRPC calls to it." choice:"integrated" choice:"remote"`
 	Pool     *pool.Config `group:"Integrated pool options (use when pool-mode=integrated)" namespace:"pool"`
 
+	DegradedMode           bool          `long:"degraded-mode" description:"If an integrated faraday, loop or pool daemon fails to start or stops unexpectedly, keep on serving lnd, accounts, sessions and the firewall instead of shutting down. The failed daemons are reported as degraded by the status RPC and their start is retried in the background."`
+	SubserverRetryInterval time.Duration `long:"subserver-retry-interval" description:"The interval in which the start of degraded subservers is retried. Only used with degraded-mode."`
+
+	SessionDrainTimeout time.Duration `long:"session-drain-timeout" description:"The time active LNC sessions are given to finish their in-flight calls when litd shuts down. Sessions that are still busy after the timeout are closed forcefully."`
+
 	RPCMiddleware *mid.Config `group:"RPC middleware options" namespace:"rpcmiddleware"`
 
 	Autopilot *autopilotserver.Config `group:"Autopilot server options" namespace:"autopilot"`
 
 	Firewall *firewall.Config `group:"Firewall options" namespace:"firewall"`
 
+	ChanBackup *scb.Config `group:"Channel backup options" namespace:"chanbackup"`
+
+	Backup *backup.Config `group:"Database backup options" namespace:"backup"`
+
+	Reports *reports.Config `group:"Scheduled reports options" namespace:"reports"`
+
+	NWC *nwc.Config `group:"Nostr Wallet Connect options" namespace:"nwc"`
+
+	LNURL *lnurl.Config `group:"LNURL-withdraw options" namespace:"lnurl"`
+
+	Portal *portal.Config `group:"Account portal options" namespace:"portal"`
+
+	OIDC *oidc.Config `group:"OpenID Connect options" namespace:"oidc"`
+
+	NodeManagement *nodemgmt.Config `group:"Node management options" namespace:"nodemanagement"`
+
+	FeeScheduler *feesched.Config `group:"Fee scheduler options" namespace:"feescheduler"`
+
+	Watchdog *watchdog.Config `group:"Watchdog options" namespace:"watchdog"`
+
+	Guardrails *guardrails.Config `group:"Guardrails options" namespace:"guardrails"`
+
+	AdminAuth *adminauth.Config `group:"Signed admin action options" namespace:"adminauth"`
+
+	Sessions *session.Config `group:"Session options" namespace:"sessions"`
+
+	Accounts *accounts.Config `group:"Account options" namespace:"accounts"`
+
+	WebProxy *webproxy.Config `group:"Subserver web proxy options" namespace:"webproxy"`
+
+	UI *webui.Config `group:"UI options" namespace:"ui"`
+
+	AuthLimit *authlimit.Config `group:"UI password rate limiting options" namespace:"authlimit"`
+
+	UIFlags *uiflags.Config `group:"UI feature flag options" namespace:"uiflags"`
+
+	Inbox *inbox.Config `group:"Notification inbox options" namespace:"inbox"`
+
+	Database *dbcompact.Config `group:"Database options" namespace:"db"`
+
+	Cluster *cluster.Config `group:"Cluster options" namespace:"cluster"`
+
+	Status *status.Config `group:"Status options" namespace:"status"`
+
+	MacaroonCache *maccache.Config `group:"Macaroon cache options" namespace:"maccache"`
+
+	KeepAlive *keepalive.Config `group:"Keepalive options" namespace:"keepalive"`
+
+	Logging *logsink.Config `group:"Logging options" namespace:"logging"`
+
+	Dev *DevConfig `group:"Development options" namespace:"dev"`
+
 	// faradayRpcConfig is a subset of faraday's full configuration that is
 	// passed into faraday's RPC server.
 	faradayRpcConfig *frdrpcserver.Config
@@ -206,12 +298,24 @@ type Config struct {
 	loopRemote    bool
 	poolRemote    bool
 
+	// lndNodes are the additional lnd nodes parsed from the
+	// Remote.LndNodes config option, keyed by their name.
+	lndNodes map[string]*lndNode
+
 	// lndAdminMacaroon is the admin macaroon that is given to us by lnd
 	// over an in-memory connection on startup. This is only set in
 	// integrated lnd mode.
 	lndAdminMacaroon []byte
 }
 
+// DevConfig holds the configuration parameters that are only meant for
+// development and testing.
+type DevConfig struct {
+	SimulateAccounts bool `long:"simulateaccounts" description:"Enable the RPCs that simulate settled invoices and completed payments of accounts without creating invoices or sending HTLCs. Only allowed on regtest and simnet."`
+
+	Faults *faults.Config `group:"Fault injection options" namespace:"faults"`
+}
+
 // RemoteConfig holds the configuration parameters that are needed when running
 // LiT in the "remote" lnd mode.
 type RemoteConfig struct {
@@ -225,6 +329,8 @@ type RemoteConfig struct {
 	Faraday *RemoteDaemonConfig `group:"Remote faraday (use when faraday-mode=remote)" namespace:"faraday"`
 	Loop    *RemoteDaemonConfig `group:"Remote loop (use when loop-mode=remote)" namespace:"loop"`
 	Pool    *RemoteDaemonConfig `group:"Remote pool (use when pool-mode=remote)" namespace:"pool"`
+
+	LndNodes []string `long:"lnd-node" description:"An additional lnd node that lnd calls can be sent to by setting the lit-lnd-node metadata field to its name, in the format name,host:port,tlscertpath,macaroonpath. Can be specified multiple times."`
 }
 
 // RemoteDaemonConfig holds the configuration parameters that are needed to
@@ -283,6 +389,24 @@ func (c *Config) lndConnectParams() (string, lndclient.Network, string,
 		c.lndAdminMacaroon
 }
 
+// logFile returns the path of the log file litd and its integrated subservers
+// write to. In integrated mode that is lnd's log file, as lnd sets up the
+// logging for all of them. In remote mode, no log file is written if the log
+// messages are sent to another sink, in which case an empty path is returned.
+func (c *Config) logFile() string {
+	if c.LndMode == ModeRemote {
+		if c.Logging.Sink != logsink.SinkFile {
+			return ""
+		}
+
+		return filepath.Join(
+			c.Remote.LitLogDir, c.Network, defaultLogFilename,
+		)
+	}
+
+	return filepath.Join(c.Lnd.LogDir, defaultLndLogFilename)
+}
+
 // defaultConfig returns a configuration struct with all default values set.
 func defaultConfig() *Config {
 	return &Config{
@@ -335,7 +459,39 @@ func defaultConfig() *Config {
 		Autopilot: &autopilotserver.Config{
 			PingCadence: time.Hour,
 		},
-		Firewall: firewall.DefaultConfig(),
+		Firewall:   firewall.DefaultConfig(),
+		ChanBackup: scb.DefaultConfig(),
+		Backup:     backup.DefaultConfig(),
+		Reports:    reports.DefaultConfig(),
+		NWC:        nwc.DefaultConfig(),
+		LNURL:      lnurl.DefaultConfig(),
+		Portal:     portal.DefaultConfig(),
+		OIDC:       oidc.DefaultConfig(),
+
+		SubserverRetryInterval: defaultSubserverRetryInterval,
+		SessionDrainTimeout:    defaultSessionDrainTimeout,
+
+		NodeManagement: nodemgmt.DefaultConfig(),
+		FeeScheduler:   feesched.DefaultConfig(),
+		Watchdog:       watchdog.DefaultConfig(),
+		Guardrails:     guardrails.DefaultConfig(),
+		AdminAuth:      adminauth.DefaultConfig(),
+		Sessions:       session.DefaultConfig(),
+		Accounts:       accounts.DefaultConfig(),
+		WebProxy:       webproxy.DefaultConfig(),
+		UI:             webui.DefaultConfig(),
+		AuthLimit:      authlimit.DefaultConfig(),
+		UIFlags:        uiflags.DefaultConfig(),
+		Inbox:          inbox.DefaultConfig(),
+		Database:       dbcompact.DefaultConfig(),
+		Cluster:        cluster.DefaultConfig(),
+		Status:         status.DefaultConfig(),
+		MacaroonCache:  maccache.DefaultConfig(),
+		KeepAlive:      keepalive.DefaultConfig(),
+		Logging:        logsink.DefaultConfig(),
+		Dev: &DevConfig{
+			Faults: &faults.Config{},
+		},
 	}
 }
 
@@ -396,6 +552,16 @@ func loadAndValidateConfig(interceptor signal.Interceptor) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.DegradedMode && cfg.SubserverRetryInterval < time.Second {
+		return nil, fmt.Errorf("the subserver retry interval must be " +
+			"at least one second")
+	}
+
+	if cfg.SessionDrainTimeout < 0 {
+		return nil, fmt.Errorf("the session drain timeout must not be " +
+			"negative")
+	}
+
 	// To enable the rpc middleware interceptor, we need LND's RPC
 	// middleware interceptor to be enabled. In remote mode we can't
 	// influence whether that's enabled on lnd. But in integrated mode we
@@ -476,6 +642,155 @@ func loadAndValidateConfig(interceptor signal.Interceptor) (*Config, error) {
 		return nil, err
 	}
 
+	if err := cfg.Backup.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Reports.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.FeeScheduler.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Watchdog.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Guardrails.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The guardrails are enforced on the requests that reach the
+	// integrated daemons, so they can't be used with remote ones.
+	if cfg.Guardrails.Loop.Enabled() && cfg.loopRemote {
+		return nil, fmt.Errorf("the loop guardrails can only be used " +
+			"in integrated loop mode")
+	}
+	if cfg.Guardrails.Pool.Enabled() && cfg.poolRemote {
+		return nil, fmt.Errorf("the pool guardrails can only be used " +
+			"in integrated pool mode")
+	}
+
+	if err := cfg.Firewall.Billing.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.AdminAuth.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Cluster.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The accounts and sessions of a cluster live in Postgres, only the
+	// local bolt databases could be compacted.
+	if cfg.Cluster.Enable && cfg.Database.Compact {
+		return nil, fmt.Errorf("the databases can't be compacted when " +
+			"running in a cluster")
+	}
+
+	if len(cfg.Remote.LndNodes) > 0 && !cfg.lndRemote {
+		return nil, fmt.Errorf("additional lnd nodes can only be used " +
+			"in remote lnd mode")
+	}
+
+	if err := cfg.Sessions.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.UIFlags.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Inbox.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Database.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Status.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.MacaroonCache.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.KeepAlive.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Autopilot.Mock {
+		if cfg.Network != "regtest" && cfg.Network != "simnet" {
+			return nil, fmt.Errorf("autopilot.mock can only be "+
+				"enabled on regtest or simnet, not %s",
+				cfg.Network)
+		}
+
+		if cfg.Autopilot.Address != "" {
+			return nil, fmt.Errorf("autopilot.mock can't be " +
+				"combined with autopilot.address")
+		}
+	}
+
+	if cfg.Dev.SimulateAccounts && cfg.Network != "regtest" &&
+		cfg.Network != "simnet" {
+
+		return nil, fmt.Errorf("dev.simulateaccounts can only be "+
+			"enabled on regtest or simnet, not %s", cfg.Network)
+	}
+
+	if err := cfg.Accounts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Dev.Faults.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.NWC.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.LNURL.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Portal.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.OIDC.Enable && cfg.DisableUI {
+		return nil, fmt.Errorf("oidc login can't be enabled if the " +
+			"UI is disabled")
+	}
+
+	if err := cfg.OIDC.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.WebProxy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.UI.AssetDir != "" && cfg.DisableUI {
+		return nil, fmt.Errorf("ui.assetdir can't be set if the UI is " +
+			"disabled")
+	}
+
+	if err := cfg.UI.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.AuthLimit.Validate(); err != nil {
+		return nil, err
+	}
+
 	// We've set the network before and have now validated the loop config
 	// which updated its default paths for that network. So if we're in
 	// remote mode and not mainnet, we want to update our default paths for
@@ -580,6 +895,11 @@ func loadConfigFile(preCfg *Config, interceptor signal.Interceptor) (*Config,
 		return nil, err
 	}
 
+	// The log sink needs to be known before the logging is set up.
+	if err := cfg.Logging.Validate(); err != nil {
+		return nil, err
+	}
+
 	switch cfg.LndMode {
 	// In case we are running lnd in-process, let's make sure its
 	// configuration is fully valid. This also sets up the main logger that
@@ -680,6 +1000,12 @@ func validateRemoteModeConfig(cfg *Config) error {
 
 	r.LitLogDir = lncfg.CleanAndExpandPath(r.LitLogDir)
 
+	var err error
+	cfg.lndNodes, err = parseLndNodes(r.LndNodes)
+	if err != nil {
+		return err
+	}
+
 	// In remote mode, we don't call lnd's ValidateConfig that sets up a
 	// logging backend for us. We need to manually create and start one. The
 	// root logger should've already been created as part of the default
@@ -687,9 +1013,15 @@ func validateRemoteModeConfig(cfg *Config) error {
 	if cfg.Lnd.LogWriter == nil {
 		cfg.Lnd.LogWriter = build.NewRotatingLogWriter()
 	}
-	err := cfg.Lnd.LogWriter.InitLogRotator(
-		filepath.Join(r.LitLogDir, cfg.Network, defaultLogFilename),
-		r.LitMaxLogFileSize, r.LitMaxLogFiles,
+
+	// If the log messages are sent to another sink, they are only written
+	// to stdout, from where they are forwarded.
+	if cfg.Logging.Sink != logsink.SinkFile {
+		return nil
+	}
+
+	err = cfg.Lnd.LogWriter.InitLogRotator(
+		cfg.logFile(), r.LitMaxLogFileSize, r.LitMaxLogFiles,
 	)
 	if err != nil {
 		return fmt.Errorf("log rotation setup failed: %v", err.Error())
@@ -0,0 +1,100 @@
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/lightninglabs/lightning-terminal/confreload"
+	"github.com/lightningnetwork/lnd"
+	"github.com/lightningnetwork/lnd/build"
+)
+
+// loadRawConfig parses the config file and the command line options in the
+// same order as loadConfigFile, but doesn't validate the result or change
+// any state. Two raw configs can therefore be compared to find out which
+// options the user changed.
+func loadRawConfig() (interface{}, error) {
+	// We don't print the parse errors, since they are already returned to
+	// the caller and the reload might not be triggered from a terminal.
+	const parserOpts = flags.Default &^ flags.PrintErrors
+
+	cfg := defaultConfig()
+	if _, err := flags.NewParser(cfg, parserOpts).Parse(); err != nil {
+		return nil, err
+	}
+
+	litDir := lnd.CleanAndExpandPath(cfg.LitDir)
+	configFilePath := lnd.CleanAndExpandPath(cfg.ConfigFile)
+	if litDir != DefaultLitDir && configFilePath == defaultConfigFile {
+		configFilePath = filepath.Join(litDir, defaultConfigFilename)
+	}
+
+	fileParser := flags.NewParser(cfg, parserOpts)
+	err := flags.NewIniParser(fileParser).ParseFile(configFilePath)
+	if err != nil {
+		// Just like on startup, a missing config file is fine.
+		if _, ok := err.(*flags.IniError); ok {
+			return nil, err
+		}
+	}
+
+	if _, err := flags.NewParser(cfg, parserOpts).Parse(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// reloadFuncs returns the config options that can be changed while litd is
+// running, mapped to the function that applies them.
+func (g *LightningTerminal) reloadFuncs() map[string]confreload.ReloadFunc {
+	// In remote mode the lit-debuglevel overwrites all other levels,
+	// otherwise the level of the integrated lnd is the master level. See
+	// loadAndValidateConfig.
+	debugLevelOption := "lnd.debuglevel"
+	if g.cfg.lndRemote {
+		debugLevelOption = "remote.lit-debuglevel"
+	}
+
+	return map[string]confreload.ReloadFunc{
+		debugLevelOption: func(newCfg interface{}) error {
+			cfg := newCfg.(*Config)
+
+			level := cfg.Lnd.DebugLevel
+			if g.cfg.lndRemote {
+				level = cfg.Remote.LitDebugLevel
+			}
+
+			return build.ParseAndSetDebugLevels(
+				level, g.cfg.Lnd.LogWriter,
+			)
+		},
+		"guardrails.": func(newCfg interface{}) error {
+			return g.guard.SetConfig(newCfg.(*Config).Guardrails)
+		},
+	}
+}
+
+// handleReloadSignals reloads the config whenever litd receives a SIGHUP, until
+// the given quit channel is closed.
+func (g *LightningTerminal) handleReloadSignals(quit <-chan struct{}) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-signals:
+			log.Infof("Received SIGHUP, reloading config")
+
+			// The reloader already logs the outcome of the reload.
+			_, _ = g.confReloader.Reload(confreload.TriggerSignal)
+
+		case <-quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package confreload
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// redacted is shown instead of the values of secret options.
+const redacted = "<redacted>"
+
+// Change is a config option whose value changed.
+type Change struct {
+	// Option is the name of the option, prefixed with the namespaces of
+	// its groups, for example "guardrails.loop.maxloopoutperday".
+	Option string `json:"option"`
+
+	// OldValue is the previous value of the option.
+	OldValue string `json:"old_value"`
+
+	// NewValue is the new value of the option.
+	NewValue string `json:"new_value"`
+
+	// RequiresRestart is true if the new value only takes effect once
+	// litd is restarted.
+	RequiresRestart bool `json:"requires_restart"`
+}
+
+// Diff returns the options whose values differ between the two given configs,
+// in the order in which they are declared. Both configs must be of the same
+// struct type that is parsed by go-flags, the options are identified by their
+// long names. The values of options that hold a password, a secret or a token
+// are redacted.
+func Diff(oldCfg, newCfg interface{}) ([]*Change, error) {
+	oldVal, newVal := reflect.ValueOf(oldCfg), reflect.ValueOf(newCfg)
+	if oldVal.Type() != newVal.Type() {
+		return nil, fmt.Errorf("cannot compare config of type %v to "+
+			"config of type %v", oldVal.Type(), newVal.Type())
+	}
+
+	groupOld, groupNew := groupValue(oldVal), groupValue(newVal)
+	if groupOld.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config of type %v is not a struct",
+			oldVal.Type())
+	}
+
+	var changes []*Change
+	diffGroup("", groupOld, groupNew, &changes)
+
+	return changes, nil
+}
+
+// diffGroup adds the changed options of the given group and all of its
+// sub-groups to the changes.
+func diffGroup(prefix string, oldVal, newVal reflect.Value,
+	changes *[]*Change) {
+
+	t := oldVal.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("no-flag") != "" {
+			continue
+		}
+
+		oldField, newField := oldVal.Field(i), newVal.Field(i)
+		if name, ok := field.Tag.Lookup("long"); ok {
+			if reflect.DeepEqual(
+				oldField.Interface(), newField.Interface(),
+			) {
+
+				continue
+			}
+
+			change := &Change{
+				Option:   prefix + name,
+				OldValue: formatValue(oldField),
+				NewValue: formatValue(newField),
+			}
+			if isSecret(change.Option) {
+				change.OldValue = redacted
+				change.NewValue = redacted
+			}
+			*changes = append(*changes, change)
+
+			continue
+		}
+
+		// Like go-flags, we look for options in all nested structs,
+		// but only follow pointers to the ones that are declared as a
+		// group. All other pointers are runtime state.
+		_, isGroup := field.Tag.Lookup("group")
+		namespace, hasNamespace := field.Tag.Lookup("namespace")
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+		case field.Type.Kind() == reflect.Ptr &&
+			field.Type.Elem().Kind() == reflect.Struct &&
+			(isGroup || hasNamespace):
+
+		default:
+			continue
+		}
+
+		groupPrefix := prefix
+		if namespace != "" {
+			groupPrefix = prefix + namespace + "."
+		}
+		diffGroup(
+			groupPrefix, groupValue(oldField), groupValue(newField),
+			changes,
+		)
+	}
+}
+
+// groupValue dereferences the given value. A nil pointer is treated like a
+// pointer to the zero value.
+func groupValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// formatValue formats the value of an option.
+func formatValue(v reflect.Value) string {
+	v = groupValue(v)
+	if v.Kind() == reflect.Slice && v.Len() == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// isSecret returns true if the option with the given name holds a password, a
+// secret or a token.
+func isSecret(option string) bool {
+	option = strings.ToLower(option)
+
+	return strings.Contains(option, "password") ||
+		strings.Contains(option, "secret") ||
+		strings.Contains(option, "token")
+}
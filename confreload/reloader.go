@@ -0,0 +1,273 @@
+package confreload
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotStarted is returned if the config is reloaded before the reloader was
+// started.
+var ErrNotStarted = errors.New("config reloader not started")
+
+// Trigger is what caused a config reload.
+type Trigger uint8
+
+const (
+	// TriggerRPC means that the reload was requested over RPC.
+	TriggerRPC Trigger = iota
+
+	// TriggerSignal means that litd received a SIGHUP.
+	TriggerSignal
+)
+
+// String returns a human-readable name of the trigger.
+func (t Trigger) String() string {
+	switch t {
+	case TriggerRPC:
+		return "rpc"
+
+	case TriggerSignal:
+		return "signal"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// Reload is a config reload.
+type Reload struct {
+	// ID is the ID of the reload in the history.
+	ID uint64 `json:"id"`
+
+	// Time is the time at which the config was reloaded.
+	Time time.Time `json:"time"`
+
+	// Trigger is what caused the reload.
+	Trigger Trigger `json:"trigger"`
+
+	// Changes are the options that changed since the previous reload or,
+	// for the first reload, since litd was started.
+	Changes []*Change `json:"changes"`
+
+	// RestartRequired are the options that changed since litd was
+	// started and whose new values only take effect once litd is
+	// restarted.
+	RestartRequired []string `json:"restart_required"`
+
+	// Error is set if the config couldn't be loaded or applied. The next
+	// reload is then compared to the config of the last successful one
+	// again.
+	Error string `json:"error,omitempty"`
+}
+
+// LoadFunc loads the current config from the config file and the command
+// line.
+type LoadFunc func() (interface{}, error)
+
+// ReloadFunc applies the changed options of the given newly loaded config to
+// a running component.
+type ReloadFunc func(cfg interface{}) error
+
+// Reloader reloads litd's config, applies the options that can be changed at
+// runtime and keeps a history of all reloads.
+type Reloader struct {
+	dir  string
+	load LoadFunc
+
+	// reloadable maps the options that can be changed at runtime to the
+	// function that applies them. An option that ends with a dot covers
+	// all options of that namespace.
+	reloadable map[string]ReloadFunc
+
+	// mu serializes the reloads and guards the fields below.
+	mu       sync.Mutex
+	store    *Store
+	startCfg interface{}
+	cfg      interface{}
+}
+
+// NewReloader creates a new config reloader that stores its history in the
+// given directory. The start config is the config litd was started with, as
+// returned by the load function.
+func NewReloader(dir string, startCfg interface{}, load LoadFunc,
+	reloadable map[string]ReloadFunc) *Reloader {
+
+	return &Reloader{
+		dir:        dir,
+		load:       load,
+		reloadable: reloadable,
+		startCfg:   startCfg,
+		cfg:        startCfg,
+	}
+}
+
+// Start opens the config reload store.
+func (r *Reloader) Start() error {
+	store, err := NewStore(r.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open config reload store: %v", err)
+	}
+
+	r.mu.Lock()
+	r.store = store
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Stop closes the config reload store.
+func (r *Reloader) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.store == nil {
+		return nil
+	}
+
+	err := r.store.Close()
+	r.store = nil
+
+	return err
+}
+
+// Reload loads the config again and applies the options that can be changed
+// at runtime. The reload is added to the history, even if it failed.
+func (r *Reloader) Reload(trigger Trigger) (*Reload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.store == nil {
+		return nil, ErrNotStarted
+	}
+
+	reload := &Reload{
+		Time:    time.Now(),
+		Trigger: trigger,
+	}
+	reloadErr := r.reload(reload)
+	if reloadErr != nil {
+		reload.Error = reloadErr.Error()
+	}
+
+	if err := r.store.AddReload(reload); err != nil {
+		return nil, fmt.Errorf("unable to store config reload: %v",
+			err)
+	}
+
+	if reloadErr != nil {
+		log.Errorf("Config reload failed: %v", reloadErr)
+
+		return nil, reloadErr
+	}
+
+	for _, change := range reload.Changes {
+		log.Infof("Config option %s changed from %q to %q (requires "+
+			"restart: %v)", change.Option, change.OldValue,
+			change.NewValue, change.RequiresRestart)
+	}
+	if len(reload.RestartRequired) > 0 {
+		log.Warnf("Config options %s changed, restart litd to apply "+
+			"them", strings.Join(reload.RestartRequired, ", "))
+	}
+
+	return reload, nil
+}
+
+// reload loads the config, applies the changed options that can be changed at
+// runtime and adds the changes to the given reload. The caller must hold the
+// mutex.
+func (r *Reloader) reload(reload *Reload) error {
+	newCfg, err := r.load()
+	if err != nil {
+		return fmt.Errorf("unable to load config: %v", err)
+	}
+
+	changes, err := Diff(r.cfg, newCfg)
+	if err != nil {
+		return err
+	}
+
+	// Each component is only notified once, even if several of its
+	// options changed.
+	var applyKeys []string
+	apply := make(map[string]struct{})
+	for _, change := range changes {
+		key, ok := r.reloadKey(change.Option)
+		if !ok {
+			change.RequiresRestart = true
+			continue
+		}
+
+		if _, ok := apply[key]; !ok {
+			apply[key] = struct{}{}
+			applyKeys = append(applyKeys, key)
+		}
+	}
+	reload.Changes = changes
+
+	for _, key := range applyKeys {
+		if err := r.reloadable[key](newCfg); err != nil {
+			return fmt.Errorf("unable to apply %s: %v", key, err)
+		}
+	}
+
+	sinceStart, err := Diff(r.startCfg, newCfg)
+	if err != nil {
+		return err
+	}
+	for _, change := range sinceStart {
+		if _, ok := r.reloadKey(change.Option); !ok {
+			reload.RestartRequired = append(
+				reload.RestartRequired, change.Option,
+			)
+		}
+	}
+
+	r.cfg = newCfg
+
+	return nil
+}
+
+// reloadKey returns the key of the function that applies the given option, if
+// it can be changed at runtime.
+func (r *Reloader) reloadKey(option string) (string, bool) {
+	if _, ok := r.reloadable[option]; ok {
+		return option, true
+	}
+
+	// If several namespaces match, the most specific one wins.
+	var keys []string
+	for key := range r.reloadable {
+		if strings.HasSuffix(key, ".") &&
+			strings.HasPrefix(option, key) {
+
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return len(keys[i]) > len(keys[j])
+	})
+
+	return keys[0], true
+}
+
+// Reloads returns the history of the config reloads, the most recent one
+// first. If maxNum is not zero, at most that many reloads are returned.
+func (r *Reloader) Reloads(maxNum int) ([]*Reload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.store == nil {
+		return nil, ErrNotStarted
+	}
+
+	return r.store.Reloads(maxNum)
+}
@@ -0,0 +1,195 @@
+package confreload
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testLimits struct {
+	MaxAmt uint64 `long:"maxamt"`
+}
+
+type testGroup struct {
+	Listen   string      `long:"listen"`
+	Password string      `long:"password"`
+	Token    string      `long:"token"`
+	Limits   *testLimits `group:"limits" namespace:"limits"`
+}
+
+type testConfig struct {
+	DebugLevel string        `long:"debuglevel"`
+	Timeout    time.Duration `long:"timeout"`
+	Peers      []string      `long:"peer"`
+	Group      *testGroup    `group:"Group" namespace:"group"`
+
+	// state is runtime state that isn't an option.
+	state int
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{
+		DebugLevel: "info",
+		Timeout:    time.Minute,
+		Group: &testGroup{
+			Listen:   "localhost:1234",
+			Password: "hunter2",
+			Limits:   &testLimits{},
+		},
+	}
+}
+
+// TestDiff tests that the changed options are found in all groups.
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldCfg := newTestConfig()
+	newCfg := newTestConfig()
+	newCfg.state = 1
+
+	changes, err := Diff(oldCfg, newCfg)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	newCfg.Timeout = time.Hour
+	newCfg.Peers = []string{"a", "b"}
+	newCfg.Group.Password = "hunter3"
+	newCfg.Group.Token = "t0ken"
+	newCfg.Group.Limits.MaxAmt = 100
+
+	changes, err = Diff(oldCfg, newCfg)
+	require.NoError(t, err)
+	require.Equal(t, []*Change{{
+		Option:   "timeout",
+		OldValue: "1m0s",
+		NewValue: "1h0m0s",
+	}, {
+		Option:   "peer",
+		NewValue: "[a b]",
+	}, {
+		Option:   "group.password",
+		OldValue: redacted,
+		NewValue: redacted,
+	}, {
+		Option:   "group.token",
+		OldValue: redacted,
+		NewValue: redacted,
+	}, {
+		Option:   "group.limits.maxamt",
+		OldValue: "0",
+		NewValue: "100",
+	}}, changes)
+
+	// A missing group is treated like a group without any options set.
+	newCfg = newTestConfig()
+	newCfg.Group = nil
+	changes, err = Diff(oldCfg, newCfg)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, "group.listen", changes[0].Option)
+
+	_, err = Diff(oldCfg, testConfig{})
+	require.Error(t, err)
+}
+
+// TestReloader tests that a reload applies the options that can be changed at
+// runtime, reports the ones that require a restart and is added to the
+// history.
+func TestReloader(t *testing.T) {
+	t.Parallel()
+
+	var (
+		startCfg = newTestConfig()
+		nextCfg  = newTestConfig()
+		loadErr  error
+
+		appliedLevel  string
+		appliedLimits []uint64
+	)
+	load := func() (interface{}, error) {
+		if loadErr != nil {
+			return nil, loadErr
+		}
+
+		cfg := *nextCfg
+		group := *nextCfg.Group
+		limits := *nextCfg.Group.Limits
+		group.Limits = &limits
+		cfg.Group = &group
+
+		return &cfg, nil
+	}
+
+	r := NewReloader(t.TempDir(), startCfg, load, map[string]ReloadFunc{
+		"debuglevel": func(cfg interface{}) error {
+			appliedLevel = cfg.(*testConfig).DebugLevel
+			return nil
+		},
+		"group.limits.": func(cfg interface{}) error {
+			appliedLimits = append(
+				appliedLimits,
+				cfg.(*testConfig).Group.Limits.MaxAmt,
+			)
+			return nil
+		},
+	})
+
+	_, err := r.Reload(TriggerRPC)
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		require.NoError(t, r.Stop())
+	})
+
+	// A reload without any changes doesn't apply anything.
+	reload, err := r.Reload(TriggerSignal)
+	require.NoError(t, err)
+	require.Empty(t, reload.Changes)
+	require.Empty(t, reload.RestartRequired)
+	require.Empty(t, appliedLevel)
+
+	nextCfg.DebugLevel = "debug"
+	nextCfg.Group.Listen = "localhost:4321"
+	nextCfg.Group.Limits.MaxAmt = 100
+
+	reload, err = r.Reload(TriggerRPC)
+	require.NoError(t, err)
+	require.Len(t, reload.Changes, 3)
+	require.False(t, reload.Changes[0].RequiresRestart)
+	require.True(t, reload.Changes[1].RequiresRestart)
+	require.False(t, reload.Changes[2].RequiresRestart)
+	require.Equal(t, []string{"group.listen"}, reload.RestartRequired)
+	require.Equal(t, "debug", appliedLevel)
+	require.Equal(t, []uint64{100}, appliedLimits)
+
+	// The options that require a restart are still reported by the next
+	// reloads, even if they didn't change again.
+	nextCfg.Group.Limits.MaxAmt = 200
+	reload, err = r.Reload(TriggerRPC)
+	require.NoError(t, err)
+	require.Len(t, reload.Changes, 1)
+	require.Equal(t, []string{"group.listen"}, reload.RestartRequired)
+	require.Equal(t, []uint64{100, 200}, appliedLimits)
+
+	// A failed reload is recorded, but doesn't change anything.
+	loadErr = errors.New("invalid config file")
+	_, err = r.Reload(TriggerSignal)
+	require.ErrorContains(t, err, "invalid config file")
+
+	reloads, err := r.Reloads(0)
+	require.NoError(t, err)
+	require.Len(t, reloads, 4)
+	require.Equal(t, uint64(4), reloads[0].ID)
+	require.Contains(t, reloads[0].Error, "invalid config file")
+	require.Equal(t, TriggerSignal, reloads[0].Trigger)
+	require.Equal(t, "group.limits.maxamt", reloads[1].Changes[0].Option)
+	require.Equal(t, uint64(1), reloads[3].ID)
+
+	reloads, err = r.Reloads(1)
+	require.NoError(t, err)
+	require.Len(t, reloads, 1)
+	require.Equal(t, uint64(4), reloads[0].ID)
+}
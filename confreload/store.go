@@ -0,0 +1,128 @@
+package confreload
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the config reload database.
+	DBFilename = "confreload.db"
+
+	// dbFilePermission is the default permission the config reload
+	// database file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The config reloads are stored in the following structure in the db:
+
+	reloads -> reload ID (8 bytes) -> json encoded Reload
+
+	The reload IDs are assigned in increasing order.
+*/
+
+// reloadsBucketKey is the key of the top level bucket holding the config
+// reloads.
+var reloadsBucketKey = []byte("reloads")
+
+// Store is a bolt-backed persistent store of the config reload history.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the config reload store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(reloadsBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// AddReload stores the given reload and sets its ID.
+func (s *Store) AddReload(reload *Reload) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(reloadsBucketKey)
+
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		reload.ID = id
+
+		b, err := json.Marshal(reload)
+		if err != nil {
+			return err
+		}
+
+		var key [8]byte
+		binary.BigEndian.PutUint64(key[:], id)
+
+		return bucket.Put(key[:], b)
+	})
+}
+
+// Reloads fetches the stored reloads, the most recent one first. If maxNum is
+// not zero, at most that many reloads are returned.
+func (s *Store) Reloads(maxNum int) ([]*Reload, error) {
+	var reloads []*Reload
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(reloadsBucketKey).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if maxNum != 0 && len(reloads) >= maxNum {
+				return nil
+			}
+
+			reload := &Reload{}
+			if err := json.Unmarshal(v, reload); err != nil {
+				return err
+			}
+			reloads = append(reloads, reload)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reloads, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,95 @@
+package terminal
+
+import (
+	"path/filepath"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/dbcompact"
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/session"
+)
+
+// DatabasePaths returns the paths of the bbolt databases owned by lit. The
+// session and firewall databases live in the network directory, the accounts
+// database lives next to the lit macaroon.
+func DatabasePaths(networkDir, macaroonPath string) []string {
+	return []string{
+		filepath.Join(filepath.Dir(macaroonPath), accounts.DBFilename),
+		filepath.Join(networkDir, session.DBFilename),
+		filepath.Join(networkDir, firewalldb.DBFilename),
+	}
+}
+
+// DatabaseChecks returns the bbolt databases owned by lit together with the
+// checks that decode their records.
+func DatabaseChecks(networkDir, macaroonPath string) []dbverify.Database {
+	paths := DatabasePaths(networkDir, macaroonPath)
+	checks := []dbverify.CheckFunc{
+		accounts.VerifyRecords,
+		session.VerifyRecords,
+		firewalldb.VerifyRecords,
+	}
+
+	dbs := make([]dbverify.Database, len(paths))
+	for i, path := range paths {
+		dbs[i] = dbverify.Database{
+			Path:  path,
+			Check: checks[i],
+		}
+	}
+
+	return dbs
+}
+
+// compactDatabases compacts the lit databases if enabled in the config. It
+// must be called before any of the databases is opened.
+func (g *LightningTerminal) compactDatabases() error {
+	if !g.cfg.Database.Compact {
+		return nil
+	}
+
+	networkDir := filepath.Join(g.cfg.LitDir, g.cfg.Network)
+	paths := DatabasePaths(networkDir, g.cfg.MacaroonPath)
+
+	log.Infof("Compacting lit databases")
+	_, err := dbcompact.CompactAll(
+		paths, g.cfg.Database.CompactMinAge,
+		func(p dbcompact.Progress) {
+			log.Debugf("Compacting %v: %.0f%%", p.Name,
+				p.Percent())
+		},
+	)
+
+	return err
+}
+
+// verifyDatabases decodes all records of the lit databases if enabled in the
+// config and quarantines the corrupt ones, so they can't make litd fail later
+// on. It must be called before any of the databases is opened.
+func (g *LightningTerminal) verifyDatabases() error {
+	if !g.cfg.Database.Verify {
+		return nil
+	}
+
+	networkDir := filepath.Join(g.cfg.LitDir, g.cfg.Network)
+	dbs := DatabaseChecks(networkDir, g.cfg.MacaroonPath)
+
+	log.Infof("Verifying lit databases")
+	results, err := dbverify.VerifyAll(dbs, true)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if len(result.Corrupt) == 0 {
+			continue
+		}
+
+		log.Warnf("Quarantined %d of %d records of %v that can't be "+
+			"decoded, see litcli db verify for details",
+			len(result.Corrupt), result.Checked, result.Path)
+	}
+
+	return nil
+}
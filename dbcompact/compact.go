@@ -0,0 +1,376 @@
+package dbcompact
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// dbTimeout is the maximum time we wait for the exclusive lock of a
+	// database file. If litd still has the database open, the lock can't
+	// be obtained.
+	dbTimeout = 5 * time.Second
+
+	// maxTxSize is the number of bytes that are copied into the compacted
+	// database before the write transaction is committed, so large
+	// databases don't need to be held in memory as a whole.
+	maxTxSize = 64 * 1024
+
+	// tempSuffix is appended to the path of a database for the file the
+	// compacted copy is written to.
+	tempSuffix = ".compact"
+
+	// lastCompactedSuffix is appended to the path of a database for the
+	// file that holds the time of its last compaction.
+	lastCompactedSuffix = ".last-compacted"
+)
+
+// Progress describes how far the compaction of a database has come.
+type Progress struct {
+	// Name is the file name of the database.
+	Name string
+
+	// CopiedKeys is the number of keys and buckets that were copied so
+	// far.
+	CopiedKeys int64
+
+	// TotalKeys is the number of keys and buckets in the database.
+	TotalKeys int64
+}
+
+// Percent returns the share of the keys that were copied so far.
+func (p *Progress) Percent() float64 {
+	if p.TotalKeys == 0 {
+		return 100
+	}
+
+	return 100 * float64(p.CopiedKeys) / float64(p.TotalKeys)
+}
+
+// ProgressFunc is called while a database is compacted.
+type ProgressFunc func(Progress)
+
+// Result holds the outcome of compacting a database.
+type Result struct {
+	// Path is the full path of the database.
+	Path string
+
+	// SizeBefore is the size of the database file before the compaction.
+	SizeBefore int64
+
+	// SizeAfter is the size of the database file after the compaction.
+	// It equals SizeBefore if the compaction was skipped.
+	SizeAfter int64
+
+	// Duration is the time the compaction took.
+	Duration time.Duration
+
+	// Skipped is true if the database was compacted more recently than
+	// the min age or doesn't exist yet.
+	Skipped bool
+}
+
+// Compact compacts the bbolt database at the given path. All buckets and keys
+// are copied into a new file which then replaces the original one, so the
+// pages on the free list are returned to the file system. The database must
+// not be opened by litd while it is compacted. If the database was compacted
+// more recently than minAge, it is skipped.
+func Compact(path string, minAge time.Duration,
+	progress ProgressFunc) (*Result, error) {
+
+	result := &Result{Path: path}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		result.Skipped = true
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.SizeBefore = info.Size()
+	result.SizeAfter = info.Size()
+
+	if minAge > 0 {
+		lastCompacted, err := LastCompacted(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if time.Since(lastCompacted) < minAge {
+			log.Infof("Skipping compaction of %v, last "+
+				"compacted at %v", path, lastCompacted)
+
+			result.Skipped = true
+			return result, nil
+		}
+	}
+
+	start := time.Now()
+	log.Infof("Compacting %v (%d bytes)", path, result.SizeBefore)
+
+	tempPath := path + tempSuffix
+	if err := os.Remove(tempPath); err != nil &&
+		!errors.Is(err, os.ErrNotExist) {
+
+		return nil, err
+	}
+
+	err = compactFile(path, tempPath, info.Mode(), progress)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return nil, err
+	}
+
+	// Only replace the original database once the compacted copy was
+	// written completely.
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return nil, fmt.Errorf("unable to replace %v: %v", path, err)
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	result.SizeAfter = info.Size()
+	result.Duration = time.Since(start)
+
+	err = os.WriteFile(
+		path+lastCompactedSuffix,
+		[]byte(strconv.FormatInt(time.Now().Unix(), 10)), 0600,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to record compaction time: %v",
+			err)
+	}
+
+	log.Infof("Compacted %v from %d to %d bytes in %v", path,
+		result.SizeBefore, result.SizeAfter, result.Duration)
+
+	return result, nil
+}
+
+// CompactAll compacts the given databases one after another. It stops at the
+// first database that can't be compacted.
+func CompactAll(paths []string, minAge time.Duration,
+	progress ProgressFunc) ([]*Result, error) {
+
+	results := make([]*Result, 0, len(paths))
+	for _, path := range paths {
+		result, err := Compact(path, minAge, progress)
+		if err != nil {
+			return results, fmt.Errorf("unable to compact %v: %v",
+				path, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// LastCompacted returns the time at which the database at the given path was
+// last compacted. The zero time is returned if it was never compacted.
+func LastCompacted(path string) (time.Time, error) {
+	content, err := os.ReadFile(path + lastCompactedSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	unix, err := strconv.ParseInt(
+		strings.TrimSpace(string(content)), 10, 64,
+	)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid compaction time in "+
+			"%v: %v", path+lastCompactedSuffix, err)
+	}
+
+	return time.Unix(unix, 0), nil
+}
+
+// compactFile copies all buckets and keys of the database at srcPath into a
+// new database at dstPath.
+func compactFile(srcPath, dstPath string, mode os.FileMode,
+	progress ProgressFunc) error {
+
+	src, err := bbolt.Open(srcPath, 0600, &bbolt.Options{
+		Timeout:  dbTimeout,
+		ReadOnly: true,
+	})
+	if err == bbolt.ErrTimeout {
+		return fmt.Errorf("unable to obtain the lock of %v, make sure "+
+			"litd isn't running", srcPath)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	dst, err := bbolt.Open(dstPath, mode, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	state := Progress{Name: filepath.Base(srcPath)}
+	err = walk(src, func(_ [][]byte, _, _ []byte, _ uint64) error {
+		state.TotalKeys++
+		return nil
+	})
+	if err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	if err := copyDB(dst, src, &state, progress); err != nil {
+		_ = dst.Close()
+		return err
+	}
+
+	return dst.Close()
+}
+
+// copyDB copies all buckets and keys from src to dst, committing the write
+// transaction every maxTxSize bytes.
+func copyDB(dst, src *bbolt.DB, state *Progress,
+	progress ProgressFunc) error {
+
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Report the progress in steps of one percent at most.
+	step := state.TotalKeys / 100
+	if step == 0 {
+		step = 1
+	}
+
+	var size int64
+	err = walk(src, func(path [][]byte, k, v []byte, seq uint64) error {
+		if size+int64(len(k)+len(v)) > maxTxSize {
+			if err := tx.Commit(); err != nil {
+				return err
+			}
+
+			tx, err = dst.Begin(true)
+			if err != nil {
+				return err
+			}
+			size = 0
+		}
+		size += int64(len(k) + len(v))
+
+		if err := copyEntry(tx, path, k, v, seq); err != nil {
+			return err
+		}
+
+		state.CopiedKeys++
+		if progress != nil && state.CopiedKeys%step == 0 {
+			progress(*state)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if progress != nil && state.CopiedKeys%step != 0 {
+		progress(*state)
+	}
+
+	return tx.Commit()
+}
+
+// copyEntry writes a single key or bucket to the bucket at the given path in
+// the destination transaction. A nil value denotes a bucket.
+func copyEntry(tx *bbolt.Tx, path [][]byte, k, v []byte, seq uint64) error {
+	// Top level entries are always buckets.
+	if len(path) == 0 {
+		bucket, err := tx.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+
+		return bucket.SetSequence(seq)
+	}
+
+	parent := tx.Bucket(path[0])
+	for _, name := range path[1:] {
+		parent = parent.Bucket(name)
+	}
+	if parent == nil {
+		return fmt.Errorf("bucket %x not found", path[len(path)-1])
+	}
+
+	// The keys are copied in order, so the pages can be filled completely.
+	parent.FillPercent = 1.0
+
+	if v == nil {
+		bucket, err := parent.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+
+		return bucket.SetSequence(seq)
+	}
+
+	return parent.Put(k, v)
+}
+
+// walkFunc is called for each bucket and key of a database. The path holds
+// the names of the parent buckets, the value is nil for buckets and the
+// sequence is only set for buckets.
+type walkFunc func(path [][]byte, k, v []byte, seq uint64) error
+
+// walk calls fn for all buckets and keys of the database in order.
+func walk(db *bbolt.DB, fn walkFunc) error {
+	return db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+			err := fn(nil, name, nil, b.Sequence())
+			if err != nil {
+				return err
+			}
+
+			return walkBucket(b, [][]byte{name}, fn)
+		})
+	})
+}
+
+// walkBucket calls fn for all keys and nested buckets of the given bucket.
+func walkBucket(b *bbolt.Bucket, path [][]byte, fn walkFunc) error {
+	return b.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return fn(path, k, v, 0)
+		}
+
+		nested := b.Bucket(k)
+		if err := fn(path, k, nil, nested.Sequence()); err != nil {
+			return err
+		}
+
+		// Copy the path so the nested calls can't modify it.
+		nestedPath := make([][]byte, len(path)+1)
+		copy(nestedPath, path)
+		nestedPath[len(path)] = k
+
+		return walkBucket(nested, nestedPath, fn)
+	})
+}
@@ -0,0 +1,113 @@
+package dbcompact
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+// TestCompact tests that a compacted database holds the same buckets, keys
+// and sequences as the original one while taking up less space.
+func TestCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := bbolt.Open(path, 0600, nil)
+	require.NoError(t, err)
+
+	value := bytes.Repeat([]byte{1}, 1000)
+	err = db.Update(func(tx *bbolt.Tx) error {
+		top, err := tx.CreateBucket([]byte("top"))
+		if err != nil {
+			return err
+		}
+		if err := top.SetSequence(7); err != nil {
+			return err
+		}
+
+		nested, err := top.CreateBucket([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		if err := nested.SetSequence(3); err != nil {
+			return err
+		}
+
+		for i := 0; i < 1000; i++ {
+			key := []byte(fmt.Sprintf("key-%04d", i))
+			if err := nested.Put(key, value); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.CreateBucket([]byte("empty"))
+		return err
+	})
+	require.NoError(t, err)
+
+	// Deleting most of the keys leaves the pages on the free list.
+	err = db.Update(func(tx *bbolt.Tx) error {
+		nested := tx.Bucket([]byte("top")).Bucket([]byte("nested"))
+		for i := 10; i < 1000; i++ {
+			key := []byte(fmt.Sprintf("key-%04d", i))
+			if err := nested.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	var updates []Progress
+	result, err := Compact(path, 0, func(p Progress) {
+		updates = append(updates, p)
+	})
+	require.NoError(t, err)
+	require.False(t, result.Skipped)
+	require.Less(t, result.SizeAfter, result.SizeBefore)
+
+	// The last update reports all keys as copied.
+	require.NotEmpty(t, updates)
+	last := updates[len(updates)-1]
+	require.EqualValues(t, 13, last.TotalKeys)
+	require.Equal(t, last.TotalKeys, last.CopiedKeys)
+	require.EqualValues(t, 100, last.Percent())
+
+	db, err = bbolt.Open(path, 0600, nil)
+	require.NoError(t, err)
+	err = db.View(func(tx *bbolt.Tx) error {
+		top := tx.Bucket([]byte("top"))
+		require.EqualValues(t, 7, top.Sequence())
+
+		nested := top.Bucket([]byte("nested"))
+		require.EqualValues(t, 3, nested.Sequence())
+		require.Equal(t, 10, nested.Stats().KeyN)
+		require.Equal(t, value, nested.Get([]byte("key-0009")))
+		require.NotNil(t, tx.Bucket([]byte("empty")))
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	lastCompacted, err := LastCompacted(path)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), lastCompacted, time.Minute)
+
+	// A database that was compacted recently is skipped.
+	result, err = Compact(path, time.Hour, nil)
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+	require.Equal(t, result.SizeBefore, result.SizeAfter)
+
+	// Databases that don't exist yet are skipped as well.
+	result, err = Compact(path+".missing", 0, nil)
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+}
@@ -0,0 +1,28 @@
+package dbcompact
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config holds all config options for compacting the lit databases.
+type Config struct {
+	Compact       bool          `long:"compact" description:"Compact the accounts, session and firewall databases on startup, before they are opened."`
+	CompactMinAge time.Duration `long:"compactminage" description:"Skip the compaction of a database on startup if it was compacted more recently than this. Set to 0 to always compact."`
+	Verify        bool          `long:"verify" description:"Check that all records of the accounts, session and firewall databases can be decoded on startup, before they are opened. Corrupt records are moved to a quarantine bucket of their database."`
+}
+
+// DefaultConfig constructs the default database compaction Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.CompactMinAge < 0 {
+		return fmt.Errorf("the database compaction min age must not " +
+			"be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package dbverify
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// QuarantineBucketKey is the top level bucket the corrupt records are
+	// moved to. Each record is stored in a nested bucket keyed by a
+	// sequence number.
+	QuarantineBucketKey = []byte("quarantine")
+
+	// quarantineLocationKey is the key of the readable location of the
+	// record.
+	quarantineLocationKey = []byte("location")
+
+	// quarantineBucketsKey is the key of the bucket path of the record,
+	// with the names separated by their lengths.
+	quarantineBucketsKey = []byte("buckets")
+
+	// quarantineKeyKey is the key of the original key of the record.
+	quarantineKeyKey = []byte("key")
+
+	// quarantineValueKey is the key of the raw value of the record.
+	quarantineValueKey = []byte("value")
+
+	// quarantineReasonKey is the key of the reason why the record can't
+	// be decoded.
+	quarantineReasonKey = []byte("reason")
+
+	// quarantineTimeKey is the key of the unix timestamp at which the
+	// record was quarantined.
+	quarantineTimeKey = []byte("time")
+
+	// byteOrder is the binary byte order we use to encode integers.
+	byteOrder = binary.BigEndian
+)
+
+// quarantineRecord moves the given record to the quarantine bucket.
+func quarantineRecord(tx *bbolt.Tx, record *Record) error {
+	bucket := tx.Bucket(record.Buckets[0])
+	for _, name := range record.Buckets[1:] {
+		if bucket == nil {
+			break
+		}
+		bucket = bucket.Bucket(name)
+	}
+	if bucket == nil {
+		return fmt.Errorf("bucket of record %v not found",
+			record.Location())
+	}
+
+	// A corrupt record may also be a bucket where a value is expected, in
+	// which case there is no value to keep.
+	value := bucket.Get(record.Key)
+
+	quarantine, err := tx.CreateBucketIfNotExists(QuarantineBucketKey)
+	if err != nil {
+		return err
+	}
+
+	seq, err := quarantine.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	var seqBytes [8]byte
+	byteOrder.PutUint64(seqBytes[:], seq)
+	entry, err := quarantine.CreateBucket(seqBytes[:])
+	if err != nil {
+		return err
+	}
+
+	var buckets bytes.Buffer
+	for _, name := range record.Buckets {
+		var length [2]byte
+		byteOrder.PutUint16(length[:], uint16(len(name)))
+		buckets.Write(length[:])
+		buckets.Write(name)
+	}
+
+	var now [8]byte
+	byteOrder.PutUint64(now[:], uint64(time.Now().Unix()))
+
+	fields := [][2][]byte{
+		{quarantineLocationKey, []byte(record.Location())},
+		{quarantineBucketsKey, buckets.Bytes()},
+		{quarantineKeyKey, record.Key},
+		{quarantineValueKey, value},
+		{quarantineReasonKey, []byte(record.Err.Error())},
+		{quarantineTimeKey, now[:]},
+	}
+	for _, field := range fields {
+		if field[1] == nil {
+			continue
+		}
+
+		if err := entry.Put(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+
+	if value == nil {
+		return bucket.DeleteBucket(record.Key)
+	}
+
+	return bucket.Delete(record.Key)
+}
@@ -0,0 +1,221 @@
+package dbverify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.etcd.io/bbolt"
+)
+
+// dbTimeout is the maximum time we wait for the exclusive lock of a database
+// file. If litd still has the database open, the lock can't be obtained.
+const dbTimeout = 5 * time.Second
+
+// Record is a record of a database that can't be decoded.
+type Record struct {
+	// Buckets is the path of nested buckets the record is stored in.
+	Buckets [][]byte
+
+	// Key is the key of the record.
+	Key []byte
+
+	// Err describes why the record can't be decoded.
+	Err error
+}
+
+// Location returns the bucket path and key of the record in a readable form.
+// Names that aren't printable are hex encoded.
+func (r *Record) Location() string {
+	parts := make([]string, 0, len(r.Buckets)+1)
+	for _, bucket := range r.Buckets {
+		parts = append(parts, readable(bucket))
+	}
+	parts = append(parts, readable(r.Key))
+
+	return strings.Join(parts, "/")
+}
+
+// Checker decodes the records of a database and collects the ones that can't
+// be decoded.
+type Checker struct {
+	checked int
+	corrupt []Record
+}
+
+// Check decodes a single record with the given function. If it returns an
+// error or panics, the record is collected as corrupt and false is returned.
+func (c *Checker) Check(buckets [][]byte, key []byte,
+	decode func() error) bool {
+
+	c.checked++
+
+	if err := safeDecode(decode); err != nil {
+		c.Corrupt(buckets, key, err)
+		return false
+	}
+
+	return true
+}
+
+// Corrupt collects a record as corrupt without decoding it, for example if it
+// references another corrupt record.
+func (c *Checker) Corrupt(buckets [][]byte, key []byte, err error) {
+	// The bucket path and the key are only valid during the transaction,
+	// so we need to copy them.
+	path := make([][]byte, len(buckets))
+	for i, bucket := range buckets {
+		path[i] = append([]byte(nil), bucket...)
+	}
+
+	c.corrupt = append(c.corrupt, Record{
+		Buckets: path,
+		Key:     append([]byte(nil), key...),
+		Err:     err,
+	})
+}
+
+// safeDecode calls the given decode function and turns a panic into an
+// error.
+func safeDecode(decode func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("decoding panicked: %v", r)
+		}
+	}()
+
+	return decode()
+}
+
+// CheckFunc decodes all records of a database with the given checker.
+type CheckFunc func(tx *bbolt.Tx, c *Checker) error
+
+// Database is a database and the check of its records.
+type Database struct {
+	// Path is the full path of the database.
+	Path string
+
+	// Check decodes the records of the database.
+	Check CheckFunc
+}
+
+// Result is the report of verifying a database.
+type Result struct {
+	// Path is the full path of the database.
+	Path string
+
+	// Checked is the number of records that were decoded.
+	Checked int
+
+	// Corrupt are the records that can't be decoded.
+	Corrupt []Record
+
+	// Quarantined is true if the corrupt records were moved to the
+	// quarantine bucket.
+	Quarantined bool
+
+	// Skipped is true if the database doesn't exist yet.
+	Skipped bool
+}
+
+// Verify decodes all records of the given database. If quarantine is true,
+// the records that can't be decoded are moved into the quarantine bucket of
+// the database, so they can't make litd fail later on. The database must not
+// be opened by litd while it is verified.
+func Verify(db Database, quarantine bool) (*Result, error) {
+	result := &Result{Path: db.Path}
+	if _, err := os.Stat(db.Path); errors.Is(err, os.ErrNotExist) {
+		result.Skipped = true
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	boltDB, err := bbolt.Open(db.Path, 0600, &bbolt.Options{
+		Timeout:  dbTimeout,
+		ReadOnly: !quarantine,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("unable to obtain the lock of %v, make "+
+			"sure litd is not running", db.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = boltDB.Close()
+	}()
+
+	var checker Checker
+	err = boltDB.View(func(tx *bbolt.Tx) error {
+		return db.Check(tx, &checker)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Checked = checker.checked
+	result.Corrupt = checker.corrupt
+
+	for _, record := range result.Corrupt {
+		log.Warnf("Corrupt record %v in %v: %v", record.Location(),
+			db.Path, record.Err)
+	}
+
+	if !quarantine || len(result.Corrupt) == 0 {
+		return result, nil
+	}
+
+	err = boltDB.Update(func(tx *bbolt.Tx) error {
+		for _, record := range result.Corrupt {
+			if err := quarantineRecord(tx, &record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to quarantine corrupt records "+
+			"of %v: %v", db.Path, err)
+	}
+	result.Quarantined = true
+
+	log.Warnf("Moved %d corrupt records of %v to the quarantine bucket",
+		len(result.Corrupt), db.Path)
+
+	return result, nil
+}
+
+// VerifyAll verifies the given databases one after another. It stops at the
+// first database that can't be verified.
+func VerifyAll(dbs []Database, quarantine bool) ([]*Result, error) {
+	results := make([]*Result, 0, len(dbs))
+	for _, db := range dbs {
+		result, err := Verify(db, quarantine)
+		if err != nil {
+			return results, fmt.Errorf("unable to verify %v: %v",
+				db.Path, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// readable returns the given name as a string if it's printable and hex
+// encoded otherwise.
+func readable(name []byte) string {
+	for _, r := range string(name) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) ||
+			r == '/' {
+
+			return fmt.Sprintf("%x", name)
+		}
+	}
+
+	return string(name)
+}
@@ -0,0 +1,45 @@
+package dbverify
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+// TestChecker tests that decoding errors and panics are collected as corrupt
+// records.
+func TestChecker(t *testing.T) {
+	var c Checker
+	path := [][]byte{[]byte("bucket")}
+
+	require.True(t, c.Check(path, []byte("ok"), func() error {
+		return nil
+	}))
+	require.False(t, c.Check(path, []byte("error"), func() error {
+		return errors.New("invalid")
+	}))
+	require.False(t, c.Check(path, []byte{0, 1}, func() error {
+		panic("out of range")
+	}))
+
+	require.Equal(t, 3, c.checked)
+	require.Len(t, c.corrupt, 2)
+	require.Equal(t, "bucket/error", c.corrupt[0].Location())
+	require.Equal(t, "bucket/0001", c.corrupt[1].Location())
+	require.ErrorContains(t, c.corrupt[1].Err, "panicked: out of range")
+}
+
+// TestVerifyMissing tests that databases that don't exist yet are skipped.
+func TestVerifyMissing(t *testing.T) {
+	result, err := Verify(Database{
+		Path: filepath.Join(t.TempDir(), "missing.db"),
+		Check: func(*bbolt.Tx, *Checker) error {
+			return errors.New("unexpected check")
+		},
+	}, true)
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+}
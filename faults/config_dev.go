@@ -0,0 +1,55 @@
+//go:build dev
+// +build dev
+
+package faults
+
+import "time"
+
+// Config holds the fault injection options. They are only available in
+// development builds.
+type Config struct {
+	InterceptorDelays   []string `long:"interceptordelay" description:"Delay a stage of an RPC middleware interceptor, given as <interceptor>:<stage>:<duration>, for example lit-macaroon-firewall:request:5s. The stage is one of stream_auth, request or response, * matches all interceptors or stages. Can be specified multiple times."`
+	InterceptorFailures []string `long:"interceptorfailure" description:"Reject the messages of a stage of an RPC middleware interceptor, given as <interceptor>:<stage>. Can be specified multiple times."`
+
+	LNCDropRate float64 `long:"lncdroprate" description:"The fraction of the frames sent to LNC clients that are silently dropped, between 0 and 1."`
+
+	SubserverCrashes []string `long:"subservercrash" description:"Simulate a crash of an integrated subserver the given time after it was started, given as <subserver>:<duration>, for example loop:1m. The subserver is one of faraday, loop or pool. Can be specified multiple times."`
+}
+
+// rules parses the configured faults.
+func (c *Config) rules() (*rules, error) {
+	r := &rules{
+		lncDropRate:      c.LNCDropRate,
+		subserverCrashes: make(map[string]time.Duration),
+	}
+
+	for _, value := range c.InterceptorDelays {
+		rule, err := parseInterceptorRule(value, true)
+		if err != nil {
+			return nil, err
+		}
+		r.interceptors = append(r.interceptors, rule)
+	}
+
+	for _, value := range c.InterceptorFailures {
+		rule, err := parseInterceptorRule(value, false)
+		if err != nil {
+			return nil, err
+		}
+		r.interceptors = append(r.interceptors, rule)
+	}
+
+	for _, value := range c.SubserverCrashes {
+		name, delay, err := parseSubserverCrash(value)
+		if err != nil {
+			return nil, err
+		}
+		r.subserverCrashes[name] = delay
+	}
+
+	if r.lncDropRate < 0 || r.lncDropRate > 1 {
+		return nil, errInvalidDropRate
+	}
+
+	return r, nil
+}
@@ -0,0 +1,14 @@
+//go:build !dev
+// +build !dev
+
+package faults
+
+// Config holds the fault injection options. They are only available in
+// development builds, so this config is empty.
+type Config struct{}
+
+// rules returns an empty set of faults, as faults can only be injected in
+// development builds.
+func (c *Config) rules() (*rules, error) {
+	return &rules{}, nil
+}
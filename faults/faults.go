@@ -0,0 +1,309 @@
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	// StageStreamAuth is the stage in which an interceptor authenticates a
+	// new stream.
+	StageStreamAuth = "stream_auth"
+
+	// StageRequest is the stage in which an interceptor checks a request.
+	StageRequest = "request"
+
+	// StageResponse is the stage in which an interceptor checks a response.
+	StageResponse = "response"
+
+	// wildcard matches all interceptors or stages.
+	wildcard = "*"
+)
+
+var (
+	// ErrInjected is the reason of the messages that are rejected by an
+	// injected interceptor failure.
+	ErrInjected = errors.New("injected fault")
+
+	// errInvalidDropRate is returned if the LNC drop rate is not a
+	// fraction.
+	errInvalidDropRate = errors.New("the LNC drop rate must be between " +
+		"0 and 1")
+
+	// stages are the valid interceptor stages.
+	stages = map[string]struct{}{
+		StageStreamAuth: {},
+		StageRequest:    {},
+		StageResponse:   {},
+		wildcard:        {},
+	}
+
+	// subservers are the integrated subservers whose crash can be
+	// simulated.
+	subservers = map[string]struct{}{
+		"faraday": {},
+		"loop":    {},
+		"pool":    {},
+	}
+)
+
+// interceptorRule describes a fault that is injected into a stage of an
+// interceptor.
+type interceptorRule struct {
+	interceptor string
+	stage       string
+
+	// delay is the time the stage is delayed by. If it is zero, the
+	// messages of the stage are rejected instead.
+	delay time.Duration
+}
+
+// matches returns true if the rule applies to the given interceptor and
+// stage.
+func (r *interceptorRule) matches(interceptor, stage string) bool {
+	return (r.interceptor == wildcard || r.interceptor == interceptor) &&
+		(r.stage == wildcard || r.stage == stage)
+}
+
+// rules are the parsed faults that are injected.
+type rules struct {
+	interceptors     []*interceptorRule
+	lncDropRate      float64
+	subserverCrashes map[string]time.Duration
+}
+
+// parseInterceptorRule parses an interceptor fault given as
+// <interceptor>:<stage>, followed by :<duration> if it is a delay.
+func parseInterceptorRule(value string, delay bool) (*interceptorRule,
+	error) {
+
+	parts := strings.Split(value, ":")
+	if (delay && len(parts) != 3) || (!delay && len(parts) != 2) {
+		return nil, fmt.Errorf("invalid interceptor fault %q", value)
+	}
+
+	if _, ok := stages[parts[1]]; !ok {
+		return nil, fmt.Errorf("invalid interceptor stage %q", parts[1])
+	}
+
+	rule := &interceptorRule{
+		interceptor: parts[0],
+		stage:       parts[1],
+	}
+	if !delay {
+		return rule, nil
+	}
+
+	var err error
+	rule.delay, err = time.ParseDuration(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid interceptor delay %q: %v",
+			parts[2], err)
+	}
+	if rule.delay <= 0 {
+		return nil, fmt.Errorf("the interceptor delay must be positive")
+	}
+
+	return rule, nil
+}
+
+// parseSubserverCrash parses a subserver crash given as
+// <subserver>:<duration>.
+func parseSubserverCrash(value string) (string, time.Duration, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("invalid subserver crash %q", value)
+	}
+
+	if _, ok := subservers[parts[0]]; !ok {
+		return "", 0, fmt.Errorf("invalid subserver %q", parts[0])
+	}
+
+	delay, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid subserver crash delay %q: %v",
+			parts[1], err)
+	}
+
+	return parts[0], delay, nil
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	_, err := c.rules()
+	return err
+}
+
+// Injector injects the configured faults into litd's components, so that
+// their failure handling can be tested.
+type Injector struct {
+	rules *rules
+
+	// crashes receives the names of the subservers whose crash is due.
+	crashes chan string
+
+	// randMtx guards the random source the dropped LNC frames are picked
+	// with.
+	randMtx sync.Mutex
+	rand    *rand.Rand
+}
+
+// NewInjector creates a new injector for the faults of the given config.
+func NewInjector(cfg *Config) (*Injector, error) {
+	r, err := cfg.rules()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Injector{
+		rules:   r,
+		crashes: make(chan string, len(subservers)),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// WrapInterceptor wraps the given interceptor so the configured faults are
+// injected into its stages.
+func (i *Injector) WrapInterceptor(
+	interceptor mid.RequestInterceptor) mid.RequestInterceptor {
+
+	var matching []*interceptorRule
+	for _, rule := range i.rules.interceptors {
+		if rule.interceptor == wildcard ||
+			rule.interceptor == interceptor.Name() {
+
+			matching = append(matching, rule)
+		}
+	}
+
+	if len(matching) == 0 {
+		return interceptor
+	}
+
+	log.Warnf("Injecting faults into interceptor %s", interceptor.Name())
+
+	return &faultyInterceptor{
+		RequestInterceptor: interceptor,
+		rules:              matching,
+	}
+}
+
+// WrapLNCConn wraps the given LNC connection so that the configured fraction
+// of the frames sent over it is dropped.
+func (i *Injector) WrapLNCConn(conn mailbox.ProxyConn) mailbox.ProxyConn {
+	if i.rules.lncDropRate == 0 {
+		return conn
+	}
+
+	return &droppingConn{
+		ProxyConn: conn,
+		drop: func() bool {
+			i.randMtx.Lock()
+			defer i.randMtx.Unlock()
+
+			return i.rand.Float64() < i.rules.lncDropRate
+		},
+	}
+}
+
+// SubserverStarted schedules the crash of the integrated subserver with the
+// given name if one is configured.
+func (i *Injector) SubserverStarted(name string) {
+	delay, ok := i.rules.subserverCrashes[name]
+	if !ok {
+		return
+	}
+
+	log.Warnf("Simulating crash of subserver %s in %v", name, delay)
+
+	time.AfterFunc(delay, func() {
+		i.crashes <- name
+	})
+}
+
+// SubserverCrashes returns a channel that receives the names of the
+// subservers whose simulated crash is due.
+func (i *Injector) SubserverCrashes() <-chan string {
+	return i.crashes
+}
+
+// faultyInterceptor is an interceptor that injects faults into the stages of
+// the interceptor it wraps.
+type faultyInterceptor struct {
+	mid.RequestInterceptor
+
+	rules []*interceptorRule
+}
+
+// Intercept delays or rejects the message if a rule matches its stage and
+// otherwise passes it on to the wrapped interceptor.
+//
+// NOTE: This is part of the rpcmiddleware.RequestInterceptor interface.
+func (f *faultyInterceptor) Intercept(ctx context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	var stage string
+	switch req.InterceptType.(type) {
+	case *lnrpc.RPCMiddlewareRequest_StreamAuth:
+		stage = StageStreamAuth
+
+	case *lnrpc.RPCMiddlewareRequest_Request:
+		stage = StageRequest
+
+	case *lnrpc.RPCMiddlewareRequest_Response:
+		stage = StageResponse
+	}
+
+	for _, rule := range f.rules {
+		if !rule.matches(f.Name(), stage) {
+			continue
+		}
+
+		if rule.delay == 0 {
+			log.Debugf("Rejecting %s message %d in interceptor %s",
+				stage, req.MsgId, f.Name())
+
+			return mid.RPCErr(req, ErrInjected)
+		}
+
+		log.Debugf("Delaying %s message %d in interceptor %s by %v",
+			stage, req.MsgId, f.Name(), rule.delay)
+
+		select {
+		case <-time.After(rule.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return f.RequestInterceptor.Intercept(ctx, req)
+}
+
+// droppingConn is an LNC connection that silently drops some of the frames
+// that are written to it.
+type droppingConn struct {
+	mailbox.ProxyConn
+
+	drop func() bool
+}
+
+// Write writes the frame to the underlying connection unless it is dropped.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *droppingConn) Write(b []byte) (int, error) {
+	if c.drop() {
+		log.Debugf("Dropping LNC frame of %d bytes", len(b))
+		return len(b), nil
+	}
+
+	return c.ProxyConn.Write(b)
+}
@@ -0,0 +1,183 @@
+package faults
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockInterceptor is an interceptor that counts the messages it receives.
+type mockInterceptor struct {
+	calls int
+}
+
+func (m *mockInterceptor) Name() string {
+	return "test-interceptor"
+}
+
+func (m *mockInterceptor) ReadOnly() bool {
+	return true
+}
+
+func (m *mockInterceptor) CustomCaveatName() string {
+	return ""
+}
+
+func (m *mockInterceptor) Intercept(_ context.Context,
+	_ *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	m.calls++
+	return &lnrpc.RPCMiddlewareResponse{}, nil
+}
+
+// mockConn is an LNC connection that records the frames written to it.
+type mockConn struct {
+	mailbox.ProxyConn
+
+	written [][]byte
+}
+
+func (m *mockConn) Write(b []byte) (int, error) {
+	m.written = append(m.written, b)
+	return len(b), nil
+}
+
+// TestParseInterceptorRule tests the parsing of the interceptor faults.
+func TestParseInterceptorRule(t *testing.T) {
+	rule, err := parseInterceptorRule("lit-privacy-mapper:request:2s", true)
+	require.NoError(t, err)
+	require.Equal(t, &interceptorRule{
+		interceptor: "lit-privacy-mapper",
+		stage:       StageRequest,
+		delay:       2 * time.Second,
+	}, rule)
+
+	rule, err = parseInterceptorRule("*:*", false)
+	require.NoError(t, err)
+	require.True(t, rule.matches("lit-privacy-mapper", StageResponse))
+
+	_, err = parseInterceptorRule("lit-privacy-mapper:request", true)
+	require.Error(t, err)
+
+	_, err = parseInterceptorRule("lit-privacy-mapper:other", false)
+	require.Error(t, err)
+
+	_, err = parseInterceptorRule("lit-privacy-mapper:request:-1s", true)
+	require.Error(t, err)
+
+	name, delay, err := parseSubserverCrash("loop:1m")
+	require.NoError(t, err)
+	require.Equal(t, "loop", name)
+	require.Equal(t, time.Minute, delay)
+
+	_, _, err = parseSubserverCrash("lnd:1m")
+	require.Error(t, err)
+}
+
+// TestWrapInterceptor tests that the messages of the configured stages are
+// delayed or rejected and that all other messages are passed on.
+func TestWrapInterceptor(t *testing.T) {
+	injector := &Injector{
+		rules: &rules{
+			interceptors: []*interceptorRule{{
+				interceptor: "test-interceptor",
+				stage:       StageRequest,
+			}, {
+				interceptor: wildcard,
+				stage:       StageResponse,
+				delay:       50 * time.Millisecond,
+			}, {
+				interceptor: "other-interceptor",
+				stage:       StageStreamAuth,
+			}},
+		},
+	}
+
+	interceptor := &mockInterceptor{}
+	wrapped := injector.WrapInterceptor(interceptor)
+	require.Equal(t, "test-interceptor", wrapped.Name())
+
+	ctx := context.Background()
+
+	// Requests are rejected without reaching the interceptor.
+	resp, err := wrapped.Intercept(ctx, &lnrpc.RPCMiddlewareRequest{
+		MsgId:         1,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Request{},
+	})
+	require.NoError(t, err)
+	require.Contains(
+		t, resp.GetFeedback().Error, ErrInjected.Error(),
+	)
+	require.Zero(t, interceptor.calls)
+
+	// Responses are delayed.
+	start := time.Now()
+	_, err = wrapped.Intercept(ctx, &lnrpc.RPCMiddlewareRequest{
+		MsgId:         2,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Response{},
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	require.Equal(t, 1, interceptor.calls)
+
+	// The rule of the other interceptor doesn't apply.
+	_, err = wrapped.Intercept(ctx, &lnrpc.RPCMiddlewareRequest{
+		MsgId:         3,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_StreamAuth{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, interceptor.calls)
+
+	// Interceptors without matching rules aren't wrapped at all.
+	injector.rules.interceptors = nil
+	require.Equal(t, interceptor, injector.WrapInterceptor(interceptor))
+}
+
+// TestWrapLNCConn tests that LNC frames are dropped at the configured rate.
+func TestWrapLNCConn(t *testing.T) {
+	injector, err := NewInjector(&Config{})
+	require.NoError(t, err)
+
+	conn := &mockConn{}
+	require.Equal(t, conn, injector.WrapLNCConn(conn))
+
+	injector.rules.lncDropRate = 1
+	wrapped := injector.WrapLNCConn(conn)
+
+	n, err := wrapped.Write([]byte("frame"))
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Empty(t, conn.written)
+}
+
+// TestSubserverCrashes tests that the crash of a subserver is signaled once
+// its delay passed.
+func TestSubserverCrashes(t *testing.T) {
+	injector, err := NewInjector(&Config{})
+	require.NoError(t, err)
+
+	injector.rules.subserverCrashes = map[string]time.Duration{
+		"loop": 10 * time.Millisecond,
+	}
+	injector.SubserverStarted("pool")
+	injector.SubserverStarted("loop")
+
+	select {
+	case name := <-injector.SubserverCrashes():
+		require.Equal(t, "loop", name)
+
+	case <-time.After(time.Second):
+		t.Fatalf("no crash signaled")
+	}
+
+	select {
+	case name := <-injector.SubserverCrashes():
+		t.Fatalf("unexpected crash of %s", name)
+
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,36 @@
+package feesched
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultInterval is the default interval in which the fee policies
+	// are evaluated.
+	defaultInterval = time.Minute
+)
+
+// Config holds all config options for the fee policy scheduler.
+type Config struct {
+	Interval   time.Duration `long:"interval" description:"The interval in which the fee policies are evaluated and applied to the channels."`
+	DryRun     bool          `long:"dryrun" description:"Only log the channel policy updates the fee policies would result in without applying them."`
+	RuleBundle string        `long:"rulebundle" description:"The name of the firewall rule bundle whose rules are enforced on all scheduled channel policy updates. If empty, the updates are only logged."`
+}
+
+// DefaultConfig constructs the default fee scheduler Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		Interval: defaultInterval,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.Interval < time.Second {
+		return fmt.Errorf("the fee scheduler interval must be at least " +
+			"one second")
+	}
+
+	return nil
+}
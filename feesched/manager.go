@@ -0,0 +1,403 @@
+package feesched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/nodemgmt"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FeatureName is the name of the feature the scheduled channel policy
+	// updates are logged with in the actions DB.
+	FeatureName = "fee-scheduler"
+
+	// actorName is the name of the actor the scheduled channel policy
+	// updates are logged with in the actions DB.
+	actorName = "fee-scheduler"
+
+	uriUpdateChannelPolicy = "/lnrpc.Lightning/UpdateChannelPolicy"
+)
+
+// ErrNotStarted is returned if the fee scheduler is used before it was
+// started.
+var ErrNotStarted = errors.New("fee scheduler not started")
+
+// Guard checks an action against the firewall rules and performs it if all
+// rules pass.
+type Guard interface {
+	// DoWithMeta checks the given request against the rules and, if they
+	// pass, calls perform with the possibly rewritten request. If perform
+	// is nil, the action is only logged as a dry run.
+	DoWithMeta(ctx context.Context, actor, trigger, intent, uri string,
+		req proto.Message, perform func(proto.Message) error) error
+}
+
+// Update is a channel policy update that results from a fee policy.
+type Update struct {
+	// ChannelPoint is the channel the update is for.
+	ChannelPoint string
+
+	// PolicyName is the name of the fee policy that caused the update.
+	PolicyName string
+
+	// OldBaseFeeMsat and OldFeeRatePpm are the fees of the channel before
+	// the update.
+	OldBaseFeeMsat int64
+	OldFeeRatePpm  uint32
+
+	// BaseFeeMsat, FeeRatePpm and TimeLockDelta are the values the
+	// channel policy is updated to.
+	BaseFeeMsat   int64
+	FeeRatePpm    uint32
+	TimeLockDelta uint32
+
+	// DryRun is true if the update was only logged but not applied.
+	DryRun bool
+
+	// Err is set if the update was rejected by the firewall rules or
+	// failed to be applied.
+	Err error
+}
+
+// Manager applies the time-based fee policies to the channels of the node.
+// All channel policy updates are passed through the firewall guard, which
+// logs them to the actions DB.
+type Manager struct {
+	cfg *Config
+	dir string
+
+	store  *Store
+	lnd    lnrpc.LightningClient
+	guard  Guard
+	nodeID string
+
+	// mu serializes the evaluation of the policies so a scheduled and a
+	// manual run can't apply the same update twice. It also guards
+	// lastDryRuns.
+	mu sync.Mutex
+
+	// lastDryRuns holds the last update of each channel that was logged
+	// by a scheduled dry run. As dry runs don't change the channels, the
+	// same update would otherwise be logged again in every run.
+	lastDryRuns map[string]Update
+
+	started atomic.Bool
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewManager creates a new fee scheduler that stores its policies in the
+// given directory.
+func NewManager(cfg *Config, dir string) *Manager {
+	return &Manager{
+		cfg:         cfg,
+		dir:         dir,
+		lastDryRuns: make(map[string]Update),
+		quit:        make(chan struct{}),
+	}
+}
+
+// Start opens the policy store and starts applying the policies in the
+// configured interval.
+func (m *Manager) Start(lnd lnrpc.LightningClient, guard Guard) error {
+	ctx := context.Background()
+	info, err := lnd.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to get node info: %v", err)
+	}
+
+	store, err := NewStore(m.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open fee policy store: %v", err)
+	}
+
+	m.store = store
+	m.lnd = lnd
+	m.guard = guard
+	m.nodeID = info.IdentityPubkey
+	m.started.Store(true)
+
+	m.wg.Add(1)
+	go m.run()
+
+	return nil
+}
+
+// Stop stops applying the policies and closes the policy store.
+func (m *Manager) Stop() error {
+	if !m.started.Load() {
+		return nil
+	}
+	m.started.Store(false)
+
+	close(m.quit)
+	m.wg.Wait()
+
+	return m.store.Close()
+}
+
+// run applies the policies in the configured interval until the manager is
+// stopped.
+func (m *Manager) run() {
+	defer m.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-m.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-m.quit:
+			return
+		}
+
+		updates, err := m.apply(ctx, time.Now(), m.cfg.DryRun, true)
+		if err != nil {
+			log.Errorf("Unable to apply fee policies: %v", err)
+		}
+
+		for _, u := range updates {
+			if u.Err != nil {
+				log.Errorf("Unable to apply fee policy %s to "+
+					"channel %s: %v", u.PolicyName,
+					u.ChannelPoint, u.Err)
+
+				continue
+			}
+
+			log.Infof("Fee policy %s set channel %s to base_fee_msat=%d, "+
+				"fee_rate_ppm=%d (dry_run=%v)", u.PolicyName,
+				u.ChannelPoint, u.BaseFeeMsat, u.FeeRatePpm,
+				u.DryRun)
+		}
+	}
+}
+
+// SetPolicy validates and stores the given policy. It is applied in the next
+// run.
+func (m *Manager) SetPolicy(p *Policy) error {
+	if !m.started.Load() {
+		return ErrNotStarted
+	}
+
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	return m.store.SetPolicy(p)
+}
+
+// Policies returns all stored policies.
+func (m *Manager) Policies() ([]*Policy, error) {
+	if !m.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	return m.store.Policies()
+}
+
+// RemovePolicy removes the policy with the given name. The channels keep the
+// fees the policy set until another policy becomes active for them.
+func (m *Manager) RemovePolicy(name string) error {
+	if !m.started.Load() {
+		return ErrNotStarted
+	}
+
+	return m.store.RemovePolicy(name)
+}
+
+// Apply sets the fees of all channels that are targeted by a policy that is
+// active at the given time. Channels that already have the fees of their
+// policy are skipped. If dryRun is true, the updates are only logged to the
+// actions DB but not applied.
+func (m *Manager) Apply(ctx context.Context, now time.Time,
+	dryRun bool) ([]*Update, error) {
+
+	return m.apply(ctx, now, dryRun, false)
+}
+
+// apply applies the policies that are active at the given time. Scheduled dry
+// runs skip updates that were already logged by the previous dry run.
+func (m *Manager) apply(ctx context.Context, now time.Time, dryRun,
+	scheduled bool) ([]*Update, error) {
+
+	if !m.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	policies, err := m.store.Policies()
+	if err != nil {
+		return nil, err
+	}
+
+	var active []*Policy
+	for _, p := range policies {
+		if p.ActiveAt(now) {
+			active = append(active, p)
+		}
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+
+	channels, err := m.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list channels: %v", err)
+	}
+
+	var updates []*Update
+	for _, channel := range channels.Channels {
+		var policy *Policy
+		for _, p := range active {
+			if p.Targets(channel.ChannelPoint, channel.RemotePubkey) {
+				policy = p
+				break
+			}
+		}
+		if policy == nil {
+			continue
+		}
+
+		update, err := m.applyPolicy(
+			ctx, policy, channel, dryRun, scheduled,
+		)
+		if err != nil {
+			log.Warnf("Skipping fee policy %s for channel %s: %v",
+				policy.Name, channel.ChannelPoint, err)
+
+			continue
+		}
+		if update != nil {
+			updates = append(updates, update)
+		}
+	}
+
+	return updates, nil
+}
+
+// applyPolicy sets the fees of the given channel to those of the policy if
+// they differ. A nil update is returned if the channel is already up to date.
+func (m *Manager) applyPolicy(ctx context.Context, policy *Policy,
+	channel *lnrpc.Channel, dryRun, scheduled bool) (*Update, error) {
+
+	edge, err := m.lnd.GetChanInfo(ctx, &lnrpc.ChanInfoRequest{
+		ChanId: channel.ChanId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get channel info: %v", err)
+	}
+
+	current := edge.Node1Policy
+	if edge.Node2Pub == m.nodeID {
+		current = edge.Node2Policy
+	}
+
+	// Our policy isn't known yet if the channel was just opened, so we
+	// try again in the next run.
+	if current == nil {
+		return nil, nil
+	}
+
+	timeLockDelta := policy.TimeLockDelta
+	if timeLockDelta == 0 {
+		timeLockDelta = current.TimeLockDelta
+	}
+
+	if current.FeeBaseMsat == policy.BaseFeeMsat &&
+		current.FeeRateMilliMsat == int64(policy.FeeRatePpm) &&
+		current.TimeLockDelta == timeLockDelta {
+
+		return nil, nil
+	}
+
+	chanPoint, err := nodemgmt.ParseChanPoint(channel.ChannelPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &Update{
+		ChannelPoint:   channel.ChannelPoint,
+		PolicyName:     policy.Name,
+		OldBaseFeeMsat: current.FeeBaseMsat,
+		OldFeeRatePpm:  uint32(current.FeeRateMilliMsat),
+		BaseFeeMsat:    policy.BaseFeeMsat,
+		FeeRatePpm:     policy.FeeRatePpm,
+		TimeLockDelta:  timeLockDelta,
+		DryRun:         dryRun,
+	}
+
+	if scheduled && dryRun {
+		last, ok := m.lastDryRuns[channel.ChannelPoint]
+		if ok && last == *update {
+			return nil, nil
+		}
+
+		m.lastDryRuns[channel.ChannelPoint] = *update
+	}
+
+	req := &lnrpc.PolicyUpdateRequest{
+		Scope: &lnrpc.PolicyUpdateRequest_ChanPoint{
+			ChanPoint: chanPoint,
+		},
+		BaseFeeMsat:   policy.BaseFeeMsat,
+		FeeRatePpm:    policy.FeeRatePpm,
+		TimeLockDelta: timeLockDelta,
+	}
+
+	var perform func(proto.Message) error
+	if !dryRun {
+		perform = func(msg proto.Message) error {
+			req, ok := msg.(*lnrpc.PolicyUpdateRequest)
+			if !ok {
+				return fmt.Errorf("unexpected request %T", msg)
+			}
+
+			resp, err := m.lnd.UpdateChannelPolicy(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			if len(resp.FailedUpdates) > 0 {
+				return errors.New(
+					resp.FailedUpdates[0].UpdateError,
+				)
+			}
+
+			return nil
+		}
+	}
+
+	trigger := fmt.Sprintf("fee policy %s", policy.Name)
+	intent := fmt.Sprintf("change fees of channel %s from %d msat + %d "+
+		"ppm to %d msat + %d ppm", channel.ChannelPoint,
+		current.FeeBaseMsat, current.FeeRateMilliMsat,
+		policy.BaseFeeMsat, policy.FeeRatePpm)
+
+	update.Err = m.guard.DoWithMeta(
+		ctx, actorName, trigger, intent, uriUpdateChannelPolicy, req,
+		perform,
+	)
+
+	return update, nil
+}
@@ -0,0 +1,310 @@
+package feesched
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	testNodeID = testPubKey(1)
+	testPeer1  = testPubKey(2)
+	testPeer2  = testPubKey(3)
+
+	errRuleViolation = errors.New("rule violation")
+)
+
+const (
+	testChan1 = "097ef666a61919ff3413b3b701eae3a5cbac08f70c0ca567806e1fa6" +
+		"acbfe384:0"
+	testChan2 = "097ef666a61919ff3413b3b701eae3a5cbac08f70c0ca567806e1fa6" +
+		"acbfe384:1"
+)
+
+type mockLnd struct {
+	lnrpc.LightningClient
+
+	channels []*lnrpc.Channel
+	policies map[uint64]*lnrpc.RoutingPolicy
+
+	policyReqs []*lnrpc.PolicyUpdateRequest
+}
+
+func newMockLnd() *mockLnd {
+	return &mockLnd{
+		channels: []*lnrpc.Channel{{
+			ChanId:       1,
+			ChannelPoint: testChan1,
+			RemotePubkey: testPeer1,
+		}, {
+			ChanId:       2,
+			ChannelPoint: testChan2,
+			RemotePubkey: testPeer2,
+		}},
+		policies: map[uint64]*lnrpc.RoutingPolicy{
+			1: {FeeBaseMsat: 1000, FeeRateMilliMsat: 100,
+				TimeLockDelta: 80},
+			2: {FeeBaseMsat: 1000, FeeRateMilliMsat: 100,
+				TimeLockDelta: 80},
+		},
+	}
+}
+
+func (m *mockLnd) GetInfo(context.Context, *lnrpc.GetInfoRequest,
+	...grpc.CallOption) (*lnrpc.GetInfoResponse, error) {
+
+	return &lnrpc.GetInfoResponse{IdentityPubkey: testNodeID}, nil
+}
+
+func (m *mockLnd) ListChannels(context.Context, *lnrpc.ListChannelsRequest,
+	...grpc.CallOption) (*lnrpc.ListChannelsResponse, error) {
+
+	return &lnrpc.ListChannelsResponse{Channels: m.channels}, nil
+}
+
+func (m *mockLnd) GetChanInfo(_ context.Context, req *lnrpc.ChanInfoRequest,
+	_ ...grpc.CallOption) (*lnrpc.ChannelEdge, error) {
+
+	// We are always node 2, so the policy of node 1 is a decoy.
+	return &lnrpc.ChannelEdge{
+		ChannelId:   req.ChanId,
+		Node1Pub:    testPeer1,
+		Node2Pub:    testNodeID,
+		Node1Policy: &lnrpc.RoutingPolicy{FeeBaseMsat: 7},
+		Node2Policy: proto.Clone(
+			m.policies[req.ChanId],
+		).(*lnrpc.RoutingPolicy),
+	}, nil
+}
+
+func (m *mockLnd) UpdateChannelPolicy(_ context.Context,
+	req *lnrpc.PolicyUpdateRequest,
+	_ ...grpc.CallOption) (*lnrpc.PolicyUpdateResponse, error) {
+
+	m.policyReqs = append(m.policyReqs, req)
+
+	chanPoint := fmt.Sprintf("%s:%d", req.GetChanPoint().GetFundingTxidStr(),
+		req.GetChanPoint().OutputIndex)
+	for _, channel := range m.channels {
+		if channel.ChannelPoint != chanPoint {
+			continue
+		}
+
+		m.policies[channel.ChanId] = &lnrpc.RoutingPolicy{
+			FeeBaseMsat:      req.BaseFeeMsat,
+			FeeRateMilliMsat: int64(req.FeeRatePpm),
+			TimeLockDelta:    req.TimeLockDelta,
+		}
+	}
+
+	return &lnrpc.PolicyUpdateResponse{}, nil
+}
+
+// mockGuard rejects requests with a base fee above maxBaseFee and records the
+// intents of all actions.
+type mockGuard struct {
+	maxBaseFee int64
+
+	intents []string
+	dryRuns int
+}
+
+func (m *mockGuard) DoWithMeta(_ context.Context, _, _, intent, _ string,
+	req proto.Message, perform func(proto.Message) error) error {
+
+	policyReq := req.(*lnrpc.PolicyUpdateRequest)
+	if policyReq.BaseFeeMsat > m.maxBaseFee {
+		return errRuleViolation
+	}
+
+	m.intents = append(m.intents, intent)
+
+	if perform == nil {
+		m.dryRuns++
+		return nil
+	}
+
+	return perform(req)
+}
+
+// testPubKey returns a hex encoded public key derived from the given seed.
+func testPubKey(seed byte) string {
+	_, pubKey := btcec.PrivKeyFromBytes([]byte{seed})
+
+	return hex.EncodeToString(pubKey.SerializeCompressed())
+}
+
+// TestPolicyActiveAt tests the time windows of the policies.
+func TestPolicyActiveAt(t *testing.T) {
+	// 2023-01-02 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2023, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		active []time.Time
+		idle   []time.Time
+	}{{
+		name:   "all day",
+		policy: Policy{StartMinute: 600, EndMinute: 600},
+		active: []time.Time{at(2, 0, 0), at(2, 23, 59)},
+	}, {
+		name:   "daytime",
+		policy: Policy{StartMinute: 6 * 60, EndMinute: 22 * 60},
+		active: []time.Time{at(2, 6, 0), at(2, 21, 59)},
+		idle:   []time.Time{at(2, 5, 59), at(2, 22, 0)},
+	}, {
+		name:   "over midnight",
+		policy: Policy{StartMinute: 22 * 60, EndMinute: 6 * 60},
+		active: []time.Time{at(2, 22, 0), at(3, 0, 0), at(3, 5, 59)},
+		idle:   []time.Time{at(2, 21, 59), at(3, 6, 0)},
+	}, {
+		name: "monday night",
+		policy: Policy{
+			StartMinute: 22 * 60,
+			EndMinute:   6 * 60,
+			Weekdays:    []time.Weekday{time.Monday},
+		},
+		active: []time.Time{at(2, 23, 0), at(3, 1, 0)},
+		idle:   []time.Time{at(2, 1, 0), at(3, 23, 0)},
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			for _, ts := range test.active {
+				require.True(t, test.policy.ActiveAt(ts), ts)
+			}
+			for _, ts := range test.idle {
+				require.False(t, test.policy.ActiveAt(ts), ts)
+			}
+		})
+	}
+
+	minute, err := ParseTimeOfDay("22:30")
+	require.NoError(t, err)
+	require.EqualValues(t, 22*60+30, minute)
+	require.Equal(t, "22:30", FormatTimeOfDay(minute))
+
+	_, err = ParseTimeOfDay("24:00")
+	require.Error(t, err)
+}
+
+// TestApply tests that the active policies are applied to the channels they
+// target and that dry runs leave the channels untouched.
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	lnd := newMockLnd()
+	guard := &mockGuard{maxBaseFee: 5000}
+
+	m := NewManager(&Config{Interval: time.Hour}, t.TempDir())
+	_, err := m.Apply(ctx, time.Now(), false)
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, m.Start(lnd, guard))
+	t.Cleanup(func() {
+		require.NoError(t, m.Stop())
+	})
+
+	night := time.Date(2023, 1, 2, 23, 0, 0, 0, time.UTC)
+	day := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, m.SetPolicy(&Policy{
+		Name:        "night",
+		StartMinute: 22 * 60,
+		EndMinute:   6 * 60,
+		BaseFeeMsat: 0,
+		FeeRatePpm:  10,
+	}))
+	require.NoError(t, m.SetPolicy(&Policy{
+		Name:          "night-peer2",
+		Peers:         []string{testPeer2},
+		StartMinute:   22 * 60,
+		EndMinute:     6 * 60,
+		BaseFeeMsat:   500,
+		FeeRatePpm:    50,
+		TimeLockDelta: 40,
+		Priority:      1,
+	}))
+	require.NoError(t, m.SetPolicy(&Policy{
+		Name:        "day",
+		StartMinute: 6 * 60,
+		EndMinute:   22 * 60,
+		BaseFeeMsat: 10000,
+		FeeRatePpm:  100,
+	}))
+
+	// Invalid policies are rejected.
+	require.Error(t, m.SetPolicy(&Policy{
+		Name:  "invalid",
+		Peers: []string{"xyz"},
+	}))
+
+	policies, err := m.Policies()
+	require.NoError(t, err)
+	require.Len(t, policies, 3)
+	require.Equal(t, "night-peer2", policies[0].Name)
+
+	// A dry run only logs the updates.
+	updates, err := m.Apply(ctx, night, true)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	require.Equal(t, 2, guard.dryRuns)
+	require.Empty(t, lnd.policyReqs)
+	require.Contains(t, guard.intents[0], "from 1000 msat + 100 ppm to "+
+		"0 msat + 10 ppm")
+
+	// The policy with the higher priority wins for the second channel.
+	updates, err = m.Apply(ctx, night, false)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	require.Equal(t, "night", updates[0].PolicyName)
+	require.EqualValues(t, 1000, updates[0].OldBaseFeeMsat)
+	require.EqualValues(t, 80, updates[0].TimeLockDelta)
+	require.Equal(t, "night-peer2", updates[1].PolicyName)
+	require.EqualValues(t, 40, updates[1].TimeLockDelta)
+	require.Len(t, lnd.policyReqs, 2)
+
+	// Channels that already have the fees of their policy are skipped.
+	updates, err = m.Apply(ctx, night, false)
+	require.NoError(t, err)
+	require.Empty(t, updates)
+
+	// The day policy is rejected by the guard, which is reported per
+	// update without touching the channels.
+	updates, err = m.Apply(ctx, day, false)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	for _, u := range updates {
+		require.ErrorIs(t, u.Err, errRuleViolation)
+	}
+	require.Len(t, lnd.policyReqs, 2)
+
+	// Scheduled dry runs don't log the same update twice.
+	require.NoError(t, m.RemovePolicy("day"))
+	require.NoError(t, m.SetPolicy(&Policy{
+		Name:        "day",
+		StartMinute: 6 * 60,
+		EndMinute:   22 * 60,
+		BaseFeeMsat: 2000,
+	}))
+	for i := 0; i < 2; i++ {
+		updates, err = m.apply(ctx, day, true, true)
+		require.NoError(t, err)
+	}
+	require.Empty(t, updates)
+	require.Equal(t, 4, guard.dryRuns)
+
+	require.ErrorIs(t, m.RemovePolicy("unknown"), ErrPolicyNotFound)
+}
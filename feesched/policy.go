@@ -0,0 +1,181 @@
+package feesched
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/nodemgmt"
+)
+
+const (
+	// minutesPerDay is the number of minutes in a day.
+	minutesPerDay = 24 * 60
+)
+
+// Policy is a time-based fee policy. While it is active, the routing fees of
+// all channels it targets are set to the fees of the policy.
+type Policy struct {
+	// Name is the unique name of the policy.
+	Name string `json:"name"`
+
+	// ChannelPoints are the channels the policy applies to, in the form
+	// txid:output_index.
+	ChannelPoints []string `json:"channel_points,omitempty"`
+
+	// Peers are the hex encoded public keys of the peers to whose
+	// channels the policy applies. If neither ChannelPoints nor Peers is
+	// set, the policy applies to all channels.
+	Peers []string `json:"peers,omitempty"`
+
+	// StartMinute is the minute of the day in UTC at which the policy
+	// becomes active.
+	StartMinute uint32 `json:"start_minute"`
+
+	// EndMinute is the minute of the day in UTC at which the policy
+	// becomes inactive again. If it is before StartMinute, the policy is
+	// active over midnight. If it equals StartMinute, the policy is active
+	// all day.
+	EndMinute uint32 `json:"end_minute"`
+
+	// Weekdays are the days on which the policy is active. For policies
+	// that are active over midnight, the day the active period starts on
+	// counts. If empty, the policy is active every day.
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+
+	// BaseFeeMsat is the base fee the policy sets.
+	BaseFeeMsat int64 `json:"base_fee_msat"`
+
+	// FeeRatePpm is the fee rate in parts per million the policy sets.
+	FeeRatePpm uint32 `json:"fee_rate_ppm"`
+
+	// TimeLockDelta is the CLTV delta the policy sets. If zero, the CLTV
+	// delta of the channels is left unchanged.
+	TimeLockDelta uint32 `json:"time_lock_delta"`
+
+	// Priority decides which policy is applied to a channel if several
+	// active policies target it. The policy with the highest priority
+	// wins, ties are broken by name.
+	Priority int32 `json:"priority"`
+}
+
+// Validate makes sure the policy is sane.
+func (p *Policy) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("policy name must be set")
+	}
+
+	if p.StartMinute >= minutesPerDay || p.EndMinute >= minutesPerDay {
+		return fmt.Errorf("invalid time of day")
+	}
+
+	for _, day := range p.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", day)
+		}
+	}
+
+	for _, chanPoint := range p.ChannelPoints {
+		if _, err := nodemgmt.ParseChanPoint(chanPoint); err != nil {
+			return err
+		}
+	}
+
+	for _, peer := range p.Peers {
+		pubKey, err := hex.DecodeString(peer)
+		if err != nil {
+			return fmt.Errorf("invalid peer %s: %v", peer, err)
+		}
+
+		if _, err := btcec.ParsePubKey(pubKey); err != nil {
+			return fmt.Errorf("invalid peer %s: %v", peer, err)
+		}
+	}
+
+	if p.BaseFeeMsat < 0 {
+		return fmt.Errorf("base fee must not be negative")
+	}
+
+	return nil
+}
+
+// ActiveAt returns true if the policy is active at the given time.
+func (p *Policy) ActiveAt(t time.Time) bool {
+	t = t.UTC()
+	minute := uint32(t.Hour()*60 + t.Minute())
+	day := t.Weekday()
+
+	switch {
+	// The policy is active all day.
+	case p.StartMinute == p.EndMinute:
+
+	case p.StartMinute < p.EndMinute:
+		if minute < p.StartMinute || minute >= p.EndMinute {
+			return false
+		}
+
+	// The policy is active over midnight and the active period started
+	// today.
+	case minute >= p.StartMinute:
+
+	// The policy is active over midnight and the active period started
+	// yesterday.
+	case minute < p.EndMinute:
+		day = (day + 6) % 7
+
+	default:
+		return false
+	}
+
+	if len(p.Weekdays) == 0 {
+		return true
+	}
+
+	for _, d := range p.Weekdays {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Targets returns true if the policy applies to the channel with the given
+// channel point and remote peer.
+func (p *Policy) Targets(chanPoint, peer string) bool {
+	if len(p.ChannelPoints) == 0 && len(p.Peers) == 0 {
+		return true
+	}
+
+	for _, c := range p.ChannelPoints {
+		if c == chanPoint {
+			return true
+		}
+	}
+
+	for _, c := range p.Peers {
+		if c == peer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseTimeOfDay parses a time of day in the form HH:MM and returns the
+// minute of the day.
+func ParseTimeOfDay(s string) (uint32, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %s, expected HH:MM",
+			s)
+	}
+
+	return uint32(t.Hour()*60 + t.Minute()), nil
+}
+
+// FormatTimeOfDay formats the given minute of the day in the form HH:MM.
+func FormatTimeOfDay(minute uint32) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
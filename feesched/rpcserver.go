@@ -0,0 +1,172 @@
+package feesched
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the FeeScheduler gRPC
+// interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedFeeSchedulerServer
+
+	manager *Manager
+}
+
+// NewRPCServer returns a new RPC server for the given fee scheduler.
+func NewRPCServer(manager *Manager) *RPCServer {
+	return &RPCServer{
+		manager: manager,
+	}
+}
+
+// SetFeePolicy creates a fee policy or replaces the policy with the same name.
+func (s *RPCServer) SetFeePolicy(_ context.Context,
+	req *litrpc.SetFeePolicyRequest) (*litrpc.SetFeePolicyResponse, error) {
+
+	if req.Policy == nil {
+		return nil, fmt.Errorf("policy must be set")
+	}
+
+	log.Infof("[setfeepolicy] name=%s, start_time=%s, end_time=%s, "+
+		"base_fee_msat=%d, fee_rate_ppm=%d", req.Policy.Name,
+		req.Policy.StartTime, req.Policy.EndTime,
+		req.Policy.BaseFeeMsat, req.Policy.FeeRatePpm)
+
+	policy, err := unmarshalPolicy(req.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.manager.SetPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.SetFeePolicyResponse{}, nil
+}
+
+// ListFeePolicies lists all fee policies.
+func (s *RPCServer) ListFeePolicies(_ context.Context,
+	_ *litrpc.ListFeePoliciesRequest) (*litrpc.ListFeePoliciesResponse,
+	error) {
+
+	log.Info("[listfeepolicies]")
+
+	policies, err := s.manager.Policies()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListFeePoliciesResponse{
+		Policies: make([]*litrpc.FeePolicy, len(policies)),
+	}
+	for i, policy := range policies {
+		resp.Policies[i] = marshalPolicy(policy)
+	}
+
+	return resp, nil
+}
+
+// RemoveFeePolicy removes a fee policy.
+func (s *RPCServer) RemoveFeePolicy(_ context.Context,
+	req *litrpc.RemoveFeePolicyRequest) (*litrpc.RemoveFeePolicyResponse,
+	error) {
+
+	log.Infof("[removefeepolicy] name=%s", req.Name)
+
+	if err := s.manager.RemovePolicy(req.Name); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RemoveFeePolicyResponse{}, nil
+}
+
+// ApplyFeePolicies applies the currently active fee policies right away.
+func (s *RPCServer) ApplyFeePolicies(ctx context.Context,
+	req *litrpc.ApplyFeePoliciesRequest) (*litrpc.ApplyFeePoliciesResponse,
+	error) {
+
+	log.Infof("[applyfeepolicies] dry_run=%v", req.DryRun)
+
+	updates, err := s.manager.Apply(ctx, time.Now(), req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ApplyFeePoliciesResponse{
+		Updates: make([]*litrpc.FeePolicyUpdate, len(updates)),
+	}
+	for i, u := range updates {
+		resp.Updates[i] = &litrpc.FeePolicyUpdate{
+			ChannelPoint:   u.ChannelPoint,
+			PolicyName:     u.PolicyName,
+			OldBaseFeeMsat: u.OldBaseFeeMsat,
+			OldFeeRatePpm:  u.OldFeeRatePpm,
+			BaseFeeMsat:    u.BaseFeeMsat,
+			FeeRatePpm:     u.FeeRatePpm,
+			TimeLockDelta:  u.TimeLockDelta,
+			DryRun:         u.DryRun,
+		}
+		if u.Err != nil {
+			resp.Updates[i].Error = u.Err.Error()
+		}
+	}
+
+	return resp, nil
+}
+
+// marshalPolicy converts a fee policy into its RPC counterpart.
+func marshalPolicy(p *Policy) *litrpc.FeePolicy {
+	weekdays := make([]uint32, len(p.Weekdays))
+	for i, day := range p.Weekdays {
+		weekdays[i] = uint32(day)
+	}
+
+	return &litrpc.FeePolicy{
+		Name:          p.Name,
+		ChannelPoints: p.ChannelPoints,
+		Peers:         p.Peers,
+		StartTime:     FormatTimeOfDay(p.StartMinute),
+		EndTime:       FormatTimeOfDay(p.EndMinute),
+		Weekdays:      weekdays,
+		BaseFeeMsat:   p.BaseFeeMsat,
+		FeeRatePpm:    p.FeeRatePpm,
+		TimeLockDelta: p.TimeLockDelta,
+		Priority:      p.Priority,
+	}
+}
+
+// unmarshalPolicy converts an RPC fee policy into a Policy.
+func unmarshalPolicy(p *litrpc.FeePolicy) (*Policy, error) {
+	start, err := ParseTimeOfDay(p.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := ParseTimeOfDay(p.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	weekdays := make([]time.Weekday, len(p.Weekdays))
+	for i, day := range p.Weekdays {
+		weekdays[i] = time.Weekday(day)
+	}
+
+	return &Policy{
+		Name:          p.Name,
+		ChannelPoints: p.ChannelPoints,
+		Peers:         p.Peers,
+		StartMinute:   start,
+		EndMinute:     end,
+		Weekdays:      weekdays,
+		BaseFeeMsat:   p.BaseFeeMsat,
+		FeeRatePpm:    p.FeeRatePpm,
+		TimeLockDelta: p.TimeLockDelta,
+		Priority:      p.Priority,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package feesched
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the fee policy database.
+	DBFilename = "feesched.db"
+
+	// dbFilePermission is the default permission the fee policy database
+	// file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The fee policies are stored in the following structure in the db:
+
+	policies -> policy name -> json encoded Policy
+*/
+
+var (
+	// policiesBucketKey is the key of the top level bucket holding all
+	// fee policies.
+	policiesBucketKey = []byte("policies")
+
+	// ErrPolicyNotFound is returned when a fee policy with the given name
+	// does not exist in the db.
+	ErrPolicyNotFound = errors.New("fee policy not found")
+)
+
+// Store is a bolt-backed persistent store of the fee policies.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the fee policy store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(policiesBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// SetPolicy stores the given policy, replacing any policy with the same name.
+func (s *Store) SetPolicy(p *Policy) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(policiesBucketKey).Put([]byte(p.Name), b)
+	})
+}
+
+// Policies returns all policies sorted by descending priority and then by
+// name, which is the order in which they are matched against the channels.
+func (s *Store) Policies() ([]*Policy, error) {
+	var policies []*Policy
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(policiesBucketKey)
+
+		return bucket.ForEach(func(_, b []byte) error {
+			var p Policy
+			if err := json.Unmarshal(b, &p); err != nil {
+				return err
+			}
+
+			policies = append(policies, &p)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Priority != policies[j].Priority {
+			return policies[i].Priority > policies[j].Priority
+		}
+
+		return policies[i].Name < policies[j].Name
+	})
+
+	return policies, nil
+}
+
+// RemovePolicy removes the policy with the given name. If no such policy
+// exists, ErrPolicyNotFound is returned.
+func (s *Store) RemovePolicy(name string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(policiesBucketKey)
+
+		if bucket.Get([]byte(name)) == nil {
+			return ErrPolicyNotFound
+		}
+
+		return bucket.Delete([]byte(name))
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
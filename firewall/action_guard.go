@@ -0,0 +1,223 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/rules"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/protobuf-hex-display/jsonpb"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// ActionGuardDB is the subset of the firewall DB the ActionGuard uses to log
+// actions and to give rules access to their stores.
+type ActionGuardDB interface {
+	firewalldb.RulesDB
+	firewalldb.ActionReadDBGetter
+	firewalldb.ActionsWriteDB
+}
+
+// ActionGuardConfig holds all the dependencies of an ActionGuard.
+type ActionGuardConfig struct {
+	// FeatureName is the name of the feature the guarded actions are
+	// logged with. The rules only consider past actions of this feature.
+	FeatureName string
+
+	// RuleBundle is the name of the rule bundle whose rules are enforced
+	// on all guarded actions. If it is empty, no rules are enforced but
+	// the actions are still logged.
+	RuleBundle string
+
+	DB          ActionGuardDB
+	RuleMgrs    rules.ManagerSet
+	RuleBundles ruleBundleGetter
+	PermsMgr    *perms.Manager
+
+	NodeID          [33]byte
+	RouterClient    lndclient.RouterClient
+	LndClient       lndclient.LightningClient
+	WalletKitClient walletrpc.WalletKitClient
+	ChainParams     *chaincfg.Params
+}
+
+// ActionGuard enforces the firewall rules of a rule bundle on actions that
+// LiT performs directly, independent of the macaroon the action was requested
+// with. This lets operators restrict actions that are triggered through the
+// UI or with an admin macaroon the same way as actions of a session.
+type ActionGuard struct {
+	cfg *ActionGuardConfig
+
+	// mu serializes the guarded actions so that concurrent actions can't
+	// both pass a rule that takes previous actions into account.
+	mu sync.Mutex
+}
+
+// NewActionGuard creates a new ActionGuard.
+func NewActionGuard(cfg *ActionGuardConfig) *ActionGuard {
+	return &ActionGuard{
+		cfg: cfg,
+	}
+}
+
+// Do checks the given request against all rules of the configured rule bundle
+// and, if all rules pass, calls perform with the possibly rewritten request.
+// The action is logged to the actions DB so that rules like the rate limit
+// can take previous actions into account.
+func (g *ActionGuard) Do(ctx context.Context, actor, uri string,
+	req proto.Message, perform func(proto.Message) error) error {
+
+	return g.DoWithMeta(ctx, actor, "", "", uri, req, perform)
+}
+
+// DoWithMeta is like Do but additionally logs what triggered the action and
+// what its intended outcome is. If perform is nil, the action is only checked
+// against the rules and logged as a dry run.
+func (g *ActionGuard) DoWithMeta(ctx context.Context, actor, trigger, intent,
+	uri string, req proto.Message, perform func(proto.Message) error) error {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	enforcers, err := g.enforcers()
+	if err != nil {
+		return fmt.Errorf("error collecting rules: %v", err)
+	}
+
+	for _, enforcer := range enforcers {
+		newReq, err := enforcer.HandleRequest(ctx, uri, req)
+		if err != nil {
+			return enforcer.violation(err)
+		}
+
+		if newReq != nil {
+			req = newReq
+		}
+	}
+
+	jsonMarshaler := &jsonpb.Marshaler{
+		EmitDefaults: true,
+		OrigName:     true,
+	}
+	jsonStr, err := jsonMarshaler.MarshalToString(protov1.MessageV1(req))
+	if err != nil {
+		return fmt.Errorf("unable to encode request: %v", err)
+	}
+
+	// Actions that are not performed by a session are stored under the
+	// empty session ID.
+	var sessionID session.ID
+	actionID, err := g.cfg.DB.AddAction(sessionID, &firewalldb.Action{
+		ActorName:     actor,
+		FeatureName:   g.cfg.FeatureName,
+		Trigger:       trigger,
+		Intent:        intent,
+		RPCMethod:     uri,
+		RPCParamsJson: []byte(jsonStr),
+		AttemptedAt:   time.Now(),
+		State:         firewalldb.ActionStateInit,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to log action: %v", err)
+	}
+
+	locator := &firewalldb.ActionLocator{
+		SessionID: sessionID,
+		ActionID:  actionID,
+	}
+
+	if perform == nil {
+		err = g.cfg.DB.SetActionState(
+			locator, firewalldb.ActionStateDryRun, "",
+		)
+		if err != nil {
+			log.Errorf("Unable to update state of action %d: %v",
+				actionID, err)
+		}
+
+		return nil
+	}
+
+	performErr := perform(req)
+	if performErr != nil {
+		err = g.cfg.DB.SetActionState(
+			locator, firewalldb.ActionStateError, performErr.Error(),
+		)
+	} else {
+		err = g.cfg.DB.SetActionState(
+			locator, firewalldb.ActionStateDone, "",
+		)
+	}
+	if err != nil {
+		log.Errorf("Unable to update state of action %d: %v", actionID,
+			err)
+	}
+
+	return performErr
+}
+
+// enforcers initialises the enforcers of all rules of the configured rule
+// bundle.
+func (g *ActionGuard) enforcers() ([]*namedEnforcer, error) {
+	if g.cfg.RuleBundle == "" {
+		return nil, nil
+	}
+
+	bundle, err := g.cfg.RuleBundles.GetBundle(g.cfg.RuleBundle)
+	if errors.Is(err, firewalldb.ErrRuleBundleNotFound) {
+		// Failing closed makes sure a typo in the bundle name or a
+		// removed bundle doesn't silently lift all restrictions.
+		return nil, fmt.Errorf("rule bundle %s does not exist",
+			g.cfg.RuleBundle)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var sessionID session.ID
+	actionsDB := g.cfg.DB.GetActionsReadDB(
+		sessionID, g.cfg.FeatureName,
+	).FeatureActionsDB()
+
+	enforcers := make([]*namedEnforcer, 0, len(bundle.Rules))
+	for name, values := range bundle.Rules {
+		cfg := &rules.ConfigImpl{
+			Stores: g.cfg.DB.GetKVStores(
+				name, sessionID, g.cfg.FeatureName,
+			),
+			ActionsDB:       actionsDB,
+			MethodPerms:     g.cfg.PermsMgr.URIPermissions,
+			NodeID:          g.cfg.NodeID,
+			RouterClient:    g.cfg.RouterClient,
+			LndClient:       g.cfg.LndClient,
+			WalletKitClient: g.cfg.WalletKitClient,
+			ChainParams:     g.cfg.ChainParams,
+		}
+
+		enforcer, err := g.cfg.RuleMgrs.InitEnforcer(cfg, name, values)
+		if err != nil {
+			return nil, err
+		}
+
+		valueBytes, err := rules.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+
+		enforcers = append(enforcers, &namedEnforcer{
+			Enforcer: enforcer,
+			name:     name,
+			values:   string(valueBytes),
+		})
+	}
+
+	return enforcers, nil
+}
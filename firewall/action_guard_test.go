@@ -0,0 +1,144 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/rules"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testFeature = "node-management"
+	testBundle  = "operator"
+	testURI     = "/lnrpc.Lightning/UpdateChannelPolicy"
+)
+
+// TestActionGuard tests that the ActionGuard enforces the rules of the
+// configured bundle and logs the guarded actions.
+func TestActionGuard(t *testing.T) {
+	db, err := firewalldb.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	permsMgr, err := perms.NewManager(false)
+	require.NoError(t, err)
+
+	ruleMgrs := rules.NewRuleManagerSet()
+	bundles := rules.NewBundleStore(db, ruleMgrs)
+	require.NoError(t, bundles.SetBundle(&rules.Bundle{
+		Name: testBundle,
+		Rules: map[string]rules.Values{
+			rules.ChanPolicyBoundsName: &rules.ChanPolicyBounds{
+				MinBaseMsat:  0,
+				MaxBaseMsat:  1000,
+				MinRatePPM:   0,
+				MaxRatePPM:   1000,
+				MinCLTVDelta: 18,
+				MaxCLTVDelta: 100,
+				MinHtlcMsat:  0,
+				MaxHtlcMsat:  100_000_000,
+			},
+			rules.RateLimitName: &rules.RateLimit{
+				WriteLimit: &rules.Rate{
+					Iterations: 2,
+					NumHours:   1,
+				},
+				ReadLimit: &rules.Rate{
+					Iterations: 10,
+					NumHours:   1,
+				},
+			},
+		},
+	}))
+
+	guard := NewActionGuard(&ActionGuardConfig{
+		FeatureName: testFeature,
+		RuleBundle:  testBundle,
+		DB:          db,
+		RuleMgrs:    ruleMgrs,
+		RuleBundles: bundles,
+		PermsMgr:    permsMgr,
+	})
+
+	ctx := context.Background()
+	newReq := func(baseFee int64) *lnrpc.PolicyUpdateRequest {
+		return &lnrpc.PolicyUpdateRequest{
+			Scope:         &lnrpc.PolicyUpdateRequest_Global{},
+			BaseFeeMsat:   baseFee,
+			FeeRatePpm:    100,
+			TimeLockDelta: 40,
+			MaxHtlcMsat:   1000,
+		}
+	}
+
+	var performed int
+	perform := func(proto.Message) error {
+		performed++
+		return nil
+	}
+
+	// A request that violates the policy bounds isn't performed.
+	err = guard.Do(ctx, "ui", testURI, newReq(2000), perform)
+	require.ErrorContains(t, err, "rule violation")
+	require.Zero(t, performed)
+
+	// The error carries the violated rule so clients don't need to parse
+	// the message.
+	detail := litrpc.ErrorDetailFromError(err)
+	require.NotNil(t, detail)
+	require.Equal(t, litrpc.ErrorCode_ERROR_RULE_VIOLATION, detail.Code)
+	require.Equal(
+		t, rules.ChanPolicyBoundsName, detail.GetRuleViolation().Rule,
+	)
+	require.NotEmpty(t, detail.GetRuleViolation().Limit)
+
+	// A failed action is logged but doesn't count towards the rate limit.
+	err = guard.Do(ctx, "ui", testURI, newReq(500), func(proto.Message) error {
+		return errors.New("lnd failed")
+	})
+	require.ErrorContains(t, err, "lnd failed")
+
+	// A dry run is checked and logged but not performed, so it doesn't
+	// count towards the rate limit either.
+	err = guard.DoWithMeta(
+		ctx, "ui", "schedule", "lower fees", testURI, newReq(500), nil,
+	)
+	require.NoError(t, err)
+
+	// The rate limit allows two successful write actions.
+	require.NoError(t, guard.Do(ctx, "ui", testURI, newReq(500), perform))
+	require.NoError(t, guard.Do(ctx, "ui", testURI, newReq(500), perform))
+	require.Equal(t, 2, performed)
+
+	err = guard.Do(ctx, "ui", testURI, newReq(500), perform)
+	require.ErrorContains(t, err, "too many requests")
+	require.Equal(t, 2, performed)
+
+	actions, _, _, err := db.ListActions(nil, &firewalldb.ListActionsQuery{})
+	require.NoError(t, err)
+	require.Len(t, actions, 4)
+	require.Equal(t, firewalldb.ActionStateError, actions[0].State)
+	require.Equal(t, "lnd failed", actions[0].ErrorReason)
+	require.Equal(t, firewalldb.ActionStateDryRun, actions[1].State)
+	require.Equal(t, "schedule", actions[1].Trigger)
+	require.Equal(t, "lower fees", actions[1].Intent)
+	for _, action := range actions {
+		require.Equal(t, testFeature, action.FeatureName)
+		require.Equal(t, "ui", action.ActorName)
+		require.Equal(t, testURI, action.RPCMethod)
+	}
+
+	// A guard that follows a bundle that doesn't exist fails closed.
+	guard.cfg.RuleBundle = "unknown"
+	err = guard.Do(ctx, "ui", testURI, newReq(500), perform)
+	require.ErrorContains(t, err, "does not exist")
+}
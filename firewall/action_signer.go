@@ -0,0 +1,101 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+type ActionSigning string
+
+const (
+	// ActionSigningNone disables the signing of the action log.
+	ActionSigningNone = "none"
+
+	// ActionSigningNode signs the action log with the node's identity
+	// key.
+	ActionSigningNode = "node"
+
+	// ActionSigningAudit signs the action log with a dedicated audit key
+	// of lnd's wallet.
+	ActionSigningAudit = "audit"
+
+	// AuditKeyFamily is the key family of lnd's wallet that the dedicated
+	// audit key is derived from. It is not used by lnd itself.
+	AuditKeyFamily keychain.KeyFamily = 220
+
+	// signTimeout is the maximum time we wait for lnd to sign a digest.
+	signTimeout = 10 * time.Second
+)
+
+// A compile-time assertion that lndActionSigner is a firewalldb.ActionSigner.
+var _ firewalldb.ActionSigner = (*lndActionSigner)(nil)
+
+// lndActionSigner is a firewalldb.ActionSigner that uses lnd's signer RPC to
+// sign the action log with a key of lnd's wallet.
+type lndActionSigner struct {
+	signer  lndclient.SignerClient
+	locator keychain.KeyLocator
+	pubKey  *btcec.PublicKey
+}
+
+// NewActionSigner creates an action log signer for the given signing mode.
+// If signing is disabled, nil is returned.
+func NewActionSigner(ctx context.Context, mode ActionSigning,
+	signer lndclient.SignerClient,
+	walletKit lndclient.WalletKitClient) (firewalldb.ActionSigner, error) {
+
+	var locator keychain.KeyLocator
+	switch mode {
+	case ActionSigningNone, "":
+		return nil, nil
+
+	case ActionSigningNode:
+		locator = keychain.KeyLocator{
+			Family: keychain.KeyFamilyNodeKey,
+		}
+
+	case ActionSigningAudit:
+		locator = keychain.KeyLocator{
+			Family: AuditKeyFamily,
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown action signing mode: %s. "+
+			"Expected either 'none', 'node' or 'audit'", mode)
+	}
+
+	keyDesc, err := walletKit.DeriveKey(ctx, &locator)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving action signing key: %w",
+			err)
+	}
+
+	return &lndActionSigner{
+		signer:  signer,
+		locator: locator,
+		pubKey:  keyDesc.PubKey,
+	}, nil
+}
+
+// PubKey returns the public key that the signatures can be verified with.
+//
+// NOTE: this is part of the firewalldb.ActionSigner interface.
+func (s *lndActionSigner) PubKey() *btcec.PublicKey {
+	return s.pubKey
+}
+
+// SignDigest signs the given digest with lnd's SignMessage RPC.
+//
+// NOTE: this is part of the firewalldb.ActionSigner interface.
+func (s *lndActionSigner) SignDigest(digest [32]byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	return s.signer.SignMessage(ctx, digest[:], s.locator)
+}
@@ -0,0 +1,145 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// CostRecorderName is the name of the CostRecorder interceptor.
+	CostRecorderName = "lit-macaroon-firewall-billing"
+)
+
+var (
+	// A compile-time assertion that CostRecorder is a
+	// rpcmiddleware.RequestInterceptor.
+	_ mid.RequestInterceptor = (*CostRecorder)(nil)
+)
+
+// CostRecorder is a RequestInterceptor that records the number and the cost
+// of the RPC calls made with the macaroon of a session or an account, so that
+// the usage of API consumers can be billed. Calls made with other macaroons
+// are not recorded.
+type CostRecorder struct {
+	billingDB firewalldb.BillingDB
+
+	// costs maps the full URI of a method to the cost of a call to it.
+	costs map[string]uint64
+
+	// defaultCost is the cost of a call to a method that isn't in the
+	// costs map.
+	defaultCost uint64
+}
+
+// NewCostRecorder creates a new CostRecorder.
+func NewCostRecorder(cfg *BillingConfig,
+	billingDB firewalldb.BillingDB) (*CostRecorder, error) {
+
+	costs, err := cfg.CostTable()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CostRecorder{
+		billingDB:   billingDB,
+		costs:       costs,
+		defaultCost: cfg.DefaultCost,
+	}, nil
+}
+
+// Name returns the name of the interceptor.
+func (r *CostRecorder) Name() string {
+	return CostRecorderName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+func (r *CostRecorder) ReadOnly() bool {
+	return true
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (r *CostRecorder) CustomCaveatName() string {
+	return ""
+}
+
+// Intercept processes an RPC middleware interception request and returns the
+// interception result which either accepts or rejects the intercepted message.
+func (r *CostRecorder) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	ri, err := NewInfoFromRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing incoming RPC middleware "+
+			"interception request: %v", err)
+	}
+
+	// Only the requests are billed. For streaming RPCs, every message
+	// the client sends counts as a call.
+	if ri.MWRequestType != MWRequestTypeRequest || uriSkipList[ri.URI] {
+		return mid.RPCOk(req)
+	}
+
+	consumer, ok, err := consumerFromMacaroon(ri.Macaroon)
+	if err != nil {
+		return mid.RPCErr(req, err)
+	}
+	if !ok {
+		return mid.RPCOk(req)
+	}
+
+	cost, ok := r.costs[ri.URI]
+	if !ok {
+		cost = r.defaultCost
+	}
+
+	log.Tracef("CostRecorder: Recording call to %s with cost %d",
+		ri.URI, cost)
+
+	return mid.RPCErr(
+		req, r.billingDB.AddUsage(consumer, ri.URI, cost, time.Now()),
+	)
+}
+
+// consumerFromMacaroon returns the consumer a call made with the given
+// macaroon is billed to. Calls made with an account macaroon are billed to
+// the account, calls made with a super macaroon to its session. False is
+// returned for all other macaroons.
+func consumerFromMacaroon(mac *macaroon.Macaroon) (firewalldb.Consumer, bool,
+	error) {
+
+	if mac == nil {
+		return firewalldb.Consumer{}, false, nil
+	}
+
+	accountID, err := accounts.AccountFromMacaroon(mac)
+	if err != nil {
+		return firewalldb.Consumer{}, false, fmt.Errorf("unable to "+
+			"parse account ID: %v", err)
+	}
+	if accountID != nil {
+		return firewalldb.Consumer{
+			Type: firewalldb.ConsumerAccount,
+			ID:   accountID[:],
+		}, true, nil
+	}
+
+	sessionID, ok := session.SuperMacaroonID(mac)
+	if !ok {
+		return firewalldb.Consumer{}, false, nil
+	}
+
+	return firewalldb.Consumer{
+		Type: firewalldb.ConsumerSession,
+		ID:   sessionID[:],
+	}, true, nil
+}
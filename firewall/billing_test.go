@@ -0,0 +1,140 @@
+package firewall
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+// testMacaroon creates a serialized macaroon with the given root key ID and
+// caveats.
+func testMacaroon(t *testing.T, rootKeyID uint64, caveats ...string) []byte {
+	idProto, err := proto.Marshal(&lnrpc.MacaroonId{
+		StorageId: []byte(strconv.FormatUint(rootKeyID, 10)),
+	})
+	require.NoError(t, err)
+
+	rawID := append([]byte{byte(bakery.LatestVersion)}, idProto...)
+	mac, err := macaroon.New([]byte("root key"), rawID, "", macaroon.V2)
+	require.NoError(t, err)
+
+	for _, caveat := range caveats {
+		require.NoError(t, mac.AddFirstPartyCaveat([]byte(caveat)))
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	return macBytes
+}
+
+// TestCostRecorder tests that the calls made through sessions and accounts
+// are recorded with their configured cost.
+func TestCostRecorder(t *testing.T) {
+	t.Parallel()
+
+	db, err := firewalldb.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	const (
+		getInfo = "/lnrpc.Lightning/GetInfo"
+		sendPay = "/lnrpc.Lightning/SendPaymentSync"
+	)
+
+	_, err = NewCostRecorder(&BillingConfig{
+		Costs: []string{"GetInfo=1"},
+	}, db)
+	require.Error(t, err)
+
+	recorder, err := NewCostRecorder(&BillingConfig{
+		DefaultCost: 1,
+		Costs:       []string{sendPay + "=10"},
+	}, db)
+	require.NoError(t, err)
+
+	var (
+		sessionID = session.ID{1, 2, 3, 4}
+		accountID = accounts.AccountID{1, 2, 3, 4, 5, 6, 7, 8}
+
+		sessionMac = testMacaroon(
+			t, session.NewSuperMacaroonRootKeyID(sessionID),
+		)
+		accountMac = testMacaroon(
+			t, 0, fmt.Sprintf("%s %s %s", macaroons.CondLndCustom,
+				accounts.CondAccount,
+				hex.EncodeToString(accountID[:])),
+		)
+		adminMac = testMacaroon(t, 0)
+	)
+
+	intercept := func(mac []byte, uri string, resp bool) {
+		msg := &lnrpc.RPCMessage{
+			MethodFullUri: uri,
+		}
+		req := &lnrpc.RPCMiddlewareRequest{
+			RawMacaroon: mac,
+			InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+				Request: msg,
+			},
+		}
+		if resp {
+			response := &lnrpc.RPCMiddlewareRequest_Response{
+				Response: msg,
+			}
+			req.InterceptType = response
+		}
+
+		res, err := recorder.Intercept(context.Background(), req)
+		require.NoError(t, err)
+		require.Empty(t, res.GetFeedback().GetError())
+	}
+
+	// Only the requests made with session and account macaroons are
+	// recorded, not the responses or the calls of other macaroons.
+	intercept(sessionMac, getInfo, false)
+	intercept(sessionMac, getInfo, true)
+	intercept(sessionMac, sendPay, false)
+	intercept(accountMac, sendPay, false)
+	intercept(adminMac, getInfo, false)
+	intercept(nil, getInfo, false)
+
+	now := time.Now()
+	report, err := db.UsageReport(
+		now, now.Add(time.Hour), firewalldb.BillingPeriodDay,
+	)
+	require.NoError(t, err)
+	require.Len(t, report, 3)
+
+	require.Equal(t, firewalldb.Consumer{
+		Type: firewalldb.ConsumerSession,
+		ID:   sessionID[:],
+	}, report[0].Consumer)
+	require.Equal(t, getInfo, report[0].RPCMethod)
+	require.EqualValues(t, 1, report[0].Calls)
+	require.EqualValues(t, 1, report[0].Cost)
+
+	require.Equal(t, sendPay, report[1].RPCMethod)
+	require.EqualValues(t, 10, report[1].Cost)
+
+	require.Equal(t, firewalldb.Consumer{
+		Type: firewalldb.ConsumerAccount,
+		ID:   accountID[:],
+	}, report[2].Consumer)
+	require.EqualValues(t, 10, report[2].Cost)
+}
@@ -131,6 +131,12 @@ type InterceptRules struct {
 	// Feature rules are rules that apply to a specific feature. The map is
 	// feature name to a map of rule name to rule value.
 	FeatureRules map[string]map[string]string `json:"feature_rules"`
+
+	// FeatureBundles maps a feature name to the name of the rule bundle
+	// that the feature's rules were taken from if the feature should
+	// follow any updates made to that bundle. The current bundle values
+	// then take precedence over the values in FeatureRules.
+	FeatureBundles map[string]string `json:"feature_bundles,omitempty"`
 }
 
 // RulesToCaveat encodes a list of rules as a full custom caveat string
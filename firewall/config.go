@@ -1,8 +1,20 @@
 package firewall
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Config holds all config options for the firewall.
 type Config struct {
 	RequestLogger *RequestLoggerConfig `group:"request-logger" namespace:"request-logger" description:"request logger settings"`
+
+	ActionSigning ActionSigning `long:"action-signing" description:"Sign every recorded action and chain it to the previous one so that the action log is tamper-evident. Options include 'none', 'node' (sign with the node's identity key) and 'audit' (sign with a dedicated audit key)"`
+
+	RedactMissionControl bool `long:"redact-mission-control" description:"Strip the mission control and pathfinding data, such as the QueryMissionControl results and the routes of payment HTLC attempts, from the responses to all sessions except admin sessions."`
+
+	Billing *BillingConfig `group:"billing" namespace:"billing" description:"per-request cost accounting settings"`
 }
 
 // RequestLoggerConfig holds all the config options for the request logger.
@@ -10,11 +22,55 @@ type RequestLoggerConfig struct {
 	RequestLoggerLevel RequestLoggerLevel `long:"level" description:"Set the request logger level. Options include 'all', 'full' and 'interceptor''"`
 }
 
+// BillingConfig holds the config options for the per-request cost accounting.
+type BillingConfig struct {
+	Enable bool `long:"enable" description:"Record the number of lnd RPC calls made through sessions and accounts and their cost, so that the usage can be exported for billing."`
+
+	DefaultCost uint64 `long:"defaultcost" description:"The cost of a call to a method that has no cost set with the cost option."`
+
+	Costs []string `long:"cost" description:"The cost of a call to an RPC method in the format <full method URI>=<cost>, for example /lnrpc.Lightning/SendPaymentSync=10. Can be specified multiple times."`
+}
+
+// CostTable parses the configured costs into a map from full method URI to
+// the cost of a call to the method.
+func (c *BillingConfig) CostTable() (map[string]uint64, error) {
+	costs := make(map[string]uint64, len(c.Costs))
+	for _, entry := range c.Costs {
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") {
+			return nil, fmt.Errorf("invalid billing cost '%s', "+
+				"expected <full method URI>=<cost>", entry)
+		}
+
+		cost, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid billing cost '%s': %v",
+				entry, err)
+		}
+
+		if _, ok := costs[parts[0]]; ok {
+			return nil, fmt.Errorf("duplicate billing cost for %s",
+				parts[0])
+		}
+		costs[parts[0]] = cost
+	}
+
+	return costs, nil
+}
+
+// Validate makes sure the billing config is sane.
+func (c *BillingConfig) Validate() error {
+	_, err := c.CostTable()
+	return err
+}
+
 // DefaultConfig constructs the default firewall Config struct.
 func DefaultConfig() *Config {
 	return &Config{
 		RequestLogger: &RequestLoggerConfig{
 			RequestLoggerLevel: RequestLoggerLevelInterceptor,
 		},
+		ActionSigning: ActionSigningNone,
+		Billing:       &BillingConfig{},
 	}
 }
@@ -0,0 +1,95 @@
+package firewall
+
+import (
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// metricsNamespace is the namespace of all Prometheus metrics of the
+	// firewall.
+	metricsNamespace = "lit"
+
+	// firewallSubsystem is the subsystem of the firewall metrics.
+	firewallSubsystem = "firewall"
+
+	// ruleLabel is the label that holds the name of a rule.
+	ruleLabel = "rule"
+)
+
+var (
+	// ruleEvaluationLatency records the time the evaluations of a rule
+	// take.
+	ruleEvaluationLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: firewallSubsystem,
+			Name:      "rule_evaluation_seconds",
+			Help: "The time it takes to check a request against " +
+				"the rule.",
+			Buckets: []float64{
+				.0001, .0005, .001, .005, .01, .05, .1, .5, 1,
+			},
+		}, []string{ruleLabel},
+	)
+
+	// ruleDenials counts the requests that were denied by a rule.
+	ruleDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: firewallSubsystem,
+		Name:      "rule_denials_total",
+		Help:      "The number of requests the rule denied.",
+	}, []string{ruleLabel})
+)
+
+func init() {
+	// The metrics are registered with the default registry, so they're
+	// served by the metrics server of the status monitor and by lnd's
+	// Prometheus exporter in integrated mode, just like the mailbox
+	// metrics.
+	prometheus.MustRegister(ruleEvaluationLatency, ruleDenials)
+}
+
+// ruleEvaluations collects the evaluations of the rules while a request is
+// checked against them.
+type ruleEvaluations []*firewalldb.RuleEvaluation
+
+// add records the evaluation of the given rule that started at the given time
+// and returned the given error.
+func (e *ruleEvaluations) add(rule string, start time.Time, err error) {
+	*e = append(*e, &firewalldb.RuleEvaluation{
+		RuleName: rule,
+		Latency:  time.Since(start),
+		Denied:   err != nil,
+	})
+}
+
+// record updates the Prometheus metrics of the evaluated rules and adds the
+// evaluations to their persisted metrics, if a db is given. Failing to persist
+// them is only logged, as the metrics must never get in the way of a request.
+func (e ruleEvaluations) record(db firewalldb.RuleMetricsDB) {
+	if len(e) == 0 {
+		return
+	}
+
+	for _, eval := range e {
+		ruleEvaluationLatency.WithLabelValues(eval.RuleName).Observe(
+			eval.Latency.Seconds(),
+		)
+		if eval.Denied {
+			ruleDenials.WithLabelValues(eval.RuleName).Inc()
+		}
+	}
+
+	if db == nil {
+		return
+	}
+
+	err := db.AddRuleEvaluations(e, time.Now())
+	if err != nil {
+		log.Errorf("Could not persist rule evaluation metrics: %v",
+			err)
+	}
+}
@@ -0,0 +1,73 @@
+package firewall
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRuleMetricsDB is a firewalldb.RuleMetricsDB that keeps the added rule
+// evaluations in memory.
+type mockRuleMetricsDB struct {
+	evals []*firewalldb.RuleEvaluation
+}
+
+// AddRuleEvaluations adds the given rule evaluations to the mock db.
+func (m *mockRuleMetricsDB) AddRuleEvaluations(
+	evals []*firewalldb.RuleEvaluation, _ time.Time) error {
+
+	m.evals = append(m.evals, evals...)
+
+	return nil
+}
+
+// ListRuleMetrics is not used by the tests.
+func (m *mockRuleMetricsDB) ListRuleMetrics() ([]*firewalldb.RuleMetrics,
+	error) {
+
+	return nil, nil
+}
+
+// TestRuleEvaluations tests that the rule evaluations update the Prometheus
+// metrics of their rules and are passed on to the db.
+func TestRuleEvaluations(t *testing.T) {
+	const (
+		fastRule = "test-fast-rule"
+		slowRule = "test-slow-rule"
+	)
+
+	var evals ruleEvaluations
+	evals.add(fastRule, time.Now(), nil)
+	evals.add(slowRule, time.Now().Add(-time.Second), errors.New("no"))
+	evals.add(fastRule, time.Now(), errors.New("no"))
+
+	require.Len(t, evals, 3)
+	require.GreaterOrEqual(t, evals[1].Latency, time.Second)
+	require.False(t, evals[0].Denied)
+	require.True(t, evals[1].Denied)
+
+	db := &mockRuleMetricsDB{}
+	evals.record(db)
+	require.Equal(t, []*firewalldb.RuleEvaluation(evals), db.evals)
+
+	require.EqualValues(
+		t, 1, testutil.ToFloat64(ruleDenials.WithLabelValues(fastRule)),
+	)
+	require.EqualValues(
+		t, 1, testutil.ToFloat64(ruleDenials.WithLabelValues(slowRule)),
+	)
+
+	// The evaluations are still exported as Prometheus metrics if they
+	// aren't persisted.
+	evals.record(nil)
+	require.EqualValues(
+		t, 2, testutil.ToFloat64(ruleDenials.WithLabelValues(fastRule)),
+	)
+	require.Equal(
+		t, 2, testutil.CollectAndCount(ruleEvaluationLatency),
+	)
+}
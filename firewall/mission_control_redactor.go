@@ -0,0 +1,223 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// missionControlRedactorName is the name of the MissionControlRedactor
+	// interceptor.
+	missionControlRedactorName = "lit-mission-control-redactor"
+
+	// CondRedactMissionControl is the name of the custom caveat that
+	// instructs lnd to send all requests with this caveat to the mission
+	// control redactor.
+	CondRedactMissionControl = "lit-redact-mc"
+)
+
+var (
+	// RedactMissionControlCaveat is the caveat that activates the mission
+	// control redactor for all requests made with a macaroon.
+	RedactMissionControlCaveat = macaroon.Caveat{
+		Id: []byte(fmt.Sprintf("%s %s", macaroons.CondLndCustom,
+			CondRedactMissionControl)),
+	}
+
+	// A compile-time assertion that MissionControlRedactor is a
+	// rpcmiddleware.RequestInterceptor.
+	_ mid.RequestInterceptor = (*MissionControlRedactor)(nil)
+)
+
+// MissionControlRedactor is a RequestInterceptor that strips the mission
+// control and pathfinding data from responses, as it reveals which parts of
+// the network the node has been sending payments through. Responses of RPCs
+// that don't contain any such data are passed through unchanged.
+type MissionControlRedactor struct {
+	checkers map[string]mid.RoundTripChecker
+}
+
+// NewMissionControlRedactor returns a new instance of MissionControlRedactor.
+func NewMissionControlRedactor() *MissionControlRedactor {
+	return &MissionControlRedactor{
+		checkers: missionControlCheckers(),
+	}
+}
+
+// Name returns the name of the interceptor.
+func (r *MissionControlRedactor) Name() string {
+	return missionControlRedactorName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+func (r *MissionControlRedactor) ReadOnly() bool {
+	return false
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (r *MissionControlRedactor) CustomCaveatName() string {
+	return CondRedactMissionControl
+}
+
+// Intercept processes an RPC middleware interception request and returns the
+// interception result which either accepts or rejects the intercepted message.
+func (r *MissionControlRedactor) Intercept(ctx context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	resp, ok := req.InterceptType.(*lnrpc.RPCMiddlewareRequest_Response)
+	if !ok || resp.Response.IsError {
+		return mid.RPCOk(req)
+	}
+
+	checker, ok := r.checkers[resp.Response.MethodFullUri]
+	if !ok {
+		return mid.RPCOk(req)
+	}
+
+	msg, err := mid.ParseProtobuf(
+		resp.Response.TypeName, resp.Response.Serialized,
+	)
+	if err != nil {
+		return mid.RPCErrString(req, "error parsing proto: %v", err)
+	}
+
+	// This is just a sanity check to make sure the implementation for the
+	// checker actually matches the correct response type.
+	if !checker.HandlesResponse(msg.ProtoReflect().Type()) {
+		return mid.RPCErrString(req, "invalid implementation, checker "+
+			"for URI %s does not accept response of type %v",
+			resp.Response.MethodFullUri, msg.ProtoReflect().Type())
+	}
+
+	replacement, err := checker.HandleResponse(ctx, msg)
+	if err != nil {
+		return mid.RPCErr(req, err)
+	}
+
+	if replacement != nil {
+		return mid.RPCReplacement(req, replacement)
+	}
+
+	return mid.RPCOk(req)
+}
+
+// missionControlCheckers returns the checkers for all RPCs whose responses
+// contain mission control or pathfinding data.
+func missionControlCheckers() map[string]mid.RoundTripChecker {
+	paymentRewriter := func(req proto.Message) mid.RoundTripChecker {
+		return mid.NewResponseRewriter(
+			req, &lnrpc.Payment{}, redactPayment,
+			mid.PassThroughErrorHandler,
+		)
+	}
+
+	return map[string]mid.RoundTripChecker{
+		"/routerrpc.Router/QueryMissionControl": mid.NewResponseRewriter(
+			&routerrpc.QueryMissionControlRequest{},
+			&routerrpc.QueryMissionControlResponse{},
+			redactMissionControl, mid.PassThroughErrorHandler,
+		),
+		"/routerrpc.Router/QueryProbability": mid.NewResponseRewriter(
+			&routerrpc.QueryProbabilityRequest{},
+			&routerrpc.QueryProbabilityResponse{},
+			redactProbability, mid.PassThroughErrorHandler,
+		),
+		"/lnrpc.Lightning/ListPayments": mid.NewResponseRewriter(
+			&lnrpc.ListPaymentsRequest{},
+			&lnrpc.ListPaymentsResponse{},
+			redactListPayments, mid.PassThroughErrorHandler,
+		),
+		"/lnrpc.Lightning/SendPaymentSync": mid.NewResponseRewriter(
+			&lnrpc.SendRequest{}, &lnrpc.SendResponse{},
+			redactSendResponse, mid.PassThroughErrorHandler,
+		),
+		"/routerrpc.Router/SendPaymentV2": paymentRewriter(
+			&routerrpc.SendPaymentRequest{},
+		),
+		"/routerrpc.Router/TrackPaymentV2": paymentRewriter(
+			&routerrpc.TrackPaymentRequest{},
+		),
+		"/routerrpc.Router/TrackPayments": paymentRewriter(
+			&routerrpc.TrackPaymentsRequest{},
+		),
+	}
+}
+
+// redactMissionControl replaces the mission control state with an empty one.
+func redactMissionControl(_ context.Context,
+	_ *routerrpc.QueryMissionControlResponse) (proto.Message, error) {
+
+	return &routerrpc.QueryMissionControlResponse{}, nil
+}
+
+// redactProbability replaces the estimated success probability and the
+// history of a node pair with empty values.
+func redactProbability(_ context.Context,
+	_ *routerrpc.QueryProbabilityResponse) (proto.Message, error) {
+
+	return &routerrpc.QueryProbabilityResponse{}, nil
+}
+
+// redactListPayments strips the routes of the HTLC attempts of all payments.
+func redactListPayments(_ context.Context,
+	r *lnrpc.ListPaymentsResponse) (proto.Message, error) {
+
+	payments := make([]*lnrpc.Payment, len(r.Payments))
+	for i, p := range r.Payments {
+		payments[i] = redactHTLCAttempts(p)
+	}
+
+	return &lnrpc.ListPaymentsResponse{
+		Payments:         payments,
+		FirstIndexOffset: r.FirstIndexOffset,
+		LastIndexOffset:  r.LastIndexOffset,
+		TotalNumPayments: r.TotalNumPayments,
+	}, nil
+}
+
+// redactPayment strips the routes of the HTLC attempts of a streamed payment
+// update.
+func redactPayment(_ context.Context, p *lnrpc.Payment) (proto.Message,
+	error) {
+
+	return redactHTLCAttempts(p), nil
+}
+
+// redactSendResponse strips the route a synchronous payment took.
+func redactSendResponse(_ context.Context, r *lnrpc.SendResponse) (
+	proto.Message, error) {
+
+	return &lnrpc.SendResponse{
+		PaymentError:    r.PaymentError,
+		PaymentPreimage: r.PaymentPreimage,
+		PaymentHash:     r.PaymentHash,
+	}, nil
+}
+
+// redactHTLCAttempts returns a copy of the payment with the routes and the
+// failure details of its HTLC attempts removed. Only the failure code is kept,
+// the other failure fields contain channel updates and the index of the
+// failing hop.
+func redactHTLCAttempts(p *lnrpc.Payment) *lnrpc.Payment {
+	redacted := proto.Clone(p).(*lnrpc.Payment)
+	for _, h := range redacted.Htlcs {
+		h.Route = nil
+
+		if h.Failure != nil {
+			h.Failure = &lnrpc.Failure{
+				Code: h.Failure.Code,
+			}
+		}
+	}
+
+	return redacted
+}
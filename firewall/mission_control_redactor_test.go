@@ -0,0 +1,120 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/rpcperms"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestMissionControlRedactor tests that the mission control and pathfinding
+// data is stripped from responses while other responses pass through.
+func TestMissionControlRedactor(t *testing.T) {
+	mac, err := macaroon.New(
+		[]byte("root key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+	require.NoError(t, mac.AddFirstPartyCaveat(
+		RedactMissionControlCaveat.Id,
+	))
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	redactor := NewMissionControlRedactor()
+	intercept := func(uri string, msg proto.Message) (
+		*lnrpc.RPCMiddlewareResponse, error) {
+
+		rawMsg, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		interceptReq := &rpcperms.InterceptionRequest{
+			Type:            rpcperms.TypeResponse,
+			Macaroon:        mac,
+			RawMacaroon:     macBytes,
+			FullURI:         uri,
+			ProtoSerialized: rawMsg,
+			ProtoTypeName:   string(proto.MessageName(msg)),
+		}
+
+		mwReq, err := interceptReq.ToRPC(1, 2)
+		require.NoError(t, err)
+
+		return redactor.Intercept(context.Background(), mwReq)
+	}
+
+	// The mission control state is replaced with an empty one.
+	resp, err := intercept(
+		"/routerrpc.Router/QueryMissionControl",
+		&routerrpc.QueryMissionControlResponse{
+			Pairs: []*routerrpc.PairHistory{{
+				NodeFrom: []byte{1},
+				NodeTo:   []byte{2},
+			}},
+		},
+	)
+	require.NoError(t, err)
+
+	mc := &routerrpc.QueryMissionControlResponse{}
+	err = proto.Unmarshal(resp.GetFeedback().ReplacementSerialized, mc)
+	require.NoError(t, err)
+	require.Empty(t, mc.Pairs)
+
+	// The routes and failure details of the HTLC attempts are removed, the
+	// rest of the payment is kept.
+	failCode := lnrpc.Failure_TEMPORARY_CHANNEL_FAILURE
+	resp, err = intercept(
+		"/lnrpc.Lightning/ListPayments",
+		&lnrpc.ListPaymentsResponse{
+			Payments: []*lnrpc.Payment{{
+				PaymentHash: "hash",
+				ValueMsat:   1000,
+				Htlcs: []*lnrpc.HTLCAttempt{{
+					AttemptId: 1,
+					Route: &lnrpc.Route{
+						Hops: []*lnrpc.Hop{{
+							ChanId: 123,
+							PubKey: "pubkey",
+						}},
+					},
+					Failure: &lnrpc.Failure{
+						Code:               failCode,
+						FailureSourceIndex: 1,
+					},
+				}},
+			}},
+			TotalNumPayments: 1,
+		},
+	)
+	require.NoError(t, err)
+
+	payments := &lnrpc.ListPaymentsResponse{}
+	err = proto.Unmarshal(
+		resp.GetFeedback().ReplacementSerialized, payments,
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, payments.TotalNumPayments)
+	require.Len(t, payments.Payments, 1)
+
+	payment := payments.Payments[0]
+	require.Equal(t, "hash", payment.PaymentHash)
+	require.EqualValues(t, 1000, payment.ValueMsat)
+	require.Len(t, payment.Htlcs, 1)
+	require.EqualValues(t, 1, payment.Htlcs[0].AttemptId)
+	require.Nil(t, payment.Htlcs[0].Route)
+	require.Equal(t, failCode, payment.Htlcs[0].Failure.Code)
+	require.Zero(t, payment.Htlcs[0].Failure.FailureSourceIndex)
+
+	// Other responses are passed through without a replacement.
+	resp, err = intercept(
+		"/lnrpc.Lightning/GetInfo", &lnrpc.GetInfoResponse{
+			Alias: "alias",
+		},
+	)
+	require.NoError(t, err)
+	require.False(t, resp.GetFeedback().ReplaceResponse)
+}
@@ -0,0 +1,169 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// onChainSpendBlockerName is the name of the OnChainSpendBlocker
+	// interceptor.
+	onChainSpendBlockerName = "lit-onchain-spend-blocker"
+
+	// CondBlockOnChainSpend is the name of the custom caveat that instructs
+	// lnd to send all requests with this caveat to the on-chain spend
+	// blocker.
+	CondBlockOnChainSpend = "lit-no-onchain-spend"
+)
+
+var (
+	// A compile-time assertion that OnChainSpendBlocker is a
+	// rpcmiddleware.RequestInterceptor.
+	_ mid.RequestInterceptor = (*OnChainSpendBlocker)(nil)
+
+	// BlockOnChainSpendCaveat is the caveat that makes the on-chain spend
+	// blocker deny all calls of a macaroon that spend on-chain funds.
+	BlockOnChainSpendCaveat = macaroon.Caveat{
+		Id: []byte(fmt.Sprintf("%s %s", macaroons.CondLndCustom,
+			CondBlockOnChainSpend)),
+	}
+
+	// ErrOnChainSpendBlocked is the error that is returned if a session
+	// that may not spend on-chain funds calls a method that spends them.
+	ErrOnChainSpendBlocked = errors.New("the session may not spend " +
+		"on-chain funds")
+
+	// onChainSpendPermissions are the permissions that allow a method to
+	// spend or sign for on-chain funds of the wallet. Every method that
+	// requires one of them is blocked.
+	onChainSpendPermissions = []bakery.Op{
+		{Entity: "onchain", Action: "write"},
+		{Entity: "signer", Action: "generate"},
+	}
+
+	// subServerSpendURIs are the URIs of the methods of the subservers
+	// that spend on-chain funds of the wallet. The subservers use their
+	// own permissions for them, so they aren't covered by the on-chain
+	// spend permissions.
+	subServerSpendURIs = map[string]bool{
+		"/looprpc.SwapClient/LoopIn":      true,
+		"/poolrpc.Trader/InitAccount":     true,
+		"/poolrpc.Trader/DepositAccount":  true,
+		"/poolrpc.Trader/WithdrawAccount": true,
+		"/poolrpc.Trader/RenewAccount":    true,
+		"/poolrpc.Trader/BumpAccountFee":  true,
+	}
+
+	// openChannelURIs are the URIs of the methods that open a channel. They
+	// spend on-chain funds of the wallet unless the channel is funded
+	// externally through a funding shim.
+	openChannelURIs = map[string]bool{
+		"/lnrpc.Lightning/OpenChannel":     true,
+		"/lnrpc.Lightning/OpenChannelSync": true,
+	}
+)
+
+// SpendsOnChain returns true if a call of the method with the given URI always
+// spends or signs for on-chain funds of the wallet. These are all methods that
+// require the on-chain write or the signer generate permission, looked up with
+// the given permissions function, and the spending methods of the subservers.
+// Channel opens only spend on-chain funds if they are funded by the wallet,
+// which depends on the request.
+func SpendsOnChain(uri string, permissions mid.PermissionsFunc) bool {
+	if subServerSpendURIs[uri] {
+		return true
+	}
+
+	if openChannelURIs[uri] {
+		return false
+	}
+
+	ops, _ := permissions(uri)
+	for _, op := range ops {
+		for _, spendOp := range onChainSpendPermissions {
+			if op == spendOp {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// OnChainSpendBlocker is a RequestInterceptor that denies all calls that spend
+// on-chain funds of the wallet for the macaroons that have the on-chain spend
+// blocking caveat, whatever permissions the macaroon has.
+type OnChainSpendBlocker struct {
+	permissions mid.PermissionsFunc
+}
+
+// NewOnChainSpendBlocker returns a new instance of OnChainSpendBlocker that
+// looks up the permissions of the intercepted methods with the given
+// function.
+func NewOnChainSpendBlocker(
+	permissions mid.PermissionsFunc) *OnChainSpendBlocker {
+
+	return &OnChainSpendBlocker{
+		permissions: permissions,
+	}
+}
+
+// Name returns the name of the interceptor.
+func (o *OnChainSpendBlocker) Name() string {
+	return onChainSpendBlockerName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+func (o *OnChainSpendBlocker) ReadOnly() bool {
+	return false
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (o *OnChainSpendBlocker) CustomCaveatName() string {
+	return CondBlockOnChainSpend
+}
+
+// Intercept processes an RPC middleware interception request and returns the
+// interception result which either accepts or rejects the intercepted message.
+func (o *OnChainSpendBlocker) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	request, ok := req.InterceptType.(*lnrpc.RPCMiddlewareRequest_Request)
+	if !ok {
+		return mid.RPCOk(req)
+	}
+
+	uri := request.Request.MethodFullUri
+	switch {
+	case SpendsOnChain(uri, o.permissions):
+		return mid.RPCErr(req, ErrOnChainSpendBlocked)
+
+	case !openChannelURIs[uri]:
+		return mid.RPCOk(req)
+	}
+
+	msg, err := mid.ParseProtobuf(
+		request.Request.TypeName, request.Request.Serialized,
+	)
+	if err != nil {
+		return mid.RPCErrString(req, "error parsing proto: %v", err)
+	}
+
+	// A channel that is opened with a funding shim is funded by a
+	// transaction that is created outside of lnd's wallet.
+	open, ok := msg.(*lnrpc.OpenChannelRequest)
+	if ok && open.FundingShim != nil {
+		return mid.RPCOk(req)
+	}
+
+	return mid.RPCErr(req, ErrOnChainSpendBlocked)
+}
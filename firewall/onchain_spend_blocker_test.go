@@ -0,0 +1,135 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"github.com/lightningnetwork/lnd/rpcperms"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestOnChainSpendBlocker tests that the calls that spend on-chain funds of
+// the wallet are denied for macaroons with the on-chain spend blocking caveat.
+func TestOnChainSpendBlocker(t *testing.T) {
+	permsMgr, err := perms.NewManager(true)
+	require.NoError(t, err)
+
+	blocker := NewOnChainSpendBlocker(permsMgr.URIPermissions)
+	intercept := func(uri string, msg proto.Message) string {
+		mac, err := macaroon.New(
+			[]byte("root key"), []byte("id"), "lnd",
+			macaroon.LatestVersion,
+		)
+		require.NoError(t, err)
+		require.NoError(t, mac.AddFirstPartyCaveat(
+			BlockOnChainSpendCaveat.Id,
+		))
+		macBytes, err := mac.MarshalBinary()
+		require.NoError(t, err)
+
+		rawMsg, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		interceptReq := &rpcperms.InterceptionRequest{
+			Type:            rpcperms.TypeRequest,
+			Macaroon:        mac,
+			RawMacaroon:     macBytes,
+			FullURI:         uri,
+			ProtoSerialized: rawMsg,
+			ProtoTypeName:   string(proto.MessageName(msg)),
+		}
+
+		mwReq, err := interceptReq.ToRPC(1, 2)
+		require.NoError(t, err)
+
+		resp, err := blocker.Intercept(context.Background(), mwReq)
+		require.NoError(t, err)
+
+		feedback := resp.GetFeedback()
+		require.NotNil(t, feedback)
+		require.False(t, feedback.ReplaceResponse)
+
+		return feedback.Error
+	}
+
+	// Calls that spend or sign for on-chain funds are denied.
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/lnrpc.Lightning/SendCoins", &lnrpc.SendCoinsRequest{
+			Addr:   "bcrt1qfoo",
+			Amount: 1000,
+		},
+	))
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/walletrpc.WalletKit/FundPsbt", &walletrpc.FundPsbtRequest{},
+	))
+
+	// Every method that needs the on-chain write or the signer generate
+	// permission is denied, so a spend of the wallet's outputs can't be
+	// signed and broadcast outside of lnd.
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/signrpc.Signer/SignOutputRaw", &signrpc.SignReq{},
+	))
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/signrpc.Signer/ComputeInputScript", &signrpc.SignReq{},
+	))
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/signrpc.Signer/MuSig2Sign", &signrpc.MuSig2SignRequest{},
+	))
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/lnrpc.Lightning/FundingStateStep",
+		&lnrpc.FundingTransitionMsg{},
+	))
+
+	// The subserver calls that spend on-chain funds are denied as well.
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/looprpc.SwapClient/LoopIn", &looprpc.LoopInRequest{},
+	))
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/poolrpc.Trader/InitAccount", &poolrpc.InitAccountRequest{},
+	))
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/poolrpc.Trader/DepositAccount",
+		&poolrpc.DepositAccountRequest{},
+	))
+
+	// Channels that are funded by the wallet can't be opened.
+	require.Equal(t, ErrOnChainSpendBlocked.Error(), intercept(
+		"/lnrpc.Lightning/OpenChannelSync", &lnrpc.OpenChannelRequest{
+			LocalFundingAmount: 100_000,
+		},
+	))
+
+	// A channel that is funded externally through a funding shim can be
+	// opened.
+	require.Empty(t, intercept(
+		"/lnrpc.Lightning/OpenChannel", &lnrpc.OpenChannelRequest{
+			LocalFundingAmount: 100_000,
+			FundingShim: &lnrpc.FundingShim{
+				Shim: &lnrpc.FundingShim_PsbtShim{
+					PsbtShim: &lnrpc.PsbtShim{
+						PendingChanId: make([]byte, 32),
+					},
+				},
+			},
+		},
+	))
+
+	// Other calls are passed through.
+	require.Empty(t, intercept(
+		"/lnrpc.Lightning/SendPaymentSync", &lnrpc.SendRequest{},
+	))
+	require.Empty(t, intercept(
+		"/lnrpc.Lightning/NewAddress", &lnrpc.NewAddressRequest{},
+	))
+	require.Empty(t, intercept(
+		"/looprpc.SwapClient/LoopOut", &looprpc.LoopOutRequest{},
+	))
+}
@@ -22,7 +22,8 @@ const (
 	// amountVariation and timeVariation are used to set the randomization
 	// of amounts and timestamps that are sent to the autopilot. Changing
 	// these values may lead to unintended consequences in the behavior of
-	// the autpilot.
+	// the autpilot. The amount variation is only the default and can be
+	// overridden per session and feature.
 	amountVariation = 0.05
 	timeVariation   = time.Duration(10) * time.Minute
 
@@ -30,6 +31,9 @@ const (
 	// between which timeVariation can be set.
 	minTimeVariation = time.Minute
 	maxTimeVariation = time.Duration(24) * time.Hour
+
+	// msatPerSat is the number of millisatoshis in one satoshi.
+	msatPerSat = 1000
 )
 
 var (
@@ -93,6 +97,13 @@ func (p *PrivacyMapper) Intercept(ctx context.Context,
 
 	log.Tracef("PrivacyMapper: Intercepting %v", ri)
 
+	// The feature is used to look up the feature specific obfuscation
+	// settings.
+	var feature string
+	if ri.MetaInfo != nil {
+		feature = ri.MetaInfo.Feature
+	}
+
 	switch r := req.InterceptType.(type) {
 	case *lnrpc.RPCMiddlewareRequest_StreamAuth:
 		return mid.RPCErr(req, fmt.Errorf("streams unsupported"))
@@ -108,7 +119,7 @@ func (p *PrivacyMapper) Intercept(ctx context.Context,
 		}
 
 		replacement, err := p.checkAndReplaceIncomingRequest(
-			ctx, r.Request.MethodFullUri, msg, sessionID,
+			ctx, r.Request.MethodFullUri, msg, sessionID, feature,
 		)
 		if err != nil {
 			return mid.RPCErr(req, err)
@@ -143,6 +154,7 @@ func (p *PrivacyMapper) Intercept(ctx context.Context,
 
 		replacement, err := p.replaceOutgoingResponse(
 			ctx, r.Response.MethodFullUri, msg, sessionID,
+			feature,
 		)
 		if err != nil {
 			return mid.RPCErr(req, err)
@@ -167,14 +179,14 @@ func (p *PrivacyMapper) Intercept(ctx context.Context,
 // checkAndReplaceIncomingRequest inspects an incoming request and optionally
 // modifies some of the request parameters.
 func (p *PrivacyMapper) checkAndReplaceIncomingRequest(ctx context.Context,
-	uri string, req proto.Message, sessionID session.ID) (proto.Message,
-	error) {
+	uri string, req proto.Message, sessionID session.ID,
+	feature string) (proto.Message, error) {
 
 	db := p.newDB(sessionID)
 
 	// If we don't have a handler for the URI, we don't allow the request
 	// to go through.
-	checker, ok := p.checkers(db)[uri]
+	checker, ok := p.checkers(db, feature)[uri]
 	if !ok {
 		return nil, ErrNotSupportedByPrivacyMapper
 	}
@@ -193,13 +205,14 @@ func (p *PrivacyMapper) checkAndReplaceIncomingRequest(ctx context.Context,
 // replaceOutgoingResponse inspects the responses before sending them out to the
 // client and replaces them if needed.
 func (p *PrivacyMapper) replaceOutgoingResponse(ctx context.Context, uri string,
-	resp proto.Message, sessionID session.ID) (proto.Message, error) {
+	resp proto.Message, sessionID session.ID, feature string) (proto.Message,
+	error) {
 
 	db := p.newDB(sessionID)
 
 	// If we don't have a handler for the URI, we don't allow the response
 	// to go to avoid accidental leaks.
-	checker, ok := p.checkers(db)[uri]
+	checker, ok := p.checkers(db, feature)[uri]
 	if !ok {
 		return nil, ErrNotSupportedByPrivacyMapper
 	}
@@ -215,8 +228,16 @@ func (p *PrivacyMapper) replaceOutgoingResponse(ctx context.Context, uri string,
 	return checker.HandleResponse(ctx, resp)
 }
 
-func (p *PrivacyMapper) checkers(
-	db firewalldb.PrivacyMapDB) map[string]mid.RoundTripChecker {
+// PrivacyMapperSupportsURI returns true if the privacy mapper lets calls of
+// the RPC method with the given URI through. Calls of all other methods are
+// rejected for sessions that use the privacy mapper.
+func PrivacyMapperSupportsURI(uri string) bool {
+	_, ok := (&PrivacyMapper{}).checkers(nil, "")[uri]
+	return ok
+}
+
+func (p *PrivacyMapper) checkers(db firewalldb.PrivacyMapDB,
+	feature string) map[string]mid.RoundTripChecker {
 
 	return map[string]mid.RoundTripChecker{
 		"/lnrpc.Lightning/GetInfo": mid.NewResponseRewriter(
@@ -226,7 +247,7 @@ func (p *PrivacyMapper) checkers(
 		"/lnrpc.Lightning/ForwardingHistory": mid.NewResponseRewriter(
 			&lnrpc.ForwardingHistoryRequest{},
 			&lnrpc.ForwardingHistoryResponse{},
-			handleFwdHistoryResponse(db, p.randIntn),
+			handleFwdHistoryResponse(db, feature, p.randIntn),
 			mid.PassThroughErrorHandler,
 		),
 		"/lnrpc.Lightning/FeeReport": mid.NewResponseRewriter(
@@ -238,7 +259,19 @@ func (p *PrivacyMapper) checkers(
 			&lnrpc.ListChannelsRequest{},
 			&lnrpc.ListChannelsResponse{},
 			handleListChannelsRequest(db),
-			handleListChannelsResponse(db, p.randIntn),
+			handleListChannelsResponse(db, feature, p.randIntn),
+			mid.PassThroughErrorHandler,
+		),
+		"/lnrpc.Lightning/ListInvoices": mid.NewResponseRewriter(
+			&lnrpc.ListInvoiceRequest{},
+			&lnrpc.ListInvoiceResponse{},
+			handleListInvoicesResponse(db, feature, p.randIntn),
+			mid.PassThroughErrorHandler,
+		),
+		"/lnrpc.Lightning/ListPayments": mid.NewResponseRewriter(
+			&lnrpc.ListPaymentsRequest{},
+			&lnrpc.ListPaymentsResponse{},
+			handleListPaymentsResponse(db, feature, p.randIntn),
 			mid.PassThroughErrorHandler,
 		),
 		"/lnrpc.Lightning/UpdateChannelPolicy": mid.NewFullRewriter(
@@ -301,7 +334,7 @@ func handleGetInfoResponse(db firewalldb.PrivacyMapDB) func(ctx context.Context,
 	}
 }
 
-func handleFwdHistoryResponse(db firewalldb.PrivacyMapDB,
+func handleFwdHistoryResponse(db firewalldb.PrivacyMapDB, feature string,
 	randIntn func(int) (int, error)) func(ctx context.Context,
 	r *lnrpc.ForwardingHistoryResponse) (proto.Message, error) {
 
@@ -313,6 +346,11 @@ func handleFwdHistoryResponse(db firewalldb.PrivacyMapDB,
 		)
 
 		err := db.Update(func(tx firewalldb.PrivacyMapTx) error {
+			settings, err := obfuscationSettings(tx, feature)
+			if err != nil {
+				return err
+			}
+
 			for i, fe := range r.ForwardingEvents {
 				// Deterministically hide channel ids.
 				chanIn, err := firewalldb.HideUint64(
@@ -330,17 +368,18 @@ func handleFwdHistoryResponse(db firewalldb.PrivacyMapDB,
 				}
 
 				// We randomize the outgoing amount for privacy.
-				amtOutMsat, err := hideAmount(
-					randIntn, amountVariation,
-					fe.AmtOutMsat,
+				amtOutMsat, err := obfuscateAmount(
+					randIntn, settings, fe.AmtOutMsat,
+					msatPerSat,
 				)
 				if err != nil {
 					return err
 				}
 
 				// We randomize fees for privacy.
-				feeMsat, err := hideAmount(
-					randIntn, amountVariation, fe.FeeMsat,
+				feeMsat, err := obfuscateAmount(
+					randIntn, settings, fe.FeeMsat,
+					msatPerSat,
 				)
 				if err != nil {
 					return err
@@ -361,6 +400,8 @@ func handleFwdHistoryResponse(db firewalldb.PrivacyMapDB,
 					return err
 				}
 
+				// The peer aliases are purposefully not set,
+				// as they would reveal the real peers.
 				fwdEvents[i] = &lnrpc.ForwardingEvent{
 					ChanIdIn:   chanIn,
 					ChanIdOut:  chanOut,
@@ -468,27 +509,32 @@ func handleListChannelsRequest(db firewalldb.PrivacyMapDB) func(
 	}
 }
 
-func handleListChannelsResponse(db firewalldb.PrivacyMapDB,
+func handleListChannelsResponse(db firewalldb.PrivacyMapDB, feature string,
 	randIntn func(int) (int, error)) func(ctx context.Context,
 	r *lnrpc.ListChannelsResponse) (proto.Message, error) {
 
 	return func(_ context.Context, r *lnrpc.ListChannelsResponse) (
 		proto.Message, error) {
 
-		hideAmount := func(a int64) (int64, error) {
-			hiddenAmount, err := hideAmount(
-				randIntn, amountVariation, uint64(a),
-			)
+		channels := make([]*lnrpc.Channel, len(r.Channels))
+
+		err := db.Update(func(tx firewalldb.PrivacyMapTx) error {
+			settings, err := obfuscationSettings(tx, feature)
 			if err != nil {
-				return 0, err
+				return err
 			}
 
-			return int64(hiddenAmount), nil
-		}
+			hideAmount := func(a int64) (int64, error) {
+				hiddenAmount, err := obfuscateAmount(
+					randIntn, settings, uint64(a), 1,
+				)
+				if err != nil {
+					return 0, err
+				}
 
-		channels := make([]*lnrpc.Channel, len(r.Channels))
+				return int64(hiddenAmount), nil
+			}
 
-		err := db.Update(func(tx firewalldb.PrivacyMapTx) error {
 			for i, c := range r.Channels {
 				// Deterministically hide the peer pubkey,
 				// the channel point, and the channel id.
@@ -623,6 +669,439 @@ func handleListChannelsResponse(db firewalldb.PrivacyMapDB,
 	}
 }
 
+func handleListInvoicesResponse(db firewalldb.PrivacyMapDB, feature string,
+	randIntn func(int) (int, error)) func(ctx context.Context,
+	r *lnrpc.ListInvoiceResponse) (proto.Message, error) {
+
+	return func(_ context.Context, r *lnrpc.ListInvoiceResponse) (
+		proto.Message, error) {
+
+		invoices := make([]*lnrpc.Invoice, len(r.Invoices))
+
+		err := db.Update(func(tx firewalldb.PrivacyMapTx) error {
+			settings, err := obfuscationSettings(tx, feature)
+			if err != nil {
+				return err
+			}
+
+			for i, inv := range r.Invoices {
+				invoices[i], err = hideInvoice(
+					tx, settings, randIntn, inv,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &lnrpc.ListInvoiceResponse{
+			Invoices:         invoices,
+			LastIndexOffset:  r.LastIndexOffset,
+			FirstIndexOffset: r.FirstIndexOffset,
+		}, nil
+	}
+}
+
+// hideInvoice returns a copy of the invoice with the identifiers replaced by
+// pseudo values and the amounts and timestamps randomized.
+func hideInvoice(tx firewalldb.PrivacyMapTx,
+	settings *firewalldb.AmountObfuscation,
+	randIntn func(int) (int, error), inv *lnrpc.Invoice) (*lnrpc.Invoice,
+	error) {
+
+	// Deterministically hide the payment hash and address.
+	rHash, err := firewalldb.HideBytes(tx, inv.RHash)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentAddr, err := firewalldb.HideBytes(tx, inv.PaymentAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// We randomize the amounts for privacy.
+	valueMsat, err := obfuscateAmount(
+		randIntn, settings, uint64(inv.ValueMsat), msatPerSat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	amtPaidMsat, err := obfuscateAmount(
+		randIntn, settings, uint64(inv.AmtPaidMsat), msatPerSat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// All timestamps of the invoice are shifted by the same offset, so
+	// that their order is kept.
+	offset, err := timestampOffset(randIntn, time.Unix(inv.CreationDate, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	htlcs := make([]*lnrpc.InvoiceHTLC, len(inv.Htlcs))
+	for i, h := range inv.Htlcs {
+		chanID, err := firewalldb.HideUint64(tx, h.ChanId)
+		if err != nil {
+			return nil, err
+		}
+
+		amtMsat, err := obfuscateAmount(
+			randIntn, settings, h.AmtMsat, msatPerSat,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		htlcs[i] = &lnrpc.InvoiceHTLC{
+			// Items we adjust.
+			ChanId:      chanID,
+			AmtMsat:     amtMsat,
+			AcceptTime:  shiftUnix(h.AcceptTime, offset),
+			ResolveTime: shiftUnix(h.ResolveTime, offset),
+
+			// Items that we zero out.
+			CustomRecords:   nil,
+			MppTotalAmtMsat: 0,
+			Amp:             nil,
+
+			// Items we keep as is.
+			HtlcIndex:    h.HtlcIndex,
+			AcceptHeight: h.AcceptHeight,
+			ExpiryHeight: h.ExpiryHeight,
+			State:        h.State,
+		}
+	}
+
+	return &lnrpc.Invoice{
+		// Items we adjust.
+		RHash:        rHash,
+		PaymentAddr:  paymentAddr,
+		Value:        int64(valueMsat / msatPerSat),
+		ValueMsat:    int64(valueMsat),
+		AmtPaid:      int64(amtPaidMsat),
+		AmtPaidSat:   int64(amtPaidMsat / msatPerSat),
+		AmtPaidMsat:  int64(amtPaidMsat),
+		CreationDate: shiftUnix(inv.CreationDate, offset),
+		SettleDate:   shiftUnix(inv.SettleDate, offset),
+		Htlcs:        htlcs,
+
+		// Items that we zero out. The payment request contains our node
+		// ID and the route hints our channels, the preimage must never
+		// leave the node.
+		Memo:            "",
+		RPreimage:       nil,
+		PaymentRequest:  "",
+		DescriptionHash: nil,
+		FallbackAddr:    "",
+		RouteHints:      nil,
+		AmpInvoiceState: nil,
+
+		// Items we keep as is.
+		Settled:     inv.Settled,
+		Expiry:      inv.Expiry,
+		CltvExpiry:  inv.CltvExpiry,
+		Private:     inv.Private,
+		AddIndex:    inv.AddIndex,
+		SettleIndex: inv.SettleIndex,
+		State:       inv.State,
+		Features:    inv.Features,
+		IsKeysend:   inv.IsKeysend,
+		IsAmp:       inv.IsAmp,
+	}, nil
+}
+
+func handleListPaymentsResponse(db firewalldb.PrivacyMapDB, feature string,
+	randIntn func(int) (int, error)) func(ctx context.Context,
+	r *lnrpc.ListPaymentsResponse) (proto.Message, error) {
+
+	return func(_ context.Context, r *lnrpc.ListPaymentsResponse) (
+		proto.Message, error) {
+
+		payments := make([]*lnrpc.Payment, len(r.Payments))
+
+		err := db.Update(func(tx firewalldb.PrivacyMapTx) error {
+			settings, err := obfuscationSettings(tx, feature)
+			if err != nil {
+				return err
+			}
+
+			for i, p := range r.Payments {
+				payments[i], err = hidePayment(
+					tx, settings, randIntn, p,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &lnrpc.ListPaymentsResponse{
+			Payments:         payments,
+			FirstIndexOffset: r.FirstIndexOffset,
+			LastIndexOffset:  r.LastIndexOffset,
+			TotalNumPayments: r.TotalNumPayments,
+		}, nil
+	}
+}
+
+// hidePayment returns a copy of the payment with the identifiers replaced by
+// pseudo values and the amounts and timestamps randomized.
+func hidePayment(tx firewalldb.PrivacyMapTx,
+	settings *firewalldb.AmountObfuscation,
+	randIntn func(int) (int, error), p *lnrpc.Payment) (*lnrpc.Payment,
+	error) {
+
+	// Deterministically hide the payment hash.
+	paymentHash, err := hideHexString(tx, p.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// We randomize the amounts for privacy.
+	valueMsat, err := obfuscateAmount(
+		randIntn, settings, uint64(p.ValueMsat), msatPerSat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	feeMsat, err := obfuscateAmount(
+		randIntn, settings, uint64(p.FeeMsat), msatPerSat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// All timestamps of the payment are shifted by the same offset, so
+	// that their order is kept.
+	offset, err := timestampOffset(randIntn, time.Unix(0, p.CreationTimeNs))
+	if err != nil {
+		return nil, err
+	}
+	creationTimeNs := shiftUnixNano(p.CreationTimeNs, offset)
+
+	htlcs := make([]*lnrpc.HTLCAttempt, len(p.Htlcs))
+	for i, h := range p.Htlcs {
+		route, err := hideRoute(tx, settings, randIntn, h.Route)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the failure code is kept. The other fields contain
+		// channel updates and the HTLC amounts.
+		var failure *lnrpc.Failure
+		if h.Failure != nil {
+			failure = &lnrpc.Failure{
+				Code:               h.Failure.Code,
+				FailureSourceIndex: h.Failure.FailureSourceIndex,
+			}
+		}
+
+		htlcs[i] = &lnrpc.HTLCAttempt{
+			// Items we adjust.
+			Route:         route,
+			AttemptTimeNs: shiftUnixNano(h.AttemptTimeNs, offset),
+			ResolveTimeNs: shiftUnixNano(h.ResolveTimeNs, offset),
+			Failure:       failure,
+
+			// Items that we zero out.
+			Preimage: nil,
+
+			// Items we keep as is.
+			AttemptId: h.AttemptId,
+			Status:    h.Status,
+		}
+	}
+
+	return &lnrpc.Payment{
+		// Items we adjust.
+		PaymentHash:    paymentHash,
+		Value:          int64(valueMsat / msatPerSat),
+		ValueSat:       int64(valueMsat / msatPerSat),
+		ValueMsat:      int64(valueMsat),
+		Fee:            int64(feeMsat / msatPerSat),
+		FeeSat:         int64(feeMsat / msatPerSat),
+		FeeMsat:        int64(feeMsat),
+		CreationDate:   creationTimeNs / int64(time.Second),
+		CreationTimeNs: creationTimeNs,
+		Htlcs:          htlcs,
+
+		// Items that we zero out. The payment request contains the
+		// node ID of the recipient, the preimage is the proof of
+		// payment.
+		PaymentPreimage: "",
+		PaymentRequest:  "",
+
+		// Items we keep as is.
+		Status:        p.Status,
+		PaymentIndex:  p.PaymentIndex,
+		FailureReason: p.FailureReason,
+	}, nil
+}
+
+// hideRoute returns a copy of the route with the channel IDs and node keys
+// replaced by pseudo values and the amounts randomized.
+func hideRoute(tx firewalldb.PrivacyMapTx,
+	settings *firewalldb.AmountObfuscation,
+	randIntn func(int) (int, error), r *lnrpc.Route) (*lnrpc.Route, error) {
+
+	if r == nil {
+		return nil, nil
+	}
+
+	hideMsat := func(a int64) (int64, error) {
+		hidden, err := obfuscateAmount(
+			randIntn, settings, uint64(a), msatPerSat,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		return int64(hidden), nil
+	}
+
+	totalAmtMsat, err := hideMsat(r.TotalAmtMsat)
+	if err != nil {
+		return nil, err
+	}
+
+	totalFeesMsat, err := hideMsat(r.TotalFeesMsat)
+	if err != nil {
+		return nil, err
+	}
+
+	hops := make([]*lnrpc.Hop, len(r.Hops))
+	for i, h := range r.Hops {
+		chanID, err := firewalldb.HideUint64(tx, h.ChanId)
+		if err != nil {
+			return nil, err
+		}
+
+		pubKey, err := hideHexString(tx, h.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		amtMsat, err := hideMsat(h.AmtToForwardMsat)
+		if err != nil {
+			return nil, err
+		}
+
+		feeMsat, err := hideMsat(h.FeeMsat)
+		if err != nil {
+			return nil, err
+		}
+
+		var mpp *lnrpc.MPPRecord
+		if h.MppRecord != nil {
+			paymentAddr, err := firewalldb.HideBytes(
+				tx, h.MppRecord.PaymentAddr,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			totalAmtMsat, err := hideMsat(h.MppRecord.TotalAmtMsat)
+			if err != nil {
+				return nil, err
+			}
+
+			mpp = &lnrpc.MPPRecord{
+				PaymentAddr:  paymentAddr,
+				TotalAmtMsat: totalAmtMsat,
+			}
+		}
+
+		hops[i] = &lnrpc.Hop{
+			// Items we adjust.
+			ChanId:           chanID,
+			PubKey:           pubKey,
+			AmtToForward:     amtMsat / msatPerSat,
+			AmtToForwardMsat: amtMsat,
+			Fee:              feeMsat / msatPerSat,
+			FeeMsat:          feeMsat,
+			MppRecord:        mpp,
+
+			// Items that we zero out.
+			AmpRecord:     nil,
+			CustomRecords: nil,
+			Metadata:      nil,
+
+			// Items we keep as is.
+			ChanCapacity: h.ChanCapacity,
+			Expiry:       h.Expiry,
+			TlvPayload:   h.TlvPayload, //nolint:staticcheck
+		}
+	}
+
+	return &lnrpc.Route{
+		TotalTimeLock: r.TotalTimeLock,
+		TotalFees:     totalFeesMsat / msatPerSat,
+		TotalFeesMsat: totalFeesMsat,
+		TotalAmt:      totalAmtMsat / msatPerSat,
+		TotalAmtMsat:  totalAmtMsat,
+		Hops:          hops,
+	}, nil
+}
+
+// hideHexString deterministically hides a hex encoded string. Empty strings
+// are returned as is.
+func hideHexString(tx firewalldb.PrivacyMapTx, real string) (string, error) {
+	if real == "" {
+		return "", nil
+	}
+
+	return firewalldb.HideString(tx, real)
+}
+
+// timestampOffset returns a random offset that a set of related timestamps
+// can be shifted by. The offset is derived by hiding the first timestamp of
+// the set.
+func timestampOffset(randIntn func(int) (int, error),
+	timestamp time.Time) (time.Duration, error) {
+
+	hidden, err := hideTimestamp(randIntn, timeVariation, timestamp)
+	if err != nil {
+		return 0, err
+	}
+
+	return hidden.Sub(timestamp), nil
+}
+
+// shiftUnix shifts a unix timestamp in seconds by the given offset. Unset
+// timestamps stay unset.
+func shiftUnix(timestamp int64, offset time.Duration) int64 {
+	if timestamp == 0 {
+		return 0
+	}
+
+	return time.Unix(timestamp, 0).Add(offset).Unix()
+}
+
+// shiftUnixNano shifts a unix timestamp in nanoseconds by the given offset.
+// Unset timestamps stay unset.
+func shiftUnixNano(timestamp int64, offset time.Duration) int64 {
+	if timestamp == 0 {
+		return 0
+	}
+
+	return timestamp + int64(offset)
+}
+
 func handleUpdatePolicyRequest(db firewalldb.PrivacyMapDB) func(
 	ctx context.Context, r *lnrpc.PolicyUpdateRequest) (proto.Message,
 	error) {
@@ -752,6 +1231,51 @@ func hideAmount(randIntn func(n int) (int, error), relativeVariation float64,
 	return uint64(randAmount), nil
 }
 
+// obfuscationSettings returns the amount obfuscation settings of the given
+// feature of the session or the default settings if none were set.
+func obfuscationSettings(tx firewalldb.PrivacyMapTx,
+	feature string) (*firewalldb.AmountObfuscation, error) {
+
+	settings, err := tx.AmountObfuscation(feature)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil {
+		return &firewalldb.AmountObfuscation{
+			Variation: amountVariation,
+		}, nil
+	}
+
+	return settings, nil
+}
+
+// obfuscateAmount randomizes an amount with the relative variation of the
+// given settings and then rounds it to their granularity. The unit is the
+// number of base units of the amount per satoshi, so 1 for amounts in
+// satoshis and msatPerSat for amounts in millisatoshis.
+func obfuscateAmount(randIntn func(n int) (int, error),
+	settings *firewalldb.AmountObfuscation, amount,
+	unit uint64) (uint64, error) {
+
+	hiddenAmount, err := hideAmount(randIntn, settings.Variation, amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return roundAmount(hiddenAmount, settings.GranularitySat*unit), nil
+}
+
+// roundAmount rounds an amount to the nearest multiple of the granularity. A
+// granularity of zero or one leaves the amount unchanged.
+func roundAmount(amount, granularity uint64) uint64 {
+	if granularity <= 1 {
+		return amount
+	}
+
+	return (amount + granularity/2) / granularity * granularity
+}
+
 // hideTimestamp symmetrically randomizes a unix timestamp given an absolute
 // variation interval. The random input is expected to be rand.Intn.
 func hideTimestamp(randIntn func(n int) (int, error),
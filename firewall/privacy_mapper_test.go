@@ -256,6 +256,162 @@ func TestPrivacyMapper(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "ListInvoices Response",
+			uri:     "/lnrpc.Lightning/ListInvoices",
+			msgType: rpcperms.TypeResponse,
+			msg: &lnrpc.ListInvoiceResponse{
+				Invoices: []*lnrpc.Invoice{{
+					Memo:           "coffee",
+					RPreimage:      []byte{9, 9, 9, 9},
+					RHash:          []byte{1, 2, 3, 4},
+					PaymentAddr:    []byte{5, 6, 7, 8},
+					Value:          1_000,
+					ValueMsat:      1_000_000,
+					AmtPaid:        1_000_000,
+					AmtPaidSat:     1_000,
+					AmtPaidMsat:    1_000_000,
+					CreationDate:   1_000,
+					SettleDate:     2_000,
+					PaymentRequest: "lnbc1...",
+					Settled:        true,
+					State:          lnrpc.Invoice_SETTLED,
+					AddIndex:       1,
+					SettleIndex:    1,
+					Htlcs: []*lnrpc.InvoiceHTLC{{
+						ChanId:      123,
+						HtlcIndex:   7,
+						AmtMsat:     1_000_000,
+						AcceptTime:  1_500,
+						ResolveTime: 2_000,
+						CustomRecords: map[uint64][]byte{
+							65536: {1},
+						},
+					}},
+				}},
+				LastIndexOffset:  1,
+				FirstIndexOffset: 1,
+			},
+			expectedReplacement: &lnrpc.ListInvoiceResponse{
+				Invoices: []*lnrpc.Invoice{{
+					RHash:        []byte{0xc8, 0x13, 0x44, 0x95},
+					PaymentAddr:  []byte{0xa1, 0xb2, 0xc3, 0xd4},
+					Value:        950,
+					ValueMsat:    950_100,
+					AmtPaid:      950_100,
+					AmtPaidSat:   950,
+					AmtPaidMsat:  950_100,
+					CreationDate: 400,
+					SettleDate:   1_400,
+					Settled:      true,
+					State:        lnrpc.Invoice_SETTLED,
+					AddIndex:     1,
+					SettleIndex:  1,
+					Htlcs: []*lnrpc.InvoiceHTLC{{
+						ChanId:      5178778334600911958,
+						HtlcIndex:   7,
+						AmtMsat:     950_100,
+						AcceptTime:  900,
+						ResolveTime: 1_400,
+					}},
+				}},
+				LastIndexOffset:  1,
+				FirstIndexOffset: 1,
+			},
+		},
+		{
+			name:    "ListPayments Response",
+			uri:     "/lnrpc.Lightning/ListPayments",
+			msgType: rpcperms.TypeResponse,
+			msg: &lnrpc.ListPaymentsResponse{
+				Payments: []*lnrpc.Payment{{
+					PaymentHash:     "01020304",
+					Value:           1_000,
+					ValueSat:        1_000,
+					ValueMsat:       1_000_000,
+					Fee:             1,
+					FeeSat:          1,
+					FeeMsat:         1_000,
+					PaymentPreimage: "09090909",
+					PaymentRequest:  "lnbc1...",
+					Status:          lnrpc.Payment_SUCCEEDED,
+					CreationDate:    1_000,
+					CreationTimeNs:  1_000_000_000_000,
+					PaymentIndex:    3,
+					Htlcs: []*lnrpc.HTLCAttempt{{
+						AttemptId:     1,
+						Status:        lnrpc.HTLCAttempt_SUCCEEDED,
+						AttemptTimeNs: 1_000_000_000_000,
+						ResolveTimeNs: 2_000_000_000_000,
+						Preimage:      []byte{9, 9, 9, 9},
+						Route: &lnrpc.Route{
+							TotalTimeLock: 100,
+							TotalFees:     1,
+							TotalFeesMsat: 1_000,
+							TotalAmt:      1_001,
+							TotalAmtMsat:  1_001_000,
+							Hops: []*lnrpc.Hop{{
+								ChanId:           321,
+								ChanCapacity:     10_000,
+								AmtToForward:     1_000,
+								AmtToForwardMsat: 1_000_000,
+								Expiry:           90,
+								PubKey:           "Tinker Bell's pub key",
+								MppRecord: &lnrpc.MPPRecord{
+									PaymentAddr:  []byte{5, 6, 7, 8},
+									TotalAmtMsat: 1_000_000,
+								},
+								Metadata: []byte{1},
+							}},
+						},
+					}},
+				}},
+				FirstIndexOffset: 3,
+				LastIndexOffset:  3,
+			},
+			expectedReplacement: &lnrpc.ListPaymentsResponse{
+				Payments: []*lnrpc.Payment{{
+					PaymentHash:    "c8134495",
+					Value:          950,
+					ValueSat:       950,
+					ValueMsat:      950_100,
+					Fee:            1,
+					FeeSat:         1,
+					FeeMsat:        1_050,
+					Status:         lnrpc.Payment_SUCCEEDED,
+					CreationDate:   400,
+					CreationTimeNs: 400_000_000_100,
+					PaymentIndex:   3,
+					Htlcs: []*lnrpc.HTLCAttempt{{
+						AttemptId:     1,
+						Status:        lnrpc.HTLCAttempt_SUCCEEDED,
+						AttemptTimeNs: 400_000_000_100,
+						ResolveTimeNs: 1_400_000_000_100,
+						Route: &lnrpc.Route{
+							TotalTimeLock: 100,
+							TotalFees:     1,
+							TotalFeesMsat: 1_050,
+							TotalAmt:      951,
+							TotalAmtMsat:  951_050,
+							Hops: []*lnrpc.Hop{{
+								ChanId:           3446430762436373227,
+								ChanCapacity:     10_000,
+								AmtToForward:     950,
+								AmtToForwardMsat: 950_100,
+								Expiry:           90,
+								PubKey:           "a44ef01c3bff970ef495c",
+								MppRecord: &lnrpc.MPPRecord{
+									PaymentAddr:  []byte{0xa1, 0xb2, 0xc3, 0xd4},
+									TotalAmtMsat: 950_100,
+								},
+							}},
+						},
+					}},
+				}},
+				FirstIndexOffset: 3,
+				LastIndexOffset:  3,
+			},
+		},
 	}
 
 	decodedID := &lnrpc.MacaroonId{
@@ -288,6 +444,7 @@ func TestPrivacyMapper(t *testing.T) {
 		"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd:0": "097ef666a61919ff3413b3b701eae3a5cbac08f70c0ca567806e1fa6acbfe384:2161781494",
 		"abcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd:1": "45ec471bfccb0b7b9a8bc4008248931c59ad994903e07b54f54821ea3ef5cc5c62:1642614131",
 		"01020304": "c8134495",
+		"05060708": "a1b2c3d4",
 	}
 
 	db := newMockDB(t, mapPreloadRealToPseudo, sessionID)
@@ -438,6 +595,64 @@ func TestPrivacyMapper(t *testing.T) {
 		require.InEpsilon(t, timestamp, mean(timestamps),
 			relativeTestAccuracy)
 	})
+
+	// Subtest to test that the amount obfuscation settings of the session
+	// are applied.
+	t.Run("Response with custom amount obfuscation", func(t *testing.T) {
+		err := db.NewSessionDB(sessionID).Update(
+			func(tx firewalldb.PrivacyMapTx) error {
+				return tx.SetAmountObfuscation(
+					"", &firewalldb.AmountObfuscation{
+						Variation:      0,
+						GranularitySat: 100,
+					},
+				)
+			},
+		)
+		require.NoError(t, err)
+
+		msg := &lnrpc.ForwardingHistoryResponse{
+			ForwardingEvents: []*lnrpc.ForwardingEvent{{
+				AmtOutMsat:  1_234_567,
+				FeeMsat:     60_000,
+				Timestamp:   1_000_000,
+				TimestampNs: 1_000_000 * 1e9,
+				ChanIdIn:    123,
+				ChanIdOut:   321,
+			}},
+		}
+		rawMsg, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		interceptReq := &rpcperms.InterceptionRequest{
+			Type:            rpcperms.TypeResponse,
+			Macaroon:        mac,
+			RawMacaroon:     macBytes,
+			FullURI:         "/lnrpc.Lightning/ForwardingHistory",
+			ProtoSerialized: rawMsg,
+			ProtoTypeName:   string(proto.MessageName(msg)),
+		}
+
+		mwReq, err := interceptReq.ToRPC(1, 2)
+		require.NoError(t, err)
+
+		// Without variation, the amounts are consistent across calls.
+		for i := 0; i < 10; i++ {
+			resp, err := p.Intercept(context.Background(), mwReq)
+			require.NoError(t, err)
+
+			fw := &lnrpc.ForwardingHistoryResponse{}
+			err = proto.Unmarshal(
+				resp.GetFeedback().ReplacementSerialized, fw,
+			)
+			require.NoError(t, err)
+
+			event := fw.ForwardingEvents[0]
+			require.EqualValues(t, 1_200_000, event.AmtOutMsat)
+			require.EqualValues(t, 100_000, event.FeeMsat)
+			require.EqualValues(t, 1_300_000, event.AmtInMsat)
+		}
+	})
 }
 
 type mockDB map[string]*mockPrivacyMapDB
@@ -480,6 +695,9 @@ func newMockPrivacyMapDB() *mockPrivacyMapDB {
 type mockPrivacyMapDB struct {
 	r2p map[string]string
 	p2r map[string]string
+	key []byte
+
+	obfuscation map[string]*firewalldb.AmountObfuscation
 }
 
 func (m *mockPrivacyMapDB) Update(
@@ -518,6 +736,36 @@ func (m *mockPrivacyMapDB) RealToPseudo(real string) (string, error) {
 	return p, nil
 }
 
+func (m *mockPrivacyMapDB) SetPseudoKey(key []byte) error {
+	m.key = key
+	return nil
+}
+
+func (m *mockPrivacyMapDB) PseudoKey() ([]byte, error) {
+	return m.key, nil
+}
+
+func (m *mockPrivacyMapDB) SetAmountObfuscation(feature string,
+	settings *firewalldb.AmountObfuscation) error {
+
+	if m.obfuscation == nil {
+		m.obfuscation = make(map[string]*firewalldb.AmountObfuscation)
+	}
+	m.obfuscation[feature] = settings
+
+	return nil
+}
+
+func (m *mockPrivacyMapDB) AmountObfuscation(feature string) (
+	*firewalldb.AmountObfuscation, error) {
+
+	if settings, ok := m.obfuscation[feature]; ok {
+		return settings, nil
+	}
+
+	return m.obfuscation[""], nil
+}
+
 var _ firewalldb.PrivacyMapDB = (*mockPrivacyMapDB)(nil)
 
 // TestRandBetween tests random number generation for numbers in an interval.
@@ -606,6 +854,71 @@ func TestHideAmount(t *testing.T) {
 	})
 }
 
+// TestObfuscateAmount tests that amounts are randomized and then rounded to
+// the granularity of the settings.
+func TestObfuscateAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *firewalldb.AmountObfuscation
+		amount   uint64
+		unit     uint64
+		expected uint64
+	}{{
+		name:     "no obfuscation",
+		settings: &firewalldb.AmountObfuscation{},
+		amount:   12_345,
+		unit:     1,
+		expected: 12_345,
+	}, {
+		name: "round down",
+		settings: &firewalldb.AmountObfuscation{
+			GranularitySat: 1_000,
+		},
+		amount:   12_345,
+		unit:     1,
+		expected: 12_000,
+	}, {
+		name: "round up",
+		settings: &firewalldb.AmountObfuscation{
+			GranularitySat: 1_000,
+		},
+		amount:   12_500,
+		unit:     1,
+		expected: 13_000,
+	}, {
+		name: "round msat",
+		settings: &firewalldb.AmountObfuscation{
+			GranularitySat: 10,
+		},
+		amount:   12_345,
+		unit:     msatPerSat,
+		expected: 10_000,
+	}, {
+		name: "vary and round",
+		settings: &firewalldb.AmountObfuscation{
+			Variation:      0.1,
+			GranularitySat: 100,
+		},
+		amount: 10_000,
+		unit:   1,
+		// The lower bound is 9_000, plus 160 from randIntn.
+		expected: 9_200,
+	}}
+
+	randIntn := func(int) (int, error) { return 160, nil }
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			val, err := obfuscateAmount(
+				randIntn, test.settings, test.amount, test.unit,
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, val)
+		})
+	}
+}
+
 // TestHideTimestamp test correct timestamp hiding.
 func TestHideTimestamp(t *testing.T) {
 	timestamp := time.Unix(1_000_000, 0)
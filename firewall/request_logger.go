@@ -49,7 +49,11 @@ const (
 type RequestLogger struct {
 	actionsDB firewalldb.ActionsWriteDB
 
+	// shouldLogAction decides which requests are logged according to the
+	// current level. It can be replaced at runtime, so the levelMu mutex
+	// must be used when accessing it.
 	shouldLogAction func(ri *RequestInfo) (bool, bool)
+	levelMu         sync.RWMutex
 
 	// reqIDToAction is a map from request ID to an ActionLocator that can
 	// be used to find the corresponding action. This is used so that
@@ -63,6 +67,39 @@ type RequestLogger struct {
 func NewRequestLogger(cfg *RequestLoggerConfig,
 	actionsDB firewalldb.ActionsWriteDB) (*RequestLogger, error) {
 
+	shouldLogAction, err := shouldLogFunc(cfg.RequestLoggerLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RequestLogger{
+		shouldLogAction: shouldLogAction,
+		actionsDB:       actionsDB,
+		reqIDToAction:   make(map[uint64]*firewalldb.ActionLocator),
+	}, nil
+}
+
+// SetLevel changes the level of the request logger. The requests that were
+// already logged are still marked once their response arrives.
+func (r *RequestLogger) SetLevel(level RequestLoggerLevel) error {
+	shouldLogAction, err := shouldLogFunc(level)
+	if err != nil {
+		return err
+	}
+
+	r.levelMu.Lock()
+	defer r.levelMu.Unlock()
+
+	r.shouldLogAction = shouldLogAction
+
+	return nil
+}
+
+// shouldLogFunc returns a function that decides whether a request is logged at
+// the given level and whether its parameters are logged with it.
+func shouldLogFunc(level RequestLoggerLevel) (func(ri *RequestInfo) (bool,
+	bool), error) {
+
 	hasInterceptorCaveat := func(caveats []string) bool {
 		for _, c := range caveats {
 			if strings.HasPrefix(c, macaroons.CondLndCustom) {
@@ -73,42 +110,35 @@ func NewRequestLogger(cfg *RequestLoggerConfig,
 		return false
 	}
 
-	var shouldLogAction func(ri *RequestInfo) (bool, bool)
-	switch cfg.RequestLoggerLevel {
+	switch level {
 	// Only log requests that have an interceptor caveat attached.
 	case RequestLoggerLevelInterceptor:
-		shouldLogAction = func(ri *RequestInfo) (bool, bool) {
+		return func(ri *RequestInfo) (bool, bool) {
 			if hasInterceptorCaveat(ri.Caveats) {
 				return true, true
 			}
 
 			return false, false
-		}
+		}, nil
 
 	// Log all requests but only log request params if the request
 	// has an interceptor caveat.
 	case RequestLoggerLevelAll:
-		shouldLogAction = func(ri *RequestInfo) (bool, bool) {
+		return func(ri *RequestInfo) (bool, bool) {
 			return true, hasInterceptorCaveat(ri.Caveats)
-		}
+		}, nil
 
 	// Log all requests will all request parameters.
 	case RequestLoggerLevelFull:
-		shouldLogAction = func(ri *RequestInfo) (bool, bool) {
+		return func(ri *RequestInfo) (bool, bool) {
 			return true, true
-		}
+		}, nil
 
 	default:
 		return nil, fmt.Errorf("unknown request logger level: %s. "+
 			"Expected either 'interceptor', 'all' or 'full'",
-			cfg.RequestLoggerLevel)
+			level)
 	}
-
-	return &RequestLogger{
-		shouldLogAction: shouldLogAction,
-		actionsDB:       actionsDB,
-		reqIDToAction:   make(map[uint64]*firewalldb.ActionLocator),
-	}, nil
 }
 
 // Name returns the name of the interceptor.
@@ -145,7 +175,28 @@ func (r *RequestLogger) Intercept(_ context.Context,
 		return mid.RPCOk(req)
 	}
 
+	// The level might have changed since the request was logged, so the
+	// response of a logged request is always handed to MarkAction, which
+	// ignores the requests that weren't logged.
+	if ri.MWRequestType == MWRequestTypeResponse {
+		var (
+			state     = firewalldb.ActionStateDone
+			errReason string
+		)
+		if ri.IsError {
+			state = firewalldb.ActionStateError
+			errReason = mid.ParseResponseErr(ri.Serialized).Error()
+		}
+
+		return mid.RPCErr(
+			req, r.MarkAction(ri.RequestID, state, errReason),
+		)
+	}
+
+	r.levelMu.RLock()
 	shouldLogAction, withPayloadData := r.shouldLogAction(ri)
+	r.levelMu.RUnlock()
+
 	if !shouldLogAction {
 		return mid.RPCOk(req)
 	}
@@ -160,21 +211,6 @@ func (r *RequestLogger) Intercept(_ context.Context,
 	case MWRequestTypeRequest:
 		return mid.RPCErr(req, r.addNewAction(ri, withPayloadData))
 
-	// Parse and possibly manipulate outgoing responses.
-	case MWRequestTypeResponse:
-		var (
-			state     = firewalldb.ActionStateDone
-			errReason string
-		)
-		if ri.IsError {
-			state = firewalldb.ActionStateError
-			errReason = mid.ParseResponseErr(ri.Serialized).Error()
-		}
-
-		return mid.RPCErr(
-			req, r.MarkAction(ri.RequestID, state, errReason),
-		)
-
 	default:
 		return mid.RPCErrString(req, "invalid intercept type: %v", r)
 	}
@@ -231,6 +267,19 @@ func (r *RequestLogger) addNewAction(ri *RequestInfo,
 		}
 	}
 
+	// Every message a client sends on a stream is logged as an action of
+	// its own, so that it is accounted for by the firewall rules. The
+	// previous message of the stream has been accepted by now, so its
+	// action is marked as done if no response has done that yet.
+	if ri.Streaming {
+		err := r.MarkAction(
+			ri.RequestID, firewalldb.ActionStateDone, "",
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	id, err := r.actionsDB.AddAction(sessionID, action)
 	if err != nil {
 		return err
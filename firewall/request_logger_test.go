@@ -0,0 +1,73 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestLoggerStream tests that every client message sent on a stream is
+// logged as an action of its own and that all of them are completed.
+func TestRequestLoggerStream(t *testing.T) {
+	db, err := firewalldb.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	logger, err := NewRequestLogger(&RequestLoggerConfig{
+		RequestLoggerLevel: RequestLoggerLevelAll,
+	}, db)
+	require.NoError(t, err)
+
+	const uri = "/lnrpc.Lightning/SendPayment"
+	intercept := func(req *lnrpc.RPCMiddlewareRequest) {
+		req.RequestId = 1
+		resp, err := logger.Intercept(context.Background(), req)
+		require.NoError(t, err)
+		require.Empty(t, resp.GetFeedback().GetError())
+	}
+	sendMsg := func() {
+		intercept(&lnrpc.RPCMiddlewareRequest{
+			InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+				Request: &lnrpc.RPCMessage{
+					MethodFullUri: uri,
+					StreamRpc:     true,
+				},
+			},
+		})
+	}
+
+	intercept(&lnrpc.RPCMiddlewareRequest{
+		InterceptType: &lnrpc.RPCMiddlewareRequest_StreamAuth{
+			StreamAuth: &lnrpc.StreamAuth{
+				MethodFullUri: uri,
+			},
+		},
+	})
+
+	// The client sends two payments before the first response arrives.
+	sendMsg()
+	sendMsg()
+	intercept(&lnrpc.RPCMiddlewareRequest{
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Response{
+			Response: &lnrpc.RPCMessage{
+				MethodFullUri: uri,
+				StreamRpc:     true,
+			},
+		},
+	})
+
+	actions, _, _, err := db.ListActions(
+		nil, &firewalldb.ListActionsQuery{},
+	)
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	for _, action := range actions {
+		require.Equal(t, uri, action.RPCMethod)
+		require.Equal(t, firewalldb.ActionStateDone, action.State)
+	}
+}
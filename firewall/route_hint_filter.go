@@ -0,0 +1,156 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon.v2"
+)
+
+const (
+	// routeHintFilterName is the name of the RouteHintFilter interceptor.
+	routeHintFilterName = "lit-route-hint-filter"
+
+	// CondRouteHints is the name of the custom caveat that instructs lnd
+	// to send all requests with this caveat to the route hint filter. The
+	// value of the caveat is the route hint policy of the session.
+	CondRouteHints = "lit-route-hints"
+)
+
+// A compile-time assertion that RouteHintFilter is a
+// rpcmiddleware.RequestInterceptor.
+var _ mid.RequestInterceptor = (*RouteHintFilter)(nil)
+
+// RouteHintCaveat returns the caveat that makes the route hint filter apply
+// the given policy to all invoices created with a macaroon.
+func RouteHintCaveat(policy session.RouteHintPolicy) macaroon.Caveat {
+	return macaroon.Caveat{
+		Id: []byte(fmt.Sprintf("%s %s %s", macaroons.CondLndCustom,
+			CondRouteHints, policy)),
+	}
+}
+
+// RouteHintFilter is a RequestInterceptor that removes route hints from the
+// invoices created with a macaroon, so that they don't reveal the private
+// channels of the node. Which route hints are removed is determined by the
+// policy in the route hint caveat of the macaroon.
+type RouteHintFilter struct{}
+
+// NewRouteHintFilter returns a new instance of RouteHintFilter.
+func NewRouteHintFilter() *RouteHintFilter {
+	return &RouteHintFilter{}
+}
+
+// Name returns the name of the interceptor.
+func (r *RouteHintFilter) Name() string {
+	return routeHintFilterName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+func (r *RouteHintFilter) ReadOnly() bool {
+	return false
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (r *RouteHintFilter) CustomCaveatName() string {
+	return CondRouteHints
+}
+
+// Intercept processes an RPC middleware interception request and returns the
+// interception result which either accepts or rejects the intercepted message.
+func (r *RouteHintFilter) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	request, ok := req.InterceptType.(*lnrpc.RPCMiddlewareRequest_Request)
+	if !ok {
+		return mid.RPCOk(req)
+	}
+
+	uri := request.Request.MethodFullUri
+	if uri != "/lnrpc.Lightning/AddInvoice" &&
+		uri != "/invoicesrpc.Invoices/AddHoldInvoice" {
+
+		return mid.RPCOk(req)
+	}
+
+	ri, err := NewInfoFromRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing incoming RPC middleware "+
+			"interception request: %v", err)
+	}
+
+	policy, err := session.ParseRouteHintPolicy(
+		macaroons.GetCustomCaveatCondition(ri.Macaroon, CondRouteHints),
+	)
+	if err != nil {
+		return mid.RPCErr(req, err)
+	}
+
+	msg, err := mid.ParseProtobuf(
+		request.Request.TypeName, request.Request.Serialized,
+	)
+	if err != nil {
+		return mid.RPCErrString(req, "error parsing proto: %v", err)
+	}
+
+	replacement := filterRouteHints(msg, policy)
+	if replacement == nil {
+		return mid.RPCOk(req)
+	}
+
+	return mid.RPCReplacement(req, replacement)
+}
+
+// filterRouteHints returns a copy of the invoice request with the route hints
+// removed that the policy doesn't allow. It returns nil if the request doesn't
+// need to be changed.
+func filterRouteHints(msg proto.Message,
+	policy session.RouteHintPolicy) proto.Message {
+
+	if policy == session.RouteHintsAllow {
+		return nil
+	}
+
+	// Unsetting the private flag keeps lnd from adding route hints for its
+	// private channels. Only the strict policy also removes the route
+	// hints that the caller set explicitly.
+	stripExplicit := policy == session.RouteHintsStrip
+	switch m := msg.(type) {
+	case *lnrpc.Invoice:
+		if !m.Private && (!stripExplicit || len(m.RouteHints) == 0) {
+			return nil
+		}
+
+		filtered := proto.Clone(m).(*lnrpc.Invoice)
+		filtered.Private = false
+		if stripExplicit {
+			filtered.RouteHints = nil
+		}
+
+		return filtered
+
+	case *invoicesrpc.AddHoldInvoiceRequest:
+		if !m.Private && (!stripExplicit || len(m.RouteHints) == 0) {
+			return nil
+		}
+
+		filtered := proto.Clone(m).(*invoicesrpc.AddHoldInvoiceRequest)
+		filtered.Private = false
+		if stripExplicit {
+			filtered.RouteHints = nil
+		}
+
+		return filtered
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/rpcperms"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon.v2"
+)
+
+// TestRouteHintFilter tests that the route hints of invoice requests are
+// removed according to the policy of the route hint caveat.
+func TestRouteHintFilter(t *testing.T) {
+	hints := []*lnrpc.RouteHint{{
+		HopHints: []*lnrpc.HopHint{{
+			NodeId: "foo",
+			ChanId: 123,
+		}},
+	}}
+
+	filter := NewRouteHintFilter()
+	intercept := func(policy session.RouteHintPolicy, uri string,
+		msg proto.Message) *lnrpc.RPCMiddlewareResponse {
+
+		mac, err := macaroon.New(
+			[]byte("root key"), []byte("id"), "lnd",
+			macaroon.LatestVersion,
+		)
+		require.NoError(t, err)
+		require.NoError(t, mac.AddFirstPartyCaveat(
+			RouteHintCaveat(policy).Id,
+		))
+		macBytes, err := mac.MarshalBinary()
+		require.NoError(t, err)
+
+		rawMsg, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		interceptReq := &rpcperms.InterceptionRequest{
+			Type:            rpcperms.TypeRequest,
+			Macaroon:        mac,
+			RawMacaroon:     macBytes,
+			FullURI:         uri,
+			ProtoSerialized: rawMsg,
+			ProtoTypeName:   string(proto.MessageName(msg)),
+		}
+
+		mwReq, err := interceptReq.ToRPC(1, 2)
+		require.NoError(t, err)
+
+		resp, err := filter.Intercept(context.Background(), mwReq)
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	replacement := func(resp *lnrpc.RPCMiddlewareResponse,
+		msg proto.Message) proto.Message {
+
+		feedback := resp.GetFeedback()
+		require.NotNil(t, feedback)
+		require.Empty(t, feedback.Error)
+
+		if !feedback.ReplaceResponse {
+			return nil
+		}
+
+		require.NoError(t, proto.Unmarshal(
+			feedback.ReplacementSerialized, msg,
+		))
+
+		return msg
+	}
+
+	// The strict policy removes the explicit route hints and keeps lnd
+	// from adding its own.
+	resp := intercept(
+		session.RouteHintsStrip, "/lnrpc.Lightning/AddInvoice",
+		&lnrpc.Invoice{
+			Memo:       "foo",
+			Private:    true,
+			RouteHints: hints,
+		},
+	)
+	invoice := replacement(resp, &lnrpc.Invoice{}).(*lnrpc.Invoice)
+	require.Equal(t, "foo", invoice.Memo)
+	require.False(t, invoice.Private)
+	require.Empty(t, invoice.RouteHints)
+
+	// The explicit only policy keeps the route hints of the caller.
+	resp = intercept(
+		session.RouteHintsExplicitOnly,
+		"/invoicesrpc.Invoices/AddHoldInvoice",
+		&invoicesrpc.AddHoldInvoiceRequest{
+			Private:    true,
+			RouteHints: hints,
+		},
+	)
+	holdInvoice := replacement(
+		resp, &invoicesrpc.AddHoldInvoiceRequest{},
+	).(*invoicesrpc.AddHoldInvoiceRequest)
+	require.False(t, holdInvoice.Private)
+	require.Len(t, holdInvoice.RouteHints, 1)
+
+	// A request that doesn't reveal any private channels is passed
+	// through unchanged.
+	resp = intercept(
+		session.RouteHintsExplicitOnly, "/lnrpc.Lightning/AddInvoice",
+		&lnrpc.Invoice{RouteHints: hints},
+	)
+	require.Nil(t, replacement(resp, &lnrpc.Invoice{}))
+
+	// Other calls are passed through as well.
+	resp = intercept(
+		session.RouteHintsStrip, "/lnrpc.Lightning/GetInfo",
+		&lnrpc.GetInfoRequest{},
+	)
+	require.Nil(t, replacement(resp, &lnrpc.GetInfoRequest{}))
+}
@@ -2,17 +2,20 @@ package firewall
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
 	"github.com/lightninglabs/lightning-terminal/perms"
 	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
 	"github.com/lightninglabs/lightning-terminal/rules"
 	"github.com/lightninglabs/lightning-terminal/session"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightningnetwork/lnd/lnrpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -38,10 +41,33 @@ type RuleEnforcer struct {
 
 	nodeID [33]byte
 
-	routerClient lndclient.RouterClient
-	lndClient    lndclient.LightningClient
+	routerClient    lndclient.RouterClient
+	lndClient       lndclient.LightningClient
+	walletKitClient walletrpc.WalletKitClient
+	chainParams     *chaincfg.Params
 
-	ruleMgrs rules.ManagerSet
+	ruleMgrs      rules.ManagerSet
+	ruleBundles   ruleBundleGetter
+	ruleOverrides ruleOverrideGetter
+
+	// ruleMetrics persists the latency and denials of the evaluated rules.
+	// If it is nil, they are only exported as Prometheus metrics.
+	ruleMetrics firewalldb.RuleMetricsDB
+}
+
+// ruleBundleGetter defines the method that the RuleEnforcer uses to fetch the
+// current values of a rule bundle.
+type ruleBundleGetter interface {
+	// GetBundle returns the rule bundle with the given name.
+	GetBundle(name string) (*rules.Bundle, error)
+}
+
+// ruleOverrideGetter defines the method that the RuleEnforcer uses to fetch
+// the rule values that replace the values of an existing session.
+type ruleOverrideGetter interface {
+	// GetRuleOverrides returns the overridden rule values of the given
+	// session as a map from feature name to rule name to rule value.
+	GetRuleOverrides(id session.ID) (map[string]map[string]string, error)
 }
 
 // featurePerms defines the signature of a function that can be used to fetch
@@ -53,7 +79,11 @@ func NewRuleEnforcer(ruleDB firewalldb.RulesDB,
 	actionsDB firewalldb.ActionReadDBGetter, getFeaturePerms featurePerms,
 	permsMgr *perms.Manager, nodeID [33]byte,
 	routerClient lndclient.RouterClient,
-	lndClient lndclient.LightningClient, ruleMgrs rules.ManagerSet,
+	lndClient lndclient.LightningClient,
+	walletKitClient walletrpc.WalletKitClient,
+	chainParams *chaincfg.Params, ruleMgrs rules.ManagerSet,
+	ruleBundles ruleBundleGetter, ruleOverrides ruleOverrideGetter,
+	ruleMetrics firewalldb.RuleMetricsDB,
 	markActionErrored func(reqID uint64, reason string) error,
 	privMap firewalldb.NewPrivacyMapDB) *RuleEnforcer {
 
@@ -65,7 +95,12 @@ func NewRuleEnforcer(ruleDB firewalldb.RulesDB,
 		nodeID:            nodeID,
 		routerClient:      routerClient,
 		lndClient:         lndClient,
+		walletKitClient:   walletKitClient,
+		chainParams:       chainParams,
 		ruleMgrs:          ruleMgrs,
+		ruleBundles:       ruleBundles,
+		ruleOverrides:     ruleOverrides,
+		ruleMetrics:       ruleMetrics,
 		markActionErrored: markActionErrored,
 		newPrivMap:        privMap,
 	}
@@ -140,10 +175,14 @@ func (r *RuleEnforcer) Intercept(ctx context.Context,
 	}
 
 	switch ri.MWRequestType {
+	// Decide whether the stream may be established at all. Each client
+	// message sent on the stream is then checked on its own as a request.
 	case MWRequestTypeStreamAuth:
-		return mid.RPCOk(req)
+		return mid.RPCErr(req, r.handleStreamAuth(ctx, ri))
 
-	// Parse incoming requests and act on them.
+	// Parse incoming requests and act on them. For streams, this is done
+	// for every message the client sends, which decides whether the
+	// stream may continue.
 	case MWRequestTypeRequest:
 		replacement, err := r.handleRequest(ctx, ri)
 		if err != nil {
@@ -211,6 +250,61 @@ func (r *RuleEnforcer) Intercept(ctx context.Context,
 	}
 }
 
+// namedEnforcer is a rule Enforcer together with the name and the JSON encoded
+// values of the rule it enforces.
+type namedEnforcer struct {
+	rules.Enforcer
+
+	name   string
+	values string
+}
+
+// violation wraps the given error returned by the enforcer into a gRPC status
+// error that carries the name and values of the violated rule.
+func (n *namedEnforcer) violation(err error) error {
+	return litrpc.RuleViolationError(
+		fmt.Sprintf("rule violation: %v", err), n.name, n.values,
+	)
+}
+
+// handleStreamAuth gathers the rules that will need to be enforced for the
+// given feature and lets all of them that implement the rules.StreamEnforcer
+// interface decide whether the stream may be established.
+func (r *RuleEnforcer) handleStreamAuth(ctx context.Context,
+	ri *RequestInfo) error {
+
+	sessionID, err := session.IDFromMacaroon(ri.Macaroon)
+	if err != nil {
+		return fmt.Errorf("could not extract ID from macaroon")
+	}
+
+	enforcers, err := r.collectEnforcers(ri, sessionID)
+	if err != nil {
+		return fmt.Errorf("error parsing rules: %v", err)
+	}
+
+	var evals ruleEvaluations
+	defer func() {
+		evals.record(r.ruleMetrics)
+	}()
+
+	for _, enforcer := range enforcers {
+		streamEnforcer, ok := enforcer.Enforcer.(rules.StreamEnforcer)
+		if !ok {
+			continue
+		}
+
+		start := time.Now()
+		err := streamEnforcer.HandleStreamAuth(ctx, ri.URI)
+		evals.add(enforcer.name, start, err)
+		if err != nil {
+			return enforcer.violation(err)
+		}
+	}
+
+	return nil
+}
+
 // handleRequest gathers the rules that will need to enforced for the given
 // feature and runs the request against each of those.
 func (r *RuleEnforcer) handleRequest(ctx context.Context,
@@ -233,22 +327,33 @@ func (r *RuleEnforcer) handleRequest(ctx context.Context,
 		return nil, fmt.Errorf("error parsing proto: %v", err)
 	}
 
+	var evals ruleEvaluations
+	defer func() {
+		evals.record(r.ruleMetrics)
+	}()
+
+	// Each rule sees the request as the rules before it left it. The
+	// request is only replaced if at least one rule changed it.
+	var replaced bool
 	for _, rule := range rules {
+		start := time.Now()
 		newRequest, err := rule.HandleRequest(ctx, ri.URI, msg)
+		evals.add(rule.name, start, err)
 		if err != nil {
-			st := status.Errorf(
-				codes.ResourceExhausted, "rule violation: %v",
-				err,
-			)
-			return nil, st
+			return nil, rule.violation(err)
 		}
 
 		if newRequest != nil {
 			msg = newRequest
+			replaced = true
 		}
 	}
 
-	return nil, nil
+	if !replaced {
+		return nil, nil
+	}
+
+	return msg, nil
 }
 
 // handleResponse gathers the rules that will need to be enforced for the given
@@ -321,28 +426,107 @@ func (r *RuleEnforcer) handleErrorResponse(ctx context.Context,
 // collectRule initialises and returns all the Rules that need to be enforced
 // for the given request.
 func (r *RuleEnforcer) collectEnforcers(ri *RequestInfo, sessionID session.ID) (
-	[]rules.Enforcer, error) {
+	[]*namedEnforcer, error) {
 
 	ruleEnforcers := make(
-		[]rules.Enforcer, 0,
+		[]*namedEnforcer, 0,
 		len(ri.Rules.FeatureRules)+len(ri.Rules.SessionRules),
 	)
 
+	// If the feature follows a rule bundle, then the current values of
+	// the bundle take precedence over the values baked into the macaroon.
+	// The bundle values are stored in their real form and so the privacy
+	// mapper does not need to be consulted for them.
+	bundle, err := r.followedBundle(ri)
+	if err != nil {
+		return nil, err
+	}
+
+	// Values that were set for the session after it was created take
+	// precedence over both. They are stored in their real form too.
+	overrides, err := r.sessionOverrides(ri, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
 	for rule, value := range ri.Rules.FeatureRules[ri.MetaInfo.Feature] {
+		var (
+			valueBytes = []byte(value)
+			privacy    = ri.WithPrivacy
+		)
+		if override, ok := overrides[rule]; ok {
+			valueBytes = []byte(override)
+			privacy = false
+		} else if bundleValue, ok := bundle[rule]; ok {
+			valueBytes, err = rules.Marshal(bundleValue)
+			if err != nil {
+				return nil, err
+			}
+			privacy = false
+		}
+
 		r, err := r.initRule(
-			ri.RequestID, rule, []byte(value), ri.MetaInfo.Feature,
-			sessionID, false, ri.WithPrivacy,
+			ri.RequestID, rule, valueBytes, ri.MetaInfo.Feature,
+			sessionID, false, privacy,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		ruleEnforcers = append(ruleEnforcers, r)
+		ruleEnforcers = append(ruleEnforcers, &namedEnforcer{
+			Enforcer: r,
+			name:     rule,
+			values:   string(valueBytes),
+		})
 	}
 
 	return ruleEnforcers, nil
 }
 
+// followedBundle returns the current rule values of the bundle that the
+// feature of the given request follows, if any. If the bundle no longer
+// exists, the rule values in the macaroon are used.
+func (r *RuleEnforcer) followedBundle(ri *RequestInfo) (map[string]rules.Values,
+	error) {
+
+	bundleName, ok := ri.Rules.FeatureBundles[ri.MetaInfo.Feature]
+	if !ok || bundleName == "" || r.ruleBundles == nil {
+		return nil, nil
+	}
+
+	bundle, err := r.ruleBundles.GetBundle(bundleName)
+	if errors.Is(err, firewalldb.ErrRuleBundleNotFound) {
+		log.Warnf("Rule bundle %s followed by feature %s no longer "+
+			"exists, using macaroon rule values", bundleName,
+			ri.MetaInfo.Feature)
+
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not fetch rule bundle %s: %v",
+			bundleName, err)
+	}
+
+	return bundle.Rules, nil
+}
+
+// sessionOverrides returns the rule values that replace the values of the
+// feature of the given request for the given session, if any.
+func (r *RuleEnforcer) sessionOverrides(ri *RequestInfo,
+	sessionID session.ID) (map[string]string, error) {
+
+	if r.ruleOverrides == nil {
+		return nil, nil
+	}
+
+	overrides, err := r.ruleOverrides.GetRuleOverrides(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch rule overrides: %v",
+			err)
+	}
+
+	return overrides[ri.MetaInfo.Feature], nil
+}
+
 // initRule initialises a rule.Rule with any required config values.
 func (r *RuleEnforcer) initRule(reqID uint64, name string, value []byte,
 	featureName string, sessionID session.ID, sessionRule,
@@ -372,13 +556,15 @@ func (r *RuleEnforcer) initRule(reqID uint64, name string, value []byte,
 	}
 
 	cfg := &rules.ConfigImpl{
-		Stores:       rulesDB,
-		ActionsDB:    actionsDB,
-		MethodPerms:  r.permsMgr.URIPermissions,
-		NodeID:       r.nodeID,
-		RouterClient: r.routerClient,
-		LndClient:    r.lndClient,
-		ReqID:        int64(reqID),
+		Stores:          rulesDB,
+		ActionsDB:       actionsDB,
+		MethodPerms:     r.permsMgr.URIPermissions,
+		NodeID:          r.nodeID,
+		RouterClient:    r.routerClient,
+		LndClient:       r.lndClient,
+		WalletKitClient: r.walletKitClient,
+		ChainParams:     r.chainParams,
+		ReqID:           int64(reqID),
 	}
 
 	return r.ruleMgrs.InitEnforcer(cfg, name, ruleValues)
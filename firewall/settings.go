@@ -0,0 +1,298 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// Settings are the effective global firewall settings.
+type Settings struct {
+	// RequestLoggerLevel is the level of the request logger.
+	RequestLoggerLevel RequestLoggerLevel
+
+	// PrivacyMapper is true if the privacy mapper obfuscates the calls of
+	// the sessions that use it.
+	PrivacyMapper bool
+
+	// RuleEnforcement is true if the rules of the sessions are enforced.
+	RuleEnforcement bool
+
+	// Overridden lists the names of the settings that were changed at
+	// runtime and no longer follow the config file.
+	Overridden []string
+}
+
+// SettingsUpdate changes the global firewall settings. A nil field leaves the
+// setting unchanged.
+type SettingsUpdate struct {
+	// RequestLoggerLevel is the new level of the request logger.
+	RequestLoggerLevel *RequestLoggerLevel
+
+	// PrivacyMapper enables or disables the privacy mapper.
+	PrivacyMapper *bool
+
+	// RuleEnforcement enables or disables the enforcement of the rules.
+	RuleEnforcement *bool
+
+	// Reset drops all settings that were changed at runtime before the
+	// update is applied, so that the config file applies again.
+	Reset bool
+}
+
+// The names of the settings that can be changed at runtime.
+const (
+	settingRequestLoggerLevel = "request_logger_level"
+	settingPrivacyMapper      = "privacy_mapper"
+	settingRuleEnforcement    = "rule_enforcement"
+)
+
+// SettingsManager keeps the global firewall settings that can be changed at
+// runtime. The changes are persisted and take precedence over the config
+// file, also after a restart.
+type SettingsManager struct {
+	cfg *Config
+	db  firewalldb.SettingsDB
+
+	// requestLogger is the request logger the level is applied to. It is
+	// nil until the interceptors are created.
+	requestLogger *RequestLogger
+
+	overrides *firewalldb.Settings
+	mu        sync.RWMutex
+}
+
+// NewSettingsManager creates a new settings manager that applies the stored
+// changes on top of the given config.
+func NewSettingsManager(cfg *Config,
+	db firewalldb.SettingsDB) (*SettingsManager, error) {
+
+	overrides, err := db.FirewallSettings()
+	if err != nil {
+		return nil, fmt.Errorf("error loading firewall settings: %v",
+			err)
+	}
+
+	return &SettingsManager{
+		cfg:       cfg,
+		db:        db,
+		overrides: overrides,
+	}, nil
+}
+
+// SetRequestLogger sets the request logger the level setting is applied to
+// and applies the current level.
+func (m *SettingsManager) SetRequestLogger(logger *RequestLogger) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requestLogger = logger
+
+	return logger.SetLevel(m.settings().RequestLoggerLevel)
+}
+
+// Settings returns the effective firewall settings.
+func (m *SettingsManager) Settings() Settings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.settings()
+}
+
+// settings returns the effective firewall settings.
+//
+// NOTE: the mu mutex must be held when calling this method.
+func (m *SettingsManager) settings() Settings {
+	settings := Settings{
+		RequestLoggerLevel: m.cfg.RequestLogger.RequestLoggerLevel,
+		PrivacyMapper:      true,
+		RuleEnforcement:    true,
+	}
+
+	if m.overrides.RequestLoggerLevel != nil {
+		settings.RequestLoggerLevel = RequestLoggerLevel(
+			*m.overrides.RequestLoggerLevel,
+		)
+		settings.Overridden = append(
+			settings.Overridden, settingRequestLoggerLevel,
+		)
+	}
+
+	if m.overrides.PrivacyMapper != nil {
+		settings.PrivacyMapper = *m.overrides.PrivacyMapper
+		settings.Overridden = append(
+			settings.Overridden, settingPrivacyMapper,
+		)
+	}
+
+	if m.overrides.RuleEnforcement != nil {
+		settings.RuleEnforcement = *m.overrides.RuleEnforcement
+		settings.Overridden = append(
+			settings.Overridden, settingRuleEnforcement,
+		)
+	}
+
+	return settings
+}
+
+// Update applies and persists the given changes and returns the new effective
+// settings.
+func (m *SettingsManager) Update(update *SettingsUpdate) (Settings, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overrides := *m.overrides
+	if update.Reset {
+		overrides = firewalldb.Settings{}
+	}
+
+	if update.RequestLoggerLevel != nil {
+		level := *update.RequestLoggerLevel
+		if _, err := shouldLogFunc(level); err != nil {
+			return Settings{}, err
+		}
+
+		levelStr := string(level)
+		overrides.RequestLoggerLevel = &levelStr
+	}
+
+	if update.PrivacyMapper != nil {
+		enabled := *update.PrivacyMapper
+		overrides.PrivacyMapper = &enabled
+	}
+
+	if update.RuleEnforcement != nil {
+		enabled := *update.RuleEnforcement
+		overrides.RuleEnforcement = &enabled
+	}
+
+	if err := m.db.StoreFirewallSettings(&overrides); err != nil {
+		return Settings{}, fmt.Errorf("error storing firewall "+
+			"settings: %v", err)
+	}
+	m.overrides = &overrides
+
+	settings := m.settings()
+	if m.requestLogger != nil {
+		err := m.requestLogger.SetLevel(settings.RequestLoggerLevel)
+		if err != nil {
+			return Settings{}, err
+		}
+	}
+
+	log.Infof("Firewall settings changed: request logger level %s, "+
+		"privacy mapper enabled %v, rule enforcement enabled %v",
+		settings.RequestLoggerLevel, settings.PrivacyMapper,
+		settings.RuleEnforcement)
+
+	return settings, nil
+}
+
+// WrapPrivacyMapper wraps the given privacy mapper so that it is skipped while
+// it is disabled.
+func (m *SettingsManager) WrapPrivacyMapper(
+	interceptor mid.RequestInterceptor) mid.RequestInterceptor {
+
+	return newToggledInterceptor(interceptor, func() bool {
+		return m.Settings().PrivacyMapper
+	})
+}
+
+// WrapRuleEnforcer wraps the given rule enforcer so that it is skipped while
+// the rule enforcement is disabled.
+func (m *SettingsManager) WrapRuleEnforcer(
+	interceptor mid.RequestInterceptor) mid.RequestInterceptor {
+
+	return newToggledInterceptor(interceptor, func() bool {
+		return m.Settings().RuleEnforcement
+	})
+}
+
+// toggledInterceptor is an interceptor that only hands the calls to the
+// interceptor it wraps while it is enabled, and accepts them unchanged
+// otherwise. For unary calls, the decision is made when the request arrives
+// and sticks with the call until its response, so that a response is never
+// rewritten without its request or the other way around.
+type toggledInterceptor struct {
+	mid.RequestInterceptor
+
+	enabled func() bool
+
+	// skipped holds the IDs of the unary requests that were accepted
+	// without calling the wrapped interceptor and whose response didn't
+	// arrive yet. The mu mutex must be used when accessing this map.
+	skipped map[uint64]struct{}
+	mu      sync.Mutex
+}
+
+// newToggledInterceptor wraps the given interceptor so that it is only called
+// while enabled returns true.
+func newToggledInterceptor(interceptor mid.RequestInterceptor,
+	enabled func() bool) *toggledInterceptor {
+
+	return &toggledInterceptor{
+		RequestInterceptor: interceptor,
+		enabled:            enabled,
+		skipped:            make(map[uint64]struct{}),
+	}
+}
+
+// Intercept hands the message to the wrapped interceptor if it is enabled, or,
+// for the response of a unary call, if its request was handed to it.
+//
+// NOTE: This is part of the rpcmiddleware.RequestInterceptor interface.
+func (i *toggledInterceptor) Intercept(ctx context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	var (
+		msg        *lnrpc.RPCMessage
+		isResponse bool
+	)
+	switch t := req.InterceptType.(type) {
+	case *lnrpc.RPCMiddlewareRequest_Request:
+		msg = t.Request
+
+	case *lnrpc.RPCMiddlewareRequest_Response:
+		msg = t.Response
+		isResponse = true
+	}
+
+	// The end of a stream isn't known, so the stream authentication and
+	// the messages of streams always follow the current state.
+	if msg == nil || msg.StreamRpc {
+		if !i.enabled() {
+			return mid.RPCOk(req)
+		}
+
+		return i.RequestInterceptor.Intercept(ctx, req)
+	}
+
+	i.mu.Lock()
+	if isResponse {
+		_, skipped := i.skipped[req.RequestId]
+		delete(i.skipped, req.RequestId)
+		i.mu.Unlock()
+
+		if skipped {
+			return mid.RPCOk(req)
+		}
+
+		return i.RequestInterceptor.Intercept(ctx, req)
+	}
+
+	enabled := i.enabled()
+	if !enabled {
+		i.skipped[req.RequestId] = struct{}{}
+	}
+	i.mu.Unlock()
+
+	if !enabled {
+		return mid.RPCOk(req)
+	}
+
+	return i.RequestInterceptor.Intercept(ctx, req)
+}
@@ -0,0 +1,150 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSettingsManager tests that the settings changed at runtime take
+// precedence over the config, are persisted and can be reset.
+func TestSettingsManager(t *testing.T) {
+	db, err := firewalldb.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	cfg := DefaultConfig()
+	mgr, err := NewSettingsManager(cfg, db)
+	require.NoError(t, err)
+	require.Equal(t, Settings{
+		RequestLoggerLevel: RequestLoggerLevelInterceptor,
+		PrivacyMapper:      true,
+		RuleEnforcement:    true,
+	}, mgr.Settings())
+
+	logger, err := NewRequestLogger(cfg.RequestLogger, db)
+	require.NoError(t, err)
+	require.NoError(t, mgr.SetRequestLogger(logger))
+
+	// An unknown level is rejected without changing anything.
+	unknown := RequestLoggerLevel("everything")
+	_, err = mgr.Update(&SettingsUpdate{RequestLoggerLevel: &unknown})
+	require.ErrorContains(t, err, "unknown request logger level")
+
+	var (
+		full     = RequestLoggerLevel(RequestLoggerLevelFull)
+		disabled = false
+	)
+	settings, err := mgr.Update(&SettingsUpdate{
+		RequestLoggerLevel: &full,
+		RuleEnforcement:    &disabled,
+	})
+	require.NoError(t, err)
+	require.Equal(t, Settings{
+		RequestLoggerLevel: RequestLoggerLevelFull,
+		PrivacyMapper:      true,
+		RuleEnforcement:    false,
+		Overridden: []string{
+			settingRequestLoggerLevel, settingRuleEnforcement,
+		},
+	}, settings)
+
+	// The request logger now logs calls without an interceptor caveat.
+	log, _ := logger.shouldLogAction(&RequestInfo{})
+	require.True(t, log)
+
+	// The changes survive a restart.
+	mgr, err = NewSettingsManager(cfg, db)
+	require.NoError(t, err)
+	require.Equal(t, settings, mgr.Settings())
+
+	// A reset makes the config apply again, except for the changes made
+	// in the same update.
+	settings, err = mgr.Update(&SettingsUpdate{
+		PrivacyMapper: &disabled,
+		Reset:         true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, Settings{
+		RequestLoggerLevel: RequestLoggerLevelInterceptor,
+		PrivacyMapper:      false,
+		RuleEnforcement:    true,
+		Overridden:         []string{settingPrivacyMapper},
+	}, settings)
+}
+
+// countingInterceptor counts the messages it is called for.
+type countingInterceptor struct {
+	mid.RequestInterceptor
+
+	calls int
+}
+
+// Intercept counts the message and accepts it.
+func (c *countingInterceptor) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	c.calls++
+
+	return mid.RPCOk(req)
+}
+
+// TestToggledInterceptor tests that a unary call sticks with the state the
+// interceptor had when its request arrived, while the messages of streams
+// follow the current state.
+func TestToggledInterceptor(t *testing.T) {
+	var (
+		wrapped = &countingInterceptor{}
+		enabled = true
+	)
+	toggled := newToggledInterceptor(wrapped, func() bool {
+		return enabled
+	})
+
+	intercept := func(id uint64, response, stream bool) {
+		msg := &lnrpc.RPCMessage{StreamRpc: stream}
+		req := &lnrpc.RPCMiddlewareRequest{
+			RequestId: id,
+			InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+				Request: msg,
+			},
+		}
+		if response {
+			req.InterceptType =
+				&lnrpc.RPCMiddlewareRequest_Response{
+					Response: msg,
+				}
+		}
+
+		_, err := toggled.Intercept(context.Background(), req)
+		require.NoError(t, err)
+	}
+
+	// A request that arrived while enabled has its response handled
+	// too, even if the interceptor is disabled in between.
+	intercept(1, false, false)
+	enabled = false
+	intercept(1, true, false)
+	require.Equal(t, 2, wrapped.calls)
+
+	// A request that arrived while disabled has its response skipped,
+	// even if the interceptor is enabled in between.
+	intercept(2, false, false)
+	enabled = true
+	intercept(2, true, false)
+	require.Equal(t, 2, wrapped.calls)
+	require.Empty(t, toggled.skipped)
+
+	// Stream messages follow the current state.
+	intercept(3, false, true)
+	enabled = false
+	intercept(3, true, true)
+	require.Equal(t, 3, wrapped.calls)
+	require.Empty(t, toggled.skipped)
+}
@@ -0,0 +1,445 @@
+package firewalldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/lightningnetwork/lnd/tlv"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	typeDigestSeq        tlv.Type = 1
+	typeDigestSessionID  tlv.Type = 2
+	typeDigestActionID   tlv.Type = 3
+	typeDigestActionHash tlv.Type = 4
+	typeDigestPrevHash   tlv.Type = 5
+	typeDigestPubKey     tlv.Type = 6
+	typeDigestSignature  tlv.Type = 7
+)
+
+/*
+	If action signing is enabled, every write of an action also appends a
+	signed digest to the action log chain:
+
+	actions-bucket -> action-digests -> <seq> -> serialised ActionDigest
+*/
+
+var (
+	// actionDigestsKey is the key used for the sub-bucket containing the
+	// signed action digests.
+	actionDigestsKey = []byte("action-digests")
+)
+
+// ActionSigner signs the digests of the action log.
+type ActionSigner interface {
+	// PubKey returns the public key that the signatures can be verified
+	// with.
+	PubKey() *btcec.PublicKey
+
+	// SignDigest signs the given digest. The signature must be a DER
+	// encoded ECDSA signature over the SHA256 hash of the digest, which is
+	// what lnd's SignMessage RPC creates.
+	SignDigest(digest [32]byte) ([]byte, error)
+}
+
+// ActionDigest is an entry of the action log chain. Each time an action is
+// added or its state changes, a new entry is appended that commits to the
+// serialised action and to the previous entry.
+type ActionDigest struct {
+	// Seq is the position of the entry in the chain, starting at 1.
+	Seq uint64
+
+	// Locator identifies the action the entry belongs to.
+	Locator ActionLocator
+
+	// ActionHash is the SHA256 hash of the serialised action.
+	ActionHash [32]byte
+
+	// PrevHash is the hash of the previous entry or all zeros for the
+	// first entry.
+	PrevHash [32]byte
+
+	// PubKey is the serialised public key that signed the entry.
+	PubKey [33]byte
+
+	// Signature is the signature over the hash of the entry.
+	Signature []byte
+}
+
+// Hash returns the hash of the entry that is signed and that the next entry
+// commits to. It covers all fields except for the signature.
+func (d *ActionDigest) Hash() ([32]byte, error) {
+	var buf bytes.Buffer
+	if err := serializeActionDigest(&buf, d, false); err != nil {
+		return [32]byte{}, err
+	}
+
+	return sha256.Sum256(buf.Bytes()), nil
+}
+
+// VerifyDigestSignature checks that the signature is a valid signature of the
+// given public key over the digest.
+func VerifyDigestSignature(digest [32]byte, sig []byte,
+	pubKey *btcec.PublicKey) error {
+
+	parsedSig, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	msgHash := sha256.Sum256(digest[:])
+	if !parsedSig.Verify(msgHash[:], pubKey) {
+		return errors.New("signature doesn't match")
+	}
+
+	return nil
+}
+
+// SetActionSigner enables the signing of the action log with the given
+// signer. It must be called before any actions are recorded.
+func (db *DB) SetActionSigner(signer ActionSigner) {
+	db.actionSigner = signer
+}
+
+// appendActionDigest appends a signed digest of the given serialised action to
+// the action log chain if action signing is enabled.
+func (db *DB) appendActionDigest(mainActionsBucket *bbolt.Bucket,
+	locator *ActionLocator, actionBytes []byte) error {
+
+	if db.actionSigner == nil {
+		return nil
+	}
+
+	digestsBucket, err := mainActionsBucket.CreateBucketIfNotExists(
+		actionDigestsKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	digest := &ActionDigest{
+		Locator:    *locator,
+		ActionHash: sha256.Sum256(actionBytes),
+	}
+	copy(digest.PubKey[:], db.actionSigner.PubKey().SerializeCompressed())
+
+	// The new entry commits to the last entry of the chain.
+	_, lastBytes := digestsBucket.Cursor().Last()
+	if lastBytes != nil {
+		last, err := deserializeActionDigest(bytes.NewReader(lastBytes))
+		if err != nil {
+			return err
+		}
+
+		digest.PrevHash, err = last.Hash()
+		if err != nil {
+			return err
+		}
+	}
+
+	digest.Seq, err = digestsBucket.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	hash, err := digest.Hash()
+	if err != nil {
+		return err
+	}
+
+	digest.Signature, err = db.actionSigner.SignDigest(hash)
+	if err != nil {
+		return fmt.Errorf("error signing action digest: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := serializeActionDigest(&buf, digest, true); err != nil {
+		return err
+	}
+
+	var seq [8]byte
+	byteOrder.PutUint64(seq[:], digest.Seq)
+
+	return digestsBucket.Put(seq[:], buf.Bytes())
+}
+
+// ActionLogReport is the result of the verification of the action log chain.
+type ActionLogReport struct {
+	// Valid is true if the chain and all signed actions are intact.
+	Valid bool
+
+	// Failure describes why the verification failed.
+	Failure string
+
+	// FailedSeq is the sequence number of the chain entry where the
+	// verification failed, if the failure relates to a specific entry.
+	FailedSeq uint64
+
+	// NumEntries is the number of entries in the chain.
+	NumEntries uint64
+
+	// NumSignedActions is the number of actions that are covered by the
+	// chain.
+	NumSignedActions uint64
+
+	// NumUnsignedActions is the number of actions that were recorded
+	// while action signing was disabled.
+	NumUnsignedActions uint64
+
+	// TipHash is the hash of the last entry of the chain.
+	TipHash [32]byte
+
+	// PubKeys are the distinct public keys that signed the entries, in the
+	// order they were first used.
+	PubKeys [][33]byte
+}
+
+// fail marks the report as invalid.
+func (r *ActionLogReport) fail(seq uint64, format string,
+	args ...interface{}) {
+
+	r.Valid = false
+	r.FailedSeq = seq
+	r.Failure = fmt.Sprintf(format, args...)
+}
+
+// VerifyActionLog walks the action log chain and checks that every entry
+// commits to its predecessor, that every signature is valid and that the
+// current state of every signed action matches its last entry. A failed
+// verification is reported in the returned report, an error is only returned
+// if the database couldn't be read.
+func (db *DB) VerifyActionLog() (*ActionLogReport, error) {
+	report := &ActionLogReport{Valid: true}
+
+	err := db.View(func(tx *bbolt.Tx) error {
+		mainActionsBucket, err := getBucket(tx, actionsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		actionsBucket := mainActionsBucket.Bucket(actionsKey)
+		if actionsBucket == nil {
+			return ErrNoSuchKeyFound
+		}
+
+		// latest maps each signed action to the hash of its last
+		// entry.
+		latest := make(map[ActionLocator][32]byte)
+
+		digestsBucket := mainActionsBucket.Bucket(actionDigestsKey)
+		if digestsBucket != nil {
+			verifyChain(digestsBucket, report, latest)
+			if !report.Valid {
+				return nil
+			}
+		}
+
+		report.NumSignedActions = uint64(len(latest))
+
+		// Finally, check that the signed actions weren't changed or
+		// removed since their last entry and count the unsigned ones.
+		err = actionsBucket.ForEach(func(sessionID, v []byte) error {
+			if v != nil || !report.Valid {
+				return nil
+			}
+
+			var locator ActionLocator
+			copy(locator.SessionID[:], sessionID)
+
+			sessBucket := actionsBucket.Bucket(sessionID)
+			return sessBucket.ForEach(func(k, v []byte) error {
+				locator.ActionID = byteOrder.Uint64(k)
+				verifyAction(report, latest, locator, v)
+
+				return nil
+			})
+		})
+		if err != nil || !report.Valid {
+			return err
+		}
+
+		// Any entries left in the map belong to removed actions.
+		for locator := range latest {
+			report.fail(0, "action %d of session %x was removed",
+				locator.ActionID, locator.SessionID[:])
+
+			break
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// verifyAction checks that the serialised action matches the last entry of
+// the chain that belongs to it. Verified actions are removed from the latest
+// map, so that the remaining entries belong to removed actions.
+func verifyAction(report *ActionLogReport, latest map[ActionLocator][32]byte,
+	locator ActionLocator, actionBytes []byte) {
+
+	if !report.Valid {
+		return
+	}
+
+	hash, ok := latest[locator]
+	if !ok {
+		report.NumUnsignedActions++
+		return
+	}
+	delete(latest, locator)
+
+	if sha256.Sum256(actionBytes) != hash {
+		report.fail(0, "action %d of session %x was modified",
+			locator.ActionID, locator.SessionID[:])
+	}
+}
+
+// verifyChain checks the links and signatures of all entries in the digests
+// bucket. It records the last action hash of each action in the latest map.
+func verifyChain(digestsBucket *bbolt.Bucket, report *ActionLogReport,
+	latest map[ActionLocator][32]byte) {
+
+	var (
+		prevHash [32]byte
+		pubKeys  = make(map[[33]byte]*btcec.PublicKey)
+		cursor   = digestsBucket.Cursor()
+	)
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		expectedSeq := report.NumEntries + 1
+		if byteOrder.Uint64(k) != expectedSeq {
+			report.fail(expectedSeq, "entry %d is missing",
+				expectedSeq)
+			return
+		}
+
+		digest, err := deserializeActionDigest(bytes.NewReader(v))
+		if err != nil {
+			report.fail(expectedSeq, "entry can't be decoded: %v",
+				err)
+			return
+		}
+
+		if digest.Seq != expectedSeq {
+			report.fail(expectedSeq, "entry has sequence number %d",
+				digest.Seq)
+			return
+		}
+
+		if digest.PrevHash != prevHash {
+			report.fail(expectedSeq, "entry doesn't commit to the "+
+				"previous entry")
+			return
+		}
+
+		pubKey, ok := pubKeys[digest.PubKey]
+		if !ok {
+			pubKey, err = btcec.ParsePubKey(digest.PubKey[:])
+			if err != nil {
+				report.fail(expectedSeq, "invalid public key: "+
+					"%v", err)
+				return
+			}
+
+			pubKeys[digest.PubKey] = pubKey
+			report.PubKeys = append(report.PubKeys, digest.PubKey)
+		}
+
+		hash, err := digest.Hash()
+		if err != nil {
+			report.fail(expectedSeq, "entry can't be hashed: %v",
+				err)
+			return
+		}
+
+		err = VerifyDigestSignature(hash, digest.Signature, pubKey)
+		if err != nil {
+			report.fail(expectedSeq, "%v", err)
+			return
+		}
+
+		latest[digest.Locator] = digest.ActionHash
+		prevHash = hash
+		report.NumEntries++
+		report.TipHash = hash
+	}
+}
+
+// serializeActionDigest binary serializes the given digest to the writer using
+// the tlv format. The signature is only included if withSig is set.
+func serializeActionDigest(w io.Writer, d *ActionDigest, withSig bool) error {
+	var (
+		seq        = d.Seq
+		sessionID  = d.Locator.SessionID[:]
+		actionID   = d.Locator.ActionID
+		actionHash = d.ActionHash
+		prevHash   = d.PrevHash
+		pubKey     = d.PubKey[:]
+		sig        = d.Signature
+	)
+
+	tlvRecords := []tlv.Record{
+		tlv.MakePrimitiveRecord(typeDigestSeq, &seq),
+		tlv.MakePrimitiveRecord(typeDigestSessionID, &sessionID),
+		tlv.MakePrimitiveRecord(typeDigestActionID, &actionID),
+		tlv.MakePrimitiveRecord(typeDigestActionHash, &actionHash),
+		tlv.MakePrimitiveRecord(typeDigestPrevHash, &prevHash),
+		tlv.MakePrimitiveRecord(typeDigestPubKey, &pubKey),
+	}
+	if withSig {
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeDigestSignature, &sig,
+		))
+	}
+
+	tlvStream, err := tlv.NewStream(tlvRecords...)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+// deserializeActionDigest deserializes a digest from the given reader,
+// expecting the data to be encoded in the tlv format.
+func deserializeActionDigest(r io.Reader) (*ActionDigest, error) {
+	var (
+		d                 ActionDigest
+		sessionID, pubKey []byte
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeDigestSeq, &d.Seq),
+		tlv.MakePrimitiveRecord(typeDigestSessionID, &sessionID),
+		tlv.MakePrimitiveRecord(typeDigestActionID, &d.Locator.ActionID),
+		tlv.MakePrimitiveRecord(typeDigestActionHash, &d.ActionHash),
+		tlv.MakePrimitiveRecord(typeDigestPrevHash, &d.PrevHash),
+		tlv.MakePrimitiveRecord(typeDigestPubKey, &pubKey),
+		tlv.MakePrimitiveRecord(typeDigestSignature, &d.Signature),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tlvStream.DecodeWithParsedTypes(r); err != nil {
+		return nil, err
+	}
+
+	if len(sessionID) != len(d.Locator.SessionID) ||
+		len(pubKey) != len(d.PubKey) {
+
+		return nil, errors.New("invalid digest encoding")
+	}
+	copy(d.Locator.SessionID[:], sessionID)
+	copy(d.PubKey[:], pubKey)
+
+	return &d, nil
+}
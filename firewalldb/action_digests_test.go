@@ -0,0 +1,174 @@
+package firewalldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+// testSigner is an ActionSigner that signs with a local private key the same
+// way lnd's SignMessage RPC does.
+type testSigner struct {
+	privKey *btcec.PrivateKey
+}
+
+func (s *testSigner) PubKey() *btcec.PublicKey {
+	return s.privKey.PubKey()
+}
+
+func (s *testSigner) SignDigest(digest [32]byte) ([]byte, error) {
+	msgHash := sha256.Sum256(digest[:])
+	return ecdsa.Sign(s.privKey, msgHash[:]).Serialize(), nil
+}
+
+// TestVerifyActionLog tests that the action log chain is recorded and that
+// changes to the actions or the chain are detected.
+func TestVerifyActionLog(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sessionID := [4]byte{1, 1, 1, 1}
+	newAction := func() *Action {
+		return &Action{
+			ActorName:   "Autopilot",
+			FeatureName: "auto-fees",
+			RPCMethod:   "UpdateChanPolicy",
+			AttemptedAt: time.Unix(32100, 0),
+			State:       ActionStateInit,
+		}
+	}
+
+	// An empty log is valid.
+	report, err := db.VerifyActionLog()
+	require.NoError(t, err)
+	require.True(t, report.Valid)
+	require.Zero(t, report.NumEntries)
+
+	// Actions recorded before signing is enabled are reported as unsigned.
+	_, err = db.AddAction(sessionID, newAction())
+	require.NoError(t, err)
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	db.SetActionSigner(&testSigner{privKey: privKey})
+
+	id, err := db.AddAction(sessionID, newAction())
+	require.NoError(t, err)
+	_, err = db.AddAction(sessionID, newAction())
+	require.NoError(t, err)
+
+	err = db.SetActionState(&ActionLocator{
+		SessionID: sessionID,
+		ActionID:  id,
+	}, ActionStateDone, "")
+	require.NoError(t, err)
+
+	report, err = db.VerifyActionLog()
+	require.NoError(t, err)
+	require.True(t, report.Valid, report.Failure)
+	require.EqualValues(t, 3, report.NumEntries)
+	require.EqualValues(t, 2, report.NumSignedActions)
+	require.EqualValues(t, 1, report.NumUnsignedActions)
+	require.Len(t, report.PubKeys, 1)
+	require.Equal(
+		t, privKey.PubKey().SerializeCompressed(), report.PubKeys[0][:],
+	)
+	require.NotEqual(t, [32]byte{}, report.TipHash)
+
+	// tamper replaces the value of the key in the given bucket with the
+	// result of f, verifies the log and then restores the original value.
+	tamper := func(bucketPath [][]byte, key []byte,
+		f func(v []byte) []byte) *ActionLogReport {
+
+		var original []byte
+		update := func(change bool) {
+			err := db.Update(func(tx *bbolt.Tx) error {
+				bucket := tx.Bucket(bucketPath[0])
+				for _, name := range bucketPath[1:] {
+					bucket = bucket.Bucket(name)
+				}
+
+				if change {
+					original = append(
+						[]byte(nil), bucket.Get(key)...,
+					)
+					return bucket.Put(key, f(original))
+				}
+
+				return bucket.Put(key, original)
+			})
+			require.NoError(t, err)
+		}
+
+		update(true)
+		report, err := db.VerifyActionLog()
+		require.NoError(t, err)
+		update(false)
+
+		return report
+	}
+
+	actionKey := make([]byte, 8)
+	byteOrder.PutUint64(actionKey, id)
+	seqKey := make([]byte, 8)
+	byteOrder.PutUint64(seqKey, 2)
+
+	// Changing a signed action is detected.
+	report = tamper(
+		[][]byte{actionsBucketKey, actionsKey, sessionID[:]},
+		actionKey, func(v []byte) []byte {
+			var buf bytes.Buffer
+			action := newAction()
+			action.State = ActionStateError
+			require.NoError(t, SerializeAction(&buf, action))
+
+			return buf.Bytes()
+		},
+	)
+	require.False(t, report.Valid)
+	require.Contains(t, report.Failure, "was modified")
+
+	// Changing an entry of the chain invalidates its signature.
+	report = tamper(
+		[][]byte{actionsBucketKey, actionDigestsKey}, seqKey,
+		func(v []byte) []byte {
+			digest, err := deserializeActionDigest(
+				bytes.NewReader(v),
+			)
+			require.NoError(t, err)
+			digest.ActionHash[0] ^= 1
+
+			var buf bytes.Buffer
+			err = serializeActionDigest(&buf, digest, true)
+			require.NoError(t, err)
+
+			return buf.Bytes()
+		},
+	)
+	require.False(t, report.Valid)
+	require.EqualValues(t, 2, report.FailedSeq)
+	require.Contains(t, report.Failure, "signature doesn't match")
+
+	// Removing an entry of the chain is detected.
+	err = db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(actionsBucketKey).Bucket(
+			actionDigestsKey,
+		).Delete(seqKey)
+	})
+	require.NoError(t, err)
+
+	report, err = db.VerifyActionLog()
+	require.NoError(t, err)
+	require.False(t, report.Valid)
+	require.EqualValues(t, 2, report.FailedSeq)
+	require.Contains(t, report.Failure, "is missing")
+}
@@ -0,0 +1,130 @@
+package firewalldb
+
+import (
+	"bytes"
+
+	"go.etcd.io/bbolt"
+)
+
+// SubscribeActions returns a channel that receives a value whenever an action
+// is added or the state of an action changes. Changes that happen while a
+// value is still waiting to be received are coalesced, so the subscriber must
+// look up what changed. The returned function must be called to cancel the
+// subscription.
+func (db *DB) SubscribeActions() (<-chan struct{}, func()) {
+	db.subMtx.Lock()
+	defer db.subMtx.Unlock()
+
+	sub := make(chan struct{}, 1)
+	db.actionSubs[sub] = struct{}{}
+
+	cancel := func() {
+		db.subMtx.Lock()
+		defer db.subMtx.Unlock()
+
+		if _, ok := db.actionSubs[sub]; ok {
+			delete(db.actionSubs, sub)
+			close(sub)
+		}
+	}
+
+	return sub, cancel
+}
+
+// notifyActionSubs signals all action subscribers that the actions changed.
+func (db *DB) notifyActionSubs() {
+	db.subMtx.Lock()
+	defer db.subMtx.Unlock()
+
+	for sub := range db.actionSubs {
+		// A subscriber that hasn't picked up the last change yet will
+		// look up all changes anyway.
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// LastActionIndex returns the index of the most recent action in the index of
+// all actions. It returns 0 if there are no actions yet.
+func (db *DB) LastActionIndex() (uint64, error) {
+	var lastIndex uint64
+	err := db.View(func(tx *bbolt.Tx) error {
+		mainActionsBucket, err := getBucket(tx, actionsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		actionsIndexBucket := mainActionsBucket.Bucket(actionsIndex)
+		if actionsIndexBucket == nil {
+			return ErrNoSuchKeyFound
+		}
+
+		indexKey, _ := actionsIndexBucket.Cursor().Last()
+		if indexKey != nil {
+			lastIndex = byteOrder.Uint64(indexKey)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lastIndex, nil
+}
+
+// ActionsByIndex returns the actions with the given positions in the index of
+// all actions. Indexes that don't exist are skipped.
+func (db *DB) ActionsByIndex(indexes []uint64) ([]*Action, error) {
+	var actions []*Action
+	err := db.View(func(tx *bbolt.Tx) error {
+		mainActionsBucket, err := getBucket(tx, actionsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		actionsBucket := mainActionsBucket.Bucket(actionsKey)
+		if actionsBucket == nil {
+			return ErrNoSuchKeyFound
+		}
+
+		actionsIndexBucket := mainActionsBucket.Bucket(actionsIndex)
+		if actionsIndexBucket == nil {
+			return ErrNoSuchKeyFound
+		}
+
+		for _, index := range indexes {
+			var indexKey [8]byte
+			byteOrder.PutUint64(indexKey[:], index)
+
+			locatorBytes := actionsIndexBucket.Get(indexKey[:])
+			if locatorBytes == nil {
+				continue
+			}
+
+			locator, err := deserializeActionLocator(
+				bytes.NewReader(locatorBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			action, err := getAction(actionsBucket, locator)
+			if err != nil {
+				return err
+			}
+			action.Index = index
+
+			actions = append(actions, action)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,75 @@
+package firewalldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestActionSubscriptions tests that subscribers are signaled about new
+// actions and state changes and that the changed actions can be looked up by
+// their index.
+func TestActionSubscriptions(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	lastIndex, err := db.LastActionIndex()
+	require.NoError(t, err)
+	require.Zero(t, lastIndex)
+
+	updates, cancel := db.SubscribeActions()
+
+	sessionID := [4]byte{1, 1, 1, 1}
+	addAction := func() uint64 {
+		id, err := db.AddAction(sessionID, &Action{
+			ActorName:   "Autopilot",
+			RPCMethod:   "UpdateChanPolicy",
+			AttemptedAt: time.Unix(32100, 0),
+			State:       ActionStateInit,
+		})
+		require.NoError(t, err)
+
+		return id
+	}
+
+	// Two changes before the subscriber picks them up are coalesced into
+	// a single signal.
+	addAction()
+	id := addAction()
+	require.Len(t, updates, 1)
+	<-updates
+
+	lastIndex, err = db.LastActionIndex()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, lastIndex)
+
+	err = db.SetActionState(&ActionLocator{
+		SessionID: sessionID,
+		ActionID:  id,
+	}, ActionStateError, "failed")
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	<-updates
+
+	// Unknown indexes are skipped.
+	actions, err := db.ActionsByIndex([]uint64{2, 3, 1})
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	require.EqualValues(t, 2, actions[0].Index)
+	require.Equal(t, ActionStateError, actions[0].State)
+	require.Equal(t, "failed", actions[0].ErrorReason)
+	require.EqualValues(t, 1, actions[1].Index)
+	require.Equal(t, ActionStateInit, actions[1].State)
+
+	// A cancelled subscription has its channel closed and isn't signaled
+	// anymore.
+	cancel()
+	_, ok := <-updates
+	require.False(t, ok)
+	addAction()
+	cancel()
+}
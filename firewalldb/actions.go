@@ -70,6 +70,10 @@ const (
 	// ActionStateError represents that an Action did not complete
 	// successfully.
 	ActionStateError ActionState = 3
+
+	// ActionStateDryRun represents that an Action passed all rules but was
+	// intentionally not executed because it was only a dry run.
+	ActionStateDryRun ActionState = 4
 )
 
 // Action represents an RPC call made through the firewall.
@@ -113,6 +117,11 @@ type Action struct {
 	// ErrorReason is the human-readable reason for why the action failed.
 	// It will only be set if State is ActionStateError.
 	ErrorReason string
+
+	// Index is the position of the action in the index of all actions.
+	// Like the SessionID, it is not serialized. It is only set for the
+	// actions returned by ListActions.
+	Index uint64
 }
 
 // AddAction serialises and adds an Action to the DB under the given sessionID.
@@ -121,6 +130,7 @@ func (db *DB) AddAction(sessionID session.ID, action *Action) (uint64, error) {
 	if err := SerializeAction(&buf, action); err != nil {
 		return 0, err
 	}
+	actionBytes := buf.Bytes()
 
 	var id uint64
 	err := db.DB.Update(func(tx *bbolt.Tx) error {
@@ -149,7 +159,7 @@ func (db *DB) AddAction(sessionID session.ID, action *Action) (uint64, error) {
 
 		var actionIndex [8]byte
 		byteOrder.PutUint64(actionIndex[:], nextActionIndex)
-		err = sessBucket.Put(actionIndex[:], buf.Bytes())
+		err = sessBucket.Put(actionIndex[:], actionBytes)
 		if err != nil {
 			return err
 		}
@@ -177,16 +187,24 @@ func (db *DB) AddAction(sessionID session.ID, action *Action) (uint64, error) {
 
 		var seqNoBytes [8]byte
 		byteOrder.PutUint64(seqNoBytes[:], nextSeq)
-		return actionsIndexBucket.Put(seqNoBytes[:], buf.Bytes())
+		err = actionsIndexBucket.Put(seqNoBytes[:], buf.Bytes())
+		if err != nil {
+			return err
+		}
+
+		return db.appendActionDigest(
+			mainActionsBucket, &locator, actionBytes,
+		)
 	})
 	if err != nil {
 		return 0, err
 	}
+	db.notifyActionSubs()
 
 	return id, nil
 }
 
-func putAction(tx *bbolt.Tx, al *ActionLocator, a *Action) error {
+func (db *DB) putAction(tx *bbolt.Tx, al *ActionLocator, a *Action) error {
 	var buf bytes.Buffer
 	if err := SerializeAction(&buf, a); err != nil {
 		return err
@@ -211,7 +229,11 @@ func putAction(tx *bbolt.Tx, al *ActionLocator, a *Action) error {
 	var id [8]byte
 	binary.BigEndian.PutUint64(id[:], al.ActionID)
 
-	return sessBucket.Put(id[:], buf.Bytes())
+	if err := sessBucket.Put(id[:], buf.Bytes()); err != nil {
+		return err
+	}
+
+	return db.appendActionDigest(mainActionsBucket, al, buf.Bytes())
 }
 
 func getAction(actionsBkt *bbolt.Bucket, al *ActionLocator) (*Action, error) {
@@ -238,7 +260,7 @@ func (db *DB) SetActionState(al *ActionLocator, state ActionState,
 			"ActionStateError")
 	}
 
-	return db.DB.Update(func(tx *bbolt.Tx) error {
+	err := db.DB.Update(func(tx *bbolt.Tx) error {
 		mainActionsBucket, err := getBucket(tx, actionsBucketKey)
 		if err != nil {
 			return err
@@ -257,8 +279,14 @@ func (db *DB) SetActionState(al *ActionLocator, state ActionState,
 		action.State = state
 		action.ErrorReason = errorReason
 
-		return putAction(tx, al, action)
+		return db.putAction(tx, al, action)
 	})
+	if err != nil {
+		return err
+	}
+	db.notifyActionSubs()
+
+	return nil
 }
 
 // ListActionsQuery can be used to tweak the query to ListActions and
@@ -326,7 +354,13 @@ func (db *DB) ListActions(filterFn ListActionsFilterFn,
 				return nil, err
 			}
 
-			return getAction(actionsBucket, locator)
+			action, err := getAction(actionsBucket, locator)
+			if err != nil {
+				return nil, err
+			}
+			action.Index = byteOrder.Uint64(index)
+
+			return action, nil
 		}
 
 		actions, lastIndex, totalCount, err = paginateActions(
@@ -491,6 +525,10 @@ type RuleAction struct {
 
 	// PerformedAt is the time at which the action was attempted.
 	PerformedAt time.Time
+
+	// RPCParamsJson is the method parameters of the request in JSON form.
+	// It is only set if the parameters of the request were persisted.
+	RPCParamsJson []byte
 }
 
 // ActionsDB represents a DB backend that contains Action entries that can
@@ -609,8 +647,9 @@ func (a *featureActionsReadDB) ListActions(_ context.Context) ([]*RuleAction,
 
 func actionToRulesAction(a *Action) *RuleAction {
 	return &RuleAction{
-		Method:      a.RPCMethod,
-		PerformedAt: a.AttemptedAt,
+		Method:        a.RPCMethod,
+		PerformedAt:   a.AttemptedAt,
+		RPCParamsJson: a.RPCParamsJson,
 	}
 }
 
@@ -210,6 +210,11 @@ func TestListActions(t *testing.T) {
 		{sessionID2, "5"},
 	})
 
+	// The actions carry their position in the index of all actions.
+	for i, a := range actions {
+		require.EqualValues(t, i+1, a.Index)
+	}
+
 	query := &ListActionsQuery{
 		Reversed: true,
 	}
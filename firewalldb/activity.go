@@ -0,0 +1,170 @@
+package firewalldb
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+)
+
+// ActivityInterval is the length of the intervals the actions are counted in
+// by an activity report.
+type ActivityInterval uint8
+
+const (
+	// ActivityIntervalHour counts the actions per UTC hour.
+	ActivityIntervalHour ActivityInterval = iota
+
+	// ActivityIntervalDay counts the actions per UTC day.
+	ActivityIntervalDay
+)
+
+// Duration returns the length of the interval.
+func (i ActivityInterval) Duration() time.Duration {
+	if i == ActivityIntervalDay {
+		return 24 * time.Hour
+	}
+
+	return time.Hour
+}
+
+// Start returns the start of the interval the given time falls into, in UTC.
+func (i ActivityInterval) Start(t time.Time) time.Time {
+	return t.UTC().Truncate(i.Duration())
+}
+
+// ActivityQuery selects the actions that are counted by an activity report.
+type ActivityQuery struct {
+	// Start is the time from which on actions are counted. It is rounded
+	// down to the start of its interval.
+	Start time.Time
+
+	// End is the time until which actions are counted.
+	End time.Time
+
+	// Interval is the interval the actions are counted in.
+	Interval ActivityInterval
+
+	// SessionID optionally restricts the report to the actions of one
+	// session.
+	SessionID *session.ID
+
+	// FeatureName optionally restricts the report to the actions of one
+	// feature.
+	FeatureName string
+}
+
+// ActivityEntry is the number of actions of one feature of a session in one
+// interval.
+type ActivityEntry struct {
+	// IntervalStart is the start of the interval.
+	IntervalStart time.Time
+
+	// SessionID is the ID of the session the actions were made through.
+	SessionID session.ID
+
+	// FeatureName is the name of the feature that performed the actions.
+	// It is empty for actions that weren't performed by a feature.
+	FeatureName string
+
+	// Actions is the number of actions.
+	Actions uint64
+
+	// Errors is the number of actions that failed.
+	Errors uint64
+}
+
+// ActivityReport counts the session actions that were attempted between the
+// start and end time of the query per interval, session and feature. Actions
+// that weren't made through a session, like the admin actions of the audit
+// trail, are not counted.
+func (db *DB) ActivityReport(q *ActivityQuery) ([]*ActivityEntry, error) {
+	if q.End.Before(q.Start) {
+		return nil, fmt.Errorf("start time must be before the end time")
+	}
+
+	var (
+		start   = q.Interval.Start(q.Start)
+		entries = make(map[string]*ActivityEntry)
+	)
+
+	// The actions are recorded in order, so we go through them from the
+	// newest to the oldest and stop as soon as the start time is passed.
+	filterFn := func(a *Action, _ bool) (bool, bool) {
+		if a.AttemptedAt.Before(start) {
+			return false, false
+		}
+
+		if a.AttemptedAt.After(q.End) ||
+			a.SessionID == (session.ID{}) {
+
+			return false, true
+		}
+
+		if q.FeatureName != "" && a.FeatureName != q.FeatureName {
+			return false, true
+		}
+
+		intervalStart := q.Interval.Start(a.AttemptedAt)
+		key := fmt.Sprintf(
+			"%d:%x:%s", intervalStart.Unix(), a.SessionID[:],
+			a.FeatureName,
+		)
+
+		entry, ok := entries[key]
+		if !ok {
+			entry = &ActivityEntry{
+				IntervalStart: intervalStart,
+				SessionID:     a.SessionID,
+				FeatureName:   a.FeatureName,
+			}
+			entries[key] = entry
+		}
+
+		entry.Actions++
+		if a.State == ActionStateError {
+			entry.Errors++
+		}
+
+		// We only count the actions, so none of them is returned.
+		return false, true
+	}
+
+	var (
+		query = &ListActionsQuery{Reversed: true}
+		err   error
+	)
+	if q.SessionID != nil {
+		_, _, _, err = db.ListSessionActions(
+			*q.SessionID, filterFn, query,
+		)
+	} else {
+		_, _, _, err = db.ListActions(filterFn, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]*ActivityEntry, 0, len(entries))
+	for _, entry := range entries {
+		report = append(report, entry)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if !a.IntervalStart.Equal(b.IntervalStart) {
+			return a.IntervalStart.Before(b.IntervalStart)
+		}
+
+		cmp := bytes.Compare(a.SessionID[:], b.SessionID[:])
+		if cmp != 0 {
+			return cmp < 0
+		}
+
+		return a.FeatureName < b.FeatureName
+	})
+
+	return report, nil
+}
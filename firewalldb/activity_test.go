@@ -0,0 +1,101 @@
+package firewalldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+)
+
+// TestActivityReport tests that the session actions are counted per interval,
+// session and feature.
+func TestActivityReport(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var (
+		sess1 = session.ID{1, 1, 1, 1}
+		sess2 = session.ID{2, 2, 2, 2}
+		day   = time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+	)
+
+	addAction := func(id session.ID, feature string, at time.Duration,
+		state ActionState) {
+
+		_, err := db.AddAction(id, &Action{
+			SessionID:   id,
+			FeatureName: feature,
+			RPCMethod:   "/lnrpc.Lightning/GetInfo",
+			AttemptedAt: day.Add(at),
+			State:       state,
+		})
+		require.NoError(t, err)
+	}
+
+	addAction(sess1, "auto-fees", 0, ActionStateDone)
+	addAction(sess1, "auto-fees", 10*time.Minute, ActionStateError)
+	addAction(sess1, "rebalancer", 20*time.Minute, ActionStateDone)
+	addAction(sess2, "", 30*time.Minute, ActionStateDone)
+	addAction(sess1, "auto-fees", 90*time.Minute, ActionStateDone)
+
+	// Actions without a session are not counted.
+	addAction(session.ID{}, "", 100*time.Minute, ActionStateDone)
+	addAction(sess2, "", 25*time.Hour, ActionStateDone)
+
+	report, err := db.ActivityReport(&ActivityQuery{
+		Start:    day.Add(5 * time.Minute),
+		End:      day.Add(24 * time.Hour),
+		Interval: ActivityIntervalHour,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ActivityEntry{{
+		IntervalStart: day,
+		SessionID:     sess1,
+		FeatureName:   "auto-fees",
+		Actions:       2,
+		Errors:        1,
+	}, {
+		IntervalStart: day,
+		SessionID:     sess1,
+		FeatureName:   "rebalancer",
+		Actions:       1,
+	}, {
+		IntervalStart: day,
+		SessionID:     sess2,
+		Actions:       1,
+	}, {
+		IntervalStart: day.Add(time.Hour),
+		SessionID:     sess1,
+		FeatureName:   "auto-fees",
+		Actions:       1,
+	}}, report)
+
+	// The report can be restricted to a session and a feature.
+	report, err = db.ActivityReport(&ActivityQuery{
+		Start:       day,
+		End:         day.Add(48 * time.Hour),
+		Interval:    ActivityIntervalDay,
+		SessionID:   &sess1,
+		FeatureName: "auto-fees",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*ActivityEntry{{
+		IntervalStart: day,
+		SessionID:     sess1,
+		FeatureName:   "auto-fees",
+		Actions:       3,
+		Errors:        1,
+	}}, report)
+
+	_, err = db.ActivityReport(&ActivityQuery{
+		Start: day.Add(time.Hour),
+		End:   day,
+	})
+	require.Error(t, err)
+}
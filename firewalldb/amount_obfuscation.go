@@ -0,0 +1,133 @@
+package firewalldb
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*
+	The amount obfuscation settings are stored in the privacy map bucket of
+	the session:
+
+	privacy -> session id -> amount-obfuscation -> feature name -> json
+
+	The settings that apply to all features are stored under the
+	sessionWideFeature key.
+*/
+
+var (
+	// amountObfuscationKey is the key of the bucket that holds the amount
+	// obfuscation settings of a session.
+	amountObfuscationKey = []byte("amount-obfuscation")
+
+	// sessionWideFeature is the key that the settings that apply to all
+	// features of a session are stored under. Bolt doesn't allow empty
+	// keys, so a single zero byte is used instead.
+	sessionWideFeature = []byte{0}
+)
+
+// AmountObfuscation describes how the privacy mapper obfuscates the amounts
+// it hands out.
+type AmountObfuscation struct {
+	// Variation is the relative variation that amounts are randomized
+	// with. It must be between 0 and 1. A variation of zero means that
+	// amounts are not randomized.
+	Variation float64 `json:"variation"`
+
+	// GranularitySat is the granularity in satoshis that amounts are
+	// rounded to after they were randomized. A granularity of zero or one
+	// means that amounts are not rounded.
+	GranularitySat uint64 `json:"granularity_sat"`
+}
+
+// Validate checks that the settings are within the allowed bounds.
+func (a *AmountObfuscation) Validate() error {
+	if a.Variation < 0 || a.Variation > 1 {
+		return fmt.Errorf("amount variation must be between 0 and 1, "+
+			"is %v", a.Variation)
+	}
+
+	return nil
+}
+
+// featureKey returns the key that the settings of the given feature are
+// stored under.
+func featureKey(feature string) []byte {
+	if feature == "" {
+		return sessionWideFeature
+	}
+
+	return []byte(feature)
+}
+
+// SetAmountObfuscation persists the amount obfuscation settings for the given
+// feature. If the feature is empty, the settings apply to all features of the
+// session that don't have their own settings.
+func (p *privacyMapTx) SetAmountObfuscation(feature string,
+	settings *AmountObfuscation) error {
+
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	privacyBucket, err := getBucket(p.boltTx, privacyBucketKey)
+	if err != nil {
+		return err
+	}
+
+	sessBucket, err := privacyBucket.CreateBucketIfNotExists(p.sessionID[:])
+	if err != nil {
+		return err
+	}
+
+	obfuscationBucket, err := sessBucket.CreateBucketIfNotExists(
+		amountObfuscationKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	return obfuscationBucket.Put(featureKey(feature), b)
+}
+
+// AmountObfuscation returns the amount obfuscation settings that apply to the
+// given feature. If the feature has no settings of its own, the session wide
+// settings are returned. If neither are set, nil is returned.
+func (p *privacyMapTx) AmountObfuscation(feature string) (*AmountObfuscation,
+	error) {
+
+	privacyBucket, err := getBucket(p.boltTx, privacyBucketKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sessBucket := privacyBucket.Bucket(p.sessionID[:])
+	if sessBucket == nil {
+		return nil, nil
+	}
+
+	obfuscationBucket := sessBucket.Bucket(amountObfuscationKey)
+	if obfuscationBucket == nil {
+		return nil, nil
+	}
+
+	b := obfuscationBucket.Get(featureKey(feature))
+	if b == nil {
+		b = obfuscationBucket.Get(sessionWideFeature)
+	}
+	if b == nil {
+		return nil, nil
+	}
+
+	var settings AmountObfuscation
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,76 @@
+package firewalldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAmountObfuscationStorage tests that the amount obfuscation settings are
+// stored per session and feature and that the session wide settings are used
+// as a fallback.
+func TestAmountObfuscationStorage(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := NewDB(tmpDir, "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sessionWide := &AmountObfuscation{
+		Variation:      0.1,
+		GranularitySat: 1_000,
+	}
+	autoFees := &AmountObfuscation{
+		GranularitySat: 10_000,
+	}
+
+	pdb1 := db.PrivacyDB([4]byte{1, 1, 1, 1})
+	err = pdb1.Update(func(tx PrivacyMapTx) error {
+		settings, err := tx.AmountObfuscation("AutoFees")
+		require.NoError(t, err)
+		require.Nil(t, settings)
+
+		require.NoError(t, tx.SetAmountObfuscation("", sessionWide))
+		require.NoError(t, tx.SetAmountObfuscation("AutoFees", autoFees))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = pdb1.View(func(tx PrivacyMapTx) error {
+		settings, err := tx.AmountObfuscation("AutoFees")
+		require.NoError(t, err)
+		require.Equal(t, autoFees, settings)
+
+		settings, err = tx.AmountObfuscation("HealthCheck")
+		require.NoError(t, err)
+		require.Equal(t, sessionWide, settings)
+
+		settings, err = tx.AmountObfuscation("")
+		require.NoError(t, err)
+		require.Equal(t, sessionWide, settings)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The settings are scoped to the session.
+	pdb2 := db.PrivacyDB([4]byte{2, 2, 2, 2})
+	err = pdb2.View(func(tx PrivacyMapTx) error {
+		settings, err := tx.AmountObfuscation("AutoFees")
+		require.NoError(t, err)
+		require.Nil(t, settings)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Variations outside of [0, 1] are rejected.
+	err = pdb2.Update(func(tx PrivacyMapTx) error {
+		return tx.SetAmountObfuscation("", &AmountObfuscation{
+			Variation: 1.5,
+		})
+	})
+	require.ErrorContains(t, err, "amount variation must be between")
+}
@@ -0,0 +1,313 @@
+package firewalldb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+/*
+	The usage of the billed RPC methods is stored in the following structure
+	in the db:
+
+	billing -> consumer -> day -> rpc method -> calls (8 bytes) ||
+	                                            cost (8 bytes)
+
+	The consumer key is the consumer type byte followed by the ID of the
+	session or account. The day is the unix timestamp of the start of the
+	UTC day the calls were made on.
+*/
+
+var (
+	// billingBucketKey is the key of the top level bucket holding the
+	// usage of all consumers.
+	billingBucketKey = []byte("billing")
+)
+
+const (
+	// usageValueLen is the length of a serialized usage counter.
+	usageValueLen = 16
+)
+
+// ConsumerType is the type of the consumer an RPC call is billed to.
+type ConsumerType uint8
+
+const (
+	// ConsumerSession means that the call was made through a session.
+	ConsumerSession ConsumerType = 1
+
+	// ConsumerAccount means that the call was made with the macaroon of an
+	// account.
+	ConsumerAccount ConsumerType = 2
+)
+
+// Consumer identifies the session or account an RPC call is billed to.
+type Consumer struct {
+	// Type is the type of the consumer.
+	Type ConsumerType
+
+	// ID is the ID of the session or account.
+	ID []byte
+}
+
+// key returns the db key of the consumer.
+func (c Consumer) key() []byte {
+	return append([]byte{byte(c.Type)}, c.ID...)
+}
+
+// consumerFromKey parses a consumer db key.
+func consumerFromKey(k []byte) (Consumer, error) {
+	if len(k) < 2 {
+		return Consumer{}, fmt.Errorf("invalid consumer key %x", k)
+	}
+
+	return Consumer{
+		Type: ConsumerType(k[0]),
+		ID:   append([]byte{}, k[1:]...),
+	}, nil
+}
+
+// BillingPeriod is the period the usage is grouped by in a usage report.
+type BillingPeriod uint8
+
+const (
+	// BillingPeriodDay groups the usage by UTC day.
+	BillingPeriodDay BillingPeriod = iota
+
+	// BillingPeriodWeek groups the usage by week, starting on Monday.
+	BillingPeriodWeek
+
+	// BillingPeriodMonth groups the usage by calendar month.
+	BillingPeriodMonth
+)
+
+// Start returns the start of the period the given time falls into, in UTC.
+func (p BillingPeriod) Start(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	switch p {
+	case BillingPeriodWeek:
+		// The week days are counted from Sunday, so we shift them by
+		// one to get the days since Monday.
+		sinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -sinceMonday)
+
+	case BillingPeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+
+	default:
+		return day
+	}
+}
+
+// UsageEntry is the accumulated usage of one RPC method by one consumer in one
+// period.
+type UsageEntry struct {
+	// PeriodStart is the start of the period.
+	PeriodStart time.Time
+
+	// Consumer is the session or account the calls were billed to.
+	Consumer Consumer
+
+	// RPCMethod is the URI of the method called.
+	RPCMethod string
+
+	// Calls is the number of calls.
+	Calls uint64
+
+	// Cost is the total cost of the calls.
+	Cost uint64
+}
+
+// BillingDB keeps track of the usage of the billed RPC methods.
+type BillingDB interface {
+	// AddUsage adds a call of the given RPC method with the given cost to
+	// the usage of the consumer on the day of the given time.
+	AddUsage(consumer Consumer, rpcMethod string, cost uint64,
+		at time.Time) error
+
+	// UsageReport returns the usage between the start and end time,
+	// grouped by the given period. The entries are ordered by the start
+	// of their period, the consumer and the method.
+	UsageReport(start, end time.Time,
+		period BillingPeriod) ([]*UsageEntry, error)
+}
+
+// A compile-time check to ensure that DB implements the BillingDB interface.
+var _ BillingDB = (*DB)(nil)
+
+// AddUsage adds a call of the given RPC method with the given cost to the
+// usage of the consumer on the day of the given time. As it is called for
+// every billed RPC call, the write is batched with concurrent calls.
+//
+// NOTE: this is part of the BillingDB interface.
+func (db *DB) AddUsage(consumer Consumer, rpcMethod string, cost uint64,
+	at time.Time) error {
+
+	if len(consumer.ID) == 0 {
+		return errors.New("consumer ID must be set")
+	}
+
+	var dayKey [8]byte
+	day := BillingPeriodDay.Start(at)
+	byteOrder.PutUint64(dayKey[:], uint64(day.Unix()))
+
+	return db.Batch(func(tx *bbolt.Tx) error {
+		billingBucket, err := tx.CreateBucketIfNotExists(
+			billingBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		consumerBucket, err := billingBucket.CreateBucketIfNotExists(
+			consumer.key(),
+		)
+		if err != nil {
+			return err
+		}
+
+		dayBucket, err := consumerBucket.CreateBucketIfNotExists(
+			dayKey[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		var calls, total uint64
+		v := dayBucket.Get([]byte(rpcMethod))
+		if len(v) == usageValueLen {
+			calls = byteOrder.Uint64(v[:8])
+			total = byteOrder.Uint64(v[8:])
+		}
+
+		var usage [usageValueLen]byte
+		byteOrder.PutUint64(usage[:8], calls+1)
+		byteOrder.PutUint64(usage[8:], total+cost)
+
+		return dayBucket.Put([]byte(rpcMethod), usage[:])
+	})
+}
+
+// UsageReport returns the usage between the start and end time, grouped by
+// the given period. The usage is recorded per UTC day, so the start time is
+// rounded down to the start of its day.
+//
+// NOTE: this is part of the BillingDB interface.
+func (db *DB) UsageReport(start, end time.Time,
+	period BillingPeriod) ([]*UsageEntry, error) {
+
+	var (
+		firstDay [8]byte
+		endUnix  = end.Unix()
+		entries  = make(map[string]*UsageEntry)
+	)
+	byteOrder.PutUint64(
+		firstDay[:], uint64(BillingPeriodDay.Start(start).Unix()),
+	)
+
+	err := db.View(func(tx *bbolt.Tx) error {
+		billingBucket := tx.Bucket(billingBucketKey)
+		if billingBucket == nil {
+			return nil
+		}
+
+		return billingBucket.ForEach(func(consumerKey, _ []byte) error {
+			consumer, err := consumerFromKey(consumerKey)
+			if err != nil {
+				return err
+			}
+
+			consumerBucket := billingBucket.Bucket(consumerKey)
+			if consumerBucket == nil {
+				return fmt.Errorf("no bucket for consumer %x",
+					consumerKey)
+			}
+
+			c := consumerBucket.Cursor()
+			k, _ := c.Seek(firstDay[:])
+			for ; k != nil; k, _ = c.Next() {
+				day := int64(byteOrder.Uint64(k))
+				if day > endUnix {
+					break
+				}
+
+				dayBucket := consumerBucket.Bucket(k)
+				if dayBucket == nil {
+					continue
+				}
+
+				periodStart := period.Start(time.Unix(day, 0))
+				err := addDayUsage(
+					dayBucket, consumer, periodStart,
+					entries,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]*UsageEntry, 0, len(entries))
+	for _, entry := range entries {
+		report = append(report, entry)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+
+		cmp := bytes.Compare(a.Consumer.key(), b.Consumer.key())
+		if cmp != 0 {
+			return cmp < 0
+		}
+
+		return a.RPCMethod < b.RPCMethod
+	})
+
+	return report, nil
+}
+
+// addDayUsage adds the usage of one consumer on one day to the entries of the
+// period that starts at the given time.
+func addDayUsage(dayBucket *bbolt.Bucket, consumer Consumer,
+	periodStart time.Time, entries map[string]*UsageEntry) error {
+
+	return dayBucket.ForEach(func(method, v []byte) error {
+		if len(v) != usageValueLen {
+			return fmt.Errorf("invalid usage value for %s", method)
+		}
+
+		key := fmt.Sprintf(
+			"%d:%x:%s", periodStart.Unix(), consumer.key(), method,
+		)
+
+		entry, ok := entries[key]
+		if !ok {
+			entry = &UsageEntry{
+				PeriodStart: periodStart,
+				Consumer:    consumer,
+				RPCMethod:   string(method),
+			}
+			entries[key] = entry
+		}
+
+		entry.Calls += byteOrder.Uint64(v[:8])
+		entry.Cost += byteOrder.Uint64(v[8:])
+
+		return nil
+	})
+}
@@ -0,0 +1,150 @@
+package firewalldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBillingPeriodStart tests that the start of the billing periods is
+// computed correctly.
+func TestBillingPeriodStart(t *testing.T) {
+	t.Parallel()
+
+	// 2024-03-03 is a Sunday.
+	sunday := time.Date(2024, 3, 3, 23, 59, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(
+		t, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+		BillingPeriodDay.Start(sunday),
+	)
+	require.Equal(
+		t, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC),
+		BillingPeriodWeek.Start(sunday),
+	)
+	require.Equal(t, monday, BillingPeriodWeek.Start(monday))
+	require.Equal(
+		t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		BillingPeriodMonth.Start(sunday),
+	)
+
+	// Times in other zones are converted to UTC first.
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	require.Equal(
+		t, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+		BillingPeriodDay.Start(time.Date(2024, 3, 4, 1, 0, 0, 0, zone)),
+	)
+}
+
+// TestUsageReport tests that the usage is accumulated per consumer, method and
+// day and grouped by the requested period.
+func TestUsageReport(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var (
+		session = Consumer{
+			Type: ConsumerSession,
+			ID:   []byte{1, 2, 3, 4},
+		}
+		account = Consumer{
+			Type: ConsumerAccount,
+			ID:   []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+
+		getInfo = "/lnrpc.Lightning/GetInfo"
+		sendPay = "/routerrpc.Router/SendPaymentV2"
+
+		sunday  = time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)
+		monday  = time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+		tuesday = time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+		april   = time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	// An empty db has no usage.
+	report, err := db.UsageReport(sunday, april, BillingPeriodDay)
+	require.NoError(t, err)
+	require.Empty(t, report)
+
+	require.Error(t, db.AddUsage(Consumer{}, getInfo, 1, sunday))
+
+	require.NoError(t, db.AddUsage(session, getInfo, 1, sunday))
+	require.NoError(t, db.AddUsage(session, getInfo, 1, sunday))
+	require.NoError(t, db.AddUsage(session, sendPay, 10, monday))
+	require.NoError(t, db.AddUsage(session, sendPay, 10, tuesday))
+	require.NoError(t, db.AddUsage(account, getInfo, 2, monday))
+	require.NoError(t, db.AddUsage(account, getInfo, 2, april))
+
+	day := func(t time.Time) time.Time {
+		return BillingPeriodDay.Start(t)
+	}
+
+	// Grouped by day, every day gets its own entries.
+	report, err = db.UsageReport(sunday, tuesday, BillingPeriodDay)
+	require.NoError(t, err)
+	require.Equal(t, []*UsageEntry{{
+		PeriodStart: day(sunday),
+		Consumer:    session,
+		RPCMethod:   getInfo,
+		Calls:       2,
+		Cost:        2,
+	}, {
+		PeriodStart: day(monday),
+		Consumer:    session,
+		RPCMethod:   sendPay,
+		Calls:       1,
+		Cost:        10,
+	}, {
+		PeriodStart: day(monday),
+		Consumer:    account,
+		RPCMethod:   getInfo,
+		Calls:       1,
+		Cost:        2,
+	}, {
+		PeriodStart: day(tuesday),
+		Consumer:    session,
+		RPCMethod:   sendPay,
+		Calls:       1,
+		Cost:        10,
+	}}, report)
+
+	// Grouped by week, Monday and Tuesday fall into the same week, Sunday
+	// into the previous one. The end time excludes the April usage.
+	report, err = db.UsageReport(sunday, tuesday, BillingPeriodWeek)
+	require.NoError(t, err)
+	require.Len(t, report, 3)
+	require.Equal(t, day(monday), report[1].PeriodStart)
+	require.Equal(t, session, report[1].Consumer)
+	require.EqualValues(t, 2, report[1].Calls)
+	require.EqualValues(t, 20, report[1].Cost)
+
+	// Grouped by month, the March and April usage is split. The start
+	// time is rounded down to the start of its day.
+	report, err = db.UsageReport(
+		sunday.Add(time.Hour), april, BillingPeriodMonth,
+	)
+	require.NoError(t, err)
+	require.Len(t, report, 4)
+	require.Equal(
+		t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		report[0].PeriodStart,
+	)
+	require.Equal(t, getInfo, report[0].RPCMethod)
+	require.EqualValues(t, 2, report[0].Calls)
+	require.Equal(t, day(april), report[3].PeriodStart)
+	require.Equal(t, account, report[3].Consumer)
+
+	// Only the usage from the start day on is reported.
+	report, err = db.UsageReport(tuesday, april, BillingPeriodMonth)
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	require.EqualValues(t, 1, report[0].Calls)
+	require.EqualValues(t, 10, report[0].Cost)
+}
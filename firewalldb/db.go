@@ -4,8 +4,10 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"go.etcd.io/bbolt"
@@ -40,6 +42,17 @@ var (
 // DB is a bolt-backed persistent store.
 type DB struct {
 	*bbolt.DB
+
+	// actionSigner signs the action log chain. If it is nil, no digests
+	// are recorded.
+	actionSigner ActionSigner
+
+	// subMtx guards actionSubs.
+	subMtx sync.Mutex
+
+	// actionSubs are signaled whenever an action is added or its state
+	// changes.
+	actionSubs map[chan struct{}]struct{}
 }
 
 // NewDB creates a new bolt database that can be found at the given directory.
@@ -66,7 +79,10 @@ func NewDB(dir, fileName string) (*DB, error) {
 		return nil, err
 	}
 
-	return &DB{DB: db}, nil
+	return &DB{
+		DB:         db,
+		actionSubs: make(map[chan struct{}]struct{}),
+	}, nil
 }
 
 // fileExists reports whether the named file or directory exists.
@@ -145,3 +161,26 @@ func initDB(filepath string, firstInit bool) (*bbolt.DB, error) {
 
 	return db, nil
 }
+
+// Snapshot writes a consistent copy of the whole database to the given writer.
+func (db *DB) Snapshot(w io.Writer) error {
+	return db.View(func(tx *bbolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// Probe runs an empty read or, if write is set, an empty write transaction
+// against the database. Committing the write transaction still syncs the
+// database file, so its duration reflects the write latency of the storage.
+func (db *DB) Probe(write bool) error {
+	if write {
+		return db.Update(func(*bbolt.Tx) error {
+			return nil
+		})
+	}
+
+	return db.View(func(*bbolt.Tx) error {
+		return nil
+	})
+}
@@ -18,6 +18,8 @@ import (
 
 	privacy -> session id -> real-to-pseudo -> {k:v}
 			      -> pseudo-to-real -> {k:v}
+			      -> pseudo-key -> key
+			      -> amount-obfuscation -> {feature:settings}
 */
 
 const (
@@ -28,6 +30,7 @@ var (
 	privacyBucketKey = []byte("privacy")
 	realToPseudoKey  = []byte("real-to-pseudo")
 	pseudoToRealKey  = []byte("pseudo-to-real")
+	pseudoKeyKey     = []byte("pseudo-key")
 
 	pseudoStrAlphabet    = []rune("abcdef0123456789")
 	pseudoStrAlphabetLen = len(pseudoStrAlphabet)
@@ -78,6 +81,24 @@ type PrivacyMapTx interface {
 	// RealToPseudo returns the pseudo value associated with the given real
 	// value. If no such pair is found, then ErrNoSuchKeyFound is returned.
 	RealToPseudo(real string) (string, error)
+
+	// SetPseudoKey persists the key that new pseudo values are derived
+	// from. Once set, new pseudo values are no longer random.
+	SetPseudoKey(key []byte) error
+
+	// PseudoKey returns the key that new pseudo values are derived from or
+	// nil if the pseudo values are random.
+	PseudoKey() ([]byte, error)
+
+	// SetAmountObfuscation persists the amount obfuscation settings for
+	// the given feature. An empty feature name sets the session wide
+	// settings.
+	SetAmountObfuscation(feature string, settings *AmountObfuscation) error
+
+	// AmountObfuscation returns the amount obfuscation settings of the
+	// given feature, falling back to the session wide settings. It
+	// returns nil if neither are set.
+	AmountObfuscation(feature string) (*AmountObfuscation, error)
 }
 
 // privacyMapDB is an implementation of PrivacyMapDB.
@@ -254,6 +275,58 @@ func (p *privacyMapTx) RealToPseudo(real string) (string, error) {
 	return string(pseudo), nil
 }
 
+// SetPseudoKey persists the key that new pseudo values are derived from.
+func (p *privacyMapTx) SetPseudoKey(key []byte) error {
+	if len(key) != PseudoKeyLen {
+		return fmt.Errorf("pseudo key must be %d bytes, got %d",
+			PseudoKeyLen, len(key))
+	}
+
+	privacyBucket, err := getBucket(p.boltTx, privacyBucketKey)
+	if err != nil {
+		return err
+	}
+
+	sessBucket, err := privacyBucket.CreateBucketIfNotExists(p.sessionID[:])
+	if err != nil {
+		return err
+	}
+
+	return sessBucket.Put(pseudoKeyKey, key)
+}
+
+// PseudoKey returns the key that new pseudo values are derived from or nil if
+// no key was set.
+func (p *privacyMapTx) PseudoKey() ([]byte, error) {
+	privacyBucket, err := getBucket(p.boltTx, privacyBucketKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sessBucket := privacyBucket.Bucket(p.sessionID[:])
+	if sessBucket == nil {
+		return nil, nil
+	}
+
+	key := sessBucket.Get(pseudoKeyKey)
+	if len(key) == 0 {
+		return nil, nil
+	}
+
+	// The returned slice is only valid during the transaction.
+	return append([]byte(nil), key...), nil
+}
+
+// generator returns the pseudo value generator for the given transaction.
+func generator(tx PrivacyMapTx) (*PseudoGenerator, error) {
+	key, err := tx.PseudoKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPseudoGenerator(key), nil
+}
+
 func HideString(tx PrivacyMapTx, real string) (string, error) {
 	pseudo, err := tx.RealToPseudo(real)
 	if err != nil && err != ErrNoSuchKeyFound {
@@ -263,7 +336,12 @@ func HideString(tx PrivacyMapTx, real string) (string, error) {
 		return pseudo, nil
 	}
 
-	pseudo, err = NewPseudoStr(len(real))
+	gen, err := generator(tx)
+	if err != nil {
+		return "", err
+	}
+
+	pseudo, err = gen.Str(real)
 	if err != nil {
 		return "", err
 	}
@@ -310,7 +388,12 @@ func HideUint64(tx PrivacyMapTx, real uint64) (uint64, error) {
 		return StrToUint64(pseudo)
 	}
 
-	pseudoUint64, pseudoUint64Str := NewPseudoUint64()
+	gen, err := generator(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	pseudoUint64, pseudoUint64Str := gen.Uint64(real)
 	if err := tx.NewPair(str, pseudoUint64Str); err != nil {
 		return 0, err
 	}
@@ -343,7 +426,12 @@ func HideChanPoint(tx PrivacyMapTx, txid string, index uint32) (string,
 		return decodeChannelPoint(pseudo)
 	}
 
-	newCp, err := NewPseudoChanPoint()
+	gen, err := generator(tx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	newCp, err := gen.ChanPoint(cp)
 	if err != nil {
 		return "", 0, err
 	}
@@ -399,6 +487,10 @@ func HideChanPointStr(tx PrivacyMapTx, cp string) (string, error) {
 }
 
 func HideBytes(tx PrivacyMapTx, realBytes []byte) ([]byte, error) {
+	if len(realBytes) == 0 {
+		return nil, nil
+	}
+
 	real := hex.EncodeToString(realBytes)
 
 	pseudo, err := HideString(tx, real)
@@ -0,0 +1,90 @@
+package firewalldb
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// PseudoKeyLen is the length of the key that pseudo values are derived
+	// from in the deterministic mode.
+	PseudoKeyLen = 32
+)
+
+// PseudoGenerator creates the pseudo values of a privacy map. Without a key,
+// the pseudo values are random. With a key, they are derived from the real
+// values with an HMAC, so that all sessions that share the same key map a real
+// value to the same pseudo value. A nil generator creates random values.
+type PseudoGenerator struct {
+	key []byte
+}
+
+// NewPseudoGenerator returns a generator for the given key. If the key is
+// empty, the generator creates random pseudo values.
+func NewPseudoGenerator(key []byte) *PseudoGenerator {
+	return &PseudoGenerator{key: key}
+}
+
+// Deterministic returns true if the pseudo values are derived from the real
+// values.
+func (g *PseudoGenerator) Deterministic() bool {
+	return g != nil && len(g.key) > 0
+}
+
+// derive returns n bytes that are derived from the given domain and real
+// value. The HMAC output is extended with a counter if more than 32 bytes are
+// needed.
+func (g *PseudoGenerator) derive(domain, real string, n int) []byte {
+	out := make([]byte, 0, n+sha256.Size)
+	for counter := uint32(0); len(out) < n; counter++ {
+		var counterBytes [4]byte
+		binary.BigEndian.PutUint32(counterBytes[:], counter)
+
+		mac := hmac.New(sha256.New, g.key)
+		_, _ = mac.Write([]byte(domain))
+		_, _ = mac.Write(counterBytes[:])
+		_, _ = mac.Write([]byte(real))
+		out = mac.Sum(out)
+	}
+
+	return out[:n]
+}
+
+// Str returns a pseudo string with the same length as the real one. The
+// pseudo string only consists of lowercase hex characters.
+func (g *PseudoGenerator) Str(real string) (string, error) {
+	if !g.Deterministic() {
+		return NewPseudoStr(len(real))
+	}
+
+	b := g.derive("str", real, (len(real)+1)/2)
+	return hex.EncodeToString(b)[:len(real)], nil
+}
+
+// Uint64 returns a pseudo uint64 for the given real value, together with its
+// string encoding that is stored in the privacy map.
+func (g *PseudoGenerator) Uint64(real uint64) (uint64, string) {
+	if !g.Deterministic() {
+		return NewPseudoUint64()
+	}
+
+	b := g.derive("uint64", Uint64ToStr(real), 8)
+	return binary.BigEndian.Uint64(b), hex.EncodeToString(b)
+}
+
+// ChanPoint returns a pseudo channel point, encoded as txid:index, for the
+// given real channel point.
+func (g *PseudoGenerator) ChanPoint(real string) (string, error) {
+	if !g.Deterministic() {
+		return NewPseudoChanPoint()
+	}
+
+	b := g.derive("chanpoint", real, txidStringLen/2+4)
+	txid := hex.EncodeToString(b[:txidStringLen/2])
+	index := binary.BigEndian.Uint32(b[txidStringLen/2:])
+
+	return fmt.Sprintf("%s:%d", txid, index), nil
+}
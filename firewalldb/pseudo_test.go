@@ -0,0 +1,143 @@
+package firewalldb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPseudoGenerator tests that deterministic pseudo values only depend on
+// the key and the real value and have the expected format.
+func TestPseudoGenerator(t *testing.T) {
+	key1 := bytes.Repeat([]byte{1}, PseudoKeyLen)
+	key2 := bytes.Repeat([]byte{2}, PseudoKeyLen)
+
+	gen1 := NewPseudoGenerator(key1)
+	require.True(t, gen1.Deterministic())
+	require.False(t, NewPseudoGenerator(nil).Deterministic())
+
+	var nilGen *PseudoGenerator
+	require.False(t, nilGen.Deterministic())
+
+	// A real value that is longer than a single HMAC output.
+	real := "02" + hex.EncodeToString(bytes.Repeat([]byte{3}, 40))
+
+	pseudo, err := gen1.Str(real)
+	require.NoError(t, err)
+	require.Len(t, pseudo, len(real))
+	require.NotEqual(t, real, pseudo)
+	_, err = hex.DecodeString(pseudo)
+	require.NoError(t, err)
+
+	// Odd lengths are supported too.
+	pseudoOdd, err := gen1.Str("abc")
+	require.NoError(t, err)
+	require.Len(t, pseudoOdd, 3)
+
+	same, err := NewPseudoGenerator(key1).Str(real)
+	require.NoError(t, err)
+	require.Equal(t, pseudo, same)
+
+	other, err := NewPseudoGenerator(key2).Str(real)
+	require.NoError(t, err)
+	require.NotEqual(t, pseudo, other)
+
+	random1, err := nilGen.Str(real)
+	require.NoError(t, err)
+	random2, err := nilGen.Str(real)
+	require.NoError(t, err)
+	require.Len(t, random1, len(real))
+	require.NotEqual(t, random1, random2)
+
+	pseudoUint, pseudoUintStr := gen1.Uint64(123)
+	sameUint, _ := NewPseudoGenerator(key1).Uint64(123)
+	require.Equal(t, pseudoUint, sameUint)
+	decoded, err := StrToUint64(pseudoUintStr)
+	require.NoError(t, err)
+	require.Equal(t, pseudoUint, decoded)
+
+	cp, err := gen1.ChanPoint(real + ":1")
+	require.NoError(t, err)
+	txid, _, err := decodeChannelPoint(cp)
+	require.NoError(t, err)
+	require.Len(t, txid, txidStringLen)
+}
+
+// TestDeterministicPrivacyMap tests that sessions that share the same pseudo
+// key map real values to the same pseudo values.
+func TestDeterministicPrivacyMap(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := NewDB(tmpDir, "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	key := bytes.Repeat([]byte{1}, PseudoKeyLen)
+	realTxid := hex.EncodeToString(bytes.Repeat([]byte{2}, 32))
+
+	type pseudoValues struct {
+		str   string
+		num   uint64
+		txid  string
+		index uint32
+	}
+
+	// hide sets the given key for the session, if any, and returns the
+	// pseudo values of a fixed set of real values.
+	hide := func(id [4]byte, key []byte) pseudoValues {
+		var values pseudoValues
+		err := db.PrivacyDB(id).Update(func(tx PrivacyMapTx) error {
+			storedKey, err := tx.PseudoKey()
+			require.NoError(t, err)
+			require.Nil(t, storedKey)
+
+			if key != nil {
+				require.NoError(t, tx.SetPseudoKey(key))
+
+				storedKey, err = tx.PseudoKey()
+				require.NoError(t, err)
+				require.Equal(t, key, storedKey)
+			}
+
+			values.str, err = HideString(tx, "some peer")
+			require.NoError(t, err)
+
+			values.num, err = HideUint64(tx, 1234)
+			require.NoError(t, err)
+
+			values.txid, values.index, err = HideChanPoint(
+				tx, realTxid, 1,
+			)
+			require.NoError(t, err)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		return values
+	}
+
+	values1 := hide([4]byte{1}, key)
+	values2 := hide([4]byte{2}, key)
+	require.Equal(t, values1, values2)
+
+	values3 := hide([4]byte{3}, bytes.Repeat([]byte{2}, PseudoKeyLen))
+	require.NotEqual(t, values1.str, values3.str)
+	require.NotEqual(t, values1.num, values3.num)
+	require.NotEqual(t, values1.txid, values3.txid)
+
+	values4 := hide([4]byte{4}, nil)
+	values5 := hide([4]byte{5}, nil)
+	require.NotEqual(t, values4.str, values5.str)
+	require.NotEqual(t, values4.num, values5.num)
+	require.NotEqual(t, values4.txid, values5.txid)
+
+	// Keys of the wrong length are rejected.
+	err = db.PrivacyDB([4]byte{6}).Update(func(tx PrivacyMapTx) error {
+		return tx.SetPseudoKey([]byte{1, 2, 3})
+	})
+	require.ErrorContains(t, err, "pseudo key must be")
+}
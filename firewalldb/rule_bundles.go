@@ -0,0 +1,149 @@
+package firewalldb
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go.etcd.io/bbolt"
+)
+
+/*
+	The rule bundles are stored in the following structure in the db:
+
+	rule-bundles -> bundle name -> json encoded RuleBundle
+*/
+
+var (
+	// ruleBundlesBucketKey is the key of the top level bucket holding all
+	// the custom rule bundles.
+	ruleBundlesBucketKey = []byte("rule-bundles")
+
+	// ErrRuleBundleNotFound is returned when a rule bundle with the given
+	// name does not exist in the db.
+	ErrRuleBundleNotFound = errors.New("rule bundle not found")
+)
+
+// RuleBundle is a named set of rule values that can be applied to sessions.
+type RuleBundle struct {
+	// Name is the unique name of the bundle.
+	Name string `json:"name"`
+
+	// Description is a human-readable description of the bundle.
+	Description string `json:"description"`
+
+	// Rules is a map from rule name to the JSON encoded rule value.
+	Rules map[string]string `json:"rules"`
+}
+
+// RuleBundlesDB provides access to the persisted custom rule bundles.
+type RuleBundlesDB interface {
+	// StoreRuleBundle adds the given rule bundle to the db or overwrites
+	// the existing bundle with the same name.
+	StoreRuleBundle(bundle *RuleBundle) error
+
+	// GetRuleBundle fetches the rule bundle with the given name. If no
+	// such bundle exists, ErrRuleBundleNotFound is returned.
+	GetRuleBundle(name string) (*RuleBundle, error)
+
+	// ListRuleBundles returns all the rule bundles in the db.
+	ListRuleBundles() ([]*RuleBundle, error)
+
+	// DeleteRuleBundle removes the rule bundle with the given name. If no
+	// such bundle exists, ErrRuleBundleNotFound is returned.
+	DeleteRuleBundle(name string) error
+}
+
+// A compile-time check to ensure that DB implements the RuleBundlesDB
+// interface.
+var _ RuleBundlesDB = (*DB)(nil)
+
+// StoreRuleBundle adds the given rule bundle to the db or overwrites the
+// existing bundle with the same name.
+//
+// NOTE: this is part of the RuleBundlesDB interface.
+func (db *DB) StoreRuleBundle(bundle *RuleBundle) error {
+	b, err := json.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(ruleBundlesBucketKey)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(bundle.Name), b)
+	})
+}
+
+// GetRuleBundle fetches the rule bundle with the given name. If no such bundle
+// exists, ErrRuleBundleNotFound is returned.
+//
+// NOTE: this is part of the RuleBundlesDB interface.
+func (db *DB) GetRuleBundle(name string) (*RuleBundle, error) {
+	var bundle *RuleBundle
+	err := db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(ruleBundlesBucketKey)
+		if bucket == nil {
+			return ErrRuleBundleNotFound
+		}
+
+		b := bucket.Get([]byte(name))
+		if b == nil {
+			return ErrRuleBundleNotFound
+		}
+
+		bundle = &RuleBundle{}
+		return json.Unmarshal(b, bundle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// ListRuleBundles returns all the rule bundles in the db.
+//
+// NOTE: this is part of the RuleBundlesDB interface.
+func (db *DB) ListRuleBundles() ([]*RuleBundle, error) {
+	var bundles []*RuleBundle
+	err := db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(ruleBundlesBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, v []byte) error {
+			var bundle RuleBundle
+			if err := json.Unmarshal(v, &bundle); err != nil {
+				return err
+			}
+
+			bundles = append(bundles, &bundle)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+// DeleteRuleBundle removes the rule bundle with the given name. If no such
+// bundle exists, ErrRuleBundleNotFound is returned.
+//
+// NOTE: this is part of the RuleBundlesDB interface.
+func (db *DB) DeleteRuleBundle(name string) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(ruleBundlesBucketKey)
+		if bucket == nil || bucket.Get([]byte(name)) == nil {
+			return ErrRuleBundleNotFound
+		}
+
+		return bucket.Delete([]byte(name))
+	})
+}
@@ -0,0 +1,66 @@
+package firewalldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuleBundles tests that rule bundles can be stored, fetched, listed and
+// deleted.
+func TestRuleBundles(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	// Fetching a bundle from an empty db should return the not found
+	// error.
+	_, err = db.GetRuleBundle("bundle-1")
+	require.ErrorIs(t, err, ErrRuleBundleNotFound)
+
+	bundles, err := db.ListRuleBundles()
+	require.NoError(t, err)
+	require.Empty(t, bundles)
+
+	bundle1 := &RuleBundle{
+		Name:        "bundle-1",
+		Description: "first bundle",
+		Rules: map[string]string{
+			"rate-limit": `{"write_limit":{"iterations":1}}`,
+		},
+	}
+	bundle2 := &RuleBundle{
+		Name:  "bundle-2",
+		Rules: map[string]string{},
+	}
+
+	require.NoError(t, db.StoreRuleBundle(bundle1))
+	require.NoError(t, db.StoreRuleBundle(bundle2))
+
+	b, err := db.GetRuleBundle("bundle-1")
+	require.NoError(t, err)
+	require.Equal(t, bundle1, b)
+
+	// Overwriting a bundle should replace its values.
+	bundle1.Description = "updated bundle"
+	require.NoError(t, db.StoreRuleBundle(bundle1))
+
+	b, err = db.GetRuleBundle("bundle-1")
+	require.NoError(t, err)
+	require.Equal(t, "updated bundle", b.Description)
+
+	bundles, err = db.ListRuleBundles()
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+
+	require.NoError(t, db.DeleteRuleBundle("bundle-2"))
+	require.ErrorIs(
+		t, db.DeleteRuleBundle("bundle-2"), ErrRuleBundleNotFound,
+	)
+
+	bundles, err = db.ListRuleBundles()
+	require.NoError(t, err)
+	require.Equal(t, []*RuleBundle{bundle1}, bundles)
+}
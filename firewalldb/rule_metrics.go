@@ -0,0 +1,210 @@
+package firewalldb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+/*
+	The evaluation metrics of the rules are stored in the following
+	structure in the db:
+
+	rule-metrics -> rule name -> evaluations (8 bytes) ||
+	                             denials (8 bytes) ||
+	                             total latency in ns (8 bytes) ||
+	                             max latency in ns (8 bytes) ||
+	                             first evaluation unix timestamp (8 bytes)
+*/
+
+var (
+	// ruleMetricsBucketKey is the key of the top level bucket holding the
+	// evaluation metrics of all rules.
+	ruleMetricsBucketKey = []byte("rule-metrics")
+)
+
+const (
+	// ruleMetricsValueLen is the length of the serialized metrics of a
+	// rule.
+	ruleMetricsValueLen = 40
+)
+
+// RuleEvaluation is a single evaluation of a rule by the rule enforcer.
+type RuleEvaluation struct {
+	// RuleName is the name of the evaluated rule.
+	RuleName string
+
+	// Latency is the time the evaluation took.
+	Latency time.Duration
+
+	// Denied is true if the rule denied the request.
+	Denied bool
+}
+
+// RuleMetrics are the accumulated evaluation metrics of one rule.
+type RuleMetrics struct {
+	// RuleName is the name of the rule.
+	RuleName string
+
+	// Evaluations is the number of times the rule was evaluated.
+	Evaluations uint64
+
+	// Denials is the number of requests the rule denied.
+	Denials uint64
+
+	// TotalLatency is the sum of the time all evaluations took.
+	TotalLatency time.Duration
+
+	// MaxLatency is the time the slowest evaluation took.
+	MaxLatency time.Duration
+
+	// Since is the time of the first recorded evaluation.
+	Since time.Time
+}
+
+// AvgLatency returns the average time an evaluation of the rule took.
+func (m *RuleMetrics) AvgLatency() time.Duration {
+	if m.Evaluations == 0 {
+		return 0
+	}
+
+	return m.TotalLatency / time.Duration(m.Evaluations)
+}
+
+// RuleMetricsDB keeps track of the evaluation metrics of the rules.
+type RuleMetricsDB interface {
+	// AddRuleEvaluations adds the given rule evaluations, which happened
+	// at the given time, to the metrics of their rules.
+	AddRuleEvaluations(evals []*RuleEvaluation, at time.Time) error
+
+	// ListRuleMetrics returns the metrics of all rules that were
+	// evaluated, ordered by the rule name.
+	ListRuleMetrics() ([]*RuleMetrics, error)
+}
+
+// A compile-time check to ensure that DB implements the RuleMetricsDB
+// interface.
+var _ RuleMetricsDB = (*DB)(nil)
+
+// AddRuleEvaluations adds the given rule evaluations, which happened at the
+// given time, to the metrics of their rules. As it is called for every request
+// that is checked against rules, the write is batched with concurrent calls.
+//
+// NOTE: this is part of the RuleMetricsDB interface.
+func (db *DB) AddRuleEvaluations(evals []*RuleEvaluation, at time.Time) error {
+	if len(evals) == 0 {
+		return nil
+	}
+
+	for _, eval := range evals {
+		if eval.RuleName == "" {
+			return errors.New("rule name must be set")
+		}
+	}
+
+	return db.Batch(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(ruleMetricsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		for _, eval := range evals {
+			metrics := &RuleMetrics{
+				RuleName: eval.RuleName,
+				Since:    at,
+			}
+
+			v := bucket.Get([]byte(eval.RuleName))
+			if v != nil {
+				metrics, err = deserializeRuleMetrics(
+					eval.RuleName, v,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			metrics.Evaluations++
+			if eval.Denied {
+				metrics.Denials++
+			}
+			metrics.TotalLatency += eval.Latency
+			if eval.Latency > metrics.MaxLatency {
+				metrics.MaxLatency = eval.Latency
+			}
+
+			err := bucket.Put(
+				[]byte(eval.RuleName),
+				serializeRuleMetrics(metrics),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListRuleMetrics returns the metrics of all rules that were evaluated,
+// ordered by the rule name.
+//
+// NOTE: this is part of the RuleMetricsDB interface.
+func (db *DB) ListRuleMetrics() ([]*RuleMetrics, error) {
+	var metrics []*RuleMetrics
+	err := db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(ruleMetricsBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		// The keys of a bucket are iterated over in byte order, so the
+		// metrics are already ordered by the rule name.
+		return bucket.ForEach(func(k, v []byte) error {
+			m, err := deserializeRuleMetrics(string(k), v)
+			if err != nil {
+				return err
+			}
+
+			metrics = append(metrics, m)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+// serializeRuleMetrics serializes the metrics of a rule into their db value.
+func serializeRuleMetrics(m *RuleMetrics) []byte {
+	var v [ruleMetricsValueLen]byte
+	byteOrder.PutUint64(v[:8], m.Evaluations)
+	byteOrder.PutUint64(v[8:16], m.Denials)
+	byteOrder.PutUint64(v[16:24], uint64(m.TotalLatency))
+	byteOrder.PutUint64(v[24:32], uint64(m.MaxLatency))
+	byteOrder.PutUint64(v[32:], uint64(m.Since.Unix()))
+
+	return v[:]
+}
+
+// deserializeRuleMetrics parses the db value of the metrics of a rule.
+func deserializeRuleMetrics(name string, v []byte) (*RuleMetrics, error) {
+	if len(v) != ruleMetricsValueLen {
+		return nil, fmt.Errorf("invalid metrics value for rule %s",
+			name)
+	}
+
+	return &RuleMetrics{
+		RuleName:     name,
+		Evaluations:  byteOrder.Uint64(v[:8]),
+		Denials:      byteOrder.Uint64(v[8:16]),
+		TotalLatency: time.Duration(byteOrder.Uint64(v[16:24])),
+		MaxLatency:   time.Duration(byteOrder.Uint64(v[24:32])),
+		Since:        time.Unix(int64(byteOrder.Uint64(v[32:])), 0),
+	}, nil
+}
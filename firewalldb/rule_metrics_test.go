@@ -0,0 +1,74 @@
+package firewalldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuleMetrics tests that the rule evaluations are accumulated per rule and
+// kept when the db is reopened.
+func TestRuleMetrics(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	db, err := NewDB(dir, "test.db")
+	require.NoError(t, err)
+
+	// Without any evaluations, no metrics are returned.
+	metrics, err := db.ListRuleMetrics()
+	require.NoError(t, err)
+	require.Empty(t, metrics)
+
+	first := time.Unix(1700000000, 0)
+	err = db.AddRuleEvaluations([]*RuleEvaluation{{
+		RuleName: "rate-limit",
+		Latency:  2 * time.Millisecond,
+	}, {
+		RuleName: "history-limit",
+		Latency:  10 * time.Millisecond,
+		Denied:   true,
+	}}, first)
+	require.NoError(t, err)
+
+	err = db.AddRuleEvaluations([]*RuleEvaluation{{
+		RuleName: "rate-limit",
+		Latency:  4 * time.Millisecond,
+		Denied:   true,
+	}}, first.Add(time.Hour))
+	require.NoError(t, err)
+
+	// A rule evaluation needs the name of the rule.
+	err = db.AddRuleEvaluations([]*RuleEvaluation{{
+		Latency: time.Millisecond,
+	}}, first)
+	require.Error(t, err)
+
+	// The metrics must survive a restart.
+	require.NoError(t, db.Close())
+	db, err = NewDB(dir, "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	metrics, err = db.ListRuleMetrics()
+	require.NoError(t, err)
+	require.Equal(t, []*RuleMetrics{{
+		RuleName:     "history-limit",
+		Evaluations:  1,
+		Denials:      1,
+		TotalLatency: 10 * time.Millisecond,
+		MaxLatency:   10 * time.Millisecond,
+		Since:        first,
+	}, {
+		RuleName:     "rate-limit",
+		Evaluations:  2,
+		Denials:      1,
+		TotalLatency: 6 * time.Millisecond,
+		MaxLatency:   4 * time.Millisecond,
+		Since:        first,
+	}}, metrics)
+	require.Equal(t, 3*time.Millisecond, metrics[1].AvgLatency())
+}
@@ -0,0 +1,151 @@
+package firewalldb
+
+import (
+	"encoding/json"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"go.etcd.io/bbolt"
+)
+
+/*
+	The rule overrides are stored in the following structure in the db:
+
+	rule-overrides -> session ID -> json encoded map from feature name to
+		rule name to rule value
+*/
+
+// ruleOverridesBucketKey is the key of the top level bucket holding the rule
+// values that replace the values of existing sessions.
+var ruleOverridesBucketKey = []byte("rule-overrides")
+
+// RuleOverride replaces the value of a rule of a feature of an existing
+// session. The value is stored in its real form, even if the session uses the
+// privacy mapper.
+type RuleOverride struct {
+	// SessionID is the ID of the session whose rule is replaced.
+	SessionID session.ID
+
+	// FeatureName is the name of the feature the rule belongs to.
+	FeatureName string
+
+	// RuleName is the name of the rule.
+	RuleName string
+
+	// Value is the JSON encoded new value of the rule.
+	Value string
+}
+
+// RuleOverridesDB provides access to the persisted rule overrides.
+type RuleOverridesDB interface {
+	// StoreRuleOverrides stores all the given overrides in a single
+	// transaction. An existing override of the same session, feature and
+	// rule is replaced.
+	StoreRuleOverrides(overrides []*RuleOverride) error
+
+	// GetRuleOverrides returns the rule values that replace the values of
+	// the given session, as a map from feature name to rule name to JSON
+	// encoded rule value. An empty map is returned if the session has no
+	// overrides.
+	GetRuleOverrides(id session.ID) (map[string]map[string]string, error)
+}
+
+// A compile-time check to ensure that DB implements the RuleOverridesDB
+// interface.
+var _ RuleOverridesDB = (*DB)(nil)
+
+// StoreRuleOverrides stores all the given overrides in a single transaction.
+// An existing override of the same session, feature and rule is replaced.
+//
+// NOTE: this is part of the RuleOverridesDB interface.
+func (db *DB) StoreRuleOverrides(overrides []*RuleOverride) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(
+			ruleOverridesBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		// The overrides of the same session are merged first, so that
+		// each session is only written once.
+		bySession := make(map[session.ID]map[string]map[string]string)
+		for _, o := range overrides {
+			features, ok := bySession[o.SessionID]
+			if !ok {
+				features, err = getRuleOverrides(
+					bucket, o.SessionID,
+				)
+				if err != nil {
+					return err
+				}
+
+				bySession[o.SessionID] = features
+			}
+
+			featRules, ok := features[o.FeatureName]
+			if !ok {
+				featRules = make(map[string]string)
+				features[o.FeatureName] = featRules
+			}
+			featRules[o.RuleName] = o.Value
+		}
+
+		for id, features := range bySession {
+			b, err := json.Marshal(features)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put(id[:], b); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetRuleOverrides returns the rule values that replace the values of the
+// given session, as a map from feature name to rule name to JSON encoded rule
+// value. An empty map is returned if the session has no overrides.
+//
+// NOTE: this is part of the RuleOverridesDB interface.
+func (db *DB) GetRuleOverrides(id session.ID) (map[string]map[string]string,
+	error) {
+
+	features := make(map[string]map[string]string)
+	err := db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(ruleOverridesBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		var err error
+		features, err = getRuleOverrides(bucket, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
+
+// getRuleOverrides reads the overrides of the given session from the bucket.
+func getRuleOverrides(bucket *bbolt.Bucket,
+	id session.ID) (map[string]map[string]string, error) {
+
+	features := make(map[string]map[string]string)
+
+	b := bucket.Get(id[:])
+	if b == nil {
+		return features, nil
+	}
+
+	if err := json.Unmarshal(b, &features); err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
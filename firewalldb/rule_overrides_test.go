@@ -0,0 +1,79 @@
+package firewalldb
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRuleOverrides tests that rule overrides of several sessions are stored
+// together and merged with the existing overrides of a session.
+func TestRuleOverrides(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var (
+		id1 = session.ID{1, 1, 1, 1}
+		id2 = session.ID{2, 2, 2, 2}
+	)
+
+	// A session without overrides has an empty map.
+	overrides, err := db.GetRuleOverrides(id1)
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+
+	require.NoError(t, db.StoreRuleOverrides([]*RuleOverride{{
+		SessionID:   id1,
+		FeatureName: "AutoFees",
+		RuleName:    "rate-limit",
+		Value:       `{"write_limit":{"iterations":1}}`,
+	}, {
+		SessionID:   id1,
+		FeatureName: "HealthCheck",
+		RuleName:    "rate-limit",
+		Value:       `{"write_limit":{"iterations":2}}`,
+	}, {
+		SessionID:   id2,
+		FeatureName: "AutoFees",
+		RuleName:    "rate-limit",
+		Value:       `{"write_limit":{"iterations":3}}`,
+	}}))
+
+	// A later override replaces the value of the same rule and keeps the
+	// other ones.
+	require.NoError(t, db.StoreRuleOverrides([]*RuleOverride{{
+		SessionID:   id1,
+		FeatureName: "AutoFees",
+		RuleName:    "rate-limit",
+		Value:       `{"write_limit":{"iterations":4}}`,
+	}, {
+		SessionID:   id1,
+		FeatureName: "AutoFees",
+		RuleName:    "history-limit",
+		Value:       `{"duration":3600}`,
+	}}))
+
+	overrides, err = db.GetRuleOverrides(id1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"AutoFees": {
+			"rate-limit":    `{"write_limit":{"iterations":4}}`,
+			"history-limit": `{"duration":3600}`,
+		},
+		"HealthCheck": {
+			"rate-limit": `{"write_limit":{"iterations":2}}`,
+		},
+	}, overrides)
+
+	overrides, err = db.GetRuleOverrides(id2)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"AutoFees": {
+			"rate-limit": `{"write_limit":{"iterations":3}}`,
+		},
+	}, overrides)
+}
@@ -0,0 +1,95 @@
+package firewalldb
+
+import (
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+)
+
+/*
+	The firewall settings are stored in the following structure in the db:
+
+	firewall-settings -> settings -> json encoded Settings
+*/
+
+var (
+	// settingsBucketKey is the key of the top level bucket holding the
+	// firewall settings that were changed at runtime.
+	settingsBucketKey = []byte("firewall-settings")
+
+	// settingsKey is the key the settings are stored under.
+	settingsKey = []byte("settings")
+)
+
+// Settings are the global firewall settings that were changed at runtime. They
+// take precedence over the values of the config file. A nil field wasn't
+// changed, so the configured value applies.
+type Settings struct {
+	// RequestLoggerLevel is the level of the request logger.
+	RequestLoggerLevel *string `json:"request_logger_level,omitempty"`
+
+	// PrivacyMapper is false if the privacy mapper is disabled.
+	PrivacyMapper *bool `json:"privacy_mapper,omitempty"`
+
+	// RuleEnforcement is false if the rules of the sessions aren't
+	// enforced.
+	RuleEnforcement *bool `json:"rule_enforcement,omitempty"`
+}
+
+// SettingsDB provides access to the persisted firewall settings.
+type SettingsDB interface {
+	// FirewallSettings returns the stored firewall settings. If none were
+	// stored yet, all fields are nil.
+	FirewallSettings() (*Settings, error)
+
+	// StoreFirewallSettings replaces the stored firewall settings.
+	StoreFirewallSettings(settings *Settings) error
+}
+
+// A compile-time check to ensure that DB implements the SettingsDB interface.
+var _ SettingsDB = (*DB)(nil)
+
+// FirewallSettings returns the stored firewall settings. If none were stored
+// yet, all fields are nil.
+//
+// NOTE: this is part of the SettingsDB interface.
+func (db *DB) FirewallSettings() (*Settings, error) {
+	settings := &Settings{}
+	err := db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(settingsBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		b := bucket.Get(settingsKey)
+		if b == nil {
+			return nil
+		}
+
+		return json.Unmarshal(b, settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// StoreFirewallSettings replaces the stored firewall settings.
+//
+// NOTE: this is part of the SettingsDB interface.
+func (db *DB) StoreFirewallSettings(settings *Settings) error {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(settingsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(settingsKey, b)
+	})
+}
@@ -0,0 +1,47 @@
+package firewalldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestFirewallSettings tests that the firewall settings can be stored and
+// replaced.
+func TestFirewallSettings(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	// Without stored settings, nothing was changed.
+	settings, err := db.FirewallSettings()
+	require.NoError(t, err)
+	require.Equal(t, &Settings{}, settings)
+
+	var (
+		level    = "full"
+		disabled = false
+	)
+	require.NoError(t, db.StoreFirewallSettings(&Settings{
+		RequestLoggerLevel: &level,
+		RuleEnforcement:    &disabled,
+	}))
+
+	settings, err = db.FirewallSettings()
+	require.NoError(t, err)
+	require.Equal(t, &Settings{
+		RequestLoggerLevel: &level,
+		RuleEnforcement:    &disabled,
+	}, settings)
+
+	// Storing the settings again replaces all of them.
+	require.NoError(t, db.StoreFirewallSettings(&Settings{
+		PrivacyMapper: &disabled,
+	}))
+
+	settings, err = db.FirewallSettings()
+	require.NoError(t, err)
+	require.Equal(t, &Settings{PrivacyMapper: &disabled}, settings)
+}
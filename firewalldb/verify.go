@@ -0,0 +1,157 @@
+package firewalldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"go.etcd.io/bbolt"
+)
+
+// VerifyRecords decodes all actions, action index entries, action digests and
+// rule bundles of the firewall database. The kv stores and the privacy map
+// hold raw values that don't need to be decoded.
+func VerifyRecords(tx *bbolt.Tx, c *dbverify.Checker) error {
+	mainActionsBucket := tx.Bucket(actionsBucketKey)
+	if mainActionsBucket != nil {
+		if err := verifyActions(mainActionsBucket, c); err != nil {
+			return err
+		}
+	}
+
+	bucket := tx.Bucket(ruleBundlesBucketKey)
+	if bucket == nil {
+		return nil
+	}
+
+	path := [][]byte{ruleBundlesBucketKey}
+	return bucket.ForEach(func(k, v []byte) error {
+		c.Check(path, k, func() error {
+			return json.Unmarshal(v, &RuleBundle{})
+		})
+
+		return nil
+	})
+}
+
+// verifyActions decodes the actions, their index entries and their digests.
+// Index entries that point to a missing or corrupt action are collected as
+// corrupt too, as listing the actions would fail on them.
+func verifyActions(mainActionsBucket *bbolt.Bucket,
+	c *dbverify.Checker) error {
+
+	// corruptActions holds the locators of the actions that can't be
+	// decoded.
+	corruptActions := make(map[ActionLocator]struct{})
+
+	actionsBucket := mainActionsBucket.Bucket(actionsKey)
+	if actionsBucket != nil {
+		path := [][]byte{actionsBucketKey, actionsKey}
+		err := actionsBucket.ForEach(func(sessionKey, v []byte) error {
+			sessionID, err := session.IDFromBytes(sessionKey)
+			if err != nil || v != nil {
+				c.Corrupt(path, sessionKey, errors.New(
+					"expected session actions bucket",
+				))
+
+				return nil
+			}
+
+			sessPath := append(path, sessionKey)
+			sessBucket := actionsBucket.Bucket(sessionKey)
+			return sessBucket.ForEach(func(k, v []byte) error {
+				ok := c.Check(sessPath, k, func() error {
+					if len(k) != 8 {
+						return fmt.Errorf("invalid "+
+							"action ID length %d",
+							len(k))
+					}
+
+					_, err := DeserializeAction(
+						bytes.NewReader(v), sessionID,
+					)
+					return err
+				})
+				if !ok && len(k) == 8 {
+					corruptActions[ActionLocator{
+						SessionID: sessionID,
+						ActionID:  byteOrder.Uint64(k),
+					}] = struct{}{}
+				}
+
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	indexBucket := mainActionsBucket.Bucket(actionsIndex)
+	if indexBucket != nil {
+		path := [][]byte{actionsBucketKey, actionsIndex}
+		err := indexBucket.ForEach(func(k, v []byte) error {
+			c.Check(path, k, func() error {
+				locator, err := deserializeActionLocator(
+					bytes.NewReader(v),
+				)
+				if err != nil {
+					return err
+				}
+
+				_, corrupt := corruptActions[*locator]
+				if corrupt || !actionExists(
+					actionsBucket, locator,
+				) {
+
+					return fmt.Errorf("action %d of "+
+						"session %x is missing or "+
+						"corrupt", locator.ActionID,
+						locator.SessionID)
+				}
+
+				return nil
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	digestsBucket := mainActionsBucket.Bucket(actionDigestsKey)
+	if digestsBucket == nil {
+		return nil
+	}
+
+	path := [][]byte{actionsBucketKey, actionDigestsKey}
+	return digestsBucket.ForEach(func(k, v []byte) error {
+		c.Check(path, k, func() error {
+			_, err := deserializeActionDigest(bytes.NewReader(v))
+			return err
+		})
+
+		return nil
+	})
+}
+
+// actionExists returns true if the action of the given locator is stored.
+func actionExists(actionsBucket *bbolt.Bucket, al *ActionLocator) bool {
+	if actionsBucket == nil {
+		return false
+	}
+
+	sessBucket := actionsBucket.Bucket(al.SessionID[:])
+	if sessBucket == nil {
+		return false
+	}
+
+	var id [8]byte
+	byteOrder.PutUint64(id[:], al.ActionID)
+
+	return sessBucket.Get(id[:]) != nil
+}
@@ -0,0 +1,108 @@
+package firewalldb
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+// TestVerifyRecords tests that corrupt actions, the index entries pointing to
+// them and corrupt rule bundles are found and quarantined, so the remaining
+// actions can still be listed.
+func TestVerifyRecords(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	db, err := NewDB(tmpDir, "test.db")
+	require.NoError(t, err)
+
+	sessionID := [4]byte{1, 1, 1, 1}
+	for i := 0; i < 2; i++ {
+		_, err := db.AddAction(sessionID, &Action{
+			SessionID:     sessionID,
+			ActorName:     "Autopilot",
+			RPCMethod:     "UpdateChanPolicy",
+			RPCParamsJson: []byte("{}"),
+			AttemptedAt:   time.Unix(32100, 0),
+			State:         ActionStateDone,
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.StoreRuleBundle(&RuleBundle{Name: "good"}))
+	require.NoError(t, db.StoreRuleBundle(&RuleBundle{Name: "bad"}))
+
+	// Corrupt the first action and one of the bundles.
+	err = db.Update(func(tx *bbolt.Tx) error {
+		var id [8]byte
+		byteOrder.PutUint64(id[:], 1)
+
+		sessBucket := tx.Bucket(actionsBucketKey).Bucket(actionsKey).
+			Bucket(sessionID[:])
+		if err := sessBucket.Put(id[:], []byte{0xff, 0xff}); err != nil {
+			return err
+		}
+
+		return tx.Bucket(ruleBundlesBucketKey).Put(
+			[]byte("bad"), []byte("{"),
+		)
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	verifyDB := dbverify.Database{
+		Path:  filepath.Join(tmpDir, "test.db"),
+		Check: VerifyRecords,
+	}
+
+	// Without quarantining, the records are only reported.
+	result, err := dbverify.Verify(verifyDB, false)
+	require.NoError(t, err)
+	require.Len(t, result.Corrupt, 3)
+	require.False(t, result.Quarantined)
+
+	locations := make([]string, len(result.Corrupt))
+	for i, record := range result.Corrupt {
+		locations[i] = record.Location()
+	}
+	require.Equal(t, []string{
+		"actions-bucket/actions/01010101/0000000000000001",
+		"actions-bucket/actions-index/0000000000000001",
+		"rule-bundles/bad",
+	}, locations)
+
+	result, err = dbverify.Verify(verifyDB, true)
+	require.NoError(t, err)
+	require.True(t, result.Quarantined)
+
+	// A second run doesn't find any corrupt records anymore.
+	result, err = dbverify.Verify(verifyDB, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Corrupt)
+
+	db, err = NewDB(tmpDir, "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	actions, _, _, err := db.ListActions(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	require.EqualValues(t, 2, actions[0].Index)
+
+	bundles, err := db.ListRuleBundles()
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+
+	err = db.View(func(tx *bbolt.Tx) error {
+		quarantine := tx.Bucket(dbverify.QuarantineBucketKey)
+		require.NotNil(t, quarantine)
+		require.Equal(t, 3, quarantine.Stats().BucketN-1)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,55 @@
+package guardrails
+
+import (
+	"fmt"
+)
+
+// Config holds all config options for the guardrails.
+type Config struct {
+	Loop *LoopConfig `group:"loop" namespace:"loop"`
+	Pool *PoolConfig `group:"pool" namespace:"pool"`
+}
+
+// LoopConfig holds the limits of the Loop swaps.
+type LoopConfig struct {
+	MaxLoopOutPerDay  uint64  `long:"maxloopoutperday" description:"The maximum amount in satoshis that may be looped out within 24 hours, no matter who initiates the swaps. Set to 0 to disable."`
+	MaxLoopInPerDay   uint64  `long:"maxloopinperday" description:"The maximum amount in satoshis that may be looped in within 24 hours, no matter who initiates the swaps. Set to 0 to disable."`
+	MaxSwapFeePercent float64 `long:"maxswapfeepercent" description:"The maximum swap fee a loop out or loop in may allow, as a percentage of the swap amount, for example 2 for 2%. Set to 0 to disable."`
+}
+
+// PoolConfig holds the limits of the Pool orders and accounts.
+type PoolConfig struct {
+	MaxOrderAmt       uint64 `long:"maxorderamt" description:"The maximum amount in satoshis of a single ask or bid. Set to 0 to disable."`
+	MaxBidRateFixed   uint32 `long:"maxbidratefixed" description:"The maximum fixed rate of a bid in parts per billion per block. Set to 0 to disable."`
+	MaxAccountFunding uint64 `long:"maxaccountfunding" description:"The maximum amount in satoshis an account may be opened with or that may be deposited into an account at once. Set to 0 to disable."`
+}
+
+// DefaultConfig constructs the default guardrails Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		Loop: &LoopConfig{},
+		Pool: &PoolConfig{},
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.Loop.MaxSwapFeePercent < 0 || c.Loop.MaxSwapFeePercent > 100 {
+		return fmt.Errorf("the maximum swap fee percentage must be " +
+			"between 0 and 100")
+	}
+
+	return nil
+}
+
+// Enabled returns true if any of the Loop limits is set.
+func (c *LoopConfig) Enabled() bool {
+	return c.MaxLoopOutPerDay > 0 || c.MaxLoopInPerDay > 0 ||
+		c.MaxSwapFeePercent > 0
+}
+
+// Enabled returns true if any of the Pool limits is set.
+func (c *PoolConfig) Enabled() bool {
+	return c.MaxOrderAmt > 0 || c.MaxBidRateFixed > 0 ||
+		c.MaxAccountFunding > 0
+}
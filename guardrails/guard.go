@@ -0,0 +1,562 @@
+package guardrails
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// InterceptorName is the name of the RPC middleware interceptor that
+	// enforces the guardrails.
+	InterceptorName = "lit-guardrails"
+
+	// MaxOverrideDuration is the longest time the guardrails can be lifted
+	// for at once.
+	MaxOverrideDuration = 24 * time.Hour
+
+	// budgetWindow is the window the daily budgets apply to.
+	budgetWindow = 24 * time.Hour
+
+	loopOutURI        = "/looprpc.SwapClient/LoopOut"
+	loopInURI         = "/looprpc.SwapClient/LoopIn"
+	submitOrderURI    = "/poolrpc.Trader/SubmitOrder"
+	initAccountURI    = "/poolrpc.Trader/InitAccount"
+	depositAccountURI = "/poolrpc.Trader/DepositAccount"
+
+	// macaroonHeader is the metadata key the macaroon of a request is
+	// sent with.
+	macaroonHeader = "macaroon"
+)
+
+var (
+	// ErrGuardrailViolation is returned if a request is rejected because
+	// it exceeds one of the limits.
+	ErrGuardrailViolation = errors.New("guardrail violation")
+
+	// ErrInvalidOverride is returned if the guardrails should be lifted
+	// for longer than allowed.
+	ErrInvalidOverride = fmt.Errorf("the guardrails can be lifted for at "+
+		"most %v", MaxOverrideDuration)
+
+	// guardedURIs are the URIs of the requests the guard checks.
+	guardedURIs = map[string]struct{}{
+		loopOutURI:        {},
+		loopInURI:         {},
+		submitOrderURI:    {},
+		initAccountURI:    {},
+		depositAccountURI: {},
+	}
+
+	// A compile-time assertion that Guard is a
+	// rpcmiddleware.RequestInterceptor.
+	_ mid.RequestInterceptor = (*Guard)(nil)
+)
+
+// SwapLister returns all swaps of the integrated loop daemon.
+type SwapLister func(ctx context.Context) ([]*looprpc.SwapStatus, error)
+
+// Auditor identifies the callers of the guarded RPCs and records the requests
+// that were rejected in the audit log.
+type Auditor interface {
+	// Actor returns the identity of the caller of the request with the
+	// given context.
+	Actor(ctx context.Context) string
+
+	// RecordViolation records in the audit log that the actor's call of
+	// the given RPC method with the given request was rejected for the
+	// given reason.
+	RecordViolation(actor, method string, req proto.Message,
+		reason string) error
+}
+
+// Status is the current state of the guardrails.
+type Status struct {
+	// LoopOutLastDay is the amount in satoshis that was looped out within
+	// the last 24 hours.
+	LoopOutLastDay uint64
+
+	// LoopInLastDay is the amount in satoshis that was looped in within
+	// the last 24 hours.
+	LoopInLastDay uint64
+
+	// OverrideUntil is the time until which the guardrails are lifted. It
+	// is zero if no override is active.
+	OverrideUntil time.Time
+}
+
+// reservation is the amount of a swap that was let through but might not be
+// listed by loop yet.
+type reservation struct {
+	swapType looprpc.SwapType
+	amount   uint64
+}
+
+// Guard enforces the configured limits on all swaps and Pool orders that are
+// requested through litd, no matter which macaroon they are requested with. It checks the
+// requests that reach the integrated daemons through lnd's RPC middleware and
+// those that are served by litd's own gRPC servers through a gRPC interceptor.
+type Guard struct {
+	// cfg holds the limits. It is replaced if the config is reloaded.
+	cfg       atomic.Pointer[Config]
+	listSwaps SwapLister
+	auditor   Auditor
+
+	// mu serializes the checks so that concurrent swaps can't both pass
+	// the same budget. It also guards the fields below.
+	mu sync.Mutex
+
+	// reservations are the swaps that were let through but whose request
+	// hasn't completed yet. Their amounts count towards the budgets in
+	// addition to the swaps loop lists, so a swap might briefly be
+	// counted twice, but never not at all.
+	reservations map[*reservation]struct{}
+
+	// requestReservations maps the IDs of the requests intercepted through
+	// the RPC middleware to their reservation, so it can be released once
+	// the response is seen.
+	requestReservations map[uint64]*reservation
+
+	overrideUntil time.Time
+
+	now func() time.Time
+}
+
+// NewGuard creates a new guard. The swap lister can be nil if loop doesn't run
+// integrated, in which case no daily budgets can be enforced. The auditor can
+// be nil if the rejected requests shouldn't be recorded.
+func NewGuard(cfg *Config, listSwaps SwapLister, auditor Auditor) *Guard {
+	g := &Guard{
+		listSwaps:           listSwaps,
+		auditor:             auditor,
+		reservations:        make(map[*reservation]struct{}),
+		requestReservations: make(map[uint64]*reservation),
+		now:                 time.Now,
+	}
+	g.cfg.Store(cfg)
+
+	return g
+}
+
+// Config returns the limits that are currently enforced.
+func (g *Guard) Config() *Config {
+	return g.cfg.Load()
+}
+
+// SetConfig replaces the limits. The swaps that were already let through
+// still count towards the new daily budgets.
+func (g *Guard) SetConfig(cfg *Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	g.cfg.Store(cfg)
+
+	return nil
+}
+
+// Name returns the name of the interceptor.
+func (g *Guard) Name() string {
+	return InterceptorName
+}
+
+// ReadOnly returns true if this interceptor should be registered in read-only
+// mode. In read-only mode no custom caveat name can be specified.
+//
+// NOTE: The guard registers in read-only mode so it receives all requests,
+// not just the ones of macaroons with a custom caveat. Read-only interceptors
+// can still reject requests.
+func (g *Guard) ReadOnly() bool {
+	return true
+}
+
+// CustomCaveatName returns the name of the custom caveat that is expected to be
+// handled by this interceptor. Cannot be specified in read-only mode.
+func (g *Guard) CustomCaveatName() string {
+	return ""
+}
+
+// Intercept checks the swap requests that reach lnd's gRPC server against the
+// limits and releases their reservation once they completed.
+//
+// NOTE: This is part of the rpcmiddleware.RequestInterceptor interface.
+func (g *Guard) Intercept(ctx context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	switch t := req.InterceptType.(type) {
+	case *lnrpc.RPCMiddlewareRequest_Request:
+		if _, ok := guardedURIs[t.Request.MethodFullUri]; !ok {
+			return mid.RPCOk(req)
+		}
+
+		msg, err := mid.ParseProtobuf(
+			t.Request.TypeName, t.Request.Serialized,
+		)
+		if err != nil {
+			return mid.RPCErrString(req, "error parsing proto: %v",
+				err)
+		}
+
+		r, err := g.checkRequest(ctx, msg)
+		if err != nil {
+			// The caller is identified by the macaroon the request
+			// was made with, just like litd's own RPCs are.
+			md := metadata.Pairs(
+				macaroonHeader, hex.EncodeToString(req.RawMacaroon),
+			)
+			g.recordViolation(
+				metadata.NewIncomingContext(ctx, md),
+				t.Request.MethodFullUri, msg, err,
+			)
+
+			return mid.RPCErr(req, err)
+		}
+
+		if r != nil {
+			g.mu.Lock()
+			g.requestReservations[req.RequestId] = r
+			g.mu.Unlock()
+		}
+
+	case *lnrpc.RPCMiddlewareRequest_Response:
+		g.mu.Lock()
+		if r, ok := g.requestReservations[req.RequestId]; ok {
+			delete(g.requestReservations, req.RequestId)
+			delete(g.reservations, r)
+		}
+		g.mu.Unlock()
+	}
+
+	return mid.RPCOk(req)
+}
+
+// UnaryServerInterceptor is a gRPC interceptor that checks the swap requests
+// that are served by litd's own gRPC servers against the limits.
+func (g *Guard) UnaryServerInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+
+	if _, ok := guardedURIs[info.FullMethod]; !ok {
+		return handler(ctx, req)
+	}
+
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	r, err := g.checkRequest(ctx, msg)
+	if err != nil {
+		g.recordViolation(ctx, info.FullMethod, msg, err)
+
+		return nil, err
+	}
+
+	if r != nil {
+		defer func() {
+			g.mu.Lock()
+			delete(g.reservations, r)
+			g.mu.Unlock()
+		}()
+	}
+
+	return handler(ctx, req)
+}
+
+// checkRequest checks the given request against the limits. If it passes and
+// it initiates a swap, the swap's amount is reserved until the returned
+// reservation is released.
+func (g *Guard) checkRequest(ctx context.Context,
+	msg proto.Message) (*reservation, error) {
+
+	cfg := g.cfg.Load()
+	loopCfg, poolCfg := cfg.Loop, cfg.Pool
+
+	switch r := msg.(type) {
+	case *looprpc.LoopOutRequest:
+		return g.checkSwap(
+			ctx, looprpc.SwapType_LOOP_OUT, r.Amt, r.MaxSwapFee,
+			loopCfg.MaxLoopOutPerDay,
+		)
+
+	case *looprpc.LoopInRequest:
+		return g.checkSwap(
+			ctx, looprpc.SwapType_LOOP_IN, r.Amt, r.MaxSwapFee,
+			loopCfg.MaxLoopInPerDay,
+		)
+
+	case *poolrpc.SubmitOrderRequest:
+		var (
+			details *poolrpc.Order
+			isBid   bool
+		)
+		switch {
+		case r.GetAsk() != nil:
+			details = r.GetAsk().Details
+
+		case r.GetBid() != nil:
+			details = r.GetBid().Details
+			isBid = true
+		}
+
+		if details == nil {
+			return nil, nil
+		}
+
+		return nil, g.checkPool(func() error {
+			if poolCfg.MaxOrderAmt > 0 &&
+				details.Amt > poolCfg.MaxOrderAmt {
+
+				return fmt.Errorf("the order amount of %d sat "+
+					"exceeds the limit of %d sat",
+					details.Amt, poolCfg.MaxOrderAmt)
+			}
+
+			if isBid && poolCfg.MaxBidRateFixed > 0 &&
+				details.RateFixed > poolCfg.MaxBidRateFixed {
+
+				return fmt.Errorf("the fixed rate of %d "+
+					"exceeds the limit of %d",
+					details.RateFixed,
+					poolCfg.MaxBidRateFixed)
+			}
+
+			return nil
+		})
+
+	case *poolrpc.InitAccountRequest:
+		return nil, g.checkPool(func() error {
+			return checkAccountFunding(poolCfg, r.AccountValue)
+		})
+
+	case *poolrpc.DepositAccountRequest:
+		return nil, g.checkPool(func() error {
+			return checkAccountFunding(poolCfg, r.AmountSat)
+		})
+
+	default:
+		return nil, nil
+	}
+}
+
+// checkSwap checks a swap of the given type against the limits and reserves
+// its amount if it passes.
+func (g *Guard) checkSwap(ctx context.Context, swapType looprpc.SwapType,
+	amt, maxSwapFee int64, dailyLimit uint64) (*reservation, error) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	if now.Before(g.overrideUntil) {
+		log.Infof("Guardrails lifted, allowing %v of %d sat", swapType,
+			amt)
+
+		return nil, nil
+	}
+
+	if amt <= 0 {
+		return nil, fmt.Errorf("%w: invalid swap amount %d",
+			ErrGuardrailViolation, amt)
+	}
+
+	maxFeePercent := g.cfg.Load().Loop.MaxSwapFeePercent
+	if maxFeePercent > 0 &&
+		float64(maxSwapFee) > float64(amt)*maxFeePercent/100 {
+
+		log.Warnf("Rejecting %v of %d sat with a maximum swap fee of %d "+
+			"sat", swapType, amt, maxSwapFee)
+
+		return nil, fmt.Errorf("%w: the maximum swap fee of %d sat is "+
+			"more than %v%% of the swap amount", ErrGuardrailViolation,
+			maxSwapFee, maxFeePercent)
+	}
+
+	if dailyLimit > 0 {
+		used, err := g.usedLocked(ctx, swapType, now)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine the swap "+
+				"budget: %v", err)
+		}
+
+		if used+uint64(amt) > dailyLimit {
+			log.Warnf("Rejecting %v of %d sat, %d sat were already "+
+				"swapped within the last 24 hours", swapType,
+				amt, used)
+
+			return nil, fmt.Errorf("%w: the swap would exceed the "+
+				"daily limit of %d sat, %d sat were already "+
+				"swapped within the last 24 hours",
+				ErrGuardrailViolation, dailyLimit, used)
+		}
+	}
+
+	r := &reservation{
+		swapType: swapType,
+		amount:   uint64(amt),
+	}
+	g.reservations[r] = struct{}{}
+
+	return r, nil
+}
+
+// checkPool runs the given check of a Pool request unless the guardrails are
+// lifted.
+func (g *Guard) checkPool(check func() error) error {
+	g.mu.Lock()
+	overridden := g.now().Before(g.overrideUntil)
+	g.mu.Unlock()
+
+	if overridden {
+		log.Infof("Guardrails lifted, allowing Pool request")
+
+		return nil
+	}
+
+	if err := check(); err != nil {
+		log.Warnf("Rejecting Pool request: %v", err)
+
+		return fmt.Errorf("%w: %v", ErrGuardrailViolation, err)
+	}
+
+	return nil
+}
+
+// checkAccountFunding checks the amount an account is opened with or that is
+// deposited into an account against the limit.
+func checkAccountFunding(cfg *PoolConfig, amt uint64) error {
+	if cfg.MaxAccountFunding > 0 && amt > cfg.MaxAccountFunding {
+		return fmt.Errorf("the account funding of %d sat exceeds the "+
+			"limit of %d sat", amt, cfg.MaxAccountFunding)
+	}
+
+	return nil
+}
+
+// recordViolation records the rejection of the given request in the audit
+// log. Errors that aren't caused by a violation of the limits, for example
+// because the budget couldn't be determined, aren't recorded.
+func (g *Guard) recordViolation(ctx context.Context, method string,
+	req proto.Message, reason error) {
+
+	if g.auditor == nil || !errors.Is(reason, ErrGuardrailViolation) {
+		return
+	}
+
+	err := g.auditor.RecordViolation(
+		g.auditor.Actor(ctx), method, req, reason.Error(),
+	)
+	if err != nil {
+		log.Errorf("Error recording guardrail violation of %v: %v",
+			method, err)
+	}
+}
+
+// usedLocked returns the amount in satoshis that was swapped with swaps of
+// the given type within the budget window, including the reserved amounts.
+// Failed swaps don't count towards the budget.
+//
+// NOTE: The mutex must be held when calling this method.
+func (g *Guard) usedLocked(ctx context.Context, swapType looprpc.SwapType,
+	now time.Time) (uint64, error) {
+
+	if g.listSwaps == nil {
+		return 0, fmt.Errorf("loop doesn't run integrated")
+	}
+
+	swaps, err := g.listSwaps(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	start := now.Add(-budgetWindow)
+
+	var used uint64
+	for _, swap := range swaps {
+		if swap.Type != swapType ||
+			swap.State == looprpc.SwapState_FAILED ||
+			time.Unix(0, swap.InitiationTime).Before(start) {
+
+			continue
+		}
+
+		used += uint64(swap.Amt)
+	}
+
+	for r := range g.reservations {
+		if r.swapType == swapType {
+			used += r.amount
+		}
+	}
+
+	return used, nil
+}
+
+// Override lifts the guardrails for the given duration and returns the time
+// until which they are lifted. A duration of zero ends an active override.
+func (g *Guard) Override(duration time.Duration) (time.Time, error) {
+	if duration < 0 || duration > MaxOverrideDuration {
+		return time.Time{}, ErrInvalidOverride
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if duration == 0 {
+		log.Infof("Guardrails override ended")
+		g.overrideUntil = time.Time{}
+
+		return time.Time{}, nil
+	}
+
+	g.overrideUntil = g.now().Add(duration)
+	log.Warnf("Guardrails lifted until %v", g.overrideUntil)
+
+	return g.overrideUntil, nil
+}
+
+// Status returns the current state of the guardrails.
+func (g *Guard) Status(ctx context.Context) (*Status, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	status := &Status{}
+	if now.Before(g.overrideUntil) {
+		status.OverrideUntil = g.overrideUntil
+	}
+
+	// The budgets can only be determined if loop runs integrated.
+	if g.listSwaps == nil {
+		return status, nil
+	}
+
+	var err error
+	status.LoopOutLastDay, err = g.usedLocked(
+		ctx, looprpc.SwapType_LOOP_OUT, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	status.LoopInLastDay, err = g.usedLocked(
+		ctx, looprpc.SwapType_LOOP_IN, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
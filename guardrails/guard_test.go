@@ -0,0 +1,312 @@
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// mockAuditor records the violations in memory.
+type mockAuditor struct {
+	violations []string
+}
+
+func (m *mockAuditor) Actor(context.Context) string {
+	return "test-actor"
+}
+
+func (m *mockAuditor) RecordViolation(actor, method string, _ proto.Message,
+	_ string) error {
+
+	m.violations = append(m.violations, actor+" "+method)
+	return nil
+}
+
+// newTestGuard creates a guard with the given limits that lists the given
+// swaps.
+func newTestGuard(cfg *LoopConfig, swaps *[]*looprpc.SwapStatus) *Guard {
+	guard := NewGuard(&Config{Loop: cfg, Pool: &PoolConfig{}}, func(
+		context.Context) ([]*looprpc.SwapStatus, error) {
+
+		return *swaps, nil
+	}, nil)
+	guard.now = func() time.Time {
+		return time.Unix(100_000, 0)
+	}
+
+	return guard
+}
+
+// TestSwapFee tests that swaps with excessive maximum swap fees are rejected
+// and that the limit can be replaced at runtime.
+func TestSwapFee(t *testing.T) {
+	var swaps []*looprpc.SwapStatus
+	guard := newTestGuard(&LoopConfig{MaxSwapFeePercent: 2}, &swaps)
+
+	ctx := context.Background()
+	_, err := guard.checkRequest(ctx, &looprpc.LoopOutRequest{
+		Amt:        100_000,
+		MaxSwapFee: 2_000,
+	})
+	require.NoError(t, err)
+
+	_, err = guard.checkRequest(ctx, &looprpc.LoopInRequest{
+		Amt:        100_000,
+		MaxSwapFee: 2_001,
+	})
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+
+	require.Error(t, guard.SetConfig(&Config{
+		Loop: &LoopConfig{MaxSwapFeePercent: 101},
+		Pool: &PoolConfig{},
+	}))
+	require.NoError(t, guard.SetConfig(&Config{
+		Loop: &LoopConfig{MaxSwapFeePercent: 3},
+		Pool: &PoolConfig{},
+	}))
+
+	_, err = guard.checkRequest(ctx, &looprpc.LoopInRequest{
+		Amt:        100_000,
+		MaxSwapFee: 2_001,
+	})
+	require.NoError(t, err)
+}
+
+// TestDailyLimit tests that the amounts of the swaps of the last 24 hours and
+// of the swaps in flight count towards the daily limits.
+func TestDailyLimit(t *testing.T) {
+	now := time.Unix(100_000, 0)
+	swaps := []*looprpc.SwapStatus{{
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_SUCCESS,
+		Amt:            400_000,
+		InitiationTime: now.Add(-time.Hour).UnixNano(),
+	}, {
+		// Failed swaps don't count.
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_FAILED,
+		Amt:            400_000,
+		InitiationTime: now.Add(-time.Hour).UnixNano(),
+	}, {
+		// Swaps older than 24 hours don't count.
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_SUCCESS,
+		Amt:            400_000,
+		InitiationTime: now.Add(-25 * time.Hour).UnixNano(),
+	}, {
+		// Swaps of the other type don't count.
+		Type:           looprpc.SwapType_LOOP_IN,
+		State:          looprpc.SwapState_SUCCESS,
+		Amt:            400_000,
+		InitiationTime: now.Add(-time.Hour).UnixNano(),
+	}}
+	guard := newTestGuard(&LoopConfig{MaxLoopOutPerDay: 1_000_000}, &swaps)
+
+	ctx := context.Background()
+	r, err := guard.checkRequest(ctx, &looprpc.LoopOutRequest{
+		Amt: 500_000,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	// The reservation of the first swap counts towards the limit.
+	_, err = guard.checkRequest(ctx, &looprpc.LoopOutRequest{
+		Amt: 100_001,
+	})
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+
+	status, err := guard.Status(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 900_000, status.LoopOutLastDay)
+	require.EqualValues(t, 400_000, status.LoopInLastDay)
+
+	// Loop ins are unlimited.
+	_, err = guard.checkRequest(ctx, &looprpc.LoopInRequest{
+		Amt: 5_000_000,
+	})
+	require.NoError(t, err)
+
+	// If the budget can't be determined, the swap is rejected.
+	guard.listSwaps = func(context.Context) ([]*looprpc.SwapStatus,
+		error) {
+
+		return nil, errors.New("loop not ready")
+	}
+	_, err = guard.checkRequest(ctx, &looprpc.LoopOutRequest{Amt: 1})
+	require.Error(t, err)
+}
+
+// TestOverride tests that all limits are lifted during an override.
+func TestOverride(t *testing.T) {
+	var swaps []*looprpc.SwapStatus
+	guard := newTestGuard(&LoopConfig{
+		MaxLoopOutPerDay:  1_000,
+		MaxSwapFeePercent: 1,
+	}, &swaps)
+
+	_, err := guard.Override(MaxOverrideDuration + time.Second)
+	require.ErrorIs(t, err, ErrInvalidOverride)
+
+	until, err := guard.Override(time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, guard.now().Add(time.Hour), until)
+
+	ctx := context.Background()
+	req := &looprpc.LoopOutRequest{
+		Amt:        100_000,
+		MaxSwapFee: 50_000,
+	}
+	_, err = guard.checkRequest(ctx, req)
+	require.NoError(t, err)
+
+	// Once the override ended, the limits apply again.
+	_, err = guard.Override(0)
+	require.NoError(t, err)
+
+	_, err = guard.checkRequest(ctx, req)
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+}
+
+// TestIntercept tests that the swaps are checked by both the RPC middleware
+// and the gRPC interceptor and that their reservations are released once the
+// requests completed.
+func TestIntercept(t *testing.T) {
+	var swaps []*looprpc.SwapStatus
+	guard := newTestGuard(&LoopConfig{MaxLoopInPerDay: 1_000_000}, &swaps)
+
+	serialized, err := proto.Marshal(&looprpc.LoopInRequest{
+		Amt: 600_000,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	resp, err := guard.Intercept(ctx, &lnrpc.RPCMiddlewareRequest{
+		RequestId: 1,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+			Request: &lnrpc.RPCMessage{
+				MethodFullUri: loopInURI,
+				TypeName:      "looprpc.LoopInRequest",
+				Serialized:    serialized,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetFeedback().Error)
+
+	// While the first swap is in flight, a second one exceeds the limit.
+	info := &grpc.UnaryServerInfo{FullMethod: loopInURI}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return &looprpc.SwapResponse{}, nil
+	}
+	_, err = guard.UnaryServerInterceptor(
+		ctx, &looprpc.LoopInRequest{Amt: 600_000}, info, handler,
+	)
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+
+	_, err = guard.Intercept(ctx, &lnrpc.RPCMiddlewareRequest{
+		RequestId: 1,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Response{
+			Response: &lnrpc.RPCMessage{
+				MethodFullUri: loopInURI,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, guard.reservations)
+
+	_, err = guard.UnaryServerInterceptor(
+		ctx, &looprpc.LoopInRequest{Amt: 600_000}, info, handler,
+	)
+	require.NoError(t, err)
+	require.Empty(t, guard.reservations)
+}
+
+// TestPool tests that Pool orders and account fundings are checked against
+// the limits and that violations are recorded.
+func TestPool(t *testing.T) {
+	var swaps []*looprpc.SwapStatus
+	guard := newTestGuard(&LoopConfig{}, &swaps)
+	guard.Config().Pool = &PoolConfig{
+		MaxOrderAmt:       1_000_000,
+		MaxBidRateFixed:   1_000,
+		MaxAccountFunding: 2_000_000,
+	}
+	auditor := &mockAuditor{}
+	guard.auditor = auditor
+
+	ctx := context.Background()
+	info := &grpc.UnaryServerInfo{FullMethod: submitOrderURI}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return &poolrpc.SubmitOrderResponse{}, nil
+	}
+	bid := func(amt uint64, rate uint32) *poolrpc.SubmitOrderRequest {
+		return &poolrpc.SubmitOrderRequest{
+			Details: &poolrpc.SubmitOrderRequest_Bid{
+				Bid: &poolrpc.Bid{
+					Details: &poolrpc.Order{
+						Amt:       amt,
+						RateFixed: rate,
+					},
+				},
+			},
+		}
+	}
+
+	_, err := guard.UnaryServerInterceptor(
+		ctx, bid(1_000_000, 1_000), info, handler,
+	)
+	require.NoError(t, err)
+
+	_, err = guard.UnaryServerInterceptor(
+		ctx, bid(1_000_001, 1_000), info, handler,
+	)
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+
+	_, err = guard.UnaryServerInterceptor(
+		ctx, bid(1_000_000, 1_001), info, handler,
+	)
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+
+	// The rate limit only applies to bids.
+	_, err = guard.checkRequest(ctx, &poolrpc.SubmitOrderRequest{
+		Details: &poolrpc.SubmitOrderRequest_Ask{
+			Ask: &poolrpc.Ask{
+				Details: &poolrpc.Order{
+					Amt:       1_000_000,
+					RateFixed: 5_000,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = guard.checkRequest(ctx, &poolrpc.InitAccountRequest{
+		AccountValue: 2_000_001,
+	})
+	require.ErrorIs(t, err, ErrGuardrailViolation)
+
+	_, err = guard.checkRequest(ctx, &poolrpc.DepositAccountRequest{
+		AmountSat: 2_000_000,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"test-actor " + submitOrderURI,
+		"test-actor " + submitOrderURI,
+	}, auditor.violations)
+
+	// During an override, all limits are lifted.
+	_, err = guard.Override(time.Minute)
+	require.NoError(t, err)
+
+	_, err = guard.checkRequest(ctx, bid(5_000_000, 5_000))
+	require.NoError(t, err)
+}
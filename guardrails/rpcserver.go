@@ -0,0 +1,79 @@
+package guardrails
+
+import (
+	"context"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the Guardrails gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedGuardrailsServer
+
+	guard *Guard
+}
+
+// NewRPCServer returns a new RPC server for the given guard.
+func NewRPCServer(guard *Guard) *RPCServer {
+	return &RPCServer{
+		guard: guard,
+	}
+}
+
+// GetGuardrails returns the configured limits, how much of the daily budgets
+// is used and whether the limits are currently lifted.
+func (s *RPCServer) GetGuardrails(ctx context.Context,
+	_ *litrpc.GetGuardrailsRequest) (*litrpc.GetGuardrailsResponse, error) {
+
+	status, err := s.guard.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := s.guard.Config()
+	loopCfg, poolCfg := cfg.Loop, cfg.Pool
+	return &litrpc.GetGuardrailsResponse{
+		Loop: &litrpc.LoopGuardrails{
+			MaxLoopOutPerDaySat: loopCfg.MaxLoopOutPerDay,
+			LoopOutLastDaySat:   status.LoopOutLastDay,
+			MaxLoopInPerDaySat:  loopCfg.MaxLoopInPerDay,
+			LoopInLastDaySat:    status.LoopInLastDay,
+			MaxSwapFeePercent:   loopCfg.MaxSwapFeePercent,
+		},
+		OverrideUntil: marshalTime(status.OverrideUntil),
+		Pool: &litrpc.PoolGuardrails{
+			MaxOrderAmtSat:       poolCfg.MaxOrderAmt,
+			MaxBidRateFixed:      poolCfg.MaxBidRateFixed,
+			MaxAccountFundingSat: poolCfg.MaxAccountFunding,
+		},
+	}, nil
+}
+
+// OverrideGuardrails lifts all limits for the given duration.
+func (s *RPCServer) OverrideGuardrails(_ context.Context,
+	req *litrpc.OverrideGuardrailsRequest) (
+	*litrpc.OverrideGuardrailsResponse, error) {
+
+	until, err := s.guard.Override(
+		time.Duration(req.DurationSec) * time.Second,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.OverrideGuardrailsResponse{
+		OverrideUntil: marshalTime(until),
+	}, nil
+}
+
+// marshalTime converts the given time into a unix timestamp, mapping the zero
+// time to zero.
+func marshalTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
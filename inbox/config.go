@@ -0,0 +1,33 @@
+package inbox
+
+import (
+	"fmt"
+)
+
+const (
+	// defaultMaxNotifications is the default number of notifications the
+	// inbox keeps.
+	defaultMaxNotifications = 1000
+)
+
+// Config holds all config options for the notification inbox.
+type Config struct {
+	MaxNotifications uint32 `long:"maxnotifications" description:"The maximum number of notifications the inbox of the web UI keeps. Once it is full, the oldest notifications are removed."`
+}
+
+// DefaultConfig constructs the default inbox Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxNotifications: defaultMaxNotifications,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.MaxNotifications == 0 {
+		return fmt.Errorf("the inbox must keep at least one " +
+			"notification")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package inbox
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultListLimit is the number of notifications that are listed if
+	// no limit is requested.
+	defaultListLimit = 100
+)
+
+// ErrNotStarted is returned if the inbox is used before it was started.
+var ErrNotStarted = errors.New("notification inbox not started")
+
+// Inbox keeps the notifications that are shown in the web UI, together with
+// whether they were read, so they survive reloads of the UI and all browsers
+// see the same state.
+type Inbox struct {
+	cfg *Config
+	dir string
+
+	// mu guards the store.
+	mu    sync.Mutex
+	store *Store
+}
+
+// NewInbox creates a new inbox that stores its notifications in the given
+// directory.
+func NewInbox(cfg *Config, dir string) *Inbox {
+	return &Inbox{
+		cfg: cfg,
+		dir: dir,
+	}
+}
+
+// Start opens the notification store.
+func (i *Inbox) Start() error {
+	store, err := NewStore(i.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open notification store: %v", err)
+	}
+
+	i.mu.Lock()
+	i.store = store
+	i.mu.Unlock()
+
+	return nil
+}
+
+// Stop closes the notification store.
+func (i *Inbox) Stop() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.store == nil {
+		return nil
+	}
+
+	err := i.store.Close()
+	i.store = nil
+
+	return err
+}
+
+// AddNotification adds the given notification to the inbox. Its ID and
+// creation time are set by the inbox.
+func (i *Inbox) AddNotification(n *Notification) error {
+	if n.Source == "" {
+		return fmt.Errorf("notification must have a source")
+	}
+
+	if n.Severity > SeverityCritical {
+		return fmt.Errorf("unknown severity %d", n.Severity)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.store == nil {
+		return ErrNotStarted
+	}
+
+	n.CreatedAt = time.Now()
+	n.ReadAt = time.Time{}
+	err := i.store.AddNotification(n, i.cfg.MaxNotifications)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Added %v notification %d from %s: %s", n.Severity, n.ID,
+		n.Source, n.Message)
+
+	return nil
+}
+
+// Notifications returns the notifications selected by the filter, newest
+// first, and the number of all notifications that weren't read yet. If the
+// filter has no limit, at most 100 notifications are returned.
+func (i *Inbox) Notifications(filter Filter) ([]*Notification, uint64,
+	error) {
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultListLimit
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.store == nil {
+		return nil, 0, ErrNotStarted
+	}
+
+	return i.store.Notifications(&filter)
+}
+
+// MarkRead marks the notifications with the given IDs or, if all is set, all
+// notifications as read. It returns the number of notifications that weren't
+// read before and the number of notifications that are still unread.
+func (i *Inbox) MarkRead(ids []uint64, all bool) (uint32, uint64, error) {
+	if len(ids) == 0 && !all {
+		return 0, 0, fmt.Errorf("either notification IDs or all must " +
+			"be set")
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.store == nil {
+		return 0, 0, ErrNotStarted
+	}
+
+	return i.store.MarkRead(ids, all, time.Now())
+}
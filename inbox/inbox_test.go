@@ -0,0 +1,133 @@
+package inbox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestInbox creates and starts an inbox that keeps at most max
+// notifications.
+func newTestInbox(t *testing.T, dir string, max uint32) *Inbox {
+	cfg := &Config{
+		MaxNotifications: max,
+	}
+	require.NoError(t, cfg.Validate())
+
+	inbox := NewInbox(cfg, dir)
+	require.NoError(t, inbox.Start())
+	t.Cleanup(func() {
+		require.NoError(t, inbox.Stop())
+	})
+
+	return inbox
+}
+
+// TestInboxListAndMarkRead makes sure notifications are listed newest first,
+// can be filtered and that their read state is persisted.
+func TestInboxListAndMarkRead(t *testing.T) {
+	dir := t.TempDir()
+	inbox := newTestInbox(t, dir, 10)
+
+	_, _, err := inbox.Notifications(Filter{})
+	require.NoError(t, err)
+
+	require.Error(t, inbox.AddNotification(&Notification{
+		Message: "no source",
+	}))
+
+	payload := json.RawMessage(`{"type":"offline_peer"}`)
+	for _, n := range []*Notification{{
+		Severity: SeverityInfo,
+		Source:   "watchdog",
+		Message:  "first",
+	}, {
+		Severity: SeverityCritical,
+		Source:   "watchdog",
+		Message:  "second",
+		Payload:  payload,
+	}, {
+		Severity: SeverityWarning,
+		Source:   "status",
+		Message:  "third",
+	}} {
+		require.NoError(t, inbox.AddNotification(n))
+	}
+
+	notifications, unread, err := inbox.Notifications(Filter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, unread)
+	require.Len(t, notifications, 3)
+	require.Equal(t, "third", notifications[0].Message)
+	require.Equal(t, "first", notifications[2].Message)
+	require.JSONEq(t, string(payload), string(notifications[1].Payload))
+
+	notifications, _, err = inbox.Notifications(Filter{
+		Source:      "watchdog",
+		MinSeverity: SeverityWarning,
+	})
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	require.Equal(t, "second", notifications[0].Message)
+
+	// Paging continues below the last ID of the previous page.
+	page, _, err := inbox.Notifications(Filter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+
+	page, _, err = inbox.Notifications(Filter{
+		BeforeID: page[1].ID,
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, "first", page[0].Message)
+
+	// Marking an unknown notification fails without marking anything.
+	_, _, err = inbox.MarkRead([]uint64{page[0].ID, 99}, false)
+	require.ErrorIs(t, err, ErrNotificationNotFound)
+
+	marked, unread, err := inbox.MarkRead([]uint64{page[0].ID}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, marked)
+	require.EqualValues(t, 2, unread)
+
+	// Notifications that were read already aren't counted again.
+	marked, unread, err = inbox.MarkRead(nil, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, marked)
+	require.EqualValues(t, 0, unread)
+
+	// The read state survives a restart.
+	require.NoError(t, inbox.Stop())
+	inbox = newTestInbox(t, dir, 10)
+
+	notifications, unread, err = inbox.Notifications(Filter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, unread)
+	require.Len(t, notifications, 3)
+	for _, n := range notifications {
+		require.True(t, n.Read())
+	}
+}
+
+// TestInboxPrune makes sure the oldest notifications are removed once the
+// inbox is full.
+func TestInboxPrune(t *testing.T) {
+	inbox := newTestInbox(t, t.TempDir(), 3)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, inbox.AddNotification(&Notification{
+			Source:  "test",
+			Message: "notification",
+		}))
+	}
+
+	notifications, unread, err := inbox.Notifications(Filter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, unread)
+	require.Len(t, notifications, 3)
+	require.EqualValues(t, 5, notifications[0].ID)
+	require.EqualValues(t, 3, notifications[2].ID)
+}
@@ -0,0 +1,123 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the Inbox gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedInboxServer
+
+	inbox *Inbox
+}
+
+// NewRPCServer returns a new RPC server for the given inbox.
+func NewRPCServer(inbox *Inbox) *RPCServer {
+	return &RPCServer{
+		inbox: inbox,
+	}
+}
+
+// ListNotifications lists the notifications of the inbox, newest first.
+func (s *RPCServer) ListNotifications(_ context.Context,
+	req *litrpc.ListNotificationsRequest) (
+	*litrpc.ListNotificationsResponse, error) {
+
+	minSeverity, err := unmarshalSeverity(req.MinSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, unread, err := s.inbox.Notifications(Filter{
+		UnreadOnly:  req.UnreadOnly,
+		Source:      req.Source,
+		MinSeverity: minSeverity,
+		BeforeID:    req.BeforeId,
+		Limit:       req.MaxNotifications,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListNotificationsResponse{
+		Notifications: make(
+			[]*litrpc.Notification, len(notifications),
+		),
+		UnreadCount: unread,
+	}
+	for i, n := range notifications {
+		resp.Notifications[i] = marshalNotification(n)
+	}
+
+	return resp, nil
+}
+
+// MarkNotificationsRead marks the given or all notifications of the inbox as
+// read.
+func (s *RPCServer) MarkNotificationsRead(_ context.Context,
+	req *litrpc.MarkNotificationsReadRequest) (
+	*litrpc.MarkNotificationsReadResponse, error) {
+
+	marked, unread, err := s.inbox.MarkRead(req.Ids, req.All)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.MarkNotificationsReadResponse{
+		Marked:      marked,
+		UnreadCount: unread,
+	}, nil
+}
+
+// marshalNotification converts a notification to its RPC form.
+func marshalNotification(n *Notification) *litrpc.Notification {
+	rpcNotification := &litrpc.Notification{
+		Id:        n.ID,
+		CreatedAt: n.CreatedAt.Unix(),
+		Severity:  marshalSeverity(n.Severity),
+		Source:    n.Source,
+		Message:   n.Message,
+		Payload:   string(n.Payload),
+		Read:      n.Read(),
+	}
+	if n.Read() {
+		rpcNotification.ReadAt = n.ReadAt.Unix()
+	}
+
+	return rpcNotification
+}
+
+// marshalSeverity converts a severity to its RPC form.
+func marshalSeverity(s Severity) litrpc.NotificationSeverity {
+	switch s {
+	case SeverityWarning:
+		return litrpc.NotificationSeverity_SEVERITY_WARNING
+
+	case SeverityCritical:
+		return litrpc.NotificationSeverity_SEVERITY_CRITICAL
+
+	default:
+		return litrpc.NotificationSeverity_SEVERITY_INFO
+	}
+}
+
+// unmarshalSeverity converts a severity from its RPC form.
+func unmarshalSeverity(s litrpc.NotificationSeverity) (Severity, error) {
+	switch s {
+	case litrpc.NotificationSeverity_SEVERITY_INFO:
+		return SeverityInfo, nil
+
+	case litrpc.NotificationSeverity_SEVERITY_WARNING:
+		return SeverityWarning, nil
+
+	case litrpc.NotificationSeverity_SEVERITY_CRITICAL:
+		return SeverityCritical, nil
+
+	default:
+		return 0, fmt.Errorf("unknown severity %v", s)
+	}
+}
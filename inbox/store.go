@@ -0,0 +1,357 @@
+package inbox
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the inbox database.
+	DBFilename = "inbox.db"
+
+	// dbFilePermission is the default permission the inbox database file
+	// is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The notifications are stored in the following structure in the db:
+
+	notifications -> id -> json encoded Notification
+
+	The IDs are taken from the sequence of the bucket and are encoded big
+	endian, so the notifications are ordered from oldest to newest.
+*/
+
+var (
+	// notificationsBucketKey is the key of the top level bucket holding all
+	// notifications.
+	notificationsBucketKey = []byte("notifications")
+
+	// ErrNotificationNotFound is returned if a notification doesn't exist.
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// Severity is the severity of a notification.
+type Severity uint8
+
+const (
+	// SeverityInfo is used for purely informational notifications.
+	SeverityInfo Severity = 0
+
+	// SeverityWarning is used for problems that should be looked at.
+	SeverityWarning Severity = 1
+
+	// SeverityCritical is used for problems that need immediate
+	// attention.
+	SeverityCritical Severity = 2
+)
+
+// String returns a human-readable name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+
+	case SeverityWarning:
+		return "warning"
+
+	case SeverityCritical:
+		return "critical"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Notification is a message that is shown in the inbox of the web UI.
+type Notification struct {
+	// ID uniquely identifies the notification. Newer notifications have
+	// higher IDs.
+	ID uint64 `json:"id"`
+
+	// CreatedAt is the time at which the notification was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Severity is the severity of the notification.
+	Severity Severity `json:"severity"`
+
+	// Source is the subsystem that created the notification, for example
+	// watchdog.
+	Source string `json:"source"`
+
+	// Message is a human-readable description of the notification.
+	Message string `json:"message"`
+
+	// Payload holds the JSON encoded details of the notification. Its
+	// format depends on the source.
+	Payload json.RawMessage `json:"payload,omitempty"`
+
+	// ReadAt is the time at which the notification was read. It is zero
+	// if the notification wasn't read yet.
+	ReadAt time.Time `json:"read_at"`
+}
+
+// Read returns true if the notification was read.
+func (n *Notification) Read() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// Filter selects the notifications that are listed.
+type Filter struct {
+	// UnreadOnly selects only the notifications that weren't read yet.
+	UnreadOnly bool
+
+	// Source selects only the notifications of this source, if set.
+	Source string
+
+	// MinSeverity selects only the notifications with at least this
+	// severity.
+	MinSeverity Severity
+
+	// BeforeID selects only the notifications with a lower ID, if set.
+	BeforeID uint64
+
+	// Limit is the maximum number of notifications that are selected.
+	Limit uint32
+}
+
+// matches returns true if the notification is selected by the filter.
+func (f *Filter) matches(n *Notification) bool {
+	if f.UnreadOnly && n.Read() {
+		return false
+	}
+
+	if f.Source != "" && n.Source != f.Source {
+		return false
+	}
+
+	return n.Severity >= f.MinSeverity
+}
+
+// notificationKey returns the db key of the notification with the given ID.
+func notificationKey(id uint64) []byte {
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], id)
+
+	return key[:]
+}
+
+// Store is a bolt-backed persistent store of the notifications.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the inbox store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(notificationsBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// AddNotification stores the given notification under a new ID, which is set
+// on the notification. If more than max notifications are stored afterwards,
+// the oldest ones are removed.
+func (s *Store) AddNotification(n *Notification, max uint32) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(notificationsBucketKey)
+
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		n.ID = id
+
+		b, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put(notificationKey(id), b); err != nil {
+			return err
+		}
+
+		// We collect the oldest keys first, as the bucket must not be
+		// changed while we iterate over it.
+		cursor := bucket.Cursor()
+		excess := -int(max)
+		for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+			excess++
+		}
+
+		var prune [][]byte
+		for k, _ := cursor.First(); k != nil && excess > 0; {
+			prune = append(prune, append([]byte(nil), k...))
+			excess--
+
+			k, _ = cursor.Next()
+		}
+
+		for _, k := range prune {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Notifications returns the notifications selected by the filter, newest
+// first, and the number of all notifications that weren't read yet.
+func (s *Store) Notifications(filter *Filter) ([]*Notification, uint64,
+	error) {
+
+	var (
+		notifications []*Notification
+		unread        uint64
+	)
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(notificationsBucketKey).Cursor()
+		for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+			var n Notification
+			if err := json.Unmarshal(v, &n); err != nil {
+				return err
+			}
+
+			if !n.Read() {
+				unread++
+			}
+
+			if filter.BeforeID != 0 && n.ID >= filter.BeforeID {
+				continue
+			}
+
+			if filter.Limit != 0 &&
+				len(notifications) >= int(filter.Limit) {
+
+				continue
+			}
+
+			if filter.matches(&n) {
+				notifications = append(notifications, &n)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, unread, nil
+}
+
+// MarkRead marks the notifications with the given IDs or, if all is set, all
+// notifications as read at the given time. It returns the number of
+// notifications that weren't read before and the number of notifications that
+// are still unread. If one of the IDs doesn't exist, no notification is
+// marked.
+func (s *Store) MarkRead(ids []uint64, all bool, now time.Time) (uint32,
+	uint64, error) {
+
+	var (
+		marked uint32
+		unread uint64
+	)
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(notificationsBucketKey)
+
+		selected := make(map[uint64]struct{}, len(ids))
+		for _, id := range ids {
+			if bucket.Get(notificationKey(id)) == nil {
+				return fmt.Errorf("%w: %d",
+					ErrNotificationNotFound, id)
+			}
+
+			selected[id] = struct{}{}
+		}
+
+		// We collect the updates first, as the bucket must not be
+		// changed while we iterate over it.
+		updates := make(map[uint64][]byte)
+		err := bucket.ForEach(func(k, v []byte) error {
+			var n Notification
+			if err := json.Unmarshal(v, &n); err != nil {
+				return err
+			}
+
+			if n.Read() {
+				return nil
+			}
+
+			if _, ok := selected[n.ID]; !ok && !all {
+				unread++
+				return nil
+			}
+
+			n.ReadAt = now
+			b, err := json.Marshal(&n)
+			if err != nil {
+				return err
+			}
+
+			updates[n.ID] = b
+			marked++
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for id, b := range updates {
+			err := bucket.Put(notificationKey(id), b)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return marked, unread, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
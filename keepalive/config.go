@@ -0,0 +1,180 @@
+package keepalive
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// defaultTCP is the default period of the TCP keep-alive probes of
+	// litd's listeners, which is also Go's default.
+	defaultTCP = 15 * time.Second
+
+	// defaultWebsocketPing is the default interval in which the gRPC web
+	// websocket connections of browsers are pinged.
+	defaultWebsocketPing = 2 * time.Minute
+
+	// defaultServerTime is the default time after which litd's gRPC
+	// servers ping an idle client, which is also gRPC's default.
+	defaultServerTime = 2 * time.Hour
+
+	// defaultMinClientInterval is the default minimum interval in which
+	// clients may ping litd's gRPC servers, which is also gRPC's default.
+	defaultMinClientInterval = 5 * time.Minute
+
+	// defaultTimeout is the default time a ping may take before the
+	// connection is closed, which is also gRPC's default.
+	defaultTimeout = 20 * time.Second
+
+	// defaultLNCHeartbeat is the default interval in which the connections
+	// of LNC sessions to their mailbox server are pinged.
+	defaultLNCHeartbeat = 2 * time.Minute
+
+	// minClientTime is the shortest interval in which a gRPC client may
+	// ping a server. gRPC silently raises shorter intervals to it.
+	minClientTime = 10 * time.Second
+
+	// minServerTime is the shortest interval in which a gRPC server may
+	// ping a client. gRPC silently raises shorter intervals to it.
+	minServerTime = time.Second
+)
+
+// Config holds the keepalive options of litd's connections.
+type Config struct {
+	TCP           time.Duration `long:"tcp" description:"The period of the TCP keep-alive probes of the connections to litd's HTTP(S) listeners, which serve gRPC, gRPC web and REST calls. Set to 0 to disable the probes."`
+	WebsocketPing time.Duration `long:"websocketping" description:"The interval in which the gRPC web websocket connections of browsers are pinged."`
+
+	ServerTime          time.Duration `long:"servertime" description:"The time after which the gRPC servers of LNC sessions ping a client that wasn't active, to check that the connection is still alive."`
+	ServerTimeout       time.Duration `long:"servertimeout" description:"The time the gRPC servers of LNC sessions wait for the response to a ping before they close the connection."`
+	MinClientInterval   time.Duration `long:"minclientinterval" description:"The shortest interval in which clients of LNC sessions may ping litd. Clients that ping more often are disconnected."`
+	PermitWithoutStream bool          `long:"permitwithoutstream" description:"Allow clients of LNC sessions to ping litd even if they have no call in flight."`
+
+	BackendTime    time.Duration `long:"backendtime" description:"The time after which litd pings the remote lnd, faraday, loop and pool daemons it forwards calls to if the connection wasn't active. Set to 0 to disable the pings. Must be at least 10s. The daemons must allow pings in this interval."`
+	BackendTimeout time.Duration `long:"backendtimeout" description:"The time litd waits for the response to a ping of a remote daemon before it closes the connection."`
+
+	LNCHeartbeat        time.Duration `long:"lncheartbeat" description:"The time after which the connections of LNC sessions to their mailbox server are pinged if they weren't active. Set to 0 to disable the pings. Must be at least 10s."`
+	LNCHeartbeatTimeout time.Duration `long:"lncheartbeattimeout" description:"The time litd waits for the response to a ping of a mailbox server before it closes the connection and reconnects."`
+}
+
+// DefaultConfig constructs the default keepalive Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		TCP:                 defaultTCP,
+		WebsocketPing:       defaultWebsocketPing,
+		ServerTime:          defaultServerTime,
+		ServerTimeout:       defaultTimeout,
+		MinClientInterval:   defaultMinClientInterval,
+		BackendTimeout:      defaultTimeout,
+		LNCHeartbeat:        defaultLNCHeartbeat,
+		LNCHeartbeatTimeout: defaultTimeout,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.TCP < 0 {
+		return fmt.Errorf("the TCP keep-alive period must not be " +
+			"negative")
+	}
+
+	if c.WebsocketPing <= 0 {
+		return fmt.Errorf("the websocket ping interval must be " +
+			"positive")
+	}
+
+	if c.ServerTime < minServerTime {
+		return fmt.Errorf("the server keepalive time must be at least "+
+			"%v", minServerTime)
+	}
+
+	// gRPC would use its default of 5 minutes for an interval of 0.
+	if c.MinClientInterval <= 0 {
+		return fmt.Errorf("the minimum client ping interval must be " +
+			"positive")
+	}
+
+	if c.BackendTime != 0 && c.BackendTime < minClientTime {
+		return fmt.Errorf("the backend keepalive time must be at "+
+			"least %v", minClientTime)
+	}
+
+	if c.LNCHeartbeat != 0 && c.LNCHeartbeat < minClientTime {
+		return fmt.Errorf("the LNC heartbeat must be at least %v",
+			minClientTime)
+	}
+
+	if c.ServerTimeout <= 0 || c.BackendTimeout <= 0 ||
+		c.LNCHeartbeatTimeout <= 0 {
+
+		return fmt.Errorf("the keepalive timeouts must be positive")
+	}
+
+	return nil
+}
+
+// Listen announces on the given TCP address with the configured TCP keep-alive
+// period.
+func (c *Config) Listen(addr string) (net.Listener, error) {
+	// A negative keep-alive period disables the probes, zero would mean
+	// Go's default.
+	period := c.TCP
+	if period == 0 {
+		period = -1
+	}
+
+	lc := net.ListenConfig{KeepAlive: period}
+
+	return lc.Listen(context.Background(), "tcp", addr)
+}
+
+// ServerOptions returns the options of the gRPC servers of LNC sessions.
+func (c *Config) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    c.ServerTime,
+			Timeout: c.ServerTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.MinClientInterval,
+			PermitWithoutStream: c.PermitWithoutStream,
+		}),
+	}
+}
+
+// BackendDialOptions returns the options of the connections to the remote
+// daemons litd forwards calls to.
+func (c *Config) BackendDialOptions() []grpc.DialOption {
+	if c.BackendTime == 0 {
+		return nil
+	}
+
+	// The connections to the daemons are kept open while litd runs, so
+	// they also need to be pinged if there is no call in flight.
+	return []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.BackendTime,
+			Timeout:             c.BackendTimeout,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// LNCDialOptions returns the options of the connections of LNC sessions to
+// their mailbox server.
+func (c *Config) LNCDialOptions() []grpc.DialOption {
+	if c.LNCHeartbeat == 0 {
+		return nil
+	}
+
+	return []grpc.DialOption{
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    c.LNCHeartbeat,
+			Timeout: c.LNCHeartbeatTimeout,
+		}),
+	}
+}
@@ -0,0 +1,134 @@
+package keepalive
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate tests that keepalive settings that gRPC would silently change
+// or that can't work are rejected.
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DefaultConfig().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		valid  bool
+	}{{
+		name: "backend pings disabled",
+		modify: func(c *Config) {
+			c.BackendTime = 0
+		},
+		valid: true,
+	}, {
+		name: "backend pings enabled",
+		modify: func(c *Config) {
+			c.BackendTime = 30 * time.Second
+		},
+		valid: true,
+	}, {
+		name: "backend pings too frequent",
+		modify: func(c *Config) {
+			c.BackendTime = 5 * time.Second
+		},
+	}, {
+		name: "LNC heartbeat disabled",
+		modify: func(c *Config) {
+			c.LNCHeartbeat = 0
+		},
+		valid: true,
+	}, {
+		name: "LNC heartbeat too frequent",
+		modify: func(c *Config) {
+			c.LNCHeartbeat = time.Second
+		},
+	}, {
+		name: "server pings too frequent",
+		modify: func(c *Config) {
+			c.ServerTime = 500 * time.Millisecond
+		},
+	}, {
+		name: "TCP keep-alive disabled",
+		modify: func(c *Config) {
+			c.TCP = 0
+		},
+		valid: true,
+	}, {
+		name: "negative TCP keep-alive",
+		modify: func(c *Config) {
+			c.TCP = -time.Second
+		},
+	}, {
+		name: "no websocket ping",
+		modify: func(c *Config) {
+			c.WebsocketPing = 0
+		},
+	}, {
+		name: "no timeout",
+		modify: func(c *Config) {
+			c.LNCHeartbeatTimeout = 0
+		},
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig()
+			test.modify(cfg)
+
+			err := cfg.Validate()
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+// TestDialOptions tests that the pings of the client connections are only
+// configured if they are enabled.
+func TestDialOptions(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	require.Empty(t, cfg.BackendDialOptions())
+	require.Len(t, cfg.LNCDialOptions(), 1)
+	require.Len(t, cfg.ServerOptions(), 2)
+
+	cfg.BackendTime = time.Minute
+	cfg.LNCHeartbeat = 0
+	require.Len(t, cfg.BackendDialOptions(), 1)
+	require.Empty(t, cfg.LNCDialOptions())
+}
+
+// TestListen tests that the listener accepts connections with and without TCP
+// keep-alive probes.
+func TestListen(t *testing.T) {
+	t.Parallel()
+
+	for _, period := range []time.Duration{0, time.Minute} {
+		cfg := DefaultConfig()
+		cfg.TCP = period
+
+		lis, err := cfg.Listen("127.0.0.1:0")
+		require.NoError(t, err)
+
+		conn, err := net.Dial("tcp", lis.Addr().String())
+		require.NoError(t, err)
+
+		accepted, err := lis.Accept()
+		require.NoError(t, err)
+
+		require.NoError(t, accepted.Close())
+		require.NoError(t, conn.Close())
+		require.NoError(t, lis.Close())
+	}
+}
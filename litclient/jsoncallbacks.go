@@ -46,4 +46,19 @@ var Registrations = []StubPackageRegistration{
 	litrpc.RegisterAccountsJSONCallbacks,
 	litrpc.RegisterAutopilotJSONCallbacks,
 	litrpc.RegisterFirewallJSONCallbacks,
+	litrpc.RegisterBackupsJSONCallbacks,
+	litrpc.RegisterReportsJSONCallbacks,
+	litrpc.RegisterNostrWalletConnectJSONCallbacks,
+	litrpc.RegisterLnurlWithdrawJSONCallbacks,
+	litrpc.RegisterAccountPortalJSONCallbacks,
+	litrpc.RegisterApiKeysJSONCallbacks,
+	litrpc.RegisterNodeManagementJSONCallbacks,
+	litrpc.RegisterFeeSchedulerJSONCallbacks,
+	litrpc.RegisterWatchdogJSONCallbacks,
+	litrpc.RegisterGuardrailsJSONCallbacks,
+	litrpc.RegisterUIFlagsJSONCallbacks,
+	litrpc.RegisterInboxJSONCallbacks,
+	litrpc.RegisterStatusJSONCallbacks,
+	litrpc.RegisterProvisioningJSONCallbacks,
+	litrpc.RegisterProxyJSONCallbacks,
 }
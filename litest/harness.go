@@ -0,0 +1,282 @@
+// Package litest provides a test harness that starts a regtest network with
+// litd nodes, so that applications built on top of lightning-terminal can be
+// tested end-to-end from their own repositories.
+//
+// The harness needs the same btcd, lnd-itest and litd-itest binaries as the
+// integration tests of this repository. They are built by the build-itest and
+// itest-only targets of the Makefile.
+package litest
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/aperture"
+	"github.com/lightninglabs/lightning-node-connect/gbn"
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/lightninglabs/lightning-terminal/itest"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd"
+	"github.com/lightningnetwork/lnd/build"
+	"github.com/lightningnetwork/lnd/lntest"
+	"github.com/lightningnetwork/lnd/signal"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefaultLitdBinary is the default name of the litd binary that was
+	// compiled with the itest build tags.
+	DefaultLitdBinary = "litd-itest"
+
+	// DefaultLndBinary is the default name of the lnd binary that was
+	// compiled with the itest build tags.
+	DefaultLndBinary = "lnd-itest"
+
+	// DefaultTimeout is the default timeout used for RPCs of the harness.
+	DefaultTimeout = lntest.DefaultTimeout
+)
+
+var (
+	// defaultLndArgs are the lnd flags the nodes of the harness are started
+	// with in addition to the flags of the Config.
+	defaultLndArgs = []string{
+		"--default-remote-max-htlcs=483",
+		"--dust-threshold=5000000",
+		"--rpcmiddleware.enable",
+	}
+
+	// setupLoggingOnce makes sure the signal interceptor is only created
+	// once per process.
+	setupLoggingOnce sync.Once
+)
+
+// Config holds the configuration of the harness.
+type Config struct {
+	// LitdBinary is the path to the litd binary. If empty, the
+	// LITD_ITEST_BINARY environment variable or DefaultLitdBinary is used.
+	LitdBinary string
+
+	// LndBinary is the path to the lnd binary. If empty, the
+	// LND_ITEST_BINARY environment variable or DefaultLndBinary is used.
+	LndBinary string
+
+	// LndArgs are additional flags both nodes are started with, formatted
+	// as "--arg=value".
+	LndArgs []string
+}
+
+// Harness is a regtest network with two funded and connected litd nodes.
+// Alice runs lnd in integrated mode, Bob connects to a remote lnd.
+type Harness struct {
+	*itest.NetworkHarness
+
+	t *testing.T
+}
+
+// New starts a new harness for the given test. The harness is stopped when
+// the test finishes.
+func New(t *testing.T, cfg *Config) *Harness {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	litdBinary := binaryPath(
+		cfg.LitdBinary, "LITD_ITEST_BINARY", DefaultLitdBinary,
+	)
+	lndBinary := binaryPath(
+		cfg.LndBinary, "LND_ITEST_BINARY", DefaultLndBinary,
+	)
+
+	setupLogging(t)
+
+	feeService := lntest.NewFeeService(t)
+	lndHarness := lntest.SetupHarness(t, lndBinary, "bbolt", feeService)
+	t.Cleanup(func() {
+		lndHarness.Stop()
+	})
+
+	chainBackend, _, err := lntest.NewBackend(
+		lndHarness.Miner.P2PAddress(), &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err, "new backend")
+	require.NoError(t, chainBackend.ConnectMiner(), "connect miner")
+
+	net, err := itest.NewNetworkHarness(
+		lndHarness.Subtest(t), chainBackend, litdBinary,
+	)
+	require.NoError(t, err)
+
+	lndArgs := append([]string{}, defaultLndArgs...)
+	lndArgs = append(lndArgs, cfg.LndArgs...)
+
+	testName := strings.ReplaceAll(t.Name(), "/", "_")
+	require.NoError(t, net.SetUp(t, testName, lndArgs), "harness setup")
+	t.Cleanup(func() {
+		require.NoError(t, net.TearDown())
+		net.Stop()
+	})
+
+	net.EnsureConnected(t, net.Alice, net.Bob)
+
+	return &Harness{
+		NetworkHarness: net,
+		t:              t,
+	}
+}
+
+// binaryPath returns the configured path, the value of the environment
+// variable or the default, in that order.
+func binaryPath(configured, envVar, defaultPath string) string {
+	if configured != "" {
+		return configured
+	}
+
+	if fromEnv := os.Getenv(envVar); fromEnv != "" {
+		return fromEnv
+	}
+
+	return defaultPath
+}
+
+// setupLogging sets up the loggers of the mailbox client that is used to
+// connect over LNC.
+func setupLogging(t *testing.T) {
+	setupLoggingOnce.Do(func() {
+		logWriter := build.NewRotatingLogWriter()
+
+		interceptor, err := signal.Intercept()
+		require.NoError(t, err)
+
+		aperture.SetupLoggers(logWriter, interceptor)
+		lnd.AddSubLogger(
+			logWriter, mailbox.Subsystem, interceptor,
+			mailbox.UseLogger,
+		)
+		lnd.AddSubLogger(
+			logWriter, gbn.Subsystem, interceptor, gbn.UseLogger,
+		)
+
+		err = build.ParseAndSetDebugLevels("debug", logWriter)
+		require.NoError(t, err)
+	})
+}
+
+// LitConn returns a connection to the litd RPC server of the given node. The
+// RPCs sent over the connection are authenticated with the lit macaroon of
+// the node. The connection is closed when the test finishes.
+func (h *Harness) LitConn(node *itest.HarnessNode) *grpc.ClientConn {
+	cfg := node.Cfg
+
+	macBytes, err := os.ReadFile(cfg.LitMacPath)
+	require.NoError(h.t, err)
+
+	tlsCreds, err := credentials.NewClientTLSFromFile(
+		cfg.LitTLSCertPath, "",
+	)
+	require.NoError(h.t, err)
+
+	ctxt, cancel := context.WithTimeout(
+		context.Background(), DefaultTimeout,
+	)
+	defer cancel()
+
+	conn, err := grpc.DialContext(
+		ctxt, cfg.LitAddr(), grpc.WithBlock(),
+		grpc.WithTransportCredentials(tlsCreds),
+		grpc.WithPerRPCCredentials(macaroonCredentials(macBytes)),
+	)
+	require.NoError(h.t, err)
+	h.t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// AddSession creates a session on the given node and returns it. If no
+// mailbox server is set in the request, DefaultMailboxServerAddr is used. If
+// no expiry is set, the session expires after one day.
+func (h *Harness) AddSession(node *itest.HarnessNode,
+	req *litrpc.AddSessionRequest) *litrpc.Session {
+
+	if req.MailboxServerAddr == "" {
+		req.MailboxServerAddr = DefaultMailboxServerAddr
+	}
+	if req.ExpiryTimestampSeconds == 0 {
+		req.ExpiryTimestampSeconds = uint64(
+			time.Now().Add(24 * time.Hour).Unix(),
+		)
+	}
+
+	ctxt, cancel := context.WithTimeout(
+		context.Background(), DefaultTimeout,
+	)
+	defer cancel()
+
+	client := litrpc.NewSessionsClient(h.LitConn(node))
+	resp, err := client.AddSession(ctxt, req)
+	require.NoError(h.t, err)
+
+	return resp.Session
+}
+
+// ConnectSession connects to the node of the given session over LNC, using
+// the pairing phrase of the session. The connection is closed when the test
+// finishes.
+func (h *Harness) ConnectSession(session *litrpc.Session) *grpc.ClientConn {
+	ctxt, cancel := context.WithTimeout(
+		context.Background(), DefaultTimeout,
+	)
+	defer cancel()
+
+	conn, err := ConnectLNC(
+		ctxt, session.MailboxServerAddr,
+		strings.Split(session.PairingSecretMnemonic, " "),
+	)
+	require.NoError(h.t, err)
+	h.t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// macaroonCredentials is a grpc.PerRPCCredentials implementation that adds a
+// macaroon to each request.
+type macaroonCredentials []byte
+
+// GetRequestMetadata returns the hex encoded macaroon.
+//
+// NOTE: This is part of the credentials.PerRPCCredentials interface.
+func (m macaroonCredentials) GetRequestMetadata(context.Context,
+	...string) (map[string]string, error) {
+
+	return map[string]string{
+		"macaroon": hex.EncodeToString(m),
+	}, nil
+}
+
+// RequireTransportSecurity returns true as the lit RPC server only accepts
+// TLS connections.
+//
+// NOTE: This is part of the credentials.PerRPCCredentials interface.
+func (m macaroonCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// MacaroonContext returns a context that authenticates the RPCs sent with it
+// with the given macaroon.
+func MacaroonContext(ctx context.Context, macBytes []byte) context.Context {
+	return metadata.AppendToOutgoingContext(
+		ctx, "macaroon", hex.EncodeToString(macBytes),
+	)
+}
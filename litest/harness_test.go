@@ -0,0 +1,35 @@
+//go:build itest
+// +build itest
+
+package litest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHarness tests that a session created through the harness can be used to
+// connect to the node over LNC.
+func TestHarness(t *testing.T) {
+	h := New(t, nil)
+
+	session := h.AddSession(h.Alice, &litrpc.AddSessionRequest{
+		Label:       "litest",
+		SessionType: litrpc.SessionType_TYPE_MACAROON_READONLY,
+	})
+
+	client := lnrpc.NewLightningClient(h.ConnectSession(session))
+
+	ctxt, cancel := context.WithTimeout(
+		context.Background(), DefaultTimeout,
+	)
+	defer cancel()
+
+	info, err := client.GetInfo(ctxt, &lnrpc.GetInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, h.Alice.PubKeyStr, info.IdentityPubkey)
+}
@@ -0,0 +1,49 @@
+package litest
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/lightningnetwork/lnd/keychain"
+	"google.golang.org/grpc"
+)
+
+// DefaultMailboxServerAddr is the mailbox server that sessions created by the
+// harness use by default.
+const DefaultMailboxServerAddr = "mailbox.testnet.lightningcluster.com:443"
+
+// ConnectLNC connects to a litd node over Lightning Node Connect using the
+// pairing phrase of a session. The returned connection can be used with any
+// of the RPC clients the session has permissions for.
+func ConnectLNC(ctx context.Context, mailboxServerAddr string,
+	pairingPhrase []string) (*grpc.ClientConn, error) {
+
+	var mnemonicWords [mailbox.NumPassphraseWords]string
+	copy(mnemonicWords[:], pairingPhrase)
+	passphrase := mailbox.PassphraseMnemonicToEntropy(mnemonicWords)
+
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	ecdh := &keychain.PrivKeyECDH{PrivKey: privKey}
+
+	connData := mailbox.NewConnData(ecdh, nil, passphrase[:], nil, nil, nil)
+
+	transportConn, err := mailbox.NewClient(ctx, connData)
+	if err != nil {
+		return nil, err
+	}
+
+	noiseConn := mailbox.NewNoiseGrpcConn(connData)
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithContextDialer(transportConn.Dial),
+		grpc.WithTransportCredentials(noiseConn),
+		grpc.WithPerRPCCredentials(noiseConn),
+		grpc.WithBlock(),
+	}
+
+	return grpc.DialContext(ctx, mailboxServerAddr, dialOpts...)
+}
@@ -0,0 +1,143 @@
+package litrpc
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewStatusError creates a gRPC status error with the given code that carries
+// the given detail. The message of the status is the message of the detail so
+// that clients that don't look at the details still see the same error.
+func NewStatusError(c codes.Code, detail *ErrorDetail) error {
+	st, err := status.New(c, detail.Message).WithDetails(detail)
+	if err != nil {
+		// This can only happen if the detail can't be marshaled, in
+		// which case we still return the plain status.
+		return status.Error(c, detail.Message)
+	}
+
+	return st.Err()
+}
+
+// AccountNotFoundError returns a NotFound status error with the
+// ERROR_ACCOUNT_NOT_FOUND code.
+func AccountNotFoundError(msg string) error {
+	return NewStatusError(codes.NotFound, &ErrorDetail{
+		Code:    ErrorCode_ERROR_ACCOUNT_NOT_FOUND,
+		Message: msg,
+	})
+}
+
+// AccountExpiredError returns a FailedPrecondition status error with the
+// ERROR_ACCOUNT_EXPIRED code.
+func AccountExpiredError(msg string) error {
+	return NewStatusError(codes.FailedPrecondition, &ErrorDetail{
+		Code:    ErrorCode_ERROR_ACCOUNT_EXPIRED,
+		Message: msg,
+	})
+}
+
+// AccountInsufficientBalanceError returns a FailedPrecondition status error
+// with the ERROR_ACCOUNT_INSUFFICIENT_BALANCE code and the balance details.
+func AccountInsufficientBalanceError(msg, accountID string, availableMsat,
+	requiredMsat int64) error {
+
+	return NewStatusError(codes.FailedPrecondition, &ErrorDetail{
+		Code:    ErrorCode_ERROR_ACCOUNT_INSUFFICIENT_BALANCE,
+		Message: msg,
+		Payload: &ErrorDetail_AccountInsufficientBalance{
+			AccountInsufficientBalance: &AccountInsufficientBalance{
+				AccountId:     accountID,
+				AvailableMsat: availableMsat,
+				RequiredMsat:  requiredMsat,
+			},
+		},
+	})
+}
+
+// SessionNotFoundError returns a NotFound status error with the
+// ERROR_SESSION_NOT_FOUND code.
+func SessionNotFoundError(msg string) error {
+	return NewStatusError(codes.NotFound, &ErrorDetail{
+		Code:    ErrorCode_ERROR_SESSION_NOT_FOUND,
+		Message: msg,
+	})
+}
+
+// SessionRevokedError returns a FailedPrecondition status error with the
+// ERROR_SESSION_REVOKED code and the details of the revoked session.
+func SessionRevokedError(msg string, localPubKey []byte,
+	revokedAt uint64) error {
+
+	return NewStatusError(codes.FailedPrecondition, &ErrorDetail{
+		Code:    ErrorCode_ERROR_SESSION_REVOKED,
+		Message: msg,
+		Payload: &ErrorDetail_SessionRevoked{
+			SessionRevoked: &SessionRevoked{
+				LocalPublicKey: localPubKey,
+				RevokedAt:      revokedAt,
+			},
+		},
+	})
+}
+
+// RuleViolationError returns a ResourceExhausted status error with the
+// ERROR_RULE_VIOLATION code and the name and values of the violated rule.
+func RuleViolationError(msg, rule, limit string) error {
+	return NewStatusError(codes.ResourceExhausted, &ErrorDetail{
+		Code:    ErrorCode_ERROR_RULE_VIOLATION,
+		Message: msg,
+		Payload: &ErrorDetail_RuleViolation{
+			RuleViolation: &RuleViolation{
+				Rule:  rule,
+				Limit: limit,
+			},
+		},
+	})
+}
+
+// NodeMaintenanceError returns an Unavailable status error with the
+// ERROR_NODE_MAINTENANCE code and the details of the maintenance window.
+func NodeMaintenanceError(msg, windowID, reason string, end int64) error {
+	return NewStatusError(codes.Unavailable, &ErrorDetail{
+		Code:    ErrorCode_ERROR_NODE_MAINTENANCE,
+		Message: msg,
+		Payload: &ErrorDetail_NodeMaintenance{
+			NodeMaintenance: &NodeMaintenance{
+				WindowId: windowID,
+				Reason:   reason,
+				End:      end,
+			},
+		},
+	})
+}
+
+// ErrorDetailFromError extracts the ErrorDetail from the given gRPC status
+// error. Nil is returned if the error doesn't carry any detail, for example
+// because it was returned by lnd after being rejected by an RPC middleware of
+// litd, which can only pass on the error message.
+func ErrorDetailFromError(err error) *ErrorDetail {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		if errDetail, ok := detail.(*ErrorDetail); ok {
+			return errDetail
+		}
+	}
+
+	return nil
+}
+
+// ErrorCodeFromError returns the ErrorCode of the given gRPC status error or
+// ERROR_CODE_UNKNOWN if the error doesn't carry an ErrorDetail.
+func ErrorCodeFromError(err error) ErrorCode {
+	detail := ErrorDetailFromError(err)
+	if detail == nil {
+		return ErrorCode_ERROR_CODE_UNKNOWN
+	}
+
+	return detail.Code
+}
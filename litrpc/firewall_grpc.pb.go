@@ -34,6 +34,53 @@ type FirewallClient interface {
 	// PrivacyMapConversion can be used map real values to their pseudo
 	// counterpart and vice versa.
 	PrivacyMapConversion(ctx context.Context, in *PrivacyMapConversionRequest, opts ...grpc.CallOption) (*PrivacyMapConversionResponse, error)
+	// litcli: `verifyactions`
+	// VerifyActionLog checks the integrity of the signed action log. If the
+	// `--firewall.action-signing` config option is set, every recorded action
+	// is signed and chained to the previous one. This call walks the chain,
+	// checks all signatures and checks that no signed action was modified or
+	// removed since.
+	VerifyActionLog(ctx context.Context, in *VerifyActionLogRequest, opts ...grpc.CallOption) (*VerifyActionLogResponse, error)
+	// litcli: `audit`
+	// AuditTrail returns the account changes, session lifecycle events,
+	// firewall denials and admin actions of the action log as one trail,
+	// ordered by the sequence in which they were recorded. The cursor of the
+	// trail stays stable while new events are added, so it can be exported
+	// incrementally, for example into a SIEM, by passing the returned cursor
+	// to the next call.
+	AuditTrail(ctx context.Context, in *AuditTrailRequest, opts ...grpc.CallOption) (*AuditTrailResponse, error)
+	// litcli: `billing`
+	// BillingExport returns the number of proxied RPC calls and their cost per
+	// session or account, method and period. The cost of each method is set
+	// with the `--firewall.billing.cost` config option, so that the usage of
+	// API consumers can be billed.
+	BillingExport(ctx context.Context, in *BillingExportRequest, opts ...grpc.CallOption) (*BillingExportResponse, error)
+	// litcli: `activity`
+	// SessionActivity returns the number of actions per session, feature and
+	// hour or day, counted from the action log. It can be used to render the
+	// usage of sessions without downloading their entire action history.
+	SessionActivity(ctx context.Context, in *SessionActivityRequest, opts ...grpc.CallOption) (*SessionActivityResponse, error)
+	// litcli: `firewall settings`
+	// GetFirewallSettings returns the global firewall settings that are in
+	// effect and which of them were changed at runtime.
+	GetFirewallSettings(ctx context.Context, in *GetFirewallSettingsRequest, opts ...grpc.CallOption) (*GetFirewallSettingsResponse, error)
+	// litcli: `firewall update`
+	// UpdateFirewallSettings changes the global firewall settings at runtime,
+	// without a restart. The changes are persisted and take precedence over
+	// the config file until they are reset.
+	UpdateFirewallSettings(ctx context.Context, in *UpdateFirewallSettingsRequest, opts ...grpc.CallOption) (*UpdateFirewallSettingsResponse, error)
+	// litcli: `actions --follow`
+	// SubscribeActions streams the actions that match the given filters. It
+	// first sends the most recent matching actions and then, if requested,
+	// every new matching action as soon as it is recorded. Pending actions are
+	// sent again once their state changes.
+	SubscribeActions(ctx context.Context, in *SubscribeActionsRequest, opts ...grpc.CallOption) (Firewall_SubscribeActionsClient, error)
+	// litcli: `firewall rulemetrics`
+	// ListRuleMetrics returns how often each rule was evaluated, how long its
+	// evaluations took and how many requests it denied, accumulated since the
+	// rule was first evaluated. It can be used to find the rules that slow
+	// down the requests of sessions.
+	ListRuleMetrics(ctx context.Context, in *ListRuleMetricsRequest, opts ...grpc.CallOption) (*ListRuleMetricsResponse, error)
 }
 
 type firewallClient struct {
@@ -62,6 +109,101 @@ func (c *firewallClient) PrivacyMapConversion(ctx context.Context, in *PrivacyMa
 	return out, nil
 }
 
+func (c *firewallClient) VerifyActionLog(ctx context.Context, in *VerifyActionLogRequest, opts ...grpc.CallOption) (*VerifyActionLogResponse, error) {
+	out := new(VerifyActionLogResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/VerifyActionLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *firewallClient) AuditTrail(ctx context.Context, in *AuditTrailRequest, opts ...grpc.CallOption) (*AuditTrailResponse, error) {
+	out := new(AuditTrailResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/AuditTrail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *firewallClient) BillingExport(ctx context.Context, in *BillingExportRequest, opts ...grpc.CallOption) (*BillingExportResponse, error) {
+	out := new(BillingExportResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/BillingExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *firewallClient) SessionActivity(ctx context.Context, in *SessionActivityRequest, opts ...grpc.CallOption) (*SessionActivityResponse, error) {
+	out := new(SessionActivityResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/SessionActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *firewallClient) GetFirewallSettings(ctx context.Context, in *GetFirewallSettingsRequest, opts ...grpc.CallOption) (*GetFirewallSettingsResponse, error) {
+	out := new(GetFirewallSettingsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/GetFirewallSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *firewallClient) UpdateFirewallSettings(ctx context.Context, in *UpdateFirewallSettingsRequest, opts ...grpc.CallOption) (*UpdateFirewallSettingsResponse, error) {
+	out := new(UpdateFirewallSettingsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/UpdateFirewallSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *firewallClient) SubscribeActions(ctx context.Context, in *SubscribeActionsRequest, opts ...grpc.CallOption) (Firewall_SubscribeActionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Firewall_ServiceDesc.Streams[0], "/litrpc.Firewall/SubscribeActions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &firewallSubscribeActionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Firewall_SubscribeActionsClient interface {
+	Recv() (*ActionEvent, error)
+	grpc.ClientStream
+}
+
+type firewallSubscribeActionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *firewallSubscribeActionsClient) Recv() (*ActionEvent, error) {
+	m := new(ActionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *firewallClient) ListRuleMetrics(ctx context.Context, in *ListRuleMetricsRequest, opts ...grpc.CallOption) (*ListRuleMetricsResponse, error) {
+	out := new(ListRuleMetricsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Firewall/ListRuleMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FirewallServer is the server API for Firewall service.
 // All implementations must embed UnimplementedFirewallServer
 // for forward compatibility
@@ -82,6 +224,53 @@ type FirewallServer interface {
 	// PrivacyMapConversion can be used map real values to their pseudo
 	// counterpart and vice versa.
 	PrivacyMapConversion(context.Context, *PrivacyMapConversionRequest) (*PrivacyMapConversionResponse, error)
+	// litcli: `verifyactions`
+	// VerifyActionLog checks the integrity of the signed action log. If the
+	// `--firewall.action-signing` config option is set, every recorded action
+	// is signed and chained to the previous one. This call walks the chain,
+	// checks all signatures and checks that no signed action was modified or
+	// removed since.
+	VerifyActionLog(context.Context, *VerifyActionLogRequest) (*VerifyActionLogResponse, error)
+	// litcli: `audit`
+	// AuditTrail returns the account changes, session lifecycle events,
+	// firewall denials and admin actions of the action log as one trail,
+	// ordered by the sequence in which they were recorded. The cursor of the
+	// trail stays stable while new events are added, so it can be exported
+	// incrementally, for example into a SIEM, by passing the returned cursor
+	// to the next call.
+	AuditTrail(context.Context, *AuditTrailRequest) (*AuditTrailResponse, error)
+	// litcli: `billing`
+	// BillingExport returns the number of proxied RPC calls and their cost per
+	// session or account, method and period. The cost of each method is set
+	// with the `--firewall.billing.cost` config option, so that the usage of
+	// API consumers can be billed.
+	BillingExport(context.Context, *BillingExportRequest) (*BillingExportResponse, error)
+	// litcli: `activity`
+	// SessionActivity returns the number of actions per session, feature and
+	// hour or day, counted from the action log. It can be used to render the
+	// usage of sessions without downloading their entire action history.
+	SessionActivity(context.Context, *SessionActivityRequest) (*SessionActivityResponse, error)
+	// litcli: `firewall settings`
+	// GetFirewallSettings returns the global firewall settings that are in
+	// effect and which of them were changed at runtime.
+	GetFirewallSettings(context.Context, *GetFirewallSettingsRequest) (*GetFirewallSettingsResponse, error)
+	// litcli: `firewall update`
+	// UpdateFirewallSettings changes the global firewall settings at runtime,
+	// without a restart. The changes are persisted and take precedence over
+	// the config file until they are reset.
+	UpdateFirewallSettings(context.Context, *UpdateFirewallSettingsRequest) (*UpdateFirewallSettingsResponse, error)
+	// litcli: `actions --follow`
+	// SubscribeActions streams the actions that match the given filters. It
+	// first sends the most recent matching actions and then, if requested,
+	// every new matching action as soon as it is recorded. Pending actions are
+	// sent again once their state changes.
+	SubscribeActions(*SubscribeActionsRequest, Firewall_SubscribeActionsServer) error
+	// litcli: `firewall rulemetrics`
+	// ListRuleMetrics returns how often each rule was evaluated, how long its
+	// evaluations took and how many requests it denied, accumulated since the
+	// rule was first evaluated. It can be used to find the rules that slow
+	// down the requests of sessions.
+	ListRuleMetrics(context.Context, *ListRuleMetricsRequest) (*ListRuleMetricsResponse, error)
 	mustEmbedUnimplementedFirewallServer()
 }
 
@@ -95,6 +284,30 @@ func (UnimplementedFirewallServer) ListActions(context.Context, *ListActionsRequ
 func (UnimplementedFirewallServer) PrivacyMapConversion(context.Context, *PrivacyMapConversionRequest) (*PrivacyMapConversionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PrivacyMapConversion not implemented")
 }
+func (UnimplementedFirewallServer) VerifyActionLog(context.Context, *VerifyActionLogRequest) (*VerifyActionLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyActionLog not implemented")
+}
+func (UnimplementedFirewallServer) AuditTrail(context.Context, *AuditTrailRequest) (*AuditTrailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditTrail not implemented")
+}
+func (UnimplementedFirewallServer) BillingExport(context.Context, *BillingExportRequest) (*BillingExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BillingExport not implemented")
+}
+func (UnimplementedFirewallServer) SessionActivity(context.Context, *SessionActivityRequest) (*SessionActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SessionActivity not implemented")
+}
+func (UnimplementedFirewallServer) GetFirewallSettings(context.Context, *GetFirewallSettingsRequest) (*GetFirewallSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFirewallSettings not implemented")
+}
+func (UnimplementedFirewallServer) UpdateFirewallSettings(context.Context, *UpdateFirewallSettingsRequest) (*UpdateFirewallSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFirewallSettings not implemented")
+}
+func (UnimplementedFirewallServer) SubscribeActions(*SubscribeActionsRequest, Firewall_SubscribeActionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeActions not implemented")
+}
+func (UnimplementedFirewallServer) ListRuleMetrics(context.Context, *ListRuleMetricsRequest) (*ListRuleMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuleMetrics not implemented")
+}
 func (UnimplementedFirewallServer) mustEmbedUnimplementedFirewallServer() {}
 
 // UnsafeFirewallServer may be embedded to opt out of forward compatibility for this service.
@@ -144,6 +357,153 @@ func _Firewall_PrivacyMapConversion_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Firewall_VerifyActionLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyActionLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).VerifyActionLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/VerifyActionLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).VerifyActionLog(ctx, req.(*VerifyActionLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Firewall_AuditTrail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditTrailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).AuditTrail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/AuditTrail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).AuditTrail(ctx, req.(*AuditTrailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Firewall_BillingExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BillingExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).BillingExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/BillingExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).BillingExport(ctx, req.(*BillingExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Firewall_SessionActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).SessionActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/SessionActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).SessionActivity(ctx, req.(*SessionActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Firewall_GetFirewallSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFirewallSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).GetFirewallSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/GetFirewallSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).GetFirewallSettings(ctx, req.(*GetFirewallSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Firewall_UpdateFirewallSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFirewallSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).UpdateFirewallSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/UpdateFirewallSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).UpdateFirewallSettings(ctx, req.(*UpdateFirewallSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Firewall_SubscribeActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeActionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FirewallServer).SubscribeActions(m, &firewallSubscribeActionsServer{stream})
+}
+
+type Firewall_SubscribeActionsServer interface {
+	Send(*ActionEvent) error
+	grpc.ServerStream
+}
+
+type firewallSubscribeActionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *firewallSubscribeActionsServer) Send(m *ActionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Firewall_ListRuleMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRuleMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FirewallServer).ListRuleMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Firewall/ListRuleMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FirewallServer).ListRuleMetrics(ctx, req.(*ListRuleMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Firewall_ServiceDesc is the grpc.ServiceDesc for Firewall service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -159,7 +519,41 @@ var Firewall_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PrivacyMapConversion",
 			Handler:    _Firewall_PrivacyMapConversion_Handler,
 		},
+		{
+			MethodName: "VerifyActionLog",
+			Handler:    _Firewall_VerifyActionLog_Handler,
+		},
+		{
+			MethodName: "AuditTrail",
+			Handler:    _Firewall_AuditTrail_Handler,
+		},
+		{
+			MethodName: "BillingExport",
+			Handler:    _Firewall_BillingExport_Handler,
+		},
+		{
+			MethodName: "SessionActivity",
+			Handler:    _Firewall_SessionActivity_Handler,
+		},
+		{
+			MethodName: "GetFirewallSettings",
+			Handler:    _Firewall_GetFirewallSettings_Handler,
+		},
+		{
+			MethodName: "UpdateFirewallSettings",
+			Handler:    _Firewall_UpdateFirewallSettings_Handler,
+		},
+		{
+			MethodName: "ListRuleMetrics",
+			Handler:    _Firewall_ListRuleMetrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeActions",
+			Handler:       _Firewall_SubscribeActions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "firewall.proto",
 }
@@ -38,9 +38,38 @@ type AccountsClient interface {
 	// ListAccounts returns all accounts that are currently stored in the account
 	// database.
 	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// litcli: `accounts info`
+	// AccountInfo returns the account with the given ID or label.
+	AccountInfo(ctx context.Context, in *AccountInfoRequest, opts ...grpc.CallOption) (*Account, error)
 	// litcli: `accounts remove`
 	// RemoveAccount removes the given account from the account database.
 	RemoveAccount(ctx context.Context, in *RemoveAccountRequest, opts ...grpc.CallOption) (*RemoveAccountResponse, error)
+	// litcli: `accounts createinvoices`
+	// CreateInvoices creates multiple invoices for an account in one call. The
+	// invoices are only associated with the account once all of them were
+	// created, so either all of them or none of them credit the account when
+	// they are settled.
+	CreateInvoices(ctx context.Context, in *CreateInvoicesRequest, opts ...grpc.CallOption) (*CreateInvoicesResponse, error)
+	// litcli: `accounts simulate invoice`
+	// SimulateInvoice credits an account as if an invoice of the account was
+	// settled, without creating an invoice in lnd. Only available if litd was
+	// started with --dev.simulateaccounts.
+	SimulateInvoice(ctx context.Context, in *SimulateInvoiceRequest, opts ...grpc.CallOption) (*SimulateInvoiceResponse, error)
+	// litcli: `accounts simulate payment`
+	// SimulatePayment records a payment of an account as if it was sent through
+	// lnd, without sending any HTLCs. A successful payment debits the account.
+	// The balance of the account is checked the same way as for real payments.
+	// Only available if litd was started with --dev.simulateaccounts.
+	SimulatePayment(ctx context.Context, in *SimulatePaymentRequest, opts ...grpc.CallOption) (*SimulatePaymentResponse, error)
+	// litcli: `accounts freeze`
+	// FreezeAccount freezes an account so it can't send payments anymore, or
+	// unfreezes it again. Invoices of a frozen account still credit it.
+	FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	// litcli: `accounts cleanup`
+	// CleanupAccounts cleans up all accounts that are due according to the
+	// cleanup policy configured with accounts.cleanupafter and
+	// accounts.cleanupaction. With dry_run set, the accounts are only reported.
+	CleanupAccounts(ctx context.Context, in *CleanupAccountsRequest, opts ...grpc.CallOption) (*CleanupAccountsResponse, error)
 }
 
 type accountsClient struct {
@@ -78,6 +107,15 @@ func (c *accountsClient) ListAccounts(ctx context.Context, in *ListAccountsReque
 	return out, nil
 }
 
+func (c *accountsClient) AccountInfo(ctx context.Context, in *AccountInfoRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/litrpc.Accounts/AccountInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountsClient) RemoveAccount(ctx context.Context, in *RemoveAccountRequest, opts ...grpc.CallOption) (*RemoveAccountResponse, error) {
 	out := new(RemoveAccountResponse)
 	err := c.cc.Invoke(ctx, "/litrpc.Accounts/RemoveAccount", in, out, opts...)
@@ -87,6 +125,51 @@ func (c *accountsClient) RemoveAccount(ctx context.Context, in *RemoveAccountReq
 	return out, nil
 }
 
+func (c *accountsClient) CreateInvoices(ctx context.Context, in *CreateInvoicesRequest, opts ...grpc.CallOption) (*CreateInvoicesResponse, error) {
+	out := new(CreateInvoicesResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Accounts/CreateInvoices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsClient) SimulateInvoice(ctx context.Context, in *SimulateInvoiceRequest, opts ...grpc.CallOption) (*SimulateInvoiceResponse, error) {
+	out := new(SimulateInvoiceResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Accounts/SimulateInvoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsClient) SimulatePayment(ctx context.Context, in *SimulatePaymentRequest, opts ...grpc.CallOption) (*SimulatePaymentResponse, error) {
+	out := new(SimulatePaymentResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Accounts/SimulatePayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsClient) FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/litrpc.Accounts/FreezeAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsClient) CleanupAccounts(ctx context.Context, in *CleanupAccountsRequest, opts ...grpc.CallOption) (*CleanupAccountsResponse, error) {
+	out := new(CleanupAccountsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Accounts/CleanupAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountsServer is the server API for Accounts service.
 // All implementations must embed UnimplementedAccountsServer
 // for forward compatibility
@@ -111,9 +194,38 @@ type AccountsServer interface {
 	// ListAccounts returns all accounts that are currently stored in the account
 	// database.
 	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// litcli: `accounts info`
+	// AccountInfo returns the account with the given ID or label.
+	AccountInfo(context.Context, *AccountInfoRequest) (*Account, error)
 	// litcli: `accounts remove`
 	// RemoveAccount removes the given account from the account database.
 	RemoveAccount(context.Context, *RemoveAccountRequest) (*RemoveAccountResponse, error)
+	// litcli: `accounts createinvoices`
+	// CreateInvoices creates multiple invoices for an account in one call. The
+	// invoices are only associated with the account once all of them were
+	// created, so either all of them or none of them credit the account when
+	// they are settled.
+	CreateInvoices(context.Context, *CreateInvoicesRequest) (*CreateInvoicesResponse, error)
+	// litcli: `accounts simulate invoice`
+	// SimulateInvoice credits an account as if an invoice of the account was
+	// settled, without creating an invoice in lnd. Only available if litd was
+	// started with --dev.simulateaccounts.
+	SimulateInvoice(context.Context, *SimulateInvoiceRequest) (*SimulateInvoiceResponse, error)
+	// litcli: `accounts simulate payment`
+	// SimulatePayment records a payment of an account as if it was sent through
+	// lnd, without sending any HTLCs. A successful payment debits the account.
+	// The balance of the account is checked the same way as for real payments.
+	// Only available if litd was started with --dev.simulateaccounts.
+	SimulatePayment(context.Context, *SimulatePaymentRequest) (*SimulatePaymentResponse, error)
+	// litcli: `accounts freeze`
+	// FreezeAccount freezes an account so it can't send payments anymore, or
+	// unfreezes it again. Invoices of a frozen account still credit it.
+	FreezeAccount(context.Context, *FreezeAccountRequest) (*Account, error)
+	// litcli: `accounts cleanup`
+	// CleanupAccounts cleans up all accounts that are due according to the
+	// cleanup policy configured with accounts.cleanupafter and
+	// accounts.cleanupaction. With dry_run set, the accounts are only reported.
+	CleanupAccounts(context.Context, *CleanupAccountsRequest) (*CleanupAccountsResponse, error)
 	mustEmbedUnimplementedAccountsServer()
 }
 
@@ -130,9 +242,27 @@ func (UnimplementedAccountsServer) UpdateAccount(context.Context, *UpdateAccount
 func (UnimplementedAccountsServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
 }
+func (UnimplementedAccountsServer) AccountInfo(context.Context, *AccountInfoRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountInfo not implemented")
+}
 func (UnimplementedAccountsServer) RemoveAccount(context.Context, *RemoveAccountRequest) (*RemoveAccountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveAccount not implemented")
 }
+func (UnimplementedAccountsServer) CreateInvoices(context.Context, *CreateInvoicesRequest) (*CreateInvoicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvoices not implemented")
+}
+func (UnimplementedAccountsServer) SimulateInvoice(context.Context, *SimulateInvoiceRequest) (*SimulateInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateInvoice not implemented")
+}
+func (UnimplementedAccountsServer) SimulatePayment(context.Context, *SimulatePaymentRequest) (*SimulatePaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulatePayment not implemented")
+}
+func (UnimplementedAccountsServer) FreezeAccount(context.Context, *FreezeAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeAccount not implemented")
+}
+func (UnimplementedAccountsServer) CleanupAccounts(context.Context, *CleanupAccountsRequest) (*CleanupAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupAccounts not implemented")
+}
 func (UnimplementedAccountsServer) mustEmbedUnimplementedAccountsServer() {}
 
 // UnsafeAccountsServer may be embedded to opt out of forward compatibility for this service.
@@ -200,6 +330,24 @@ func _Accounts_ListAccounts_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Accounts_AccountInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).AccountInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Accounts/AccountInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).AccountInfo(ctx, req.(*AccountInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Accounts_RemoveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveAccountRequest)
 	if err := dec(in); err != nil {
@@ -218,6 +366,96 @@ func _Accounts_RemoveAccount_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Accounts_CreateInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInvoicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).CreateInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Accounts/CreateInvoices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).CreateInvoices(ctx, req.(*CreateInvoicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounts_SimulateInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).SimulateInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Accounts/SimulateInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).SimulateInvoice(ctx, req.(*SimulateInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounts_SimulatePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulatePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).SimulatePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Accounts/SimulatePayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).SimulatePayment(ctx, req.(*SimulatePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounts_FreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).FreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Accounts/FreezeAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).FreezeAccount(ctx, req.(*FreezeAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounts_CleanupAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServer).CleanupAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Accounts/CleanupAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServer).CleanupAccounts(ctx, req.(*CleanupAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Accounts_ServiceDesc is the grpc.ServiceDesc for Accounts service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -237,10 +475,34 @@ var Accounts_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAccounts",
 			Handler:    _Accounts_ListAccounts_Handler,
 		},
+		{
+			MethodName: "AccountInfo",
+			Handler:    _Accounts_AccountInfo_Handler,
+		},
 		{
 			MethodName: "RemoveAccount",
 			Handler:    _Accounts_RemoveAccount_Handler,
 		},
+		{
+			MethodName: "CreateInvoices",
+			Handler:    _Accounts_CreateInvoices_Handler,
+		},
+		{
+			MethodName: "SimulateInvoice",
+			Handler:    _Accounts_SimulateInvoice_Handler,
+		},
+		{
+			MethodName: "SimulatePayment",
+			Handler:    _Accounts_SimulatePayment_Handler,
+		},
+		{
+			MethodName: "FreezeAccount",
+			Handler:    _Accounts_FreezeAccount_Handler,
+		},
+		{
+			MethodName: "CleanupAccounts",
+			Handler:    _Accounts_CleanupAccounts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lit-accounts.proto",
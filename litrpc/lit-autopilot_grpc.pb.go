@@ -34,6 +34,31 @@ type AutopilotClient interface {
 	// litcli: `autopilot revoke`
 	// RevokeAutopilotSession revokes an Autopilot session.
 	RevokeAutopilotSession(ctx context.Context, in *RevokeAutopilotSessionRequest, opts ...grpc.CallOption) (*RevokeAutopilotSessionResponse, error)
+	// litcli: `autopilot bundles list`
+	// ListRuleBundles lists all the built-in and custom rule bundles that can be
+	// selected when creating an Autopilot session.
+	ListRuleBundles(ctx context.Context, in *ListRuleBundlesRequest, opts ...grpc.CallOption) (*ListRuleBundlesResponse, error)
+	// litcli: `autopilot bundles get`
+	// GetRuleBundle returns the built-in or custom rule bundle with the given
+	// name.
+	GetRuleBundle(ctx context.Context, in *GetRuleBundleRequest, opts ...grpc.CallOption) (*GetRuleBundleResponse, error)
+	// litcli: `autopilot bundles set`
+	// SetRuleBundle adds a new custom rule bundle or updates an existing one.
+	// Sessions that were created with the bundle and that follow bundle updates
+	// will have the new rule values applied.
+	SetRuleBundle(ctx context.Context, in *SetRuleBundleRequest, opts ...grpc.CallOption) (*SetRuleBundleResponse, error)
+	// litcli: `autopilot bundles remove`
+	// RemoveRuleBundle removes a custom rule bundle. Sessions that followed the
+	// bundle fall back to the rule values they were created with.
+	RemoveRuleBundle(ctx context.Context, in *RemoveRuleBundleRequest, opts ...grpc.CallOption) (*RemoveRuleBundleResponse, error)
+	// litcli: `autopilot updaterules`
+	// UpdateSessionRules sets the value of a rule across all active Autopilot
+	// sessions or a filtered set of them in a single transaction, for example to
+	// lower the rate limit of all sessions after an incident. The new value takes
+	// precedence over the value the session was created with and over the value
+	// of a followed rule bundle. With preview set, the affected sessions are
+	// only listed and nothing is changed.
+	UpdateSessionRules(ctx context.Context, in *UpdateSessionRulesRequest, opts ...grpc.CallOption) (*UpdateSessionRulesResponse, error)
 }
 
 type autopilotClient struct {
@@ -80,6 +105,51 @@ func (c *autopilotClient) RevokeAutopilotSession(ctx context.Context, in *Revoke
 	return out, nil
 }
 
+func (c *autopilotClient) ListRuleBundles(ctx context.Context, in *ListRuleBundlesRequest, opts ...grpc.CallOption) (*ListRuleBundlesResponse, error) {
+	out := new(ListRuleBundlesResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Autopilot/ListRuleBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *autopilotClient) GetRuleBundle(ctx context.Context, in *GetRuleBundleRequest, opts ...grpc.CallOption) (*GetRuleBundleResponse, error) {
+	out := new(GetRuleBundleResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Autopilot/GetRuleBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *autopilotClient) SetRuleBundle(ctx context.Context, in *SetRuleBundleRequest, opts ...grpc.CallOption) (*SetRuleBundleResponse, error) {
+	out := new(SetRuleBundleResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Autopilot/SetRuleBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *autopilotClient) RemoveRuleBundle(ctx context.Context, in *RemoveRuleBundleRequest, opts ...grpc.CallOption) (*RemoveRuleBundleResponse, error) {
+	out := new(RemoveRuleBundleResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Autopilot/RemoveRuleBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *autopilotClient) UpdateSessionRules(ctx context.Context, in *UpdateSessionRulesRequest, opts ...grpc.CallOption) (*UpdateSessionRulesResponse, error) {
+	out := new(UpdateSessionRulesResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Autopilot/UpdateSessionRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AutopilotServer is the server API for Autopilot service.
 // All implementations must embed UnimplementedAutopilotServer
 // for forward compatibility
@@ -100,6 +170,31 @@ type AutopilotServer interface {
 	// litcli: `autopilot revoke`
 	// RevokeAutopilotSession revokes an Autopilot session.
 	RevokeAutopilotSession(context.Context, *RevokeAutopilotSessionRequest) (*RevokeAutopilotSessionResponse, error)
+	// litcli: `autopilot bundles list`
+	// ListRuleBundles lists all the built-in and custom rule bundles that can be
+	// selected when creating an Autopilot session.
+	ListRuleBundles(context.Context, *ListRuleBundlesRequest) (*ListRuleBundlesResponse, error)
+	// litcli: `autopilot bundles get`
+	// GetRuleBundle returns the built-in or custom rule bundle with the given
+	// name.
+	GetRuleBundle(context.Context, *GetRuleBundleRequest) (*GetRuleBundleResponse, error)
+	// litcli: `autopilot bundles set`
+	// SetRuleBundle adds a new custom rule bundle or updates an existing one.
+	// Sessions that were created with the bundle and that follow bundle updates
+	// will have the new rule values applied.
+	SetRuleBundle(context.Context, *SetRuleBundleRequest) (*SetRuleBundleResponse, error)
+	// litcli: `autopilot bundles remove`
+	// RemoveRuleBundle removes a custom rule bundle. Sessions that followed the
+	// bundle fall back to the rule values they were created with.
+	RemoveRuleBundle(context.Context, *RemoveRuleBundleRequest) (*RemoveRuleBundleResponse, error)
+	// litcli: `autopilot updaterules`
+	// UpdateSessionRules sets the value of a rule across all active Autopilot
+	// sessions or a filtered set of them in a single transaction, for example to
+	// lower the rate limit of all sessions after an incident. The new value takes
+	// precedence over the value the session was created with and over the value
+	// of a followed rule bundle. With preview set, the affected sessions are
+	// only listed and nothing is changed.
+	UpdateSessionRules(context.Context, *UpdateSessionRulesRequest) (*UpdateSessionRulesResponse, error)
 	mustEmbedUnimplementedAutopilotServer()
 }
 
@@ -119,6 +214,21 @@ func (UnimplementedAutopilotServer) ListAutopilotSessions(context.Context, *List
 func (UnimplementedAutopilotServer) RevokeAutopilotSession(context.Context, *RevokeAutopilotSessionRequest) (*RevokeAutopilotSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeAutopilotSession not implemented")
 }
+func (UnimplementedAutopilotServer) ListRuleBundles(context.Context, *ListRuleBundlesRequest) (*ListRuleBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuleBundles not implemented")
+}
+func (UnimplementedAutopilotServer) GetRuleBundle(context.Context, *GetRuleBundleRequest) (*GetRuleBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRuleBundle not implemented")
+}
+func (UnimplementedAutopilotServer) SetRuleBundle(context.Context, *SetRuleBundleRequest) (*SetRuleBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRuleBundle not implemented")
+}
+func (UnimplementedAutopilotServer) RemoveRuleBundle(context.Context, *RemoveRuleBundleRequest) (*RemoveRuleBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRuleBundle not implemented")
+}
+func (UnimplementedAutopilotServer) UpdateSessionRules(context.Context, *UpdateSessionRulesRequest) (*UpdateSessionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSessionRules not implemented")
+}
 func (UnimplementedAutopilotServer) mustEmbedUnimplementedAutopilotServer() {}
 
 // UnsafeAutopilotServer may be embedded to opt out of forward compatibility for this service.
@@ -204,6 +314,96 @@ func _Autopilot_RevokeAutopilotSession_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Autopilot_ListRuleBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRuleBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutopilotServer).ListRuleBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Autopilot/ListRuleBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutopilotServer).ListRuleBundles(ctx, req.(*ListRuleBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Autopilot_GetRuleBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRuleBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutopilotServer).GetRuleBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Autopilot/GetRuleBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutopilotServer).GetRuleBundle(ctx, req.(*GetRuleBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Autopilot_SetRuleBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRuleBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutopilotServer).SetRuleBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Autopilot/SetRuleBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutopilotServer).SetRuleBundle(ctx, req.(*SetRuleBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Autopilot_RemoveRuleBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRuleBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutopilotServer).RemoveRuleBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Autopilot/RemoveRuleBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutopilotServer).RemoveRuleBundle(ctx, req.(*RemoveRuleBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Autopilot_UpdateSessionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSessionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutopilotServer).UpdateSessionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Autopilot/UpdateSessionRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutopilotServer).UpdateSessionRules(ctx, req.(*UpdateSessionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Autopilot_ServiceDesc is the grpc.ServiceDesc for Autopilot service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +427,26 @@ var Autopilot_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeAutopilotSession",
 			Handler:    _Autopilot_RevokeAutopilotSession_Handler,
 		},
+		{
+			MethodName: "ListRuleBundles",
+			Handler:    _Autopilot_ListRuleBundles_Handler,
+		},
+		{
+			MethodName: "GetRuleBundle",
+			Handler:    _Autopilot_GetRuleBundle_Handler,
+		},
+		{
+			MethodName: "SetRuleBundle",
+			Handler:    _Autopilot_SetRuleBundle_Handler,
+		},
+		{
+			MethodName: "RemoveRuleBundle",
+			Handler:    _Autopilot_RemoveRuleBundle_Handler,
+		},
+		{
+			MethodName: "UpdateSessionRules",
+			Handler:    _Autopilot_UpdateSessionRules_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lit-autopilot.proto",
@@ -24,10 +24,44 @@ type SessionsClient interface {
 	// litcli: `sessions list`
 	// ListSessions returns all sessions known to the session store.
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// litcli: `sessions get`
+	// GetSession returns the session with the given local public key, which is
+	// the stable identifier of a session.
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error)
 	// litcli: `sessions revoke`
 	// RevokeSession revokes a single session and also stops it if it is currently
 	// active.
 	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	// litcli: `sessions update`
+	// UpdateSession replaces the notes and tags of a session.
+	UpdateSession(ctx context.Context, in *UpdateSessionRequest, opts ...grpc.CallOption) (*UpdateSessionResponse, error)
+	// litcli: `sessions search`
+	// Search matches the given query against the labels of accounts and the
+	// labels, notes and tags of sessions and returns all matching entries.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// litcli: `sessions export`
+	// ExportSessionPairing returns the pairing data of a session that can still
+	// be used, encrypted with the given passphrase. The bundle can be passed on
+	// instead of the plain text pairing phrase and be opened with
+	// ImportSessionPairing.
+	ExportSessionPairing(ctx context.Context, in *ExportSessionPairingRequest, opts ...grpc.CallOption) (*ExportSessionPairingResponse, error)
+	// litcli: `sessions import`
+	// ImportSessionPairing decrypts a pairing bundle that was created by
+	// ExportSessionPairing and returns the pairing data it contains. The bundle
+	// can be opened by any litd instance, it doesn't need to know the session.
+	ImportSessionPairing(ctx context.Context, in *ImportSessionPairingRequest, opts ...grpc.CallOption) (*ImportSessionPairingResponse, error)
+	// litcli: `sessions connections`
+	// ListConnectionAttempts returns the most recent LNC connection attempts of a
+	// session, both the accepted and the rejected ones, for security review.
+	ListConnectionAttempts(ctx context.Context, in *ListConnectionAttemptsRequest, opts ...grpc.CallOption) (*ListConnectionAttemptsResponse, error)
+	// litcli: `sessions importmacaroon`
+	// ImportMacaroon creates a custom macaroon LNC session from an existing
+	// macaroon that was baked by lnd. The session gets the permissions of the
+	// macaroon and keeps all of its caveats, so it can never do more than the
+	// macaroon itself. The protections of the session, like its route hint
+	// policy, are applied on top. This allows applications that access lnd
+	// directly to be moved to LNC without provisioning them from scratch.
+	ImportMacaroon(ctx context.Context, in *ImportMacaroonRequest, opts ...grpc.CallOption) (*ImportMacaroonResponse, error)
 }
 
 type sessionsClient struct {
@@ -56,6 +90,15 @@ func (c *sessionsClient) ListSessions(ctx context.Context, in *ListSessionsReque
 	return out, nil
 }
 
+func (c *sessionsClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error) {
+	out := new(GetSessionResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sessionsClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
 	out := new(RevokeSessionResponse)
 	err := c.cc.Invoke(ctx, "/litrpc.Sessions/RevokeSession", in, out, opts...)
@@ -65,6 +108,60 @@ func (c *sessionsClient) RevokeSession(ctx context.Context, in *RevokeSessionReq
 	return out, nil
 }
 
+func (c *sessionsClient) UpdateSession(ctx context.Context, in *UpdateSessionRequest, opts ...grpc.CallOption) (*UpdateSessionResponse, error) {
+	out := new(UpdateSessionResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/UpdateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) ExportSessionPairing(ctx context.Context, in *ExportSessionPairingRequest, opts ...grpc.CallOption) (*ExportSessionPairingResponse, error) {
+	out := new(ExportSessionPairingResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/ExportSessionPairing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) ImportSessionPairing(ctx context.Context, in *ImportSessionPairingRequest, opts ...grpc.CallOption) (*ImportSessionPairingResponse, error) {
+	out := new(ImportSessionPairingResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/ImportSessionPairing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) ListConnectionAttempts(ctx context.Context, in *ListConnectionAttemptsRequest, opts ...grpc.CallOption) (*ListConnectionAttemptsResponse, error) {
+	out := new(ListConnectionAttemptsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/ListConnectionAttempts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionsClient) ImportMacaroon(ctx context.Context, in *ImportMacaroonRequest, opts ...grpc.CallOption) (*ImportMacaroonResponse, error) {
+	out := new(ImportMacaroonResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Sessions/ImportMacaroon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionsServer is the server API for Sessions service.
 // All implementations must embed UnimplementedSessionsServer
 // for forward compatibility
@@ -75,10 +172,44 @@ type SessionsServer interface {
 	// litcli: `sessions list`
 	// ListSessions returns all sessions known to the session store.
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// litcli: `sessions get`
+	// GetSession returns the session with the given local public key, which is
+	// the stable identifier of a session.
+	GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error)
 	// litcli: `sessions revoke`
 	// RevokeSession revokes a single session and also stops it if it is currently
 	// active.
 	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	// litcli: `sessions update`
+	// UpdateSession replaces the notes and tags of a session.
+	UpdateSession(context.Context, *UpdateSessionRequest) (*UpdateSessionResponse, error)
+	// litcli: `sessions search`
+	// Search matches the given query against the labels of accounts and the
+	// labels, notes and tags of sessions and returns all matching entries.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// litcli: `sessions export`
+	// ExportSessionPairing returns the pairing data of a session that can still
+	// be used, encrypted with the given passphrase. The bundle can be passed on
+	// instead of the plain text pairing phrase and be opened with
+	// ImportSessionPairing.
+	ExportSessionPairing(context.Context, *ExportSessionPairingRequest) (*ExportSessionPairingResponse, error)
+	// litcli: `sessions import`
+	// ImportSessionPairing decrypts a pairing bundle that was created by
+	// ExportSessionPairing and returns the pairing data it contains. The bundle
+	// can be opened by any litd instance, it doesn't need to know the session.
+	ImportSessionPairing(context.Context, *ImportSessionPairingRequest) (*ImportSessionPairingResponse, error)
+	// litcli: `sessions connections`
+	// ListConnectionAttempts returns the most recent LNC connection attempts of a
+	// session, both the accepted and the rejected ones, for security review.
+	ListConnectionAttempts(context.Context, *ListConnectionAttemptsRequest) (*ListConnectionAttemptsResponse, error)
+	// litcli: `sessions importmacaroon`
+	// ImportMacaroon creates a custom macaroon LNC session from an existing
+	// macaroon that was baked by lnd. The session gets the permissions of the
+	// macaroon and keeps all of its caveats, so it can never do more than the
+	// macaroon itself. The protections of the session, like its route hint
+	// policy, are applied on top. This allows applications that access lnd
+	// directly to be moved to LNC without provisioning them from scratch.
+	ImportMacaroon(context.Context, *ImportMacaroonRequest) (*ImportMacaroonResponse, error)
 	mustEmbedUnimplementedSessionsServer()
 }
 
@@ -92,9 +223,30 @@ func (UnimplementedSessionsServer) AddSession(context.Context, *AddSessionReques
 func (UnimplementedSessionsServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
 }
+func (UnimplementedSessionsServer) GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
 func (UnimplementedSessionsServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
 }
+func (UnimplementedSessionsServer) UpdateSession(context.Context, *UpdateSessionRequest) (*UpdateSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSession not implemented")
+}
+func (UnimplementedSessionsServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedSessionsServer) ExportSessionPairing(context.Context, *ExportSessionPairingRequest) (*ExportSessionPairingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSessionPairing not implemented")
+}
+func (UnimplementedSessionsServer) ImportSessionPairing(context.Context, *ImportSessionPairingRequest) (*ImportSessionPairingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSessionPairing not implemented")
+}
+func (UnimplementedSessionsServer) ListConnectionAttempts(context.Context, *ListConnectionAttemptsRequest) (*ListConnectionAttemptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConnectionAttempts not implemented")
+}
+func (UnimplementedSessionsServer) ImportMacaroon(context.Context, *ImportMacaroonRequest) (*ImportMacaroonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMacaroon not implemented")
+}
 func (UnimplementedSessionsServer) mustEmbedUnimplementedSessionsServer() {}
 
 // UnsafeSessionsServer may be embedded to opt out of forward compatibility for this service.
@@ -144,6 +296,24 @@ func _Sessions_ListSessions_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Sessions_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Sessions_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RevokeSessionRequest)
 	if err := dec(in); err != nil {
@@ -162,6 +332,114 @@ func _Sessions_RevokeSession_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Sessions_UpdateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).UpdateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/UpdateSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).UpdateSession(ctx, req.(*UpdateSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_ExportSessionPairing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSessionPairingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).ExportSessionPairing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/ExportSessionPairing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).ExportSessionPairing(ctx, req.(*ExportSessionPairingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_ImportSessionPairing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSessionPairingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).ImportSessionPairing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/ImportSessionPairing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).ImportSessionPairing(ctx, req.(*ImportSessionPairingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_ListConnectionAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConnectionAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).ListConnectionAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/ListConnectionAttempts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).ListConnectionAttempts(ctx, req.(*ListConnectionAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sessions_ImportMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMacaroonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionsServer).ImportMacaroon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Sessions/ImportMacaroon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionsServer).ImportMacaroon(ctx, req.(*ImportMacaroonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Sessions_ServiceDesc is the grpc.ServiceDesc for Sessions service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,10 +455,38 @@ var Sessions_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessions",
 			Handler:    _Sessions_ListSessions_Handler,
 		},
+		{
+			MethodName: "GetSession",
+			Handler:    _Sessions_GetSession_Handler,
+		},
 		{
 			MethodName: "RevokeSession",
 			Handler:    _Sessions_RevokeSession_Handler,
 		},
+		{
+			MethodName: "UpdateSession",
+			Handler:    _Sessions_UpdateSession_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Sessions_Search_Handler,
+		},
+		{
+			MethodName: "ExportSessionPairing",
+			Handler:    _Sessions_ExportSessionPairing_Handler,
+		},
+		{
+			MethodName: "ImportSessionPairing",
+			Handler:    _Sessions_ImportSessionPairing_Handler,
+		},
+		{
+			MethodName: "ListConnectionAttempts",
+			Handler:    _Sessions_ListConnectionAttempts_Handler,
+		},
+		{
+			MethodName: "ImportMacaroon",
+			Handler:    _Sessions_ImportMacaroon_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lit-sessions.proto",
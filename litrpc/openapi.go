@@ -0,0 +1,97 @@
+package litrpc
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+)
+
+const (
+	// OpenAPIPath is the path under which the merged OpenAPI (swagger)
+	// spec of all litrpc services is served.
+	OpenAPIPath = "/v1/openapi.json"
+)
+
+// swaggerFiles are the swagger specs that are generated for each of the
+// litrpc proto files by gen_protos.sh.
+//
+//go:embed *.swagger.json
+var swaggerFiles embed.FS
+
+// swaggerSpec is the part of a generated swagger spec that is merged.
+type swaggerSpec struct {
+	Tags        []map[string]interface{} `json:"tags"`
+	Paths       map[string]interface{}   `json:"paths"`
+	Definitions map[string]interface{}   `json:"definitions"`
+}
+
+// OpenAPISpec merges the swagger specs of all litrpc proto files into one spec
+// that describes litd's whole REST API. The given version is reported as the
+// version of the API.
+func OpenAPISpec(version string) ([]byte, error) {
+	names, err := fs.Glob(swaggerFiles, "*.swagger.json")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var (
+		tags        []map[string]interface{}
+		paths       = make(map[string]interface{})
+		definitions = make(map[string]interface{})
+	)
+	for _, name := range names {
+		content, err := swaggerFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		var spec swaggerSpec
+		if err := json.Unmarshal(content, &spec); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %v", name,
+				err)
+		}
+
+		tags = append(tags, spec.Tags...)
+
+		for path, item := range spec.Paths {
+			if _, ok := paths[path]; ok {
+				return nil, fmt.Errorf("path %s of %s is "+
+					"already defined", path, name)
+			}
+			paths[path] = item
+		}
+
+		// Messages that are used by several services are defined in
+		// all of their specs, but they must be the same everywhere.
+		for defName, def := range spec.Definitions {
+			existing, ok := definitions[defName]
+			if ok && !reflect.DeepEqual(existing, def) {
+				return nil, fmt.Errorf("definition %s of %s "+
+					"differs from previous definition",
+					defName, name)
+			}
+			definitions[defName] = def
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return fmt.Sprint(tags[i]["name"]) < fmt.Sprint(tags[j]["name"])
+	})
+
+	return json.MarshalIndent(map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":   "Lightning Terminal REST API",
+			"version": version,
+		},
+		"tags":        tags,
+		"consumes":    []string{"application/json"},
+		"produces":    []string{"application/json"},
+		"paths":       paths,
+		"definitions": definitions,
+	}, "", "  ")
+}
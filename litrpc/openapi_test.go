@@ -0,0 +1,54 @@
+package litrpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAPISpec tests that the swagger specs of all proto files can be
+// merged and that every referenced definition is part of the merged spec.
+func TestOpenAPISpec(t *testing.T) {
+	content, err := OpenAPISpec("1.2.3")
+	require.NoError(t, err)
+
+	var spec struct {
+		Info struct {
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths       map[string]interface{} `json:"paths"`
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(content, &spec))
+
+	require.Equal(t, "1.2.3", spec.Info.Version)
+	require.Contains(t, spec.Paths, "/v1/accounts")
+	require.Contains(t, spec.Paths, "/v1/firewall/actions")
+
+	var checkRefs func(v interface{})
+	checkRefs = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				if ref, ok := value.(string); ok &&
+					key == "$ref" {
+
+					name := strings.TrimPrefix(
+						ref, "#/definitions/",
+					)
+					require.Contains(t, spec.Definitions, name)
+				}
+				checkRefs(value)
+			}
+
+		case []interface{}:
+			for _, value := range v {
+				checkRefs(value)
+			}
+		}
+	}
+	checkRefs(spec.Paths)
+	checkRefs(spec.Definitions)
+}
@@ -25,6 +25,32 @@ type ProxyClient interface {
 	// StopDaemon will send a shutdown request to the interrupt handler,
 	// triggering a graceful shutdown of the daemon.
 	StopDaemon(ctx context.Context, in *StopDaemonRequest, opts ...grpc.CallOption) (*StopDaemonResponse, error)
+	// litcli: `adminchallenge`
+	// GetAdminChallenge creates a single use challenge for a destructive RPC.
+	// If signed admin actions are enabled, such an RPC is only executed if the
+	// call carries the challenge and a signature of its message by the admin key
+	// in the lit-admin-challenge and lit-admin-signature metadata fields.
+	GetAdminChallenge(ctx context.Context, in *GetAdminChallengeRequest, opts ...grpc.CallOption) (*GetAdminChallengeResponse, error)
+	// litcli: `connecturi`
+	// GetConnectURI bakes a new macaroon with the permissions of the given
+	// preset and returns an lndconnect URI with the address and TLS certificate
+	// of litd and the macaroon. Wallets that support lndconnect can use the URI
+	// to connect to the node through litd without LNC.
+	GetConnectURI(ctx context.Context, in *GetConnectURIRequest, opts ...grpc.CallOption) (*GetConnectURIResponse, error)
+	// litcli: `authlockouts list`
+	// ListAuthLockouts lists the clients that recently entered a wrong UI
+	// password, together with their backoff delay or lockout.
+	ListAuthLockouts(ctx context.Context, in *ListAuthLockoutsRequest, opts ...grpc.CallOption) (*ListAuthLockoutsResponse, error)
+	// litcli: `authlockouts clear`
+	// ClearAuthLockout resets the failed UI password attempts of a client or of
+	// all clients, which lifts their backoff delays and lockouts.
+	ClearAuthLockout(ctx context.Context, in *ClearAuthLockoutRequest, opts ...grpc.CallOption) (*ClearAuthLockoutResponse, error)
+	// litcli: `inspectmacaroon`
+	// InspectMacaroon lists the methods the given macaroon can call through
+	// litd. Besides the permissions of the macaroon, the caveats, the account
+	// the macaroon is locked to and the privacy mapper and rules of its session
+	// are taken into account.
+	InspectMacaroon(ctx context.Context, in *InspectMacaroonRequest, opts ...grpc.CallOption) (*InspectMacaroonResponse, error)
 }
 
 type proxyClient struct {
@@ -53,6 +79,51 @@ func (c *proxyClient) StopDaemon(ctx context.Context, in *StopDaemonRequest, opt
 	return out, nil
 }
 
+func (c *proxyClient) GetAdminChallenge(ctx context.Context, in *GetAdminChallengeRequest, opts ...grpc.CallOption) (*GetAdminChallengeResponse, error) {
+	out := new(GetAdminChallengeResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/GetAdminChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) GetConnectURI(ctx context.Context, in *GetConnectURIRequest, opts ...grpc.CallOption) (*GetConnectURIResponse, error) {
+	out := new(GetConnectURIResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/GetConnectURI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ListAuthLockouts(ctx context.Context, in *ListAuthLockoutsRequest, opts ...grpc.CallOption) (*ListAuthLockoutsResponse, error) {
+	out := new(ListAuthLockoutsResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ListAuthLockouts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) ClearAuthLockout(ctx context.Context, in *ClearAuthLockoutRequest, opts ...grpc.CallOption) (*ClearAuthLockoutResponse, error) {
+	out := new(ClearAuthLockoutResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/ClearAuthLockout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) InspectMacaroon(ctx context.Context, in *InspectMacaroonRequest, opts ...grpc.CallOption) (*InspectMacaroonResponse, error) {
+	out := new(InspectMacaroonResponse)
+	err := c.cc.Invoke(ctx, "/litrpc.Proxy/InspectMacaroon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProxyServer is the server API for Proxy service.
 // All implementations must embed UnimplementedProxyServer
 // for forward compatibility
@@ -64,6 +135,32 @@ type ProxyServer interface {
 	// StopDaemon will send a shutdown request to the interrupt handler,
 	// triggering a graceful shutdown of the daemon.
 	StopDaemon(context.Context, *StopDaemonRequest) (*StopDaemonResponse, error)
+	// litcli: `adminchallenge`
+	// GetAdminChallenge creates a single use challenge for a destructive RPC.
+	// If signed admin actions are enabled, such an RPC is only executed if the
+	// call carries the challenge and a signature of its message by the admin key
+	// in the lit-admin-challenge and lit-admin-signature metadata fields.
+	GetAdminChallenge(context.Context, *GetAdminChallengeRequest) (*GetAdminChallengeResponse, error)
+	// litcli: `connecturi`
+	// GetConnectURI bakes a new macaroon with the permissions of the given
+	// preset and returns an lndconnect URI with the address and TLS certificate
+	// of litd and the macaroon. Wallets that support lndconnect can use the URI
+	// to connect to the node through litd without LNC.
+	GetConnectURI(context.Context, *GetConnectURIRequest) (*GetConnectURIResponse, error)
+	// litcli: `authlockouts list`
+	// ListAuthLockouts lists the clients that recently entered a wrong UI
+	// password, together with their backoff delay or lockout.
+	ListAuthLockouts(context.Context, *ListAuthLockoutsRequest) (*ListAuthLockoutsResponse, error)
+	// litcli: `authlockouts clear`
+	// ClearAuthLockout resets the failed UI password attempts of a client or of
+	// all clients, which lifts their backoff delays and lockouts.
+	ClearAuthLockout(context.Context, *ClearAuthLockoutRequest) (*ClearAuthLockoutResponse, error)
+	// litcli: `inspectmacaroon`
+	// InspectMacaroon lists the methods the given macaroon can call through
+	// litd. Besides the permissions of the macaroon, the caveats, the account
+	// the macaroon is locked to and the privacy mapper and rules of its session
+	// are taken into account.
+	InspectMacaroon(context.Context, *InspectMacaroonRequest) (*InspectMacaroonResponse, error)
 	mustEmbedUnimplementedProxyServer()
 }
 
@@ -77,6 +174,21 @@ func (UnimplementedProxyServer) GetInfo(context.Context, *GetInfoRequest) (*GetI
 func (UnimplementedProxyServer) StopDaemon(context.Context, *StopDaemonRequest) (*StopDaemonResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopDaemon not implemented")
 }
+func (UnimplementedProxyServer) GetAdminChallenge(context.Context, *GetAdminChallengeRequest) (*GetAdminChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAdminChallenge not implemented")
+}
+func (UnimplementedProxyServer) GetConnectURI(context.Context, *GetConnectURIRequest) (*GetConnectURIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectURI not implemented")
+}
+func (UnimplementedProxyServer) ListAuthLockouts(context.Context, *ListAuthLockoutsRequest) (*ListAuthLockoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuthLockouts not implemented")
+}
+func (UnimplementedProxyServer) ClearAuthLockout(context.Context, *ClearAuthLockoutRequest) (*ClearAuthLockoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearAuthLockout not implemented")
+}
+func (UnimplementedProxyServer) InspectMacaroon(context.Context, *InspectMacaroonRequest) (*InspectMacaroonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectMacaroon not implemented")
+}
 func (UnimplementedProxyServer) mustEmbedUnimplementedProxyServer() {}
 
 // UnsafeProxyServer may be embedded to opt out of forward compatibility for this service.
@@ -126,6 +238,96 @@ func _Proxy_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Proxy_GetAdminChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAdminChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).GetAdminChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/GetAdminChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).GetAdminChallenge(ctx, req.(*GetAdminChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_GetConnectURI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectURIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).GetConnectURI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/GetConnectURI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).GetConnectURI(ctx, req.(*GetConnectURIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ListAuthLockouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuthLockoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ListAuthLockouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ListAuthLockouts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ListAuthLockouts(ctx, req.(*ListAuthLockoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_ClearAuthLockout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearAuthLockoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).ClearAuthLockout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/ClearAuthLockout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).ClearAuthLockout(ctx, req.(*ClearAuthLockoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_InspectMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectMacaroonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).InspectMacaroon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/litrpc.Proxy/InspectMacaroon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).InspectMacaroon(ctx, req.(*InspectMacaroonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Proxy_ServiceDesc is the grpc.ServiceDesc for Proxy service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -141,6 +343,26 @@ var Proxy_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopDaemon",
 			Handler:    _Proxy_StopDaemon_Handler,
 		},
+		{
+			MethodName: "GetAdminChallenge",
+			Handler:    _Proxy_GetAdminChallenge_Handler,
+		},
+		{
+			MethodName: "GetConnectURI",
+			Handler:    _Proxy_GetConnectURI_Handler,
+		},
+		{
+			MethodName: "ListAuthLockouts",
+			Handler:    _Proxy_ListAuthLockouts_Handler,
+		},
+		{
+			MethodName: "ClearAuthLockout",
+			Handler:    _Proxy_ClearAuthLockout_Handler,
+		},
+		{
+			MethodName: "InspectMacaroon",
+			Handler:    _Proxy_InspectMacaroon_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proxy.proto",
@@ -0,0 +1,2340 @@
+// Code generated by litrpc-ts. DO NOT EDIT.
+// source: litrpc/*.proto
+
+/**
+ * A transport that sends a call to the JSON stub registered for the method,
+ * for example the LNC class of lnc-web.
+ */
+export interface LitRpcTransport {
+    request<T>(method: string, request?: object): Promise<T>;
+    subscribe<T>(
+        method: string,
+        request?: object,
+        onMessage?: (message: T) => void,
+        onError?: (error: Error) => void,
+    ): void;
+}
+
+/** Makes all fields of a request optional, also those of nested messages. */
+export type DeepPartial<T> = T extends object
+    ? { [K in keyof T]?: DeepPartial<T[K]> }
+    : T;
+
+export type ActionState =
+    | 'STATE_UNKNOWN'
+    | 'STATE_PENDING'
+    | 'STATE_DONE'
+    | 'STATE_ERROR'
+    | 'STATE_DRY_RUN';
+
+export type AuditCategory =
+    | 'AUDIT_CATEGORY_UNKNOWN'
+    | 'AUDIT_CATEGORY_ACCOUNT'
+    | 'AUDIT_CATEGORY_SESSION'
+    | 'AUDIT_CATEGORY_FIREWALL_DENIAL'
+    | 'AUDIT_CATEGORY_ADMIN'
+    | 'AUDIT_CATEGORY_AUTH';
+
+export type BillingPeriod =
+    | 'BILLING_PERIOD_DAY'
+    | 'BILLING_PERIOD_WEEK'
+    | 'BILLING_PERIOD_MONTH';
+
+export type ActivityInterval =
+    | 'ACTIVITY_INTERVAL_HOUR'
+    | 'ACTIVITY_INTERVAL_DAY';
+
+export type FirewallToggle =
+    | 'FIREWALL_TOGGLE_UNCHANGED'
+    | 'FIREWALL_TOGGLE_ENABLE'
+    | 'FIREWALL_TOGGLE_DISABLE';
+
+export interface VerifyActionLogRequest {
+}
+
+export interface VerifyActionLogResponse {
+    valid: boolean;
+    failure: string;
+    failed_seq: string;
+    num_entries: string;
+    num_signed_actions: string;
+    num_unsigned_actions: string;
+    tip_hash: string;
+    pub_keys: string[];
+}
+
+export interface PrivacyMapConversionRequest {
+    real_to_pseudo: boolean;
+    session_id: string;
+    input: string;
+}
+
+export interface PrivacyMapConversionResponse {
+    output: string;
+}
+
+export interface ListActionsRequest {
+    feature_name: string;
+    actor_name: string;
+    method_name: string;
+    state: ActionState;
+    index_offset: string;
+    max_num_actions: string;
+    reversed: boolean;
+    count_total: boolean;
+    session_id: string;
+    start_timestamp: string;
+    end_timestamp: string;
+}
+
+export interface ListActionsResponse {
+    actions: Action[];
+    last_index_offset: string;
+    total_count: string;
+}
+
+export interface Action {
+    actor_name: string;
+    feature_name: string;
+    trigger: string;
+    intent: string;
+    structured_json_data: string;
+    rpc_method: string;
+    rpc_params_json: string;
+    timestamp: string;
+    state: ActionState;
+    error_reason: string;
+    session_id: string;
+}
+
+export interface AuditTrailRequest {
+    categories: AuditCategory[];
+    actor_name: string;
+    session_id: string;
+    start_timestamp: string;
+    end_timestamp: string;
+    cursor: string;
+    max_events: number;
+}
+
+export interface AuditTrailResponse {
+    events: AuditEvent[];
+    next_cursor: string;
+}
+
+export interface AuditEvent {
+    seq: string;
+    category: AuditCategory;
+    timestamp: string;
+    actor_name: string;
+    session_id: string;
+    rpc_method: string;
+    rpc_params_json: string;
+    state: ActionState;
+    error_reason: string;
+}
+
+export interface BillingExportRequest {
+    start_timestamp: string;
+    end_timestamp: string;
+    period: BillingPeriod;
+}
+
+export interface BillingExportResponse {
+    entries: BillingEntry[];
+    total_cost: string;
+}
+
+export interface BillingEntry {
+    period_start: string;
+    session_id: string;
+    account_id: string;
+    rpc_method: string;
+    calls: string;
+    cost: string;
+}
+
+export interface SessionActivityRequest {
+    start_timestamp: string;
+    end_timestamp: string;
+    interval: ActivityInterval;
+    session_id: string;
+    feature_name: string;
+}
+
+export interface SessionActivityResponse {
+    buckets: ActivityBucket[];
+}
+
+export interface ActivityBucket {
+    interval_start: string;
+    session_id: string;
+    feature_name: string;
+    actions: string;
+    errors: string;
+}
+
+export interface GetFirewallSettingsRequest {
+}
+
+export interface GetFirewallSettingsResponse {
+    settings: FirewallSettings | null;
+}
+
+export interface UpdateFirewallSettingsRequest {
+    request_logger_level: string;
+    privacy_mapper: FirewallToggle;
+    rule_enforcement: FirewallToggle;
+    reset_overrides: boolean;
+}
+
+export interface UpdateFirewallSettingsResponse {
+    settings: FirewallSettings | null;
+}
+
+export interface FirewallSettings {
+    request_logger_level: string;
+    privacy_mapper: boolean;
+    rule_enforcement: boolean;
+    overridden: string[];
+}
+
+export interface SubscribeActionsRequest {
+    feature_name: string;
+    actor_name: string;
+    method_name: string;
+    state: ActionState;
+    session_id: string;
+    num_recent: number;
+    follow: boolean;
+}
+
+export interface ActionEvent {
+    action: Action | null;
+    index: string;
+    state_update: boolean;
+}
+
+export interface ListRuleMetricsRequest {
+}
+
+export interface ListRuleMetricsResponse {
+    rules: RuleMetrics[];
+}
+
+export interface RuleMetrics {
+    rule_name: string;
+    evaluations: string;
+    denials: string;
+    avg_latency_us: string;
+    max_latency_us: string;
+    total_latency_us: string;
+    since: string;
+}
+
+export type ExpiredInvoicePolicy =
+    | 'EXPIRED_INVOICE_POLICY_UNSPECIFIED'
+    | 'EXPIRED_INVOICE_POLICY_GRACE'
+    | 'EXPIRED_INVOICE_POLICY_CREDIT'
+    | 'EXPIRED_INVOICE_POLICY_REJECT';
+
+export interface CreateAccountRequest {
+    account_balance: string;
+    expiration_date: string;
+    label: string;
+    session: AccountSessionRequest | null;
+    limits: AccountLimits | null;
+    expired_invoice_policy: ExpiredInvoicePolicy;
+    account_balance_msat: string;
+}
+
+export interface AccountSessionRequest {
+    label: string;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+}
+
+export interface CreateAccountResponse {
+    account: Account | null;
+    macaroon: string;
+    session: AccountSession | null;
+}
+
+export interface AccountSession {
+    id: string;
+    label: string;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    pairing_secret_mnemonic: string;
+    local_public_key: string;
+}
+
+export interface Account {
+    id: string;
+    initial_balance: string;
+    current_balance: string;
+    last_update: string;
+    expiration_date: string;
+    invoices: AccountInvoice[];
+    payments: AccountPayment[];
+    label: string;
+    frozen: boolean;
+    frozen_at: string;
+    frozen_reason: string;
+    limits: AccountLimits | null;
+    expired_invoice_policy: ExpiredInvoicePolicy;
+    initial_balance_msat: string;
+    current_balance_msat: string;
+    ledger: AccountLedgerEntry[];
+}
+
+export interface AccountInvoice {
+    hash: string;
+}
+
+export interface AccountPayment {
+    hash: string;
+    state: string;
+    full_amount: string;
+    full_amount_msat: string;
+}
+
+export interface UpdateAccountRequest {
+    id: string;
+    account_balance: string;
+    expiration_date: string;
+    limits: AccountLimits | null;
+    expired_invoice_policy: ExpiredInvoicePolicy;
+    account_balance_msat: string;
+}
+
+export interface ListAccountsRequest {
+    include_removed: boolean;
+}
+
+export interface ListAccountsResponse {
+    accounts: Account[];
+    removed_accounts: RemovedAccount[];
+}
+
+export interface RemovedAccount {
+    id: string;
+    label: string;
+    removed_at: string;
+    removed_by: string;
+    reason: string;
+}
+
+export interface AccountInfoRequest {
+    id: string;
+    label: string;
+    include_ledger: boolean;
+}
+
+export interface RemoveAccountRequest {
+    id: string;
+    reason: string;
+}
+
+export interface RemoveAccountResponse {
+}
+
+export interface CreateInvoicesRequest {
+    id: string;
+    amounts: string[];
+    num_invoices: number;
+    amount: string;
+    memo: string;
+    expiry: string;
+}
+
+export interface CreatedInvoice {
+    hash: string;
+    payment_request: string;
+    amount: string;
+    amount_msat: string;
+}
+
+export interface CreateInvoicesResponse {
+    invoices: CreatedInvoice[];
+}
+
+export interface SimulateInvoiceRequest {
+    id: string;
+    amount: string;
+}
+
+export interface SimulateInvoiceResponse {
+    hash: string;
+    account: Account | null;
+}
+
+export interface SimulatePaymentRequest {
+    id: string;
+    amount: string;
+    fee: string;
+    fail: boolean;
+}
+
+export interface SimulatePaymentResponse {
+    hash: string;
+    account: Account | null;
+}
+
+export interface FreezeAccountRequest {
+    id: string;
+    unfreeze: boolean;
+    reason: string;
+}
+
+export interface AccountLimits {
+    min_invoice_amt: string;
+    max_invoice_amt: string;
+    max_fee: string;
+    max_fee_percent: number;
+}
+
+export interface AccountLedgerEntry {
+    timestamp: string;
+    invoice_hash: string;
+    amount_msat: string;
+    balance_msat: string;
+    reason: string;
+}
+
+export interface CleanupAccountsRequest {
+    dry_run: boolean;
+}
+
+export interface CleanupAccountsResponse {
+    accounts: Account[];
+    action: string;
+}
+
+export type ApiKeyPreset =
+    | 'API_KEY_PRESET_READONLY'
+    | 'API_KEY_PRESET_ADMIN'
+    | 'API_KEY_PRESET_ACCOUNT';
+
+export interface CreateApiKeyRequest {
+    label: string;
+    preset: ApiKeyPreset;
+    account_id: string;
+    expiration_date: string;
+}
+
+export interface ApiKey {
+    id: string;
+    label: string;
+    preset: ApiKeyPreset;
+    account_id: string;
+    created_at: string;
+    expiration_date: string;
+}
+
+export interface CreateApiKeyResponse {
+    api_key: ApiKey | null;
+    key: string;
+}
+
+export interface ListApiKeysRequest {
+}
+
+export interface ListApiKeysResponse {
+    api_keys: ApiKey[];
+}
+
+export interface RevokeApiKeyRequest {
+    id: string;
+}
+
+export interface RevokeApiKeyResponse {
+}
+
+export interface AddAutopilotSessionRequest {
+    label: string;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+    features: { [key: string]: FeatureConfig };
+    session_rules: RulesMap | null;
+    no_privacy_mapper: boolean;
+    rule_bundle: string;
+    follow_bundle_updates: boolean;
+    notes: string;
+    tags: { [key: string]: string };
+    deterministic_privacy: boolean;
+    privacy_key_from: string;
+    amount_obfuscation: AmountObfuscation | null;
+    route_hint_policy: RouteHintPolicy;
+    block_onchain_spend: boolean;
+}
+
+export interface AmountObfuscation {
+    variation_percent: number;
+    granularity_sat: string;
+}
+
+export interface FeatureConfig {
+    rules: RulesMap | null;
+    config: string;
+    amount_obfuscation: AmountObfuscation | null;
+}
+
+export interface ListAutopilotSessionsRequest {
+}
+
+export interface ListAutopilotSessionsResponse {
+    sessions: Session[];
+}
+
+export interface AddAutopilotSessionResponse {
+    session: Session | null;
+    previous_session_diff: SessionDiff | null;
+}
+
+export interface ListAutopilotFeaturesRequest {
+}
+
+export interface ListAutopilotFeaturesResponse {
+    features: { [key: string]: Feature };
+}
+
+export interface RevokeAutopilotSessionRequest {
+    local_public_key: string;
+}
+
+export interface RevokeAutopilotSessionResponse {
+}
+
+export interface Feature {
+    name: string;
+    description: string;
+    rules: { [key: string]: RuleValues };
+    permissions_list: Permissions[];
+    requires_upgrade: boolean;
+}
+
+export interface RuleValues {
+    known: boolean;
+    defaults: RuleValue | null;
+    min_value: RuleValue | null;
+    max_value: RuleValue | null;
+}
+
+export interface Permissions {
+    method: string;
+    operations: MacaroonPermission[];
+}
+
+export interface RuleBundle {
+    name: string;
+    description: string;
+    rules: RulesMap | null;
+    built_in: boolean;
+}
+
+export interface ListRuleBundlesRequest {
+}
+
+export interface ListRuleBundlesResponse {
+    bundles: RuleBundle[];
+}
+
+export interface GetRuleBundleRequest {
+    name: string;
+}
+
+export interface GetRuleBundleResponse {
+    bundle: RuleBundle | null;
+}
+
+export interface SetRuleBundleRequest {
+    bundle: RuleBundle | null;
+}
+
+export interface SetRuleBundleResponse {
+}
+
+export interface RemoveRuleBundleRequest {
+    name: string;
+}
+
+export interface RemoveRuleBundleResponse {
+}
+
+export interface UpdateSessionRulesRequest {
+    rule_name: string;
+    value: RuleValue | null;
+    session_ids: string[];
+    feature_name: string;
+    preview: boolean;
+}
+
+export interface SessionRuleUpdate {
+    session_id: string;
+    label: string;
+    change: RuleChange | null;
+}
+
+export interface UpdateSessionRulesResponse {
+    updates: SessionRuleUpdate[];
+    applied: boolean;
+}
+
+export interface ExportChannelBackupRequest {
+    passphrase: string;
+}
+
+export interface ExportChannelBackupResponse {
+    encrypted_backup: string;
+    created_at: string;
+    key_source: string;
+    last_push: string;
+    last_push_error: string;
+}
+
+export interface GetBackupStatusRequest {
+}
+
+export interface GetBackupStatusResponse {
+    enabled: boolean;
+    last_attempt: string;
+    last_success: string;
+    last_error: string;
+    last_object_key: string;
+    next_run: string;
+}
+
+export type ErrorCode =
+    | 'ERROR_CODE_UNKNOWN'
+    | 'ERROR_ACCOUNT_NOT_FOUND'
+    | 'ERROR_ACCOUNT_EXPIRED'
+    | 'ERROR_ACCOUNT_INSUFFICIENT_BALANCE'
+    | 'ERROR_SESSION_NOT_FOUND'
+    | 'ERROR_SESSION_REVOKED'
+    | 'ERROR_RULE_VIOLATION'
+    | 'ERROR_NODE_MAINTENANCE';
+
+export interface ErrorDetail {
+    code: ErrorCode;
+    message: string;
+    account_insufficient_balance?: AccountInsufficientBalance;
+    session_revoked?: SessionRevoked;
+    rule_violation?: RuleViolation;
+    node_maintenance?: NodeMaintenance;
+}
+
+export interface AccountInsufficientBalance {
+    account_id: string;
+    available_msat: string;
+    required_msat: string;
+}
+
+export interface SessionRevoked {
+    local_public_key: string;
+    revoked_at: string;
+}
+
+export interface RuleViolation {
+    rule: string;
+    limit: string;
+}
+
+export interface NodeMaintenance {
+    window_id: string;
+    reason: string;
+    end: string;
+}
+
+export interface FeePolicy {
+    name: string;
+    channel_points: string[];
+    peers: string[];
+    start_time: string;
+    end_time: string;
+    weekdays: number[];
+    base_fee_msat: string;
+    fee_rate_ppm: number;
+    time_lock_delta: number;
+    priority: number;
+}
+
+export interface SetFeePolicyRequest {
+    policy: FeePolicy | null;
+}
+
+export interface SetFeePolicyResponse {
+}
+
+export interface ListFeePoliciesRequest {
+}
+
+export interface ListFeePoliciesResponse {
+    policies: FeePolicy[];
+}
+
+export interface RemoveFeePolicyRequest {
+    name: string;
+}
+
+export interface RemoveFeePolicyResponse {
+}
+
+export interface ApplyFeePoliciesRequest {
+    dry_run: boolean;
+}
+
+export interface FeePolicyUpdate {
+    channel_point: string;
+    policy_name: string;
+    old_base_fee_msat: string;
+    old_fee_rate_ppm: number;
+    base_fee_msat: string;
+    fee_rate_ppm: number;
+    time_lock_delta: number;
+    dry_run: boolean;
+    error: string;
+}
+
+export interface ApplyFeePoliciesResponse {
+    updates: FeePolicyUpdate[];
+}
+
+export interface GetGuardrailsRequest {
+}
+
+export interface GetGuardrailsResponse {
+    loop: LoopGuardrails | null;
+    override_until: string;
+    pool: PoolGuardrails | null;
+}
+
+export interface LoopGuardrails {
+    max_loop_out_per_day_sat: string;
+    loop_out_last_day_sat: string;
+    max_loop_in_per_day_sat: string;
+    loop_in_last_day_sat: string;
+    max_swap_fee_percent: number;
+}
+
+export interface PoolGuardrails {
+    max_order_amt_sat: string;
+    max_bid_rate_fixed: number;
+    max_account_funding_sat: string;
+}
+
+export interface OverrideGuardrailsRequest {
+    duration_sec: number;
+}
+
+export interface OverrideGuardrailsResponse {
+    override_until: string;
+}
+
+export type NotificationSeverity =
+    | 'SEVERITY_INFO'
+    | 'SEVERITY_WARNING'
+    | 'SEVERITY_CRITICAL';
+
+export interface Notification {
+    id: string;
+    created_at: string;
+    severity: NotificationSeverity;
+    source: string;
+    message: string;
+    payload: string;
+    read: boolean;
+    read_at: string;
+}
+
+export interface ListNotificationsRequest {
+    unread_only: boolean;
+    source: string;
+    min_severity: NotificationSeverity;
+    before_id: string;
+    max_notifications: number;
+}
+
+export interface ListNotificationsResponse {
+    notifications: Notification[];
+    unread_count: string;
+}
+
+export interface MarkNotificationsReadRequest {
+    ids: string[];
+    all: boolean;
+}
+
+export interface MarkNotificationsReadResponse {
+    marked: number;
+    unread_count: string;
+}
+
+export interface CreateVoucherRequest {
+    account_id: string;
+    min_withdrawable: string;
+    max_withdrawable: string;
+    max_uses: number;
+    expiration_date: string;
+    description: string;
+}
+
+export interface VoucherRedemption {
+    payment_hash: string;
+    amount: string;
+    redeemed_at: string;
+    settled: boolean;
+}
+
+export interface Voucher {
+    id: string;
+    account_id: string;
+    min_withdrawable: string;
+    max_withdrawable: string;
+    max_uses: number;
+    remaining_uses: number;
+    description: string;
+    created_at: string;
+    expiration_date: string;
+    redemptions: VoucherRedemption[];
+    url: string;
+    lnurl: string;
+}
+
+export interface CreateVoucherResponse {
+    voucher: Voucher | null;
+}
+
+export interface ListVouchersRequest {
+    account_id: string;
+}
+
+export interface ListVouchersResponse {
+    vouchers: Voucher[];
+}
+
+export interface RevokeVoucherRequest {
+    id: string;
+}
+
+export interface RevokeVoucherResponse {
+}
+
+export interface OpenChannelRequest {
+    node_pubkey: string;
+    local_funding_amount: string;
+    push_sat: string;
+    private: boolean;
+    sat_per_vbyte: string;
+    target_conf: number;
+    close_address: string;
+}
+
+export interface OpenChannelResponse {
+    channel_point: string;
+}
+
+export interface CloseChannelRequest {
+    channel_point: string;
+    force: boolean;
+    target_conf: number;
+    sat_per_vbyte: string;
+    delivery_address: string;
+}
+
+export interface CloseChannelResponse {
+    closing_txid: string;
+}
+
+export interface UpdateChannelPolicyRequest {
+    channel_point: string;
+    base_fee_msat: string;
+    fee_rate_ppm: number;
+    time_lock_delta: number;
+    max_htlc_msat: string;
+    min_htlc_msat: string;
+    min_htlc_msat_specified: boolean;
+}
+
+export interface UpdateChannelPolicyResponse {
+    failed_updates: string[];
+}
+
+export interface SetAliasRequest {
+    alias: string;
+}
+
+export interface SetAliasResponse {
+}
+
+export interface AddNWCConnectionRequest {
+    account_id: string;
+    label: string;
+}
+
+export interface NWCConnection {
+    client_pubkey: string;
+    account_id: string;
+    label: string;
+    created_at: string;
+}
+
+export interface AddNWCConnectionResponse {
+    connection: NWCConnection | null;
+    connection_uri: string;
+}
+
+export interface ListNWCConnectionsRequest {
+}
+
+export interface ListNWCConnectionsResponse {
+    connections: NWCConnection[];
+}
+
+export interface RemoveNWCConnectionRequest {
+    client_pubkey: string;
+}
+
+export interface RemoveNWCConnectionResponse {
+}
+
+export interface CreatePortalTokenRequest {
+    account_id: string;
+    label: string;
+}
+
+export interface PortalToken {
+    id: string;
+    account_id: string;
+    label: string;
+    created_at: string;
+}
+
+export interface CreatePortalTokenResponse {
+    token: PortalToken | null;
+    secret: string;
+}
+
+export interface ListPortalTokensRequest {
+    account_id: string;
+}
+
+export interface ListPortalTokensResponse {
+    tokens: PortalToken[];
+}
+
+export interface RevokePortalTokenRequest {
+    id: string;
+}
+
+export interface RevokePortalTokenResponse {
+}
+
+export type ProvisioningResource =
+    | 'PROVISIONING_RESOURCE_UNKNOWN'
+    | 'PROVISIONING_RESOURCE_ACCOUNT'
+    | 'PROVISIONING_RESOURCE_SESSION'
+    | 'PROVISIONING_RESOURCE_RULE_BUNDLE';
+
+export type ProvisioningAction =
+    | 'PROVISIONING_ACTION_UNKNOWN'
+    | 'PROVISIONING_ACTION_CREATE'
+    | 'PROVISIONING_ACTION_UPDATE'
+    | 'PROVISIONING_ACTION_REPLACE'
+    | 'PROVISIONING_ACTION_DELETE';
+
+export interface ProvisioningSpec {
+    accounts: AccountSpec[];
+    sessions: SessionSpec[];
+    rule_bundles: RuleBundle[];
+}
+
+export interface AccountSpec {
+    label: string;
+    balance: string;
+    expiration_date: string;
+    id: string;
+}
+
+export interface SessionSpec {
+    label: string;
+    session_type: SessionType;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+    macaroon_custom_permissions: MacaroonPermission[];
+    account_label: string;
+    notes: string;
+    tags: { [key: string]: string };
+    id: string;
+}
+
+export interface ProvisioningChange {
+    resource: ProvisioningResource;
+    name: string;
+    action: ProvisioningAction;
+    description: string;
+    applied: boolean;
+    account: Account | null;
+    account_macaroon: string;
+    session: Session | null;
+}
+
+export interface ApplySpecRequest {
+    spec: ProvisioningSpec | null;
+    dry_run: boolean;
+    prune: boolean;
+}
+
+export interface ExportSpecRequest {
+}
+
+export interface ExportSpecResponse {
+    spec: ProvisioningSpec | null;
+    yaml: string;
+    skipped: string[];
+}
+
+export interface ApplySpecResponse {
+    changes: ProvisioningChange[];
+}
+
+export interface ForwardingReportRequest {
+    start_time: string;
+    end_time: string;
+    bucket_seconds: string;
+}
+
+export interface ForwardingStats {
+    num_forwards: string;
+    volume_in_msat: string;
+    volume_out_msat: string;
+    fees_msat: string;
+    fee_ppm: string;
+}
+
+export interface ChannelForwardingStats {
+    chan_id: string;
+    forwards_in: string;
+    forwards_out: string;
+    volume_in_msat: string;
+    volume_out_msat: string;
+    fees_msat: string;
+    fee_ppm: string;
+}
+
+export interface ForwardingBucket {
+    start_time: string;
+    end_time: string;
+    stats: ForwardingStats | null;
+}
+
+export interface ForwardingReportResponse {
+    totals: ForwardingStats | null;
+    channels: ChannelForwardingStats[];
+    buckets: ForwardingBucket[];
+}
+
+export interface RebalanceReportRequest {
+    start_time: string;
+    end_time: string;
+}
+
+export interface ChannelRebalanceStats {
+    chan_id: string;
+    num_in: string;
+    amount_in_msat: string;
+    num_out: string;
+    amount_out_msat: string;
+    fees_msat: string;
+}
+
+export interface RebalanceReportResponse {
+    num_rebalances: string;
+    amount_msat: string;
+    fees_msat: string;
+    fee_ppm: string;
+    channels: ChannelRebalanceStats[];
+}
+
+export interface SendTestReportRequest {
+}
+
+export interface ReportSummary {
+    start_time: string;
+    end_time: string;
+    routing: ForwardingStats | null;
+    num_active_accounts: string;
+    account_liabilities_msat: string;
+    num_loop_outs: string;
+    num_loop_ins: string;
+    num_failed_swaps: string;
+    swap_amount_sat: string;
+    swap_cost_sat: string;
+    num_actions: string;
+    num_failed_actions: string;
+}
+
+export interface ReportDelivery {
+    target: string;
+    error: string;
+}
+
+export interface SendTestReportResponse {
+    summary: ReportSummary | null;
+    deliveries: ReportDelivery[];
+}
+
+export interface DashboardSummaryRequest {
+}
+
+export interface DashboardSummaryResponse {
+    wallet_confirmed_sat: string;
+    wallet_unconfirmed_sat: string;
+    channel_local_balance_sat: string;
+    channel_pending_balance_sat: string;
+    num_active_channels: number;
+    num_inactive_channels: number;
+    num_pending_channels: number;
+    num_pending_swaps: number;
+    pending_swap_amount_sat: string;
+    num_pool_accounts: number;
+    pool_account_value_sat: string;
+    num_active_accounts: string;
+    account_liabilities_msat: string;
+    num_active_sessions: number;
+    unavailable: UnavailableSection[];
+}
+
+export interface UnavailableSection {
+    section: string;
+    reason: string;
+}
+
+export type SessionType =
+    | 'TYPE_MACAROON_READONLY'
+    | 'TYPE_MACAROON_ADMIN'
+    | 'TYPE_MACAROON_CUSTOM'
+    | 'TYPE_UI_PASSWORD'
+    | 'TYPE_AUTOPILOT'
+    | 'TYPE_MACAROON_ACCOUNT';
+
+export type SessionState =
+    | 'STATE_CREATED'
+    | 'STATE_IN_USE'
+    | 'STATE_REVOKED'
+    | 'STATE_EXPIRED';
+
+export type RouteHintPolicy =
+    | 'ROUTE_HINTS_ALLOW'
+    | 'ROUTE_HINTS_EXPLICIT_ONLY'
+    | 'ROUTE_HINTS_STRIP';
+
+export interface AddSessionRequest {
+    label: string;
+    session_type: SessionType;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+    macaroon_custom_permissions: MacaroonPermission[];
+    account_id: string;
+    notes: string;
+    tags: { [key: string]: string };
+    idempotent_label: boolean;
+    pin_client: boolean;
+    macaroon_timeout_seconds: string;
+    macaroon_ip_address: string;
+    offline_pairing_phrase: boolean;
+    route_hint_policy: RouteHintPolicy;
+    pairing_passphrase: string;
+    block_onchain_spend: boolean;
+}
+
+export interface MacaroonPermission {
+    entity: string;
+    action: string;
+}
+
+export interface AddSessionResponse {
+    session: Session | null;
+    existing: boolean;
+    previous_session_diff: SessionDiff | null;
+}
+
+export interface Session {
+    id: string;
+    label: string;
+    session_state: SessionState;
+    session_type: SessionType;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+    pairing_secret: string;
+    pairing_secret_mnemonic: string;
+    local_public_key: string;
+    remote_public_key: string;
+    created_at: string;
+    macaroon_recipe: MacaroonRecipe | null;
+    account_id: string;
+    autopilot_feature_info: { [key: string]: RulesMap };
+    revoked_at: string;
+    notes: string;
+    tags: { [key: string]: string };
+    revoked_by: string;
+    revocation_reason: string;
+    pin_client: boolean;
+    client: ClientIdentity | null;
+    offline_pairing_phrase: boolean;
+    route_hint_policy: RouteHintPolicy;
+    passphrase_protected: boolean;
+    block_onchain_spend: boolean;
+}
+
+export interface MacaroonRecipe {
+    permissions: MacaroonPermission[];
+    caveats: string[];
+}
+
+export interface ListSessionsRequest {
+    tags: { [key: string]: string };
+}
+
+export interface ListSessionsResponse {
+    sessions: Session[];
+}
+
+export interface GetSessionRequest {
+    local_public_key: string;
+}
+
+export interface GetSessionResponse {
+    session: Session | null;
+}
+
+export interface RevokeSessionRequest {
+    local_public_key: string;
+    reason: string;
+}
+
+export interface RevokeSessionResponse {
+}
+
+export interface ExportSessionPairingRequest {
+    local_public_key: string;
+    passphrase: string;
+}
+
+export interface ExportSessionPairingResponse {
+    encrypted_bundle: string;
+}
+
+export interface ImportSessionPairingRequest {
+    encrypted_bundle: string;
+    passphrase: string;
+}
+
+export interface ImportSessionPairingResponse {
+    pairing: SessionPairing | null;
+}
+
+export interface SessionPairing {
+    label: string;
+    session_type: SessionType;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+    pairing_secret_mnemonic: string;
+    local_public_key: string;
+    passphrase_protected: boolean;
+}
+
+export interface UpdateSessionRequest {
+    local_public_key: string;
+    notes: string;
+    tags: { [key: string]: string };
+}
+
+export interface UpdateSessionResponse {
+    session: Session | null;
+}
+
+export interface SearchRequest {
+    query: string;
+}
+
+export interface SearchResult {
+    session?: Session;
+    account?: Account;
+    matched_fields: string[];
+}
+
+export interface SearchResponse {
+    results: SearchResult[];
+}
+
+export interface RulesMap {
+    rules: { [key: string]: RuleValue };
+}
+
+export interface RuleValue {
+    rate_limit?: RateLimit;
+    chan_policy_bounds?: ChannelPolicyBounds;
+    history_limit?: HistoryLimit;
+    off_chain_budget?: OffChainBudget;
+    on_chain_budget?: OnChainBudget;
+    send_to_self?: SendToSelf;
+    channel_restrict?: ChannelRestrict;
+    peer_restrict?: PeerRestrict;
+    onchain_addr_restrict?: OnChainAddrRestrict;
+    channel_open_constraints?: ChannelOpenConstraints;
+    invoice_quota?: InvoiceQuota;
+    payment_fee_limit?: PaymentFeeLimit;
+}
+
+export interface RateLimit {
+    read_limit: Rate | null;
+    write_limit: Rate | null;
+}
+
+export interface Rate {
+    iterations: number;
+    num_hours: number;
+}
+
+export interface HistoryLimit {
+    start_time: string;
+    duration: string;
+}
+
+export interface ChannelPolicyBounds {
+    min_base_msat: string;
+    max_base_msat: string;
+    min_rate_ppm: number;
+    max_rate_ppm: number;
+    min_cltv_delta: number;
+    max_cltv_delta: number;
+    min_htlc_msat: string;
+    max_htlc_msat: string;
+}
+
+export interface OffChainBudget {
+    max_amt_msat: string;
+    max_fees_msat: string;
+}
+
+export interface OnChainBudget {
+    absolute_amt_sats: string;
+    max_sat_per_v_byte: string;
+}
+
+export interface SendToSelf {
+}
+
+export interface ChannelRestrict {
+    channel_ids: string[];
+}
+
+export interface PeerRestrict {
+    peer_ids: string[];
+}
+
+export interface OnChainAddrRestrict {
+    allowed_addrs: string[];
+    internal_wallet: boolean;
+}
+
+export interface ChannelOpenConstraints {
+    peer_ids: string[];
+    min_chan_size_sat: string;
+    max_chan_size_sat: string;
+    max_sat_per_vbyte: string;
+}
+
+export interface ListConnectionAttemptsRequest {
+    local_public_key: string;
+}
+
+export interface ListConnectionAttemptsResponse {
+    attempts: ConnectionAttempt[];
+    total_handshakes: string;
+}
+
+export interface ConnectionAttempt {
+    timestamp: string;
+    accepted: boolean;
+    reason: string;
+}
+
+export interface ClientIdentity {
+    user_agent: string;
+    client_info: string;
+    first_seen: string;
+}
+
+export interface SessionDiff {
+    previous_session_id: string;
+    added_permissions: MacaroonPermission[];
+    removed_permissions: MacaroonPermission[];
+    added_caveats: string[];
+    removed_caveats: string[];
+    rule_changes: RuleChange[];
+}
+
+export interface RuleChange {
+    feature_name: string;
+    rule_name: string;
+    previous_value: RuleValue | null;
+    current_value: RuleValue | null;
+}
+
+export interface InvoiceQuota {
+    max_invoices: number;
+    max_amt_msat: string;
+    num_hours: number;
+}
+
+export interface PaymentFeeLimit {
+    default_fee_ppm: number;
+    min_fee_msat: string;
+    max_fee_msat: string;
+}
+
+export interface ImportMacaroonRequest {
+    macaroon: string;
+    label: string;
+    expiry_timestamp_seconds: string;
+    mailbox_server_addr: string;
+    dev_server: boolean;
+    notes: string;
+    pin_client: boolean;
+    route_hint_policy: RouteHintPolicy;
+    pairing_passphrase: string;
+    block_onchain_spend: boolean;
+}
+
+export interface ImportMacaroonResponse {
+    session: Session | null;
+    permissions: string[];
+}
+
+export type ConfigReloadTrigger =
+    | 'CONFIG_RELOAD_TRIGGER_RPC'
+    | 'CONFIG_RELOAD_TRIGGER_SIGNAL';
+
+export interface GetStatusRequest {
+}
+
+export interface GetStatusResponse {
+    databases: DatabaseStatus[];
+    remote_signer: RemoteSignerStatus | null;
+    disabled_features: DisabledFeature[];
+    lockdown: LockdownStatus | null;
+    maintenance: MaintenanceWindow | null;
+    degraded_subservers: DegradedSubserver[];
+    mailboxes: MailboxStatus[];
+    keepalive: KeepaliveSettings | null;
+}
+
+export interface DatabaseStatus {
+    name: string;
+    path: string;
+    size_bytes: string;
+    last_compacted: string;
+    read_latency: LatencyStats | null;
+    write_latency: LatencyStats | null;
+    last_error: string;
+}
+
+export interface LatencyStats {
+    samples: number;
+    p50_us: string;
+    p90_us: string;
+    p99_us: string;
+    max_us: string;
+}
+
+export interface TailLogsRequest {
+    subsystems: string[];
+    lines: number;
+    follow: boolean;
+}
+
+export interface LogLine {
+    text: string;
+    timestamp_ms: string;
+    level: string;
+    subsystem: string;
+}
+
+export interface RecentErrorsRequest {
+    subsystems: string[];
+}
+
+export interface RecentErrorsResponse {
+    subsystems: SubsystemErrors[];
+}
+
+export interface SubsystemErrors {
+    subsystem: string;
+    total: string;
+    entries: ErrorEntry[];
+}
+
+export interface ErrorEntry {
+    timestamp_ms: string;
+    level: string;
+    message: string;
+}
+
+export interface RemoteSignerStatus {
+    rpc_host: string;
+    reachable: boolean;
+    last_checked: string;
+    last_error: string;
+}
+
+export interface DisabledFeature {
+    name: string;
+    reason: string;
+}
+
+export interface LockdownModeRequest {
+    enable: boolean;
+    reason: string;
+}
+
+export interface LockdownModeResponse {
+    lockdown: LockdownStatus | null;
+}
+
+export interface LockdownStatus {
+    active: boolean;
+    reason: string;
+    since: string;
+}
+
+export interface MaintenanceWindow {
+    id: string;
+    start: string;
+    end: string;
+    reason: string;
+    active: boolean;
+}
+
+export interface ScheduleMaintenanceRequest {
+    start: string;
+    end: string;
+    reason: string;
+}
+
+export interface ScheduleMaintenanceResponse {
+    window: MaintenanceWindow | null;
+}
+
+export interface ListMaintenanceWindowsRequest {
+}
+
+export interface ListMaintenanceWindowsResponse {
+    windows: MaintenanceWindow[];
+}
+
+export interface CancelMaintenanceRequest {
+    id: string;
+}
+
+export interface CancelMaintenanceResponse {
+}
+
+export interface ReloadConfigRequest {
+}
+
+export interface ReloadConfigResponse {
+    reload: ConfigReload | null;
+}
+
+export interface ListConfigReloadsRequest {
+    max_reloads: number;
+}
+
+export interface ListConfigReloadsResponse {
+    reloads: ConfigReload[];
+}
+
+export interface ConfigReload {
+    id: string;
+    timestamp: string;
+    trigger: ConfigReloadTrigger;
+    changes: ConfigChange[];
+    restart_required: string[];
+    error: string;
+}
+
+export interface ConfigChange {
+    option: string;
+    old_value: string;
+    new_value: string;
+    requires_restart: boolean;
+}
+
+export interface DegradedSubserver {
+    name: string;
+    since: string;
+    last_attempt: string;
+    attempts: number;
+    last_error: string;
+}
+
+export interface SubscribeShutdownRequest {
+}
+
+export interface ShutdownStep {
+    step: number;
+    total_steps: number;
+    name: string;
+    duration_ms: string;
+    error: string;
+}
+
+export interface MailboxStatus {
+    server_addr: string;
+    reachable: boolean;
+    degraded: boolean;
+    round_trip: LatencyStats | null;
+    consecutive_failures: number;
+    last_checked: string;
+    last_error: string;
+    last_round_trip_us: string;
+}
+
+export interface KeepaliveSettings {
+    tcp_ms: string;
+    websocket_ping_ms: string;
+    server_time_ms: string;
+    server_timeout_ms: string;
+    min_client_interval_ms: string;
+    permit_without_stream: boolean;
+    backend_time_ms: string;
+    backend_timeout_ms: string;
+    lnc_heartbeat_ms: string;
+    lnc_heartbeat_timeout_ms: string;
+}
+
+export interface GetUIFlagsRequest {
+    role: string;
+}
+
+export interface SubscribeUIFlagsRequest {
+    role: string;
+}
+
+export interface UIFlagValues {
+    role: string;
+    flags: { [key: string]: boolean };
+}
+
+export interface UIFlagSetting {
+    name: string;
+    role: string;
+    enabled: boolean;
+    source: string;
+}
+
+export interface ListUIFlagSettingsRequest {
+}
+
+export interface ListUIFlagSettingsResponse {
+    settings: UIFlagSetting[];
+}
+
+export interface SetUIFlagRequest {
+    name: string;
+    role: string;
+    enabled: boolean;
+}
+
+export interface SetUIFlagResponse {
+}
+
+export interface RemoveUIFlagRequest {
+    name: string;
+    role: string;
+}
+
+export interface RemoveUIFlagResponse {
+}
+
+export type AlertType =
+    | 'ALERT_TYPE_UNKNOWN'
+    | 'ALERT_STUCK_HTLC'
+    | 'ALERT_HTLC_EXPIRY'
+    | 'ALERT_STUCK_PAYMENT'
+    | 'ALERT_ACCOUNT_DRAIN'
+    | 'ALERT_ACCOUNT_FAILED_PAYMENTS'
+    | 'ALERT_ACCOUNT_NEW_DESTINATIONS'
+    | 'ALERT_MAILBOX_DEGRADED';
+
+export interface Alert {
+    id: string;
+    type: AlertType;
+    message: string;
+    created_at: string;
+    resolved_at: string;
+    channel_point: string;
+    payment_hash: string;
+    account_id: string;
+    amount_msat: string;
+    account_frozen: boolean;
+}
+
+export interface ListAlertsRequest {
+    include_resolved: boolean;
+}
+
+export interface ListAlertsResponse {
+    alerts: Alert[];
+}
+
+export interface AccountNotifications {
+    account_id: string;
+    webhook_url: string;
+    alert_types: AlertType[];
+    min_amount_msat: string;
+}
+
+export interface SetAccountNotificationsRequest {
+    notifications: AccountNotifications | null;
+}
+
+export interface SetAccountNotificationsResponse {
+    notifications: AccountNotifications | null;
+}
+
+export interface ListAccountNotificationsRequest {
+}
+
+export interface ListAccountNotificationsResponse {
+    notifications: AccountNotifications[];
+}
+
+export interface RemoveAccountNotificationsRequest {
+    account_id: string;
+}
+
+export interface RemoveAccountNotificationsResponse {
+}
+
+export type ConnectPreset =
+    | 'CONNECT_PRESET_READONLY'
+    | 'CONNECT_PRESET_INVOICE'
+    | 'CONNECT_PRESET_ADMIN';
+
+export interface StopDaemonRequest {
+}
+
+export interface StopDaemonResponse {
+}
+
+export interface GetInfoRequest {
+}
+
+export interface GetInfoResponse {
+    version: string;
+    lnd_nodes: string[];
+}
+
+export interface GetAdminChallengeRequest {
+    method: string;
+}
+
+export interface GetAdminChallengeResponse {
+    challenge: string;
+    message: string;
+    expires_at: string;
+    pub_key: string;
+    required: boolean;
+}
+
+export interface GetConnectURIRequest {
+    preset: ConnectPreset;
+    host: string;
+    timeout_seconds: string;
+}
+
+export interface GetConnectURIResponse {
+    uri: string;
+    root_key_id: string;
+}
+
+export interface ListAuthLockoutsRequest {
+}
+
+export interface ListAuthLockoutsResponse {
+    clients: AuthLockout[];
+}
+
+export interface AuthLockout {
+    client: string;
+    failed_attempts: number;
+    last_failure: string;
+    next_attempt: string;
+    locked_out: boolean;
+    lockouts: number;
+}
+
+export interface ClearAuthLockoutRequest {
+    client: string;
+    all: boolean;
+}
+
+export interface ClearAuthLockoutResponse {
+    cleared: number;
+}
+
+export interface InspectMacaroonRequest {
+    macaroon: string;
+    method: string;
+    include_denied: boolean;
+}
+
+export interface InspectMacaroonResponse {
+    permissions: string[];
+    expiry: string;
+    ip_address: string;
+    account_id: string;
+    custom_caveats: string[];
+    methods: MacaroonMethod[];
+}
+
+export interface MacaroonMethod {
+    method: string;
+    allowed: boolean;
+    reason: string;
+    verified: boolean;
+}
+
+export class Firewall {
+    constructor(private transport: LitRpcTransport) {}
+
+    listActions(request?: DeepPartial<ListActionsRequest>): Promise<ListActionsResponse> {
+        return this.transport.request('litrpc.Firewall.ListActions', request);
+    }
+
+    privacyMapConversion(request?: DeepPartial<PrivacyMapConversionRequest>): Promise<PrivacyMapConversionResponse> {
+        return this.transport.request('litrpc.Firewall.PrivacyMapConversion', request);
+    }
+
+    verifyActionLog(request?: DeepPartial<VerifyActionLogRequest>): Promise<VerifyActionLogResponse> {
+        return this.transport.request('litrpc.Firewall.VerifyActionLog', request);
+    }
+
+    auditTrail(request?: DeepPartial<AuditTrailRequest>): Promise<AuditTrailResponse> {
+        return this.transport.request('litrpc.Firewall.AuditTrail', request);
+    }
+
+    billingExport(request?: DeepPartial<BillingExportRequest>): Promise<BillingExportResponse> {
+        return this.transport.request('litrpc.Firewall.BillingExport', request);
+    }
+
+    sessionActivity(request?: DeepPartial<SessionActivityRequest>): Promise<SessionActivityResponse> {
+        return this.transport.request('litrpc.Firewall.SessionActivity', request);
+    }
+
+    getFirewallSettings(request?: DeepPartial<GetFirewallSettingsRequest>): Promise<GetFirewallSettingsResponse> {
+        return this.transport.request('litrpc.Firewall.GetFirewallSettings', request);
+    }
+
+    updateFirewallSettings(request?: DeepPartial<UpdateFirewallSettingsRequest>): Promise<UpdateFirewallSettingsResponse> {
+        return this.transport.request('litrpc.Firewall.UpdateFirewallSettings', request);
+    }
+
+    subscribeActions(
+        request?: DeepPartial<SubscribeActionsRequest>,
+        onMessage?: (message: ActionEvent) => void,
+        onError?: (error: Error) => void,
+    ): void {
+        this.transport.subscribe('litrpc.Firewall.SubscribeActions', request, onMessage, onError);
+    }
+
+    listRuleMetrics(request?: DeepPartial<ListRuleMetricsRequest>): Promise<ListRuleMetricsResponse> {
+        return this.transport.request('litrpc.Firewall.ListRuleMetrics', request);
+    }
+}
+
+export class Accounts {
+    constructor(private transport: LitRpcTransport) {}
+
+    createAccount(request?: DeepPartial<CreateAccountRequest>): Promise<CreateAccountResponse> {
+        return this.transport.request('litrpc.Accounts.CreateAccount', request);
+    }
+
+    updateAccount(request?: DeepPartial<UpdateAccountRequest>): Promise<Account> {
+        return this.transport.request('litrpc.Accounts.UpdateAccount', request);
+    }
+
+    listAccounts(request?: DeepPartial<ListAccountsRequest>): Promise<ListAccountsResponse> {
+        return this.transport.request('litrpc.Accounts.ListAccounts', request);
+    }
+
+    accountInfo(request?: DeepPartial<AccountInfoRequest>): Promise<Account> {
+        return this.transport.request('litrpc.Accounts.AccountInfo', request);
+    }
+
+    removeAccount(request?: DeepPartial<RemoveAccountRequest>): Promise<RemoveAccountResponse> {
+        return this.transport.request('litrpc.Accounts.RemoveAccount', request);
+    }
+
+    createInvoices(request?: DeepPartial<CreateInvoicesRequest>): Promise<CreateInvoicesResponse> {
+        return this.transport.request('litrpc.Accounts.CreateInvoices', request);
+    }
+
+    simulateInvoice(request?: DeepPartial<SimulateInvoiceRequest>): Promise<SimulateInvoiceResponse> {
+        return this.transport.request('litrpc.Accounts.SimulateInvoice', request);
+    }
+
+    simulatePayment(request?: DeepPartial<SimulatePaymentRequest>): Promise<SimulatePaymentResponse> {
+        return this.transport.request('litrpc.Accounts.SimulatePayment', request);
+    }
+
+    freezeAccount(request?: DeepPartial<FreezeAccountRequest>): Promise<Account> {
+        return this.transport.request('litrpc.Accounts.FreezeAccount', request);
+    }
+
+    cleanupAccounts(request?: DeepPartial<CleanupAccountsRequest>): Promise<CleanupAccountsResponse> {
+        return this.transport.request('litrpc.Accounts.CleanupAccounts', request);
+    }
+}
+
+export class ApiKeys {
+    constructor(private transport: LitRpcTransport) {}
+
+    createApiKey(request?: DeepPartial<CreateApiKeyRequest>): Promise<CreateApiKeyResponse> {
+        return this.transport.request('litrpc.ApiKeys.CreateApiKey', request);
+    }
+
+    listApiKeys(request?: DeepPartial<ListApiKeysRequest>): Promise<ListApiKeysResponse> {
+        return this.transport.request('litrpc.ApiKeys.ListApiKeys', request);
+    }
+
+    revokeApiKey(request?: DeepPartial<RevokeApiKeyRequest>): Promise<RevokeApiKeyResponse> {
+        return this.transport.request('litrpc.ApiKeys.RevokeApiKey', request);
+    }
+}
+
+export class Autopilot {
+    constructor(private transport: LitRpcTransport) {}
+
+    listAutopilotFeatures(request?: DeepPartial<ListAutopilotFeaturesRequest>): Promise<ListAutopilotFeaturesResponse> {
+        return this.transport.request('litrpc.Autopilot.ListAutopilotFeatures', request);
+    }
+
+    addAutopilotSession(request?: DeepPartial<AddAutopilotSessionRequest>): Promise<AddAutopilotSessionResponse> {
+        return this.transport.request('litrpc.Autopilot.AddAutopilotSession', request);
+    }
+
+    listAutopilotSessions(request?: DeepPartial<ListAutopilotSessionsRequest>): Promise<ListAutopilotSessionsResponse> {
+        return this.transport.request('litrpc.Autopilot.ListAutopilotSessions', request);
+    }
+
+    revokeAutopilotSession(request?: DeepPartial<RevokeAutopilotSessionRequest>): Promise<RevokeAutopilotSessionResponse> {
+        return this.transport.request('litrpc.Autopilot.RevokeAutopilotSession', request);
+    }
+
+    listRuleBundles(request?: DeepPartial<ListRuleBundlesRequest>): Promise<ListRuleBundlesResponse> {
+        return this.transport.request('litrpc.Autopilot.ListRuleBundles', request);
+    }
+
+    getRuleBundle(request?: DeepPartial<GetRuleBundleRequest>): Promise<GetRuleBundleResponse> {
+        return this.transport.request('litrpc.Autopilot.GetRuleBundle', request);
+    }
+
+    setRuleBundle(request?: DeepPartial<SetRuleBundleRequest>): Promise<SetRuleBundleResponse> {
+        return this.transport.request('litrpc.Autopilot.SetRuleBundle', request);
+    }
+
+    removeRuleBundle(request?: DeepPartial<RemoveRuleBundleRequest>): Promise<RemoveRuleBundleResponse> {
+        return this.transport.request('litrpc.Autopilot.RemoveRuleBundle', request);
+    }
+
+    updateSessionRules(request?: DeepPartial<UpdateSessionRulesRequest>): Promise<UpdateSessionRulesResponse> {
+        return this.transport.request('litrpc.Autopilot.UpdateSessionRules', request);
+    }
+}
+
+export class Backups {
+    constructor(private transport: LitRpcTransport) {}
+
+    exportChannelBackup(request?: DeepPartial<ExportChannelBackupRequest>): Promise<ExportChannelBackupResponse> {
+        return this.transport.request('litrpc.Backups.ExportChannelBackup', request);
+    }
+
+    getBackupStatus(request?: DeepPartial<GetBackupStatusRequest>): Promise<GetBackupStatusResponse> {
+        return this.transport.request('litrpc.Backups.GetBackupStatus', request);
+    }
+}
+
+export class FeeScheduler {
+    constructor(private transport: LitRpcTransport) {}
+
+    setFeePolicy(request?: DeepPartial<SetFeePolicyRequest>): Promise<SetFeePolicyResponse> {
+        return this.transport.request('litrpc.FeeScheduler.SetFeePolicy', request);
+    }
+
+    listFeePolicies(request?: DeepPartial<ListFeePoliciesRequest>): Promise<ListFeePoliciesResponse> {
+        return this.transport.request('litrpc.FeeScheduler.ListFeePolicies', request);
+    }
+
+    removeFeePolicy(request?: DeepPartial<RemoveFeePolicyRequest>): Promise<RemoveFeePolicyResponse> {
+        return this.transport.request('litrpc.FeeScheduler.RemoveFeePolicy', request);
+    }
+
+    applyFeePolicies(request?: DeepPartial<ApplyFeePoliciesRequest>): Promise<ApplyFeePoliciesResponse> {
+        return this.transport.request('litrpc.FeeScheduler.ApplyFeePolicies', request);
+    }
+}
+
+export class Guardrails {
+    constructor(private transport: LitRpcTransport) {}
+
+    getGuardrails(request?: DeepPartial<GetGuardrailsRequest>): Promise<GetGuardrailsResponse> {
+        return this.transport.request('litrpc.Guardrails.GetGuardrails', request);
+    }
+
+    overrideGuardrails(request?: DeepPartial<OverrideGuardrailsRequest>): Promise<OverrideGuardrailsResponse> {
+        return this.transport.request('litrpc.Guardrails.OverrideGuardrails', request);
+    }
+}
+
+export class Inbox {
+    constructor(private transport: LitRpcTransport) {}
+
+    listNotifications(request?: DeepPartial<ListNotificationsRequest>): Promise<ListNotificationsResponse> {
+        return this.transport.request('litrpc.Inbox.ListNotifications', request);
+    }
+
+    markNotificationsRead(request?: DeepPartial<MarkNotificationsReadRequest>): Promise<MarkNotificationsReadResponse> {
+        return this.transport.request('litrpc.Inbox.MarkNotificationsRead', request);
+    }
+}
+
+export class LnurlWithdraw {
+    constructor(private transport: LitRpcTransport) {}
+
+    createVoucher(request?: DeepPartial<CreateVoucherRequest>): Promise<CreateVoucherResponse> {
+        return this.transport.request('litrpc.LnurlWithdraw.CreateVoucher', request);
+    }
+
+    listVouchers(request?: DeepPartial<ListVouchersRequest>): Promise<ListVouchersResponse> {
+        return this.transport.request('litrpc.LnurlWithdraw.ListVouchers', request);
+    }
+
+    revokeVoucher(request?: DeepPartial<RevokeVoucherRequest>): Promise<RevokeVoucherResponse> {
+        return this.transport.request('litrpc.LnurlWithdraw.RevokeVoucher', request);
+    }
+}
+
+export class NodeManagement {
+    constructor(private transport: LitRpcTransport) {}
+
+    openChannel(request?: DeepPartial<OpenChannelRequest>): Promise<OpenChannelResponse> {
+        return this.transport.request('litrpc.NodeManagement.OpenChannel', request);
+    }
+
+    closeChannel(request?: DeepPartial<CloseChannelRequest>): Promise<CloseChannelResponse> {
+        return this.transport.request('litrpc.NodeManagement.CloseChannel', request);
+    }
+
+    updateChannelPolicy(request?: DeepPartial<UpdateChannelPolicyRequest>): Promise<UpdateChannelPolicyResponse> {
+        return this.transport.request('litrpc.NodeManagement.UpdateChannelPolicy', request);
+    }
+
+    setAlias(request?: DeepPartial<SetAliasRequest>): Promise<SetAliasResponse> {
+        return this.transport.request('litrpc.NodeManagement.SetAlias', request);
+    }
+}
+
+export class NostrWalletConnect {
+    constructor(private transport: LitRpcTransport) {}
+
+    addConnection(request?: DeepPartial<AddNWCConnectionRequest>): Promise<AddNWCConnectionResponse> {
+        return this.transport.request('litrpc.NostrWalletConnect.AddConnection', request);
+    }
+
+    listConnections(request?: DeepPartial<ListNWCConnectionsRequest>): Promise<ListNWCConnectionsResponse> {
+        return this.transport.request('litrpc.NostrWalletConnect.ListConnections', request);
+    }
+
+    removeConnection(request?: DeepPartial<RemoveNWCConnectionRequest>): Promise<RemoveNWCConnectionResponse> {
+        return this.transport.request('litrpc.NostrWalletConnect.RemoveConnection', request);
+    }
+}
+
+export class AccountPortal {
+    constructor(private transport: LitRpcTransport) {}
+
+    createPortalToken(request?: DeepPartial<CreatePortalTokenRequest>): Promise<CreatePortalTokenResponse> {
+        return this.transport.request('litrpc.AccountPortal.CreatePortalToken', request);
+    }
+
+    listPortalTokens(request?: DeepPartial<ListPortalTokensRequest>): Promise<ListPortalTokensResponse> {
+        return this.transport.request('litrpc.AccountPortal.ListPortalTokens', request);
+    }
+
+    revokePortalToken(request?: DeepPartial<RevokePortalTokenRequest>): Promise<RevokePortalTokenResponse> {
+        return this.transport.request('litrpc.AccountPortal.RevokePortalToken', request);
+    }
+}
+
+export class Provisioning {
+    constructor(private transport: LitRpcTransport) {}
+
+    applySpec(request?: DeepPartial<ApplySpecRequest>): Promise<ApplySpecResponse> {
+        return this.transport.request('litrpc.Provisioning.ApplySpec', request);
+    }
+
+    exportSpec(request?: DeepPartial<ExportSpecRequest>): Promise<ExportSpecResponse> {
+        return this.transport.request('litrpc.Provisioning.ExportSpec', request);
+    }
+}
+
+export class Reports {
+    constructor(private transport: LitRpcTransport) {}
+
+    forwardingReport(request?: DeepPartial<ForwardingReportRequest>): Promise<ForwardingReportResponse> {
+        return this.transport.request('litrpc.Reports.ForwardingReport', request);
+    }
+
+    rebalanceReport(request?: DeepPartial<RebalanceReportRequest>): Promise<RebalanceReportResponse> {
+        return this.transport.request('litrpc.Reports.RebalanceReport', request);
+    }
+
+    sendTestReport(request?: DeepPartial<SendTestReportRequest>): Promise<SendTestReportResponse> {
+        return this.transport.request('litrpc.Reports.SendTestReport', request);
+    }
+
+    dashboardSummary(request?: DeepPartial<DashboardSummaryRequest>): Promise<DashboardSummaryResponse> {
+        return this.transport.request('litrpc.Reports.DashboardSummary', request);
+    }
+}
+
+export class Sessions {
+    constructor(private transport: LitRpcTransport) {}
+
+    addSession(request?: DeepPartial<AddSessionRequest>): Promise<AddSessionResponse> {
+        return this.transport.request('litrpc.Sessions.AddSession', request);
+    }
+
+    listSessions(request?: DeepPartial<ListSessionsRequest>): Promise<ListSessionsResponse> {
+        return this.transport.request('litrpc.Sessions.ListSessions', request);
+    }
+
+    getSession(request?: DeepPartial<GetSessionRequest>): Promise<GetSessionResponse> {
+        return this.transport.request('litrpc.Sessions.GetSession', request);
+    }
+
+    revokeSession(request?: DeepPartial<RevokeSessionRequest>): Promise<RevokeSessionResponse> {
+        return this.transport.request('litrpc.Sessions.RevokeSession', request);
+    }
+
+    updateSession(request?: DeepPartial<UpdateSessionRequest>): Promise<UpdateSessionResponse> {
+        return this.transport.request('litrpc.Sessions.UpdateSession', request);
+    }
+
+    search(request?: DeepPartial<SearchRequest>): Promise<SearchResponse> {
+        return this.transport.request('litrpc.Sessions.Search', request);
+    }
+
+    exportSessionPairing(request?: DeepPartial<ExportSessionPairingRequest>): Promise<ExportSessionPairingResponse> {
+        return this.transport.request('litrpc.Sessions.ExportSessionPairing', request);
+    }
+
+    importSessionPairing(request?: DeepPartial<ImportSessionPairingRequest>): Promise<ImportSessionPairingResponse> {
+        return this.transport.request('litrpc.Sessions.ImportSessionPairing', request);
+    }
+
+    listConnectionAttempts(request?: DeepPartial<ListConnectionAttemptsRequest>): Promise<ListConnectionAttemptsResponse> {
+        return this.transport.request('litrpc.Sessions.ListConnectionAttempts', request);
+    }
+
+    importMacaroon(request?: DeepPartial<ImportMacaroonRequest>): Promise<ImportMacaroonResponse> {
+        return this.transport.request('litrpc.Sessions.ImportMacaroon', request);
+    }
+}
+
+export class Status {
+    constructor(private transport: LitRpcTransport) {}
+
+    getStatus(request?: DeepPartial<GetStatusRequest>): Promise<GetStatusResponse> {
+        return this.transport.request('litrpc.Status.GetStatus', request);
+    }
+
+    tailLogs(
+        request?: DeepPartial<TailLogsRequest>,
+        onMessage?: (message: LogLine) => void,
+        onError?: (error: Error) => void,
+    ): void {
+        this.transport.subscribe('litrpc.Status.TailLogs', request, onMessage, onError);
+    }
+
+    recentErrors(request?: DeepPartial<RecentErrorsRequest>): Promise<RecentErrorsResponse> {
+        return this.transport.request('litrpc.Status.RecentErrors', request);
+    }
+
+    lockdownMode(request?: DeepPartial<LockdownModeRequest>): Promise<LockdownModeResponse> {
+        return this.transport.request('litrpc.Status.LockdownMode', request);
+    }
+
+    scheduleMaintenance(request?: DeepPartial<ScheduleMaintenanceRequest>): Promise<ScheduleMaintenanceResponse> {
+        return this.transport.request('litrpc.Status.ScheduleMaintenance', request);
+    }
+
+    listMaintenanceWindows(request?: DeepPartial<ListMaintenanceWindowsRequest>): Promise<ListMaintenanceWindowsResponse> {
+        return this.transport.request('litrpc.Status.ListMaintenanceWindows', request);
+    }
+
+    cancelMaintenance(request?: DeepPartial<CancelMaintenanceRequest>): Promise<CancelMaintenanceResponse> {
+        return this.transport.request('litrpc.Status.CancelMaintenance', request);
+    }
+
+    reloadConfig(request?: DeepPartial<ReloadConfigRequest>): Promise<ReloadConfigResponse> {
+        return this.transport.request('litrpc.Status.ReloadConfig', request);
+    }
+
+    listConfigReloads(request?: DeepPartial<ListConfigReloadsRequest>): Promise<ListConfigReloadsResponse> {
+        return this.transport.request('litrpc.Status.ListConfigReloads', request);
+    }
+
+    subscribeShutdown(
+        request?: DeepPartial<SubscribeShutdownRequest>,
+        onMessage?: (message: ShutdownStep) => void,
+        onError?: (error: Error) => void,
+    ): void {
+        this.transport.subscribe('litrpc.Status.SubscribeShutdown', request, onMessage, onError);
+    }
+}
+
+export class UIFlags {
+    constructor(private transport: LitRpcTransport) {}
+
+    getUIFlags(request?: DeepPartial<GetUIFlagsRequest>): Promise<UIFlagValues> {
+        return this.transport.request('litrpc.UIFlags.GetUIFlags', request);
+    }
+
+    subscribeUIFlags(
+        request?: DeepPartial<SubscribeUIFlagsRequest>,
+        onMessage?: (message: UIFlagValues) => void,
+        onError?: (error: Error) => void,
+    ): void {
+        this.transport.subscribe('litrpc.UIFlags.SubscribeUIFlags', request, onMessage, onError);
+    }
+
+    listUIFlagSettings(request?: DeepPartial<ListUIFlagSettingsRequest>): Promise<ListUIFlagSettingsResponse> {
+        return this.transport.request('litrpc.UIFlags.ListUIFlagSettings', request);
+    }
+
+    setUIFlag(request?: DeepPartial<SetUIFlagRequest>): Promise<SetUIFlagResponse> {
+        return this.transport.request('litrpc.UIFlags.SetUIFlag', request);
+    }
+
+    removeUIFlag(request?: DeepPartial<RemoveUIFlagRequest>): Promise<RemoveUIFlagResponse> {
+        return this.transport.request('litrpc.UIFlags.RemoveUIFlag', request);
+    }
+}
+
+export class Watchdog {
+    constructor(private transport: LitRpcTransport) {}
+
+    listAlerts(request?: DeepPartial<ListAlertsRequest>): Promise<ListAlertsResponse> {
+        return this.transport.request('litrpc.Watchdog.ListAlerts', request);
+    }
+
+    setAccountNotifications(request?: DeepPartial<SetAccountNotificationsRequest>): Promise<SetAccountNotificationsResponse> {
+        return this.transport.request('litrpc.Watchdog.SetAccountNotifications', request);
+    }
+
+    listAccountNotifications(request?: DeepPartial<ListAccountNotificationsRequest>): Promise<ListAccountNotificationsResponse> {
+        return this.transport.request('litrpc.Watchdog.ListAccountNotifications', request);
+    }
+
+    removeAccountNotifications(request?: DeepPartial<RemoveAccountNotificationsRequest>): Promise<RemoveAccountNotificationsResponse> {
+        return this.transport.request('litrpc.Watchdog.RemoveAccountNotifications', request);
+    }
+}
+
+export class Proxy {
+    constructor(private transport: LitRpcTransport) {}
+
+    getInfo(request?: DeepPartial<GetInfoRequest>): Promise<GetInfoResponse> {
+        return this.transport.request('litrpc.Proxy.GetInfo', request);
+    }
+
+    stopDaemon(request?: DeepPartial<StopDaemonRequest>): Promise<StopDaemonResponse> {
+        return this.transport.request('litrpc.Proxy.StopDaemon', request);
+    }
+
+    getAdminChallenge(request?: DeepPartial<GetAdminChallengeRequest>): Promise<GetAdminChallengeResponse> {
+        return this.transport.request('litrpc.Proxy.GetAdminChallenge', request);
+    }
+
+    getConnectURI(request?: DeepPartial<GetConnectURIRequest>): Promise<GetConnectURIResponse> {
+        return this.transport.request('litrpc.Proxy.GetConnectURI', request);
+    }
+
+    listAuthLockouts(request?: DeepPartial<ListAuthLockoutsRequest>): Promise<ListAuthLockoutsResponse> {
+        return this.transport.request('litrpc.Proxy.ListAuthLockouts', request);
+    }
+
+    clearAuthLockout(request?: DeepPartial<ClearAuthLockoutRequest>): Promise<ClearAuthLockoutResponse> {
+        return this.transport.request('litrpc.Proxy.ClearAuthLockout', request);
+    }
+
+    inspectMacaroon(request?: DeepPartial<InspectMacaroonRequest>): Promise<InspectMacaroonResponse> {
+        return this.transport.request('litrpc.Proxy.InspectMacaroon', request);
+    }
+}
+
+/** The clients of all litrpc services. */
+export class LitRpc {
+    firewall: Firewall;
+    accounts: Accounts;
+    apiKeys: ApiKeys;
+    autopilot: Autopilot;
+    backups: Backups;
+    feeScheduler: FeeScheduler;
+    guardrails: Guardrails;
+    inbox: Inbox;
+    lnurlWithdraw: LnurlWithdraw;
+    nodeManagement: NodeManagement;
+    nostrWalletConnect: NostrWalletConnect;
+    accountPortal: AccountPortal;
+    provisioning: Provisioning;
+    reports: Reports;
+    sessions: Sessions;
+    status: Status;
+    uiFlags: UIFlags;
+    watchdog: Watchdog;
+    proxy: Proxy;
+
+    constructor(transport: LitRpcTransport) {
+        this.firewall = new Firewall(transport);
+        this.accounts = new Accounts(transport);
+        this.apiKeys = new ApiKeys(transport);
+        this.autopilot = new Autopilot(transport);
+        this.backups = new Backups(transport);
+        this.feeScheduler = new FeeScheduler(transport);
+        this.guardrails = new Guardrails(transport);
+        this.inbox = new Inbox(transport);
+        this.lnurlWithdraw = new LnurlWithdraw(transport);
+        this.nodeManagement = new NodeManagement(transport);
+        this.nostrWalletConnect = new NostrWalletConnect(transport);
+        this.accountPortal = new AccountPortal(transport);
+        this.provisioning = new Provisioning(transport);
+        this.reports = new Reports(transport);
+        this.sessions = new Sessions(transport);
+        this.status = new Status(transport);
+        this.uiFlags = new UIFlags(transport);
+        this.watchdog = new Watchdog(transport);
+        this.proxy = new Proxy(transport);
+    }
+}
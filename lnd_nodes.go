@@ -0,0 +1,191 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/apikeys"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lncfg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HeaderLndNode is the gRPC metadata field name that selects the
+	// additional lnd node a proxied lnd call is sent to. If it isn't set,
+	// the call goes to the primary lnd node.
+	HeaderLndNode = "lit-lnd-node"
+)
+
+var (
+	// lndNodeNameRegex is the pattern the name of an additional lnd node
+	// must match.
+	lndNodeNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+)
+
+// lndNode is an additional lnd node LiT proxies lnd calls to.
+type lndNode struct {
+	// name is the name the node is selected by.
+	name string
+
+	// rpcServer is the host:port of the node's RPC server.
+	rpcServer string
+
+	// tlsCertPath is the path to the node's TLS certificate.
+	tlsCertPath string
+
+	// macaroonPath is the path to the macaroon that is attached to calls
+	// authenticated with the UI password or an OIDC session.
+	macaroonPath string
+
+	// conn is the connection to the node. It is nil until the RPC proxy
+	// is started.
+	conn *grpc.ClientConn
+}
+
+// parseLndNode parses an additional lnd node from its config value in the
+// format name,host:port,tlscertpath,macaroonpath.
+func parseLndNode(value string) (*lndNode, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("invalid lnd node '%s', expected the "+
+			"format name,host:port,tlscertpath,macaroonpath", value)
+	}
+
+	for idx := range parts {
+		parts[idx] = strings.TrimSpace(parts[idx])
+		if parts[idx] == "" {
+			return nil, fmt.Errorf("invalid lnd node '%s', all "+
+				"fields must be set", value)
+		}
+	}
+
+	if !lndNodeNameRegex.MatchString(parts[0]) {
+		return nil, fmt.Errorf("invalid lnd node name '%s', only up "+
+			"to 32 letters, digits, dashes and underscores are "+
+			"allowed", parts[0])
+	}
+
+	return &lndNode{
+		name:         parts[0],
+		rpcServer:    parts[1],
+		tlsCertPath:  lncfg.CleanAndExpandPath(parts[2]),
+		macaroonPath: lncfg.CleanAndExpandPath(parts[3]),
+	}, nil
+}
+
+// parseLndNodes parses all additional lnd nodes and makes sure their names
+// are unique.
+func parseLndNodes(values []string) (map[string]*lndNode, error) {
+	nodes := make(map[string]*lndNode, len(values))
+	for _, value := range values {
+		node, err := parseLndNode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := nodes[node.name]; ok {
+			return nil, fmt.Errorf("duplicate lnd node name '%s'",
+				node.name)
+		}
+
+		nodes[node.name] = node
+	}
+
+	return nodes, nil
+}
+
+// lndNodeFromContext returns the name of the additional lnd node the request
+// with the given context should be sent to or an empty string if it is meant
+// for the primary node.
+func lndNodeFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(HeaderLndNode)
+	if len(values) != 1 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// directToNode directs an lnd call to the additional lnd node with the given
+// name. Sessions, accounts and API keys are bound to the primary node, so
+// calls authenticated with them are rejected. Calls authenticated with the UI
+// password or an OIDC session are sent with the node's own macaroon.
+func (p *rpcProxy) directToNode(ctx context.Context, name,
+	requestURI string, mdCopy metadata.MD) (context.Context,
+	*grpc.ClientConn, error) {
+
+	delete(mdCopy, HeaderLndNode)
+	outCtx := metadata.NewOutgoingContext(ctx, mdCopy)
+
+	node, ok := p.lndNodes[name]
+	if !ok {
+		return outCtx, nil, status.Errorf(codes.NotFound, "unknown "+
+			"lnd node %s", name)
+	}
+
+	if !p.permsMgr.IsLndURI(requestURI) {
+		return outCtx, nil, status.Errorf(codes.InvalidArgument,
+			"only lnd calls can be sent to lnd node %s", name)
+	}
+
+	authHeaders := mdCopy.Get("authorization")
+	macHeader := mdCopy.Get(HeaderMacaroon)
+
+	switch {
+	case len(authHeaders) == 1 && apikeys.IsAPIKey(authHeaders[0]),
+		len(macHeader) == 1 && session.IsSuperMacaroon(macHeader[0]):
+
+		return outCtx, nil, status.Errorf(codes.PermissionDenied,
+			"sessions, accounts and API keys are scoped to the "+
+				"primary lnd node")
+
+	case len(authHeaders) == 1 && !p.cfg.DisableUI:
+		// The UI password or OIDC session is checked against the
+		// primary node's permissions first, the returned macaroon is
+		// then swapped for the one of the selected node.
+		_, err := p.basicAuthToMacaroon(
+			ctx, authHeaders[0], requestURI, status.Error(
+				codes.Unauthenticated, "invalid authorization",
+			),
+		)
+		if err != nil {
+			return outCtx, nil, err
+		}
+
+		macBytes, err := readMacaroon(node.macaroonPath)
+		if err != nil {
+			return outCtx, nil, err
+		}
+
+		delete(mdCopy, "authorization")
+		mdCopy.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
+	}
+
+	// Any other macaroon is forwarded as is and validated by the node
+	// itself.
+	return outCtx, node.conn, nil
+}
+
+// lndNodeNames returns the names of all additional lnd nodes.
+func (p *rpcProxy) lndNodeNames() []string {
+	names := make([]string, 0, len(p.lndNodes))
+	for name := range p.lndNodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
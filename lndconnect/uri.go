@@ -0,0 +1,57 @@
+// Package lndconnect creates lndconnect URIs. Such a URI contains everything a
+// wallet needs to connect to an lnd compatible RPC endpoint: the host and port
+// of the endpoint, its TLS certificate and a macaroon.
+package lndconnect
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// Scheme is the URI scheme of lndconnect URIs.
+const Scheme = "lndconnect"
+
+// Encode returns the lndconnect URI for the endpoint at the given host:port
+// that uses the given PEM encoded TLS certificate and the given serialized
+// macaroon. The certificate can be nil if the endpoint uses a certificate that
+// is signed by a public certificate authority.
+func Encode(hostPort string, certPEM, mac []byte) (string, error) {
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %s: %w", hostPort, err)
+	}
+	if host == "" || port == "" {
+		return "", fmt.Errorf("host %s must contain a host and a port",
+			hostPort)
+	}
+
+	if len(mac) == 0 {
+		return "", fmt.Errorf("macaroon cannot be empty")
+	}
+
+	// The certificate and the macaroon are both encoded as unpadded
+	// base64url. The certificate is sent in its DER form.
+	query := url.Values{}
+	if len(certPEM) > 0 {
+		block, _ := pem.Decode(certPEM)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return "", fmt.Errorf("invalid PEM certificate")
+		}
+
+		query.Set("cert", base64.RawURLEncoding.EncodeToString(
+			block.Bytes,
+		))
+	}
+	query.Set("macaroon", base64.RawURLEncoding.EncodeToString(mac))
+
+	uri := url.URL{
+		Scheme:   Scheme,
+		Host:     net.JoinHostPort(host, port),
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String(), nil
+}
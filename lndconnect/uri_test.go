@@ -0,0 +1,44 @@
+package lndconnect
+
+import (
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncode tests that lndconnect URIs are encoded correctly.
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: []byte{0xfb, 0xff, 0x01},
+	})
+	mac := []byte{0x02, 0x01, 0x03, 0xfe}
+
+	uri, err := Encode("node.example.com:8443", certPEM, mac)
+	require.NoError(t, err)
+	require.Equal(
+		t, "lndconnect://node.example.com:8443?cert=-_8B&"+
+			"macaroon=AgED_g", uri,
+	)
+
+	// Without a certificate, only the macaroon is added. IPv6 addresses
+	// are put in brackets.
+	uri, err = Encode("[::1]:8443", nil, mac)
+	require.NoError(t, err)
+	require.Equal(t, "lndconnect://[::1]:8443?macaroon=AgED_g", uri)
+
+	_, err = Encode("node.example.com", certPEM, mac)
+	require.Error(t, err)
+
+	_, err = Encode(":8443", certPEM, mac)
+	require.Error(t, err)
+
+	_, err = Encode("node.example.com:8443", []byte("no pem"), mac)
+	require.ErrorContains(t, err, "invalid PEM certificate")
+
+	_, err = Encode("node.example.com:8443", certPEM, nil)
+	require.ErrorContains(t, err, "macaroon cannot be empty")
+}
@@ -0,0 +1,48 @@
+package lnurl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Config holds all config options for the LNURL-withdraw voucher service.
+type Config struct {
+	Enable      bool   `long:"enable" description:"Serve LNURL-withdraw vouchers that are backed by lit accounts on litd's HTTP(S) listener."`
+	ExternalURL string `long:"externalurl" description:"The public base URL under which litd's HTTP(S) listener is reachable by wallets, for example https://lit.example.com. Must be set if LNURL-withdraw is enabled."`
+}
+
+// DefaultConfig constructs the default LNURL Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure the config is sane if the service is enabled.
+func (c *Config) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.ExternalURL == "" {
+		return fmt.Errorf("lnurl.externalurl must be set if LNURL " +
+			"vouchers are enabled")
+	}
+
+	u, err := url.Parse(c.ExternalURL)
+	if err != nil {
+		return fmt.Errorf("invalid lnurl.externalurl: %v", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid lnurl.externalurl: scheme must be " +
+			"http or https")
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid lnurl.externalurl: host missing")
+	}
+
+	c.ExternalURL = strings.TrimSuffix(c.ExternalURL, "/")
+
+	return nil
+}
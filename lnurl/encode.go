@@ -0,0 +1,26 @@
+package lnurl
+
+import (
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+)
+
+// hrp is the human-readable part of a bech32 encoded LNURL.
+const hrp = "lnurl"
+
+// Encode encodes the given URL as a bech32 LNURL as defined in LUD-01. The
+// result is upper case to allow for a more compact QR code.
+func Encode(rawURL string) (string, error) {
+	data, err := bech32.ConvertBits([]byte(rawURL), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := bech32.Encode(hrp, data)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToUpper(encoded), nil
+}
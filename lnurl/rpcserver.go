@@ -0,0 +1,165 @@
+package lnurl
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// RPCServer is the main server that implements the LnurlWithdraw gRPC
+// interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedLnurlWithdrawServer
+
+	service *Service
+}
+
+// NewRPCServer returns a new RPC server for the given LNURL service.
+func NewRPCServer(service *Service) *RPCServer {
+	return &RPCServer{
+		service: service,
+	}
+}
+
+// CreateVoucher creates a new LNURL-withdraw voucher that withdraws from the
+// given account.
+func (s *RPCServer) CreateVoucher(_ context.Context,
+	req *litrpc.CreateVoucherRequest) (*litrpc.CreateVoucherResponse,
+	error) {
+
+	log.Infof("[createvoucher] account_id=%s, min=%d, max=%d, "+
+		"max_uses=%d, expiration_date=%d", req.AccountId,
+		req.MinWithdrawable, req.MaxWithdrawable, req.MaxUses,
+		req.ExpirationDate)
+
+	accountID, err := accounts.ParseAccountID(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt time.Time
+	if req.ExpirationDate > 0 {
+		expiresAt = time.Unix(req.ExpirationDate, 0)
+	}
+
+	v, err := s.service.CreateVoucher(
+		*accountID, satToMsat(req.MinWithdrawable),
+		satToMsat(req.MaxWithdrawable), req.MaxUses, expiresAt,
+		req.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher, err := s.marshalVoucher(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.CreateVoucherResponse{
+		Voucher: voucher,
+	}, nil
+}
+
+// ListVouchers returns all LNURL-withdraw vouchers, optionally filtered by
+// account.
+func (s *RPCServer) ListVouchers(_ context.Context,
+	req *litrpc.ListVouchersRequest) (*litrpc.ListVouchersResponse, error) {
+
+	log.Infof("[listvouchers] account_id=%s", req.AccountId)
+
+	var accountID *accounts.AccountID
+	if req.AccountId != "" {
+		var err error
+		accountID, err = accounts.ParseAccountID(req.AccountId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vouchers, err := s.service.Vouchers()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListVouchersResponse{}
+	for _, v := range vouchers {
+		if accountID != nil && v.AccountID != *accountID {
+			continue
+		}
+
+		voucher, err := s.marshalVoucher(v)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Vouchers = append(resp.Vouchers, voucher)
+	}
+
+	return resp, nil
+}
+
+// RevokeVoucher removes the given voucher so that it can no longer be
+// redeemed.
+func (s *RPCServer) RevokeVoucher(_ context.Context,
+	req *litrpc.RevokeVoucherRequest) (*litrpc.RevokeVoucherResponse,
+	error) {
+
+	log.Infof("[revokevoucher] id=%s", req.Id)
+
+	if err := s.service.RemoveVoucher(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RevokeVoucherResponse{}, nil
+}
+
+// marshalVoucher converts a voucher into its RPC counterpart.
+func (s *RPCServer) marshalVoucher(v *Voucher) (*litrpc.Voucher, error) {
+	voucherURL := s.service.VoucherURL(v)
+	encoded, err := Encode(voucherURL)
+	if err != nil {
+		return nil, err
+	}
+
+	voucher := &litrpc.Voucher{
+		Id:              v.ID,
+		AccountId:       hex.EncodeToString(v.AccountID[:]),
+		MinWithdrawable: uint64(v.MinWithdrawable.ToSatoshis()),
+		MaxWithdrawable: uint64(v.MaxWithdrawable.ToSatoshis()),
+		MaxUses:         v.MaxUses,
+		RemainingUses:   v.RemainingUses(),
+		Description:     v.Description,
+		CreatedAt:       v.CreatedAt.Unix(),
+		Url:             voucherURL,
+		Lnurl:           encoded,
+	}
+
+	if !v.ExpiresAt.IsZero() {
+		voucher.ExpirationDate = v.ExpiresAt.Unix()
+	}
+
+	for _, r := range v.Redemptions {
+		voucher.Redemptions = append(
+			voucher.Redemptions, &litrpc.VoucherRedemption{
+				PaymentHash: r.PaymentHash,
+				Amount:      uint64(r.Amount.ToSatoshis()),
+				RedeemedAt:  r.RedeemedAt.Unix(),
+				Settled:     r.Settled,
+			},
+		)
+	}
+
+	return voucher, nil
+}
+
+// satToMsat converts the given amount in satoshis to millisatoshis.
+func satToMsat(amt uint64) lnwire.MilliSatoshi {
+	return lnwire.NewMSatFromSatoshis(btcutil.Amount(amt))
+}
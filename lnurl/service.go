@@ -0,0 +1,528 @@
+package lnurl
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+const (
+	// PathPrefix is the URL path prefix under which the vouchers are
+	// served by litd's HTTP(S) listener.
+	PathPrefix = "/lnurlw/"
+
+	// callbackPath is the path element of a voucher's callback URL that
+	// the wallet sends its invoice to.
+	callbackPath = "callback"
+
+	// tagWithdrawRequest is the LNURL tag of a withdraw request.
+	tagWithdrawRequest = "withdrawRequest"
+
+	// defaultMinWithdrawable is the minimum amount of a withdrawal if none
+	// is set when creating a voucher.
+	defaultMinWithdrawable = lnwire.MilliSatoshi(1000)
+
+	// defaultDescription is the default description of the invoices
+	// created by the wallet if none is set when creating a voucher.
+	defaultDescription = "lit voucher withdrawal"
+)
+
+var (
+	// ErrServiceDisabled is returned if the LNURL service is used even
+	// though it isn't enabled.
+	ErrServiceDisabled = errors.New("lnurl service is not enabled")
+)
+
+// AccountService is the subset of the account service the LNURL service
+// looks up the accounts with.
+type AccountService interface {
+	// Account retrieves the account with the given ID.
+	Account(id accounts.AccountID) (*accounts.OffChainBalanceAccount,
+		error)
+}
+
+// withdrawRequest is the response to the first request of a wallet as defined
+// in LUD-03.
+type withdrawRequest struct {
+	Tag                string              `json:"tag"`
+	Callback           string              `json:"callback"`
+	K1                 string              `json:"k1"`
+	DefaultDescription string              `json:"defaultDescription"`
+	MinWithdrawable    lnwire.MilliSatoshi `json:"minWithdrawable"`
+	MaxWithdrawable    lnwire.MilliSatoshi `json:"maxWithdrawable"`
+}
+
+// statusResponse is the generic LNURL status response as defined in LUD-01.
+type statusResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Service manages the LNURL-withdraw vouchers and serves them to wallets.
+// Each voucher withdraws from a lit account, so the withdrawals are debited
+// from and tracked by the account the same way as any other payment of the
+// account.
+type Service struct {
+	cfg      *Config
+	dir      string
+	accounts AccountService
+
+	store       *Store
+	payer       *accounts.Payer
+	chainParams *chaincfg.Params
+
+	// ready is set once the service is started and the vouchers can be
+	// redeemed.
+	ready atomic.Bool
+
+	// mu serializes the redemptions so that a voucher can't be redeemed
+	// more often than allowed by concurrent requests.
+	mu sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewService creates a new LNURL service that stores its data in the given
+// directory.
+func NewService(cfg *Config, dir string, accts AccountService) *Service {
+	return &Service{
+		cfg:      cfg,
+		dir:      dir,
+		accounts: accts,
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start opens the LNURL store if the service is enabled. Vouchers are only
+// served once the service is started.
+func (s *Service) Start(payer *accounts.Payer,
+	chainParams *chaincfg.Params) error {
+
+	if !s.cfg.Enable {
+		return nil
+	}
+
+	store, err := NewStore(s.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open lnurl store: %v", err)
+	}
+
+	s.store = store
+	s.payer = payer
+	s.chainParams = chainParams
+	s.ready.Store(true)
+
+	log.Infof("Serving LNURL-withdraw vouchers at %s%s",
+		s.cfg.ExternalURL, PathPrefix)
+
+	return nil
+}
+
+// Stop waits for the redemptions in flight to be handed off and closes the
+// store. Redemptions whose payment didn't reach a final state yet stay
+// counted against the uses of their voucher.
+func (s *Service) Stop() error {
+	if !s.ready.Load() {
+		return nil
+	}
+	s.ready.Store(false)
+
+	close(s.quit)
+	s.wg.Wait()
+
+	return s.store.Close()
+}
+
+// CreateVoucher creates a new voucher that withdraws from the given account.
+// The voucher can be redeemed maxUses times for amounts between min and max
+// each until it expires. A zero expiry means the voucher never expires.
+func (s *Service) CreateVoucher(accountID accounts.AccountID, min,
+	max lnwire.MilliSatoshi, maxUses uint32, expiresAt time.Time,
+	description string) (*Voucher, error) {
+
+	if !s.ready.Load() {
+		return nil, ErrServiceDisabled
+	}
+
+	if _, err := s.accounts.Account(accountID); err != nil {
+		return nil, err
+	}
+
+	if min == 0 {
+		min = defaultMinWithdrawable
+	}
+	if max == 0 {
+		return nil, fmt.Errorf("maximum withdrawable amount must be set")
+	}
+	if min > max {
+		return nil, fmt.Errorf("minimum withdrawable amount %v exceeds "+
+			"maximum %v", min, max)
+	}
+
+	if maxUses == 0 {
+		maxUses = 1
+	}
+
+	if !expiresAt.IsZero() && expiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	if description == "" {
+		description = defaultDescription
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	k1, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Voucher{
+		ID:              id,
+		AccountID:       accountID,
+		K1:              k1,
+		MinWithdrawable: min,
+		MaxWithdrawable: max,
+		MaxUses:         maxUses,
+		Description:     description,
+		CreatedAt:       time.Now(),
+		ExpiresAt:       expiresAt,
+	}
+	if err := s.store.AddVoucher(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Vouchers returns all vouchers.
+func (s *Service) Vouchers() ([]*Voucher, error) {
+	if !s.ready.Load() {
+		return nil, ErrServiceDisabled
+	}
+
+	return s.store.Vouchers()
+}
+
+// RemoveVoucher removes the voucher with the given ID so that it can no longer
+// be redeemed. Withdrawals that are already in flight are not affected.
+func (s *Service) RemoveVoucher(id string) error {
+	if !s.ready.Load() {
+		return ErrServiceDisabled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.store.RemoveVoucher(id)
+}
+
+// VoucherURL returns the URL of the given voucher.
+func (s *Service) VoucherURL(v *Voucher) string {
+	return s.cfg.ExternalURL + PathPrefix + v.ID
+}
+
+// ServeHTTP serves the LNURL-withdraw endpoints of the vouchers. The voucher
+// URL returns the withdraw request and its callback URL pays the invoice of
+// the wallet.
+func (s *Service) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !s.cfg.Enable {
+		http.NotFound(resp, req)
+		return
+	}
+
+	// LNURL endpoints are meant to be usable from browser based wallets.
+	resp.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if req.Method != http.MethodGet {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !s.ready.Load() {
+		writeError(resp, "service not ready")
+		return
+	}
+
+	// Just like the RPCs of the accounts, vouchers can't be used while the
+	// lockdown mode is enabled.
+	if err := s.payer.CheckLockdown(); err != nil {
+		writeError(resp, redemptionError(err))
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, PathPrefix), "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		s.handleWithdrawRequest(resp, parts[0])
+
+	case len(parts) == 2 && parts[1] == callbackPath:
+		s.handleCallback(resp, parts[0], req.URL.Query())
+
+	default:
+		http.NotFound(resp, req)
+	}
+}
+
+// handleWithdrawRequest answers the first request of a wallet with the
+// parameters of the withdrawal.
+func (s *Service) handleWithdrawRequest(resp http.ResponseWriter, id string) {
+	v, err := s.store.Voucher(id)
+	if err != nil {
+		writeError(resp, "unknown voucher")
+		return
+	}
+
+	max, err := s.maxWithdrawable(v)
+	if err != nil {
+		writeError(resp, err.Error())
+		return
+	}
+
+	writeJSON(resp, &withdrawRequest{
+		Tag:                tagWithdrawRequest,
+		Callback:           s.VoucherURL(v) + "/" + callbackPath,
+		K1:                 v.K1,
+		DefaultDescription: v.Description,
+		MinWithdrawable:    v.MinWithdrawable,
+		MaxWithdrawable:    max,
+	})
+}
+
+// handleCallback pays the invoice the wallet sent to the callback URL of a
+// voucher.
+func (s *Service) handleCallback(resp http.ResponseWriter, id string,
+	query url.Values) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, err := s.store.Voucher(id)
+	if err != nil {
+		writeError(resp, "unknown voucher")
+		return
+	}
+
+	k1 := query.Get("k1")
+	if subtle.ConstantTimeCompare([]byte(k1), []byte(v.K1)) != 1 {
+		writeError(resp, "invalid k1")
+		return
+	}
+
+	invoice := query.Get("pr")
+	if invoice == "" {
+		writeError(resp, "missing invoice")
+		return
+	}
+
+	payReq, err := zpay32.Decode(invoice, s.chainParams)
+	if err != nil {
+		writeError(resp, "invalid invoice")
+		return
+	}
+	if payReq.MilliSat == nil {
+		writeError(resp, "invoice must specify an amount")
+		return
+	}
+	amt := *payReq.MilliSat
+
+	max, err := s.maxWithdrawable(v)
+	if err != nil {
+		writeError(resp, err.Error())
+		return
+	}
+	if amt < v.MinWithdrawable || amt > max {
+		writeError(resp, fmt.Sprintf("amount must be between %d and "+
+			"%d msat", v.MinWithdrawable, max))
+		return
+	}
+
+	resultChan, err := s.payer.PayInvoice(v.AccountID, invoice, amt)
+	if err != nil {
+		log.Debugf("Unable to redeem voucher %s: %v", v.ID, err)
+		writeError(resp, redemptionError(err))
+		return
+	}
+
+	// The payment is in flight, so the redemption counts against the uses
+	// of the voucher until we know it failed.
+	hash := hex.EncodeToString(payReq.PaymentHash[:])
+	err = s.store.UpdateVoucher(v.ID, func(v *Voucher) error {
+		v.Redemptions = append(v.Redemptions, &Redemption{
+			PaymentHash: hash,
+			Amount:      amt,
+			RedeemedAt:  time.Now(),
+		})
+
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Unable to store redemption %s of voucher %s: %v",
+			hash, v.ID, err)
+	}
+
+	log.Infof("Redeemed voucher %s for %v with payment %s", v.ID, amt,
+		hash)
+
+	s.wg.Add(1)
+	go s.trackRedemption(v.ID, hash, resultChan)
+
+	writeJSON(resp, &statusResponse{Status: "OK"})
+}
+
+// trackRedemption waits for the final outcome of a redemption's payment. A
+// settled redemption is marked as such, a failed one is removed again so that
+// it doesn't count against the uses of the voucher.
+func (s *Service) trackRedemption(id, hash string,
+	resultChan <-chan accounts.PaymentResult) {
+
+	defer s.wg.Done()
+
+	var result accounts.PaymentResult
+	select {
+	case result = <-resultChan:
+	case <-s.quit:
+		return
+	}
+
+	if result.Err != nil {
+		log.Infof("Redemption %s of voucher %s failed: %v", hash, id,
+			result.Err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.store.UpdateVoucher(id, func(v *Voucher) error {
+		for i, r := range v.Redemptions {
+			if r.PaymentHash != hash {
+				continue
+			}
+
+			if result.Err != nil {
+				v.Redemptions = append(
+					v.Redemptions[:i], v.Redemptions[i+1:]...,
+				)
+			} else {
+				r.Settled = true
+			}
+
+			return nil
+		}
+
+		return nil
+	})
+
+	// If the voucher was removed in the meantime, there's nothing left to
+	// update.
+	if err != nil && !errors.Is(err, ErrVoucherNotFound) {
+		log.Errorf("Unable to update redemption %s of voucher %s: %v",
+			hash, id, err)
+	}
+}
+
+// maxWithdrawable returns the maximum amount that can currently be withdrawn
+// with the given voucher, or an error if it can't be redeemed at all.
+func (s *Service) maxWithdrawable(v *Voucher) (lnwire.MilliSatoshi, error) {
+	if v.HasExpired() {
+		return 0, fmt.Errorf("voucher has expired")
+	}
+
+	if v.RemainingUses() == 0 {
+		return 0, fmt.Errorf("voucher has already been redeemed")
+	}
+
+	account, err := s.accounts.Account(v.AccountID)
+	if err != nil {
+		return 0, fmt.Errorf("voucher account not found")
+	}
+
+	if account.HasExpired() {
+		return 0, fmt.Errorf("voucher account has expired")
+	}
+
+	max := v.MaxWithdrawable
+	if account.CurrentBalance < int64(max) {
+		max = lnwire.MilliSatoshi(0)
+		if account.CurrentBalance > 0 {
+			max = lnwire.MilliSatoshi(account.CurrentBalance)
+		}
+	}
+
+	if max < v.MinWithdrawable {
+		return 0, fmt.Errorf("insufficient voucher balance")
+	}
+
+	return max, nil
+}
+
+// redemptionError returns the reason that is sent to the wallet if a
+// redemption couldn't be paid.
+func redemptionError(err error) string {
+	switch {
+	case errors.Is(err, accounts.ErrAccBalanceInsufficient):
+		return "insufficient voucher balance"
+
+	case errors.Is(err, accounts.ErrAccExpired):
+		return "voucher account has expired"
+
+	case errors.Is(err, accounts.ErrInvalidInvoice):
+		return "invalid invoice"
+
+	case errors.Is(err, accounts.ErrPaymentFailed):
+		return "payment failed"
+
+	case errors.Is(err, accounts.ErrLockdown):
+		return "service temporarily unavailable"
+
+	default:
+		return "internal error"
+	}
+}
+
+// writeJSON writes the given value as the JSON response.
+func writeJSON(resp http.ResponseWriter, v interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(v); err != nil {
+		log.Errorf("Unable to write LNURL response: %v", err)
+	}
+}
+
+// writeError writes an LNURL error response with the given reason. As
+// defined in LUD-01, errors are returned with a successful HTTP status.
+func writeError(resp http.ResponseWriter, reason string) {
+	writeJSON(resp, &statusResponse{
+		Status: "ERROR",
+		Reason: reason,
+	})
+}
+
+// randomHex returns the given number of random bytes, hex encoded.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
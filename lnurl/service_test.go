@@ -0,0 +1,358 @@
+package lnurl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainParams = &chaincfg.RegressionNetParams
+
+// mockAccounts is a mock implementation of the account service.
+type mockAccounts struct {
+	accts   map[accounts.AccountID]*accounts.OffChainBalanceAccount
+	tracked map[lntypes.Hash]accounts.AccountID
+}
+
+func newMockAccounts() *mockAccounts {
+	return &mockAccounts{
+		accts: make(
+			map[accounts.AccountID]*accounts.OffChainBalanceAccount,
+		),
+		tracked: make(map[lntypes.Hash]accounts.AccountID),
+	}
+}
+
+func (m *mockAccounts) Account(id accounts.AccountID) (
+	*accounts.OffChainBalanceAccount, error) {
+
+	acct, ok := m.accts[id]
+	if !ok {
+		return nil, accounts.ErrAccNotFound
+	}
+
+	return acct, nil
+}
+
+func (m *mockAccounts) CheckBalance(id accounts.AccountID,
+	requiredBalance lnwire.MilliSatoshi) error {
+
+	acct, err := m.Account(id)
+	if err != nil {
+		return err
+	}
+
+	if acct.CurrentBalance < int64(requiredBalance) {
+		return accounts.ErrAccBalanceInsufficient
+	}
+
+	return nil
+}
+
+func (m *mockAccounts) AssociateInvoice(accounts.AccountID,
+	lntypes.Hash) error {
+
+	return nil
+}
+
+func (m *mockAccounts) TrackPayment(id accounts.AccountID, hash lntypes.Hash,
+	_ lnwire.MilliSatoshi) error {
+
+	m.tracked[hash] = id
+	return nil
+}
+
+func (m *mockAccounts) RemovePayment(lntypes.Hash) error {
+	return nil
+}
+
+func (m *mockAccounts) RecordPaymentAttempt(accounts.AccountID,
+	lnwire.MilliSatoshi, *route.Vertex) {
+}
+
+func (m *mockAccounts) RecordPaymentFailure(accounts.AccountID,
+	lntypes.Hash) {
+}
+
+// mockRouter is a mock implementation of the lnd router client whose
+// payments end in the configured state.
+type mockRouter struct {
+	lndclient.RouterClient
+
+	finalState lnrpc.Payment_PaymentStatus
+}
+
+func (m *mockRouter) SendPayment(_ context.Context,
+	_ lndclient.SendPaymentRequest) (chan lndclient.PaymentStatus,
+	chan error, error) {
+
+	statusChan := make(chan lndclient.PaymentStatus, 2)
+	statusChan <- lndclient.PaymentStatus{
+		State: lnrpc.Payment_IN_FLIGHT,
+	}
+	statusChan <- lndclient.PaymentStatus{
+		State: m.finalState,
+	}
+
+	return statusChan, make(chan error), nil
+}
+
+// newTestInvoice creates a new signed invoice for the given amount.
+func newTestInvoice(t *testing.T, amt lnwire.MilliSatoshi,
+	preimage lntypes.Preimage) string {
+
+	nodeKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	invoice, err := zpay32.NewInvoice(
+		testChainParams, preimage.Hash(), time.Now(),
+		zpay32.Description("test"), zpay32.Amount(amt),
+	)
+	require.NoError(t, err)
+
+	payReq, err := invoice.Encode(zpay32.MessageSigner{
+		SignCompact: func(msg []byte) ([]byte, error) {
+			return ecdsa.SignCompact(
+				nodeKey, chainhash.HashB(msg), true,
+			)
+		},
+	})
+	require.NoError(t, err)
+
+	return payReq
+}
+
+// newTestService creates a started LNURL service with a single account that
+// has the given balance. The lockdown mode of the service can be toggled
+// with the returned lockdown checker.
+func newTestService(t *testing.T, balance lnwire.MilliSatoshi,
+	finalState lnrpc.Payment_PaymentStatus) (*Service, *mockAccounts,
+	accounts.AccountID, *mockLockdown) {
+
+	accts := newMockAccounts()
+	accountID := accounts.AccountID{1, 2, 3}
+	accts.accts[accountID] = &accounts.OffChainBalanceAccount{
+		ID:             accountID,
+		CurrentBalance: int64(balance),
+	}
+
+	cfg := &Config{
+		Enable:      true,
+		ExternalURL: "https://lit.example.com",
+	}
+	s := NewService(cfg, t.TempDir(), accts)
+
+	lockdown := &mockLockdown{}
+	payer := accounts.NewPayer(
+		accts, &mockRouter{finalState: finalState}, testChainParams,
+		lockdown,
+	)
+	require.NoError(t, s.Start(payer, testChainParams))
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+
+	return s, accts, accountID, lockdown
+}
+
+// mockLockdown is a lockdown checker whose lockdown mode can be toggled.
+type mockLockdown struct {
+	active bool
+}
+
+// Active returns true if the lockdown mode is enabled.
+func (m *mockLockdown) Active() bool {
+	return m.active
+}
+
+// get sends a GET request for the given URL to the service and decodes the
+// JSON response.
+func get(t *testing.T, s *Service, rawURL string) map[string]interface{} {
+	req := httptest.NewRequest(http.MethodGet, rawURL, nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	return resp
+}
+
+// callbackURL returns the callback URL of the given voucher for the given
+// invoice.
+func callbackURL(s *Service, v *Voucher, k1, invoice string) string {
+	query := url.Values{
+		"k1": []string{k1},
+		"pr": []string{invoice},
+	}
+
+	return s.VoucherURL(v) + "/" + callbackPath + "?" + query.Encode()
+}
+
+// TestWithdrawRequest tests that a voucher's withdraw request is limited by
+// the voucher and the account balance.
+func TestWithdrawRequest(t *testing.T) {
+	s, accts, accountID, _ := newTestService(
+		t, 50_000_000, lnrpc.Payment_SUCCEEDED,
+	)
+
+	v, err := s.CreateVoucher(
+		accountID, 0, 100_000_000, 0, time.Time{}, "",
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, v.MaxUses)
+	require.Equal(t, defaultMinWithdrawable, v.MinWithdrawable)
+
+	// The maximum is capped to the balance of the account.
+	resp := get(t, s, s.VoucherURL(v))
+	require.Equal(t, tagWithdrawRequest, resp["tag"])
+	require.Equal(t, v.K1, resp["k1"])
+	require.Equal(t, s.VoucherURL(v)+"/callback", resp["callback"])
+	require.EqualValues(t, 1000, resp["minWithdrawable"])
+	require.EqualValues(t, 50_000_000, resp["maxWithdrawable"])
+
+	// An empty account can't be withdrawn from.
+	accts.accts[accountID].CurrentBalance = 0
+	resp = get(t, s, s.VoucherURL(v))
+	require.Equal(t, "ERROR", resp["status"])
+
+	// Unknown vouchers are rejected.
+	resp = get(t, s, "https://lit.example.com/lnurlw/unknown")
+	require.Equal(t, "ERROR", resp["status"])
+
+	// Vouchers can't be created for unknown accounts or with invalid
+	// limits.
+	_, err = s.CreateVoucher(
+		accounts.AccountID{9}, 0, 1000, 1, time.Time{}, "",
+	)
+	require.ErrorIs(t, err, accounts.ErrAccNotFound)
+
+	_, err = s.CreateVoucher(accountID, 2000, 1000, 1, time.Time{}, "")
+	require.Error(t, err)
+
+	_, err = s.CreateVoucher(
+		accountID, 0, 1000, 1, time.Now().Add(-time.Hour), "",
+	)
+	require.Error(t, err)
+}
+
+// TestRedeemVoucher tests that a voucher can only be redeemed as often as
+// allowed and that the redemptions are tracked by the account.
+func TestRedeemVoucher(t *testing.T) {
+	s, accts, accountID, lockdown := newTestService(
+		t, 50_000_000, lnrpc.Payment_SUCCEEDED,
+	)
+
+	v, err := s.CreateVoucher(
+		accountID, 1000, 10_000_000, 1, time.Time{}, "",
+	)
+	require.NoError(t, err)
+
+	preimage := lntypes.Preimage{1}
+	invoice := newTestInvoice(t, 5_000_000, preimage)
+
+	// A wrong k1 is rejected.
+	resp := get(t, s, callbackURL(s, v, "wrong", invoice))
+	require.Equal(t, "ERROR", resp["status"])
+
+	// An amount above the maximum is rejected.
+	tooMuch := newTestInvoice(t, 20_000_000, lntypes.Preimage{2})
+	resp = get(t, s, callbackURL(s, v, v.K1, tooMuch))
+	require.Equal(t, "ERROR", resp["status"])
+
+	// Vouchers can't be used while the lockdown mode is enabled.
+	lockdown.active = true
+	resp = get(t, s, callbackURL(s, v, v.K1, invoice))
+	require.Equal(t, "ERROR", resp["status"])
+	require.Empty(t, accts.tracked)
+	lockdown.active = false
+
+	// A valid invoice is paid and the payment is tracked by the account.
+	resp = get(t, s, callbackURL(s, v, v.K1, invoice))
+	require.Equal(t, "OK", resp["status"])
+	require.Equal(t, accountID, accts.tracked[preimage.Hash()])
+
+	// The redemption is eventually marked as settled.
+	require.Eventually(t, func() bool {
+		stored, err := s.store.Voucher(v.ID)
+		require.NoError(t, err)
+
+		return len(stored.Redemptions) == 1 &&
+			stored.Redemptions[0].Settled
+	}, time.Second, 10*time.Millisecond)
+
+	// A single-use voucher can't be redeemed twice.
+	second := newTestInvoice(t, 5_000_000, lntypes.Preimage{3})
+	resp = get(t, s, callbackURL(s, v, v.K1, second))
+	require.Equal(t, "ERROR", resp["status"])
+
+	// A revoked voucher can no longer be used.
+	require.NoError(t, s.RemoveVoucher(v.ID))
+	resp = get(t, s, s.VoucherURL(v))
+	require.Equal(t, "ERROR", resp["status"])
+}
+
+// TestFailedRedemption tests that a failed redemption doesn't count against
+// the uses of a voucher.
+func TestFailedRedemption(t *testing.T) {
+	s, _, accountID, _ := newTestService(
+		t, 50_000_000, lnrpc.Payment_FAILED,
+	)
+
+	v, err := s.CreateVoucher(
+		accountID, 1000, 10_000_000, 1, time.Time{}, "",
+	)
+	require.NoError(t, err)
+
+	invoice := newTestInvoice(t, 5_000_000, lntypes.Preimage{1})
+	resp := get(t, s, callbackURL(s, v, v.K1, invoice))
+	require.Equal(t, "OK", resp["status"])
+
+	require.Eventually(t, func() bool {
+		stored, err := s.store.Voucher(v.ID)
+		require.NoError(t, err)
+
+		return len(stored.Redemptions) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	resp = get(t, s, s.VoucherURL(v))
+	require.Equal(t, tagWithdrawRequest, resp["tag"])
+}
+
+// TestEncode tests the bech32 encoding of LNURLs.
+func TestEncode(t *testing.T) {
+	rawURL := "https://lit.example.com/lnurlw/0123456789abcdef"
+
+	encoded, err := Encode(rawURL)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(encoded, "LNURL1"))
+
+	hrp, data, err := bech32.DecodeNoLimit(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "lnurl", hrp)
+
+	decoded, err := bech32.ConvertBits(data, 5, 8, false)
+	require.NoError(t, err)
+	require.Equal(t, rawURL, string(decoded))
+}
@@ -0,0 +1,264 @@
+package lnurl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the LNURL database.
+	DBFilename = "lnurl.db"
+
+	// dbFilePermission is the default permission the LNURL database file
+	// is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The LNURL data is stored in the following structure in the db:
+
+	vouchers -> voucher ID -> json encoded Voucher
+*/
+
+var (
+	// vouchersBucketKey is the key of the top level bucket holding all
+	// the vouchers.
+	vouchersBucketKey = []byte("vouchers")
+
+	// ErrVoucherNotFound is returned when a voucher with the given ID does
+	// not exist in the db.
+	ErrVoucherNotFound = errors.New("lnurl voucher not found")
+)
+
+// Redemption is a single withdrawal of a voucher.
+type Redemption struct {
+	// PaymentHash is the hex encoded hash of the invoice that was paid.
+	PaymentHash string `json:"payment_hash"`
+
+	// Amount is the amount that was withdrawn.
+	Amount lnwire.MilliSatoshi `json:"amount"`
+
+	// RedeemedAt is the time the withdrawal was requested.
+	RedeemedAt time.Time `json:"redeemed_at"`
+
+	// Settled is true once the payment of the withdrawal succeeded.
+	Settled bool `json:"settled"`
+}
+
+// Voucher is an LNURL-withdraw link that lets its bearer withdraw from a lit
+// account.
+type Voucher struct {
+	// ID is the hex encoded random ID of the voucher. It is part of the
+	// voucher's URL and therefore a secret.
+	ID string `json:"id"`
+
+	// AccountID is the ID of the account the voucher withdraws from.
+	AccountID accounts.AccountID `json:"account_id"`
+
+	// K1 is the hex encoded random secret the wallet has to present when
+	// requesting the withdrawal.
+	K1 string `json:"k1"`
+
+	// MinWithdrawable is the minimum amount of a single withdrawal.
+	MinWithdrawable lnwire.MilliSatoshi `json:"min_withdrawable"`
+
+	// MaxWithdrawable is the maximum amount of a single withdrawal.
+	MaxWithdrawable lnwire.MilliSatoshi `json:"max_withdrawable"`
+
+	// MaxUses is the number of times the voucher can be redeemed.
+	MaxUses uint32 `json:"max_uses"`
+
+	// Description is the description of the invoices created by the
+	// wallet.
+	Description string `json:"description"`
+
+	// CreatedAt is the time the voucher was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is the time after which the voucher can no longer be
+	// redeemed. A zero time means it never expires.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Redemptions are all withdrawals of the voucher that are in flight
+	// or settled. Failed withdrawals are removed again so they don't count
+	// against the uses of the voucher.
+	Redemptions []*Redemption `json:"redemptions"`
+}
+
+// HasExpired returns true if the voucher has an expiry time that lies in the
+// past.
+func (v *Voucher) HasExpired() bool {
+	return !v.ExpiresAt.IsZero() && v.ExpiresAt.Before(time.Now())
+}
+
+// RemainingUses returns the number of times the voucher can still be
+// redeemed.
+func (v *Voucher) RemainingUses() uint32 {
+	if uint32(len(v.Redemptions)) >= v.MaxUses {
+		return 0
+	}
+
+	return v.MaxUses - uint32(len(v.Redemptions))
+}
+
+// Store is a bolt-backed persistent store of the vouchers.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the LNURL store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(vouchersBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// AddVoucher stores the given voucher.
+func (s *Store) AddVoucher(v *Voucher) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putVoucher(tx, v)
+	})
+}
+
+// Voucher fetches the voucher with the given ID. If no such voucher exists,
+// ErrVoucherNotFound is returned.
+func (s *Store) Voucher(id string) (*Voucher, error) {
+	var v *Voucher
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		v, err = getVoucher(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// UpdateVoucher applies the given update function to the voucher with the
+// given ID and stores the result atomically.
+func (s *Store) UpdateVoucher(id string, update func(v *Voucher) error) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		v, err := getVoucher(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := update(v); err != nil {
+			return err
+		}
+
+		return putVoucher(tx, v)
+	})
+}
+
+// Vouchers returns all vouchers sorted by their creation time.
+func (s *Store) Vouchers() ([]*Voucher, error) {
+	var vouchers []*Voucher
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(vouchersBucketKey)
+
+		return bucket.ForEach(func(_, b []byte) error {
+			var v Voucher
+			if err := json.Unmarshal(b, &v); err != nil {
+				return err
+			}
+
+			vouchers = append(vouchers, &v)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(vouchers, func(i, j int) bool {
+		return vouchers[i].CreatedAt.Before(vouchers[j].CreatedAt)
+	})
+
+	return vouchers, nil
+}
+
+// RemoveVoucher removes the voucher with the given ID. If no such voucher
+// exists, ErrVoucherNotFound is returned.
+func (s *Store) RemoveVoucher(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(vouchersBucketKey)
+
+		if bucket.Get([]byte(id)) == nil {
+			return ErrVoucherNotFound
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// getVoucher reads the voucher with the given ID within the given
+// transaction.
+func getVoucher(tx *bbolt.Tx, id string) (*Voucher, error) {
+	b := tx.Bucket(vouchersBucketKey).Get([]byte(id))
+	if b == nil {
+		return nil, ErrVoucherNotFound
+	}
+
+	v := &Voucher{}
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// putVoucher writes the given voucher within the given transaction.
+func putVoucher(tx *bbolt.Tx, v *Voucher) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(vouchersBucketKey).Put([]byte(v.ID), b)
+}
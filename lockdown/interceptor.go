@@ -0,0 +1,100 @@
+package lockdown
+
+import (
+	"context"
+
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// lockdownInterceptor is an RPC middleware interceptor that rejects all
+// requests while the lockdown mode is enabled and otherwise hands them to the
+// interceptor it wraps.
+type lockdownInterceptor struct {
+	mid.RequestInterceptor
+
+	mgr *Manager
+}
+
+// WrapInterceptor wraps the given RPC middleware interceptor so that the
+// requests it is called for are rejected while the lockdown mode is enabled.
+// lnd only calls an interceptor for macaroons that carry its custom caveat, so
+// this covers the account and session macaroons but never the admin macaroon.
+// Read-only interceptors can't reject requests and are returned as is.
+func (m *Manager) WrapInterceptor(
+	interceptor mid.RequestInterceptor) mid.RequestInterceptor {
+
+	if interceptor.ReadOnly() || interceptor.CustomCaveatName() == "" {
+		return interceptor
+	}
+
+	return &lockdownInterceptor{
+		RequestInterceptor: interceptor,
+		mgr:                m,
+	}
+}
+
+// Intercept rejects requests and stream authentications while the lockdown
+// mode is enabled. Responses of calls that were accepted before are still
+// handed to the wrapped interceptor, so that it can finish its bookkeeping.
+//
+// NOTE: This is part of the rpcmiddleware.RequestInterceptor interface.
+func (i *lockdownInterceptor) Intercept(ctx context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	var uri string
+	switch t := req.InterceptType.(type) {
+	case *lnrpc.RPCMiddlewareRequest_StreamAuth:
+		uri = t.StreamAuth.MethodFullUri
+
+	case *lnrpc.RPCMiddlewareRequest_Request:
+		uri = t.Request.MethodFullUri
+
+	default:
+		return i.RequestInterceptor.Intercept(ctx, req)
+	}
+
+	if i.mgr.Active() {
+		log.Debugf("Rejecting call to %s in interceptor %s", uri,
+			i.Name())
+
+		return mid.RPCErr(req, ErrLockdown)
+	}
+
+	return i.RequestInterceptor.Intercept(ctx, req)
+}
+
+// UnaryServerInterceptor is a gRPC unary interceptor that rejects all calls
+// while the lockdown mode is enabled. It is installed on the gRPC servers of
+// the LNC sessions.
+func (m *Manager) UnaryServerInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+
+	if m.Active() {
+		log.Debugf("Rejecting session call to %s", info.FullMethod)
+
+		return nil, status.Error(codes.Unavailable, ErrLockdown.Error())
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor is a gRPC stream interceptor that rejects all calls
+// while the lockdown mode is enabled. It is installed on the gRPC servers of
+// the LNC sessions.
+func (m *Manager) StreamServerInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if m.Active() {
+		log.Debugf("Rejecting session call to %s", info.FullMethod)
+
+		return status.Error(codes.Unavailable, ErrLockdown.Error())
+	}
+
+	return handler(srv, ss)
+}
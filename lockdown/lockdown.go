@@ -0,0 +1,186 @@
+package lockdown
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLockdown is returned for the calls that are rejected because the
+	// lockdown mode is enabled.
+	ErrLockdown = errors.New("litd is in lockdown mode, account and " +
+		"session RPCs are rejected")
+
+	// ErrNotStarted is returned if the lockdown mode is changed before the
+	// manager was started.
+	ErrNotStarted = errors.New("lockdown manager not started")
+)
+
+// State is the lockdown mode of litd.
+type State struct {
+	// Active is true if the lockdown mode is enabled.
+	Active bool `json:"active"`
+
+	// Reason is why the lockdown mode was enabled.
+	Reason string `json:"reason"`
+
+	// Since is the time the lockdown mode was enabled. It is zero if the
+	// lockdown mode is lifted.
+	Since time.Time `json:"since"`
+}
+
+// Manager keeps track of the lockdown mode of litd. While the lockdown mode is
+// enabled, all RPCs that are authenticated with the macaroon of an account or
+// through an LNC session are rejected, so that an operator can stop all
+// non-admin traffic at once during an incident.
+type Manager struct {
+	dir string
+
+	// onEnable is called every time the lockdown mode is enabled. It is
+	// nil if no callback was set.
+	onEnable func()
+
+	// mu guards the store and the state.
+	mu    sync.RWMutex
+	store *Store
+	state State
+}
+
+// NewManager creates a new lockdown manager that stores the lockdown mode in
+// the given directory.
+func NewManager(dir string) *Manager {
+	return &Manager{
+		dir: dir,
+	}
+}
+
+// SetOnEnable sets the function that is called every time the lockdown mode is
+// enabled, including when it is restored on startup. It must be called before
+// the manager is started.
+func (m *Manager) SetOnEnable(onEnable func()) {
+	m.onEnable = onEnable
+}
+
+// Start opens the lockdown store and restores the lockdown mode that was
+// enabled before the last shutdown, if any.
+func (m *Manager) Start() error {
+	store, err := NewStore(m.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open lockdown store: %v", err)
+	}
+
+	state, err := store.State()
+	if err != nil {
+		_ = store.Close()
+		return fmt.Errorf("unable to read lockdown mode: %v", err)
+	}
+
+	if state.Active {
+		log.Warnf("Lockdown mode enabled since %v (reason: %q), "+
+			"rejecting all account and session RPCs", state.Since,
+			state.Reason)
+
+		if m.onEnable != nil {
+			m.onEnable()
+		}
+	}
+
+	m.mu.Lock()
+	m.store = store
+	m.state = *state
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Stop closes the lockdown store.
+func (m *Manager) Stop() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	err := m.store.Close()
+	m.store = nil
+
+	return err
+}
+
+// State returns the current lockdown mode.
+func (m *Manager) State() State {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.state
+}
+
+// Active returns true if the lockdown mode is enabled.
+func (m *Manager) Active() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.state.Active
+}
+
+// Enable enables the lockdown mode for the given reason. If the lockdown mode
+// is already enabled, only its reason is updated.
+func (m *Manager) Enable(reason string) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := State{
+		Active: true,
+		Reason: reason,
+		Since:  m.state.Since,
+	}
+	if !m.state.Active {
+		state.Since = time.Now()
+	}
+
+	if err := m.setState(&state); err != nil {
+		return State{}, err
+	}
+
+	log.Warnf("Lockdown mode enabled (reason: %q), rejecting all "+
+		"account and session RPCs", reason)
+
+	if m.onEnable != nil {
+		m.onEnable()
+	}
+
+	return state, nil
+}
+
+// Lift lifts the lockdown mode.
+func (m *Manager) Lift() (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := State{}
+	if err := m.setState(&state); err != nil {
+		return State{}, err
+	}
+
+	log.Infof("Lockdown mode lifted")
+
+	return state, nil
+}
+
+// setState stores the given lockdown mode and makes it the current one. The
+// caller must hold the mutex.
+func (m *Manager) setState(state *State) error {
+	if m.store == nil {
+		return ErrNotStarted
+	}
+
+	if err := m.store.SetState(state); err != nil {
+		return fmt.Errorf("unable to store lockdown mode: %v", err)
+	}
+	m.state = *state
+
+	return nil
+}
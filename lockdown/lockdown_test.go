@@ -0,0 +1,150 @@
+package lockdown
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockInterceptor is an interceptor that counts the messages it receives.
+type mockInterceptor struct {
+	caveat string
+	calls  int
+}
+
+func (m *mockInterceptor) Name() string {
+	return "test-interceptor"
+}
+
+func (m *mockInterceptor) ReadOnly() bool {
+	return m.caveat == ""
+}
+
+func (m *mockInterceptor) CustomCaveatName() string {
+	return m.caveat
+}
+
+func (m *mockInterceptor) Intercept(_ context.Context,
+	_ *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	m.calls++
+
+	return &lnrpc.RPCMiddlewareResponse{}, nil
+}
+
+// TestLockdown tests that the lockdown mode rejects the calls of account and
+// session macaroons and LNC sessions and survives a restart.
+func TestLockdown(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	mgr := NewManager(dir)
+
+	enabled := 0
+	mgr.SetOnEnable(func() {
+		enabled++
+	})
+
+	_, err := mgr.Enable("incident")
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, mgr.Start())
+	require.False(t, mgr.Active())
+
+	// Read-only interceptors are called for all macaroons, so they aren't
+	// wrapped.
+	readOnly := &mockInterceptor{}
+	require.Same(t, readOnly, mgr.WrapInterceptor(readOnly))
+
+	interceptor := &mockInterceptor{caveat: "account"}
+	wrapped := mgr.WrapInterceptor(interceptor)
+	require.Equal(t, "test-interceptor", wrapped.Name())
+
+	request := &lnrpc.RPCMiddlewareRequest{
+		MsgId: 1,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+			Request: &lnrpc.RPCMessage{
+				MethodFullUri: "/lnrpc.Lightning/SendPaymentSync",
+			},
+		},
+	}
+	response := &lnrpc.RPCMiddlewareRequest{
+		MsgId: 2,
+		InterceptType: &lnrpc.RPCMiddlewareRequest_Response{
+			Response: &lnrpc.RPCMessage{},
+		},
+	}
+
+	unaryCalls := 0
+	unary := func() error {
+		_, err := mgr.UnaryServerInterceptor(
+			ctx, nil, &grpc.UnaryServerInfo{},
+			func(context.Context, interface{}) (interface{},
+				error) {
+
+				unaryCalls++
+				return nil, nil
+			},
+		)
+		return err
+	}
+
+	// Without lockdown, all calls are handed on.
+	resp, err := wrapped.Intercept(ctx, request)
+	require.NoError(t, err)
+	require.Empty(t, resp.GetFeedback().GetError())
+	require.NoError(t, unary())
+	require.Equal(t, 1, interceptor.calls)
+	require.Equal(t, 1, unaryCalls)
+
+	// In lockdown, requests are rejected but responses are still handed
+	// to the interceptor.
+	state, err := mgr.Enable("incident")
+	require.NoError(t, err)
+	require.True(t, state.Active)
+	require.False(t, state.Since.IsZero())
+	require.Equal(t, 1, enabled)
+
+	resp, err = wrapped.Intercept(ctx, request)
+	require.NoError(t, err)
+	require.Contains(t, resp.GetFeedback().Error, ErrLockdown.Error())
+	require.Equal(t, 1, interceptor.calls)
+
+	_, err = wrapped.Intercept(ctx, response)
+	require.NoError(t, err)
+	require.Equal(t, 2, interceptor.calls)
+
+	err = unary()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 1, unaryCalls)
+
+	// Updating the reason keeps the time the lockdown started.
+	updated, err := mgr.Enable("still investigating")
+	require.NoError(t, err)
+	require.Equal(t, state.Since, updated.Since)
+
+	// The lockdown mode survives a restart.
+	require.NoError(t, mgr.Stop())
+	mgr = NewManager(dir)
+	mgr.SetOnEnable(func() {
+		enabled++
+	})
+	require.NoError(t, mgr.Start())
+	t.Cleanup(func() {
+		require.NoError(t, mgr.Stop())
+	})
+	require.True(t, mgr.Active())
+	require.Equal(t, "still investigating", mgr.State().Reason)
+	require.Equal(t, 3, enabled)
+
+	// Once lifted, calls are handed on again.
+	state, err = mgr.Lift()
+	require.NoError(t, err)
+	require.False(t, state.Active)
+	require.NoError(t, unary())
+	require.Equal(t, 2, unaryCalls)
+}
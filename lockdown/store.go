@@ -0,0 +1,111 @@
+package lockdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the lockdown database.
+	DBFilename = "lockdown.db"
+
+	// dbFilePermission is the default permission the lockdown database
+	// file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The lockdown mode is stored in the following structure in the db:
+
+	lockdown -> state -> json encoded State
+*/
+
+var (
+	// lockdownBucketKey is the key of the top level bucket holding the
+	// lockdown mode.
+	lockdownBucketKey = []byte("lockdown")
+
+	// stateKey is the key the lockdown mode is stored under.
+	stateKey = []byte("state")
+)
+
+// Store is a bolt-backed persistent store of the lockdown mode.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the lockdown store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(lockdownBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// State fetches the stored lockdown mode. If none was stored yet, the
+// lockdown mode is lifted.
+func (s *Store) State() (*State, error) {
+	state := &State{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(lockdownBucketKey).Get(stateKey)
+		if b == nil {
+			return nil
+		}
+
+		return json.Unmarshal(b, state)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// SetState stores the given lockdown mode.
+func (s *Store) SetState(state *State) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(lockdownBucketKey).Put(stateKey, b)
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -5,11 +5,40 @@ import (
 	"github.com/lightninglabs/faraday"
 	"github.com/lightninglabs/lightning-node-connect/mailbox"
 	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/adminauth"
+	"github.com/lightninglabs/lightning-terminal/apikeys"
+	"github.com/lightninglabs/lightning-terminal/authlimit"
 	"github.com/lightninglabs/lightning-terminal/autopilotserver"
+	"github.com/lightninglabs/lightning-terminal/autopilotserver/mock"
+	"github.com/lightninglabs/lightning-terminal/backup"
+	"github.com/lightninglabs/lightning-terminal/cluster"
+	"github.com/lightninglabs/lightning-terminal/confreload"
+	"github.com/lightninglabs/lightning-terminal/dbcompact"
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"github.com/lightninglabs/lightning-terminal/faults"
+	"github.com/lightninglabs/lightning-terminal/feesched"
 	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/guardrails"
+	"github.com/lightninglabs/lightning-terminal/inbox"
+	"github.com/lightninglabs/lightning-terminal/lnurl"
+	"github.com/lightninglabs/lightning-terminal/lockdown"
+	"github.com/lightninglabs/lightning-terminal/maccache"
+	"github.com/lightninglabs/lightning-terminal/maintenance"
+	"github.com/lightninglabs/lightning-terminal/nodemgmt"
+	"github.com/lightninglabs/lightning-terminal/nwc"
+	"github.com/lightninglabs/lightning-terminal/oidc"
+	"github.com/lightninglabs/lightning-terminal/portal"
+	"github.com/lightninglabs/lightning-terminal/provision"
+	"github.com/lightninglabs/lightning-terminal/reports"
 	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
 	"github.com/lightninglabs/lightning-terminal/rules"
+	"github.com/lightninglabs/lightning-terminal/scb"
 	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lightning-terminal/status"
+	"github.com/lightninglabs/lightning-terminal/uiflags"
+	"github.com/lightninglabs/lightning-terminal/watchdog"
+	"github.com/lightninglabs/lightning-terminal/webproxy"
+	"github.com/lightninglabs/lightning-terminal/webui"
 	"github.com/lightninglabs/loop/loopd"
 	"github.com/lightninglabs/pool"
 	"github.com/lightningnetwork/lnd"
@@ -65,6 +94,7 @@ func SetupLoggers(root *build.RotatingLogWriter, intercept signal.Interceptor) {
 	lnd.AddSubLogger(root, session.Subsystem, intercept, session.UseLogger)
 	lnd.AddSubLogger(root, mailbox.Subsystem, intercept, mailbox.UseLogger)
 	lnd.AddSubLogger(root, mid.Subsystem, intercept, mid.UseLogger)
+	lnd.AddSubLogger(root, faults.Subsystem, intercept, faults.UseLogger)
 	lnd.AddSubLogger(
 		root, accounts.Subsystem, intercept, accounts.UseLogger,
 	)
@@ -72,10 +102,70 @@ func SetupLoggers(root *build.RotatingLogWriter, intercept signal.Interceptor) {
 		root, firewall.Subsystem, intercept, firewall.UseLogger,
 	)
 	lnd.AddSubLogger(root, rules.Subsystem, intercept, rules.UseLogger)
+	lnd.AddSubLogger(root, scb.Subsystem, intercept, scb.UseLogger)
+	lnd.AddSubLogger(root, backup.Subsystem, intercept, backup.UseLogger)
+	lnd.AddSubLogger(root, reports.Subsystem, intercept, reports.UseLogger)
+	lnd.AddSubLogger(root, nwc.Subsystem, intercept, nwc.UseLogger)
+	lnd.AddSubLogger(root, lnurl.Subsystem, intercept, lnurl.UseLogger)
+	lnd.AddSubLogger(root, portal.Subsystem, intercept, portal.UseLogger)
+	lnd.AddSubLogger(root, oidc.Subsystem, intercept, oidc.UseLogger)
+	lnd.AddSubLogger(root, apikeys.Subsystem, intercept, apikeys.UseLogger)
+	lnd.AddSubLogger(
+		root, nodemgmt.Subsystem, intercept, nodemgmt.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, feesched.Subsystem, intercept, feesched.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, watchdog.Subsystem, intercept, watchdog.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, guardrails.Subsystem, intercept, guardrails.UseLogger,
+	)
+	lnd.AddSubLogger(root, status.Subsystem, intercept, status.UseLogger)
+	lnd.AddSubLogger(root, cluster.Subsystem, intercept, cluster.UseLogger)
+	lnd.AddSubLogger(
+		root, provision.Subsystem, intercept, provision.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, webproxy.Subsystem, intercept, webproxy.UseLogger,
+	)
+	lnd.AddSubLogger(root, webui.Subsystem, intercept, webui.UseLogger)
+	lnd.AddSubLogger(
+		root, uiflags.Subsystem, intercept, uiflags.UseLogger,
+	)
+	lnd.AddSubLogger(root, inbox.Subsystem, intercept, inbox.UseLogger)
+	lnd.AddSubLogger(
+		root, lockdown.Subsystem, intercept, lockdown.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, confreload.Subsystem, intercept, confreload.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, authlimit.Subsystem, intercept, authlimit.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, adminauth.Subsystem, intercept, adminauth.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, maintenance.Subsystem, intercept, maintenance.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, dbcompact.Subsystem, intercept, dbcompact.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, dbverify.Subsystem, intercept, dbverify.UseLogger,
+	)
+	lnd.AddSubLogger(
+		root, maccache.Subsystem, intercept, maccache.UseLogger,
+	)
 	lnd.AddSubLogger(
 		root, autopilotserver.Subsystem, intercept,
 		autopilotserver.UseLogger,
 	)
+	lnd.AddSubLogger(
+		root, mock.Subsystem, intercept, mock.UseLogger,
+	)
 
 	// Add daemon loggers to lnd's root logger.
 	faraday.SetupLoggers(root, intercept)
@@ -0,0 +1,102 @@
+package logsink
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SinkFile writes the log messages to the log file and stdout.
+	SinkFile = "file"
+
+	// SinkSyslog sends the log messages to a syslog server.
+	SinkSyslog = "syslog"
+
+	// SinkJournald sends the log messages to the systemd journal.
+	SinkJournald = "journald"
+
+	// defaultTag is the default app name the log messages are tagged with.
+	defaultTag = "litd"
+
+	// defaultFacility is the default syslog facility of the log messages.
+	defaultFacility = "daemon"
+)
+
+// Config holds all config options for the log sinks.
+type Config struct {
+	Sink                string   `long:"sink" description:"Where the log messages are written to. 'file' writes them to the log file and stdout. 'syslog' sends them to a syslog server in the RFC5424 format and 'journald' to the systemd journal instead of stdout. In remote lnd mode, no log file is written if a sink other than 'file' is selected." choice:"file" choice:"syslog" choice:"journald"`
+	Tag                 string   `long:"tag" description:"The app name the log messages are tagged with in syslog and the journal."`
+	Facility            string   `long:"facility" description:"The syslog facility of the log messages, for example daemon or local0."`
+	SubsystemFacilities []string `long:"subsystemfacility" description:"Overrides the syslog facility of the log messages of a subsystem, in the form <subsystem>=<facility>, for example FIRE=authpriv. Can be specified multiple times."`
+
+	Syslog *SyslogConfig `group:"syslog" namespace:"syslog"`
+}
+
+// SyslogConfig holds the options for sending the log messages to a syslog
+// server.
+type SyslogConfig struct {
+	Network string `long:"network" description:"The network of the syslog server. If empty, the messages are sent to the local syslog daemon." choice:"" choice:"unix" choice:"udp" choice:"tcp"`
+	Address string `long:"address" description:"The address of the syslog server, for example localhost:514 or /dev/log for the 'unix' network."`
+}
+
+// DefaultConfig constructs the default log sink Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		Sink:     SinkFile,
+		Tag:      defaultTag,
+		Facility: defaultFacility,
+		Syslog:   &SyslogConfig{},
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.Sink == SinkFile {
+		return nil
+	}
+
+	if c.Tag == "" {
+		return fmt.Errorf("the log tag must be set")
+	}
+
+	if _, err := c.facilities(); err != nil {
+		return err
+	}
+
+	if c.Sink == SinkSyslog && c.Syslog.Network != "" &&
+		c.Syslog.Address == "" {
+
+		return fmt.Errorf("the syslog address must be set for the %s "+
+			"network", c.Syslog.Network)
+	}
+
+	return nil
+}
+
+// facilities parses the configured facilities.
+func (c *Config) facilities() (*facilityMap, error) {
+	def, err := parseFacility(c.Facility)
+	if err != nil {
+		return nil, err
+	}
+
+	fm := &facilityMap{
+		def:        def,
+		subsystems: make(map[string]int, len(c.SubsystemFacilities)),
+	}
+	for _, override := range c.SubsystemFacilities {
+		subsystem, name, ok := strings.Cut(override, "=")
+		if !ok || subsystem == "" {
+			return nil, fmt.Errorf("invalid subsystem facility %q, "+
+				"expected <subsystem>=<facility>", override)
+		}
+
+		facility, err := parseFacility(name)
+		if err != nil {
+			return nil, err
+		}
+		fm.subsystems[strings.ToUpper(subsystem)] = facility
+	}
+
+	return fm, nil
+}
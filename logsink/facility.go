@@ -0,0 +1,83 @@
+package logsink
+
+import (
+	"fmt"
+	"strings"
+)
+
+// facilities maps the names of the syslog facilities to their codes as
+// defined in RFC5424.
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// severities maps the btclog levels to the syslog severities as defined in
+// RFC5424. Trace messages are sent with the debug severity, as syslog doesn't
+// know a lower one.
+var severities = map[string]int{
+	"CRT": 2,
+	"ERR": 3,
+	"WRN": 4,
+	"INF": 6,
+	"DBG": 7,
+	"TRC": 7,
+}
+
+// severityInfo is the severity of the lines that weren't written by btclog,
+// for example the output of a panic.
+const severityInfo = 6
+
+// parseFacility returns the code of the syslog facility with the given name.
+func parseFacility(name string) (int, error) {
+	facility, ok := facilities[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+
+	return facility, nil
+}
+
+// severity returns the syslog severity of the given btclog level.
+func severity(level string) int {
+	if s, ok := severities[level]; ok {
+		return s
+	}
+
+	return severityInfo
+}
+
+// facilityMap holds the syslog facility of the log messages of each
+// subsystem.
+type facilityMap struct {
+	def        int
+	subsystems map[string]int
+}
+
+// facility returns the syslog facility of the log messages of the given
+// subsystem.
+func (f *facilityMap) facility(subsystem string) int {
+	if facility, ok := f.subsystems[subsystem]; ok {
+		return facility
+	}
+
+	return f.def
+}
@@ -0,0 +1,126 @@
+package logsink
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/lightninglabs/lightning-terminal/status"
+)
+
+// Sink is a destination the log messages are sent to.
+type Sink interface {
+	// Send sends a single line of a log message.
+	Send(line *status.LogLine) error
+
+	// Close closes the connection to the sink.
+	Close() error
+}
+
+// New creates the sink of the given config.
+func New(cfg *Config) (Sink, error) {
+	facilities, err := cfg.facilities()
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Sink {
+	case SinkSyslog:
+		return newSyslogSink(cfg, facilities)
+
+	case SinkJournald:
+		return newJournaldSink(cfg, facilities)
+
+	default:
+		return nil, fmt.Errorf("unknown log sink %q", cfg.Sink)
+	}
+}
+
+// Forwarder forwards the log messages to a sink. The log backends of litd and
+// its integrated subservers write every message to stdout, so the forwarder
+// replaces stdout with a pipe and sends every line that is written to it to
+// the sink instead.
+type Forwarder struct {
+	sink Sink
+
+	stdout *os.File
+	reader *os.File
+	writer *os.File
+
+	wg sync.WaitGroup
+}
+
+// NewForwarder creates a new forwarder that sends the log messages to the
+// given sink.
+func NewForwarder(sink Sink) *Forwarder {
+	return &Forwarder{
+		sink: sink,
+	}
+}
+
+// Start replaces stdout and starts forwarding the lines written to it.
+func (f *Forwarder) Start() error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("unable to create log pipe: %v", err)
+	}
+
+	f.reader = reader
+	f.writer = writer
+	f.stdout = os.Stdout
+	os.Stdout = writer
+
+	f.wg.Add(1)
+	go f.run()
+
+	return nil
+}
+
+// Stop restores stdout, forwards the remaining lines and closes the sink.
+func (f *Forwarder) Stop() error {
+	os.Stdout = f.stdout
+	if err := f.writer.Close(); err != nil {
+		return err
+	}
+	f.wg.Wait()
+
+	if err := f.reader.Close(); err != nil {
+		return err
+	}
+
+	return f.sink.Close()
+}
+
+// run reads the lines written to stdout until the pipe is closed and sends
+// them to the sink.
+//
+// NOTE: This must be run as a goroutine.
+func (f *Forwarder) run() {
+	defer f.wg.Done()
+
+	var (
+		parser  status.LogParser
+		scanner = bufio.NewScanner(f.reader)
+	)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		err := f.sink.Send(parser.Parse(scanner.Text()))
+		if err != nil {
+			// There's no other place left to report the error, and
+			// the line itself shouldn't be lost.
+			_, _ = fmt.Fprintf(os.Stderr, "unable to forward "+
+				"log message: %v\n%s\n", err, scanner.Text())
+		}
+	}
+
+	// If a line can't be read, for example because it's too long, the
+	// remaining output is written to the original stdout, so that the
+	// writers don't block once the pipe is full.
+	if err := scanner.Err(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "unable to read log messages: "+
+			"%v\n", err)
+		_, _ = io.Copy(f.stdout, f.reader)
+	}
+}
@@ -0,0 +1,56 @@
+package logsink
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/lightninglabs/lightning-terminal/status"
+)
+
+// journaldSink sends the log messages to the systemd journal using its native
+// protocol, so the level and subsystem of each message are kept as fields.
+type journaldSink struct {
+	tag        string
+	facilities *facilityMap
+}
+
+// newJournaldSink creates a new sink that sends the log messages to the
+// systemd journal.
+func newJournaldSink(cfg *Config, facilities *facilityMap) (*journaldSink,
+	error) {
+
+	if !journal.Enabled() {
+		return nil, errors.New("the systemd journal is not available")
+	}
+
+	return &journaldSink{
+		tag:        cfg.Tag,
+		facilities: facilities,
+	}, nil
+}
+
+// Send sends the given log line to the journal.
+func (j *journaldSink) Send(line *status.LogLine) error {
+	vars := map[string]string{
+		"SYSLOG_IDENTIFIER": j.tag,
+		"SYSLOG_FACILITY": strconv.Itoa(
+			j.facilities.facility(line.Subsystem),
+		),
+	}
+	if line.Subsystem != "" {
+		vars["LIT_SUBSYSTEM"] = line.Subsystem
+	}
+
+	return journal.Send(
+		line.Message, journal.Priority(severity(line.Level)), vars,
+	)
+}
+
+// Close is a no-op as the journal connection is shared by the process.
+func (j *journaldSink) Close() error {
+	return nil
+}
+
+// A compile-time assertion that journaldSink is a Sink.
+var _ Sink = (*journaldSink)(nil)
@@ -0,0 +1,114 @@
+package logsink
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigValidate tests that the facilities of the subsystems are parsed
+// and invalid ones are rejected.
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Sink = SinkSyslog
+	cfg.SubsystemFacilities = []string{"fire=authpriv", "LITD=local3"}
+	require.NoError(t, cfg.Validate())
+
+	fm, err := cfg.facilities()
+	require.NoError(t, err)
+	require.Equal(t, 10, fm.facility("FIRE"))
+	require.Equal(t, 19, fm.facility("LITD"))
+	require.Equal(t, 3, fm.facility("LOOP"))
+
+	cfg.SubsystemFacilities = []string{"FIRE"}
+	require.ErrorContains(t, cfg.Validate(), "invalid subsystem facility")
+
+	cfg.SubsystemFacilities = []string{"FIRE=local9"}
+	require.ErrorContains(t, cfg.Validate(), "unknown syslog facility")
+
+	cfg.SubsystemFacilities = nil
+	cfg.Syslog.Network = "udp"
+	require.ErrorContains(t, cfg.Validate(), "address must be set")
+
+	// The options of the sinks are ignored if the log file is used.
+	cfg.Sink = SinkFile
+	require.NoError(t, cfg.Validate())
+}
+
+// TestFormatRFC5424 tests the formatting of syslog messages.
+func TestFormatRFC5424(t *testing.T) {
+	ts := time.Date(2023, 4, 1, 10, 0, 2, 123000000, time.UTC)
+
+	require.Equal(
+		t, "<27>1 2023-04-01T10:00:02.123000Z my-host litd 42 LOOP - "+
+			"Swap failed",
+		formatRFC5424(
+			3, 3, ts, "my-host", "litd", 42, "LOOP", "Swap failed",
+		),
+	)
+
+	// Empty and invalid header fields are replaced.
+	require.Equal(
+		t, "<134>1 - - my_app 1 - - panic",
+		formatRFC5424(16, 6, time.Time{}, "", "my app", 1, "", "panic"),
+	)
+}
+
+// TestSyslogForwarding tests that the lines written to stdout are forwarded
+// to a syslog server with the facility of their subsystem and the severity
+// of their level.
+func TestSyslogForwarding(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+	})
+
+	cfg := DefaultConfig()
+	cfg.Sink = SinkSyslog
+	cfg.SubsystemFacilities = []string{"FIRE=authpriv"}
+	cfg.Syslog.Network = "udp"
+	cfg.Syslog.Address = server.LocalAddr().String()
+	require.NoError(t, cfg.Validate())
+
+	sink, err := New(cfg)
+	require.NoError(t, err)
+
+	forwarder := NewForwarder(sink)
+	require.NoError(t, forwarder.Start())
+
+	_, err = fmt.Fprint(
+		os.Stdout, "2023-04-01 10:00:02.123 [ERR] LOOP: Swap failed\n"+
+			"goroutine 1 [running]:\n"+
+			"2023-04-01 10:00:03.000 [DBG] FIRE: Rule checked\n",
+	)
+	require.NoError(t, err)
+	require.NoError(t, forwarder.Stop())
+
+	messages := make([]string, 3)
+	buf := make([]byte, 1024)
+	for i := range messages {
+		require.NoError(
+			t, server.SetReadDeadline(time.Now().Add(5*time.Second)),
+		)
+		n, _, err := server.ReadFrom(buf)
+		require.NoError(t, err)
+		messages[i] = string(buf[:n])
+	}
+
+	// The continued line is sent with the level and subsystem of the
+	// message it belongs to.
+	require.True(t, strings.HasPrefix(messages[0], "<27>1 2023-04-01T"))
+	require.True(t, strings.HasSuffix(messages[0], " LOOP - Swap failed"))
+	require.True(t, strings.HasPrefix(messages[1], "<27>1 "))
+	require.True(t, strings.HasSuffix(
+		messages[1], " LOOP - goroutine 1 [running]:",
+	))
+	require.True(t, strings.HasPrefix(messages[2], "<87>1 "))
+	require.True(t, strings.HasSuffix(messages[2], " FIRE - Rule checked"))
+}
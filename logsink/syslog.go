@@ -0,0 +1,194 @@
+package logsink
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/status"
+)
+
+const (
+	// maxMsgIDLength is the maximum length of the MSGID field of a syslog
+	// message.
+	maxMsgIDLength = 32
+
+	// dialTimeout is the timeout for connecting to a syslog server.
+	dialTimeout = 5 * time.Second
+)
+
+// localSyslogPaths are the paths the socket of the local syslog daemon is
+// looked for at.
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// syslogSink sends the log messages to a syslog server in the RFC5424 format.
+type syslogSink struct {
+	network    string
+	address    string
+	tag        string
+	hostname   string
+	pid        int
+	facilities *facilityMap
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// newSyslogSink creates a new sink that sends the log messages to the syslog
+// server of the given config.
+func newSyslogSink(cfg *Config, facilities *facilityMap) (*syslogSink,
+	error) {
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	s := &syslogSink{
+		network:    cfg.Syslog.Network,
+		address:    cfg.Syslog.Address,
+		tag:        cfg.Tag,
+		hostname:   hostname,
+		pid:        os.Getpid(),
+		facilities: facilities,
+	}
+
+	// We connect right away, so a wrong address is reported on startup.
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// connect connects to the syslog server. The caller must hold the mutex if
+// the sink is already in use.
+func (s *syslogSink) connect() error {
+	var (
+		conn net.Conn
+		err  error
+	)
+	switch s.network {
+	case "":
+		for _, path := range localSyslogPaths {
+			conn, err = net.DialTimeout(
+				"unixgram", path, dialTimeout,
+			)
+			if err == nil {
+				break
+			}
+		}
+
+	case "unix":
+		conn, err = net.DialTimeout("unixgram", s.address, dialTimeout)
+
+	default:
+		conn, err = net.DialTimeout(s.network, s.address, dialTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to connect to syslog: %v", err)
+	}
+
+	s.conn = conn
+
+	return nil
+}
+
+// Send sends the given log line to the syslog server. If the connection was
+// lost, the sink reconnects once.
+func (s *syslogSink) Send(line *status.LogLine) error {
+	msg := s.format(line)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		_, err := s.conn.Write(msg)
+		if err == nil {
+			return nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+
+	if err := s.connect(); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(msg)
+
+	return err
+}
+
+// Close closes the connection to the syslog server.
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// format formats the given log line as an RFC5424 syslog message. Messages
+// sent over TCP are framed by their length as defined in RFC6587.
+func (s *syslogSink) format(line *status.LogLine) []byte {
+	msg := formatRFC5424(
+		s.facilities.facility(line.Subsystem), severity(line.Level),
+		line.Timestamp, s.hostname, s.tag, s.pid, line.Subsystem,
+		line.Message,
+	)
+
+	if s.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+
+	return []byte(msg)
+}
+
+// formatRFC5424 formats a syslog message as defined in RFC5424. The subsystem
+// of the log message is used as its MSGID.
+func formatRFC5424(facility, severity int, ts time.Time, hostname,
+	appName string, pid int, subsystem, msg string) string {
+
+	timestamp := "-"
+	if !ts.IsZero() {
+		timestamp = ts.Format("2006-01-02T15:04:05.000000Z07:00")
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s - %s",
+		facility*8+severity, timestamp, headerField(hostname, 255),
+		headerField(appName, 48), pid,
+		headerField(subsystem, maxMsgIDLength), msg)
+}
+
+// headerField returns the given value as a header field of a syslog message.
+// Header fields may only contain printable ASCII characters except spaces,
+// so other characters are replaced. Empty fields are replaced by the NILVALUE.
+func headerField(value string, maxLength int) string {
+	if value == "" {
+		return "-"
+	}
+
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+
+		return r
+	}, value)
+}
+
+// A compile-time assertion that syslogSink is a Sink.
+var _ Sink = (*syslogSink)(nil)
@@ -0,0 +1,424 @@
+package terminal
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/firewall"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+// featurePermsFunc returns the methods each autopilot feature may call, keyed
+// by the feature name.
+type featurePermsFunc func(ctx context.Context) (map[string]map[string]bool,
+	error)
+
+// macaroonInspector determines which methods a macaroon can call through litd.
+// It combines the permissions of the macaroon with the checks of the daemon
+// that validates it and the restrictions that the RPC middleware applies to
+// lnd calls because of its custom caveats.
+type macaroonInspector struct {
+	cfg      *Config
+	permsMgr *perms.Manager
+
+	// validator validates the macaroons of the daemons that run in the
+	// litd process.
+	validator macaroons.MacaroonValidator
+
+	// lndValidator validates macaroons that were baked by lnd, which
+	// includes the super macaroons.
+	lndValidator session.SuperMacaroonValidator
+
+	accounts         *accounts.InterceptorService
+	firewallSettings *firewall.SettingsManager
+
+	// featurePerms is nil if the autopilot is disabled, in which case no
+	// rules are enforced.
+	featurePerms featurePermsFunc
+}
+
+// macaroonState is what an inspection learned about a macaroon that applies
+// to all methods.
+type macaroonState struct {
+	mac      *macaroon.Macaroon
+	macBytes []byte
+	super    bool
+	granted  map[string]bool
+
+	expiry time.Time
+
+	// accountErr is set if the macaroon is locked to an account that
+	// doesn't exist or has expired.
+	account    *accounts.AccountID
+	accountErr error
+
+	privacy bool
+	rules   *firewall.InterceptRules
+
+	// blockOnChain is set if the macaroon may not spend on-chain funds.
+	blockOnChain bool
+
+	// verifyErrs caches the result of validating the macaroon with each
+	// daemon, keyed by the daemon name.
+	verifyErrs map[string]error
+}
+
+// inspect returns the methods the macaroon of the given request can call
+// through litd, together with the permissions and restrictions of the
+// macaroon.
+func (i *macaroonInspector) inspect(ctx context.Context,
+	req *litrpc.InspectMacaroonRequest) (*litrpc.InspectMacaroonResponse,
+	error) {
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(req.Macaroon); err != nil {
+		return nil, fmt.Errorf("error parsing macaroon: %v", err)
+	}
+
+	ops, err := macaroonOps(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	macHex := hex.EncodeToString(req.Macaroon)
+	state := &macaroonState{
+		mac:        mac,
+		macBytes:   req.Macaroon,
+		super:      session.IsSuperMacaroon(macHex),
+		granted:    make(map[string]bool),
+		verifyErrs: make(map[string]error),
+	}
+
+	grantedURIs := i.permsMgr.GrantedURIs(ops)
+	for _, uri := range grantedURIs {
+		state.granted[uri] = true
+	}
+
+	resp := &litrpc.InspectMacaroonResponse{
+		Permissions: make([]string, len(ops)),
+	}
+	for idx, op := range ops {
+		resp.Permissions[idx] = fmt.Sprintf("%s:%s", op.Entity,
+			op.Action)
+	}
+	sort.Strings(resp.Permissions)
+
+	if err := i.parseCaveats(state, resp); err != nil {
+		return nil, err
+	}
+
+	// Without a method, all methods that the permissions grant are
+	// checked. A single method is returned even if it isn't granted, so
+	// that the reason can be shown.
+	uris := grantedURIs
+	if req.Method != "" {
+		if _, ok := i.permsMgr.URIPermissions(req.Method); !ok {
+			return nil, fmt.Errorf("unknown method %s", req.Method)
+		}
+
+		uris = []string{req.Method}
+	}
+
+	settings := i.firewallSettings.Settings()
+	for _, uri := range uris {
+		method := i.checkMethod(ctx, state, settings, uri)
+		if !method.Allowed && !req.IncludeDenied && req.Method == "" {
+			continue
+		}
+
+		resp.Methods = append(resp.Methods, method)
+	}
+
+	return resp, nil
+}
+
+// parseCaveats collects the restrictions of the macaroon's caveats.
+func (i *macaroonInspector) parseCaveats(state *macaroonState,
+	resp *litrpc.InspectMacaroonResponse) error {
+
+	customCaveats := make(map[string]bool)
+	for _, caveat := range state.mac.Caveats() {
+		condition := string(caveat.Id)
+		name, arg, _ := strings.Cut(condition, " ")
+
+		switch name {
+		case checkers.CondTimeBefore:
+			expiry, err := time.Parse(time.RFC3339Nano, arg)
+			if err != nil {
+				return fmt.Errorf("invalid expiry caveat %s: "+
+					"%v", condition, err)
+			}
+
+			// The earliest expiry is the one that applies.
+			if state.expiry.IsZero() ||
+				expiry.Before(state.expiry) {
+
+				state.expiry = expiry
+			}
+
+		case ipLockCondition:
+			resp.IpAddress = arg
+
+		case macaroons.CondLndCustom:
+			customName, _, _ := strings.Cut(arg, " ")
+			customCaveats[customName] = true
+
+			if firewall.IsPrivacyCaveat(condition) {
+				state.privacy = true
+			}
+
+			if customName == firewall.CondBlockOnChainSpend {
+				state.blockOnChain = true
+			}
+
+			rules, err := firewall.ParseRuleCaveat(condition)
+			if err == nil {
+				state.rules = rules
+			}
+		}
+	}
+
+	if !state.expiry.IsZero() {
+		resp.Expiry = state.expiry.Unix()
+	}
+
+	for name := range customCaveats {
+		resp.CustomCaveats = append(resp.CustomCaveats, name)
+	}
+	sort.Strings(resp.CustomCaveats)
+
+	account, err := accounts.AccountFromMacaroon(state.mac)
+	if err != nil {
+		return fmt.Errorf("error parsing account from macaroon: %v",
+			err)
+	}
+	if account == nil {
+		return nil
+	}
+
+	state.account = account
+	resp.AccountId = hex.EncodeToString(account[:])
+
+	acct, err := i.accounts.Account(*account)
+	switch {
+	case err != nil:
+		state.accountErr = fmt.Errorf("error getting account %x: %v",
+			account[:], err)
+
+	case acct.HasExpired():
+		state.accountErr = fmt.Errorf("account %x has expired",
+			account[:])
+	}
+
+	return nil
+}
+
+// checkMethod checks whether the macaroon can call the method with the given
+// URI and why not.
+func (i *macaroonInspector) checkMethod(ctx context.Context,
+	state *macaroonState, settings firewall.Settings,
+	uri string) *litrpc.MacaroonMethod {
+
+	method := &litrpc.MacaroonMethod{
+		Method:   uri,
+		Verified: true,
+	}
+	deny := func(reason string) *litrpc.MacaroonMethod {
+		method.Reason = reason
+		return method
+	}
+
+	required, _ := i.permsMgr.URIPermissions(uri)
+	if !state.granted[uri] {
+		return deny(fmt.Sprintf("macaroon lacks the permissions %s",
+			formatOps(required)))
+	}
+
+	if !state.expiry.IsZero() && time.Now().After(state.expiry) {
+		return deny(fmt.Sprintf("macaroon expired at %v",
+			state.expiry.UTC().Format(time.RFC3339)))
+	}
+
+	verified, err := i.verify(ctx, state, uri, required)
+	method.Verified = verified
+	if err != nil {
+		return deny(err.Error())
+	}
+
+	// The RPC middleware only intercepts the calls to lnd.
+	if !i.permsMgr.IsLndURI(uri) {
+		method.Allowed = true
+		return method
+	}
+
+	if state.account != nil {
+		if state.accountErr != nil {
+			return deny(state.accountErr.Error())
+		}
+
+		if !i.accounts.SupportsURI(uri) {
+			return deny(
+				accounts.ErrNotSupportedWithAccounts.Error(),
+			)
+		}
+	}
+
+	if state.privacy && settings.PrivacyMapper &&
+		!firewall.PrivacyMapperSupportsURI(uri) {
+
+		return deny(firewall.ErrNotSupportedByPrivacyMapper.Error())
+	}
+
+	spendsOnChain := firewall.SpendsOnChain(uri, i.permsMgr.URIPermissions)
+	if state.blockOnChain && spendsOnChain {
+		return deny(firewall.ErrOnChainSpendBlocked.Error())
+	}
+
+	if state.rules != nil && settings.RuleEnforcement &&
+		i.featurePerms != nil {
+
+		allowed, err := i.allowedByFeatures(ctx, state.rules, uri)
+		if err != nil {
+			return deny(err.Error())
+		}
+		if !allowed {
+			return deny(fmt.Sprintf("method %s is not allowed for "+
+				"any feature of the session", uri))
+		}
+	}
+
+	method.Allowed = true
+	return method
+}
+
+// verify validates the macaroon with the daemon that serves the method with
+// the given URI. The result is cached per daemon, as the signature and the
+// caveats of a macaroon are checked the same way for all methods of a daemon.
+// False is returned if the macaroon can't be verified by litd because the
+// daemon runs remotely.
+func (i *macaroonInspector) verify(ctx context.Context, state *macaroonState,
+	uri string, required []bakery.Op) (bool, error) {
+
+	var (
+		daemon string
+		remote bool
+	)
+	switch {
+	case i.permsMgr.IsLndURI(uri):
+		daemon = "lnd"
+
+	case i.permsMgr.IsFaradayURI(uri):
+		daemon, remote = "faraday", i.cfg.faradayRemote
+
+	case i.permsMgr.IsLoopURI(uri):
+		daemon, remote = "loop", i.cfg.loopRemote
+
+	case i.permsMgr.IsPoolURI(uri):
+		daemon, remote = "pool", i.cfg.poolRemote
+
+	default:
+		daemon = "lit"
+	}
+
+	// Remote daemons check their own macaroons, only the super macaroons
+	// are converted by litd after checking them with lnd.
+	if remote && !state.super {
+		return false, nil
+	}
+
+	if err, ok := state.verifyErrs[daemon]; ok {
+		return true, err
+	}
+
+	var err error
+	if daemon == "lnd" || state.super {
+		err = i.lndValidator(ctx, state.macBytes, required, uri)
+	} else {
+		macCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(
+			HeaderMacaroon, hex.EncodeToString(state.macBytes),
+		))
+		err = i.validator.ValidateMacaroon(macCtx, required, uri)
+	}
+	state.verifyErrs[daemon] = err
+
+	return true, err
+}
+
+// allowedByFeatures returns true if any of the features the rules apply to
+// may call the method with the given URI. If the rules don't name any
+// feature, all features known to the autopilot are considered.
+func (i *macaroonInspector) allowedByFeatures(ctx context.Context,
+	rules *firewall.InterceptRules, uri string) (bool, error) {
+
+	featurePerms, err := i.featurePerms(ctx)
+	if err != nil {
+		return false, fmt.Errorf("unable to get feature permissions: "+
+			"%v", err)
+	}
+
+	for feature, perms := range featurePerms {
+		_, ok := rules.FeatureRules[feature]
+		if len(rules.FeatureRules) != 0 && !ok {
+			continue
+		}
+
+		if perms[uri] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// macaroonOps returns the permissions that are encoded in the ID of the given
+// macaroon.
+func macaroonOps(mac *macaroon.Macaroon) ([]bakery.Op, error) {
+	rawID := mac.Id()
+	if len(rawID) == 0 || rawID[0] != byte(bakery.LatestVersion) {
+		return nil, errors.New("macaroon ID is not on the latest " +
+			"version")
+	}
+
+	decodedID := &lnrpc.MacaroonId{}
+	if err := proto.Unmarshal(rawID[1:], decodedID); err != nil {
+		return nil, fmt.Errorf("error decoding macaroon ID: %v", err)
+	}
+
+	var ops []bakery.Op
+	for _, op := range decodedID.Ops {
+		for _, action := range op.Actions {
+			ops = append(ops, bakery.Op{
+				Entity: op.Entity,
+				Action: action,
+			})
+		}
+	}
+
+	return ops, nil
+}
+
+// formatOps formats the given permissions as a comma separated list of
+// entity:action pairs.
+func formatOps(ops []bakery.Op) string {
+	formatted := make([]string, len(ops))
+	for idx, op := range ops {
+		formatted[idx] = fmt.Sprintf("%s:%s", op.Entity, op.Action)
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,231 @@
+package maccache
+
+import (
+	"context"
+	"crypto/sha256"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/session"
+	"google.golang.org/grpc/peer"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+)
+
+// key identifies a cached verification result.
+type key struct {
+	// macHash is the hash of the hex encoded macaroon.
+	macHash [sha256.Size]byte
+
+	// method is the full URI of the RPC method the macaroon was verified
+	// for.
+	method string
+
+	// peerHost is the host of the caller. It is part of the key because
+	// a macaroon can be locked to an IP address with a caveat.
+	peerHost string
+}
+
+// entry is a cached successful verification.
+type entry struct {
+	// rootKeyID is the root key ID of the macaroon, used to remove the
+	// entry if the root key is revoked.
+	rootKeyID uint64
+
+	// expiry is the time after which the entry must no longer be used.
+	expiry time.Time
+}
+
+// Cache caches successful macaroon verifications per macaroon, RPC method and
+// caller, so that the signature and caveats of a macaroon and the database
+// lookups of its root key don't need to be repeated for every request. Only
+// successful verifications are cached, a failed verification is always
+// repeated. A nil Cache or one with a TTL of zero caches nothing.
+type Cache struct {
+	cfg *Config
+
+	// timeNow returns the current time, it can be overwritten in tests.
+	timeNow func() time.Time
+
+	mu      sync.Mutex
+	entries map[key]*entry
+}
+
+// NewCache creates a new macaroon verification cache.
+func NewCache(cfg *Config) *Cache {
+	return &Cache{
+		cfg:     cfg,
+		timeNow: time.Now,
+		entries: make(map[key]*entry),
+	}
+}
+
+// enabled returns true if verifications should be cached.
+func (c *Cache) enabled() bool {
+	return c != nil && c.cfg.TTL > 0
+}
+
+// Verified returns true if the given hex encoded macaroon was successfully
+// verified for the given method and the caller of the given context within the
+// TTL.
+func (c *Cache) Verified(ctx context.Context, macHex, method string) bool {
+	if !c.enabled() {
+		return false
+	}
+
+	k := newKey(ctx, macHex, method)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return false
+	}
+
+	if !c.timeNow().Before(e.expiry) {
+		delete(c.entries, k)
+		return false
+	}
+
+	return true
+}
+
+// Add caches the successful verification of the given hex encoded macaroon
+// for the given method and the caller of the given context. The entry expires
+// after the TTL or when a time-before caveat of the macaroon expires, whatever
+// happens first. Macaroons that can't be parsed aren't cached.
+func (c *Cache) Add(ctx context.Context, macHex, method string) {
+	if !c.enabled() {
+		return
+	}
+
+	mac, err := session.ParseMacaroon(macHex)
+	if err != nil || len(mac.Id()) == 0 {
+		return
+	}
+
+	rootKeyID, err := session.RootKeyIDFromMacaroon(mac)
+	if err != nil {
+		return
+	}
+
+	now := c.timeNow()
+	expiry := now.Add(c.cfg.TTL)
+	for _, caveat := range mac.Caveats() {
+		// We don't know when a third party caveat becomes invalid, so
+		// we don't cache such macaroons at all.
+		if len(caveat.VerificationId) > 0 {
+			return
+		}
+
+		cond, arg, err := checkers.ParseCaveat(string(caveat.Id))
+		if err != nil || cond != checkers.CondTimeBefore {
+			continue
+		}
+
+		timeBefore, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			return
+		}
+		if timeBefore.Before(expiry) {
+			expiry = timeBefore
+		}
+	}
+
+	if !now.Before(expiry) {
+		return
+	}
+
+	k := newKey(ctx, macHex, method)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[k]; !ok && len(c.entries) >= c.cfg.MaxEntries {
+		c.evict(now)
+	}
+
+	c.entries[k] = &entry{
+		rootKeyID: rootKeyID,
+		expiry:    expiry,
+	}
+}
+
+// evict makes room for a new entry by removing all expired entries. If none
+// have expired, an arbitrary entry is removed.
+//
+// NOTE: The mutex must be held when calling this method.
+func (c *Cache) evict(now time.Time) {
+	for k, e := range c.entries {
+		if !now.Before(e.expiry) {
+			delete(c.entries, k)
+		}
+	}
+
+	for k := range c.entries {
+		if len(c.entries) < c.cfg.MaxEntries {
+			return
+		}
+
+		delete(c.entries, k)
+	}
+}
+
+// RemoveRootKey removes all cached verifications of macaroons with the given
+// root key ID. It must be called when the root key is revoked.
+func (c *Cache) RemoveRootKey(rootKeyID uint64) {
+	if !c.enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var removed int
+	for k, e := range c.entries {
+		if e.rootKeyID == rootKeyID {
+			delete(c.entries, k)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		log.Debugf("Removed %d cached verifications of root key %d",
+			removed, rootKeyID)
+	}
+}
+
+// Purge removes all cached verifications. It must be called if macaroons
+// were revoked and it isn't known which root keys were affected.
+func (c *Cache) Purge() {
+	if !c.enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	log.Debugf("Purging %d cached macaroon verifications", len(c.entries))
+
+	c.entries = make(map[key]*entry)
+}
+
+// newKey creates the cache key of the given macaroon, method and the caller
+// of the given context.
+func newKey(ctx context.Context, macHex, method string) key {
+	k := key{
+		macHash: sha256.Sum256([]byte(macHex)),
+		method:  method,
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		k.peerHost = host
+	}
+
+	return k
+}
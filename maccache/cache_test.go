@@ -0,0 +1,153 @@
+package maccache
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+)
+
+const testMethod = "/litrpc.Sessions/ListSessions"
+
+// newTestMacaroon bakes a hex encoded macaroon with the given root key ID and
+// first party caveats.
+func newTestMacaroon(t *testing.T, rootKeyID uint64,
+	caveats ...checkers.Caveat) string {
+
+	idProto, err := proto.Marshal(&lnrpc.MacaroonId{
+		StorageId: []byte(strconv.FormatUint(rootKeyID, 10)),
+	})
+	require.NoError(t, err)
+
+	rawID := make([]byte, len(idProto)+1)
+	rawID[0] = byte(bakery.LatestVersion)
+	copy(rawID[1:], idProto)
+
+	mac, err := macaroon.New(
+		[]byte("root-key"), rawID, "lnd", macaroon.LatestVersion,
+	)
+	require.NoError(t, err)
+
+	for _, caveat := range caveats {
+		require.NoError(t, mac.AddFirstPartyCaveat(
+			[]byte(caveat.Condition),
+		))
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	require.NoError(t, err)
+
+	return hex.EncodeToString(macBytes)
+}
+
+// peerCtx returns a context with the given caller IP address.
+func peerCtx(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+	})
+}
+
+// TestCache tests that verifications are cached per macaroon, method and
+// caller until they expire.
+func TestCache(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	c := NewCache(&Config{TTL: time.Minute, MaxEntries: 10})
+	c.timeNow = func() time.Time {
+		return now
+	}
+
+	ctx := peerCtx("10.0.0.1")
+	mac := newTestMacaroon(t, 1)
+
+	require.False(t, c.Verified(ctx, mac, testMethod))
+	c.Add(ctx, mac, testMethod)
+	require.True(t, c.Verified(ctx, mac, testMethod))
+
+	// Other methods, macaroons and callers aren't covered by the entry.
+	require.False(t, c.Verified(ctx, mac, "/litrpc.Sessions/AddSession"))
+	require.False(t, c.Verified(ctx, newTestMacaroon(t, 2), testMethod))
+	require.False(t, c.Verified(peerCtx("10.0.0.2"), mac, testMethod))
+
+	// The entry expires after the TTL.
+	now = now.Add(time.Minute)
+	require.False(t, c.Verified(ctx, mac, testMethod))
+	require.Empty(t, c.entries)
+}
+
+// TestCacheTimeBefore tests that an entry expires with the time-before caveat
+// of its macaroon if that is earlier than the TTL.
+func TestCacheTimeBefore(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	c := NewCache(&Config{TTL: time.Minute, MaxEntries: 10})
+	c.timeNow = func() time.Time {
+		return now
+	}
+
+	ctx := peerCtx("10.0.0.1")
+	mac := newTestMacaroon(
+		t, 1, checkers.TimeBeforeCaveat(now.Add(10*time.Second)),
+	)
+
+	c.Add(ctx, mac, testMethod)
+	require.True(t, c.Verified(ctx, mac, testMethod))
+
+	now = now.Add(10 * time.Second)
+	require.False(t, c.Verified(ctx, mac, testMethod))
+
+	// A macaroon that already expired isn't cached at all.
+	c.Add(ctx, mac, testMethod)
+	require.Empty(t, c.entries)
+}
+
+// TestCacheInvalidation tests that entries are removed by root key and that
+// the cache can be purged.
+func TestCacheInvalidation(t *testing.T) {
+	c := NewCache(&Config{TTL: time.Minute, MaxEntries: 10})
+	ctx := peerCtx("10.0.0.1")
+
+	mac1 := newTestMacaroon(t, 1)
+	mac2 := newTestMacaroon(t, 2)
+	c.Add(ctx, mac1, testMethod)
+	c.Add(ctx, mac2, testMethod)
+
+	c.RemoveRootKey(1)
+	require.False(t, c.Verified(ctx, mac1, testMethod))
+	require.True(t, c.Verified(ctx, mac2, testMethod))
+
+	c.Purge()
+	require.False(t, c.Verified(ctx, mac2, testMethod))
+}
+
+// TestCacheLimits tests that the number of entries is limited and that a
+// disabled or nil cache caches nothing.
+func TestCacheLimits(t *testing.T) {
+	c := NewCache(&Config{TTL: time.Minute, MaxEntries: 2})
+	ctx := peerCtx("10.0.0.1")
+
+	for i := uint64(1); i <= 5; i++ {
+		c.Add(ctx, newTestMacaroon(t, i), testMethod)
+	}
+	require.Len(t, c.entries, 2)
+
+	mac := newTestMacaroon(t, 1)
+
+	disabled := NewCache(&Config{})
+	disabled.Add(ctx, mac, testMethod)
+	require.False(t, disabled.Verified(ctx, mac, testMethod))
+
+	var nilCache *Cache
+	nilCache.Add(ctx, mac, testMethod)
+	require.False(t, nilCache.Verified(ctx, mac, testMethod))
+	nilCache.RemoveRootKey(1)
+	nilCache.Purge()
+}
@@ -0,0 +1,49 @@
+package maccache
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultTTL is the default time a successful macaroon verification
+	// is cached for. The cache is disabled by default, as revocations
+	// that litd doesn't see only take effect once the TTL passed.
+	defaultTTL = 0
+
+	// defaultMaxEntries is the default maximum number of cached
+	// verification results.
+	defaultMaxEntries = 10_000
+)
+
+// Config holds all config options for the macaroon verification cache.
+type Config struct {
+	TTL        time.Duration `long:"ttl" description:"The time a successful macaroon verification is cached for per macaroon and RPC method. A revoked session is removed from the cache right away, other revocations take effect after this time at the latest. Set to 0 to disable the cache, which is the default."`
+	MaxEntries int           `long:"maxentries" description:"The maximum number of cached macaroon verification results."`
+}
+
+// DefaultConfig constructs the default macaroon cache Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		TTL:        defaultTTL,
+		MaxEntries: defaultMaxEntries,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.TTL == 0 {
+		return nil
+	}
+
+	if c.TTL < 0 {
+		return fmt.Errorf("the macaroon cache TTL must not be negative")
+	}
+
+	if c.MaxEntries <= 0 {
+		return fmt.Errorf("the macaroon cache must hold at least one " +
+			"entry")
+	}
+
+	return nil
+}
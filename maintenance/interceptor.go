@@ -0,0 +1,55 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor is a gRPC unary interceptor that rejects all calls
+// while a maintenance window is active. It is installed on the gRPC servers of
+// the LNC sessions.
+func (s *Scheduler) UnaryServerInterceptor(ctx context.Context,
+	req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	if window, ok := s.Active(); ok {
+		log.Debugf("Rejecting session call to %s", info.FullMethod)
+
+		return nil, maintenanceError(&window)
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor is a gRPC stream interceptor that rejects all calls
+// while a maintenance window is active. It is installed on the gRPC servers of
+// the LNC sessions.
+func (s *Scheduler) StreamServerInterceptor(srv interface{},
+	ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if window, ok := s.Active(); ok {
+		log.Debugf("Rejecting session call to %s", info.FullMethod)
+
+		return maintenanceError(&window)
+	}
+
+	return handler(srv, ss)
+}
+
+// maintenanceError returns the structured error the calls are rejected with
+// during the given maintenance window.
+func maintenanceError(window *Window) error {
+	msg := fmt.Sprintf("node in maintenance until %v",
+		window.End.UTC().Format("2006-01-02 15:04:05 MST"))
+	if window.Reason != "" {
+		msg += ": " + window.Reason
+	}
+
+	return litrpc.NodeMaintenanceError(
+		msg, window.ID, window.Reason, window.End.Unix(),
+	)
+}
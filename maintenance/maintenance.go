@@ -0,0 +1,377 @@
+package maintenance
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotStarted is returned if a maintenance window is changed before
+	// the scheduler was started.
+	ErrNotStarted = errors.New("maintenance scheduler not started")
+
+	// ErrWindowNotFound is returned if a maintenance window doesn't exist.
+	ErrWindowNotFound = errors.New("maintenance window not found")
+
+	// ErrOverlap is returned if a maintenance window overlaps with one that
+	// is already scheduled.
+	ErrOverlap = errors.New("maintenance window overlaps with a " +
+		"scheduled window")
+)
+
+// Window is a period of time during which the node is in maintenance.
+type Window struct {
+	// ID is the unique ID of the window.
+	ID string `json:"id"`
+
+	// Start is the time the window starts.
+	Start time.Time `json:"start"`
+
+	// End is the time the window ends.
+	End time.Time `json:"end"`
+
+	// Reason is why the node is in maintenance during the window.
+	Reason string `json:"reason"`
+}
+
+// ActiveAt returns true if the given time lies within the window.
+func (w *Window) ActiveAt(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Scheduler keeps track of the scheduled maintenance windows. While a window
+// is active, all calls of LNC sessions are rejected so that an operator can
+// work on the node without the sessions' clients running into confusing
+// errors. The scheduler enters and leaves the windows automatically.
+type Scheduler struct {
+	dir string
+
+	// mu guards the store and the windows.
+	mu      sync.RWMutex
+	store   *Store
+	windows map[string]Window
+
+	// update is signaled whenever the windows change, so that the
+	// scheduler re-computes when it has to wake up next.
+	update chan struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new maintenance scheduler that stores the windows in
+// the given directory.
+func NewScheduler(dir string) *Scheduler {
+	return &Scheduler{
+		dir:     dir,
+		windows: make(map[string]Window),
+		update:  make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+	}
+}
+
+// Start opens the maintenance store, restores the windows that haven't ended
+// yet and starts entering and leaving them automatically.
+func (s *Scheduler) Start() error {
+	store, err := NewStore(s.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open maintenance store: %v", err)
+	}
+
+	windows, err := store.Windows()
+	if err != nil {
+		_ = store.Close()
+		return fmt.Errorf("unable to read maintenance windows: %v", err)
+	}
+
+	s.mu.Lock()
+	s.store = store
+	for _, window := range windows {
+		s.windows[window.ID] = window
+	}
+	s.pruneEnded(time.Now())
+	s.mu.Unlock()
+
+	if window, ok := s.Active(); ok {
+		log.Warnf("Node in maintenance until %v (reason: %q), "+
+			"rejecting all LNC session calls", window.End,
+			window.Reason)
+	}
+
+	s.wg.Add(1)
+	go s.run()
+
+	return nil
+}
+
+// Stop stops the scheduler and closes the maintenance store.
+func (s *Scheduler) Stop() error {
+	close(s.quit)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store == nil {
+		return nil
+	}
+
+	err := s.store.Close()
+	s.store = nil
+
+	return err
+}
+
+// Schedule schedules a new maintenance window for the given period. If the
+// start is zero, the window starts right away.
+func (s *Scheduler) Schedule(start, end time.Time,
+	reason string) (Window, error) {
+
+	now := time.Now()
+	if start.IsZero() {
+		start = now
+	}
+
+	switch {
+	case !end.After(start):
+		return Window{}, errors.New("the end of a maintenance window " +
+			"must be after its start")
+
+	case !end.After(now):
+		return Window{}, errors.New("the end of a maintenance window " +
+			"must be in the future")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Window{}, err
+	}
+
+	window := Window{
+		ID:     id,
+		Start:  start,
+		End:    end,
+		Reason: reason,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store == nil {
+		return Window{}, ErrNotStarted
+	}
+
+	for _, other := range s.windows {
+		if window.Start.Before(other.End) &&
+			other.Start.Before(window.End) {
+
+			return Window{}, fmt.Errorf("%w %s", ErrOverlap,
+				other.ID)
+		}
+	}
+
+	if err := s.store.AddWindow(&window); err != nil {
+		return Window{}, fmt.Errorf("unable to store maintenance "+
+			"window: %v", err)
+	}
+	s.windows[window.ID] = window
+
+	log.Infof("Scheduled maintenance window %s from %v to %v "+
+		"(reason: %q)", window.ID, window.Start, window.End, reason)
+
+	s.notify()
+
+	return window, nil
+}
+
+// Cancel cancels the maintenance window with the given ID. If the window is
+// active, the node leaves maintenance right away.
+func (s *Scheduler) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store == nil {
+		return ErrNotStarted
+	}
+
+	window, ok := s.windows[id]
+	if !ok {
+		return ErrWindowNotFound
+	}
+
+	if err := s.store.DeleteWindows(id); err != nil {
+		return fmt.Errorf("unable to delete maintenance window: %v",
+			err)
+	}
+	delete(s.windows, id)
+
+	if window.ActiveAt(time.Now()) {
+		log.Infof("Maintenance window %s cancelled, leaving "+
+			"maintenance", id)
+	} else {
+		log.Infof("Maintenance window %s cancelled", id)
+	}
+
+	s.notify()
+
+	return nil
+}
+
+// Windows returns the active and upcoming maintenance windows, ordered by
+// their start.
+func (s *Scheduler) Windows() []Window {
+	now := time.Now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	windows := make([]Window, 0, len(s.windows))
+	for _, window := range s.windows {
+		if !window.End.After(now) {
+			continue
+		}
+
+		windows = append(windows, window)
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+
+	return windows
+}
+
+// Active returns the maintenance window that is currently active, if any.
+func (s *Scheduler) Active() (Window, bool) {
+	return s.activeAt(time.Now())
+}
+
+// activeAt returns the maintenance window that is active at the given time,
+// if any.
+func (s *Scheduler) activeAt(t time.Time) (Window, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, window := range s.windows {
+		if window.ActiveAt(t) {
+			return window, true
+		}
+	}
+
+	return Window{}, false
+}
+
+// run enters and leaves the maintenance windows at their start and end and
+// removes the windows that ended.
+//
+// NOTE: This must be run as a goroutine.
+func (s *Scheduler) run() {
+	defer s.wg.Done()
+
+	active, _ := s.Active()
+	for {
+		// The rejection of the calls only depends on the windows and
+		// the current time, so waking up at the next boundary is only
+		// needed to log the transition and to clean up.
+		timer := time.NewTimer(s.nextBoundary(time.Now()))
+
+		select {
+		case <-timer.C:
+
+		case <-s.update:
+			timer.Stop()
+
+		case <-s.quit:
+			timer.Stop()
+			return
+		}
+
+		now := time.Now()
+		current, ok := s.activeAt(now)
+		switch {
+		case ok && current.ID != active.ID:
+			log.Warnf("Entering maintenance window %s until %v "+
+				"(reason: %q), rejecting all LNC session calls",
+				current.ID, current.End, current.Reason)
+
+		case !ok && active.ID != "":
+			log.Infof("Leaving maintenance window %s", active.ID)
+		}
+		active = current
+
+		s.mu.Lock()
+		s.pruneEnded(now)
+		s.mu.Unlock()
+	}
+}
+
+// nextBoundary returns the duration until the next start or end of a
+// maintenance window after the given time. If there is none, a long duration
+// is returned as the scheduler is woken up whenever the windows change.
+func (s *Scheduler) nextBoundary(now time.Time) time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	next := time.Duration(1<<63 - 1)
+	for _, window := range s.windows {
+		for _, t := range []time.Time{window.Start, window.End} {
+			if d := t.Sub(now); d > 0 && d < next {
+				next = d
+			}
+		}
+	}
+
+	return next
+}
+
+// pruneEnded removes the maintenance windows that ended before the given
+// time. The caller must hold the mutex.
+func (s *Scheduler) pruneEnded(now time.Time) {
+	if s.store == nil {
+		return
+	}
+
+	var ended []string
+	for id, window := range s.windows {
+		if !window.End.After(now) {
+			ended = append(ended, id)
+		}
+	}
+
+	if len(ended) == 0 {
+		return
+	}
+
+	if err := s.store.DeleteWindows(ended...); err != nil {
+		log.Errorf("Unable to delete ended maintenance windows: %v",
+			err)
+		return
+	}
+
+	for _, id := range ended {
+		delete(s.windows, id)
+	}
+}
+
+// notify wakes up the scheduler to re-compute when it has to wake up next.
+func (s *Scheduler) notify() {
+	select {
+	case s.update <- struct{}{}:
+	default:
+	}
+}
+
+// newID returns a new random window ID.
+func newID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("unable to generate window ID: %v", err)
+	}
+
+	return hex.EncodeToString(b[:]), nil
+}
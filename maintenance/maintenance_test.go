@@ -0,0 +1,141 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestMaintenance tests that maintenance windows reject the calls of LNC
+// sessions while they are active and survive a restart.
+func TestMaintenance(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	scheduler := NewScheduler(dir)
+
+	now := time.Now()
+	_, err := scheduler.Schedule(time.Time{}, now.Add(time.Hour), "")
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, scheduler.Start())
+
+	unaryCalls := 0
+	unary := func() error {
+		_, err := scheduler.UnaryServerInterceptor(
+			ctx, nil, &grpc.UnaryServerInfo{},
+			func(context.Context, interface{}) (interface{},
+				error) {
+
+				unaryCalls++
+				return nil, nil
+			},
+		)
+		return err
+	}
+
+	// Without an active window, all calls are handed on.
+	require.NoError(t, unary())
+	require.Equal(t, 1, unaryCalls)
+
+	// Invalid windows are rejected.
+	_, err = scheduler.Schedule(now, now, "")
+	require.Error(t, err)
+	_, err = scheduler.Schedule(
+		now.Add(-2*time.Hour), now.Add(-time.Hour), "",
+	)
+	require.Error(t, err)
+
+	// An upcoming window doesn't reject any calls yet.
+	upcoming, err := scheduler.Schedule(
+		now.Add(time.Hour), now.Add(2*time.Hour), "upgrade",
+	)
+	require.NoError(t, err)
+	require.NoError(t, unary())
+	require.Equal(t, 2, unaryCalls)
+
+	_, ok := scheduler.activeAt(now.Add(90 * time.Minute))
+	require.True(t, ok)
+
+	// Overlapping windows are rejected.
+	_, err = scheduler.Schedule(
+		now.Add(90*time.Minute), now.Add(3*time.Hour), "",
+	)
+	require.ErrorIs(t, err, ErrOverlap)
+
+	// A window that starts right away rejects the calls with the
+	// structured maintenance error.
+	active, err := scheduler.Schedule(
+		time.Time{}, now.Add(30*time.Minute), "db migration",
+	)
+	require.NoError(t, err)
+
+	err = unary()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, 2, unaryCalls)
+
+	detail := litrpc.ErrorDetailFromError(err)
+	require.NotNil(t, detail)
+	require.Equal(t, litrpc.ErrorCode_ERROR_NODE_MAINTENANCE, detail.Code)
+	require.Equal(t, active.ID, detail.GetNodeMaintenance().WindowId)
+	require.Equal(t, "db migration", detail.GetNodeMaintenance().Reason)
+	require.Equal(t, active.End.Unix(), detail.GetNodeMaintenance().End)
+
+	windows := scheduler.Windows()
+	require.Len(t, windows, 2)
+	require.Equal(t, active.ID, windows[0].ID)
+	require.Equal(t, upcoming.ID, windows[1].ID)
+
+	// The windows survive a restart.
+	require.NoError(t, scheduler.Stop())
+	scheduler = NewScheduler(dir)
+	require.NoError(t, scheduler.Start())
+	t.Cleanup(func() {
+		require.NoError(t, scheduler.Stop())
+	})
+	require.Len(t, scheduler.Windows(), 2)
+
+	window, ok := scheduler.Active()
+	require.True(t, ok)
+	require.Equal(t, active.ID, window.ID)
+
+	// Cancelling the active window ends the maintenance right away.
+	require.ErrorIs(t, scheduler.Cancel("unknown"), ErrWindowNotFound)
+	require.NoError(t, scheduler.Cancel(active.ID))
+	require.NoError(t, unary())
+	require.Equal(t, 3, unaryCalls)
+	require.Len(t, scheduler.Windows(), 1)
+}
+
+// TestMaintenanceExpiry tests that the scheduler leaves a window at its end
+// and removes it.
+func TestMaintenanceExpiry(t *testing.T) {
+	scheduler := NewScheduler(t.TempDir())
+	require.NoError(t, scheduler.Start())
+	t.Cleanup(func() {
+		require.NoError(t, scheduler.Stop())
+	})
+
+	_, err := scheduler.Schedule(
+		time.Time{}, time.Now().Add(100*time.Millisecond), "",
+	)
+	require.NoError(t, err)
+
+	_, ok := scheduler.Active()
+	require.True(t, ok)
+
+	require.Eventually(t, func() bool {
+		scheduler.mu.RLock()
+		defer scheduler.mu.RUnlock()
+
+		return len(scheduler.windows) == 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	_, ok = scheduler.Active()
+	require.False(t, ok)
+}
@@ -0,0 +1,124 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the maintenance database.
+	DBFilename = "maintenance.db"
+
+	// dbFilePermission is the default permission the maintenance database
+	// file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The maintenance windows are stored in the following structure in the
+	db:
+
+	windows -> window ID -> json encoded Window
+*/
+
+// windowsBucketKey is the key of the top level bucket holding the
+// maintenance windows.
+var windowsBucketKey = []byte("windows")
+
+// Store is a bolt-backed persistent store of the maintenance windows.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the maintenance store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(windowsBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Windows fetches all stored maintenance windows.
+func (s *Store) Windows() ([]Window, error) {
+	var windows []Window
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(windowsBucketKey).ForEach(func(_, v []byte) error {
+			var window Window
+			if err := json.Unmarshal(v, &window); err != nil {
+				return err
+			}
+
+			windows = append(windows, window)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+// AddWindow stores the given maintenance window.
+func (s *Store) AddWindow(window *Window) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := json.Marshal(window)
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(windowsBucketKey).Put([]byte(window.ID), b)
+	})
+}
+
+// DeleteWindows deletes the maintenance windows with the given IDs.
+func (s *Store) DeleteWindows(ids ...string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(windowsBucketKey)
+		for _, id := range ids {
+			if err := bucket.Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,11 @@
+package nodemgmt
+
+// Config holds all config options for the node management service.
+type Config struct {
+	RuleBundle string `long:"rulebundle" description:"The name of the firewall rule bundle whose rules are enforced on all node management actions, regardless of the macaroon used. If empty, the actions are only logged."`
+}
+
+// DefaultConfig constructs the default node management Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
@@ -0,0 +1,183 @@
+package nodemgmt
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/peer"
+)
+
+// RPCServer is the main server that implements the NodeManagement gRPC
+// interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedNodeManagementServer
+
+	service *Service
+}
+
+// NewRPCServer returns a new RPC server for the given node management service.
+func NewRPCServer(service *Service) *RPCServer {
+	return &RPCServer{
+		service: service,
+	}
+}
+
+// OpenChannel opens a channel to the given peer.
+func (s *RPCServer) OpenChannel(ctx context.Context,
+	req *litrpc.OpenChannelRequest) (*litrpc.OpenChannelResponse, error) {
+
+	log.Infof("[openchannel] node_pubkey=%s, local_funding_amount=%d",
+		req.NodePubkey, req.LocalFundingAmount)
+
+	pubKey, err := hex.DecodeString(req.NodePubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node_pubkey: %v", err)
+	}
+
+	if req.SatPerVbyte != 0 && req.TargetConf != 0 {
+		return nil, fmt.Errorf("sat_per_vbyte and target_conf are " +
+			"mutually exclusive")
+	}
+
+	chanPoint, err := s.service.OpenChannel(
+		ctx, actorFromContext(ctx), &lnrpc.OpenChannelRequest{
+			NodePubkey:         pubKey,
+			LocalFundingAmount: req.LocalFundingAmount,
+			PushSat:            req.PushSat,
+			Private:            req.Private,
+			SatPerVbyte:        req.SatPerVbyte,
+			TargetConf:         req.TargetConf,
+			CloseAddress:       req.CloseAddress,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	txid, err := lnrpc.GetChanPointFundingTxid(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.OpenChannelResponse{
+		ChannelPoint: fmt.Sprintf("%v:%d", txid, chanPoint.OutputIndex),
+	}, nil
+}
+
+// CloseChannel closes the given channel.
+func (s *RPCServer) CloseChannel(ctx context.Context,
+	req *litrpc.CloseChannelRequest) (*litrpc.CloseChannelResponse, error) {
+
+	log.Infof("[closechannel] channel_point=%s, force=%v",
+		req.ChannelPoint, req.Force)
+
+	chanPoint, err := ParseChanPoint(req.ChannelPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SatPerVbyte != 0 && req.TargetConf != 0 {
+		return nil, fmt.Errorf("sat_per_vbyte and target_conf are " +
+			"mutually exclusive")
+	}
+
+	closingTxid, err := s.service.CloseChannel(
+		ctx, actorFromContext(ctx), &lnrpc.CloseChannelRequest{
+			ChannelPoint:    chanPoint,
+			Force:           req.Force,
+			TargetConf:      req.TargetConf,
+			SatPerVbyte:     req.SatPerVbyte,
+			DeliveryAddress: req.DeliveryAddress,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.CloseChannelResponse{
+		ClosingTxid: closingTxid.String(),
+	}, nil
+}
+
+// UpdateChannelPolicy updates the routing policy of one or all channels.
+func (s *RPCServer) UpdateChannelPolicy(ctx context.Context,
+	req *litrpc.UpdateChannelPolicyRequest) (
+	*litrpc.UpdateChannelPolicyResponse, error) {
+
+	log.Infof("[updatechannelpolicy] channel_point=%s, base_fee_msat=%d, "+
+		"fee_rate_ppm=%d, time_lock_delta=%d", req.ChannelPoint,
+		req.BaseFeeMsat, req.FeeRatePpm, req.TimeLockDelta)
+
+	policyReq := &lnrpc.PolicyUpdateRequest{
+		Scope: &lnrpc.PolicyUpdateRequest_Global{
+			Global: true,
+		},
+		BaseFeeMsat:          req.BaseFeeMsat,
+		FeeRatePpm:           req.FeeRatePpm,
+		TimeLockDelta:        req.TimeLockDelta,
+		MaxHtlcMsat:          req.MaxHtlcMsat,
+		MinHtlcMsat:          req.MinHtlcMsat,
+		MinHtlcMsatSpecified: req.MinHtlcMsatSpecified,
+	}
+	if req.ChannelPoint != "" {
+		chanPoint, err := ParseChanPoint(req.ChannelPoint)
+		if err != nil {
+			return nil, err
+		}
+
+		policyReq.Scope = &lnrpc.PolicyUpdateRequest_ChanPoint{
+			ChanPoint: chanPoint,
+		}
+	}
+
+	resp, err := s.service.UpdateChannelPolicy(
+		ctx, actorFromContext(ctx), policyReq,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := make([]string, 0, len(resp.FailedUpdates))
+	for _, update := range resp.FailedUpdates {
+		failed = append(failed, fmt.Sprintf("%v:%d: %s",
+			update.Outpoint.TxidStr, update.Outpoint.OutputIndex,
+			update.UpdateError))
+	}
+
+	return &litrpc.UpdateChannelPolicyResponse{
+		FailedUpdates: failed,
+	}, nil
+}
+
+// SetAlias updates the alias of the node.
+func (s *RPCServer) SetAlias(ctx context.Context,
+	req *litrpc.SetAliasRequest) (*litrpc.SetAliasResponse, error) {
+
+	log.Infof("[setalias] alias=%s", req.Alias)
+
+	if req.Alias == "" {
+		return nil, fmt.Errorf("alias must be set")
+	}
+
+	err := s.service.SetAlias(ctx, actorFromContext(ctx), req.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.SetAliasResponse{}, nil
+}
+
+// actorFromContext returns the address of the client that made the request,
+// which is logged as the actor of the action.
+func actorFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+
+	return p.Addr.String()
+}
@@ -0,0 +1,246 @@
+package nodemgmt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/peersrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FeatureName is the name of the feature the node management actions
+	// are logged with in the actions DB.
+	FeatureName = "node-management"
+
+	uriOpenChannel         = "/lnrpc.Lightning/OpenChannelSync"
+	uriCloseChannel        = "/lnrpc.Lightning/CloseChannel"
+	uriUpdateChannelPolicy = "/lnrpc.Lightning/UpdateChannelPolicy"
+	uriUpdateNodeAnn       = "/peersrpc.Peers/UpdateNodeAnnouncement"
+)
+
+// ErrNotStarted is returned if an action is requested before the service was
+// started.
+var ErrNotStarted = errors.New("node management service not started")
+
+// Guard checks an action against the firewall rules and performs it if all
+// rules pass.
+type Guard interface {
+	// Do checks the given request against the rules and, if they pass,
+	// calls perform with the possibly rewritten request.
+	Do(ctx context.Context, actor, uri string, req proto.Message,
+		perform func(proto.Message) error) error
+}
+
+// Service performs node management actions on lnd. Each action is passed
+// through the firewall guard with the lnd request it results in, so the same
+// rules as for lnd calls of sessions can be applied.
+type Service struct {
+	lnd   lnrpc.LightningClient
+	peers peersrpc.PeersClient
+	guard Guard
+
+	started atomic.Bool
+}
+
+// NewService creates a new node management service.
+func NewService() *Service {
+	return &Service{}
+}
+
+// Start starts the service with the given lnd clients and firewall guard.
+func (s *Service) Start(lnd lnrpc.LightningClient, peers peersrpc.PeersClient,
+	guard Guard) error {
+
+	s.lnd = lnd
+	s.peers = peers
+	s.guard = guard
+	s.started.Store(true)
+
+	return nil
+}
+
+// Stop stops the service.
+func (s *Service) Stop() error {
+	s.started.Store(false)
+
+	return nil
+}
+
+// OpenChannel opens a channel and returns its channel point once the funding
+// transaction was published.
+func (s *Service) OpenChannel(ctx context.Context, actor string,
+	req *lnrpc.OpenChannelRequest) (*lnrpc.ChannelPoint, error) {
+
+	if !s.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	var chanPoint *lnrpc.ChannelPoint
+	err := s.guard.Do(ctx, actor, uriOpenChannel, req,
+		func(msg proto.Message) error {
+			req, ok := msg.(*lnrpc.OpenChannelRequest)
+			if !ok {
+				return fmt.Errorf("unexpected request %T", msg)
+			}
+
+			var err error
+			chanPoint, err = s.lnd.OpenChannelSync(ctx, req)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chanPoint, nil
+}
+
+// CloseChannel closes a channel and returns the ID of the closing transaction
+// once it was published.
+func (s *Service) CloseChannel(ctx context.Context, actor string,
+	req *lnrpc.CloseChannelRequest) (*chainhash.Hash, error) {
+
+	if !s.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	var closingTxid *chainhash.Hash
+	err := s.guard.Do(ctx, actor, uriCloseChannel, req,
+		func(msg proto.Message) error {
+			req, ok := msg.(*lnrpc.CloseChannelRequest)
+			if !ok {
+				return fmt.Errorf("unexpected request %T", msg)
+			}
+
+			var err error
+			closingTxid, err = s.closeChannel(ctx, req)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return closingTxid, nil
+}
+
+// closeChannel closes a channel and waits for the first update, which
+// contains the closing transaction. lnd continues closing the channel after
+// the update stream is closed.
+func (s *Service) closeChannel(ctx context.Context,
+	req *lnrpc.CloseChannelRequest) (*chainhash.Hash, error) {
+
+	ctxc, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.lnd.CloseChannel(ctxc, req)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	var txid []byte
+	switch u := update.Update.(type) {
+	case *lnrpc.CloseStatusUpdate_ClosePending:
+		txid = u.ClosePending.Txid
+
+	case *lnrpc.CloseStatusUpdate_ChanClose:
+		txid = u.ChanClose.ClosingTxid
+
+	default:
+		return nil, fmt.Errorf("unexpected close update %T", u)
+	}
+
+	return chainhash.NewHash(txid)
+}
+
+// UpdateChannelPolicy updates the routing policy of one or all channels.
+func (s *Service) UpdateChannelPolicy(ctx context.Context, actor string,
+	req *lnrpc.PolicyUpdateRequest) (*lnrpc.PolicyUpdateResponse, error) {
+
+	if !s.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	var resp *lnrpc.PolicyUpdateResponse
+	err := s.guard.Do(ctx, actor, uriUpdateChannelPolicy, req,
+		func(msg proto.Message) error {
+			req, ok := msg.(*lnrpc.PolicyUpdateRequest)
+			if !ok {
+				return fmt.Errorf("unexpected request %T", msg)
+			}
+
+			var err error
+			resp, err = s.lnd.UpdateChannelPolicy(ctx, req)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetAlias updates the alias in the node announcement of the node.
+func (s *Service) SetAlias(ctx context.Context, actor, alias string) error {
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+
+	req := &peersrpc.NodeAnnouncementUpdateRequest{
+		Alias: alias,
+	}
+
+	return s.guard.Do(ctx, actor, uriUpdateNodeAnn, req,
+		func(msg proto.Message) error {
+			req, ok := msg.(*peersrpc.NodeAnnouncementUpdateRequest)
+			if !ok {
+				return fmt.Errorf("unexpected request %T", msg)
+			}
+
+			_, err := s.peers.UpdateNodeAnnouncement(ctx, req)
+			return err
+		},
+	)
+}
+
+// ParseChanPoint parses a channel point in the form txid:output_index.
+func ParseChanPoint(s string) (*lnrpc.ChannelPoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid channel point %s, expected "+
+			"txid:output_index", s)
+	}
+
+	if len(parts[0]) != chainhash.MaxHashStringSize {
+		return nil, fmt.Errorf("invalid channel point txid length")
+	}
+	if _, err := chainhash.NewHashFromStr(parts[0]); err != nil {
+		return nil, fmt.Errorf("invalid channel point txid: %v", err)
+	}
+
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel point output index: "+
+			"%v", err)
+	}
+
+	return &lnrpc.ChannelPoint{
+		FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+			FundingTxidStr: parts[0],
+		},
+		OutputIndex: uint32(index),
+	}, nil
+}
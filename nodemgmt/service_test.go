@@ -0,0 +1,113 @@
+package nodemgmt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var errRuleViolation = errors.New("rule violation")
+
+// mockGuard rejects requests with a base fee above maxBaseFee and caps the
+// fee rate of all other requests.
+type mockGuard struct {
+	maxBaseFee int64
+	maxFeeRate uint32
+
+	uris []string
+}
+
+func (m *mockGuard) Do(_ context.Context, _, uri string, req proto.Message,
+	perform func(proto.Message) error) error {
+
+	m.uris = append(m.uris, uri)
+
+	policyReq, ok := req.(*lnrpc.PolicyUpdateRequest)
+	if !ok {
+		return perform(req)
+	}
+
+	if policyReq.BaseFeeMsat > m.maxBaseFee {
+		return errRuleViolation
+	}
+
+	if policyReq.FeeRatePpm > m.maxFeeRate {
+		policyReq = proto.Clone(policyReq).(*lnrpc.PolicyUpdateRequest)
+		policyReq.FeeRatePpm = m.maxFeeRate
+	}
+
+	return perform(policyReq)
+}
+
+type mockLnd struct {
+	lnrpc.LightningClient
+
+	policyReqs []*lnrpc.PolicyUpdateRequest
+}
+
+func (m *mockLnd) UpdateChannelPolicy(_ context.Context,
+	req *lnrpc.PolicyUpdateRequest,
+	_ ...grpc.CallOption) (*lnrpc.PolicyUpdateResponse, error) {
+
+	m.policyReqs = append(m.policyReqs, req)
+
+	return &lnrpc.PolicyUpdateResponse{}, nil
+}
+
+// TestUpdateChannelPolicy tests that the policy updates are passed through the
+// guard and that lnd only sees the requests the guard allowed.
+func TestUpdateChannelPolicy(t *testing.T) {
+	ctx := context.Background()
+	lnd := &mockLnd{}
+	guard := &mockGuard{maxBaseFee: 1000, maxFeeRate: 500}
+
+	s := NewService()
+	_, err := s.UpdateChannelPolicy(
+		ctx, "test", &lnrpc.PolicyUpdateRequest{},
+	)
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, s.Start(lnd, nil, guard))
+
+	_, err = s.UpdateChannelPolicy(ctx, "test", &lnrpc.PolicyUpdateRequest{
+		BaseFeeMsat: 2000,
+	})
+	require.ErrorIs(t, err, errRuleViolation)
+	require.Empty(t, lnd.policyReqs)
+
+	_, err = s.UpdateChannelPolicy(ctx, "test", &lnrpc.PolicyUpdateRequest{
+		BaseFeeMsat: 1000,
+		FeeRatePpm:  1000,
+	})
+	require.NoError(t, err)
+	require.Len(t, lnd.policyReqs, 1)
+	require.EqualValues(t, 500, lnd.policyReqs[0].FeeRatePpm)
+
+	require.Equal(t, []string{
+		uriUpdateChannelPolicy, uriUpdateChannelPolicy,
+	}, guard.uris)
+}
+
+// TestParseChanPoint tests the parsing of channel points.
+func TestParseChanPoint(t *testing.T) {
+	txid := "097ef666a61919ff3413b3b701eae3a5cbac08f70c0ca567806e1fa6acbfe384"
+
+	chanPoint, err := ParseChanPoint(txid + ":2")
+	require.NoError(t, err)
+	require.Equal(t, txid, chanPoint.GetFundingTxidStr())
+	require.EqualValues(t, 2, chanPoint.OutputIndex)
+
+	_, err = ParseChanPoint(txid)
+	require.Error(t, err)
+
+	_, err = ParseChanPoint("abc:1")
+	require.Error(t, err)
+
+	_, err = ParseChanPoint(txid + ":x")
+	require.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package nwc
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Config holds all config options for the Nostr Wallet Connect service.
+type Config struct {
+	Enable bool     `long:"enable" description:"Run a Nostr Wallet Connect (NIP-47) wallet service that lets NWC apps spend from lit accounts."`
+	Relays []string `long:"relay" description:"The URL of a nostr relay the wallet service listens on, for example wss://relay.example.com. Can be specified multiple times."`
+}
+
+// DefaultConfig constructs the default NWC Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure the config is sane if the service is enabled.
+func (c *Config) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if len(c.Relays) == 0 {
+		return fmt.Errorf("at least one nwc relay must be set")
+	}
+
+	for _, relay := range c.Relays {
+		u, err := url.Parse(relay)
+		if err != nil {
+			return fmt.Errorf("invalid nwc relay %s: %v", relay, err)
+		}
+
+		if u.Scheme != "ws" && u.Scheme != "wss" {
+			return fmt.Errorf("invalid nwc relay %s: scheme must "+
+				"be ws or wss", relay)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package nwc
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+const (
+	// kindInfo is the kind of the replaceable event that announces the
+	// methods the wallet service supports.
+	kindInfo = 13194
+
+	// kindRequest is the kind of the events that carry a request from a
+	// client to the wallet service.
+	kindRequest = 23194
+
+	// kindResponse is the kind of the events that carry a response from
+	// the wallet service to a client.
+	kindResponse = 23195
+)
+
+var (
+	// ErrInvalidEvent is returned if an event's ID or signature doesn't
+	// match its content.
+	ErrInvalidEvent = errors.New("invalid nostr event")
+)
+
+// Event is a nostr event as defined in NIP-01.
+type Event struct {
+	ID        string     `json:"id"`
+	PubKey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+// serialize returns the canonical serialization of the event that its ID is
+// the hash of.
+func (e *Event) serialize() ([]byte, error) {
+	tags := e.Tags
+	if tags == nil {
+		tags = [][]string{}
+	}
+
+	// The canonical serialization must not escape HTML characters, which
+	// the default JSON encoder would do.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode([]interface{}{
+		0, e.PubKey, e.CreatedAt, e.Kind, tags, e.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// hash returns the hash of the canonical serialization of the event.
+func (e *Event) hash() ([]byte, error) {
+	serialized, err := e.serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(serialized)
+	return hash[:], nil
+}
+
+// Sign sets the public key, ID and signature of the event using the given
+// private key.
+func (e *Event) Sign(privKey *btcec.PrivateKey) error {
+	e.PubKey = pubKeyHex(privKey.PubKey())
+
+	hash, err := e.hash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := schnorr.Sign(privKey, hash)
+	if err != nil {
+		return err
+	}
+
+	e.ID = hex.EncodeToString(hash)
+	e.Sig = hex.EncodeToString(sig.Serialize())
+
+	return nil
+}
+
+// Verify makes sure the ID of the event matches its content and that it was
+// signed by its public key.
+func (e *Event) Verify() error {
+	hash, err := e.hash()
+	if err != nil {
+		return err
+	}
+
+	if e.ID != hex.EncodeToString(hash) {
+		return fmt.Errorf("%w: ID mismatch", ErrInvalidEvent)
+	}
+
+	pubKey, err := parsePubKey(e.PubKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	sigBytes, err := hex.DecodeString(e.Sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	if !sig.Verify(hash, pubKey) {
+		return fmt.Errorf("%w: invalid signature", ErrInvalidEvent)
+	}
+
+	return nil
+}
+
+// tag returns the first value of the first tag with the given name.
+func (e *Event) tag(name string) string {
+	for _, t := range e.Tags {
+		if len(t) >= 2 && t[0] == name {
+			return t[1]
+		}
+	}
+
+	return ""
+}
+
+// newEvent creates a new unsigned event with the current time.
+func newEvent(kind int, tags [][]string, content string) *Event {
+	return &Event{
+		CreatedAt: time.Now().Unix(),
+		Kind:      kind,
+		Tags:      tags,
+		Content:   content,
+	}
+}
+
+// pubKeyHex returns the hex encoded x-only public key as used by nostr.
+func pubKeyHex(pubKey *btcec.PublicKey) string {
+	return hex.EncodeToString(schnorr.SerializePubKey(pubKey))
+}
+
+// parsePubKey parses a hex encoded x-only public key as used by nostr.
+func parsePubKey(pubKeyHex string) (*btcec.PublicKey, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return schnorr.ParsePubKey(pubKeyBytes)
+}
+
+// nip04Encrypt encrypts the given plaintext for the given receiver as defined
+// in NIP-04.
+func nip04Encrypt(privKey *btcec.PrivateKey, receiver *btcec.PublicKey,
+	plaintext []byte) (string, error) {
+
+	block, err := aes.NewCipher(btcec.GenerateSharedSecret(
+		privKey, receiver,
+	))
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	// Apply PKCS#7 padding.
+	padLen := aes.BlockSize - len(plaintext)%aes.BlockSize
+	padded := make([]byte, len(plaintext)+padLen)
+	copy(padded, plaintext)
+	for i := len(plaintext); i < len(padded); i++ {
+		padded[i] = byte(padLen)
+	}
+
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+
+	return base64.StdEncoding.EncodeToString(ciphertext) + "?iv=" +
+		base64.StdEncoding.EncodeToString(iv), nil
+}
+
+// nip04Decrypt decrypts the given NIP-04 content that was encrypted by the
+// given sender.
+func nip04Decrypt(privKey *btcec.PrivateKey, sender *btcec.PublicKey,
+	content string) ([]byte, error) {
+
+	parts := strings.Split(content, "?iv=")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid encrypted content")
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != aes.BlockSize || len(ciphertext) == 0 ||
+		len(ciphertext)%aes.BlockSize != 0 {
+
+		return nil, fmt.Errorf("invalid encrypted content")
+	}
+
+	block, err := aes.NewCipher(btcec.GenerateSharedSecret(
+		privKey, sender,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	// Remove the PKCS#7 padding.
+	padLen := int(plaintext[len(plaintext)-1])
+	if padLen == 0 || padLen > aes.BlockSize || padLen > len(plaintext) {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	for _, b := range plaintext[len(plaintext)-padLen:] {
+		if int(b) != padLen {
+			return nil, fmt.Errorf("invalid padding")
+		}
+	}
+
+	return plaintext[:len(plaintext)-padLen], nil
+}
@@ -0,0 +1,82 @@
+package nwc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEventSignVerify tests that a signed event can be verified and that any
+// modification of it is detected.
+func TestEventSignVerify(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	event := newEvent(kindRequest, [][]string{{"p", "abcd"}}, "<&>")
+	require.NoError(t, event.Sign(privKey))
+	require.NoError(t, event.Verify())
+	require.Equal(t, pubKeyHex(privKey.PubKey()), event.PubKey)
+	require.Equal(t, "abcd", event.tag("p"))
+	require.Empty(t, event.tag("e"))
+
+	// HTML characters must not be escaped in the serialization the ID is
+	// computed from.
+	serialized, err := event.serialize()
+	require.NoError(t, err)
+	require.Contains(t, string(serialized), `"<&>"`)
+
+	// Changing the content invalidates the ID.
+	tampered := *event
+	tampered.Content = "other"
+	require.ErrorIs(t, tampered.Verify(), ErrInvalidEvent)
+
+	// Re-computing the ID with another key doesn't match the signature.
+	otherKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	tampered = *event
+	tampered.PubKey = pubKeyHex(otherKey.PubKey())
+	hash, err := tampered.hash()
+	require.NoError(t, err)
+	tampered.ID = hex.EncodeToString(hash)
+	require.ErrorIs(t, tampered.Verify(), ErrInvalidEvent)
+}
+
+// TestNIP04 tests that content encrypted by one party can be decrypted by the
+// other party.
+func TestNIP04(t *testing.T) {
+	alice, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	bob, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	// Nostr only transports the x-only public keys, so we make sure the
+	// shared secret is the same regardless of the parity of the key.
+	bobPubKey, err := parsePubKey(pubKeyHex(bob.PubKey()))
+	require.NoError(t, err)
+	alicePubKey, err := parsePubKey(pubKeyHex(alice.PubKey()))
+	require.NoError(t, err)
+
+	for _, msg := range []string{"", "hello", "exactly 16 bytes"} {
+		encrypted, err := nip04Encrypt(alice, bobPubKey, []byte(msg))
+		require.NoError(t, err)
+
+		decrypted, err := nip04Decrypt(bob, alicePubKey, encrypted)
+		require.NoError(t, err)
+		require.Equal(t, msg, string(decrypted))
+	}
+
+	// Decrypting with a wrong key fails or yields different content.
+	encrypted, err := nip04Encrypt(alice, bobPubKey, []byte("hello"))
+	require.NoError(t, err)
+
+	decrypted, err := nip04Decrypt(alice, alicePubKey, encrypted)
+	if err == nil {
+		require.NotEqual(t, "hello", string(decrypted))
+	}
+
+	_, err = nip04Decrypt(bob, alicePubKey, "invalid")
+	require.Error(t, err)
+}
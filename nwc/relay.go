@@ -0,0 +1,246 @@
+package nwc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// subscriptionID is the ID of the subscription for the requests to the
+	// wallet service.
+	subscriptionID = "lit-nwc"
+
+	// minReconnectBackoff is the initial time we wait before reconnecting
+	// to a relay after the connection was lost.
+	minReconnectBackoff = 5 * time.Second
+
+	// maxReconnectBackoff is the maximum time we wait before reconnecting
+	// to a relay.
+	maxReconnectBackoff = 5 * time.Minute
+
+	// writeTimeout is the maximum time a write to a relay may take.
+	writeTimeout = 10 * time.Second
+
+	// subscriptionSlack is how far back we ask a relay for events when
+	// reconnecting so that no request is missed because of clock skew.
+	subscriptionSlack = time.Minute
+)
+
+// relay is a connection to a single nostr relay that subscribes to the
+// requests to the wallet service and publishes the responses. The connection
+// is automatically re-established if it is lost.
+type relay struct {
+	url           string
+	servicePubKey string
+
+	// onConnect is called each time the connection to the relay was
+	// (re-)established.
+	onConnect func(r *relay)
+
+	// onEvent is called for each event received on the subscription.
+	onEvent func(r *relay, e *Event)
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newRelay creates a new relay connection.
+func newRelay(url, servicePubKey string, onConnect func(r *relay),
+	onEvent func(r *relay, e *Event)) *relay {
+
+	return &relay{
+		url:           url,
+		servicePubKey: servicePubKey,
+		onConnect:     onConnect,
+		onEvent:       onEvent,
+		quit:          make(chan struct{}),
+	}
+}
+
+// start connects to the relay in the background.
+func (r *relay) start() {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		backoff := minReconnectBackoff
+		since := time.Now()
+		for {
+			connectedAt := time.Now()
+			err := r.run(since)
+
+			select {
+			case <-r.quit:
+				return
+			default:
+			}
+
+			// If the connection was stable for a while, we start
+			// over with the minimum backoff.
+			if time.Since(connectedAt) > maxReconnectBackoff {
+				backoff = minReconnectBackoff
+			}
+
+			log.Warnf("Connection to relay %s lost, reconnecting "+
+				"in %v: %v", r.url, backoff, err)
+
+			since = time.Now().Add(-subscriptionSlack)
+			select {
+			case <-time.After(backoff):
+			case <-r.quit:
+				return
+			}
+
+			backoff *= 2
+			if backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+		}
+	}()
+}
+
+// stop closes the connection to the relay and waits for the background
+// goroutine to exit.
+func (r *relay) stop() {
+	close(r.quit)
+
+	r.mu.Lock()
+	if r.conn != nil {
+		_ = r.conn.Close()
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+}
+
+// run connects to the relay, subscribes to the requests created after the
+// given time and dispatches them until the connection fails.
+func (r *relay) run(since time.Time) error {
+	conn, _, err := websocket.DefaultDialer.Dial(r.url, nil)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.conn = conn
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		r.conn = nil
+		r.mu.Unlock()
+
+		_ = conn.Close()
+	}()
+
+	// The relay might have been stopped while we were connecting.
+	select {
+	case <-r.quit:
+		return nil
+	default:
+	}
+
+	err = r.send([]interface{}{
+		"REQ", subscriptionID, map[string]interface{}{
+			"kinds": []int{kindRequest},
+			"#p":    []string{r.servicePubKey},
+			"since": since.Unix(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Connected to relay %s", r.url)
+	r.onConnect(r)
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		r.handleMessage(msg)
+	}
+}
+
+// handleMessage handles a single message received from the relay.
+func (r *relay) handleMessage(msg []byte) {
+	var parts []json.RawMessage
+	if err := json.Unmarshal(msg, &parts); err != nil || len(parts) < 2 {
+		log.Debugf("Ignoring invalid message from relay %s", r.url)
+		return
+	}
+
+	var msgType string
+	if err := json.Unmarshal(parts[0], &msgType); err != nil {
+		return
+	}
+
+	switch msgType {
+	case "EVENT":
+		if len(parts) < 3 {
+			return
+		}
+
+		var event Event
+		if err := json.Unmarshal(parts[2], &event); err != nil {
+			log.Debugf("Ignoring invalid event from relay %s: %v",
+				r.url, err)
+			return
+		}
+
+		r.onEvent(r, &event)
+
+	case "OK":
+		var (
+			accepted bool
+			reason   string
+		)
+		if len(parts) >= 3 {
+			_ = json.Unmarshal(parts[2], &accepted)
+		}
+		if len(parts) >= 4 {
+			_ = json.Unmarshal(parts[3], &reason)
+		}
+
+		if !accepted {
+			log.Warnf("Relay %s rejected event: %s", r.url,
+				reason)
+		}
+
+	case "NOTICE":
+		var notice string
+		_ = json.Unmarshal(parts[1], &notice)
+		log.Infof("Notice from relay %s: %s", r.url, notice)
+	}
+}
+
+// publish publishes the given event to the relay.
+func (r *relay) publish(e *Event) error {
+	return r.send([]interface{}{"EVENT", e})
+}
+
+// send writes the given message to the relay.
+func (r *relay) send(msg interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		return fmt.Errorf("not connected to relay %s", r.url)
+	}
+
+	err := r.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+
+	return r.conn.WriteJSON(msg)
+}
@@ -0,0 +1,95 @@
+package nwc
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the NostrWalletConnect gRPC
+// interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedNostrWalletConnectServer
+
+	service *Service
+}
+
+// NewRPCServer returns a new RPC server for the given NWC service.
+func NewRPCServer(service *Service) *RPCServer {
+	return &RPCServer{
+		service: service,
+	}
+}
+
+// AddConnection creates a new NWC connection to the given account.
+func (s *RPCServer) AddConnection(_ context.Context,
+	req *litrpc.AddNWCConnectionRequest) (*litrpc.AddNWCConnectionResponse,
+	error) {
+
+	log.Infof("[addconnection] account_id=%s, label=%s", req.AccountId,
+		req.Label)
+
+	accountID, err := accounts.ParseAccountID(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, uri, err := s.service.AddConnection(*accountID, req.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.AddNWCConnectionResponse{
+		Connection:    marshalConnection(conn),
+		ConnectionUri: uri,
+	}, nil
+}
+
+// ListConnections returns all NWC connections.
+func (s *RPCServer) ListConnections(_ context.Context,
+	_ *litrpc.ListNWCConnectionsRequest) (
+	*litrpc.ListNWCConnectionsResponse, error) {
+
+	log.Info("[listconnections]")
+
+	conns, err := s.service.Connections()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListNWCConnectionsResponse{
+		Connections: make([]*litrpc.NWCConnection, len(conns)),
+	}
+	for i, conn := range conns {
+		resp.Connections[i] = marshalConnection(conn)
+	}
+
+	return resp, nil
+}
+
+// RemoveConnection removes the given NWC connection.
+func (s *RPCServer) RemoveConnection(_ context.Context,
+	req *litrpc.RemoveNWCConnectionRequest) (
+	*litrpc.RemoveNWCConnectionResponse, error) {
+
+	log.Infof("[removeconnection] client_pubkey=%s", req.ClientPubkey)
+
+	if err := s.service.RemoveConnection(req.ClientPubkey); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RemoveNWCConnectionResponse{}, nil
+}
+
+// marshalConnection converts a connection into its RPC counterpart.
+func marshalConnection(conn *Connection) *litrpc.NWCConnection {
+	return &litrpc.NWCConnection{
+		ClientPubkey: conn.ClientPubKey,
+		AccountId:    hex.EncodeToString(conn.AccountID[:]),
+		Label:        conn.Label,
+		CreatedAt:    uint64(conn.CreatedAt.Unix()),
+	}
+}
@@ -0,0 +1,545 @@
+package nwc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+const (
+	// uriScheme is the scheme of the NWC connection URIs.
+	uriScheme = "nostr+walletconnect"
+
+	// maxSeenEvents is the number of request event IDs that are
+	// remembered to not handle the same request twice if it is received
+	// from multiple relays.
+	maxSeenEvents = 1000
+)
+
+const (
+	// methodPayInvoice pays a BOLT11 invoice from the account.
+	methodPayInvoice = "pay_invoice"
+
+	// methodMakeInvoice creates an invoice that credits the account once
+	// it is paid.
+	methodMakeInvoice = "make_invoice"
+
+	// methodGetBalance returns the balance of the account.
+	methodGetBalance = "get_balance"
+)
+
+// supportedMethods are all NIP-47 methods supported by the wallet service.
+var supportedMethods = []string{
+	methodPayInvoice, methodMakeInvoice, methodGetBalance,
+}
+
+// The NIP-47 error codes returned to the clients.
+const (
+	errCodeNotImplemented      = "NOT_IMPLEMENTED"
+	errCodeInsufficientBalance = "INSUFFICIENT_BALANCE"
+	errCodeRestricted          = "RESTRICTED"
+	errCodeUnauthorized        = "UNAUTHORIZED"
+	errCodeInternal            = "INTERNAL"
+	errCodePaymentFailed       = "PAYMENT_FAILED"
+	errCodeOther               = "OTHER"
+)
+
+// AccountService is the subset of the account service the NWC service spends
+// from and credits to the accounts with.
+type AccountService interface {
+	accounts.Service
+
+	// Account retrieves the account with the given ID.
+	Account(id accounts.AccountID) (*accounts.OffChainBalanceAccount,
+		error)
+}
+
+// request is the decrypted content of a NIP-47 request event.
+type request struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// responseError is the error of a NIP-47 response.
+type responseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// newError creates a new NIP-47 error.
+func newError(code, format string, args ...interface{}) *responseError {
+	return &responseError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// response is the content of a NIP-47 response event before encryption.
+type response struct {
+	ResultType string         `json:"result_type"`
+	Error      *responseError `json:"error,omitempty"`
+	Result     interface{}    `json:"result,omitempty"`
+}
+
+// Service is a Nostr Wallet Connect (NIP-47) wallet service that lets NWC
+// clients spend from and receive to lit accounts. Each connection is mapped to
+// a single account so the balance of the account is the spending limit of the
+// connection.
+type Service struct {
+	cfg      *Config
+	dir      string
+	accounts AccountService
+
+	store      *Store
+	serviceKey *btcec.PrivateKey
+
+	lnd         lndclient.LightningClient
+	payer       *accounts.Payer
+	chainParams *chaincfg.Params
+
+	relays []*relay
+
+	seenMtx   sync.Mutex
+	seen      map[string]struct{}
+	seenOrder []string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewService creates a new NWC service that stores its data in the given
+// directory.
+func NewService(cfg *Config, dir string, accts AccountService) *Service {
+	return &Service{
+		cfg:      cfg,
+		dir:      dir,
+		accounts: accts,
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// Start opens the NWC store and connects to the configured relays if the
+// service is enabled.
+func (s *Service) Start(lnd lndclient.LightningClient, payer *accounts.Payer,
+	chainParams *chaincfg.Params) error {
+
+	if !s.cfg.Enable {
+		return nil
+	}
+
+	s.lnd = lnd
+	s.payer = payer
+	s.chainParams = chainParams
+
+	store, err := NewStore(s.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open nwc store: %v", err)
+	}
+	s.store = store
+
+	s.serviceKey, err = store.ServiceKey()
+	if err != nil {
+		_ = store.Close()
+		return fmt.Errorf("unable to fetch nwc service key: %v", err)
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	log.Infof("Starting NWC wallet service %s",
+		pubKeyHex(s.serviceKey.PubKey()))
+
+	for _, relayURL := range s.cfg.Relays {
+		r := newRelay(
+			relayURL, pubKeyHex(s.serviceKey.PubKey()),
+			s.publishInfo, s.handleEvent,
+		)
+		r.start()
+
+		s.relays = append(s.relays, r)
+	}
+
+	return nil
+}
+
+// Stop disconnects from all relays, waits for the running requests to finish
+// and closes the store.
+func (s *Service) Stop() error {
+	if s.store == nil {
+		return nil
+	}
+
+	for _, r := range s.relays {
+		r.stop()
+	}
+
+	s.cancel()
+	s.wg.Wait()
+
+	return s.store.Close()
+}
+
+// AddConnection creates a new connection to the given account. The returned
+// connection URI contains the secret of the client and is not stored, so it
+// can only be retrieved once.
+func (s *Service) AddConnection(accountID accounts.AccountID,
+	label string) (*Connection, string, error) {
+
+	if s.store == nil {
+		return nil, "", fmt.Errorf("nwc service is not enabled")
+	}
+
+	if _, err := s.accounts.Account(accountID); err != nil {
+		return nil, "", err
+	}
+
+	clientKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	conn := &Connection{
+		ClientPubKey: pubKeyHex(clientKey.PubKey()),
+		AccountID:    accountID,
+		Label:        label,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.store.AddConnection(conn); err != nil {
+		return nil, "", err
+	}
+
+	query := url.Values{
+		"relay":  s.cfg.Relays,
+		"secret": []string{hex.EncodeToString(clientKey.Serialize())},
+	}
+	uri := fmt.Sprintf("%s://%s?%s", uriScheme,
+		pubKeyHex(s.serviceKey.PubKey()), query.Encode())
+
+	return conn, uri, nil
+}
+
+// Connections returns all connections.
+func (s *Service) Connections() ([]*Connection, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("nwc service is not enabled")
+	}
+
+	return s.store.Connections()
+}
+
+// RemoveConnection removes the connection of the given client. Any further
+// request of the client is rejected.
+func (s *Service) RemoveConnection(clientPubKey string) error {
+	if s.store == nil {
+		return fmt.Errorf("nwc service is not enabled")
+	}
+
+	return s.store.RemoveConnection(clientPubKey)
+}
+
+// publishInfo publishes the info event that announces the supported methods
+// to the given relay.
+func (s *Service) publishInfo(r *relay) {
+	event := newEvent(kindInfo, nil, strings.Join(supportedMethods, " "))
+	if err := event.Sign(s.serviceKey); err != nil {
+		log.Errorf("Unable to sign info event: %v", err)
+		return
+	}
+
+	if err := r.publish(event); err != nil {
+		log.Errorf("Unable to publish info event to relay %s: %v",
+			r.url, err)
+	}
+}
+
+// markSeen returns true if the event with the given ID was already seen and
+// otherwise remembers it.
+func (s *Service) markSeen(id string) bool {
+	s.seenMtx.Lock()
+	defer s.seenMtx.Unlock()
+
+	if _, ok := s.seen[id]; ok {
+		return true
+	}
+
+	s.seen[id] = struct{}{}
+	s.seenOrder = append(s.seenOrder, id)
+	if len(s.seenOrder) > maxSeenEvents {
+		delete(s.seen, s.seenOrder[0])
+		s.seenOrder = s.seenOrder[1:]
+	}
+
+	return false
+}
+
+// handleEvent handles an event received from a relay. Valid requests are
+// handled in the background and the response is published to the relay the
+// request was received from.
+func (s *Service) handleEvent(r *relay, event *Event) {
+	servicePubKey := pubKeyHex(s.serviceKey.PubKey())
+	if event.Kind != kindRequest || event.tag("p") != servicePubKey {
+		return
+	}
+
+	if err := event.Verify(); err != nil {
+		log.Debugf("Ignoring request from relay %s: %v", r.url, err)
+		return
+	}
+
+	if s.markSeen(event.ID) {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		resp, err := s.handleRequestEvent(s.ctx, event)
+		if err != nil {
+			log.Errorf("Unable to handle request %s: %v", event.ID,
+				err)
+			return
+		}
+
+		if err := r.publish(resp); err != nil {
+			log.Errorf("Unable to publish response to request "+
+				"%s: %v", event.ID, err)
+		}
+	}()
+}
+
+// handleRequestEvent decrypts the given request event, executes the request
+// on behalf of the connection's account and returns the signed response
+// event.
+func (s *Service) handleRequestEvent(ctx context.Context,
+	event *Event) (*Event, error) {
+
+	clientPubKey, err := parsePubKey(event.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := nip04Decrypt(s.serviceKey, clientPubKey, event.Content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt request: %v", err)
+	}
+
+	var req request
+	if err := json.Unmarshal(plaintext, &req); err != nil {
+		return nil, fmt.Errorf("unable to parse request: %v", err)
+	}
+
+	resp := &response{
+		ResultType: req.Method,
+	}
+
+	conn, err := s.store.Connection(event.PubKey)
+	switch {
+	case errors.Is(err, ErrConnectionNotFound):
+		resp.Error = newError(errCodeUnauthorized, "unknown connection")
+
+	case err != nil:
+		return nil, err
+
+	default:
+		log.Debugf("Handling %s request of connection %s", req.Method,
+			conn.ClientPubKey)
+
+		resp.Result, resp.Error = s.handleRequest(ctx, conn, &req)
+	}
+
+	content, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := nip04Encrypt(s.serviceKey, clientPubKey, content)
+	if err != nil {
+		return nil, err
+	}
+
+	respEvent := newEvent(kindResponse, [][]string{
+		{"p", event.PubKey},
+		{"e", event.ID},
+	}, encrypted)
+	if err := respEvent.Sign(s.serviceKey); err != nil {
+		return nil, err
+	}
+
+	return respEvent, nil
+}
+
+// handleRequest executes the given request on behalf of the account of the
+// given connection.
+func (s *Service) handleRequest(ctx context.Context, conn *Connection,
+	req *request) (interface{}, *responseError) {
+
+	// Just like the RPCs of the accounts, all requests are rejected while
+	// the lockdown mode is enabled.
+	if err := s.payer.CheckLockdown(); err != nil {
+		return nil, newError(errCodeRestricted, "%v", err)
+	}
+
+	switch req.Method {
+	case methodPayInvoice:
+		return s.payInvoice(ctx, conn.AccountID, req.Params)
+
+	case methodMakeInvoice:
+		return s.makeInvoice(ctx, conn.AccountID, req.Params)
+
+	case methodGetBalance:
+		return s.getBalance(conn.AccountID)
+
+	default:
+		return nil, newError(errCodeNotImplemented, "method %s is "+
+			"not supported", req.Method)
+	}
+}
+
+// getBalance returns the balance of the given account.
+func (s *Service) getBalance(id accounts.AccountID) (interface{},
+	*responseError) {
+
+	acct, err := s.accounts.Account(id)
+	if err != nil {
+		return nil, newError(errCodeInternal, "%v", err)
+	}
+
+	balance := acct.CurrentBalance
+	if balance < 0 {
+		balance = 0
+	}
+
+	return map[string]interface{}{
+		"balance": balance,
+	}, nil
+}
+
+// makeInvoice creates an invoice that credits the given account once it is
+// paid.
+func (s *Service) makeInvoice(ctx context.Context, id accounts.AccountID,
+	rawParams json.RawMessage) (interface{}, *responseError) {
+
+	var params struct {
+		Amount          uint64 `json:"amount"`
+		Description     string `json:"description"`
+		DescriptionHash string `json:"description_hash"`
+		Expiry          int64  `json:"expiry"`
+	}
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return nil, newError(errCodeOther, "invalid params: %v", err)
+	}
+
+	var descHash []byte
+	if params.DescriptionHash != "" {
+		var err error
+		descHash, err = hex.DecodeString(params.DescriptionHash)
+		if err != nil || len(descHash) != 32 {
+			return nil, newError(errCodeOther, "invalid "+
+				"description hash")
+		}
+	}
+
+	hash, invoice, err := s.lnd.AddInvoice(ctx, &invoicesrpc.AddInvoiceData{
+		Memo:            params.Description,
+		Value:           lnwire.MilliSatoshi(params.Amount),
+		DescriptionHash: descHash,
+		Expiry:          params.Expiry,
+	})
+	if err != nil {
+		return nil, newError(errCodeInternal, "unable to create "+
+			"invoice: %v", err)
+	}
+
+	if err := s.accounts.AssociateInvoice(id, hash); err != nil {
+		return nil, newError(errCodeInternal, "%v", err)
+	}
+
+	payReq, err := zpay32.Decode(invoice, s.chainParams)
+	if err != nil {
+		return nil, newError(errCodeInternal, "%v", err)
+	}
+
+	return map[string]interface{}{
+		"type":             "incoming",
+		"invoice":          invoice,
+		"description":      params.Description,
+		"description_hash": params.DescriptionHash,
+		"payment_hash":     hash.String(),
+		"amount":           params.Amount,
+		"created_at":       payReq.Timestamp.Unix(),
+		"expires_at":       payReq.Timestamp.Add(payReq.Expiry()).Unix(),
+	}, nil
+}
+
+// payInvoice pays the given invoice from the given account and waits for the
+// payment to complete. The payment is only attempted if the account has enough
+// balance to pay for the amount and the maximum routing fee.
+func (s *Service) payInvoice(ctx context.Context, id accounts.AccountID,
+	rawParams json.RawMessage) (interface{}, *responseError) {
+
+	var params struct {
+		Invoice string `json:"invoice"`
+		Amount  uint64 `json:"amount"`
+	}
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return nil, newError(errCodeOther, "invalid params: %v", err)
+	}
+
+	resultChan, err := s.payer.PayInvoice(
+		id, params.Invoice, lnwire.MilliSatoshi(params.Amount),
+	)
+	if err != nil {
+		return nil, paymentError(err)
+	}
+
+	select {
+	case result := <-resultChan:
+		if result.Err != nil {
+			return nil, paymentError(result.Err)
+		}
+
+		return map[string]interface{}{
+			"preimage": result.Preimage.String(),
+		}, nil
+
+	case <-ctx.Done():
+		return nil, newError(errCodeInternal, "shutting down")
+	}
+}
+
+// paymentError maps an error of the account payer to a NIP-47 error.
+func paymentError(err error) *responseError {
+	switch {
+	case errors.Is(err, accounts.ErrAccBalanceInsufficient):
+		return newError(errCodeInsufficientBalance, "%v", err)
+
+	case errors.Is(err, accounts.ErrAccExpired),
+		errors.Is(err, accounts.ErrLockdown):
+
+		return newError(errCodeRestricted, "%v", err)
+
+	case errors.Is(err, accounts.ErrInvalidInvoice):
+		return newError(errCodeOther, "%v", err)
+
+	case errors.Is(err, accounts.ErrPaymentFailed):
+		return newError(errCodePaymentFailed, "%v", err)
+
+	default:
+		return newError(errCodeInternal, "%v", err)
+	}
+}
@@ -0,0 +1,357 @@
+package nwc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainParams = &chaincfg.RegressionNetParams
+
+// mockAccounts is a mock implementation of the AccountService.
+type mockAccounts struct {
+	accts    map[accounts.AccountID]*accounts.OffChainBalanceAccount
+	invoices map[lntypes.Hash]accounts.AccountID
+}
+
+func newMockAccounts() *mockAccounts {
+	return &mockAccounts{
+		accts: make(
+			map[accounts.AccountID]*accounts.OffChainBalanceAccount,
+		),
+		invoices: make(map[lntypes.Hash]accounts.AccountID),
+	}
+}
+
+func (m *mockAccounts) Account(id accounts.AccountID) (
+	*accounts.OffChainBalanceAccount, error) {
+
+	acct, ok := m.accts[id]
+	if !ok {
+		return nil, accounts.ErrAccNotFound
+	}
+
+	return acct, nil
+}
+
+func (m *mockAccounts) CheckBalance(id accounts.AccountID,
+	requiredBalance lnwire.MilliSatoshi) error {
+
+	acct, err := m.Account(id)
+	if err != nil {
+		return err
+	}
+
+	if acct.CurrentBalance < int64(requiredBalance) {
+		return accounts.ErrAccBalanceInsufficient
+	}
+
+	return nil
+}
+
+func (m *mockAccounts) AssociateInvoice(id accounts.AccountID,
+	hash lntypes.Hash) error {
+
+	m.invoices[hash] = id
+	return nil
+}
+
+func (m *mockAccounts) TrackPayment(accounts.AccountID, lntypes.Hash,
+	lnwire.MilliSatoshi) error {
+
+	return nil
+}
+
+func (m *mockAccounts) RemovePayment(lntypes.Hash) error {
+	return nil
+}
+
+func (m *mockAccounts) RecordPaymentAttempt(accounts.AccountID,
+	lnwire.MilliSatoshi, *route.Vertex) {
+}
+
+func (m *mockAccounts) RecordPaymentFailure(accounts.AccountID,
+	lntypes.Hash) {
+}
+
+// mockLnd is a mock implementation of the lnd client that creates invoices.
+type mockLnd struct {
+	lndclient.LightningClient
+
+	nodeKey *btcec.PrivateKey
+}
+
+// AddInvoice creates a new signed invoice for the given amount.
+func (m *mockLnd) AddInvoice(_ context.Context,
+	in *invoicesrpc.AddInvoiceData) (lntypes.Hash, string, error) {
+
+	invoice, hash, err := newTestInvoice(m.nodeKey, in.Value)
+	return hash, invoice, err
+}
+
+// newTestInvoice creates a new signed invoice for the given amount.
+func newTestInvoice(nodeKey *btcec.PrivateKey,
+	amt lnwire.MilliSatoshi) (string, lntypes.Hash, error) {
+
+	preimage := lntypes.Preimage{1, 2, 3}
+	hash := preimage.Hash()
+
+	options := []func(*zpay32.Invoice){zpay32.Description("test")}
+	if amt > 0 {
+		options = append(options, zpay32.Amount(amt))
+	}
+
+	invoice, err := zpay32.NewInvoice(
+		testChainParams, hash, time.Now(), options...,
+	)
+	if err != nil {
+		return "", hash, err
+	}
+
+	payReq, err := invoice.Encode(zpay32.MessageSigner{
+		SignCompact: func(msg []byte) ([]byte, error) {
+			return ecdsa.SignCompact(
+				nodeKey, chainhash.HashB(msg), true,
+			)
+		},
+	})
+
+	return payReq, hash, err
+}
+
+// mockLockdown is a lockdown checker whose lockdown mode can be toggled.
+type mockLockdown struct {
+	active bool
+}
+
+// Active returns true if the lockdown mode is enabled.
+func (m *mockLockdown) Active() bool {
+	return m.active
+}
+
+// newTestService creates a new enabled service with a mock account service
+// that has a single account with the given balance.
+func newTestService(t *testing.T, balance int64) (*Service, *mockAccounts,
+	accounts.AccountID) {
+
+	nodeKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	accts := newMockAccounts()
+	acctID := accounts.AccountID{1}
+	accts.accts[acctID] = &accounts.OffChainBalanceAccount{
+		ID:             acctID,
+		CurrentBalance: balance,
+	}
+
+	s := NewService(&Config{
+		Enable: true,
+		Relays: []string{"wss://relay.example.com"},
+	}, t.TempDir(), accts)
+	s.lnd = &mockLnd{nodeKey: nodeKey}
+	s.payer = accounts.NewPayer(accts, nil, testChainParams, nil)
+	s.chainParams = testChainParams
+
+	s.store, err = NewStore(s.dir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.store.Close())
+	})
+
+	s.serviceKey, err = s.store.ServiceKey()
+	require.NoError(t, err)
+
+	return s, accts, acctID
+}
+
+// sendRequest encrypts and signs the given request as the given client and
+// returns the decrypted response of the service.
+func sendRequest(t *testing.T, s *Service, clientKey *btcec.PrivateKey,
+	method string, params interface{}) *response {
+
+	paramBytes, err := json.Marshal(params)
+	require.NoError(t, err)
+
+	reqBytes, err := json.Marshal(&request{
+		Method: method,
+		Params: paramBytes,
+	})
+	require.NoError(t, err)
+
+	servicePubKey := s.serviceKey.PubKey()
+	content, err := nip04Encrypt(clientKey, servicePubKey, reqBytes)
+	require.NoError(t, err)
+
+	event := newEvent(kindRequest, [][]string{
+		{"p", pubKeyHex(servicePubKey)},
+	}, content)
+	require.NoError(t, event.Sign(clientKey))
+
+	respEvent, err := s.handleRequestEvent(context.Background(), event)
+	require.NoError(t, err)
+	require.NoError(t, respEvent.Verify())
+	require.Equal(t, kindResponse, respEvent.Kind)
+	require.Equal(t, event.ID, respEvent.tag("e"))
+	require.Equal(t, event.PubKey, respEvent.tag("p"))
+
+	plaintext, err := nip04Decrypt(
+		clientKey, servicePubKey, respEvent.Content,
+	)
+	require.NoError(t, err)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(plaintext, &resp))
+	require.Equal(t, method, resp.ResultType)
+
+	return &resp
+}
+
+// TestService tests that requests of connected clients are executed on
+// behalf of their account and that unknown clients are rejected.
+func TestService(t *testing.T) {
+	s, accts, acctID := newTestService(t, 50_000)
+
+	// Connections can only be created for existing accounts.
+	_, _, err := s.AddConnection(accounts.AccountID{2}, "")
+	require.ErrorIs(t, err, accounts.ErrAccNotFound)
+
+	conn, uri, err := s.AddConnection(acctID, "my app")
+	require.NoError(t, err)
+	connURI, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, uriScheme, connURI.Scheme)
+	require.Equal(t, pubKeyHex(s.serviceKey.PubKey()), connURI.Host)
+	require.Equal(
+		t, []string{"wss://relay.example.com"},
+		connURI.Query()["relay"],
+	)
+
+	conns, err := s.Connections()
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+	require.Equal(t, conn.ClientPubKey, conns[0].ClientPubKey)
+	require.Equal(t, "my app", conns[0].Label)
+
+	// We use the secret from the URI to act as the client.
+	clientKey := privKeyFromHex(t, connURI.Query().Get("secret"))
+	require.Equal(t, conn.ClientPubKey, pubKeyHex(clientKey.PubKey()))
+
+	resp := sendRequest(t, s, clientKey, methodGetBalance, struct{}{})
+	require.Nil(t, resp.Error)
+	require.EqualValues(
+		t, 50_000, resp.Result.(map[string]interface{})["balance"],
+	)
+
+	// A new invoice is associated with the account.
+	resp = sendRequest(t, s, clientKey, methodMakeInvoice, map[string]int{
+		"amount": 10_000,
+	})
+	require.Nil(t, resp.Error)
+	result := resp.Result.(map[string]interface{})
+	require.Equal(t, "incoming", result["type"])
+	hash, err := lntypes.MakeHashFromStr(result["payment_hash"].(string))
+	require.NoError(t, err)
+	require.Equal(t, acctID, accts.invoices[hash])
+
+	// Paying an invoice that exceeds the balance is refused before lnd
+	// is involved.
+	invoice, _, err := newTestInvoice(s.serviceKey, 60_000)
+	require.NoError(t, err)
+	resp = sendRequest(t, s, clientKey, methodPayInvoice, map[string]string{
+		"invoice": invoice,
+	})
+	require.Equal(t, errCodeInsufficientBalance, resp.Error.Code)
+
+	// Invoices without an amount are not supported.
+	invoice, _, err = newTestInvoice(s.serviceKey, 0)
+	require.NoError(t, err)
+	resp = sendRequest(t, s, clientKey, methodPayInvoice, map[string]string{
+		"invoice": invoice,
+	})
+	require.Equal(t, errCodeOther, resp.Error.Code)
+
+	resp = sendRequest(t, s, clientKey, "pay_keysend", struct{}{})
+	require.Equal(t, errCodeNotImplemented, resp.Error.Code)
+
+	// All requests are rejected while the lockdown mode is enabled.
+	lockdown := &mockLockdown{active: true}
+	s.payer = accounts.NewPayer(accts, nil, testChainParams, lockdown)
+	resp = sendRequest(t, s, clientKey, methodGetBalance, struct{}{})
+	require.Equal(t, errCodeRestricted, resp.Error.Code)
+	require.Nil(t, resp.Result)
+
+	lockdown.active = false
+	resp = sendRequest(t, s, clientKey, methodGetBalance, struct{}{})
+	require.Nil(t, resp.Error)
+
+	// Once the connection is removed, the client is rejected.
+	require.NoError(t, s.RemoveConnection(conn.ClientPubKey))
+	require.ErrorIs(
+		t, s.RemoveConnection(conn.ClientPubKey), ErrConnectionNotFound,
+	)
+
+	resp = sendRequest(t, s, clientKey, methodGetBalance, struct{}{})
+	require.Equal(t, errCodeUnauthorized, resp.Error.Code)
+	require.Nil(t, resp.Result)
+}
+
+// TestStoreServiceKey tests that the service key is persisted across
+// restarts.
+func TestStoreServiceKey(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewStore(dir)
+	require.NoError(t, err)
+	key1, err := store.ServiceKey()
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	store, err = NewStore(dir)
+	require.NoError(t, err)
+	key2, err := store.ServiceKey()
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	require.Equal(t, key1.Serialize(), key2.Serialize())
+}
+
+// TestMarkSeen tests that a request is only handled once and that the number
+// of remembered requests is bounded.
+func TestMarkSeen(t *testing.T) {
+	s := NewService(&Config{}, "", nil)
+
+	require.False(t, s.markSeen("a"))
+	require.True(t, s.markSeen("a"))
+
+	for i := 0; i < maxSeenEvents; i++ {
+		s.markSeen(string(rune(i + 1000)))
+	}
+	require.Len(t, s.seen, maxSeenEvents)
+	require.False(t, s.markSeen("a"))
+}
+
+// privKeyFromHex parses a hex encoded private key.
+func privKeyFromHex(t *testing.T, keyHex string) *btcec.PrivateKey {
+	keyBytes := make([]byte, 32)
+	_, err := hex.Decode(keyBytes, []byte(keyHex))
+	require.NoError(t, err)
+
+	privKey, _ := btcec.PrivKeyFromBytes(keyBytes)
+	return privKey
+}
@@ -0,0 +1,223 @@
+package nwc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the NWC database.
+	DBFilename = "nwc.db"
+
+	// dbFilePermission is the default permission the NWC database file is
+	// created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The NWC data is stored in the following structure in the db:
+
+	service -> service-key -> 32 byte private key of the wallet service
+
+	connections -> client pubkey -> json encoded Connection
+*/
+
+var (
+	// serviceBucketKey is the key of the top level bucket holding the
+	// data of the wallet service itself.
+	serviceBucketKey = []byte("service")
+
+	// serviceKeyKey is the key under which the private key of the wallet
+	// service is stored.
+	serviceKeyKey = []byte("service-key")
+
+	// connectionsBucketKey is the key of the top level bucket holding all
+	// the connections.
+	connectionsBucketKey = []byte("connections")
+
+	// ErrConnectionNotFound is returned when a connection with the given
+	// client public key does not exist in the db.
+	ErrConnectionNotFound = errors.New("nwc connection not found")
+)
+
+// Connection maps the key of an NWC client to the lit account it spends
+// from.
+type Connection struct {
+	// ClientPubKey is the hex encoded x-only public key of the client. It
+	// uniquely identifies the connection.
+	ClientPubKey string `json:"client_pubkey"`
+
+	// AccountID is the ID of the account the connection spends from.
+	AccountID accounts.AccountID `json:"account_id"`
+
+	// Label is an optional human-readable label of the connection.
+	Label string `json:"label"`
+
+	// CreatedAt is the time the connection was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store is a bolt-backed persistent store of the wallet service key and the
+// NWC connections.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the NWC store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(serviceBucketKey)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists(connectionsBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// ServiceKey returns the private key of the wallet service. A new key is
+// created the first time this is called.
+func (s *Store) ServiceKey() (*btcec.PrivateKey, error) {
+	var privKey *btcec.PrivateKey
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(serviceBucketKey)
+
+		keyBytes := bucket.Get(serviceKeyKey)
+		if keyBytes != nil {
+			privKey, _ = btcec.PrivKeyFromBytes(keyBytes)
+			return nil
+		}
+
+		var err error
+		privKey, err = btcec.NewPrivateKey()
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(serviceKeyKey, privKey.Serialize())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return privKey, nil
+}
+
+// AddConnection stores the given connection.
+func (s *Store) AddConnection(conn *Connection) error {
+	b, err := json.Marshal(conn)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(connectionsBucketKey)
+		return bucket.Put([]byte(conn.ClientPubKey), b)
+	})
+}
+
+// Connection fetches the connection of the given client. If no such
+// connection exists, ErrConnectionNotFound is returned.
+func (s *Store) Connection(clientPubKey string) (*Connection, error) {
+	var conn *Connection
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(connectionsBucketKey)
+
+		b := bucket.Get([]byte(clientPubKey))
+		if b == nil {
+			return ErrConnectionNotFound
+		}
+
+		conn = &Connection{}
+		return json.Unmarshal(b, conn)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Connections returns all connections sorted by their creation time.
+func (s *Store) Connections() ([]*Connection, error) {
+	var conns []*Connection
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(connectionsBucketKey)
+
+		return bucket.ForEach(func(_, v []byte) error {
+			var conn Connection
+			if err := json.Unmarshal(v, &conn); err != nil {
+				return err
+			}
+
+			conns = append(conns, &conn)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(conns, func(i, j int) bool {
+		return conns[i].CreatedAt.Before(conns[j].CreatedAt)
+	})
+
+	return conns, nil
+}
+
+// RemoveConnection removes the connection of the given client. If no such
+// connection exists, ErrConnectionNotFound is returned.
+func (s *Store) RemoveConnection(clientPubKey string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(connectionsBucketKey)
+
+		if bucket.Get([]byte(clientPubKey)) == nil {
+			return ErrConnectionNotFound
+		}
+
+		return bucket.Delete([]byte(clientPubKey))
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,363 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PathPrefix is the URL path prefix of the OIDC endpoints served by
+	// litd's HTTP(S) listener.
+	PathPrefix = "/oidc/"
+
+	// LoginPath is the path the UI sends the user to for logging in with
+	// the provider.
+	LoginPath = PathPrefix + "login"
+
+	// CallbackPath is the path the provider redirects the user back to
+	// after logging in.
+	CallbackPath = PathPrefix + "callback"
+
+	// TokenFragment is the name of the URL fragment parameter the session
+	// token is handed to the UI in after a successful login. The UI sends
+	// the token as a bearer token in the authorization header.
+	TokenFragment = "oidc_token"
+
+	// loginTimeout is the maximum time a user has to log in with the
+	// provider.
+	loginTimeout = 10 * time.Minute
+
+	// maxPendingLogins is the maximum number of logins that can be in
+	// progress at the same time.
+	maxPendingLogins = 1000
+
+	// httpTimeout is the timeout of the requests to the provider.
+	httpTimeout = 10 * time.Second
+)
+
+// Session is a UI session created by logging in with the provider.
+type Session struct {
+	// Subject is the subject of the ID token the session was created
+	// with.
+	Subject string `json:"sub"`
+
+	// Role is the lit role the user was mapped to.
+	Role Role `json:"role"`
+
+	// Expiry is the unix timestamp after which the session is no longer
+	// valid.
+	Expiry int64 `json:"exp"`
+}
+
+// pendingLogin is a login that was started but not yet completed.
+type pendingLogin struct {
+	nonce     string
+	verifier  string
+	createdAt time.Time
+}
+
+// Authenticator implements the OpenID Connect authorization code flow for the
+// web UI. After a successful login, the user is issued a lit session token
+// that carries the role of the user and is validated on each request.
+type Authenticator struct {
+	cfg      *Config
+	provider *provider
+
+	// sessionKey is the key the session tokens are authenticated with. It
+	// only lives in memory, so all sessions end when litd restarts.
+	sessionKey []byte
+
+	mu      sync.Mutex
+	pending map[string]*pendingLogin
+}
+
+// NewAuthenticator creates a new authenticator for the given config.
+func NewAuthenticator(cfg *Config) (*Authenticator, error) {
+	sessionKey := make([]byte, 32)
+	if _, err := rand.Read(sessionKey); err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: httpTimeout}
+
+	return &Authenticator{
+		cfg:        cfg,
+		provider:   newProvider(cfg.IssuerURL, client),
+		sessionKey: sessionKey,
+		pending:    make(map[string]*pendingLogin),
+	}, nil
+}
+
+// ServeHTTP serves the login and callback endpoints of the OIDC flow.
+func (a *Authenticator) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch req.URL.Path {
+	case LoginPath:
+		a.handleLogin(resp, req)
+
+	case CallbackPath:
+		a.handleCallback(resp, req)
+
+	default:
+		http.NotFound(resp, req)
+	}
+}
+
+// handleLogin starts a new login by redirecting the user to the provider.
+func (a *Authenticator) handleLogin(resp http.ResponseWriter,
+	req *http.Request) {
+
+	providerCfg, err := a.provider.configuration(req.Context())
+	if err != nil {
+		log.Errorf("Unable to start OIDC login: %v", err)
+		http.Error(resp, "identity provider unavailable",
+			http.StatusBadGateway)
+		return
+	}
+
+	state, nonce, verifier, err := a.newPendingLogin()
+	if err != nil {
+		log.Errorf("Unable to start OIDC login: %v", err)
+		http.Error(resp, "unable to start login",
+			http.StatusServiceUnavailable)
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	query := url.Values{
+		"response_type": []string{"code"},
+		"client_id":     []string{a.cfg.ClientID},
+		"redirect_uri":  []string{a.cfg.RedirectURL},
+		"scope": []string{strings.Join(
+			append([]string{"openid"}, a.cfg.Scopes...), " ",
+		)},
+		"state": []string{state},
+		"nonce": []string{nonce},
+		"code_challenge": []string{
+			base64.RawURLEncoding.EncodeToString(challenge[:]),
+		},
+		"code_challenge_method": []string{"S256"},
+	}
+
+	authURL := providerCfg.AuthorizationEndpoint
+	if strings.Contains(authURL, "?") {
+		authURL += "&" + query.Encode()
+	} else {
+		authURL += "?" + query.Encode()
+	}
+
+	http.Redirect(resp, req, authURL, http.StatusFound)
+}
+
+// handleCallback completes a login after the provider redirected the user
+// back to litd and hands the new session token to the UI.
+func (a *Authenticator) handleCallback(resp http.ResponseWriter,
+	req *http.Request) {
+
+	query := req.URL.Query()
+	if errCode := query.Get("error"); errCode != "" {
+		log.Infof("OIDC login failed: %s", errCode)
+		http.Error(resp, "login failed", http.StatusUnauthorized)
+		return
+	}
+
+	login, ok := a.popPendingLogin(query.Get("state"))
+	if !ok {
+		http.Error(resp, "unknown or expired login",
+			http.StatusBadRequest)
+		return
+	}
+
+	token, session, err := a.completeLogin(
+		req, query.Get("code"), login,
+	)
+	if err != nil {
+		log.Infof("OIDC login failed: %v", err)
+		http.Error(resp, "login failed", http.StatusUnauthorized)
+		return
+	}
+
+	log.Infof("User %s logged in through OIDC with role %s",
+		session.Subject, session.Role)
+
+	// The token is handed over in the URL fragment, which is never sent
+	// to a server, so it doesn't end up in any logs.
+	fragment := url.Values{TokenFragment: []string{token}}
+	http.Redirect(resp, req, "/#"+fragment.Encode(), http.StatusFound)
+}
+
+// completeLogin exchanges the authorization code for an ID token, validates
+// it and issues a session token for the role of the user.
+func (a *Authenticator) completeLogin(req *http.Request, code string,
+	login *pendingLogin) (string, *Session, error) {
+
+	if code == "" {
+		return "", nil, fmt.Errorf("missing authorization code")
+	}
+
+	idToken, err := a.provider.exchangeCode(
+		req.Context(), a.cfg, code, login.verifier,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims, err := a.provider.verifyIDToken(
+		req.Context(), a.cfg, idToken, login.nonce,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	subject, _ := claims["sub"].(string)
+	groups := stringsClaim(claims, a.cfg.GroupsClaim)
+	role, ok := a.cfg.roleForGroups(groups)
+	if !ok {
+		return "", nil, fmt.Errorf("user %s has no mapped group",
+			subject)
+	}
+
+	session := &Session{
+		Subject: subject,
+		Role:    role,
+		Expiry:  time.Now().Add(a.cfg.SessionExpiry).Unix(),
+	}
+	token, err := a.issueToken(session)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, session, nil
+}
+
+// ValidateToken validates the given session token and returns the session it
+// belongs to.
+func (a *Authenticator) ValidateToken(token string) (*Session, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if !hmac.Equal(sig, a.sign(parts[0])) {
+		return nil, ErrInvalidToken
+	}
+
+	var session Session
+	if err := decodeSegment(parts[0], &session); err != nil {
+		return nil, err
+	}
+
+	if time.Unix(session.Expiry, 0).Before(time.Now()) {
+		return nil, fmt.Errorf("%w: session expired", ErrInvalidToken)
+	}
+
+	return &session, nil
+}
+
+// issueToken creates a session token for the given session.
+func (a *Authenticator) issueToken(session *Session) (string, error) {
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	sig := base64.RawURLEncoding.EncodeToString(a.sign(encoded))
+
+	return encoded + "." + sig, nil
+}
+
+// sign returns the MAC of the given session token payload.
+func (a *Authenticator) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, a.sessionKey)
+	_, _ = mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
+
+// newPendingLogin creates and stores the state, nonce and PKCE verifier of a
+// new login.
+func (a *Authenticator) newPendingLogin() (string, string, string, error) {
+	state, err := randomString()
+	if err != nil {
+		return "", "", "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", "", "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Clean up the logins that were never completed.
+	for s, login := range a.pending {
+		if time.Since(login.createdAt) > loginTimeout {
+			delete(a.pending, s)
+		}
+	}
+
+	if len(a.pending) >= maxPendingLogins {
+		return "", "", "", fmt.Errorf("too many pending logins")
+	}
+
+	a.pending[state] = &pendingLogin{
+		nonce:     nonce,
+		verifier:  verifier,
+		createdAt: time.Now(),
+	}
+
+	return state, nonce, verifier, nil
+}
+
+// popPendingLogin removes and returns the pending login with the given state
+// if it didn't time out yet.
+func (a *Authenticator) popPendingLogin(state string) (*pendingLogin, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	login, ok := a.pending[state]
+	if !ok {
+		return nil, false
+	}
+	delete(a.pending, state)
+
+	if time.Since(login.createdAt) > loginTimeout {
+		return nil, false
+	}
+
+	return login, true
+}
+
+// randomString returns a random base64url encoded string with 256 bits of
+// entropy.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
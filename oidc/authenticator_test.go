@@ -0,0 +1,306 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const testClientID = "lit"
+
+// testProvider is a minimal OpenID provider that issues ID tokens for the
+// authorization code flow.
+type testProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// groups are the groups of the user in the issued ID tokens.
+	groups []string
+
+	// nonce and challenge are taken from the last authorization request.
+	nonce     string
+	challenge string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testProvider{t: t, key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter,
+		_ *http.Request) {
+
+		_ = json.NewEncoder(w).Encode(&providerConfig{
+			Issuer:                p.server.URL,
+			AuthorizationEndpoint: p.server.URL + "/authorize",
+			TokenEndpoint:         p.server.URL + "/token",
+			JWKSURI:               p.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		e := big.NewInt(int64(key.PublicKey.E)).Bytes()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []jsonWebKey{{
+				Kty: "RSA",
+				Kid: "test",
+				Use: "sig",
+				N: base64.RawURLEncoding.EncodeToString(
+					key.PublicKey.N.Bytes(),
+				),
+				E: base64.RawURLEncoding.EncodeToString(e),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", p.handleToken)
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+// handleToken exchanges the code for a signed ID token after checking the
+// PKCE verifier.
+func (p *testProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	require.NoError(p.t, r.ParseForm())
+	require.Equal(p.t, "authorization_code", r.Form.Get("grant_type"))
+	require.Equal(p.t, testClientID, r.Form.Get("client_id"))
+
+	challenge := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != p.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"id_token": p.idToken(map[string]interface{}{
+			"iss":    p.server.URL,
+			"aud":    testClientID,
+			"sub":    "alice",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"nonce":  p.nonce,
+			"groups": p.groups,
+		}),
+	})
+}
+
+// idToken creates an ID token with the given claims signed by the provider.
+func (p *testProvider) idToken(claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256", "kid": "test",
+	})
+	require.NoError(p.t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(p.t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256,
+		digest[:])
+	require.NoError(p.t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// newTestAuthenticator creates an authenticator for the given provider.
+func newTestAuthenticator(t *testing.T, p *testProvider) *Authenticator {
+	cfg := DefaultConfig()
+	cfg.Enable = true
+	cfg.IssuerURL = p.server.URL
+	cfg.ClientID = testClientID
+	cfg.RedirectURL = "https://lit.example.com" + CallbackPath
+	cfg.RoleMappings = []string{"lit-admins:admin", "lit-users:readonly"}
+	require.NoError(t, cfg.Validate())
+
+	a, err := NewAuthenticator(cfg)
+	require.NoError(t, err)
+
+	return a
+}
+
+// login runs the authorization code flow against the test provider and
+// returns the response of the callback.
+func login(t *testing.T, a *Authenticator,
+	p *testProvider) *httptest.ResponseRecorder {
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LoginPath, nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	authURL, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, p.server.URL+"/authorize",
+		authURL.Scheme+"://"+authURL.Host+authURL.Path)
+
+	authQuery := authURL.Query()
+	require.Equal(t, "S256", authQuery.Get("code_challenge_method"))
+	p.nonce = authQuery.Get("nonce")
+	p.challenge = authQuery.Get("code_challenge")
+
+	callback := CallbackPath + "?" + url.Values{
+		"code":  []string{"code"},
+		"state": []string{authQuery.Get("state")},
+	}.Encode()
+
+	rec = httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, callback, nil))
+
+	return rec
+}
+
+// TestLogin tests that a user is issued a session token with the role its
+// groups are mapped to.
+func TestLogin(t *testing.T) {
+	p := newTestProvider(t)
+	a := newTestAuthenticator(t, p)
+
+	p.groups = []string{"lit-users", "lit-admins", "other"}
+	rec := login(t, a, p)
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	redirect, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "/", redirect.Path)
+
+	fragment, err := url.ParseQuery(redirect.Fragment)
+	require.NoError(t, err)
+
+	session, err := a.ValidateToken(fragment.Get(TokenFragment))
+	require.NoError(t, err)
+	require.Equal(t, "alice", session.Subject)
+	require.Equal(t, RoleAdmin, session.Role)
+
+	// A tampered token is rejected.
+	_, err = a.ValidateToken(fragment.Get(TokenFragment) + "x")
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	// The state of a login can't be used twice.
+	callback := CallbackPath + "?code=code&state=unknown"
+	rec = httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, callback, nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestLoginWithoutRole tests that users without a mapped group can't log in.
+func TestLoginWithoutRole(t *testing.T) {
+	p := newTestProvider(t)
+	a := newTestAuthenticator(t, p)
+
+	p.groups = []string{"other"}
+	rec := login(t, a, p)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+// TestVerifyIDToken tests the validation of the ID token claims.
+func TestVerifyIDToken(t *testing.T) {
+	p := newTestProvider(t)
+	a := newTestAuthenticator(t, p)
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":   p.server.URL,
+			"aud":   []string{"other", testClientID},
+			"sub":   "alice",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": "nonce",
+		}
+	}
+
+	ctx := context.Background()
+	_, err := a.provider.verifyIDToken(
+		ctx, a.cfg, p.idToken(valid()), "nonce",
+	)
+	require.NoError(t, err)
+
+	tests := map[string]func(claims map[string]interface{}){
+		"wrong issuer": func(c map[string]interface{}) {
+			c["iss"] = "https://evil.example.com"
+		},
+		"wrong audience": func(c map[string]interface{}) {
+			c["aud"] = "other"
+		},
+		"expired": func(c map[string]interface{}) {
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+		},
+		"wrong nonce": func(c map[string]interface{}) {
+			c["nonce"] = "other"
+		},
+	}
+	for name, modify := range tests {
+		claims := valid()
+		modify(claims)
+
+		_, err := a.provider.verifyIDToken(
+			ctx, a.cfg, p.idToken(claims), "nonce",
+		)
+		require.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+
+	// A token signed by another key is rejected.
+	other := newTestProvider(t)
+	_, err = a.provider.verifyIDToken(
+		ctx, a.cfg, other.idToken(valid()), "nonce",
+	)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+// TestRoleAllows tests the permissions granted by the roles.
+func TestRoleAllows(t *testing.T) {
+	read := []bakery.Op{{Entity: "info", Action: "read"}}
+	write := []bakery.Op{
+		{Entity: "info", Action: "read"},
+		{Entity: "offchain", Action: "write"},
+	}
+
+	require.True(t, RoleAdmin.Allows(read))
+	require.True(t, RoleAdmin.Allows(write))
+	require.True(t, RoleReadOnly.Allows(read))
+	require.False(t, RoleReadOnly.Allows(write))
+	require.False(t, Role("unknown").Allows(read))
+}
+
+// TestConfigValidate tests the validation of the role mappings.
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Enable = true
+	cfg.IssuerURL = "https://accounts.example.com/"
+	cfg.ClientID = testClientID
+	cfg.RedirectURL = "https://lit.example.com/oidc/callback"
+
+	require.Error(t, cfg.Validate())
+
+	cfg.RoleMappings = []string{"team:ops:readonly"}
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, "https://accounts.example.com", cfg.IssuerURL)
+
+	role, ok := cfg.roleForGroups([]string{"team:ops"})
+	require.True(t, ok)
+	require.Equal(t, RoleReadOnly, role)
+
+	cfg.RoleMappings = []string{"team:superuser"}
+	require.Error(t, cfg.Validate())
+
+	cfg.RoleMappings = []string{"team:admin"}
+	cfg.RedirectURL = "https://lit.example.com/callback"
+	require.Error(t, cfg.Validate())
+}
@@ -0,0 +1,187 @@
+package oidc
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	// DefaultGroupsClaim is the default ID token claim that holds the
+	// groups of a user.
+	DefaultGroupsClaim = "groups"
+
+	// DefaultSessionExpiry is the default duration a UI session created
+	// through OIDC stays valid.
+	DefaultSessionExpiry = 12 * time.Hour
+)
+
+// Role is a lit role a user of the OIDC provider is mapped to. The role
+// determines which RPCs the user can call through the web UI.
+type Role string
+
+const (
+	// RoleAdmin grants the same permissions as the UI password.
+	RoleAdmin Role = "admin"
+
+	// RoleReadOnly only grants the permissions of read operations.
+	RoleReadOnly Role = "readonly"
+)
+
+// Allows returns true if the role grants all the given permissions.
+func (r Role) Allows(ops []bakery.Op) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+
+	case RoleReadOnly:
+		for _, op := range ops {
+			if op.Action != "read" {
+				return false
+			}
+		}
+
+		return true
+
+	default:
+		return false
+	}
+}
+
+// rank orders the roles by the permissions they grant.
+func (r Role) rank() int {
+	switch r {
+	case RoleAdmin:
+		return 2
+
+	case RoleReadOnly:
+		return 1
+
+	default:
+		return 0
+	}
+}
+
+// parseRole parses the name of a role.
+func parseRole(name string) (Role, error) {
+	switch Role(name) {
+	case RoleAdmin, RoleReadOnly:
+		return Role(name), nil
+
+	default:
+		return "", fmt.Errorf("unknown role %s, must be %s or %s",
+			name, RoleAdmin, RoleReadOnly)
+	}
+}
+
+// Config holds all config options for logging into the web UI with an OpenID
+// Connect provider.
+type Config struct {
+	Enable        bool          `long:"enable" description:"Allow logging into the web UI with an OpenID Connect provider. Logging in with the UI password stays possible."`
+	IssuerURL     string        `long:"issuer" description:"The issuer URL of the OpenID Connect provider, for example https://accounts.example.com. The provider configuration is discovered from <issuer>/.well-known/openid-configuration."`
+	ClientID      string        `long:"clientid" description:"The client ID litd is registered with at the provider."`
+	ClientSecret  string        `long:"clientsecret" description:"The client secret litd is registered with at the provider."`
+	RedirectURL   string        `long:"redirecturl" description:"The public URL of litd's OIDC callback as registered with the provider, for example https://lit.example.com/oidc/callback."`
+	Scopes        []string      `long:"scope" description:"An additional scope to request besides openid, for example groups. Can be specified multiple times."`
+	GroupsClaim   string        `long:"groupsclaim" description:"The ID token claim that holds the groups of the user."`
+	RoleMappings  []string      `long:"rolemapping" description:"Maps a group of the user to a lit role in the form group:role. The available roles are admin and readonly. Users without a mapped group can't log in. Can be specified multiple times."`
+	SessionExpiry time.Duration `long:"sessionexpiry" description:"How long a UI session created through OIDC stays valid."`
+
+	// roles maps the groups of the provider to the lit roles. It is
+	// populated from the role mappings when validating the config.
+	roles map[string]Role
+}
+
+// DefaultConfig constructs the default OIDC Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		GroupsClaim:   DefaultGroupsClaim,
+		SessionExpiry: DefaultSessionExpiry,
+	}
+}
+
+// Validate makes sure the config is sane if OIDC login is enabled.
+func (c *Config) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	issuer, err := url.Parse(c.IssuerURL)
+	if err != nil || issuer.Host == "" ||
+		(issuer.Scheme != "https" && issuer.Scheme != "http") {
+
+		return fmt.Errorf("invalid oidc.issuer %s", c.IssuerURL)
+	}
+	c.IssuerURL = strings.TrimSuffix(c.IssuerURL, "/")
+
+	if c.ClientID == "" {
+		return fmt.Errorf("oidc.clientid must be set")
+	}
+
+	redirect, err := url.Parse(c.RedirectURL)
+	if err != nil || redirect.Host == "" ||
+		(redirect.Scheme != "https" && redirect.Scheme != "http") {
+
+		return fmt.Errorf("invalid oidc.redirecturl %s", c.RedirectURL)
+	}
+	if redirect.Path != CallbackPath {
+		return fmt.Errorf("the path of oidc.redirecturl must be %s",
+			CallbackPath)
+	}
+
+	if c.GroupsClaim == "" {
+		return fmt.Errorf("oidc.groupsclaim must be set")
+	}
+
+	if c.SessionExpiry <= 0 {
+		return fmt.Errorf("oidc.sessionexpiry must be positive")
+	}
+
+	if len(c.RoleMappings) == 0 {
+		return fmt.Errorf("at least one oidc.rolemapping must be set")
+	}
+
+	c.roles = make(map[string]Role, len(c.RoleMappings))
+	for _, mapping := range c.RoleMappings {
+		idx := strings.LastIndex(mapping, ":")
+		if idx <= 0 {
+			return fmt.Errorf("invalid oidc.rolemapping %s, must "+
+				"be in the form group:role", mapping)
+		}
+
+		role, err := parseRole(mapping[idx+1:])
+		if err != nil {
+			return fmt.Errorf("invalid oidc.rolemapping %s: %v",
+				mapping, err)
+		}
+
+		c.roles[mapping[:idx]] = role
+	}
+
+	return nil
+}
+
+// roleForGroups returns the role with the most permissions any of the given
+// groups is mapped to.
+func (c *Config) roleForGroups(groups []string) (Role, bool) {
+	var (
+		role  Role
+		found bool
+	)
+	for _, group := range groups {
+		r, ok := c.roles[group]
+		if !ok {
+			continue
+		}
+
+		if !found || r.rank() > role.rank() {
+			role = r
+			found = true
+		}
+	}
+
+	return role, found
+}
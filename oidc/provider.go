@@ -0,0 +1,447 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// discoveryPath is the path of the provider configuration relative to
+	// the issuer URL.
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// clockSkew is the tolerance when checking the time claims of an ID
+	// token.
+	clockSkew = time.Minute
+
+	// maxResponseSize is the maximum size of a response of the provider we
+	// read.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	// ErrInvalidToken is returned if an ID token or a lit session token is
+	// invalid.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// providerConfig is the subset of the OpenID provider metadata that we need.
+type providerConfig struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// jsonWebKey is a single public key of the provider's key set.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey converts the JSON web key into a public key. Only RSA and P-256
+// keys are supported.
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+// provider fetches and caches the configuration and the signing keys of the
+// OpenID provider.
+type provider struct {
+	issuer string
+	client *http.Client
+
+	mu     sync.Mutex
+	config *providerConfig
+	keys   map[string]crypto.PublicKey
+}
+
+// newProvider creates a new provider for the given issuer.
+func newProvider(issuer string, client *http.Client) *provider {
+	return &provider{
+		issuer: issuer,
+		client: client,
+	}
+}
+
+// configuration returns the provider configuration. It is discovered the first
+// time it is needed so that litd can start even if the provider is down.
+func (p *provider) configuration(ctx context.Context) (*providerConfig,
+	error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config != nil {
+		return p.config, nil
+	}
+
+	var cfg providerConfig
+	err := p.getJSON(ctx, p.issuer+discoveryPath, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover provider: %v", err)
+	}
+
+	if strings.TrimSuffix(cfg.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("provider issuer %s doesn't match "+
+			"configured issuer %s", cfg.Issuer, p.issuer)
+	}
+
+	if cfg.AuthorizationEndpoint == "" || cfg.TokenEndpoint == "" ||
+		cfg.JWKSURI == "" {
+
+		return nil, fmt.Errorf("incomplete provider configuration")
+	}
+
+	p.config = &cfg
+
+	return p.config, nil
+}
+
+// key returns the signing key with the given ID. The key set is re-fetched if
+// the key is unknown, so that rotated keys are picked up.
+func (p *provider) key(ctx context.Context, kid string) (crypto.PublicKey,
+	error) {
+
+	cfg, err := p.configuration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	var keySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, cfg.JWKSURI, &keySet); err != nil {
+		return nil, fmt.Errorf("unable to fetch provider keys: %v", err)
+	}
+
+	p.keys = make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, k := range keySet.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			log.Debugf("Skipping provider key %s: %v", k.Kid, err)
+			continue
+		}
+
+		p.keys[k.Kid] = key
+	}
+
+	key, ok := p.lookupKey(kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %s",
+			ErrInvalidToken, kid)
+	}
+
+	return key, nil
+}
+
+// lookupKey returns the cached key with the given ID. If the token doesn't
+// specify a key ID, the only key of the provider is used. The caller must hold
+// the mutex.
+func (p *provider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// exchangeCode exchanges the given authorization code for an ID token at the
+// token endpoint of the provider.
+func (p *provider) exchangeCode(ctx context.Context, cfg *Config, code,
+	verifier string) (string, error) {
+
+	providerCfg, err := p.configuration(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"code":          []string{code},
+		"redirect_uri":  []string{cfg.RedirectURL},
+		"client_id":     []string{cfg.ClientID},
+		"code_verifier": []string{verifier},
+	}
+	if cfg.ClientSecret != "" {
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, providerCfg.TokenEndpoint,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+		Error   string `json:"error"`
+	}
+	if err := p.doJSON(req, &tokenResp); err != nil {
+		return "", fmt.Errorf("unable to exchange code: %v", err)
+	}
+
+	if tokenResp.IDToken == "" {
+		return "", fmt.Errorf("provider didn't return an ID token: %s",
+			tokenResp.Error)
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+// verifyIDToken verifies the signature and the standard claims of the given
+// ID token and returns its claims.
+func (p *provider) verifyIDToken(ctx context.Context, cfg *Config, raw,
+	nonce string) (map[string]interface{}, error) {
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed ID token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	key, err := p.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !verifySignature(header.Alg, key, digest[:], sig) {
+		return nil, fmt.Errorf("%w: invalid signature", ErrInvalidToken)
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if iss, _ := claims["iss"].(string); iss != p.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %s",
+			ErrInvalidToken, iss)
+	}
+
+	if !hasAudience(claims["aud"], cfg.ClientID) {
+		return nil, fmt.Errorf("%w: unexpected audience",
+			ErrInvalidToken)
+	}
+
+	exp, _ := claims["exp"].(float64)
+	if time.Unix(int64(exp), 0).Add(clockSkew).Before(time.Now()) {
+		return nil, fmt.Errorf("%w: ID token expired", ErrInvalidToken)
+	}
+
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// getJSON fetches the given URL and decodes the JSON response.
+func (p *provider) getJSON(ctx context.Context, rawURL string,
+	v interface{}) error {
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, rawURL, nil,
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	return p.doJSON(req, v)
+}
+
+// doJSON sends the given request and decodes the JSON response.
+func (p *provider) doJSON(req *http.Request, v interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status,
+			strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// verifySignature verifies the JWS signature of the given digest with the
+// given algorithm.
+func verifySignature(alg string, key crypto.PublicKey, digest,
+	sig []byte) bool {
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return false
+		}
+
+		err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, sig)
+		return err == nil
+
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(sig) != 64 {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(ecKey, digest, r, s)
+
+	default:
+		return false
+	}
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a JWT.
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return nil
+}
+
+// hasAudience returns true if the given aud claim, which is either a string or
+// a list of strings, contains the given client ID.
+func hasAudience(aud interface{}, clientID string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == clientID
+
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// stringsClaim returns the given claim as a list of strings. A single string
+// is treated as a list with one element.
+func stringsClaim(claims map[string]interface{}, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+
+	default:
+		return nil
+	}
+}
@@ -3,6 +3,7 @@ package perms
 import (
 	"net"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/watchtowerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/wtclientrpc"
 	"github.com/lightningnetwork/lnd/lntest/mock"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/sweep"
 	"gopkg.in/macaroon-bakery.v2/bakery"
@@ -42,10 +44,41 @@ var (
 			Entity: "sessions",
 			Action: "read",
 		}},
+		"/litrpc.Sessions/GetSession": {{
+			Entity: "sessions",
+			Action: "read",
+		}},
 		"/litrpc.Sessions/RevokeSession": {{
 			Entity: "sessions",
 			Action: "write",
 		}},
+		"/litrpc.Sessions/UpdateSession": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
+		"/litrpc.Sessions/Search": {{
+			Entity: "sessions",
+			Action: "read",
+		}, {
+			Entity: "account",
+			Action: "read",
+		}},
+		"/litrpc.Sessions/ExportSessionPairing": {{
+			Entity: "sessions",
+			Action: "read",
+		}},
+		"/litrpc.Sessions/ImportSessionPairing": {{
+			Entity: "sessions",
+			Action: "read",
+		}},
+		"/litrpc.Sessions/ListConnectionAttempts": {{
+			Entity: "sessions",
+			Action: "read",
+		}},
+		"/litrpc.Sessions/ImportMacaroon": {{
+			Entity: "sessions",
+			Action: "write",
+		}},
 		"/litrpc.Accounts/CreateAccount": {{
 			Entity: "account",
 			Action: "write",
@@ -58,14 +91,73 @@ var (
 			Entity: "account",
 			Action: "read",
 		}},
+		"/litrpc.Accounts/AccountInfo": {{
+			Entity: "account",
+			Action: "read",
+		}},
 		"/litrpc.Accounts/RemoveAccount": {{
 			Entity: "account",
 			Action: "write",
 		}},
+		"/litrpc.Accounts/CreateInvoices": {{
+			Entity: "account",
+			Action: "write",
+		}, {
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/litrpc.Accounts/SimulateInvoice": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.Accounts/SimulatePayment": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.Accounts/FreezeAccount": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.Accounts/CleanupAccounts": {{
+			Entity: "account",
+			Action: "write",
+		}},
 		"/litrpc.Firewall/ListActions": {{
 			Entity: "actions",
 			Action: "read",
 		}},
+		"/litrpc.Firewall/SubscribeActions": {{
+			Entity: "actions",
+			Action: "read",
+		}},
+		"/litrpc.Firewall/VerifyActionLog": {{
+			Entity: "actions",
+			Action: "read",
+		}},
+		"/litrpc.Firewall/AuditTrail": {{
+			Entity: "actions",
+			Action: "read",
+		}},
+		"/litrpc.Firewall/BillingExport": {{
+			Entity: "actions",
+			Action: "read",
+		}},
+		"/litrpc.Firewall/SessionActivity": {{
+			Entity: "actions",
+			Action: "read",
+		}},
+		"/litrpc.Firewall/GetFirewallSettings": {{
+			Entity: "firewall",
+			Action: "read",
+		}},
+		"/litrpc.Firewall/UpdateFirewallSettings": {{
+			Entity: "firewall",
+			Action: "write",
+		}},
+		"/litrpc.Firewall/ListRuleMetrics": {{
+			Entity: "firewall",
+			Action: "read",
+		}},
 		"/litrpc.Autopilot/ListAutopilotFeatures": {{
 			Entity: "autopilot",
 			Action: "read",
@@ -82,6 +174,26 @@ var (
 			Entity: "autopilot",
 			Action: "write",
 		}},
+		"/litrpc.Autopilot/ListRuleBundles": {{
+			Entity: "autopilot",
+			Action: "read",
+		}},
+		"/litrpc.Autopilot/GetRuleBundle": {{
+			Entity: "autopilot",
+			Action: "read",
+		}},
+		"/litrpc.Autopilot/SetRuleBundle": {{
+			Entity: "autopilot",
+			Action: "write",
+		}},
+		"/litrpc.Autopilot/RemoveRuleBundle": {{
+			Entity: "autopilot",
+			Action: "write",
+		}},
+		"/litrpc.Autopilot/UpdateSessionRules": {{
+			Entity: "autopilot",
+			Action: "write",
+		}},
 		"/litrpc.Firewall/PrivacyMapConversion": {{
 			Entity: "privacymap",
 			Action: "read",
@@ -94,6 +206,251 @@ var (
 			Entity: "proxy",
 			Action: "read",
 		}},
+		"/litrpc.Proxy/GetAdminChallenge": {{
+			Entity: "proxy",
+			Action: "read",
+		}},
+		"/litrpc.Proxy/GetConnectURI": {{
+			Entity: "macaroon",
+			Action: "generate",
+		}},
+		"/litrpc.Proxy/ListAuthLockouts": {{
+			Entity: "proxy",
+			Action: "read",
+		}},
+		"/litrpc.Proxy/ClearAuthLockout": {{
+			Entity: "proxy",
+			Action: "write",
+		}},
+		"/litrpc.Proxy/InspectMacaroon": {{
+			Entity: "macaroon",
+			Action: "read",
+		}},
+		"/litrpc.Backups/ExportChannelBackup": {{
+			Entity: "backup",
+			Action: "read",
+		}},
+		"/litrpc.Backups/GetBackupStatus": {{
+			Entity: "backup",
+			Action: "read",
+		}},
+		"/litrpc.Reports/ForwardingReport": {{
+			Entity: "reports",
+			Action: "read",
+		}},
+		"/litrpc.Reports/RebalanceReport": {{
+			Entity: "reports",
+			Action: "read",
+		}},
+		"/litrpc.Reports/SendTestReport": {{
+			Entity: "reports",
+			Action: "write",
+		}},
+		"/litrpc.Reports/DashboardSummary": {{
+			Entity: "reports",
+			Action: "read",
+		}},
+		"/litrpc.NostrWalletConnect/AddConnection": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.NostrWalletConnect/ListConnections": {{
+			Entity: "account",
+			Action: "read",
+		}},
+		"/litrpc.NostrWalletConnect/RemoveConnection": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.LnurlWithdraw/CreateVoucher": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.LnurlWithdraw/ListVouchers": {{
+			Entity: "account",
+			Action: "read",
+		}},
+		"/litrpc.LnurlWithdraw/RevokeVoucher": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.AccountPortal/CreatePortalToken": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.AccountPortal/ListPortalTokens": {{
+			Entity: "account",
+			Action: "read",
+		}},
+		"/litrpc.AccountPortal/RevokePortalToken": {{
+			Entity: "account",
+			Action: "write",
+		}},
+		"/litrpc.ApiKeys/CreateApiKey": {{
+			Entity: "macaroon",
+			Action: "generate",
+		}},
+		"/litrpc.ApiKeys/ListApiKeys": {{
+			Entity: "macaroon",
+			Action: "read",
+		}},
+		"/litrpc.ApiKeys/RevokeApiKey": {{
+			Entity: "macaroon",
+			Action: "write",
+		}},
+		"/litrpc.NodeManagement/OpenChannel": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.NodeManagement/CloseChannel": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.NodeManagement/UpdateChannelPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.NodeManagement/SetAlias": {{
+			Entity: "peers",
+			Action: "write",
+		}},
+		"/litrpc.FeeScheduler/SetFeePolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.FeeScheduler/ListFeePolicies": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/litrpc.FeeScheduler/RemoveFeePolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.FeeScheduler/ApplyFeePolicies": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.Watchdog/ListAlerts": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/litrpc.Watchdog/SetAccountNotifications": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.Watchdog/ListAccountNotifications": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/litrpc.Watchdog/RemoveAccountNotifications": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/litrpc.Guardrails/GetGuardrails": {{
+			Entity: "swap",
+			Action: "read",
+		}},
+		"/litrpc.Guardrails/OverrideGuardrails": {{
+			Entity: "swap",
+			Action: "execute",
+		}, {
+			Entity: "macaroon",
+			Action: "generate",
+		}},
+		"/litrpc.UIFlags/GetUIFlags": {{
+			Entity: "uiflags",
+			Action: "read",
+		}},
+		"/litrpc.UIFlags/SubscribeUIFlags": {{
+			Entity: "uiflags",
+			Action: "read",
+		}},
+		"/litrpc.UIFlags/ListUIFlagSettings": {{
+			Entity: "uiflags",
+			Action: "read",
+		}},
+		"/litrpc.UIFlags/SetUIFlag": {{
+			Entity: "uiflags",
+			Action: "write",
+		}},
+		"/litrpc.UIFlags/RemoveUIFlag": {{
+			Entity: "uiflags",
+			Action: "write",
+		}},
+		"/litrpc.Inbox/ListNotifications": {{
+			Entity: "inbox",
+			Action: "read",
+		}},
+		"/litrpc.Inbox/MarkNotificationsRead": {{
+			Entity: "inbox",
+			Action: "write",
+		}},
+		"/litrpc.Status/GetStatus": {{
+			Entity: "status",
+			Action: "read",
+		}},
+		"/litrpc.Status/TailLogs": {{
+			Entity: "status",
+			Action: "logs",
+		}},
+		"/litrpc.Status/RecentErrors": {{
+			Entity: "status",
+			Action: "read",
+		}},
+		"/litrpc.Status/LockdownMode": {{
+			Entity: "status",
+			Action: "write",
+		}},
+		"/litrpc.Status/ScheduleMaintenance": {{
+			Entity: "status",
+			Action: "write",
+		}},
+		"/litrpc.Status/ListMaintenanceWindows": {{
+			Entity: "status",
+			Action: "read",
+		}},
+		"/litrpc.Status/CancelMaintenance": {{
+			Entity: "status",
+			Action: "write",
+		}},
+		"/litrpc.Status/ReloadConfig": {{
+			Entity: "status",
+			Action: "write",
+		}},
+		"/litrpc.Status/ListConfigReloads": {{
+			Entity: "status",
+			Action: "read",
+		}},
+		"/litrpc.Status/SubscribeShutdown": {{
+			Entity: "status",
+			Action: "read",
+		}},
+		"/litrpc.Provisioning/ExportSpec": {{
+			Entity: "account",
+			Action: "read",
+		}, {
+			Entity: "sessions",
+			Action: "read",
+		}, {
+			Entity: "autopilot",
+			Action: "read",
+		}},
+		"/litrpc.Provisioning/ApplySpec": {{
+			Entity: "account",
+			Action: "write",
+		}, {
+			Entity: "sessions",
+			Action: "write",
+		}, {
+			Entity: "autopilot",
+			Action: "write",
+		}},
 	}
 
 	// whiteListedLNDMethods is a map of all lnd RPC methods that don't
@@ -341,6 +698,51 @@ func (pm *Manager) ActivePermissions(readOnly bool) []bakery.Op {
 	return result
 }
 
+// GrantedURIs returns the URIs of all methods that the given permissions allow
+// to call, in alphabetical order. A method is allowed if all of its required
+// permissions are given or if the URI permission of the method is given.
+// Methods that don't require any permission are not returned, as they can be
+// called without a macaroon.
+func (pm *Manager) GrantedURIs(ops []bakery.Op) []string {
+	pm.permsMu.RLock()
+	defer pm.permsMu.RUnlock()
+
+	granted := make(map[bakery.Op]bool, len(ops))
+	for _, op := range ops {
+		granted[op] = true
+	}
+
+	var uris []string
+	for uri, required := range pm.perms {
+		if len(required) == 0 {
+			continue
+		}
+
+		uriOp := bakery.Op{
+			Entity: macaroons.PermissionEntityCustomURI,
+			Action: uri,
+		}
+		if granted[uriOp] {
+			uris = append(uris, uri)
+			continue
+		}
+
+		allowed := true
+		for _, op := range required {
+			if !granted[op] {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			uris = append(uris, uri)
+		}
+	}
+	sort.Strings(uris)
+
+	return uris
+}
+
 // GetLitPerms returns a map of all permissions that the manager is aware of
 // _except_ for any LND permissions. In other words, this returns permissions
 // for which the external validator of Lit is responsible.
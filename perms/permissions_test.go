@@ -85,3 +85,55 @@ func TestMatchRegexURI(t *testing.T) {
 	require.False(t, isRegex)
 	require.Empty(t, uris)
 }
+
+// TestGrantedURIs tests that only the methods whose required permissions are
+// all given, or whose URI permission is given, are granted.
+func TestGrantedURIs(t *testing.T) {
+	m := &Manager{
+		perms: map[string][]bakery.Op{
+			"/lnrpc.WalletUnlocker/GenSeed": {},
+			"/lnrpc.Lightning/SendCoins": {{
+				Entity: "onchain",
+				Action: "write",
+			}},
+			"/lnrpc.Lightning/OpenChannel": {{
+				Entity: "onchain",
+				Action: "write",
+			}, {
+				Entity: "offchain",
+				Action: "write",
+			}},
+			"/litrpc.Sessions/AddSession": {{
+				Entity: "sessions",
+				Action: "write",
+			}},
+			"/litrpc.Sessions/ListSessions": {{
+				Entity: "sessions",
+				Action: "read",
+			}},
+		},
+	}
+
+	// Methods without any required permission are never granted.
+	require.Empty(t, m.GrantedURIs(nil))
+
+	// A method is only granted if all its permissions are given.
+	uris := m.GrantedURIs([]bakery.Op{{
+		Entity: "onchain",
+		Action: "write",
+	}, {
+		Entity: "sessions",
+		Action: "read",
+	}})
+	require.Equal(t, []string{
+		"/litrpc.Sessions/ListSessions",
+		"/lnrpc.Lightning/SendCoins",
+	}, uris)
+
+	// The URI permission grants a single method.
+	uris = m.GrantedURIs([]bakery.Op{{
+		Entity: "uri",
+		Action: "/litrpc.Sessions/AddSession",
+	}})
+	require.Equal(t, []string{"/litrpc.Sessions/AddSession"}, uris)
+}
@@ -0,0 +1,30 @@
+package portal
+
+import (
+	"fmt"
+)
+
+// Config holds all config options for the self-serve account portal.
+type Config struct {
+	Enable bool   `long:"enable" description:"Serve the self-serve account portal that lets the holder of a portal token see the balance and history of their account and create invoices for it."`
+	Listen string `long:"listen" description:"The host:port to listen on for the HTTPS requests of the account portal. It uses the same TLS certificate as litd's main HTTPS listener but serves nothing else, so it can be exposed to account holders without exposing any RPCs or the UI. Must be set if the portal is enabled."`
+}
+
+// DefaultConfig constructs the default portal Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure the config is sane if the portal is enabled.
+func (c *Config) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.Listen == "" {
+		return fmt.Errorf("portal.listen must be set if the account " +
+			"portal is enabled")
+	}
+
+	return nil
+}
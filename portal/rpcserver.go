@@ -0,0 +1,107 @@
+package portal
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the AccountPortal gRPC
+// interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedAccountPortalServer
+
+	service *Service
+}
+
+// NewRPCServer returns a new RPC server for the given portal service.
+func NewRPCServer(service *Service) *RPCServer {
+	return &RPCServer{
+		service: service,
+	}
+}
+
+// CreatePortalToken creates a new portal token for the given account.
+func (s *RPCServer) CreatePortalToken(_ context.Context,
+	req *litrpc.CreatePortalTokenRequest) (
+	*litrpc.CreatePortalTokenResponse, error) {
+
+	log.Infof("[createportaltoken] account_id=%s, label=%s",
+		req.AccountId, req.Label)
+
+	accountID, err := accounts.ParseAccountID(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	t, secret, err := s.service.CreateToken(*accountID, req.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.CreatePortalTokenResponse{
+		Token:  marshalToken(t),
+		Secret: secret,
+	}, nil
+}
+
+// ListPortalTokens returns all portal tokens, optionally filtered by account.
+func (s *RPCServer) ListPortalTokens(_ context.Context,
+	req *litrpc.ListPortalTokensRequest) (*litrpc.ListPortalTokensResponse,
+	error) {
+
+	log.Infof("[listportaltokens] account_id=%s", req.AccountId)
+
+	var accountID *accounts.AccountID
+	if req.AccountId != "" {
+		var err error
+		accountID, err = accounts.ParseAccountID(req.AccountId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tokens, err := s.service.Tokens()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListPortalTokensResponse{}
+	for _, t := range tokens {
+		if accountID != nil && t.AccountID != *accountID {
+			continue
+		}
+
+		resp.Tokens = append(resp.Tokens, marshalToken(t))
+	}
+
+	return resp, nil
+}
+
+// RevokePortalToken removes the given portal token so that it can no longer be
+// used.
+func (s *RPCServer) RevokePortalToken(_ context.Context,
+	req *litrpc.RevokePortalTokenRequest) (
+	*litrpc.RevokePortalTokenResponse, error) {
+
+	log.Infof("[revokeportaltoken] id=%s", req.Id)
+
+	if err := s.service.RevokeToken(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RevokePortalTokenResponse{}, nil
+}
+
+// marshalToken converts a token into its RPC counterpart.
+func marshalToken(t *Token) *litrpc.PortalToken {
+	return &litrpc.PortalToken{
+		Id:        t.ID,
+		AccountId: hex.EncodeToString(t.AccountID[:]),
+		Label:     t.Label,
+		CreatedAt: t.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,474 @@
+package portal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// accountPath is the path of the endpoint that returns the account.
+	accountPath = "/v1/account"
+
+	// historyPath is the path of the endpoint that returns the invoices
+	// and payments of the account.
+	historyPath = "/v1/history"
+
+	// invoicesPath is the path of the endpoint that creates invoices for
+	// the account.
+	invoicesPath = "/v1/invoices"
+
+	// bearerPrefix is the prefix of the authorization header that carries
+	// the secret of a token.
+	bearerPrefix = "Bearer "
+
+	// secretLen is the number of random bytes of a token's secret.
+	secretLen = 32
+
+	// maxRequestSize is the maximum size of a request body.
+	maxRequestSize = 4096
+
+	// lndTimeout is the maximum time a call to lnd may take while serving
+	// a request.
+	lndTimeout = 30 * time.Second
+)
+
+var (
+	// ErrServiceDisabled is returned if the portal is used even though it
+	// isn't enabled.
+	ErrServiceDisabled = errors.New("account portal is not enabled")
+)
+
+// AccountService is the subset of the account service the portal looks up
+// the accounts and associates the invoices with.
+type AccountService interface {
+	// Account retrieves the account with the given ID.
+	Account(id accounts.AccountID) (*accounts.OffChainBalanceAccount,
+		error)
+
+	// AssociateInvoice associates a generated invoice with the given
+	// account, making it possible for the account to be credited in case
+	// the invoice is paid.
+	AssociateInvoice(id accounts.AccountID, hash lntypes.Hash) error
+}
+
+// accountResponse is the response of the account endpoint.
+type accountResponse struct {
+	ID             string `json:"id"`
+	Label          string `json:"label"`
+	BalanceSat     int64  `json:"balance_sat"`
+	BalanceMsat    int64  `json:"balance_msat"`
+	ExpirationDate int64  `json:"expiration_date"`
+	Frozen         bool   `json:"frozen"`
+}
+
+// invoiceEntry is a single invoice of the history endpoint.
+type invoiceEntry struct {
+	PaymentHash    string `json:"payment_hash"`
+	Memo           string `json:"memo"`
+	AmountSat      int64  `json:"amount_sat"`
+	AmountMsat     int64  `json:"amount_msat"`
+	AmountPaidSat  int64  `json:"amount_paid_sat"`
+	AmountPaidMsat int64  `json:"amount_paid_msat"`
+	State          string `json:"state"`
+	CreationDate   int64  `json:"creation_date"`
+	SettleDate     int64  `json:"settle_date"`
+	PaymentRequest string `json:"payment_request"`
+}
+
+// paymentEntry is a single payment of the history endpoint.
+type paymentEntry struct {
+	PaymentHash string `json:"payment_hash"`
+	AmountSat   int64  `json:"amount_sat"`
+	AmountMsat  int64  `json:"amount_msat"`
+	Status      string `json:"status"`
+}
+
+// historyResponse is the response of the history endpoint.
+type historyResponse struct {
+	Invoices []*invoiceEntry `json:"invoices"`
+	Payments []*paymentEntry `json:"payments"`
+}
+
+// invoiceRequest is the request of the invoice endpoint.
+type invoiceRequest struct {
+	AmountSat uint64 `json:"amount_sat"`
+	Memo      string `json:"memo"`
+}
+
+// invoiceResponse is the response of the invoice endpoint.
+type invoiceResponse struct {
+	PaymentHash    string `json:"payment_hash"`
+	PaymentRequest string `json:"payment_request"`
+}
+
+// errorResponse is returned by all endpoints if the request fails.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Service manages the portal tokens and serves the portal endpoints to the
+// holders of those tokens. A token only gives access to its own account, and
+// the portal never exposes any of litd's or lnd's RPCs.
+type Service struct {
+	cfg      *Config
+	dir      string
+	accounts AccountService
+
+	store    *Store
+	lnd      lndclient.LightningClient
+	lockdown accounts.LockdownChecker
+
+	// ready is set once the service is started and the portal can be
+	// used.
+	ready atomic.Bool
+}
+
+// NewService creates a new portal service that stores its data in the given
+// directory.
+func NewService(cfg *Config, dir string, accts AccountService) *Service {
+	return &Service{
+		cfg:      cfg,
+		dir:      dir,
+		accounts: accts,
+	}
+}
+
+// Start opens the portal store if the portal is enabled. The portal endpoints
+// are only served once the service is started and while the lockdown mode of
+// litd isn't enabled. The lockdown checker may be nil.
+func (s *Service) Start(lnd lndclient.LightningClient,
+	lockdown accounts.LockdownChecker) error {
+
+	if !s.cfg.Enable {
+		return nil
+	}
+
+	store, err := NewStore(s.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open portal store: %v", err)
+	}
+
+	s.store = store
+	s.lnd = lnd
+	s.lockdown = lockdown
+	s.ready.Store(true)
+
+	return nil
+}
+
+// Stop closes the store.
+func (s *Service) Stop() error {
+	if !s.ready.Load() {
+		return nil
+	}
+	s.ready.Store(false)
+
+	return s.store.Close()
+}
+
+// CreateToken creates a new token for the given account and returns it
+// together with its secret. The secret isn't stored, so it can't be
+// retrieved again.
+func (s *Service) CreateToken(accountID accounts.AccountID,
+	label string) (*Token, string, error) {
+
+	if !s.ready.Load() {
+		return nil, "", ErrServiceDisabled
+	}
+
+	if _, err := s.accounts.Account(accountID); err != nil {
+		return nil, "", err
+	}
+
+	secretBytes := make([]byte, secretLen)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", err
+	}
+	secret := hex.EncodeToString(secretBytes)
+
+	hash, id := hashSecret(secret)
+	t := &Token{
+		ID:         id,
+		AccountID:  accountID,
+		Label:      label,
+		SecretHash: hex.EncodeToString(hash),
+		CreatedAt:  time.Now(),
+	}
+	if err := s.store.AddToken(t); err != nil {
+		return nil, "", err
+	}
+
+	return t, secret, nil
+}
+
+// Tokens returns all tokens.
+func (s *Service) Tokens() ([]*Token, error) {
+	if !s.ready.Load() {
+		return nil, ErrServiceDisabled
+	}
+
+	return s.store.Tokens()
+}
+
+// RevokeToken removes the token with the given ID.
+func (s *Service) RevokeToken(id string) error {
+	if !s.ready.Load() {
+		return ErrServiceDisabled
+	}
+
+	return s.store.RemoveToken(id)
+}
+
+// authenticate returns the account of the token whose secret is sent in the
+// authorization header of the given request.
+func (s *Service) authenticate(req *http.Request) (accounts.AccountID,
+	error) {
+
+	var noID accounts.AccountID
+
+	header := req.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return noID, fmt.Errorf("missing bearer token")
+	}
+
+	hash, id := hashSecret(strings.TrimPrefix(header, bearerPrefix))
+	t, err := s.store.Token(id)
+	if err != nil {
+		return noID, fmt.Errorf("invalid token")
+	}
+
+	storedHash, err := hex.DecodeString(t.SecretHash)
+	if err != nil || subtle.ConstantTimeCompare(hash, storedHash) != 1 {
+		return noID, fmt.Errorf("invalid token")
+	}
+
+	return t.AccountID, nil
+}
+
+// ServeHTTP serves the portal endpoints. Every request must carry the secret
+// of a token as its bearer token and only ever sees the account of that
+// token.
+func (s *Service) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !s.ready.Load() {
+		writeError(resp, http.StatusServiceUnavailable, "portal not "+
+			"ready")
+		return
+	}
+
+	// Neither invoices nor the history of an account are served while
+	// litd is locked down.
+	if s.lockdown != nil && s.lockdown.Active() {
+		writeError(
+			resp, http.StatusServiceUnavailable,
+			accounts.ErrLockdown.Error(),
+		)
+		return
+	}
+
+	accountID, err := s.authenticate(req)
+	if err != nil {
+		writeError(resp, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), lndTimeout)
+	defer cancel()
+
+	switch {
+	case req.URL.Path == accountPath && req.Method == http.MethodGet:
+		s.handleAccount(resp, accountID)
+
+	case req.URL.Path == historyPath && req.Method == http.MethodGet:
+		s.handleHistory(ctx, resp, accountID)
+
+	case req.URL.Path == invoicesPath && req.Method == http.MethodPost:
+		s.handleInvoice(ctx, resp, req, accountID)
+
+	case req.URL.Path == accountPath || req.URL.Path == historyPath ||
+		req.URL.Path == invoicesPath:
+
+		writeError(resp, http.StatusMethodNotAllowed, "method not "+
+			"allowed")
+
+	default:
+		writeError(resp, http.StatusNotFound, "not found")
+	}
+}
+
+// handleAccount returns the balance and state of the account.
+func (s *Service) handleAccount(resp http.ResponseWriter,
+	id accounts.AccountID) {
+
+	acct, err := s.accounts.Account(id)
+	if err != nil {
+		writeError(resp, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	account := &accountResponse{
+		ID:          hex.EncodeToString(id[:]),
+		Label:       acct.Label,
+		BalanceSat:  acct.CurrentBalanceSats(),
+		BalanceMsat: acct.CurrentBalance,
+		Frozen:      acct.IsFrozen(),
+	}
+	if !acct.ExpirationDate.IsZero() {
+		account.ExpirationDate = acct.ExpirationDate.Unix()
+	}
+
+	writeJSON(resp, http.StatusOK, account)
+}
+
+// handleHistory returns the invoices and payments of the account, newest
+// invoices first.
+func (s *Service) handleHistory(ctx context.Context,
+	resp http.ResponseWriter, id accounts.AccountID) {
+
+	acct, err := s.accounts.Account(id)
+	if err != nil {
+		writeError(resp, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	history := &historyResponse{
+		Invoices: make([]*invoiceEntry, 0, len(acct.Invoices)),
+		Payments: make([]*paymentEntry, 0, len(acct.Payments)),
+	}
+	for hash := range acct.Invoices {
+		invoice, err := s.lnd.LookupInvoice(ctx, hash)
+		switch {
+		// An invoice that lnd doesn't know anymore isn't shown.
+		case status.Code(err) == codes.NotFound:
+			continue
+
+		case err != nil:
+			log.Errorf("Unable to look up invoice %v: %v", hash,
+				err)
+			writeError(resp, http.StatusInternalServerError,
+				"unable to look up invoices")
+			return
+		}
+
+		entry := &invoiceEntry{
+			PaymentHash:    hash.String(),
+			Memo:           invoice.Memo,
+			AmountSat:      int64(invoice.Amount.ToSatoshis()),
+			AmountMsat:     int64(invoice.Amount),
+			AmountPaidSat:  int64(invoice.AmountPaid.ToSatoshis()),
+			AmountPaidMsat: int64(invoice.AmountPaid),
+			State:          invoice.State.String(),
+			CreationDate:   invoice.CreationDate.Unix(),
+			PaymentRequest: invoice.PaymentRequest,
+		}
+		if !invoice.SettleDate.IsZero() {
+			entry.SettleDate = invoice.SettleDate.Unix()
+		}
+
+		history.Invoices = append(history.Invoices, entry)
+	}
+	sort.Slice(history.Invoices, func(i, j int) bool {
+		return history.Invoices[i].CreationDate >
+			history.Invoices[j].CreationDate
+	})
+
+	for hash, payment := range acct.Payments {
+		history.Payments = append(history.Payments, &paymentEntry{
+			PaymentHash: hash.String(),
+			AmountSat:   int64(payment.FullAmount.ToSatoshis()),
+			AmountMsat:  int64(payment.FullAmount),
+			Status:      payment.Status.String(),
+		})
+	}
+	sort.Slice(history.Payments, func(i, j int) bool {
+		return history.Payments[i].PaymentHash <
+			history.Payments[j].PaymentHash
+	})
+
+	writeJSON(resp, http.StatusOK, history)
+}
+
+// handleInvoice creates an invoice that credits the account once it is paid.
+func (s *Service) handleInvoice(ctx context.Context,
+	resp http.ResponseWriter, req *http.Request, id accounts.AccountID) {
+
+	var invoiceReq invoiceRequest
+	body := http.MaxBytesReader(resp, req.Body, maxRequestSize)
+	if err := json.NewDecoder(body).Decode(&invoiceReq); err != nil {
+		writeError(resp, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	acct, err := s.accounts.Account(id)
+	if err != nil {
+		writeError(resp, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if acct.HasExpired() {
+		writeError(resp, http.StatusForbidden, "account has expired")
+		return
+	}
+
+	amt := lnwire.NewMSatFromSatoshis(btcutil.Amount(invoiceReq.AmountSat))
+	if err := acct.CheckInvoiceAmount(amt); err != nil {
+		writeError(resp, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hash, payReq, err := s.lnd.AddInvoice(ctx, &invoicesrpc.AddInvoiceData{
+		Memo:  invoiceReq.Memo,
+		Value: amt,
+	})
+	if err != nil {
+		log.Errorf("Unable to create invoice: %v", err)
+		writeError(resp, http.StatusInternalServerError, "unable to "+
+			"create invoice")
+		return
+	}
+
+	if err := s.accounts.AssociateInvoice(id, hash); err != nil {
+		writeError(resp, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(resp, http.StatusOK, &invoiceResponse{
+		PaymentHash:    hash.String(),
+		PaymentRequest: payReq,
+	})
+}
+
+// writeJSON writes the given value as the JSON response with the given
+// status code.
+func writeJSON(resp http.ResponseWriter, status int, v interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+	if err := json.NewEncoder(resp).Encode(v); err != nil {
+		log.Errorf("Unable to write portal response: %v", err)
+	}
+}
+
+// writeError writes an error response with the given status code.
+func writeError(resp http.ResponseWriter, status int, reason string) {
+	writeJSON(resp, status, &errorResponse{
+		Error: reason,
+	})
+}
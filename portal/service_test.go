@@ -0,0 +1,259 @@
+package portal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/invoices"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockAccounts is a mock implementation of the account service.
+type mockAccounts struct {
+	accts map[accounts.AccountID]*accounts.OffChainBalanceAccount
+}
+
+func (m *mockAccounts) Account(id accounts.AccountID) (
+	*accounts.OffChainBalanceAccount, error) {
+
+	acct, ok := m.accts[id]
+	if !ok {
+		return nil, accounts.ErrAccNotFound
+	}
+
+	return acct, nil
+}
+
+func (m *mockAccounts) AssociateInvoice(id accounts.AccountID,
+	hash lntypes.Hash) error {
+
+	m.accts[id].Invoices[hash] = struct{}{}
+	return nil
+}
+
+// mockLnd is a mock implementation of the lnd client that remembers the
+// invoices it created.
+type mockLnd struct {
+	lndclient.LightningClient
+
+	invoices map[lntypes.Hash]*lndclient.Invoice
+
+	// lookupErr is returned by LookupInvoice if set.
+	lookupErr error
+}
+
+func (m *mockLnd) AddInvoice(_ context.Context,
+	in *invoicesrpc.AddInvoiceData) (lntypes.Hash, string, error) {
+
+	hash := lntypes.Hash{byte(len(m.invoices) + 1)}
+	m.invoices[hash] = &lndclient.Invoice{
+		Hash:           hash,
+		Memo:           in.Memo,
+		Amount:         in.Value,
+		PaymentRequest: "lnbcrt1" + hash.String(),
+		CreationDate:   time.Now(),
+		State:          invoices.ContractOpen,
+	}
+
+	return hash, m.invoices[hash].PaymentRequest, nil
+}
+
+func (m *mockLnd) LookupInvoice(_ context.Context,
+	hash lntypes.Hash) (*lndclient.Invoice, error) {
+
+	if m.lookupErr != nil {
+		return nil, m.lookupErr
+	}
+
+	invoice, ok := m.invoices[hash]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "invoice not found")
+	}
+
+	return invoice, nil
+}
+
+// mockLockdown is a mock of the lockdown mode of litd.
+type mockLockdown struct {
+	active bool
+}
+
+// Active returns true if the lockdown mode is enabled.
+func (m *mockLockdown) Active() bool {
+	return m.active
+}
+
+// TestPortal tests that the portal endpoints only serve the account of the
+// token they are called with.
+func TestPortal(t *testing.T) {
+	acctID := accounts.AccountID{1, 2, 3, 4, 5, 6, 7, 8}
+	otherID := accounts.AccountID{8, 7, 6, 5, 4, 3, 2, 1}
+	accts := &mockAccounts{
+		accts: map[accounts.AccountID]*accounts.OffChainBalanceAccount{
+			acctID: {
+				ID:             acctID,
+				Label:          "alice",
+				CurrentBalance: 50_000_000,
+				Invoices:       make(map[lntypes.Hash]struct{}),
+				Payments: map[lntypes.Hash]*accounts.PaymentEntry{
+					{9}: {FullAmount: 2_000_000},
+				},
+				Limits: accounts.AccountLimits{
+					MaxInvoiceAmount: 100_000_000,
+				},
+			},
+			otherID: {
+				ID:       otherID,
+				Invoices: make(map[lntypes.Hash]struct{}),
+			},
+		},
+	}
+	lnd := &mockLnd{invoices: make(map[lntypes.Hash]*lndclient.Invoice)}
+
+	cfg := &Config{Enable: true, Listen: "localhost:0"}
+	s := NewService(cfg, t.TempDir(), accts)
+
+	// Tokens can't be created before the service is started.
+	_, _, err := s.CreateToken(acctID, "alice")
+	require.ErrorIs(t, err, ErrServiceDisabled)
+
+	lockdown := &mockLockdown{}
+	require.NoError(t, s.Start(lnd, lockdown))
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+
+	// Tokens can only be created for existing accounts.
+	_, _, err = s.CreateToken(accounts.AccountID{9}, "")
+	require.ErrorIs(t, err, accounts.ErrAccNotFound)
+
+	token, secret, err := s.CreateToken(acctID, "alice")
+	require.NoError(t, err)
+	require.NotContains(t, token.SecretHash, secret)
+
+	otherToken, otherSecret, err := s.CreateToken(otherID, "")
+	require.NoError(t, err)
+
+	call := func(method, path, secret, body string) (int, []byte) {
+		req := httptest.NewRequest(
+			method, path, strings.NewReader(body),
+		)
+		if secret != "" {
+			req.Header.Set("Authorization", bearerPrefix+secret)
+		}
+
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		return rec.Code, rec.Body.Bytes()
+	}
+
+	// Requests without a valid token are rejected.
+	code, _ := call(http.MethodGet, accountPath, "", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = call(http.MethodGet, accountPath, secret+"00", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	// The account endpoint returns the account of the token.
+	code, body := call(http.MethodGet, accountPath, secret, "")
+	require.Equal(t, http.StatusOK, code)
+	var account accountResponse
+	require.NoError(t, json.Unmarshal(body, &account))
+	require.Equal(t, "0102030405060708", account.ID)
+	require.Equal(t, "alice", account.Label)
+	require.EqualValues(t, 50_000, account.BalanceSat)
+	require.EqualValues(t, 50_000_000, account.BalanceMsat)
+
+	// An invoice above the account's limit is rejected.
+	code, _ = call(
+		http.MethodPost, invoicesPath, secret, `{"amount_sat":200000}`,
+	)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// A valid invoice is associated with the account.
+	code, body = call(
+		http.MethodPost, invoicesPath, secret,
+		`{"amount_sat":1000,"memo":"top up"}`,
+	)
+	require.Equal(t, http.StatusOK, code)
+	var invoice invoiceResponse
+	require.NoError(t, json.Unmarshal(body, &invoice))
+	require.Len(t, accts.accts[acctID].Invoices, 1)
+	require.Empty(t, accts.accts[otherID].Invoices)
+
+	// The history contains the invoice and the payment of the account.
+	code, body = call(http.MethodGet, historyPath, secret, "")
+	require.Equal(t, http.StatusOK, code)
+	var history historyResponse
+	require.NoError(t, json.Unmarshal(body, &history))
+	require.Len(t, history.Invoices, 1)
+	require.Equal(t, invoice.PaymentHash, history.Invoices[0].PaymentHash)
+	require.Equal(t, "top up", history.Invoices[0].Memo)
+	require.EqualValues(t, 1000, history.Invoices[0].AmountSat)
+	require.Len(t, history.Payments, 1)
+	require.EqualValues(t, 2000, history.Payments[0].AmountSat)
+
+	// Invoices lnd doesn't know anymore are left out, other lookup errors
+	// fail the request.
+	accts.accts[acctID].Invoices[lntypes.Hash{42}] = struct{}{}
+	code, body = call(http.MethodGet, historyPath, secret, "")
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &history))
+	require.Len(t, history.Invoices, 1)
+	require.Equal(t, invoice.PaymentHash, history.Invoices[0].PaymentHash)
+
+	lnd.lookupErr = errors.New("lnd unavailable")
+	code, _ = call(http.MethodGet, historyPath, secret, "")
+	require.Equal(t, http.StatusInternalServerError, code)
+	lnd.lookupErr = nil
+	delete(accts.accts[acctID].Invoices, lntypes.Hash{42})
+
+	// The other token only sees its own account.
+	code, body = call(http.MethodGet, historyPath, otherSecret, "")
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &history))
+	require.Empty(t, history.Invoices)
+	require.Empty(t, history.Payments)
+
+	// Nothing is served while litd is locked down.
+	lockdown.active = true
+	code, _ = call(http.MethodGet, historyPath, secret, "")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = call(
+		http.MethodPost, invoicesPath, secret, `{"amount_sat":1000}`,
+	)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, accts.accts[acctID].Invoices, 1)
+	lockdown.active = false
+
+	// Only the documented methods are allowed.
+	code, _ = call(http.MethodPost, accountPath, secret, "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+	code, _ = call(http.MethodGet, "/v1/unknown", secret, "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Revoked tokens can no longer be used.
+	tokens, err := s.Tokens()
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+
+	require.NoError(t, s.RevokeToken(otherToken.ID))
+	require.ErrorIs(t, s.RevokeToken(otherToken.ID), ErrTokenNotFound)
+
+	code, _ = call(http.MethodGet, accountPath, otherSecret, "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = call(http.MethodGet, accountPath, secret, "")
+	require.Equal(t, http.StatusOK, code)
+}
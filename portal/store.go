@@ -0,0 +1,198 @@
+package portal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the portal database.
+	DBFilename = "portal.db"
+
+	// dbFilePermission is the default permission the portal database file
+	// is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+
+	// tokenIDLen is the number of bytes of the secret's hash that make up
+	// the ID of a token.
+	tokenIDLen = 8
+)
+
+/*
+	The portal data is stored in the following structure in the db:
+
+	tokens -> token ID -> json encoded Token
+*/
+
+var (
+	// tokensBucketKey is the key of the top level bucket holding all the
+	// tokens.
+	tokensBucketKey = []byte("tokens")
+
+	// ErrTokenNotFound is returned when a token with the given ID does not
+	// exist in the db.
+	ErrTokenNotFound = errors.New("portal token not found")
+)
+
+// Token gives the holder of its secret access to the portal of a single
+// account. Only the hash of the secret is stored.
+type Token struct {
+	// ID is derived from the hash of the secret so that a token can be
+	// looked up by its secret.
+	ID string `json:"id"`
+
+	// AccountID is the ID of the account the token gives access to.
+	AccountID accounts.AccountID `json:"account_id"`
+
+	// Label is an optional label of the token.
+	Label string `json:"label"`
+
+	// SecretHash is the hex encoded SHA256 hash of the secret.
+	SecretHash string `json:"secret_hash"`
+
+	// CreatedAt is the time the token was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// hashSecret returns the hash of the given secret and the ID of the token
+// that belongs to it.
+func hashSecret(secret string) ([]byte, string) {
+	hash := sha256.Sum256([]byte(secret))
+
+	return hash[:], hex.EncodeToString(hash[:tokenIDLen])
+}
+
+// Store is a bolt-backed persistent store of the portal tokens.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the portal store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(tokensBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// AddToken stores the given token.
+func (s *Store) AddToken(t *Token) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(tokensBucketKey)
+
+		if bucket.Get([]byte(t.ID)) != nil {
+			return fmt.Errorf("portal token %s already exists", t.ID)
+		}
+
+		b, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(t.ID), b)
+	})
+}
+
+// Token fetches the token with the given ID. If no such token exists,
+// ErrTokenNotFound is returned.
+func (s *Store) Token(id string) (*Token, error) {
+	var t *Token
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(tokensBucketKey).Get([]byte(id))
+		if b == nil {
+			return ErrTokenNotFound
+		}
+
+		t = &Token{}
+		return json.Unmarshal(b, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Tokens returns all tokens sorted by their creation time.
+func (s *Store) Tokens() ([]*Token, error) {
+	var tokens []*Token
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(tokensBucketKey)
+
+		return bucket.ForEach(func(_, b []byte) error {
+			var t Token
+			if err := json.Unmarshal(b, &t); err != nil {
+				return err
+			}
+
+			tokens = append(tokens, &t)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+
+	return tokens, nil
+}
+
+// RemoveToken removes the token with the given ID. If no such token exists,
+// ErrTokenNotFound is returned.
+func (s *Store) RemoveToken(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(tokensBucketKey)
+
+		if bucket.Get([]byte(id)) == nil {
+			return ErrTokenNotFound
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,198 @@
+package provision
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// Export returns the current accounts, active macaroon sessions and custom
+// rule bundles as a spec. Applying the returned spec doesn't change anything.
+// Entries that can't be expressed in a spec, for example because their label
+// is empty or not unique, are skipped and described in the returned list.
+func (p *Provisioner) Export(ctx context.Context) (*litrpc.ProvisioningSpec,
+	[]string, error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		spec    = &litrpc.ProvisioningSpec{}
+		skipped []string
+	)
+
+	acctResp, err := p.servers.Accounts.ListAccounts(
+		ctx, &litrpc.ListAccountsRequest{},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing accounts: %w", err)
+	}
+
+	acctLabels := make(map[string]int)
+	for _, a := range acctResp.Accounts {
+		acctLabels[a.Label]++
+	}
+
+	// accountLabels maps the IDs of the exported accounts to their labels
+	// so that account sessions can reference them.
+	accountLabels := make(map[string]string)
+	for _, a := range acctResp.Accounts {
+		switch {
+		case a.Label == "":
+			skipped = append(skipped, fmt.Sprintf("account %s: "+
+				"no label", a.Id))
+			continue
+
+		case acctLabels[a.Label] > 1:
+			skipped = append(skipped, fmt.Sprintf("account %s: "+
+				"label %s is not unique", a.Id, a.Label))
+			continue
+		}
+
+		var balance uint64
+		if a.CurrentBalance > 0 {
+			balance = uint64(a.CurrentBalance)
+		}
+
+		spec.Accounts = append(spec.Accounts, &litrpc.AccountSpec{
+			Label:          a.Label,
+			Balance:        balance,
+			ExpirationDate: a.ExpirationDate,
+			Id:             a.Id,
+		})
+		accountLabels[a.Id] = a.Label
+	}
+
+	sessions, err := activeSessions(ctx, p.servers.Sessions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sessLabels := make(map[string]int)
+	for _, s := range sessions {
+		sessLabels[s.Label]++
+	}
+
+	for _, s := range sessions {
+		id := fmt.Sprintf("%x", s.LocalPublicKey)
+		switch {
+		case s.Label == "":
+			skipped = append(skipped, fmt.Sprintf("session %s: "+
+				"no label", id))
+			continue
+
+		case sessLabels[s.Label] > 1:
+			skipped = append(skipped, fmt.Sprintf("session %s: "+
+				"label %s is not unique", id, s.Label))
+			continue
+		}
+
+		sessSpec := &litrpc.SessionSpec{
+			Label:                  s.Label,
+			SessionType:            s.SessionType,
+			ExpiryTimestampSeconds: s.ExpiryTimestampSeconds,
+			MailboxServerAddr:      s.MailboxServerAddr,
+			DevServer:              s.DevServer,
+			Notes:                  s.Notes,
+			Tags:                   s.Tags,
+			Id:                     id,
+		}
+
+		switch s.SessionType {
+		case litrpc.SessionType_TYPE_MACAROON_CUSTOM:
+			if s.MacaroonRecipe != nil {
+				sessSpec.MacaroonCustomPermissions =
+					s.MacaroonRecipe.Permissions
+			}
+
+		case litrpc.SessionType_TYPE_MACAROON_ACCOUNT:
+			label, ok := accountLabels[s.AccountId]
+			if !ok {
+				skipped = append(skipped, fmt.Sprintf("session "+
+					"%s: account %s is not exported", id,
+					s.AccountId))
+				continue
+			}
+			sessSpec.AccountLabel = label
+		}
+
+		spec.Sessions = append(spec.Sessions, sessSpec)
+	}
+
+	bundleResp, err := p.servers.Autopilot.ListRuleBundles(
+		ctx, &litrpc.ListRuleBundlesRequest{},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing rule bundles: %w",
+			err)
+	}
+
+	for _, b := range bundleResp.Bundles {
+		if b.BuiltIn {
+			continue
+		}
+
+		spec.RuleBundles = append(spec.RuleBundles, b)
+	}
+
+	// Sorting the entries keeps the output stable, so that exports can be
+	// compared with each other.
+	sort.Slice(spec.Accounts, func(i, j int) bool {
+		return spec.Accounts[i].Label < spec.Accounts[j].Label
+	})
+	sort.Slice(spec.Sessions, func(i, j int) bool {
+		return spec.Sessions[i].Label < spec.Sessions[j].Label
+	})
+	sort.Slice(spec.RuleBundles, func(i, j int) bool {
+		return spec.RuleBundles[i].Name < spec.RuleBundles[j].Name
+	})
+
+	return spec, skipped, nil
+}
+
+// MarshalYAML encodes the spec in the YAML format that `litcli apply` reads.
+// The fields use their proto names and are in the order of the proto
+// definition.
+func MarshalYAML(spec *litrpc.ProvisioningSpec) (string, error) {
+	jsonBytes, err := protojson.MarshalOptions{
+		UseProtoNames: true,
+	}.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	// JSON is valid YAML, so decoding it into a node keeps the order of
+	// the fields. We only need to switch from the flow to the block style.
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return "", err
+	}
+	resetStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// resetStyle recursively resets the style of the node and its children to the
+// default block style. String scalars that would be parsed as another type
+// are still quoted by the encoder.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
@@ -0,0 +1,104 @@
+package provision
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// TestExport tests that an exported spec contains the stable identifiers of
+// the entries and that applying it again doesn't change anything.
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+
+	accounts := &mockAccounts{}
+	for _, label := range []string{"alice", "", "dup", "dup"} {
+		_, err := accounts.CreateAccount(
+			ctx, &litrpc.CreateAccountRequest{
+				AccountBalance: 1000,
+				Label:          label,
+			},
+		)
+		require.NoError(t, err)
+	}
+	aliceID := accounts.accounts[0].Id
+	dupID := accounts.accounts[2].Id
+
+	sessions := &mockSessions{}
+	addSession := func(label string, typ litrpc.SessionType,
+		accountID string) {
+
+		_, err := sessions.AddSession(ctx, &litrpc.AddSessionRequest{
+			Label:                  label,
+			SessionType:            typ,
+			ExpiryTimestampSeconds: expiry,
+			MailboxServerAddr:      defaultMailboxServerAddr,
+			AccountId:              accountID,
+			Notes:                  "notes of " + label,
+		})
+		require.NoError(t, err)
+	}
+	addSession(
+		"alice-wallet", litrpc.SessionType_TYPE_MACAROON_ACCOUNT,
+		aliceID,
+	)
+	addSession("ops", litrpc.SessionType_TYPE_MACAROON_ADMIN, "")
+	addSession("orphan", litrpc.SessionType_TYPE_MACAROON_ACCOUNT, dupID)
+	addSession("", litrpc.SessionType_TYPE_MACAROON_READONLY, "")
+	addSession("ui", litrpc.SessionType_TYPE_UI_PASSWORD, "")
+
+	autopilot := &mockAutopilot{
+		bundles: map[string]*litrpc.RuleBundle{
+			"default":  {Name: "default", BuiltIn: true},
+			"cautious": {Name: "cautious", Description: "careful"},
+		},
+	}
+
+	p := NewProvisioner(&Servers{
+		Accounts:  accounts,
+		Sessions:  sessions,
+		Autopilot: autopilot,
+	})
+
+	spec, skipped, err := p.Export(ctx)
+	require.NoError(t, err)
+	require.Len(t, skipped, 5)
+
+	require.Len(t, spec.Accounts, 1)
+	require.Equal(t, "alice", spec.Accounts[0].Label)
+	require.Equal(t, aliceID, spec.Accounts[0].Id)
+
+	require.Len(t, spec.Sessions, 2)
+	require.Equal(t, "alice-wallet", spec.Sessions[0].Label)
+	require.Equal(t, "alice", spec.Sessions[0].AccountLabel)
+	require.Equal(t, "01", spec.Sessions[0].Id)
+	require.Equal(t, "ops", spec.Sessions[1].Label)
+
+	require.Len(t, spec.RuleBundles, 1)
+	require.Equal(t, "cautious", spec.RuleBundles[0].Name)
+
+	// The YAML must be readable the same way litcli reads a spec.
+	yamlSpec, err := MarshalYAML(spec)
+	require.NoError(t, err)
+	require.Contains(t, yamlSpec, "label: alice-wallet")
+
+	var raw interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(yamlSpec), &raw))
+	jsonBytes, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	parsed := &litrpc.ProvisioningSpec{}
+	require.NoError(t, protojson.Unmarshal(jsonBytes, parsed))
+
+	// The skipped entries are left alone as long as we don't prune.
+	changes, err := p.Apply(ctx, parsed, false, false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
@@ -0,0 +1,740 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultMailboxServerAddr is the mailbox server that is used for
+	// sessions that don't specify one.
+	defaultMailboxServerAddr = "mailbox.terminal.lightning.today:443"
+
+	// reasonNotInSpec is the reason that sessions are revoked and accounts
+	// are removed with if they are no longer part of the spec.
+	reasonNotInSpec = "removed from the provisioning spec"
+
+	resourceAccount    = litrpc.ProvisioningResource_PROVISIONING_RESOURCE_ACCOUNT
+	resourceSession    = litrpc.ProvisioningResource_PROVISIONING_RESOURCE_SESSION
+	resourceRuleBundle = litrpc.ProvisioningResource_PROVISIONING_RESOURCE_RULE_BUNDLE
+
+	actionCreate  = litrpc.ProvisioningAction_PROVISIONING_ACTION_CREATE
+	actionUpdate  = litrpc.ProvisioningAction_PROVISIONING_ACTION_UPDATE
+	actionReplace = litrpc.ProvisioningAction_PROVISIONING_ACTION_REPLACE
+	actionDelete  = litrpc.ProvisioningAction_PROVISIONING_ACTION_DELETE
+)
+
+// Servers holds the RPC servers the Provisioner uses to inspect and change the
+// accounts, sessions and rule bundles. Using the RPC servers makes sure the
+// spec is validated the same way as the individual RPC calls.
+type Servers struct {
+	// Accounts is used to manage the accounts.
+	Accounts litrpc.AccountsServer
+
+	// Sessions is used to manage the sessions.
+	Sessions litrpc.SessionsServer
+
+	// Autopilot is used to manage the custom rule bundles.
+	Autopilot litrpc.AutopilotServer
+}
+
+// change is a planned change together with the function that applies it.
+type change struct {
+	*litrpc.ProvisioningChange
+
+	apply func(ctx context.Context) error
+}
+
+// Provisioner reconciles the accounts, sessions and custom rule bundles of the
+// node with a declarative spec.
+type Provisioner struct {
+	servers *Servers
+
+	// mu serializes the reconciliation so that two concurrent calls don't
+	// plan and apply the same changes.
+	mu sync.Mutex
+}
+
+// NewProvisioner creates a new Provisioner that uses the given RPC servers.
+func NewProvisioner(servers *Servers) *Provisioner {
+	return &Provisioner{
+		servers: servers,
+	}
+}
+
+// Apply plans the changes that are required to make the node match the given
+// spec and, unless dryRun is set, applies them in order. If prune is set,
+// resources that are not part of the spec are removed. The planned changes are
+// returned.
+func (p *Provisioner) Apply(ctx context.Context, spec *litrpc.ProvisioningSpec,
+	dryRun, prune bool) ([]*litrpc.ProvisioningChange, error) {
+
+	if err := validateSpec(spec); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pl := &planner{
+		servers:    p.servers,
+		spec:       spec,
+		prune:      prune,
+		accountIDs: make(map[string]string),
+	}
+	changes, err := pl.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*litrpc.ProvisioningChange, len(changes))
+	for i, c := range changes {
+		result[i] = c.ProvisioningChange
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for i, c := range changes {
+		log.Infof("Applying change %d/%d: %s %s: %s", i+1,
+			len(changes), c.Action, c.Name, c.Description)
+
+		if err := c.apply(ctx); err != nil {
+			return nil, fmt.Errorf("error applying change %d of "+
+				"%d (%s %s), the first %d changes were "+
+				"applied: %w", i+1, len(changes), c.Action,
+				c.Name, i, err)
+		}
+
+		c.Applied = true
+	}
+
+	return result, nil
+}
+
+// validateSpec checks that all resources of the spec have a unique label or
+// name and that the sessions reference accounts of the spec.
+func validateSpec(spec *litrpc.ProvisioningSpec) error {
+	if spec == nil {
+		return fmt.Errorf("spec must be set")
+	}
+
+	accounts := make(map[string]struct{}, len(spec.Accounts))
+	for _, a := range spec.Accounts {
+		if a.Label == "" {
+			return fmt.Errorf("accounts must have a label")
+		}
+		if _, ok := accounts[a.Label]; ok {
+			return fmt.Errorf("duplicate account label %s",
+				a.Label)
+		}
+		accounts[a.Label] = struct{}{}
+	}
+
+	sessions := make(map[string]struct{}, len(spec.Sessions))
+	for _, s := range spec.Sessions {
+		if s.Label == "" {
+			return fmt.Errorf("sessions must have a label")
+		}
+		if _, ok := sessions[s.Label]; ok {
+			return fmt.Errorf("duplicate session label %s",
+				s.Label)
+		}
+		sessions[s.Label] = struct{}{}
+
+		if !isManagedType(s.SessionType) {
+			return fmt.Errorf("session %s: type %v can't be "+
+				"managed by a spec", s.Label, s.SessionType)
+		}
+
+		isAccount := s.SessionType ==
+			litrpc.SessionType_TYPE_MACAROON_ACCOUNT
+		switch {
+		case isAccount && s.AccountLabel == "":
+			return fmt.Errorf("session %s: account sessions "+
+				"must set an account label", s.Label)
+
+		case !isAccount && s.AccountLabel != "":
+			return fmt.Errorf("session %s: only account sessions "+
+				"can set an account label", s.Label)
+		}
+
+		if _, ok := accounts[s.AccountLabel]; isAccount && !ok {
+			return fmt.Errorf("session %s: account %s is not part "+
+				"of the spec", s.Label, s.AccountLabel)
+		}
+	}
+
+	bundles := make(map[string]struct{}, len(spec.RuleBundles))
+	for _, b := range spec.RuleBundles {
+		if b.Name == "" {
+			return fmt.Errorf("rule bundles must have a name")
+		}
+		if _, ok := bundles[b.Name]; ok {
+			return fmt.Errorf("duplicate rule bundle name %s",
+				b.Name)
+		}
+		bundles[b.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// isManagedType returns true if sessions of the given type can be managed by
+// a spec.
+func isManagedType(t litrpc.SessionType) bool {
+	switch t {
+	case litrpc.SessionType_TYPE_MACAROON_READONLY,
+		litrpc.SessionType_TYPE_MACAROON_ADMIN,
+		litrpc.SessionType_TYPE_MACAROON_CUSTOM,
+		litrpc.SessionType_TYPE_MACAROON_ACCOUNT:
+
+		return true
+
+	default:
+		return false
+	}
+}
+
+// planner computes the changes for a single reconciliation.
+type planner struct {
+	servers *Servers
+	spec    *litrpc.ProvisioningSpec
+	prune   bool
+
+	// accountIDs maps the labels of the spec accounts to their IDs. The
+	// IDs of accounts that are created are only known once the change is
+	// applied.
+	accountIDs map[string]string
+
+	// deletions are the changes that remove resources. They are applied
+	// after all other changes, sessions first, so that nothing that is
+	// still referenced is removed.
+	deletions []*change
+}
+
+// plan returns all changes in the order in which they need to be applied.
+func (p *planner) plan(ctx context.Context) ([]*change, error) {
+	var changes []*change
+
+	bundleChanges, err := p.planRuleBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, bundleChanges...)
+
+	accountChanges, err := p.planAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, accountChanges...)
+
+	sessionChanges, err := p.planSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, sessionChanges...)
+
+	// The deletions were collected from rule bundles to sessions, so we
+	// apply them in reverse.
+	for i := len(p.deletions) - 1; i >= 0; i-- {
+		changes = append(changes, p.deletions[i])
+	}
+
+	return changes, nil
+}
+
+// planRuleBundles plans the changes of the custom rule bundles.
+func (p *planner) planRuleBundles(ctx context.Context) ([]*change, error) {
+	resp, err := p.servers.Autopilot.ListRuleBundles(
+		ctx, &litrpc.ListRuleBundlesRequest{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing rule bundles: %w", err)
+	}
+
+	existing := make(map[string]*litrpc.RuleBundle, len(resp.Bundles))
+	for _, b := range resp.Bundles {
+		existing[b.Name] = b
+	}
+
+	var (
+		changes []*change
+		inSpec  = make(map[string]struct{}, len(p.spec.RuleBundles))
+	)
+	for _, b := range p.spec.RuleBundles {
+		b := b
+		inSpec[b.Name] = struct{}{}
+
+		setBundle := func(ctx context.Context) error {
+			_, err := p.servers.Autopilot.SetRuleBundle(
+				ctx, &litrpc.SetRuleBundleRequest{Bundle: b},
+			)
+			return err
+		}
+
+		e, ok := existing[b.Name]
+		switch {
+		case !ok:
+			changes = append(changes, &change{
+				ProvisioningChange: newChange(
+					resourceRuleBundle,
+					b.Name,
+					actionCreate,
+					"create rule bundle",
+				),
+				apply: setBundle,
+			})
+
+		case e.BuiltIn:
+			return nil, fmt.Errorf("rule bundle %s is built-in "+
+				"and can't be managed by a spec", b.Name)
+
+		case e.Description != b.Description ||
+			!proto.Equal(e.Rules, b.Rules):
+
+			changes = append(changes, &change{
+				ProvisioningChange: newChange(
+					resourceRuleBundle,
+					b.Name,
+					actionUpdate,
+					"update description and rules",
+				),
+				apply: setBundle,
+			})
+		}
+	}
+
+	if !p.prune {
+		return changes, nil
+	}
+
+	for _, b := range resp.Bundles {
+		if _, ok := inSpec[b.Name]; ok || b.BuiltIn {
+			continue
+		}
+
+		name := b.Name
+		p.deletions = append(p.deletions, &change{
+			ProvisioningChange: newChange(
+				resourceRuleBundle,
+				name,
+				actionDelete,
+				"remove rule bundle",
+			),
+			apply: func(ctx context.Context) error {
+				_, err := p.servers.Autopilot.RemoveRuleBundle(
+					ctx, &litrpc.RemoveRuleBundleRequest{
+						Name: name,
+					},
+				)
+				return err
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+// planAccounts plans the changes of the accounts.
+func (p *planner) planAccounts(ctx context.Context) ([]*change, error) {
+	resp, err := p.servers.Accounts.ListAccounts(
+		ctx, &litrpc.ListAccountsRequest{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing accounts: %w", err)
+	}
+
+	existing := make(map[string][]*litrpc.Account)
+	for _, a := range resp.Accounts {
+		existing[a.Label] = append(existing[a.Label], a)
+	}
+
+	var (
+		changes []*change
+		inSpec  = make(map[string]struct{}, len(p.spec.Accounts))
+	)
+	for _, a := range p.spec.Accounts {
+		a := a
+		inSpec[a.Label] = struct{}{}
+
+		accts := existing[a.Label]
+		if len(accts) > 1 {
+			return nil, fmt.Errorf("%d accounts with the label %s "+
+				"exist", len(accts), a.Label)
+		}
+
+		if len(accts) == 0 {
+			c := &change{
+				ProvisioningChange: newChange(
+					resourceAccount,
+					a.Label,
+					actionCreate,
+					fmt.Sprintf("create account with a "+
+						"balance of %d sat and %s",
+						a.Balance,
+						formatExpiry(a.ExpirationDate)),
+				),
+			}
+			c.apply = func(ctx context.Context) error {
+				resp, err := p.servers.Accounts.CreateAccount(
+					ctx, &litrpc.CreateAccountRequest{
+						AccountBalance: a.Balance,
+						ExpirationDate: a.ExpirationDate,
+						Label:          a.Label,
+					},
+				)
+				if err != nil {
+					return err
+				}
+
+				c.Account = resp.Account
+				c.AccountMacaroon = resp.Macaroon
+				p.accountIDs[a.Label] = resp.Account.Id
+
+				return nil
+			}
+			changes = append(changes, c)
+
+			continue
+		}
+
+		acct := accts[0]
+		p.accountIDs[a.Label] = acct.Id
+
+		if acct.ExpirationDate == a.ExpirationDate {
+			continue
+		}
+
+		changes = append(changes, &change{
+			ProvisioningChange: newChange(
+				resourceAccount,
+				a.Label,
+				actionUpdate,
+				fmt.Sprintf("change expiration from %s to %s",
+					formatExpiry(acct.ExpirationDate),
+					formatExpiry(a.ExpirationDate)),
+			),
+			apply: func(ctx context.Context) error {
+				_, err := p.servers.Accounts.UpdateAccount(
+					ctx, &litrpc.UpdateAccountRequest{
+						Id: acct.Id,
+
+						// A balance of -1 keeps the
+						// current balance.
+						AccountBalance: -1,
+						ExpirationDate: a.ExpirationDate,
+					},
+				)
+				return err
+			},
+		})
+	}
+
+	if !p.prune {
+		return changes, nil
+	}
+
+	for _, a := range resp.Accounts {
+		if _, ok := inSpec[a.Label]; ok {
+			continue
+		}
+
+		id := a.Id
+		name := a.Label
+		if name == "" {
+			name = id
+		}
+		p.deletions = append(p.deletions, &change{
+			ProvisioningChange: newChange(
+				resourceAccount,
+				name,
+				actionDelete,
+				fmt.Sprintf("remove account %s with a balance "+
+					"of %d sat", id, a.CurrentBalance),
+			),
+			apply: func(ctx context.Context) error {
+				_, err := p.servers.Accounts.RemoveAccount(
+					ctx, &litrpc.RemoveAccountRequest{
+						Id:     id,
+						Reason: reasonNotInSpec,
+					},
+				)
+				return err
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+// planSessions plans the changes of the sessions. Only active sessions of the
+// managed types are taken into account.
+func (p *planner) planSessions(ctx context.Context) ([]*change, error) {
+	active, err := activeSessions(ctx, p.servers.Sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string][]*litrpc.Session)
+	for _, s := range active {
+		existing[s.Label] = append(existing[s.Label], s)
+	}
+
+	var (
+		changes []*change
+		inSpec  = make(map[string]struct{}, len(p.spec.Sessions))
+	)
+	for _, s := range p.spec.Sessions {
+		s := s
+		inSpec[s.Label] = struct{}{}
+
+		mailboxAddr := s.MailboxServerAddr
+		if mailboxAddr == "" {
+			mailboxAddr = defaultMailboxServerAddr
+		}
+
+		sessions := existing[s.Label]
+		if len(sessions) > 1 {
+			return nil, fmt.Errorf("%d active sessions with the "+
+				"label %s exist", len(sessions), s.Label)
+		}
+
+		c := &change{}
+		addSession := func(ctx context.Context) error {
+			req := &litrpc.AddSessionRequest{
+				Label:                     s.Label,
+				SessionType:               s.SessionType,
+				ExpiryTimestampSeconds:    s.ExpiryTimestampSeconds,
+				MailboxServerAddr:         mailboxAddr,
+				DevServer:                 s.DevServer,
+				MacaroonCustomPermissions: s.MacaroonCustomPermissions,
+				Notes:                     s.Notes,
+				Tags:                      s.Tags,
+				IdempotentLabel:           true,
+			}
+			if s.AccountLabel != "" {
+				req.AccountId = p.accountIDs[s.AccountLabel]
+			}
+
+			resp, err := p.servers.Sessions.AddSession(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			c.Session = resp.Session
+
+			return nil
+		}
+
+		if len(sessions) == 0 {
+			c.ProvisioningChange = newChange(
+				resourceSession,
+				s.Label,
+				actionCreate,
+				fmt.Sprintf("create %v session", s.SessionType),
+			)
+			c.apply = addSession
+			changes = append(changes, c)
+
+			continue
+		}
+
+		sess := sessions[0]
+		reason := p.replaceReason(s, sess, mailboxAddr)
+		switch {
+		case reason != "":
+			c.ProvisioningChange = newChange(
+				resourceSession,
+				s.Label,
+				actionReplace,
+				fmt.Sprintf("revoke and create new session "+
+					"because the %s changed", reason),
+			)
+			c.apply = func(ctx context.Context) error {
+				_, err := p.servers.Sessions.RevokeSession(
+					ctx, &litrpc.RevokeSessionRequest{
+						LocalPublicKey: sess.LocalPublicKey,
+						Reason: fmt.Sprintf("replaced "+
+							"because the %s "+
+							"changed", reason),
+					},
+				)
+				if err != nil {
+					return err
+				}
+
+				return addSession(ctx)
+			}
+			changes = append(changes, c)
+
+		case sess.Notes != s.Notes || !tagsEqual(sess.Tags, s.Tags):
+			c.ProvisioningChange = newChange(
+				resourceSession,
+				s.Label,
+				actionUpdate,
+				"update notes and tags",
+			)
+			c.apply = func(ctx context.Context) error {
+				_, err := p.servers.Sessions.UpdateSession(
+					ctx, &litrpc.UpdateSessionRequest{
+						LocalPublicKey: sess.LocalPublicKey,
+						Notes:          s.Notes,
+						Tags:           s.Tags,
+					},
+				)
+				return err
+			}
+			changes = append(changes, c)
+		}
+	}
+
+	if !p.prune {
+		return changes, nil
+	}
+
+	for _, s := range active {
+		if _, ok := inSpec[s.Label]; ok {
+			continue
+		}
+
+		pubKey := s.LocalPublicKey
+		name := s.Label
+		if name == "" {
+			name = fmt.Sprintf("%x", pubKey)
+		}
+		p.deletions = append(p.deletions, &change{
+			ProvisioningChange: newChange(
+				resourceSession,
+				name,
+				actionDelete,
+				fmt.Sprintf("revoke %v session", s.SessionType),
+			),
+			apply: func(ctx context.Context) error {
+				_, err := p.servers.Sessions.RevokeSession(
+					ctx, &litrpc.RevokeSessionRequest{
+						LocalPublicKey: pubKey,
+						Reason:         reasonNotInSpec,
+					},
+				)
+				return err
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+// activeSessions returns all sessions of the managed types that are neither
+// revoked nor expired.
+func activeSessions(ctx context.Context,
+	server litrpc.SessionsServer) ([]*litrpc.Session, error) {
+
+	resp, err := server.ListSessions(ctx, &litrpc.ListSessionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+
+	now := uint64(time.Now().Unix())
+	var active []*litrpc.Session
+	for _, s := range resp.Sessions {
+		if s.SessionState != litrpc.SessionState_STATE_CREATED &&
+			s.SessionState != litrpc.SessionState_STATE_IN_USE {
+
+			continue
+		}
+
+		if !isManagedType(s.SessionType) ||
+			s.ExpiryTimestampSeconds <= now {
+
+			continue
+		}
+
+		active = append(active, s)
+	}
+
+	return active, nil
+}
+
+// replaceReason returns which property of the session differs from its spec
+// in a way that can't be updated in place. An empty string is returned if the
+// session doesn't need to be replaced.
+//
+// NOTE: The permissions of custom sessions aren't compared since the spec can
+// contain regular expressions and keywords that are only expanded when the
+// session is created.
+func (p *planner) replaceReason(spec *litrpc.SessionSpec, sess *litrpc.Session,
+	mailboxAddr string) string {
+
+	switch {
+	case sess.SessionType != spec.SessionType:
+		return "type"
+
+	case sess.ExpiryTimestampSeconds != spec.ExpiryTimestampSeconds:
+		return "expiry"
+
+	case sess.MailboxServerAddr != mailboxAddr:
+		return "mailbox server address"
+
+	case sess.DevServer != spec.DevServer:
+		return "dev server flag"
+	}
+
+	if spec.AccountLabel == "" {
+		return ""
+	}
+
+	// If the account is only created by this reconciliation, its ID isn't
+	// known yet and can't be the ID the session is bound to.
+	if id, ok := p.accountIDs[spec.AccountLabel]; !ok ||
+		sess.AccountId != id {
+
+		return "account"
+	}
+
+	return ""
+}
+
+// newChange creates a change that isn't applied yet.
+func newChange(resource litrpc.ProvisioningResource, name string,
+	action litrpc.ProvisioningAction,
+	description string) *litrpc.ProvisioningChange {
+
+	return &litrpc.ProvisioningChange{
+		Resource:    resource,
+		Name:        name,
+		Action:      action,
+		Description: description,
+	}
+}
+
+// tagsEqual returns true if both tag maps contain the same tags.
+func tagsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatExpiry formats an account expiration date for a change description.
+func formatExpiry(expiry int64) string {
+	if expiry == 0 {
+		return "no expiration"
+	}
+
+	return fmt.Sprintf("expiration %s",
+		time.Unix(expiry, 0).UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,407 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type mockAccounts struct {
+	litrpc.UnimplementedAccountsServer
+
+	accounts []*litrpc.Account
+	nextID   int
+}
+
+func (m *mockAccounts) ListAccounts(context.Context,
+	*litrpc.ListAccountsRequest) (*litrpc.ListAccountsResponse, error) {
+
+	return &litrpc.ListAccountsResponse{Accounts: m.accounts}, nil
+}
+
+func (m *mockAccounts) CreateAccount(_ context.Context,
+	req *litrpc.CreateAccountRequest) (*litrpc.CreateAccountResponse,
+	error) {
+
+	m.nextID++
+	acct := &litrpc.Account{
+		Id:             fmt.Sprintf("%016x", m.nextID),
+		InitialBalance: req.AccountBalance,
+		CurrentBalance: int64(req.AccountBalance),
+		ExpirationDate: req.ExpirationDate,
+		Label:          req.Label,
+	}
+	m.accounts = append(m.accounts, acct)
+
+	return &litrpc.CreateAccountResponse{
+		Account:  acct,
+		Macaroon: []byte("macaroon"),
+	}, nil
+}
+
+func (m *mockAccounts) UpdateAccount(_ context.Context,
+	req *litrpc.UpdateAccountRequest) (*litrpc.Account, error) {
+
+	for _, acct := range m.accounts {
+		if acct.Id == req.Id {
+			acct.ExpirationDate = req.ExpirationDate
+			return acct, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account not found")
+}
+
+func (m *mockAccounts) RemoveAccount(_ context.Context,
+	req *litrpc.RemoveAccountRequest) (*litrpc.RemoveAccountResponse,
+	error) {
+
+	for i, acct := range m.accounts {
+		if acct.Id == req.Id {
+			m.accounts = append(m.accounts[:i], m.accounts[i+1:]...)
+			return &litrpc.RemoveAccountResponse{}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account not found")
+}
+
+type mockSessions struct {
+	litrpc.UnimplementedSessionsServer
+
+	sessions []*litrpc.Session
+}
+
+func (m *mockSessions) ListSessions(context.Context,
+	*litrpc.ListSessionsRequest) (*litrpc.ListSessionsResponse, error) {
+
+	return &litrpc.ListSessionsResponse{Sessions: m.sessions}, nil
+}
+
+func (m *mockSessions) AddSession(_ context.Context,
+	req *litrpc.AddSessionRequest) (*litrpc.AddSessionResponse, error) {
+
+	sess := &litrpc.Session{
+		Label:                  req.Label,
+		SessionState:           litrpc.SessionState_STATE_CREATED,
+		SessionType:            req.SessionType,
+		ExpiryTimestampSeconds: req.ExpiryTimestampSeconds,
+		MailboxServerAddr:      req.MailboxServerAddr,
+		DevServer:              req.DevServer,
+		LocalPublicKey:         []byte{byte(len(m.sessions) + 1)},
+		AccountId:              req.AccountId,
+		Notes:                  req.Notes,
+		Tags:                   req.Tags,
+	}
+	m.sessions = append(m.sessions, sess)
+
+	return &litrpc.AddSessionResponse{Session: sess}, nil
+}
+
+func (m *mockSessions) find(pubKey []byte) (*litrpc.Session, error) {
+	for _, sess := range m.sessions {
+		if string(sess.LocalPublicKey) == string(pubKey) {
+			return sess, nil
+		}
+	}
+
+	return nil, fmt.Errorf("session not found")
+}
+
+func (m *mockSessions) RevokeSession(_ context.Context,
+	req *litrpc.RevokeSessionRequest) (*litrpc.RevokeSessionResponse,
+	error) {
+
+	sess, err := m.find(req.LocalPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	sess.SessionState = litrpc.SessionState_STATE_REVOKED
+
+	return &litrpc.RevokeSessionResponse{}, nil
+}
+
+func (m *mockSessions) UpdateSession(_ context.Context,
+	req *litrpc.UpdateSessionRequest) (*litrpc.UpdateSessionResponse,
+	error) {
+
+	sess, err := m.find(req.LocalPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	sess.Notes = req.Notes
+	sess.Tags = req.Tags
+
+	return &litrpc.UpdateSessionResponse{Session: sess}, nil
+}
+
+type mockAutopilot struct {
+	litrpc.UnimplementedAutopilotServer
+
+	bundles map[string]*litrpc.RuleBundle
+}
+
+func (m *mockAutopilot) ListRuleBundles(context.Context,
+	*litrpc.ListRuleBundlesRequest) (*litrpc.ListRuleBundlesResponse,
+	error) {
+
+	resp := &litrpc.ListRuleBundlesResponse{}
+	for _, b := range m.bundles {
+		resp.Bundles = append(resp.Bundles, b)
+	}
+
+	return resp, nil
+}
+
+func (m *mockAutopilot) SetRuleBundle(_ context.Context,
+	req *litrpc.SetRuleBundleRequest) (*litrpc.SetRuleBundleResponse,
+	error) {
+
+	m.bundles[req.Bundle.Name] = proto.Clone(
+		req.Bundle,
+	).(*litrpc.RuleBundle)
+
+	return &litrpc.SetRuleBundleResponse{}, nil
+}
+
+func (m *mockAutopilot) RemoveRuleBundle(_ context.Context,
+	req *litrpc.RemoveRuleBundleRequest) (*litrpc.RemoveRuleBundleResponse,
+	error) {
+
+	delete(m.bundles, req.Name)
+
+	return &litrpc.RemoveRuleBundleResponse{}, nil
+}
+
+// summary returns the action and name of each change.
+func summary(changes []*litrpc.ProvisioningChange) []string {
+	result := make([]string, len(changes))
+	for i, c := range changes {
+		result[i] = fmt.Sprintf("%v %s", c.Action, c.Name)
+	}
+
+	return result
+}
+
+// TestApply tests that the spec is planned and applied and that applying it
+// again doesn't change anything.
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+
+	accounts := &mockAccounts{}
+	_, err := accounts.CreateAccount(ctx, &litrpc.CreateAccountRequest{
+		AccountBalance: 500,
+		Label:          "old",
+	})
+	require.NoError(t, err)
+
+	sessions := &mockSessions{}
+	_, err = sessions.AddSession(ctx, &litrpc.AddSessionRequest{
+		Label:                  "legacy",
+		SessionType:            litrpc.SessionType_TYPE_MACAROON_ADMIN,
+		ExpiryTimestampSeconds: expiry,
+	})
+	require.NoError(t, err)
+	_, err = sessions.AddSession(ctx, &litrpc.AddSessionRequest{
+		Label:                  "ui",
+		SessionType:            litrpc.SessionType_TYPE_UI_PASSWORD,
+		ExpiryTimestampSeconds: expiry,
+	})
+	require.NoError(t, err)
+
+	rateLimit := &litrpc.RulesMap{
+		Rules: map[string]*litrpc.RuleValue{
+			"rate-limit": {
+				Value: &litrpc.RuleValue_RateLimit{
+					RateLimit: &litrpc.RateLimit{
+						ReadLimit: &litrpc.Rate{
+							Iterations: 10,
+							NumHours:   1,
+						},
+						WriteLimit: &litrpc.Rate{
+							Iterations: 1,
+							NumHours:   1,
+						},
+					},
+				},
+			},
+		},
+	}
+	autopilot := &mockAutopilot{
+		bundles: map[string]*litrpc.RuleBundle{
+			"default": {Name: "default", BuiltIn: true},
+			"stale":   {Name: "stale"},
+		},
+	}
+
+	p := NewProvisioner(&Servers{
+		Accounts:  accounts,
+		Sessions:  sessions,
+		Autopilot: autopilot,
+	})
+
+	spec := &litrpc.ProvisioningSpec{
+		Accounts: []*litrpc.AccountSpec{{
+			Label:   "alice",
+			Balance: 1000,
+		}},
+		Sessions: []*litrpc.SessionSpec{{
+			Label:                  "alice-wallet",
+			SessionType:            litrpc.SessionType_TYPE_MACAROON_ACCOUNT,
+			ExpiryTimestampSeconds: expiry,
+			AccountLabel:           "alice",
+		}, {
+			Label:                  "ops",
+			SessionType:            litrpc.SessionType_TYPE_MACAROON_ADMIN,
+			ExpiryTimestampSeconds: expiry,
+			Notes:                  "operations team",
+		}},
+		RuleBundles: []*litrpc.RuleBundle{{
+			Name:  "cautious",
+			Rules: rateLimit,
+		}},
+	}
+
+	// A dry run only plans the changes. The deletions come last, sessions
+	// first.
+	changes, err := p.Apply(ctx, spec, true, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"PROVISIONING_ACTION_CREATE cautious",
+		"PROVISIONING_ACTION_CREATE alice",
+		"PROVISIONING_ACTION_CREATE alice-wallet",
+		"PROVISIONING_ACTION_CREATE ops",
+		"PROVISIONING_ACTION_DELETE legacy",
+		"PROVISIONING_ACTION_DELETE old",
+		"PROVISIONING_ACTION_DELETE stale",
+	}, summary(changes))
+	for _, c := range changes {
+		require.False(t, c.Applied)
+	}
+	require.Len(t, accounts.accounts, 1)
+	require.Len(t, autopilot.bundles, 2)
+
+	// Now apply the changes.
+	changes, err = p.Apply(ctx, spec, false, true)
+	require.NoError(t, err)
+	require.Len(t, changes, 7)
+	for _, c := range changes {
+		require.True(t, c.Applied)
+	}
+
+	require.Len(t, accounts.accounts, 1)
+	alice := accounts.accounts[0]
+	require.Equal(t, "alice", alice.Label)
+	require.Equal(t, alice, changes[1].Account)
+	require.NotEmpty(t, changes[1].AccountMacaroon)
+
+	require.Equal(t, alice.Id, changes[2].Session.AccountId)
+	require.Equal(
+		t, defaultMailboxServerAddr, changes[3].Session.MailboxServerAddr,
+	)
+	require.Equal(
+		t, litrpc.SessionState_STATE_REVOKED,
+		sessions.sessions[0].SessionState,
+	)
+	require.Equal(
+		t, litrpc.SessionState_STATE_CREATED,
+		sessions.sessions[1].SessionState,
+	)
+
+	require.Len(t, autopilot.bundles, 2)
+	require.True(t, proto.Equal(rateLimit, autopilot.bundles["cautious"].Rules))
+
+	// Applying the same spec again doesn't change anything.
+	changes, err = p.Apply(ctx, spec, false, true)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Drifted entries are updated in place where possible.
+	spec.Accounts[0].ExpirationDate = int64(expiry)
+	spec.Sessions[0].Notes = "alice's phone"
+	spec.Sessions[1].ExpiryTimestampSeconds = expiry + 60
+	changes, err = p.Apply(ctx, spec, false, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"PROVISIONING_ACTION_UPDATE alice",
+		"PROVISIONING_ACTION_UPDATE alice-wallet",
+		"PROVISIONING_ACTION_REPLACE ops",
+	}, summary(changes))
+	require.Contains(t, changes[2].Description, "expiry changed")
+
+	require.EqualValues(t, expiry, alice.ExpirationDate)
+	require.Len(t, sessions.sessions, 5)
+	require.Equal(
+		t, litrpc.SessionState_STATE_REVOKED,
+		sessions.sessions[3].SessionState,
+	)
+	require.Equal(t, expiry+60, sessions.sessions[4].ExpiryTimestampSeconds)
+
+	changes, err = p.Apply(ctx, spec, false, false)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Built-in bundles can't be managed.
+	spec.RuleBundles[0].Name = "default"
+	_, err = p.Apply(ctx, spec, true, false)
+	require.ErrorContains(t, err, "built-in")
+}
+
+// TestValidateSpec tests that invalid specs are rejected.
+func TestValidateSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *litrpc.ProvisioningSpec
+		err  string
+	}{{
+		name: "duplicate account",
+		spec: &litrpc.ProvisioningSpec{
+			Accounts: []*litrpc.AccountSpec{
+				{Label: "a"}, {Label: "a"},
+			},
+		},
+		err: "duplicate account label a",
+	}, {
+		name: "unmanaged session type",
+		spec: &litrpc.ProvisioningSpec{
+			Sessions: []*litrpc.SessionSpec{{
+				Label:       "s",
+				SessionType: litrpc.SessionType_TYPE_AUTOPILOT,
+			}},
+		},
+		err: "can't be managed",
+	}, {
+		name: "unknown account",
+		spec: &litrpc.ProvisioningSpec{
+			Sessions: []*litrpc.SessionSpec{{
+				Label:        "s",
+				SessionType:  litrpc.SessionType_TYPE_MACAROON_ACCOUNT,
+				AccountLabel: "a",
+			}},
+		},
+		err: "account a is not part of the spec",
+	}, {
+		name: "account label without account session",
+		spec: &litrpc.ProvisioningSpec{
+			Accounts: []*litrpc.AccountSpec{{Label: "a"}},
+			Sessions: []*litrpc.SessionSpec{{
+				Label:        "s",
+				AccountLabel: "a",
+			}},
+		},
+		err: "only account sessions",
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorContains(t, validateSpec(test.spec), test.err)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+)
+
+// RPCServer is the main server that implements the Provisioning gRPC
+// interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedProvisioningServer
+
+	provisioner *Provisioner
+}
+
+// NewRPCServer returns a new RPC server for the given provisioner.
+func NewRPCServer(provisioner *Provisioner) *RPCServer {
+	return &RPCServer{
+		provisioner: provisioner,
+	}
+}
+
+// ApplySpec reconciles the accounts, sessions and custom rule bundles with
+// the given spec and returns the planned changes.
+func (s *RPCServer) ApplySpec(ctx context.Context,
+	req *litrpc.ApplySpecRequest) (*litrpc.ApplySpecResponse, error) {
+
+	log.Infof("[applyspec] dry_run=%v, prune=%v", req.DryRun, req.Prune)
+
+	changes, err := s.provisioner.Apply(
+		ctx, req.Spec, req.DryRun, req.Prune,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.ApplySpecResponse{
+		Changes: changes,
+	}, nil
+}
+
+// ExportSpec returns the current state as spec.
+func (s *RPCServer) ExportSpec(ctx context.Context,
+	_ *litrpc.ExportSpecRequest) (*litrpc.ExportSpecResponse, error) {
+
+	log.Infof("[exportspec]")
+
+	spec, skipped, err := s.provisioner.Export(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	yamlSpec, err := MarshalYAML(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding spec: %v", err)
+	}
+
+	return &litrpc.ExportSpecResponse{
+		Spec:    spec,
+		Yaml:    yamlSpec,
+		Skipped: skipped,
+	}, nil
+}
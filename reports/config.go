@@ -0,0 +1,91 @@
+package reports
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	// ScheduleNone disables the scheduled reports.
+	ScheduleNone = "none"
+
+	// ScheduleDaily sends a summary of the previous day every day at
+	// midnight UTC.
+	ScheduleDaily = "daily"
+
+	// ScheduleWeekly sends a summary of the previous week every Monday at
+	// midnight UTC.
+	ScheduleWeekly = "weekly"
+
+	// defaultSMTPPort is the default port of the SMTP server.
+	defaultSMTPPort = 587
+)
+
+// Config holds all config options for the scheduled summary reports.
+type Config struct {
+	Schedule string `long:"schedule" description:"How often a summary report is generated and delivered." choice:"none" choice:"daily" choice:"weekly"`
+
+	Webhook *WebhookConfig `group:"webhook" namespace:"webhook"`
+	SMTP    *SMTPConfig    `group:"smtp" namespace:"smtp"`
+}
+
+// WebhookConfig holds the options for delivering the reports to a webhook.
+type WebhookConfig struct {
+	URL string `long:"url" description:"If set, each report is POSTed as JSON to this URL."`
+}
+
+// SMTPConfig holds the options for delivering the reports by email.
+type SMTPConfig struct {
+	Host     string   `long:"host" description:"If set, each report is emailed through the SMTP server at this host."`
+	Port     int      `long:"port" description:"The port of the SMTP server."`
+	Username string   `long:"username" description:"The username used to authenticate with the SMTP server. If empty, no authentication is used."`
+	Password string   `long:"password" description:"The password used to authenticate with the SMTP server."`
+	From     string   `long:"from" description:"The sender address of the report emails."`
+	To       []string `long:"to" description:"A recipient address of the report emails. Can be specified multiple times."`
+}
+
+// DefaultConfig constructs the default reports Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		Schedule: ScheduleNone,
+		Webhook:  &WebhookConfig{},
+		SMTP: &SMTPConfig{
+			Port: defaultSMTPPort,
+		},
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.Webhook.URL != "" {
+		if _, err := url.ParseRequestURI(c.Webhook.URL); err != nil {
+			return fmt.Errorf("invalid reports webhook URL: %v",
+				err)
+		}
+	}
+
+	if c.SMTP.Host != "" {
+		switch {
+		case c.SMTP.Port <= 0 || c.SMTP.Port > 65535:
+			return fmt.Errorf("invalid reports SMTP port %d",
+				c.SMTP.Port)
+
+		case c.SMTP.From == "":
+			return fmt.Errorf("the reports SMTP sender address " +
+				"must be set")
+
+		case len(c.SMTP.To) == 0:
+			return fmt.Errorf("at least one reports SMTP " +
+				"recipient must be set")
+		}
+	}
+
+	if c.Schedule != ScheduleNone && c.Webhook.URL == "" &&
+		c.SMTP.Host == "" {
+
+		return fmt.Errorf("scheduled reports require a webhook URL " +
+			"or an SMTP host to be set")
+	}
+
+	return nil
+}
@@ -0,0 +1,313 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// SectionWallet is the dashboard section of the on-chain balance.
+	SectionWallet = "wallet"
+
+	// SectionChannels is the dashboard section of the channel balances
+	// and counts.
+	SectionChannels = "channels"
+
+	// SectionSwaps is the dashboard section of the pending loop swaps.
+	SectionSwaps = "swaps"
+
+	// SectionPool is the dashboard section of the pool accounts.
+	SectionPool = "pool"
+
+	// SectionAccounts is the dashboard section of the off-chain accounts.
+	SectionAccounts = "accounts"
+
+	// SectionSessions is the dashboard section of the LNC sessions.
+	SectionSessions = "sessions"
+)
+
+// errSourceUnavailable is returned for dashboard sections whose source isn't
+// available in the current configuration, for example if a daemon runs in
+// remote mode.
+var errSourceUnavailable = fmt.Errorf("not available in this configuration")
+
+// Dashboard is a snapshot of the node's current state across all subservers.
+type Dashboard struct {
+	// WalletConfirmed is the confirmed on-chain balance.
+	WalletConfirmed btcutil.Amount
+
+	// WalletUnconfirmed is the unconfirmed on-chain balance.
+	WalletUnconfirmed btcutil.Amount
+
+	// ChannelLocalBalance is the local balance of all open channels.
+	ChannelLocalBalance btcutil.Amount
+
+	// ChannelPendingBalance is the local balance of all pending channels.
+	ChannelPendingBalance btcutil.Amount
+
+	// NumActiveChannels is the number of open channels that are active.
+	NumActiveChannels uint32
+
+	// NumInactiveChannels is the number of open channels that are
+	// inactive.
+	NumInactiveChannels uint32
+
+	// NumPendingChannels is the number of channels that are pending open
+	// or close.
+	NumPendingChannels uint32
+
+	// NumPendingSwaps is the number of loop swaps that haven't reached a
+	// final state yet.
+	NumPendingSwaps uint32
+
+	// PendingSwapAmount is the total amount of the pending swaps.
+	PendingSwapAmount btcutil.Amount
+
+	// NumPoolAccounts is the number of pool accounts that aren't closed.
+	NumPoolAccounts uint32
+
+	// PoolAccountValue is the total value of the pool accounts.
+	PoolAccountValue btcutil.Amount
+
+	// NumActiveAccounts is the number of off-chain accounts that have
+	// neither expired nor been depleted.
+	NumActiveAccounts uint64
+
+	// AccountLiabilities is the total balance of all active accounts.
+	AccountLiabilities lnwire.MilliSatoshi
+
+	// NumActiveSessions is the number of LNC sessions that can still be
+	// used.
+	NumActiveSessions uint32
+
+	// Unavailable maps the sections that couldn't be gathered to the
+	// reason why.
+	Unavailable map[string]error
+}
+
+// UnavailableSections returns the names of the sections that couldn't be
+// gathered, sorted by name.
+func (d *Dashboard) UnavailableSections() []string {
+	sections := make([]string, 0, len(d.Unavailable))
+	for section := range d.Unavailable {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	return sections
+}
+
+// buildDashboard gathers all sections of the dashboard concurrently. A section
+// that can't be gathered doesn't fail the whole dashboard but is reported as
+// unavailable instead, so a single subserver that isn't running doesn't leave
+// the UI without any data.
+func buildDashboard(ctx context.Context, lnd lndclient.LightningClient,
+	sources *SummarySources) *Dashboard {
+
+	d := &Dashboard{
+		Unavailable: make(map[string]error),
+	}
+
+	sections := map[string]func(context.Context) error{
+		SectionWallet: func(ctx context.Context) error {
+			return d.addWallet(ctx, lnd)
+		},
+		SectionChannels: func(ctx context.Context) error {
+			return d.addChannels(ctx, lnd)
+		},
+		SectionSwaps: func(ctx context.Context) error {
+			if sources.Swaps == nil {
+				return errSourceUnavailable
+			}
+
+			swaps, err := sources.Swaps(ctx)
+			if err != nil {
+				return err
+			}
+
+			d.addSwaps(swaps)
+			return nil
+		},
+		SectionPool: func(ctx context.Context) error {
+			if sources.PoolAccounts == nil {
+				return errSourceUnavailable
+			}
+
+			accts, err := sources.PoolAccounts(ctx)
+			if err != nil {
+				return err
+			}
+
+			d.addPoolAccounts(accts)
+			return nil
+		},
+		SectionAccounts: func(ctx context.Context) error {
+			if sources.Accounts == nil {
+				return errSourceUnavailable
+			}
+
+			accts, err := sources.Accounts(ctx)
+			if err != nil {
+				return err
+			}
+
+			// The accounts are counted the same way as in the
+			// summary reports.
+			var summary Summary
+			summary.addAccounts(accts)
+
+			d.NumActiveAccounts = summary.NumActiveAccounts
+			d.AccountLiabilities = summary.AccountLiabilities
+
+			return nil
+		},
+		SectionSessions: func(_ context.Context) error {
+			if sources.Sessions == nil {
+				return errSourceUnavailable
+			}
+
+			sessions, err := sources.Sessions()
+			if err != nil {
+				return err
+			}
+
+			d.addSessions(sessions, time.Now())
+			return nil
+		},
+	}
+
+	// Each section only writes its own fields, so only the map of
+	// unavailable sections needs to be guarded.
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, fn := range sections {
+		name, fn := name, fn
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := fn(ctx); err != nil {
+				log.Debugf("Dashboard section %s unavailable: "+
+					"%v", name, err)
+
+				mu.Lock()
+				d.Unavailable[name] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return d
+}
+
+// addWallet adds the on-chain balance of lnd's wallet to the dashboard.
+func (d *Dashboard) addWallet(ctx context.Context,
+	lnd lndclient.LightningClient) error {
+
+	balance, err := lnd.WalletBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.WalletConfirmed = balance.Confirmed
+	d.WalletUnconfirmed = balance.Unconfirmed
+
+	return nil
+}
+
+// addChannels adds the channel balances and the number of open and pending
+// channels to the dashboard.
+func (d *Dashboard) addChannels(ctx context.Context,
+	lnd lndclient.LightningClient) error {
+
+	balance, err := lnd.ChannelBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	channels, err := lnd.ListChannels(ctx, false, false)
+	if err != nil {
+		return err
+	}
+
+	pending, err := lnd.PendingChannels(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.ChannelLocalBalance = balance.Balance
+	d.ChannelPendingBalance = balance.PendingBalance
+
+	for _, channel := range channels {
+		if channel.Active {
+			d.NumActiveChannels++
+		} else {
+			d.NumInactiveChannels++
+		}
+	}
+
+	d.NumPendingChannels = uint32(
+		len(pending.PendingOpen) + len(pending.PendingForceClose) +
+			len(pending.WaitingClose),
+	)
+
+	return nil
+}
+
+// addSwaps adds the swaps that haven't reached a final state yet to the
+// dashboard.
+func (d *Dashboard) addSwaps(swaps []*looprpc.SwapStatus) {
+	for _, swap := range swaps {
+		switch swap.State {
+		case looprpc.SwapState_SUCCESS, looprpc.SwapState_FAILED:
+			continue
+		}
+
+		d.NumPendingSwaps++
+		d.PendingSwapAmount += btcutil.Amount(swap.Amt)
+	}
+}
+
+// addPoolAccounts adds the pool accounts that aren't closed to the dashboard.
+func (d *Dashboard) addPoolAccounts(accts []*poolrpc.Account) {
+	for _, acct := range accts {
+		if acct.State == poolrpc.AccountState_CLOSED {
+			continue
+		}
+
+		d.NumPoolAccounts++
+		d.PoolAccountValue += btcutil.Amount(acct.Value)
+	}
+}
+
+// addSessions adds the sessions that can still be used at the given time to
+// the dashboard.
+func (d *Dashboard) addSessions(sessions []*session.Session, now time.Time) {
+	for _, sess := range sessions {
+		if sess.State != session.StateCreated &&
+			sess.State != session.StateInUse {
+
+			continue
+		}
+
+		if !sess.Expiry.After(now) {
+			continue
+		}
+
+		d.NumActiveSessions++
+	}
+}
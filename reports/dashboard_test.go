@@ -0,0 +1,160 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLnd is a mock of the lnd client that only implements the calls needed
+// for the dashboard.
+type mockLnd struct {
+	lndclient.LightningClient
+
+	walletErr error
+}
+
+func (m *mockLnd) WalletBalance(context.Context) (*lndclient.WalletBalance,
+	error) {
+
+	if m.walletErr != nil {
+		return nil, m.walletErr
+	}
+
+	return &lndclient.WalletBalance{
+		Confirmed:   100_000,
+		Unconfirmed: 20_000,
+	}, nil
+}
+
+func (m *mockLnd) ChannelBalance(context.Context) (*lndclient.ChannelBalance,
+	error) {
+
+	return &lndclient.ChannelBalance{
+		Balance:        500_000,
+		PendingBalance: 50_000,
+	}, nil
+}
+
+func (m *mockLnd) ListChannels(context.Context, bool,
+	bool) ([]lndclient.ChannelInfo, error) {
+
+	return []lndclient.ChannelInfo{
+		{Active: true}, {Active: true}, {Active: false},
+	}, nil
+}
+
+func (m *mockLnd) PendingChannels(context.Context) (*lndclient.PendingChannels,
+	error) {
+
+	return &lndclient.PendingChannels{
+		PendingOpen:  make([]lndclient.PendingChannel, 1),
+		WaitingClose: make([]lndclient.WaitingCloseChannel, 1),
+	}, nil
+}
+
+// TestDashboard tests that all sections of the dashboard are gathered and
+// that a failing or missing source only marks its own section as
+// unavailable.
+func TestDashboard(t *testing.T) {
+	now := time.Now()
+	sources := &SummarySources{
+		Accounts: func(context.Context) (
+			[]*accounts.OffChainBalanceAccount, error) {
+
+			return []*accounts.OffChainBalanceAccount{{
+				CurrentBalance: 5_000,
+			}, {
+				// Depleted.
+				CurrentBalance: 0,
+			}}, nil
+		},
+		Swaps: func(context.Context) ([]*looprpc.SwapStatus, error) {
+			return []*looprpc.SwapStatus{{
+				Amt:   100_000,
+				State: looprpc.SwapState_HTLC_PUBLISHED,
+			}, {
+				Amt:   50_000,
+				State: looprpc.SwapState_INITIATED,
+			}, {
+				Amt:   70_000,
+				State: looprpc.SwapState_SUCCESS,
+			}}, nil
+		},
+		PoolAccounts: func(context.Context) ([]*poolrpc.Account,
+			error) {
+
+			return nil, errors.New("pool is not running")
+		},
+		Sessions: func() ([]*session.Session, error) {
+			return []*session.Session{{
+				State:  session.StateInUse,
+				Expiry: now.Add(time.Hour),
+			}, {
+				State:  session.StateCreated,
+				Expiry: now.Add(time.Hour),
+			}, {
+				// Expired but not yet marked as such.
+				State:  session.StateInUse,
+				Expiry: now.Add(-time.Hour),
+			}, {
+				State:  session.StateRevoked,
+				Expiry: now.Add(time.Hour),
+			}}, nil
+		},
+	}
+
+	d := buildDashboard(context.Background(), &mockLnd{}, sources)
+
+	require.EqualValues(t, 100_000, d.WalletConfirmed)
+	require.EqualValues(t, 20_000, d.WalletUnconfirmed)
+	require.EqualValues(t, 500_000, d.ChannelLocalBalance)
+	require.EqualValues(t, 50_000, d.ChannelPendingBalance)
+	require.EqualValues(t, 2, d.NumActiveChannels)
+	require.EqualValues(t, 1, d.NumInactiveChannels)
+	require.EqualValues(t, 2, d.NumPendingChannels)
+	require.EqualValues(t, 2, d.NumPendingSwaps)
+	require.EqualValues(t, 150_000, d.PendingSwapAmount)
+	require.EqualValues(t, 1, d.NumActiveAccounts)
+	require.EqualValues(t, 5_000, d.AccountLiabilities)
+	require.EqualValues(t, 2, d.NumActiveSessions)
+	require.Equal(t, []string{SectionPool}, d.UnavailableSections())
+
+	// Without any optional sources and a failing lnd wallet, only the
+	// channels can be gathered.
+	lnd := &mockLnd{walletErr: errors.New("wallet locked")}
+	d = buildDashboard(context.Background(), lnd, &SummarySources{})
+
+	require.EqualValues(t, 2, d.NumActiveChannels)
+	require.Equal(t, []string{
+		SectionAccounts, SectionPool, SectionSessions, SectionSwaps,
+		SectionWallet,
+	}, d.UnavailableSections())
+	require.ErrorIs(t, d.Unavailable[SectionSwaps], errSourceUnavailable)
+}
+
+// TestDashboardPoolAccounts tests that closed pool accounts aren't counted.
+func TestDashboardPoolAccounts(t *testing.T) {
+	var d Dashboard
+	d.addPoolAccounts([]*poolrpc.Account{{
+		Value: 1_000_000,
+		State: poolrpc.AccountState_OPEN,
+	}, {
+		Value: 500_000,
+		State: poolrpc.AccountState_PENDING_UPDATE,
+	}, {
+		Value: 2_000_000,
+		State: poolrpc.AccountState_CLOSED,
+	}})
+
+	require.EqualValues(t, 2, d.NumPoolAccounts)
+	require.EqualValues(t, 1_500_000, d.PoolAccountValue)
+}
@@ -0,0 +1,165 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// deliveryTimeout is the maximum time a delivery of a report may
+	// take.
+	deliveryTimeout = time.Minute
+)
+
+// deliverer delivers a summary report to a single target.
+type deliverer interface {
+	// deliver delivers the given summary.
+	deliver(ctx context.Context, summary *Summary) error
+
+	// String returns a human-readable description of the target.
+	String() string
+}
+
+// webhookDeliverer is a deliverer that POSTs the summary as JSON to a URL.
+type webhookDeliverer struct {
+	url    string
+	client *http.Client
+}
+
+// A compile-time check to ensure that webhookDeliverer implements the
+// deliverer interface.
+var _ deliverer = (*webhookDeliverer)(nil)
+
+// deliver POSTs the JSON encoded summary to the webhook URL. The JSON has the
+// same format as the summary returned by the REST API.
+//
+// NOTE: this is part of the deliverer interface.
+func (w *webhookDeliverer) deliver(ctx context.Context,
+	summary *Summary) error {
+
+	body, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(marshalSummary(summary))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, w.url, bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode,
+			body)
+	}
+
+	return nil
+}
+
+// String returns a human-readable description of the webhook. Only the host
+// is included since the URL might contain credentials.
+//
+// NOTE: this is part of the deliverer interface.
+func (w *webhookDeliverer) String() string {
+	u, err := url.Parse(w.url)
+	if err != nil {
+		return "webhook"
+	}
+
+	return fmt.Sprintf("webhook host %s", u.Host)
+}
+
+// smtpDeliverer is a deliverer that emails the summary in plain text.
+type smtpDeliverer struct {
+	cfg *SMTPConfig
+}
+
+// A compile-time check to ensure that smtpDeliverer implements the deliverer
+// interface.
+var _ deliverer = (*smtpDeliverer)(nil)
+
+// deliver emails the summary to all configured recipients.
+//
+// NOTE: this is part of the deliverer interface.
+func (s *smtpDeliverer) deliver(_ context.Context, summary *Summary) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth(
+			"", s.cfg.Username, s.cfg.Password, s.cfg.Host,
+		)
+	}
+
+	msg := formatEmail(s.cfg.From, s.cfg.To, summary, time.Now())
+
+	return smtp.SendMail(addr, auth, s.cfg.From, s.cfg.To, msg)
+}
+
+// String returns a human-readable description of the SMTP server.
+//
+// NOTE: this is part of the deliverer interface.
+func (s *smtpDeliverer) String() string {
+	return fmt.Sprintf("SMTP host %s", s.cfg.Host)
+}
+
+// formatEmail formats the summary as a plain text email message.
+func formatEmail(from string, to []string, summary *Summary,
+	now time.Time) []byte {
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", summary.Subject())
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&b, "\r\n")
+	b.WriteString(strings.ReplaceAll(summary.Text(), "\n", "\r\n"))
+
+	return b.Bytes()
+}
+
+// deliverersFromConfig creates the deliverers for all targets configured in
+// the given config.
+func deliverersFromConfig(cfg *Config) []deliverer {
+	var deliverers []deliverer
+	if cfg.Webhook.URL != "" {
+		deliverers = append(deliverers, &webhookDeliverer{
+			url:    cfg.Webhook.URL,
+			client: http.DefaultClient,
+		})
+	}
+
+	if cfg.SMTP.Host != "" {
+		deliverers = append(deliverers, &smtpDeliverer{cfg: cfg.SMTP})
+	}
+
+	return deliverers
+}
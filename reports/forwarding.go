@@ -0,0 +1,180 @@
+package reports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ForwardingStats are the aggregated values of a set of forwards.
+type ForwardingStats struct {
+	// NumForwards is the number of forwards.
+	NumForwards uint64
+
+	// VolumeIn is the total amount that came in through the incoming
+	// channels.
+	VolumeIn lnwire.MilliSatoshi
+
+	// VolumeOut is the total amount that went out through the outgoing
+	// channels.
+	VolumeOut lnwire.MilliSatoshi
+
+	// Fees is the routing revenue earned with the forwards.
+	Fees lnwire.MilliSatoshi
+}
+
+// add adds the given forwarding event to the stats.
+func (s *ForwardingStats) add(event *lndclient.ForwardingEvent) {
+	s.NumForwards++
+	s.VolumeIn += event.AmountMsatIn
+	s.VolumeOut += event.AmountMsatOut
+	s.Fees += event.FeeMsat
+}
+
+// FeePPM returns the routing revenue in parts per million of the outgoing
+// volume.
+func (s *ForwardingStats) FeePPM() uint64 {
+	return ppm(s.Fees, s.VolumeOut)
+}
+
+// ChannelForwardingStats are the aggregated forwards of a single channel.
+type ChannelForwardingStats struct {
+	// ChanID is the short channel ID of the channel.
+	ChanID uint64
+
+	// ForwardsIn is the number of forwards that came in through the
+	// channel.
+	ForwardsIn uint64
+
+	// ForwardsOut is the number of forwards that went out through the
+	// channel.
+	ForwardsOut uint64
+
+	// VolumeIn is the total amount that came in through the channel.
+	VolumeIn lnwire.MilliSatoshi
+
+	// VolumeOut is the total amount that went out through the channel.
+	VolumeOut lnwire.MilliSatoshi
+
+	// Fees is the routing revenue earned by forwards that went out through
+	// the channel.
+	Fees lnwire.MilliSatoshi
+}
+
+// FeePPM returns the routing revenue of the channel in parts per million of
+// its outgoing volume.
+func (s *ChannelForwardingStats) FeePPM() uint64 {
+	return ppm(s.Fees, s.VolumeOut)
+}
+
+// ForwardingBucket are the aggregated forwards of a time bucket.
+type ForwardingBucket struct {
+	// Start is the start of the bucket, inclusive.
+	Start time.Time
+
+	// End is the end of the bucket, exclusive.
+	End time.Time
+
+	// Stats are the aggregated forwards of the bucket.
+	Stats ForwardingStats
+}
+
+// ForwardingReport is the aggregated forwarding history of a time window.
+type ForwardingReport struct {
+	// Totals are the aggregated forwards of the whole time window.
+	Totals ForwardingStats
+
+	// Channels are the aggregated forwards per channel, sorted by revenue
+	// in descending order.
+	Channels []*ChannelForwardingStats
+
+	// Buckets are the aggregated forwards per time bucket. It is only set
+	// if a bucket size was given.
+	Buckets []*ForwardingBucket
+}
+
+// aggregateForwards aggregates the given forwarding events that happened in
+// the window [start, end) into a report. If bucketSize is non-zero, the
+// window is split into buckets of that size.
+func aggregateForwards(events []lndclient.ForwardingEvent, start,
+	end time.Time, bucketSize time.Duration) *ForwardingReport {
+
+	report := &ForwardingReport{}
+
+	if bucketSize > 0 {
+		for t := start; t.Before(end); t = t.Add(bucketSize) {
+			bucketEnd := t.Add(bucketSize)
+			if bucketEnd.After(end) {
+				bucketEnd = end
+			}
+
+			report.Buckets = append(
+				report.Buckets, &ForwardingBucket{
+					Start: t,
+					End:   bucketEnd,
+				},
+			)
+		}
+	}
+
+	channels := make(map[uint64]*ChannelForwardingStats)
+	channel := func(chanID uint64) *ChannelForwardingStats {
+		c, ok := channels[chanID]
+		if !ok {
+			c = &ChannelForwardingStats{ChanID: chanID}
+			channels[chanID] = c
+		}
+
+		return c
+	}
+
+	for i := range events {
+		event := &events[i]
+		if event.Timestamp.Before(start) ||
+			!event.Timestamp.Before(end) {
+
+			continue
+		}
+
+		report.Totals.add(event)
+
+		in := channel(event.ChannelIn)
+		in.ForwardsIn++
+		in.VolumeIn += event.AmountMsatIn
+
+		out := channel(event.ChannelOut)
+		out.ForwardsOut++
+		out.VolumeOut += event.AmountMsatOut
+		out.Fees += event.FeeMsat
+
+		if bucketSize > 0 {
+			idx := int(event.Timestamp.Sub(start) / bucketSize)
+			report.Buckets[idx].Stats.add(event)
+		}
+	}
+
+	for _, c := range channels {
+		report.Channels = append(report.Channels, c)
+	}
+	sort.Slice(report.Channels, func(i, j int) bool {
+		ci, cj := report.Channels[i], report.Channels[j]
+		if ci.Fees != cj.Fees {
+			return ci.Fees > cj.Fees
+		}
+
+		return ci.ChanID < cj.ChanID
+	})
+
+	return report
+}
+
+// ppm returns the given part in parts per million of the given total.
+func ppm(part, total lnwire.MilliSatoshi) uint64 {
+	if total == 0 {
+		return 0
+	}
+
+	return uint64(part) * 1_000_000 / uint64(total)
+}
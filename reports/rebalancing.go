@@ -0,0 +1,137 @@
+package reports
+
+import (
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ChannelRebalanceStats are the aggregated rebalances of a single channel.
+type ChannelRebalanceStats struct {
+	// ChanID is the short channel ID of the channel.
+	ChanID uint64
+
+	// NumIn is the number of rebalances that added liquidity to the
+	// channel.
+	NumIn uint64
+
+	// AmountIn is the total amount of liquidity added to the channel.
+	AmountIn lnwire.MilliSatoshi
+
+	// NumOut is the number of rebalances that removed liquidity from the
+	// channel.
+	NumOut uint64
+
+	// AmountOut is the total amount of liquidity removed from the channel.
+	AmountOut lnwire.MilliSatoshi
+
+	// Fees are the fees paid for the rebalances that added liquidity to
+	// the channel.
+	Fees lnwire.MilliSatoshi
+}
+
+// RebalanceReport is the aggregated set of rebalances of a time window.
+type RebalanceReport struct {
+	// NumRebalances is the number of rebalances.
+	NumRebalances uint64
+
+	// Amount is the total amount that was rebalanced.
+	Amount lnwire.MilliSatoshi
+
+	// Fees are the total fees paid for the rebalances.
+	Fees lnwire.MilliSatoshi
+
+	// Channels are the aggregated rebalances per channel, sorted by fees
+	// paid in descending order.
+	Channels []*ChannelRebalanceStats
+}
+
+// FeePPM returns the fees paid in parts per million of the rebalanced amount.
+func (r *RebalanceReport) FeePPM() uint64 {
+	return ppm(r.Fees, r.Amount)
+}
+
+// aggregateRebalances aggregates the circular payments of the node with the
+// given public key that settled in the window [start, end) into a report. A
+// payment is considered circular if the route of a successful HTLC ends at the
+// node itself.
+func aggregateRebalances(payments []lndclient.Payment, self []byte, start,
+	end time.Time) *RebalanceReport {
+
+	report := &RebalanceReport{}
+	channels := make(map[uint64]*ChannelRebalanceStats)
+	channel := func(chanID uint64) *ChannelRebalanceStats {
+		c, ok := channels[chanID]
+		if !ok {
+			c = &ChannelRebalanceStats{ChanID: chanID}
+			channels[chanID] = c
+		}
+
+		return c
+	}
+
+	for _, payment := range payments {
+		for _, htlc := range payment.Htlcs {
+			if !isCircularHtlc(htlc, self) {
+				continue
+			}
+
+			resolved := time.Unix(0, htlc.ResolveTimeNs)
+			if resolved.Before(start) || !resolved.Before(end) {
+				continue
+			}
+
+			hops := htlc.Route.Hops
+			lastHop := hops[len(hops)-1]
+			amt := lnwire.MilliSatoshi(lastHop.AmtToForwardMsat)
+			fees := lnwire.MilliSatoshi(htlc.Route.TotalFeesMsat)
+
+			report.NumRebalances++
+			report.Amount += amt
+			report.Fees += fees
+
+			out := channel(hops[0].ChanId)
+			out.NumOut++
+			out.AmountOut += amt
+
+			in := channel(lastHop.ChanId)
+			in.NumIn++
+			in.AmountIn += amt
+			in.Fees += fees
+		}
+	}
+
+	for _, c := range channels {
+		report.Channels = append(report.Channels, c)
+	}
+	sort.Slice(report.Channels, func(i, j int) bool {
+		ci, cj := report.Channels[i], report.Channels[j]
+		if ci.Fees != cj.Fees {
+			return ci.Fees > cj.Fees
+		}
+
+		return ci.ChanID < cj.ChanID
+	})
+
+	return report
+}
+
+// isCircularHtlc returns true if the given HTLC attempt succeeded and its
+// route ends at the node with the given public key.
+func isCircularHtlc(htlc *lnrpc.HTLCAttempt, self []byte) bool {
+	if htlc.Status != lnrpc.HTLCAttempt_SUCCEEDED || htlc.Route == nil {
+		return false
+	}
+
+	hops := htlc.Route.Hops
+	if len(hops) < 2 {
+		return false
+	}
+
+	lastHop := hops[len(hops)-1]
+	return lastHop.PubKey == hex.EncodeToString(self)
+}
@@ -0,0 +1,168 @@
+package reports
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAggregateForwards tests that forwarding events are correctly
+// aggregated in total, per channel and per bucket.
+func TestAggregateForwards(t *testing.T) {
+	start := time.Unix(1_000_000, 0)
+	end := start.Add(3 * time.Hour)
+
+	events := []lndclient.ForwardingEvent{{
+		// Before the window.
+		Timestamp:     start.Add(-time.Second),
+		ChannelIn:     1,
+		ChannelOut:    2,
+		AmountMsatIn:  1_000_100,
+		AmountMsatOut: 1_000_000,
+		FeeMsat:       100,
+	}, {
+		Timestamp:     start,
+		ChannelIn:     1,
+		ChannelOut:    2,
+		AmountMsatIn:  1_000_100,
+		AmountMsatOut: 1_000_000,
+		FeeMsat:       100,
+	}, {
+		Timestamp:     start.Add(90 * time.Minute),
+		ChannelIn:     2,
+		ChannelOut:    3,
+		AmountMsatIn:  2_001_000,
+		AmountMsatOut: 2_000_000,
+		FeeMsat:       1_000,
+	}, {
+		// Exactly at the end of the window.
+		Timestamp:     end,
+		ChannelIn:     1,
+		ChannelOut:    3,
+		AmountMsatIn:  1_000_100,
+		AmountMsatOut: 1_000_000,
+		FeeMsat:       100,
+	}}
+
+	report := aggregateForwards(events, start, end, time.Hour)
+
+	require.Equal(t, ForwardingStats{
+		NumForwards: 2,
+		VolumeIn:    3_001_100,
+		VolumeOut:   3_000_000,
+		Fees:        1_100,
+	}, report.Totals)
+	require.EqualValues(t, 366, report.Totals.FeePPM())
+
+	require.Equal(t, []*ChannelForwardingStats{{
+		ChanID:      3,
+		ForwardsOut: 1,
+		VolumeOut:   2_000_000,
+		Fees:        1_000,
+	}, {
+		ChanID:      2,
+		ForwardsIn:  1,
+		ForwardsOut: 1,
+		VolumeIn:    2_001_000,
+		VolumeOut:   1_000_000,
+		Fees:        100,
+	}, {
+		ChanID:     1,
+		ForwardsIn: 1,
+		VolumeIn:   1_000_100,
+	}}, report.Channels)
+	require.EqualValues(t, 500, report.Channels[0].FeePPM())
+
+	require.Len(t, report.Buckets, 3)
+	require.EqualValues(t, 1, report.Buckets[0].Stats.NumForwards)
+	require.EqualValues(t, 1, report.Buckets[1].Stats.NumForwards)
+	require.EqualValues(t, 0, report.Buckets[2].Stats.NumForwards)
+	require.Equal(t, end, report.Buckets[2].End)
+
+	// Without a bucket size, no buckets should be created.
+	report = aggregateForwards(events, start, end, 0)
+	require.Empty(t, report.Buckets)
+}
+
+// TestAggregateRebalances tests that only successful circular payments are
+// aggregated as rebalances.
+func TestAggregateRebalances(t *testing.T) {
+	self := []byte{2, 1, 2, 3}
+	selfHex := hex.EncodeToString(self)
+	start := time.Unix(1_000_000, 0)
+	end := start.Add(time.Hour)
+
+	circularHtlc := func(status lnrpc.HTLCAttempt_HTLCStatus,
+		resolved time.Time, outChan, inChan uint64) *lnrpc.HTLCAttempt {
+
+		return &lnrpc.HTLCAttempt{
+			Status:        status,
+			ResolveTimeNs: resolved.UnixNano(),
+			Route: &lnrpc.Route{
+				TotalFeesMsat: 500,
+				Hops: []*lnrpc.Hop{{
+					ChanId: outChan,
+					PubKey: "peer",
+				}, {
+					ChanId:           inChan,
+					PubKey:           selfHex,
+					AmtToForwardMsat: 1_000_000,
+				}},
+			},
+		}
+	}
+
+	payments := []lndclient.Payment{{
+		Htlcs: []*lnrpc.HTLCAttempt{
+			// A failed attempt followed by a successful one.
+			circularHtlc(
+				lnrpc.HTLCAttempt_FAILED,
+				start.Add(time.Minute), 1, 2,
+			),
+			circularHtlc(
+				lnrpc.HTLCAttempt_SUCCEEDED,
+				start.Add(2*time.Minute), 1, 2,
+			),
+		},
+	}, {
+		Htlcs: []*lnrpc.HTLCAttempt{
+			// Outside the window.
+			circularHtlc(
+				lnrpc.HTLCAttempt_SUCCEEDED,
+				end.Add(time.Minute), 1, 2,
+			),
+		},
+	}, {
+		Htlcs: []*lnrpc.HTLCAttempt{{
+			// A regular payment to another node.
+			Status:        lnrpc.HTLCAttempt_SUCCEEDED,
+			ResolveTimeNs: start.Add(time.Minute).UnixNano(),
+			Route: &lnrpc.Route{
+				Hops: []*lnrpc.Hop{
+					{ChanId: 1, PubKey: "peer"},
+					{ChanId: 5, PubKey: "other"},
+				},
+			},
+		}},
+	}}
+
+	report := aggregateRebalances(payments, self, start, end)
+	require.EqualValues(t, 1, report.NumRebalances)
+	require.EqualValues(t, 1_000_000, report.Amount)
+	require.EqualValues(t, 500, report.Fees)
+	require.EqualValues(t, 500, report.FeePPM())
+	require.Equal(t, []*ChannelRebalanceStats{{
+		ChanID:   2,
+		NumIn:    1,
+		AmountIn: 1_000_000,
+		Fees:     500,
+	}, {
+		ChanID:    1,
+		NumOut:    1,
+		AmountOut: 1_000_000,
+	}}, report.Channels)
+}
@@ -0,0 +1,350 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lndclient"
+)
+
+const (
+	// maxBuckets is the maximum number of time buckets a forwarding report
+	// can be split into.
+	maxBuckets = 1000
+
+	// forwardingPageSize is the number of forwarding events that are
+	// fetched from lnd per call.
+	forwardingPageSize = 10_000
+
+	// paymentsPageSize is the number of payments that are fetched from
+	// lnd per call.
+	paymentsPageSize = 1_000
+)
+
+// RPCServer is the main server that implements the Reports gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedReportsServer
+
+	scheduler *Scheduler
+
+	lnd lndclient.LightningClient
+}
+
+// NewRPCServer returns a new RPC server for the reports.
+func NewRPCServer(scheduler *Scheduler) *RPCServer {
+	return &RPCServer{
+		scheduler: scheduler,
+	}
+}
+
+// Start sets the lnd client that is used to fetch the data the reports are
+// computed from.
+func (s *RPCServer) Start(lnd lndclient.LightningClient) {
+	s.lnd = lnd
+}
+
+// ForwardingReport aggregates the forwarding history of the given time window
+// into the routing revenue, volume and fee efficiency in total, per channel and
+// optionally per time bucket.
+func (s *RPCServer) ForwardingReport(ctx context.Context,
+	req *litrpc.ForwardingReportRequest) (*litrpc.ForwardingReportResponse,
+	error) {
+
+	log.Infof("[forwardingreport] start=%d, end=%d, bucket=%d",
+		req.StartTime, req.EndTime, req.BucketSeconds)
+
+	if s.lnd == nil {
+		return nil, fmt.Errorf("reports server not started")
+	}
+
+	start, end, err := parseWindow(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketSize := time.Duration(req.BucketSeconds) * time.Second
+	if bucketSize > 0 && end.Sub(start)/bucketSize >= maxBuckets {
+		return nil, fmt.Errorf("a report can have at most %d buckets",
+			maxBuckets)
+	}
+
+	events, err := fetchForwards(ctx, s.lnd, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := aggregateForwards(events, start, end, bucketSize)
+
+	rpcResp := &litrpc.ForwardingReportResponse{
+		Totals: marshalForwardingStats(&report.Totals),
+	}
+	for _, c := range report.Channels {
+		rpcResp.Channels = append(
+			rpcResp.Channels, &litrpc.ChannelForwardingStats{
+				ChanId:        c.ChanID,
+				ForwardsIn:    c.ForwardsIn,
+				ForwardsOut:   c.ForwardsOut,
+				VolumeInMsat:  uint64(c.VolumeIn),
+				VolumeOutMsat: uint64(c.VolumeOut),
+				FeesMsat:      uint64(c.Fees),
+				FeePpm:        c.FeePPM(),
+			},
+		)
+	}
+	for _, b := range report.Buckets {
+		rpcResp.Buckets = append(
+			rpcResp.Buckets, &litrpc.ForwardingBucket{
+				StartTime: uint64(b.Start.Unix()),
+				EndTime:   uint64(b.End.Unix()),
+				Stats:     marshalForwardingStats(&b.Stats),
+			},
+		)
+	}
+
+	return rpcResp, nil
+}
+
+// RebalanceReport aggregates the circular payments of the node in the given
+// time window into the amounts moved and fees paid in total and per channel.
+func (s *RPCServer) RebalanceReport(ctx context.Context,
+	req *litrpc.RebalanceReportRequest) (*litrpc.RebalanceReportResponse,
+	error) {
+
+	log.Infof("[rebalancereport] start=%d, end=%d", req.StartTime,
+		req.EndTime)
+
+	if s.lnd == nil {
+		return nil, fmt.Errorf("reports server not started")
+	}
+
+	start, end, err := parseWindow(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.lnd.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch node info: %v", err)
+	}
+
+	// Payments can't be filtered by time, so we page through them from
+	// newest to oldest until we reach payments that were all created
+	// before the start of the window.
+	var (
+		payments []lndclient.Payment
+		offset   uint64
+	)
+	for {
+		resp, err := s.lnd.ListPayments(
+			ctx, lndclient.ListPaymentsRequest{
+				MaxPayments: paymentsPageSize,
+				Offset:      offset,
+				Reversed:    true,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch payments: %v",
+				err)
+		}
+
+		payments = append(payments, resp.Payments...)
+		if len(resp.Payments) < paymentsPageSize ||
+			allBefore(resp.Payments, start) {
+
+			break
+		}
+		offset = resp.FirstIndexOffset
+	}
+
+	report := aggregateRebalances(
+		payments, info.IdentityPubkey[:], start, end,
+	)
+
+	rpcResp := &litrpc.RebalanceReportResponse{
+		NumRebalances: report.NumRebalances,
+		AmountMsat:    uint64(report.Amount),
+		FeesMsat:      uint64(report.Fees),
+		FeePpm:        report.FeePPM(),
+	}
+	for _, c := range report.Channels {
+		rpcResp.Channels = append(
+			rpcResp.Channels, &litrpc.ChannelRebalanceStats{
+				ChanId:        c.ChanID,
+				NumIn:         c.NumIn,
+				AmountInMsat:  uint64(c.AmountIn),
+				NumOut:        c.NumOut,
+				AmountOutMsat: uint64(c.AmountOut),
+				FeesMsat:      uint64(c.Fees),
+			},
+		)
+	}
+
+	return rpcResp, nil
+}
+
+// SendTestReport generates a summary report of the last scheduled period and
+// delivers it to all configured delivery targets right away.
+func (s *RPCServer) SendTestReport(ctx context.Context,
+	_ *litrpc.SendTestReportRequest) (*litrpc.SendTestReportResponse,
+	error) {
+
+	log.Infof("[sendtestreport]")
+
+	summary, results, err := s.scheduler.SendTestReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.SendTestReportResponse{
+		Summary: marshalSummary(summary),
+	}
+	for _, r := range results {
+		delivery := &litrpc.ReportDelivery{
+			Target: r.Target,
+		}
+		if r.Err != nil {
+			delivery.Error = r.Err.Error()
+		}
+
+		resp.Deliveries = append(resp.Deliveries, delivery)
+	}
+
+	return resp, nil
+}
+
+// DashboardSummary returns a snapshot of the node's current state across all
+// subservers. Sections that can't be gathered are listed as unavailable
+// instead of failing the whole call.
+func (s *RPCServer) DashboardSummary(ctx context.Context,
+	_ *litrpc.DashboardSummaryRequest) (*litrpc.DashboardSummaryResponse,
+	error) {
+
+	log.Debugf("[dashboardsummary]")
+
+	d, err := s.scheduler.Dashboard(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.DashboardSummaryResponse{
+		WalletConfirmedSat:       uint64(d.WalletConfirmed),
+		WalletUnconfirmedSat:     uint64(d.WalletUnconfirmed),
+		ChannelLocalBalanceSat:   uint64(d.ChannelLocalBalance),
+		ChannelPendingBalanceSat: uint64(d.ChannelPendingBalance),
+		NumActiveChannels:        d.NumActiveChannels,
+		NumInactiveChannels:      d.NumInactiveChannels,
+		NumPendingChannels:       d.NumPendingChannels,
+		NumPendingSwaps:          d.NumPendingSwaps,
+		PendingSwapAmountSat:     uint64(d.PendingSwapAmount),
+		NumPoolAccounts:          d.NumPoolAccounts,
+		PoolAccountValueSat:      uint64(d.PoolAccountValue),
+		NumActiveAccounts:        d.NumActiveAccounts,
+		AccountLiabilitiesMsat:   uint64(d.AccountLiabilities),
+		NumActiveSessions:        d.NumActiveSessions,
+	}
+	for _, section := range d.UnavailableSections() {
+		resp.Unavailable = append(
+			resp.Unavailable, &litrpc.UnavailableSection{
+				Section: section,
+				Reason:  d.Unavailable[section].Error(),
+			},
+		)
+	}
+
+	return resp, nil
+}
+
+// fetchForwards fetches all forwarding events of the given time window from
+// lnd.
+func fetchForwards(ctx context.Context, lnd lndclient.LightningClient, start,
+	end time.Time) ([]lndclient.ForwardingEvent, error) {
+
+	var (
+		events []lndclient.ForwardingEvent
+		offset uint32
+	)
+	for {
+		resp, err := lnd.ForwardingHistory(
+			ctx, lndclient.ForwardingHistoryRequest{
+				StartTime: start,
+				EndTime:   end,
+				Offset:    offset,
+				MaxEvents: forwardingPageSize,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch forwarding "+
+				"history: %v", err)
+		}
+
+		events = append(events, resp.Events...)
+		if len(resp.Events) < forwardingPageSize {
+			return events, nil
+		}
+		offset = resp.LastIndexOffset
+	}
+}
+
+// parseWindow converts the given unix timestamps into a time window. An end
+// of zero means now.
+func parseWindow(startUnix, endUnix uint64) (time.Time, time.Time, error) {
+	start := time.Unix(int64(startUnix), 0)
+	end := time.Now()
+	if endUnix != 0 {
+		end = time.Unix(int64(endUnix), 0)
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start time must " +
+			"be before end time")
+	}
+
+	return start, end, nil
+}
+
+// allBefore returns true if all HTLC attempts of all the given payments were
+// made before the given time.
+func allBefore(payments []lndclient.Payment, t time.Time) bool {
+	for _, payment := range payments {
+		for _, htlc := range payment.Htlcs {
+			if !time.Unix(0, htlc.AttemptTimeNs).Before(t) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// marshalForwardingStats converts the forwarding stats into their RPC
+// counterpart.
+func marshalForwardingStats(s *ForwardingStats) *litrpc.ForwardingStats {
+	return &litrpc.ForwardingStats{
+		NumForwards:   s.NumForwards,
+		VolumeInMsat:  uint64(s.VolumeIn),
+		VolumeOutMsat: uint64(s.VolumeOut),
+		FeesMsat:      uint64(s.Fees),
+		FeePpm:        s.FeePPM(),
+	}
+}
+
+// marshalSummary converts the summary into its RPC counterpart.
+func marshalSummary(s *Summary) *litrpc.ReportSummary {
+	return &litrpc.ReportSummary{
+		StartTime:              uint64(s.Start.Unix()),
+		EndTime:                uint64(s.End.Unix()),
+		Routing:                marshalForwardingStats(&s.Routing),
+		NumActiveAccounts:      s.NumActiveAccounts,
+		AccountLiabilitiesMsat: uint64(s.AccountLiabilities),
+		NumLoopOuts:            s.NumLoopOuts,
+		NumLoopIns:             s.NumLoopIns,
+		NumFailedSwaps:         s.NumFailedSwaps,
+		SwapAmountSat:          uint64(s.SwapAmount),
+		SwapCostSat:            uint64(s.SwapCost),
+		NumActions:             s.NumActions,
+		NumFailedActions:       s.NumFailedActions,
+	}
+}
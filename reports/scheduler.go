@@ -0,0 +1,188 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lndclient"
+)
+
+// periodLength returns the length of the period that is summarized by each
+// report of the given schedule.
+func periodLength(schedule string) time.Duration {
+	if schedule == ScheduleWeekly {
+		return 7 * 24 * time.Hour
+	}
+
+	return 24 * time.Hour
+}
+
+// lastPeriodEnd returns the end of the most recent complete period of the
+// given schedule before or at the given time. Daily periods end at midnight
+// UTC, weekly periods end on Monday at midnight UTC.
+func lastPeriodEnd(schedule string, now time.Time) time.Time {
+	now = now.UTC()
+	end := time.Date(
+		now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC,
+	)
+
+	if schedule == ScheduleWeekly {
+		daysSinceMonday := (int(end.Weekday()) + 6) % 7
+		end = end.AddDate(0, 0, -daysSinceMonday)
+	}
+
+	return end
+}
+
+// DeliveryResult is the outcome of the delivery of a report to a single
+// target.
+type DeliveryResult struct {
+	// Target is a human-readable description of the target.
+	Target string
+
+	// Err is the error that occurred during the delivery, nil if it
+	// succeeded.
+	Err error
+}
+
+// Scheduler periodically generates summary reports of the node's activity and
+// delivers them via webhook or email.
+type Scheduler struct {
+	cfg        *Config
+	sources    *SummarySources
+	deliverers []deliverer
+
+	lnd lndclient.LightningClient
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new reports Scheduler.
+func NewScheduler(cfg *Config, sources *SummarySources) *Scheduler {
+	return &Scheduler{
+		cfg:        cfg,
+		sources:    sources,
+		deliverers: deliverersFromConfig(cfg),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start sets the lnd client the summaries are gathered with and starts the
+// scheduled reports if they are enabled.
+func (s *Scheduler) Start(lnd lndclient.LightningClient) error {
+	s.lnd = lnd
+
+	if s.cfg.Schedule == ScheduleNone {
+		return nil
+	}
+
+	log.Infof("Starting %s reports", s.cfg.Schedule)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-s.quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		length := periodLength(s.cfg.Schedule)
+		for {
+			next := lastPeriodEnd(
+				s.cfg.Schedule, time.Now(),
+			).Add(length)
+
+			select {
+			case <-time.After(time.Until(next)):
+			case <-s.quit:
+				return
+			}
+
+			_, _, err := s.sendReport(ctx, next.Add(-length), next)
+			if err != nil {
+				log.Errorf("Unable to create report: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the scheduler and waits for any running delivery to finish.
+func (s *Scheduler) Stop() error {
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// SendTestReport generates a summary of the most recent complete period and
+// delivers it to all configured targets right away. If no schedule is
+// configured, the previous day is summarized.
+func (s *Scheduler) SendTestReport(ctx context.Context) (*Summary,
+	[]DeliveryResult, error) {
+
+	if len(s.deliverers) == 0 {
+		return nil, nil, fmt.Errorf("no report delivery targets " +
+			"configured")
+	}
+
+	end := lastPeriodEnd(s.cfg.Schedule, time.Now())
+	start := end.Add(-periodLength(s.cfg.Schedule))
+
+	return s.sendReport(ctx, start, end)
+}
+
+// Dashboard gathers a snapshot of the node's current state across all
+// subservers.
+func (s *Scheduler) Dashboard(ctx context.Context) (*Dashboard, error) {
+	if s.lnd == nil {
+		return nil, fmt.Errorf("reports scheduler not started")
+	}
+
+	return buildDashboard(ctx, s.lnd, s.sources), nil
+}
+
+// sendReport generates the summary of the given period and delivers it to all
+// configured targets. A failed delivery to one target doesn't prevent the
+// delivery to the other targets.
+func (s *Scheduler) sendReport(ctx context.Context, start,
+	end time.Time) (*Summary, []DeliveryResult, error) {
+
+	if s.lnd == nil {
+		return nil, nil, fmt.Errorf("reports scheduler not started")
+	}
+
+	summary, err := buildSummary(ctx, s.lnd, s.sources, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]DeliveryResult, len(s.deliverers))
+	for i, d := range s.deliverers {
+		results[i] = DeliveryResult{
+			Target: d.String(),
+			Err:    d.deliver(ctx, summary),
+		}
+
+		if results[i].Err != nil {
+			log.Errorf("Unable to deliver report to %v: %v", d,
+				results[i].Err)
+			continue
+		}
+
+		log.Infof("Delivered report to %v", d)
+	}
+
+	return summary, results, nil
+}
@@ -0,0 +1,199 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLastPeriodEnd tests that the end of the most recent complete period is
+// determined correctly for both schedules.
+func TestLastPeriodEnd(t *testing.T) {
+	// 2023-03-15 was a Wednesday.
+	now := time.Date(2023, 3, 15, 13, 37, 0, 0, time.UTC)
+
+	require.Equal(
+		t, time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC),
+		lastPeriodEnd(ScheduleDaily, now),
+	)
+	require.Equal(
+		t, time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC),
+		lastPeriodEnd(ScheduleWeekly, now),
+	)
+
+	// A Monday at midnight is the end of the previous week.
+	monday := time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, monday, lastPeriodEnd(ScheduleWeekly, monday))
+
+	// A Sunday belongs to the week that started on the previous Monday.
+	sunday := time.Date(2023, 3, 19, 23, 0, 0, 0, time.UTC)
+	require.Equal(t, monday, lastPeriodEnd(ScheduleWeekly, sunday))
+}
+
+// TestSummary tests that accounts, swaps and actions are correctly added to a
+// summary.
+func TestSummary(t *testing.T) {
+	start := time.Unix(1_000_000, 0)
+	end := start.Add(24 * time.Hour)
+	summary := &Summary{
+		Start: start,
+		End:   end,
+	}
+
+	summary.addAccounts([]*accounts.OffChainBalanceAccount{{
+		CurrentBalance: 5_000,
+	}, {
+		CurrentBalance: 7_000,
+		ExpirationDate: time.Now().Add(time.Hour),
+	}, {
+		// Expired.
+		CurrentBalance: 1_000,
+		ExpirationDate: time.Now().Add(-time.Hour),
+	}, {
+		// Depleted.
+		CurrentBalance: 0,
+	}})
+	require.EqualValues(t, 2, summary.NumActiveAccounts)
+	require.Equal(t, lnwire.MilliSatoshi(12_000), summary.AccountLiabilities)
+
+	summary.addSwaps([]*looprpc.SwapStatus{{
+		Amt:            100_000,
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_SUCCESS,
+		LastUpdateTime: start.UnixNano(),
+		CostServer:     100,
+		CostOnchain:    200,
+		CostOffchain:   3,
+	}, {
+		Amt:            50_000,
+		Type:           looprpc.SwapType_LOOP_IN,
+		State:          looprpc.SwapState_SUCCESS,
+		LastUpdateTime: start.Add(time.Hour).UnixNano(),
+		CostServer:     50,
+	}, {
+		Amt:            50_000,
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_FAILED,
+		LastUpdateTime: start.Add(time.Hour).UnixNano(),
+	}, {
+		// Pending swaps are ignored.
+		Amt:            50_000,
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_HTLC_PUBLISHED,
+		LastUpdateTime: start.Add(time.Hour).UnixNano(),
+	}, {
+		// Outside the period.
+		Amt:            50_000,
+		Type:           looprpc.SwapType_LOOP_OUT,
+		State:          looprpc.SwapState_SUCCESS,
+		LastUpdateTime: end.UnixNano(),
+	}})
+	require.EqualValues(t, 1, summary.NumLoopOuts)
+	require.EqualValues(t, 1, summary.NumLoopIns)
+	require.EqualValues(t, 1, summary.NumFailedSwaps)
+	require.EqualValues(t, 150_000, summary.SwapAmount)
+	require.EqualValues(t, 353, summary.SwapCost)
+
+	summary.addActions([]*firewalldb.Action{{
+		State: firewalldb.ActionStateDone,
+	}, {
+		State: firewalldb.ActionStateError,
+	}})
+	require.EqualValues(t, 2, summary.NumActions)
+	require.EqualValues(t, 1, summary.NumFailedActions)
+
+	require.Contains(t, summary.Text(), "Liabilities:     12 sat")
+}
+
+// TestWebhookDelivery tests that a summary is POSTed to the webhook in the
+// same JSON format as returned by the REST API.
+func TestWebhookDelivery(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(body, &received))
+		},
+	))
+	defer server.Close()
+
+	d := &webhookDeliverer{url: server.URL, client: server.Client()}
+	summary := &Summary{
+		Start:             time.Unix(1_000_000, 0),
+		End:               time.Unix(1_086_400, 0),
+		NumActiveAccounts: 3,
+	}
+	require.NoError(t, d.deliver(context.Background(), summary))
+
+	require.Equal(t, "1000000", received["start_time"])
+	require.Equal(t, "3", received["num_active_accounts"])
+	require.Contains(t, received, "routing")
+
+	// A non-2xx status is reported as an error.
+	failing := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	))
+	defer failing.Close()
+
+	d = &webhookDeliverer{url: failing.URL, client: failing.Client()}
+	require.ErrorContains(
+		t, d.deliver(context.Background(), summary),
+		"unexpected status 500",
+	)
+}
+
+// TestFormatEmail tests that the email message contains the expected headers
+// and uses CRLF line endings.
+func TestFormatEmail(t *testing.T) {
+	summary := &Summary{
+		Start: time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	msg := string(formatEmail(
+		"lit@example.com", []string{"a@example.com", "b@example.com"},
+		summary, time.Now(),
+	))
+
+	require.True(t, strings.HasPrefix(msg, "From: lit@example.com\r\n"))
+	require.Contains(t, msg, "To: a@example.com, b@example.com\r\n")
+	require.Contains(
+		t, msg, "Subject: LiT summary 2023-03-14 - 2023-03-15\r\n",
+	)
+	require.NotContains(t, strings.ReplaceAll(msg, "\r\n", ""), "\n")
+}
+
+// TestConfigValidate tests the validation of the reports config.
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	// A schedule without any delivery target is invalid.
+	cfg.Schedule = ScheduleDaily
+	require.ErrorContains(t, cfg.Validate(), "require a webhook URL")
+
+	cfg.SMTP.Host = "smtp.example.com"
+	require.ErrorContains(t, cfg.Validate(), "sender address")
+
+	cfg.SMTP.From = "lit@example.com"
+	require.ErrorContains(t, cfg.Validate(), "recipient")
+
+	cfg.SMTP.To = []string{"me@example.com"}
+	require.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,232 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool/poolrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// dateFormat is the format of the dates in the summary subject.
+	dateFormat = "2006-01-02"
+
+	// dateTimeFormat is the format of the times in the summary text.
+	dateTimeFormat = "2006-01-02 15:04:05"
+)
+
+// SummarySources are the sources the data of a summary report is gathered
+// from. Each source is optional and the corresponding part of the summary is
+// left empty if it isn't set.
+type SummarySources struct {
+	// Accounts returns all the off-chain accounts.
+	Accounts func(ctx context.Context) ([]*accounts.OffChainBalanceAccount,
+		error)
+
+	// Swaps returns all the loop swaps of the node.
+	Swaps func(ctx context.Context) ([]*looprpc.SwapStatus, error)
+
+	// Actions returns all the actions that were performed through the
+	// firewall in the given time window.
+	Actions func(start, end time.Time) ([]*firewalldb.Action, error)
+
+	// PoolAccounts returns the pool accounts of the node that aren't
+	// closed. It is only used for the dashboard summary.
+	PoolAccounts func(ctx context.Context) ([]*poolrpc.Account, error)
+
+	// Sessions returns all LNC sessions. It is only used for the dashboard
+	// summary.
+	Sessions func() ([]*session.Session, error)
+}
+
+// Summary is a summary of the node's activity in a period.
+type Summary struct {
+	// Start is the start of the period, inclusive.
+	Start time.Time
+
+	// End is the end of the period, exclusive.
+	End time.Time
+
+	// Routing are the aggregated forwards of the period.
+	Routing ForwardingStats
+
+	// NumActiveAccounts is the number of accounts that have neither
+	// expired nor been depleted.
+	NumActiveAccounts uint64
+
+	// AccountLiabilities is the total balance of all active accounts.
+	AccountLiabilities lnwire.MilliSatoshi
+
+	// NumLoopOuts is the number of successful loop outs in the period.
+	NumLoopOuts uint64
+
+	// NumLoopIns is the number of successful loop ins in the period.
+	NumLoopIns uint64
+
+	// NumFailedSwaps is the number of failed swaps in the period.
+	NumFailedSwaps uint64
+
+	// SwapAmount is the total amount swapped by the successful swaps.
+	SwapAmount btcutil.Amount
+
+	// SwapCost is the total cost paid for the successful swaps.
+	SwapCost btcutil.Amount
+
+	// NumActions is the number of actions performed through the firewall
+	// in the period.
+	NumActions uint64
+
+	// NumFailedActions is the number of actions performed through the
+	// firewall that failed.
+	NumFailedActions uint64
+}
+
+// buildSummary gathers the summary of the node's activity in the given
+// period.
+func buildSummary(ctx context.Context, lnd lndclient.LightningClient,
+	sources *SummarySources, start, end time.Time) (*Summary, error) {
+
+	summary := &Summary{
+		Start: start,
+		End:   end,
+	}
+
+	events, err := fetchForwards(ctx, lnd, start, end)
+	if err != nil {
+		return nil, err
+	}
+	summary.Routing = aggregateForwards(events, start, end, 0).Totals
+
+	if sources.Accounts != nil {
+		accts, err := sources.Accounts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch accounts: %v",
+				err)
+		}
+
+		summary.addAccounts(accts)
+	}
+
+	if sources.Swaps != nil {
+		swaps, err := sources.Swaps(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch swaps: %v", err)
+		}
+
+		summary.addSwaps(swaps)
+	}
+
+	if sources.Actions != nil {
+		actions, err := sources.Actions(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch actions: %v",
+				err)
+		}
+
+		summary.addActions(actions)
+	}
+
+	return summary, nil
+}
+
+// addAccounts adds the liabilities of the given accounts to the summary. Only
+// accounts that have neither expired nor been depleted are taken into account.
+func (s *Summary) addAccounts(accts []*accounts.OffChainBalanceAccount) {
+	for _, acct := range accts {
+		if acct.HasExpired() || acct.CurrentBalance <= 0 {
+			continue
+		}
+
+		s.NumActiveAccounts++
+		s.AccountLiabilities += lnwire.MilliSatoshi(acct.CurrentBalance)
+	}
+}
+
+// addSwaps adds the swaps that were last updated within the period of the
+// summary to it.
+func (s *Summary) addSwaps(swaps []*looprpc.SwapStatus) {
+	for _, swap := range swaps {
+		updated := time.Unix(0, swap.LastUpdateTime)
+		if updated.Before(s.Start) || !updated.Before(s.End) {
+			continue
+		}
+
+		switch swap.State {
+		case looprpc.SwapState_SUCCESS:
+			if swap.Type == looprpc.SwapType_LOOP_IN {
+				s.NumLoopIns++
+			} else {
+				s.NumLoopOuts++
+			}
+
+			s.SwapAmount += btcutil.Amount(swap.Amt)
+			s.SwapCost += btcutil.Amount(
+				swap.CostServer + swap.CostOnchain +
+					swap.CostOffchain,
+			)
+
+		case looprpc.SwapState_FAILED:
+			s.NumFailedSwaps++
+		}
+	}
+}
+
+// addActions adds the given firewall actions to the summary.
+func (s *Summary) addActions(actions []*firewalldb.Action) {
+	for _, action := range actions {
+		s.NumActions++
+		if action.State == firewalldb.ActionStateError {
+			s.NumFailedActions++
+		}
+	}
+}
+
+// Subject returns a short title of the summary.
+func (s *Summary) Subject() string {
+	return fmt.Sprintf("LiT summary %s - %s",
+		s.Start.UTC().Format(dateFormat),
+		s.End.UTC().Format(dateFormat))
+}
+
+// Text returns a human-readable plain text version of the summary.
+func (s *Summary) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Summary of %s to %s (UTC)\n\n",
+		s.Start.UTC().Format(dateTimeFormat),
+		s.End.UTC().Format(dateTimeFormat))
+
+	fmt.Fprintf(&b, "Routing\n")
+	fmt.Fprintf(&b, "  Forwards:        %d\n", s.Routing.NumForwards)
+	fmt.Fprintf(&b, "  Volume:          %d sat\n",
+		s.Routing.VolumeOut.ToSatoshis())
+	fmt.Fprintf(&b, "  Revenue:         %d msat\n", s.Routing.Fees)
+	fmt.Fprintf(&b, "  Fee rate:        %d ppm\n\n", s.Routing.FeePPM())
+
+	fmt.Fprintf(&b, "Accounts\n")
+	fmt.Fprintf(&b, "  Active accounts: %d\n", s.NumActiveAccounts)
+	fmt.Fprintf(&b, "  Liabilities:     %d sat\n\n",
+		s.AccountLiabilities.ToSatoshis())
+
+	fmt.Fprintf(&b, "Swaps\n")
+	fmt.Fprintf(&b, "  Loop outs:       %d\n", s.NumLoopOuts)
+	fmt.Fprintf(&b, "  Loop ins:        %d\n", s.NumLoopIns)
+	fmt.Fprintf(&b, "  Failed:          %d\n", s.NumFailedSwaps)
+	fmt.Fprintf(&b, "  Amount:          %d sat\n", s.SwapAmount)
+	fmt.Fprintf(&b, "  Cost:            %d sat\n\n", s.SwapCost)
+
+	fmt.Fprintf(&b, "Autopilot actions\n")
+	fmt.Fprintf(&b, "  Actions:         %d\n", s.NumActions)
+	fmt.Fprintf(&b, "  Failed:          %d\n", s.NumFailedActions)
+
+	return b.String()
+}
@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/hex"
@@ -13,7 +14,13 @@ import (
 	"time"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/lightninglabs/lightning-terminal/adminauth"
+	"github.com/lightninglabs/lightning-terminal/apikeys"
+	"github.com/lightninglabs/lightning-terminal/authlimit"
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/lndconnect"
+	"github.com/lightninglabs/lightning-terminal/maccache"
+	"github.com/lightninglabs/lightning-terminal/oidc"
 	"github.com/lightninglabs/lightning-terminal/perms"
 	"github.com/lightninglabs/lightning-terminal/session"
 	"github.com/lightningnetwork/lnd/lncfg"
@@ -24,8 +31,11 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 	"gopkg.in/macaroon.v2"
 )
 
@@ -37,6 +47,24 @@ const (
 	HeaderMacaroon = "Macaroon"
 )
 
+// macaroonRevokingMethods are the lnd calls that revoke macaroons. After a
+// successful call, all cached macaroon verifications are dropped as we don't
+// know which root keys were affected.
+var macaroonRevokingMethods = map[string]bool{
+	"/lnrpc.Lightning/DeleteMacaroonID":    true,
+	"/lnrpc.WalletUnlocker/ChangePassword": true,
+}
+
+// invoicePermissions are the permissions of the invoice connect preset. They
+// match the ones of lnd's invoice.macaroon.
+var invoicePermissions = []bakery.Op{
+	{Entity: "invoices", Action: "read"},
+	{Entity: "invoices", Action: "write"},
+	{Entity: "address", Action: "read"},
+	{Entity: "address", Action: "write"},
+	{Entity: "onchain", Action: "read"},
+}
+
 // proxyErr is an error type that adds more context to an error occurring in the
 // proxy.
 type proxyErr struct {
@@ -57,10 +85,17 @@ func (e *proxyErr) Unwrap() error {
 
 // newRpcProxy creates a new RPC proxy that can take any native gRPC, grpc-web
 // or REST request and delegate (and convert if necessary) it to the correct
-// component.
+// component. The given unary interceptors are run after the proxy's own
+// authentication and the admin signature check for all calls that are served
+// by the proxy itself. The lockouts of clients that entered a wrong UI
+// password too often are recorded in the given audit log.
 func newRpcProxy(cfg *Config, validator macaroons.MacaroonValidator,
 	superMacValidator session.SuperMacaroonValidator,
-	permsMgr *perms.Manager, bufListener *bufconn.Listener) *rpcProxy {
+	permsMgr *perms.Manager, bufListener *bufconn.Listener,
+	oidcAuth *oidc.Authenticator, apiKeys *apikeys.Manager,
+	macCache *maccache.Cache, adminAuth *adminauth.Verifier,
+	macBaker session.MacaroonBaker, audit *auditLog,
+	unaryInterceptors ...grpc.UnaryServerInterceptor) *rpcProxy {
 
 	// The gRPC web calls are protected by HTTP basic auth which is defined
 	// by base64(username:password). Because we only have a password, we
@@ -81,13 +116,29 @@ func newRpcProxy(cfg *Config, validator macaroons.MacaroonValidator,
 		macValidator:      validator,
 		superMacValidator: superMacValidator,
 		bufListener:       bufListener,
+		oidcAuth:          oidcAuth,
+		apiKeys:           apiKeys,
+		macCache:          macCache,
+		adminAuth:         adminAuth,
+		macBaker:          macBaker,
+		audit:             audit,
 	}
+	p.authLimiter = authlimit.NewLimiter(cfg.AuthLimit, p.recordLockout)
 	p.grpcServer = grpc.NewServer(
 		// From the grpxProxy doc: This codec is *crucial* to the
 		// functioning of the proxy.
 		grpc.CustomCodec(grpcProxy.Codec()), // nolint:staticcheck
-		grpc.ChainStreamInterceptor(p.StreamServerInterceptor),
-		grpc.ChainUnaryInterceptor(p.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(
+			p.StreamServerInterceptor,
+			adminAuth.StreamServerInterceptor,
+		),
+		grpc.ChainUnaryInterceptor(append(
+			[]grpc.UnaryServerInterceptor{
+				p.UnaryServerInterceptor,
+				adminAuth.UnaryServerInterceptor,
+			},
+			unaryInterceptors...,
+		)...),
 		grpc.UnknownServiceHandler(
 			grpcProxy.TransparentHandler(p.makeDirector(true)),
 		),
@@ -97,7 +148,7 @@ func newRpcProxy(cfg *Config, validator macaroons.MacaroonValidator,
 	// converts the browser's gRPC web calls into native gRPC.
 	options := []grpcweb.Option{
 		grpcweb.WithWebsockets(true),
-		grpcweb.WithWebsocketPingInterval(2 * time.Minute),
+		grpcweb.WithWebsocketPingInterval(cfg.KeepAlive.WebsocketPing),
 		grpcweb.WithCorsForRegisteredEndpointsOnly(false),
 	}
 	p.grpcWebProxy = grpcweb.WrapServer(p.grpcServer, options...)
@@ -155,6 +206,35 @@ type rpcProxy struct {
 	superMacValidator session.SuperMacaroonValidator
 	bufListener       *bufconn.Listener
 
+	// oidcAuth validates the session tokens of users that logged into the
+	// UI through OpenID Connect. It is nil if OIDC login is disabled.
+	oidcAuth *oidc.Authenticator
+
+	// apiKeys unlocks the macaroons of the API keys sent as bearer tokens.
+	apiKeys *apikeys.Manager
+
+	// macCache caches successful macaroon verifications so they don't
+	// need to be repeated for every request.
+	macCache *maccache.Cache
+
+	// adminAuth hands out the challenges that must be signed before the
+	// destructive admin RPCs are executed.
+	adminAuth *adminauth.Verifier
+
+	// macBaker bakes the macaroons of the connect URIs.
+	macBaker session.MacaroonBaker
+
+	// authLimiter slows down and locks out the clients that repeatedly
+	// enter a wrong UI password.
+	authLimiter *authlimit.Limiter
+
+	// audit records the lockouts and the cleared lockouts in the audit
+	// trail.
+	audit *auditLog
+
+	// macInspector determines which methods a macaroon can call.
+	macInspector *macaroonInspector
+
 	superMacaroon string
 
 	lndConn     *grpc.ClientConn
@@ -162,6 +242,10 @@ type rpcProxy struct {
 	loopConn    *grpc.ClientConn
 	poolConn    *grpc.ClientConn
 
+	// lndNodes are the additional lnd nodes lnd calls can be sent to by
+	// setting the HeaderLndNode metadata field, keyed by their name.
+	lndNodes map[string]*lndNode
+
 	grpcServer   *grpc.Server
 	grpcWebProxy *grpcweb.WrappedGrpcServer
 }
@@ -170,6 +254,10 @@ type rpcProxy struct {
 func (p *rpcProxy) Start() error {
 	var err error
 
+	// The connections to remote daemons are pinged as configured, so that
+	// idle connections aren't dropped silently.
+	dialOpts := p.cfg.KeepAlive.BackendDialOptions()
+
 	// Setup the connection to lnd.
 	host, _, tlsPath, _, _ := p.cfg.lndConnectParams()
 
@@ -177,7 +265,7 @@ func (p *rpcProxy) Start() error {
 	if p.cfg.LndMode == ModeIntegrated {
 		p.lndConn, err = dialBufConnBackend(p.bufListener)
 	} else {
-		p.lndConn, err = dialBackend("lnd", host, tlsPath)
+		p.lndConn, err = dialBackend("lnd", host, tlsPath, dialOpts...)
 	}
 	if err != nil {
 		return fmt.Errorf("could not dial lnd: %v", err)
@@ -190,7 +278,7 @@ func (p *rpcProxy) Start() error {
 			"faraday", p.cfg.Remote.Faraday.RPCServer,
 			lncfg.CleanAndExpandPath(
 				p.cfg.Remote.Faraday.TLSCertPath,
-			),
+			), dialOpts...,
 		)
 		if err != nil {
 			return fmt.Errorf("could not dial remote faraday: %v",
@@ -202,6 +290,7 @@ func (p *rpcProxy) Start() error {
 		p.loopConn, err = dialBackend(
 			"loop", p.cfg.Remote.Loop.RPCServer,
 			lncfg.CleanAndExpandPath(p.cfg.Remote.Loop.TLSCertPath),
+			dialOpts...,
 		)
 		if err != nil {
 			return fmt.Errorf("could not dial remote loop: %v", err)
@@ -212,48 +301,65 @@ func (p *rpcProxy) Start() error {
 		p.poolConn, err = dialBackend(
 			"pool", p.cfg.Remote.Pool.RPCServer,
 			lncfg.CleanAndExpandPath(p.cfg.Remote.Pool.TLSCertPath),
+			dialOpts...,
 		)
 		if err != nil {
 			return fmt.Errorf("could not dial remote pool: %v", err)
 		}
 	}
 
+	p.lndNodes = make(map[string]*lndNode, len(p.cfg.lndNodes))
+	for name, node := range p.cfg.lndNodes {
+		nodeCopy := *node
+		nodeCopy.conn, err = dialBackend(
+			"lnd node "+name, node.rpcServer, node.tlsCertPath,
+			dialOpts...,
+		)
+		if err != nil {
+			return fmt.Errorf("could not dial lnd node %s: %v",
+				name, err)
+		}
+
+		p.lndNodes[name] = &nodeCopy
+	}
+
 	return nil
 }
 
-// Stop shuts down the lnd connection.
+// Stop shuts down the lnd connection and the connections to the other
+// backends. All connections are closed, even if closing one of them fails.
 func (p *rpcProxy) Stop() error {
 	p.grpcServer.Stop()
 
-	if p.lndConn != nil {
-		if err := p.lndConn.Close(); err != nil {
-			log.Errorf("Error closing lnd connection: %v", err)
-			return err
+	var closeErrs []string
+	closeConn := func(name string, conn *grpc.ClientConn) {
+		if conn == nil {
+			return
 		}
-	}
 
-	if p.faradayConn != nil {
-		if err := p.faradayConn.Close(); err != nil {
-			log.Errorf("Error closing faraday connection: %v", err)
-			return err
+		if err := conn.Close(); err != nil {
+			log.Errorf("Error closing %s connection: %v", name, err)
+			closeErrs = append(
+				closeErrs, fmt.Sprintf("%s: %v", name, err),
+			)
 		}
 	}
 
-	if p.loopConn != nil {
-		if err := p.loopConn.Close(); err != nil {
-			log.Errorf("Error closing loop connection: %v", err)
-			return err
-		}
+	closeConn("lnd", p.lndConn)
+	closeConn("faraday", p.faradayConn)
+	closeConn("loop", p.loopConn)
+	closeConn("pool", p.poolConn)
+
+	for _, name := range p.lndNodeNames() {
+		closeConn("lnd node "+name, p.lndNodes[name].conn)
 	}
 
-	if p.poolConn != nil {
-		if err := p.poolConn.Close(); err != nil {
-			log.Errorf("Error closing pool connection: %v", err)
-			return err
-		}
+	if len(closeErrs) == 0 {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("error closing connections: %s",
+		strings.Join(closeErrs, "; "))
 }
 
 // StopDaemon will send a shutdown request to the interrupt handler, triggering
@@ -277,10 +383,262 @@ func (p *rpcProxy) GetInfo(_ context.Context, _ *litrpc.GetInfoRequest) (
 	*litrpc.GetInfoResponse, error) {
 
 	return &litrpc.GetInfoResponse{
-		Version: Version(),
+		Version:  Version(),
+		LndNodes: p.lndNodeNames(),
+	}, nil
+}
+
+// GetAdminChallenge creates a single use challenge that must be signed before
+// the given destructive RPC is executed.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) GetAdminChallenge(_ context.Context,
+	req *litrpc.GetAdminChallengeRequest) (
+	*litrpc.GetAdminChallengeResponse, error) {
+
+	challenge, err := p.adminAuth.NewChallenge(req.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.GetAdminChallengeResponse{
+		Required: challenge.Required,
+	}
+	if challenge.Required {
+		resp.Challenge = challenge.Challenge
+		resp.Message = adminauth.Message(
+			challenge.Method, challenge.Challenge,
+		)
+		resp.ExpiresAt = challenge.ExpiresAt.Unix()
+		resp.PubKey = hex.EncodeToString(
+			challenge.PubKey.SerializeCompressed(),
+		)
+	}
+
+	return resp, nil
+}
+
+// GetConnectURI bakes a new macaroon with the permissions of the requested
+// preset and returns it in an lndconnect URI for LiT's RPC endpoint.
+//
+// NOTE: this is part of the litrpc.ProxyServiceServer interface.
+func (p *rpcProxy) GetConnectURI(ctx context.Context,
+	req *litrpc.GetConnectURIRequest) (*litrpc.GetConnectURIResponse,
+	error) {
+
+	var perms []bakery.Op
+	switch req.Preset {
+	case litrpc.ConnectPreset_CONNECT_PRESET_READONLY:
+		perms = p.permsMgr.ActivePermissions(true)
+
+	case litrpc.ConnectPreset_CONNECT_PRESET_INVOICE:
+		perms = invoicePermissions
+
+	case litrpc.ConnectPreset_CONNECT_PRESET_ADMIN:
+		perms = p.permsMgr.ActivePermissions(false)
+
+	default:
+		return nil, fmt.Errorf("unknown preset %v", req.Preset)
+	}
+
+	hostPort := req.Host
+	if hostPort == "" {
+		hostPort = p.cfg.HTTPSListen
+	}
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %s: %v", hostPort, err)
+	}
+	if ip := net.ParseIP(host); host == "" ||
+		(ip != nil && ip.IsUnspecified()) {
+
+		return nil, fmt.Errorf("LiT listens on %s, a host that is "+
+			"reachable by the wallet must be set", hostPort)
+	}
+
+	// A certificate signed by Let's Encrypt is trusted by the wallet
+	// anyway, so we only add our own self-signed one.
+	var certPEM []byte
+	if !p.cfg.LetsEncrypt {
+		certPEM, err = ioutil.ReadFile(lncfg.CleanAndExpandPath(
+			p.cfg.TLSCertPath,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read TLS cert: %v",
+				err)
+		}
+	}
+
+	var caveats []macaroon.Caveat
+	if req.TimeoutSeconds != 0 {
+		cav := checkers.TimeBeforeCaveat(time.Now().Add(
+			time.Duration(req.TimeoutSeconds) * time.Second,
+		))
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(cav.Condition),
+		})
+	}
+
+	var rootKeyIDSuffix [4]byte
+	if _, err := rand.Read(rootKeyIDSuffix[:]); err != nil {
+		return nil, err
+	}
+	rootKeyID := session.NewSuperMacaroonRootKeyID(rootKeyIDSuffix)
+
+	macHex, err := p.macBaker(ctx, rootKeyID, &session.MacaroonRecipe{
+		Permissions: perms,
+		Caveats:     caveats,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error baking macaroon: %v", err)
+	}
+	mac, err := hex.DecodeString(macHex)
+	if err != nil {
+		return nil, err
+	}
+
+	uri, err := lndconnect.Encode(hostPort, certPEM, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.GetConnectURIResponse{
+		Uri:       uri,
+		RootKeyId: rootKeyID,
 	}, nil
 }
 
+// ListAuthLockouts lists the clients that recently entered a wrong UI
+// password.
+//
+// NOTE: This is part of the litrpc.ProxyServer interface.
+func (p *rpcProxy) ListAuthLockouts(_ context.Context,
+	_ *litrpc.ListAuthLockoutsRequest) (*litrpc.ListAuthLockoutsResponse,
+	error) {
+
+	clients := p.authLimiter.Clients()
+
+	resp := &litrpc.ListAuthLockoutsResponse{
+		Clients: make([]*litrpc.AuthLockout, len(clients)),
+	}
+	for i := range clients {
+		resp.Clients[i] = marshalAuthLockout(&clients[i])
+	}
+
+	return resp, nil
+}
+
+// ClearAuthLockout resets the failed UI password attempts of a client or of
+// all clients.
+//
+// NOTE: This is part of the litrpc.ProxyServer interface.
+func (p *rpcProxy) ClearAuthLockout(ctx context.Context,
+	req *litrpc.ClearAuthLockoutRequest) (*litrpc.ClearAuthLockoutResponse,
+	error) {
+
+	var cleared int
+	switch {
+	case req.All && req.Client != "":
+		return nil, fmt.Errorf("either a client or all clients can " +
+			"be cleared, not both")
+
+	case req.All:
+		cleared = p.authLimiter.ClearAll()
+
+	case req.Client == "":
+		return nil, fmt.Errorf("a client must be specified")
+
+	case p.authLimiter.Clear(req.Client):
+		cleared = 1
+	}
+
+	err := p.audit.Record(
+		p.actorFromContext(ctx), "/litrpc.Proxy/ClearAuthLockout", req,
+	)
+	if err != nil {
+		log.Errorf("Error recording cleared lockout: %v", err)
+	}
+
+	return &litrpc.ClearAuthLockoutResponse{
+		Cleared: uint32(cleared),
+	}, nil
+}
+
+// InspectMacaroon lists the methods the given macaroon can call through litd.
+//
+// NOTE: this is part of the litrpc.ProxyServer interface.
+func (p *rpcProxy) InspectMacaroon(ctx context.Context,
+	req *litrpc.InspectMacaroonRequest) (*litrpc.InspectMacaroonResponse,
+	error) {
+
+	if len(req.Macaroon) == 0 {
+		return nil, fmt.Errorf("a macaroon must be specified")
+	}
+
+	return p.macInspector.inspect(ctx, req)
+}
+
+// recordLockout records the lockout of a client in the audit trail.
+func (p *rpcProxy) recordLockout(method string,
+	state authlimit.ClientState) {
+
+	if err := p.audit.RecordLockout(method, state); err != nil {
+		log.Errorf("Error recording lockout of client %s: %v",
+			state.Client, err)
+	}
+}
+
+// marshalAuthLockout converts the state of a client into its RPC counterpart.
+func marshalAuthLockout(state *authlimit.ClientState) *litrpc.AuthLockout {
+	return &litrpc.AuthLockout{
+		Client:         state.Client,
+		FailedAttempts: state.FailedAttempts,
+		LastFailure:    state.LastFailure.Unix(),
+		NextAttempt:    state.NextAttempt.Unix(),
+		LockedOut:      state.LockedOut,
+		Lockouts:       state.Lockouts,
+	}
+}
+
+// authenticateWebRequest returns true if the given request to the subserver
+// web UIs carries valid credentials. Failed attempts are rate limited like the
+// ones of the gRPC calls.
+func (p *rpcProxy) authenticateWebRequest(req *http.Request) bool {
+	client := clientFromAddr(req.RemoteAddr)
+	if err := p.authLimiter.Check(client); err != nil {
+		return false
+	}
+
+	if p.actorFromAuthHeader(req.Header.Get("Authorization")) == "" {
+		p.authLimiter.Failure(client, req.URL.Path)
+		return false
+	}
+	p.authLimiter.Success(client)
+
+	return true
+}
+
+// clientFromContext returns the IP address of the client that made the
+// request with the given context.
+func clientFromContext(ctx context.Context) string {
+	pr, ok := peer.FromContext(ctx)
+	if !ok || pr.Addr == nil {
+		return ""
+	}
+
+	return clientFromAddr(pr.Addr.String())
+}
+
+// clientFromAddr returns the IP address of the given host:port address.
+func clientFromAddr(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
 // isHandling checks if the specified request is something to be handled by lnd
 // or any of the attached sub daemons. If true is returned, the call was handled
 // by the RPC proxy and the caller MUST NOT handle it again. If false is
@@ -327,15 +685,40 @@ func (p *rpcProxy) makeDirector(allowLitRPC bool) func(ctx context.Context,
 		mdCopy := md.Copy()
 		delete(mdCopy, "connection")
 
+		// Calls for one of the additional lnd nodes are handled
+		// separately as they don't have access to any of LiT's
+		// features that are bound to the primary node.
+		if node := lndNodeFromContext(ctx); node != "" {
+			return p.directToNode(ctx, node, requestURI, mdCopy)
+		}
+
 		outCtx := metadata.NewOutgoingContext(ctx, mdCopy)
 
 		// Is there a basic auth or super macaroon set?
 		authHeaders := md.Get("authorization")
 		macHeader := md.Get(HeaderMacaroon)
+
+		// An API key is replaced by the macaroon it wraps, which is then
+		// handled like any other macaroon. The key itself is never
+		// forwarded to the backend.
+		if len(authHeaders) == 1 && apikeys.IsAPIKey(authHeaders[0]) {
+			macBytes, err := p.apiKeys.Macaroon(authHeaders[0])
+			if err != nil {
+				return outCtx, nil, err
+			}
+
+			macHex := hex.EncodeToString(macBytes)
+			delete(mdCopy, "authorization")
+			mdCopy.Set(HeaderMacaroon, macHex)
+
+			authHeaders = nil
+			macHeader = []string{macHex}
+		}
+
 		switch {
 		case len(authHeaders) == 1 && !p.cfg.DisableUI:
 			macBytes, err := p.basicAuthToMacaroon(
-				authHeaders[0], requestURI, nil,
+				ctx, authHeaders[0], requestURI, nil,
 			)
 			if err != nil {
 				return outCtx, nil, err
@@ -424,14 +807,15 @@ func (p *rpcProxy) UnaryServerInterceptor(ctx context.Context, req interface{},
 
 	// With the basic auth converted to a macaroon if necessary,
 	// let's now validate the macaroon.
-	err = p.macValidator.ValidateMacaroon(
-		newCtx, uriPermissions, info.FullMethod,
-	)
+	err = p.validateMacaroon(newCtx, uriPermissions, info.FullMethod)
 	if err != nil {
 		return nil, err
 	}
 
-	return handler(ctx, req)
+	resp, err := handler(ctx, req)
+	p.purgeMacaroonCache(info.FullMethod, err)
+
+	return resp, err
 }
 
 // StreamServerInterceptor is a GRPC interceptor that checks whether the
@@ -467,14 +851,47 @@ func (p *rpcProxy) StreamServerInterceptor(srv interface{},
 
 	// With the basic auth converted to a macaroon if necessary,
 	// let's now validate the macaroon.
+	err = p.validateMacaroon(ctx, uriPermissions, info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	err = handler(srv, ss)
+	p.purgeMacaroonCache(info.FullMethod, err)
+
+	return err
+}
+
+// validateMacaroon validates the macaroon of the given context for the given
+// method, unless a successful validation of it is still cached.
+func (p *rpcProxy) validateMacaroon(ctx context.Context,
+	requiredPermissions []bakery.Op, fullMethod string) error {
+
+	macHex, err := macaroons.RawMacaroonFromContext(ctx)
+	if err == nil && p.macCache.Verified(ctx, macHex, fullMethod) {
+		return nil
+	}
+
 	err = p.macValidator.ValidateMacaroon(
-		ctx, uriPermissions, info.FullMethod,
+		ctx, requiredPermissions, fullMethod,
 	)
 	if err != nil {
 		return err
 	}
 
-	return handler(srv, ss)
+	if macHex != "" {
+		p.macCache.Add(ctx, macHex, fullMethod)
+	}
+
+	return nil
+}
+
+// purgeMacaroonCache purges the macaroon cache after a successful call that
+// revokes macaroons in a way we can't map to specific root keys.
+func (p *rpcProxy) purgeMacaroonCache(fullMethod string, callErr error) {
+	if callErr == nil && macaroonRevokingMethods[fullMethod] {
+		p.macCache.Purge()
+	}
 }
 
 // convertBasicAuth tries to convert the HTTP authorization header into a
@@ -482,12 +899,6 @@ func (p *rpcProxy) StreamServerInterceptor(srv interface{},
 func (p *rpcProxy) convertBasicAuth(ctx context.Context,
 	requestURI string, ctxErr error) (context.Context, error) {
 
-	// If the UI is disabled, then there is no UI password and so the
-	// request is required to have a macaroon in it.
-	if p.cfg.DisableUI {
-		return ctx, ctxErr
-	}
-
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return ctx, ctxErr
@@ -500,8 +911,26 @@ func (p *rpcProxy) convertBasicAuth(ctx context.Context,
 		return ctx, ctxErr
 	}
 
+	// API keys are accepted even if the UI is disabled since they are
+	// meant for integrators.
+	if apikeys.IsAPIKey(authHeaders[0]) {
+		macBytes, err := p.apiKeys.Macaroon(authHeaders[0])
+		if err != nil {
+			return ctx, ctxErr
+		}
+
+		md.Set(HeaderMacaroon, hex.EncodeToString(macBytes))
+		return metadata.NewIncomingContext(ctx, md), nil
+	}
+
+	// If the UI is disabled, then there is no UI password and so the
+	// request is required to have a macaroon in it.
+	if p.cfg.DisableUI {
+		return ctx, ctxErr
+	}
+
 	macBytes, err := p.basicAuthToMacaroon(
-		authHeaders[0], requestURI, ctxErr,
+		ctx, authHeaders[0], requestURI, ctxErr,
 	)
 	if err != nil || len(macBytes) == 0 {
 		return ctx, err
@@ -511,11 +940,44 @@ func (p *rpcProxy) convertBasicAuth(ctx context.Context,
 	return metadata.NewIncomingContext(ctx, md), nil
 }
 
+// callerRole returns the role of the web UI user that made the call. Users
+// that logged in through OIDC carry their role in their session token, all
+// other callers are treated as admins.
+func (p *rpcProxy) callerRole(ctx context.Context) oidc.Role {
+	if p.oidcAuth == nil {
+		return oidc.RoleAdmin
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return oidc.RoleAdmin
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return oidc.RoleAdmin
+	}
+
+	authHeaderParts := strings.Split(authHeaders[0], " ")
+	if len(authHeaderParts) != 2 ||
+		!strings.EqualFold(authHeaderParts[0], "bearer") {
+
+		return oidc.RoleAdmin
+	}
+
+	oidcSession, err := p.oidcAuth.ValidateToken(authHeaderParts[1])
+	if err != nil {
+		return oidc.RoleAdmin
+	}
+
+	return oidcSession.Role
+}
+
 // basicAuthToMacaroon checks that the incoming request context has the expected
 // and valid basic authentication header then attaches the correct macaroon to
 // the context so it can be forwarded to the actual gRPC server.
-func (p *rpcProxy) basicAuthToMacaroon(basicAuth, requestURI string,
-	ctxErr error) ([]byte, error) {
+func (p *rpcProxy) basicAuthToMacaroon(ctx context.Context, basicAuth,
+	requestURI string, ctxErr error) ([]byte, error) {
 
 	// The user specified an authorization header so this is very likely a
 	// gRPC Web call from the UI. But we only attach the macaroon if the
@@ -526,8 +988,43 @@ func (p *rpcProxy) basicAuthToMacaroon(basicAuth, requestURI string,
 	if len(authHeaderParts) != 2 {
 		return nil, ctxErr
 	}
-	if authHeaderParts[1] != p.basicAuth {
-		return nil, ctxErr
+
+	switch {
+	// Users that logged in through OIDC send their lit session token as a
+	// bearer token. The token carries the role of the user which limits
+	// the calls it can make.
+	case p.oidcAuth != nil && strings.EqualFold(
+		authHeaderParts[0], "bearer",
+	):
+		oidcSession, err := p.oidcAuth.ValidateToken(
+			authHeaderParts[1],
+		)
+		if err != nil {
+			return nil, ctxErr
+		}
+
+		ops, ok := p.permsMgr.URIPermissions(requestURI)
+		if !ok || !oidcSession.Role.Allows(ops) {
+			return nil, fmt.Errorf("role %s is not permitted to "+
+				"call %s", oidcSession.Role, requestURI)
+		}
+
+	default:
+		// The UI password is rate limited per client to slow down
+		// brute-force attacks. A client that is locked out is rejected
+		// even if it sends the right password.
+		client := clientFromContext(ctx)
+		if err := p.authLimiter.Check(client); err != nil {
+			return nil, status.Error(
+				codes.ResourceExhausted, err.Error(),
+			)
+		}
+
+		if authHeaderParts[1] != p.basicAuth {
+			p.authLimiter.Failure(client, requestURI)
+			return nil, ctxErr
+		}
+		p.authLimiter.Success(client)
 	}
 
 	var (
@@ -626,12 +1123,15 @@ func (p *rpcProxy) convertSuperMacaroon(ctx context.Context, macHex string,
 	}
 
 	// Make sure the super macaroon is valid and contains all the required
-	// permissions.
-	err = p.superMacValidator(
-		ctx, macBytes, requiredPermissions, fullMethod,
-	)
-	if err != nil {
-		return nil, err
+	// permissions. The interceptors already validated it for this call, so
+	// the result is usually cached.
+	if !p.macCache.Verified(ctx, macHex, fullMethod) {
+		err = p.superMacValidator(
+			ctx, macBytes, requiredPermissions, fullMethod,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Is this actually a request that goes to a daemon that is running
@@ -686,8 +1186,11 @@ func dialBufConnBackend(listener *bufconn.Listener) (*grpc.ClientConn, error) {
 }
 
 // dialBackend connects to a gRPC backend through the given address and uses the
-// given TLS certificate to authenticate the connection.
-func dialBackend(name, dialAddr, tlsCertPath string) (*grpc.ClientConn, error) {
+// given TLS certificate to authenticate the connection. The given options are
+// added to the default ones.
+func dialBackend(name, dialAddr, tlsCertPath string,
+	extraOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
+
 	var opts []grpc.DialOption
 	tlsConfig, err := credentials.NewClientTLSFromFile(tlsCertPath, "")
 	if err != nil {
@@ -708,6 +1211,7 @@ func dialBackend(name, dialAddr, tlsCertPath string) (*grpc.ClientConn, error) {
 			MinConnectTimeout: defaultConnectTimeout,
 		}),
 	)
+	opts = append(opts, extraOpts...)
 
 	log.Infof("Dialing %s gRPC server at %s", name, dialAddr)
 	cc, err := grpc.Dial(dialAddr, opts...)
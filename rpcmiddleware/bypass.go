@@ -0,0 +1,122 @@
+package rpcmiddleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// readAction is the macaroon permission action of read-only methods.
+const readAction = "read"
+
+// PermissionsFunc returns the macaroon permissions required for the method
+// with the given full URI and whether the method is known at all.
+type PermissionsFunc func(uri string) ([]bakery.Op, bool)
+
+// Bypass is a set of read-only methods for which the interceptors it wraps
+// aren't called. The macaroon of a bypassed call is still checked by lnd.
+type Bypass struct {
+	methods map[string]struct{}
+}
+
+// NewBypass creates a Bypass for the given methods. Only methods that are
+// known and only require read permissions can be bypassed, so a method that
+// changes the node's state can never skip the interceptors.
+func NewBypass(methods []string, permissions PermissionsFunc) (*Bypass,
+	error) {
+
+	b := &Bypass{
+		methods: make(map[string]struct{}, len(methods)),
+	}
+	for _, method := range methods {
+		ops, ok := permissions(method)
+		if !ok {
+			return nil, fmt.Errorf("unknown method %s can't "+
+				"bypass the RPC middleware", method)
+		}
+
+		if len(ops) == 0 {
+			return nil, fmt.Errorf("method %s doesn't require any "+
+				"permissions and can't bypass the RPC "+
+				"middleware", method)
+		}
+
+		for _, op := range ops {
+			if op.Action != readAction {
+				return nil, fmt.Errorf("method %s requires "+
+					"%s:%s permissions, only read-only "+
+					"methods can bypass the RPC "+
+					"middleware", method, op.Entity,
+					op.Action)
+			}
+		}
+
+		b.methods[method] = struct{}{}
+	}
+
+	return b, nil
+}
+
+// Bypassed returns true if the wrapped interceptors aren't called for the
+// method with the given full URI.
+func (b *Bypass) Bypassed(uri string) bool {
+	if b == nil {
+		return false
+	}
+
+	_, ok := b.methods[uri]
+	return ok
+}
+
+// Wrap wraps the given interceptor so that it isn't called for the bypassed
+// methods. It must only be used for interceptors that never restrict or
+// record read-only calls. Interceptors that rewrite or filter responses must
+// not be wrapped, as the responses would be returned unmodified for the
+// bypassed methods. Neither must the request logger or the rule enforcer, as
+// rules like the history and rate limits also apply to read-only calls.
+func (b *Bypass) Wrap(interceptor RequestInterceptor) RequestInterceptor {
+	if b == nil || len(b.methods) == 0 {
+		return interceptor
+	}
+
+	return &bypassInterceptor{
+		RequestInterceptor: interceptor,
+		bypass:             b,
+	}
+}
+
+// bypassInterceptor is an interceptor that accepts the calls of the bypassed
+// methods without calling the interceptor it wraps.
+type bypassInterceptor struct {
+	RequestInterceptor
+
+	bypass *Bypass
+}
+
+// Intercept accepts all messages of the bypassed methods right away and hands
+// all other messages to the wrapped interceptor.
+//
+// NOTE: This is part of the RequestInterceptor interface.
+func (i *bypassInterceptor) Intercept(ctx context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	var uri string
+	switch t := req.InterceptType.(type) {
+	case *lnrpc.RPCMiddlewareRequest_StreamAuth:
+		uri = t.StreamAuth.MethodFullUri
+
+	case *lnrpc.RPCMiddlewareRequest_Request:
+		uri = t.Request.MethodFullUri
+
+	case *lnrpc.RPCMiddlewareRequest_Response:
+		uri = t.Response.MethodFullUri
+	}
+
+	if i.bypass.Bypassed(uri) {
+		return RPCOk(req)
+	}
+
+	return i.RequestInterceptor.Intercept(ctx, req)
+}
@@ -0,0 +1,121 @@
+package rpcmiddleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+const (
+	getInfoURI = "/lnrpc.Lightning/GetInfo"
+	sendURI    = "/lnrpc.Lightning/SendCoins"
+)
+
+// testPermissions returns the permissions of the methods used in the tests.
+func testPermissions(uri string) ([]bakery.Op, bool) {
+	switch uri {
+	case getInfoURI:
+		return []bakery.Op{{Entity: "info", Action: "read"}}, true
+
+	case sendURI:
+		return []bakery.Op{{Entity: "onchain", Action: "write"}}, true
+
+	case "/lnrpc.Lightning/ListMixed":
+		return []bakery.Op{
+			{Entity: "info", Action: "read"},
+			{Entity: "offchain", Action: "write"},
+		}, true
+
+	case "/lnrpc.Lightning/NoPerms":
+		return nil, true
+
+	default:
+		return nil, false
+	}
+}
+
+// countingInterceptor counts the messages it intercepts.
+type countingInterceptor struct {
+	RequestInterceptor
+
+	calls int
+}
+
+// Intercept counts the message and accepts it.
+func (c *countingInterceptor) Intercept(_ context.Context,
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.RPCMiddlewareResponse, error) {
+
+	c.calls++
+	return RPCOk(req)
+}
+
+// TestNewBypass tests that only known read-only methods can be bypassed.
+func TestNewBypass(t *testing.T) {
+	_, err := NewBypass([]string{getInfoURI}, testPermissions)
+	require.NoError(t, err)
+
+	for _, method := range []string{
+		sendURI, "/lnrpc.Lightning/ListMixed",
+		"/lnrpc.Lightning/NoPerms", "/lnrpc.Lightning/Unknown",
+	} {
+		_, err := NewBypass(
+			[]string{getInfoURI, method}, testPermissions,
+		)
+		require.Error(t, err, method)
+	}
+}
+
+// TestBypassWrap tests that a wrapped interceptor isn't called for the
+// messages of bypassed methods.
+func TestBypassWrap(t *testing.T) {
+	counter := &countingInterceptor{}
+
+	// Without any bypassed methods the interceptor isn't wrapped.
+	empty, err := NewBypass(nil, testPermissions)
+	require.NoError(t, err)
+	require.Same(t, counter, empty.Wrap(counter))
+
+	var nilBypass *Bypass
+	require.Same(t, counter, nilBypass.Wrap(counter))
+
+	b, err := NewBypass([]string{getInfoURI}, testPermissions)
+	require.NoError(t, err)
+	wrapped := b.Wrap(counter)
+
+	reqFor := func(uri string) *lnrpc.RPCMiddlewareRequest {
+		return &lnrpc.RPCMiddlewareRequest{
+			InterceptType: &lnrpc.RPCMiddlewareRequest_Request{
+				Request: &lnrpc.RPCMessage{
+					MethodFullUri: uri,
+				},
+			},
+		}
+	}
+	respFor := func(uri string) *lnrpc.RPCMiddlewareRequest {
+		return &lnrpc.RPCMiddlewareRequest{
+			InterceptType: &lnrpc.RPCMiddlewareRequest_Response{
+				Response: &lnrpc.RPCMessage{
+					MethodFullUri: uri,
+				},
+			},
+		}
+	}
+
+	resp, err := wrapped.Intercept(ctxb, reqFor(getInfoURI))
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetFeedback())
+	require.Empty(t, resp.GetFeedback().Error)
+
+	_, err = wrapped.Intercept(ctxb, respFor(getInfoURI))
+	require.NoError(t, err)
+	require.Zero(t, counter.calls)
+
+	_, err = wrapped.Intercept(ctxb, reqFor(sendURI))
+	require.NoError(t, err)
+	_, err = wrapped.Intercept(ctxb, respFor(sendURI))
+	require.NoError(t, err)
+	require.Equal(t, 2, counter.calls)
+}
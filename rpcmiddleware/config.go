@@ -12,6 +12,7 @@ const (
 type Config struct {
 	Disabled         bool          `long:"disabled" description:"Disable the RPC middleware"`
 	InterceptTimeout time.Duration `long:"intercept-timeout" description:"The maximum time the RPC middleware is allowed to take for intercepting each RPC request"`
+	BypassMethods    []string      `long:"bypass-method" description:"The full URI of a read-only RPC method (e.g. /lnrpc.Lightning/GetInfo) for which the guardrails and the on-chain spend blocker are skipped to reduce latency. Macaroons are still checked, calls are still logged and checked against the firewall rules and responses are still rewritten by the privacy mapper and the account interceptor. Only methods that require nothing but read permissions can be bypassed. Can be specified multiple times."`
 }
 
 // DefaultConfig returns the default RPC middleware configuration.
@@ -0,0 +1,240 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+)
+
+const (
+	// ConservativeAutopilotBundle is the name of the built-in rule bundle
+	// that only allows a slow rate of actions and a limited view of the
+	// node's history.
+	ConservativeAutopilotBundle = "conservative-autopilot"
+
+	// FeeManagementBundle is the name of the built-in rule bundle that is
+	// tailored to features that manage the channel fees of the node.
+	FeeManagementBundle = "fee-management"
+)
+
+// ErrBuiltInBundle is returned when an attempt is made to overwrite or remove
+// one of the built-in rule bundles.
+var ErrBuiltInBundle = errors.New("built-in rule bundles cannot be modified")
+
+// Bundle is a named set of rule values that can be selected when creating a
+// session instead of specifying each of the rules individually.
+type Bundle struct {
+	// Name is the unique name of the bundle.
+	Name string
+
+	// Description is a human-readable description of the bundle.
+	Description string
+
+	// Rules is a map from rule name to the rule values of the bundle.
+	Rules map[string]Values
+
+	// BuiltIn is true if the bundle ships with LiT and can therefore not
+	// be modified.
+	BuiltIn bool
+}
+
+// builtInBundles returns the set of rule bundles that ship with LiT.
+func builtInBundles() map[string]*Bundle {
+	bundles := []*Bundle{
+		{
+			Name: ConservativeAutopilotBundle,
+			Description: "Allows only a few write actions per " +
+				"day and restricts the visible history to " +
+				"the last week.",
+			Rules: map[string]Values{
+				RateLimitName: &RateLimit{
+					WriteLimit: &Rate{
+						Iterations: 1,
+						NumHours:   24,
+					},
+					ReadLimit: &Rate{
+						Iterations: 10,
+						NumHours:   1,
+					},
+				},
+				HistoryLimitName: &HistoryLimit{
+					Duration: time.Hour * 24 * 7,
+				},
+			},
+		},
+		{
+			Name: FeeManagementBundle,
+			Description: "Keeps channel fee updates within " +
+				"moderate bounds and limits how often they " +
+				"can be made.",
+			Rules: map[string]Values{
+				RateLimitName: &RateLimit{
+					WriteLimit: &Rate{
+						Iterations: 10,
+						NumHours:   24,
+					},
+					ReadLimit: &Rate{
+						Iterations: 60,
+						NumHours:   1,
+					},
+				},
+				ChanPolicyBoundsName: &ChanPolicyBounds{
+					MinBaseMsat:  0,
+					MaxBaseMsat:  10_000,
+					MinRatePPM:   1,
+					MaxRatePPM:   5_000,
+					MinCLTVDelta: 40,
+					MaxCLTVDelta: 2016,
+					MinHtlcMsat:  1,
+					MaxHtlcMsat:  100_000_000_000,
+				},
+			},
+		},
+	}
+
+	res := make(map[string]*Bundle, len(bundles))
+	for _, b := range bundles {
+		b.BuiltIn = true
+		res[b.Name] = b
+	}
+
+	return res
+}
+
+// BundleStore provides access to both the built-in rule bundles and the
+// custom rule bundles persisted in the firewall db.
+type BundleStore struct {
+	db      firewalldb.RuleBundlesDB
+	mgrs    ManagerSet
+	builtIn map[string]*Bundle
+}
+
+// NewBundleStore constructs a new BundleStore.
+func NewBundleStore(db firewalldb.RuleBundlesDB,
+	mgrs ManagerSet) *BundleStore {
+
+	return &BundleStore{
+		db:      db,
+		mgrs:    mgrs,
+		builtIn: builtInBundles(),
+	}
+}
+
+// GetBundle returns the bundle with the given name. Built-in bundles take
+// precedence over custom ones. If no such bundle exists,
+// firewalldb.ErrRuleBundleNotFound is returned.
+func (s *BundleStore) GetBundle(name string) (*Bundle, error) {
+	if b, ok := s.builtIn[name]; ok {
+		return b, nil
+	}
+
+	dbBundle, err := s.db.GetRuleBundle(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.unmarshalBundle(dbBundle)
+}
+
+// ListBundles returns all the built-in and custom bundles sorted by name.
+func (s *BundleStore) ListBundles() ([]*Bundle, error) {
+	dbBundles, err := s.db.ListRuleBundles()
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([]*Bundle, 0, len(s.builtIn)+len(dbBundles))
+	for _, b := range s.builtIn {
+		bundles = append(bundles, b)
+	}
+
+	for _, dbBundle := range dbBundles {
+		b, err := s.unmarshalBundle(dbBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		bundles = append(bundles, b)
+	}
+
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].Name < bundles[j].Name
+	})
+
+	return bundles, nil
+}
+
+// SetBundle adds a new custom bundle or updates an existing one. Any session
+// that follows the bundle will have the new values applied.
+func (s *BundleStore) SetBundle(b *Bundle) error {
+	if b.Name == "" {
+		return fmt.Errorf("bundle name cannot be empty")
+	}
+
+	if _, ok := s.builtIn[b.Name]; ok {
+		return ErrBuiltInBundle
+	}
+
+	if len(b.Rules) == 0 {
+		return fmt.Errorf("bundle must contain at least one rule")
+	}
+
+	dbBundle := &firewalldb.RuleBundle{
+		Name:        b.Name,
+		Description: b.Description,
+		Rules:       make(map[string]string, len(b.Rules)),
+	}
+	for name, v := range b.Rules {
+		if name != v.RuleName() {
+			return fmt.Errorf("rule %s has values of rule %s",
+				name, v.RuleName())
+		}
+
+		if _, ok := s.mgrs[name]; !ok {
+			return fmt.Errorf("%s is not a known rule", name)
+		}
+
+		vb, err := Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		dbBundle.Rules[name] = string(vb)
+	}
+
+	return s.db.StoreRuleBundle(dbBundle)
+}
+
+// RemoveBundle removes the custom bundle with the given name.
+func (s *BundleStore) RemoveBundle(name string) error {
+	if _, ok := s.builtIn[name]; ok {
+		return ErrBuiltInBundle
+	}
+
+	return s.db.DeleteRuleBundle(name)
+}
+
+// unmarshalBundle converts a persisted bundle into a Bundle.
+func (s *BundleStore) unmarshalBundle(
+	dbBundle *firewalldb.RuleBundle) (*Bundle, error) {
+
+	b := &Bundle{
+		Name:        dbBundle.Name,
+		Description: dbBundle.Description,
+		Rules:       make(map[string]Values, len(dbBundle.Rules)),
+	}
+	for name, value := range dbBundle.Rules {
+		v, err := s.mgrs.InitRuleValues(name, []byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rule %s of "+
+				"bundle %s: %v", name, dbBundle.Name, err)
+		}
+
+		b.Rules[name] = v
+	}
+
+	return b, nil
+}
@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBundleStore tests that the BundleStore correctly serves the built-in
+// bundles and manages the custom bundles.
+func TestBundleStore(t *testing.T) {
+	db, err := firewalldb.NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	store := NewBundleStore(db, NewRuleManagerSet())
+
+	// Initially only the built-in bundles should be listed.
+	bundles, err := store.ListBundles()
+	require.NoError(t, err)
+	require.Len(t, bundles, len(builtInBundles()))
+	for _, b := range bundles {
+		require.True(t, b.BuiltIn)
+	}
+
+	bundle, err := store.GetBundle(ConservativeAutopilotBundle)
+	require.NoError(t, err)
+	require.Contains(t, bundle.Rules, RateLimitName)
+
+	// Built-in bundles can't be overwritten or removed.
+	err = store.SetBundle(&Bundle{
+		Name:  ConservativeAutopilotBundle,
+		Rules: map[string]Values{HistoryLimitName: &HistoryLimit{}},
+	})
+	require.ErrorIs(t, err, ErrBuiltInBundle)
+	require.ErrorIs(
+		t, store.RemoveBundle(ConservativeAutopilotBundle),
+		ErrBuiltInBundle,
+	)
+
+	// A bundle with rule values stored under the wrong name is rejected.
+	err = store.SetBundle(&Bundle{
+		Name:  "custom",
+		Rules: map[string]Values{RateLimitName: &HistoryLimit{}},
+	})
+	require.ErrorContains(t, err, "has values of rule")
+
+	// Add a custom bundle and make sure it can be fetched again.
+	custom := &Bundle{
+		Name:        "custom",
+		Description: "a custom bundle",
+		Rules: map[string]Values{
+			RateLimitName: &RateLimit{
+				WriteLimit: &Rate{Iterations: 2, NumHours: 1},
+				ReadLimit:  &Rate{Iterations: 20, NumHours: 1},
+			},
+		},
+	}
+	require.NoError(t, store.SetBundle(custom))
+
+	bundle, err = store.GetBundle("custom")
+	require.NoError(t, err)
+	require.Equal(t, custom, bundle)
+
+	// Updating the bundle should be reflected in the next fetch.
+	custom.Rules[RateLimitName].(*RateLimit).WriteLimit.Iterations = 5
+	require.NoError(t, store.SetBundle(custom))
+
+	bundle, err = store.GetBundle("custom")
+	require.NoError(t, err)
+	require.EqualValues(
+		t, 5, bundle.Rules[RateLimitName].(*RateLimit).WriteLimit.
+			Iterations,
+	)
+
+	bundles, err = store.ListBundles()
+	require.NoError(t, err)
+	require.Len(t, bundles, len(builtInBundles())+1)
+
+	// Finally, remove the custom bundle again.
+	require.NoError(t, store.RemoveBundle("custom"))
+	_, err = store.GetBundle("custom")
+	require.ErrorIs(t, err, firewalldb.ErrRuleBundleNotFound)
+}
@@ -0,0 +1,416 @@
+package rules
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// Compile-time checks to ensure that ChanOpenConstraintsMgr,
+	// ChanOpenConstraints and ChanOpenConstraintsEnforcer implement the
+	// appropriate Manager, Enforcer and Values interface.
+	_ Manager  = (*ChanOpenConstraintsMgr)(nil)
+	_ Enforcer = (*ChanOpenConstraintsEnforcer)(nil)
+	_ Values   = (*ChanOpenConstraints)(nil)
+)
+
+// ChanOpenConstraintsName is the string identifier of the ChanOpenConstraints
+// rule.
+const ChanOpenConstraintsName = "channel-open-constraints"
+
+// ChanOpenConstraintsMgr manages the ChanOpenConstraints rule.
+type ChanOpenConstraintsMgr struct{}
+
+// Stop cleans up the resources held by the manager.
+//
+// NOTE: This is part of the Manager interface.
+func (c *ChanOpenConstraintsMgr) Stop() error {
+	return nil
+}
+
+// NewEnforcer constructs a new ChanOpenConstraints rule enforcer using the
+// passed values and config.
+//
+// NOTE: This is part of the Manager interface.
+func (c *ChanOpenConstraintsMgr) NewEnforcer(_ Config, values Values) (
+	Enforcer, error) {
+
+	constraints, ok := values.(*ChanOpenConstraints)
+	if !ok {
+		return nil, fmt.Errorf("values must be of type "+
+			"ChanOpenConstraints, got %T", values)
+	}
+
+	peerMap := make(map[string]bool, len(constraints.AllowedPeers))
+	for _, peerID := range constraints.AllowedPeers {
+		peerMap[strings.ToLower(peerID)] = true
+	}
+
+	return &ChanOpenConstraintsEnforcer{
+		ChanOpenConstraints: constraints,
+		peerMap:             peerMap,
+	}, nil
+}
+
+// NewValueFromProto converts the given proto value into a ChanOpenConstraints
+// Value object.
+//
+// NOTE: This is part of the Manager interface.
+func (c *ChanOpenConstraintsMgr) NewValueFromProto(v *litrpc.RuleValue) (
+	Values, error) {
+
+	rv, ok := v.Value.(*litrpc.RuleValue_ChannelOpenConstraints)
+	if !ok {
+		return nil, fmt.Errorf("incorrect RuleValue type")
+	}
+
+	constraints := rv.ChannelOpenConstraints
+
+	if constraints.MaxChanSizeSat != 0 &&
+		constraints.MinChanSizeSat > constraints.MaxChanSizeSat {
+
+		return nil, fmt.Errorf("minimum channel size cannot be " +
+			"larger than the maximum channel size")
+	}
+
+	return &ChanOpenConstraints{
+		AllowedPeers:   constraints.PeerIds,
+		MinChanSize:    constraints.MinChanSizeSat,
+		MaxChanSize:    constraints.MaxChanSizeSat,
+		MaxSatPerVByte: constraints.MaxSatPerVbyte,
+	}, nil
+}
+
+// EmptyValue returns a new ChanOpenConstraints instance.
+//
+// NOTE: This is part of the Manager interface.
+func (c *ChanOpenConstraintsMgr) EmptyValue() Values {
+	return &ChanOpenConstraints{}
+}
+
+// ChanOpenConstraintsEnforcer enforces requests and responses against a
+// ChanOpenConstraints rule.
+type ChanOpenConstraintsEnforcer struct {
+	*ChanOpenConstraints
+
+	peerMap map[string]bool
+}
+
+// HandleRequest checks the validity of a request using the ChanOpenConstraints
+// rpcmiddleware.RoundTripCheckers.
+//
+// NOTE: this is part of the Enforcer interface.
+func (c *ChanOpenConstraintsEnforcer) HandleRequest(ctx context.Context,
+	uri string, msg proto.Message) (proto.Message, error) {
+
+	checkers := c.checkers()
+	if checkers == nil {
+		return nil, nil
+	}
+
+	checker, ok := checkers[uri]
+	if !ok {
+		return nil, nil
+	}
+
+	if !checker.HandlesRequest(msg.ProtoReflect().Type()) {
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept request of type %v", uri,
+			msg.ProtoReflect().Type())
+	}
+
+	return checker.HandleRequest(ctx, msg)
+}
+
+// HandleResponse handles a response using the ChanOpenConstraints
+// rpcmiddleware.RoundTripCheckers.
+//
+// NOTE: this is part of the Enforcer interface.
+func (c *ChanOpenConstraintsEnforcer) HandleResponse(ctx context.Context,
+	uri string, msg proto.Message) (proto.Message, error) {
+
+	checkers := c.checkers()
+	if checkers == nil {
+		return nil, nil
+	}
+
+	checker, ok := checkers[uri]
+	if !ok {
+		return nil, nil
+	}
+
+	if !checker.HandlesResponse(msg.ProtoReflect().Type()) {
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept response of type %v", uri,
+			msg.ProtoReflect().Type())
+	}
+
+	return checker.HandleResponse(ctx, msg)
+}
+
+// HandleErrorResponse handles and possible alters an error. This is a noop for
+// the ChanOpenConstraints rule.
+//
+// NOTE: this is part of the Enforcer interface.
+func (c *ChanOpenConstraintsEnforcer) HandleErrorResponse(_ context.Context,
+	_ string, _ error) (error, error) {
+
+	return nil, nil
+}
+
+// checkers returns a map of URI to rpcmiddleware.RoundTripChecker which define
+// how the URI should be handled.
+func (c *ChanOpenConstraintsEnforcer) checkers() map[string]mid.RoundTripChecker {
+	checkOpen := func(r *lnrpc.OpenChannelRequest) error {
+		peerID := hex.EncodeToString(r.GetNodePubkey())
+		if len(r.GetNodePubkey()) == 0 {
+			peerID = r.GetNodePubkeyString()
+		}
+
+		feeRate := r.GetSatPerVbyte()
+		if feeRate == 0 {
+			feeRate = uint64(r.GetSatPerByte())
+		}
+
+		return c.checkChannel(
+			peerID, r.GetLocalFundingAmount(), feeRate,
+		)
+	}
+
+	return map[string]mid.RoundTripChecker{
+		"/lnrpc.Lightning/OpenChannelSync": mid.NewRequestChecker(
+			&lnrpc.OpenChannelRequest{},
+			&lnrpc.ChannelPoint{},
+			func(_ context.Context,
+				r *lnrpc.OpenChannelRequest) error {
+
+				return checkOpen(r)
+			},
+		),
+		"/lnrpc.Lightning/OpenChannel": mid.NewRequestChecker(
+			&lnrpc.OpenChannelRequest{},
+			&lnrpc.OpenStatusUpdate{},
+			func(_ context.Context,
+				r *lnrpc.OpenChannelRequest) error {
+
+				return checkOpen(r)
+			},
+		),
+		"/lnrpc.Lightning/BatchOpenChannel": mid.NewRequestChecker(
+			&lnrpc.BatchOpenChannelRequest{},
+			&lnrpc.BatchOpenChannelResponse{},
+			func(_ context.Context,
+				r *lnrpc.BatchOpenChannelRequest) error {
+
+				for _, channel := range r.GetChannels() {
+					err := c.checkChannel(
+						hex.EncodeToString(
+							channel.GetNodePubkey(),
+						),
+						channel.GetLocalFundingAmount(),
+						uint64(r.GetSatPerVbyte()),
+					)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		),
+	}
+}
+
+// checkChannel checks that a channel to the given peer with the given size
+// and funding fee rate is within the bounds of the rule.
+func (c *ChanOpenConstraintsEnforcer) checkChannel(peerID string, amt int64,
+	satPerVByte uint64) error {
+
+	if len(c.peerMap) != 0 && !c.peerMap[strings.ToLower(peerID)] {
+		return fmt.Errorf("opening a channel to peer %s is not "+
+			"allowed", peerID)
+	}
+
+	if amt < 0 {
+		return fmt.Errorf("invalid channel size %d", amt)
+	}
+
+	if uint64(amt) < c.MinChanSize {
+		return fmt.Errorf("channel size of %d sat is below the "+
+			"minimum of %d sat", amt, c.MinChanSize)
+	}
+
+	if c.MaxChanSize != 0 && uint64(amt) > c.MaxChanSize {
+		return fmt.Errorf("channel size of %d sat is above the "+
+			"maximum of %d sat", amt, c.MaxChanSize)
+	}
+
+	if c.MaxSatPerVByte == 0 {
+		return nil
+	}
+
+	// We can't know which fee rate lnd will estimate for a confirmation
+	// target, so an explicit fee rate is required if the fee is bounded.
+	if satPerVByte == 0 {
+		return fmt.Errorf("an explicit funding fee rate must be set " +
+			"when using a channel open fee rate limit")
+	}
+
+	if satPerVByte > c.MaxSatPerVByte {
+		return fmt.Errorf("funding fee rate of %d sat/vbyte is above "+
+			"the maximum of %d sat/vbyte", satPerVByte,
+			c.MaxSatPerVByte)
+	}
+
+	return nil
+}
+
+// ChanOpenConstraints is a rule that restricts the channels that may be
+// opened.
+type ChanOpenConstraints struct {
+	// AllowedPeers is a list of peer IDs that channels may be opened to.
+	// If empty, channels may be opened to any peer.
+	AllowedPeers []string `json:"allowed_peers"`
+
+	// MinChanSize is the minimum channel size in satoshis.
+	MinChanSize uint64 `json:"min_chan_size_sat"`
+
+	// MaxChanSize is the maximum channel size in satoshis. If zero, no
+	// maximum is enforced.
+	MaxChanSize uint64 `json:"max_chan_size_sat"`
+
+	// MaxSatPerVByte is the maximum funding transaction fee rate in
+	// sat/vbyte. If zero, no maximum is enforced.
+	MaxSatPerVByte uint64 `json:"max_sat_per_vbyte"`
+}
+
+// VerifySane checks that the value of the values is ok given the min and max
+// allowed values.
+//
+// NOTE: this is part of the Values interface.
+func (c *ChanOpenConstraints) VerifySane(minVal, maxVal Values) error {
+	minCons, ok := minVal.(*ChanOpenConstraints)
+	if !ok {
+		return fmt.Errorf("min value is not of type " +
+			"ChanOpenConstraints")
+	}
+
+	maxCons, ok := maxVal.(*ChanOpenConstraints)
+	if !ok {
+		return fmt.Errorf("max value is not of type " +
+			"ChanOpenConstraints")
+	}
+
+	if c.MinChanSize < minCons.MinChanSize {
+		return fmt.Errorf("invalid min channel size")
+	}
+
+	if maxCons.MaxChanSize != 0 && (c.MaxChanSize == 0 ||
+		c.MaxChanSize > maxCons.MaxChanSize) {
+
+		return fmt.Errorf("invalid max channel size")
+	}
+
+	if maxCons.MaxSatPerVByte != 0 && (c.MaxSatPerVByte == 0 ||
+		c.MaxSatPerVByte > maxCons.MaxSatPerVByte) {
+
+		return fmt.Errorf("invalid max funding fee rate")
+	}
+
+	return nil
+}
+
+// RuleName returns the name of the rule that these values are to be used with.
+//
+// NOTE: this is part of the Values interface.
+func (c *ChanOpenConstraints) RuleName() string {
+	return ChanOpenConstraintsName
+}
+
+// ToProto converts the rule Values to the litrpc counterpart.
+//
+// NOTE: this is part of the Values interface.
+func (c *ChanOpenConstraints) ToProto() *litrpc.RuleValue {
+	return &litrpc.RuleValue{
+		Value: &litrpc.RuleValue_ChannelOpenConstraints{
+			ChannelOpenConstraints: &litrpc.ChannelOpenConstraints{
+				PeerIds:        c.AllowedPeers,
+				MinChanSizeSat: c.MinChanSize,
+				MaxChanSizeSat: c.MaxChanSize,
+				MaxSatPerVbyte: c.MaxSatPerVByte,
+			},
+		},
+	}
+}
+
+// PseudoToReal assumes that the allowed peer list contains pseudo peer IDs and
+// uses these to check the privacy map db for the corresponding real peer IDs.
+// It constructs a new ChanOpenConstraints instance with these real peer IDs.
+//
+// NOTE: this is part of the Values interface.
+func (c *ChanOpenConstraints) PseudoToReal(db firewalldb.PrivacyMapDB) (Values,
+	error) {
+
+	peers := make([]string, len(c.AllowedPeers))
+	err := db.View(func(tx firewalldb.PrivacyMapTx) error {
+		for i, peerID := range c.AllowedPeers {
+			real, err := firewalldb.RevealString(tx, peerID)
+			if err != nil {
+				return err
+			}
+
+			peers[i] = real
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChanOpenConstraints{
+		AllowedPeers:   peers,
+		MinChanSize:    c.MinChanSize,
+		MaxChanSize:    c.MaxChanSize,
+		MaxSatPerVByte: c.MaxSatPerVByte,
+	}, nil
+}
+
+// RealToPseudo converts all the real peer IDs into pseudo IDs.
+//
+// NOTE: this is part of the Values interface.
+func (c *ChanOpenConstraints) RealToPseudo(
+	gen *firewalldb.PseudoGenerator) (Values, map[string]string, error) {
+
+	pseudoIDs := make([]string, len(c.AllowedPeers))
+	privMapPairs := make(map[string]string)
+	for i, id := range c.AllowedPeers {
+		if pseudo, ok := privMapPairs[id]; ok {
+			pseudoIDs[i] = pseudo
+			continue
+		}
+
+		pseudo, err := gen.Str(id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		privMapPairs[id] = pseudo
+		pseudoIDs[i] = pseudo
+	}
+
+	return &ChanOpenConstraints{
+		AllowedPeers:   pseudoIDs,
+		MinChanSize:    c.MinChanSize,
+		MaxChanSize:    c.MaxChanSize,
+		MaxSatPerVByte: c.MaxSatPerVByte,
+	}, privMapPairs, nil
+}
@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChanOpenConstraintsCheckRequest ensures that the
+// ChanOpenConstraintsEnforcer correctly accepts or denies a request.
+func TestChanOpenConstraintsCheckRequest(t *testing.T) {
+	peerID1, err := firewalldb.NewPseudoStr(66)
+	require.NoError(t, err)
+
+	peerID2, err := firewalldb.NewPseudoStr(66)
+	require.NoError(t, err)
+
+	peerKey2, err := hex.DecodeString(peerID2)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	mgr := &ChanOpenConstraintsMgr{}
+	enf, err := mgr.NewEnforcer(nil, &ChanOpenConstraints{
+		AllowedPeers:   []string{peerID1},
+		MinChanSize:    100_000,
+		MaxChanSize:    1_000_000,
+		MaxSatPerVByte: 10,
+	})
+	require.NoError(t, err)
+
+	// A request for an irrelevant URI should be allowed.
+	_, err = enf.HandleRequest(ctx, "random-URI", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		req    *lnrpc.OpenChannelRequest
+		expErr string
+	}{
+		{
+			name: "valid open",
+			req: &lnrpc.OpenChannelRequest{
+				NodePubkeyString:   peerID1,
+				LocalFundingAmount: 500_000,
+				SatPerVbyte:        5,
+			},
+		},
+		{
+			name: "peer not allowed",
+			req: &lnrpc.OpenChannelRequest{
+				NodePubkey:         peerKey2,
+				LocalFundingAmount: 500_000,
+				SatPerVbyte:        5,
+			},
+			expErr: "is not allowed",
+		},
+		{
+			name: "channel too small",
+			req: &lnrpc.OpenChannelRequest{
+				NodePubkeyString:   peerID1,
+				LocalFundingAmount: 50_000,
+				SatPerVbyte:        5,
+			},
+			expErr: "below the minimum",
+		},
+		{
+			name: "channel too large",
+			req: &lnrpc.OpenChannelRequest{
+				NodePubkeyString:   peerID1,
+				LocalFundingAmount: 5_000_000,
+				SatPerVbyte:        5,
+			},
+			expErr: "above the maximum",
+		},
+		{
+			name: "fee rate too high",
+			req: &lnrpc.OpenChannelRequest{
+				NodePubkeyString:   peerID1,
+				LocalFundingAmount: 500_000,
+				SatPerVbyte:        50,
+			},
+			expErr: "funding fee rate of 50 sat/vbyte",
+		},
+		{
+			name: "no explicit fee rate",
+			req: &lnrpc.OpenChannelRequest{
+				NodePubkeyString:   peerID1,
+				LocalFundingAmount: 500_000,
+				TargetConf:         6,
+			},
+			expErr: "explicit funding fee rate must be set",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			for _, uri := range []string{
+				"/lnrpc.Lightning/OpenChannelSync",
+				"/lnrpc.Lightning/OpenChannel",
+			} {
+				_, err := enf.HandleRequest(ctx, uri, test.req)
+				if test.expErr == "" {
+					require.NoError(t, err)
+					continue
+				}
+
+				require.ErrorContains(t, err, test.expErr)
+			}
+		})
+	}
+
+	// A batch open is denied if any of the channels is not allowed.
+	_, err = enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/BatchOpenChannel",
+		&lnrpc.BatchOpenChannelRequest{
+			SatPerVbyte: 5,
+			Channels: []*lnrpc.BatchOpenChannel{
+				{
+					NodePubkey:         peerKey2,
+					LocalFundingAmount: 500_000,
+				},
+			},
+		},
+	)
+	require.ErrorContains(t, err, "is not allowed")
+}
+
+// TestChanOpenConstraintsVerifySane tests the VerifySane method of the
+// ChanOpenConstraints values.
+func TestChanOpenConstraintsVerifySane(t *testing.T) {
+	minVal := &ChanOpenConstraints{MinChanSize: 20_000}
+	maxVal := &ChanOpenConstraints{
+		MaxChanSize:    1_000_000,
+		MaxSatPerVByte: 100,
+	}
+
+	require.NoError(t, (&ChanOpenConstraints{
+		MinChanSize:    20_000,
+		MaxChanSize:    500_000,
+		MaxSatPerVByte: 10,
+	}).VerifySane(minVal, maxVal))
+
+	require.ErrorContains(t, (&ChanOpenConstraints{
+		MinChanSize:    10_000,
+		MaxChanSize:    500_000,
+		MaxSatPerVByte: 10,
+	}).VerifySane(minVal, maxVal), "invalid min channel size")
+
+	require.ErrorContains(t, (&ChanOpenConstraints{
+		MinChanSize:    20_000,
+		MaxSatPerVByte: 10,
+	}).VerifySane(minVal, maxVal), "invalid max channel size")
+
+	require.ErrorContains(t, (&ChanOpenConstraints{
+		MinChanSize: 20_000,
+		MaxChanSize: 500_000,
+	}).VerifySane(minVal, maxVal), "invalid max funding fee rate")
+}
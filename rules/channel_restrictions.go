@@ -363,7 +363,9 @@ func (c *ChannelRestrict) PseudoToReal(db firewalldb.PrivacyMapDB) (Values,
 // RealToPseudo converts all the channel IDs into pseudo IDs.
 //
 // NOTE: this is part of the Values interface.
-func (c *ChannelRestrict) RealToPseudo() (Values, map[string]string, error) {
+func (c *ChannelRestrict) RealToPseudo(gen *firewalldb.PseudoGenerator) (Values,
+	map[string]string, error) {
+
 	pseudoIDs := make([]uint64, len(c.DenyList))
 	privMapPairs := make(map[string]string)
 	for i, c := range c.DenyList {
@@ -380,7 +382,7 @@ func (c *ChannelRestrict) RealToPseudo() (Values, map[string]string, error) {
 			continue
 		}
 
-		pseudoCp, pseudoCpStr := firewalldb.NewPseudoUint64()
+		pseudoCp, pseudoCpStr := gen.Uint64(c)
 		privMapPairs[chanID] = pseudoCpStr
 		pseudoIDs[i] = pseudoCp
 	}
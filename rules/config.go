@@ -1,8 +1,10 @@
 package rules
 
 import (
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/lightninglabs/lightning-terminal/firewalldb"
 	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 )
 
@@ -34,6 +36,15 @@ type Config interface {
 
 	// GetLndClient returns an lnd client.
 	GetLndClient() lndclient.LightningClient
+
+	// GetWalletKitClient returns a raw lnd wallet kit client. The raw
+	// client is used since lndclient does not expose all the wallet kit
+	// calls that rules may need, such as ListAddresses.
+	GetWalletKitClient() walletrpc.WalletKitClient
+
+	// GetChainParams returns the chain parameters of the network that lnd
+	// is running on.
+	GetChainParams() *chaincfg.Params
 }
 
 // ConfigImpl is an implementation of the Config interface.
@@ -63,6 +74,14 @@ type ConfigImpl struct {
 
 	// LndClient is a connection to the Lit node's LND node.
 	LndClient lndclient.LightningClient
+
+	// WalletKitClient is a raw connection to the wallet kit sub-server of
+	// the Lit node's LND node.
+	WalletKitClient walletrpc.WalletKitClient
+
+	// ChainParams are the chain parameters of the network that the Lit
+	// node's LND node is running on.
+	ChainParams *chaincfg.Params
 }
 
 func (c *ConfigImpl) GetStores() firewalldb.KVStores {
@@ -100,6 +119,16 @@ func (c *ConfigImpl) GetLndClient() lndclient.LightningClient {
 	return c.LndClient
 }
 
+// GetWalletKitClient returns a raw lnd wallet kit client.
+func (c *ConfigImpl) GetWalletKitClient() walletrpc.WalletKitClient {
+	return c.WalletKitClient
+}
+
+// GetChainParams returns the chain parameters of the lnd node's network.
+func (c *ConfigImpl) GetChainParams() *chaincfg.Params {
+	return c.ChainParams
+}
+
 // A compile-time check to ensure that ConfigImpl implements the Config
 // interface.
 var _ Config = (*ConfigImpl)(nil)
@@ -45,6 +45,16 @@ type Enforcer interface {
 		error)
 }
 
+// StreamEnforcer is an optional interface that an Enforcer can implement to be
+// consulted when a client establishes a stream. The client messages sent on an
+// established stream are each passed to HandleRequest and so are checked and
+// accounted for one by one.
+type StreamEnforcer interface {
+	// HandleStreamAuth decides whether a stream to the given URI may be
+	// established.
+	HandleStreamAuth(ctx context.Context, uri string) error
+}
+
 // Values represents the static values that encompass the settings of the rule.
 type Values interface {
 	// RuleName returns the name of the rule that these values are to be
@@ -60,8 +70,10 @@ type Values interface {
 
 	// RealToPseudo converts the rule Values to a new one that uses pseudo
 	// keys, channel IDs, channel points etc. It returns a map of real to
-	// pseudo strings that should be persisted.
-	RealToPseudo() (Values, map[string]string, error)
+	// pseudo strings that should be persisted. The given generator creates
+	// the pseudo values. A nil generator creates random values.
+	RealToPseudo(gen *firewalldb.PseudoGenerator) (Values,
+		map[string]string, error)
 
 	// PseudoToReal attempts to convert any appropriate pseudo fields in
 	// the rule Values to their corresponding real values. It uses the
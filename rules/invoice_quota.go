@@ -0,0 +1,357 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// Compile-time checks to ensure that InvoiceQuotaMgr, InvoiceQuota and
+	// InvoiceQuotaEnforcer implement the appropriate Manager, Enforcer and
+	// Values interface.
+	_ Manager  = (*InvoiceQuotaMgr)(nil)
+	_ Enforcer = (*InvoiceQuotaEnforcer)(nil)
+	_ Values   = (*InvoiceQuota)(nil)
+)
+
+const (
+	// InvoiceQuotaName is the string identifier of the InvoiceQuota rule.
+	InvoiceQuotaName = "invoice-quota"
+
+	// addInvoiceURI is the URI of the call that creates a regular invoice.
+	addInvoiceURI = "/lnrpc.Lightning/AddInvoice"
+
+	// addHoldInvoiceURI is the URI of the call that creates a hold
+	// invoice.
+	addHoldInvoiceURI = "/invoicesrpc.Invoices/AddHoldInvoice"
+)
+
+// InvoiceQuotaMgr manages the InvoiceQuota rule.
+type InvoiceQuotaMgr struct{}
+
+// Stop cleans up the resources held by the manager.
+//
+// NOTE: This is part of the Manager interface.
+func (i *InvoiceQuotaMgr) Stop() error {
+	return nil
+}
+
+// NewEnforcer constructs a new InvoiceQuota rule enforcer using the passed
+// values and config.
+//
+// NOTE: This is part of the Manager interface.
+func (i *InvoiceQuotaMgr) NewEnforcer(cfg Config, values Values) (Enforcer,
+	error) {
+
+	quota, ok := values.(*InvoiceQuota)
+	if !ok {
+		return nil, fmt.Errorf("values must be of type "+
+			"InvoiceQuota, got %T", values)
+	}
+
+	return &InvoiceQuotaEnforcer{
+		invoiceQuotaConfig: cfg,
+		InvoiceQuota:       quota,
+	}, nil
+}
+
+// NewValueFromProto converts the given proto value into an InvoiceQuota Value
+// object.
+//
+// NOTE: This is part of the Manager interface.
+func (i *InvoiceQuotaMgr) NewValueFromProto(v *litrpc.RuleValue) (Values,
+	error) {
+
+	rv, ok := v.Value.(*litrpc.RuleValue_InvoiceQuota)
+	if !ok {
+		return nil, fmt.Errorf("incorrect RuleValue type")
+	}
+
+	quota := rv.InvoiceQuota
+	if quota.NumHours == 0 {
+		return nil, fmt.Errorf("the invoice quota time window must " +
+			"be at least one hour")
+	}
+
+	return &InvoiceQuota{
+		MaxInvoices: quota.MaxInvoices,
+		MaxAmtMsat:  quota.MaxAmtMsat,
+		NumHours:    quota.NumHours,
+	}, nil
+}
+
+// EmptyValue returns a new InvoiceQuota instance.
+//
+// NOTE: This is part of the Manager interface.
+func (i *InvoiceQuotaMgr) EmptyValue() Values {
+	return &InvoiceQuota{}
+}
+
+// invoiceQuotaConfig is the config required by InvoiceQuotaMgr. It can be
+// derived from the main rules Config struct.
+type invoiceQuotaConfig interface {
+	GetActionsDB() firewalldb.ActionsDB
+}
+
+// InvoiceQuotaEnforcer enforces requests and responses against an
+// InvoiceQuota rule.
+type InvoiceQuotaEnforcer struct {
+	invoiceQuotaConfig
+	*InvoiceQuota
+}
+
+// HandleRequest checks that creating the invoice of the request doesn't
+// exceed the quota of the time window.
+//
+// NOTE: this is part of the Enforcer interface.
+func (i *InvoiceQuotaEnforcer) HandleRequest(ctx context.Context, uri string,
+	msg proto.Message) (proto.Message, error) {
+
+	checker, ok := i.checkers()[uri]
+	if !ok {
+		return nil, nil
+	}
+
+	if !checker.HandlesRequest(msg.ProtoReflect().Type()) {
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept request of type %v", uri,
+			msg.ProtoReflect().Type())
+	}
+
+	return checker.HandleRequest(ctx, msg)
+}
+
+// HandleResponse handles and possible alters a response. This is a noop for
+// the InvoiceQuota rule.
+//
+// NOTE: this is part of the Enforcer interface.
+func (i *InvoiceQuotaEnforcer) HandleResponse(_ context.Context, _ string,
+	_ proto.Message) (proto.Message, error) {
+
+	return nil, nil
+}
+
+// HandleErrorResponse handles and possible alters an error. This is a noop for
+// the InvoiceQuota rule.
+//
+// NOTE: this is part of the Enforcer interface.
+func (i *InvoiceQuotaEnforcer) HandleErrorResponse(_ context.Context,
+	_ string, _ error) (error, error) {
+
+	return nil, nil
+}
+
+// checkers returns a map of URI to rpcmiddleware.RoundTripChecker which define
+// how the URI should be handled.
+func (i *InvoiceQuotaEnforcer) checkers() map[string]mid.RoundTripChecker {
+	return map[string]mid.RoundTripChecker{
+		addInvoiceURI: mid.NewRequestChecker(
+			&lnrpc.Invoice{}, &lnrpc.AddInvoiceResponse{},
+			func(ctx context.Context, r *lnrpc.Invoice) error {
+				amt := invoiceAmtMsat(r.Value, r.ValueMsat)
+				return i.checkQuota(ctx, amt)
+			},
+		),
+		addHoldInvoiceURI: mid.NewRequestChecker(
+			&invoicesrpc.AddHoldInvoiceRequest{},
+			&invoicesrpc.AddHoldInvoiceResp{},
+			func(ctx context.Context,
+				r *invoicesrpc.AddHoldInvoiceRequest) error {
+
+				amt := invoiceAmtMsat(r.Value, r.ValueMsat)
+				return i.checkQuota(ctx, amt)
+			},
+		),
+	}
+}
+
+// checkQuota determines whether creating another invoice of the given amount
+// would exceed the quota of the time window.
+func (i *InvoiceQuotaEnforcer) checkQuota(ctx context.Context,
+	amtMsat uint64) error {
+
+	actions, err := i.GetActionsDB().ListActions(ctx)
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now().Add(
+		-time.Duration(i.NumHours) * time.Hour,
+	)
+
+	// Count the invoices created in the time window, including the one
+	// of the current request.
+	var (
+		count = uint64(1)
+		total = amtMsat
+	)
+	for _, action := range actions {
+		if action.Method != addInvoiceURI &&
+			action.Method != addHoldInvoiceURI {
+
+			continue
+		}
+
+		if action.PerformedAt.Before(startTime) {
+			continue
+		}
+
+		amt, err := actionAmtMsat(action)
+		if err != nil {
+			return err
+		}
+
+		count++
+		total += amt
+	}
+
+	if i.MaxInvoices != 0 && count > uint64(i.MaxInvoices) {
+		return fmt.Errorf("invoice quota of %d invoices per %d hours "+
+			"reached", i.MaxInvoices, i.NumHours)
+	}
+
+	if i.MaxAmtMsat != 0 && total > i.MaxAmtMsat {
+		return fmt.Errorf("invoice quota of %d msat per %d hours "+
+			"exceeded", i.MaxAmtMsat, i.NumHours)
+	}
+
+	return nil
+}
+
+// invoiceAmtMsat returns the amount of an invoice in msat. Like lnd, the msat
+// amount takes precedence if it is set.
+func invoiceAmtMsat(value, valueMsat int64) uint64 {
+	amt := valueMsat
+	if amt == 0 {
+		amt = value * 1000
+	}
+
+	if amt < 0 {
+		return 0
+	}
+
+	return uint64(amt)
+}
+
+// actionAmtMsat returns the amount in msat of the invoice a past action
+// created. If the parameters of the call weren't persisted, the amount is
+// unknown and zero is returned.
+func actionAmtMsat(action *firewalldb.RuleAction) (uint64, error) {
+	if len(action.RPCParamsJson) == 0 {
+		return 0, nil
+	}
+
+	// Both AddInvoice and AddHoldInvoice carry the amount in the same
+	// fields. The int64 fields are encoded as strings.
+	var params struct {
+		Value     int64 `json:"value,string"`
+		ValueMsat int64 `json:"value_msat,string"`
+	}
+	if err := json.Unmarshal(action.RPCParamsJson, &params); err != nil {
+		return 0, fmt.Errorf("unable to parse invoice amount: %v", err)
+	}
+
+	return invoiceAmtMsat(params.Value, params.ValueMsat), nil
+}
+
+// InvoiceQuota is a rule that caps the number and the aggregate amount of the
+// invoices that can be created in a time window.
+type InvoiceQuota struct {
+	// MaxInvoices is the maximum number of invoices that can be created
+	// in the time window. If zero, the number is not limited.
+	MaxInvoices uint32 `json:"max_invoices"`
+
+	// MaxAmtMsat is the maximum aggregate amount in msat of the invoices
+	// created in the time window. If zero, the amount is not limited.
+	MaxAmtMsat uint64 `json:"max_amt_msat"`
+
+	// NumHours is the number of hours the time window spans.
+	NumHours uint32 `json:"num_hours"`
+}
+
+// VerifySane checks that the value of the values is ok given the min and max
+// allowed values.
+//
+// NOTE: this is part of the Values interface.
+func (i *InvoiceQuota) VerifySane(minVal, maxVal Values) error {
+	minQuota, ok := minVal.(*InvoiceQuota)
+	if !ok {
+		return fmt.Errorf("min value is not of type InvoiceQuota")
+	}
+
+	maxQuota, ok := maxVal.(*InvoiceQuota)
+	if !ok {
+		return fmt.Errorf("max value is not of type InvoiceQuota")
+	}
+
+	if i.NumHours < minQuota.NumHours {
+		return fmt.Errorf("invalid invoice quota time window")
+	}
+
+	if maxQuota.MaxInvoices != 0 && (i.MaxInvoices == 0 ||
+		i.MaxInvoices > maxQuota.MaxInvoices) {
+
+		return fmt.Errorf("invalid max number of invoices")
+	}
+
+	if maxQuota.MaxAmtMsat != 0 && (i.MaxAmtMsat == 0 ||
+		i.MaxAmtMsat > maxQuota.MaxAmtMsat) {
+
+		return fmt.Errorf("invalid max invoice amount")
+	}
+
+	return nil
+}
+
+// RuleName returns the name of the rule that these values are to be used with.
+//
+// NOTE: this is part of the Values interface.
+func (i *InvoiceQuota) RuleName() string {
+	return InvoiceQuotaName
+}
+
+// ToProto converts the rule Values to the litrpc counterpart.
+//
+// NOTE: this is part of the Values interface.
+func (i *InvoiceQuota) ToProto() *litrpc.RuleValue {
+	return &litrpc.RuleValue{
+		Value: &litrpc.RuleValue_InvoiceQuota{
+			InvoiceQuota: &litrpc.InvoiceQuota{
+				MaxInvoices: i.MaxInvoices,
+				MaxAmtMsat:  i.MaxAmtMsat,
+				NumHours:    i.NumHours,
+			},
+		},
+	}
+}
+
+// PseudoToReal attempts to convert any appropriate pseudo fields in the rule
+// Values to their corresponding real values. It uses the passed PrivacyMapDB to
+// find the real values. This is a no-op for the InvoiceQuota rule.
+//
+// NOTE: this is part of the Values interface.
+func (i *InvoiceQuota) PseudoToReal(_ firewalldb.PrivacyMapDB) (Values,
+	error) {
+
+	return i, nil
+}
+
+// RealToPseudo converts the rule Values to a new one that uses pseudo keys,
+// channel IDs, channel points etc. It returns a map of real to pseudo strings
+// that should be persisted. This is a no-op for the InvoiceQuota rule.
+//
+// NOTE: this is part of the Values interface.
+func (i *InvoiceQuota) RealToPseudo(_ *firewalldb.PseudoGenerator) (Values,
+	map[string]string, error) {
+
+	return i, nil, nil
+}
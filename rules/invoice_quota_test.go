@@ -0,0 +1,157 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockInvoiceQuotaCfg is used to mock the config backend given to the
+// InvoiceQuotaMgr values during testing.
+type mockInvoiceQuotaCfg struct {
+	db *mockActionsDB
+}
+
+var _ invoiceQuotaConfig = (*mockInvoiceQuotaCfg)(nil)
+
+func (m *mockInvoiceQuotaCfg) GetActionsDB() firewalldb.ActionsDB {
+	return m.db
+}
+
+// TestInvoiceQuotaCheckRequest tests that the number and the aggregate amount
+// of the invoices created in the time window are capped.
+func TestInvoiceQuotaCheckRequest(t *testing.T) {
+	ctx := context.Background()
+	db := &mockActionsDB{}
+	enf := &InvoiceQuotaEnforcer{
+		invoiceQuotaConfig: &mockInvoiceQuotaCfg{db: db},
+		InvoiceQuota: &InvoiceQuota{
+			MaxInvoices: 3,
+			MaxAmtMsat:  10_000_000,
+			NumHours:    24,
+		},
+	}
+
+	addInvoice := func(params string, at time.Time) {
+		db.actions = append(db.actions, &firewalldb.RuleAction{
+			Method:        addInvoiceURI,
+			PerformedAt:   at,
+			RPCParamsJson: []byte(params),
+		})
+	}
+
+	// Other calls are not affected by the rule.
+	_, err := enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/GetInfo", &lnrpc.GetInfoRequest{},
+	)
+	require.NoError(t, err)
+
+	// A single invoice can't exceed the amount quota.
+	_, err = enf.HandleRequest(ctx, addInvoiceURI, &lnrpc.Invoice{
+		Value: 10_001,
+	})
+	require.ErrorContains(t, err, "msat per 24 hours exceeded")
+
+	_, err = enf.HandleRequest(ctx, addInvoiceURI, &lnrpc.Invoice{
+		Value: 5_000,
+	})
+	require.NoError(t, err)
+
+	// Invoices created before the time window don't count.
+	addInvoice(
+		`{"value":"9000","value_msat":"0"}`,
+		time.Now().Add(-25*time.Hour),
+	)
+	addInvoice(`{"value":"0","value_msat":"5000000"}`, time.Now())
+
+	// The amount of the invoices created in the time window counts
+	// towards the quota, including the amount of hold invoices.
+	_, err = enf.HandleRequest(
+		ctx, addHoldInvoiceURI, &invoicesrpc.AddHoldInvoiceRequest{
+			ValueMsat: 5_000_001,
+		},
+	)
+	require.ErrorContains(t, err, "msat per 24 hours exceeded")
+
+	_, err = enf.HandleRequest(
+		ctx, addHoldInvoiceURI, &invoicesrpc.AddHoldInvoiceRequest{
+			ValueMsat: 5_000_000,
+		},
+	)
+	require.NoError(t, err)
+
+	// Amountless invoices still count towards the number of invoices.
+	addInvoice(`{"value":"0","value_msat":"0"}`, time.Now())
+	addInvoice(`{"value":"0","value_msat":"0"}`, time.Now())
+
+	_, err = enf.HandleRequest(ctx, addInvoiceURI, &lnrpc.Invoice{})
+	require.ErrorContains(t, err, "3 invoices per 24 hours reached")
+}
+
+// TestInvoiceQuotaVerifySane tests that the InvoiceQuota VerifySane method
+// correctly checks the values against the min and max sane values.
+func TestInvoiceQuotaVerifySane(t *testing.T) {
+	var (
+		minVal = &InvoiceQuota{
+			NumHours: 1,
+		}
+		maxVal = &InvoiceQuota{
+			MaxInvoices: 100,
+			MaxAmtMsat:  1_000_000,
+			NumHours:    24,
+		}
+	)
+
+	require.NoError(t, (&InvoiceQuota{
+		MaxInvoices: 10,
+		MaxAmtMsat:  1_000,
+		NumHours:    1,
+	}).VerifySane(minVal, maxVal))
+
+	require.Error(t, (&InvoiceQuota{
+		MaxInvoices: 10,
+		MaxAmtMsat:  1_000,
+	}).VerifySane(minVal, maxVal))
+
+	require.Error(t, (&InvoiceQuota{
+		MaxAmtMsat: 1_000,
+		NumHours:   1,
+	}).VerifySane(minVal, maxVal))
+
+	require.Error(t, (&InvoiceQuota{
+		MaxInvoices: 10,
+		MaxAmtMsat:  1_000_001,
+		NumHours:    1,
+	}).VerifySane(minVal, maxVal))
+}
+
+// TestInvoiceQuotaProto tests that the InvoiceQuota values survive a round
+// trip through their proto counterpart.
+func TestInvoiceQuotaProto(t *testing.T) {
+	mgr := &InvoiceQuotaMgr{}
+
+	quota := &InvoiceQuota{
+		MaxInvoices: 10,
+		MaxAmtMsat:  1_000,
+		NumHours:    2,
+	}
+	v, err := mgr.NewValueFromProto(quota.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, quota, v)
+
+	// A quota needs a time window.
+	_, err = mgr.NewValueFromProto(&litrpc.RuleValue{
+		Value: &litrpc.RuleValue_InvoiceQuota{
+			InvoiceQuota: &litrpc.InvoiceQuota{
+				MaxInvoices: 10,
+			},
+		},
+	})
+	require.Error(t, err)
+}
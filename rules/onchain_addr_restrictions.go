@@ -0,0 +1,496 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// Compile-time checks to ensure that OnChainAddrRestrictMgr,
+	// OnChainAddrRestrict and OnChainAddrRestrictEnforcer implement the
+	// appropriate Manager, Enforcer and Values interface.
+	_ Manager  = (*OnChainAddrRestrictMgr)(nil)
+	_ Enforcer = (*OnChainAddrRestrictEnforcer)(nil)
+	_ Values   = (*OnChainAddrRestrict)(nil)
+)
+
+// OnChainAddrRestrictName is the string identifier of the OnChainAddrRestrict
+// rule.
+const OnChainAddrRestrictName = "onchain-address-restriction"
+
+// OnChainAddrRestrictMgr manages the OnChainAddrRestrict rule.
+type OnChainAddrRestrictMgr struct{}
+
+// Stop cleans up the resources held by the manager.
+//
+// NOTE: This is part of the Manager interface.
+func (o *OnChainAddrRestrictMgr) Stop() error {
+	return nil
+}
+
+// NewEnforcer constructs a new OnChainAddrRestrict rule enforcer using the
+// passed values and config.
+//
+// NOTE: This is part of the Manager interface.
+func (o *OnChainAddrRestrictMgr) NewEnforcer(cfg Config, values Values) (
+	Enforcer, error) {
+
+	addrs, ok := values.(*OnChainAddrRestrict)
+	if !ok {
+		return nil, fmt.Errorf("values must be of type "+
+			"OnChainAddrRestrict, got %T", values)
+	}
+
+	// We normalise all the addresses in the allow-list so that they can be
+	// compared against the addresses found in requests regardless of the
+	// encoding used by the caller.
+	allowMap := make(map[string]bool, len(addrs.AllowList))
+	for _, addr := range addrs.AllowList {
+		normalised, err := normaliseAddr(addr, cfg.GetChainParams())
+		if err != nil {
+			return nil, err
+		}
+
+		allowMap[normalised] = true
+	}
+
+	return &OnChainAddrRestrictEnforcer{
+		cfg:                 cfg,
+		OnChainAddrRestrict: addrs,
+		allowMap:            allowMap,
+	}, nil
+}
+
+// NewValueFromProto converts the given proto value into a OnChainAddrRestrict
+// Value object.
+//
+// NOTE: This is part of the Manager interface.
+func (o *OnChainAddrRestrictMgr) NewValueFromProto(v *litrpc.RuleValue) (
+	Values, error) {
+
+	rv, ok := v.Value.(*litrpc.RuleValue_OnchainAddrRestrict)
+	if !ok {
+		return nil, fmt.Errorf("incorrect RuleValue type")
+	}
+
+	addrs := rv.OnchainAddrRestrict.AllowedAddrs
+	internal := rv.OnchainAddrRestrict.InternalWallet
+
+	if len(addrs) == 0 && !internal {
+		return nil, fmt.Errorf("on-chain address allow list cannot " +
+			"be empty if sending to the internal wallet is not " +
+			"allowed either")
+	}
+
+	return &OnChainAddrRestrict{
+		AllowList:      addrs,
+		InternalWallet: internal,
+	}, nil
+}
+
+// EmptyValue returns a new OnChainAddrRestrict instance.
+//
+// NOTE: This is part of the Manager interface.
+func (o *OnChainAddrRestrictMgr) EmptyValue() Values {
+	return &OnChainAddrRestrict{}
+}
+
+// onChainAddrRestrictCfg is the config required by OnChainAddrRestrictMgr. It
+// can be derived from the main rules Config struct.
+type onChainAddrRestrictCfg interface {
+	GetWalletKitClient() walletrpc.WalletKitClient
+	GetChainParams() *chaincfg.Params
+}
+
+// OnChainAddrRestrictEnforcer enforces requests and responses against a
+// OnChainAddrRestrict rule.
+type OnChainAddrRestrictEnforcer struct {
+	cfg onChainAddrRestrictCfg
+	*OnChainAddrRestrict
+
+	allowMap map[string]bool
+}
+
+// HandleRequest checks the validity of a request using the OnChainAddrRestrict
+// rpcmiddleware.RoundTripCheckers.
+//
+// NOTE: this is part of the Enforcer interface.
+func (o *OnChainAddrRestrictEnforcer) HandleRequest(ctx context.Context,
+	uri string, msg proto.Message) (proto.Message, error) {
+
+	checkers := o.checkers()
+	if checkers == nil {
+		return nil, nil
+	}
+
+	checker, ok := checkers[uri]
+	if !ok {
+		return nil, nil
+	}
+
+	if !checker.HandlesRequest(msg.ProtoReflect().Type()) {
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept request of type %v", uri,
+			msg.ProtoReflect().Type())
+	}
+
+	return checker.HandleRequest(ctx, msg)
+}
+
+// HandleResponse handles a response using the OnChainAddrRestrict
+// rpcmiddleware.RoundTripCheckers.
+//
+// NOTE: this is part of the Enforcer interface.
+func (o *OnChainAddrRestrictEnforcer) HandleResponse(ctx context.Context,
+	uri string, msg proto.Message) (proto.Message, error) {
+
+	checkers := o.checkers()
+	if checkers == nil {
+		return nil, nil
+	}
+
+	checker, ok := checkers[uri]
+	if !ok {
+		return nil, nil
+	}
+
+	if !checker.HandlesResponse(msg.ProtoReflect().Type()) {
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept response of type %v", uri,
+			msg.ProtoReflect().Type())
+	}
+
+	return checker.HandleResponse(ctx, msg)
+}
+
+// HandleErrorResponse handles and possible alters an error. This is a noop for
+// the OnChainAddrRestrict rule.
+//
+// NOTE: this is part of the Enforcer interface.
+func (o *OnChainAddrRestrictEnforcer) HandleErrorResponse(_ context.Context,
+	_ string, _ error) (error, error) {
+
+	return nil, nil
+}
+
+// checkers returns a map of URI to rpcmiddleware.RoundTripChecker which define
+// how the URI should be handled.
+func (o *OnChainAddrRestrictEnforcer) checkers() map[string]mid.RoundTripChecker {
+	return map[string]mid.RoundTripChecker{
+		"/lnrpc.Lightning/SendCoins": mid.NewRequestChecker(
+			&lnrpc.SendCoinsRequest{},
+			&lnrpc.SendCoinsResponse{},
+			func(ctx context.Context,
+				r *lnrpc.SendCoinsRequest) error {
+
+				return o.checkAddrs(ctx, []string{r.GetAddr()})
+			},
+		),
+		"/lnrpc.Lightning/SendMany": mid.NewRequestChecker(
+			&lnrpc.SendManyRequest{},
+			&lnrpc.SendManyResponse{},
+			func(ctx context.Context,
+				r *lnrpc.SendManyRequest) error {
+
+				addrs := make([]string, 0, len(r.AddrToAmount))
+				for addr := range r.AddrToAmount {
+					addrs = append(addrs, addr)
+				}
+
+				return o.checkAddrs(ctx, addrs)
+			},
+		),
+		"/walletrpc.WalletKit/FundPsbt": mid.NewRequestChecker(
+			&walletrpc.FundPsbtRequest{},
+			&walletrpc.FundPsbtResponse{},
+			func(ctx context.Context,
+				r *walletrpc.FundPsbtRequest) error {
+
+				addrs, err := o.fundPsbtAddrs(r)
+				if err != nil {
+					return err
+				}
+
+				return o.checkAddrs(ctx, addrs)
+			},
+		),
+		"/walletrpc.WalletKit/SendOutputs": mid.NewRequestChecker(
+			&walletrpc.SendOutputsRequest{},
+			&walletrpc.SendOutputsResponse{},
+			func(ctx context.Context,
+				r *walletrpc.SendOutputsRequest) error {
+
+				addrs := make([]string, len(r.Outputs))
+				for i, out := range r.Outputs {
+					addr, err := o.scriptToAddr(
+						out.PkScript,
+					)
+					if err != nil {
+						return err
+					}
+
+					addrs[i] = addr
+				}
+
+				return o.checkAddrs(ctx, addrs)
+			},
+		),
+	}
+}
+
+// fundPsbtAddrs extracts all the destination addresses from the template of
+// the given FundPsbt request.
+func (o *OnChainAddrRestrictEnforcer) fundPsbtAddrs(
+	r *walletrpc.FundPsbtRequest) ([]string, error) {
+
+	switch t := r.Template.(type) {
+	case *walletrpc.FundPsbtRequest_Raw:
+		addrs := make([]string, 0, len(t.Raw.GetOutputs()))
+		for addr := range t.Raw.GetOutputs() {
+			addrs = append(addrs, addr)
+		}
+
+		return addrs, nil
+
+	case *walletrpc.FundPsbtRequest_Psbt:
+		packet, err := psbt.NewFromRawBytes(
+			bytes.NewReader(t.Psbt), false,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse PSBT: %v", err)
+		}
+
+		addrs := make([]string, len(packet.UnsignedTx.TxOut))
+		for i, out := range packet.UnsignedTx.TxOut {
+			addr, err := o.scriptToAddr(out.PkScript)
+			if err != nil {
+				return nil, err
+			}
+
+			addrs[i] = addr
+		}
+
+		return addrs, nil
+
+	default:
+		return nil, fmt.Errorf("unknown FundPsbt template type %T", t)
+	}
+}
+
+// scriptToAddr converts the given output script to the address it pays to.
+func (o *OnChainAddrRestrictEnforcer) scriptToAddr(pkScript []byte) (string,
+	error) {
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		pkScript, o.cfg.GetChainParams(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if len(addrs) != 1 {
+		return "", fmt.Errorf("unable to determine the destination " +
+			"address of output script")
+	}
+
+	return addrs[0].EncodeAddress(), nil
+}
+
+// checkAddrs ensures that each of the given addresses is either in the
+// allow-list or, if allowed, belongs to the internal lnd wallet.
+func (o *OnChainAddrRestrictEnforcer) checkAddrs(ctx context.Context,
+	addrs []string) error {
+
+	var walletAddrs map[string]bool
+	for _, addr := range addrs {
+		normalised, err := normaliseAddr(addr, o.cfg.GetChainParams())
+		if err != nil {
+			return err
+		}
+
+		if o.allowMap[normalised] {
+			continue
+		}
+
+		if !o.InternalWallet {
+			return fmt.Errorf("address %s is not in the on-chain "+
+				"address allow list", addr)
+		}
+
+		// Only fetch the wallet addresses once we actually need them
+		// and then re-use them for the rest of the request.
+		if walletAddrs == nil {
+			walletAddrs, err = o.listWalletAddrs(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !walletAddrs[normalised] {
+			return fmt.Errorf("address %s is neither in the "+
+				"on-chain address allow list nor does it "+
+				"belong to the internal wallet", addr)
+		}
+	}
+
+	return nil
+}
+
+// listWalletAddrs fetches all the addresses of the default accounts of the
+// internal lnd wallet.
+func (o *OnChainAddrRestrictEnforcer) listWalletAddrs(
+	ctx context.Context) (map[string]bool, error) {
+
+	resp, err := o.cfg.GetWalletKitClient().ListAddresses(
+		ctx, &walletrpc.ListAddressesRequest{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not list wallet addresses: %v",
+			err)
+	}
+
+	addrs := make(map[string]bool)
+	for _, account := range resp.AccountWithAddresses {
+		for _, addr := range account.Addresses {
+			normalised, err := normaliseAddr(
+				addr.Address, o.cfg.GetChainParams(),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			addrs[normalised] = true
+		}
+	}
+
+	return addrs, nil
+}
+
+// normaliseAddr decodes the given address for the given network and returns
+// its canonical encoding.
+func normaliseAddr(addr string, params *chaincfg.Params) (string, error) {
+	a, err := btcutil.DecodeAddress(addr, params)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %v", addr, err)
+	}
+
+	if !a.IsForNet(params) {
+		return "", fmt.Errorf("address %s is not for network %s", addr,
+			params.Name)
+	}
+
+	return a.EncodeAddress(), nil
+}
+
+// OnChainAddrRestrict is a rule that restricts the on-chain addresses that
+// coins may be sent to.
+type OnChainAddrRestrict struct {
+	// AllowList is a list of on-chain addresses that coins may be sent
+	// to.
+	AllowList []string `json:"addr_allow_list"`
+
+	// InternalWallet indicates that coins may also be sent to any address
+	// belonging to the internal lnd wallet.
+	InternalWallet bool `json:"internal_wallet"`
+}
+
+// VerifySane checks that the value of the values is ok given the min and max
+// allowed values. This is a noop for the OnChainAddrRestrict rule.
+//
+// NOTE: this is part of the Values interface.
+func (o *OnChainAddrRestrict) VerifySane(_, _ Values) error {
+	return nil
+}
+
+// RuleName returns the name of the rule that these values are to be used with.
+//
+// NOTE: this is part of the Values interface.
+func (o *OnChainAddrRestrict) RuleName() string {
+	return OnChainAddrRestrictName
+}
+
+// ToProto converts the rule Values to the litrpc counterpart.
+//
+// NOTE: this is part of the Values interface.
+func (o *OnChainAddrRestrict) ToProto() *litrpc.RuleValue {
+	return &litrpc.RuleValue{
+		Value: &litrpc.RuleValue_OnchainAddrRestrict{
+			OnchainAddrRestrict: &litrpc.OnChainAddrRestrict{
+				AllowedAddrs:   o.AllowList,
+				InternalWallet: o.InternalWallet,
+			},
+		},
+	}
+}
+
+// PseudoToReal assumes that the allow-list contains pseudo addresses and uses
+// these to check the privacy map db for the corresponding real addresses. It
+// constructs a new OnChainAddrRestrict instance with these real addresses.
+//
+// NOTE: this is part of the Values interface.
+func (o *OnChainAddrRestrict) PseudoToReal(db firewalldb.PrivacyMapDB) (Values,
+	error) {
+
+	allowList := make([]string, len(o.AllowList))
+	err := db.View(func(tx firewalldb.PrivacyMapTx) error {
+		for i, addr := range o.AllowList {
+			real, err := firewalldb.RevealString(tx, addr)
+			if err != nil {
+				return err
+			}
+
+			allowList[i] = real
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OnChainAddrRestrict{
+		AllowList:      allowList,
+		InternalWallet: o.InternalWallet,
+	}, nil
+}
+
+// RealToPseudo converts all the real addresses into pseudo addresses.
+//
+// NOTE: this is part of the Values interface.
+func (o *OnChainAddrRestrict) RealToPseudo(
+	gen *firewalldb.PseudoGenerator) (Values, map[string]string, error) {
+
+	pseudoAddrs := make([]string, len(o.AllowList))
+	privMapPairs := make(map[string]string)
+	for i, addr := range o.AllowList {
+		if pseudo, ok := privMapPairs[addr]; ok {
+			pseudoAddrs[i] = pseudo
+			continue
+		}
+
+		pseudo, err := gen.Str(addr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		privMapPairs[addr] = pseudo
+		pseudoAddrs[i] = pseudo
+	}
+
+	return &OnChainAddrRestrict{
+		AllowList:      pseudoAddrs,
+		InternalWallet: o.InternalWallet,
+	}, privMapPairs, nil
+}
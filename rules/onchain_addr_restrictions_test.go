@@ -0,0 +1,220 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestOnChainAddrRestrictCheckRequest ensures that the
+// OnChainAddrRestrictEnforcer correctly accepts or denies a request.
+func TestOnChainAddrRestrictCheckRequest(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+
+	allowedAddr := newTestAddr(t, 1, params)
+	walletAddr := newTestAddr(t, 2, params)
+	otherAddr := newTestAddr(t, 3, params)
+
+	ctx := context.Background()
+	mgr := &OnChainAddrRestrictMgr{}
+	cfg := &mockOnChainAddrCfg{
+		params: params,
+		walletKit: &mockWalletKitClient{
+			addrs: []string{walletAddr.EncodeAddress()},
+		},
+	}
+
+	enf, err := mgr.NewEnforcer(cfg, &OnChainAddrRestrict{
+		AllowList: []string{allowedAddr.EncodeAddress()},
+	})
+	require.NoError(t, err)
+
+	// A request for an irrelevant URI should be allowed.
+	_, err = enf.HandleRequest(ctx, "random-URI", nil)
+	require.NoError(t, err)
+
+	// Sending to an address in the allow list is allowed.
+	_, err = enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/SendCoins", &lnrpc.SendCoinsRequest{
+			Addr: allowedAddr.EncodeAddress(),
+		},
+	)
+	require.NoError(t, err)
+
+	// Sending to a wallet address is not allowed since the rule does not
+	// allow internal wallet sends.
+	_, err = enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/SendCoins", &lnrpc.SendCoinsRequest{
+			Addr: walletAddr.EncodeAddress(),
+		},
+	)
+	require.ErrorContains(t, err, "not in the on-chain address allow list")
+
+	// A SendMany with one disallowed address should be denied.
+	_, err = enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/SendMany", &lnrpc.SendManyRequest{
+			AddrToAmount: map[string]int64{
+				allowedAddr.EncodeAddress(): 1000,
+				otherAddr.EncodeAddress():   1000,
+			},
+		},
+	)
+	require.ErrorContains(t, err, "not in the on-chain address allow list")
+
+	// Now create an enforcer that also allows sends to the internal
+	// wallet.
+	enf, err = mgr.NewEnforcer(cfg, &OnChainAddrRestrict{
+		AllowList:      []string{allowedAddr.EncodeAddress()},
+		InternalWallet: true,
+	})
+	require.NoError(t, err)
+
+	_, err = enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/SendMany", &lnrpc.SendManyRequest{
+			AddrToAmount: map[string]int64{
+				allowedAddr.EncodeAddress(): 1000,
+				walletAddr.EncodeAddress():  1000,
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	// A raw FundPsbt template is checked.
+	_, err = enf.HandleRequest(
+		ctx, "/walletrpc.WalletKit/FundPsbt",
+		&walletrpc.FundPsbtRequest{
+			Template: &walletrpc.FundPsbtRequest_Raw{
+				Raw: &walletrpc.TxTemplate{
+					Outputs: map[string]uint64{
+						otherAddr.EncodeAddress(): 1000,
+					},
+				},
+			},
+		},
+	)
+	require.ErrorContains(t, err, "nor does it belong to the internal "+
+		"wallet")
+
+	// And so are the outputs of a PSBT template.
+	otherScript, err := txscript.PayToAddrScript(otherAddr)
+	require.NoError(t, err)
+
+	walletScript, err := txscript.PayToAddrScript(walletAddr)
+	require.NoError(t, err)
+
+	packet, err := psbt.New(
+		nil, []*wire.TxOut{{Value: 1000, PkScript: walletScript}}, 2, 0,
+		nil,
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, packet.Serialize(&buf))
+
+	_, err = enf.HandleRequest(
+		ctx, "/walletrpc.WalletKit/FundPsbt",
+		&walletrpc.FundPsbtRequest{
+			Template: &walletrpc.FundPsbtRequest_Psbt{
+				Psbt: buf.Bytes(),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	// SendOutputs uses raw output scripts.
+	_, err = enf.HandleRequest(
+		ctx, "/walletrpc.WalletKit/SendOutputs",
+		&walletrpc.SendOutputsRequest{
+			Outputs: []*signrpc.TxOut{{
+				Value:    1000,
+				PkScript: otherScript,
+			}},
+		},
+	)
+	require.ErrorContains(t, err, "nor does it belong to the internal "+
+		"wallet")
+}
+
+// TestOnChainAddrRestrictPrivacy tests that the pseudo and real addresses of
+// the OnChainAddrRestrict values are correctly converted.
+func TestOnChainAddrRestrictPrivacy(t *testing.T) {
+	addr := newTestAddr(t, 1, &chaincfg.RegressionNetParams)
+
+	values := &OnChainAddrRestrict{
+		AllowList: []string{
+			addr.EncodeAddress(), addr.EncodeAddress(),
+		},
+		InternalWallet: true,
+	}
+
+	pseudo, pairs, err := values.RealToPseudo(nil)
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+
+	pseudoValues := pseudo.(*OnChainAddrRestrict)
+	require.True(t, pseudoValues.InternalWallet)
+	require.Len(t, pseudoValues.AllowList, 2)
+	require.Equal(t, pseudoValues.AllowList[0], pseudoValues.AllowList[1])
+	require.Equal(t, pairs[addr.EncodeAddress()], pseudoValues.AllowList[0])
+}
+
+// newTestAddr creates a deterministic P2WKH address for the given network.
+func newTestAddr(t *testing.T, seed byte,
+	params *chaincfg.Params) btcutil.Address {
+
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(
+		bytes.Repeat([]byte{seed}, 20), params,
+	)
+	require.NoError(t, err)
+
+	return addr
+}
+
+type mockOnChainAddrCfg struct {
+	Config
+
+	params    *chaincfg.Params
+	walletKit walletrpc.WalletKitClient
+}
+
+func (m *mockOnChainAddrCfg) GetChainParams() *chaincfg.Params {
+	return m.params
+}
+
+func (m *mockOnChainAddrCfg) GetWalletKitClient() walletrpc.WalletKitClient {
+	return m.walletKit
+}
+
+type mockWalletKitClient struct {
+	walletrpc.WalletKitClient
+
+	addrs []string
+}
+
+func (m *mockWalletKitClient) ListAddresses(_ context.Context,
+	_ *walletrpc.ListAddressesRequest, _ ...grpc.CallOption) (
+	*walletrpc.ListAddressesResponse, error) {
+
+	addrs := make([]*walletrpc.AddressProperty, len(m.addrs))
+	for i, addr := range m.addrs {
+		addrs[i] = &walletrpc.AddressProperty{Address: addr}
+	}
+
+	return &walletrpc.ListAddressesResponse{
+		AccountWithAddresses: []*walletrpc.AccountWithAddresses{{
+			Name:      "default",
+			Addresses: addrs,
+		}},
+	}, nil
+}
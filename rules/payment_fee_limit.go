@@ -0,0 +1,489 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// Compile-time checks to ensure that PaymentFeeLimitMgr,
+	// PaymentFeeLimit and PaymentFeeLimitEnforcer implement the appropriate
+	// Manager, Enforcer and Values interface.
+	_ Manager  = (*PaymentFeeLimitMgr)(nil)
+	_ Enforcer = (*PaymentFeeLimitEnforcer)(nil)
+	_ Values   = (*PaymentFeeLimit)(nil)
+)
+
+const (
+	// PaymentFeeLimitName is the string identifier of the PaymentFeeLimit
+	// rule.
+	PaymentFeeLimitName = "payment-fee-limit"
+
+	// sendPaymentSyncURI is the URI of lnd's synchronous payment call.
+	sendPaymentSyncURI = "/lnrpc.Lightning/SendPaymentSync"
+
+	// sendPaymentURI is the URI of lnd's streaming payment call.
+	sendPaymentURI = "/lnrpc.Lightning/SendPayment"
+
+	// sendPaymentV2URI is the URI of the router's payment call.
+	sendPaymentV2URI = "/routerrpc.Router/SendPaymentV2"
+
+	// routerSendPaymentURI is the URI of the router's deprecated payment
+	// call.
+	routerSendPaymentURI = "/routerrpc.Router/SendPayment"
+
+	// sendToRouteSyncURI is the URI of lnd's synchronous call that pays
+	// along a given route.
+	sendToRouteSyncURI = "/lnrpc.Lightning/SendToRouteSync"
+
+	// sendToRouteURI is the URI of lnd's streaming call that pays along a
+	// given route.
+	sendToRouteURI = "/lnrpc.Lightning/SendToRoute"
+
+	// routerSendToRouteURI is the URI of the router's deprecated call that
+	// pays along a given route.
+	routerSendToRouteURI = "/routerrpc.Router/SendToRoute"
+
+	// routerSendToRouteV2URI is the URI of the router's call that pays
+	// along a given route.
+	routerSendToRouteV2URI = "/routerrpc.Router/SendToRouteV2"
+)
+
+// PaymentFeeLimitMgr manages the PaymentFeeLimit rule.
+type PaymentFeeLimitMgr struct{}
+
+// Stop cleans up the resources held by the manager.
+//
+// NOTE: This is part of the Manager interface.
+func (p *PaymentFeeLimitMgr) Stop() error {
+	return nil
+}
+
+// NewEnforcer constructs a new PaymentFeeLimit rule enforcer using the passed
+// values and config.
+//
+// NOTE: This is part of the Manager interface.
+func (p *PaymentFeeLimitMgr) NewEnforcer(cfg Config, values Values) (Enforcer,
+	error) {
+
+	limit, ok := values.(*PaymentFeeLimit)
+	if !ok {
+		return nil, fmt.Errorf("values must be of type "+
+			"PaymentFeeLimit, got %T", values)
+	}
+
+	return &PaymentFeeLimitEnforcer{
+		paymentFeeLimitConfig: cfg,
+		PaymentFeeLimit:       limit,
+	}, nil
+}
+
+// NewValueFromProto converts the given proto value into a PaymentFeeLimit
+// Value object.
+//
+// NOTE: This is part of the Manager interface.
+func (p *PaymentFeeLimitMgr) NewValueFromProto(v *litrpc.RuleValue) (Values,
+	error) {
+
+	rv, ok := v.Value.(*litrpc.RuleValue_PaymentFeeLimit)
+	if !ok {
+		return nil, fmt.Errorf("incorrect RuleValue type")
+	}
+
+	limit := rv.PaymentFeeLimit
+	if limit.DefaultFeePpm == 0 && limit.MinFeeMsat == 0 &&
+		limit.MaxFeeMsat == 0 {
+
+		return nil, fmt.Errorf("at least one of the default fee " +
+			"rate, the minimum fee and the maximum fee must be set")
+	}
+
+	if limit.MaxFeeMsat != 0 && limit.MinFeeMsat > limit.MaxFeeMsat {
+		return nil, fmt.Errorf("the minimum fee can't be larger than " +
+			"the maximum fee")
+	}
+
+	return &PaymentFeeLimit{
+		DefaultFeePpm: limit.DefaultFeePpm,
+		MinFeeMsat:    limit.MinFeeMsat,
+		MaxFeeMsat:    limit.MaxFeeMsat,
+	}, nil
+}
+
+// EmptyValue returns a new PaymentFeeLimit instance.
+//
+// NOTE: This is part of the Manager interface.
+func (p *PaymentFeeLimitMgr) EmptyValue() Values {
+	return &PaymentFeeLimit{}
+}
+
+// paymentFeeLimitConfig is the config required by PaymentFeeLimitMgr. It can
+// be derived from the main rules Config struct.
+type paymentFeeLimitConfig interface {
+	GetChainParams() *chaincfg.Params
+}
+
+// PaymentFeeLimitEnforcer enforces requests against a PaymentFeeLimit rule.
+type PaymentFeeLimitEnforcer struct {
+	paymentFeeLimitConfig
+	*PaymentFeeLimit
+}
+
+// HandleRequest sets the default fee limit on payment requests that don't
+// specify one and lowers fee limits that exceed the maximum. Payments along a
+// given route are rejected if the fee of the route exceeds the maximum.
+//
+// NOTE: this is part of the Enforcer interface.
+func (p *PaymentFeeLimitEnforcer) HandleRequest(ctx context.Context,
+	uri string, msg proto.Message) (proto.Message, error) {
+
+	checker, ok := p.checkers()[uri]
+	if !ok {
+		return nil, nil
+	}
+
+	if !checker.HandlesRequest(msg.ProtoReflect().Type()) {
+		return nil, fmt.Errorf("invalid implementation, checker for "+
+			"URI %s does not accept request of type %v", uri,
+			msg.ProtoReflect().Type())
+	}
+
+	return checker.HandleRequest(ctx, msg)
+}
+
+// HandleResponse handles and possible alters a response. This is a noop for
+// the PaymentFeeLimit rule.
+//
+// NOTE: this is part of the Enforcer interface.
+func (p *PaymentFeeLimitEnforcer) HandleResponse(_ context.Context, _ string,
+	_ proto.Message) (proto.Message, error) {
+
+	return nil, nil
+}
+
+// HandleErrorResponse handles and possible alters an error. This is a noop for
+// the PaymentFeeLimit rule.
+//
+// NOTE: this is part of the Enforcer interface.
+func (p *PaymentFeeLimitEnforcer) HandleErrorResponse(_ context.Context,
+	_ string, _ error) (error, error) {
+
+	return nil, nil
+}
+
+// checkers returns a map of URI to rpcmiddleware.RoundTripChecker which define
+// how the URI should be handled.
+func (p *PaymentFeeLimitEnforcer) checkers() map[string]mid.RoundTripChecker {
+	sendRequestRewriter := func(_ context.Context,
+		r *lnrpc.SendRequest) (proto.Message, error) {
+
+		return p.rewriteSendRequest(r)
+	}
+	sendPaymentRequestRewriter := func(_ context.Context,
+		r *routerrpc.SendPaymentRequest) (proto.Message, error) {
+
+		return p.rewriteSendPaymentRequest(r)
+	}
+
+	return map[string]mid.RoundTripChecker{
+		sendPaymentSyncURI: mid.NewRequestRewriter(
+			&lnrpc.SendRequest{}, &lnrpc.SendResponse{},
+			sendRequestRewriter,
+		),
+		sendPaymentURI: mid.NewRequestRewriter(
+			&lnrpc.SendRequest{}, &lnrpc.SendResponse{},
+			sendRequestRewriter,
+		),
+		sendPaymentV2URI: mid.NewRequestRewriter(
+			&routerrpc.SendPaymentRequest{}, &lnrpc.Payment{},
+			sendPaymentRequestRewriter,
+		),
+		routerSendPaymentURI: mid.NewRequestRewriter(
+			&routerrpc.SendPaymentRequest{},
+			&routerrpc.PaymentStatus{},
+			sendPaymentRequestRewriter,
+		),
+		sendToRouteSyncURI: mid.NewRequestChecker(
+			&lnrpc.SendToRouteRequest{}, &lnrpc.SendResponse{},
+			func(_ context.Context,
+				r *lnrpc.SendToRouteRequest) error {
+
+				return p.checkRoute(r.Route)
+			},
+		),
+		sendToRouteURI: mid.NewRequestChecker(
+			&lnrpc.SendToRouteRequest{}, &lnrpc.SendResponse{},
+			func(_ context.Context,
+				r *lnrpc.SendToRouteRequest) error {
+
+				return p.checkRoute(r.Route)
+			},
+		),
+		routerSendToRouteURI: mid.NewRequestChecker(
+			&routerrpc.SendToRouteRequest{},
+			&routerrpc.SendToRouteResponse{},
+			func(_ context.Context,
+				r *routerrpc.SendToRouteRequest) error {
+
+				return p.checkRoute(r.Route)
+			},
+		),
+		routerSendToRouteV2URI: mid.NewRequestChecker(
+			&routerrpc.SendToRouteRequest{}, &lnrpc.HTLCAttempt{},
+			func(_ context.Context,
+				r *routerrpc.SendToRouteRequest) error {
+
+				return p.checkRoute(r.Route)
+			},
+		),
+	}
+}
+
+// rewriteSendRequest sets the fee limit of a SendPaymentSync request. If the
+// request doesn't have a fee limit, lnd would use up to the full amount of
+// small payments as the fee, so the default fee limit is set instead. Fee
+// limits that exceed the maximum are lowered to it. Nil is returned if the
+// request doesn't need to be changed.
+func (p *PaymentFeeLimitEnforcer) rewriteSendRequest(
+	r *lnrpc.SendRequest) (proto.Message, error) {
+
+	amt, err := p.paymentAmt(r.Amt, r.AmtMsat, r.PaymentRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		feeLimit   = lnrpc.CalculateFeeLimit(r.FeeLimit, amt)
+		setDefault = r.FeeLimit.GetLimit() == nil && p.hasDefault()
+	)
+	if setDefault {
+		feeLimit = p.defaultFeeLimit(amt)
+	}
+
+	feeLimit, capped := p.capFeeLimit(feeLimit)
+	if !capped && !setDefault {
+		return nil, nil
+	}
+
+	r.FeeLimit = &lnrpc.FeeLimit{
+		Limit: &lnrpc.FeeLimit_FixedMsat{
+			FixedMsat: int64(feeLimit),
+		},
+	}
+
+	return r, nil
+}
+
+// rewriteSendPaymentRequest lowers the fee limit of a SendPaymentV2 request to
+// the maximum. Unlike SendPaymentSync, a request without a fee limit only
+// allows routes without any fees, so such a request is left as it is. Nil is
+// returned if the request doesn't need to be changed.
+func (p *PaymentFeeLimitEnforcer) rewriteSendPaymentRequest(
+	r *routerrpc.SendPaymentRequest) (proto.Message, error) {
+
+	feeLimit, err := lnrpc.UnmarshallAmt(r.FeeLimitSat, r.FeeLimitMsat)
+	if err != nil {
+		return nil, err
+	}
+
+	feeLimit, capped := p.capFeeLimit(feeLimit)
+	if !capped {
+		return nil, nil
+	}
+
+	r.FeeLimitSat = 0
+	r.FeeLimitMsat = int64(feeLimit)
+
+	return r, nil
+}
+
+// checkRoute makes sure the fee of a route a payment is sent along doesn't
+// exceed the maximum. The route is already fixed, so its fee can't be lowered.
+func (p *PaymentFeeLimitEnforcer) checkRoute(r *lnrpc.Route) error {
+	fee := routeFee(r)
+	if p.MaxFeeMsat != 0 && fee > lnwire.MilliSatoshi(p.MaxFeeMsat) {
+		return fmt.Errorf("fee of %v of the route exceeds the maximum "+
+			"fee of %v", fee, lnwire.MilliSatoshi(p.MaxFeeMsat))
+	}
+
+	return nil
+}
+
+// routeFee returns the fee of a route the same way lnd does, from the total
+// amount of the route and the amount that is forwarded by its last hop. The
+// total fee of the route is ignored, since lnd doesn't use it either.
+func routeFee(r *lnrpc.Route) lnwire.MilliSatoshi {
+	if r == nil || len(r.Hops) == 0 {
+		return 0
+	}
+
+	lastHop := r.Hops[len(r.Hops)-1]
+	if lastHop.AmtToForwardMsat >= r.TotalAmtMsat {
+		return 0
+	}
+
+	return lnwire.MilliSatoshi(r.TotalAmtMsat - lastHop.AmtToForwardMsat)
+}
+
+// paymentAmt returns the amount of a payment. Like lnd, the amount of the
+// payment request is used if the request doesn't specify one.
+func (p *PaymentFeeLimitEnforcer) paymentAmt(amt, amtMsat int64,
+	payReq string) (lnwire.MilliSatoshi, error) {
+
+	sendAmt, err := lnrpc.UnmarshallAmt(amt, amtMsat)
+	if err != nil {
+		return 0, err
+	}
+
+	if sendAmt != 0 || payReq == "" {
+		return sendAmt, nil
+	}
+
+	invoice, err := zpay32.Decode(payReq, p.GetChainParams())
+	if err != nil {
+		return 0, fmt.Errorf("unable to decode payment request: %v",
+			err)
+	}
+
+	if invoice.MilliSat == nil {
+		return 0, nil
+	}
+
+	return *invoice.MilliSat, nil
+}
+
+// PaymentFeeLimit is a rule that sets a default fee limit on payments that
+// don't specify one and caps the fee limit of all payments.
+type PaymentFeeLimit struct {
+	// DefaultFeePpm is the fee limit in parts per million of the payment
+	// amount that is set on payments without a fee limit.
+	DefaultFeePpm uint32 `json:"default_fee_ppm"`
+
+	// MinFeeMsat is the lowest fee limit in msat that is set on payments
+	// without a fee limit, so that small payments can still be routed.
+	MinFeeMsat uint64 `json:"min_fee_msat"`
+
+	// MaxFeeMsat is the highest fee limit in msat that any payment can
+	// have. Larger fee limits are lowered to it. If zero, the fee limit is
+	// not capped.
+	MaxFeeMsat uint64 `json:"max_fee_msat"`
+}
+
+// hasDefault returns true if the rule sets a default fee limit. If it doesn't,
+// lnd's default fee limit is used and only capped.
+func (p *PaymentFeeLimit) hasDefault() bool {
+	return p.DefaultFeePpm != 0 || p.MinFeeMsat != 0
+}
+
+// defaultFeeLimit returns the fee limit for a payment of the given amount
+// without a fee limit.
+func (p *PaymentFeeLimit) defaultFeeLimit(
+	amt lnwire.MilliSatoshi) lnwire.MilliSatoshi {
+
+	feeLimit := amt * lnwire.MilliSatoshi(p.DefaultFeePpm) / 1_000_000
+	if feeLimit < lnwire.MilliSatoshi(p.MinFeeMsat) {
+		feeLimit = lnwire.MilliSatoshi(p.MinFeeMsat)
+	}
+
+	return feeLimit
+}
+
+// capFeeLimit lowers the given fee limit to the maximum fee limit. It returns
+// whether the fee limit was lowered.
+func (p *PaymentFeeLimit) capFeeLimit(
+	feeLimit lnwire.MilliSatoshi) (lnwire.MilliSatoshi, bool) {
+
+	maxFee := lnwire.MilliSatoshi(p.MaxFeeMsat)
+	if p.MaxFeeMsat == 0 || feeLimit <= maxFee {
+		return feeLimit, false
+	}
+
+	return maxFee, true
+}
+
+// VerifySane checks that the value of the values is ok given the min and max
+// allowed values.
+//
+// NOTE: this is part of the Values interface.
+func (p *PaymentFeeLimit) VerifySane(minVal, maxVal Values) error {
+	if _, ok := minVal.(*PaymentFeeLimit); !ok {
+		return fmt.Errorf("min value is not of type PaymentFeeLimit")
+	}
+
+	maxLimit, ok := maxVal.(*PaymentFeeLimit)
+	if !ok {
+		return fmt.Errorf("max value is not of type PaymentFeeLimit")
+	}
+
+	if maxLimit.DefaultFeePpm != 0 &&
+		p.DefaultFeePpm > maxLimit.DefaultFeePpm {
+
+		return fmt.Errorf("invalid default fee rate")
+	}
+
+	if maxLimit.MinFeeMsat != 0 && p.MinFeeMsat > maxLimit.MinFeeMsat {
+		return fmt.Errorf("invalid minimum fee")
+	}
+
+	if maxLimit.MaxFeeMsat != 0 && (p.MaxFeeMsat == 0 ||
+		p.MaxFeeMsat > maxLimit.MaxFeeMsat) {
+
+		return fmt.Errorf("invalid maximum fee")
+	}
+
+	return nil
+}
+
+// RuleName returns the name of the rule that these values are to be used with.
+//
+// NOTE: this is part of the Values interface.
+func (p *PaymentFeeLimit) RuleName() string {
+	return PaymentFeeLimitName
+}
+
+// ToProto converts the rule Values to the litrpc counterpart.
+//
+// NOTE: this is part of the Values interface.
+func (p *PaymentFeeLimit) ToProto() *litrpc.RuleValue {
+	return &litrpc.RuleValue{
+		Value: &litrpc.RuleValue_PaymentFeeLimit{
+			PaymentFeeLimit: &litrpc.PaymentFeeLimit{
+				DefaultFeePpm: p.DefaultFeePpm,
+				MinFeeMsat:    p.MinFeeMsat,
+				MaxFeeMsat:    p.MaxFeeMsat,
+			},
+		},
+	}
+}
+
+// PseudoToReal attempts to convert any appropriate pseudo fields in the rule
+// Values to their corresponding real values. It uses the passed PrivacyMapDB to
+// find the real values. This is a no-op for the PaymentFeeLimit rule.
+//
+// NOTE: this is part of the Values interface.
+func (p *PaymentFeeLimit) PseudoToReal(_ firewalldb.PrivacyMapDB) (Values,
+	error) {
+
+	return p, nil
+}
+
+// RealToPseudo converts the rule Values to a new one that uses pseudo keys,
+// channel IDs, channel points etc. It returns a map of real to pseudo strings
+// that should be persisted. This is a no-op for the PaymentFeeLimit rule.
+//
+// NOTE: this is part of the Values interface.
+func (p *PaymentFeeLimit) RealToPseudo(_ *firewalldb.PseudoGenerator) (Values,
+	map[string]string, error) {
+
+	return p, nil, nil
+}
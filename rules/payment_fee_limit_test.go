@@ -0,0 +1,306 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// mockPaymentFeeLimitCfg is used to mock the config backend given to the
+// PaymentFeeLimitMgr values during testing.
+type mockPaymentFeeLimitCfg struct{}
+
+var _ paymentFeeLimitConfig = (*mockPaymentFeeLimitCfg)(nil)
+
+func (m *mockPaymentFeeLimitCfg) GetChainParams() *chaincfg.Params {
+	return &chaincfg.RegressionNetParams
+}
+
+// fixedMsat returns a fee limit of the given fixed amount in msat.
+func fixedMsat(amt int64) *lnrpc.FeeLimit {
+	return &lnrpc.FeeLimit{
+		Limit: &lnrpc.FeeLimit_FixedMsat{
+			FixedMsat: amt,
+		},
+	}
+}
+
+// TestPaymentFeeLimitSendPaymentSync tests that SendPaymentSync requests
+// without a fee limit get the default fee limit and that fee limits above the
+// maximum are lowered.
+func TestPaymentFeeLimitSendPaymentSync(t *testing.T) {
+	ctx := context.Background()
+	enf := &PaymentFeeLimitEnforcer{
+		paymentFeeLimitConfig: &mockPaymentFeeLimitCfg{},
+		PaymentFeeLimit: &PaymentFeeLimit{
+			DefaultFeePpm: 5_000,
+			MinFeeMsat:    10_000,
+			MaxFeeMsat:    100_000,
+		},
+	}
+
+	send := func(r *lnrpc.SendRequest) *lnrpc.SendRequest {
+		t.Helper()
+
+		msg, err := enf.HandleRequest(ctx, sendPaymentSyncURI, r)
+		require.NoError(t, err)
+		if msg == nil {
+			return nil
+		}
+
+		return msg.(*lnrpc.SendRequest)
+	}
+
+	// Other calls are not affected by the rule.
+	msg, err := enf.HandleRequest(
+		ctx, "/lnrpc.Lightning/GetInfo", &lnrpc.GetInfoRequest{},
+	)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+
+	// A request without a fee limit gets the default fee rate.
+	r := send(&lnrpc.SendRequest{AmtMsat: 4_000_000})
+	require.True(t, proto.Equal(fixedMsat(20_000), r.FeeLimit))
+
+	// An empty fee limit counts as no fee limit.
+	r = send(&lnrpc.SendRequest{Amt: 4_000, FeeLimit: &lnrpc.FeeLimit{}})
+	require.True(t, proto.Equal(fixedMsat(20_000), r.FeeLimit))
+
+	// Small payments get at least the minimum fee limit and large ones
+	// at most the maximum.
+	r = send(&lnrpc.SendRequest{Amt: 100})
+	require.True(t, proto.Equal(fixedMsat(10_000), r.FeeLimit))
+
+	r = send(&lnrpc.SendRequest{Amt: 1_000_000})
+	require.True(t, proto.Equal(fixedMsat(100_000), r.FeeLimit))
+
+	// A fee limit within the maximum is kept as it is.
+	require.Nil(t, send(&lnrpc.SendRequest{
+		Amt:      1_000_000,
+		FeeLimit: fixedMsat(50_000),
+	}))
+
+	// A fee limit above the maximum is lowered, whatever its kind.
+	r = send(&lnrpc.SendRequest{
+		Amt: 1_000_000,
+		FeeLimit: &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Percent{
+				Percent: 100,
+			},
+		},
+	})
+	require.True(t, proto.Equal(fixedMsat(100_000), r.FeeLimit))
+
+	// Without a default, lnd's default fee limit is only capped.
+	enf.PaymentFeeLimit = &PaymentFeeLimit{
+		MaxFeeMsat: 100_000,
+	}
+	require.Nil(t, send(&lnrpc.SendRequest{Amt: 100}))
+
+	r = send(&lnrpc.SendRequest{Amt: 1_000_000})
+	require.True(t, proto.Equal(fixedMsat(100_000), r.FeeLimit))
+
+	// The payment request of a payment must be valid to determine its
+	// amount.
+	_, err = enf.HandleRequest(ctx, sendPaymentSyncURI, &lnrpc.SendRequest{
+		PaymentRequest: "lnbcrt1invalid",
+	})
+	require.ErrorContains(t, err, "unable to decode payment request")
+}
+
+// TestPaymentFeeLimitSendPaymentV2 tests that SendPaymentV2 requests are only
+// changed if their fee limit is above the maximum.
+func TestPaymentFeeLimitSendPaymentV2(t *testing.T) {
+	ctx := context.Background()
+	enf := &PaymentFeeLimitEnforcer{
+		paymentFeeLimitConfig: &mockPaymentFeeLimitCfg{},
+		PaymentFeeLimit: &PaymentFeeLimit{
+			DefaultFeePpm: 5_000,
+			MaxFeeMsat:    100_000,
+		},
+	}
+
+	// A request without a fee limit only uses routes without fees, so it
+	// is left as it is.
+	msg, err := enf.HandleRequest(
+		ctx, sendPaymentV2URI, &routerrpc.SendPaymentRequest{
+			Amt: 1_000_000,
+		},
+	)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+
+	msg, err = enf.HandleRequest(
+		ctx, sendPaymentV2URI, &routerrpc.SendPaymentRequest{
+			Amt:         1_000_000,
+			FeeLimitSat: 100,
+		},
+	)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+
+	// An unlimited fee limit is lowered to the maximum.
+	msg, err = enf.HandleRequest(
+		ctx, sendPaymentV2URI, &routerrpc.SendPaymentRequest{
+			Amt:         1_000_000,
+			FeeLimitSat: 1<<63 - 1,
+		},
+	)
+	require.NoError(t, err)
+
+	r := msg.(*routerrpc.SendPaymentRequest)
+	require.Zero(t, r.FeeLimitSat)
+	require.EqualValues(t, 100_000, r.FeeLimitMsat)
+}
+
+// TestPaymentFeeLimitOtherSendCalls tests that the streaming payment calls are
+// rewritten just like their synchronous counterparts and that payments along a
+// given route are rejected if the fee of the route exceeds the maximum.
+func TestPaymentFeeLimitOtherSendCalls(t *testing.T) {
+	ctx := context.Background()
+	enf := &PaymentFeeLimitEnforcer{
+		paymentFeeLimitConfig: &mockPaymentFeeLimitCfg{},
+		PaymentFeeLimit: &PaymentFeeLimit{
+			DefaultFeePpm: 5_000,
+			MaxFeeMsat:    100_000,
+		},
+	}
+
+	msg, err := enf.HandleRequest(
+		ctx, sendPaymentURI, &lnrpc.SendRequest{Amt: 1_000_000},
+	)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(
+		fixedMsat(100_000), msg.(*lnrpc.SendRequest).FeeLimit,
+	))
+
+	msg, err = enf.HandleRequest(
+		ctx, routerSendPaymentURI, &routerrpc.SendPaymentRequest{
+			Amt:         1_000_000,
+			FeeLimitSat: 1<<63 - 1,
+		},
+	)
+	require.NoError(t, err)
+	require.EqualValues(
+		t, 100_000, msg.(*routerrpc.SendPaymentRequest).FeeLimitMsat,
+	)
+
+	// The fee of a route is the difference between its total amount and
+	// the amount its last hop forwards, no matter what the route claims.
+	route := func(fee int64) *lnrpc.Route {
+		return &lnrpc.Route{
+			TotalAmtMsat: 1_000_000 + fee,
+			Hops: []*lnrpc.Hop{{
+				AmtToForwardMsat: 1_000_000,
+			}, {
+				AmtToForwardMsat: 1_000_000,
+			}},
+		}
+	}
+	cheapRoute := route(100_000)
+	expensiveRoute := route(100_001)
+	expensiveRoute.TotalFeesMsat = 1
+
+	for _, uri := range []string{sendToRouteSyncURI, sendToRouteURI} {
+		_, err = enf.HandleRequest(ctx, uri, &lnrpc.SendToRouteRequest{
+			Route: cheapRoute,
+		})
+		require.NoError(t, err)
+
+		_, err = enf.HandleRequest(ctx, uri, &lnrpc.SendToRouteRequest{
+			Route: expensiveRoute,
+		})
+		require.ErrorContains(t, err, "exceeds the maximum fee")
+	}
+
+	for _, uri := range []string{
+		routerSendToRouteURI, routerSendToRouteV2URI,
+	} {
+
+		_, err = enf.HandleRequest(
+			ctx, uri, &routerrpc.SendToRouteRequest{
+				Route: cheapRoute,
+			},
+		)
+		require.NoError(t, err)
+
+		_, err = enf.HandleRequest(
+			ctx, uri, &routerrpc.SendToRouteRequest{
+				Route: expensiveRoute,
+			},
+		)
+		require.ErrorContains(t, err, "exceeds the maximum fee")
+	}
+
+	// Without a maximum, any route is allowed.
+	enf.PaymentFeeLimit = &PaymentFeeLimit{DefaultFeePpm: 5_000}
+	_, err = enf.HandleRequest(
+		ctx, sendToRouteSyncURI, &lnrpc.SendToRouteRequest{
+			Route: expensiveRoute,
+		},
+	)
+	require.NoError(t, err)
+}
+
+// TestPaymentFeeLimitVerifySane tests that the PaymentFeeLimit VerifySane
+// method correctly checks the values against the min and max sane values.
+func TestPaymentFeeLimitVerifySane(t *testing.T) {
+	var (
+		minVal = &PaymentFeeLimit{}
+		maxVal = &PaymentFeeLimit{
+			DefaultFeePpm: 10_000,
+			MaxFeeMsat:    1_000_000,
+		}
+	)
+
+	require.NoError(t, (&PaymentFeeLimit{
+		DefaultFeePpm: 5_000,
+		MinFeeMsat:    1_000,
+		MaxFeeMsat:    500_000,
+	}).VerifySane(minVal, maxVal))
+
+	require.Error(t, (&PaymentFeeLimit{
+		DefaultFeePpm: 20_000,
+		MaxFeeMsat:    500_000,
+	}).VerifySane(minVal, maxVal))
+
+	// The fee limit must be capped if the maximum caps it.
+	require.Error(t, (&PaymentFeeLimit{
+		DefaultFeePpm: 5_000,
+	}).VerifySane(minVal, maxVal))
+
+	require.Error(t, (&PaymentFeeLimit{
+		MaxFeeMsat: 1_000_001,
+	}).VerifySane(minVal, maxVal))
+}
+
+// TestPaymentFeeLimitProto tests that the PaymentFeeLimit values survive a
+// round trip through their proto counterpart.
+func TestPaymentFeeLimitProto(t *testing.T) {
+	mgr := &PaymentFeeLimitMgr{}
+
+	limit := &PaymentFeeLimit{
+		DefaultFeePpm: 5_000,
+		MinFeeMsat:    1_000,
+		MaxFeeMsat:    500_000,
+	}
+	v, err := mgr.NewValueFromProto(limit.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, limit, v)
+
+	// A rule without any value has no effect.
+	_, err = mgr.NewValueFromProto((&PaymentFeeLimit{}).ToProto())
+	require.Error(t, err)
+
+	// The minimum fee limit can't exceed the maximum.
+	_, err = mgr.NewValueFromProto((&PaymentFeeLimit{
+		MinFeeMsat: 2_000,
+		MaxFeeMsat: 1_000,
+	}).ToProto())
+	require.Error(t, err)
+}
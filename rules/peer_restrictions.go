@@ -370,7 +370,9 @@ func (c *PeerRestrict) PseudoToReal(db firewalldb.PrivacyMapDB) (Values,
 // RealToPseudo converts all the real peer IDs into pseudo IDs.
 //
 // NOTE: this is part of the Values interface.
-func (c *PeerRestrict) RealToPseudo() (Values, map[string]string, error) {
+func (c *PeerRestrict) RealToPseudo(gen *firewalldb.PseudoGenerator) (Values,
+	map[string]string, error) {
+
 	pseudoIDs := make([]string, len(c.DenyList))
 	privMapPairs := make(map[string]string)
 	for i, id := range c.DenyList {
@@ -382,7 +384,7 @@ func (c *PeerRestrict) RealToPseudo() (Values, map[string]string, error) {
 			continue
 		}
 
-		pseudo, err := firewalldb.NewPseudoStr(len(id))
+		pseudo, err := gen.Str(id)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -114,6 +114,24 @@ func (r *RateLimitEnforcer) HandleResponse(_ context.Context, _ string,
 func (r *RateLimitEnforcer) HandleRequest(ctx context.Context, uri string,
 	_ proto.Message) (proto.Message, error) {
 
+	return nil, r.checkLimit(ctx, uri)
+}
+
+// HandleStreamAuth checks that a stream to the given URI may be established.
+// A stream is rejected right away if the rate limit for the URI has already
+// been reached. Each client message sent on the stream is then checked against
+// the rate limit by HandleRequest.
+//
+// NOTE: this is part of the StreamEnforcer interface.
+func (r *RateLimitEnforcer) HandleStreamAuth(ctx context.Context,
+	uri string) error {
+
+	return r.checkLimit(ctx, uri)
+}
+
+// checkLimit determines whether another call to the given URI would violate
+// the rate limit.
+func (r *RateLimitEnforcer) checkLimit(ctx context.Context, uri string) error {
 	// First, we need to classify if this is a read or write call.
 	read := r.isRead(uri)
 
@@ -127,7 +145,7 @@ func (r *RateLimitEnforcer) HandleRequest(ctx context.Context, uri string,
 	// Now we need to go and count all the previous read or write actions.
 	actions, err := r.GetActionsDB().ListActions(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Determine the start time of the actions window.
@@ -151,10 +169,10 @@ func (r *RateLimitEnforcer) HandleRequest(ctx context.Context, uri string,
 	}
 
 	if count >= rateLim.Iterations {
-		return nil, fmt.Errorf("too many requests received")
+		return fmt.Errorf("too many requests received")
 	}
 
-	return nil, nil
+	return nil
 }
 
 // HandleErrorResponse handles and possible alters an error. This is a noop for
@@ -277,6 +295,8 @@ func (r *RateLimit) PseudoToReal(_ firewalldb.PrivacyMapDB) (Values,
 // that should be persisted. This is a no-op for the RateLimit rule.
 //
 // NOTE: this is part of the Values interface.
-func (r *RateLimit) RealToPseudo() (Values, map[string]string, error) {
+func (r *RateLimit) RealToPseudo(_ *firewalldb.PseudoGenerator) (Values,
+	map[string]string, error) {
+
 	return r, nil, nil
 }
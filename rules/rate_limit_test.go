@@ -247,3 +247,43 @@ func (m *mockActionsDB) ListActions(_ context.Context) (
 
 	return m.actions, nil
 }
+
+// TestRateLimitStreamAuth checks that a stream is only established if the rate
+// limit hasn't been reached yet.
+func TestRateLimitStreamAuth(t *testing.T) {
+	ctx := context.Background()
+	db := &mockActionsDB{}
+	enf := &RateLimitEnforcer{
+		rateLimitConfig: &mockRateLimitCfg{
+			db: db,
+			perms: map[string][]bakery.Op{
+				"stream-uri": {{Action: "write"}},
+			},
+		},
+		RateLimit: &RateLimit{
+			WriteLimit: &Rate{
+				Iterations: 2,
+				NumHours:   1,
+			},
+			ReadLimit: &Rate{
+				Iterations: 2,
+				NumHours:   1,
+			},
+		},
+	}
+
+	var streamEnforcer StreamEnforcer = enf
+	require.NoError(t, streamEnforcer.HandleStreamAuth(ctx, "stream-uri"))
+
+	// Each message sent on the stream counts towards the limit.
+	db.addAction("stream-uri", time.Now())
+	_, err := enf.HandleRequest(ctx, "stream-uri", nil)
+	require.NoError(t, err)
+
+	db.addAction("stream-uri", time.Now())
+	_, err = enf.HandleRequest(ctx, "stream-uri", nil)
+	require.Error(t, err)
+
+	// New streams are rejected right away once the limit is reached.
+	require.Error(t, streamEnforcer.HandleStreamAuth(ctx, "stream-uri"))
+}
@@ -0,0 +1,20 @@
+package scb
+
+// Config holds all config options for the static channel backup surfacing.
+type Config struct {
+	PassphraseFile string `long:"passphrase-file" description:"The full path to a file containing the passphrase that is used to encrypt exported channel backups. If not set, the passphrase must either be provided with each export request or lnd's wallet-unlock-password-file is used in integrated mode."`
+
+	PushDir string `long:"push-dir" description:"If set, an encrypted copy of the latest channel backup is written to this directory on every channel update. This can for example be an SFTP or cloud drive mount."`
+	PushURL string `long:"push-url" description:"If set, an encrypted copy of the latest channel backup is uploaded to this URL with an HTTP PUT request on every channel update. This can for example be a pre-signed S3 or Google Cloud Storage upload URL."`
+}
+
+// DefaultConfig constructs the default channel backup Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// pushEnabled returns true if at least one remote to push the channel backups
+// to is configured.
+func (c *Config) pushEnabled() bool {
+	return c.PushDir != "" || c.PushURL != ""
+}
@@ -0,0 +1,102 @@
+package scb
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// encryptionVersion is the version of the encryption scheme that is
+	// used for exported channel backups.
+	encryptionVersion byte = 0
+
+	// saltLen is the length of the random salt that is used to derive the
+	// encryption key from the passphrase.
+	saltLen = 16
+
+	// The scrypt parameters used to derive the encryption key.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrInvalidBackup is returned if an encrypted backup blob can't be parsed.
+var ErrInvalidBackup = errors.New("invalid encrypted channel backup")
+
+// Encrypt encrypts the given plaintext with a key derived from the given
+// passphrase. The returned blob has the following format:
+//
+//	version (1 byte) || salt (16 bytes) || nonce (24 bytes) || ciphertext
+func Encrypt(plaintext, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	var salt [saltLen]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, salt[:])
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	blob := make([]byte, 0, 1+saltLen+len(nonce)+len(plaintext)+
+		aead.Overhead())
+	blob = append(blob, encryptionVersion)
+	blob = append(blob, salt[:]...)
+	blob = append(blob, nonce...)
+
+	return aead.Seal(blob, nonce, plaintext, nil), nil
+}
+
+// Decrypt decrypts a blob that was created by Encrypt using the same
+// passphrase.
+func Decrypt(blob, passphrase []byte) ([]byte, error) {
+	nonceLen := chacha20poly1305.NonceSizeX
+	if len(blob) < 1+saltLen+nonceLen || blob[0] != encryptionVersion {
+		return nil, ErrInvalidBackup
+	}
+
+	salt := blob[1 : 1+saltLen]
+	nonce := blob[1+saltLen : 1+saltLen+nonceLen]
+	ciphertext := blob[1+saltLen+nonceLen:]
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt channel backup: %w",
+			err)
+	}
+
+	return plaintext, nil
+}
+
+// newAEAD derives the encryption key from the passphrase and salt and returns
+// the XChaCha20-Poly1305 cipher for it.
+func newAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(
+		passphrase, salt, scryptN, scryptR, scryptP,
+		chacha20poly1305.KeySize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.NewX(key)
+}
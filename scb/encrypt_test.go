@@ -0,0 +1,37 @@
+package scb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncryptDecrypt tests that an encrypted backup can only be decrypted with
+// the passphrase it was encrypted with.
+func TestEncryptDecrypt(t *testing.T) {
+	plaintext := []byte("multi channel backup")
+	passphrase := []byte("correct horse battery staple")
+
+	_, err := Encrypt(plaintext, nil)
+	require.ErrorContains(t, err, "passphrase cannot be empty")
+
+	blob, err := Encrypt(plaintext, passphrase)
+	require.NoError(t, err)
+	require.NotContains(t, string(blob), string(plaintext))
+
+	// Encrypting the same plaintext twice should result in a different
+	// blob because of the random salt and nonce.
+	blob2, err := Encrypt(plaintext, passphrase)
+	require.NoError(t, err)
+	require.NotEqual(t, blob, blob2)
+
+	decrypted, err := Decrypt(blob, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	_, err = Decrypt(blob, []byte("wrong passphrase"))
+	require.ErrorContains(t, err, "unable to decrypt")
+
+	_, err = Decrypt(blob[:10], passphrase)
+	require.ErrorIs(t, err, ErrInvalidBackup)
+}
@@ -0,0 +1,290 @@
+package scb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// KeySource describes where the passphrase used to encrypt a backup came
+// from.
+type KeySource string
+
+const (
+	// KeySourceRequest means the passphrase was provided by the caller.
+	KeySourceRequest KeySource = "request"
+
+	// KeySourcePassphraseFile means the passphrase was read from the
+	// configured passphrase file.
+	KeySourcePassphraseFile KeySource = "passphrase-file"
+
+	// KeySourceWalletPassword means the lnd wallet unlock password was
+	// used as the passphrase.
+	KeySourceWalletPassword KeySource = "wallet-password"
+)
+
+// Backup is an encrypted static channel backup.
+type Backup struct {
+	// EncryptedBlob is the encrypted multi channel backup as returned by
+	// lnd.
+	EncryptedBlob []byte
+
+	// CreatedAt is the time the backup was fetched from lnd.
+	CreatedAt time.Time
+
+	// KeySource describes which passphrase was used to encrypt the blob.
+	KeySource KeySource
+}
+
+// Manager fetches static channel backups from lnd, encrypts them and
+// optionally pushes them to the configured remotes on every channel update.
+type Manager struct {
+	cfg *Config
+
+	// walletPasswordFile is the path to lnd's wallet unlock password file
+	// which is used as a fallback passphrase. It is only set if lnd runs
+	// in integrated mode.
+	walletPasswordFile string
+
+	uploaders []Uploader
+	lnd       lnrpc.LightningClient
+
+	mu          sync.Mutex
+	lastPush    time.Time
+	lastPushErr error
+
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewManager creates a new channel backup Manager.
+func NewManager(cfg *Config, walletPasswordFile string) *Manager {
+	return &Manager{
+		cfg:                cfg,
+		walletPasswordFile: walletPasswordFile,
+		uploaders:          uploadersFromConfig(cfg),
+	}
+}
+
+// Start starts the Manager. If any remotes are configured, a subscription to
+// lnd's channel backup updates is created and each new snapshot is pushed to
+// the remotes.
+func (m *Manager) Start(lnd lnrpc.LightningClient) error {
+	m.lnd = lnd
+
+	if len(m.uploaders) == 0 {
+		return nil
+	}
+
+	// Make sure that we have a passphrase to encrypt the pushed backups
+	// with, since there won't be a caller providing one.
+	if _, _, err := m.passphrase(nil); err != nil {
+		return fmt.Errorf("channel backup push is enabled but no "+
+			"passphrase is available: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	stream, err := lnd.SubscribeChannelBackups(
+		ctx, &lnrpc.ChannelBackupSubscription{},
+	)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("unable to subscribe to channel backups: %v",
+			err)
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		for {
+			snapshot, err := stream.Recv()
+			if err != nil {
+				// A cancelled context means we're shutting
+				// down.
+				if ctx.Err() == nil {
+					log.Errorf("Error in channel backup "+
+						"subscription: %v", err)
+				}
+
+				return
+			}
+
+			multi := snapshot.MultiChanBackup
+			if multi == nil {
+				continue
+			}
+
+			m.push(ctx, multi.MultiChanBackup)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the Manager.
+func (m *Manager) Stop() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+
+	return nil
+}
+
+// Export fetches the latest multi channel backup from lnd and encrypts it with
+// the given passphrase. If no passphrase is given, the configured passphrase
+// file or the lnd wallet unlock password is used.
+func (m *Manager) Export(ctx context.Context, passphrase []byte) (*Backup,
+	error) {
+
+	pass, source, err := m.passphrase(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	multi, err := m.ChannelBackup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := Encrypt(multi, pass)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt channel backup: %v",
+			err)
+	}
+
+	return &Backup{
+		EncryptedBlob: blob,
+		CreatedAt:     time.Now(),
+		KeySource:     source,
+	}, nil
+}
+
+// ChannelBackup fetches the latest multi channel backup from lnd. The backup
+// is only encrypted with lnd's own key and not with a passphrase.
+func (m *Manager) ChannelBackup(ctx context.Context) ([]byte, error) {
+	if m.lnd == nil {
+		return nil, fmt.Errorf("channel backup manager not started")
+	}
+
+	resp, err := m.lnd.ExportAllChannelBackups(
+		ctx, &lnrpc.ChanBackupExportRequest{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch channel backups: %v",
+			err)
+	}
+
+	if resp.MultiChanBackup == nil {
+		return nil, fmt.Errorf("lnd returned no multi channel backup")
+	}
+
+	return resp.MultiChanBackup.MultiChanBackup, nil
+}
+
+// LastPush returns the time of the last successful push to all remotes and
+// the error of the last push attempt, if any.
+func (m *Manager) LastPush() (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.lastPush, m.lastPushErr
+}
+
+// push encrypts the given multi channel backup and pushes it to all remotes.
+func (m *Manager) push(ctx context.Context, multi []byte) {
+	var pushErr error
+	defer func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.lastPushErr = pushErr
+		if pushErr == nil {
+			m.lastPush = time.Now()
+		}
+	}()
+
+	pass, _, err := m.passphrase(nil)
+	if err != nil {
+		pushErr = err
+		log.Errorf("Unable to get channel backup passphrase: %v", err)
+		return
+	}
+
+	blob, err := Encrypt(multi, pass)
+	if err != nil {
+		pushErr = err
+		log.Errorf("Unable to encrypt channel backup: %v", err)
+		return
+	}
+
+	for _, uploader := range m.uploaders {
+		if err := uploader.Upload(ctx, blob); err != nil {
+			pushErr = err
+			log.Errorf("Unable to push channel backup to %v: %v",
+				uploader, err)
+
+			continue
+		}
+
+		log.Debugf("Pushed channel backup to %v", uploader)
+	}
+}
+
+// passphrase returns the passphrase to encrypt a backup with and where it
+// came from. A passphrase given by the caller takes precedence over the
+// configured passphrase file which in turn takes precedence over the lnd
+// wallet unlock password.
+func (m *Manager) passphrase(override []byte) ([]byte, KeySource, error) {
+	switch {
+	case len(override) > 0:
+		return override, KeySourceRequest, nil
+
+	case m.cfg.PassphraseFile != "":
+		pass, err := ReadPassphraseFile(m.cfg.PassphraseFile)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return pass, KeySourcePassphraseFile, nil
+
+	case m.walletPasswordFile != "":
+		pass, err := ReadPassphraseFile(m.walletPasswordFile)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return pass, KeySourceWalletPassword, nil
+
+	default:
+		return nil, "", fmt.Errorf("no passphrase provided and " +
+			"neither a passphrase file nor a wallet password " +
+			"file is configured")
+	}
+}
+
+// ReadPassphraseFile reads a passphrase from the given file, stripping any
+// trailing newline characters.
+func ReadPassphraseFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(lncfg.CleanAndExpandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read passphrase file: %v",
+			err)
+	}
+
+	pass := bytes.TrimRight(content, "\r\n")
+	if len(pass) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", path)
+	}
+
+	return pass, nil
+}
@@ -0,0 +1,94 @@
+package scb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPassphrase tests that the passphrase used to encrypt a backup is chosen
+// from the correct source.
+func TestPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	passFile := filepath.Join(dir, "passphrase")
+	walletPassFile := filepath.Join(dir, "wallet-password")
+	require.NoError(t, os.WriteFile(passFile, []byte("pass\n"), 0600))
+	require.NoError(t, os.WriteFile(walletPassFile, []byte("wallet"), 0600))
+
+	// Without any source, no passphrase is available.
+	m := NewManager(&Config{}, "")
+	_, _, err := m.passphrase(nil)
+	require.ErrorContains(t, err, "no passphrase provided")
+
+	// The wallet password is the last fallback.
+	m = NewManager(&Config{}, walletPassFile)
+	pass, source, err := m.passphrase(nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("wallet"), pass)
+	require.Equal(t, KeySourceWalletPassword, source)
+
+	// The passphrase file takes precedence over the wallet password and
+	// the trailing newline is removed.
+	m = NewManager(&Config{PassphraseFile: passFile}, walletPassFile)
+	pass, source, err = m.passphrase(nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("pass"), pass)
+	require.Equal(t, KeySourcePassphraseFile, source)
+
+	// A passphrase given in the request always wins.
+	pass, source, err = m.passphrase([]byte("request"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("request"), pass)
+	require.Equal(t, KeySourceRequest, source)
+}
+
+// TestPush tests that a backup is pushed to all configured remotes.
+func TestPush(t *testing.T) {
+	dir := t.TempDir()
+	passFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, os.WriteFile(passFile, []byte("pass"), 0600))
+
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+
+			uploaded, _ = io.ReadAll(r.Body)
+		},
+	))
+	t.Cleanup(server.Close)
+
+	pushDir := filepath.Join(dir, "push")
+	m := NewManager(&Config{
+		PassphraseFile: passFile,
+		PushDir:        pushDir,
+		PushURL:        server.URL,
+	}, "")
+	require.Len(t, m.uploaders, 2)
+
+	m.push(context.Background(), []byte("multi"))
+
+	lastPush, lastErr := m.LastPush()
+	require.NoError(t, lastErr)
+	require.False(t, lastPush.IsZero())
+
+	// Both remotes should have received a blob that decrypts to the
+	// original backup.
+	blob, err := os.ReadFile(filepath.Join(pushDir, backupFileName))
+	require.NoError(t, err)
+
+	for _, b := range [][]byte{blob, uploaded} {
+		plaintext, err := Decrypt(b, []byte("pass"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("multi"), plaintext)
+	}
+}
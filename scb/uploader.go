@@ -0,0 +1,139 @@
+package scb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// backupFileName is the name of the file the encrypted channel backup
+	// is stored as when pushed to a directory.
+	backupFileName = "channel.backup.enc"
+
+	// uploadTimeout is the maximum time an upload of a backup may take.
+	uploadTimeout = time.Minute
+)
+
+// Uploader is an interface for pushing an encrypted channel backup to a
+// remote location.
+type Uploader interface {
+	// Upload pushes the given encrypted backup blob to the remote.
+	Upload(ctx context.Context, blob []byte) error
+
+	// String returns a human-readable description of the remote.
+	String() string
+}
+
+// dirUploader is an Uploader that writes the backup to a local directory.
+type dirUploader struct {
+	dir string
+}
+
+// A compile-time check to ensure that dirUploader implements the Uploader
+// interface.
+var _ Uploader = (*dirUploader)(nil)
+
+// Upload writes the given encrypted backup blob to the directory. The file is
+// first written to a temporary file and then renamed so that the directory
+// never contains a partially written backup.
+//
+// NOTE: this is part of the Uploader interface.
+func (d *dirUploader) Upload(_ context.Context, blob []byte) error {
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return err
+	}
+
+	target := filepath.Join(d.dir, backupFileName)
+	tmpFile := target + ".tmp"
+	if err := os.WriteFile(tmpFile, blob, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, target)
+}
+
+// String returns a human-readable description of the remote.
+//
+// NOTE: this is part of the Uploader interface.
+func (d *dirUploader) String() string {
+	return fmt.Sprintf("directory %s", d.dir)
+}
+
+// httpUploader is an Uploader that uploads the backup to a URL with an HTTP
+// PUT request.
+type httpUploader struct {
+	url    string
+	client *http.Client
+}
+
+// A compile-time check to ensure that httpUploader implements the Uploader
+// interface.
+var _ Uploader = (*httpUploader)(nil)
+
+// Upload uploads the given encrypted backup blob to the URL.
+//
+// NOTE: this is part of the Uploader interface.
+func (h *httpUploader) Upload(ctx context.Context, blob []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, uploadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, h.url, bytes.NewReader(blob),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode,
+			body)
+	}
+
+	return nil
+}
+
+// String returns a human-readable description of the remote. Only the host is
+// included since the URL might contain credentials.
+//
+// NOTE: this is part of the Uploader interface.
+func (h *httpUploader) String() string {
+	u, err := url.Parse(h.url)
+	if err != nil {
+		return "URL"
+	}
+
+	return fmt.Sprintf("URL host %s", u.Host)
+}
+
+// uploadersFromConfig creates the uploaders for all remotes configured in the
+// given config.
+func uploadersFromConfig(cfg *Config) []Uploader {
+	var uploaders []Uploader
+	if cfg.PushDir != "" {
+		uploaders = append(uploaders, &dirUploader{dir: cfg.PushDir})
+	}
+
+	if cfg.PushURL != "" {
+		uploaders = append(uploaders, &httpUploader{
+			url:    cfg.PushURL,
+			client: http.DefaultClient,
+		})
+	}
+
+	return uploaders
+}
@@ -0,0 +1,160 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HeaderUserAgent is the gRPC metadata key that holds the user agent
+	// of a client. It is set by the gRPC library of the client.
+	HeaderUserAgent = "user-agent"
+
+	// HeaderClientInfo is the gRPC metadata key that LNC clients can set to
+	// identify themselves beyond the user agent, for example with the name
+	// and version of an app.
+	HeaderClientInfo = "clientinfo"
+)
+
+// ErrClientMismatch is returned if a client that identifies differently than
+// the client a session is pinned to tries to use the session.
+var ErrClientMismatch = errors.New("client does not match the pinned " +
+	"client of the session")
+
+// ClientIdentity describes the client that uses a session, as reported by the
+// client itself. It can't be used to authenticate a client, a malicious client
+// can report any identity.
+type ClientIdentity struct {
+	// UserAgent is the user agent the client sent.
+	UserAgent string
+
+	// Info is the client info the client sent, if any.
+	Info string
+
+	// FirstSeen is the time the client was first seen with this identity.
+	FirstSeen time.Time
+}
+
+// matches returns true if the other identity reports the same client.
+func (c *ClientIdentity) matches(other *ClientIdentity) bool {
+	return c.UserAgent == other.UserAgent && c.Info == other.Info
+}
+
+// ClientTracker keeps track of the clients that use sessions.
+type ClientTracker interface {
+	// UpdateSessionClient sets the identity of the client that was last
+	// seen using the session with the given local public key.
+	UpdateSessionClient(key *btcec.PublicKey,
+		client *ClientIdentity) error
+}
+
+// clientGuard records the identity of the clients that call a session's gRPC
+// server and, if the session is pinned, rejects calls of other clients than
+// the first one.
+type clientGuard struct {
+	localKey *btcec.PublicKey
+	pinned   bool
+	tracker  ClientTracker
+
+	// current is the identity of the client that was last seen using the
+	// session.
+	current *ClientIdentity
+	mu      sync.Mutex
+}
+
+// newClientGuard creates a new clientGuard for the given session.
+func newClientGuard(session *Session, tracker ClientTracker) *clientGuard {
+	return &clientGuard{
+		localKey: session.LocalPublicKey,
+		pinned:   session.PinClient,
+		tracker:  tracker,
+		current:  session.Client,
+	}
+}
+
+// checkClient checks the identity of the client of a call and records it if
+// it changed.
+func (g *clientGuard) checkClient(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	client := &ClientIdentity{
+		UserAgent: firstValue(md, HeaderUserAgent),
+		Info:      firstValue(md, HeaderClientInfo),
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.current != nil && g.current.matches(client) {
+		return nil
+	}
+
+	if g.current != nil && g.pinned {
+		log.Warnf("Rejecting call of client (user agent %q, info %q) "+
+			"to session %x pinned to client (user agent %q, "+
+			"info %q)", client.UserAgent, client.Info,
+			g.localKey.SerializeCompressed(), g.current.UserAgent,
+			g.current.Info)
+
+		return status.Error(
+			codes.PermissionDenied, ErrClientMismatch.Error(),
+		)
+	}
+
+	client.FirstSeen = time.Now()
+	if err := g.tracker.UpdateSessionClient(g.localKey, client); err != nil {
+		log.Errorf("Unable to record client of session %x: %v",
+			g.localKey.SerializeCompressed(), err)
+
+		return status.Error(
+			codes.Internal, "unable to record client of session",
+		)
+	}
+	g.current = client
+
+	return nil
+}
+
+// unaryInterceptor is a gRPC unary interceptor that checks the client of each
+// call.
+func (g *clientGuard) unaryInterceptor(ctx context.Context, req interface{},
+	_ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+
+	if err := g.checkClient(ctx); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamInterceptor is a gRPC stream interceptor that checks the client of
+// each call.
+func (g *clientGuard) streamInterceptor(srv interface{},
+	ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	if err := g.checkClient(ss.Context()); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// firstValue returns the first value of the given metadata key or an empty
+// string if it isn't set.
+func firstValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
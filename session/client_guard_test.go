@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockClientTracker is a ClientTracker that keeps the last recorded client in
+// memory.
+type mockClientTracker struct {
+	client  *ClientIdentity
+	updates int
+}
+
+// UpdateSessionClient records the given client.
+func (m *mockClientTracker) UpdateSessionClient(_ *btcec.PublicKey,
+	client *ClientIdentity) error {
+
+	m.client = client
+	m.updates++
+
+	return nil
+}
+
+// clientContext returns an incoming context with the given client metadata.
+func clientContext(userAgent, info string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		HeaderUserAgent, userAgent, HeaderClientInfo, info,
+	))
+}
+
+// TestClientGuard tests that the client of a session is recorded when it
+// changes and that pinned sessions reject other clients.
+func TestClientGuard(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		pinned bool
+	}{{
+		name: "not pinned",
+	}, {
+		name:   "pinned",
+		pinned: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := &mockClientTracker{}
+			guard := newClientGuard(&Session{
+				LocalPublicKey: priv.PubKey(),
+				PinClient:      test.pinned,
+			}, tracker)
+
+			// The first client is recorded, repeated calls of the
+			// same client don't update it again.
+			ctx := clientContext("grpc-go/1.56.3", "shop-app/2.1.0")
+			require.NoError(t, guard.checkClient(ctx))
+			require.NoError(t, guard.checkClient(ctx))
+			require.Equal(t, 1, tracker.updates)
+			require.Equal(t, "grpc-go/1.56.3", tracker.client.UserAgent)
+			require.Equal(t, "shop-app/2.1.0", tracker.client.Info)
+
+			// A different client is only allowed if the session
+			// isn't pinned.
+			err := guard.checkClient(
+				clientContext("grpc-go/1.56.3", "other-app/1.0"),
+			)
+			if !test.pinned {
+				require.NoError(t, err)
+				require.Equal(t, 2, tracker.updates)
+				require.Equal(
+					t, "other-app/1.0", tracker.client.Info,
+				)
+
+				return
+			}
+
+			require.Equal(t, codes.PermissionDenied, status.Code(err))
+			require.Equal(t, 1, tracker.updates)
+			require.Equal(t, "shop-app/2.1.0", tracker.client.Info)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package session
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultExpiry is the default lifetime of a session that doesn't
+	// specify an expiry.
+	defaultExpiry = 90 * 24 * time.Hour
+)
+
+// Config holds the config options that restrict the expiry of new sessions.
+type Config struct {
+	DefaultExpiry time.Duration `long:"defaultexpiry" description:"The lifetime of new sessions that don't specify an expiry."`
+	MaxLifetime   time.Duration `long:"maxlifetime" description:"The maximum lifetime of new sessions. Longer requests are rejected unless sessions.clampexpiry is set. Set to 0 to allow any lifetime."`
+	ClampExpiry   bool          `long:"clampexpiry" description:"Shorten the expiry of new sessions that exceed sessions.maxlifetime instead of rejecting them."`
+	KeyBackend    string        `long:"keybackend" description:"Where the static LNC keys of new sessions are kept. 'local' stores them in the session database, 'lnd' derives them from lnd's wallet so that they never leave lnd or its remote signer. Existing sessions keep their keys." choice:"local" choice:"lnd"`
+}
+
+// DefaultConfig constructs the default session Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		DefaultExpiry: defaultExpiry,
+		KeyBackend:    KeyBackendLocal,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.DefaultExpiry <= 0 {
+		return fmt.Errorf("the default session expiry must be positive")
+	}
+
+	if c.MaxLifetime < 0 {
+		return fmt.Errorf("the maximum session lifetime must not be " +
+			"negative")
+	}
+
+	if c.MaxLifetime != 0 && c.DefaultExpiry > c.MaxLifetime {
+		return fmt.Errorf("the default session expiry %v exceeds the "+
+			"maximum session lifetime %v", c.DefaultExpiry,
+			c.MaxLifetime)
+	}
+
+	switch c.KeyBackend {
+	// An unset backend means the default local one.
+	case "", KeyBackendLocal, KeyBackendLnd:
+
+	default:
+		return fmt.Errorf("unknown session key backend %q, expected "+
+			"%q or %q", c.KeyBackend, KeyBackendLocal,
+			KeyBackendLnd)
+	}
+
+	return nil
+}
+
+// Expiry applies the configured policy to the requested expiry of a new
+// session. A zero expiry means that the default expiry is used. An expiry
+// beyond the maximum lifetime is either clamped or rejected.
+func (c *Config) Expiry(requested, now time.Time) (time.Time, error) {
+	if requested.IsZero() {
+		return now.Add(c.DefaultExpiry), nil
+	}
+
+	if !now.Before(requested) {
+		return time.Time{}, fmt.Errorf("expiry must be in the future")
+	}
+
+	if c.MaxLifetime == 0 {
+		return requested, nil
+	}
+
+	maxExpiry := now.Add(c.MaxLifetime)
+	if !requested.After(maxExpiry) {
+		return requested, nil
+	}
+
+	if c.ClampExpiry {
+		return maxExpiry, nil
+	}
+
+	return time.Time{}, fmt.Errorf("session lifetime of %v exceeds the "+
+		"maximum of %v", requested.Sub(now).Truncate(time.Second),
+		c.MaxLifetime)
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigExpiry tests that the expiry policy is applied to the requested
+// expiry of new sessions.
+func TestConfigExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name      string
+		cfg       *Config
+		requested time.Time
+		expected  time.Time
+		expectErr string
+	}{{
+		name:     "default expiry",
+		cfg:      DefaultConfig(),
+		expected: now.Add(defaultExpiry),
+	}, {
+		name:      "expiry in the past",
+		cfg:       DefaultConfig(),
+		requested: now.Add(-time.Second),
+		expectErr: "expiry must be in the future",
+	}, {
+		name:      "no max lifetime",
+		cfg:       DefaultConfig(),
+		requested: now.Add(10 * 365 * 24 * time.Hour),
+		expected:  now.Add(10 * 365 * 24 * time.Hour),
+	}, {
+		name: "within max lifetime",
+		cfg: &Config{
+			DefaultExpiry: time.Hour,
+			MaxLifetime:   24 * time.Hour,
+		},
+		requested: now.Add(24 * time.Hour),
+		expected:  now.Add(24 * time.Hour),
+	}, {
+		name: "exceeds max lifetime",
+		cfg: &Config{
+			DefaultExpiry: time.Hour,
+			MaxLifetime:   24 * time.Hour,
+		},
+		requested: now.Add(48 * time.Hour),
+		expectErr: "session lifetime of 48h0m0s exceeds the maximum " +
+			"of 24h0m0s",
+	}, {
+		name: "clamped to max lifetime",
+		cfg: &Config{
+			DefaultExpiry: time.Hour,
+			MaxLifetime:   24 * time.Hour,
+			ClampExpiry:   true,
+		},
+		requested: now.Add(48 * time.Hour),
+		expected:  now.Add(24 * time.Hour),
+	}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, test.cfg.Validate())
+
+			expiry, err := test.cfg.Expiry(test.requested, now)
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, expiry)
+		})
+	}
+
+	// A default expiry beyond the max lifetime is rejected.
+	cfg := &Config{
+		DefaultExpiry: 48 * time.Hour,
+		MaxLifetime:   24 * time.Hour,
+	}
+	require.ErrorContains(t, cfg.Validate(), "exceeds the maximum")
+}
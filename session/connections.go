@@ -0,0 +1,311 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	// maxStoredNonces is the maximum number of handshake nonces that are
+	// kept per session. Once it is reached, the oldest nonce is removed for
+	// every new one.
+	maxStoredNonces = 10000
+
+	// maxStoredAttempts is the maximum number of connection attempts that
+	// are kept per session. Once it is reached, the oldest attempt is
+	// removed for every new one.
+	maxStoredAttempts = 100
+
+	typeAttemptTimestamp tlv.Type = 1
+	typeAttemptAccepted  tlv.Type = 2
+	typeAttemptReason    tlv.Type = 3
+)
+
+var (
+	// connectionsBucketKey is the top level bucket that holds the handshake
+	// nonces and connection attempts of the sessions. It contains one
+	// sub-bucket per session, indexed by the session's local public key.
+	connectionsBucketKey = []byte("connections")
+
+	// noncesBucketKey is the sub-bucket of a session's connections bucket
+	// that maps the nonces of all handshakes seen for the session to the
+	// sequence number they were added with.
+	noncesBucketKey = []byte("nonces")
+
+	// nonceIndexBucketKey is the sub-bucket of a session's connections
+	// bucket that maps the sequence numbers of the nonces to the nonces,
+	// so the oldest nonces can be found.
+	nonceIndexBucketKey = []byte("nonce-index")
+
+	// attemptsBucketKey is the sub-bucket of a session's connections bucket
+	// that holds the connection attempts of the session, indexed by their
+	// sequence number. The sequence of the bucket is the total number of
+	// handshakes that were attempted for the session.
+	attemptsBucketKey = []byte("attempts")
+
+	// ErrHandshakeReplayed is returned if the first act of an LNC handshake
+	// was already seen for a session.
+	ErrHandshakeReplayed = errors.New("replayed handshake")
+)
+
+// ConnectionAttempt is a record of an LNC client trying to connect to a
+// session.
+type ConnectionAttempt struct {
+	// Timestamp is the time the handshake of the attempt ended.
+	Timestamp time.Time
+
+	// Accepted is true if the handshake succeeded.
+	Accepted bool
+
+	// Reason is the reason the attempt was rejected. It is empty for
+	// accepted attempts.
+	Reason string
+}
+
+// CheckHandshakeNonce records the nonce of a new handshake for the session
+// with the given local public key. ErrHandshakeReplayed is returned if the
+// nonce was already seen for the session.
+func (db *DB) CheckHandshakeNonce(key *btcec.PublicKey, nonce [32]byte) error {
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+		connBucket, err := sessionConnectionsBucket(tx, key)
+		if err != nil {
+			return err
+		}
+
+		nonces, err := connBucket.CreateBucketIfNotExists(
+			noncesBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		if nonces.Get(nonce[:]) != nil {
+			return ErrHandshakeReplayed
+		}
+
+		index, err := connBucket.CreateBucketIfNotExists(
+			nonceIndexBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		seq, err := index.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], seq)
+
+		if err := nonces.Put(nonce[:], seqBytes[:]); err != nil {
+			return err
+		}
+		if err := index.Put(seqBytes[:], nonce[:]); err != nil {
+			return err
+		}
+
+		// Remove the oldest nonce if we're above the limit now.
+		oldestSeq, oldestNonce := index.ReadCursor().First()
+		if seq-byteOrder.Uint64(oldestSeq) < maxStoredNonces {
+			return nil
+		}
+
+		if err := nonces.Delete(oldestNonce); err != nil {
+			return err
+		}
+
+		return index.Delete(oldestSeq)
+	}, func() {})
+}
+
+// AddConnectionAttempt records a connection attempt for the session with the
+// given local public key.
+func (db *DB) AddConnectionAttempt(key *btcec.PublicKey,
+	attempt *ConnectionAttempt) error {
+
+	var buf bytes.Buffer
+	if err := serializeConnectionAttempt(&buf, attempt); err != nil {
+		return err
+	}
+
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+		connBucket, err := sessionConnectionsBucket(tx, key)
+		if err != nil {
+			return err
+		}
+
+		attempts, err := connBucket.CreateBucketIfNotExists(
+			attemptsBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		seq, err := attempts.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], seq)
+
+		if err := attempts.Put(seqBytes[:], buf.Bytes()); err != nil {
+			return err
+		}
+
+		// Remove the oldest attempt if we're above the limit now.
+		oldestSeq, _ := attempts.ReadCursor().First()
+		if seq-byteOrder.Uint64(oldestSeq) < maxStoredAttempts {
+			return nil
+		}
+
+		return attempts.Delete(oldestSeq)
+	}, func() {})
+}
+
+// ListConnectionAttempts returns the most recent connection attempts of the
+// session with the given local public key, newest first, together with the
+// total number of handshakes that were attempted for the session.
+func (db *DB) ListConnectionAttempts(key *btcec.PublicKey) (
+	[]*ConnectionAttempt, uint64, error) {
+
+	var (
+		attempts []*ConnectionAttempt
+		total    uint64
+	)
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		sessionBucket, err := getReadBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		if len(sessionBucket.Get(key.SerializeCompressed())) == 0 {
+			return ErrSessionNotFound
+		}
+
+		connectionsBucket, err := getReadBucket(
+			tx, connectionsBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		connBucket := connectionsBucket.NestedReadBucket(
+			key.SerializeCompressed(),
+		)
+		if connBucket == nil {
+			return nil
+		}
+
+		attemptsBucket := connBucket.NestedReadBucket(attemptsBucketKey)
+		if attemptsBucket == nil {
+			return nil
+		}
+
+		// The attempts are keyed by their sequence number and only the
+		// oldest ones are ever removed, so the key of the newest
+		// attempt is the total number of attempts.
+		c := attemptsBucket.ReadCursor()
+		k, v := c.Last()
+		if k != nil {
+			total = byteOrder.Uint64(k)
+		}
+		for ; k != nil; k, v = c.Prev() {
+			attempt, err := deserializeConnectionAttempt(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			attempts = append(attempts, attempt)
+		}
+
+		return nil
+	}, func() {
+		attempts = nil
+		total = 0
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, total, nil
+}
+
+// sessionConnectionsBucket returns the connections bucket of the session with
+// the given local public key, creating it if it doesn't exist yet.
+func sessionConnectionsBucket(tx kvdb.RwTx, key *btcec.PublicKey) (
+	kvdb.RwBucket, error) {
+
+	connectionsBucket, err := getBucket(tx, connectionsBucketKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return connectionsBucket.CreateBucketIfNotExists(
+		key.SerializeCompressed(),
+	)
+}
+
+// serializeConnectionAttempt binary serializes the given connection attempt to
+// the writer using the tlv format.
+func serializeConnectionAttempt(w io.Writer,
+	attempt *ConnectionAttempt) error {
+
+	var (
+		timestamp = uint64(attempt.Timestamp.Unix())
+		accepted  = uint8(0)
+		reason    = []byte(attempt.Reason)
+	)
+
+	if attempt.Accepted {
+		accepted = 1
+	}
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeAttemptTimestamp, &timestamp),
+		tlv.MakePrimitiveRecord(typeAttemptAccepted, &accepted),
+		tlv.MakePrimitiveRecord(typeAttemptReason, &reason),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+// deserializeConnectionAttempt deserializes a connection attempt from the
+// given reader, expecting the data to be encoded in the tlv format.
+func deserializeConnectionAttempt(r io.Reader) (*ConnectionAttempt, error) {
+	var (
+		timestamp uint64
+		accepted  uint8
+		reason    []byte
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeAttemptTimestamp, &timestamp),
+		tlv.MakePrimitiveRecord(typeAttemptAccepted, &accepted),
+		tlv.MakePrimitiveRecord(typeAttemptReason, &reason),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tlvStream.Decode(r); err != nil {
+		return nil, err
+	}
+
+	return &ConnectionAttempt{
+		Timestamp: time.Unix(int64(timestamp), 0),
+		Accepted:  accepted == 1,
+		Reason:    string(reason),
+	}, nil
+}
@@ -0,0 +1,167 @@
+package session
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSession creates a new session with a fresh local key and stores it in
+// the given DB.
+func newTestSession(t *testing.T, db *DB) *Session {
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	sess := &Session{
+		Label:           "test",
+		LocalPrivateKey: priv,
+		LocalPublicKey:  priv.PubKey(),
+		Expiry:          time.Now().Add(time.Hour),
+		CreatedAt:       time.Now(),
+	}
+	require.NoError(t, db.StoreSession(sess))
+
+	return sess
+}
+
+// TestHandshakeNonces tests that a handshake nonce can only be used once per
+// session and that the oldest nonces are removed once the limit is reached.
+func TestHandshakeNonces(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sess1 := newTestSession(t, db)
+	sess2 := newTestSession(t, db)
+
+	nonce := sha256.Sum256([]byte("act one"))
+	require.NoError(t, db.CheckHandshakeNonce(sess1.LocalPublicKey, nonce))
+
+	// The same nonce is rejected for the same session but not for another
+	// one.
+	err = db.CheckHandshakeNonce(sess1.LocalPublicKey, nonce)
+	require.ErrorIs(t, err, ErrHandshakeReplayed)
+	require.NoError(t, db.CheckHandshakeNonce(sess2.LocalPublicKey, nonce))
+
+	// Once enough new nonces were added, the first one is forgotten.
+	for i := 0; i < maxStoredNonces; i++ {
+		n := sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+		require.NoError(t, db.CheckHandshakeNonce(
+			sess1.LocalPublicKey, n,
+		))
+	}
+	require.NoError(t, db.CheckHandshakeNonce(sess1.LocalPublicKey, nonce))
+}
+
+// TestConnectionAttempts tests that connection attempts are listed newest first
+// and that only the most recent ones are kept.
+func TestConnectionAttempts(t *testing.T) {
+	db, err := NewDB(t.TempDir(), "test.db")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	sess := newTestSession(t, db)
+
+	// A session without any attempts yet returns an empty list.
+	attempts, total, err := db.ListConnectionAttempts(sess.LocalPublicKey)
+	require.NoError(t, err)
+	require.Empty(t, attempts)
+	require.Zero(t, total)
+
+	// Unknown sessions are reported as such.
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	_, _, err = db.ListConnectionAttempts(priv.PubKey())
+	require.ErrorIs(t, err, ErrSessionNotFound)
+
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < maxStoredAttempts+5; i++ {
+		attempt := &ConnectionAttempt{
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+			Accepted:  i%2 == 0,
+		}
+		if !attempt.Accepted {
+			attempt.Reason = ErrHandshakeReplayed.Error()
+		}
+
+		require.NoError(t, db.AddConnectionAttempt(
+			sess.LocalPublicKey, attempt,
+		))
+	}
+
+	attempts, total, err = db.ListConnectionAttempts(sess.LocalPublicKey)
+	require.NoError(t, err)
+	require.EqualValues(t, maxStoredAttempts+5, total)
+	require.Len(t, attempts, maxStoredAttempts)
+
+	newest := attempts[0]
+	require.Equal(t, start.Add((maxStoredAttempts+4)*time.Second),
+		newest.Timestamp)
+	require.True(t, newest.Accepted)
+	require.Empty(t, newest.Reason)
+
+	oldest := attempts[len(attempts)-1]
+	require.Equal(t, start.Add(5*time.Second), oldest.Timestamp)
+	require.False(t, oldest.Accepted)
+	require.Equal(t, ErrHandshakeReplayed.Error(), oldest.Reason)
+}
+
+// fakeProxyConn is a mailbox.ProxyConn that reads from the given reader.
+type fakeProxyConn struct {
+	mailbox.ProxyConn
+
+	r io.Reader
+}
+
+// Read reads from the reader of the fake connection.
+func (c *fakeProxyConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// TestNonceConn tests that the nonce of the first act is checked once it was
+// read completely, no matter how the reads are split up.
+func TestNonceConn(t *testing.T) {
+	actOne := bytes.Repeat([]byte{2}, actOneNonceSize+50)
+	expectedNonce := sha256.Sum256(actOne[:actOneNonceSize])
+
+	var checked [][32]byte
+	conn := &nonceConn{
+		ProxyConn: &fakeProxyConn{r: bytes.NewReader(actOne)},
+		check: func(nonce [32]byte) error {
+			checked = append(checked, nonce)
+			return nil
+		},
+	}
+
+	// Read the version byte, the key in two parts and then the rest.
+	for _, size := range []int{1, 20, 13, 50} {
+		buf := make([]byte, size)
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		require.Equal(t, size, n)
+	}
+	require.Equal(t, [][32]byte{expectedNonce}, checked)
+
+	// A failed check fails the read.
+	conn = &nonceConn{
+		ProxyConn: &fakeProxyConn{r: bytes.NewReader(actOne)},
+		check: func([32]byte) error {
+			return ErrHandshakeReplayed
+		},
+	}
+	_, err := conn.Read(make([]byte, 1))
+	require.NoError(t, err)
+
+	_, err = conn.Read(make([]byte, 33))
+	require.ErrorIs(t, err, ErrHandshakeReplayed)
+}
@@ -3,10 +3,11 @@ package session
 import (
 	"encoding/binary"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
 	"time"
 
+	"github.com/lightningnetwork/lnd/kvdb"
 	"go.etcd.io/bbolt"
 )
 
@@ -14,10 +15,6 @@ const (
 	// DBFilename is the default filename of the session database.
 	DBFilename = "session.db"
 
-	// dbFilePermission is the default permission the session database file
-	// is created with.
-	dbFilePermission = 0600
-
 	// DefaultSessionDBTimeout is the default maximum time we wait for the
 	// session bbolt database to be opened. If the database is already
 	// opened by another process, the unique lock cannot be obtained. With
@@ -32,65 +29,65 @@ var (
 	byteOrder = binary.BigEndian
 )
 
-// DB is a bolt-backed persistent store.
+// DB is a kvdb-backed persistent store. It is backed by a local bolt database
+// by default or by a shared Postgres database in a lit cluster.
 type DB struct {
-	*bbolt.DB
+	kvdb.Backend
 }
 
 // NewDB creates a new bolt database that can be found at the given directory.
 func NewDB(dir, fileName string) (*DB, error) {
-	firstInit := false
-	path := filepath.Join(dir, fileName)
-
-	// If the database file does not exist yet, create its directory.
-	if !fileExists(path) {
-		if err := os.MkdirAll(dir, 0700); err != nil {
-			return nil, err
-		}
-		firstInit = true
+	backend, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
+		DBPath:     dir,
+		DBFileName: fileName,
+		DBTimeout:  DefaultSessionDBTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			filepath.Join(dir, fileName), DefaultSessionDBTimeout)
 	}
-
-	db, err := initDB(path, firstInit)
 	if err != nil {
 		return nil, err
 	}
 
+	return NewDBFromBackend(backend)
+}
+
+// NewDBFromBackend creates a new session store on top of the given, already
+// opened database backend.
+func NewDBFromBackend(backend kvdb.Backend) (*DB, error) {
+	if err := initDB(backend); err != nil {
+		return nil, err
+	}
+
 	// Attempt to sync the database's current version with the latest known
 	// version available.
-	if err := syncVersions(db); err != nil {
+	if err := syncVersions(backend); err != nil {
 		return nil, err
 	}
 
-	return &DB{DB: db}, nil
-}
-
-// fileExists reports whether the named file or directory exists.
-func fileExists(path string) bool {
-	if _, err := os.Stat(path); err != nil {
-		if os.IsNotExist(err) {
-			return false
-		}
-	}
-	return true
+	return &DB{Backend: backend}, nil
 }
 
 // initDB initializes all the required top-level buckets for the database.
-func initDB(filepath string, firstInit bool) (*bbolt.DB, error) {
-	db, err := bbolt.Open(filepath, dbFilePermission, &bbolt.Options{
-		Timeout: DefaultSessionDBTimeout,
-	})
-	if err == bbolt.ErrTimeout {
-		return nil, fmt.Errorf("error while trying to open %s: timed "+
-			"out after %v when trying to obtain exclusive lock",
-			filepath, DefaultSessionDBTimeout)
-	}
-	if err != nil {
-		return nil, err
-	}
+func initDB(db kvdb.Backend) error {
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+		// Only a brand new database without any buckets is already at
+		// the latest version. An existing database without a metadata
+		// bucket is rejected when its version is checked, so that it
+		// isn't marked as migrated without running the migrations.
+		firstInit := true
+		err := tx.ForEachBucket(func([]byte) error {
+			firstInit = false
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 
-	err = db.Update(func(tx *bbolt.Tx) error {
 		if firstInit {
-			metadataBucket, err := tx.CreateBucketIfNotExists(
+			metadataBucket, err := tx.CreateTopLevelBucket(
 				metadataBucketKey,
 			)
 			if err != nil {
@@ -102,12 +99,32 @@ func initDB(filepath string, firstInit bool) (*bbolt.DB, error) {
 			}
 		}
 
-		_, err = tx.CreateBucketIfNotExists(sessionBucketKey)
+		_, err = tx.CreateTopLevelBucket(sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateTopLevelBucket(connectionsBucketKey)
 		return err
-	})
-	if err != nil {
-		return nil, err
+	}, func() {})
+}
+
+// Snapshot writes a consistent copy of the whole database to the given writer.
+func (db *DB) Snapshot(w io.Writer) error {
+	return db.Copy(w)
+}
+
+// Probe runs an empty read or, if write is set, an empty write transaction
+// against the database. Committing the write transaction still syncs the
+// database file, so its duration reflects the write latency of the storage.
+func (db *DB) Probe(write bool) error {
+	if write {
+		return kvdb.Update(db, func(kvdb.RwTx) error {
+			return nil
+		}, func() {})
 	}
 
-	return db, nil
+	return kvdb.View(db, func(kvdb.RTx) error {
+		return nil
+	}, func() {})
 }
@@ -0,0 +1,59 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInitDBVersion tests that only a brand new database is marked as being at
+// the latest version, while an existing database without a version is
+// rejected instead of skipping its migrations.
+func TestInitDBVersion(t *testing.T) {
+	t.Parallel()
+
+	openBackend := func(t *testing.T, dir string) kvdb.Backend {
+		backend, err := kvdb.GetBoltBackend(&kvdb.BoltBackendConfig{
+			DBPath:     dir,
+			DBFileName: DBFilename,
+			DBTimeout:  time.Second,
+		})
+		require.NoError(t, err)
+
+		return backend
+	}
+
+	t.Run("new database", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		db, err := NewDBFromBackend(openBackend(t, dir))
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		// The version survives a restart.
+		db, err = NewDBFromBackend(openBackend(t, dir))
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+	})
+
+	t.Run("legacy database without version", func(t *testing.T) {
+		t.Parallel()
+
+		backend := openBackend(t, t.TempDir())
+		t.Cleanup(func() {
+			_ = backend.Close()
+		})
+
+		err := kvdb.Update(backend, func(tx kvdb.RwTx) error {
+			_, err := tx.CreateTopLevelBucket(sessionBucketKey)
+			return err
+		}, func() {})
+		require.NoError(t, err)
+
+		_, err = NewDBFromBackend(backend)
+		require.ErrorContains(t, err, string(metadataBucketKey))
+	})
+}
@@ -0,0 +1,171 @@
+package session
+
+import (
+	"sort"
+
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// Grants is everything a session grants its user.
+type Grants struct {
+	// Permissions are the macaroon permissions of the session.
+	Permissions []bakery.Op
+
+	// Caveats are the caveats that restrict the macaroon of the session,
+	// not including the firewall rules.
+	Caveats []string
+
+	// Rules maps the features of the session to their rules, each one
+	// mapped from the rule name to the encoded rule values.
+	Rules map[string]map[string]string
+}
+
+// RuleChange is a rule of a feature that was added, removed or changed.
+type RuleChange struct {
+	// Feature is the name of the feature the rule belongs to.
+	Feature string
+
+	// Rule is the name of the rule.
+	Rule string
+
+	// Previous is the encoded value of the rule before the change. It is
+	// empty if the rule was added.
+	Previous string
+
+	// Current is the encoded value of the rule after the change. It is
+	// empty if the rule was removed.
+	Current string
+}
+
+// GrantsDiff is the difference between the grants of two sessions.
+type GrantsDiff struct {
+	// AddedPermissions are the permissions that only the current session
+	// has.
+	AddedPermissions []bakery.Op
+
+	// RemovedPermissions are the permissions that only the previous
+	// session had.
+	RemovedPermissions []bakery.Op
+
+	// AddedCaveats are the caveats that only the current session has.
+	AddedCaveats []string
+
+	// RemovedCaveats are the caveats that only the previous session had.
+	RemovedCaveats []string
+
+	// RuleChanges are the rules that differ between the sessions, ordered
+	// by feature and rule name.
+	RuleChanges []RuleChange
+}
+
+// DiffGrants returns the difference between the grants of a previous session
+// and the current one. All lists of the diff are sorted.
+func DiffGrants(prev, cur *Grants) *GrantsDiff {
+	diff := &GrantsDiff{
+		AddedPermissions:   diffOps(cur.Permissions, prev.Permissions),
+		RemovedPermissions: diffOps(prev.Permissions, cur.Permissions),
+		AddedCaveats:       diffStrings(cur.Caveats, prev.Caveats),
+		RemovedCaveats:     diffStrings(prev.Caveats, cur.Caveats),
+	}
+
+	features := make(map[string]struct{})
+	for feature := range prev.Rules {
+		features[feature] = struct{}{}
+	}
+	for feature := range cur.Rules {
+		features[feature] = struct{}{}
+	}
+
+	for feature := range features {
+		prevRules, curRules := prev.Rules[feature], cur.Rules[feature]
+
+		names := make(map[string]struct{})
+		for name := range prevRules {
+			names[name] = struct{}{}
+		}
+		for name := range curRules {
+			names[name] = struct{}{}
+		}
+
+		for name := range names {
+			if prevRules[name] == curRules[name] {
+				continue
+			}
+
+			diff.RuleChanges = append(diff.RuleChanges, RuleChange{
+				Feature:  feature,
+				Rule:     name,
+				Previous: prevRules[name],
+				Current:  curRules[name],
+			})
+		}
+	}
+
+	sort.Slice(diff.RuleChanges, func(i, j int) bool {
+		a, b := diff.RuleChanges[i], diff.RuleChanges[j]
+		if a.Feature != b.Feature {
+			return a.Feature < b.Feature
+		}
+
+		return a.Rule < b.Rule
+	})
+
+	return diff
+}
+
+// diffOps returns the sorted permissions of a that aren't in b.
+func diffOps(a, b []bakery.Op) []bakery.Op {
+	inB := make(map[bakery.Op]struct{}, len(b))
+	for _, op := range b {
+		inB[op] = struct{}{}
+	}
+
+	var ops []bakery.Op
+	seen := make(map[bakery.Op]struct{}, len(a))
+	for _, op := range a {
+		if _, ok := inB[op]; ok {
+			continue
+		}
+		if _, ok := seen[op]; ok {
+			continue
+		}
+		seen[op] = struct{}{}
+
+		ops = append(ops, op)
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Entity != ops[j].Entity {
+			return ops[i].Entity < ops[j].Entity
+		}
+
+		return ops[i].Action < ops[j].Action
+	})
+
+	return ops
+}
+
+// diffStrings returns the sorted strings of a that aren't in b.
+func diffStrings(a, b []string) []string {
+	inB := make(map[string]struct{}, len(b))
+	for _, s := range b {
+		inB[s] = struct{}{}
+	}
+
+	var strs []string
+	seen := make(map[string]struct{}, len(a))
+	for _, s := range a {
+		if _, ok := inB[s]; ok {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+
+		strs = append(strs, s)
+	}
+	sort.Strings(strs)
+
+	return strs
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+// TestDiffGrants tests that the differences between the grants of two
+// sessions are found and sorted.
+func TestDiffGrants(t *testing.T) {
+	var (
+		readInfo      = bakery.Op{Entity: "info", Action: "read"}
+		readOnchain   = bakery.Op{Entity: "onchain", Action: "read"}
+		writeOffchain = bakery.Op{Entity: "offchain", Action: "write"}
+		writeOnchain  = bakery.Op{Entity: "onchain", Action: "write"}
+	)
+
+	prev := &Grants{
+		Permissions: []bakery.Op{readInfo, readOnchain},
+		Caveats:     []string{"ipaddr 10.0.0.1", "privacy"},
+		Rules: map[string]map[string]string{
+			"AutoFees": {
+				"rate-limit":    `{"read":1}`,
+				"history-limit": `{"days":7}`,
+			},
+			"HealthCheck": {
+				"rate-limit": `{"read":1}`,
+			},
+		},
+	}
+	cur := &Grants{
+		Permissions: []bakery.Op{
+			writeOnchain, readInfo, writeOffchain, writeOnchain,
+		},
+		Caveats: []string{"privacy"},
+		Rules: map[string]map[string]string{
+			"AutoFees": {
+				"rate-limit":    `{"read":5}`,
+				"history-limit": `{"days":7}`,
+			},
+			"Rebalance": {
+				"rate-limit": `{"read":1}`,
+			},
+		},
+	}
+
+	diff := DiffGrants(prev, cur)
+	require.Equal(t, &GrantsDiff{
+		AddedPermissions:   []bakery.Op{writeOffchain, writeOnchain},
+		RemovedPermissions: []bakery.Op{readOnchain},
+		RemovedCaveats:     []string{"ipaddr 10.0.0.1"},
+		RuleChanges: []RuleChange{{
+			Feature:  "AutoFees",
+			Rule:     "rate-limit",
+			Previous: `{"read":1}`,
+			Current:  `{"read":5}`,
+		}, {
+			Feature:  "HealthCheck",
+			Rule:     "rate-limit",
+			Previous: `{"read":1}`,
+		}, {
+			Feature: "Rebalance",
+			Rule:    "rate-limit",
+			Current: `{"read":1}`,
+		}},
+	}, diff)
+
+	// Equal grants have no differences.
+	require.Equal(t, &GrantsDiff{}, DiffGrants(prev, prev))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/lightningnetwork/lnd/keychain"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
 )
@@ -33,6 +34,54 @@ const (
 	StateExpired State = 3
 )
 
+// RouteHintPolicy determines which route hints the invoices that are created
+// with a session may contain.
+type RouteHintPolicy uint8
+
+const (
+	// RouteHintsAllow leaves the route hints of invoices unchanged.
+	RouteHintsAllow RouteHintPolicy = 0
+
+	// RouteHintsExplicitOnly keeps lnd from adding route hints for its
+	// private channels. Route hints that the caller sets explicitly are
+	// kept, as the caller already knows about their channels.
+	RouteHintsExplicitOnly RouteHintPolicy = 1
+
+	// RouteHintsStrip removes all route hints from invoices.
+	RouteHintsStrip RouteHintPolicy = 2
+)
+
+// String returns the name of the policy as it is used in the route hint
+// caveat.
+func (p RouteHintPolicy) String() string {
+	switch p {
+	case RouteHintsAllow:
+		return "allow"
+
+	case RouteHintsExplicitOnly:
+		return "explicit-only"
+
+	case RouteHintsStrip:
+		return "strip"
+
+	default:
+		return fmt.Sprintf("unknown(%d)", p)
+	}
+}
+
+// ParseRouteHintPolicy parses the name of a route hint policy.
+func ParseRouteHintPolicy(name string) (RouteHintPolicy, error) {
+	for _, p := range []RouteHintPolicy{
+		RouteHintsAllow, RouteHintsExplicitOnly, RouteHintsStrip,
+	} {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown route hint policy %q", name)
+}
+
 // MacaroonRecipe defines the permissions and caveats that should be used
 // to bake a macaroon.
 type MacaroonRecipe struct {
@@ -63,27 +112,72 @@ type Session struct {
 	RemotePublicKey   *btcec.PublicKey
 	FeatureConfig     *FeaturesConfig
 	WithPrivacyMapper bool
+
+	// Notes is a free-form text that the operator can use to describe
+	// the session.
+	Notes string
+
+	// Tags is a set of key-value pairs that the operator can use to
+	// categorise the session, for example by customer or device.
+	Tags map[string]string
+
+	// RevokedBy is the identity of the caller that revoked the session.
+	RevokedBy string
+
+	// RevocationReason is the reason that was given for the revocation.
+	RevocationReason string
+
+	// PinClient is true if the session may only be used by the client
+	// that was seen first for it. Calls of clients that identify
+	// differently are rejected.
+	PinClient bool
+
+	// Client is the identity of the client that was last seen using the
+	// session, or the first one if the session is pinned to it. It is nil
+	// if no client used the session yet.
+	Client *ClientIdentity
+
+	// KeyLocator locates the static key of the session in the external
+	// key backend that keeps its private key. It is nil if the private key
+	// is stored in LocalPrivateKey.
+	KeyLocator *keychain.KeyLocator
+
+	// OfflinePairingPhrase is true if the pairing phrase of the session
+	// must never be returned over RPC. The phrase then has to be derived
+	// from the pairing secret offline.
+	OfflinePairingPhrase bool
+
+	// RouteHintPolicy determines which route hints the invoices that are
+	// created with the session may contain.
+	RouteHintPolicy RouteHintPolicy
+
+	// BlockOnChainSpend is true if the session may not spend any on-chain
+	// funds of the node, whatever the permissions of its macaroon allow.
+	BlockOnChainSpend bool
+
+	// PassphraseSecret is the secret that is used during the handshake
+	// instead of the pairing secret if the session is protected by a
+	// passphrase. It is derived from the pairing secret and the passphrase
+	// and is nil if the session isn't protected.
+	PassphraseSecret *[mailbox.NumPassphraseEntropyBytes]byte
 }
 
 // MacaroonBaker is a function type for baking a super macaroon.
 type MacaroonBaker func(ctx context.Context, rootKeyID uint64,
 	recipe *MacaroonRecipe) (string, error)
 
-// NewSession creates a new session with the given user-defined parameters.
-func NewSession(label string, typ Type, expiry time.Time, serverAddr string,
-	devServer bool, perms []bakery.Op, caveats []macaroon.Caveat,
-	featureConfig FeaturesConfig, privacy bool) (*Session, error) {
+// NewSession creates a new session with the given static key and
+// user-defined parameters.
+func NewSession(key *StaticKey, label string, typ Type, expiry time.Time,
+	serverAddr string, devServer bool, perms []bakery.Op,
+	caveats []macaroon.Caveat, featureConfig FeaturesConfig,
+	privacy bool) (*Session, error) {
 
 	_, pairingSecret, err := mailbox.NewPassphraseEntropy()
 	if err != nil {
 		return nil, fmt.Errorf("error deriving pairing secret: %v", err)
 	}
-
-	privateKey, err := btcec.NewPrivateKey()
-	if err != nil {
-		return nil, fmt.Errorf("error deriving private key: %v", err)
-	}
-	pubKey := privateKey.PubKey()
+	pubKey := key.PubKey
 
 	var macRootKeyBase [4]byte
 	copy(macRootKeyBase[:], pubKey.SerializeCompressed())
@@ -100,10 +194,11 @@ func NewSession(label string, typ Type, expiry time.Time, serverAddr string,
 		DevServer:         devServer,
 		MacaroonRootKey:   macRootKey,
 		PairingSecret:     pairingSecret,
-		LocalPrivateKey:   privateKey,
+		LocalPrivateKey:   key.PrivKey,
 		LocalPublicKey:    pubKey,
 		RemotePublicKey:   nil,
 		WithPrivacyMapper: privacy,
+		KeyLocator:        key.Locator,
 	}
 
 	if perms != nil || caveats != nil {
@@ -120,6 +215,18 @@ func NewSession(label string, typ Type, expiry time.Time, serverAddr string,
 	return sess, nil
 }
 
+// PublicPairingSecret returns the pairing secret that may be returned in any
+// RPC response about the session. The pairing secret of a session with an
+// offline pairing phrase is only returned once, when the session is created,
+// since the phrase can be derived from it. Nil is returned for such sessions.
+func (s *Session) PublicPairingSecret() []byte {
+	if s.OfflinePairingPhrase {
+		return nil
+	}
+
+	return s.PairingSecret[:]
+}
+
 // Store is the interface a persistent storage must implement for storing and
 // retrieving Terminal Connect sessions.
 type Store interface {
@@ -132,6 +239,7 @@ type Store interface {
 	ListSessions() ([]*Session, error)
 
 	// RevokeSession updates the state of the session with the given local
-	// public key to be revoked.
-	RevokeSession(*btcec.PublicKey) error
+	// public key to be revoked. The revocation is recorded with the given
+	// actor and reason.
+	RevokeSession(key *btcec.PublicKey, revokedBy, reason string) error
 }
@@ -0,0 +1,248 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+)
+
+const (
+	// KeyBackendLocal is the key backend that creates the static keys of
+	// sessions locally and stores them in the session DB.
+	KeyBackendLocal = "local"
+
+	// KeyBackendLnd is the key backend that derives the static keys of
+	// sessions from lnd's wallet, so they never leave lnd or the remote
+	// signer or HSM it uses.
+	KeyBackendLnd = "lnd"
+
+	// LNCKeyFamily is the key family the static keys of sessions are
+	// derived with by an external key backend. It lies outside of the
+	// range of families that lnd uses itself.
+	LNCKeyFamily keychain.KeyFamily = 4040
+
+	// keyOperationTimeout is the maximum time an operation of an external
+	// key backend may take.
+	keyOperationTimeout = 30 * time.Second
+)
+
+// StaticKey is the long term static key of a session that is used for the LNC
+// handshakes.
+type StaticKey struct {
+	// PubKey is the public key of the static key.
+	PubKey *btcec.PublicKey
+
+	// PrivKey is the private key of the static key. It is only set if the
+	// key is stored in the session DB.
+	PrivKey *btcec.PrivateKey
+
+	// Locator locates the key in an external key backend. It is only set
+	// if the private key is kept by that backend.
+	Locator *keychain.KeyLocator
+}
+
+// KeyBackend creates the static keys of new sessions and performs the ECDH
+// operations of the LNC handshakes with them.
+type KeyBackend interface {
+	// NewKey creates a new static key.
+	NewKey(ctx context.Context) (*StaticKey, error)
+
+	// ECDH returns the ECDH implementation of the static key of the given
+	// session.
+	ECDH(sess *Session) (keychain.SingleKeyECDH, error)
+}
+
+// KeyDeriver is an external key store that derives keys without ever handing
+// out the private keys.
+type KeyDeriver interface {
+	// DeriveNextKey derives the next key of the given key family.
+	DeriveNextKey(ctx context.Context, family int32) (
+		*keychain.KeyDescriptor, error)
+
+	// DeriveSharedKey performs an ECDH operation between the given public
+	// key and the private key at the given locator and returns the
+	// SHA256 hash of the shared point.
+	DeriveSharedKey(ctx context.Context, pubKey *btcec.PublicKey,
+		locator *keychain.KeyLocator) ([32]byte, error)
+}
+
+// LocalKeyBackend is the default key backend. It creates the static keys of
+// sessions locally, so they are stored in the session DB.
+type LocalKeyBackend struct{}
+
+// A compile-time check to ensure LocalKeyBackend implements KeyBackend.
+var _ KeyBackend = (*LocalKeyBackend)(nil)
+
+// NewKey creates a new random static key.
+//
+// NOTE: This is part of the KeyBackend interface.
+func (l *LocalKeyBackend) NewKey(_ context.Context) (*StaticKey, error) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("error deriving private key: %v", err)
+	}
+
+	return &StaticKey{
+		PubKey:  privKey.PubKey(),
+		PrivKey: privKey,
+	}, nil
+}
+
+// ECDH returns the ECDH implementation of the locally stored static key of
+// the given session.
+//
+// NOTE: This is part of the KeyBackend interface.
+func (l *LocalKeyBackend) ECDH(sess *Session) (keychain.SingleKeyECDH,
+	error) {
+
+	if sess.LocalPrivateKey == nil {
+		return nil, fmt.Errorf("the private key of session %x is not "+
+			"stored locally", sess.ID[:])
+	}
+
+	return &keychain.PrivKeyECDH{PrivKey: sess.LocalPrivateKey}, nil
+}
+
+// DerivedKeyBackend is a key backend that derives the static keys of sessions
+// from an external key store. Sessions that were created with a locally
+// stored key keep using it.
+type DerivedKeyBackend struct {
+	deriver KeyDeriver
+	local   LocalKeyBackend
+}
+
+// A compile-time check to ensure DerivedKeyBackend implements KeyBackend.
+var _ KeyBackend = (*DerivedKeyBackend)(nil)
+
+// NewDerivedKeyBackend creates a key backend that derives the static keys of
+// sessions from the given external key store.
+func NewDerivedKeyBackend(deriver KeyDeriver) *DerivedKeyBackend {
+	return &DerivedKeyBackend{
+		deriver: deriver,
+	}
+}
+
+// NewKey derives the next static key of the LNC key family.
+//
+// NOTE: This is part of the KeyBackend interface.
+func (d *DerivedKeyBackend) NewKey(ctx context.Context) (*StaticKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, keyOperationTimeout)
+	defer cancel()
+
+	desc, err := d.deriver.DeriveNextKey(ctx, int32(LNCKeyFamily))
+	if err != nil {
+		return nil, fmt.Errorf("error deriving static key: %v", err)
+	}
+
+	return &StaticKey{
+		PubKey:  desc.PubKey,
+		Locator: &desc.KeyLocator,
+	}, nil
+}
+
+// ECDH returns the ECDH implementation of the static key of the given
+// session, which is performed by the external key store unless the key is
+// stored locally.
+//
+// NOTE: This is part of the KeyBackend interface.
+func (d *DerivedKeyBackend) ECDH(sess *Session) (keychain.SingleKeyECDH,
+	error) {
+
+	if sess.KeyLocator == nil {
+		return d.local.ECDH(sess)
+	}
+
+	return &derivedECDH{
+		pubKey:  sess.LocalPublicKey,
+		locator: *sess.KeyLocator,
+		deriver: d.deriver,
+	}, nil
+}
+
+// LndKeyDeriver is a KeyDeriver that derives the keys from lnd's wallet. If
+// lnd runs with a remote signer, the ECDH operations are performed by the
+// remote signer.
+type LndKeyDeriver struct {
+	lnd func() (*lndclient.LndServices, error)
+}
+
+// A compile-time check to ensure LndKeyDeriver implements KeyDeriver.
+var _ KeyDeriver = (*LndKeyDeriver)(nil)
+
+// NewLndKeyDeriver creates a KeyDeriver that uses the lnd connection returned
+// by the given function. The connection is looked up for every operation, so
+// it doesn't need to be established yet. The function returns an error as
+// long as it isn't.
+func NewLndKeyDeriver(
+	lnd func() (*lndclient.LndServices, error)) *LndKeyDeriver {
+
+	return &LndKeyDeriver{
+		lnd: lnd,
+	}
+}
+
+// DeriveNextKey derives the next key of the given key family from lnd's
+// wallet.
+//
+// NOTE: This is part of the KeyDeriver interface.
+func (l *LndKeyDeriver) DeriveNextKey(ctx context.Context, family int32) (
+	*keychain.KeyDescriptor, error) {
+
+	lnd, err := l.lnd()
+	if err != nil {
+		return nil, err
+	}
+
+	return lnd.WalletKit.DeriveNextKey(ctx, family)
+}
+
+// DeriveSharedKey lets lnd perform the ECDH operation with the key at the
+// given locator.
+//
+// NOTE: This is part of the KeyDeriver interface.
+func (l *LndKeyDeriver) DeriveSharedKey(ctx context.Context,
+	pubKey *btcec.PublicKey, locator *keychain.KeyLocator) ([32]byte,
+	error) {
+
+	lnd, err := l.lnd()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return lnd.Signer.DeriveSharedKey(ctx, pubKey, locator)
+}
+
+// derivedECDH performs the ECDH operations of a static key that is kept by an
+// external key store.
+type derivedECDH struct {
+	pubKey  *btcec.PublicKey
+	locator keychain.KeyLocator
+	deriver KeyDeriver
+}
+
+// A compile-time check to ensure derivedECDH implements SingleKeyECDH.
+var _ keychain.SingleKeyECDH = (*derivedECDH)(nil)
+
+// PubKey returns the public key of the static key.
+//
+// NOTE: This is part of the keychain.SingleKeyECDH interface.
+func (e *derivedECDH) PubKey() *btcec.PublicKey {
+	return e.pubKey
+}
+
+// ECDH lets the external key store perform the ECDH operation with the given
+// public key.
+//
+// NOTE: This is part of the keychain.SingleKeyECDH interface.
+func (e *derivedECDH) ECDH(pubKey *btcec.PublicKey) ([32]byte, error) {
+	ctx, cancel := context.WithTimeout(
+		context.Background(), keyOperationTimeout,
+	)
+	defer cancel()
+
+	return e.deriver.DeriveSharedKey(ctx, pubKey, &e.locator)
+}
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKeyDeriver is a KeyDeriver that keeps its keys in memory.
+type mockKeyDeriver struct {
+	keys []*btcec.PrivateKey
+}
+
+// DeriveNextKey creates a new random key.
+func (m *mockKeyDeriver) DeriveNextKey(_ context.Context,
+	family int32) (*keychain.KeyDescriptor, error) {
+
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	m.keys = append(m.keys, privKey)
+
+	return &keychain.KeyDescriptor{
+		KeyLocator: keychain.KeyLocator{
+			Family: keychain.KeyFamily(family),
+			Index:  uint32(len(m.keys) - 1),
+		},
+		PubKey: privKey.PubKey(),
+	}, nil
+}
+
+// DeriveSharedKey performs an ECDH operation with the key at the locator.
+func (m *mockKeyDeriver) DeriveSharedKey(_ context.Context,
+	pubKey *btcec.PublicKey, locator *keychain.KeyLocator) ([32]byte,
+	error) {
+
+	ecdh := &keychain.PrivKeyECDH{PrivKey: m.keys[locator.Index]}
+	return ecdh.ECDH(pubKey)
+}
+
+// TestDerivedKeyBackend tests that the static keys of new sessions are kept by
+// the external key store and that sessions with local keys keep using them.
+func TestDerivedKeyBackend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	backend := NewDerivedKeyBackend(&mockKeyDeriver{})
+
+	remoteKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	remoteECDH := &keychain.PrivKeyECDH{PrivKey: remoteKey}
+
+	newSession := func(key *StaticKey) *Session {
+		sess, err := NewSession(
+			key, "derived", TypeMacaroonAdmin,
+			time.Now().Add(time.Hour), "mailbox:443", false, nil,
+			nil, nil, false,
+		)
+		require.NoError(t, err)
+
+		return sess
+	}
+
+	key, err := backend.NewKey(ctx)
+	require.NoError(t, err)
+	require.Nil(t, key.PrivKey)
+	require.Equal(t, LNCKeyFamily, key.Locator.Family)
+
+	sess := newSession(key)
+	require.Nil(t, sess.LocalPrivateKey)
+	require.Equal(t, key.Locator, sess.KeyLocator)
+
+	ecdh, err := backend.ECDH(sess)
+	require.NoError(t, err)
+	require.True(t, ecdh.PubKey().IsEqual(sess.LocalPublicKey))
+
+	shared, err := ecdh.ECDH(remoteKey.PubKey())
+	require.NoError(t, err)
+	expected, err := remoteECDH.ECDH(sess.LocalPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, expected, shared)
+
+	// A session with a locally stored key keeps using it.
+	localKey, err := (&LocalKeyBackend{}).NewKey(ctx)
+	require.NoError(t, err)
+
+	localSess := newSession(localKey)
+	ecdh, err = backend.ECDH(localSess)
+	require.NoError(t, err)
+	require.True(t, ecdh.PubKey().IsEqual(localKey.PubKey))
+
+	// The local backend can't use a key it doesn't have.
+	_, err = (&LocalKeyBackend{}).ECDH(sess)
+	require.Error(t, err)
+}
+
+// TestLndKeyDeriverNotConnected tests that the lnd key deriver fails its
+// operations instead of panicking while lnd isn't connected yet.
+func TestLndKeyDeriverNotConnected(t *testing.T) {
+	t.Parallel()
+
+	errNotConnected := errors.New("lnd is not connected")
+	deriver := NewLndKeyDeriver(func() (*lndclient.LndServices, error) {
+		return nil, errNotConnected
+	})
+
+	ctx := context.Background()
+	_, err := deriver.DeriveNextKey(ctx, int32(LNCKeyFamily))
+	require.ErrorIs(t, err, errNotConnected)
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	_, err = deriver.DeriveSharedKey(
+		ctx, privKey.PubKey(), &keychain.KeyLocator{},
+	)
+	require.ErrorIs(t, err, errNotConnected)
+}
@@ -77,6 +77,17 @@ func isSuperMacaroonRootKeyID(rootKeyID uint64) bool {
 	return bytes.HasPrefix(rootKeyBytes, SuperMacaroonRootKeyPrefix[:])
 }
 
+// SuperMacaroonID returns the session ID of the given macaroon and true if
+// the macaroon is a super macaroon baked by LiT. Otherwise false is returned.
+func SuperMacaroonID(mac *macaroon.Macaroon) (ID, bool) {
+	rootKeyID, err := RootKeyIDFromMacaroon(mac)
+	if err != nil || !isSuperMacaroonRootKeyID(rootKeyID) {
+		return ID{}, false
+	}
+
+	return IDFromMacRootKeyID(rootKeyID), true
+}
+
 // IDFromMacaroon is a helper function that creates a session ID from
 // a macaroon ID.
 func IDFromMacaroon(mac *macaroon.Macaroon) (ID, error) {
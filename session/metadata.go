@@ -4,13 +4,13 @@ import (
 	"errors"
 	"fmt"
 
-	"go.etcd.io/bbolt"
+	"github.com/lightningnetwork/lnd/kvdb"
 )
 
 // migration is a function which takes a prior outdated version of the database
 // instance and mutates the key/bucket structure to arrive at a more up-to-date
 // version of the database.
-type migration func(tx *bbolt.Tx) error
+type migration func(tx kvdb.RwTx) error
 
 var (
 	// metadataBucketKey stores all the metadata concerning the state of the
@@ -35,7 +35,7 @@ var (
 )
 
 // getDBVersion retrieves the current database version.
-func getDBVersion(bucket *bbolt.Bucket) (uint32, error) {
+func getDBVersion(bucket kvdb.RBucket) (uint32, error) {
 	versionBytes := bucket.Get(dbVersionKey)
 	if versionBytes == nil {
 		return 0, errors.New("database version not found")
@@ -44,15 +44,25 @@ func getDBVersion(bucket *bbolt.Bucket) (uint32, error) {
 }
 
 // setDBVersion updates the current database version.
-func setDBVersion(bucket *bbolt.Bucket, version uint32) error {
+func setDBVersion(bucket kvdb.RwBucket, version uint32) error {
 	var b [4]byte
 	byteOrder.PutUint32(b[:], version)
 	return bucket.Put(dbVersionKey, b[:])
 }
 
 // getBucket retrieves the bucket with the given key.
-func getBucket(tx *bbolt.Tx, key []byte) (*bbolt.Bucket, error) {
-	bucket := tx.Bucket(key)
+func getBucket(tx kvdb.RwTx, key []byte) (kvdb.RwBucket, error) {
+	bucket := tx.ReadWriteBucket(key)
+	if bucket == nil {
+		return nil, fmt.Errorf("bucket \"%v\" does not exist",
+			string(key))
+	}
+	return bucket, nil
+}
+
+// getReadBucket retrieves the bucket with the given key for reading.
+func getReadBucket(tx kvdb.RTx, key []byte) (kvdb.RBucket, error) {
+	bucket := tx.ReadBucket(key)
 	if bucket == nil {
 		return nil, fmt.Errorf("bucket \"%v\" does not exist",
 			string(key))
@@ -63,16 +73,16 @@ func getBucket(tx *bbolt.Tx, key []byte) (*bbolt.Bucket, error) {
 // syncVersions function is used for safe db version synchronization. It
 // applies migration functions to the current database and recovers the
 // previous state of db if at least one error/panic appeared during migration.
-func syncVersions(db *bbolt.DB) error {
+func syncVersions(db kvdb.Backend) error {
 	var currentVersion uint32
-	err := db.View(func(tx *bbolt.Tx) error {
-		metadata, err := getBucket(tx, metadataBucketKey)
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		metadata, err := getReadBucket(tx, metadataBucketKey)
 		if err != nil {
 			return err
 		}
 		currentVersion, err = getDBVersion(metadata)
 		return err
-	})
+	}, func() {})
 	if err != nil {
 		return err
 	}
@@ -101,7 +111,7 @@ func syncVersions(db *bbolt.DB) error {
 
 	// Otherwise, we execute the migrations serially within a single
 	// database transaction to ensure the migration is atomic.
-	return db.Update(func(tx *bbolt.Tx) error {
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
 		for v := currentVersion; v < latestDBVersion; v++ {
 			log.Infof("Applying migration #%v", v+1)
 
@@ -117,5 +127,5 @@ func syncVersions(db *bbolt.DB) error {
 			return err
 		}
 		return setDBVersion(metadata, latestDBVersion)
-	})
+	}, func() {})
 }
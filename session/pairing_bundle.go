@@ -0,0 +1,192 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/lightninglabs/lightning-terminal/scb"
+	"github.com/lightningnetwork/lnd/tlv"
+)
+
+const (
+	typeBundleLabel       tlv.Type = 1
+	typeBundleType        tlv.Type = 2
+	typeBundleExpiry      tlv.Type = 3
+	typeBundleServerAddr  tlv.Type = 4
+	typeBundleDevServer   tlv.Type = 5
+	typeBundleSecret      tlv.Type = 6
+	typeBundleLocalPubKey tlv.Type = 7
+	typeBundlePassphrase  tlv.Type = 8
+)
+
+// ErrInvalidPairingBundle is returned if a decrypted pairing bundle can't be
+// parsed.
+var ErrInvalidPairingBundle = errors.New("invalid pairing bundle")
+
+// PairingBundle holds the data a client needs to pair with a session. It is
+// exported as an encrypted file so that the pairing phrase doesn't have to be
+// passed on in plain text.
+type PairingBundle struct {
+	Label          string
+	Type           Type
+	Expiry         time.Time
+	ServerAddr     string
+	DevServer      bool
+	PairingSecret  [mailbox.NumPassphraseEntropyBytes]byte
+	LocalPublicKey *btcec.PublicKey
+
+	// PassphraseProtected is true if a passphrase must be entered in
+	// addition to the pairing phrase when connecting. The passphrase
+	// itself is never part of the bundle.
+	PassphraseProtected bool
+}
+
+// NewPairingBundle returns the pairing bundle of the given session. Only
+// sessions that can still be used can be exported. Sessions with an offline
+// pairing phrase can't be exported, as importing the bundle would reveal the
+// phrase.
+func NewPairingBundle(s *Session, now time.Time) (*PairingBundle, error) {
+	if s.OfflinePairingPhrase {
+		return nil, fmt.Errorf("the pairing phrase of the session is " +
+			"only available offline")
+	}
+
+	if s.State != StateCreated && s.State != StateInUse {
+		return nil, fmt.Errorf("session is in state %d and can't be "+
+			"paired with", s.State)
+	}
+
+	if !s.Expiry.After(now) {
+		return nil, fmt.Errorf("session expired at %v", s.Expiry)
+	}
+
+	return &PairingBundle{
+		Label:               s.Label,
+		Type:                s.Type,
+		Expiry:              s.Expiry,
+		ServerAddr:          s.ServerAddr,
+		DevServer:           s.DevServer,
+		PairingSecret:       s.PairingSecret,
+		LocalPublicKey:      s.LocalPublicKey,
+		PassphraseProtected: s.PassphraseSecret != nil,
+	}, nil
+}
+
+// EncryptPairingBundle serializes the given bundle and encrypts it with a key
+// derived from the passphrase.
+func EncryptPairingBundle(b *PairingBundle, passphrase []byte) ([]byte,
+	error) {
+
+	var buf bytes.Buffer
+	if err := serializePairingBundle(&buf, b); err != nil {
+		return nil, err
+	}
+
+	return scb.Encrypt(buf.Bytes(), passphrase)
+}
+
+// DecryptPairingBundle decrypts a bundle that was created by
+// EncryptPairingBundle using the same passphrase.
+func DecryptPairingBundle(blob, passphrase []byte) (*PairingBundle, error) {
+	plaintext, err := scb.Decrypt(blob, passphrase)
+	if errors.Is(err, scb.ErrInvalidBackup) {
+		return nil, ErrInvalidPairingBundle
+	} else if err != nil {
+		return nil, errors.New("unable to decrypt pairing bundle, is " +
+			"the passphrase correct?")
+	}
+
+	return deserializePairingBundle(bytes.NewReader(plaintext))
+}
+
+// serializePairingBundle binary serializes the given bundle to the writer
+// using the tlv format.
+func serializePairingBundle(w io.Writer, b *PairingBundle) error {
+	var (
+		label         = []byte(b.Label)
+		typ           = uint8(b.Type)
+		expiry        = uint64(b.Expiry.Unix())
+		serverAddr    = []byte(b.ServerAddr)
+		devServer     = uint8(0)
+		pairingSecret = b.PairingSecret[:]
+		localPubKey   = b.LocalPublicKey.SerializeCompressed()
+		passphrase    = uint8(0)
+	)
+
+	if b.DevServer {
+		devServer = 1
+	}
+
+	if b.PassphraseProtected {
+		passphrase = 1
+	}
+
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeBundleLabel, &label),
+		tlv.MakePrimitiveRecord(typeBundleType, &typ),
+		tlv.MakePrimitiveRecord(typeBundleExpiry, &expiry),
+		tlv.MakePrimitiveRecord(typeBundleServerAddr, &serverAddr),
+		tlv.MakePrimitiveRecord(typeBundleDevServer, &devServer),
+		tlv.MakePrimitiveRecord(typeBundleSecret, &pairingSecret),
+		tlv.MakePrimitiveRecord(typeBundleLocalPubKey, &localPubKey),
+		tlv.MakePrimitiveRecord(typeBundlePassphrase, &passphrase),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tlvStream.Encode(w)
+}
+
+// deserializePairingBundle deserializes a bundle from the given reader,
+// expecting the data to be encoded in the tlv format.
+func deserializePairingBundle(r io.Reader) (*PairingBundle, error) {
+	var (
+		b                          PairingBundle
+		label, serverAddr          []byte
+		pairingSecret, localPubKey []byte
+		typ, devServer, passphrase uint8
+		expiry                     uint64
+	)
+	tlvStream, err := tlv.NewStream(
+		tlv.MakePrimitiveRecord(typeBundleLabel, &label),
+		tlv.MakePrimitiveRecord(typeBundleType, &typ),
+		tlv.MakePrimitiveRecord(typeBundleExpiry, &expiry),
+		tlv.MakePrimitiveRecord(typeBundleServerAddr, &serverAddr),
+		tlv.MakePrimitiveRecord(typeBundleDevServer, &devServer),
+		tlv.MakePrimitiveRecord(typeBundleSecret, &pairingSecret),
+		tlv.MakePrimitiveRecord(typeBundleLocalPubKey, &localPubKey),
+		tlv.MakePrimitiveRecord(typeBundlePassphrase, &passphrase),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tlvStream.DecodeWithParsedTypes(r); err != nil {
+		return nil, ErrInvalidPairingBundle
+	}
+
+	if len(pairingSecret) != len(b.PairingSecret) {
+		return nil, ErrInvalidPairingBundle
+	}
+
+	b.LocalPublicKey, err = btcec.ParsePubKey(localPubKey)
+	if err != nil {
+		return nil, ErrInvalidPairingBundle
+	}
+
+	b.Label = string(label)
+	b.Type = Type(typ)
+	b.Expiry = time.Unix(int64(expiry), 0)
+	b.ServerAddr = string(serverAddr)
+	b.DevServer = devServer == 1
+	b.PassphraseProtected = passphrase == 1
+	copy(b.PairingSecret[:], pairingSecret)
+
+	return &b, nil
+}
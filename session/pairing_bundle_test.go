@@ -0,0 +1,100 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPairingBundle tests that a pairing bundle can only be created for usable
+// sessions and that it can be decrypted with the passphrase it was encrypted
+// with.
+func TestPairingBundle(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	key, err := (&LocalKeyBackend{}).NewKey(context.Background())
+	require.NoError(t, err)
+
+	sess, err := NewSession(
+		key, "shop", TypeMacaroonAdmin, time.Unix(5000, 0),
+		"mailbox.terminal.lightning.today:443", true, nil, nil, nil,
+		false,
+	)
+	require.NoError(t, err)
+
+	bundle, err := NewPairingBundle(sess, now)
+	require.NoError(t, err)
+
+	blob, err := EncryptPairingBundle(bundle, []byte("pass"))
+	require.NoError(t, err)
+
+	decrypted, err := DecryptPairingBundle(blob, []byte("pass"))
+	require.NoError(t, err)
+	require.Equal(t, "shop", decrypted.Label)
+	require.Equal(t, TypeMacaroonAdmin, decrypted.Type)
+	require.Equal(t, sess.Expiry.Unix(), decrypted.Expiry.Unix())
+	require.Equal(t, sess.ServerAddr, decrypted.ServerAddr)
+	require.True(t, decrypted.DevServer)
+	require.Equal(t, sess.PairingSecret, decrypted.PairingSecret)
+	require.True(t, sess.LocalPublicKey.IsEqual(decrypted.LocalPublicKey))
+	require.False(t, decrypted.PassphraseProtected)
+
+	// The bundle of a passphrase protected session says so, but it still
+	// only carries the pairing secret.
+	require.NoError(t, sess.ProtectWithPassphrase([]byte("hunter2")))
+	bundle, err = NewPairingBundle(sess, now)
+	require.NoError(t, err)
+
+	blob, err = EncryptPairingBundle(bundle, []byte("pass"))
+	require.NoError(t, err)
+
+	decrypted, err = DecryptPairingBundle(blob, []byte("pass"))
+	require.NoError(t, err)
+	require.True(t, decrypted.PassphraseProtected)
+	require.Equal(t, sess.PairingSecret, decrypted.PairingSecret)
+
+	// A wrong passphrase or a corrupted bundle is detected.
+	_, err = DecryptPairingBundle(blob, []byte("wrong"))
+	require.ErrorContains(t, err, "is the passphrase correct")
+
+	_, err = DecryptPairingBundle(blob[:10], []byte("pass"))
+	require.ErrorIs(t, err, ErrInvalidPairingBundle)
+
+	// Expired and revoked sessions can't be exported.
+	_, err = NewPairingBundle(sess, time.Unix(6000, 0))
+	require.ErrorContains(t, err, "session expired")
+
+	sess.State = StateRevoked
+	_, err = NewPairingBundle(sess, now)
+	require.ErrorContains(t, err, "can't be paired with")
+
+	// Neither can sessions with an offline pairing phrase.
+	sess.State = StateCreated
+	sess.OfflinePairingPhrase = true
+	_, err = NewPairingBundle(sess, now)
+	require.ErrorContains(t, err, "only available offline")
+}
+
+// TestPublicPairingSecret tests that the pairing secret of a session with an
+// offline pairing phrase isn't public, so listing or fetching the session
+// doesn't reveal the phrase.
+func TestPublicPairingSecret(t *testing.T) {
+	t.Parallel()
+
+	key, err := (&LocalKeyBackend{}).NewKey(context.Background())
+	require.NoError(t, err)
+
+	sess, err := NewSession(
+		key, "vault", TypeMacaroonReadonly, time.Unix(5000, 0),
+		"mailbox.terminal.lightning.today:443", false, nil, nil, nil,
+		false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, sess.PairingSecret[:], sess.PublicPairingSecret())
+
+	sess.OfflinePairingPhrase = true
+	require.Nil(t, sess.PublicPairingSecret())
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"errors"
+
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// The scrypt parameters used to derive the handshake secret of a
+	// passphrase protected session. The client has to use the same
+	// parameters when connecting.
+	passphraseScryptN = 1 << 15
+	passphraseScryptR = 8
+	passphraseScryptP = 1
+)
+
+// passphraseSalt is prepended to the pairing secret to form the salt of the
+// derivation, so that the derived secret is bound to this specific use.
+var passphraseSalt = []byte("lnc-pairing-passphrase")
+
+// ErrEmptyPassphrase is returned if a session is to be protected with an
+// empty passphrase.
+var ErrEmptyPassphrase = errors.New("pairing passphrase must not be empty")
+
+// DerivePassphraseSecret derives the secret that is used instead of the pairing
+// secret during the handshake of a passphrase protected session. The pairing
+// secret is used as the salt, so that the same passphrase results in a
+// different secret for every session. As both the mailbox stream and the
+// handshake depend on the derived secret, a client that only knows the pairing
+// phrase can neither find nor complete the connection.
+func DerivePassphraseSecret(
+	pairingSecret [mailbox.NumPassphraseEntropyBytes]byte,
+	passphrase []byte) ([mailbox.NumPassphraseEntropyBytes]byte, error) {
+
+	var secret [mailbox.NumPassphraseEntropyBytes]byte
+	if len(passphrase) == 0 {
+		return secret, ErrEmptyPassphrase
+	}
+
+	salt := make([]byte, 0, len(passphraseSalt)+len(pairingSecret))
+	salt = append(salt, passphraseSalt...)
+	salt = append(salt, pairingSecret[:]...)
+
+	key, err := scrypt.Key(
+		passphrase, salt, passphraseScryptN, passphraseScryptR,
+		passphraseScryptP, len(secret),
+	)
+	if err != nil {
+		return secret, err
+	}
+	copy(secret[:], key)
+
+	return secret, nil
+}
+
+// ProtectWithPassphrase makes the given passphrase a requirement for
+// connecting to the session. Only the derived secret is kept, the passphrase
+// itself can't be recovered from the session.
+func (s *Session) ProtectWithPassphrase(passphrase []byte) error {
+	secret, err := DerivePassphraseSecret(s.PairingSecret, passphrase)
+	if err != nil {
+		return err
+	}
+
+	s.PassphraseSecret = &secret
+
+	return nil
+}
+
+// HandshakeSecret returns the secret that the client must know to connect to
+// the session. This is the pairing secret, unless the session is protected by
+// a passphrase.
+func (s *Session) HandshakeSecret() []byte {
+	if s.PassphraseSecret != nil {
+		return s.PassphraseSecret[:]
+	}
+
+	return s.PairingSecret[:]
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPassphraseSecret tests that the handshake secret of a session only
+// changes if it is protected by a passphrase and that the derived secret
+// depends on both the passphrase and the pairing secret.
+func TestPassphraseSecret(t *testing.T) {
+	var (
+		secret1 = [14]byte{1, 2, 3}
+		secret2 = [14]byte{4, 5, 6}
+	)
+
+	sess := &Session{PairingSecret: secret1}
+	require.Equal(t, secret1[:], sess.HandshakeSecret())
+
+	_, err := DerivePassphraseSecret(secret1, nil)
+	require.ErrorIs(t, err, ErrEmptyPassphrase)
+	require.ErrorIs(t, sess.ProtectWithPassphrase(nil), ErrEmptyPassphrase)
+	require.Nil(t, sess.PassphraseSecret)
+
+	require.NoError(t, sess.ProtectWithPassphrase([]byte("hunter2")))
+	require.NotNil(t, sess.PassphraseSecret)
+	require.NotEqual(t, secret1[:], sess.HandshakeSecret())
+	require.Equal(t, sess.PassphraseSecret[:], sess.HandshakeSecret())
+
+	// The derivation is deterministic, so the client arrives at the same
+	// secret.
+	derived, err := DerivePassphraseSecret(secret1, []byte("hunter2"))
+	require.NoError(t, err)
+	require.Equal(t, derived[:], sess.HandshakeSecret())
+
+	// A different passphrase or pairing secret results in a different
+	// secret.
+	other, err := DerivePassphraseSecret(secret1, []byte("hunter3"))
+	require.NoError(t, err)
+	require.NotEqual(t, derived, other)
+
+	other, err = DerivePassphraseSecret(secret2, []byte("hunter2"))
+	require.NoError(t, err)
+	require.NotEqual(t, derived, other)
+}
@@ -0,0 +1,143 @@
+package session
+
+import (
+	"crypto/sha256"
+	"net"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"google.golang.org/grpc/credentials"
+)
+
+// actOneNonceSize is the number of bytes at the start of the first act of an
+// LNC handshake that are used as the handshake's nonce. These are the version
+// byte and the (masked) ephemeral key of the client, which is freshly
+// generated for every handshake.
+const actOneNonceSize = 1 + 33
+
+// HandshakeTracker keeps track of the LNC handshakes of sessions.
+type HandshakeTracker interface {
+	// CheckHandshakeNonce records the nonce of a new handshake for the
+	// session with the given local public key. ErrHandshakeReplayed is
+	// returned if the nonce was already seen for the session.
+	CheckHandshakeNonce(key *btcec.PublicKey, nonce [32]byte) error
+
+	// AddConnectionAttempt records a connection attempt for the session
+	// with the given local public key.
+	AddConnectionAttempt(key *btcec.PublicKey,
+		attempt *ConnectionAttempt) error
+}
+
+// replayGuard wraps the transport credentials of a session's mailbox server.
+// It rejects handshakes that start with a first act that was already seen for
+// the session and records every connection attempt.
+type replayGuard struct {
+	credentials.TransportCredentials
+
+	localKey *btcec.PublicKey
+	tracker  HandshakeTracker
+}
+
+// newReplayGuard creates a new replayGuard for the session with the given
+// local public key.
+func newReplayGuard(creds credentials.TransportCredentials,
+	localKey *btcec.PublicKey, tracker HandshakeTracker) *replayGuard {
+
+	return &replayGuard{
+		TransportCredentials: creds,
+		localKey:             localKey,
+		tracker:              tracker,
+	}
+}
+
+// ServerHandshake runs the server part of the wrapped handshake while checking
+// the nonce of the first act and records the outcome as a connection attempt.
+//
+// NOTE: This is part of the credentials.TransportCredentials interface.
+func (g *replayGuard) ServerHandshake(conn net.Conn) (net.Conn,
+	credentials.AuthInfo, error) {
+
+	proxyConn, ok := conn.(mailbox.ProxyConn)
+	if !ok {
+		return g.TransportCredentials.ServerHandshake(conn)
+	}
+
+	secureConn, authInfo, err := g.TransportCredentials.ServerHandshake(
+		&nonceConn{
+			ProxyConn: proxyConn,
+			check: func(nonce [32]byte) error {
+				return g.tracker.CheckHandshakeNonce(
+					g.localKey, nonce,
+				)
+			},
+		},
+	)
+
+	attempt := &ConnectionAttempt{
+		Timestamp: time.Now(),
+		Accepted:  err == nil,
+	}
+	if err != nil {
+		log.Warnf("Rejected connection attempt for session %x: %v",
+			g.localKey.SerializeCompressed(), err)
+
+		attempt.Reason = err.Error()
+	}
+
+	trackErr := g.tracker.AddConnectionAttempt(g.localKey, attempt)
+	if trackErr != nil {
+		log.Errorf("Unable to record connection attempt for session "+
+			"%x: %v", g.localKey.SerializeCompressed(), trackErr)
+	}
+
+	return secureConn, authInfo, err
+}
+
+// Clone makes a copy of the replay guard and the wrapped credentials.
+//
+// NOTE: This is part of the credentials.TransportCredentials interface.
+func (g *replayGuard) Clone() credentials.TransportCredentials {
+	return newReplayGuard(
+		g.TransportCredentials.Clone(), g.localKey, g.tracker,
+	)
+}
+
+// nonceConn is a mailbox.ProxyConn that captures the start of the first act
+// of a handshake read from the connection and checks its nonce before the
+// handshake continues.
+type nonceConn struct {
+	mailbox.ProxyConn
+
+	check   func(nonce [32]byte) error
+	actOne  []byte
+	checked bool
+}
+
+// Read reads from the underlying connection. Once the nonce of the first act
+// is read, it is checked and an error is returned if the check fails.
+//
+// NOTE: This is part of the net.Conn interface.
+func (c *nonceConn) Read(b []byte) (int, error) {
+	n, err := c.ProxyConn.Read(b)
+	if c.checked || n == 0 {
+		return n, err
+	}
+
+	missing := actOneNonceSize - len(c.actOne)
+	if n < missing {
+		missing = n
+	}
+	c.actOne = append(c.actOne, b[:missing]...)
+
+	if len(c.actOne) < actOneNonceSize {
+		return n, err
+	}
+
+	c.checked = true
+	if checkErr := c.check(sha256.Sum256(c.actOne)); checkErr != nil {
+		return 0, checkErr
+	}
+
+	return n, err
+}
@@ -2,7 +2,9 @@ package session
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -11,16 +13,54 @@ import (
 	"github.com/lightningnetwork/lnd/keychain"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/keepalive"
 )
 
+// ErrServerDraining is returned if a session is started while the server
+// drains its sessions on shutdown.
+var ErrServerDraining = errors.New("session server is shutting down")
+
 type sessionID [33]byte
 
 type GRPCServerCreator func(opts ...grpc.ServerOption) *grpc.Server
 
+// ConnWrapper wraps the connections the mailbox server of a session accepts.
+// It is used to inject faults in development builds.
+type ConnWrapper func(conn mailbox.ProxyConn) mailbox.ProxyConn
+
+// wrappingListener is a listener that wraps the mailbox connections it
+// accepts.
+type wrappingListener struct {
+	net.Listener
+
+	wrap ConnWrapper
+}
+
+// Accept waits for the next connection and wraps it.
+//
+// NOTE: This is part of the net.Listener interface.
+func (l *wrappingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	proxyConn, ok := conn.(mailbox.ProxyConn)
+	if !ok {
+		return conn, nil
+	}
+
+	return l.wrap(proxyConn), nil
+}
+
 type mailboxSession struct {
 	server *grpc.Server
 
+	// serverAddr is the address of the mailbox server the session
+	// connects through and devServer is true if it is a development
+	// server.
+	serverAddr string
+	devServer  bool
+
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
@@ -31,52 +71,70 @@ func newMailboxSession() *mailboxSession {
 	}
 }
 
-func (m *mailboxSession) start(session *Session,
-	serverCreator GRPCServerCreator, authData []byte,
+func (m *mailboxSession) start(session *Session, ecdh keychain.SingleKeyECDH,
+	serverCreator GRPCServerCreator, tracker HandshakeTracker,
+	clients ClientTracker, wrapConn ConnWrapper,
+	mailboxDialOpts []grpc.DialOption, authData []byte,
 	onUpdate func(sess *Session) error,
 	onNewStatus func(s mailbox.ServerStatus)) error {
 
+	m.serverAddr = session.ServerAddr
+	m.devServer = session.DevServer
+
 	tlsConfig := &tls.Config{}
 	if session.DevServer {
 		tlsConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
-	ecdh := &keychain.PrivKeyECDH{PrivKey: session.LocalPrivateKey}
-
 	keys := mailbox.NewConnData(
-		ecdh, session.RemotePublicKey, session.PairingSecret[:],
+		ecdh, session.RemotePublicKey, session.HandshakeSecret(),
 		authData, func(key *btcec.PublicKey) error {
 			session.RemotePublicKey = key
 			return onUpdate(session)
 		}, nil,
 	)
 
-	// Start the mailbox gRPC server.
-	mailboxServer, err := mailbox.NewServer(
-		session.ServerAddr, keys, onNewStatus,
+	// Start the mailbox gRPC server. The dial options contain the
+	// heartbeat that keeps the connection to the mailbox server alive.
+	dialOpts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time: 2 * time.Minute,
-		}),
+	}, mailboxDialOpts...)
+	mailboxServer, err := mailbox.NewServer(
+		session.ServerAddr, keys, onNewStatus, dialOpts...,
 	)
 	if err != nil {
 		return err
 	}
 
 	noiseConn := mailbox.NewNoiseGrpcConn(keys)
-	m.server = serverCreator(grpc.Creds(noiseConn))
+	clientGuard := newClientGuard(session, clients)
+	m.server = serverCreator(
+		grpc.Creds(newReplayGuard(
+			noiseConn, session.LocalPublicKey, tracker,
+		)),
+		grpc.ChainUnaryInterceptor(clientGuard.unaryInterceptor),
+		grpc.ChainStreamInterceptor(clientGuard.streamInterceptor),
+	)
+
+	var listener net.Listener = mailboxServer
+	if wrapConn != nil {
+		listener = &wrappingListener{
+			Listener: mailboxServer,
+			wrap:     wrapConn,
+		}
+	}
 
 	m.wg.Add(1)
-	go m.run(mailboxServer)
+	go m.run(listener)
 
 	return nil
 }
 
-func (m *mailboxSession) run(mailboxServer *mailbox.Server) {
+func (m *mailboxSession) run(listener net.Listener) {
 	defer m.wg.Done()
 
-	log.Infof("Mailbox RPC server listening on %s", mailboxServer.Addr())
-	if err := m.server.Serve(mailboxServer); err != nil {
+	log.Infof("Mailbox RPC server listening on %s", listener.Addr())
+	if err := m.server.Serve(listener); err != nil {
 		log.Errorf("Unable to serve mailbox gRPC: %v", err)
 	}
 }
@@ -87,20 +145,63 @@ func (m *mailboxSession) stop() {
 	m.wg.Wait()
 }
 
+// drain stops the session gracefully, which lets the calls in flight finish
+// but rejects new ones. If the calls don't finish within the given timeout,
+// the session is stopped forcefully.
+func (m *mailboxSession) drain(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		m.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Warnf("Mailbox RPC server still busy after %v, stopping it",
+			timeout)
+		m.server.Stop()
+		<-done
+	}
+
+	close(m.quit)
+	m.wg.Wait()
+}
+
 type Server struct {
 	serverCreator GRPCServerCreator
+	tracker       HandshakeTracker
+	clients       ClientTracker
+	wrapConn      ConnWrapper
+	keys          KeyBackend
+
+	// mailboxDialOpts are the extra options of the connections to the
+	// mailbox servers.
+	mailboxDialOpts []grpc.DialOption
 
 	activeSessions    map[sessionID]*mailboxSession
 	activeSessionsMtx sync.Mutex
 
+	// draining is set once the server drains its sessions on shutdown,
+	// after which no new sessions are started.
+	draining bool
+
 	quit chan struct{}
 }
 
-func NewServer(serverCreator GRPCServerCreator) *Server {
+func NewServer(serverCreator GRPCServerCreator, tracker HandshakeTracker,
+	clients ClientTracker, wrapConn ConnWrapper, keys KeyBackend,
+	mailboxDialOpts []grpc.DialOption) *Server {
+
 	return &Server{
-		serverCreator:  serverCreator,
-		activeSessions: make(map[sessionID]*mailboxSession),
-		quit:           make(chan struct{}),
+		serverCreator:   serverCreator,
+		tracker:         tracker,
+		clients:         clients,
+		wrapConn:        wrapConn,
+		keys:            keys,
+		mailboxDialOpts: mailboxDialOpts,
+		activeSessions:  make(map[sessionID]*mailboxSession),
+		quit:            make(chan struct{}),
 	}
 }
 
@@ -111,6 +212,10 @@ func (s *Server) StartSession(session *Session, authData []byte,
 	s.activeSessionsMtx.Lock()
 	defer s.activeSessionsMtx.Unlock()
 
+	if s.draining {
+		return nil, ErrServerDraining
+	}
+
 	var id sessionID
 	copy(id[:], session.LocalPublicKey.SerializeCompressed())
 
@@ -119,11 +224,17 @@ func (s *Server) StartSession(session *Session, authData []byte,
 		return nil, fmt.Errorf("session %x is already active", id[:])
 	}
 
+	ecdh, err := s.keys.ECDH(session)
+	if err != nil {
+		return nil, err
+	}
+
 	sess := newMailboxSession()
 	s.activeSessions[id] = sess
 
 	return sess.quit, sess.start(
-		session, s.serverCreator, authData, onUpdate, onNewStatus,
+		session, ecdh, s.serverCreator, s.tracker, s.clients, s.wrapConn,
+		s.mailboxDialOpts, authData, onUpdate, onNewStatus,
 	)
 }
 
@@ -145,6 +256,24 @@ func (s *Server) StopSession(localPublicKey *btcec.PublicKey) error {
 	return nil
 }
 
+// MailboxServers returns the addresses of the mailbox servers the active
+// sessions connect through, mapped to whether they are development servers.
+func (s *Server) MailboxServers() map[string]bool {
+	s.activeSessionsMtx.Lock()
+	defer s.activeSessionsMtx.Unlock()
+
+	servers := make(map[string]bool)
+	for _, session := range s.activeSessions {
+		if session.serverAddr == "" {
+			continue
+		}
+
+		servers[session.serverAddr] = session.devServer
+	}
+
+	return servers
+}
+
 func (s *Server) Stop() {
 	s.activeSessionsMtx.Lock()
 	defer s.activeSessionsMtx.Unlock()
@@ -154,3 +283,30 @@ func (s *Server) Stop() {
 		delete(s.activeSessions, id)
 	}
 }
+
+// Drain stops all active sessions gracefully and returns their number. The
+// calls in flight are given the given timeout to finish. The sessions are
+// drained concurrently, so the whole drain takes at most the timeout. Once
+// the server is draining, no new sessions can be started.
+func (s *Server) Drain(timeout time.Duration) int {
+	s.activeSessionsMtx.Lock()
+	defer s.activeSessionsMtx.Unlock()
+
+	s.draining = true
+	numSessions := len(s.activeSessions)
+
+	var wg sync.WaitGroup
+	for id, session := range s.activeSessions {
+		wg.Add(1)
+		go func(session *mailboxSession) {
+			defer wg.Done()
+
+			session.drain(timeout)
+		}(session)
+
+		delete(s.activeSessions, id)
+	}
+	wg.Wait()
+
+	return numSessions
+}
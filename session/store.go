@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
-	"go.etcd.io/bbolt"
+	"github.com/lightningnetwork/lnd/kvdb"
 )
 
 var (
@@ -35,21 +35,21 @@ func (db *DB) StoreSession(session *Session) error {
 	}
 	sessionKey := getSessionKey(session)
 
-	return db.Update(func(tx *bbolt.Tx) error {
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
 		sessionBucket, err := getBucket(tx, sessionBucketKey)
 		if err != nil {
 			return err
 		}
 
 		return sessionBucket.Put(sessionKey, buf.Bytes())
-	})
+	}, func() {})
 }
 
 // GetSession fetches the session with the given key.
 func (db *DB) GetSession(key *btcec.PublicKey) (*Session, error) {
 	var session *Session
-	err := db.View(func(tx *bbolt.Tx) error {
-		sessionBucket, err := getBucket(tx, sessionBucketKey)
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		sessionBucket, err := getReadBucket(tx, sessionBucketKey)
 		if err != nil {
 			return err
 		}
@@ -65,7 +65,7 @@ func (db *DB) GetSession(key *btcec.PublicKey) (*Session, error) {
 		}
 
 		return nil
-	})
+	}, func() {})
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +76,8 @@ func (db *DB) GetSession(key *btcec.PublicKey) (*Session, error) {
 // ListSessions returns all sessions currently known to the store.
 func (db *DB) ListSessions(filterFn func(s *Session) bool) ([]*Session, error) {
 	var sessions []*Session
-	err := db.View(func(tx *bbolt.Tx) error {
-		sessionBucket, err := getBucket(tx, sessionBucketKey)
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		sessionBucket, err := getReadBucket(tx, sessionBucketKey)
 		if err != nil {
 			return err
 		}
@@ -102,6 +102,8 @@ func (db *DB) ListSessions(filterFn func(s *Session) bool) ([]*Session, error) {
 
 			return nil
 		})
+	}, func() {
+		sessions = nil
 	})
 	if err != nil {
 		return nil, err
@@ -111,11 +113,14 @@ func (db *DB) ListSessions(filterFn func(s *Session) bool) ([]*Session, error) {
 }
 
 // RevokeSession updates the state of the session with the given local
-// public key to be revoked.
-func (db *DB) RevokeSession(key *btcec.PublicKey) error {
+// public key to be revoked. The revocation is recorded with the given actor
+// and reason.
+func (db *DB) RevokeSession(key *btcec.PublicKey, revokedBy,
+	reason string) error {
+
 	var session *Session
-	err := db.View(func(tx *bbolt.Tx) error {
-		sessionBucket, err := getBucket(tx, sessionBucketKey)
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		sessionBucket, err := getReadBucket(tx, sessionBucketKey)
 		if err != nil {
 			return err
 		}
@@ -127,13 +132,84 @@ func (db *DB) RevokeSession(key *btcec.PublicKey) error {
 
 		session, err = DeserializeSession(bytes.NewReader(sessionBytes))
 		return err
-	})
+	}, func() {})
 	if err != nil {
 		return err
 	}
 
 	session.State = StateRevoked
 	session.RevokedAt = time.Now()
+	session.RevokedBy = revokedBy
+	session.RevocationReason = reason
 
 	return db.StoreSession(session)
 }
+
+// UpdateSessionNotes replaces the notes and tags of the session with the given
+// local public key.
+func (db *DB) UpdateSessionNotes(key *btcec.PublicKey, notes string,
+	tags map[string]string) error {
+
+	var buf bytes.Buffer
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+		sessionBucket, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		sessionBytes := sessionBucket.Get(key.SerializeCompressed())
+		if len(sessionBytes) == 0 {
+			return ErrSessionNotFound
+		}
+
+		session, err := DeserializeSession(bytes.NewReader(sessionBytes))
+		if err != nil {
+			return err
+		}
+
+		session.Notes = notes
+		session.Tags = tags
+
+		if err := SerializeSession(&buf, session); err != nil {
+			return err
+		}
+
+		return sessionBucket.Put(key.SerializeCompressed(), buf.Bytes())
+	}, func() {
+		buf.Reset()
+	})
+}
+
+// UpdateSessionClient sets the identity of the client that was last seen
+// using the session with the given local public key.
+func (db *DB) UpdateSessionClient(key *btcec.PublicKey,
+	client *ClientIdentity) error {
+
+	var buf bytes.Buffer
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+		sessionBucket, err := getBucket(tx, sessionBucketKey)
+		if err != nil {
+			return err
+		}
+
+		sessionBytes := sessionBucket.Get(key.SerializeCompressed())
+		if len(sessionBytes) == 0 {
+			return ErrSessionNotFound
+		}
+
+		session, err := DeserializeSession(bytes.NewReader(sessionBytes))
+		if err != nil {
+			return err
+		}
+
+		session.Client = client
+
+		if err := SerializeSession(&buf, session); err != nil {
+			return err
+		}
+
+		return sessionBucket.Put(key.SerializeCompressed(), buf.Bytes())
+	}, func() {
+		buf.Reset()
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightninglabs/lightning-node-connect/mailbox"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/tlv"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
@@ -28,6 +30,21 @@ const (
 	typeFeaturesConfig  tlv.Type = 14
 	typeWithPrivacy     tlv.Type = 15
 	typeRevokedAt       tlv.Type = 16
+	typeNotes           tlv.Type = 17
+	typeTags            tlv.Type = 18
+	typeRevokedBy       tlv.Type = 19
+	typeRevokeReason    tlv.Type = 20
+	typePinClient       tlv.Type = 21
+	typeClientUserAgent tlv.Type = 22
+	typeClientInfo      tlv.Type = 23
+	typeClientFirstSeen tlv.Type = 24
+	typeLocalPublicKey  tlv.Type = 25
+	typeKeyFamily       tlv.Type = 26
+	typeKeyIndex        tlv.Type = 27
+	typeOfflinePhrase   tlv.Type = 28
+	typeRouteHints      tlv.Type = 29
+	typePassphrase      tlv.Type = 30
+	typeBlockOnChain    tlv.Type = 31
 
 	// typeMacaroon is no longer used, but we leave it defined for backwards
 	// compatibility.
@@ -45,6 +62,9 @@ const (
 
 	typeFeatureName   tlv.Type = 1
 	typeFeatureConfig tlv.Type = 2
+
+	typeTagKey   tlv.Type = 1
+	typeTagValue tlv.Type = 2
 )
 
 // SerializeSession binary serializes the given session to the writer using the
@@ -62,7 +82,6 @@ func SerializeSession(w io.Writer, session *Session) error {
 		serverAddr    = []byte(session.ServerAddr)
 		devServer     = uint8(0)
 		pairingSecret = session.PairingSecret[:]
-		privateKey    = session.LocalPrivateKey.Serialize()
 		createdAt     = uint64(session.CreatedAt.Unix())
 		revokedAt     uint64
 		withPrivacy   = uint8(0)
@@ -95,9 +114,17 @@ func SerializeSession(w io.Writer, session *Session) error {
 	tlvRecords = append(
 		tlvRecords,
 		tlv.MakePrimitiveRecord(typePairingSecret, &pairingSecret),
-		tlv.MakePrimitiveRecord(typeLocalPrivateKey, &privateKey),
 	)
 
+	// The private key is only stored if it isn't kept by an external key
+	// backend.
+	if session.LocalPrivateKey != nil {
+		privateKey := session.LocalPrivateKey.Serialize()
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeLocalPrivateKey, &privateKey,
+		))
+	}
+
 	if session.RemotePublicKey != nil {
 		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
 			typeRemotePublicKey, &session.RemotePublicKey,
@@ -140,6 +167,101 @@ func SerializeSession(w io.Writer, session *Session) error {
 		tlv.MakePrimitiveRecord(typeRevokedAt, &revokedAt),
 	)
 
+	if session.Notes != "" {
+		notes := []byte(session.Notes)
+		tlvRecords = append(
+			tlvRecords, tlv.MakePrimitiveRecord(typeNotes, &notes),
+		)
+	}
+
+	if len(session.Tags) != 0 {
+		tlvRecords = append(tlvRecords, tlv.MakeDynamicRecord(
+			typeTags, &session.Tags,
+			func() uint64 {
+				return recordSize(tagsEncoder, &session.Tags)
+			},
+			tagsEncoder, tagsDecoder,
+		))
+	}
+
+	if session.RevokedBy != "" {
+		revokedBy := []byte(session.RevokedBy)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeRevokedBy, &revokedBy,
+		))
+	}
+
+	if session.RevocationReason != "" {
+		reason := []byte(session.RevocationReason)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeRevokeReason, &reason,
+		))
+	}
+
+	if session.PinClient {
+		pinClient := uint8(1)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typePinClient, &pinClient,
+		))
+	}
+
+	if session.Client != nil {
+		var (
+			userAgent  = []byte(session.Client.UserAgent)
+			clientInfo = []byte(session.Client.Info)
+			firstSeen  = uint64(session.Client.FirstSeen.Unix())
+		)
+		tlvRecords = append(
+			tlvRecords,
+			tlv.MakePrimitiveRecord(typeClientUserAgent, &userAgent),
+			tlv.MakePrimitiveRecord(typeClientInfo, &clientInfo),
+			tlv.MakePrimitiveRecord(typeClientFirstSeen, &firstSeen),
+		)
+	}
+
+	if session.KeyLocator != nil {
+		var (
+			family = uint32(session.KeyLocator.Family)
+			index  = session.KeyLocator.Index
+		)
+		tlvRecords = append(
+			tlvRecords,
+			tlv.MakePrimitiveRecord(
+				typeLocalPublicKey, &session.LocalPublicKey,
+			),
+			tlv.MakePrimitiveRecord(typeKeyFamily, &family),
+			tlv.MakePrimitiveRecord(typeKeyIndex, &index),
+		)
+	}
+
+	if session.OfflinePairingPhrase {
+		offlinePhrase := uint8(1)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeOfflinePhrase, &offlinePhrase,
+		))
+	}
+
+	if session.RouteHintPolicy != RouteHintsAllow {
+		routeHints := uint8(session.RouteHintPolicy)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeRouteHints, &routeHints,
+		))
+	}
+
+	if session.PassphraseSecret != nil {
+		passphraseSecret := session.PassphraseSecret[:]
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typePassphrase, &passphraseSecret,
+		))
+	}
+
+	if session.BlockOnChainSpend {
+		blockOnChain := uint8(1)
+		tlvRecords = append(tlvRecords, tlv.MakePrimitiveRecord(
+			typeBlockOnChain, &blockOnChain,
+		))
+	}
+
 	tlvStream, err := tlv.NewStream(tlvRecords...)
 	if err != nil {
 		return err
@@ -153,12 +275,21 @@ func SerializeSession(w io.Writer, session *Session) error {
 func DeserializeSession(r io.Reader) (*Session, error) {
 	var (
 		session                        = &Session{}
-		label, serverAddr              []byte
+		label, serverAddr, notes       []byte
+		revokedBy, revokeReason        []byte
 		pairingSecret, privateKey      []byte
+		passphraseSecret               []byte
+		userAgent, clientInfo          []byte
 		state, typ, devServer, privacy uint8
+		pinClient, offlinePhrase       uint8
+		routeHints, blockOnChain       uint8
 		expiry, createdAt, revokedAt   uint64
+		clientFirstSeen                uint64
+		keyFamily, keyIndex            uint32
+		localPubKey                    *btcec.PublicKey
 		macRecipe                      MacaroonRecipe
 		featureConfig                  FeaturesConfig
+		tags                           map[string]string
 	)
 	tlvStream, err := tlv.NewStream(
 		tlv.MakePrimitiveRecord(typeLabel, &label),
@@ -186,6 +317,23 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 		),
 		tlv.MakePrimitiveRecord(typeWithPrivacy, &privacy),
 		tlv.MakePrimitiveRecord(typeRevokedAt, &revokedAt),
+		tlv.MakePrimitiveRecord(typeNotes, &notes),
+		tlv.MakeDynamicRecord(
+			typeTags, &tags, nil, tagsEncoder, tagsDecoder,
+		),
+		tlv.MakePrimitiveRecord(typeRevokedBy, &revokedBy),
+		tlv.MakePrimitiveRecord(typeRevokeReason, &revokeReason),
+		tlv.MakePrimitiveRecord(typePinClient, &pinClient),
+		tlv.MakePrimitiveRecord(typeClientUserAgent, &userAgent),
+		tlv.MakePrimitiveRecord(typeClientInfo, &clientInfo),
+		tlv.MakePrimitiveRecord(typeClientFirstSeen, &clientFirstSeen),
+		tlv.MakePrimitiveRecord(typeLocalPublicKey, &localPubKey),
+		tlv.MakePrimitiveRecord(typeKeyFamily, &keyFamily),
+		tlv.MakePrimitiveRecord(typeKeyIndex, &keyIndex),
+		tlv.MakePrimitiveRecord(typeOfflinePhrase, &offlinePhrase),
+		tlv.MakePrimitiveRecord(typeRouteHints, &routeHints),
+		tlv.MakePrimitiveRecord(typePassphrase, &passphraseSecret),
+		tlv.MakePrimitiveRecord(typeBlockOnChain, &blockOnChain),
 	)
 	if err != nil {
 		return nil, err
@@ -205,6 +353,13 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 	session.ServerAddr = string(serverAddr)
 	session.DevServer = devServer == 1
 	session.WithPrivacyMapper = privacy == 1
+	session.Notes = string(notes)
+	session.RevokedBy = string(revokedBy)
+	session.RevocationReason = string(revokeReason)
+	session.PinClient = pinClient == 1
+	session.OfflinePairingPhrase = offlinePhrase == 1
+	session.RouteHintPolicy = RouteHintPolicy(routeHints)
+	session.BlockOnChainSpend = blockOnChain == 1
 
 	if revokedAt != 0 {
 		session.RevokedAt = time.Unix(int64(revokedAt), 0)
@@ -218,16 +373,46 @@ func DeserializeSession(r io.Reader) (*Session, error) {
 		copy(session.PairingSecret[:], pairingSecret)
 	}
 
+	if t, ok := parsedTypes[typePassphrase]; ok && t == nil {
+		var secret [mailbox.NumPassphraseEntropyBytes]byte
+		if len(passphraseSecret) != len(secret) {
+			return nil, fmt.Errorf("invalid passphrase secret "+
+				"length %d", len(passphraseSecret))
+		}
+		copy(secret[:], passphraseSecret)
+		session.PassphraseSecret = &secret
+	}
+
 	if t, ok := parsedTypes[typeLocalPrivateKey]; ok && t == nil {
 		session.LocalPrivateKey, session.LocalPublicKey = btcec.PrivKeyFromBytes(
 			privateKey,
 		)
 	}
 
+	if t, ok := parsedTypes[typeKeyFamily]; ok && t == nil {
+		session.LocalPublicKey = localPubKey
+		session.KeyLocator = &keychain.KeyLocator{
+			Family: keychain.KeyFamily(keyFamily),
+			Index:  keyIndex,
+		}
+	}
+
 	if t, ok := parsedTypes[typeFeaturesConfig]; ok && t == nil {
 		session.FeatureConfig = &featureConfig
 	}
 
+	if t, ok := parsedTypes[typeTags]; ok && t == nil {
+		session.Tags = tags
+	}
+
+	if t, ok := parsedTypes[typeClientFirstSeen]; ok && t == nil {
+		session.Client = &ClientIdentity{
+			UserAgent: string(userAgent),
+			Info:      string(clientInfo),
+			FirstSeen: time.Unix(int64(clientFirstSeen), 0),
+		}
+	}
+
 	return session, nil
 }
 
@@ -333,6 +518,99 @@ func featureConfigDecoder(r io.Reader, val interface{}, buf *[8]byte,
 	return tlv.NewTypeForEncodingErr(val, "FeaturesConfig")
 }
 
+// tagsEncoder is a custom TLV encoder for the session tags record.
+func tagsEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
+	if v, ok := val.(*map[string]string); ok {
+		for k, tagValue := range *v {
+			key := []byte(k)
+			value := []byte(tagValue)
+
+			var tagTLVBytes bytes.Buffer
+			tlvStream, err := tlv.NewStream(
+				tlv.MakePrimitiveRecord(typeTagKey, &key),
+				tlv.MakePrimitiveRecord(typeTagValue, &value),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = tlvStream.Encode(&tagTLVBytes)
+			if err != nil {
+				return err
+			}
+
+			// We encode the record with a varint length followed by
+			// the _raw_ TLV bytes.
+			tlvLen := uint64(len(tagTLVBytes.Bytes()))
+			if err := tlv.WriteVarInt(w, tlvLen, buf); err != nil {
+				return err
+			}
+
+			_, err = w.Write(tagTLVBytes.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return tlv.NewTypeForEncodingErr(val, "Tags")
+}
+
+// tagsDecoder is a custom TLV decoder for the session tags record.
+func tagsDecoder(r io.Reader, val interface{}, buf *[8]byte, l uint64) error {
+	if v, ok := val.(*map[string]string); ok {
+		tags := make(map[string]string)
+
+		// Using this information, we'll create a new limited
+		// reader that'll return an EOF once the end has been
+		// reached so the stream stops consuming bytes.
+		innerTlvReader := io.LimitedReader{
+			R: r,
+			N: int64(l),
+		}
+
+		for {
+			// Read out the varint that encodes the size of this
+			// inner TLV record.
+			blobSize, err := tlv.ReadVarInt(&innerTlvReader, buf)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+
+			innerInnerTlvReader := io.LimitedReader{
+				R: &innerTlvReader,
+				N: int64(blobSize),
+			}
+
+			var key, value []byte
+			tlvStream, err := tlv.NewStream(
+				tlv.MakePrimitiveRecord(typeTagKey, &key),
+				tlv.MakePrimitiveRecord(typeTagValue, &value),
+			)
+			if err != nil {
+				return err
+			}
+
+			err = tlvStream.Decode(&innerInnerTlvReader)
+			if err != nil {
+				return err
+			}
+
+			tags[string(key)] = string(value)
+		}
+
+		*v = tags
+
+		return nil
+	}
+
+	return tlv.NewTypeForDecodingErr(val, "Tags", l, l)
+}
+
 // macaroonRecipeEncoder is a custom TLV encoder for a MacaroonRecipe record.
 func macaroonRecipeEncoder(w io.Writer, val interface{}, buf *[8]byte) error {
 	if v, ok := val.(*MacaroonRecipe); ok {
@@ -2,10 +2,12 @@ package session
 
 import (
 	"bytes"
+	"context"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/tlv"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/macaroon-bakery.v2/bakery"
@@ -57,6 +59,17 @@ func TestSerializeDeserializeSession(t *testing.T) {
 		perms         []bakery.Op
 		caveats       []macaroon.Caveat
 		featureConfig map[string][]byte
+		notes         string
+		tags          map[string]string
+		revokedBy     string
+		revokeReason  string
+		pinClient     bool
+		client        *ClientIdentity
+		keyLocator    *keychain.KeyLocator
+		offlinePhrase bool
+		routeHints    RouteHintPolicy
+		passphrase    string
+		blockOnChain  bool
 	}{
 		{
 			name:     "session 1",
@@ -79,12 +92,91 @@ func TestSerializeDeserializeSession(t *testing.T) {
 				"AutoSomething": {4, 3, 4, 5, 6, 6},
 			},
 		},
+		{
+			name:     "revoked session with actor and reason",
+			sessType: TypeMacaroonAccount,
+			revokedAt: time.Date(
+				2023, 1, 10, 10, 10, 0, 0, time.UTC,
+			),
+			revokedBy:    "oidc:alice",
+			revokeReason: "device was lost",
+		},
+		{
+			name:     "session with notes and tags",
+			sessType: TypeMacaroonAdmin,
+			notes:    "session for the shop's point of sale",
+			tags: map[string]string{
+				"customer": "alice",
+				"device":   "pos-1",
+			},
+		},
+		{
+			name:      "session pinned to a client",
+			sessType:  TypeMacaroonReadonly,
+			pinClient: true,
+			client: &ClientIdentity{
+				UserAgent: "grpc-go/1.56.3",
+				Info:      "shop-app/2.1.0",
+				FirstSeen: time.Unix(1673345400, 0),
+			},
+		},
+		{
+			name:     "session with an externally kept key",
+			sessType: TypeMacaroonAdmin,
+			keyLocator: &keychain.KeyLocator{
+				Family: LNCKeyFamily,
+				Index:  7,
+			},
+		},
+		{
+			name:          "session with an offline pairing phrase",
+			sessType:      TypeMacaroonAdmin,
+			offlinePhrase: true,
+		},
+		{
+			name:       "session that strips route hints",
+			sessType:   TypeMacaroonAccount,
+			routeHints: RouteHintsStrip,
+		},
+		{
+			name:          "session with an external key and all flags",
+			sessType:      TypeMacaroonAdmin,
+			offlinePhrase: true,
+			routeHints:    RouteHintsExplicitOnly,
+			passphrase:    "hunter2",
+			keyLocator: &keychain.KeyLocator{
+				Family: LNCKeyFamily,
+				Index:  9,
+			},
+		},
+		{
+			name:       "session protected by a passphrase",
+			sessType:   TypeMacaroonReadonly,
+			passphrase: "correct horse battery staple",
+		},
+		{
+			name:         "session that can't spend on-chain",
+			sessType:     TypeAutopilot,
+			blockOnChain: true,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			key, err := (&LocalKeyBackend{}).NewKey(
+				context.Background(),
+			)
+			require.NoError(t, err)
+
+			// A key that is kept by an external key backend isn't
+			// stored with the session.
+			if test.keyLocator != nil {
+				key.PrivKey = nil
+				key.Locator = test.keyLocator
+			}
+
 			session, err := NewSession(
-				test.name, test.sessType,
+				key, test.name, test.sessType,
 				time.Date(99999, 1, 1, 0, 0, 0, 0, time.UTC),
 				"foo.bar.baz:1234", true, test.perms,
 				test.caveats, test.featureConfig, true,
@@ -92,6 +184,22 @@ func TestSerializeDeserializeSession(t *testing.T) {
 			require.NoError(t, err)
 
 			session.RevokedAt = test.revokedAt
+			session.Notes = test.notes
+			session.Tags = test.tags
+			session.RevokedBy = test.revokedBy
+			session.RevocationReason = test.revokeReason
+			session.PinClient = test.pinClient
+			session.Client = test.client
+			session.OfflinePairingPhrase = test.offlinePhrase
+			session.RouteHintPolicy = test.routeHints
+			session.BlockOnChainSpend = test.blockOnChain
+
+			if test.passphrase != "" {
+				err := session.ProtectWithPassphrase(
+					[]byte(test.passphrase),
+				)
+				require.NoError(t, err)
+			}
 
 			_, remotePubKey := btcec.PrivKeyFromBytes(testRootKey)
 			session.RemotePublicKey = remotePubKey
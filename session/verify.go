@@ -0,0 +1,107 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/dbverify"
+	"go.etcd.io/bbolt"
+)
+
+// VerifyRecords decodes all sessions and connection records of the session
+// database.
+func VerifyRecords(tx *bbolt.Tx, c *dbverify.Checker) error {
+	if bucket := tx.Bucket(sessionBucketKey); bucket != nil {
+		path := [][]byte{sessionBucketKey}
+		err := bucket.ForEach(func(k, v []byte) error {
+			// The session bucket also holds nested buckets, which
+			// are identified by a nil value.
+			if v == nil {
+				return nil
+			}
+
+			c.Check(path, k, func() error {
+				_, err := DeserializeSession(bytes.NewReader(v))
+				return err
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	bucket := tx.Bucket(connectionsBucketKey)
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.ForEach(func(sessionKey, v []byte) error {
+		path := [][]byte{connectionsBucketKey}
+		if v != nil {
+			c.Corrupt(path, sessionKey, fmt.Errorf("expected "+
+				"connections bucket"))
+
+			return nil
+		}
+
+		return verifyConnections(
+			bucket.Bucket(sessionKey), append(path, sessionKey), c,
+		)
+	})
+}
+
+// verifyConnections decodes the handshake nonces and connection attempts of a
+// session.
+func verifyConnections(connBucket *bbolt.Bucket, path [][]byte,
+	c *dbverify.Checker) error {
+
+	// The nonces are keyed by the nonce and reference their index entry,
+	// the index entries are keyed by their sequence number and hold the
+	// nonce.
+	lengths := map[string]int{
+		string(noncesBucketKey):     8,
+		string(nonceIndexBucketKey): 32,
+	}
+	for name, length := range lengths {
+		bucket := connBucket.Bucket([]byte(name))
+		if bucket == nil {
+			continue
+		}
+
+		bucketPath := append(path, []byte(name))
+		err := bucket.ForEach(func(k, v []byte) error {
+			c.Check(bucketPath, k, func() error {
+				if len(v) != length {
+					return fmt.Errorf("invalid length %d",
+						len(v))
+				}
+
+				return nil
+			})
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	attempts := connBucket.Bucket(attemptsBucketKey)
+	if attempts == nil {
+		return nil
+	}
+
+	attemptsPath := append(path, attemptsBucketKey)
+	return attempts.ForEach(func(k, v []byte) error {
+		c.Check(attemptsPath, k, func() error {
+			_, err := deserializeConnectionAttempt(
+				bytes.NewReader(v),
+			)
+			return err
+		})
+
+		return nil
+	})
+}
@@ -1,10 +1,15 @@
 package terminal
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -16,11 +21,14 @@ import (
 	"github.com/lightninglabs/lightning-terminal/firewall"
 	"github.com/lightninglabs/lightning-terminal/firewalldb"
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/maccache"
 	"github.com/lightninglabs/lightning-terminal/perms"
 	"github.com/lightninglabs/lightning-terminal/rules"
 	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
 	"gopkg.in/macaroon.v2"
@@ -34,6 +42,29 @@ import (
 // other special cases.
 const readOnlyAction = "***readonly***"
 
+// ipLockCondition is the condition of lnd's IP lock caveat.
+const ipLockCondition = "ipaddr"
+
+// maxActivityIntervals is the maximum number of intervals a SessionActivity
+// call can span.
+const maxActivityIntervals = 1000
+
+// maxRecentActions is the maximum number of recent actions that can be
+// requested when subscribing to actions.
+const maxRecentActions = 1000
+
+const (
+	// reasonExpired is the revocation reason of sessions that litd
+	// revoked because they expired.
+	reasonExpired = "session expired"
+
+	// reasonFirstConnDeadline is the revocation reason of sessions that
+	// litd revoked because no connection was made before the deadline for
+	// the first connection.
+	reasonFirstConnDeadline = "no connection before the first " +
+		"connection deadline"
+)
+
 // sessionRpcServer is the gRPC server for the Session RPC interface.
 type sessionRpcServer struct {
 	litrpc.UnimplementedSessionsServer
@@ -44,6 +75,10 @@ type sessionRpcServer struct {
 	db            *session.DB
 	sessionServer *session.Server
 
+	// addSessionMu serializes AddSession calls so that two calls with the
+	// same idempotent label can't both create a session.
+	addSessionMu sync.Mutex
+
 	quit     chan struct{}
 	wg       sync.WaitGroup
 	stopOnce sync.Once
@@ -54,23 +89,45 @@ type sessionRpcServer struct {
 type sessionRpcServerConfig struct {
 	basicAuth               string
 	dbDir                   string
+	dbBackend               kvdb.Backend
 	grpcOptions             []grpc.ServerOption
+	mailboxDialOptions      []grpc.DialOption
 	registerGrpcServers     func(server *grpc.Server)
 	superMacBaker           session.MacaroonBaker
 	firstConnectionDeadline time.Duration
+	sessionDrainTimeout     time.Duration
+	sessionCfg              *session.Config
 	permMgr                 *perms.Manager
 	actionsDB               *firewalldb.DB
 	autopilot               autopilotserver.Autopilot
 	ruleMgrs                rules.ManagerSet
+	ruleBundles             *rules.BundleStore
 	privMap                 firewalldb.NewPrivacyMapDB
+	accountService          *accounts.InterceptorService
+	auditor                 accounts.Auditor
+	redactMissionControl    bool
+	firewallSettings        *firewall.SettingsManager
+	wrapLNCConn             session.ConnWrapper
+	keyBackend              session.KeyBackend
+	macCache                *maccache.Cache
+	lndValidator            session.SuperMacaroonValidator
 }
 
 // newSessionRPCServer creates a new sessionRpcServer using the passed config.
 func newSessionRPCServer(cfg *sessionRpcServerConfig) (*sessionRpcServer,
 	error) {
 
-	// Create an instance of the local Terminal Connect session store DB.
-	db, err := session.NewDB(cfg.dbDir, session.DBFilename)
+	// Create an instance of the local Terminal Connect session store DB,
+	// unless the sessions are stored in the shared database of a cluster.
+	var (
+		db  *session.DB
+		err error
+	)
+	if cfg.dbBackend != nil {
+		db, err = session.NewDBFromBackend(cfg.dbBackend)
+	} else {
+		db, err = session.NewDB(cfg.dbDir, session.DBFilename)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error creating session DB: %v", err)
 	}
@@ -86,7 +143,8 @@ func newSessionRPCServer(cfg *sessionRpcServerConfig) (*sessionRpcServer,
 			cfg.registerGrpcServers(grpcServer)
 
 			return grpcServer
-		},
+		}, db, db, cfg.wrapLNCConn, cfg.keyBackend,
+		cfg.mailboxDialOptions,
 	)
 
 	return &sessionRpcServer{
@@ -152,8 +210,11 @@ func (s *sessionRpcServer) start() error {
 					err)
 
 				if perm {
-					err := s.db.RevokeSession(
+					err := s.revokeSession(
 						sess.LocalPublicKey,
+						accounts.ActorLitd,
+						"autopilot server rejected "+
+							"the session",
 					)
 					if err != nil {
 						log.Errorf("error revoking "+
@@ -190,13 +251,51 @@ func (s *sessionRpcServer) stop() error {
 	return returnErr
 }
 
+// drainSessions stops all active LNC sessions gracefully so that their calls
+// in flight can finish within the configured drain timeout. No new sessions
+// are started afterwards.
+func (s *sessionRpcServer) drainSessions() {
+	timeout := s.cfg.sessionDrainTimeout
+	numSessions := s.sessionServer.Drain(timeout)
+
+	log.Infof("Drained %d active LNC sessions", numSessions)
+}
+
 // AddSession adds and starts a new Terminal Connect session.
-func (s *sessionRpcServer) AddSession(_ context.Context,
+func (s *sessionRpcServer) AddSession(ctx context.Context,
 	req *litrpc.AddSessionRequest) (*litrpc.AddSessionResponse, error) {
 
-	expiry := time.Unix(int64(req.ExpiryTimestampSeconds), 0)
-	if time.Now().After(expiry) {
-		return nil, fmt.Errorf("expiry must be in the future")
+	resp, err := s.addSession(ctx, req, nil)
+	if err != nil || resp.Existing {
+		return resp, err
+	}
+
+	// The passphrase must not end up in the action log.
+	auditReq := proto.Clone(req).(*litrpc.AddSessionRequest)
+	auditReq.PairingPassphrase = ""
+
+	s.recordSessionCreation(
+		ctx, "/litrpc.Sessions/AddSession", resp, auditReq,
+	)
+
+	return resp, nil
+}
+
+// addSession adds and starts a new session for the given request. The given
+// caveats are added to the session's macaroon in addition to the ones that are
+// requested.
+func (s *sessionRpcServer) addSession(ctx context.Context,
+	req *litrpc.AddSessionRequest,
+	extraCaveats []macaroon.Caveat) (*litrpc.AddSessionResponse, error) {
+
+	// A zero expiry means that the configured default expiry is used.
+	var expiry time.Time
+	if req.ExpiryTimestampSeconds != 0 {
+		expiry = time.Unix(int64(req.ExpiryTimestampSeconds), 0)
+	}
+	expiry, err := s.cfg.sessionCfg.Expiry(expiry, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
 	typ, err := unmarshalRPCType(req.SessionType)
@@ -204,6 +303,48 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		return nil, err
 	}
 
+	routeHints, err := unmarshalRouteHintPolicy(req.RouteHintPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTags(req.Tags); err != nil {
+		return nil, err
+	}
+
+	lndCaveats, err := macaroonRequestCaveats(req, time.Now(), expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	s.addSessionMu.Lock()
+	defer s.addSessionMu.Unlock()
+
+	if req.IdempotentLabel {
+		sess, err := s.sessionByLabel(req.Label, typ)
+		if err != nil {
+			return nil, err
+		}
+
+		if sess != nil {
+			rpcSession, err := s.marshalRPCSession(sess)
+			if err != nil {
+				return nil, fmt.Errorf("error marshaling "+
+					"session: %v", err)
+			}
+
+			return &litrpc.AddSessionResponse{
+				Session:  rpcSession,
+				Existing: true,
+			}, nil
+		}
+	}
+
+	prev, err := s.previousSession(req.Label, typ)
+	if err != nil {
+		return nil, err
+	}
+
 	// Store the entity-action permission pairs in a map in order to
 	// de-dup any repeat perms.
 	permissions := make(map[string]map[string]struct{})
@@ -219,10 +360,16 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		permissions[entity][action] = struct{}{}
 	}
 
-	var caveats []macaroon.Caveat
+	// The requested lnd caveats are added to every session type. They
+	// are copied so that the caller's caveats are never modified.
+	caveats := make(
+		[]macaroon.Caveat, 0, len(lndCaveats)+len(extraCaveats),
+	)
+	caveats = append(caveats, lndCaveats...)
+	caveats = append(caveats, extraCaveats...)
 	switch typ {
-	// For the default session types we use empty caveats and permissions,
-	// the macaroons are baked correctly when creating the session.
+	// For the default session types we use empty permissions, the
+	// macaroons are baked correctly when creating the session.
 	case session.TypeMacaroonAdmin, session.TypeMacaroonReadonly:
 
 	// For account based sessions we just add the account ID caveat, the
@@ -241,8 +388,7 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		})
 
 	// For the custom macaroon type, we use the custom permissions specified
-	// in the request. For the time being, the caveats list will be empty
-	// for this type.
+	// in the request.
 	case session.TypeMacaroonCustom:
 		if len(req.MacaroonCustomPermissions) == 0 {
 			return nil, fmt.Errorf("custom macaroon " +
@@ -315,13 +461,32 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		}
 	}
 
+	key, err := s.cfg.keyBackend.NewKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session key: %v", err)
+	}
+
 	sess, err := session.NewSession(
-		req.Label, typ, expiry, req.MailboxServerAddr, req.DevServer,
-		uniquePermissions, caveats, nil, false,
+		key, req.Label, typ, expiry, req.MailboxServerAddr,
+		req.DevServer, uniquePermissions, caveats, nil, false,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new session: %v", err)
 	}
+	sess.Notes = req.Notes
+	sess.Tags = req.Tags
+	sess.PinClient = req.PinClient
+	sess.OfflinePairingPhrase = req.OfflinePairingPhrase
+	sess.RouteHintPolicy = routeHints
+	sess.BlockOnChainSpend = req.BlockOnchainSpend
+
+	if req.PairingPassphrase != "" {
+		err := sess.ProtectWithPassphrase([]byte(req.PairingPassphrase))
+		if err != nil {
+			return nil, fmt.Errorf("error protecting session with "+
+				"passphrase: %v", err)
+		}
+	}
 
 	if err := s.db.StoreSession(sess); err != nil {
 		return nil, fmt.Errorf("error storing session: %v", err)
@@ -336,11 +501,71 @@ func (s *sessionRpcServer) AddSession(_ context.Context,
 		return nil, fmt.Errorf("error marshaling session: %v", err)
 	}
 
+	// The phrase of a session with an offline pairing phrase is derived
+	// from the pairing secret, which is therefore only returned now.
+	rpcSession.PairingSecret = sess.PairingSecret[:]
+
 	return &litrpc.AddSessionResponse{
-		Session: rpcSession,
+		Session:             rpcSession,
+		PreviousSessionDiff: s.sessionDiff(prev, sess),
 	}, nil
 }
 
+// recordSessionCreation records the creation of the given session with the
+// given method in the action log. Failing to do so is only logged.
+func (s *sessionRpcServer) recordSessionCreation(ctx context.Context,
+	method string, resp *litrpc.AddSessionResponse, req proto.Message) {
+
+	err := s.cfg.auditor.Record(s.cfg.auditor.Actor(ctx), method, req)
+	if err != nil {
+		log.Errorf("Error recording creation of session %x: %v",
+			resp.Session.LocalPublicKey, err)
+	}
+}
+
+// sessionByLabel returns the session with the given label that is neither
+// revoked nor expired. Nil is returned if no such session exists. An error is
+// returned if the label is empty or the session is not of the given type.
+func (s *sessionRpcServer) sessionByLabel(label string,
+	typ session.Type) (*session.Session, error) {
+
+	if label == "" {
+		return nil, fmt.Errorf("a label must be set to use it as an " +
+			"idempotency key")
+	}
+
+	sessions, err := s.db.ListSessions(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %v", err)
+	}
+
+	now := time.Now()
+	for _, sess := range sessions {
+		if sess.Label != label {
+			continue
+		}
+
+		if sess.State != session.StateCreated &&
+			sess.State != session.StateInUse {
+
+			continue
+		}
+
+		if sess.Expiry.Before(now) {
+			continue
+		}
+
+		if sess.Type != typ {
+			return nil, fmt.Errorf("session with label %s already "+
+				"exists with a different type", label)
+		}
+
+		return sess, nil
+	}
+
+	return nil, nil
+}
+
 // resumeSession tries to start an existing session if it is not expired, not
 // revoked and a LiT session.
 func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
@@ -362,7 +587,10 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 		log.Debugf("Not resuming session %x with expiry %s",
 			pubKeyBytes, sess.Expiry)
 
-		if err := s.db.RevokeSession(pubKey); err != nil {
+		err := s.revokeSession(
+			pubKey, accounts.ActorLitd, reasonExpired,
+		)
+		if err != nil {
 			return fmt.Errorf("error revoking session: %v", err)
 		}
 
@@ -375,10 +603,13 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 		readOnly    = sess.Type == session.TypeMacaroonReadonly
 	)
 	switch sess.Type {
-	// For the default session types we use empty caveats and permissions,
-	// the macaroons are baked correctly when creating the session.
+	// For the default session types we use all active permissions and the
+	// caveats that were requested on session creation, if any.
 	case session.TypeMacaroonAdmin, session.TypeMacaroonReadonly:
 		permissions = s.cfg.permMgr.ActivePermissions(readOnly)
+		if sess.MacaroonRecipe != nil {
+			caveats = sess.MacaroonRecipe.Caveats
+		}
 
 	// For account based sessions we just add the account ID caveat, the
 	// permissions are added dynamically when creating the session.
@@ -408,6 +639,30 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 		return nil
 	}
 
+	// Sessions other than admin sessions don't get to see the mission
+	// control data of the node if it is redacted. The caveat is added when
+	// the session is resumed, so the option also applies to existing
+	// sessions.
+	if s.cfg.redactMissionControl &&
+		sess.Type != session.TypeMacaroonAdmin {
+
+		caveats = append(caveats, firewall.RedactMissionControlCaveat)
+	}
+
+	// The route hints of the invoices created with the session are
+	// filtered according to its policy.
+	if sess.RouteHintPolicy != session.RouteHintsAllow {
+		caveats = append(
+			caveats, firewall.RouteHintCaveat(sess.RouteHintPolicy),
+		)
+	}
+
+	// Calls that spend on-chain funds are rejected for the session,
+	// whatever its permissions are.
+	if sess.BlockOnChainSpend {
+		caveats = append(caveats, firewall.BlockOnChainSpendCaveat)
+	}
+
 	// Add the session expiry as a macaroon caveat.
 	macExpiry := checkers.TimeBeforeCaveat(sess.Expiry)
 	caveats = append(caveats, macaroon.Caveat{
@@ -442,7 +697,10 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 			log.Debugf("Deadline for session %x has already "+
 				"passed. Revoking session", pubKeyBytes)
 
-			return s.db.RevokeSession(pubKey)
+			return s.revokeSession(
+				pubKey, accounts.ActorLitd,
+				reasonFirstConnDeadline,
+			)
 		}
 
 		// Start the deadline timer.
@@ -490,6 +748,7 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 		ticker := time.NewTimer(time.Until(sess.Expiry))
 		defer ticker.Stop()
 
+		var reason string
 		select {
 		case <-s.quit:
 			return
@@ -500,11 +759,13 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 		case <-ticker.C:
 			log.Debugf("Stopping expired session %x with "+
 				"type %d", pubKeyBytes, sess.Type)
+			reason = reasonExpired
 
 		case <-firstConnTimout:
 			log.Debugf("Deadline exceeded for first connection "+
 				"for session %x. Stopping and revoking.",
 				pubKeyBytes)
+			reason = reasonFirstConnDeadline
 		}
 
 		if s.cfg.autopilot != nil {
@@ -522,7 +783,7 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 			log.Debugf("Error stopping session: %v", err)
 		}
 
-		err = s.db.RevokeSession(pubKey)
+		err = s.revokeSession(pubKey, accounts.ActorLitd, reason)
 		if err != nil {
 			log.Debugf("error revoking session: %v", err)
 		}
@@ -533,9 +794,11 @@ func (s *sessionRpcServer) resumeSession(sess *session.Session) error {
 
 // ListSessions returns all sessions known to the session store.
 func (s *sessionRpcServer) ListSessions(_ context.Context,
-	_ *litrpc.ListSessionsRequest) (*litrpc.ListSessionsResponse, error) {
+	req *litrpc.ListSessionsRequest) (*litrpc.ListSessionsResponse, error) {
 
-	sessions, err := s.db.ListSessions(nil)
+	sessions, err := s.db.ListSessions(func(sess *session.Session) bool {
+		return hasTags(sess, req.Tags)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error fetching sessions: %v", err)
 	}
@@ -554,209 +817,1360 @@ func (s *sessionRpcServer) ListSessions(_ context.Context,
 	return response, nil
 }
 
-// RevokeSession revokes a single session and also stops it if it is currently
-// active.
-func (s *sessionRpcServer) RevokeSession(ctx context.Context,
-	req *litrpc.RevokeSessionRequest) (*litrpc.RevokeSessionResponse, error) {
+// UpdateSession replaces the notes and tags of a session.
+func (s *sessionRpcServer) UpdateSession(ctx context.Context,
+	req *litrpc.UpdateSessionRequest) (*litrpc.UpdateSessionResponse, error) {
 
 	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing public key: %v", err)
 	}
 
-	if err := s.db.RevokeSession(pubKey); err != nil {
-		return nil, fmt.Errorf("error revoking session: %v", err)
+	if err := validateTags(req.Tags); err != nil {
+		return nil, err
 	}
 
-	if s.cfg.autopilot != nil {
-		s.cfg.autopilot.SessionRevoked(ctx, pubKey)
+	err = s.db.UpdateSessionNotes(pubKey, req.Notes, req.Tags)
+	if err != nil {
+		return nil, sessionRPCError(
+			fmt.Errorf("error updating session: %w", err),
+		)
 	}
 
-	// If the session expired already it might not be running anymore. So we
-	// only log possible errors here.
-	if err := s.sessionServer.StopSession(pubKey); err != nil {
-		log.Debugf("Error stopping session: %v", err)
+	err = s.cfg.auditor.Record(
+		s.cfg.auditor.Actor(ctx), "/litrpc.Sessions/UpdateSession", req,
+	)
+	if err != nil {
+		log.Errorf("Error recording update of session %x: %v",
+			req.LocalPublicKey, err)
 	}
 
-	return &litrpc.RevokeSessionResponse{}, nil
+	sess, err := s.db.GetSession(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching session: %v", err)
+	}
+
+	rpcSession, err := s.marshalRPCSession(sess)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling session: %v", err)
+	}
+
+	return &litrpc.UpdateSessionResponse{
+		Session: rpcSession,
+	}, nil
 }
 
-// PrivacyMapConversion can be used map real values to their pseudo counterpart
-// and vice versa.
-func (s *sessionRpcServer) PrivacyMapConversion(_ context.Context,
-	req *litrpc.PrivacyMapConversionRequest) (
-	*litrpc.PrivacyMapConversionResponse, error) {
+// Search matches the given query against the labels of accounts and the
+// labels, notes and tags of sessions and returns all matching entries.
+func (s *sessionRpcServer) Search(_ context.Context,
+	req *litrpc.SearchRequest) (*litrpc.SearchResponse, error) {
 
-	sessionID, err := session.IDFromBytes(req.SessionId)
+	query := strings.ToLower(strings.TrimSpace(req.Query))
+	if query == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+
+	accts, err := s.cfg.accountService.Accounts()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error listing accounts: %v", err)
 	}
 
-	var res string
-	privMap := s.cfg.privMap(sessionID)
-	err = privMap.View(func(tx firewalldb.PrivacyMapTx) error {
-		var err error
-		if req.RealToPseudo {
-			res, err = tx.RealToPseudo(req.Input)
-			return err
+	var results []*litrpc.SearchResult
+	for _, acct := range accts {
+		if !strings.Contains(strings.ToLower(acct.Label), query) {
+			continue
 		}
 
-		res, err = tx.PseudoToReal(req.Input)
-		return err
-	})
+		results = append(results, &litrpc.SearchResult{
+			Result: &litrpc.SearchResult_Account{
+				Account: accounts.MarshalAccount(acct),
+			},
+			MatchedFields: []string{"label"},
+		})
+	}
+
+	sessions, err := s.db.ListSessions(nil)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error listing sessions: %v", err)
 	}
 
-	return &litrpc.PrivacyMapConversionResponse{
-		Output: res,
+	for _, sess := range sessions {
+		matched := matchSession(sess, query)
+		if len(matched) == 0 {
+			continue
+		}
+
+		rpcSession, err := s.marshalRPCSession(sess)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling session: %v",
+				err)
+		}
+
+		results = append(results, &litrpc.SearchResult{
+			Result: &litrpc.SearchResult_Session{
+				Session: rpcSession,
+			},
+			MatchedFields: matched,
+		})
+	}
+
+	return &litrpc.SearchResponse{
+		Results: results,
 	}, nil
 }
 
-// ListActions will return a list of actions that have been performed on the
-// node. The actions that will be persisted depends on the value of the
-// `--firewall.request-logger.level` config option. The default value of the
-// option is the "interceptor" mode which will persist only the actions (with
-// all request parameters) made with macaroons with caveats that force them to
-// be checked by an rpc middleware interceptor. If the "all" mode is used then
-// all actions will be persisted but only full request parameters will only be
-// stored if the actions are interceptor actions, otherwise only the URI and
-// timestamp of the actions will be stored. The "full" mode will persist all
-// request data for all actions.
-func (s *sessionRpcServer) ListActions(_ context.Context,
-	req *litrpc.ListActionsRequest) (*litrpc.ListActionsResponse, error) {
+// matchSession returns the names of the session fields that contain the given
+// lower case query.
+func matchSession(sess *session.Session, query string) []string {
+	contains := func(s string) bool {
+		return strings.Contains(strings.ToLower(s), query)
+	}
 
-	// If no maximum number of actions is given, use a default of 100.
-	if req.MaxNumActions == 0 {
-		req.MaxNumActions = 100
+	var matched []string
+	if contains(sess.Label) {
+		matched = append(matched, "label")
+	}
+	if contains(sess.Notes) {
+		matched = append(matched, "notes")
 	}
 
-	// Build a filter function based on the request values.
-	filterFn := func(a *firewalldb.Action, reversed bool) (bool, bool) {
-		timeStamp := uint64(a.AttemptedAt.Unix())
-		if req.EndTimestamp != 0 {
-			// If actions are being considered in order and the
-			// timestamp of this action exceeds the given end
-			// timestamp, then there is no need to continue
-			// traversing.
-			if !reversed && timeStamp > req.EndTimestamp {
-				return false, false
-			}
+	tagKeys := make([]string, 0, len(sess.Tags))
+	for k := range sess.Tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
 
-			// If the actions are in reverse order and the timestamp
-			// comes after the end timestamp, then the actions is
-			// not included but the search can continue.
-			if reversed && timeStamp > req.EndTimestamp {
-				return false, true
-			}
+	for _, k := range tagKeys {
+		if contains(k) || contains(sess.Tags[k]) {
+			matched = append(matched, fmt.Sprintf("tag:%s", k))
 		}
+	}
 
-		if req.StartTimestamp != 0 {
-			// If actions are being considered in order and the
-			// timestamp of this action comes before the given start
-			// timestamp, then the action is not included but the
-			// search can continue.
-			if !reversed && timeStamp < req.StartTimestamp {
-				return false, true
-			}
+	return matched
+}
 
-			// If the actions are in reverse order and the timestamp
-			// comes before the start timestamp, then there is no
-			// need to continue traversing.
-			if reversed && timeStamp < req.StartTimestamp {
-				return false, false
-			}
-		}
+// macaroonRequestCaveats returns the lnd timeout and IP lock caveats that are
+// requested for a new session's macaroon. The timeout is turned into an
+// absolute time since the macaroon is baked again every time the session is
+// resumed.
+func macaroonRequestCaveats(req *litrpc.AddSessionRequest, now,
+	expiry time.Time) ([]macaroon.Caveat, error) {
 
-		if req.FeatureName != "" && a.FeatureName != req.FeatureName {
-			return false, true
+	var caveats []macaroon.Caveat
+	if req.MacaroonTimeoutSeconds != 0 {
+		timeout := now.Add(
+			time.Duration(req.MacaroonTimeoutSeconds) * time.Second,
+		)
+		if timeout.After(expiry) {
+			return nil, fmt.Errorf("macaroon timeout of %ds ends "+
+				"after the session expiry %v",
+				req.MacaroonTimeoutSeconds, expiry)
 		}
 
-		if req.ActorName != "" && a.ActorName != req.ActorName {
-			return false, true
-		}
+		cav := checkers.TimeBeforeCaveat(timeout)
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(cav.Condition),
+		})
+	}
 
-		if req.MethodName != "" && a.RPCMethod != req.MethodName {
-			return false, true
+	if req.MacaroonIpAddress != "" {
+		ip := net.ParseIP(req.MacaroonIpAddress)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid macaroon IP address %s",
+				req.MacaroonIpAddress)
 		}
 
-		if req.State != 0 {
-			s, err := marshalActionState(a.State)
-			if err != nil {
-				return false, true
-			}
+		cav := checkers.Condition(ipLockCondition, ip.String())
+		caveats = append(caveats, macaroon.Caveat{
+			Id: []byte(cav),
+		})
+	}
 
-			if s != req.State {
-				return false, true
-			}
-		}
+	return caveats, nil
+}
 
-		return true, true
+// validateTags makes sure that the given session tags are valid.
+func validateTags(tags map[string]string) error {
+	for k := range tags {
+		if strings.TrimSpace(k) == "" {
+			return fmt.Errorf("session tag keys cannot be empty")
+		}
 	}
 
-	query := &firewalldb.ListActionsQuery{
+	return nil
+}
+
+// hasTags returns true if the given session has all the given tags with
+// matching values.
+func hasTags(sess *session.Session, tags map[string]string) bool {
+	for k, v := range tags {
+		sessValue, ok := sess.Tags[k]
+		if !ok || sessValue != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetSession returns the session with the given local public key.
+func (s *sessionRpcServer) GetSession(_ context.Context,
+	req *litrpc.GetSessionRequest) (*litrpc.GetSessionResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.db.GetSession(pubKey)
+	if err != nil {
+		return nil, sessionRPCError(
+			fmt.Errorf("error fetching session: %w", err),
+		)
+	}
+
+	rpcSession, err := s.marshalRPCSession(sess)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling session: %v", err)
+	}
+
+	return &litrpc.GetSessionResponse{
+		Session: rpcSession,
+	}, nil
+}
+
+// revokeSession marks the session with the given local public key as revoked
+// and drops all cached verifications of its super macaroon, so it can't be
+// used for any further calls.
+func (s *sessionRpcServer) revokeSession(pubKey *btcec.PublicKey, revokedBy,
+	reason string) error {
+
+	err := s.db.RevokeSession(pubKey, revokedBy, reason)
+	if err != nil {
+		return err
+	}
+
+	var id session.ID
+	copy(id[:], pubKey.SerializeCompressed())
+	s.cfg.macCache.RemoveRootKey(session.NewSuperMacaroonRootKeyID(id))
+
+	return nil
+}
+
+// RevokeSession revokes a single session and also stops it if it is currently
+// active. The caller and the optional reason are recorded with the session.
+func (s *sessionRpcServer) RevokeSession(ctx context.Context,
+	req *litrpc.RevokeSessionRequest) (*litrpc.RevokeSessionResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.db.GetSession(pubKey)
+	if err != nil {
+		return nil, sessionRPCError(
+			fmt.Errorf("error fetching session: %w", err),
+		)
+	}
+
+	if sess.State == session.StateRevoked {
+		var revokedAt uint64
+		if !sess.RevokedAt.IsZero() {
+			revokedAt = uint64(sess.RevokedAt.Unix())
+		}
+
+		return nil, litrpc.SessionRevokedError(
+			"session has already been revoked", req.LocalPublicKey,
+			revokedAt,
+		)
+	}
+
+	actor := s.cfg.auditor.Actor(ctx)
+	err = s.revokeSession(pubKey, actor, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("error revoking session: %v", err)
+	}
+
+	err = s.cfg.auditor.Record(actor, "/litrpc.Sessions/RevokeSession", req)
+	if err != nil {
+		log.Errorf("Error recording revocation of session %x: %v",
+			req.LocalPublicKey, err)
+	}
+
+	if s.cfg.autopilot != nil {
+		s.cfg.autopilot.SessionRevoked(ctx, pubKey)
+	}
+
+	// If the session expired already it might not be running anymore. So we
+	// only log possible errors here.
+	if err := s.sessionServer.StopSession(pubKey); err != nil {
+		log.Debugf("Error stopping session: %v", err)
+	}
+
+	return &litrpc.RevokeSessionResponse{}, nil
+}
+
+// ExportSessionPairing returns the pairing data of a session encrypted with the
+// given passphrase.
+func (s *sessionRpcServer) ExportSessionPairing(_ context.Context,
+	req *litrpc.ExportSessionPairingRequest) (
+	*litrpc.ExportSessionPairingResponse, error) {
+
+	if len(req.Passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.db.GetSession(pubKey)
+	if err != nil {
+		return nil, sessionRPCError(
+			fmt.Errorf("error fetching session: %w", err),
+		)
+	}
+
+	bundle, err := session.NewPairingBundle(sess, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error exporting session: %v", err)
+	}
+
+	blob, err := session.EncryptPairingBundle(bundle, req.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting pairing bundle: %v",
+			err)
+	}
+
+	return &litrpc.ExportSessionPairingResponse{
+		EncryptedBundle: blob,
+	}, nil
+}
+
+// ImportSessionPairing decrypts a pairing bundle and returns the pairing data
+// it contains.
+func (s *sessionRpcServer) ImportSessionPairing(_ context.Context,
+	req *litrpc.ImportSessionPairingRequest) (
+	*litrpc.ImportSessionPairingResponse, error) {
+
+	bundle, err := session.DecryptPairingBundle(
+		req.EncryptedBundle, req.Passphrase,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcType, err := marshalRPCType(bundle.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonic, err := mailbox.PassphraseEntropyToMnemonic(
+		bundle.PairingSecret,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.ImportSessionPairingResponse{
+		Pairing: &litrpc.SessionPairing{
+			Label:                  bundle.Label,
+			SessionType:            rpcType,
+			ExpiryTimestampSeconds: uint64(bundle.Expiry.Unix()),
+			MailboxServerAddr:      bundle.ServerAddr,
+			DevServer:              bundle.DevServer,
+			PairingSecretMnemonic:  strings.Join(mnemonic[:], " "),
+			LocalPublicKey: bundle.LocalPublicKey.
+				SerializeCompressed(),
+			PassphraseProtected: bundle.PassphraseProtected,
+		},
+	}, nil
+}
+
+// ListConnectionAttempts returns the most recent LNC connection attempts of a
+// session together with the total number of handshakes attempted for it.
+func (s *sessionRpcServer) ListConnectionAttempts(_ context.Context,
+	req *litrpc.ListConnectionAttemptsRequest) (
+	*litrpc.ListConnectionAttemptsResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	attempts, total, err := s.db.ListConnectionAttempts(pubKey)
+	if err != nil {
+		return nil, sessionRPCError(
+			fmt.Errorf("error fetching connection attempts: %w",
+				err),
+		)
+	}
+
+	rpcAttempts := make([]*litrpc.ConnectionAttempt, len(attempts))
+	for i, attempt := range attempts {
+		rpcAttempts[i] = &litrpc.ConnectionAttempt{
+			Timestamp: uint64(attempt.Timestamp.Unix()),
+			Accepted:  attempt.Accepted,
+			Reason:    attempt.Reason,
+		}
+	}
+
+	return &litrpc.ListConnectionAttemptsResponse{
+		Attempts:        rpcAttempts,
+		TotalHandshakes: total,
+	}, nil
+}
+
+// validateMacaroonOps lets lnd validate the given macaroon for each of its
+// permissions. Every permission is checked for a call that requires it, so
+// that all caveats that depend on the called method are checked as well.
+func (s *sessionRpcServer) validateMacaroonOps(ctx context.Context,
+	mac []byte, ops []bakery.Op) error {
+
+	for _, op := range ops {
+		method, ok := s.methodForOp(op, ops)
+		if !ok {
+			return fmt.Errorf("permission %s:%s isn't required by "+
+				"any known call", op.Entity, op.Action)
+		}
+
+		err := s.cfg.lndValidator(ctx, mac, []bakery.Op{op}, method)
+		if err != nil {
+			return fmt.Errorf("permission %s:%s for %s: %w",
+				op.Entity, op.Action, method, err)
+		}
+	}
+
+	return nil
+}
+
+// methodForOp returns a call that requires the given permission. Calls that
+// require nothing else are preferred, otherwise a call that the given
+// permissions allow is returned.
+func (s *sessionRpcServer) methodForOp(op bakery.Op,
+	ops []bakery.Op) (string, bool) {
+
+	if uris := s.cfg.permMgr.GrantedURIs([]bakery.Op{op}); len(uris) > 0 {
+		return uris[0], true
+	}
+
+	for _, uri := range s.cfg.permMgr.GrantedURIs(ops) {
+		required, _ := s.cfg.permMgr.URIPermissions(uri)
+		for _, requiredOp := range required {
+			if requiredOp == op {
+				return uri, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// ImportMacaroon creates a custom macaroon session from an existing macaroon
+// that was baked by lnd. The session gets the permissions of the macaroon and
+// keeps its caveats, the protections of the session are added on top.
+func (s *sessionRpcServer) ImportMacaroon(ctx context.Context,
+	req *litrpc.ImportMacaroonRequest) (*litrpc.ImportMacaroonResponse,
+	error) {
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(req.Macaroon); err != nil {
+		return nil, fmt.Errorf("error parsing macaroon: %v", err)
+	}
+
+	// The super macaroons of litd are baked by lnd as well, but they belong
+	// to existing sessions or to litd itself.
+	if session.IsSuperMacaroon(hex.EncodeToString(req.Macaroon)) {
+		return nil, fmt.Errorf("litd super macaroons can't be imported")
+	}
+
+	ops, err := macaroonOps(mac)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("macaroon doesn't have any permissions")
+	}
+
+	// All caveats of the macaroon are carried over to the session's
+	// macaroon, so that the session can't do more than the macaroon itself.
+	var (
+		caveats   = make([]macaroon.Caveat, 0, len(mac.Caveats()))
+		macExpiry time.Time
+	)
+	for _, caveat := range mac.Caveats() {
+		if len(caveat.VerificationId) != 0 {
+			return nil, fmt.Errorf("macaroons with third-party " +
+				"caveats can't be imported")
+		}
+
+		caveats = append(caveats, macaroon.Caveat{Id: caveat.Id})
+
+		name, arg, _ := strings.Cut(string(caveat.Id), " ")
+		if name != checkers.CondTimeBefore {
+			continue
+		}
+
+		expiry, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry caveat %s: %v",
+				caveat.Id, err)
+		}
+
+		// The earliest expiry is the one that applies.
+		if macExpiry.IsZero() || expiry.Before(macExpiry) {
+			macExpiry = expiry
+		}
+	}
+
+	// Only a macaroon that lnd baked and still accepts may be imported.
+	// Otherwise, a session could be given permissions that the caller
+	// doesn't have.
+	if err := s.validateMacaroonOps(ctx, req.Macaroon, ops); err != nil {
+		return nil, fmt.Errorf("error validating macaroon: %w", err)
+	}
+
+	// Without an explicit expiry, the session doesn't outlive the
+	// macaroon.
+	expiry := req.ExpiryTimestampSeconds
+	defaultExpiry := time.Now().Add(s.cfg.sessionCfg.DefaultExpiry)
+	if expiry == 0 && !macExpiry.IsZero() &&
+		macExpiry.Before(defaultExpiry) {
+
+		expiry = uint64(macExpiry.Unix())
+	}
+
+	permissions := make([]*litrpc.MacaroonPermission, len(ops))
+	for idx, op := range ops {
+		permissions[idx] = &litrpc.MacaroonPermission{
+			Entity: op.Entity,
+			Action: op.Action,
+		}
+	}
+
+	addReq := &litrpc.AddSessionRequest{
+		Label:                     req.Label,
+		ExpiryTimestampSeconds:    expiry,
+		MailboxServerAddr:         req.MailboxServerAddr,
+		DevServer:                 req.DevServer,
+		MacaroonCustomPermissions: permissions,
+		Notes:                     req.Notes,
+		PinClient:                 req.PinClient,
+		RouteHintPolicy:           req.RouteHintPolicy,
+		PairingPassphrase:         req.PairingPassphrase,
+		BlockOnchainSpend:         req.BlockOnchainSpend,
+	}
+	addReq.SessionType = litrpc.SessionType_TYPE_MACAROON_CUSTOM
+
+	resp, err := s.addSession(ctx, addReq, caveats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Neither the macaroon nor the passphrase must end up in the action
+	// log.
+	auditReq := proto.Clone(req).(*litrpc.ImportMacaroonRequest)
+	auditReq.Macaroon = nil
+	auditReq.PairingPassphrase = ""
+
+	s.recordSessionCreation(
+		ctx, "/litrpc.Sessions/ImportMacaroon", resp, auditReq,
+	)
+
+	formatted := make([]string, len(ops))
+	for idx, op := range ops {
+		formatted[idx] = fmt.Sprintf("%s:%s", op.Entity, op.Action)
+	}
+	sort.Strings(formatted)
+
+	return &litrpc.ImportMacaroonResponse{
+		Session:     resp.Session,
+		Permissions: formatted,
+	}, nil
+}
+
+// PrivacyMapConversion can be used map real values to their pseudo counterpart
+// and vice versa.
+func (s *sessionRpcServer) PrivacyMapConversion(_ context.Context,
+	req *litrpc.PrivacyMapConversionRequest) (
+	*litrpc.PrivacyMapConversionResponse, error) {
+
+	sessionID, err := session.IDFromBytes(req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var res string
+	privMap := s.cfg.privMap(sessionID)
+	err = privMap.View(func(tx firewalldb.PrivacyMapTx) error {
+		var err error
+		if req.RealToPseudo {
+			res, err = tx.RealToPseudo(req.Input)
+			return err
+		}
+
+		res, err = tx.PseudoToReal(req.Input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.PrivacyMapConversionResponse{
+		Output: res,
+	}, nil
+}
+
+// ListActions will return a list of actions that have been performed on the
+// node. The actions that will be persisted depends on the value of the
+// `--firewall.request-logger.level` config option. The default value of the
+// option is the "interceptor" mode which will persist only the actions (with
+// all request parameters) made with macaroons with caveats that force them to
+// be checked by an rpc middleware interceptor. If the "all" mode is used then
+// all actions will be persisted but only full request parameters will only be
+// stored if the actions are interceptor actions, otherwise only the URI and
+// timestamp of the actions will be stored. The "full" mode will persist all
+// request data for all actions.
+func (s *sessionRpcServer) ListActions(_ context.Context,
+	req *litrpc.ListActionsRequest) (*litrpc.ListActionsResponse, error) {
+
+	// If no maximum number of actions is given, use a default of 100.
+	if req.MaxNumActions == 0 {
+		req.MaxNumActions = 100
+	}
+
+	// Build a filter function based on the request values.
+	filterFn := func(a *firewalldb.Action, reversed bool) (bool, bool) {
+		timeStamp := uint64(a.AttemptedAt.Unix())
+		if req.EndTimestamp != 0 {
+			// If actions are being considered in order and the
+			// timestamp of this action exceeds the given end
+			// timestamp, then there is no need to continue
+			// traversing.
+			if !reversed && timeStamp > req.EndTimestamp {
+				return false, false
+			}
+
+			// If the actions are in reverse order and the timestamp
+			// comes after the end timestamp, then the actions is
+			// not included but the search can continue.
+			if reversed && timeStamp > req.EndTimestamp {
+				return false, true
+			}
+		}
+
+		if req.StartTimestamp != 0 {
+			// If actions are being considered in order and the
+			// timestamp of this action comes before the given start
+			// timestamp, then the action is not included but the
+			// search can continue.
+			if !reversed && timeStamp < req.StartTimestamp {
+				return false, true
+			}
+
+			// If the actions are in reverse order and the timestamp
+			// comes before the start timestamp, then there is no
+			// need to continue traversing.
+			if reversed && timeStamp < req.StartTimestamp {
+				return false, false
+			}
+		}
+
+		if req.FeatureName != "" && a.FeatureName != req.FeatureName {
+			return false, true
+		}
+
+		if req.ActorName != "" && a.ActorName != req.ActorName {
+			return false, true
+		}
+
+		if req.MethodName != "" && a.RPCMethod != req.MethodName {
+			return false, true
+		}
+
+		if req.State != 0 {
+			s, err := marshalActionState(a.State)
+			if err != nil {
+				return false, true
+			}
+
+			if s != req.State {
+				return false, true
+			}
+		}
+
+		return true, true
+	}
+
+	query := &firewalldb.ListActionsQuery{
 		IndexOffset: req.IndexOffset,
 		MaxNum:      req.MaxNumActions,
 		Reversed:    req.Reversed,
 		CountAll:    req.CountTotal,
 	}
 
-	var (
-		db         = s.cfg.actionsDB
-		actions    []*firewalldb.Action
-		lastIndex  uint64
-		totalCount uint64
-		err        error
-	)
-	if req.SessionId != nil {
-		sessionID, err := session.IDFromBytes(req.SessionId)
+	var (
+		db         = s.cfg.actionsDB
+		actions    []*firewalldb.Action
+		lastIndex  uint64
+		totalCount uint64
+		err        error
+	)
+	if req.SessionId != nil {
+		sessionID, err := session.IDFromBytes(req.SessionId)
+		if err != nil {
+			return nil, err
+		}
+
+		actions, lastIndex, totalCount, err = db.ListSessionActions(
+			sessionID, filterFn, query,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		actions, lastIndex, totalCount, err = db.ListActions(
+			filterFn, query,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp := make([]*litrpc.Action, len(actions))
+	for i, a := range actions {
+		resp[i], err = marshalAction(a)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &litrpc.ListActionsResponse{
+		Actions:         resp,
+		LastIndexOffset: lastIndex,
+		TotalCount:      totalCount,
+	}, nil
+}
+
+// SubscribeActions streams the actions that match the given filters. It first
+// sends the most recent matching actions and then, if requested, every new
+// matching action as soon as it is recorded. Pending actions are sent again
+// once their state changes.
+func (s *sessionRpcServer) SubscribeActions(
+	req *litrpc.SubscribeActionsRequest,
+	stream litrpc.Firewall_SubscribeActionsServer) error {
+
+	if req.NumRecent > maxRecentActions {
+		return fmt.Errorf("at most %d recent actions can be requested",
+			maxRecentActions)
+	}
+
+	var sessionID *session.ID
+	if len(req.SessionId) != 0 {
+		id, err := session.IDFromBytes(req.SessionId)
+		if err != nil {
+			return err
+		}
+		sessionID = &id
+	}
+
+	// matches checks all filters except the state, as the state of a
+	// pending action can still change.
+	matches := func(a *firewalldb.Action) bool {
+		switch {
+		case sessionID != nil && a.SessionID != *sessionID:
+			return false
+
+		case req.FeatureName != "" && a.FeatureName != req.FeatureName:
+			return false
+
+		case req.ActorName != "" && a.ActorName != req.ActorName:
+			return false
+
+		case req.MethodName != "" && a.RPCMethod != req.MethodName:
+			return false
+		}
+
+		return true
+	}
+
+	hasState := func(a *firewalldb.Action) bool {
+		if req.State == litrpc.ActionState_STATE_UNKNOWN {
+			return true
+		}
+
+		state, err := marshalActionState(a.State)
+		return err == nil && state == req.State
+	}
+
+	// pending holds the index of the matching actions that are still
+	// pending, together with whether they were already sent.
+	pending := make(map[uint64]bool)
+
+	// send sends the given matching action if it has the requested state
+	// and keeps track of it while it is pending.
+	send := func(a *firewalldb.Action, stateUpdate bool) error {
+		sent := hasState(a)
+		if a.State == firewalldb.ActionStateInit {
+			pending[a.Index] = sent
+		}
+
+		if !sent {
+			return nil
+		}
+
+		action, err := marshalAction(a)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		actions, lastIndex, totalCount, err = db.ListSessionActions(
-			sessionID, filterFn, query,
+		return stream.Send(&litrpc.ActionEvent{
+			Action:      action,
+			Index:       a.Index,
+			StateUpdate: stateUpdate,
+		})
+	}
+
+	db := s.cfg.actionsDB
+
+	// We subscribe before looking up the most recent actions so we don't
+	// miss an action that is recorded in between.
+	updates, cancel := db.SubscribeActions()
+	defer cancel()
+
+	lastIndex, err := db.LastActionIndex()
+	if err != nil {
+		return err
+	}
+
+	if req.NumRecent > 0 && lastIndex > 0 {
+		// Actions recorded after we looked up the last index are sent
+		// below, so they are skipped here.
+		filterFn := func(a *firewalldb.Action, _ bool) (bool, bool) {
+			return a.Index <= lastIndex && matches(a) && hasState(a),
+				true
+		}
+		recent, _, _, err := db.ListActions(
+			filterFn, &firewalldb.ListActionsQuery{
+				IndexOffset: lastIndex + 1,
+				MaxNum:      uint64(req.NumRecent),
+				Reversed:    true,
+			},
 		)
 		if err != nil {
-			return nil, err
+			return err
 		}
-	} else {
-		actions, lastIndex, totalCount, err = db.ListActions(
-			filterFn, query,
+
+		for i := len(recent) - 1; i >= 0; i-- {
+			if err := send(recent[i], false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !req.Follow {
+		return nil
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-updates:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// The pending actions were recorded before the new ones, so we
+		// send their new state first.
+		indexes := make([]uint64, 0, len(pending))
+		for index := range pending {
+			indexes = append(indexes, index)
+		}
+		sort.Slice(indexes, func(i, j int) bool {
+			return indexes[i] < indexes[j]
+		})
+
+		actions, err := db.ActionsByIndex(indexes)
+		if err != nil {
+			return err
+		}
+		for _, a := range actions {
+			if a.State == firewalldb.ActionStateInit {
+				continue
+			}
+
+			wasSent := pending[a.Index]
+			delete(pending, a.Index)
+
+			if err := send(a, wasSent); err != nil {
+				return err
+			}
+		}
+
+		filterFn := func(a *firewalldb.Action, _ bool) (bool, bool) {
+			return matches(a), true
+		}
+		actions, newLastIndex, _, err := db.ListActions(
+			filterFn, &firewalldb.ListActionsQuery{
+				IndexOffset: lastIndex,
+			},
 		)
+		if err != nil {
+			return err
+		}
+		for _, a := range actions {
+			if err := send(a, false); err != nil {
+				return err
+			}
+		}
+
+		// The last index is also returned if no action was read, so we
+		// make sure to never go back.
+		if newLastIndex > lastIndex {
+			lastIndex = newLastIndex
+		}
+	}
+}
+
+// marshalAction converts an action into its RPC counterpart.
+func marshalAction(a *firewalldb.Action) (*litrpc.Action, error) {
+	state, err := marshalActionState(a.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.Action{
+		SessionId:          a.SessionID[:],
+		ActorName:          a.ActorName,
+		FeatureName:        a.FeatureName,
+		Trigger:            a.Trigger,
+		Intent:             a.Intent,
+		StructuredJsonData: a.StructuredJsonData,
+		RpcMethod:          a.RPCMethod,
+		RpcParamsJson:      string(a.RPCParamsJson),
+		Timestamp:          uint64(a.AttemptedAt.Unix()),
+		State:              state,
+		ErrorReason:        a.ErrorReason,
+	}, nil
+}
+
+// VerifyActionLog checks the integrity of the signed action log. It walks the
+// chain of signed action digests, checks all signatures and checks that no
+// signed action was modified or removed since.
+func (s *sessionRpcServer) VerifyActionLog(_ context.Context,
+	_ *litrpc.VerifyActionLogRequest) (*litrpc.VerifyActionLogResponse,
+	error) {
+
+	report, err := s.cfg.actionsDB.VerifyActionLog()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeys := make([][]byte, len(report.PubKeys))
+	for i, pubKey := range report.PubKeys {
+		pubKeys[i] = pubKey[:]
+	}
+
+	var tipHash []byte
+	if report.NumEntries > 0 {
+		tipHash = report.TipHash[:]
+	}
+
+	return &litrpc.VerifyActionLogResponse{
+		Valid:              report.Valid,
+		Failure:            report.Failure,
+		FailedSeq:          report.FailedSeq,
+		NumEntries:         report.NumEntries,
+		NumSignedActions:   report.NumSignedActions,
+		NumUnsignedActions: report.NumUnsignedActions,
+		TipHash:            tipHash,
+		PubKeys:            pubKeys,
+	}, nil
+}
+
+// AuditTrail returns the account changes, session lifecycle events, firewall
+// denials and admin actions of the action log as one trail, in the order in
+// which they were recorded.
+func (s *sessionRpcServer) AuditTrail(_ context.Context,
+	req *litrpc.AuditTrailRequest) (*litrpc.AuditTrailResponse, error) {
+
+	// If no maximum number of events is given, use a default of 100.
+	maxEvents := uint64(req.MaxEvents)
+	if maxEvents == 0 {
+		maxEvents = 100
+	}
+
+	var sessionID *session.ID
+	if len(req.SessionId) != 0 {
+		id, err := session.IDFromBytes(req.SessionId)
 		if err != nil {
 			return nil, err
 		}
+		sessionID = &id
 	}
-	resp := make([]*litrpc.Action, len(actions))
+
+	categories := make(map[litrpc.AuditCategory]bool, len(req.Categories))
+	for _, category := range req.Categories {
+		categories[category] = true
+	}
+
+	// The cursor is the index of the last action that was looked at, so
+	// that the next call doesn't look at the actions again that aren't
+	// part of the trail.
+	var (
+		nextCursor   = req.Cursor
+		pendingSince = time.Now().Add(-auditPendingGrace)
+	)
+	filterFn := func(a *firewalldb.Action, _ bool) (bool, bool) {
+		// A pending action of a session may still be rejected by a
+		// firewall rule, so the trail stops in front of it until it
+		// completes, unless it is pending for too long.
+		if a.State == firewalldb.ActionStateInit &&
+			a.SessionID != (session.ID{}) &&
+			a.AttemptedAt.After(pendingSince) {
+
+			return false, false
+		}
+
+		// The actions are recorded in order, so there is no need to
+		// continue once the end timestamp is exceeded. The cursor
+		// doesn't move past it, so that a later call with a later end
+		// timestamp still returns the action.
+		timestamp := uint64(a.AttemptedAt.Unix())
+		if req.EndTimestamp != 0 && timestamp > req.EndTimestamp {
+			return false, false
+		}
+		nextCursor = a.Index
+
+		if req.StartTimestamp != 0 && timestamp < req.StartTimestamp {
+			return false, true
+		}
+
+		category := auditCategory(a)
+		if category == litrpc.AuditCategory_AUDIT_CATEGORY_UNKNOWN {
+			return false, true
+		}
+
+		if len(categories) != 0 && !categories[category] {
+			return false, true
+		}
+
+		if req.ActorName != "" && a.ActorName != req.ActorName {
+			return false, true
+		}
+
+		if sessionID != nil && a.SessionID != *sessionID {
+			return false, true
+		}
+
+		return true, true
+	}
+
+	actions, _, _, err := s.cfg.actionsDB.ListActions(
+		filterFn, &firewalldb.ListActionsQuery{
+			IndexOffset: req.Cursor,
+			MaxNum:      maxEvents,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*litrpc.AuditEvent, len(actions))
 	for i, a := range actions {
 		state, err := marshalActionState(a.State)
 		if err != nil {
 			return nil, err
 		}
 
-		resp[i] = &litrpc.Action{
-			SessionId:          a.SessionID[:],
-			ActorName:          a.ActorName,
-			FeatureName:        a.FeatureName,
-			Trigger:            a.Trigger,
-			Intent:             a.Intent,
-			StructuredJsonData: a.StructuredJsonData,
-			RpcMethod:          a.RPCMethod,
-			RpcParamsJson:      string(a.RPCParamsJson),
-			Timestamp:          uint64(a.AttemptedAt.Unix()),
-			State:              state,
-			ErrorReason:        a.ErrorReason,
+		var sessionID []byte
+		if a.SessionID != (session.ID{}) {
+			sessionID = a.SessionID[:]
+		}
+
+		events[i] = &litrpc.AuditEvent{
+			Seq:           a.Index,
+			Category:      auditCategory(a),
+			Timestamp:     uint64(a.AttemptedAt.Unix()),
+			ActorName:     a.ActorName,
+			SessionId:     sessionID,
+			RpcMethod:     a.RPCMethod,
+			RpcParamsJson: string(a.RPCParamsJson),
+			State:         state,
+			ErrorReason:   a.ErrorReason,
 		}
 	}
 
-	return &litrpc.ListActionsResponse{
-		Actions:         resp,
-		LastIndexOffset: lastIndex,
-		TotalCount:      totalCount,
+	return &litrpc.AuditTrailResponse{
+		Events:     events,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// BillingExport returns the number of RPC calls and their cost per session or
+// account, method and period.
+func (s *sessionRpcServer) BillingExport(_ context.Context,
+	req *litrpc.BillingExportRequest) (*litrpc.BillingExportResponse,
+	error) {
+
+	var period firewalldb.BillingPeriod
+	switch req.Period {
+	case litrpc.BillingPeriod_BILLING_PERIOD_DAY:
+		period = firewalldb.BillingPeriodDay
+
+	case litrpc.BillingPeriod_BILLING_PERIOD_WEEK:
+		period = firewalldb.BillingPeriodWeek
+
+	case litrpc.BillingPeriod_BILLING_PERIOD_MONTH:
+		period = firewalldb.BillingPeriodMonth
+
+	default:
+		return nil, fmt.Errorf("unknown billing period: %v", req.Period)
+	}
+
+	end := time.Now()
+	if req.EndTimestamp != 0 {
+		end = time.Unix(int64(req.EndTimestamp), 0)
+	}
+	start := time.Unix(int64(req.StartTimestamp), 0)
+	if start.After(end) {
+		return nil, fmt.Errorf("start timestamp must be before the " +
+			"end timestamp")
+	}
+
+	usage, err := s.cfg.actionsDB.UsageReport(start, end, period)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.BillingExportResponse{
+		Entries: make([]*litrpc.BillingEntry, len(usage)),
+	}
+	for i, u := range usage {
+		entry := &litrpc.BillingEntry{
+			PeriodStart: uint64(u.PeriodStart.Unix()),
+			RpcMethod:   u.RPCMethod,
+			Calls:       u.Calls,
+			Cost:        u.Cost,
+		}
+
+		switch u.Consumer.Type {
+		case firewalldb.ConsumerSession:
+			entry.SessionId = u.Consumer.ID
+
+		case firewalldb.ConsumerAccount:
+			entry.AccountId = hex.EncodeToString(u.Consumer.ID)
+		}
+
+		resp.Entries[i] = entry
+		resp.TotalCost += u.Cost
+	}
+
+	return resp, nil
+}
+
+// SessionActivity returns the number of actions per session, feature and
+// interval.
+func (s *sessionRpcServer) SessionActivity(_ context.Context,
+	req *litrpc.SessionActivityRequest) (*litrpc.SessionActivityResponse,
+	error) {
+
+	// If no start is given, we return the last day in hours or the last
+	// month in days.
+	var (
+		interval    firewalldb.ActivityInterval
+		defaultSpan time.Duration
+	)
+	switch req.Interval {
+	case litrpc.ActivityInterval_ACTIVITY_INTERVAL_HOUR:
+		interval = firewalldb.ActivityIntervalHour
+		defaultSpan = 24 * time.Hour
+
+	case litrpc.ActivityInterval_ACTIVITY_INTERVAL_DAY:
+		interval = firewalldb.ActivityIntervalDay
+		defaultSpan = 30 * 24 * time.Hour
+
+	default:
+		return nil, fmt.Errorf("unknown activity interval: %v",
+			req.Interval)
+	}
+
+	end := time.Now()
+	if req.EndTimestamp != 0 {
+		end = time.Unix(int64(req.EndTimestamp), 0)
+	}
+	start := end.Add(-defaultSpan)
+	if req.StartTimestamp != 0 {
+		start = time.Unix(int64(req.StartTimestamp), 0)
+	}
+	if start.After(end) {
+		return nil, fmt.Errorf("start timestamp must be before the " +
+			"end timestamp")
+	}
+	if end.Sub(start) > maxActivityIntervals*interval.Duration() {
+		return nil, fmt.Errorf("the activity can span at most %d "+
+			"intervals", maxActivityIntervals)
+	}
+
+	query := &firewalldb.ActivityQuery{
+		Start:       start,
+		End:         end,
+		Interval:    interval,
+		FeatureName: req.FeatureName,
+	}
+	if len(req.SessionId) != 0 {
+		id, err := session.IDFromBytes(req.SessionId)
+		if err != nil {
+			return nil, err
+		}
+		query.SessionID = &id
+	}
+
+	report, err := s.cfg.actionsDB.ActivityReport(query)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.SessionActivityResponse{
+		Buckets: make([]*litrpc.ActivityBucket, len(report)),
+	}
+	for i, entry := range report {
+		resp.Buckets[i] = &litrpc.ActivityBucket{
+			IntervalStart: uint64(entry.IntervalStart.Unix()),
+			SessionId:     entry.SessionID[:],
+			FeatureName:   entry.FeatureName,
+			Actions:       entry.Actions,
+			Errors:        entry.Errors,
+		}
+	}
+
+	return resp, nil
+}
+
+// GetFirewallSettings returns the global firewall settings that are in effect.
+func (s *sessionRpcServer) GetFirewallSettings(_ context.Context,
+	_ *litrpc.GetFirewallSettingsRequest) (
+	*litrpc.GetFirewallSettingsResponse, error) {
+
+	return &litrpc.GetFirewallSettingsResponse{
+		Settings: marshalFirewallSettings(
+			s.cfg.firewallSettings.Settings(),
+		),
+	}, nil
+}
+
+// UpdateFirewallSettings changes the global firewall settings at runtime.
+func (s *sessionRpcServer) UpdateFirewallSettings(ctx context.Context,
+	req *litrpc.UpdateFirewallSettingsRequest) (
+	*litrpc.UpdateFirewallSettingsResponse, error) {
+
+	update := &firewall.SettingsUpdate{
+		Reset: req.ResetOverrides,
+	}
+	if req.RequestLoggerLevel != "" {
+		level := firewall.RequestLoggerLevel(req.RequestLoggerLevel)
+		update.RequestLoggerLevel = &level
+	}
+
+	var err error
+	update.PrivacyMapper, err = unmarshalFirewallToggle(req.PrivacyMapper)
+	if err != nil {
+		return nil, err
+	}
+	update.RuleEnforcement, err = unmarshalFirewallToggle(
+		req.RuleEnforcement,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.cfg.firewallSettings.Update(update)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.cfg.auditor.Record(
+		s.cfg.auditor.Actor(ctx),
+		"/litrpc.Firewall/UpdateFirewallSettings", req,
+	)
+	if err != nil {
+		log.Errorf("Error recording update of firewall settings: %v",
+			err)
+	}
+
+	return &litrpc.UpdateFirewallSettingsResponse{
+		Settings: marshalFirewallSettings(settings),
 	}, nil
 }
 
+// marshalFirewallSettings converts the firewall settings into their RPC
+// counterpart.
+func marshalFirewallSettings(
+	settings firewall.Settings) *litrpc.FirewallSettings {
+
+	return &litrpc.FirewallSettings{
+		RequestLoggerLevel: string(settings.RequestLoggerLevel),
+		PrivacyMapper:      settings.PrivacyMapper,
+		RuleEnforcement:    settings.RuleEnforcement,
+		Overridden:         settings.Overridden,
+	}
+}
+
+// unmarshalFirewallToggle converts an RPC toggle into the new state of a
+// setting. Nil is returned if the setting should not be updated.
+func unmarshalFirewallToggle(toggle litrpc.FirewallToggle) (*bool, error) {
+	var enabled bool
+	switch toggle {
+	case litrpc.FirewallToggle_FIREWALL_TOGGLE_UNCHANGED:
+		return nil, nil
+
+	case litrpc.FirewallToggle_FIREWALL_TOGGLE_ENABLE:
+		enabled = true
+
+	case litrpc.FirewallToggle_FIREWALL_TOGGLE_DISABLE:
+		enabled = false
+
+	default:
+		return nil, fmt.Errorf("unknown firewall toggle: %v", toggle)
+	}
+
+	return &enabled, nil
+}
+
+// ListRuleMetrics returns the accumulated evaluation metrics of all rules.
+func (s *sessionRpcServer) ListRuleMetrics(_ context.Context,
+	_ *litrpc.ListRuleMetricsRequest) (*litrpc.ListRuleMetricsResponse,
+	error) {
+
+	metrics, err := s.cfg.actionsDB.ListRuleMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListRuleMetricsResponse{
+		Rules: make([]*litrpc.RuleMetrics, len(metrics)),
+	}
+	for i, m := range metrics {
+		var (
+			avgUs   = m.AvgLatency().Microseconds()
+			maxUs   = m.MaxLatency.Microseconds()
+			totalUs = m.TotalLatency.Microseconds()
+		)
+		resp.Rules[i] = &litrpc.RuleMetrics{
+			RuleName:       m.RuleName,
+			Evaluations:    m.Evaluations,
+			Denials:        m.Denials,
+			AvgLatencyUs:   uint64(avgUs),
+			MaxLatencyUs:   uint64(maxUs),
+			TotalLatencyUs: uint64(totalUs),
+			Since:          uint64(m.Since.Unix()),
+		}
+	}
+
+	return resp, nil
+}
+
 // ListAutopilotFeatures fetches all the features supported by the autopilot
 // server along with the rules that we need to support in order to subscribe
 // to those features.
@@ -800,13 +2214,68 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 		return nil, fmt.Errorf("must include at least one feature")
 	}
 
-	expiry := time.Unix(int64(req.ExpiryTimestampSeconds), 0)
-	if time.Now().After(expiry) {
-		return nil, fmt.Errorf("expiry must be in the future")
+	if err := validateTags(req.Tags); err != nil {
+		return nil, err
+	}
+
+	routeHints, err := unmarshalRouteHintPolicy(req.RouteHintPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := time.Unix(int64(req.ExpiryTimestampSeconds), 0)
+	if time.Now().After(expiry) {
+		return nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	privacy := !req.NoPrivacyMapper
+	privacyMapPairs := make(map[string]string)
+
+	if req.DeterministicPrivacy && !privacy {
+		return nil, fmt.Errorf("deterministic privacy can't be " +
+			"combined with no privacy mapper")
+	}
+	if len(req.PrivacyKeyFrom) > 0 && !req.DeterministicPrivacy {
+		return nil, fmt.Errorf("privacy key from requires " +
+			"deterministic privacy")
+	}
+
+	var pseudoKey []byte
+	if req.DeterministicPrivacy {
+		pseudoKey, err = s.pseudoKey(req.PrivacyKeyFrom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pseudoGen := firewalldb.NewPseudoGenerator(pseudoKey)
+
+	// Collect the amount obfuscation settings. The session wide settings
+	// are stored under an empty feature name.
+	obfuscation := make(map[string]*firewalldb.AmountObfuscation)
+	if req.AmountObfuscation != nil {
+		obfuscation[""], err = unmarshalAmountObfuscation(
+			req.AmountObfuscation,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
+	for f, cfg := range req.Features {
+		if cfg.AmountObfuscation == nil {
+			continue
+		}
 
-	privacy := !req.NoPrivacyMapper
-	privacyMapPairs := make(map[string]string)
+		obfuscation[f], err = unmarshalAmountObfuscation(
+			cfg.AmountObfuscation,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("feature %s: %w", f, err)
+		}
+	}
+	if len(obfuscation) > 0 && !privacy {
+		return nil, fmt.Errorf("amount obfuscation requires the " +
+			"privacy mapper")
+	}
 
 	// First need to fetch all the perms that need to be baked into this
 	// mac based on the features.
@@ -824,9 +2293,23 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 	// allRules represents all the rules that our firewall knows about.
 	allRules := s.cfg.ruleMgrs.GetAllRules()
 
+	// Fetch the rule bundle if one was selected.
+	var bundle *rules.Bundle
+	if req.RuleBundle != "" {
+		bundle, err = s.cfg.ruleBundles.GetBundle(req.RuleBundle)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching rule bundle "+
+				"%s: %v", req.RuleBundle, err)
+		}
+	} else if req.FollowBundleUpdates {
+		return nil, fmt.Errorf("a rule bundle must be selected in " +
+			"order to follow its updates")
+	}
+
 	// Check that each requested feature is a valid autopilot feature and
 	// that the necessary rules for the feature have been specified.
 	featureRules := make(map[string]map[string]string, len(req.Features))
+	featureBundles := make(map[string]string)
 	for f, rs := range req.Features {
 		// Check that the features is known by the autopilot server.
 		autopilotFeature, ok := autopilotFeatureMap[f]
@@ -847,20 +2330,51 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 					return nil, err
 				}
 
-				if privacy {
-					var privMapPairs map[string]string
-					v, privMapPairs, err = v.RealToPseudo()
-					if err != nil {
-						return nil, err
-					}
+				reqRules = append(reqRules, v)
+			}
+		}
 
-					for k, v := range privMapPairs {
-						privacyMapPairs[k] = v
-					}
+		// If a rule bundle was selected, then any of its rules that
+		// the feature supports and that were not explicitly set in
+		// the request are added too.
+		if bundle != nil {
+			explicit := make(map[string]bool, len(reqRules))
+			for _, r := range reqRules {
+				explicit[r.RuleName()] = true
+			}
+
+			for name, v := range bundle.Rules {
+				if explicit[name] {
+					continue
+				}
+
+				if _, ok := autopilotFeature.Rules[name]; !ok {
+					continue
 				}
 
 				reqRules = append(reqRules, v)
 			}
+
+			if req.FollowBundleUpdates {
+				featureBundles[f] = bundle.Name
+			}
+		}
+
+		if privacy {
+			for i, v := range reqRules {
+				pseudo, privMapPairs, err := v.RealToPseudo(
+					pseudoGen,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				for k, v := range privMapPairs {
+					privacyMapPairs[k] = v
+				}
+
+				reqRules[i] = pseudo
+			}
 		}
 
 		// Create a lookup map for the rules specified in this feature.
@@ -940,7 +2454,8 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 	}
 
 	interceptRules := &firewall.InterceptRules{
-		FeatureRules: featureRules,
+		FeatureRules:   featureRules,
+		FeatureBundles: featureBundles,
 	}
 
 	// Gather all the permissions we need to add to the macaroon given the
@@ -979,17 +2494,45 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 		caveats = append(caveats, firewall.MetaPrivacyCaveat)
 	}
 
+	prev, err := s.previousSession(req.Label, session.TypeAutopilot)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.cfg.keyBackend.NewKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session key: %v", err)
+	}
+
 	sess, err := session.NewSession(
-		req.Label, session.TypeAutopilot, expiry, req.MailboxServerAddr,
-		req.DevServer, perms, caveats, featureConfig, privacy,
+		key, req.Label, session.TypeAutopilot, expiry,
+		req.MailboxServerAddr, req.DevServer, perms, caveats,
+		featureConfig, privacy,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new session: %v", err)
 	}
+	sess.Notes = req.Notes
+	sess.Tags = req.Tags
+	sess.RouteHintPolicy = routeHints
+	sess.BlockOnChainSpend = req.BlockOnchainSpend
 
 	// Register all the privacy map pairs for this session ID.
 	privDB := s.cfg.privMap(sess.ID)
 	err = privDB.Update(func(tx firewalldb.PrivacyMapTx) error {
+		if pseudoKey != nil {
+			if err := tx.SetPseudoKey(pseudoKey); err != nil {
+				return err
+			}
+		}
+
+		for f, settings := range obfuscation {
+			err := tx.SetAmountObfuscation(f, settings)
+			if err != nil {
+				return err
+			}
+		}
+
 		for r, p := range privacyMapPairs {
 			err := tx.NewPair(r, p)
 			if err != nil {
@@ -1029,66 +2572,436 @@ func (s *sessionRpcServer) AddAutopilotSession(ctx context.Context,
 	}
 
 	return &litrpc.AddAutopilotSessionResponse{
-		Session: rpcSession,
+		Session:             rpcSession,
+		PreviousSessionDiff: s.sessionDiff(prev, sess),
+	}, nil
+}
+
+// unmarshalAmountObfuscation converts the RPC amount obfuscation settings to
+// their firewalldb counterpart.
+func unmarshalAmountObfuscation(
+	rpcSettings *litrpc.AmountObfuscation) (*firewalldb.AmountObfuscation,
+	error) {
+
+	if rpcSettings.VariationPercent < 0 ||
+		rpcSettings.VariationPercent > 100 {
+
+		return nil, fmt.Errorf("amount variation must be between 0 "+
+			"and 100 percent, is %v", rpcSettings.VariationPercent)
+	}
+
+	return &firewalldb.AmountObfuscation{
+		Variation:      rpcSettings.VariationPercent / 100,
+		GranularitySat: rpcSettings.GranularitySat,
+	}, nil
+}
+
+// pseudoKey returns the key that the pseudo values of a new session with
+// deterministic privacy are derived from. If the local public key of an
+// earlier session is given, its key is reused. Otherwise, a new random key is
+// created.
+func (s *sessionRpcServer) pseudoKey(localPubKey []byte) ([]byte, error) {
+	if len(localPubKey) == 0 {
+		key := make([]byte, firewalldb.PseudoKeyLen)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	}
+
+	pubKey, err := btcec.ParsePubKey(localPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.db.GetSession(pubKey)
+	if err != nil {
+		return nil, sessionRPCError(
+			fmt.Errorf("error fetching session: %w", err),
+		)
+	}
+
+	var key []byte
+	privMap := s.cfg.privMap(sess.ID)
+	err = privMap.View(func(tx firewalldb.PrivacyMapTx) error {
+		var err error
+		key, err = tx.PseudoKey()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("session %x doesn't use deterministic "+
+			"privacy", localPubKey)
+	}
+
+	return key, nil
+}
+
+// ListAutopilotSessions fetches and returns all the sessions from the DB that
+// are of type TypeAutopilot.
+func (s *sessionRpcServer) ListAutopilotSessions(_ context.Context,
+	_ *litrpc.ListAutopilotSessionsRequest) (
+	*litrpc.ListAutopilotSessionsResponse, error) {
+
+	sessions, err := s.db.ListSessions(func(s *session.Session) bool {
+		return s.Type == session.TypeAutopilot
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sessions: %v", err)
+	}
+
+	response := &litrpc.ListAutopilotSessionsResponse{
+		Sessions: make([]*litrpc.Session, len(sessions)),
+	}
+	for idx, sess := range sessions {
+		response.Sessions[idx], err = s.marshalRPCSession(sess)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling session: %v",
+				err)
+		}
+	}
+
+	return response, nil
+}
+
+// sessionRPCError converts the known session errors into gRPC status errors
+// that carry an ErrorDetail with a machine-readable error code. Other errors
+// are returned unchanged.
+func sessionRPCError(err error) error {
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return litrpc.SessionNotFoundError(err.Error())
+	}
+
+	return err
+}
+
+// RevokeAutopilotSession revokes an autopilot session.
+func (s *sessionRpcServer) RevokeAutopilotSession(ctx context.Context,
+	req *litrpc.RevokeAutopilotSessionRequest) (
+	*litrpc.RevokeAutopilotSessionResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	sess, err := s.db.GetSession(pubKey)
+	if err != nil {
+		return nil, sessionRPCError(err)
+	}
+
+	if sess.Type != session.TypeAutopilot {
+		return nil, sessionRPCError(session.ErrSessionNotFound)
+	}
+
+	_, err = s.RevokeSession(
+		ctx, &litrpc.RevokeSessionRequest{
+			LocalPublicKey: req.LocalPublicKey,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RevokeAutopilotSessionResponse{}, nil
+}
+
+// ListRuleBundles lists all the built-in and custom rule bundles.
+func (s *sessionRpcServer) ListRuleBundles(_ context.Context,
+	_ *litrpc.ListRuleBundlesRequest) (*litrpc.ListRuleBundlesResponse,
+	error) {
+
+	bundles, err := s.cfg.ruleBundles.ListBundles()
+	if err != nil {
+		return nil, fmt.Errorf("error listing rule bundles: %v", err)
+	}
+
+	resp := &litrpc.ListRuleBundlesResponse{
+		Bundles: make([]*litrpc.RuleBundle, len(bundles)),
+	}
+	for i, bundle := range bundles {
+		resp.Bundles[i] = marshalRuleBundle(bundle)
+	}
+
+	return resp, nil
+}
+
+// GetRuleBundle returns the rule bundle with the given name.
+func (s *sessionRpcServer) GetRuleBundle(_ context.Context,
+	req *litrpc.GetRuleBundleRequest) (*litrpc.GetRuleBundleResponse,
+	error) {
+
+	bundle, err := s.cfg.ruleBundles.GetBundle(req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rule bundle: %w", err)
+	}
+
+	return &litrpc.GetRuleBundleResponse{
+		Bundle: marshalRuleBundle(bundle),
 	}, nil
 }
 
-// ListAutopilotSessions fetches and returns all the sessions from the DB that
-// are of type TypeAutopilot.
-func (s *sessionRpcServer) ListAutopilotSessions(_ context.Context,
-	_ *litrpc.ListAutopilotSessionsRequest) (
-	*litrpc.ListAutopilotSessionsResponse, error) {
+// SetRuleBundle adds a new custom rule bundle or updates an existing one.
+func (s *sessionRpcServer) SetRuleBundle(_ context.Context,
+	req *litrpc.SetRuleBundleRequest) (*litrpc.SetRuleBundleResponse,
+	error) {
+
+	if req.Bundle == nil {
+		return nil, fmt.Errorf("no rule bundle specified")
+	}
+
+	bundle := &rules.Bundle{
+		Name:        req.Bundle.Name,
+		Description: req.Bundle.Description,
+		Rules:       make(map[string]rules.Values),
+	}
+	if req.Bundle.Rules != nil {
+		for name, rule := range req.Bundle.Rules.Rules {
+			v, err := s.cfg.ruleMgrs.UnmarshalRuleValues(
+				name, rule,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing rule "+
+					"%s: %v", name, err)
+			}
+
+			bundle.Rules[name] = v
+		}
+	}
+
+	if err := s.cfg.ruleBundles.SetBundle(bundle); err != nil {
+		return nil, fmt.Errorf("error storing rule bundle: %v", err)
+	}
+
+	return &litrpc.SetRuleBundleResponse{}, nil
+}
+
+// RemoveRuleBundle removes a custom rule bundle.
+func (s *sessionRpcServer) RemoveRuleBundle(_ context.Context,
+	req *litrpc.RemoveRuleBundleRequest) (*litrpc.RemoveRuleBundleResponse,
+	error) {
+
+	if err := s.cfg.ruleBundles.RemoveBundle(req.Name); err != nil {
+		return nil, fmt.Errorf("error removing rule bundle: %v", err)
+	}
+
+	return &litrpc.RemoveRuleBundleResponse{}, nil
+}
+
+// UpdateSessionRules sets the value of a rule across all active Autopilot
+// sessions or a filtered set of them. All sessions are updated in a single
+// transaction. With preview set, the affected sessions are only listed.
+func (s *sessionRpcServer) UpdateSessionRules(ctx context.Context,
+	req *litrpc.UpdateSessionRulesRequest) (
+	*litrpc.UpdateSessionRulesResponse, error) {
+
+	if req.RuleName == "" {
+		return nil, fmt.Errorf("no rule name specified")
+	}
+
+	if req.Value == nil {
+		return nil, fmt.Errorf("no rule value specified")
+	}
+
+	value, err := s.cfg.ruleMgrs.UnmarshalRuleValues(
+		req.RuleName, req.Value,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule %s: %v",
+			req.RuleName, err)
+	}
+
+	valueBytes, err := rules.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[session.ID]bool, len(req.SessionIds))
+	for _, idBytes := range req.SessionIds {
+		id, err := session.IDFromBytes(idBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid session ID %x: %v",
+				idBytes, err)
+		}
+
+		ids[id] = false
+	}
+
+	sessions, err := s.db.ListSessions(func(sess *session.Session) bool {
+		if sess.Type != session.TypeAutopilot {
+			return false
+		}
+
+		if sess.State != session.StateCreated &&
+			sess.State != session.StateInUse {
+
+			return false
+		}
+
+		if len(ids) == 0 {
+			return true
+		}
+
+		_, ok := ids[sess.ID]
+		return ok
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sessions: %v", err)
+	}
+
+	for _, sess := range sessions {
+		ids[sess.ID] = true
+	}
+	for id, found := range ids {
+		if !found {
+			return nil, fmt.Errorf("session %x is not an active "+
+				"Autopilot session", id[:])
+		}
+	}
+
+	// The bounds of the rule are the same for all sessions that use a
+	// feature, so they are only looked up once per feature.
+	var features map[string]*autopilotserver.Feature
+	verifyBounds := func(featureName string) error {
+		if features == nil {
+			all, err := s.cfg.autopilot.ListFeatures(ctx)
+			if err != nil {
+				return err
+			}
+
+			features = make(map[string]*autopilotserver.Feature)
+			for _, f := range all {
+				features[f.Name] = f
+			}
+		}
+
+		feature, ok := features[featureName]
+		if !ok {
+			return fmt.Errorf("%s is not a feature provided by "+
+				"the Autopilot server", featureName)
+		}
+
+		specs, ok := feature.Rules[req.RuleName]
+		if !ok {
+			return fmt.Errorf("autopilot did not specify %s as a "+
+				"rule for feature %s", req.RuleName,
+				featureName)
+		}
+
+		min, err := s.cfg.ruleMgrs.InitRuleValues(
+			req.RuleName, specs.MinVal,
+		)
+		if err != nil {
+			return err
+		}
+
+		max, err := s.cfg.ruleMgrs.InitRuleValues(
+			req.RuleName, specs.MaxVal,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := value.VerifySane(min, max); err != nil {
+			return fmt.Errorf("rule value for %s is not within "+
+				"the bounds of feature %s: %v", req.RuleName,
+				featureName, err)
+		}
 
-	sessions, err := s.db.ListSessions(func(s *session.Session) bool {
-		return s.Type == session.TypeAutopilot
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error fetching sessions: %v", err)
+		return nil
 	}
 
-	response := &litrpc.ListAutopilotSessionsResponse{
-		Sessions: make([]*litrpc.Session, len(sessions)),
-	}
-	for idx, sess := range sessions {
-		response.Sessions[idx], err = s.marshalRPCSession(sess)
+	var (
+		resp      = &litrpc.UpdateSessionRulesResponse{}
+		overrides []*firewalldb.RuleOverride
+		verified  = make(map[string]bool)
+	)
+	for _, sess := range sessions {
+		featureRules, err := s.sessionRules(sess)
 		if err != nil {
-			return nil, fmt.Errorf("error marshaling session: %v",
-				err)
+			return nil, fmt.Errorf("error parsing rules of "+
+				"session %x: %v", sess.ID[:], err)
 		}
-	}
 
-	return response, nil
-}
+		for feature, values := range featureRules {
+			if req.FeatureName != "" && feature != req.FeatureName {
+				continue
+			}
 
-// RevokeAutopilotSession revokes an autopilot session.
-func (s *sessionRpcServer) RevokeAutopilotSession(ctx context.Context,
-	req *litrpc.RevokeAutopilotSessionRequest) (
-	*litrpc.RevokeAutopilotSessionResponse, error) {
+			current, ok := values[req.RuleName]
+			if !ok {
+				continue
+			}
 
-	pubKey, err := btcec.ParsePubKey(req.LocalPublicKey)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing public key: %v", err)
+			currentBytes, err := rules.Marshal(current)
+			if err != nil {
+				return nil, err
+			}
+
+			if bytes.Equal(currentBytes, valueBytes) {
+				continue
+			}
+
+			if !verified[feature] {
+				if err := verifyBounds(feature); err != nil {
+					return nil, err
+				}
+				verified[feature] = true
+			}
+
+			resp.Updates = append(resp.Updates,
+				&litrpc.SessionRuleUpdate{
+					SessionId: sess.ID[:],
+					Label:     sess.Label,
+					Change: &litrpc.RuleChange{
+						FeatureName:   feature,
+						RuleName:      req.RuleName,
+						PreviousValue: current.ToProto(),
+						CurrentValue:  value.ToProto(),
+					},
+				},
+			)
+			overrides = append(overrides, &firewalldb.RuleOverride{
+				SessionID:   sess.ID,
+				FeatureName: feature,
+				RuleName:    req.RuleName,
+				Value:       string(valueBytes),
+			})
+		}
 	}
 
-	sess, err := s.db.GetSession(pubKey)
-	if err != nil {
-		return nil, err
+	if req.Preview || len(overrides) == 0 {
+		return resp, nil
 	}
 
-	if sess.Type != session.TypeAutopilot {
-		return nil, session.ErrSessionNotFound
+	if err := s.cfg.actionsDB.StoreRuleOverrides(overrides); err != nil {
+		return nil, fmt.Errorf("error storing rule values: %v", err)
 	}
+	resp.Applied = true
 
-	_, err = s.RevokeSession(
-		ctx, &litrpc.RevokeSessionRequest{
-			LocalPublicKey: req.LocalPublicKey,
-		},
-	)
-	if err != nil {
-		return nil, err
+	log.Infof("Updated rule %s of %d session features", req.RuleName,
+		len(overrides))
+
+	return resp, nil
+}
+
+// marshalRuleBundle converts a rule bundle into its RPC counterpart.
+func marshalRuleBundle(bundle *rules.Bundle) *litrpc.RuleBundle {
+	rulesMap := make(map[string]*litrpc.RuleValue, len(bundle.Rules))
+	for name, v := range bundle.Rules {
+		rulesMap[name] = v.ToProto()
 	}
 
-	return &litrpc.RevokeAutopilotSessionResponse{}, nil
+	return &litrpc.RuleBundle{
+		Name:        bundle.Name,
+		Description: bundle.Description,
+		Rules:       &litrpc.RulesMap{Rules: rulesMap},
+		BuiltIn:     bundle.BuiltIn,
+	}
 }
 
 func marshalRulesToStringMap(rs []rules.Values) (map[string]string, error) {
@@ -1121,10 +3034,16 @@ func convertRules(ruleMgr rules.ManagerSet,
 		knownRules = ruleMgr.GetAllRules()
 	)
 	for name, rule := range ruleList {
-		known := true
+		// The values of a rule we don't know can't be parsed. We still
+		// list the rule so that the caller knows what the feature
+		// needs and that an upgrade is required.
 		if !knownRules[name] {
 			upgrade = true
-			known = false
+			res[name] = &litrpc.RuleValues{
+				Known: false,
+			}
+
+			continue
 		}
 
 		defaultVals, err := ruleMgr.InitRuleValues(name, rule.Default)
@@ -1143,7 +3062,7 @@ func convertRules(ruleMgr rules.ManagerSet,
 		}
 
 		res[name] = &litrpc.RuleValues{
-			Known:    known,
+			Known:    true,
 			Defaults: defaultVals.ToProto(),
 			MinValue: minVals.ToProto(),
 			MaxValue: maxVals.ToProto(),
@@ -1172,6 +3091,12 @@ func marshalPerms(perms map[string][]bakery.Op) []*litrpc.Permissions {
 		})
 	}
 
+	// Return the permissions in a stable order so that they can be
+	// compared and displayed nicely.
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Method < res[j].Method
+	})
+
 	return res
 }
 
@@ -1194,9 +3119,19 @@ func (s *sessionRpcServer) marshalRPCSession(sess *session.Session) (
 		remotePubKey = sess.RemotePublicKey.SerializeCompressed()
 	}
 
-	mnemonic, err := mailbox.PassphraseEntropyToMnemonic(sess.PairingSecret)
-	if err != nil {
-		return nil, err
+	// The pairing phrase of a session that is paired offline must not
+	// show up in any response, and neither may the pairing secret it is
+	// derived from after the session was created.
+	var pairingPhrase string
+	if !sess.OfflinePairingPhrase {
+		mnemonic, err := mailbox.PassphraseEntropyToMnemonic(
+			sess.PairingSecret,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		pairingPhrase = strings.Join(mnemonic[:], " ")
 	}
 
 	macRecipe := marshalRPCMacaroonRecipe(sess.MacaroonRecipe)
@@ -1206,38 +3141,19 @@ func (s *sessionRpcServer) marshalRPCSession(sess *session.Session) (
 		revokedAt = uint64(sess.RevokedAt.Unix())
 	}
 
-	featureInfo := make(map[string]*litrpc.RulesMap)
-	if sess.MacaroonRecipe != nil {
-		for _, cav := range sess.MacaroonRecipe.Caveats {
-			info, err := firewall.ParseRuleCaveat(string(cav.Id))
-			if errors.Is(err, firewall.ErrNoRulesCaveat) {
-				continue
-			} else if err != nil {
-				return nil, err
-			}
-
-			for feature, rules := range info.FeatureRules {
-				ruleMap := make(map[string]*litrpc.RuleValue)
-				for name, rule := range rules {
-					val, err := s.cfg.ruleMgrs.InitRuleValues(name, []byte(rule))
-					if err != nil {
-						return nil, err
-					}
-
-					if sess.WithPrivacyMapper {
-						db := s.cfg.privMap(sess.ID)
-						val, err = val.PseudoToReal(db)
-						if err != nil {
-							return nil, err
-						}
-					}
-
-					ruleMap[name] = val.ToProto()
-				}
+	featureRules, err := s.sessionRules(sess)
+	if err != nil {
+		return nil, err
+	}
 
-				featureInfo[feature] = &litrpc.RulesMap{Rules: ruleMap}
-			}
+	featureInfo := make(map[string]*litrpc.RulesMap, len(featureRules))
+	for feature, values := range featureRules {
+		ruleMap := make(map[string]*litrpc.RuleValue, len(values))
+		for name, val := range values {
+			ruleMap[name] = val.ToProto()
 		}
+
+		featureInfo[feature] = &litrpc.RulesMap{Rules: ruleMap}
 	}
 
 	return &litrpc.Session{
@@ -1248,17 +3164,280 @@ func (s *sessionRpcServer) marshalRPCSession(sess *session.Session) (
 		ExpiryTimestampSeconds: uint64(sess.Expiry.Unix()),
 		MailboxServerAddr:      sess.ServerAddr,
 		DevServer:              sess.DevServer,
-		PairingSecret:          sess.PairingSecret[:],
-		PairingSecretMnemonic:  strings.Join(mnemonic[:], " "),
+		PairingSecret:          sess.PublicPairingSecret(),
+		PairingSecretMnemonic:  pairingPhrase,
 		LocalPublicKey:         sess.LocalPublicKey.SerializeCompressed(),
 		RemotePublicKey:        remotePubKey,
 		CreatedAt:              uint64(sess.CreatedAt.Unix()),
 		RevokedAt:              revokedAt,
 		MacaroonRecipe:         macRecipe,
 		AutopilotFeatureInfo:   featureInfo,
+		Notes:                  sess.Notes,
+		Tags:                   sess.Tags,
+		RevokedBy:              sess.RevokedBy,
+		RevocationReason:       sess.RevocationReason,
+		PinClient:              sess.PinClient,
+		Client:                 marshalRPCClient(sess.Client),
+		OfflinePairingPhrase:   sess.OfflinePairingPhrase,
+		RouteHintPolicy: marshalRouteHintPolicy(
+			sess.RouteHintPolicy,
+		),
+		PassphraseProtected: sess.PassphraseSecret != nil,
+		BlockOnchainSpend:   sess.BlockOnChainSpend,
+	}, nil
+}
+
+// sessionRules returns the rules of the features of the given session. The
+// values are converted to their real values if the session uses the privacy
+// mapper.
+func (s *sessionRpcServer) sessionRules(
+	sess *session.Session) (map[string]map[string]rules.Values, error) {
+
+	featureRules := make(map[string]map[string]rules.Values)
+	if sess.MacaroonRecipe == nil {
+		return featureRules, nil
+	}
+
+	for _, cav := range sess.MacaroonRecipe.Caveats {
+		info, err := firewall.ParseRuleCaveat(string(cav.Id))
+		if errors.Is(err, firewall.ErrNoRulesCaveat) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		for feature, featRules := range info.FeatureRules {
+			values := make(map[string]rules.Values, len(featRules))
+			for name, rule := range featRules {
+				val, err := s.cfg.ruleMgrs.InitRuleValues(
+					name, []byte(rule),
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				if sess.WithPrivacyMapper {
+					db := s.cfg.privMap(sess.ID)
+					val, err = val.PseudoToReal(db)
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				values[name] = val
+			}
+
+			featureRules[feature] = values
+		}
+	}
+
+	// Rule values that were set after the session was created replace the
+	// values of the macaroon. They are stored in their real form.
+	overrides, err := s.cfg.actionsDB.GetRuleOverrides(sess.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for feature, featRules := range overrides {
+		values, ok := featureRules[feature]
+		if !ok {
+			continue
+		}
+
+		for name, rule := range featRules {
+			if _, ok := values[name]; !ok {
+				continue
+			}
+
+			val, err := s.cfg.ruleMgrs.InitRuleValues(
+				name, []byte(rule),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			values[name] = val
+		}
+	}
+
+	return featureRules, nil
+}
+
+// previousSession returns the most recently created session with the given
+// label and type, no matter its state. Sessions with the same label and type
+// form a group in which a new session is a re-creation of the previous one.
+// Nil is returned if the label is empty or there is no such session.
+func (s *sessionRpcServer) previousSession(label string,
+	typ session.Type) (*session.Session, error) {
+
+	if label == "" {
+		return nil, nil
+	}
+
+	sessions, err := s.db.ListSessions(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %v", err)
+	}
+
+	var prev *session.Session
+	for _, sess := range sessions {
+		if sess.Label != label || sess.Type != typ {
+			continue
+		}
+
+		if prev == nil || sess.CreatedAt.After(prev.CreatedAt) {
+			prev = sess
+		}
+	}
+
+	return prev, nil
+}
+
+// sessionGrants collects the permissions, caveats and rules of the given
+// session. The rules are encoded with their real values, so that sessions with
+// different pseudo values can be compared.
+func (s *sessionRpcServer) sessionGrants(
+	sess *session.Session) (*session.Grants, error) {
+
+	featureRules, err := s.sessionRules(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := &session.Grants{
+		Rules: make(map[string]map[string]string, len(featureRules)),
+	}
+	for feature, values := range featureRules {
+		encoded := make(map[string]string, len(values))
+		for name, val := range values {
+			valBytes, err := rules.Marshal(val)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded[name] = string(valBytes)
+		}
+
+		grants.Rules[feature] = encoded
+	}
+
+	if sess.MacaroonRecipe == nil {
+		return grants, nil
+	}
+
+	grants.Permissions = sess.MacaroonRecipe.Permissions
+	for _, cav := range sess.MacaroonRecipe.Caveats {
+		_, err := firewall.ParseRuleCaveat(string(cav.Id))
+		if !errors.Is(err, firewall.ErrNoRulesCaveat) {
+			continue
+		}
+
+		grants.Caveats = append(grants.Caveats, string(cav.Id))
+	}
+
+	return grants, nil
+}
+
+// sessionDiff returns the differences between the permissions, caveats and
+// rules of the previous session of a group and the given new session. Nil is
+// returned if there is no previous session. Errors are only logged, as the new
+// session was already created.
+func (s *sessionRpcServer) sessionDiff(prev,
+	sess *session.Session) *litrpc.SessionDiff {
+
+	if prev == nil {
+		return nil
+	}
+
+	rpcDiff, err := s.marshalSessionDiff(prev, sess)
+	if err != nil {
+		log.Errorf("Error comparing session %x to its previous session "+
+			"%x: %v", sess.ID[:], prev.ID[:], err)
+
+		return nil
+	}
+
+	return rpcDiff
+}
+
+// marshalSessionDiff compares the grants of the two sessions and converts the
+// differences into their RPC counterpart.
+func (s *sessionRpcServer) marshalSessionDiff(prev,
+	sess *session.Session) (*litrpc.SessionDiff, error) {
+
+	prevGrants, err := s.sessionGrants(prev)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := s.sessionGrants(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := session.DiffGrants(prevGrants, grants)
+
+	ruleValue := func(name, value string) (*litrpc.RuleValue, error) {
+		if value == "" {
+			return nil, nil
+		}
+
+		val, err := s.cfg.ruleMgrs.InitRuleValues(name, []byte(value))
+		if err != nil {
+			return nil, err
+		}
+
+		return val.ToProto(), nil
+	}
+
+	ruleChanges := make([]*litrpc.RuleChange, len(diff.RuleChanges))
+	for i, change := range diff.RuleChanges {
+		prevValue, err := ruleValue(change.Rule, change.Previous)
+		if err != nil {
+			return nil, err
+		}
+
+		curValue, err := ruleValue(change.Rule, change.Current)
+		if err != nil {
+			return nil, err
+		}
+
+		ruleChanges[i] = &litrpc.RuleChange{
+			FeatureName:   change.Feature,
+			RuleName:      change.Rule,
+			PreviousValue: prevValue,
+			CurrentValue:  curValue,
+		}
+	}
+
+	return &litrpc.SessionDiff{
+		PreviousSessionId: prev.ID[:],
+		AddedPermissions: marshalRPCPermissions(
+			diff.AddedPermissions,
+		),
+		RemovedPermissions: marshalRPCPermissions(
+			diff.RemovedPermissions,
+		),
+		AddedCaveats:   diff.AddedCaveats,
+		RemovedCaveats: diff.RemovedCaveats,
+		RuleChanges:    ruleChanges,
 	}, nil
 }
 
+// marshalRPCClient converts a session client identity into its RPC
+// counterpart.
+func marshalRPCClient(client *session.ClientIdentity) *litrpc.ClientIdentity {
+	if client == nil {
+		return nil
+	}
+
+	return &litrpc.ClientIdentity{
+		UserAgent:  client.UserAgent,
+		ClientInfo: client.Info,
+		FirstSeen:  uint64(client.FirstSeen.Unix()),
+	}
+}
+
 // marshalRPCMacaroonRecipe converts a macaroon recipe (permissions and caveats)
 // into its RPC counterpart.
 func marshalRPCMacaroonRecipe(
@@ -1268,13 +3447,7 @@ func marshalRPCMacaroonRecipe(
 		return nil
 	}
 
-	perms := make([]*litrpc.MacaroonPermission, len(recipe.Permissions))
-	for i, op := range recipe.Permissions {
-		perms[i] = &litrpc.MacaroonPermission{
-			Entity: op.Entity,
-			Action: op.Action,
-		}
-	}
+	perms := marshalRPCPermissions(recipe.Permissions)
 
 	caveats := make([]string, len(recipe.Caveats))
 	for i, cav := range recipe.Caveats {
@@ -1287,6 +3460,20 @@ func marshalRPCMacaroonRecipe(
 	}
 }
 
+// marshalRPCPermissions converts macaroon permissions into their RPC
+// counterpart.
+func marshalRPCPermissions(ops []bakery.Op) []*litrpc.MacaroonPermission {
+	perms := make([]*litrpc.MacaroonPermission, len(ops))
+	for i, op := range ops {
+		perms[i] = &litrpc.MacaroonPermission{
+			Entity: op.Entity,
+			Action: op.Action,
+		}
+	}
+
+	return perms
+}
+
 // marshalRPCState converts a session state to its RPC counterpart.
 func marshalRPCState(state session.State) (litrpc.SessionState, error) {
 	switch state {
@@ -1359,6 +3546,42 @@ func unmarshalRPCType(typ litrpc.SessionType) (session.Type, error) {
 	}
 }
 
+// marshalRouteHintPolicy converts a route hint policy to its RPC counterpart.
+func marshalRouteHintPolicy(
+	policy session.RouteHintPolicy) litrpc.RouteHintPolicy {
+
+	switch policy {
+	case session.RouteHintsExplicitOnly:
+		return litrpc.RouteHintPolicy_ROUTE_HINTS_EXPLICIT_ONLY
+
+	case session.RouteHintsStrip:
+		return litrpc.RouteHintPolicy_ROUTE_HINTS_STRIP
+
+	default:
+		return litrpc.RouteHintPolicy_ROUTE_HINTS_ALLOW
+	}
+}
+
+// unmarshalRouteHintPolicy converts an RPC route hint policy to its session
+// counterpart.
+func unmarshalRouteHintPolicy(
+	policy litrpc.RouteHintPolicy) (session.RouteHintPolicy, error) {
+
+	switch policy {
+	case litrpc.RouteHintPolicy_ROUTE_HINTS_ALLOW:
+		return session.RouteHintsAllow, nil
+
+	case litrpc.RouteHintPolicy_ROUTE_HINTS_EXPLICIT_ONLY:
+		return session.RouteHintsExplicitOnly, nil
+
+	case litrpc.RouteHintPolicy_ROUTE_HINTS_STRIP:
+		return session.RouteHintsStrip, nil
+
+	default:
+		return 0, fmt.Errorf("unknown route hint policy <%d>", policy)
+	}
+}
+
 // marshalActionState converts an Action state into its RPC counterpart.
 func marshalActionState(state firewalldb.ActionState) (litrpc.ActionState,
 	error) {
@@ -1372,6 +3595,8 @@ func marshalActionState(state firewalldb.ActionState) (litrpc.ActionState,
 		return litrpc.ActionState_STATE_DONE, nil
 	case firewalldb.ActionStateError:
 		return litrpc.ActionState_STATE_ERROR, nil
+	case firewalldb.ActionStateDryRun:
+		return litrpc.ActionState_STATE_DRY_RUN, nil
 	default:
 		return 0, fmt.Errorf("unknown state <%d>", state)
 	}
@@ -0,0 +1,356 @@
+package terminal
+
+import (
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/status"
+)
+
+// shutdownStep is a single step of the orderly shutdown of litd.
+type shutdownStep struct {
+	// name describes what is stopped in the step.
+	name string
+
+	// stop stops everything that belongs to the step. It returns the last
+	// error that occurred, the remaining components of the step are
+	// stopped anyway.
+	stop func() error
+}
+
+// shutdownSteps returns the steps of the shutdown in the order they need to
+// run in. The LNC sessions are drained first so that no new calls reach the
+// components that are stopped afterwards. The account updates are flushed
+// before the integrated daemons stop, since their payments may still update
+// the accounts. The connection to lnd is closed last, because most other
+// components depend on it.
+func (g *LightningTerminal) shutdownSteps() []shutdownStep {
+	return []shutdownStep{
+		{name: "lnc sessions", stop: g.drainSessions},
+		{name: "account updates", stop: g.flushAccounts},
+		{name: "integrated daemons", stop: g.stopIntegratedDaemons},
+		{name: "background services", stop: g.stopBackgroundServices},
+		{name: "sessions and firewall", stop: g.stopFirewall},
+		{name: "macaroon service", stop: g.stopMacaroonService},
+		{name: "status monitor", stop: g.stopStatusServices},
+		{name: "cluster", stop: g.stopCluster},
+		{name: "lnd connection", stop: g.closeLndConnection},
+	}
+}
+
+// runShutdownSteps runs all shutdown steps and reports their progress to the
+// shutdown tracker. A failed step doesn't stop the shutdown, the last error
+// that occurred is returned.
+func (g *LightningTerminal) runShutdownSteps() error {
+	var (
+		steps     = g.shutdownSteps()
+		returnErr error
+	)
+	for i, step := range steps {
+		log.Infof("Shutdown step %d/%d: stopping %s", i+1, len(steps),
+			step.name)
+
+		start := time.Now()
+		err := step.stop()
+		if err != nil {
+			returnErr = err
+		}
+
+		g.shutdownTracker.StepDone(status.ShutdownStep{
+			Step:       i + 1,
+			TotalSteps: len(steps),
+			Name:       step.name,
+			Duration:   time.Since(start),
+			Err:        err,
+		})
+	}
+
+	return returnErr
+}
+
+// drainSessions stops all active LNC sessions gracefully, which lets their
+// calls in flight finish.
+func (g *LightningTerminal) drainSessions() error {
+	if g.sessionRpcServerStarted {
+		g.sessionRpcServer.drainSessions()
+	}
+
+	return nil
+}
+
+// flushAccounts makes the account service reject new payments and waits for
+// the account updates in progress to complete.
+func (g *LightningTerminal) flushAccounts() error {
+	if !g.accountServiceStarted {
+		return nil
+	}
+
+	inFlight := g.accountService.Flush()
+	log.Infof("Flushed account updates, %d account payments are still in "+
+		"flight", inFlight)
+
+	return nil
+}
+
+// stopIntegratedDaemons stops the integrated daemons in the reverse order of
+// their start.
+func (g *LightningTerminal) stopIntegratedDaemons() error {
+	var returnErr error
+
+	if g.poolStarted.Load() {
+		if err := g.poolServer.Stop(); err != nil {
+			log.Errorf("Error stopping pool: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.loopStarted.Load() {
+		g.loopServer.Stop()
+		if err := <-g.loopServer.ErrChan; err != nil {
+			log.Errorf("Error stopping loop: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.faradayStarted.Load() {
+		if err := g.faradayServer.Stop(); err != nil {
+			log.Errorf("Error stopping faraday: %v", err)
+			returnErr = err
+		}
+	}
+
+	return returnErr
+}
+
+// stopBackgroundServices stops the services that run independently of the
+// RPC servers, such as the schedulers.
+func (g *LightningTerminal) stopBackgroundServices() error {
+	var returnErr error
+
+	if g.autopilotClient != nil {
+		g.autopilotClient.Stop()
+	}
+
+	if g.autopilotMock != nil {
+		g.autopilotMock.Stop()
+	}
+
+	if g.lockdownMgrStarted {
+		if err := g.lockdownMgr.Stop(); err != nil {
+			log.Errorf("Error stopping lockdown manager: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.maintenanceSchedulerStarted {
+		if err := g.maintenanceScheduler.Stop(); err != nil {
+			log.Errorf("Error stopping maintenance scheduler: %v",
+				err)
+			returnErr = err
+		}
+	}
+
+	if g.reportsSchedulerStarted {
+		if err := g.reportsScheduler.Stop(); err != nil {
+			log.Errorf("Error stopping reports scheduler: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.backupSchedulerStarted {
+		if err := g.backupScheduler.Stop(); err != nil {
+			log.Errorf("Error stopping backup scheduler: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.chanBackupMgrStarted {
+		if err := g.chanBackupMgr.Stop(); err != nil {
+			log.Errorf("Error stopping channel backup manager: %v",
+				err)
+			returnErr = err
+		}
+	}
+
+	if g.feeSchedulerStarted {
+		if err := g.feeScheduler.Stop(); err != nil {
+			log.Errorf("Error stopping fee scheduler: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.nodeMgmtServiceStarted {
+		if err := g.nodeMgmtService.Stop(); err != nil {
+			log.Errorf("Error stopping node management service: %v",
+				err)
+			returnErr = err
+		}
+	}
+
+	if g.watchdogStarted {
+		if err := g.watchdog.Stop(); err != nil {
+			log.Errorf("Error stopping watchdog: %v", err)
+			returnErr = err
+		}
+	}
+
+	// The inbox is stopped after the watchdog, which adds its alerts to
+	// it.
+	if g.inboxStarted {
+		if err := g.inbox.Stop(); err != nil {
+			log.Errorf("Error stopping notification inbox: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.uiFlagMgrStarted {
+		if err := g.uiFlagMgr.Stop(); err != nil {
+			log.Errorf("Error stopping UI flag manager: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.apiKeyMgrStarted {
+		if err := g.apiKeyMgr.Stop(); err != nil {
+			log.Errorf("Error stopping API key manager: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.portalServiceStarted {
+		if err := g.portalService.Stop(); err != nil {
+			log.Errorf("Error stopping account portal: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.lnurlServiceStarted {
+		if err := g.lnurlService.Stop(); err != nil {
+			log.Errorf("Error stopping LNURL service: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.nwcServiceStarted {
+		if err := g.nwcService.Stop(); err != nil {
+			log.Errorf("Error stopping NWC service: %v", err)
+			returnErr = err
+		}
+	}
+
+	return returnErr
+}
+
+// stopFirewall stops the session server, the account service and the
+// firewall and closes their databases.
+func (g *LightningTerminal) stopFirewall() error {
+	var returnErr error
+
+	if g.sessionRpcServerStarted {
+		if err := g.sessionRpcServer.stop(); err != nil {
+			log.Errorf("Error closing session DB: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.accountServiceStarted {
+		if err := g.accountService.Stop(); err != nil {
+			log.Errorf("Error stopping account service: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.middlewareStarted {
+		g.middleware.Stop()
+	}
+
+	if g.firewallDB != nil {
+		if err := g.firewallDB.Close(); err != nil {
+			log.Errorf("Error closing rules DB: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.ruleMgrs != nil {
+		if err := g.ruleMgrs.Stop(); err != nil {
+			log.Errorf("Error stopping rule manager set: %v", err)
+			returnErr = err
+		}
+	}
+
+	return returnErr
+}
+
+// stopMacaroonService stops the macaroon service and closes its database.
+func (g *LightningTerminal) stopMacaroonService() error {
+	var returnErr error
+
+	if g.macaroonServiceStarted {
+		if err := g.macaroonService.Stop(); err != nil {
+			log.Errorf("Error stopping macaroon service: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.macaroonDB != nil {
+		g.macaroonDB.Close()
+	}
+
+	return returnErr
+}
+
+// stopStatusServices stops the status monitor, the error log and the config
+// reloader.
+func (g *LightningTerminal) stopStatusServices() error {
+	var returnErr error
+
+	if g.statusMonitorStarted {
+		if err := g.statusMonitor.Stop(); err != nil {
+			log.Errorf("Error stopping status monitor: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.errorLogStarted {
+		if err := g.errorLog.Stop(); err != nil {
+			log.Errorf("Error stopping error log: %v", err)
+			returnErr = err
+		}
+	}
+
+	if g.confReloaderStarted {
+		if err := g.confReloader.Stop(); err != nil {
+			log.Errorf("Error stopping config reloader: %v", err)
+			returnErr = err
+		}
+	}
+
+	return returnErr
+}
+
+// stopCluster resigns from the leadership of the cluster. This must only
+// happen once the shared stores are closed.
+func (g *LightningTerminal) stopCluster() error {
+	if g.clusterMgr == nil {
+		return nil
+	}
+
+	if err := g.clusterMgr.Stop(); err != nil {
+		log.Errorf("Error leaving cluster: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// closeLndConnection closes the connection to lnd and stops the REST proxy.
+func (g *LightningTerminal) closeLndConnection() error {
+	if g.lndClient != nil {
+		g.lndClient.Close()
+	}
+
+	if g.restCancel != nil {
+		g.restCancel()
+	}
+
+	return nil
+}
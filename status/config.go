@@ -0,0 +1,86 @@
+package status
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// defaultProbeInterval is the default interval in which the latency of
+	// the databases is probed.
+	defaultProbeInterval = 30 * time.Second
+
+	// defaultLatencySamples is the default number of probes per database
+	// the latency percentiles are calculated from.
+	defaultLatencySamples = 120
+
+	// defaultErrorsPerSubsystem is the default number of recent warnings
+	// and errors that are kept per subsystem.
+	defaultErrorsPerSubsystem = 20
+
+	// defaultMailboxLatencyThreshold is the default round trip latency of
+	// a mailbox server above which its connectivity is considered
+	// degraded.
+	defaultMailboxLatencyThreshold = 2 * time.Second
+
+	// defaultMailboxFailures is the default number of failed probes in a
+	// row after which the connectivity of a mailbox server is considered
+	// degraded.
+	defaultMailboxFailures = 2
+)
+
+// Config holds all config options for the status monitor.
+type Config struct {
+	ProbeInterval  time.Duration `long:"probeinterval" description:"The interval in which a read and a write transaction are run against each lit database to measure its latency and in which the remote signer of a watch-only lnd is checked. Set to 0 to disable the probes."`
+	LatencySamples uint32        `long:"latencysamples" description:"The number of recent probes per database the reported latency percentiles are calculated from."`
+
+	ErrorsPerSubsystem uint32 `long:"errorspersubsystem" description:"The number of recent warnings and errors of each subsystem that are kept in memory for the RecentErrors RPC. Set to 0 to disable."`
+
+	MailboxLatencyThreshold time.Duration `long:"mailboxlatencythreshold" description:"The round trip latency of a mailbox server used by LNC sessions above which its connectivity is considered degraded. Set to 0 to only consider failed probes."`
+	MailboxFailures         uint32        `long:"mailboxfailures" description:"The number of failed probes in a row after which the connectivity of a mailbox server used by LNC sessions is considered degraded."`
+
+	MetricsListen string `long:"metricslisten" description:"The host:port to serve the Prometheus metrics of litd on, for example the mailbox connectivity. If lnd runs in integrated mode and was built with monitoring support, the metrics are also exported by lnd's Prometheus exporter."`
+
+	PublicPage  bool   `long:"publicpage" description:"Serve a public status page at /public/status that shows whether lnd is alive and synced and the states of the subservers, for uptime monitors. The page doesn't require any authentication unless a token is set and never shows data that identifies the node."`
+	PublicToken string `long:"publictoken" description:"An optional token that must be passed to the public status page as bearer token or as token query parameter."`
+}
+
+// DefaultConfig constructs the default status Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		ProbeInterval:      defaultProbeInterval,
+		LatencySamples:     defaultLatencySamples,
+		ErrorsPerSubsystem: defaultErrorsPerSubsystem,
+
+		MailboxLatencyThreshold: defaultMailboxLatencyThreshold,
+		MailboxFailures:         defaultMailboxFailures,
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.PublicToken != "" && !c.PublicPage {
+		return fmt.Errorf("the public status token requires the " +
+			"public status page to be enabled")
+	}
+
+	if c.MailboxFailures == 0 {
+		return fmt.Errorf("the number of failed mailbox probes must " +
+			"be at least one")
+	}
+
+	if c.ProbeInterval == 0 {
+		return nil
+	}
+
+	if c.ProbeInterval < time.Second {
+		return fmt.Errorf("the status probe interval must be at least " +
+			"one second")
+	}
+
+	if c.LatencySamples == 0 {
+		return fmt.Errorf("at least one latency sample must be kept")
+	}
+
+	return nil
+}
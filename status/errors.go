@@ -0,0 +1,221 @@
+package status
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxErrorMessageLength is the length in bytes after which no further lines of
+// a log message are kept, for example of a long stack trace.
+const maxErrorMessageLength = 4096
+
+// errorLevels are the log levels of the messages the error log keeps.
+var errorLevels = map[string]struct{}{
+	"WRN": {},
+	"ERR": {},
+	"CRT": {},
+}
+
+// ErrorEntry is a warning or error that was logged.
+type ErrorEntry struct {
+	// Timestamp is the time the message was logged.
+	Timestamp time.Time
+
+	// Level is the level of the message, for example ERR.
+	Level string
+
+	// Message is the logged message without the log header. If the
+	// message spans multiple lines, they are separated by newlines.
+	Message string
+}
+
+// SubsystemErrors are the recent warnings and errors of a subsystem.
+type SubsystemErrors struct {
+	// Subsystem is the name of the subsystem, for example LOOP.
+	Subsystem string
+
+	// Total is the number of warnings and errors the subsystem logged
+	// since the error log was started.
+	Total uint64
+
+	// Entries are the most recent warnings and errors of the subsystem,
+	// newest first.
+	Entries []ErrorEntry
+}
+
+// subsystemErrors holds the recent warnings and errors of a subsystem.
+type subsystemErrors struct {
+	total   uint64
+	entries []*ErrorEntry
+}
+
+// ErrorLog follows the log file of litd and keeps the most recent warnings
+// and errors of each subsystem in memory, so they can be shown without access
+// to the log file.
+type ErrorLog struct {
+	cfg     *Config
+	logFile string
+
+	// mu guards the recorded errors.
+	mu         sync.Mutex
+	subsystems map[string]*subsystemErrors
+
+	// last is the entry of the most recent log message if it was recorded,
+	// so the lines that continue the message can be added to it.
+	last *ErrorEntry
+
+	started atomic.Bool
+	cancel  func()
+	wg      sync.WaitGroup
+}
+
+// NewErrorLog creates a new error log that follows the given log file.
+func NewErrorLog(cfg *Config, logFile string) *ErrorLog {
+	return &ErrorLog{
+		cfg:        cfg,
+		logFile:    logFile,
+		subsystems: make(map[string]*subsystemErrors),
+	}
+}
+
+// Start starts following the log file. Only the messages that are logged
+// after the error log was started are recorded.
+func (e *ErrorLog) Start() error {
+	e.started.Store(true)
+
+	// Without a log file, there is nothing to follow.
+	if e.cfg.ErrorsPerSubsystem == 0 || e.logFile == "" {
+		return nil
+	}
+
+	// We open the log file right away, so no message that is logged after
+	// the start is missed.
+	file, err := os.Open(e.logFile)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		err := followLog(
+			ctx, file, e.logFile, 0, true, func(*LogLine) bool {
+				return true
+			}, e.record,
+		)
+		if err != nil {
+			log.Errorf("Unable to follow log file %s: %v",
+				e.logFile, err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops following the log file.
+func (e *ErrorLog) Stop() error {
+	if !e.started.Load() {
+		return nil
+	}
+	e.started.Store(false)
+
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+
+	return nil
+}
+
+// record records the given log line if it belongs to a warning or an error.
+func (e *ErrorLog) record(line *LogLine) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if line.Continued {
+		if e.last != nil && len(e.last.Message) < maxErrorMessageLength {
+			e.last.Message += "\n" + line.Message
+		}
+
+		return nil
+	}
+
+	e.last = nil
+	if _, ok := errorLevels[line.Level]; !ok {
+		return nil
+	}
+
+	errs, ok := e.subsystems[line.Subsystem]
+	if !ok {
+		errs = &subsystemErrors{}
+		e.subsystems[line.Subsystem] = errs
+	}
+
+	entry := &ErrorEntry{
+		Timestamp: line.Timestamp,
+		Level:     line.Level,
+		Message:   line.Message,
+	}
+	errs.total++
+	errs.entries = append(errs.entries, entry)
+	if len(errs.entries) > int(e.cfg.ErrorsPerSubsystem) {
+		errs.entries = errs.entries[1:]
+	}
+	e.last = entry
+
+	return nil
+}
+
+// RecentErrors returns the recent warnings and errors of the given
+// subsystems, sorted by subsystem name. The names are matched
+// case-insensitively. If no subsystems are given, the errors of all
+// subsystems are returned.
+func (e *ErrorLog) RecentErrors(subsystems []string) ([]SubsystemErrors,
+	error) {
+
+	if !e.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	filter := SubsystemFilter(subsystems)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make([]SubsystemErrors, 0, len(e.subsystems))
+	for subsystem, errs := range e.subsystems {
+		if !filter(&LogLine{Subsystem: subsystem}) {
+			continue
+		}
+
+		entries := make([]ErrorEntry, len(errs.entries))
+		for i, entry := range errs.entries {
+			entries[len(entries)-1-i] = *entry
+		}
+
+		result = append(result, SubsystemErrors{
+			Subsystem: subsystem,
+			Total:     errs.total,
+			Entries:   entries,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Subsystem < result[j].Subsystem
+	})
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package status
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencyStats summarizes the recent latency samples of a database
+// operation.
+type LatencyStats struct {
+	// Samples is the number of samples the statistics are based on.
+	Samples int
+
+	// P50 is the median latency.
+	P50 time.Duration
+
+	// P90 is the 90th percentile latency.
+	P90 time.Duration
+
+	// P99 is the 99th percentile latency.
+	P99 time.Duration
+
+	// Max is the highest latency.
+	Max time.Duration
+}
+
+// latencyWindow holds the most recent latency samples in a ring buffer.
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+// newLatencyWindow creates a window that holds up to size samples.
+func newLatencyWindow(size uint32) *latencyWindow {
+	return &latencyWindow{
+		samples: make([]time.Duration, size),
+	}
+}
+
+// add records a sample, replacing the oldest one if the window is full.
+func (w *latencyWindow) add(d time.Duration) {
+	w.samples[w.next] = d
+	w.next++
+	if w.next == len(w.samples) {
+		w.next = 0
+		w.full = true
+	}
+}
+
+// stats calculates the percentiles of the samples in the window.
+func (w *latencyWindow) stats() LatencyStats {
+	n := w.next
+	if w.full {
+		n = len(w.samples)
+	}
+	if n == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := make([]time.Duration, n)
+	copy(sorted, w.samples[:n])
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return LatencyStats{
+		Samples: n,
+		P50:     percentile(sorted, 50),
+		P90:     percentile(sorted, 90),
+		P99:     percentile(sorted, 99),
+		Max:     sorted[n-1],
+	}
+}
+
+// percentile returns the p-th percentile of the given sorted samples using
+// the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,287 @@
+package status
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// logTimeLayout is the layout of the timestamp btclog writes at the
+	// start of every log message.
+	logTimeLayout = "2006-01-02 15:04:05.000"
+
+	// MaxBacklogLines is the maximum number of recent lines that can be
+	// requested before a log file is followed.
+	MaxBacklogLines = 10000
+)
+
+var (
+	// ErrNoLogFile is returned if the logs are requested while the log
+	// messages are sent to another sink instead of a log file.
+	ErrNoLogFile = errors.New("no log file is written, the log messages " +
+		"are sent to another sink")
+
+	// logHeaderRegex matches the header btclog writes in front of every log
+	// message, for example "2023-04-01 10:00:00.000 [INF] LITD: ".
+	logHeaderRegex = regexp.MustCompile(
+		`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}) \[([A-Z]{3})\] ` +
+			`([^ :]+): `,
+	)
+
+	// logPollInterval is the interval in which a followed log file is
+	// checked for new lines.
+	logPollInterval = 250 * time.Millisecond
+)
+
+// LogLine is a single line of a log file.
+type LogLine struct {
+	// Text is the complete line without the trailing newline.
+	Text string
+
+	// Timestamp is the time the log message the line belongs to was
+	// written.
+	Timestamp time.Time
+
+	// Level is the level of the log message the line belongs to, for
+	// example INF or ERR.
+	Level string
+
+	// Subsystem is the subsystem that wrote the log message the line
+	// belongs to, for example LITD or LOOP.
+	Subsystem string
+
+	// Message is the text of the line without the log header.
+	Message string
+
+	// Continued is true if the line doesn't have a log header of its own
+	// but continues the log message of the line before it.
+	Continued bool
+}
+
+// LogParser parses the lines of a log file. Messages that span multiple lines,
+// for example stack traces, only have a header in their first line, so the
+// following lines are attributed to the message of the last header.
+type LogParser struct {
+	timestamp time.Time
+	level     string
+	subsystem string
+}
+
+// Parse parses a single line of a log file.
+func (p *LogParser) Parse(text string) *LogLine {
+	line := &LogLine{
+		Text:      text,
+		Message:   text,
+		Continued: true,
+	}
+
+	matches := logHeaderRegex.FindStringSubmatch(text)
+	if matches != nil {
+		// btclog writes the timestamps in local time.
+		ts, err := time.ParseInLocation(
+			logTimeLayout, matches[1], time.Local,
+		)
+		if err == nil {
+			p.timestamp = ts
+			p.level = matches[2]
+			p.subsystem = matches[3]
+
+			line.Message = text[len(matches[0]):]
+			line.Continued = false
+		}
+	}
+
+	line.Timestamp = p.timestamp
+	line.Level = p.level
+	line.Subsystem = p.subsystem
+
+	return line
+}
+
+// SubsystemFilter returns a filter that matches the lines of the given
+// subsystems. The names are matched case-insensitively. If no subsystems are
+// given, all lines are matched.
+func SubsystemFilter(subsystems []string) func(*LogLine) bool {
+	if len(subsystems) == 0 {
+		return func(*LogLine) bool {
+			return true
+		}
+	}
+
+	wanted := make(map[string]struct{}, len(subsystems))
+	for _, subsystem := range subsystems {
+		wanted[strings.ToUpper(subsystem)] = struct{}{}
+	}
+
+	return func(line *LogLine) bool {
+		_, ok := wanted[strings.ToUpper(line.Subsystem)]
+		return ok
+	}
+}
+
+// FollowLog calls fn with the last backlog lines of the log file at the given
+// path that match the filter. If follow is true, it then waits for new lines
+// and calls fn for each matching one until the context is canceled or fn
+// returns an error. A log file that is rotated while it is followed is
+// reopened.
+func FollowLog(ctx context.Context, path string, backlog int, follow bool,
+	filter func(*LogLine) bool, fn func(*LogLine) error) error {
+
+	if path == "" {
+		return ErrNoLogFile
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	// Without a backlog, we start at the end of the file.
+	if backlog <= 0 {
+		if _, err := file.Seek(0, io.SeekEnd); err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+
+	return followLog(ctx, file, path, backlog, follow, filter, fn)
+}
+
+// followLog follows the given open log file from its current position like
+// FollowLog and closes it when it returns.
+func followLog(ctx context.Context, file *os.File, path string, backlog int,
+	follow bool, filter func(*LogLine) bool, fn func(*LogLine) error) error {
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	var (
+		parser  LogParser
+		reader  = bufio.NewReader(file)
+		partial string
+	)
+
+	// readLines calls handle for every complete line that can currently be
+	// read from the file. A line that isn't terminated yet is kept until
+	// the rest of it was written.
+	readLines := func(handle func(*LogLine) error) error {
+		for {
+			text, err := reader.ReadString('\n')
+			offset += int64(len(text))
+
+			if err == io.EOF {
+				partial += text
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			text = strings.TrimSuffix(partial+text, "\n")
+			partial = ""
+
+			line := parser.Parse(text)
+			if !filter(line) {
+				continue
+			}
+			if err := handle(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The rest of the file is read to find the most recent matching lines,
+	// as the lines of a subsystem can be spread over the entire file.
+	if backlog > 0 {
+		var (
+			recent = make([]*LogLine, backlog)
+			total  int
+		)
+		err := readLines(func(line *LogLine) error {
+			recent[total%backlog] = line
+			total++
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		start := 0
+		if total > backlog {
+			start = total - backlog
+		}
+		for i := start; i < total; i++ {
+			if err := fn(recent[i%backlog]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+
+	for {
+		select {
+		case <-time.After(logPollInterval):
+		case <-ctx.Done():
+			return nil
+		}
+
+		if err := readLines(fn); err != nil {
+			return err
+		}
+
+		rotated, err := logRotated(file, path, offset)
+		if err != nil || !rotated {
+			continue
+		}
+
+		// The file was rotated, so we read what was written to the old
+		// file after our last read before we switch to the new one.
+		if err := readLines(fn); err != nil {
+			return err
+		}
+
+		newFile, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		_ = file.Close()
+
+		file = newFile
+		reader.Reset(file)
+		offset = 0
+		partial = ""
+	}
+}
+
+// logRotated returns true if the file at the given path is no longer the open
+// file or if the open file was truncated below the given read offset.
+func logRotated(file *os.File, path string, offset int64) (bool, error) {
+	pathInfo, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	return !os.SameFile(pathInfo, fileInfo) || fileInfo.Size() < offset,
+		nil
+}
@@ -0,0 +1,179 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testLog = `2023-04-01 10:00:00.000 [INF] LITD: Starting LiT
+2023-04-01 10:00:01.000 [INF] LOOP: Starting loop
+2023-04-01 10:00:02.000 [ERR] LOOP: Swap failed
+goroutine 1 [running]:
+2023-04-01 10:00:03.000 [WRN] LNDC: Slow response
+2023-04-01 10:00:04.000 [INF] LOOPD: Loop started
+`
+
+// TestLogParser tests that lines without a header are attributed to the last
+// log message.
+func TestLogParser(t *testing.T) {
+	var parser LogParser
+
+	line := parser.Parse("2023-04-01 10:00:02.123 [ERR] LOOP: Swap failed")
+	require.Equal(t, "ERR", line.Level)
+	require.Equal(t, "LOOP", line.Subsystem)
+	require.Equal(t, time.Date(
+		2023, 4, 1, 10, 0, 2, 123*int(time.Millisecond), time.Local,
+	), line.Timestamp)
+
+	line = parser.Parse("goroutine 1 [running]:")
+	require.Equal(t, "ERR", line.Level)
+	require.Equal(t, "LOOP", line.Subsystem)
+	require.Equal(t, "goroutine 1 [running]:", line.Text)
+}
+
+// TestFollowLog tests that the most recent matching lines are sent first and
+// that new lines are followed, also after the log file was rotated.
+func TestFollowLog(t *testing.T) {
+	logPollInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "litd.log")
+	require.NoError(t, os.WriteFile(path, []byte(testLog), 0600))
+
+	collect := func(ctx context.Context, backlog int, follow bool,
+		subsystems ...string) chan string {
+
+		lines := make(chan string, 100)
+		go func() {
+			defer close(lines)
+
+			err := FollowLog(
+				ctx, path, backlog, follow,
+				SubsystemFilter(subsystems),
+				func(line *LogLine) error {
+					lines <- line.Text
+					return nil
+				},
+			)
+			require.NoError(t, err)
+		}()
+
+		return lines
+	}
+	receive := func(lines chan string) string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(time.Second):
+			t.Fatalf("no line received")
+			return ""
+		}
+	}
+
+	// Without following, only the most recent matching lines are sent.
+	// Subsystems are matched exactly, but case-insensitively.
+	lines := collect(context.Background(), 2, false, "loop")
+	require.Equal(t, "2023-04-01 10:00:02.000 [ERR] LOOP: Swap failed",
+		receive(lines))
+	require.Equal(t, "goroutine 1 [running]:", receive(lines))
+	_, ok := <-lines
+	require.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lines = collect(ctx, 1, true, "LITD")
+	require.Equal(t, "2023-04-01 10:00:00.000 [INF] LITD: Starting LiT",
+		receive(lines))
+
+	// A line is only sent once it is complete.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString("2023-04-01 10:00:05.000 [INF] LITD: Half")
+	require.NoError(t, err)
+	time.Sleep(5 * logPollInterval)
+	require.Empty(t, lines)
+
+	_, err = file.WriteString(" done\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, "2023-04-01 10:00:05.000 [INF] LITD: Half done",
+		receive(lines))
+
+	// After a rotation, the new file is followed.
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, os.WriteFile(
+		path, []byte("2023-04-01 10:00:06.000 [INF] LITD: Rotated\n"),
+		0600,
+	))
+	require.Equal(t, "2023-04-01 10:00:06.000 [INF] LITD: Rotated",
+		receive(lines))
+
+	cancel()
+	_, ok = <-lines
+	require.False(t, ok)
+}
+
+// TestErrorLog tests that the most recent warnings and errors of each
+// subsystem are kept, including the lines that continue them.
+func TestErrorLog(t *testing.T) {
+	logPollInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "litd.log")
+	require.NoError(t, os.WriteFile(path, []byte(testLog), 0600))
+
+	errorLog := NewErrorLog(&Config{ErrorsPerSubsystem: 2}, path)
+	_, err := errorLog.RecentErrors(nil)
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, errorLog.Start())
+	t.Cleanup(func() {
+		require.NoError(t, errorLog.Stop())
+	})
+
+	// The errors that were logged before the start aren't recorded.
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`2023-04-01 11:00:00.000 [ERR] LOOP: First
+2023-04-01 11:00:01.000 [INF] LOOP: Info
+2023-04-01 11:00:02.000 [WRN] LOOP: Second
+2023-04-01 11:00:03.000 [CRT] LOOP: Third
+goroutine 1 [running]:
+2023-04-01 11:00:04.000 [ERR] LITD: Other
+`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	var errs []SubsystemErrors
+	require.Eventually(t, func() bool {
+		errs, err = errorLog.RecentErrors(nil)
+		require.NoError(t, err)
+
+		return len(errs) == 2
+	}, time.Second, logPollInterval)
+
+	require.Equal(t, "LITD", errs[0].Subsystem)
+	require.EqualValues(t, 1, errs[0].Total)
+	require.Equal(t, "Other", errs[0].Entries[0].Message)
+
+	require.Equal(t, "LOOP", errs[1].Subsystem)
+	require.EqualValues(t, 3, errs[1].Total)
+	require.Equal(t, []ErrorEntry{{
+		Timestamp: time.Date(2023, 4, 1, 11, 0, 3, 0, time.Local),
+		Level:     "CRT",
+		Message:   "Third\ngoroutine 1 [running]:",
+	}, {
+		Timestamp: time.Date(2023, 4, 1, 11, 0, 2, 0, time.Local),
+		Level:     "WRN",
+		Message:   "Second",
+	}}, errs[1].Entries)
+
+	// The subsystems can be filtered.
+	errs, err = errorLog.RecentErrors([]string{"litd"})
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	require.Equal(t, "LITD", errs[0].Subsystem)
+}
@@ -0,0 +1,328 @@
+package status
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lightninglabs/lightning-node-connect/hashmailrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// mailboxProbeTimeout is the maximum time a single probe of a mailbox
+	// server may take.
+	mailboxProbeTimeout = 20 * time.Second
+
+	// mailboxStreamIDSize is the size of the random stream ID of the
+	// cipher box that is used to probe a mailbox server.
+	mailboxStreamIDSize = 64
+)
+
+// mailboxProbeMsg is the message that is sent through the mailbox server to
+// measure its round trip latency.
+var mailboxProbeMsg = []byte("litd mailbox probe")
+
+// MailboxProbe sends a message through the mailbox server at the given
+// address and returns the time it took until the message could be read back.
+// If devServer is set, the TLS certificate of the server isn't verified.
+type MailboxProbe func(ctx context.Context, addr string,
+	devServer bool) (time.Duration, error)
+
+// MailboxStatus describes the connectivity of a mailbox server that LNC
+// sessions connect through.
+type MailboxStatus struct {
+	// ServerAddr is the host:port of the mailbox server.
+	ServerAddr string
+
+	// Reachable is true if the last probe of the server succeeded.
+	Reachable bool
+
+	// Degraded is true if the connectivity of the server is degraded,
+	// either because several probes in a row failed or because the round
+	// trip latency is above the configured threshold.
+	Degraded bool
+
+	// RoundTrip summarizes the round trip latency of the recent
+	// successful probes.
+	RoundTrip LatencyStats
+
+	// LastRoundTrip is the round trip latency of the last successful
+	// probe.
+	LastRoundTrip time.Duration
+
+	// ConsecutiveFailures is the number of probes in a row that failed.
+	ConsecutiveFailures uint32
+
+	// LastChecked is the time of the last probe. It is the zero time if
+	// the server wasn't probed yet.
+	LastChecked time.Time
+
+	// LastError is the error of the last probe, if any.
+	LastError error
+}
+
+// mailboxState holds the probe results of a mailbox server.
+type mailboxState struct {
+	status     MailboxStatus
+	roundTrips *latencyWindow
+}
+
+// SetMailboxes sets the function that returns the mailbox servers the active
+// LNC sessions connect through, mapped to whether they are development
+// servers. The connectivity of each of them is probed together with the
+// databases.
+func (m *Monitor) SetMailboxes(servers func() map[string]bool,
+	probe MailboxProbe) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mailboxServers = servers
+	m.mailboxProbe = probe
+}
+
+// probeMailboxes measures the round trip latency of all mailbox servers that
+// are currently in use and records the result. Servers that are no longer
+// used are forgotten.
+func (m *Monitor) probeMailboxes() {
+	m.mu.Lock()
+	serversFn, probe := m.mailboxServers, m.mailboxProbe
+	m.mu.Unlock()
+
+	if serversFn == nil || probe == nil {
+		return
+	}
+
+	servers := serversFn()
+
+	m.mu.Lock()
+	for addr := range m.mailboxes {
+		if _, ok := servers[addr]; !ok {
+			delete(m.mailboxes, addr)
+			forgetMailboxMetrics(addr)
+		}
+	}
+	m.mu.Unlock()
+
+	for addr, devServer := range servers {
+		ctx, cancel := context.WithTimeout(
+			context.Background(), mailboxProbeTimeout,
+		)
+		roundTrip, err := probe(ctx, addr, devServer)
+		cancel()
+
+		if err != nil {
+			log.Warnf("Unable to probe mailbox server %v: %v", addr,
+				err)
+		}
+
+		m.recordMailboxProbe(addr, roundTrip, err)
+	}
+}
+
+// recordMailboxProbe records the result of a probe of the given mailbox
+// server.
+func (m *Monitor) recordMailboxProbe(addr string, roundTrip time.Duration,
+	err error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.mailboxes[addr]
+	if !ok {
+		state = &mailboxState{
+			status: MailboxStatus{
+				ServerAddr: addr,
+			},
+			roundTrips: newLatencyWindow(m.samples),
+		}
+		m.mailboxes[addr] = state
+	}
+
+	status := &state.status
+	status.Reachable = err == nil
+	status.LastChecked = time.Now()
+	status.LastError = err
+	if err != nil {
+		status.ConsecutiveFailures++
+	} else {
+		status.ConsecutiveFailures = 0
+		status.LastRoundTrip = roundTrip
+		state.roundTrips.add(roundTrip)
+	}
+	status.RoundTrip = state.roundTrips.stats()
+
+	wasDegraded := status.Degraded
+	status.Degraded = m.mailboxDegraded(status)
+	switch {
+	case status.Degraded && !wasDegraded:
+		log.Warnf("Connectivity to mailbox server %v is degraded: %v",
+			addr, m.mailboxReason(status))
+
+	case !status.Degraded && wasDegraded:
+		log.Infof("Connectivity to mailbox server %v recovered", addr)
+	}
+
+	recordMailboxMetrics(status, roundTrip)
+}
+
+// mailboxDegraded returns true if the connectivity described by the status is
+// degraded.
+func (m *Monitor) mailboxDegraded(status *MailboxStatus) bool {
+	failures := m.cfg.MailboxFailures
+	if failures == 0 {
+		failures = 1
+	}
+	if status.ConsecutiveFailures >= failures {
+		return true
+	}
+
+	threshold := m.cfg.MailboxLatencyThreshold
+	return status.Reachable && threshold > 0 &&
+		status.LastRoundTrip > threshold
+}
+
+// mailboxReason describes why the connectivity described by the status is
+// degraded.
+func (m *Monitor) mailboxReason(status *MailboxStatus) string {
+	if !status.Reachable {
+		return fmt.Sprintf("%d probes in a row failed, last error: %v",
+			status.ConsecutiveFailures, status.LastError)
+	}
+
+	return fmt.Sprintf("round trip latency of %v is above the threshold "+
+		"of %v", status.LastRoundTrip, m.cfg.MailboxLatencyThreshold)
+}
+
+// MailboxStatus returns the connectivity of the mailbox servers that are in
+// use, sorted by their address. Servers that weren't probed yet are not
+// included.
+func (m *Monitor) MailboxStatus() []MailboxStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]MailboxStatus, 0, len(m.mailboxes))
+	for _, state := range m.mailboxes {
+		result = append(result, state.status)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ServerAddr < result[j].ServerAddr
+	})
+
+	return result
+}
+
+// DegradedMailboxes returns the mailbox servers whose connectivity is
+// degraded, mapped to a description of the problem.
+func (m *Monitor) DegradedMailboxes() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	degraded := make(map[string]string)
+	for addr, state := range m.mailboxes {
+		if state.status.Degraded {
+			degraded[addr] = m.mailboxReason(&state.status)
+		}
+	}
+
+	return degraded
+}
+
+// NewHashMailProbe returns a probe that measures the round trip latency of a
+// mailbox server with its hash-mail API. It creates a cipher box with a random
+// stream ID, sends a single message through it and waits until the message
+// can be read back. The cipher box is removed again afterwards.
+func NewHashMailProbe() MailboxProbe {
+	return func(ctx context.Context, addr string,
+		devServer bool) (time.Duration, error) {
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		tlsConfig := &tls.Config{}
+		if devServer {
+			tlsConfig.InsecureSkipVerify = true
+		}
+
+		conn, err := grpc.DialContext(
+			ctx, addr, grpc.WithTransportCredentials(
+				credentials.NewTLS(tlsConfig),
+			),
+		)
+		if err != nil {
+			return 0, err
+		}
+		defer conn.Close()
+
+		streamID := make([]byte, mailboxStreamIDSize)
+		if _, err := rand.Read(streamID); err != nil {
+			return 0, err
+		}
+
+		client := hashmailrpc.NewHashMailClient(conn)
+		desc := &hashmailrpc.CipherBoxDesc{
+			StreamId: streamID,
+		}
+		auth := &hashmailrpc.CipherBoxAuth{
+			Desc: desc,
+			Auth: &hashmailrpc.CipherBoxAuth_LndAuth{
+				LndAuth: &hashmailrpc.LndAuth{},
+			},
+		}
+
+		_, err = client.NewCipherBox(ctx, auth)
+		if err != nil {
+			return 0, fmt.Errorf("unable to create cipher box: %v",
+				err)
+		}
+		defer func() {
+			delCtx, delCancel := context.WithTimeout(
+				context.Background(), mailboxProbeTimeout,
+			)
+			defer delCancel()
+
+			_, err := client.DelCipherBox(delCtx, auth)
+			if err != nil {
+				log.Debugf("Unable to remove probe cipher "+
+					"box: %v", err)
+			}
+		}()
+
+		recvStream, err := client.RecvStream(ctx, desc)
+		if err != nil {
+			return 0, fmt.Errorf("unable to open receive stream: "+
+				"%v", err)
+		}
+
+		sendStream, err := client.SendStream(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("unable to open send stream: %v",
+				err)
+		}
+
+		start := time.Now()
+		err = sendStream.Send(&hashmailrpc.CipherBox{
+			Desc: desc,
+			Msg:  mailboxProbeMsg,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to send probe: %v", err)
+		}
+
+		if _, err := recvStream.Recv(); err != nil {
+			return 0, fmt.Errorf("unable to receive probe: %v",
+				err)
+		}
+		roundTrip := time.Since(start)
+
+		_ = sendStream.CloseSend()
+
+		return roundTrip, nil
+	}
+}
@@ -0,0 +1,139 @@
+package status
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// metricsNamespace is the namespace of all Prometheus metrics of the
+	// status monitor.
+	metricsNamespace = "lit"
+
+	// mailboxSubsystem is the subsystem of the mailbox metrics.
+	mailboxSubsystem = "mailbox"
+
+	// serverLabel is the label that holds the address of a mailbox server.
+	serverLabel = "server"
+
+	// metricsReadHeaderTimeout is the maximum time the metrics server waits
+	// for the headers of a request.
+	metricsReadHeaderTimeout = 10 * time.Second
+)
+
+var (
+	// mailboxReachable reports whether the last probe of a mailbox server
+	// succeeded.
+	mailboxReachable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: mailboxSubsystem,
+		Name:      "reachable",
+		Help: "Whether the last probe of the mailbox server " +
+			"succeeded.",
+	}, []string{serverLabel})
+
+	// mailboxDegraded reports whether the connectivity of a mailbox server
+	// is degraded.
+	mailboxDegraded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: mailboxSubsystem,
+		Name:      "degraded",
+		Help: "Whether the connectivity of the mailbox server is " +
+			"degraded.",
+	}, []string{serverLabel})
+
+	// mailboxRoundTrip records the round trip latency of the successful
+	// probes of a mailbox server.
+	mailboxRoundTrip = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: mailboxSubsystem,
+		Name:      "round_trip_seconds",
+		Help: "The time it takes to send a message through the " +
+			"mailbox server.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{serverLabel})
+
+	// mailboxProbeFailures counts the failed probes of a mailbox server.
+	mailboxProbeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: mailboxSubsystem,
+		Name:      "probe_failures_total",
+		Help:      "The number of failed probes of the mailbox server.",
+	}, []string{serverLabel})
+)
+
+func init() {
+	// The metrics are registered with the default registry, so they're
+	// also exported by lnd's Prometheus exporter if lnd runs in integrated
+	// mode and was built with monitoring support.
+	prometheus.MustRegister(
+		mailboxReachable, mailboxDegraded, mailboxRoundTrip,
+		mailboxProbeFailures,
+	)
+}
+
+// recordMailboxMetrics updates the metrics of a mailbox server after a probe.
+func recordMailboxMetrics(status *MailboxStatus, roundTrip time.Duration) {
+	addr := status.ServerAddr
+
+	mailboxReachable.WithLabelValues(addr).Set(boolToFloat(status.Reachable))
+	mailboxDegraded.WithLabelValues(addr).Set(boolToFloat(status.Degraded))
+
+	if status.Reachable {
+		mailboxRoundTrip.WithLabelValues(addr).Observe(
+			roundTrip.Seconds(),
+		)
+	} else {
+		mailboxProbeFailures.WithLabelValues(addr).Inc()
+	}
+}
+
+// forgetMailboxMetrics removes the metrics of a mailbox server that is no
+// longer in use.
+func forgetMailboxMetrics(addr string) {
+	mailboxReachable.DeleteLabelValues(addr)
+	mailboxDegraded.DeleteLabelValues(addr)
+	mailboxRoundTrip.DeleteLabelValues(addr)
+	mailboxProbeFailures.DeleteLabelValues(addr)
+}
+
+// boolToFloat returns 1 for true and 0 for false.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// startMetricsServer starts an HTTP server that serves the Prometheus
+// metrics of the default registry at /metrics on the given address.
+func startMetricsServer(addr string) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+	go func() {
+		err := server.Serve(lis)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Metrics server stopped: %v", err)
+		}
+	}()
+
+	log.Infof("Serving Prometheus metrics at %v/metrics", lis.Addr())
+
+	return server, nil
+}
@@ -0,0 +1,352 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/dbcompact"
+)
+
+// ErrNotStarted is returned if the status is requested before the monitor was
+// started.
+var ErrNotStarted = errors.New("status monitor not started")
+
+// Store is a database whose health is reported by the monitor.
+type Store struct {
+	// Path is the full path of the database file.
+	Path string
+
+	// Probe runs an empty read or, if write is set, an empty write
+	// transaction against the database. Its duration is recorded as the
+	// latency of the database.
+	Probe func(write bool) error
+}
+
+// DatabaseStatus describes the health of a database.
+type DatabaseStatus struct {
+	// Name is the file name of the database.
+	Name string
+
+	// Path is the full path of the database file.
+	Path string
+
+	// Size is the size of the database file in bytes.
+	Size int64
+
+	// LastCompacted is the time at which the database was last compacted.
+	// It is the zero time if the database was never compacted.
+	LastCompacted time.Time
+
+	// ReadLatency summarizes the latency of the recent read probes.
+	ReadLatency LatencyStats
+
+	// WriteLatency summarizes the latency of the recent write probes.
+	WriteLatency LatencyStats
+
+	// LastError is the error of the most recent probe, if any.
+	LastError error
+}
+
+// storeState holds the latency samples of a store.
+type storeState struct {
+	store   Store
+	reads   *latencyWindow
+	writes  *latencyWindow
+	lastErr error
+}
+
+// Monitor periodically probes the latency of the lit databases and reports
+// their size, latency and the time of their last compaction. If lnd uses a
+// remote signer, it also checks whether the signer can be reached and it
+// measures the round trip latency of the mailbox servers used by LNC sessions.
+// Besides that, it keeps track of the disabled features and degraded
+// subservers.
+type Monitor struct {
+	cfg *Config
+
+	// samples is the size of the latency windows.
+	samples uint32
+
+	// mu guards the latency samples of the stores and the fields below.
+	mu     sync.Mutex
+	stores []*storeState
+
+	remoteSigner *RemoteSigner
+	signerStatus SignerStatus
+
+	disabledFeatures []DisabledFeature
+
+	degraded map[string]*DegradedSubserver
+
+	mailboxServers func() map[string]bool
+	mailboxProbe   MailboxProbe
+	mailboxes      map[string]*mailboxState
+
+	metricsServer *http.Server
+
+	started atomic.Bool
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewMonitor creates a new status monitor for the given stores.
+func NewMonitor(cfg *Config, stores []Store) *Monitor {
+	m := &Monitor{
+		cfg:       cfg,
+		stores:    make([]*storeState, len(stores)),
+		degraded:  make(map[string]*DegradedSubserver),
+		mailboxes: make(map[string]*mailboxState),
+		quit:      make(chan struct{}),
+	}
+
+	size := cfg.LatencySamples
+	if size == 0 {
+		size = 1
+	}
+	m.samples = size
+	for i, store := range stores {
+		m.stores[i] = &storeState{
+			store:  store,
+			reads:  newLatencyWindow(size),
+			writes: newLatencyWindow(size),
+		}
+	}
+
+	return m
+}
+
+// Start starts probing the databases in the configured interval.
+func (m *Monitor) Start() error {
+	if m.cfg.MetricsListen != "" {
+		server, err := startMetricsServer(m.cfg.MetricsListen)
+		if err != nil {
+			return fmt.Errorf("unable to start metrics server: %v",
+				err)
+		}
+		m.metricsServer = server
+	}
+
+	m.started.Store(true)
+
+	if m.cfg.ProbeInterval == 0 {
+		return nil
+	}
+
+	m.wg.Add(1)
+	go m.run()
+
+	return nil
+}
+
+// Stop stops the monitor.
+func (m *Monitor) Stop() error {
+	if !m.started.Load() {
+		return nil
+	}
+	m.started.Store(false)
+
+	close(m.quit)
+	m.wg.Wait()
+
+	if m.metricsServer != nil {
+		return m.metricsServer.Close()
+	}
+
+	return nil
+}
+
+// run probes the databases in the configured interval until the monitor is
+// stopped.
+func (m *Monitor) run() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.cfg.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		m.probe()
+
+		select {
+		case <-ticker.C:
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// probe runs a read and a write transaction against each database and records
+// their latency. It also checks the remote signer, if any, and the mailbox
+// servers.
+func (m *Monitor) probe() {
+	m.probeSigner()
+	m.probeMailboxes()
+
+	for _, state := range m.stores {
+		readLatency, readErr := timeProbe(state.store, false)
+		writeLatency, writeErr := timeProbe(state.store, true)
+
+		err := readErr
+		if err == nil {
+			err = writeErr
+		}
+		if err != nil {
+			log.Errorf("Unable to probe %v: %v", state.store.Path,
+				err)
+		}
+
+		m.mu.Lock()
+		if readErr == nil {
+			state.reads.add(readLatency)
+		}
+		if writeErr == nil {
+			state.writes.add(writeLatency)
+		}
+		state.lastErr = err
+		m.mu.Unlock()
+	}
+}
+
+// timeProbe runs a single probe against the store and returns its duration.
+func timeProbe(store Store, write bool) (time.Duration, error) {
+	start := time.Now()
+	if err := store.Probe(write); err != nil {
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
+
+// DatabaseStatus returns the current status of all databases.
+func (m *Monitor) DatabaseStatus() ([]DatabaseStatus, error) {
+	if !m.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	result := make([]DatabaseStatus, len(m.stores))
+	for i, state := range m.stores {
+		status := DatabaseStatus{
+			Name: filepath.Base(state.store.Path),
+			Path: state.store.Path,
+		}
+
+		info, err := os.Stat(state.store.Path)
+		switch {
+		case err == nil:
+			status.Size = info.Size()
+
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+
+		status.LastCompacted, err = dbcompact.LastCompacted(
+			state.store.Path,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		m.mu.Lock()
+		status.ReadLatency = state.reads.stats()
+		status.WriteLatency = state.writes.stats()
+		status.LastError = state.lastErr
+		m.mu.Unlock()
+
+		result[i] = status
+	}
+
+	return result, nil
+}
+
+// SetRemoteSigner sets the remote signer lnd delegates all signing to. Its
+// connectivity is checked together with the databases.
+func (m *Monitor) SetRemoteSigner(signer *RemoteSigner) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remoteSigner = signer
+	m.signerStatus = SignerStatus{
+		RPCHost: signer.RPCHost,
+	}
+}
+
+// probeSigner checks whether the remote signer can be reached and records the
+// result.
+func (m *Monitor) probeSigner() {
+	m.mu.Lock()
+	signer := m.remoteSigner
+	m.mu.Unlock()
+
+	if signer == nil || signer.Probe == nil {
+		return
+	}
+
+	err := signer.Probe()
+	if err != nil {
+		log.Errorf("Unable to reach remote signer %v: %v",
+			signer.RPCHost, err)
+	}
+
+	m.mu.Lock()
+	m.signerStatus.Reachable = err == nil
+	m.signerStatus.LastChecked = time.Now()
+	m.signerStatus.LastError = err
+	m.mu.Unlock()
+}
+
+// RemoteSignerStatus returns the connectivity of the remote signer. It returns
+// nil if lnd doesn't use a remote signer.
+func (m *Monitor) RemoteSignerStatus() *SignerStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.remoteSigner == nil {
+		return nil
+	}
+
+	status := m.signerStatus
+	return &status
+}
+
+// DisableFeature records that the feature with the given name is disabled for
+// the given reason.
+func (m *Monitor) DisableFeature(name, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.disabledFeatures = append(m.disabledFeatures, DisabledFeature{
+		Name:   name,
+		Reason: reason,
+	})
+}
+
+// FeatureDisabled returns the reason the feature with the given name is
+// disabled for and true if it is disabled.
+func (m *Monitor) FeatureDisabled(name string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, feature := range m.disabledFeatures {
+		if feature.Name == name {
+			return feature.Reason, true
+		}
+	}
+
+	return "", false
+}
+
+// DisabledFeatures returns all disabled features.
+func (m *Monitor) DisabledFeatures() []DisabledFeature {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	features := make([]DisabledFeature, len(m.disabledFeatures))
+	copy(features, m.disabledFeatures)
+
+	return features
+}
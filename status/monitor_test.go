@@ -0,0 +1,238 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLatencyWindow tests that the percentiles are calculated from the most
+// recent samples only.
+func TestLatencyWindow(t *testing.T) {
+	w := newLatencyWindow(100)
+	require.Equal(t, LatencyStats{}, w.stats())
+
+	for i := 1; i <= 100; i++ {
+		w.add(time.Duration(i) * time.Millisecond)
+	}
+
+	stats := w.stats()
+	require.Equal(t, 100, stats.Samples)
+	require.Equal(t, 50*time.Millisecond, stats.P50)
+	require.Equal(t, 90*time.Millisecond, stats.P90)
+	require.Equal(t, 99*time.Millisecond, stats.P99)
+	require.Equal(t, 100*time.Millisecond, stats.Max)
+
+	// Once the window is full, the oldest samples are replaced.
+	for i := 0; i < 50; i++ {
+		w.add(time.Second)
+	}
+
+	stats = w.stats()
+	require.Equal(t, 100, stats.Samples)
+	require.Equal(t, 100*time.Millisecond, stats.P50)
+	require.Equal(t, time.Second, stats.P90)
+	require.Equal(t, time.Second, stats.Max)
+}
+
+// TestDatabaseStatus tests that the size, the probe latency and errors of a
+// database are reported.
+func TestDatabaseStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	require.NoError(t, os.WriteFile(path, make([]byte, 4096), 0600))
+
+	var (
+		reads, writes int
+		probeErr      error
+	)
+	monitor := NewMonitor(&Config{LatencySamples: 10}, []Store{{
+		Path: path,
+		Probe: func(write bool) error {
+			if write {
+				writes++
+			} else {
+				reads++
+			}
+
+			return probeErr
+		},
+	}})
+
+	_, err := monitor.DatabaseStatus()
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	// With a zero interval the databases are only probed on demand.
+	require.NoError(t, monitor.Start())
+	t.Cleanup(func() {
+		require.NoError(t, monitor.Stop())
+	})
+
+	monitor.probe()
+	monitor.probe()
+	require.Equal(t, 2, reads)
+	require.Equal(t, 2, writes)
+
+	databases, err := monitor.DatabaseStatus()
+	require.NoError(t, err)
+	require.Len(t, databases, 1)
+	require.Equal(t, "test.db", databases[0].Name)
+	require.EqualValues(t, 4096, databases[0].Size)
+	require.True(t, databases[0].LastCompacted.IsZero())
+	require.Equal(t, 2, databases[0].ReadLatency.Samples)
+	require.Equal(t, 2, databases[0].WriteLatency.Samples)
+	require.NoError(t, databases[0].LastError)
+
+	// Failed probes are reported but not counted as samples.
+	probeErr = errors.New("disk on fire")
+	monitor.probe()
+
+	databases, err = monitor.DatabaseStatus()
+	require.NoError(t, err)
+	require.Equal(t, 2, databases[0].ReadLatency.Samples)
+	require.ErrorIs(t, databases[0].LastError, probeErr)
+}
+
+// TestRemoteSigner tests that the connectivity of the remote signer and the
+// disabled features are reported.
+func TestRemoteSigner(t *testing.T) {
+	monitor := NewMonitor(&Config{LatencySamples: 10}, nil)
+	require.Nil(t, monitor.RemoteSignerStatus())
+
+	probeErr := errors.New("connection refused")
+	monitor.SetRemoteSigner(&RemoteSigner{
+		RPCHost: "signer:10009",
+		Probe: func() error {
+			return probeErr
+		},
+	})
+
+	status := monitor.RemoteSignerStatus()
+	require.Equal(t, "signer:10009", status.RPCHost)
+	require.True(t, status.LastChecked.IsZero())
+
+	monitor.probe()
+	status = monitor.RemoteSignerStatus()
+	require.False(t, status.Reachable)
+	require.False(t, status.LastChecked.IsZero())
+	require.ErrorIs(t, status.LastError, probeErr)
+
+	probeErr = nil
+	monitor.probe()
+	status = monitor.RemoteSignerStatus()
+	require.True(t, status.Reachable)
+	require.NoError(t, status.LastError)
+
+	_, disabled := monitor.FeatureDisabled("loop")
+	require.False(t, disabled)
+
+	monitor.DisableFeature("loop", "needs private keys")
+	reason, disabled := monitor.FeatureDisabled("loop")
+	require.True(t, disabled)
+	require.Equal(t, "needs private keys", reason)
+	require.Equal(t, []DisabledFeature{{
+		Name:   "loop",
+		Reason: "needs private keys",
+	}}, monitor.DisabledFeatures())
+}
+
+// TestDegradedSubservers tests that failed subservers are reported until they
+// recover.
+func TestDegradedSubservers(t *testing.T) {
+	monitor := NewMonitor(&Config{LatencySamples: 10}, nil)
+	require.Empty(t, monitor.DegradedSubservers())
+
+	monitor.SubserverFailed("pool", errors.New("auctioneer unreachable"))
+	monitor.SubserverFailed("loop", errors.New("db locked"))
+	monitor.SubserverFailed("loop", errors.New("db still locked"))
+	require.True(t, monitor.SubserverDegraded("loop"))
+	require.False(t, monitor.SubserverDegraded("faraday"))
+
+	subservers := monitor.DegradedSubservers()
+	require.Len(t, subservers, 2)
+	require.Equal(t, "loop", subservers[0].Name)
+	require.Equal(t, uint32(2), subservers[0].Attempts)
+	require.EqualError(t, subservers[0].LastError, "db still locked")
+	require.False(t, subservers[0].LastAttempt.Before(subservers[0].Since))
+
+	monitor.SubserverRecovered("loop")
+	require.False(t, monitor.SubserverDegraded("loop"))
+	require.Len(t, monitor.DegradedSubservers(), 1)
+}
+
+// TestMailboxStatus tests that the round trip latency of the mailbox servers
+// in use is recorded and that their connectivity is reported as degraded once
+// too many probes failed or the latency is too high.
+func TestMailboxStatus(t *testing.T) {
+	monitor := NewMonitor(&Config{
+		LatencySamples:          10,
+		MailboxLatencyThreshold: time.Second,
+		MailboxFailures:         2,
+	}, nil)
+
+	servers := map[string]bool{
+		"mailbox.terminal.lightning.today:443": false,
+		"localhost:11110":                      true,
+	}
+	var (
+		roundTrip = 100 * time.Millisecond
+		probeErr  error
+	)
+	monitor.SetMailboxes(
+		func() map[string]bool {
+			return servers
+		},
+		func(_ context.Context, addr string,
+			devServer bool) (time.Duration, error) {
+
+			require.Equal(t, servers[addr], devServer)
+			if addr == "localhost:11110" {
+				return 0, probeErr
+			}
+
+			return roundTrip, nil
+		},
+	)
+	require.Empty(t, monitor.MailboxStatus())
+
+	// A single failed probe doesn't degrade the connectivity yet.
+	probeErr = errors.New("connection refused")
+	monitor.probe()
+	mailboxes := monitor.MailboxStatus()
+	require.Len(t, mailboxes, 2)
+	require.Equal(t, "localhost:11110", mailboxes[0].ServerAddr)
+	require.False(t, mailboxes[0].Reachable)
+	require.False(t, mailboxes[0].Degraded)
+	require.ErrorIs(t, mailboxes[0].LastError, probeErr)
+	require.True(t, mailboxes[1].Reachable)
+	require.Equal(t, roundTrip, mailboxes[1].LastRoundTrip)
+	require.Equal(t, 1, mailboxes[1].RoundTrip.Samples)
+	require.Empty(t, monitor.DegradedMailboxes())
+
+	// The second failure in a row and a round trip above the threshold
+	// both degrade the connectivity.
+	roundTrip = 2 * time.Second
+	monitor.probe()
+	mailboxes = monitor.MailboxStatus()
+	require.Equal(t, uint32(2), mailboxes[0].ConsecutiveFailures)
+	require.True(t, mailboxes[0].Degraded)
+	require.True(t, mailboxes[1].Degraded)
+	require.Len(t, monitor.DegradedMailboxes(), 2)
+
+	// Once the probes succeed again, the connectivity recovers.
+	probeErr = nil
+	roundTrip = 200 * time.Millisecond
+	monitor.probe()
+	require.Empty(t, monitor.DegradedMailboxes())
+
+	// Servers that are no longer used are forgotten.
+	delete(servers, "localhost:11110")
+	monitor.probe()
+	mailboxes = monitor.MailboxStatus()
+	require.Len(t, mailboxes, 1)
+	require.Equal(t, 4, mailboxes[0].RoundTrip.Samples)
+}
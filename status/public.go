@@ -0,0 +1,149 @@
+package status
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// PublicPath is the path the public status page is served at by the
+	// main web server of litd.
+	PublicPath = "/public/status"
+
+	// publicStatusTimeout is the maximum time the public status page
+	// waits for the status to be collected.
+	publicStatusTimeout = 5 * time.Second
+)
+
+// The states of a subserver that are shown on the public status page.
+const (
+	// SubserverRunning is the state of a subserver that is running
+	// normally.
+	SubserverRunning = "running"
+
+	// SubserverStarting is the state of a subserver that wasn't started
+	// yet.
+	SubserverStarting = "starting"
+
+	// SubserverDegraded is the state of an integrated subserver that
+	// failed and is retried in degraded-mode.
+	SubserverDegraded = "degraded"
+
+	// SubserverRemote is the state of a subserver that runs remotely and
+	// whose health isn't known to litd.
+	SubserverRemote = "remote"
+)
+
+// PublicStatus is the coarse health information that is shown on the public
+// status page. It must never contain any data that identifies the node, like
+// its public key, alias, addresses, channels or balances.
+type PublicStatus struct {
+	// Alive is true if lnd is connected and responds.
+	Alive bool `json:"alive"`
+
+	// SyncedToChain is true if lnd is synced to the chain.
+	SyncedToChain bool `json:"synced_to_chain"`
+
+	// SyncedToGraph is true if lnd is synced to the channel graph.
+	SyncedToGraph bool `json:"synced_to_graph"`
+
+	// Subservers maps the name of each subserver to its state.
+	Subservers map[string]string `json:"subservers"`
+}
+
+// Healthy returns true if lnd is alive and synced to the chain and no
+// subserver is degraded.
+func (s *PublicStatus) Healthy() bool {
+	if !s.Alive || !s.SyncedToChain {
+		return false
+	}
+
+	for _, state := range s.Subservers {
+		if state == SubserverDegraded {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PublicStatusFunc collects the current public status.
+type PublicStatusFunc func(ctx context.Context) *PublicStatus
+
+// PublicHandler serves the public status page for uptime monitors. It answers
+// with 200 if the status is healthy and with 503 otherwise, so that monitors
+// don't have to parse the response.
+type PublicHandler struct {
+	token  string
+	status PublicStatusFunc
+}
+
+// NewPublicHandler returns a handler for the public status page. If the token
+// is set, it must be passed as bearer token or as token query parameter.
+func NewPublicHandler(token string, status PublicStatusFunc) *PublicHandler {
+	return &PublicHandler{
+		token:  token,
+		status: status,
+	}
+}
+
+// ServeHTTP serves the public status page.
+//
+// NOTE: this is part of the http.Handler interface.
+func (h *PublicHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp.Header().Set("Allow", "GET, HEAD")
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if !h.authorized(req) {
+		resp.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), publicStatusTimeout)
+	defer cancel()
+
+	status := h.status(ctx)
+	body, err := json.Marshal(status)
+	if err != nil {
+		resp.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	code := http.StatusOK
+	if !status.Healthy() {
+		code = http.StatusServiceUnavailable
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Cache-Control", "no-store")
+	resp.WriteHeader(code)
+
+	if req.Method == http.MethodGet {
+		_, _ = resp.Write(body)
+	}
+}
+
+// authorized returns true if no token is required or the request carries the
+// right one.
+func (h *PublicHandler) authorized(req *http.Request) bool {
+	if h.token == "" {
+		return true
+	}
+
+	token := req.URL.Query().Get("token")
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
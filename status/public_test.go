@@ -0,0 +1,86 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPublicHandler tests that the public status page reports the status with
+// a matching status code and checks its token.
+func TestPublicHandler(t *testing.T) {
+	current := &PublicStatus{
+		Alive:         true,
+		SyncedToChain: true,
+		SyncedToGraph: true,
+		Subservers: map[string]string{
+			"loop": SubserverRunning,
+			"pool": SubserverRemote,
+		},
+	}
+	statusFunc := func(context.Context) *PublicStatus {
+		return current
+	}
+
+	serve := func(h http.Handler, method, target,
+		auth string) *httptest.ResponseRecorder {
+
+		req := httptest.NewRequest(method, target, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// Without a token, the page is open to everyone.
+	h := NewPublicHandler("", statusFunc)
+	rec := serve(h, http.MethodGet, PublicPath, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var got PublicStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, *current, got)
+
+	// A HEAD request only gets the status code.
+	rec = serve(h, http.MethodHead, PublicPath, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Empty(t, rec.Body.Bytes())
+
+	rec = serve(h, http.MethodPost, PublicPath, "")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	// A degraded subserver or an unsynced lnd make the status unhealthy.
+	current.Subservers["loop"] = SubserverDegraded
+	rec = serve(h, http.MethodGet, PublicPath, "")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	current.Subservers["loop"] = SubserverRunning
+	current.SyncedToChain = false
+	rec = serve(h, http.MethodGet, PublicPath, "")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	current.SyncedToChain = true
+
+	// With a token, it must be passed as bearer token or query parameter.
+	h = NewPublicHandler("s3cret", statusFunc)
+	rec = serve(h, http.MethodGet, PublicPath, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Empty(t, rec.Body.Bytes())
+
+	rec = serve(h, http.MethodGet, PublicPath, "Bearer wrong")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(h, http.MethodGet, PublicPath, "Bearer s3cret")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(h, http.MethodGet, PublicPath+"?token=s3cret", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+}
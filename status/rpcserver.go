@@ -0,0 +1,531 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/confreload"
+	"github.com/lightninglabs/lightning-terminal/keepalive"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/lockdown"
+	"github.com/lightninglabs/lightning-terminal/maintenance"
+)
+
+// defaultMaxConfigReloads is the number of config reloads that are returned
+// if the caller doesn't specify a maximum.
+const defaultMaxConfigReloads = 100
+
+// RPCServer is the main server that implements the Status gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedStatusServer
+
+	monitor     *Monitor
+	errorLog    *ErrorLog
+	lockdown    *lockdown.Manager
+	maintenance *maintenance.Scheduler
+	reloader    *confreload.Reloader
+	shutdown    *ShutdownTracker
+
+	// keepAlive holds the keepalive settings of litd's connections, which
+	// are reported for diagnosing dropped connections.
+	keepAlive *keepalive.Config
+
+	// logFile is the path of the log file litd and its integrated
+	// subservers write to.
+	logFile string
+}
+
+// NewRPCServer returns a new RPC server for the given status monitor, error
+// log, lockdown manager, maintenance scheduler, config reloader, shutdown
+// tracker, keepalive settings and log file.
+func NewRPCServer(monitor *Monitor, errorLog *ErrorLog,
+	lockdownMgr *lockdown.Manager, scheduler *maintenance.Scheduler,
+	reloader *confreload.Reloader, shutdown *ShutdownTracker,
+	keepAlive *keepalive.Config, logFile string) *RPCServer {
+
+	return &RPCServer{
+		monitor:     monitor,
+		errorLog:    errorLog,
+		lockdown:    lockdownMgr,
+		maintenance: scheduler,
+		reloader:    reloader,
+		shutdown:    shutdown,
+		keepAlive:   keepAlive,
+		logFile:     logFile,
+	}
+}
+
+// GetStatus returns the current health of litd's components.
+func (s *RPCServer) GetStatus(_ context.Context,
+	_ *litrpc.GetStatusRequest) (*litrpc.GetStatusResponse, error) {
+
+	databases, err := s.monitor.DatabaseStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.GetStatusResponse{
+		Databases: make([]*litrpc.DatabaseStatus, len(databases)),
+	}
+	for i := range databases {
+		resp.Databases[i] = marshalDatabaseStatus(&databases[i])
+	}
+
+	if signerStatus := s.monitor.RemoteSignerStatus(); signerStatus != nil {
+		resp.RemoteSigner = marshalSignerStatus(signerStatus)
+	}
+
+	for _, feature := range s.monitor.DisabledFeatures() {
+		resp.DisabledFeatures = append(
+			resp.DisabledFeatures, &litrpc.DisabledFeature{
+				Name:   feature.Name,
+				Reason: feature.Reason,
+			},
+		)
+	}
+
+	for _, subserver := range s.monitor.DegradedSubservers() {
+		resp.DegradedSubservers = append(
+			resp.DegradedSubservers,
+			marshalDegradedSubserver(&subserver),
+		)
+	}
+
+	for _, mailbox := range s.monitor.MailboxStatus() {
+		resp.Mailboxes = append(
+			resp.Mailboxes, marshalMailboxStatus(&mailbox),
+		)
+	}
+
+	resp.Lockdown = marshalLockdownState(s.lockdown.State())
+
+	if window, ok := s.maintenance.Active(); ok {
+		resp.Maintenance = marshalMaintenanceWindow(&window, true)
+	}
+
+	if s.keepAlive != nil {
+		resp.Keepalive = marshalKeepaliveSettings(s.keepAlive)
+	}
+
+	return resp, nil
+}
+
+// TailLogs streams the log output of litd and its integrated subservers. It
+// first sends the most recent lines and then, if requested, every new line as
+// soon as it is written.
+func (s *RPCServer) TailLogs(req *litrpc.TailLogsRequest,
+	stream litrpc.Status_TailLogsServer) error {
+
+	if req.Lines > MaxBacklogLines {
+		return fmt.Errorf("at most %d lines can be requested",
+			MaxBacklogLines)
+	}
+
+	return FollowLog(
+		stream.Context(), s.logFile, int(req.Lines), req.Follow,
+		SubsystemFilter(req.Subsystems), func(line *LogLine) error {
+			return stream.Send(marshalLogLine(line))
+		},
+	)
+}
+
+// RecentErrors returns the most recent warnings and errors that litd and its
+// integrated subservers logged, grouped by subsystem.
+func (s *RPCServer) RecentErrors(_ context.Context,
+	req *litrpc.RecentErrorsRequest) (*litrpc.RecentErrorsResponse, error) {
+
+	subsystems, err := s.errorLog.RecentErrors(req.Subsystems)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.RecentErrorsResponse{
+		Subsystems: make([]*litrpc.SubsystemErrors, len(subsystems)),
+	}
+	for i := range subsystems {
+		resp.Subsystems[i] = marshalSubsystemErrors(&subsystems[i])
+	}
+
+	return resp, nil
+}
+
+// LockdownMode enables or lifts the lockdown mode of litd.
+func (s *RPCServer) LockdownMode(_ context.Context,
+	req *litrpc.LockdownModeRequest) (*litrpc.LockdownModeResponse, error) {
+
+	log.Infof("[lockdownmode] enable=%v, reason=%q", req.Enable,
+		req.Reason)
+
+	var (
+		state lockdown.State
+		err   error
+	)
+	if req.Enable {
+		state, err = s.lockdown.Enable(req.Reason)
+	} else {
+		state, err = s.lockdown.Lift()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.LockdownModeResponse{
+		Lockdown: marshalLockdownState(state),
+	}, nil
+}
+
+// ScheduleMaintenance schedules a maintenance window.
+func (s *RPCServer) ScheduleMaintenance(_ context.Context,
+	req *litrpc.ScheduleMaintenanceRequest) (
+	*litrpc.ScheduleMaintenanceResponse, error) {
+
+	log.Infof("[schedulemaintenance] start=%d, end=%d, reason=%q",
+		req.Start, req.End, req.Reason)
+
+	var start time.Time
+	if req.Start != 0 {
+		start = time.Unix(req.Start, 0)
+	}
+
+	window, err := s.maintenance.Schedule(
+		start, time.Unix(req.End, 0), req.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.ScheduleMaintenanceResponse{
+		Window: marshalMaintenanceWindow(
+			&window, window.ActiveAt(time.Now()),
+		),
+	}, nil
+}
+
+// ListMaintenanceWindows lists the active and upcoming maintenance windows.
+func (s *RPCServer) ListMaintenanceWindows(_ context.Context,
+	_ *litrpc.ListMaintenanceWindowsRequest) (
+	*litrpc.ListMaintenanceWindowsResponse, error) {
+
+	now := time.Now()
+	windows := s.maintenance.Windows()
+
+	resp := &litrpc.ListMaintenanceWindowsResponse{
+		Windows: make([]*litrpc.MaintenanceWindow, len(windows)),
+	}
+	for i := range windows {
+		resp.Windows[i] = marshalMaintenanceWindow(
+			&windows[i], windows[i].ActiveAt(now),
+		)
+	}
+
+	return resp, nil
+}
+
+// CancelMaintenance cancels a maintenance window.
+func (s *RPCServer) CancelMaintenance(_ context.Context,
+	req *litrpc.CancelMaintenanceRequest) (
+	*litrpc.CancelMaintenanceResponse, error) {
+
+	log.Infof("[cancelmaintenance] id=%s", req.Id)
+
+	if err := s.maintenance.Cancel(req.Id); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.CancelMaintenanceResponse{}, nil
+}
+
+// ReloadConfig loads the config again and applies the options that can be
+// changed at runtime.
+func (s *RPCServer) ReloadConfig(_ context.Context,
+	_ *litrpc.ReloadConfigRequest) (*litrpc.ReloadConfigResponse, error) {
+
+	log.Infof("[reloadconfig]")
+
+	reload, err := s.reloader.Reload(confreload.TriggerRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.ReloadConfigResponse{
+		Reload: marshalConfigReload(reload),
+	}, nil
+}
+
+// ListConfigReloads returns the history of the config reloads.
+func (s *RPCServer) ListConfigReloads(_ context.Context,
+	req *litrpc.ListConfigReloadsRequest) (
+	*litrpc.ListConfigReloadsResponse, error) {
+
+	maxReloads := int(req.MaxReloads)
+	if maxReloads == 0 {
+		maxReloads = defaultMaxConfigReloads
+	}
+
+	reloads, err := s.reloader.Reloads(maxReloads)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListConfigReloadsResponse{
+		Reloads: make([]*litrpc.ConfigReload, len(reloads)),
+	}
+	for i, reload := range reloads {
+		resp.Reloads[i] = marshalConfigReload(reload)
+	}
+
+	return resp, nil
+}
+
+// SubscribeShutdown streams the progress of the shutdown of litd. It first
+// sends the steps that already completed and then every further step as soon
+// as it completes.
+func (s *RPCServer) SubscribeShutdown(_ *litrpc.SubscribeShutdownRequest,
+	stream litrpc.Status_SubscribeShutdownServer) error {
+
+	// We subscribe before reading the steps so that we can't miss a step
+	// that completes in between.
+	updates, cancel := s.shutdown.Subscribe()
+	defer cancel()
+
+	var sent int
+	for {
+		steps, finished := s.shutdown.Steps()
+		for ; sent < len(steps); sent++ {
+			err := stream.Send(marshalShutdownStep(&steps[sent]))
+			if err != nil {
+				return err
+			}
+		}
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-updates:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// marshalLogLine converts a log line into its RPC counterpart.
+func marshalLogLine(line *LogLine) *litrpc.LogLine {
+	rpcLine := &litrpc.LogLine{
+		Text:      line.Text,
+		Level:     line.Level,
+		Subsystem: line.Subsystem,
+	}
+
+	// Lines in front of the first log message of a file don't have a
+	// timestamp.
+	if !line.Timestamp.IsZero() {
+		rpcLine.TimestampMs = line.Timestamp.UnixMilli()
+	}
+
+	return rpcLine
+}
+
+// marshalDatabaseStatus converts a database status into its RPC counterpart.
+func marshalDatabaseStatus(s *DatabaseStatus) *litrpc.DatabaseStatus {
+	rpcStatus := &litrpc.DatabaseStatus{
+		Name:         s.Name,
+		Path:         s.Path,
+		SizeBytes:    s.Size,
+		ReadLatency:  marshalLatencyStats(s.ReadLatency),
+		WriteLatency: marshalLatencyStats(s.WriteLatency),
+	}
+	if !s.LastCompacted.IsZero() {
+		rpcStatus.LastCompacted = s.LastCompacted.Unix()
+	}
+	if s.LastError != nil {
+		rpcStatus.LastError = s.LastError.Error()
+	}
+
+	return rpcStatus
+}
+
+// marshalSignerStatus converts the status of the remote signer into its RPC
+// counterpart.
+func marshalSignerStatus(s *SignerStatus) *litrpc.RemoteSignerStatus {
+	rpcStatus := &litrpc.RemoteSignerStatus{
+		RpcHost:   s.RPCHost,
+		Reachable: s.Reachable,
+	}
+	if !s.LastChecked.IsZero() {
+		rpcStatus.LastChecked = s.LastChecked.Unix()
+	}
+	if s.LastError != nil {
+		rpcStatus.LastError = s.LastError.Error()
+	}
+
+	return rpcStatus
+}
+
+// marshalMailboxStatus converts the connectivity of a mailbox server into its
+// RPC counterpart.
+func marshalMailboxStatus(s *MailboxStatus) *litrpc.MailboxStatus {
+	rpcStatus := &litrpc.MailboxStatus{
+		ServerAddr:          s.ServerAddr,
+		Reachable:           s.Reachable,
+		Degraded:            s.Degraded,
+		RoundTrip:           marshalLatencyStats(s.RoundTrip),
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		LastRoundTripUs:     uint64(s.LastRoundTrip.Microseconds()),
+	}
+	if !s.LastChecked.IsZero() {
+		rpcStatus.LastChecked = s.LastChecked.Unix()
+	}
+	if s.LastError != nil {
+		rpcStatus.LastError = s.LastError.Error()
+	}
+
+	return rpcStatus
+}
+
+// marshalKeepaliveSettings converts the keepalive settings into their RPC
+// counterpart.
+func marshalKeepaliveSettings(c *keepalive.Config) *litrpc.KeepaliveSettings {
+	ms := func(d time.Duration) uint64 {
+		return uint64(d.Milliseconds())
+	}
+
+	return &litrpc.KeepaliveSettings{
+		TcpMs:                 ms(c.TCP),
+		WebsocketPingMs:       ms(c.WebsocketPing),
+		ServerTimeMs:          ms(c.ServerTime),
+		ServerTimeoutMs:       ms(c.ServerTimeout),
+		MinClientIntervalMs:   ms(c.MinClientInterval),
+		PermitWithoutStream:   c.PermitWithoutStream,
+		BackendTimeMs:         ms(c.BackendTime),
+		BackendTimeoutMs:      ms(c.BackendTimeout),
+		LncHeartbeatMs:        ms(c.LNCHeartbeat),
+		LncHeartbeatTimeoutMs: ms(c.LNCHeartbeatTimeout),
+	}
+}
+
+// marshalDegradedSubserver converts a degraded subserver into its RPC
+// counterpart.
+func marshalDegradedSubserver(s *DegradedSubserver) *litrpc.DegradedSubserver {
+	rpcSubserver := &litrpc.DegradedSubserver{
+		Name:        s.Name,
+		Since:       s.Since.Unix(),
+		LastAttempt: s.LastAttempt.Unix(),
+		Attempts:    s.Attempts,
+	}
+	if s.LastError != nil {
+		rpcSubserver.LastError = s.LastError.Error()
+	}
+
+	return rpcSubserver
+}
+
+// marshalShutdownStep converts a shutdown step into its RPC counterpart.
+func marshalShutdownStep(s *ShutdownStep) *litrpc.ShutdownStep {
+	rpcStep := &litrpc.ShutdownStep{
+		Step:       uint32(s.Step),
+		TotalSteps: uint32(s.TotalSteps),
+		Name:       s.Name,
+		DurationMs: s.Duration.Milliseconds(),
+	}
+	if s.Err != nil {
+		rpcStep.Error = s.Err.Error()
+	}
+
+	return rpcStep
+}
+
+// marshalLatencyStats converts latency statistics into their RPC
+// counterpart.
+func marshalLatencyStats(s LatencyStats) *litrpc.LatencyStats {
+	return &litrpc.LatencyStats{
+		Samples: uint32(s.Samples),
+		P50Us:   uint64(s.P50.Microseconds()),
+		P90Us:   uint64(s.P90.Microseconds()),
+		P99Us:   uint64(s.P99.Microseconds()),
+		MaxUs:   uint64(s.Max.Microseconds()),
+	}
+}
+
+// marshalSubsystemErrors converts the errors of a subsystem into their RPC
+// counterpart.
+func marshalSubsystemErrors(s *SubsystemErrors) *litrpc.SubsystemErrors {
+	rpcErrors := &litrpc.SubsystemErrors{
+		Subsystem: s.Subsystem,
+		Total:     s.Total,
+		Entries:   make([]*litrpc.ErrorEntry, len(s.Entries)),
+	}
+	for i, entry := range s.Entries {
+		rpcErrors.Entries[i] = &litrpc.ErrorEntry{
+			TimestampMs: entry.Timestamp.UnixMilli(),
+			Level:       entry.Level,
+			Message:     entry.Message,
+		}
+	}
+
+	return rpcErrors
+}
+
+// marshalLockdownState converts the lockdown mode into its RPC counterpart.
+func marshalLockdownState(state lockdown.State) *litrpc.LockdownStatus {
+	rpcStatus := &litrpc.LockdownStatus{
+		Active: state.Active,
+		Reason: state.Reason,
+	}
+	if !state.Since.IsZero() {
+		rpcStatus.Since = state.Since.Unix()
+	}
+
+	return rpcStatus
+}
+
+// marshalMaintenanceWindow converts a maintenance window into its RPC
+// counterpart.
+func marshalMaintenanceWindow(window *maintenance.Window,
+	active bool) *litrpc.MaintenanceWindow {
+
+	return &litrpc.MaintenanceWindow{
+		Id:     window.ID,
+		Start:  window.Start.Unix(),
+		End:    window.End.Unix(),
+		Reason: window.Reason,
+		Active: active,
+	}
+}
+
+// marshalConfigReload converts a config reload into its RPC counterpart.
+func marshalConfigReload(reload *confreload.Reload) *litrpc.ConfigReload {
+	rpcReload := &litrpc.ConfigReload{
+		Id:              reload.ID,
+		Timestamp:       reload.Time.Unix(),
+		Trigger:         marshalReloadTrigger(reload.Trigger),
+		RestartRequired: reload.RestartRequired,
+		Error:           reload.Error,
+	}
+
+	rpcReload.Changes = make([]*litrpc.ConfigChange, len(reload.Changes))
+
+	for i, change := range reload.Changes {
+		rpcReload.Changes[i] = &litrpc.ConfigChange{
+			Option:          change.Option,
+			OldValue:        change.OldValue,
+			NewValue:        change.NewValue,
+			RequiresRestart: change.RequiresRestart,
+		}
+	}
+
+	return rpcReload
+}
+
+// marshalReloadTrigger converts the trigger of a config reload into its RPC
+// counterpart.
+func marshalReloadTrigger(t confreload.Trigger) litrpc.ConfigReloadTrigger {
+	if t == confreload.TriggerSignal {
+		return litrpc.ConfigReloadTrigger_CONFIG_RELOAD_TRIGGER_SIGNAL
+	}
+
+	return litrpc.ConfigReloadTrigger_CONFIG_RELOAD_TRIGGER_RPC
+}
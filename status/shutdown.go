@@ -0,0 +1,111 @@
+package status
+
+import (
+	"sync"
+	"time"
+)
+
+// ShutdownStep is a completed step of the shutdown of litd.
+type ShutdownStep struct {
+	// Step is the number of the step, starting at 1.
+	Step int
+
+	// TotalSteps is the total number of steps of the shutdown.
+	TotalSteps int
+
+	// Name describes what was stopped in the step, for example
+	// lnc sessions.
+	Name string
+
+	// Duration is how long the step took.
+	Duration time.Duration
+
+	// Err is the error of the step, if any. A failed step doesn't stop
+	// the shutdown.
+	Err error
+}
+
+// ShutdownTracker records the progress of the shutdown of litd and notifies
+// its subscribers about every completed step.
+type ShutdownTracker struct {
+	mu          sync.Mutex
+	steps       []ShutdownStep
+	finished    bool
+	subscribers map[chan struct{}]struct{}
+}
+
+// NewShutdownTracker creates a new tracker for a shutdown that hasn't started
+// yet.
+func NewShutdownTracker() *ShutdownTracker {
+	return &ShutdownTracker{
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// StepDone records a completed step and notifies all subscribers.
+func (t *ShutdownTracker) StepDone(step ShutdownStep) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.steps = append(t.steps, step)
+	for sub := range t.subscribers {
+		// A subscriber that hasn't picked up the last step yet will
+		// read all steps anyway.
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Finish marks the shutdown as complete, as far as it can be reported, and
+// closes the channels of all subscribers.
+func (t *ShutdownTracker) Finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finished = true
+	for sub := range t.subscribers {
+		delete(t.subscribers, sub)
+		close(sub)
+	}
+}
+
+// Steps returns the steps that completed so far and whether the shutdown is
+// finished.
+func (t *ShutdownTracker) Steps() ([]ShutdownStep, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	steps := make([]ShutdownStep, len(t.steps))
+	copy(steps, t.steps)
+
+	return steps, t.finished
+}
+
+// Subscribe returns a channel that receives a value whenever a step completes.
+// The channel is closed once the shutdown is finished. The returned function
+// must be called to cancel the subscription.
+func (t *ShutdownTracker) Subscribe() (<-chan struct{}, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sub := make(chan struct{}, 1)
+	if t.finished {
+		close(sub)
+		return sub, func() {}
+	}
+	t.subscribers[sub] = struct{}{}
+
+	cancel := func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if _, ok := t.subscribers[sub]; ok {
+			delete(t.subscribers, sub)
+			close(sub)
+		}
+	}
+
+	return sub, cancel
+}
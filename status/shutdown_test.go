@@ -0,0 +1,52 @@
+package status
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestShutdownTracker tests that the subscribers of the shutdown tracker are
+// notified about every step and that their channels are closed once the
+// shutdown is finished.
+func TestShutdownTracker(t *testing.T) {
+	tracker := NewShutdownTracker()
+
+	steps, finished := tracker.Steps()
+	require.Empty(t, steps)
+	require.False(t, finished)
+
+	updates, cancel := tracker.Subscribe()
+	defer cancel()
+
+	tracker.StepDone(ShutdownStep{Step: 1, TotalSteps: 2, Name: "one"})
+	_, ok := <-updates
+	require.True(t, ok)
+
+	// A subscriber that is slow to pick up its notification doesn't block
+	// the shutdown.
+	stepErr := errors.New("failed")
+	tracker.StepDone(ShutdownStep{Step: 2, TotalSteps: 2, Err: stepErr})
+	tracker.StepDone(ShutdownStep{Step: 3, TotalSteps: 2})
+
+	steps, finished = tracker.Steps()
+	require.Len(t, steps, 3)
+	require.Equal(t, "one", steps[0].Name)
+	require.ErrorIs(t, steps[1].Err, stepErr)
+	require.False(t, finished)
+
+	tracker.Finish()
+	<-updates
+	_, ok = <-updates
+	require.False(t, ok)
+
+	_, finished = tracker.Steps()
+	require.True(t, finished)
+
+	// Subscribing after the shutdown finished returns a closed channel.
+	late, lateCancel := tracker.Subscribe()
+	defer lateCancel()
+	_, ok = <-late
+	require.False(t, ok)
+}
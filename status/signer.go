@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// RemoteSigner describes the remote signer a watch-only lnd delegates all
+// signing to.
+type RemoteSigner struct {
+	// RPCHost is the host:port of the remote signer. It is empty if it
+	// isn't known, for example because lnd runs remotely.
+	RPCHost string
+
+	// Probe checks whether the remote signer can be reached. It is nil if
+	// the connectivity can't be checked.
+	Probe func() error
+}
+
+// SignerStatus describes the connectivity of the remote signer.
+type SignerStatus struct {
+	// RPCHost is the host:port of the remote signer, if known.
+	RPCHost string
+
+	// Reachable is true if the remote signer could be reached at the last
+	// check.
+	Reachable bool
+
+	// LastChecked is the time of the last check. It is the zero time if
+	// the remote signer wasn't checked yet.
+	LastChecked time.Time
+
+	// LastError is the error of the last check, if any.
+	LastError error
+}
+
+// DisabledFeature is a feature of litd that is disabled because it doesn't
+// work with the way lnd is set up.
+type DisabledFeature struct {
+	// Name is the name of the feature, for example loop.
+	Name string
+
+	// Reason describes why the feature is disabled.
+	Reason string
+}
+
+// NewSignerProbe returns a probe that checks whether the remote signer at the
+// given host can be reached and is unlocked. It only uses the State service,
+// which doesn't require a macaroon.
+func NewSignerProbe(rpcHost, tlsCertPath string,
+	timeout time.Duration) func() error {
+
+	return func() error {
+		creds, err := credentials.NewClientTLSFromFile(tlsCertPath, "")
+		if err != nil {
+			return fmt.Errorf("unable to load TLS certificate: %v",
+				err)
+		}
+
+		conn, err := grpc.Dial(
+			rpcHost, grpc.WithTransportCredentials(creds),
+		)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(), timeout,
+		)
+		defer cancel()
+
+		resp, err := lnrpc.NewStateClient(conn).GetState(
+			ctx, &lnrpc.GetStateRequest{},
+		)
+		if err != nil {
+			return err
+		}
+
+		switch resp.State {
+		case lnrpc.WalletState_RPC_ACTIVE,
+			lnrpc.WalletState_SERVER_ACTIVE:
+
+			return nil
+
+		default:
+			return fmt.Errorf("remote signer is in state %v",
+				resp.State)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package status
+
+import (
+	"sort"
+	"time"
+)
+
+// DegradedSubserver is an integrated subserver that failed to start or
+// stopped unexpectedly while litd keeps on running without it.
+type DegradedSubserver struct {
+	// Name is the name of the subserver, for example loop.
+	Name string
+
+	// Since is the time of the first failure since the subserver was last
+	// running.
+	Since time.Time
+
+	// LastAttempt is the time of the last failed attempt to start the
+	// subserver.
+	LastAttempt time.Time
+
+	// Attempts is the number of failed attempts to start the subserver.
+	Attempts uint32
+
+	// LastError is the error of the last failed attempt.
+	LastError error
+}
+
+// SubserverFailed records that the subserver with the given name failed with
+// the given error and is now degraded.
+func (m *Monitor) SubserverFailed(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	subserver, ok := m.degraded[name]
+	if !ok {
+		subserver = &DegradedSubserver{
+			Name:  name,
+			Since: now,
+		}
+		m.degraded[name] = subserver
+	}
+
+	subserver.LastAttempt = now
+	subserver.Attempts++
+	subserver.LastError = err
+}
+
+// SubserverRecovered records that the subserver with the given name is running
+// again.
+func (m *Monitor) SubserverRecovered(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.degraded, name)
+}
+
+// SubserverDegraded returns true if the subserver with the given name is
+// degraded.
+func (m *Monitor) SubserverDegraded(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.degraded[name]
+	return ok
+}
+
+// DegradedSubservers returns all degraded subservers, ordered by name.
+func (m *Monitor) DegradedSubservers() []DegradedSubserver {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subservers := make([]DegradedSubserver, 0, len(m.degraded))
+	for _, subserver := range m.degraded {
+		subservers = append(subservers, *subserver)
+	}
+	sort.Slice(subservers, func(i, j int) bool {
+		return subservers[i].Name < subservers[j].Name
+	})
+
+	return subservers
+}
@@ -0,0 +1,181 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lightninglabs/faraday/frdrpc"
+	"github.com/lightninglabs/faraday/frdrpcserver"
+	"github.com/lightninglabs/lightning-terminal/status"
+	"github.com/lightninglabs/loop/loopd"
+	"github.com/lightninglabs/loop/looprpc"
+	"github.com/lightninglabs/pool"
+	"github.com/lightninglabs/pool/poolrpc"
+)
+
+// errSimulatedCrash is the error a degraded subserver is marked with after a
+// simulated crash.
+var errSimulatedCrash = errors.New("simulated crash")
+
+// faradayRPC, loopRPC and poolRPC are registered with the gRPC servers instead
+// of the integrated daemons themselves. A daemon can only be started once, so
+// in degraded-mode a daemon that failed is replaced by a new instance before
+// its start is retried. Since the gRPC servers only know the wrappers, their
+// calls then reach the new instance. Calls are rejected by ValidateMacaroon
+// while a daemon isn't started, so the instance is never replaced while it
+// serves a call.
+type faradayRPC struct {
+	frdrpc.FaradayServerServer
+}
+
+type loopRPC struct {
+	looprpc.SwapClientServer
+}
+
+type poolRPC struct {
+	poolrpc.TraderServer
+}
+
+// newIntegratedSubserver creates a new instance of the integrated daemon with
+// the given name and makes the gRPC servers use it.
+func (g *LightningTerminal) newIntegratedSubserver(name string) {
+	switch name {
+	case "faraday":
+		g.faradayServer = frdrpcserver.NewRPCServer(
+			g.cfg.faradayRpcConfig,
+		)
+		g.faradayRPC.FaradayServerServer = g.faradayServer
+
+	case "loop":
+		g.loopServer = loopd.New(g.cfg.Loop, nil)
+		g.loopRPC.SwapClientServer = g.loopServer
+
+	case "pool":
+		g.poolServer = pool.NewServer(g.cfg.Pool)
+		g.poolRPC.TraderServer = g.poolServer
+	}
+}
+
+// startIntegratedSubserver starts the integrated daemon with the given name.
+// The connection to lnd must be established already.
+func (g *LightningTerminal) startIntegratedSubserver(name string) error {
+	log.Infof("Starting integrated %s daemon", name)
+
+	var err error
+	switch name {
+	case "faraday":
+		err = g.faradayServer.StartAsSubserver(
+			g.lndClient.LndServices, g.createDefaultMacaroons,
+		)
+		g.faradayStarted.Store(err == nil)
+
+	case "loop":
+		err = g.loopServer.StartAsSubserver(
+			g.lndClient, g.createDefaultMacaroons,
+		)
+		g.loopStarted.Store(err == nil)
+
+	case "pool":
+		err = g.poolServer.StartAsSubserver(
+			g.basicClient, g.lndClient, g.createDefaultMacaroons,
+		)
+		g.poolStarted.Store(err == nil)
+
+	default:
+		return fmt.Errorf("unknown subserver %s", name)
+	}
+	if err != nil {
+		return fmt.Errorf("error starting integrated %s daemon: %w",
+			name, err)
+	}
+
+	g.faultInjector.SubserverStarted(name)
+
+	return nil
+}
+
+// subserverFailed handles an integrated daemon that failed to start or stopped
+// unexpectedly. Without degraded-mode, the error is returned so that litd
+// shuts down. In degraded-mode, the daemon is marked as degraded and replaced
+// by a new instance that is started by the next retry.
+func (g *LightningTerminal) subserverFailed(name string, err error) error {
+	if !g.cfg.DegradedMode {
+		return err
+	}
+
+	log.Errorf("Integrated %s daemon failed, continuing without it: %v",
+		name, err)
+
+	g.statusMonitor.SubserverFailed(name, err)
+	g.newIntegratedSubserver(name)
+
+	return nil
+}
+
+// retryDegradedSubservers tries to start all degraded integrated daemons
+// again.
+func (g *LightningTerminal) retryDegradedSubservers() {
+	for _, subserver := range g.statusMonitor.DegradedSubservers() {
+		err := g.startIntegratedSubserver(subserver.Name)
+		if err != nil {
+			_ = g.subserverFailed(subserver.Name, err)
+			continue
+		}
+
+		log.Infof("Integrated %s daemon recovered after %d failed "+
+			"attempts", subserver.Name, subserver.Attempts)
+		g.statusMonitor.SubserverRecovered(subserver.Name)
+	}
+}
+
+// collectPublicStatus collects the coarse health information that is shown on
+// the public status page. Nothing that identifies the node is included.
+func (g *LightningTerminal) collectPublicStatus(
+	ctx context.Context) *status.PublicStatus {
+
+	subservers := []struct {
+		name    string
+		remote  bool
+		started bool
+	}{
+		{"faraday", g.cfg.faradayRemote, g.faradayStarted.Load()},
+		{"loop", g.cfg.loopRemote, g.loopStarted.Load()},
+		{"pool", g.cfg.poolRemote, g.poolStarted.Load()},
+	}
+
+	publicStatus := &status.PublicStatus{
+		Subservers: make(map[string]string, len(subservers)),
+	}
+	for _, subserver := range subservers {
+		state := status.SubserverStarting
+		switch {
+		case subserver.remote:
+			state = status.SubserverRemote
+
+		case g.statusMonitor.SubserverDegraded(subserver.name):
+			state = status.SubserverDegraded
+
+		case subserver.started:
+			state = status.SubserverRunning
+		}
+
+		publicStatus.Subservers[subserver.name] = state
+	}
+
+	if g.lndClient == nil {
+		return publicStatus
+	}
+
+	info, err := g.lndClient.Client.GetInfo(ctx)
+	if err != nil {
+		log.Debugf("Public status could not reach lnd: %v", err)
+		return publicStatus
+	}
+
+	publicStatus.Alive = true
+	publicStatus.SyncedToChain = info.SyncedToChain
+	publicStatus.SyncedToGraph = info.SyncedToGraph
+
+	return publicStatus
+}
@@ -7,30 +7,59 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
-	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	restProxy "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jessevdk/go-flags"
 	"github.com/lightninglabs/faraday/frdrpc"
 	"github.com/lightninglabs/faraday/frdrpcserver"
 	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/adminauth"
+	"github.com/lightninglabs/lightning-terminal/apikeys"
 	"github.com/lightninglabs/lightning-terminal/autopilotserver"
+	"github.com/lightninglabs/lightning-terminal/autopilotserver/mock"
+	"github.com/lightninglabs/lightning-terminal/backup"
+	"github.com/lightninglabs/lightning-terminal/cluster"
+	"github.com/lightninglabs/lightning-terminal/confreload"
+	"github.com/lightninglabs/lightning-terminal/faults"
+	"github.com/lightninglabs/lightning-terminal/feesched"
 	"github.com/lightninglabs/lightning-terminal/firewall"
 	"github.com/lightninglabs/lightning-terminal/firewalldb"
+	"github.com/lightninglabs/lightning-terminal/guardrails"
+	"github.com/lightninglabs/lightning-terminal/inbox"
 	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/lnurl"
+	"github.com/lightninglabs/lightning-terminal/lockdown"
+	"github.com/lightninglabs/lightning-terminal/logsink"
+	"github.com/lightninglabs/lightning-terminal/maccache"
+	"github.com/lightninglabs/lightning-terminal/maintenance"
+	"github.com/lightninglabs/lightning-terminal/nodemgmt"
+	"github.com/lightninglabs/lightning-terminal/nwc"
+	"github.com/lightninglabs/lightning-terminal/oidc"
 	"github.com/lightninglabs/lightning-terminal/perms"
+	"github.com/lightninglabs/lightning-terminal/portal"
+	"github.com/lightninglabs/lightning-terminal/provision"
 	"github.com/lightninglabs/lightning-terminal/queue"
+	"github.com/lightninglabs/lightning-terminal/reports"
 	mid "github.com/lightninglabs/lightning-terminal/rpcmiddleware"
 	"github.com/lightninglabs/lightning-terminal/rules"
+	"github.com/lightninglabs/lightning-terminal/scb"
 	"github.com/lightninglabs/lightning-terminal/session"
+	"github.com/lightninglabs/lightning-terminal/status"
+	"github.com/lightninglabs/lightning-terminal/uiflags"
+	"github.com/lightninglabs/lightning-terminal/watchdog"
+	"github.com/lightninglabs/lightning-terminal/webproxy"
+	"github.com/lightninglabs/lightning-terminal/webui"
 	"github.com/lightninglabs/lndclient"
 	"github.com/lightninglabs/loop"
 	"github.com/lightninglabs/loop/loopd"
@@ -46,6 +75,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/autopilotrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/peersrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/routerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/verrpc"
@@ -53,6 +83,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc/watchtowerrpc"
 	"github.com/lightningnetwork/lnd/lnrpc/wtclientrpc"
 	"github.com/lightningnetwork/lnd/lntest/wait"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/rpcperms"
@@ -160,24 +191,50 @@ type LightningTerminal struct {
 	wg       sync.WaitGroup
 	errQueue *queue.ConcurrentQueue[error]
 
+	// clusterMgr holds the leadership of the cluster this instance is part
+	// of. It is nil if litd doesn't run in a cluster.
+	clusterMgr *cluster.Manager
+
 	lndClient   *lndclient.GrpcLndServices
 	basicClient lnrpc.LightningClient
 
+	// basicWalletKitClient is a raw wallet kit client that shares the
+	// connection of the basic client. It is used for calls that are not
+	// exposed by lndclient.
+	basicWalletKitClient walletrpc.WalletKitClient
+
+	// basicPeersClient is a raw peers client that shares the connection
+	// of the basic client.
+	basicPeersClient peersrpc.PeersClient
+
+	// faradayStarted, loopStarted and poolStarted are set while the
+	// integrated daemons are started and read by the RPC calls and the
+	// public status page at the same time.
 	faradayServer  *frdrpcserver.RPCServer
-	faradayStarted bool
+	faradayRPC     *faradayRPC
+	faradayStarted atomic.Bool
 
 	autopilotClient autopilotserver.Autopilot
+	autopilotMock   *mock.Server
 
-	ruleMgrs rules.ManagerSet
+	ruleMgrs    rules.ManagerSet
+	ruleBundles *rules.BundleStore
 
 	loopServer  *loopd.Daemon
-	loopStarted bool
+	loopRPC     *loopRPC
+	loopStarted atomic.Bool
 
 	poolServer  *pool.Server
-	poolStarted bool
+	poolRPC     *poolRPC
+	poolStarted atomic.Bool
+
+	// createDefaultMacaroons is true if the integrated daemons should
+	// create their default macaroon files when they are started.
+	createDefaultMacaroons bool
 
 	rpcProxy   *rpcProxy
 	httpServer *http.Server
+	uiReloader *webui.Reloader
 
 	sessionRpcServer        *sessionRpcServer
 	sessionRpcServerStarted bool
@@ -188,13 +245,99 @@ type LightningTerminal struct {
 
 	middleware        *mid.Manager
 	middlewareStarted bool
+	middlewareBypass  *mid.Bypass
 
 	accountService        *accounts.InterceptorService
 	accountServiceStarted bool
 
 	accountRpcServer *accounts.RPCServer
 
-	firewallDB *firewalldb.DB
+	chanBackupMgr        *scb.Manager
+	chanBackupMgrStarted bool
+
+	backupScheduler        *backup.Scheduler
+	backupSchedulerStarted bool
+
+	backupRpcServer *backup.RPCServer
+
+	reportsScheduler        *reports.Scheduler
+	reportsSchedulerStarted bool
+
+	reportsRpcServer *reports.RPCServer
+
+	nwcService        *nwc.Service
+	nwcServiceStarted bool
+	nwcRpcServer      *nwc.RPCServer
+
+	accountPayer *accounts.Payer
+
+	oidcAuth *oidc.Authenticator
+
+	lnurlService        *lnurl.Service
+	lnurlServiceStarted bool
+	lnurlRpcServer      *lnurl.RPCServer
+
+	portalService        *portal.Service
+	portalServiceStarted bool
+	portalRpcServer      *portal.RPCServer
+	portalServer         *http.Server
+
+	webProxy *webproxy.Proxy
+
+	apiKeyMgr        *apikeys.Manager
+	apiKeyMgrStarted bool
+	apiKeyRpcServer  *apikeys.RPCServer
+
+	macCache *maccache.Cache
+
+	nodeMgmtService        *nodemgmt.Service
+	nodeMgmtServiceStarted bool
+	nodeMgmtRpcServer      *nodemgmt.RPCServer
+
+	feeScheduler          *feesched.Manager
+	feeSchedulerStarted   bool
+	feeSchedulerRpcServer *feesched.RPCServer
+
+	watchdog          *watchdog.Watchdog
+	watchdogStarted   bool
+	watchdogRpcServer *watchdog.RPCServer
+
+	guard          *guardrails.Guard
+	guardRpcServer *guardrails.RPCServer
+
+	adminAuth *adminauth.Verifier
+
+	uiFlagMgr        *uiflags.Manager
+	uiFlagMgrStarted bool
+	uiFlagRpcServer  *uiflags.RPCServer
+
+	inbox          *inbox.Inbox
+	inboxStarted   bool
+	inboxRpcServer *inbox.RPCServer
+
+	lockdownMgr        *lockdown.Manager
+	lockdownMgrStarted bool
+
+	maintenanceScheduler        *maintenance.Scheduler
+	maintenanceSchedulerStarted bool
+
+	confReloader        *confreload.Reloader
+	confReloaderStarted bool
+
+	statusMonitor        *status.Monitor
+	statusMonitorStarted bool
+	errorLog             *status.ErrorLog
+	errorLogStarted      bool
+	statusRpcServer      *status.RPCServer
+	shutdownTracker      *status.ShutdownTracker
+	publicStatus         *status.PublicHandler
+
+	faultInjector *faults.Injector
+
+	provisionRpcServer *provision.RPCServer
+
+	firewallDB       *firewalldb.DB
+	firewallSettings *firewall.SettingsManager
 
 	restHandler http.Handler
 	restCancel  func()
@@ -202,7 +345,9 @@ type LightningTerminal struct {
 
 // New creates a new instance of the lightning-terminal daemon.
 func New() *LightningTerminal {
-	return &LightningTerminal{}
+	return &LightningTerminal{
+		shutdownTracker: status.NewShutdownTracker(),
+	}
 }
 
 // Run starts everything and then blocks until either the application is shut
@@ -221,6 +366,28 @@ func (g *LightningTerminal) Run() error {
 	g.cfg = cfg
 	g.defaultImplCfg = g.cfg.Lnd.ImplementationConfig(shutdownInterceptor)
 
+	// If configured, the log messages are forwarded to syslog or the
+	// journal until everything is shut down.
+	if g.cfg.Logging.Sink != logsink.SinkFile {
+		sink, err := logsink.New(g.cfg.Logging)
+		if err != nil {
+			return fmt.Errorf("could not create log sink: %v", err)
+		}
+
+		forwarder := logsink.NewForwarder(sink)
+		if err := forwarder.Start(); err != nil {
+			_ = sink.Close()
+			return fmt.Errorf("could not forward log messages: %v",
+				err)
+		}
+		defer func() {
+			if err := forwarder.Stop(); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Error stopping "+
+					"log forwarder: %v\n", err)
+			}
+		}()
+	}
+
 	// Show version at startup.
 	log.Infof("LiT version: %s", Version())
 
@@ -231,26 +398,69 @@ func (g *LightningTerminal) Run() error {
 	g.errQueue.Start()
 	defer g.errQueue.Stop()
 
+	// Faults are only ever injected in development builds.
+	g.faultInjector, err = faults.NewInjector(g.cfg.Dev.Faults)
+	if err != nil {
+		return fmt.Errorf("could not create fault injector: %v", err)
+	}
+
 	// Construct a new Manager.
 	g.permsMgr, err = perms.NewManager(false)
 	if err != nil {
 		return fmt.Errorf("could not create permissions manager")
 	}
 
+	// Make sure only read-only methods bypass the RPC middleware before
+	// we start anything.
+	g.middlewareBypass, err = mid.NewBypass(
+		g.cfg.RPCMiddleware.BypassMethods, g.permsMgr.URIPermissions,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid RPC middleware bypass: %v", err)
+	}
+
 	// Create the instances of our subservers now so we can hook them up to
 	// lnd once it's fully started.
 	bufRpcListener := bufconn.Listen(100)
-	g.faradayServer = frdrpcserver.NewRPCServer(g.cfg.faradayRpcConfig)
-	g.loopServer = loopd.New(g.cfg.Loop, nil)
-	g.poolServer = pool.NewServer(g.cfg.Pool)
-	g.rpcProxy = newRpcProxy(
-		g.cfg, g, g.validateSuperMacaroon, g.permsMgr, bufRpcListener,
-	)
-	g.accountService, err = accounts.NewService(
-		filepath.Dir(g.cfg.MacaroonPath), g.errQueue.ChanIn(),
-	)
-	if err != nil {
-		return fmt.Errorf("error creating account service: %v", err)
+	g.faradayRPC = &faradayRPC{}
+	g.loopRPC = &loopRPC{}
+	g.poolRPC = &poolRPC{}
+	g.newIntegratedSubserver("faraday")
+	g.newIntegratedSubserver("loop")
+	g.newIntegratedSubserver("pool")
+	if g.cfg.OIDC.Enable {
+		g.oidcAuth, err = oidc.NewAuthenticator(g.cfg.OIDC)
+		if err != nil {
+			return fmt.Errorf("could not create OIDC authenticator: "+
+				"%v", err)
+		}
+	}
+	// The databases can only be compacted before they are opened.
+	if err := g.compactDatabases(); err != nil {
+		return fmt.Errorf("could not compact databases: %v", err)
+	}
+	if err := g.verifyDatabases(); err != nil {
+		return fmt.Errorf("could not verify databases: %v", err)
+	}
+
+	// In a cluster, the accounts and sessions are stored in the shared
+	// database that only the leader may open.
+	var sessionBackend kvdb.Backend
+	if g.cfg.Cluster.Enable {
+		sessionBackend, err = g.joinCluster(
+			shutdownInterceptor.ShutdownChannel(),
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		g.accountService, err = accounts.NewService(
+			filepath.Dir(g.cfg.MacaroonPath), g.errQueue.ChanIn(),
+		)
+		if err != nil {
+			return fmt.Errorf("error creating account service: %v",
+				err)
+		}
 	}
 
 	superMacBaker := func(ctx context.Context, rootKeyID uint64,
@@ -262,10 +472,160 @@ func (g *LightningTerminal) Run() error {
 		)
 	}
 
+	// The session RPC server is only created further below, so we look it
+	// up when an account session is actually requested.
+	addAccountSession := func(ctx context.Context,
+		req *litrpc.AddSessionRequest) (*litrpc.AddSessionResponse,
+		error) {
+
+		return g.sessionRpcServer.AddSession(ctx, req)
+	}
+
+	// The RPC proxy and the firewall DB are only created further below.
+	// The actions DB of the audit log is set once the DB is opened.
+	audit := &auditLog{
+		actor: func(ctx context.Context) string {
+			return g.rpcProxy.actorFromContext(ctx)
+		},
+	}
+
 	g.accountRpcServer = accounts.NewRPCServer(
-		g.accountService, superMacBaker,
+		g.accountService, superMacBaker, addAccountSession, audit,
+		g.cfg.Dev.SimulateAccounts,
 	)
 
+	deleteRootKey := func(ctx context.Context, rootKeyID uint64) error {
+		if g.basicClient == nil {
+			return errors.New("lnd not yet connected")
+		}
+
+		_, err := g.basicClient.DeleteMacaroonID(
+			ctx, &lnrpc.DeleteMacaroonIDRequest{
+				RootKeyId: rootKeyID,
+			},
+		)
+		return err
+	}
+
+	g.apiKeyMgr = apikeys.NewManager(
+		filepath.Dir(g.cfg.MacaroonPath), superMacBaker, deleteRootKey,
+		g.permsMgr.ActivePermissions, g.accountService,
+	)
+	g.apiKeyRpcServer = apikeys.NewRPCServer(g.apiKeyMgr)
+
+	// We can only enforce the daily swap budgets if we have direct access
+	// to the swaps, which is only the case if loop runs integrated.
+	var listSwaps guardrails.SwapLister
+	if !g.cfg.loopRemote {
+		listSwaps = g.listSwaps
+	}
+	g.guard = guardrails.NewGuard(g.cfg.Guardrails, listSwaps, audit)
+	g.guardRpcServer = guardrails.NewRPCServer(g.guard)
+
+	// The lnd connection is only established further below, so we look up
+	// the node's identity key when a challenge is actually signed with it.
+	g.adminAuth, err = adminauth.NewVerifier(
+		g.cfg.AdminAuth, func() (*btcec.PublicKey, error) {
+			if g.lndClient == nil {
+				return nil, errors.New("lnd is not connected")
+			}
+
+			return btcec.ParsePubKey(g.lndClient.NodePubkey[:])
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not create admin signature "+
+			"verifier: %v", err)
+	}
+
+	g.macCache = maccache.NewCache(g.cfg.MacaroonCache)
+	g.rpcProxy = newRpcProxy(
+		g.cfg, g, g.validateSuperMacaroon, g.permsMgr, bufRpcListener,
+		g.oidcAuth, g.apiKeyMgr, g.macCache, g.adminAuth,
+		superMacBaker, audit, g.guard.UnaryServerInterceptor,
+	)
+
+	// lnd's wallet unlock password can only be used to encrypt channel
+	// backups if we have access to it, which is only the case in
+	// integrated mode.
+	var walletPasswordFile string
+	if g.cfg.LndMode == ModeIntegrated {
+		walletPasswordFile = g.cfg.Lnd.WalletUnlockPasswordFile
+	}
+	g.chanBackupMgr = scb.NewManager(g.cfg.ChanBackup, walletPasswordFile)
+
+	// The stores are only opened later on, so we reference them lazily.
+	backupSources := []backup.Source{{
+		Name: accounts.DBFilename,
+		Snapshot: func(w io.Writer) error {
+			return g.accountService.Snapshot(w)
+		},
+	}, {
+		Name: session.DBFilename,
+		Snapshot: func(w io.Writer) error {
+			return g.sessionRpcServer.db.Snapshot(w)
+		},
+	}, {
+		Name: firewalldb.DBFilename,
+		Snapshot: func(w io.Writer) error {
+			return g.firewallDB.Snapshot(w)
+		},
+	}, {
+		Name: lncfg.MacaroonDBName,
+		Snapshot: func(w io.Writer) error {
+			return g.macaroonDB.Copy(w)
+		},
+	}}
+	// The accounts and sessions of a cluster live in Postgres and must be
+	// backed up together with it.
+	if g.cfg.Cluster.Enable {
+		backupSources = backupSources[2:]
+	}
+	g.backupScheduler, err = backup.NewScheduler(
+		g.cfg.Backup, g.cfg.Network, backupSources,
+		g.chanBackupMgr.ChannelBackup,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating backup scheduler: %v", err)
+	}
+
+	g.backupRpcServer = backup.NewRPCServer(
+		g.chanBackupMgr, g.backupScheduler,
+	)
+
+	reportSources := &reports.SummarySources{
+		Accounts: func(_ context.Context) (
+			[]*accounts.OffChainBalanceAccount, error) {
+
+			return g.accountService.Accounts()
+		},
+		Sessions: func() ([]*session.Session, error) {
+			return g.sessionRpcServer.db.ListSessions(nil)
+		},
+		Actions: func(start, end time.Time) ([]*firewalldb.Action,
+			error) {
+
+			actions, _, _, err := g.firewallDB.ListActions(
+				func(a *firewalldb.Action, _ bool) (bool, bool) {
+					return !a.AttemptedAt.Before(start) &&
+						a.AttemptedAt.Before(end), true
+				}, nil,
+			)
+			return actions, err
+		},
+	}
+
+	// We only have direct access to the swaps and pool accounts if the
+	// daemons run integrated.
+	if !g.cfg.loopRemote {
+		reportSources.Swaps = g.listSwaps
+	}
+	if !g.cfg.poolRemote {
+		reportSources.PoolAccounts = g.listPoolAccounts
+	}
+	g.reportsScheduler = reports.NewScheduler(g.cfg.Reports, reportSources)
+	g.reportsRpcServer = reports.NewRPCServer(g.reportsScheduler)
+
 	g.ruleMgrs = rules.NewRuleManagerSet()
 
 	networkDir := filepath.Join(g.cfg.LitDir, g.cfg.Network)
@@ -273,8 +633,159 @@ func (g *LightningTerminal) Run() error {
 	if err != nil {
 		return fmt.Errorf("error creating session DB: %v", err)
 	}
+	audit.actionsDB = g.firewallDB
+
+	g.firewallSettings, err = firewall.NewSettingsManager(
+		g.cfg.Firewall, g.firewallDB,
+	)
+	if err != nil {
+		return err
+	}
+
+	g.ruleBundles = rules.NewBundleStore(g.firewallDB, g.ruleMgrs)
+
+	g.nwcService = nwc.NewService(g.cfg.NWC, networkDir, g.accountService)
+	g.nwcRpcServer = nwc.NewRPCServer(g.nwcService)
+
+	g.lnurlService = lnurl.NewService(
+		g.cfg.LNURL, networkDir, g.accountService,
+	)
+	g.lnurlRpcServer = lnurl.NewRPCServer(g.lnurlService)
+
+	g.portalService = portal.NewService(
+		g.cfg.Portal, networkDir, g.accountService,
+	)
+	g.portalRpcServer = portal.NewRPCServer(g.portalService)
+
+	// Requests to the subserver web UIs are accepted with the same
+	// credentials as litd's own RPCs. The UI password is checked with a
+	// basic auth challenge so that browsers can open the UIs directly.
+	webProxyRoutes, err := g.cfg.WebProxy.ParseRoutes()
+	if err != nil {
+		return err
+	}
+	if len(webProxyRoutes) > 0 {
+		g.webProxy, err = webproxy.NewProxy(
+			webProxyRoutes, g.rpcProxy.authenticateWebRequest,
+			!g.cfg.DisableUI,
+		)
+		if err != nil {
+			return fmt.Errorf("error creating web proxy: %v", err)
+		}
+	}
+
+	g.nodeMgmtService = nodemgmt.NewService()
+	g.nodeMgmtRpcServer = nodemgmt.NewRPCServer(g.nodeMgmtService)
+
+	g.feeScheduler = feesched.NewManager(g.cfg.FeeScheduler, networkDir)
+	g.feeSchedulerRpcServer = feesched.NewRPCServer(g.feeScheduler)
+
+	g.watchdog = watchdog.NewWatchdog(g.cfg.Watchdog, networkDir)
+	g.watchdogRpcServer = watchdog.NewRPCServer(g.watchdog)
+	g.accountService.SetPaymentObserver(g.watchdog)
+
+	// The macaroons of a removed account must not be accepted from the
+	// macaroon cache anymore. Their root keys aren't known, so we drop
+	// all cached verifications.
+	g.accountService.SetOnRemove(func(accounts.AccountID) {
+		g.macCache.Purge()
+	})
+
+	g.uiFlagMgr = uiflags.NewManager(g.cfg.UIFlags, networkDir)
+	g.uiFlagRpcServer = uiflags.NewRPCServer(
+		g.uiFlagMgr, g.rpcProxy.callerRole,
+	)
+
+	// The alerts of the watchdog are also shown in the inbox of the web
+	// UI.
+	g.inbox = inbox.NewInbox(g.cfg.Inbox, networkDir)
+	g.inboxRpcServer = inbox.NewRPCServer(g.inbox)
+	g.watchdog.SetInbox(g.inbox)
+
+	// The session DB is only opened further below, so the probes must
+	// look up the databases when they run.
+	dbPaths := DatabasePaths(networkDir, g.cfg.MacaroonPath)
+	g.statusMonitor = status.NewMonitor(g.cfg.Status, []status.Store{{
+		Path: dbPaths[0],
+		Probe: func(write bool) error {
+			return g.accountService.Probe(write)
+		},
+	}, {
+		Path: dbPaths[1],
+		Probe: func(write bool) error {
+			return g.sessionRpcServer.db.Probe(write)
+		},
+	}, {
+		Path: dbPaths[2],
+		Probe: func(write bool) error {
+			return g.firewallDB.Probe(write)
+		},
+	}})
+
+	// The same goes for the session server, which is asked for the mailbox
+	// servers of the active sessions on each probe. Their connectivity is
+	// also watched by the watchdog.
+	g.statusMonitor.SetMailboxes(func() map[string]bool {
+		return g.sessionRpcServer.sessionServer.MailboxServers()
+	}, status.NewHashMailProbe())
+	g.watchdog.SetMailboxSource(g.statusMonitor)
+
+	// The error log is started right away, so that it also records the
+	// problems that happen while lnd and the subservers are starting up.
+	g.errorLog = status.NewErrorLog(g.cfg.Status, g.cfg.logFile())
+	if err := g.errorLog.Start(); err != nil {
+		return fmt.Errorf("error starting error log: %v", err)
+	}
+	g.errorLogStarted = true
+
+	// The config litd was started with is loaded again without any
+	// validation, so that it can be compared to the config of later
+	// reloads.
+	startCfg, err := loadRawConfig()
+	if err != nil {
+		return fmt.Errorf("could not load config: %w", err)
+	}
+	g.confReloader = confreload.NewReloader(
+		networkDir, startCfg, loadRawConfig, g.reloadFuncs(),
+	)
+	if err := g.confReloader.Start(); err != nil {
+		return fmt.Errorf("error starting config reloader: %v", err)
+	}
+	g.confReloaderStarted = true
+	go g.handleReloadSignals(shutdownInterceptor.ShutdownChannel())
+
+	g.lockdownMgr = lockdown.NewManager(networkDir)
+	g.lockdownMgr.SetOnEnable(g.macCache.Purge)
+	g.maintenanceScheduler = maintenance.NewScheduler(networkDir)
+	g.statusRpcServer = status.NewRPCServer(
+		g.statusMonitor, g.errorLog, g.lockdownMgr,
+		g.maintenanceScheduler, g.confReloader, g.shutdownTracker,
+		g.cfg.KeepAlive, g.cfg.logFile(),
+	)
+
+	if g.cfg.Status.PublicPage {
+		g.publicStatus = status.NewPublicHandler(
+			g.cfg.Status.PublicToken, g.collectPublicStatus,
+		)
+	}
 
 	if !g.cfg.Autopilot.Disable {
+		// The mock server is started right away, so that we know the
+		// address the client needs to connect to.
+		if g.cfg.Autopilot.Mock {
+			g.autopilotMock = mock.NewServer()
+			if err := g.autopilotMock.Start(); err != nil {
+				return fmt.Errorf("could not start mock "+
+					"autopilot server: %v", err)
+			}
+
+			log.Infof("Started mock autopilot server on %v",
+				g.autopilotMock.Addr())
+
+			g.cfg.Autopilot.Address = g.autopilotMock.Addr()
+			g.cfg.Autopilot.Insecure = true
+		}
+
 		if g.cfg.Autopilot.Address == "" &&
 			len(g.cfg.Autopilot.DialOpts) == 0 {
 
@@ -303,16 +814,54 @@ func (g *LightningTerminal) Run() error {
 		}
 	}
 
+	g.rpcProxy.macInspector = &macaroonInspector{
+		cfg:              g.cfg,
+		permsMgr:         g.permsMgr,
+		validator:        g,
+		lndValidator:     g.validateSuperMacaroon,
+		accounts:         g.accountService,
+		firewallSettings: g.firewallSettings,
+	}
+	if g.autopilotClient != nil {
+		g.rpcProxy.macInspector.featurePerms =
+			g.autopilotClient.ListFeaturePerms
+	}
+
+	// The static LNC keys of new sessions are either stored locally or
+	// derived from lnd's wallet. The lnd connection is only established
+	// further below, so we look it up when a key is actually used.
+	var keyBackend session.KeyBackend = &session.LocalKeyBackend{}
+	if g.cfg.Sessions.KeyBackend == session.KeyBackendLnd {
+		lnd := func() (*lndclient.LndServices, error) {
+			if g.lndClient == nil {
+				return nil, errors.New("lnd is not connected")
+			}
+
+			return &g.lndClient.LndServices, nil
+		}
+		keyBackend = session.NewDerivedKeyBackend(
+			session.NewLndKeyDeriver(lnd),
+		)
+	}
+
 	g.sessionRpcServer, err = newSessionRPCServer(&sessionRpcServerConfig{
 		basicAuth: g.rpcProxy.basicAuth,
 		dbDir:     filepath.Join(g.cfg.LitDir, g.cfg.Network),
-		grpcOptions: []grpc.ServerOption{
+		dbBackend: sessionBackend,
+		grpcOptions: append([]grpc.ServerOption{
 			grpc.CustomCodec(grpcProxy.Codec()), // nolint: staticcheck,
 			grpc.ChainStreamInterceptor(
+				g.lockdownMgr.StreamServerInterceptor,
+				g.maintenanceScheduler.StreamServerInterceptor,
 				g.rpcProxy.StreamServerInterceptor,
+				g.adminAuth.StreamServerInterceptor,
 			),
 			grpc.ChainUnaryInterceptor(
+				g.lockdownMgr.UnaryServerInterceptor,
+				g.maintenanceScheduler.UnaryServerInterceptor,
 				g.rpcProxy.UnaryServerInterceptor,
+				g.adminAuth.UnaryServerInterceptor,
+				g.guard.UnaryServerInterceptor,
 			),
 			grpc.UnknownServiceHandler(
 				grpcProxy.TransparentHandler(
@@ -320,23 +869,43 @@ func (g *LightningTerminal) Run() error {
 					g.rpcProxy.makeDirector(false),
 				),
 			),
-		},
+		}, g.cfg.KeepAlive.ServerOptions()...),
+		mailboxDialOptions: g.cfg.KeepAlive.LNCDialOptions(),
 		registerGrpcServers: func(server *grpc.Server) {
 			g.registerSubDaemonGrpcServers(server, false)
 		},
 		superMacBaker:           superMacBaker,
 		firstConnectionDeadline: g.cfg.FirstLNCConnDeadline,
+		sessionDrainTimeout:     g.cfg.SessionDrainTimeout,
+		sessionCfg:              g.cfg.Sessions,
 		permMgr:                 g.permsMgr,
 		actionsDB:               g.firewallDB,
 		autopilot:               g.autopilotClient,
 		ruleMgrs:                g.ruleMgrs,
+		ruleBundles:             g.ruleBundles,
 		privMap:                 g.firewallDB.PrivacyDB,
+		accountService:          g.accountService,
+		redactMissionControl:    g.cfg.Firewall.RedactMissionControl,
+		firewallSettings:        g.firewallSettings,
+		auditor:                 audit,
+		wrapLNCConn:             g.faultInjector.WrapLNCConn,
+		keyBackend:              keyBackend,
+		macCache:                g.macCache,
+		lndValidator:            g.validateSuperMacaroon,
 	})
 	if err != nil {
 		return fmt.Errorf("could not create new session rpc "+
 			"server: %v", err)
 	}
 
+	g.provisionRpcServer = provision.NewRPCServer(
+		provision.NewProvisioner(&provision.Servers{
+			Accounts:  g.accountRpcServer,
+			Sessions:  g.sessionRpcServer,
+			Autopilot: g.sessionRpcServer,
+		}),
+	)
+
 	// Overwrite the loop and pool daemon's user agent name so it sends
 	// "litd" instead of "loopd" and "poold" respectively.
 	loop.AgentName = "litd"
@@ -481,26 +1050,218 @@ func (g *LightningTerminal) Run() error {
 		return err
 	}
 
+	// In degraded-mode, we periodically retry to start the integrated
+	// daemons that failed.
+	var retryDegraded <-chan time.Time
+	if g.cfg.DegradedMode {
+		retryTicker := time.NewTicker(g.cfg.SubserverRetryInterval)
+		defer retryTicker.Stop()
+
+		retryDegraded = retryTicker.C
+	}
+
 	// Now block until we receive an error or the main shutdown signal.
-	select {
-	case err := <-g.loopServer.ErrChan:
-		// Loop will shut itself down if an error happens. We don't need
-		// to try to stop it again.
-		g.loopStarted = false
-		log.Errorf("Received critical error from loop, shutting down: "+
-			"%v", err)
+	for {
+		select {
+		case err := <-g.loopServer.ErrChan:
+			// Loop will shut itself down if an error happens. We
+			// don't need to try to stop it again.
+			g.loopStarted.Store(false)
+			if g.cfg.DegradedMode {
+				_ = g.subserverFailed("loop", err)
+				continue
+			}
+			log.Errorf("Received critical error from loop, "+
+				"shutting down: %v", err)
 
-	case err := <-g.errQueue.ChanOut():
+		case <-retryDegraded:
+			g.retryDegradedSubservers()
+			continue
+
+		case err := <-g.errQueue.ChanOut():
+			if err != nil {
+				log.Errorf("Received critical error from "+
+					"subsystem, shutting down: %v", err)
+			}
+
+		case name := <-g.faultInjector.SubserverCrashes():
+			// A simulated crash only takes down the subserver,
+			// so we keep on running.
+			g.crashSubserver(name)
+			continue
+
+		case <-lndQuit:
+			return nil
+
+		case <-shutdownInterceptor.ShutdownChannel():
+			log.Infof("Shutdown signal received")
+		}
+
+		return nil
+	}
+}
+
+// crashSubserver simulates a crash of the integrated subserver with the given
+// name by stopping it while litd keeps on running.
+func (g *LightningTerminal) crashSubserver(name string) {
+	log.Warnf("Simulating crash of integrated %s daemon", name)
+
+	var err error
+	switch name {
+	case "faraday":
+		if !g.faradayStarted.Load() {
+			return
+		}
+		g.faradayStarted.Store(false)
+		err = g.faradayServer.Stop()
+
+	case "loop":
+		if !g.loopStarted.Load() {
+			return
+		}
+		g.loopStarted.Store(false)
+		g.loopServer.Stop()
+		err = <-g.loopServer.ErrChan
+
+	case "pool":
+		if !g.poolStarted.Load() {
+			return
+		}
+		g.poolStarted.Store(false)
+		err = g.poolServer.Stop()
+	}
+
+	if err != nil {
+		log.Errorf("Error stopping %s: %v", name, err)
+	}
+
+	// In degraded-mode, the crashed subserver is restarted by the next
+	// retry.
+	_ = g.subserverFailed(name, errSimulatedCrash)
+}
+
+// listSwaps returns all swaps of the integrated loop daemon.
+func (g *LightningTerminal) listSwaps(ctx context.Context) (
+	[]*looprpc.SwapStatus, error) {
+
+	if !g.loopStarted.Load() {
+		return nil, fmt.Errorf("loop is not running")
+	}
+
+	resp, err := g.loopServer.ListSwaps(ctx, &looprpc.ListSwapsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Swaps, nil
+}
+
+// listPoolAccounts returns the accounts of the integrated pool daemon that
+// aren't closed.
+func (g *LightningTerminal) listPoolAccounts(ctx context.Context) (
+	[]*poolrpc.Account, error) {
+
+	if !g.poolStarted.Load() {
+		return nil, fmt.Errorf("pool is not running")
+	}
+
+	resp, err := g.poolServer.ListAccounts(
+		ctx, &poolrpc.ListAccountsRequest{
+			ActiveOnly: true,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Accounts, nil
+}
+
+// setupRemoteSigner finds out whether lnd runs in watch-only mode with a
+// remote signer. If it does, the connectivity of the signer is reported by the
+// status monitor and the integrated daemons that need private keys lnd doesn't
+// have are disabled.
+func (g *LightningTerminal) setupRemoteSigner(ctx context.Context) error {
+	var signer *status.RemoteSigner
+	switch {
+	// In integrated mode we know the remote signer from lnd's config and
+	// can check whether it can be reached.
+	case g.cfg.LndMode == ModeIntegrated && g.cfg.Lnd.RemoteSigner.Enable:
+		signerCfg := g.cfg.Lnd.RemoteSigner
+		signer = &status.RemoteSigner{
+			RPCHost: signerCfg.RPCHost,
+			Probe: status.NewSignerProbe(
+				signerCfg.RPCHost, signerCfg.TLSCertPath,
+				signerCfg.Timeout,
+			),
+		}
+
+	// A remote lnd doesn't tell us about its signer, but its default
+	// account is watch-only if it uses one.
+	case g.cfg.LndMode == ModeRemote:
+		resp, err := g.basicWalletKitClient.ListAccounts(
+			ctx, &walletrpc.ListAccountsRequest{
+				Name: lnwallet.DefaultAccountName,
+			},
+		)
 		if err != nil {
-			log.Errorf("Received critical error from subsystem, "+
-				"shutting down: %v", err)
+			return err
 		}
 
-	case <-lndQuit:
+		for _, account := range resp.Accounts {
+			if account.WatchOnly {
+				signer = &status.RemoteSigner{}
+				break
+			}
+		}
+	}
+
+	if signer == nil {
 		return nil
+	}
 
-	case <-shutdownInterceptor.ShutdownChannel():
-		log.Infof("Shutdown signal received")
+	log.Infof("lnd runs in watch-only mode with a remote signer")
+	g.statusMonitor.SetRemoteSigner(signer)
+
+	reason := "lnd runs in watch-only mode with a remote signer, which " +
+		"the integrated %s daemon doesn't support"
+	if !g.cfg.loopRemote {
+		log.Warnf("Disabling integrated loop daemon")
+		g.statusMonitor.DisableFeature("loop", fmt.Sprintf(reason, "loop"))
+	}
+	if !g.cfg.poolRemote {
+		log.Warnf("Disabling integrated pool daemon")
+		g.statusMonitor.DisableFeature("pool", fmt.Sprintf(reason, "pool"))
+	}
+
+	return nil
+}
+
+// disabledSubserverErr returns an error with the reason if the given URI
+// belongs to an integrated daemon that is disabled or degraded.
+func (g *LightningTerminal) disabledSubserverErr(fullMethod string) error {
+	var name string
+	switch {
+	case g.permsMgr.IsFaradayURI(fullMethod):
+		name = "faraday"
+
+	case g.permsMgr.IsLoopURI(fullMethod):
+		name = "loop"
+
+	case g.permsMgr.IsPoolURI(fullMethod):
+		name = "pool"
+
+	default:
+		return nil
+	}
+
+	if reason, disabled := g.statusMonitor.FeatureDisabled(name); disabled {
+		return fmt.Errorf("%s is disabled: %s", name, reason)
+	}
+
+	if g.statusMonitor.SubserverDegraded(name) {
+		return fmt.Errorf("%s is degraded, its start is retried in "+
+			"the background", name)
 	}
 
 	return nil
@@ -542,12 +1303,19 @@ func (g *LightningTerminal) startSubservers() error {
 		// Create an lnd client now that we have the full configuration.
 		// We'll need a basic client and a full client because not all
 		// subservers have the same requirements.
-		var err error
-		g.basicClient, err = lndclient.NewBasicClient(
+		conn, err := lndclient.NewBasicConn(
 			host, tlsPath, filepath.Dir(macPath), string(network),
 			clientOptions...,
 		)
-		return err
+		if err != nil {
+			return err
+		}
+
+		g.basicClient = lnrpc.NewLightningClient(conn)
+		g.basicWalletKitClient = walletrpc.NewWalletKitClient(conn)
+		g.basicPeersClient = peersrpc.NewPeersClient(conn)
+
+		return nil
 	}, defaultStartupTimeout)
 	if err != nil {
 		return err
@@ -600,6 +1368,13 @@ func (g *LightningTerminal) startSubservers() error {
 	// filter the available permissions accordingly.
 	g.permsMgr.OnLNDBuildTags(g.lndClient.Version.BuildTags)
 
+	// A watch-only lnd delegates all signing to a remote signer, which
+	// some of the integrated daemons can't work with. We find that out
+	// now, so they aren't started at all instead of failing later on.
+	if err := g.setupRemoteSigner(ctxc); err != nil {
+		return fmt.Errorf("error checking for remote signer: %v", err)
+	}
+
 	// In the integrated mode, we received an admin macaroon once lnd was
 	// ready. We can now bake a "super macaroon" that contains all
 	// permissions of all daemons that we can use for any internal calls.
@@ -636,39 +1411,27 @@ func (g *LightningTerminal) startSubservers() error {
 		createDefaultMacaroons = !macService.StatelessInit
 	}
 
-	// Both connection types are ready now, let's start our subservers if
-	// they should be started locally as an integrated service.
-	if !g.cfg.faradayRemote {
-		log.Infof("Starting integrated faraday daemon")
-		err = g.faradayServer.StartAsSubserver(
-			g.lndClient.LndServices, createDefaultMacaroons,
-		)
-		if err != nil {
-			return err
-		}
-		g.faradayStarted = true
-	}
+	g.createDefaultMacaroons = createDefaultMacaroons
 
-	if !g.cfg.loopRemote {
-		log.Infof("Starting integrated loop daemon")
-		err = g.loopServer.StartAsSubserver(
-			g.lndClient, createDefaultMacaroons,
-		)
-		if err != nil {
-			return err
+	// Both connection types are ready now, let's start our subservers if
+	// they should be started locally as an integrated service. In
+	// degraded-mode, a subserver that fails to start doesn't stop litd.
+	_, loopDisabled := g.statusMonitor.FeatureDisabled("loop")
+	_, poolDisabled := g.statusMonitor.FeatureDisabled("pool")
+	integrated := map[string]bool{
+		"faraday": !g.cfg.faradayRemote,
+		"loop":    !g.cfg.loopRemote && !loopDisabled,
+		"pool":    !g.cfg.poolRemote && !poolDisabled,
+	}
+	for _, name := range []string{"faraday", "loop", "pool"} {
+		if !integrated[name] {
+			continue
 		}
-		g.loopStarted = true
-	}
 
-	if !g.cfg.poolRemote {
-		log.Infof("Starting integrated pool daemon")
-		err = g.poolServer.StartAsSubserver(
-			g.basicClient, g.lndClient, createDefaultMacaroons,
-		)
-		if err != nil {
+		err := g.startIntegratedSubserver(name)
+		if err != nil && g.subserverFailed(name, err) != nil {
 			return err
 		}
-		g.poolStarted = true
 	}
 
 	log.Infof("Starting LiT macaroon service")
@@ -720,11 +1483,47 @@ func (g *LightningTerminal) startSubservers() error {
 		}
 	}
 
+	// The lockdown mode must be restored before the sessions are resumed,
+	// so that no session call slips through after a restart.
+	log.Infof("Starting LiT lockdown manager")
+	if err := g.lockdownMgr.Start(); err != nil {
+		return fmt.Errorf("error starting lockdown manager: %v", err)
+	}
+	g.lockdownMgrStarted = true
+
+	log.Infof("Starting LiT maintenance scheduler")
+	if err := g.maintenanceScheduler.Start(); err != nil {
+		return fmt.Errorf("error starting maintenance scheduler: %v",
+			err)
+	}
+	g.maintenanceSchedulerStarted = true
+
 	log.Infof("Starting LiT session server")
 	if err = g.sessionRpcServer.start(); err != nil {
 		return err
 	}
-	g.sessionRpcServerStarted = true
+	g.sessionRpcServerStarted = true
+
+	log.Infof("Starting LiT channel backup manager")
+	if err = g.chanBackupMgr.Start(g.basicClient); err != nil {
+		return fmt.Errorf("error starting channel backup manager: %v",
+			err)
+	}
+	g.chanBackupMgrStarted = true
+
+	log.Infof("Starting LiT backup scheduler")
+	if err = g.backupScheduler.Start(); err != nil {
+		return fmt.Errorf("error starting backup scheduler: %v", err)
+	}
+	g.backupSchedulerStarted = true
+
+	g.reportsRpcServer.Start(g.lndClient.Client)
+
+	log.Infof("Starting LiT reports scheduler")
+	if err = g.reportsScheduler.Start(g.lndClient.Client); err != nil {
+		return fmt.Errorf("error starting reports scheduler: %v", err)
+	}
+	g.reportsSchedulerStarted = true
 
 	// The rest of the function only applies if the rpc middleware
 	// interceptor has been enabled.
@@ -735,9 +1534,10 @@ func (g *LightningTerminal) startSubservers() error {
 	}
 
 	log.Infof("Starting LiT account service")
+	g.accountService.SetListClient(g.basicClient)
 	err = g.accountService.Start(
-		g.lndClient.Client, g.lndClient.Router,
-		g.lndClient.ChainParams,
+		g.lndClient.Client, g.lndClient.Router, g.lndClient.Invoices,
+		g.lndClient.ChainParams, g.cfg.Accounts,
 	)
 	if err != nil {
 		return fmt.Errorf("error starting account service: %v",
@@ -745,6 +1545,78 @@ func (g *LightningTerminal) startSubservers() error {
 	}
 	g.accountServiceStarted = true
 
+	g.accountPayer = accounts.NewPayer(
+		g.accountService, g.lndClient.Router, g.lndClient.ChainParams,
+		g.lockdownMgr,
+	)
+
+	log.Infof("Starting LiT NWC service")
+	err = g.nwcService.Start(
+		g.lndClient.Client, g.accountPayer, g.lndClient.ChainParams,
+	)
+	if err != nil {
+		return fmt.Errorf("error starting NWC service: %v", err)
+	}
+	g.nwcServiceStarted = true
+
+	log.Infof("Starting LiT LNURL service")
+	err = g.lnurlService.Start(g.accountPayer, g.lndClient.ChainParams)
+	if err != nil {
+		return fmt.Errorf("error starting LNURL service: %v", err)
+	}
+	g.lnurlServiceStarted = true
+
+	log.Infof("Starting LiT account portal")
+	err = g.portalService.Start(g.lndClient.Client, g.lockdownMgr)
+	if err != nil {
+		return fmt.Errorf("error starting account portal: %v", err)
+	}
+	g.portalServiceStarted = true
+
+	log.Infof("Starting LiT API key manager")
+	if err := g.apiKeyMgr.Start(); err != nil {
+		return fmt.Errorf("error starting API key manager: %v", err)
+	}
+	g.apiKeyMgrStarted = true
+
+	log.Infof("Starting LiT notification inbox")
+	if err := g.inbox.Start(); err != nil {
+		return fmt.Errorf("error starting notification inbox: %v", err)
+	}
+	g.inboxStarted = true
+
+	log.Infof("Starting LiT watchdog")
+	err = g.watchdog.Start(
+		g.basicClient, g.accountService, g.accountService,
+	)
+	if err != nil {
+		return fmt.Errorf("error starting watchdog: %v", err)
+	}
+	g.watchdogStarted = true
+
+	log.Infof("Starting LiT UI flag manager")
+	if err := g.uiFlagMgr.Start(); err != nil {
+		return fmt.Errorf("error starting UI flag manager: %v", err)
+	}
+	g.uiFlagMgrStarted = true
+
+	log.Infof("Starting LiT status monitor")
+	if err := g.statusMonitor.Start(); err != nil {
+		return fmt.Errorf("error starting status monitor: %v", err)
+	}
+	g.statusMonitorStarted = true
+
+	actionSigner, err := firewall.NewActionSigner(
+		ctxc, g.cfg.Firewall.ActionSigning, g.lndClient.Signer,
+		g.lndClient.WalletKit,
+	)
+	if err != nil {
+		return fmt.Errorf("error creating action signer: %v", err)
+	}
+	if actionSigner != nil {
+		g.firewallDB.SetActionSigner(actionSigner)
+	}
+
 	requestLogger, err := firewall.NewRequestLogger(
 		g.cfg.Firewall.RequestLogger, g.firewallDB,
 	)
@@ -752,28 +1624,66 @@ func (g *LightningTerminal) startSubservers() error {
 		return fmt.Errorf("error creating new request logger")
 	}
 
+	// The request logger level may have been changed at runtime, which
+	// takes precedence over the config.
+	err = g.firewallSettings.SetRequestLogger(requestLogger)
+	if err != nil {
+		return fmt.Errorf("error applying firewall settings: %v", err)
+	}
+
 	privacyMapper := firewall.NewPrivacyMapper(
 		g.firewallDB.PrivacyDB, firewall.CryptoRandIntn,
 	)
 
+	// The privacy mapper, the mission control redactor, the route hint
+	// filter and the account interceptor rewrite or filter messages, so
+	// they must never be bypassed. The request logger and the rule
+	// enforcer are never bypassed either, as rules like the history and
+	// rate limits check and count read-only calls too. Only the guardrails
+	// and the on-chain spend blocker, which never restrict read-only calls,
+	// are skipped for the configured bypass methods. The privacy mapper
+	// and the rule enforcer can be disabled at runtime through the
+	// firewall settings.
 	mw := []mid.RequestInterceptor{
-		privacyMapper,
+		g.firewallSettings.WrapPrivacyMapper(privacyMapper),
+		firewall.NewMissionControlRedactor(),
+		firewall.NewRouteHintFilter(),
 		g.accountService,
 		requestLogger,
+		g.middlewareBypass.Wrap(g.guard),
+		g.middlewareBypass.Wrap(firewall.NewOnChainSpendBlocker(
+			g.permsMgr.URIPermissions,
+		)),
 	}
 
-	if !g.cfg.Autopilot.Disable {
-		info, err := g.lndClient.Client.GetInfo(ctxc)
+	// The cost recorder is never bypassed, as every call made through a
+	// session or account must be billed.
+	if g.cfg.Firewall.Billing.Enable {
+		costRecorder, err := firewall.NewCostRecorder(
+			g.cfg.Firewall.Billing, g.firewallDB,
+		)
 		if err != nil {
-			return fmt.Errorf("GetInfo call failed: %v", err)
+			return fmt.Errorf("error creating cost recorder: %v",
+				err)
 		}
 
+		mw = append(mw, costRecorder)
+	}
+
+	info, err := g.lndClient.Client.GetInfo(ctxc)
+	if err != nil {
+		return fmt.Errorf("GetInfo call failed: %v", err)
+	}
+
+	if !g.cfg.Autopilot.Disable {
 		ruleEnforcer := firewall.NewRuleEnforcer(
 			g.firewallDB, g.firewallDB,
 			g.autopilotClient.ListFeaturePerms,
 			g.permsMgr, info.IdentityPubkey,
 			g.lndClient.Router,
-			g.lndClient.Client, g.ruleMgrs,
+			g.lndClient.Client, g.basicWalletKitClient,
+			g.lndClient.ChainParams, g.ruleMgrs, g.ruleBundles,
+			g.firewallDB, g.firewallDB,
 			func(reqID uint64, reason string) error {
 				return requestLogger.MarkAction(
 					reqID, firewalldb.ActionStateError,
@@ -782,7 +1692,69 @@ func (g *LightningTerminal) startSubservers() error {
 			}, g.firewallDB.PrivacyDB,
 		)
 
-		mw = append(mw, ruleEnforcer)
+		mw = append(
+			mw, g.firewallSettings.WrapRuleEnforcer(ruleEnforcer),
+		)
+	}
+
+	// Node management actions are always checked against the firewall
+	// rules, independent of the autopilot and the macaroon used.
+	nodeMgmtGuard := firewall.NewActionGuard(&firewall.ActionGuardConfig{
+		FeatureName:     nodemgmt.FeatureName,
+		RuleBundle:      g.cfg.NodeManagement.RuleBundle,
+		DB:              g.firewallDB,
+		RuleMgrs:        g.ruleMgrs,
+		RuleBundles:     g.ruleBundles,
+		PermsMgr:        g.permsMgr,
+		NodeID:          info.IdentityPubkey,
+		RouterClient:    g.lndClient.Router,
+		LndClient:       g.lndClient.Client,
+		WalletKitClient: g.basicWalletKitClient,
+		ChainParams:     g.lndClient.ChainParams,
+	})
+
+	log.Infof("Starting LiT node management service")
+	err = g.nodeMgmtService.Start(
+		g.basicClient, g.basicPeersClient, nodeMgmtGuard,
+	)
+	if err != nil {
+		return fmt.Errorf("error starting node management service: %v",
+			err)
+	}
+	g.nodeMgmtServiceStarted = true
+
+	// The scheduled fee updates are checked against their own rule bundle
+	// and are logged as actions of their own feature.
+	feeSchedulerGuard := firewall.NewActionGuard(
+		&firewall.ActionGuardConfig{
+			FeatureName:     feesched.FeatureName,
+			RuleBundle:      g.cfg.FeeScheduler.RuleBundle,
+			DB:              g.firewallDB,
+			RuleMgrs:        g.ruleMgrs,
+			RuleBundles:     g.ruleBundles,
+			PermsMgr:        g.permsMgr,
+			NodeID:          info.IdentityPubkey,
+			RouterClient:    g.lndClient.Router,
+			LndClient:       g.lndClient.Client,
+			WalletKitClient: g.basicWalletKitClient,
+			ChainParams:     g.lndClient.ChainParams,
+		},
+	)
+
+	log.Infof("Starting LiT fee scheduler")
+	err = g.feeScheduler.Start(g.basicClient, feeSchedulerGuard)
+	if err != nil {
+		return fmt.Errorf("error starting fee scheduler: %v", err)
+	}
+	g.feeSchedulerStarted = true
+
+	// In development builds, faults can be injected into the stages of
+	// the interceptors. All interceptors that are called for account and
+	// session macaroons reject their requests in lockdown mode.
+	for i, interceptor := range mw {
+		mw[i] = g.lockdownMgr.WrapInterceptor(
+			g.faultInjector.WrapInterceptor(interceptor),
+		)
 	}
 
 	// Start the middleware manager.
@@ -833,21 +1805,41 @@ func (g *LightningTerminal) registerSubDaemonGrpcServers(server *grpc.Server,
 	// any server for it. The director will then forward the request to the
 	// remote service.
 	if !g.cfg.faradayRemote {
-		frdrpc.RegisterFaradayServerServer(server, g.faradayServer)
+		frdrpc.RegisterFaradayServerServer(server, g.faradayRPC)
 	}
 
 	if !g.cfg.loopRemote {
-		looprpc.RegisterSwapClientServer(server, g.loopServer)
+		looprpc.RegisterSwapClientServer(server, g.loopRPC)
 	}
 
 	if !g.cfg.poolRemote {
-		poolrpc.RegisterTraderServer(server, g.poolServer)
+		poolrpc.RegisterTraderServer(server, g.poolRPC)
 	}
 
 	if withLitRPC {
 		litrpc.RegisterSessionsServer(server, g.sessionRpcServer)
 		litrpc.RegisterAccountsServer(server, g.accountRpcServer)
 		litrpc.RegisterProxyServer(server, g.rpcProxy)
+		litrpc.RegisterBackupsServer(server, g.backupRpcServer)
+		litrpc.RegisterReportsServer(server, g.reportsRpcServer)
+		litrpc.RegisterNostrWalletConnectServer(server, g.nwcRpcServer)
+		litrpc.RegisterLnurlWithdrawServer(server, g.lnurlRpcServer)
+		litrpc.RegisterAccountPortalServer(server, g.portalRpcServer)
+		litrpc.RegisterApiKeysServer(server, g.apiKeyRpcServer)
+		litrpc.RegisterNodeManagementServer(
+			server, g.nodeMgmtRpcServer,
+		)
+		litrpc.RegisterFeeSchedulerServer(
+			server, g.feeSchedulerRpcServer,
+		)
+		litrpc.RegisterWatchdogServer(server, g.watchdogRpcServer)
+		litrpc.RegisterGuardrailsServer(server, g.guardRpcServer)
+		litrpc.RegisterUIFlagsServer(server, g.uiFlagRpcServer)
+		litrpc.RegisterInboxServer(server, g.inboxRpcServer)
+		litrpc.RegisterStatusServer(server, g.statusRpcServer)
+		litrpc.RegisterProvisioningServer(
+			server, g.provisionRpcServer,
+		)
 	}
 
 	litrpc.RegisterFirewallServer(server, g.sessionRpcServer)
@@ -909,6 +1901,104 @@ func (g *LightningTerminal) RegisterRestSubserver(ctx context.Context,
 		return err
 	}
 
+	err = litrpc.RegisterBackupsHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterReportsHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterNostrWalletConnectHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterLnurlWithdrawHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterAccountPortalHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterApiKeysHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterNodeManagementHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterFeeSchedulerHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterWatchdogHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterGuardrailsHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterUIFlagsHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterInboxHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterStatusHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = litrpc.RegisterProvisioningHandlerFromEndpoint(
+		ctx, mux, endpoint, dialOpts,
+	)
+	if err != nil {
+		return err
+	}
+
 	err = frdrpc.RegisterFaradayServerHandlerFromEndpoint(
 		ctx, mux, endpoint, dialOpts,
 	)
@@ -937,6 +2027,12 @@ func (g *LightningTerminal) RegisterRestSubserver(ctx context.Context,
 func (g *LightningTerminal) ValidateMacaroon(ctx context.Context,
 	requiredPermissions []bakery.Op, fullMethod string) error {
 
+	// Calls to integrated daemons that are disabled because they don't
+	// work with the way lnd is set up fail with the reason right away.
+	if err := g.disabledSubserverErr(fullMethod); err != nil {
+		return err
+	}
+
 	macHex, err := macaroons.RawMacaroonFromContext(ctx)
 	if err != nil {
 		return err
@@ -973,7 +2069,7 @@ func (g *LightningTerminal) ValidateMacaroon(ctx context.Context,
 			return nil
 		}
 
-		if !g.faradayStarted {
+		if !g.faradayStarted.Load() {
 			return fmt.Errorf("faraday is not yet ready for " +
 				"requests, lnd possibly still starting or " +
 				"syncing")
@@ -997,7 +2093,7 @@ func (g *LightningTerminal) ValidateMacaroon(ctx context.Context,
 			return nil
 		}
 
-		if !g.loopStarted {
+		if !g.loopStarted.Load() {
 			return fmt.Errorf("loop is not yet ready for " +
 				"requests, lnd possibly still starting or " +
 				"syncing")
@@ -1021,7 +2117,7 @@ func (g *LightningTerminal) ValidateMacaroon(ctx context.Context,
 			return nil
 		}
 
-		if !g.poolStarted {
+		if !g.poolStarted.Load() {
 			return fmt.Errorf("pool is not yet ready for " +
 				"requests, lnd possibly still starting or " +
 				"syncing")
@@ -1092,84 +2188,11 @@ func (g *LightningTerminal) BuildWalletConfig(ctx context.Context,
 
 // shutdown stops all subservers that were started and attached to lnd.
 func (g *LightningTerminal) shutdown() error {
-	var returnErr error
-
-	if g.faradayStarted {
-		if err := g.faradayServer.Stop(); err != nil {
-			log.Errorf("Error stopping faraday: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.loopStarted {
-		g.loopServer.Stop()
-		if err := <-g.loopServer.ErrChan; err != nil {
-			log.Errorf("Error stopping loop: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.poolStarted {
-		if err := g.poolServer.Stop(); err != nil {
-			log.Errorf("Error stopping pool: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.autopilotClient != nil {
-		g.autopilotClient.Stop()
-	}
-
-	if g.sessionRpcServerStarted {
-		if err := g.sessionRpcServer.stop(); err != nil {
-			log.Errorf("Error closing session DB: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.macaroonServiceStarted {
-		if err := g.macaroonService.Stop(); err != nil {
-			log.Errorf("Error stopping macaroon service: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.macaroonDB != nil {
-		g.macaroonDB.Close()
-	}
-
-	if g.accountServiceStarted {
-		if err := g.accountService.Stop(); err != nil {
-			log.Errorf("Error stopping account service: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.middlewareStarted {
-		g.middleware.Stop()
-	}
-
-	if g.firewallDB != nil {
-		if err := g.firewallDB.Close(); err != nil {
-			log.Errorf("Error closing rules DB: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.ruleMgrs != nil {
-		if err := g.ruleMgrs.Stop(); err != nil {
-			log.Errorf("Error stopping rule manager set: %v", err)
-			returnErr = err
-		}
-	}
-
-	if g.lndClient != nil {
-		g.lndClient.Close()
-	}
+	returnErr := g.runShutdownSteps()
 
-	if g.restCancel != nil {
-		g.restCancel()
-	}
+	// The RPC servers are stopped last, so that the progress of all
+	// previous steps can be streamed to the subscribers.
+	g.shutdownTracker.Finish()
 
 	if g.rpcProxy != nil {
 		if err := g.rpcProxy.Stop(); err != nil {
@@ -1185,6 +2208,18 @@ func (g *LightningTerminal) shutdown() error {
 		}
 	}
 
+	if g.portalServer != nil {
+		if err := g.portalServer.Close(); err != nil {
+			log.Errorf("Error stopping account portal server: %v",
+				err)
+			returnErr = err
+		}
+	}
+
+	if g.uiReloader != nil {
+		g.uiReloader.Stop()
+	}
+
 	// In case the error wasn't thrown by lnd, make sure we stop it too.
 	interceptor.RequestShutdown()
 
@@ -1262,10 +2297,34 @@ func (g *LightningTerminal) startMainWebServer() error {
 	if err != nil {
 		return err
 	}
+	assets := http.FS(buildDir)
+
+	// An operator can replace the embedded UI with a build on disk. The
+	// live reloader then tells the browsers to reload once that build
+	// changes.
+	if g.cfg.UI.AssetDir != "" {
+		log.Infof("Serving UI from %s", g.cfg.UI.AssetDir)
+		assets = http.Dir(g.cfg.UI.AssetDir)
+
+		if g.cfg.UI.LiveReload {
+			g.uiReloader = webui.NewReloader(g.cfg.UI.AssetDir)
+			if err := g.uiReloader.Start(); err != nil {
+				return fmt.Errorf("unable to start UI live "+
+					"reload: %v", err)
+			}
+			assets = g.uiReloader.FileSystem(assets)
+		}
+	}
 	staticFileServer := http.FileServer(&ClientRouteWrapper{
-		assets: http.FS(buildDir),
+		assets: assets,
 	})
 
+	// The OpenAPI spec describes the REST API of all litrpc services.
+	openAPISpec, err := litrpc.OpenAPISpec(Version())
+	if err != nil {
+		return fmt.Errorf("unable to create OpenAPI spec: %v", err)
+	}
+
 	// Both gRPC (web) and static file requests will come into through the
 	// main UI HTTP server. We use this simple switching handler to send the
 	// requests to the correct implementation.
@@ -1277,6 +2336,56 @@ func (g *LightningTerminal) startMainWebServer() error {
 			return
 		}
 
+		// The OIDC login endpoints must be reachable without any
+		// authentication since that's what they're there for.
+		if g.oidcAuth != nil &&
+			strings.HasPrefix(req.URL.Path, oidc.PathPrefix) {
+
+			log.Infof("Handling OIDC request: %s", req.URL.Path)
+			g.oidcAuth.ServeHTTP(resp, req)
+
+			return
+		}
+
+		// LNURL-withdraw vouchers are redeemed by wallets without any
+		// authentication, the voucher ID in the URL is the secret.
+		if strings.HasPrefix(req.URL.Path, lnurl.PathPrefix) {
+			log.Infof("Handling LNURL request")
+			g.lnurlService.ServeHTTP(resp, req)
+
+			return
+		}
+
+		// The public status page is meant for uptime monitors, which
+		// can't authenticate. It checks its optional token itself.
+		if g.publicStatus != nil && req.URL.Path == status.PublicPath {
+			g.publicStatus.ServeHTTP(resp, req)
+
+			return
+		}
+
+		// The web UIs and HTTP endpoints of subservers are only
+		// forwarded to if the request is authenticated, which the proxy
+		// checks itself.
+		if g.webProxy != nil &&
+			strings.HasPrefix(req.URL.Path, webproxy.PathPrefix) {
+
+			log.Infof("Handling subserver web request: %s",
+				req.URL.Path)
+			g.webProxy.ServeHTTP(resp, req)
+
+			return
+		}
+
+		// The OpenAPI spec is public documentation, so it doesn't
+		// require any authentication.
+		if g.cfg.EnableREST && req.URL.Path == litrpc.OpenAPIPath {
+			resp.Header().Set("Content-Type", "application/json")
+			_, _ = resp.Write(openAPISpec)
+
+			return
+		}
+
 		// REST requests aren't that easy to identify, we have to look
 		// at the URL itself. If this is a REST request, we give it
 		// directly to our REST handler which will then forward it to
@@ -1296,6 +2405,13 @@ func (g *LightningTerminal) startMainWebServer() error {
 			return
 		}
 
+		// The browsers wait for reload events on a long-lived
+		// connection.
+		if g.uiReloader != nil && req.URL.Path == webui.LiveReloadPath {
+			g.uiReloader.ServeHTTP(resp, req)
+			return
+		}
+
 		// If we got here, it's a static file the browser wants, or
 		// something we don't know in which case the static file server
 		// will answer with a 404.
@@ -1304,9 +2420,14 @@ func (g *LightningTerminal) startMainWebServer() error {
 		// Add 1-year cache header for static files. React uses content-
 		// based hashes in file names, so when any file is updated, the
 		// url will change causing the browser cached version to be
-		// invalidated.
+		// invalidated. A custom build from the asset dir might not do
+		// that, so the browser has to check every file for changes.
 		var re = regexp.MustCompile(`^/(static|fonts|icons)/.*`)
-		if re.MatchString(req.URL.Path) {
+		switch {
+		case g.cfg.UI.AssetDir != "":
+			resp.Header().Set("Cache-Control", "no-cache")
+
+		case re.MatchString(req.URL.Path):
 			resp.Header().Set("Cache-Control", "max-age=31536000")
 		}
 
@@ -1334,7 +2455,7 @@ func (g *LightningTerminal) startMainWebServer() error {
 		ReadHeaderTimeout: defaultServerTimeout,
 		Handler:           http.HandlerFunc(httpHandler),
 	}
-	httpListener, err := net.Listen("tcp", g.cfg.HTTPSListen)
+	httpListener, err := g.cfg.KeepAlive.Listen(g.cfg.HTTPSListen)
 	if err != nil {
 		return fmt.Errorf("unable to listen on %v: %v",
 			g.cfg.HTTPSListen, err)
@@ -1356,10 +2477,18 @@ func (g *LightningTerminal) startMainWebServer() error {
 		}
 	}()
 
+	// The account portal has its own listener so that it can be exposed
+	// to account holders without exposing anything else.
+	if g.cfg.Portal.Enable {
+		if err := g.startPortalServer(tlsConfig); err != nil {
+			return err
+		}
+	}
+
 	// We only enable an additional HTTP only listener if the user
 	// explicitly sets a value.
 	if g.cfg.HTTPListen != "" {
-		insecureListener, err := net.Listen("tcp", g.cfg.HTTPListen)
+		insecureListener, err := g.cfg.KeepAlive.Listen(g.cfg.HTTPListen)
 		if err != nil {
 			return fmt.Errorf("unable to listen on %v: %v",
 				g.cfg.HTTPListen, err)
@@ -1381,6 +2510,36 @@ func (g *LightningTerminal) startMainWebServer() error {
 	return nil
 }
 
+// startPortalServer starts the HTTPS server of the account portal with the
+// given TLS config. The server only serves the portal, which answers with an
+// error until the portal service is started.
+func (g *LightningTerminal) startPortalServer(tlsConfig *tls.Config) error {
+	g.portalServer = &http.Server{
+		ReadHeaderTimeout: defaultServerTimeout,
+		Handler:           g.portalService,
+	}
+	portalListener, err := g.cfg.KeepAlive.Listen(g.cfg.Portal.Listen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v: %v",
+			g.cfg.Portal.Listen, err)
+	}
+	tlsListener := tls.NewListener(portalListener, tlsConfig)
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		log.Infof("Listening for account portal requests on: %v",
+			tlsListener.Addr())
+		err := g.portalServer.Serve(tlsListener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("account portal server error: %v", err)
+		}
+	}()
+
+	return nil
+}
+
 // createRESTProxy creates a grpc-gateway based REST proxy that takes any call
 // identified as a REST call, converts it to a gRPC request and forwards it to
 // our local main server for further triage/forwarding.
@@ -0,0 +1,99 @@
+package uiflags
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lightninglabs/lightning-terminal/oidc"
+)
+
+var (
+	// namePattern is the pattern a flag name must match.
+	namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	// KnownFlags are the modules of the web UI that can be hidden. They
+	// are always returned so the UI doesn't need to know the defaults.
+	KnownFlags = []string{
+		"accounts", "autopilot", "faraday", "loop", "pool", "sessions",
+	}
+)
+
+// Config holds all config options for the feature flags of the web UI.
+type Config struct {
+	Disable        []string `long:"disable" description:"Hide a module of the web UI from all users, for example pool. Can be specified multiple times."`
+	DisableForRole []string `long:"disableforrole" description:"Hide a module of the web UI from the users of a role in the form role:module, for example readonly:accounts. The roles are admin and readonly. Can be specified multiple times."`
+
+	// settings are the flag values that are set through the config. They
+	// are populated when validating the config.
+	settings []*Setting
+}
+
+// DefaultConfig constructs the default UI feature flag Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure all flag names and roles are valid.
+func (c *Config) Validate() error {
+	c.settings = nil
+	for _, name := range c.Disable {
+		if err := ValidateName(name); err != nil {
+			return fmt.Errorf("invalid uiflags.disable: %v", err)
+		}
+
+		c.settings = append(c.settings, &Setting{
+			Name:   name,
+			Source: SourceConfig,
+		})
+	}
+
+	for _, spec := range c.DisableForRole {
+		role, name, ok := strings.Cut(spec, ":")
+		if !ok {
+			return fmt.Errorf("invalid uiflags.disableforrole %q: "+
+				"must be of the form role:module", spec)
+		}
+
+		if err := ValidateRole(oidc.Role(role)); err != nil {
+			return fmt.Errorf("invalid uiflags.disableforrole: %v",
+				err)
+		}
+
+		if err := ValidateName(name); err != nil {
+			return fmt.Errorf("invalid uiflags.disableforrole: %v",
+				err)
+		}
+
+		c.settings = append(c.settings, &Setting{
+			Name:   name,
+			Role:   oidc.Role(role),
+			Source: SourceConfig,
+		})
+	}
+
+	return nil
+}
+
+// ValidateName checks that the given flag name is well-formed.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("flag name %q must only contain lower case "+
+			"letters, digits and dashes", name)
+	}
+
+	return nil
+}
+
+// ValidateRole checks that the given role is empty, which stands for all
+// roles, or one of the roles of the web UI.
+func ValidateRole(role oidc.Role) error {
+	switch role {
+	case "", oidc.RoleAdmin, oidc.RoleReadOnly:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown role %s, must be %s or %s", role,
+			oidc.RoleAdmin, oidc.RoleReadOnly)
+	}
+}
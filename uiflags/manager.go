@@ -0,0 +1,221 @@
+package uiflags
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lightninglabs/lightning-terminal/oidc"
+)
+
+// ErrNotStarted is returned if the flag manager is used before it was
+// started.
+var ErrNotStarted = errors.New("ui flag manager not started")
+
+// Manager resolves the feature flags of the web UI from the config and the
+// values set through the RPC and notifies subscribers about changes.
+type Manager struct {
+	cfg *Config
+	dir string
+
+	// mu guards the store and the subscribers.
+	mu          sync.Mutex
+	store       *Store
+	subscribers map[chan struct{}]struct{}
+}
+
+// NewManager creates a new flag manager that stores the values set through
+// the RPC in the given directory.
+func NewManager(cfg *Config, dir string) *Manager {
+	return &Manager{
+		cfg:         cfg,
+		dir:         dir,
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Start opens the flag store.
+func (m *Manager) Start() error {
+	store, err := NewStore(m.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open ui flag store: %v", err)
+	}
+
+	m.mu.Lock()
+	m.store = store
+	m.mu.Unlock()
+
+	return nil
+}
+
+// Stop closes the flag store and ends all subscriptions.
+func (m *Manager) Stop() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for sub := range m.subscribers {
+		close(sub)
+		delete(m.subscribers, sub)
+	}
+
+	if m.store == nil {
+		return nil
+	}
+
+	err := m.store.Close()
+	m.store = nil
+
+	return err
+}
+
+// Settings returns the values set through the config followed by the values
+// set through the RPC.
+func (m *Manager) Settings() ([]*Setting, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.settings()
+}
+
+// settings returns all settings. The caller must hold the mutex.
+func (m *Manager) settings() ([]*Setting, error) {
+	if m.store == nil {
+		return nil, ErrNotStarted
+	}
+
+	stored, err := m.store.Settings()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]*Setting, 0, len(m.cfg.settings)+len(stored))
+	settings = append(settings, m.cfg.settings...)
+
+	return append(settings, stored...), nil
+}
+
+// Flags returns whether each module is shown to the users of the given role.
+// A value for the role takes precedence over a value for all roles, and a
+// value set through the RPC takes precedence over the config. Modules without
+// a value are shown.
+func (m *Manager) Flags(role oidc.Role) (map[string]bool, error) {
+	m.mu.Lock()
+	settings, err := m.settings()
+	m.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]bool)
+	for _, name := range KnownFlags {
+		flags[name] = true
+	}
+
+	rank := func(s *Setting) int {
+		r := 0
+		if s.Role != "" {
+			r += 2
+		}
+		if s.Source == SourceRPC {
+			r++
+		}
+
+		return r
+	}
+
+	ranks := make(map[string]int)
+	for _, s := range settings {
+		if s.Role != "" && s.Role != role {
+			continue
+		}
+
+		r := rank(s)
+		if prev, ok := ranks[s.Name]; ok && prev > r {
+			continue
+		}
+
+		ranks[s.Name] = r
+		flags[s.Name] = s.Enabled
+	}
+
+	return flags, nil
+}
+
+// SetFlag stores the given value and notifies all subscribers.
+func (m *Manager) SetFlag(setting *Setting) error {
+	if err := ValidateName(setting.Name); err != nil {
+		return err
+	}
+
+	if err := ValidateRole(setting.Role); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return ErrNotStarted
+	}
+
+	if err := m.store.SetSetting(setting); err != nil {
+		return err
+	}
+	m.notify()
+
+	return nil
+}
+
+// RemoveFlag removes the value of the given flag and role that was set through
+// the RPC and notifies all subscribers.
+func (m *Manager) RemoveFlag(name string, role oidc.Role) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return ErrNotStarted
+	}
+
+	if err := m.store.RemoveSetting(name, role); err != nil {
+		return err
+	}
+	m.notify()
+
+	return nil
+}
+
+// Subscribe returns a channel that receives a value whenever a flag changes.
+// The channel is closed when the manager stops. The returned function must be
+// called to cancel the subscription.
+func (m *Manager) Subscribe() (<-chan struct{}, func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := make(chan struct{}, 1)
+	m.subscribers[sub] = struct{}{}
+
+	cancel := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if _, ok := m.subscribers[sub]; ok {
+			delete(m.subscribers, sub)
+			close(sub)
+		}
+	}
+
+	return sub, cancel
+}
+
+// notify signals all subscribers that the flags changed. The caller must hold
+// the mutex.
+func (m *Manager) notify() {
+	for sub := range m.subscribers {
+		// A subscriber that hasn't picked up the last change yet will
+		// read the current flags anyway.
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+}
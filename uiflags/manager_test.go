@@ -0,0 +1,113 @@
+package uiflags
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/lightning-terminal/oidc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFlagPrecedence makes sure values for a role take precedence over values
+// for all roles and values set through the RPC take precedence over the
+// config.
+func TestFlagPrecedence(t *testing.T) {
+	cfg := &Config{
+		Disable:        []string{"pool", "loop"},
+		DisableForRole: []string{"readonly:accounts"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	mgr := NewManager(cfg, t.TempDir())
+	require.NoError(t, mgr.Start())
+	t.Cleanup(func() {
+		require.NoError(t, mgr.Stop())
+	})
+
+	flags, err := mgr.Flags(oidc.RoleAdmin)
+	require.NoError(t, err)
+	require.False(t, flags["pool"])
+	require.False(t, flags["loop"])
+	require.True(t, flags["accounts"])
+
+	flags, err = mgr.Flags(oidc.RoleReadOnly)
+	require.NoError(t, err)
+	require.False(t, flags["accounts"])
+
+	// Showing pool through the RPC overrides the config for all roles.
+	require.NoError(t, mgr.SetFlag(&Setting{
+		Name:    "pool",
+		Enabled: true,
+		Source:  SourceRPC,
+	}))
+
+	// Hiding it again for the read-only role wins over the value for all
+	// roles.
+	require.NoError(t, mgr.SetFlag(&Setting{
+		Name:   "pool",
+		Role:   oidc.RoleReadOnly,
+		Source: SourceRPC,
+	}))
+
+	flags, err = mgr.Flags(oidc.RoleAdmin)
+	require.NoError(t, err)
+	require.True(t, flags["pool"])
+
+	flags, err = mgr.Flags(oidc.RoleReadOnly)
+	require.NoError(t, err)
+	require.False(t, flags["pool"])
+
+	// Removing the role specific value falls back to the value for all
+	// roles.
+	require.NoError(t, mgr.RemoveFlag("pool", oidc.RoleReadOnly))
+	require.ErrorIs(
+		t, mgr.RemoveFlag("pool", oidc.RoleReadOnly),
+		ErrSettingNotFound,
+	)
+
+	flags, err = mgr.Flags(oidc.RoleReadOnly)
+	require.NoError(t, err)
+	require.True(t, flags["pool"])
+
+	settings, err := mgr.Settings()
+	require.NoError(t, err)
+	require.Len(t, settings, 4)
+	require.Equal(t, SourceRPC, settings[3].Source)
+}
+
+// TestSubscribe makes sure subscribers are notified about changes and their
+// channel is closed when the manager stops.
+func TestSubscribe(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	mgr := NewManager(cfg, t.TempDir())
+	require.NoError(t, mgr.Start())
+
+	updates, cancel := mgr.Subscribe()
+	defer cancel()
+
+	require.NoError(t, mgr.SetFlag(&Setting{
+		Name:   "loop",
+		Source: SourceRPC,
+	}))
+
+	_, ok := <-updates
+	require.True(t, ok)
+
+	require.NoError(t, mgr.Stop())
+
+	_, ok = <-updates
+	require.False(t, ok)
+}
+
+// TestConfigValidate makes sure invalid flag names and roles are rejected.
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{DisableForRole: []string{"root:pool"}}
+	require.Error(t, cfg.Validate())
+
+	cfg = &Config{DisableForRole: []string{"pool"}}
+	require.Error(t, cfg.Validate())
+
+	cfg = &Config{Disable: []string{"Pool"}}
+	require.Error(t, cfg.Validate())
+}
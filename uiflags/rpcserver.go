@@ -0,0 +1,171 @@
+package uiflags
+
+import (
+	"context"
+
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightninglabs/lightning-terminal/oidc"
+)
+
+// RoleFunc returns the role of the caller of an RPC.
+type RoleFunc func(ctx context.Context) oidc.Role
+
+// RPCServer is the main server that implements the UIFlags gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedUIFlagsServer
+
+	mgr        *Manager
+	callerRole RoleFunc
+}
+
+// NewRPCServer returns a new RPC server for the given flag manager. The
+// callerRole function is used to pick the flags of the caller if a request
+// doesn't name a role.
+func NewRPCServer(mgr *Manager, callerRole RoleFunc) *RPCServer {
+	return &RPCServer{
+		mgr:        mgr,
+		callerRole: callerRole,
+	}
+}
+
+// GetUIFlags returns whether each module of the web UI is shown to the users
+// of a role.
+func (s *RPCServer) GetUIFlags(ctx context.Context,
+	req *litrpc.GetUIFlagsRequest) (*litrpc.UIFlagValues, error) {
+
+	role, err := s.role(ctx, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.flagValues(role)
+}
+
+// SubscribeUIFlags returns the flags of a role right away and again every time
+// a flag changes.
+func (s *RPCServer) SubscribeUIFlags(req *litrpc.SubscribeUIFlagsRequest,
+	stream litrpc.UIFlags_SubscribeUIFlagsServer) error {
+
+	ctx := stream.Context()
+	role, err := s.role(ctx, req.Role)
+	if err != nil {
+		return err
+	}
+
+	// We subscribe before sending the current flags so we don't miss a
+	// change that happens in between.
+	updates, cancel := s.mgr.Subscribe()
+	defer cancel()
+
+	for {
+		values, err := s.flagValues(role)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(values); err != nil {
+			return err
+		}
+
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				return ErrNotStarted
+			}
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ListUIFlagSettings lists all flag values that are set through the config or
+// the RPC.
+func (s *RPCServer) ListUIFlagSettings(_ context.Context,
+	_ *litrpc.ListUIFlagSettingsRequest) (*litrpc.ListUIFlagSettingsResponse,
+	error) {
+
+	settings, err := s.mgr.Settings()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListUIFlagSettingsResponse{
+		Settings: make([]*litrpc.UIFlagSetting, len(settings)),
+	}
+	for i, setting := range settings {
+		resp.Settings[i] = &litrpc.UIFlagSetting{
+			Name:    setting.Name,
+			Role:    string(setting.Role),
+			Enabled: setting.Enabled,
+			Source:  string(setting.Source),
+		}
+	}
+
+	return resp, nil
+}
+
+// SetUIFlag sets the value of a flag for all users or for the users of a role.
+func (s *RPCServer) SetUIFlag(_ context.Context,
+	req *litrpc.SetUIFlagRequest) (*litrpc.SetUIFlagResponse, error) {
+
+	err := s.mgr.SetFlag(&Setting{
+		Name:    req.Name,
+		Role:    oidc.Role(req.Role),
+		Enabled: req.Enabled,
+		Source:  SourceRPC,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("UI flag %s for role %q set to %v", req.Name, req.Role,
+		req.Enabled)
+
+	return &litrpc.SetUIFlagResponse{}, nil
+}
+
+// RemoveUIFlag removes a value set through the RPC.
+func (s *RPCServer) RemoveUIFlag(_ context.Context,
+	req *litrpc.RemoveUIFlagRequest) (*litrpc.RemoveUIFlagResponse, error) {
+
+	err := s.mgr.RemoveFlag(req.Name, oidc.Role(req.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("UI flag %s for role %q removed", req.Name, req.Role)
+
+	return &litrpc.RemoveUIFlagResponse{}, nil
+}
+
+// role returns the requested role or, if none was requested, the role of the
+// caller.
+func (s *RPCServer) role(ctx context.Context, requested string) (oidc.Role,
+	error) {
+
+	if requested == "" {
+		return s.callerRole(ctx), nil
+	}
+
+	role := oidc.Role(requested)
+	if err := ValidateRole(role); err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+// flagValues returns the flags of the given role in their RPC form.
+func (s *RPCServer) flagValues(role oidc.Role) (*litrpc.UIFlagValues, error) {
+	flags, err := s.mgr.Flags(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &litrpc.UIFlagValues{
+		Role:  string(role),
+		Flags: flags,
+	}, nil
+}
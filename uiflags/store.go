@@ -0,0 +1,173 @@
+package uiflags
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/oidc"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the UI feature flag database.
+	DBFilename = "uiflags.db"
+
+	// dbFilePermission is the default permission the UI feature flag
+	// database file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The flag values set through the RPC are stored in the following
+	structure in the db:
+
+	flags -> role:name -> json encoded Setting
+*/
+
+var (
+	// flagsBucketKey is the key of the top level bucket holding all flag
+	// values.
+	flagsBucketKey = []byte("flags")
+
+	// ErrSettingNotFound is returned when no value is stored for a flag.
+	ErrSettingNotFound = errors.New("ui flag setting not found")
+)
+
+// Source is where the value of a flag was set.
+type Source string
+
+const (
+	// SourceConfig is used for values set through the config.
+	SourceConfig Source = "config"
+
+	// SourceRPC is used for values set through the RPC.
+	SourceRPC Source = "rpc"
+)
+
+// Setting is the value of a flag for all users or for the users of a role.
+type Setting struct {
+	// Name is the name of the flag.
+	Name string `json:"name"`
+
+	// Role is the role the value applies to. If empty, it applies to all
+	// roles.
+	Role oidc.Role `json:"role"`
+
+	// Enabled is true if the module is shown.
+	Enabled bool `json:"enabled"`
+
+	// Source is where the value was set.
+	Source Source `json:"-"`
+}
+
+// key returns the db key of the setting.
+func (s *Setting) key() []byte {
+	return settingKey(s.Name, s.Role)
+}
+
+// settingKey returns the db key of the given flag and role.
+func settingKey(name string, role oidc.Role) []byte {
+	return []byte(string(role) + ":" + name)
+}
+
+// Store is a bolt-backed persistent store of the flag values set through the
+// RPC.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the UI feature flag store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(flagsBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// SetSetting stores the given setting, replacing any setting for the same flag
+// and role.
+func (s *Store) SetSetting(setting *Setting) error {
+	b, err := json.Marshal(setting)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(flagsBucketKey).Put(setting.key(), b)
+	})
+}
+
+// Settings returns all stored settings ordered by role and name.
+func (s *Store) Settings() ([]*Setting, error) {
+	var settings []*Setting
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(flagsBucketKey)
+
+		return bucket.ForEach(func(_, b []byte) error {
+			var setting Setting
+			if err := json.Unmarshal(b, &setting); err != nil {
+				return err
+			}
+			setting.Source = SourceRPC
+
+			settings = append(settings, &setting)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// RemoveSetting removes the stored setting of the given flag and role.
+func (s *Store) RemoveSetting(name string, role oidc.Role) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(flagsBucketKey)
+
+		key := settingKey(name, role)
+		if bucket.Get(key) == nil {
+			return ErrSettingNotFound
+		}
+
+		return bucket.Delete(key)
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,127 @@
+package watchdog
+
+import (
+	"time"
+)
+
+// AlertType is the type of condition an alert was raised for.
+type AlertType uint8
+
+const (
+	// AlertTypeStuckHTLC is raised if an HTLC is pending on a channel for
+	// longer than the configured threshold.
+	AlertTypeStuckHTLC AlertType = 1
+
+	// AlertTypeHTLCExpiry is raised if a pending HTLC is close to its
+	// expiry height.
+	AlertTypeHTLCExpiry AlertType = 2
+
+	// AlertTypeStuckPayment is raised if an account payment is in flight
+	// for longer than the configured threshold.
+	AlertTypeStuckPayment AlertType = 3
+
+	// AlertTypeAccountDrain is raised if an account attempts to send a
+	// large part of its balance within the anomaly detection window.
+	AlertTypeAccountDrain AlertType = 4
+
+	// AlertTypeAccountFailedPayments is raised if many payments of an
+	// account fail within the anomaly detection window.
+	AlertTypeAccountFailedPayments AlertType = 5
+
+	// AlertTypeAccountNewDestinations is raised if an account pays many
+	// destinations it didn't pay before within the anomaly detection
+	// window.
+	AlertTypeAccountNewDestinations AlertType = 6
+
+	// AlertTypeMailboxDegraded is raised if the connectivity to a mailbox
+	// server that LNC sessions connect through is degraded.
+	AlertTypeMailboxDegraded AlertType = 7
+)
+
+// String returns a human-readable name of the alert type.
+func (t AlertType) String() string {
+	switch t {
+	case AlertTypeStuckHTLC:
+		return "stuck_htlc"
+
+	case AlertTypeHTLCExpiry:
+		return "htlc_expiry"
+
+	case AlertTypeStuckPayment:
+		return "stuck_payment"
+
+	case AlertTypeAccountDrain:
+		return "account_drain"
+
+	case AlertTypeAccountFailedPayments:
+		return "account_failed_payments"
+
+	case AlertTypeAccountNewDestinations:
+		return "account_new_destinations"
+
+	case AlertTypeMailboxDegraded:
+		return "mailbox_degraded"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Alert is raised by the watchdog once a pending HTLC or an in-flight payment
+// exceeds one of the configured thresholds, an account behaves unusually or
+// the connectivity to a mailbox server is degraded. It is resolved once the
+// condition no longer holds.
+type Alert struct {
+	// ID uniquely identifies the alert. It is derived from the type of
+	// the alert and the HTLC or payment it is about, so the same condition
+	// always results in the same ID.
+	ID string
+
+	// Type is the type of the alert.
+	Type AlertType
+
+	// Message is a human-readable description of the alert.
+	Message string
+
+	// CreatedAt is the time at which the alert was raised.
+	CreatedAt time.Time
+
+	// ResolvedAt is the time at which the alert was resolved. It is zero
+	// while the alert is active.
+	ResolvedAt time.Time
+
+	// ChannelPoint is the channel the HTLC is pending on. It is empty for
+	// payment alerts.
+	ChannelPoint string
+
+	// PaymentHash is the hex encoded payment hash of the HTLC or payment.
+	PaymentHash string
+
+	// AccountID is the hex encoded ID of the account the payment belongs
+	// to. It is empty for HTLC alerts.
+	AccountID string
+
+	// AmountMsat is the amount of the HTLC or payment.
+	AmountMsat uint64
+
+	// AccountFrozen is true if the account of an anomaly alert was frozen
+	// pending review.
+	AccountFrozen bool
+}
+
+// eventType is the type of an alert event that is sent to the webhook.
+type eventType string
+
+const (
+	// eventRaised is sent when an alert is raised.
+	eventRaised eventType = "raised"
+
+	// eventResolved is sent when an alert is resolved.
+	eventResolved eventType = "resolved"
+)
+
+// event is a change of an alert's state.
+type event struct {
+	eventType eventType
+	alert     Alert
+}
@@ -0,0 +1,336 @@
+package watchdog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing/route"
+)
+
+// AccountFreezer freezes accounts so they can't send payments anymore.
+type AccountFreezer interface {
+	// FreezeAccount freezes the given account with the given reason.
+	FreezeAccount(id accounts.AccountID,
+		reason string) (*accounts.OffChainBalanceAccount, error)
+}
+
+// paymentAttempt is a payment an account tried to send.
+type paymentAttempt struct {
+	// time is the time of the attempt.
+	time time.Time
+
+	// amount is the amount of the payment including the fee limit.
+	amount lnwire.MilliSatoshi
+
+	// balance is the balance of the account at the time of the attempt.
+	balance int64
+}
+
+// finding is an unusual behavior the detector found for an account.
+type finding struct {
+	// message is a human-readable description of the finding.
+	message string
+
+	// amount is the amount that is relevant for the finding, if any.
+	amount lnwire.MilliSatoshi
+
+	// lastSeen is the last time the behavior was observed.
+	lastSeen time.Time
+
+	// frozen is true if the account was frozen because of the finding.
+	frozen bool
+}
+
+// accountActivity is the recent payment activity of an account.
+type accountActivity struct {
+	// attempts are the payment attempts within the window, oldest first.
+	attempts []paymentAttempt
+
+	// failures are the times of the failed payments within the window,
+	// oldest first.
+	failures []time.Time
+
+	// newDestinations are the times at which a destination was paid that
+	// wasn't paid before within the window, oldest first.
+	newDestinations []time.Time
+
+	// knownDestinations are all destinations the account paid since litd
+	// started.
+	knownDestinations map[route.Vertex]struct{}
+
+	// findings are the unusual behaviors found for the account by their
+	// alert type.
+	findings map[AlertType]*finding
+}
+
+// prune removes all activity that happened before the given time.
+func (a *accountActivity) prune(cutoff time.Time) {
+	for len(a.attempts) > 0 && a.attempts[0].time.Before(cutoff) {
+		a.attempts = a.attempts[1:]
+	}
+	a.failures = pruneTimes(a.failures, cutoff)
+	a.newDestinations = pruneTimes(a.newDestinations, cutoff)
+
+	for alertType, f := range a.findings {
+		if f.lastSeen.Before(cutoff) {
+			delete(a.findings, alertType)
+		}
+	}
+}
+
+// reset forgets the activity within the window, but keeps the known
+// destinations and the findings.
+func (a *accountActivity) reset() {
+	a.attempts = nil
+	a.failures = nil
+	a.newDestinations = nil
+}
+
+// pruneTimes removes all times before the cutoff from the given sorted slice.
+func pruneTimes(times []time.Time, cutoff time.Time) []time.Time {
+	for len(times) > 0 && times[0].Before(cutoff) {
+		times = times[1:]
+	}
+
+	return times
+}
+
+// anomalyDetector watches the payment activity of accounts and finds unusual
+// behavior, like sudden attempts to drain an account, many failed payments or
+// payments to many new destinations.
+type anomalyDetector struct {
+	cfg *AnomalyConfig
+
+	// freezer is used to freeze accounts that behave unusually if
+	// auto-freeze is enabled. It is nil until the watchdog is started.
+	freezer AccountFreezer
+
+	// onFinding is called without holding mu once a new finding was
+	// made.
+	onFinding func()
+
+	// now returns the current time.
+	now func() time.Time
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// activity holds the recent activity of each account.
+	activity map[accounts.AccountID]*accountActivity
+}
+
+// newAnomalyDetector creates a new detector for the given config.
+func newAnomalyDetector(cfg *AnomalyConfig,
+	onFinding func()) *anomalyDetector {
+
+	return &anomalyDetector{
+		cfg:       cfg,
+		onFinding: onFinding,
+		now:       time.Now,
+		activity:  make(map[accounts.AccountID]*accountActivity),
+	}
+}
+
+// accountActivity returns the activity of the given account with everything
+// older than the window removed. The caller must hold mu.
+func (d *anomalyDetector) accountActivity(id accounts.AccountID,
+	now time.Time) *accountActivity {
+
+	a, ok := d.activity[id]
+	if !ok {
+		a = &accountActivity{
+			knownDestinations: make(map[route.Vertex]struct{}),
+			findings:          make(map[AlertType]*finding),
+		}
+		d.activity[id] = a
+	}
+	a.prune(now.Add(-d.cfg.Window))
+
+	return a
+}
+
+// paymentAttempted records a payment attempt of the given account and freezes
+// the account if it behaves unusually and auto-freeze is enabled.
+func (d *anomalyDetector) paymentAttempted(id accounts.AccountID,
+	amount lnwire.MilliSatoshi, dest *route.Vertex, balance int64) {
+
+	now := d.now()
+
+	d.mu.Lock()
+	a := d.accountActivity(id, now)
+	a.attempts = append(a.attempts, paymentAttempt{
+		time:    now,
+		amount:  amount,
+		balance: balance,
+	})
+
+	if dest != nil {
+		if _, ok := a.knownDestinations[*dest]; !ok {
+			a.knownDestinations[*dest] = struct{}{}
+			a.newDestinations = append(a.newDestinations, now)
+		}
+	}
+
+	found := d.evaluate(id, a, now)
+	freeze := len(found) > 0 && d.cfg.AutoFreeze && d.freezer != nil
+	if freeze {
+		for _, f := range a.findings {
+			f.frozen = true
+		}
+
+		// The account starts over once it's unfrozen after a review.
+		a.reset()
+	}
+	d.mu.Unlock()
+
+	if freeze {
+		reason := "anomaly detection: " + strings.Join(found, "; ")
+		if _, err := d.freezer.FreezeAccount(id, reason); err != nil {
+			log.Errorf("Unable to freeze account %x: %v", id[:],
+				err)
+		}
+	}
+
+	if len(found) > 0 && d.onFinding != nil {
+		d.onFinding()
+	}
+}
+
+// paymentFailed records a failed payment of the given account.
+func (d *anomalyDetector) paymentFailed(id accounts.AccountID) {
+	now := d.now()
+
+	d.mu.Lock()
+	a := d.accountActivity(id, now)
+	a.failures = append(a.failures, now)
+	found := d.evaluate(id, a, now)
+	d.mu.Unlock()
+
+	if len(found) > 0 && d.onFinding != nil {
+		d.onFinding()
+	}
+}
+
+// evaluate updates the findings of the given account and returns the messages
+// of the thresholds that are currently exceeded. The caller must hold mu.
+func (d *anomalyDetector) evaluate(id accounts.AccountID, a *accountActivity,
+	now time.Time) []string {
+
+	var found []string
+	record := func(alertType AlertType, message string,
+		amount lnwire.MilliSatoshi) {
+
+		f, ok := a.findings[alertType]
+		if !ok {
+			f = &finding{}
+			a.findings[alertType] = f
+		}
+		f.message = message
+		f.amount = amount
+		f.lastSeen = now
+		found = append(found, message)
+	}
+
+	// The balance before the oldest attempt within the window is the
+	// balance the attempts are compared against.
+	if d.cfg.DrainRatio > 0 && len(a.attempts) > 0 &&
+		a.attempts[0].balance > 0 {
+
+		var attempted lnwire.MilliSatoshi
+		for _, attempt := range a.attempts {
+			attempted += attempt.amount
+		}
+
+		balance := a.attempts[0].balance
+		if float64(attempted) >= d.cfg.DrainRatio*float64(balance) {
+			record(AlertTypeAccountDrain, fmt.Sprintf("Account %x "+
+				"attempted to send %v within %v, which is "+
+				"%.0f%% of its balance of %v", id[:],
+				attempted, d.cfg.Window,
+				100*float64(attempted)/float64(balance),
+				lnwire.MilliSatoshi(balance)), attempted)
+		}
+	}
+
+	failures := len(a.failures)
+	if d.cfg.FailedPayments > 0 && failures >= int(d.cfg.FailedPayments) {
+		record(AlertTypeAccountFailedPayments, fmt.Sprintf("%d "+
+			"payments of account %x failed within %v", failures,
+			id[:], d.cfg.Window), 0)
+	}
+
+	newDests := len(a.newDestinations)
+	if d.cfg.NewDestinations > 0 &&
+		newDests >= int(d.cfg.NewDestinations) {
+
+		record(AlertTypeAccountNewDestinations, fmt.Sprintf("Account "+
+			"%x paid %d new destinations within %v", id[:],
+			newDests, d.cfg.Window), 0)
+	}
+
+	return found
+}
+
+// firing adds an alert to the given map for each finding that was made within
+// the window before the given time.
+func (d *anomalyDetector) firing(firing map[string]*Alert, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, a := range d.activity {
+		a.prune(now.Add(-d.cfg.Window))
+
+		for alertType, f := range a.findings {
+			message := f.message
+			if f.frozen {
+				message += ". The account was frozen pending " +
+					"review."
+			}
+
+			alertID := fmt.Sprintf("%v/%x", alertType, id[:])
+			firing[alertID] = &Alert{
+				Type:          alertType,
+				Message:       message,
+				AccountID:     hex.EncodeToString(id[:]),
+				AmountMsat:    uint64(f.amount),
+				AccountFrozen: f.frozen,
+			}
+		}
+	}
+}
+
+// PaymentAttempted is called when the given account is about to send a
+// payment. If anomaly detection is enabled, the attempt is evaluated and the
+// account is frozen if it behaves unusually and auto-freeze is enabled.
+//
+// NOTE: This is part of the accounts.PaymentObserver interface.
+func (w *Watchdog) PaymentAttempted(id accounts.AccountID,
+	amount lnwire.MilliSatoshi, dest *route.Vertex, balance int64) {
+
+	if w.anomalies == nil || !w.started.Load() {
+		return
+	}
+
+	w.anomalies.paymentAttempted(id, amount, dest, balance)
+}
+
+// PaymentFailed is called once a payment of the given account failed.
+//
+// NOTE: This is part of the accounts.PaymentObserver interface.
+func (w *Watchdog) PaymentFailed(id accounts.AccountID, _ lntypes.Hash) {
+	if w.anomalies == nil || !w.started.Load() {
+		return
+	}
+
+	w.anomalies.paymentFailed(id)
+}
+
+// A compile-time check to make sure the watchdog implements the
+// accounts.PaymentObserver interface.
+var _ accounts.PaymentObserver = (*Watchdog)(nil)
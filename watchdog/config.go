@@ -0,0 +1,112 @@
+package watchdog
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// defaultInterval is the default interval in which the HTLCs and
+	// payments are checked.
+	defaultInterval = time.Minute
+
+	// defaultHTLCThreshold is the default time after which a pending HTLC
+	// is considered stuck.
+	defaultHTLCThreshold = time.Hour
+
+	// defaultHTLCExpiryBlocks is the default number of blocks before its
+	// expiry at which a pending HTLC raises an alert.
+	defaultHTLCExpiryBlocks = 24
+
+	// defaultPaymentThreshold is the default time after which an in-flight
+	// account payment is considered stuck.
+	defaultPaymentThreshold = time.Hour
+
+	// defaultAnomalyWindow is the default window within which the payment
+	// activity of an account is evaluated.
+	defaultAnomalyWindow = time.Hour
+
+	// defaultAnomalyDrainRatio is the default share of its balance an
+	// account may attempt to send within the window.
+	defaultAnomalyDrainRatio = 0.8
+
+	// defaultAnomalyFailedPayments is the default number of failed
+	// payments within the window that raise an alert.
+	defaultAnomalyFailedPayments = 10
+
+	// defaultAnomalyNewDestinations is the default number of new
+	// destinations within the window that raise an alert.
+	defaultAnomalyNewDestinations = 5
+)
+
+// Config holds all config options for the HTLC watchdog.
+type Config struct {
+	Disable          bool          `long:"disable" description:"Disable the watchdog that raises alerts for stuck HTLCs and account payments."`
+	Interval         time.Duration `long:"interval" description:"The interval in which pending HTLCs and in-flight account payments are checked."`
+	HTLCThreshold    time.Duration `long:"htlcthreshold" description:"The time after which a pending HTLC raises an alert. Set to 0 to disable."`
+	HTLCExpiryBlocks uint32        `long:"htlcexpiryblocks" description:"The number of blocks before its expiry at which a pending HTLC raises an alert. Set to 0 to disable."`
+	PaymentThreshold time.Duration `long:"paymentthreshold" description:"The time after which an in-flight account payment raises an alert. Set to 0 to disable."`
+	WebhookURL       string        `long:"webhookurl" description:"If set, an event is POSTed as JSON to this URL whenever an alert is raised or resolved."`
+
+	Anomaly *AnomalyConfig `group:"anomaly" namespace:"anomaly"`
+}
+
+// AnomalyConfig holds the options for detecting unusual account activity.
+type AnomalyConfig struct {
+	Enable          bool          `long:"enable" description:"Raise alerts for accounts that behave unusually."`
+	Window          time.Duration `long:"window" description:"The window within which the payment activity of an account is evaluated."`
+	DrainRatio      float64       `long:"drainratio" description:"The share of its balance an account may attempt to send within the window, for example 0.8 for 80%. Set to 0 to disable."`
+	FailedPayments  uint32        `long:"failedpayments" description:"The number of failed payments of an account within the window that raise an alert. Set to 0 to disable."`
+	NewDestinations uint32        `long:"newdestinations" description:"The number of destinations an account didn't pay before that it may pay within the window before an alert is raised. Set to 0 to disable."`
+	AutoFreeze      bool          `long:"autofreeze" description:"Freeze accounts that behave unusually pending review, so they can't send payments until they are unfrozen."`
+}
+
+// DefaultConfig constructs the default watchdog Config struct.
+func DefaultConfig() *Config {
+	return &Config{
+		Interval:         defaultInterval,
+		HTLCThreshold:    defaultHTLCThreshold,
+		HTLCExpiryBlocks: defaultHTLCExpiryBlocks,
+		PaymentThreshold: defaultPaymentThreshold,
+		Anomaly: &AnomalyConfig{
+			Window:          defaultAnomalyWindow,
+			DrainRatio:      defaultAnomalyDrainRatio,
+			FailedPayments:  defaultAnomalyFailedPayments,
+			NewDestinations: defaultAnomalyNewDestinations,
+		},
+	}
+}
+
+// Validate makes sure the config is sane.
+func (c *Config) Validate() error {
+	if c.Disable {
+		return nil
+	}
+
+	if c.Interval < time.Second {
+		return fmt.Errorf("the watchdog interval must be at least " +
+			"one second")
+	}
+
+	if c.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(c.WebhookURL); err != nil {
+			return fmt.Errorf("invalid watchdog webhook URL: %v",
+				err)
+		}
+	}
+
+	if c.Anomaly.Enable {
+		if c.Anomaly.Window < time.Minute {
+			return fmt.Errorf("the anomaly detection window must " +
+				"be at least one minute")
+		}
+
+		if c.Anomaly.DrainRatio < 0 || c.Anomaly.DrainRatio > 1 {
+			return fmt.Errorf("the anomaly drain ratio must be " +
+				"between 0 and 1")
+		}
+	}
+
+	return nil
+}
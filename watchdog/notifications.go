@@ -0,0 +1,221 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/inbox"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// inboxSource is the source of the inbox notifications of the watchdog.
+const inboxSource = "watchdog"
+
+// AccountNotifications are the notification preferences of an account. They
+// decide which of the account's alerts are delivered and to which webhook, so
+// that the alerts of busy accounts don't flood the operator's webhook.
+type AccountNotifications struct {
+	// AccountID is the ID of the account the preferences belong to.
+	AccountID accounts.AccountID `json:"account_id"`
+
+	// WebhookURL is the URL the alerts of the account are POSTed to
+	// instead of the watchdog's webhook. If empty, the alerts are sent to
+	// the watchdog's webhook.
+	WebhookURL string `json:"webhook_url"`
+
+	// AlertTypes are the types of the alerts of the account that are
+	// delivered. If empty, alerts of all types are delivered.
+	AlertTypes []AlertType `json:"alert_types"`
+
+	// MinAmount is the minimum amount of an alert of the account to be
+	// delivered. Alerts that don't refer to an amount are always
+	// delivered.
+	MinAmount lnwire.MilliSatoshi `json:"min_amount"`
+}
+
+// validate makes sure the preferences are sane.
+func (n *AccountNotifications) validate() error {
+	if n.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(n.WebhookURL); err != nil {
+			return fmt.Errorf("invalid webhook URL: %v", err)
+		}
+	}
+
+	for _, t := range n.AlertTypes {
+		switch t {
+		case AlertTypeStuckPayment, AlertTypeAccountDrain,
+			AlertTypeAccountFailedPayments,
+			AlertTypeAccountNewDestinations:
+
+		default:
+			return fmt.Errorf("alert type %v is not raised for "+
+				"accounts", t)
+		}
+	}
+
+	return nil
+}
+
+// wants returns true if the given alert of the account should be delivered.
+func (n *AccountNotifications) wants(a *Alert) bool {
+	if len(n.AlertTypes) > 0 {
+		found := false
+		for _, t := range n.AlertTypes {
+			if t == a.Type {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return a.AmountMsat == 0 || a.AmountMsat >= uint64(n.MinAmount)
+}
+
+// SetAccountNotifications sets the notification preferences of an account,
+// replacing its previous preferences.
+func (w *Watchdog) SetAccountNotifications(n *AccountNotifications) error {
+	if !w.started.Load() {
+		return ErrNotStarted
+	}
+
+	if err := n.validate(); err != nil {
+		return err
+	}
+
+	if w.accounts != nil {
+		if _, err := w.accounts.Account(n.AccountID); err != nil {
+			return err
+		}
+	}
+
+	return w.store.SetNotifications(n)
+}
+
+// AccountNotifications returns the notification preferences of all accounts
+// that have any.
+func (w *Watchdog) AccountNotifications() ([]*AccountNotifications, error) {
+	if !w.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	return w.store.AllNotifications()
+}
+
+// RemoveAccountNotifications removes the notification preferences of an
+// account, so that all its alerts are delivered to the watchdog's webhook
+// again.
+func (w *Watchdog) RemoveAccountNotifications(id accounts.AccountID) error {
+	if !w.started.Load() {
+		return ErrNotStarted
+	}
+
+	return w.store.RemoveNotifications(id)
+}
+
+// notifierFor returns the notifier the given alert is delivered to, or nil if
+// it shouldn't be delivered at all. The notification preferences of the
+// alert's account take precedence over the watchdog's webhook.
+func (w *Watchdog) notifierFor(a *Alert) (*webhookNotifier, error) {
+	if a.AccountID == "" {
+		return w.notifier, nil
+	}
+
+	id, err := accounts.ParseAccountID(a.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := w.store.Notifications(*id)
+	switch {
+	case errors.Is(err, ErrNotificationsNotFound):
+		return w.notifier, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	if !n.wants(a) {
+		return nil, nil
+	}
+
+	if n.WebhookURL != "" {
+		return newWebhookNotifier(n.WebhookURL), nil
+	}
+
+	return w.notifier, nil
+}
+
+// deliver sends the given event to the notifier of its alert, if any.
+func (w *Watchdog) deliver(ctx context.Context, e event) {
+	notifier, err := w.notifierFor(&e.alert)
+	if err != nil {
+		log.Errorf("Unable to get notifier of alert %s: %v", e.alert.ID,
+			err)
+		return
+	}
+
+	if notifier == nil {
+		return
+	}
+
+	if err := notifier.notify(ctx, e); err != nil {
+		log.Errorf("Unable to deliver alert %s to %v: %v", e.alert.ID,
+			notifier, err)
+	}
+}
+
+// addToInbox adds a notification for the given event to the inbox of the web
+// UI, if any. Its payload is the event that is also sent to the webhook.
+func (w *Watchdog) addToInbox(e event) {
+	w.mu.Lock()
+	ib := w.inbox
+	w.mu.Unlock()
+
+	if ib == nil {
+		return
+	}
+
+	payload, err := marshalEvent(e)
+	if err != nil {
+		log.Errorf("Unable to marshal alert %s: %v", e.alert.ID, err)
+		return
+	}
+
+	n := &inbox.Notification{
+		Severity: inboxSeverity(e),
+		Source:   inboxSource,
+		Message:  e.alert.Message,
+		Payload:  payload,
+	}
+	if e.eventType == eventResolved {
+		n.Message = fmt.Sprintf("Resolved: %s", e.alert.Message)
+	}
+
+	if err := ib.AddNotification(n); err != nil {
+		log.Errorf("Unable to add alert %s to inbox: %v", e.alert.ID,
+			err)
+	}
+}
+
+// inboxSeverity returns the severity of the inbox notification of the given
+// event. Alerts that can cost funds if they're ignored are critical.
+func inboxSeverity(e event) inbox.Severity {
+	if e.eventType == eventResolved {
+		return inbox.SeverityInfo
+	}
+
+	switch e.alert.Type {
+	case AlertTypeHTLCExpiry, AlertTypeAccountDrain:
+		return inbox.SeverityCritical
+
+	default:
+		return inbox.SeverityWarning
+	}
+}
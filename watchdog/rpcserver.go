@@ -0,0 +1,240 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/litrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// RPCServer is the main server that implements the Watchdog gRPC interface.
+type RPCServer struct {
+	// Required by the grpc-gateway/v2 library for forward compatibility.
+	litrpc.UnimplementedWatchdogServer
+
+	watchdog *Watchdog
+}
+
+// NewRPCServer returns a new RPC server for the given watchdog.
+func NewRPCServer(watchdog *Watchdog) *RPCServer {
+	return &RPCServer{
+		watchdog: watchdog,
+	}
+}
+
+// ListAlerts lists the active and, optionally, the recently resolved alerts.
+func (s *RPCServer) ListAlerts(_ context.Context,
+	req *litrpc.ListAlertsRequest) (*litrpc.ListAlertsResponse, error) {
+
+	alerts, err := s.watchdog.Alerts(req.IncludeResolved)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListAlertsResponse{
+		Alerts: make([]*litrpc.Alert, len(alerts)),
+	}
+	for i := range alerts {
+		resp.Alerts[i] = marshalAlert(&alerts[i])
+	}
+
+	return resp, nil
+}
+
+// SetAccountNotifications sets the notification preferences of an account,
+// replacing its previous preferences.
+func (s *RPCServer) SetAccountNotifications(_ context.Context,
+	req *litrpc.SetAccountNotificationsRequest) (
+	*litrpc.SetAccountNotificationsResponse, error) {
+
+	if req.Notifications == nil {
+		return nil, fmt.Errorf("notifications must be set")
+	}
+
+	log.Infof("[setaccountnotifications] account_id=%s, alert_types=%v, "+
+		"min_amount_msat=%d", req.Notifications.AccountId,
+		req.Notifications.AlertTypes, req.Notifications.MinAmountMsat)
+
+	n, err := unmarshalAccountNotifications(req.Notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.watchdog.SetAccountNotifications(n); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.SetAccountNotificationsResponse{
+		Notifications: marshalAccountNotifications(n),
+	}, nil
+}
+
+// ListAccountNotifications lists the notification preferences of all accounts
+// that have any.
+func (s *RPCServer) ListAccountNotifications(_ context.Context,
+	_ *litrpc.ListAccountNotificationsRequest) (
+	*litrpc.ListAccountNotificationsResponse, error) {
+
+	all, err := s.watchdog.AccountNotifications()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &litrpc.ListAccountNotificationsResponse{
+		Notifications: make(
+			[]*litrpc.AccountNotifications, len(all),
+		),
+	}
+	for i, n := range all {
+		resp.Notifications[i] = marshalAccountNotifications(n)
+	}
+
+	return resp, nil
+}
+
+// RemoveAccountNotifications removes the notification preferences of an
+// account.
+func (s *RPCServer) RemoveAccountNotifications(_ context.Context,
+	req *litrpc.RemoveAccountNotificationsRequest) (
+	*litrpc.RemoveAccountNotificationsResponse, error) {
+
+	log.Infof("[removeaccountnotifications] account_id=%s", req.AccountId)
+
+	id, err := accounts.ParseAccountID(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.watchdog.RemoveAccountNotifications(*id); err != nil {
+		return nil, err
+	}
+
+	return &litrpc.RemoveAccountNotificationsResponse{}, nil
+}
+
+// unmarshalAccountNotifications converts the RPC notification preferences of
+// an account into their native counterpart.
+func unmarshalAccountNotifications(
+	n *litrpc.AccountNotifications) (*AccountNotifications, error) {
+
+	id, err := accounts.ParseAccountID(n.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := &AccountNotifications{
+		AccountID:  *id,
+		WebhookURL: n.WebhookUrl,
+		MinAmount:  lnwire.MilliSatoshi(n.MinAmountMsat),
+	}
+	for _, t := range n.AlertTypes {
+		alertType, err := unmarshalAlertType(t)
+		if err != nil {
+			return nil, err
+		}
+
+		prefs.AlertTypes = append(prefs.AlertTypes, alertType)
+	}
+
+	return prefs, nil
+}
+
+// marshalAccountNotifications converts the notification preferences of an
+// account into their RPC counterpart.
+func marshalAccountNotifications(
+	n *AccountNotifications) *litrpc.AccountNotifications {
+
+	rpcNotifications := &litrpc.AccountNotifications{
+		AccountId:     hex.EncodeToString(n.AccountID[:]),
+		WebhookUrl:    n.WebhookURL,
+		MinAmountMsat: uint64(n.MinAmount),
+	}
+	for _, t := range n.AlertTypes {
+		rpcNotifications.AlertTypes = append(
+			rpcNotifications.AlertTypes, marshalAlertType(t),
+		)
+	}
+
+	return rpcNotifications
+}
+
+// marshalAlert converts an alert into its RPC counterpart.
+func marshalAlert(a *Alert) *litrpc.Alert {
+	rpcAlert := &litrpc.Alert{
+		Id:            a.ID,
+		Type:          marshalAlertType(a.Type),
+		Message:       a.Message,
+		CreatedAt:     a.CreatedAt.Unix(),
+		ChannelPoint:  a.ChannelPoint,
+		PaymentHash:   a.PaymentHash,
+		AccountId:     a.AccountID,
+		AmountMsat:    a.AmountMsat,
+		AccountFrozen: a.AccountFrozen,
+	}
+	if !a.ResolvedAt.IsZero() {
+		rpcAlert.ResolvedAt = a.ResolvedAt.Unix()
+	}
+
+	return rpcAlert
+}
+
+// marshalAlertType converts an alert type into its RPC counterpart.
+func marshalAlertType(t AlertType) litrpc.AlertType {
+	switch t {
+	case AlertTypeStuckHTLC:
+		return litrpc.AlertType_ALERT_STUCK_HTLC
+
+	case AlertTypeHTLCExpiry:
+		return litrpc.AlertType_ALERT_HTLC_EXPIRY
+
+	case AlertTypeStuckPayment:
+		return litrpc.AlertType_ALERT_STUCK_PAYMENT
+
+	case AlertTypeAccountDrain:
+		return litrpc.AlertType_ALERT_ACCOUNT_DRAIN
+
+	case AlertTypeAccountFailedPayments:
+		return litrpc.AlertType_ALERT_ACCOUNT_FAILED_PAYMENTS
+
+	case AlertTypeAccountNewDestinations:
+		return litrpc.AlertType_ALERT_ACCOUNT_NEW_DESTINATIONS
+
+	case AlertTypeMailboxDegraded:
+		return litrpc.AlertType_ALERT_MAILBOX_DEGRADED
+
+	default:
+		return litrpc.AlertType_ALERT_TYPE_UNKNOWN
+	}
+}
+
+// unmarshalAlertType converts an RPC alert type into its native counterpart.
+func unmarshalAlertType(t litrpc.AlertType) (AlertType, error) {
+	switch t {
+	case litrpc.AlertType_ALERT_STUCK_HTLC:
+		return AlertTypeStuckHTLC, nil
+
+	case litrpc.AlertType_ALERT_HTLC_EXPIRY:
+		return AlertTypeHTLCExpiry, nil
+
+	case litrpc.AlertType_ALERT_STUCK_PAYMENT:
+		return AlertTypeStuckPayment, nil
+
+	case litrpc.AlertType_ALERT_ACCOUNT_DRAIN:
+		return AlertTypeAccountDrain, nil
+
+	case litrpc.AlertType_ALERT_ACCOUNT_FAILED_PAYMENTS:
+		return AlertTypeAccountFailedPayments, nil
+
+	case litrpc.AlertType_ALERT_ACCOUNT_NEW_DESTINATIONS:
+		return AlertTypeAccountNewDestinations, nil
+
+	case litrpc.AlertType_ALERT_MAILBOX_DEGRADED:
+		return AlertTypeMailboxDegraded, nil
+
+	default:
+		return 0, fmt.Errorf("unknown alert type %v", t)
+	}
+}
@@ -0,0 +1,158 @@
+package watchdog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// DBFilename is the default filename of the watchdog database.
+	DBFilename = "watchdog.db"
+
+	// dbFilePermission is the default permission the watchdog database
+	// file is created with.
+	dbFilePermission = 0600
+
+	// dbTimeout is the maximum time we wait for the bbolt database to be
+	// opened.
+	dbTimeout = 5 * time.Second
+)
+
+/*
+	The watchdog data is stored in the following structure in the db:
+
+	account-notifications -> account ID -> json encoded AccountNotifications
+*/
+
+var (
+	// notificationsBucketKey is the key of the top level bucket holding
+	// the notification preferences of the accounts.
+	notificationsBucketKey = []byte("account-notifications")
+
+	// ErrNotificationsNotFound is returned if an account has no
+	// notification preferences.
+	ErrNotificationsNotFound = errors.New("no notification preferences " +
+		"for account")
+)
+
+// Store is a bolt-backed persistent store of the watchdog's settings.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens or creates the watchdog store in the given directory.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, DBFilename)
+	db, err := bbolt.Open(path, dbFilePermission, &bbolt.Options{
+		Timeout: dbTimeout,
+	})
+	if err == bbolt.ErrTimeout {
+		return nil, fmt.Errorf("error while trying to open %s: timed "+
+			"out after %v when trying to obtain exclusive lock",
+			path, dbTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(notificationsBucketKey)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// SetNotifications stores the given notification preferences, replacing the
+// previous preferences of the account.
+func (s *Store) SetNotifications(n *AccountNotifications) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(notificationsBucketKey).Put(n.AccountID[:], b)
+	})
+}
+
+// Notifications fetches the notification preferences of the given account. If
+// the account has none, ErrNotificationsNotFound is returned.
+func (s *Store) Notifications(id accounts.AccountID) (*AccountNotifications,
+	error) {
+
+	var n *AccountNotifications
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(notificationsBucketKey).Get(id[:])
+		if b == nil {
+			return ErrNotificationsNotFound
+		}
+
+		n = &AccountNotifications{}
+		return json.Unmarshal(b, n)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// AllNotifications returns the notification preferences of all accounts,
+// ordered by account ID.
+func (s *Store) AllNotifications() ([]*AccountNotifications, error) {
+	var all []*AccountNotifications
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(notificationsBucketKey)
+
+		return bucket.ForEach(func(_, b []byte) error {
+			var n AccountNotifications
+			if err := json.Unmarshal(b, &n); err != nil {
+				return err
+			}
+
+			all = append(all, &n)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// RemoveNotifications removes the notification preferences of the given
+// account. If the account has none, ErrNotificationsNotFound is returned.
+func (s *Store) RemoveNotifications(id accounts.AccountID) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(notificationsBucketKey)
+
+		if bucket.Get(id[:]) == nil {
+			return ErrNotificationsNotFound
+		}
+
+		return bucket.Delete(id[:])
+	})
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
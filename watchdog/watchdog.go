@@ -0,0 +1,472 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/inbox"
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+const (
+	// maxResolvedAlerts is the maximum number of resolved alerts that are
+	// kept for ListAlerts.
+	maxResolvedAlerts = 100
+)
+
+// ErrNotStarted is returned if the alerts are requested before the watchdog
+// was started.
+var ErrNotStarted = errors.New("watchdog not started")
+
+// AccountService is the subset of the account service the watchdog looks up
+// and freezes accounts with.
+type AccountService interface {
+	AccountFreezer
+
+	// Account retrieves the account with the given ID.
+	Account(id accounts.AccountID) (*accounts.OffChainBalanceAccount,
+		error)
+}
+
+// PaymentSource provides the account payments that are still in flight.
+type PaymentSource interface {
+	// InFlightPayments returns all account payments that haven't reached
+	// a final state yet.
+	InFlightPayments() []accounts.InFlightPayment
+}
+
+// MailboxSource provides the connectivity of the mailbox servers that the LNC
+// sessions connect through.
+type MailboxSource interface {
+	// DegradedMailboxes returns the mailbox servers whose connectivity is
+	// degraded, mapped to a description of the problem.
+	DegradedMailboxes() map[string]string
+}
+
+// Inbox keeps the notifications that are shown in the web UI.
+type Inbox interface {
+	// AddNotification adds the given notification to the inbox.
+	AddNotification(n *inbox.Notification) error
+}
+
+// Watchdog periodically checks the pending HTLCs of all channels and the
+// in-flight account payments and raises alerts for the ones that exceed the
+// configured thresholds. If enabled, it also raises alerts for accounts that
+// behave unusually. Alerts are also raised for mailbox servers whose
+// connectivity is degraded.
+type Watchdog struct {
+	cfg      *Config
+	dir      string
+	notifier *webhookNotifier
+
+	// store holds the notification preferences of the accounts.
+	store *Store
+
+	// anomalies detects unusual account activity. It is nil if anomaly
+	// detection is disabled.
+	anomalies *anomalyDetector
+
+	// trigger is used to request a check before the next interval, for
+	// example once an anomaly was found.
+	trigger chan struct{}
+
+	lnd      lnrpc.LightningClient
+	payments PaymentSource
+	accounts AccountService
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// mailboxes reports the connectivity of the mailbox servers. It is nil
+	// if it isn't monitored.
+	mailboxes MailboxSource
+
+	// inbox receives a notification for each raised and resolved alert.
+	// It is nil if the alerts aren't shown in the web UI.
+	inbox Inbox
+
+	// firstSeen holds the time at which each pending HTLC was first seen,
+	// as lnd doesn't report when an HTLC was added.
+	firstSeen map[string]time.Time
+
+	// active holds the alerts that are currently raised by their ID.
+	active map[string]*Alert
+
+	// resolved holds the most recently resolved alerts, oldest first.
+	resolved []*Alert
+
+	started atomic.Bool
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewWatchdog creates a new watchdog that stores its data in the given
+// directory.
+func NewWatchdog(cfg *Config, dir string) *Watchdog {
+	w := &Watchdog{
+		cfg:       cfg,
+		dir:       dir,
+		firstSeen: make(map[string]time.Time),
+		active:    make(map[string]*Alert),
+		trigger:   make(chan struct{}, 1),
+		quit:      make(chan struct{}),
+	}
+
+	if !cfg.Disable && cfg.Anomaly.Enable {
+		w.anomalies = newAnomalyDetector(cfg.Anomaly, w.triggerCheck)
+	}
+
+	if cfg.WebhookURL != "" {
+		w.notifier = newWebhookNotifier(cfg.WebhookURL)
+	}
+
+	return w
+}
+
+// Start starts checking the HTLCs of the given lnd node and the payments of
+// the given source in the configured interval. The account service is used to
+// freeze accounts that behave unusually if auto-freeze is enabled.
+func (w *Watchdog) Start(lnd lnrpc.LightningClient, payments PaymentSource,
+	accts AccountService) error {
+
+	store, err := NewStore(w.dir)
+	if err != nil {
+		return fmt.Errorf("unable to open watchdog store: %v", err)
+	}
+
+	w.store = store
+	w.lnd = lnd
+	w.payments = payments
+	w.accounts = accts
+	if w.anomalies != nil {
+		w.anomalies.mu.Lock()
+		w.anomalies.freezer = accts
+		w.anomalies.mu.Unlock()
+	}
+	w.started.Store(true)
+
+	if w.cfg.Disable {
+		return nil
+	}
+
+	w.wg.Add(1)
+	go w.run()
+
+	return nil
+}
+
+// SetMailboxSource sets the source of the mailbox server connectivity. An
+// alert is raised for each mailbox server it reports as degraded.
+func (w *Watchdog) SetMailboxSource(mailboxes MailboxSource) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.mailboxes = mailboxes
+}
+
+// SetInbox sets the inbox of the web UI. A notification is added to it for
+// each alert that is raised or resolved, regardless of the notification
+// preferences of the alert's account.
+func (w *Watchdog) SetInbox(inbox Inbox) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.inbox = inbox
+}
+
+// Stop stops the watchdog.
+func (w *Watchdog) Stop() error {
+	if !w.started.Load() {
+		return nil
+	}
+	w.started.Store(false)
+
+	close(w.quit)
+	w.wg.Wait()
+
+	return w.store.Close()
+}
+
+// run checks the HTLCs and payments in the configured interval until the
+// watchdog is stopped.
+func (w *Watchdog) run() {
+	defer w.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-w.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.check(ctx, time.Now())
+		if err != nil {
+			log.Errorf("Unable to check pending HTLCs: %v", err)
+		}
+
+		for _, e := range events {
+			switch e.eventType {
+			case eventRaised:
+				log.Warnf("Alert raised: %s", e.alert.Message)
+
+			case eventResolved:
+				log.Infof("Alert resolved: %s", e.alert.Message)
+			}
+
+			w.deliver(ctx, e)
+			w.addToInbox(e)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-w.trigger:
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// triggerCheck requests a check before the next interval. It doesn't block if
+// a check was already requested.
+func (w *Watchdog) triggerCheck() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Alerts returns the active alerts and, if includeResolved is set, the most
+// recently resolved alerts, newest first.
+func (w *Watchdog) Alerts(includeResolved bool) ([]Alert, error) {
+	if !w.started.Load() {
+		return nil, ErrNotStarted
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(w.active))
+	for _, a := range w.active {
+		alerts = append(alerts, *a)
+	}
+	if includeResolved {
+		for _, a := range w.resolved {
+			alerts = append(alerts, *a)
+		}
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].CreatedAt.Equal(alerts[j].CreatedAt) {
+			return alerts[i].ID < alerts[j].ID
+		}
+
+		return alerts[i].CreatedAt.After(alerts[j].CreatedAt)
+	})
+
+	return alerts, nil
+}
+
+// check evaluates all pending HTLCs, in-flight payments, account anomalies and
+// the mailbox connectivity at the given time, raises alerts for the ones that exceed a threshold and
+// resolves the alerts whose condition no longer holds. The resulting alert
+// events are returned.
+func (w *Watchdog) check(ctx context.Context, now time.Time) ([]event,
+	error) {
+
+	info, err := w.lnd.GetInfo(ctx, &lnrpc.GetInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get node info: %v", err)
+	}
+
+	channels, err := w.lnd.ListChannels(ctx, &lnrpc.ListChannelsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list channels: %v", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	firing := make(map[string]*Alert)
+	seen := make(map[string]struct{})
+	for _, channel := range channels.Channels {
+		for _, htlc := range channel.PendingHtlcs {
+			key := htlcKey(channel.ChannelPoint, htlc)
+			seen[key] = struct{}{}
+
+			firstSeen, ok := w.firstSeen[key]
+			if !ok {
+				firstSeen = now
+				w.firstSeen[key] = now
+			}
+
+			w.checkHTLC(
+				firing, key, channel.ChannelPoint, htlc,
+				now.Sub(firstSeen), info.BlockHeight,
+			)
+		}
+	}
+
+	for key := range w.firstSeen {
+		if _, ok := seen[key]; !ok {
+			delete(w.firstSeen, key)
+		}
+	}
+
+	if w.cfg.PaymentThreshold > 0 && w.payments != nil {
+		for _, p := range w.payments.InFlightPayments() {
+			age := now.Sub(p.TrackedSince)
+			if age < w.cfg.PaymentThreshold {
+				continue
+			}
+
+			id := fmt.Sprintf("%v/%v", AlertTypeStuckPayment, p.Hash)
+			firing[id] = &Alert{
+				Type: AlertTypeStuckPayment,
+				Message: fmt.Sprintf("Payment %v of account "+
+					"%x over %v is in flight for more "+
+					"than %v", p.Hash, p.AccountID[:],
+					p.FullAmount, w.cfg.PaymentThreshold),
+				PaymentHash: p.Hash.String(),
+				AccountID:   hex.EncodeToString(p.AccountID[:]),
+				AmountMsat:  uint64(p.FullAmount),
+			}
+		}
+	}
+
+	if w.mailboxes != nil {
+		for addr, reason := range w.mailboxes.DegradedMailboxes() {
+			id := fmt.Sprintf("%v/%v", AlertTypeMailboxDegraded, addr)
+			firing[id] = &Alert{
+				Type: AlertTypeMailboxDegraded,
+				Message: fmt.Sprintf("Connectivity to mailbox "+
+					"server %v is degraded: %v", addr,
+					reason),
+			}
+		}
+	}
+
+	if w.anomalies != nil {
+		w.anomalies.firing(firing, now)
+	}
+
+	return w.updateAlerts(firing, now), nil
+}
+
+// checkHTLC adds alerts to firing for each threshold the given HTLC exceeds.
+func (w *Watchdog) checkHTLC(firing map[string]*Alert, key,
+	chanPoint string, htlc *lnrpc.HTLC, age time.Duration,
+	height uint32) {
+
+	direction := "outgoing"
+	if htlc.Incoming {
+		direction = "incoming"
+	}
+
+	hash := hex.EncodeToString(htlc.HashLock)
+	amtMsat := uint64(htlc.Amount) * 1000
+
+	if w.cfg.HTLCThreshold > 0 && age >= w.cfg.HTLCThreshold {
+		id := fmt.Sprintf("%v/%s", AlertTypeStuckHTLC, key)
+		firing[id] = &Alert{
+			Type: AlertTypeStuckHTLC,
+			Message: fmt.Sprintf("The %s HTLC %s of %d sat on "+
+				"channel %s is pending for more than %v",
+				direction, hash, htlc.Amount, chanPoint,
+				w.cfg.HTLCThreshold),
+			ChannelPoint: chanPoint,
+			PaymentHash:  hash,
+			AmountMsat:   amtMsat,
+		}
+	}
+
+	expiryBlocks := w.cfg.HTLCExpiryBlocks
+	if expiryBlocks > 0 && htlc.ExpirationHeight <= height+expiryBlocks {
+		var blocksLeft uint32
+		if htlc.ExpirationHeight > height {
+			blocksLeft = htlc.ExpirationHeight - height
+		}
+
+		id := fmt.Sprintf("%v/%s", AlertTypeHTLCExpiry, key)
+		firing[id] = &Alert{
+			Type: AlertTypeHTLCExpiry,
+			Message: fmt.Sprintf("The %s HTLC %s of %d sat on "+
+				"channel %s expires in %d blocks", direction,
+				hash, htlc.Amount, chanPoint, blocksLeft),
+			ChannelPoint: chanPoint,
+			PaymentHash:  hash,
+			AmountMsat:   amtMsat,
+		}
+	}
+}
+
+// updateAlerts raises the firing alerts that aren't active yet and resolves
+// the active alerts that no longer fire. The caller must hold mu.
+func (w *Watchdog) updateAlerts(firing map[string]*Alert,
+	now time.Time) []event {
+
+	var events []event
+	for id, alert := range firing {
+		if _, ok := w.active[id]; ok {
+			continue
+		}
+
+		alert.ID = id
+		alert.CreatedAt = now
+		w.active[id] = alert
+
+		events = append(events, event{
+			eventType: eventRaised,
+			alert:     *alert,
+		})
+	}
+
+	for id, alert := range w.active {
+		if _, ok := firing[id]; ok {
+			continue
+		}
+
+		alert.ResolvedAt = now
+		delete(w.active, id)
+		w.resolved = append(w.resolved, alert)
+
+		events = append(events, event{
+			eventType: eventResolved,
+			alert:     *alert,
+		})
+	}
+
+	if len(w.resolved) > maxResolvedAlerts {
+		w.resolved = w.resolved[len(w.resolved)-maxResolvedAlerts:]
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].alert.ID < events[j].alert.ID
+	})
+
+	return events
+}
+
+// htlcKey returns a key that identifies the given HTLC on a channel.
+func htlcKey(chanPoint string, htlc *lnrpc.HTLC) string {
+	direction := "out"
+	if htlc.Incoming {
+		direction = "in"
+	}
+
+	return fmt.Sprintf("%s/%s/%d", chanPoint, direction, htlc.HtlcIndex)
+}
@@ -0,0 +1,460 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lightninglabs/lightning-terminal/accounts"
+	"github.com/lightninglabs/lightning-terminal/inbox"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/routing/route"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const testChanPoint = "097ef666a61919ff3413b3b701eae3a5cbac08f70c0ca567806e" +
+	"1fa6acbfe384:0"
+
+type mockLnd struct {
+	lnrpc.LightningClient
+
+	height uint32
+	htlcs  []*lnrpc.HTLC
+}
+
+func (m *mockLnd) GetInfo(context.Context, *lnrpc.GetInfoRequest,
+	...grpc.CallOption) (*lnrpc.GetInfoResponse, error) {
+
+	return &lnrpc.GetInfoResponse{BlockHeight: m.height}, nil
+}
+
+func (m *mockLnd) ListChannels(context.Context, *lnrpc.ListChannelsRequest,
+	...grpc.CallOption) (*lnrpc.ListChannelsResponse, error) {
+
+	return &lnrpc.ListChannelsResponse{
+		Channels: []*lnrpc.Channel{{
+			ChannelPoint: testChanPoint,
+			PendingHtlcs: m.htlcs,
+		}},
+	}, nil
+}
+
+type mockPayments struct {
+	payments []accounts.InFlightPayment
+}
+
+func (m *mockPayments) InFlightPayments() []accounts.InFlightPayment {
+	return m.payments
+}
+
+type mockMailboxes struct {
+	degraded map[string]string
+}
+
+func (m *mockMailboxes) DegradedMailboxes() map[string]string {
+	return m.degraded
+}
+
+// TestWatchdog tests that alerts are raised once the thresholds are exceeded
+// and resolved once the HTLCs and payments complete.
+func TestWatchdog(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1_700_000_000, 0)
+
+	lnd := &mockLnd{
+		height: 1000,
+		htlcs: []*lnrpc.HTLC{{
+			Amount:           100,
+			HashLock:         []byte{1, 2, 3},
+			ExpirationHeight: 1100,
+			HtlcIndex:        3,
+		}},
+	}
+	payments := &mockPayments{
+		payments: []accounts.InFlightPayment{{
+			AccountID:    accounts.AccountID{1},
+			Hash:         lntypes.Hash{2},
+			FullAmount:   5000,
+			TrackedSince: start,
+		}},
+	}
+
+	cfg := DefaultConfig()
+	cfg.Disable = true
+	w := NewWatchdog(cfg, t.TempDir())
+
+	_, err := w.Alerts(false)
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, w.Start(lnd, payments, nil))
+	t.Cleanup(func() {
+		require.NoError(t, w.Stop())
+	})
+
+	// Nothing exceeds a threshold yet.
+	events, err := w.check(ctx, start)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// After the thresholds passed, the HTLC and the payment are stuck.
+	events, err = w.check(ctx, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, eventRaised, events[0].eventType)
+	require.Equal(t, AlertTypeStuckHTLC, events[0].alert.Type)
+	require.Equal(t, "stuck_htlc/"+testChanPoint+"/out/3",
+		events[0].alert.ID)
+	require.EqualValues(t, 100_000, events[0].alert.AmountMsat)
+	require.Equal(t, AlertTypeStuckPayment, events[1].alert.Type)
+	require.Equal(t, "0100000000000000", events[1].alert.AccountID)
+
+	// Alerts are only raised once.
+	events, err = w.check(ctx, start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// The HTLC approaches its expiry and the payment completes.
+	lnd.height = 1080
+	payments.payments = nil
+	events, err = w.check(ctx, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, eventRaised, events[0].eventType)
+	require.Equal(t, AlertTypeHTLCExpiry, events[0].alert.Type)
+	require.Contains(t, events[0].alert.Message, "expires in 20 blocks")
+	require.Equal(t, eventResolved, events[1].eventType)
+	require.Equal(t, AlertTypeStuckPayment, events[1].alert.Type)
+
+	alerts, err := w.Alerts(false)
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	require.Equal(t, AlertTypeHTLCExpiry, alerts[0].Type)
+
+	// Once the HTLC is resolved, all alerts are resolved.
+	lnd.htlcs = nil
+	events, err = w.check(ctx, start.Add(4*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Empty(t, w.firstSeen)
+
+	alerts, err = w.Alerts(false)
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+
+	alerts, err = w.Alerts(true)
+	require.NoError(t, err)
+	require.Len(t, alerts, 3)
+	for _, alert := range alerts {
+		require.False(t, alert.ResolvedAt.IsZero())
+	}
+}
+
+// TestMailboxAlerts tests that an alert is raised for each mailbox server whose
+// connectivity is degraded and resolved once it recovers.
+func TestMailboxAlerts(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1_700_000_000, 0)
+
+	cfg := DefaultConfig()
+	cfg.Disable = true
+	w := NewWatchdog(cfg, t.TempDir())
+
+	mailboxes := &mockMailboxes{
+		degraded: map[string]string{
+			"mailbox.example.com:443": "3 probes in a row failed",
+		},
+	}
+	w.SetMailboxSource(mailboxes)
+
+	require.NoError(t, w.Start(&mockLnd{}, &mockPayments{}, nil))
+	t.Cleanup(func() {
+		require.NoError(t, w.Stop())
+	})
+
+	events, err := w.check(ctx, start)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, eventRaised, events[0].eventType)
+	require.Equal(t, AlertTypeMailboxDegraded, events[0].alert.Type)
+	require.Equal(t, "mailbox_degraded/mailbox.example.com:443",
+		events[0].alert.ID)
+	require.Contains(t, events[0].alert.Message, "3 probes in a row")
+
+	mailboxes.degraded = nil
+	events, err = w.check(ctx, start.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, eventResolved, events[0].eventType)
+}
+
+// TestWebhookNotifier tests that the alert events are POSTed to the webhook.
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			require.NoError(t, err)
+
+			received <- body
+		},
+	))
+	defer server.Close()
+
+	n := newWebhookNotifier(server.URL)
+	err := n.notify(context.Background(), event{
+		eventType: eventResolved,
+		alert: Alert{
+			ID:         "stuck_payment/abcd",
+			Type:       AlertTypeStuckPayment,
+			CreatedAt:  time.Unix(1000, 0),
+			ResolvedAt: time.Unix(2000, 0),
+		},
+	})
+	require.NoError(t, err)
+
+	body := <-received
+	require.Equal(t, "resolved", body["event"])
+
+	alert := body["alert"].(map[string]interface{})
+	require.Equal(t, "stuck_payment/abcd", alert["id"])
+	require.Equal(t, "ALERT_STUCK_PAYMENT", alert["type"])
+	require.Equal(t, "2000", alert["resolved_at"])
+}
+
+type mockInbox struct {
+	notifications []*inbox.Notification
+}
+
+func (m *mockInbox) AddNotification(n *inbox.Notification) error {
+	m.notifications = append(m.notifications, n)
+	return nil
+}
+
+// TestInboxNotifications tests that raised and resolved alerts are added to
+// the inbox of the web UI with the webhook event as their payload.
+func TestInboxNotifications(t *testing.T) {
+	w := NewWatchdog(DefaultConfig(), t.TempDir())
+
+	// Without an inbox, nothing happens.
+	alert := Alert{
+		ID:        "htlc_expiry/abcd",
+		Type:      AlertTypeHTLCExpiry,
+		Message:   "HTLC is close to expiry",
+		CreatedAt: time.Unix(1000, 0),
+	}
+	w.addToInbox(event{eventType: eventRaised, alert: alert})
+
+	ib := &mockInbox{}
+	w.SetInbox(ib)
+
+	w.addToInbox(event{eventType: eventRaised, alert: alert})
+
+	alert.ResolvedAt = time.Unix(2000, 0)
+	w.addToInbox(event{eventType: eventResolved, alert: alert})
+
+	w.addToInbox(event{eventType: eventRaised, alert: Alert{
+		ID:   "stuck_htlc/abcd",
+		Type: AlertTypeStuckHTLC,
+	}})
+
+	require.Len(t, ib.notifications, 3)
+
+	raised := ib.notifications[0]
+	require.Equal(t, inbox.SeverityCritical, raised.Severity)
+	require.Equal(t, "watchdog", raised.Source)
+	require.Equal(t, alert.Message, raised.Message)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(raised.Payload, &payload))
+	require.Equal(t, "raised", payload["event"])
+
+	resolved := ib.notifications[1]
+	require.Equal(t, inbox.SeverityInfo, resolved.Severity)
+	require.Contains(t, resolved.Message, alert.Message)
+
+	require.Equal(t, inbox.SeverityWarning, ib.notifications[2].Severity)
+}
+
+type mockFreezer struct {
+	frozen map[accounts.AccountID]string
+}
+
+func (m *mockFreezer) FreezeAccount(id accounts.AccountID,
+	reason string) (*accounts.OffChainBalanceAccount, error) {
+
+	m.frozen[id] = reason
+
+	return &accounts.OffChainBalanceAccount{ID: id}, nil
+}
+
+// TestAnomalyDetector tests that unusual account activity is found, the
+// account is frozen if auto-freeze is enabled and the alerts resolve once the
+// activity left the window.
+func TestAnomalyDetector(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cfg := &AnomalyConfig{
+		Enable:          true,
+		Window:          time.Hour,
+		DrainRatio:      0.8,
+		FailedPayments:  2,
+		NewDestinations: 3,
+		AutoFreeze:      true,
+	}
+
+	var findings int
+	d := newAnomalyDetector(cfg, func() { findings++ })
+	d.now = func() time.Time { return now }
+
+	freezer := &mockFreezer{frozen: make(map[accounts.AccountID]string)}
+	d.freezer = freezer
+
+	acct1, acct2 := accounts.AccountID{1}, accounts.AccountID{2}
+	dest1, dest2 := route.Vertex{1}, route.Vertex{2}
+
+	// Paying less than the drain ratio to known destinations is fine.
+	d.paymentAttempted(acct1, 300_000, &dest1, 1_000_000)
+	d.paymentAttempted(acct1, 300_000, &dest1, 700_000)
+	d.paymentFailed(acct2)
+	require.Zero(t, findings)
+
+	firing := make(map[string]*Alert)
+	d.firing(firing, now)
+	require.Empty(t, firing)
+
+	// The third payment exceeds 80% of the balance at the start of the
+	// window, so the account is frozen.
+	d.paymentAttempted(acct1, 300_000, &dest2, 400_000)
+	require.Equal(t, 1, findings)
+	require.Contains(t, freezer.frozen[acct1], "90% of its balance")
+
+	// A second failure of the other account raises an alert too.
+	now = now.Add(time.Minute)
+	d.paymentFailed(acct2)
+	require.Equal(t, 2, findings)
+	require.NotContains(t, freezer.frozen, acct2)
+
+	d.firing(firing, now)
+	require.Len(t, firing, 2)
+
+	drain := firing["account_drain/0100000000000000"]
+	require.NotNil(t, drain)
+	require.True(t, drain.AccountFrozen)
+	require.EqualValues(t, 900_000, drain.AmountMsat)
+	require.Contains(t, drain.Message, "frozen pending review")
+
+	failed := firing["account_failed_payments/0200000000000000"]
+	require.NotNil(t, failed)
+	require.False(t, failed.AccountFrozen)
+
+	// Failures are only recorded, the account is frozen once it attempts
+	// the next payment.
+	d.paymentAttempted(acct2, 1, &dest1, 1_000_000)
+	require.Equal(t, 3, findings)
+	require.Contains(t, freezer.frozen[acct2], "2 payments")
+
+	// Paying many new destinations raises an alert as well.
+	acct3 := accounts.AccountID{3}
+	for i := byte(10); i < 13; i++ {
+		d.paymentAttempted(acct3, 1, &route.Vertex{i}, 1_000_000)
+	}
+	require.Equal(t, 4, findings)
+	require.Contains(t, freezer.frozen[acct3], "3 new destinations")
+
+	d.firing(firing, now)
+	require.Len(t, firing, 3)
+
+	// Once the activity left the window, all alerts resolve.
+	firing = make(map[string]*Alert)
+	d.firing(firing, now.Add(2*time.Hour))
+	require.Empty(t, firing)
+}
+
+// TestAccountNotifications tests that the notification preferences of an
+// account filter its alerts and route them to the account's webhook.
+func TestAccountNotifications(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Disable = true
+	cfg.WebhookURL = "https://example.com/global"
+	dir := t.TempDir()
+	w := NewWatchdog(cfg, dir)
+
+	acctID := accounts.AccountID{1}
+	prefs := &AccountNotifications{
+		AccountID:  acctID,
+		WebhookURL: "https://example.com/account",
+		AlertTypes: []AlertType{AlertTypeAccountDrain},
+		MinAmount:  1000,
+	}
+	require.ErrorIs(t, w.SetAccountNotifications(prefs), ErrNotStarted)
+
+	require.NoError(t, w.Start(&mockLnd{}, &mockPayments{}, nil))
+
+	// Alert types that aren't raised for accounts are rejected.
+	err := w.SetAccountNotifications(&AccountNotifications{
+		AccountID:  acctID,
+		AlertTypes: []AlertType{AlertTypeStuckHTLC},
+	})
+	require.Error(t, err)
+	require.NoError(t, w.SetAccountNotifications(prefs))
+
+	notifierFor := func(a *Alert) *webhookNotifier {
+		notifier, err := w.notifierFor(a)
+		require.NoError(t, err)
+		return notifier
+	}
+
+	// Alerts of other accounts and without an account go to the global
+	// webhook.
+	require.Equal(t, cfg.WebhookURL, notifierFor(&Alert{
+		Type: AlertTypeStuckHTLC,
+	}).url)
+	require.Equal(t, cfg.WebhookURL, notifierFor(&Alert{
+		Type:      AlertTypeAccountDrain,
+		AccountID: "0200000000000000",
+	}).url)
+
+	// Matching alerts of the account go to its webhook, the others are
+	// dropped.
+	require.Equal(t, prefs.WebhookURL, notifierFor(&Alert{
+		Type:       AlertTypeAccountDrain,
+		AccountID:  "0100000000000000",
+		AmountMsat: 1000,
+	}).url)
+	require.Nil(t, notifierFor(&Alert{
+		Type:       AlertTypeAccountDrain,
+		AccountID:  "0100000000000000",
+		AmountMsat: 999,
+	}))
+	require.Nil(t, notifierFor(&Alert{
+		Type:      AlertTypeStuckPayment,
+		AccountID: "0100000000000000",
+	}))
+
+	// The preferences survive a restart.
+	require.NoError(t, w.Stop())
+	w = NewWatchdog(cfg, dir)
+	require.NoError(t, w.Start(&mockLnd{}, &mockPayments{}, nil))
+	t.Cleanup(func() {
+		require.NoError(t, w.Stop())
+	})
+
+	all, err := w.AccountNotifications()
+	require.NoError(t, err)
+	require.Equal(t, []*AccountNotifications{prefs}, all)
+
+	require.NoError(t, w.RemoveAccountNotifications(acctID))
+	require.ErrorIs(
+		t, w.RemoveAccountNotifications(acctID),
+		ErrNotificationsNotFound,
+	)
+	require.Equal(t, cfg.WebhookURL, notifierFor(&Alert{
+		Type:      AlertTypeStuckPayment,
+		AccountID: "0100000000000000",
+	}).url)
+}
@@ -0,0 +1,104 @@
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// deliveryTimeout is the maximum time the delivery of an event may
+	// take.
+	deliveryTimeout = 30 * time.Second
+)
+
+// webhookEvent is the JSON body that is POSTed to the webhook.
+type webhookEvent struct {
+	// Event is either "raised" or "resolved".
+	Event eventType `json:"event"`
+
+	// Alert is the alert in the same format as returned by the REST API.
+	Alert json.RawMessage `json:"alert"`
+}
+
+// webhookNotifier POSTs alert events as JSON to a URL.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// newWebhookNotifier creates a notifier for the given webhook URL.
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{
+		url:    url,
+		client: http.DefaultClient,
+	}
+}
+
+// marshalEvent returns the JSON body of the given event that is POSTed to the
+// webhook.
+func marshalEvent(e event) ([]byte, error) {
+	alert, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(marshalAlert(&e.alert))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&webhookEvent{
+		Event: e.eventType,
+		Alert: alert,
+	})
+}
+
+// notify POSTs the given event to the webhook URL.
+func (w *webhookNotifier) notify(ctx context.Context, e event) error {
+	body, err := marshalEvent(e)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, w.url, bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode,
+			body)
+	}
+
+	return nil
+}
+
+// String returns a human-readable description of the webhook. Only the host
+// is included since the URL might contain credentials.
+func (w *webhookNotifier) String() string {
+	u, err := url.Parse(w.url)
+	if err != nil {
+		return "webhook"
+	}
+
+	return fmt.Sprintf("webhook host %s", u.Host)
+}
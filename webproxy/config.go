@@ -0,0 +1,130 @@
+package webproxy
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+)
+
+var (
+	// routeNamePattern is the pattern a route name must match. It is used
+	// as a path element of the proxied URLs.
+	routeNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// Config holds all config options for the reverse proxy of subserver web UIs.
+type Config struct {
+	Routes   []string `long:"route" description:"Reverse-proxy an HTTP endpoint of a subserver under /subserver/<name>/ of litd's HTTP(S) listener, specified as <name>=<url>, for example taproot-assets=https://localhost:8089. Requests to the route must be authenticated the same way as litd's own RPCs. Can be specified multiple times."`
+	TLSCerts []string `long:"tlscert" description:"The TLS certificate to verify the HTTPS endpoint of a route with, specified as <name>=<path>. If not set, the system's root certificates are used. Can be specified multiple times."`
+}
+
+// Route is an HTTP endpoint of a subserver that is proxied under the path
+// prefix of its name.
+type Route struct {
+	// Name is the path element under PathPrefix the route is served at.
+	Name string
+
+	// Target is the base URL requests are forwarded to.
+	Target *url.URL
+
+	// TLSCertPath is the path to the TLS certificate of the target. If it
+	// is empty, the system's root certificates are used.
+	TLSCertPath string
+}
+
+// DefaultConfig constructs the default web proxy Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure all routes and certificates are well-formed.
+func (c *Config) Validate() error {
+	_, err := c.ParseRoutes()
+	return err
+}
+
+// ParseRoutes parses the configured routes and their certificates.
+func (c *Config) ParseRoutes() ([]*Route, error) {
+	var (
+		routes []*Route
+		byName = make(map[string]*Route)
+	)
+	for _, spec := range c.Routes {
+		name, target, err := splitNameValue(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webproxy.route %q: %v",
+				spec, err)
+		}
+
+		if _, ok := byName[name]; ok {
+			return nil, fmt.Errorf("duplicate webproxy.route %q",
+				name)
+		}
+
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webproxy.route %q: %v",
+				spec, err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("invalid webproxy.route %q: "+
+				"scheme must be http or https", spec)
+		}
+
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid webproxy.route %q: "+
+				"host missing", spec)
+		}
+		u.Path = strings.TrimSuffix(u.Path, "/")
+
+		route := &Route{
+			Name:   name,
+			Target: u,
+		}
+		byName[name] = route
+		routes = append(routes, route)
+	}
+
+	for _, spec := range c.TLSCerts {
+		name, path, err := splitNameValue(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webproxy.tlscert %q: %v",
+				spec, err)
+		}
+
+		route, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("webproxy.tlscert %q doesn't "+
+				"belong to a route", name)
+		}
+
+		if route.Target.Scheme != "https" {
+			return nil, fmt.Errorf("webproxy.tlscert %q is set "+
+				"for a route that doesn't use https", name)
+		}
+
+		route.TLSCertPath = lncfg.CleanAndExpandPath(path)
+	}
+
+	return routes, nil
+}
+
+// splitNameValue splits a <name>=<value> option and checks that the name is a
+// valid route name.
+func splitNameValue(spec string) (string, string, error) {
+	name, value, ok := strings.Cut(spec, "=")
+	if !ok || value == "" {
+		return "", "", fmt.Errorf("must be of the form <name>=<value>")
+	}
+
+	if !routeNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("name must only contain lower case " +
+			"letters, digits and dashes")
+	}
+
+	return name, value, nil
+}
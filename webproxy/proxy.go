@@ -0,0 +1,163 @@
+package webproxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"strings"
+)
+
+const (
+	// PathPrefix is the URL path prefix under which the routes are served
+	// by litd's HTTP(S) listener.
+	PathPrefix = "/subserver/"
+
+	// HeaderForwardedPrefix is the header that tells the subserver under
+	// which path prefix it is served, so it can create absolute links.
+	HeaderForwardedPrefix = "X-Forwarded-Prefix"
+)
+
+// Authenticator returns true if the request carries valid credentials for
+// litd.
+type Authenticator func(req *http.Request) bool
+
+// Proxy forwards authenticated requests under PathPrefix to the HTTP endpoints
+// of subservers.
+type Proxy struct {
+	routes       map[string]*httputil.ReverseProxy
+	authenticate Authenticator
+
+	// basicAuthChallenge is true if unauthenticated requests should be
+	// answered with a basic auth challenge, so a browser asks for the UI
+	// password.
+	basicAuthChallenge bool
+}
+
+// NewProxy creates a proxy for the given routes. Requests are only forwarded
+// if the authenticator accepts them.
+func NewProxy(routes []*Route, authenticate Authenticator,
+	basicAuthChallenge bool) (*Proxy, error) {
+
+	p := &Proxy{
+		routes:             make(map[string]*httputil.ReverseProxy),
+		authenticate:       authenticate,
+		basicAuthChallenge: basicAuthChallenge,
+	}
+	for _, route := range routes {
+		proxy, err := newReverseProxy(route)
+		if err != nil {
+			return nil, fmt.Errorf("error creating proxy for route "+
+				"%s: %v", route.Name, err)
+		}
+
+		p.routes[route.Name] = proxy
+	}
+
+	return p, nil
+}
+
+// ServeHTTP forwards a request under PathPrefix to the route it belongs to.
+//
+// NOTE: this is part of the http.Handler interface.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, rest, _ := strings.Cut(
+		strings.TrimPrefix(r.URL.Path, PathPrefix), "/",
+	)
+
+	proxy, ok := p.routes[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !p.authenticate(r) {
+		if p.basicAuthChallenge {
+			w.Header().Set("WWW-Authenticate", `Basic realm="litd"`)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	// The request must not escape the base path of the target.
+	if strings.Contains("/"+rest+"/", "/../") {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	// Relative links of a UI only work if its root is requested with a
+	// trailing slash.
+	if rest == "" && !strings.HasSuffix(r.URL.Path, "/") {
+		target := r.URL.Path + "/"
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+
+		return
+	}
+
+	log.Debugf("Forwarding request to route %s: /%s", name, rest)
+	proxy.ServeHTTP(w, r)
+}
+
+// newReverseProxy creates the reverse proxy that forwards the requests of the
+// given route to its target.
+func newReverseProxy(route *Route) (*httputil.ReverseProxy, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if route.TLSCertPath != "" {
+		certBytes, err := os.ReadFile(route.TLSCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading TLS cert: %v", err)
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(certBytes) {
+			return nil, fmt.Errorf("no certificate found in %s",
+				route.TLSCertPath)
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    certPool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	prefix := PathPrefix + route.Name
+	target := route.Target
+	director := func(req *http.Request) {
+		rest := strings.TrimPrefix(req.URL.Path, prefix)
+
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		req.URL.Path = target.Path + rest
+		req.URL.RawPath = ""
+		req.Host = target.Host
+
+		// The credentials are meant for litd, so they must not be
+		// passed on to the subserver.
+		req.Header.Del("Authorization")
+		req.Header.Set(HeaderForwardedPrefix, prefix)
+
+		// Make sure the default user agent of Go isn't sent if the
+		// client didn't send one.
+		if _, ok := req.Header["User-Agent"]; !ok {
+			req.Header.Set("User-Agent", "")
+		}
+	}
+
+	return &httputil.ReverseProxy{
+		Director:  director,
+		Transport: transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request,
+			err error) {
+
+			log.Errorf("Error forwarding request to route %s: %v",
+				route.Name, err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}, nil
+}
@@ -0,0 +1,139 @@
+package webproxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseRoutes tests that routes and their certificates are parsed and that
+// invalid options are rejected.
+func TestParseRoutes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Routes: []string{
+			"taproot-assets=https://localhost:8089/",
+			"docs=http://localhost:8000/docs",
+		},
+		TLSCerts: []string{"taproot-assets=/tmp/tls.cert"},
+	}
+	routes, err := cfg.ParseRoutes()
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	require.Equal(t, "taproot-assets", routes[0].Name)
+	require.Equal(t, "https://localhost:8089", routes[0].Target.String())
+	require.Equal(t, "/tmp/tls.cert", routes[0].TLSCertPath)
+	require.Equal(t, "/docs", routes[1].Target.Path)
+	require.Empty(t, routes[1].TLSCertPath)
+
+	invalid := []struct {
+		cfg    *Config
+		errStr string
+	}{{
+		cfg:    &Config{Routes: []string{"Tapd=https://localhost:8089"}},
+		errStr: "lower case letters",
+	}, {
+		cfg:    &Config{Routes: []string{"tapd"}},
+		errStr: "must be of the form",
+	}, {
+		cfg:    &Config{Routes: []string{"tapd=localhost:8089"}},
+		errStr: "scheme must be http or https",
+	}, {
+		cfg: &Config{Routes: []string{
+			"tapd=https://a:1", "tapd=https://b:2",
+		}},
+		errStr: "duplicate",
+	}, {
+		cfg: &Config{
+			Routes:   []string{"tapd=https://localhost:8089"},
+			TLSCerts: []string{"loop=/tmp/tls.cert"},
+		},
+		errStr: "doesn't belong to a route",
+	}, {
+		cfg: &Config{
+			Routes:   []string{"tapd=http://localhost:8089"},
+			TLSCerts: []string{"tapd=/tmp/tls.cert"},
+		},
+		errStr: "doesn't use https",
+	}}
+	for _, tc := range invalid {
+		require.ErrorContains(t, tc.cfg.Validate(), tc.errStr)
+	}
+}
+
+// TestProxy tests that only authenticated requests are forwarded and that the
+// path prefix and litd's credentials are removed from them.
+func TestProxy(t *testing.T) {
+	t.Parallel()
+
+	var forwarded *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			forwarded = r
+			_, _ = w.Write([]byte("universe"))
+		},
+	))
+	t.Cleanup(backend.Close)
+
+	cfg := &Config{
+		Routes: []string{"tapd=" + backend.URL + "/base"},
+	}
+	routes, err := cfg.ParseRoutes()
+	require.NoError(t, err)
+
+	authenticate := func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Basic secret"
+	}
+	proxy, err := NewProxy(routes, authenticate, true)
+	require.NoError(t, err)
+
+	request := func(path string, auth bool) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth {
+			req.Header.Set("Authorization", "Basic secret")
+		}
+
+		rec := httptest.NewRecorder()
+		proxy.ServeHTTP(rec, req)
+
+		return rec.Result()
+	}
+
+	// Unauthenticated requests get a basic auth challenge.
+	resp := request("/subserver/tapd/v1/universe/roots", false)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("WWW-Authenticate"), "Basic")
+	require.Nil(t, forwarded)
+
+	resp = request("/subserver/tapd/v1/universe/roots?limit=2", true)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "universe", string(body))
+
+	require.NotNil(t, forwarded)
+	require.Equal(t, "/base/v1/universe/roots", forwarded.URL.Path)
+	require.Equal(t, "limit=2", forwarded.URL.RawQuery)
+	require.Empty(t, forwarded.Header.Get("Authorization"))
+	require.Equal(
+		t, "/subserver/tapd",
+		forwarded.Header.Get(HeaderForwardedPrefix),
+	)
+
+	// The root of a route is redirected to its trailing slash form.
+	resp = request("/subserver/tapd", true)
+	require.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	require.Equal(t, "/subserver/tapd/", resp.Header.Get("Location"))
+
+	// Unknown routes and paths that try to leave the target's base path
+	// are rejected.
+	resp = request("/subserver/loop/", true)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = request("/subserver/tapd/../secret", true)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
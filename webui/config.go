@@ -0,0 +1,46 @@
+package webui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lightningnetwork/lnd/lncfg"
+)
+
+// Config holds all config options for serving the web UI.
+type Config struct {
+	AssetDir   string `long:"assetdir" description:"Serve the web UI from the given directory instead of the UI that is embedded in the binary. The directory must contain the index.html of a UI build."`
+	LiveReload bool   `long:"livereload" description:"Reload the web UI in all open browser tabs when a file in ui.assetdir changes. Requires ui.assetdir to be set."`
+}
+
+// DefaultConfig constructs the default web UI Config struct.
+func DefaultConfig() *Config {
+	return &Config{}
+}
+
+// Validate makes sure the asset directory contains a UI build if it is set.
+func (c *Config) Validate() error {
+	if c.AssetDir == "" {
+		if c.LiveReload {
+			return fmt.Errorf("ui.livereload requires ui.assetdir " +
+				"to be set")
+		}
+
+		return nil
+	}
+
+	c.AssetDir = lncfg.CleanAndExpandPath(c.AssetDir)
+
+	info, err := os.Stat(filepath.Join(c.AssetDir, indexFile))
+	if err != nil {
+		return fmt.Errorf("invalid ui.assetdir: %v", err)
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("invalid ui.assetdir: %s is a directory",
+			indexFile)
+	}
+
+	return nil
+}
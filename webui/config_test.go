@@ -0,0 +1,32 @@
+package webui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigValidate tests that the asset directory must contain a UI build
+// and that live reload can only be enabled together with it.
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DefaultConfig().Validate())
+
+	cfg := &Config{LiveReload: true}
+	require.ErrorContains(t, cfg.Validate(), "requires ui.assetdir")
+
+	dir := t.TempDir()
+	cfg = &Config{AssetDir: dir}
+	require.ErrorContains(t, cfg.Validate(), "invalid ui.assetdir")
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, indexFile), 0700))
+	require.ErrorContains(t, cfg.Validate(), "is a directory")
+
+	dir = t.TempDir()
+	writeFile(t, filepath.Join(dir, indexFile), "<html></html>")
+	cfg = &Config{AssetDir: dir, LiveReload: true}
+	require.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,301 @@
+package webui
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// LiveReloadPath is the path of the server-sent events endpoint that
+	// notifies the browser about changed assets.
+	LiveReloadPath = "/ui-livereload"
+
+	// indexFile is the entry point of a UI build.
+	indexFile = "index.html"
+
+	// defaultPollInterval is the interval in which the asset directory is
+	// checked for changes.
+	defaultPollInterval = time.Second
+)
+
+// liveReloadScript is injected into all HTML files if live reload is enabled.
+// It reloads the page once the server reports changed assets.
+var liveReloadScript = []byte(`<script>new EventSource("` + LiveReloadPath +
+	`").addEventListener("reload", function () { location.reload(); });` +
+	`</script>`)
+
+// Reloader watches the asset directory of the UI and tells all connected
+// browsers to reload the page once a file in it changes.
+type Reloader struct {
+	dir          string
+	pollInterval time.Duration
+
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewReloader creates a reloader for the given asset directory.
+func NewReloader(dir string) *Reloader {
+	return &Reloader{
+		dir:          dir,
+		pollInterval: defaultPollInterval,
+		clients:      make(map[chan struct{}]struct{}),
+		quit:         make(chan struct{}),
+	}
+}
+
+// Start starts watching the asset directory.
+func (r *Reloader) Start() error {
+	fingerprint, err := dirFingerprint(r.dir)
+	if err != nil {
+		return fmt.Errorf("error reading asset dir: %v", err)
+	}
+
+	r.wg.Add(1)
+	go r.watch(fingerprint)
+
+	return nil
+}
+
+// Stop stops watching the asset directory and disconnects all browsers.
+func (r *Reloader) Stop() {
+	close(r.quit)
+	r.wg.Wait()
+}
+
+// watch polls the asset directory until the reloader is stopped and notifies
+// the browsers whenever its fingerprint changes.
+func (r *Reloader) watch(fingerprint [32]byte) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			newFingerprint, err := dirFingerprint(r.dir)
+			if err != nil {
+				log.Warnf("Error reading asset dir: %v", err)
+				continue
+			}
+
+			if newFingerprint == fingerprint {
+				continue
+			}
+			fingerprint = newFingerprint
+
+			log.Infof("UI assets changed, reloading browsers")
+			r.notify()
+
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// notify tells all connected browsers to reload.
+func (r *Reloader) notify() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for client := range r.clients {
+		// A browser that hasn't picked up the last notification yet
+		// will reload anyway.
+		select {
+		case client <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ServeHTTP streams a reload event to the browser every time the assets
+// change.
+//
+// NOTE: this is part of the http.Handler interface.
+func (r *Reloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported",
+			http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan struct{}, 1)
+	r.mu.Lock()
+	r.clients[client] = struct{}{}
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.clients, client)
+		r.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-client:
+			_, err := io.WriteString(w, "event: reload\ndata: {}\n\n")
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-req.Context().Done():
+			return
+
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// FileSystem wraps the given file system so that the live reload script is
+// injected into all HTML files.
+func (r *Reloader) FileSystem(assets http.FileSystem) http.FileSystem {
+	return &reloadFS{assets: assets}
+}
+
+// dirFingerprint returns a hash over the names, sizes and modification times
+// of all files in the given directory.
+func dirFingerprint(dir string) ([32]byte, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry,
+		err error) error {
+
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var buf [16]byte
+		binary.BigEndian.PutUint64(buf[:8], uint64(info.Size()))
+		binary.BigEndian.PutUint64(
+			buf[8:], uint64(info.ModTime().UnixNano()),
+		)
+
+		_, _ = hash.Write([]byte(p))
+		_, _ = hash.Write(buf[:])
+
+		return nil
+	})
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	var fingerprint [32]byte
+	copy(fingerprint[:], hash.Sum(nil))
+
+	return fingerprint, nil
+}
+
+// reloadFS is a file system that injects the live reload script into all HTML
+// files of the wrapped file system.
+type reloadFS struct {
+	assets http.FileSystem
+}
+
+// Open opens the named file and injects the live reload script if it is an
+// HTML file.
+//
+// NOTE: this is part of the http.FileSystem interface.
+func (f *reloadFS) Open(name string) (http.File, error) {
+	file, err := f.assets.Open(name)
+	if err != nil || path.Ext(name) != ".html" {
+		return file, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	content = injectScript(content)
+
+	return &memFile{
+		Reader: bytes.NewReader(content),
+		info: &memFileInfo{
+			FileInfo: info,
+			size:     int64(len(content)),
+		},
+	}, nil
+}
+
+// injectScript adds the live reload script to the end of the body of the
+// given HTML document.
+func injectScript(html []byte) []byte {
+	result := make([]byte, 0, len(html)+len(liveReloadScript))
+
+	idx := bytes.LastIndex(html, []byte("</body>"))
+	if idx < 0 {
+		result = append(result, html...)
+		return append(result, liveReloadScript...)
+	}
+
+	result = append(result, html[:idx]...)
+	result = append(result, liveReloadScript...)
+
+	return append(result, html[idx:]...)
+}
+
+// memFile is an http.File that is served from memory.
+type memFile struct {
+	*bytes.Reader
+
+	info os.FileInfo
+}
+
+// Close is a no-op since the file is in memory.
+func (f *memFile) Close() error {
+	return nil
+}
+
+// Readdir returns an error since a memFile is never a directory.
+func (f *memFile) Readdir(int) ([]os.FileInfo, error) {
+	return nil, fmt.Errorf("not a directory")
+}
+
+// Stat returns the file info of the original file with the size of the
+// content in memory.
+func (f *memFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+
+// memFileInfo overrides the size of the original file's info.
+type memFileInfo struct {
+	os.FileInfo
+
+	size int64
+}
+
+// Size returns the size of the content in memory.
+func (i *memFileInfo) Size() int64 {
+	return i.size
+}
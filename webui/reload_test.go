@@ -0,0 +1,105 @@
+package webui
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReloadFS tests that the live reload script is only injected into HTML
+// files.
+func TestReloadFS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	index := "<html><body><div id=\"root\"></div></body></html>"
+	css := "body { color: red; }"
+	writeFile(t, filepath.Join(dir, indexFile), index)
+	writeFile(t, filepath.Join(dir, "main.css"), css)
+
+	assets := NewReloader(dir).FileSystem(http.Dir(dir))
+
+	file, err := assets.Open("/" + indexFile)
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(
+		t, "<html><body><div id=\"root\"></div>"+
+			string(liveReloadScript)+"</body></html>",
+		string(content),
+	)
+
+	info, err := file.Stat()
+	require.NoError(t, err)
+	require.EqualValues(t, len(content), info.Size())
+	require.NoError(t, file.Close())
+
+	file, err = assets.Open("/main.css")
+	require.NoError(t, err)
+	content, err = io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, css, string(content))
+	require.NoError(t, file.Close())
+}
+
+// TestReloader tests that connected browsers are notified when a file in the
+// asset directory changes.
+func TestReloader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, indexFile), "<html></html>")
+
+	reloader := NewReloader(dir)
+	reloader.pollInterval = 10 * time.Millisecond
+	require.NoError(t, reloader.Start())
+
+	server := httptest.NewServer(reloader)
+	t.Cleanup(func() {
+		server.Close()
+		reloader.Stop()
+	})
+
+	resp, err := http.Get(server.URL + LiveReloadPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Wait for the browser to be registered before changing the assets.
+	require.Eventually(t, func() bool {
+		reloader.mu.Lock()
+		defer reloader.mu.Unlock()
+
+		return len(reloader.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	writeFile(t, filepath.Join(dir, "main.js"), "console.log(1);")
+
+	events := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(resp.Body).ReadString('\n')
+		events <- line
+	}()
+
+	select {
+	case line := <-events:
+		require.Equal(t, "event: reload", strings.TrimSpace(line))
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload event received")
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	require.NoError(t, os.WriteFile(name, []byte(content), 0600))
+}